@@ -2,10 +2,20 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/cmd/openshift-install/agent"
+	"github.com/openshift/installer/cmd/openshift-install/command"
+	agentpkg "github.com/openshift/installer/pkg/agent"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
 	"github.com/openshift/installer/pkg/asset/agent/configimage"
@@ -14,6 +24,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/agent/mirror"
 	"github.com/openshift/installer/pkg/asset/kubeconfig"
 	"github.com/openshift/installer/pkg/asset/password"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
 )
 
 func newAgentCmd(ctx context.Context) *cobra.Command {
@@ -129,12 +140,132 @@ func newAgentCreateCmd(ctx context.Context) *cobra.Command {
 	for _, t := range agentTargets {
 		t.command.Args = cobra.ExactArgs(0)
 		t.command.Run = runTargetCmd(ctx, t.assets...)
+		if t.name == agentManifestsTarget.name {
+			t.command.Run = runAgentManifestsCmd(ctx, t.command, t.assets)
+		}
+		if t.name == agentImageTarget.name || t.name == agentPXEFilesTarget.name {
+			t.command.Flags().StringVar(&agentImageCustomization, "customize", "", fmt.Sprintf("Path of a file adding files, systemd units and kernel arguments to the image, in the format of the imageCustomization of the agent-config; read as %s", image.CustomizationFilename))
+		}
 		cmd.AddCommand(t.command)
 	}
+	cmd.AddCommand(newAgentCreateClusterCmd(ctx))
 
 	return cmd
 }
 
+// newAgentCreateClusterCmd generates the agent ISO, boots the hosts of the
+// agent-config from it through the Redfish virtual media of their BMC, and
+// waits for the installation to complete.
+func newAgentCreateClusterCmd(ctx context.Context) *cobra.Command {
+	var imageURL, serveImage string
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Generates the agent ISO, boots the hosts from it and waits for the cluster installation to complete",
+		Args:  cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			runTargetCmd(ctx, agentImageTarget.assets...)(cmd, args)
+
+			cleanup := command.SetupFileHook(command.RootOpts.Dir)
+			defer cleanup()
+
+			if serveImage != "" {
+				url, stop, err := serveAgentImage(command.RootOpts.Dir, serveImage, imageURL)
+				if err != nil {
+					logrus.Fatal(errors.Wrap(err, "failed to serve the agent ISO"))
+				}
+				defer stop()
+				imageURL = url
+			}
+			if imageURL == "" {
+				logrus.Info("No image URL is set, boot the hosts from the agent ISO manually")
+			} else if err := agentpkg.BootHostsFromImage(ctx, command.RootOpts.Dir, imageURL); err != nil {
+				logrus.Fatal(err)
+			}
+
+			agent.WaitForInstallComplete(ctx, command.RootOpts.Dir)
+		},
+	}
+	cmd.Flags().StringVar(&imageURL, "image-url", "", "URL the BMCs of the hosts fetch the agent ISO from, once it is uploaded there")
+	cmd.Flags().StringVar(&serveImage, "serve-image", "", "Address, e.g. 192.168.111.1:8080, to serve the agent ISO on over HTTP until the installation completes")
+	cmd.Flags().StringVar(&agentImageCustomization, "customize", "", fmt.Sprintf("Path of a file adding files, systemd units and kernel arguments to the image, in the format of the imageCustomization of the agent-config; read as %s", image.CustomizationFilename))
+	return cmd
+}
+
+// serveAgentImage serves the agent ISO of the directory over HTTP on address,
+// and returns the URL it is reachable at and a function stopping the server.
+// The URL is derived from address unless imageURL is set, which is required
+// when address does not name the host.
+func serveAgentImage(directory, address, imageURL string) (string, func(), error) {
+	isos, err := filepath.Glob(filepath.Join(directory, "agent.*.iso"))
+	if err != nil {
+		return "", nil, err
+	}
+	if len(isos) != 1 {
+		return "", nil, errors.Errorf("expected one agent ISO in %s, found %d", directory, len(isos))
+	}
+	iso := isos[0]
+
+	if imageURL == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "invalid address %s", address)
+		}
+		if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+			return "", nil, errors.Errorf("--image-url must be set when the image is served on %s", address)
+		}
+		imageURL = fmt.Sprintf("http://%s/%s", address, filepath.Base(iso))
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", nil, err
+	}
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, iso)
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Error(errors.Wrap(err, "failed to serve the agent ISO"))
+		}
+	}()
+	logrus.Infof("Serving the agent ISO at %s", imageURL)
+	return imageURL, func() { server.Close() }, nil
+}
+
+// runAgentManifestsCmd adds the --ztp flag to the cluster-manifests command,
+// which additionally generates the manifests to be applied to a hub cluster
+// running assisted-service.
+func runAgentManifestsCmd(ctx context.Context, command *cobra.Command, assets []asset.WritableAsset) func(cmd *cobra.Command, args []string) {
+	var ztp bool
+	command.Flags().BoolVar(&ztp, "ztp", false, "Also generate the manifests, including BareMetalHosts, for a hub cluster running assisted-service")
+	return func(cmd *cobra.Command, args []string) {
+		if ztp {
+			assets = append(assets, &manifests.ZTPManifests{})
+		}
+		runTargetCmd(ctx, assets...)(cmd, args)
+	}
+}
+
+// agentImageCustomization is the path of the image customization file
+// passed with --customize.
+var agentImageCustomization string
+
+// agentImageCustomizationStoreOptions returns the asset store options
+// providing the image customization file passed with --customize.
+func agentImageCustomizationStoreOptions() ([]assetstore.Option, error) {
+	if agentImageCustomization == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(agentImageCustomization)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the image customization")
+	}
+	return []assetstore.Option{assetstore.WithFiles(&asset.File{Filename: image.CustomizationFilename, Data: data})}, nil
+}
+
 func newAgentGraphCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "graph",
@@ -145,6 +276,6 @@ func newAgentGraphCmd() *cobra.Command {
 			return runGraphCmd(cmd, args, agentTargets)
 		},
 	}
-	cmd.PersistentFlags().StringVar(&graphOpts.outputFile, "output-file", "", "file where the graph is written, if empty prints the graph to Stdout.")
+	addGraphFlags(cmd)
 	return cmd
 }
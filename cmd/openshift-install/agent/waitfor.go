@@ -68,6 +68,10 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 				logrus.Exit(exitCodeBootstrapFailed)
 			}
 
+			for _, err := range cluster.PreflightChecks() {
+				logrus.Warn(err)
+			}
+
 			if err := agentpkg.WaitForBootstrapComplete(cluster); err != nil {
 				handleBootstrapError(cluster, err)
 			}
@@ -85,33 +89,39 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			defer cleanup()
 
 			assetDir := cmd.Flags().Lookup("dir").Value.String()
-			logrus.Debugf("asset directory: %s", assetDir)
-			if len(assetDir) == 0 {
-				logrus.Fatal("No cluster installation directory found")
-			}
+			WaitForInstallComplete(context.Background(), assetDir)
+		},
+	}
+}
 
-			ctx := context.Background()
-			cluster, err := agentpkg.NewCluster(ctx, assetDir)
-			if err != nil {
-				logrus.Exit(exitCodeBootstrapFailed)
-			}
+// WaitForInstallComplete waits until the bootstrap and then the installation
+// of the cluster whose assets are in assetDir are complete, exiting with the
+// matching exit code on failure.
+func WaitForInstallComplete(ctx context.Context, assetDir string) {
+	logrus.Debugf("asset directory: %s", assetDir)
+	if len(assetDir) == 0 {
+		logrus.Fatal("No cluster installation directory found")
+	}
 
-			if err := agentpkg.WaitForBootstrapComplete(cluster); err != nil {
-				handleBootstrapError(cluster, err)
-			}
+	cluster, err := agentpkg.NewCluster(ctx, assetDir)
+	if err != nil {
+		logrus.Exit(exitCodeBootstrapFailed)
+	}
+
+	if err := agentpkg.WaitForBootstrapComplete(cluster); err != nil {
+		handleBootstrapError(cluster, err)
+	}
 
-			if err = agentpkg.WaitForInstallComplete(cluster); err != nil {
-				logrus.Error(err)
-				err2 := cluster.API.OpenShift.LogClusterOperatorConditions()
-				if err2 != nil {
-					logrus.Error("Attempted to gather ClusterOperator status after wait failure: ", err2)
-				}
-				logrus.Error(`Cluster initialization failed because one or more operators are not functioning properly.
+	if err = agentpkg.WaitForInstallComplete(cluster); err != nil {
+		logrus.Error(err)
+		err2 := cluster.API.OpenShift.LogClusterOperatorConditions()
+		if err2 != nil {
+			logrus.Error("Attempted to gather ClusterOperator status after wait failure: ", err2)
+		}
+		logrus.Error(`Cluster initialization failed because one or more operators are not functioning properly.
 				The cluster should be accessible for troubleshooting as detailed in the documentation linked below,
 				https://docs.openshift.com/container-platform/latest/support/troubleshooting/troubleshooting-installations.html`)
-				logrus.Exit(exitCodeInstallFailed)
-			}
-			cluster.PrintInstallationComplete()
-		},
+		logrus.Exit(exitCodeInstallFailed)
 	}
+	cluster.PrintInstallationComplete()
 }
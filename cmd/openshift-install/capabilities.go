@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// addListCapabilitiesFlag adds the --list-capabilities flag to the command,
+// which prints the optional capabilities that can be enabled or disabled in
+// the install-config instead of running the command.
+func addListCapabilitiesFlag(cmd *cobra.Command) {
+	var listCapabilities bool
+	cmd.Flags().BoolVar(&listCapabilities, "list-capabilities", false, "list the optional capabilities that can be enabled or disabled in the install-config, and exit")
+
+	run := cmd.Run
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if listCapabilities {
+			printCapabilities(os.Stdout)
+			return
+		}
+		run(cmd, args)
+	}
+}
+
+// printCapabilities writes a table of the known capabilities, whether they
+// are enabled by default, and the capabilities they require.
+func printCapabilities(w io.Writer) {
+	defaults := sets.New[configv1.ClusterVersionCapability](configv1.ClusterVersionCapabilitySets[configv1.ClusterVersionCapabilitySetCurrent]...)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CAPABILITY\tDEFAULT\tREQUIRES")
+	for _, capability := range sets.List(types.KnownCapabilities()) {
+		required := []string{}
+		for _, r := range sets.List(types.RequiredCapabilities(capability)) {
+			required = append(required, string(r))
+		}
+		fmt.Fprintf(tw, "%s\t%t\t%s\n", capability, defaults.Has(capability), strings.Join(required, ","))
+	}
+	tw.Flush()
+}
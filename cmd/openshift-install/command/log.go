@@ -9,14 +9,19 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/gather/redact"
 	"github.com/openshift/installer/pkg/version"
 )
 
 var (
-	// RootOpts holds the log directory and log level configuration.
+	// RootOpts holds the log directory, log level, remote storage and
+	// metrics configuration.
 	RootOpts struct {
-		Dir      string
-		LogLevel string
+		Dir           string
+		LogLevel      string
+		StorageURL    string
+		MetricsURL    string
+		RedactSecrets bool
 	}
 )
 
@@ -82,6 +87,15 @@ func (h *fileHook) Fire(entry *logrus.Entry) error {
 	return nil
 }
 
+// RedactingFormatter returns the formatter, redacting the secrets of the
+// entries if the output is requested to be redacted.
+func RedactingFormatter(formatter logrus.Formatter) logrus.Formatter {
+	if !RootOpts.RedactSecrets {
+		return formatter
+	}
+	return &redact.Formatter{Formatter: formatter, Redactor: &redact.Redactor{}}
+}
+
 // SetupFileHook creates the base log directory and configures logrus options.
 func SetupFileHook(baseDir string) func() {
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
@@ -97,12 +111,12 @@ func SetupFileHook(baseDir string) func() {
 	for k, v := range logrus.StandardLogger().Hooks {
 		originalHooks[k] = v
 	}
-	logrus.AddHook(newFileHook(logfile, logrus.TraceLevel, &logrus.TextFormatter{
+	logrus.AddHook(newFileHook(logfile, logrus.TraceLevel, RedactingFormatter(&logrus.TextFormatter{
 		DisableColors:          true,
 		DisableTimestamp:       false,
 		FullTimestamp:          true,
 		DisableLevelTruncation: false,
-	}))
+	})))
 
 	versionString, err := version.String()
 	if err != nil {
@@ -211,10 +211,18 @@ func clusterCreatePostRun(ctx context.Context) (int, error) {
 	}
 	timer.StopTimer("Bootstrap Destroy")
 
+	//
+	// Apply the extra manifests.
+	//
+	if err := applyExtraManifestsToCluster(ctx, config); err != nil {
+		logrus.Error(err)
+		return exitCodeInstallFailed, nil
+	}
+
 	//
 	// Wait for the cluster to initialize.
 	//
-	err = waitForInstallComplete(ctx, config, command.RootOpts.Dir)
+	err = waitForInstallComplete(ctx, config, command.RootOpts.Dir, defaultReadinessGates())
 	if err != nil {
 		if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
 			logrus.Error("Attempted to gather ClusterOperator status after installation failure: ", err2)
@@ -291,13 +299,34 @@ func newCreateCmd(ctx context.Context) *cobra.Command {
 		t.command.Run = runTargetCmd(ctx, t.assets...)
 		cmd.AddCommand(t.command)
 	}
+	addListCapabilitiesFlag(installConfigTarget.command)
+	addPlanOnlyFlag(ctx, clusterTarget.command)
+	addApplyExtraManifestsFlag(clusterTarget.command)
+	addHostedControlPlaneFlag(ctx, manifestsTarget.command)
+	addInstallConfigSourceFlag(cmd)
+	addDriftPolicyFlag(cmd)
+	addSigningKeyFlag(cmd)
 
 	return cmd
 }
 
 func runTargetCmd(ctx context.Context, targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
 	runner := func(directory string) error {
-		fetcher := assetstore.NewAssetsFetcher(directory)
+		opts, err := installConfigStoreOptions(ctx)
+		if err != nil {
+			return err
+		}
+		customizationOpts, err := agentImageCustomizationStoreOptions()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, customizationOpts...)
+		driftOpts, err := driftPolicyStoreOptions()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, driftOpts...)
+		fetcher := assetstore.NewAssetsFetcher(directory, opts...)
 		return fetcher.FetchAndPersist(ctx, targets)
 	}
 
@@ -314,6 +343,10 @@ func runTargetCmd(ctx context.Context, targets ...asset.WritableAsset) func(cmd
 
 		err := runner(command.RootOpts.Dir)
 		if err != nil {
+			if ctx.Err() != nil {
+				logrus.Error(err)
+				logrus.Exit(exitCodeInterrupt)
+			}
 			if strings.Contains(err.Error(), asset.InstallConfigError) {
 				logrus.Error(err)
 				logrus.Exit(exitCodeInstallConfigError)
@@ -324,6 +357,9 @@ func runTargetCmd(ctx context.Context, targets ...asset.WritableAsset) func(cmd
 			}
 			logrus.Fatal(err)
 		}
+		if err := signTargets(cmd.Name(), command.RootOpts.Dir, targets); err != nil {
+			logrus.Fatal(errors.Wrap(err, "failed to sign the generated files"))
+		}
 		switch cmd.Name() {
 		case "cluster", "image", "pxe-files":
 		default:
@@ -588,6 +624,7 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 
 		checkIfAgentCommand(assetStore)
 	}
+	timeout = phaseTimeout(ctx, timeout)
 
 	untilTime := time.Now().Add(timeout)
 	timezone, _ := untilTime.Zone()
@@ -651,14 +688,14 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 	return errors.Wrap(err, "failed to initialize the cluster")
 }
 
-// waitForStableOperators ensures that each cluster operator is "stable", i.e. the
-// operator has not been in a progressing state for at least a certain duration,
-// 30 seconds by default. Returns an error if any operator does meet this threshold
-// after a deadline, 30 minutes by default.
-func waitForStableOperators(ctx context.Context, config *rest.Config) error {
+// waitForStableOperators ensures that each cluster operator selected by the gates
+// is "stable", i.e. the operator has not been in a progressing state for at least
+// a certain duration, 30 seconds by default. Returns an error if any operator does
+// meet this threshold after a deadline, 30 minutes by default.
+func waitForStableOperators(ctx context.Context, config *rest.Config, gates *readinessGates) error {
 	timer.StartTimer("Cluster Operators Stable")
 
-	stabilityCheckDuration := 30 * time.Minute
+	stabilityCheckDuration := phaseTimeout(ctx, 30*time.Minute)
 	stabilityContext, cancel := context.WithTimeout(ctx, stabilityCheckDuration)
 	defer cancel()
 
@@ -679,16 +716,22 @@ func waitForStableOperators(ctx context.Context, config *rest.Config) error {
 		return fmt.Errorf("informers never started")
 	}
 
-	waitErr := wait.PollUntilContextCancel(stabilityContext, 1*time.Second, true, waitForAllClusterOperators(clusterOperatorLister))
+	waitErr := wait.PollUntilContextCancel(stabilityContext, 1*time.Second, true, waitForAllClusterOperators(clusterOperatorLister, gates))
 	if waitErr != nil {
 		logrus.Errorf("Error checking cluster operator Progressing status: %q", waitErr)
-		stableOperators, unstableOperators, err := currentOperatorStability(clusterOperatorLister)
+		stableOperators, unstableOperators, err := currentOperatorStability(clusterOperatorLister, gates)
 		if err != nil {
 			logrus.Errorf("Error checking final cluster operator Progressing status: %q", err)
 		}
 		logrus.Debugf("These cluster operators were stable: [%s]", strings.Join(sets.List(stableOperators), ", "))
 		logrus.Errorf("These cluster operators were not stable: [%s]", strings.Join(sets.List(unstableOperators), ", "))
 
+		summaryCtx, summaryCancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer summaryCancel()
+		if err := logReadinessSummary(summaryCtx, config, gates); err != nil {
+			logrus.Error("Attempted to gather ClusterOperator readiness after wait failure: ", err)
+		}
+
 		logrus.Exit(exitCodeOperatorStabilityFailed)
 	}
 
@@ -762,21 +805,42 @@ func logComplete(directory, consoleURL string) error {
 		return err
 	}
 	kubeconfig := filepath.Join(absDir, "auth", "kubeconfig")
+	logrus.Info("Install complete!")
+	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
+	if consoleURL == "" {
+		return nil
+	}
+	logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
+	if kubeadminDisabled(directory) {
+		logrus.Info("Login to the console with one of the identity providers of the install-config")
+		return nil
+	}
 	pwFile := filepath.Join(absDir, "auth", "kubeadmin-password")
 	pw, err := os.ReadFile(pwFile)
 	if err != nil {
 		return err
 	}
-	logrus.Info("Install complete!")
-	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
-	if consoleURL != "" {
-		logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
-		logrus.Infof("Login to the console with user: %q, and password: %q", "kubeadmin", pw)
-	}
+	logrus.Infof("Login to the console with user: %q, and password: %q", "kubeadmin", pw)
 	return nil
 }
 
-func waitForInstallComplete(ctx context.Context, config *rest.Config, directory string) error {
+// kubeadminDisabled returns whether the install-config recorded in the
+// asset directory removed the kubeadmin user.
+func kubeadminDisabled(directory string) bool {
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return false
+	}
+	installConfig, err := assetStore.Load(&installconfig.InstallConfig{})
+	if err != nil || installConfig == nil {
+		return false
+	}
+	return installConfig.(*installconfig.InstallConfig).Config.KubeadminDisabled()
+}
+
+// waitForInstallComplete waits for the cluster to be initialized and for the
+// readiness gates to be met.
+func waitForInstallComplete(ctx context.Context, config *rest.Config, directory string, gates *readinessGates) error {
 	if err := waitForInitializedCluster(ctx, config); err != nil {
 		return err
 	}
@@ -785,15 +849,32 @@ func waitForInstallComplete(ctx context.Context, config *rest.Config, directory
 		return err
 	}
 
-	if err := waitForStableOperators(ctx, config); err != nil {
-		return err
+	if gates.checksOperators() {
+		if err := waitForStableOperators(ctx, config, gates); err != nil {
+			return err
+		}
+	}
+
+	if gates.ingress {
+		if err := waitForIngress(ctx, config); err != nil {
+			return err
+		}
 	}
 
 	consoleURL, err := getConsole(ctx, config)
 	if err != nil {
+		if gates.console {
+			return errors.Wrap(err, "the console is not available")
+		}
 		logrus.Warnf("Cluster does not have a console available: %v", err)
 	}
 
+	if gates.routes {
+		if err := probeIngressRoutes(ctx, config, consoleURL); err != nil {
+			return err
+		}
+	}
+
 	return logComplete(command.RootOpts.Dir, consoleURL)
 }
 
@@ -810,11 +891,11 @@ func checkIfAgentCommand(assetStore asset.Store) {
 	}
 }
 
-func waitForAllClusterOperators(clusterOperatorLister configlisters.ClusterOperatorLister) func(ctx context.Context) (bool, error) {
+func waitForAllClusterOperators(clusterOperatorLister configlisters.ClusterOperatorLister, gates *readinessGates) func(ctx context.Context) (bool, error) {
 	previouslyStableOperators := sets.Set[string]{}
 
 	return func(ctx context.Context) (bool, error) {
-		stableOperators, unstableOperators, err := currentOperatorStability(clusterOperatorLister)
+		stableOperators, unstableOperators, err := currentOperatorStability(clusterOperatorLister, gates)
 		if err != nil {
 			return false, err
 		}
@@ -838,7 +919,7 @@ func waitForAllClusterOperators(clusterOperatorLister configlisters.ClusterOpera
 	}
 }
 
-func currentOperatorStability(clusterOperatorLister configlisters.ClusterOperatorLister) (sets.Set[string], sets.Set[string], error) {
+func currentOperatorStability(clusterOperatorLister configlisters.ClusterOperatorLister, gates *readinessGates) (sets.Set[string], sets.Set[string], error) {
 	clusterOperators, err := clusterOperatorLister.List(labels.Everything())
 	if err != nil {
 		return nil, nil, err // lister should never fail
@@ -848,6 +929,9 @@ func currentOperatorStability(clusterOperatorLister configlisters.ClusterOperato
 	unstableOperators := sets.Set[string]{}
 	for _, clusterOperator := range clusterOperators {
 		name := clusterOperator.Name
+		if !gates.includesOperator(name) {
+			continue
+		}
 		progressing := cov1helpers.FindStatusCondition(clusterOperator.Status.Conditions, configv1.OperatorProgressing)
 		if progressing == nil {
 			logrus.Debugf("Cluster Operator %s progressing == nil", name)
@@ -862,6 +946,11 @@ func currentOperatorStability(clusterOperatorLister configlisters.ClusterOperato
 		}
 	}
 
+	// Requested operators that do not exist yet cannot be stable.
+	if !gates.allOperators {
+		unstableOperators = unstableOperators.Union(gates.operators.Difference(stableOperators))
+	}
+
 	return stableOperators, unstableOperators, nil
 }
 
@@ -882,7 +971,7 @@ func handleUnreachableAPIServer(ctx context.Context, config *rest.Config) error
 	}
 	switch installConfig.Config.Platform.Name() { //nolint:gocritic
 	case gcp.Name:
-		if installConfig.Config.GCP.UserProvisionedDNS != gcp.UserProvisionedDNSEnabled {
+		if !installConfig.Config.ClusterHostedDNS() {
 			return nil
 		}
 	default:
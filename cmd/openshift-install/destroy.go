@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/installer/cmd/openshift-install/command"
+	agentpkg "github.com/openshift/installer/pkg/agent"
+	"github.com/openshift/installer/pkg/asset/cluster/metadata"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/destroy"
 	"github.com/openshift/installer/pkg/destroy/bootstrap"
+	"github.com/openshift/installer/pkg/destroy/providers"
 	quotaasset "github.com/openshift/installer/pkg/destroy/quota"
+	infra "github.com/openshift/installer/pkg/infrastructure/platform"
 	"github.com/openshift/installer/pkg/metrics/timer"
 
 	_ "github.com/openshift/installer/pkg/destroy/aws"
@@ -40,11 +44,18 @@ func newDestroyCmd() *cobra.Command {
 	}
 	cmd.AddCommand(newDestroyBootstrapCmd())
 	cmd.AddCommand(newDestroyClusterCmd())
+	cmd.AddCommand(newDestroyInfraEnvCmd())
 	return cmd
 }
 
+// destroyConfirmEnvVar confirms the destruction of a cluster tagged as
+// production, like --confirm, when set to the name of the cluster.
+const destroyConfirmEnvVar = "OPENSHIFT_INSTALL_DESTROY_CONFIRM"
+
 func newDestroyClusterCmd() *cobra.Command {
-	return &cobra.Command{
+	var confirm string
+	var dryRun bool
+	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "Destroy an OpenShift cluster",
 		Args:  cobra.ExactArgs(0),
@@ -52,6 +63,15 @@ func newDestroyClusterCmd() *cobra.Command {
 			cleanup := command.SetupFileHook(command.RootOpts.Dir)
 			defer cleanup()
 
+			if dryRun {
+				if err := runDestroyDryRun(command.RootOpts.Dir); err != nil {
+					logrus.Fatal(err)
+				}
+				return
+			}
+			if err := checkDestroyConfirmation(command.RootOpts.Dir, confirm); err != nil {
+				logrus.Fatal(err)
+			}
 			err := runDestroyCmd(command.RootOpts.Dir, os.Getenv("OPENSHIFT_INSTALL_REPORT_QUOTA_FOOTPRINT") == "true")
 			if err != nil {
 				logrus.Fatal(err)
@@ -59,6 +79,41 @@ func newDestroyClusterCmd() *cobra.Command {
 			logrus.Infof("Uninstallation complete!")
 		},
 	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the resources of the cluster that would be destroyed, without destroying them")
+	cmd.Flags().StringVar(&confirm, "confirm", os.Getenv(destroyConfirmEnvVar), fmt.Sprintf("Name of the cluster, required to destroy a cluster tagged as production; defaults to $%s", destroyConfirmEnvVar))
+	return cmd
+}
+
+// checkDestroyConfirmation returns an error if the cluster of the directory
+// is tagged as production and confirm is not its name, so that a cluster is
+// not destroyed by mistake from a shared working directory.
+func checkDestroyConfirmation(directory, confirm string) error {
+	clusterMetadata, err := metadata.Load(directory)
+	if err != nil {
+		// The destroyer reports the missing or invalid metadata.
+		return nil
+	}
+	if !metadata.IsProduction(clusterMetadata) || confirm == clusterMetadata.ClusterName {
+		return nil
+	}
+	if confirm != "" {
+		return errors.Errorf("the confirmation %q does not match the name of the production cluster %s", confirm, clusterMetadata.ClusterName)
+	}
+	return errors.Errorf("cluster %s is tagged as production, confirm its destruction with --confirm %s", clusterMetadata.ClusterName, clusterMetadata.ClusterName)
+}
+
+// runDestroyDryRun lists the resources the destroyer of the cluster would
+// destroy, if it supports it.
+func runDestroyDryRun(directory string) error {
+	destroyer, err := destroy.New(logrus.StandardLogger(), directory)
+	if err != nil {
+		return errors.Wrap(err, "Failed while preparing to destroy cluster")
+	}
+	dryRunner, ok := destroyer.(providers.DryRunner)
+	if !ok {
+		return errors.New("the destroyer of the cluster platform does not support --dry-run")
+	}
+	return dryRunner.DryRun()
 }
 
 func runDestroyCmd(directory string, reportQuota bool) error {
@@ -78,6 +133,20 @@ func runDestroyCmd(directory string, reportQuota bool) error {
 		}
 	}
 
+	// Let the infrastructure provider clean up the files it keeps in the
+	// install dir, while the metadata is still around to look it up.
+	clusterMetadata, err := metadata.Load(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to load cluster metadata")
+	}
+	provider, err := infra.ProviderForMetadata(clusterMetadata)
+	if err != nil {
+		return errors.Wrap(err, "failed to get infrastructure provider")
+	}
+	if err := provider.Destroy(directory); err != nil {
+		return errors.Wrap(err, "failed to clean up infrastructure files")
+	}
+
 	store, err := assetstore.NewStore(directory)
 	if err != nil {
 		return errors.Wrap(err, "failed to create asset store")
@@ -94,21 +163,6 @@ func runDestroyCmd(directory string, reportQuota bool) error {
 		return errors.Wrap(err, "failed to remove state file")
 	}
 
-	// delete terraform files
-	tfstateFiles, err := filepath.Glob(filepath.Join(directory, "*.tfstate"))
-	if err != nil {
-		return errors.Wrap(err, "failed to glob for tfstate files")
-	}
-	tfvarsFiles, err := filepath.Glob(filepath.Join(directory, "*.tfvars.json"))
-	if err != nil {
-		return errors.Wrap(err, "failed to glob for tfvars files")
-	}
-	for _, f := range append(tfstateFiles, tfvarsFiles...) {
-		if err := os.Remove(f); err != nil {
-			return errors.Wrapf(err, "failed to remove terraform file %q", f)
-		}
-	}
-
 	timer.StopTimer(timer.TotalTimeElapsed)
 	timer.LogSummary()
 
@@ -134,3 +188,27 @@ func newDestroyBootstrapCmd() *cobra.Command {
 		},
 	}
 }
+
+func newDestroyInfraEnvCmd() *cobra.Command {
+	var hubKubeconfig string
+	cmd := &cobra.Command{
+		Use:   "infraenv",
+		Short: "Deregister the cluster and infraenv of an agent-based install and remove the generated ISOs",
+		Long: `Deregister the cluster and infraenv of an agent-based install from the
+assisted-service running on the rendezvous host, and remove the ISOs generated
+in the asset directory. With --hub-kubeconfig, the InfraEnv and cluster
+resources are deleted from the hub cluster instead (ZTP).`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := command.SetupFileHook(command.RootOpts.Dir)
+			defer cleanup()
+
+			if err := agentpkg.DestroyInfraEnv(context.TODO(), command.RootOpts.Dir, hubKubeconfig); err != nil {
+				logrus.Fatal(err)
+			}
+			logrus.Info("InfraEnv destroyed")
+		},
+	}
+	cmd.Flags().StringVar(&hubKubeconfig, "hub-kubeconfig", "", "kubeconfig of the hub cluster the InfraEnv and cluster resources were applied to")
+	return cmd
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDestroyConfirmation(t *testing.T) {
+	cases := []struct {
+		name          string
+		metadata      string
+		confirm       string
+		expectedError string
+	}{
+		{
+			name:     "untagged",
+			metadata: `{"clusterName":"test-cluster","infraID":"test-cluster-abcde"}`,
+		},
+		{
+			name:     "staging",
+			metadata: `{"clusterName":"test-cluster","infraID":"test-cluster-abcde","tags":{"environment":"staging"}}`,
+		},
+		{
+			name:          "production unconfirmed",
+			metadata:      `{"clusterName":"test-cluster","infraID":"test-cluster-abcde","tags":{"environment":"production"}}`,
+			expectedError: "cluster test-cluster is tagged as production, confirm its destruction with --confirm test-cluster",
+		},
+		{
+			name:          "production wrongly confirmed",
+			metadata:      `{"clusterName":"test-cluster","infraID":"test-cluster-abcde","tags":{"environment":"production"}}`,
+			confirm:       "other-cluster",
+			expectedError: `the confirmation "other-cluster" does not match the name of the production cluster test-cluster`,
+		},
+		{
+			name:     "production confirmed",
+			metadata: `{"clusterName":"test-cluster","infraID":"test-cluster-abcde","tags":{"environment":"production"}}`,
+			confirm:  "test-cluster",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(tc.metadata), 0o600))
+
+			err := checkDestroyConfirmation(dir, tc.confirm)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
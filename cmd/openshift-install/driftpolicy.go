@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+var driftPolicy = string(assetstore.DriftPolicyWarn)
+
+func addDriftPolicyFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&driftPolicy, "on-edited-assets", driftPolicy, `what to do with the assets edited in the assets directory that must be regenerated because their dependencies changed: "warn" regenerates them with a warning, "diff" also logs the discarded edits, and "preserve" keeps the edited assets`)
+}
+
+// driftPolicyStoreOptions returns the asset store options applying the
+// requested policy to the edited assets.
+func driftPolicyStoreOptions() ([]assetstore.Option, error) {
+	policy, err := assetstore.ParseDriftPolicy(driftPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return []assetstore.Option{assetstore.WithDriftPolicy(policy)}, nil
+}
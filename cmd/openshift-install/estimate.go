@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/cmd/openshift-install/command"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/machines"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/cost"
+)
+
+func newEstimateCmd(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate properties of the cluster without creating any resources",
+		Long:  "",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newEstimateCostCmd(ctx))
+	return cmd
+}
+
+func newEstimateCostCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cost",
+		Short: "Estimate the monthly cost of the cluster",
+		Long: `Maps the instance types, disks and load balancers of the install-config to
+the price sheets bundled with the installer for AWS, Azure and GCP, and prints
+the approximate monthly cost of the cluster.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := command.SetupFileHook(command.RootOpts.Dir)
+			defer cleanup()
+
+			store, err := assetstore.NewStore(command.RootOpts.Dir)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to create asset store"))
+			}
+			ic := &installconfig.InstallConfig{}
+			mastersAsset := &machines.Master{}
+			workersAsset := &machines.Worker{}
+			if err := store.Fetch(ctx, ic); err != nil {
+				logrus.Fatal(errors.Wrapf(err, "failed to fetch %s", ic.Name()))
+			}
+			if err := store.Fetch(ctx, mastersAsset); err != nil {
+				logrus.Fatal(errors.Wrapf(err, "failed to fetch %s", mastersAsset.Name()))
+			}
+			if err := store.Fetch(ctx, workersAsset); err != nil {
+				logrus.Fatal(errors.Wrapf(err, "failed to fetch %s", workersAsset.Name()))
+			}
+
+			masters, err := mastersAsset.Machines()
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			workers, err := workersAsset.MachineSets()
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			estimate, err := cost.EstimateCost(ic.Config, masters, workers)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to estimate the cost of the cluster"))
+			}
+			for _, resource := range estimate.Unknown {
+				logrus.Warnf("The price of %s is unknown, it is not included in the estimate", resource)
+			}
+			if err := printEstimate(os.Stdout, estimate); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+}
+
+func printEstimate(w io.Writer, estimate *cost.Estimate) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tQUANTITY\tMONTHLY (USD)\t")
+	for _, item := range estimate.Items {
+		fmt.Fprintf(tw, "%s\t%d\t%.2f\t\n", item.Resource, item.Quantity, item.MonthlyCost)
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%.2f\t\n", estimate.Total())
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\nPrices are approximate on-demand list prices in %s and do not include data transfer, support or discounts.\n", estimate.Region)
+	return err
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/installer/cmd/openshift-install/command"
+	"github.com/openshift/installer/pkg/extramanifests"
+)
+
+// extraManifestsTimeout is how long the extra manifests are retried, while
+// the operators defining their kinds and creating their namespaces roll out.
+const extraManifestsTimeout = 30 * time.Minute
+
+var applyExtraManifests bool
+
+// addApplyExtraManifestsFlag adds the --apply-extra-manifests flag to the
+// create cluster command, which applies the manifests of the extra-manifests
+// directory to the cluster once its control plane is up.
+func addApplyExtraManifestsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&applyExtraManifests, "apply-extra-manifests", false, "apply the manifests of the "+extramanifests.DirName+" directory to the cluster with server-side apply once the control plane is up, e.g. the custom resources of the operators installed with the cluster")
+
+	run := cmd.Run
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		// The manifests are read before the infrastructure is created, so
+		// that a malformed one does not fail the installation halfway.
+		if applyExtraManifests {
+			manifests, err := extramanifests.Load(command.RootOpts.Dir)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to load the extra manifests"))
+			}
+			if len(manifests) == 0 {
+				logrus.Warnf("--apply-extra-manifests is set, but there is no manifest in %s", extramanifests.DirName)
+			}
+		}
+		run(cmd, args)
+	}
+}
+
+// applyExtraManifestsToCluster applies the extra manifests, if requested.
+func applyExtraManifestsToCluster(ctx context.Context, config *rest.Config) error {
+	if !applyExtraManifests {
+		return nil
+	}
+	manifests, err := extramanifests.Load(command.RootOpts.Dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the extra manifests")
+	}
+	if len(manifests) == 0 {
+		return nil
+	}
+	logrus.Infof("Applying %d extra manifests...", len(manifests))
+	return extramanifests.Apply(ctx, config, manifests, extraManifestsTimeout)
+}
@@ -14,16 +14,19 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	gossh "golang.org/x/crypto/ssh"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
 	configv1 "github.com/openshift/api/config/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/openshift/installer/cmd/openshift-install/command"
+	clustermetadata "github.com/openshift/installer/pkg/asset/cluster/metadata"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/asset/tls"
 	serialgather "github.com/openshift/installer/pkg/gather"
+	"github.com/openshift/installer/pkg/gather/redact"
 	"github.com/openshift/installer/pkg/gather/service"
 	"github.com/openshift/installer/pkg/gather/ssh"
 	"github.com/openshift/installer/pkg/infrastructure"
@@ -48,11 +51,13 @@ to debug the installation failures`,
 		},
 	}
 	cmd.AddCommand(newGatherBootstrapCmd(ctx))
+	cmd.AddCommand(newGatherRedactCmd())
 	return cmd
 }
 
 var gatherBootstrapOpts struct {
 	bootstrap    string
+	bastion      string
 	masters      []string
 	sshKeys      []string
 	skipAnalysis bool
@@ -71,6 +76,16 @@ func newGatherBootstrapCmd(ctx context.Context) *cobra.Command {
 				logrus.Fatal(err)
 			}
 
+			if command.RootOpts.RedactSecrets {
+				redacted, err := redactFile(&redact.Redactor{}, bundlePath)
+				if err != nil {
+					logrus.Fatal(err)
+				}
+				if err := os.Rename(redacted, bundlePath); err != nil {
+					logrus.Fatal(err)
+				}
+			}
+
 			if !gatherBootstrapOpts.skipAnalysis {
 				if err := service.AnalyzeGatherBundle(bundlePath); err != nil {
 					logrus.Fatal(err)
@@ -81,12 +96,64 @@ func newGatherBootstrapCmd(ctx context.Context) *cobra.Command {
 		},
 	}
 	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bootstrap, "bootstrap", "", "Hostname or IP of the bootstrap host")
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bastion, "bastion", "", "Hostname or IP of the SSH bastion host to reach the bootstrap host through. Defaults to the bastion host provisioned for a private cluster, if any")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.masters, "master", []string{}, "Hostnames or IPs of all control plane hosts")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.sshKeys, "key", []string{}, "Path to SSH private keys that should be used for authentication. If no key was provided, SSH private keys from user's environment will be used")
 	cmd.PersistentFlags().BoolVar(&gatherBootstrapOpts.skipAnalysis, "skipAnalysis", false, "Skip analysis of the gathered data")
 	return cmd
 }
 
+var gatherRedactOpts struct {
+	ips bool
+}
+
+func newGatherRedactCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redact [FILE]...",
+		Short: "Redact the secrets from the install log and the gathered log bundles",
+		Long: `Redact the secrets from the install log and the gathered log bundles.
+
+The pull secrets, passwords, tokens and private keys, and optionally the IP
+addresses, are stripped from the given files, or from the install log of the
+assets directory if none is given, before they are attached to support cases.
+Each file is redacted into a copy next to it, with .redacted before its extension.`,
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) == 0 {
+				args = []string{filepath.Join(command.RootOpts.Dir, ".openshift_install.log")}
+			}
+			r := &redact.Redactor{IPs: gatherRedactOpts.ips}
+			for _, path := range args {
+				redacted, err := redactFile(r, path)
+				if err != nil {
+					logrus.Fatal(err)
+				}
+				logrus.Infof("Redacted %s into %s", path, redacted)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&gatherRedactOpts.ips, "redact-ips", false, "Redact the IP addresses too")
+	return cmd
+}
+
+// redactFile redacts the file into a copy next to it, and returns the path
+// of the copy.
+func redactFile(r *redact.Redactor, path string) (string, error) {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	if strings.HasSuffix(name, ".tar.gz") {
+		ext = ".tar.gz"
+	}
+	if ext == name {
+		// Hidden files like .bashrc have no extension.
+		ext = ""
+	}
+	redacted := strings.TrimSuffix(path, ext) + ".redacted" + ext
+	if err := r.File(path, redacted); err != nil {
+		return "", err
+	}
+	return redacted, nil
+}
+
 func runGatherBootstrapCmd(ctx context.Context, directory string) (string, error) {
 	assetStore, err := assetstore.NewStore(directory)
 	if err != nil {
@@ -110,6 +177,18 @@ func runGatherBootstrapCmd(ctx context.Context, directory string) (string, error
 	}
 	gatherBootstrapOpts.sshKeys = append(gatherBootstrapOpts.sshKeys, tmpfile.Name())
 
+	// add the core key pair generated by the installer, if any, and use the
+	// bastion host provisioned for a private cluster, if any
+	if metadata, err := clustermetadata.Load(directory); err == nil {
+		if metadata.SSHPrivateKeyPath != "" {
+			gatherBootstrapOpts.sshKeys = append(gatherBootstrapOpts.sshKeys, filepath.Join(directory, metadata.SSHPrivateKeyPath))
+		}
+		if gatherBootstrapOpts.bastion == "" && metadata.Resources != nil && metadata.Resources.BastionAddress != "" {
+			logrus.Infof("Reaching the bootstrap host through the bastion host %s", metadata.Resources.BastionAddress)
+			gatherBootstrapOpts.bastion = metadata.Resources.BastionAddress
+		}
+	}
+
 	ha := &infrastructure.HostAddresses{
 		Bootstrap: gatherBootstrapOpts.bootstrap,
 		Port:      22,
@@ -132,7 +211,9 @@ func runGatherBootstrapCmd(ctx context.Context, directory string) (string, error
 	}
 
 	if ha.Bootstrap == "" {
-		return "", errors.New("must provide bootstrap host address")
+		// The console logs are read from the platform API, and are most
+		// useful precisely when the bootstrap host cannot be reached.
+		logrus.Warn("The bootstrap host address is unknown, only the VM console logs will be gathered")
 	}
 
 	return gatherBootstrap(ha.Bootstrap, ha.Port, ha.Masters, directory)
@@ -160,11 +241,13 @@ func gatherBootstrap(bootstrap string, port int, masters []string, directory str
 		}
 	}
 
-	clusterLogBundlePath, err := pullLogsFromBootstrap(gatherID, bootstrap, port, masters, directory)
-	if err != nil {
-		logrus.Infof("Failed to gather bootstrap logs: %s", err.Error())
-	} else {
-		archives[clusterLogBundlePath] = ""
+	if bootstrap != "" {
+		clusterLogBundlePath, err := pullLogsFromBootstrap(gatherID, bootstrap, port, masters, directory)
+		if err != nil {
+			logrus.Infof("Failed to gather bootstrap logs: %s", err.Error())
+		} else {
+			archives[clusterLogBundlePath] = ""
+		}
 	}
 
 	if len(archives) == 0 {
@@ -182,7 +265,13 @@ func gatherBootstrap(bootstrap string, port int, masters []string, directory str
 
 func pullLogsFromBootstrap(gatherID string, bootstrap string, port int, masters []string, directory string) (string, error) {
 	logrus.Info("Pulling debug logs from the bootstrap machine")
-	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	var client *gossh.Client
+	var err error
+	if bastion := gatherBootstrapOpts.bastion; bastion != "" {
+		client, err = ssh.NewClientThroughJumpHost("core", net.JoinHostPort(bastion, "22"), net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	} else {
+		client, err = ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	}
 	if err != nil {
 		if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ETIMEDOUT) {
 			return "", fmt.Errorf("failed to connect to the bootstrap machine: %w", err)
@@ -1,25 +1,57 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 
 	"github.com/awalterschulze/gographviz"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/installer/cmd/openshift-install/command"
 	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+const (
+	graphFormatDot  = "dot"
+	graphFormatJSON = "json"
 )
 
 var (
 	graphOpts struct {
 		outputFile string
+		format     string
 	}
 )
 
+// graphTarget is a target of the create command in the JSON graph.
+type graphTarget struct {
+	Name   string   `json:"name"`
+	Assets []string `json:"assets"`
+}
+
+// graphAsset is an asset in the JSON graph. Dependencies are referenced by
+// their type.
+type graphAsset struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Dependencies []string `json:"dependencies"`
+	assetstore.AssetStatus
+}
+
+// graph is the JSON representation of the asset dependency graph.
+type graph struct {
+	Targets []graphTarget `json:"targets"`
+	Assets  []graphAsset  `json:"assets"`
+}
+
 func newGraphCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "graph",
@@ -30,11 +62,57 @@ func newGraphCmd() *cobra.Command {
 			return runGraphCmd(cmd, args, targets)
 		},
 	}
-	cmd.PersistentFlags().StringVar(&graphOpts.outputFile, "output-file", "", "file where the graph is written, if empty prints the graph to Stdout.")
+	addGraphFlags(cmd)
 	return cmd
 }
 
+func addGraphFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&graphOpts.outputFile, "output-file", "", "file where the graph is written, if empty prints the graph to Stdout.")
+	cmd.PersistentFlags().StringVar(&graphOpts.format, "format", graphFormatDot, fmt.Sprintf("format of the graph, one of %q or %q. Both formats include whether the assets in the asset directory are dirty, on disk or generated.", graphFormatDot, graphFormatJSON))
+}
+
 func runGraphCmd(cmd *cobra.Command, args []string, cmdTargets []target) error {
+	var targetAssets []asset.Asset
+	for _, t := range cmdTargets {
+		for _, a := range t.assets {
+			targetAssets = append(targetAssets, a)
+		}
+	}
+	status, err := assetstore.LoadStatus(command.RootOpts.Dir, targetAssets...)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the status of the assets")
+	}
+
+	var data string
+	switch graphOpts.format {
+	case graphFormatDot:
+		data = dotGraph(cmdTargets, status)
+	case graphFormatJSON:
+		data, err = jsonGraph(cmdTargets, status)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("unsupported graph format %q, must be one of %q or %q", graphOpts.format, graphFormatDot, graphFormatJSON)
+	}
+
+	out := os.Stdout
+	if graphOpts.outputFile != "" {
+		f, err := os.Create(graphOpts.outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if _, err := io.WriteString(out, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+func dotGraph(cmdTargets []target, status map[reflect.Type]assetstore.AssetStatus) string {
 	g := gographviz.NewGraph()
 	g.SetName("G")
 	g.SetDir(true)
@@ -48,7 +126,7 @@ func runGraphCmd(cmd *cobra.Command, args []string, cmdTargets []target) error {
 		name := fmt.Sprintf("%q", fmt.Sprintf("Target %s", t.name))
 		g.AddNode("G", name, tNodeAttr)
 		for _, dep := range t.assets {
-			addEdge(g, name, dep)
+			addEdge(g, name, dep, status)
 		}
 	}
 
@@ -63,29 +141,35 @@ func runGraphCmd(cmd *cobra.Command, args []string, cmdTargets []target) error {
 		}
 		g.AddNode(subgraphName, node.Name, nil)
 	}
+	return g.String()
+}
 
-	out := os.Stdout
-	if graphOpts.outputFile != "" {
-		f, err := os.Create(graphOpts.outputFile)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		out = f
+// statusNodeAttrs returns the attributes highlighting the status of an asset
+// in the dot graph.
+func statusNodeAttrs(status assetstore.AssetStatus) map[string]string {
+	var color string
+	switch {
+	case status.Dirty:
+		color = "orange"
+	case status.OnDisk:
+		color = "lightblue"
+	case status.Generated:
+		color = "palegreen"
+	default:
+		return nil
 	}
-
-	if _, err := io.WriteString(out, g.String()); err != nil {
-		return err
+	return map[string]string{
+		string(gographviz.Style):     "filled",
+		string(gographviz.FillColor): color,
 	}
-	return nil
 }
 
-func addEdge(g *gographviz.Graph, parent string, asset asset.Asset) {
+func addEdge(g *gographviz.Graph, parent string, asset asset.Asset, status map[reflect.Type]assetstore.AssetStatus) {
 	name := fmt.Sprintf("%q", reflect.TypeOf(asset).Elem())
 
 	if !g.IsNode(name) {
 		logrus.Debugf("adding node %s", name)
-		g.AddNode("G", name, nil)
+		g.AddNode("G", name, statusNodeAttrs(status[reflect.TypeOf(asset)]))
 	}
 	if !isEdge(g, name, parent) {
 		logrus.Debugf("adding edge %s -> %s", name, parent)
@@ -94,7 +178,7 @@ func addEdge(g *gographviz.Graph, parent string, asset asset.Asset) {
 
 	deps := asset.Dependencies()
 	for _, dep := range deps {
-		addEdge(g, name, dep)
+		addEdge(g, name, dep, status)
 	}
 }
 
@@ -106,3 +190,48 @@ func isEdge(g *gographviz.Graph, src, dst string) bool {
 	}
 	return false
 }
+
+func jsonGraph(cmdTargets []target, status map[reflect.Type]assetstore.AssetStatus) (string, error) {
+	g := graph{
+		Targets: make([]graphTarget, 0, len(cmdTargets)),
+		Assets:  []graphAsset{},
+	}
+	assets := map[string]graphAsset{}
+	var addAsset func(a asset.Asset) string
+	addAsset = func(a asset.Asset) string {
+		assetType := reflect.TypeOf(a).Elem().String()
+		if _, ok := assets[assetType]; ok {
+			return assetType
+		}
+		ga := graphAsset{
+			Name:         a.Name(),
+			Type:         assetType,
+			Dependencies: []string{},
+			AssetStatus:  status[reflect.TypeOf(a)],
+		}
+		assets[assetType] = ga
+		for _, dep := range a.Dependencies() {
+			ga.Dependencies = append(ga.Dependencies, addAsset(dep))
+		}
+		assets[assetType] = ga
+		return assetType
+	}
+
+	for _, t := range cmdTargets {
+		gt := graphTarget{Name: t.name, Assets: []string{}}
+		for _, a := range t.assets {
+			gt.Assets = append(gt.Assets, addAsset(a))
+		}
+		g.Targets = append(g.Targets, gt)
+	}
+	for _, a := range assets {
+		g.Assets = append(g.Assets, a)
+	}
+	sort.Slice(g.Assets, func(i, j int) bool { return g.Assets[i].Type < g.Assets[j].Type })
+
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the graph")
+	}
+	return string(data) + "\n", nil
+}
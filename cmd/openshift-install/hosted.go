@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
+)
+
+// addHostedControlPlaneFlag adds the --hosted-control-plane flag to the
+// create manifests command, which writes the HostedCluster and NodePool
+// manifests of the cluster instead of the standalone cluster manifests.
+func addHostedControlPlaneFlag(ctx context.Context, cmd *cobra.Command) {
+	var hosted bool
+	cmd.Flags().BoolVar(&hosted, "hosted-control-plane", false, "write the HostedCluster and NodePool manifests of a cluster with a control plane hosted by HyperShift, instead of the standalone cluster manifests (AWS and None platforms)")
+
+	run := cmd.Run
+	hostedRun := runTargetCmd(ctx, targetassets.HostedManifests...)
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if hosted {
+			hostedRun(cmd, args)
+			logrus.Info("Apply the hosted cluster manifests to the management cluster to create the cluster")
+			return
+		}
+		run(cmd, args)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+// installConfigURLEnvVar is the environment variable providing the URL of
+// the install-config when the --install-config flag is not set.
+const installConfigURLEnvVar = "OPENSHIFT_INSTALL_CONFIG_URL"
+
+var installConfigSource string
+
+func addInstallConfigSourceFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&installConfigSource, "install-config", "", fmt.Sprintf(`read the install-config from stdin ("-") or from an http(s) URL instead of the assets directory, without writing it to the directory; defaults to $%s. The pull secret is still recorded in plaintext in the state file and the ignition configs of the directory`, installConfigURLEnvVar))
+}
+
+// installConfigStoreOptions returns the asset store options providing the
+// install-config from stdin or from a URL, when requested.
+func installConfigStoreOptions(ctx context.Context) ([]assetstore.Option, error) {
+	source := installConfigSource
+	if source == "" {
+		source = os.Getenv(installConfigURLEnvVar)
+	}
+	if source == "" {
+		return nil, nil
+	}
+
+	data, err := readInstallConfigSource(ctx, source)
+	if err != nil {
+		return nil, errors.Wrap(errors.Wrap(err, "failed to read the install-config"), asset.InstallConfigError)
+	}
+	return []assetstore.Option{assetstore.WithFiles(&asset.File{Filename: "install-config.yaml", Data: data})}, nil
+}
+
+func readInstallConfigSource(ctx context.Context, source string) ([]byte, error) {
+	if source == "-" {
+		logrus.Debug("Reading the install-config from stdin")
+		return io.ReadAll(os.Stdin)
+	}
+
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("unsupported install-config source %q, it must be - or an http(s) URL", source)
+	}
+	logrus.Debugf("Reading the install-config from %s", u.Redacted())
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s from %s", resp.Status, u.Redacted())
+	}
+	return io.ReadAll(resp.Body)
+}
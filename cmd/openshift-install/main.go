@@ -6,6 +6,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -16,7 +20,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	"github.com/openshift/installer/cmd/openshift-install/command"
+	"github.com/openshift/installer/pkg/asset/cluster/metadata"
+	"github.com/openshift/installer/pkg/asset/store/remote"
 	"github.com/openshift/installer/pkg/clusterapi"
+	"github.com/openshift/installer/pkg/metrics/report"
+	"github.com/openshift/installer/pkg/metrics/timer"
+	"github.com/openshift/installer/pkg/version"
 )
 
 func main() {
@@ -54,7 +63,11 @@ func installerMain() {
 		newCoreOSCmd(),
 		newCompletionCmd(),
 		newExplainCmd(),
+		newValidateCmd(ctx),
+		newEstimateCmd(ctx),
 		newAgentCmd(ctx),
+		newRegenerateCmd(ctx),
+		newMirrorCmd(ctx),
 	} {
 		rootCmd.AddCommand(subCmd)
 	}
@@ -66,15 +79,22 @@ func installerMain() {
 
 func newRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:              filepath.Base(os.Args[0]),
-		Short:            "Creates OpenShift clusters",
-		Long:             "",
-		PersistentPreRun: runRootCmd,
-		SilenceErrors:    true,
-		SilenceUsage:     true,
+		Use:               filepath.Base(os.Args[0]),
+		Short:             "Creates OpenShift clusters",
+		Long:              "",
+		PersistentPreRun:  runRootCmd,
+		PersistentPostRun: runRootPostCmd,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
 	}
 	cmd.PersistentFlags().StringVar(&command.RootOpts.Dir, "dir", ".", "assets directory")
 	cmd.PersistentFlags().StringVar(&command.RootOpts.LogLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().StringVar(&command.RootOpts.StorageURL, "storage-url", os.Getenv("OPENSHIFT_INSTALL_STORAGE_URL"),
+		"remote storage (s3://bucket/prefix, gs://bucket/prefix or http(s)://url) the assets directory is synchronized with before and after the command")
+	cmd.PersistentFlags().StringVar(&command.RootOpts.MetricsURL, "metrics-url", os.Getenv("OPENSHIFT_INSTALL_METRICS_URL"),
+		"opt-in metrics (file:///path for local JSON lines or http(s)://collector for OpenTelemetry) the stage durations and failure classification of the command are reported to")
+	cmd.PersistentFlags().BoolVar(&command.RootOpts.RedactSecrets, "redact-secrets", false,
+		"redact the pull secrets, passwords and tokens from the output and the log file, and from the gathered log bundles")
 	return cmd
 }
 
@@ -87,7 +107,7 @@ func runRootCmd(cmd *cobra.Command, args []string) {
 		level = logrus.InfoLevel
 	}
 
-	logrus.AddHook(command.NewFileHookWithNewlineTruncate(os.Stderr, level, &logrus.TextFormatter{
+	logrus.AddHook(command.NewFileHookWithNewlineTruncate(os.Stderr, level, command.RedactingFormatter(&logrus.TextFormatter{
 		// Setting ForceColors is necessary because logrus.TextFormatter determines
 		// whether or not to enable colors by looking at the output of the logger.
 		// In this case, the output is io.Discard, which is not a terminal.
@@ -97,27 +117,127 @@ func runRootCmd(cmd *cobra.Command, args []string) {
 		DisableTimestamp:       true,
 		DisableLevelTruncation: true,
 		DisableQuote:           true,
-	}))
+	})))
 
 	if err != nil {
 		logrus.Fatal(errors.Wrap(err, "invalid log-level"))
 	}
+
+	if command.RootOpts.StorageURL != "" {
+		pullStorage(cmd.Context())
+	}
+
+	if command.RootOpts.MetricsURL != "" {
+		setupMetrics(cmd)
+	}
+}
+
+var (
+	metricsEmitter report.Emitter
+	metricsCommand string
+	emitOnce       sync.Once
+)
+
+// setupMetrics prepares the report of the command to the metrics URL, and
+// makes sure it is emitted when the command exits, as a failure unless the
+// command completed.
+func setupMetrics(cmd *cobra.Command) {
+	emitter, err := report.NewEmitter(command.RootOpts.MetricsURL)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	metricsEmitter = emitter
+	metricsCommand = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	logrus.RegisterExitHandler(func() {
+		if interrupted.Load() {
+			emitMetrics(report.ResultInterrupted)
+		} else {
+			emitMetrics(report.ResultFailure)
+		}
+	})
 }
 
-// handleInterrupt executes a graceful shutdown then exits in
-// the case of a user interrupt. It returns a new context that
-// will be cancelled upon interrupt.
+// emitMetrics reports the stages of the command and its result to the
+// metrics URL, if any. Failing to report is not fatal to the command.
+func emitMetrics(result string) {
+	if metricsEmitter == nil {
+		return
+	}
+	emitOnce.Do(func() {
+		installerVersion, _ := version.Version()
+		r := report.New(metricsCommand, installerVersion, result, timer.Stages())
+		if m, err := metadata.Load(command.RootOpts.Dir); err == nil {
+			r.Platform, r.InfraID = m.Platform(), m.InfraID
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		logrus.Debugf("Reporting the metrics of the command to %s", command.RootOpts.MetricsURL)
+		if err := metricsEmitter.Emit(ctx, r); err != nil {
+			logrus.Warn(errors.Wrapf(err, "failed to report the metrics to %s", command.RootOpts.MetricsURL))
+		}
+	})
+}
+
+// runRootPostCmd runs once the command completed.
+func runRootPostCmd(cmd *cobra.Command, args []string) {
+	emitMetrics(report.ResultSuccess)
+	pushStorage()
+}
+
+var (
+	storageBackend remote.Backend
+	pushOnce       sync.Once
+)
+
+// pullStorage populates the assets directory from the remote storage, and
+// makes sure the directory is pushed back when the command exits, even on
+// failure, so that the cluster can still be destroyed.
+func pullStorage(ctx context.Context) {
+	backend, err := remote.New(ctx, command.RootOpts.StorageURL)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	if err := os.MkdirAll(command.RootOpts.Dir, 0750); err != nil {
+		logrus.Fatal(errors.Wrap(err, "failed to create assets directory"))
+	}
+	logrus.Debugf("Pulling the assets directory from %s", command.RootOpts.StorageURL)
+	if err := remote.Pull(ctx, backend, command.RootOpts.Dir); err != nil {
+		logrus.Fatal(errors.Wrapf(err, "failed to pull the assets directory from %s", command.RootOpts.StorageURL))
+	}
+	storageBackend = backend
+	logrus.RegisterExitHandler(pushStorage)
+}
+
+// pushStorage uploads the assets directory to the remote storage, if any.
+func pushStorage() {
+	if storageBackend == nil {
+		return
+	}
+	pushOnce.Do(func() {
+		logrus.Debugf("Pushing the assets directory to %s", command.RootOpts.StorageURL)
+		if err := remote.Push(context.Background(), storageBackend, command.RootOpts.Dir); err != nil {
+			logrus.Error(errors.Wrapf(err, "failed to push the assets directory to %s", command.RootOpts.StorageURL))
+		}
+	})
+}
+
+// interrupted is set once a user interrupt has been received.
+var interrupted atomic.Bool
+
+// handleInterrupt returns a new context that will be cancelled upon user
+// interrupt, so that the running command stops its calls and keeps its
+// state before exiting through the shutdown handler. A second interrupt
+// exits immediately.
 func handleInterrupt(signalCtx context.Context) context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// If the context from the signal handler is done,
-	// an interrupt has been received, so shutdown & exit.
+	// an interrupt has been received, so stop the command.
 	go func() {
 		<-signalCtx.Done()
-		logrus.Warn("Received interrupt signal")
-		shutdown()
+		logrus.Warn("Received interrupt signal, interrupt again to exit immediately")
+		interrupted.Store(true)
 		cancel()
-		logrus.Exit(exitCodeInterrupt)
 	}()
 
 	return ctx
@@ -0,0 +1,70 @@
+//go:build !altinfra
+// +build !altinfra
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/installer/pkg/infrastructure/platform"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+var (
+	mirrorTerraformOpts struct {
+		platforms []string
+	}
+)
+
+func newMirrorTerraformCmd(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "terraform DIR",
+		Short: "Export terraform, its providers and its modules into a filesystem mirror",
+		Long: fmt.Sprintf(`Export terraform, its providers and its modules into a filesystem mirror.
+
+The terraform binary, the providers and the modules of the platforms
+provisioned with terraform are exported into DIR, and the modules the
+installer gets from the terraform registry are downloaded into it. Copy DIR to
+a host without access to the terraform registry, and point %s
+to it to create clusters from it.`, terraform.MirrorEnvVar),
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runMirrorTerraformCmd(ctx, args[0])
+		},
+	}
+	cmd.Flags().StringSliceVar(&mirrorTerraformOpts.platforms, "platform", nil, "Platforms to export the modules of; defaults to all the platforms provisioned with terraform")
+	return cmd
+}
+
+func runMirrorTerraformCmd(ctx context.Context, dir string) error {
+	stagesByPlatform := platform.TerraformStages()
+	platforms := sets.List(sets.KeySet(stagesByPlatform))
+	if len(mirrorTerraformOpts.platforms) > 0 {
+		platforms = mirrorTerraformOpts.platforms
+	}
+
+	var stages []terraform.Stage
+	for _, p := range platforms {
+		s, ok := stagesByPlatform[p]
+		if !ok {
+			return errors.Errorf("platform %q is not provisioned with terraform, supported platforms are %v", p, sets.List(sets.KeySet(stagesByPlatform)))
+		}
+		stages = append(stages, s...)
+	}
+
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	if err := terraform.ExportMirror(ctx, dir, stages); err != nil {
+		return errors.Wrap(err, "failed to export the terraform mirror")
+	}
+	logrus.Infof("Exported the terraform mirror to %s, use it by setting %s to its path", dir, terraform.MirrorEnvVar)
+	return nil
+}
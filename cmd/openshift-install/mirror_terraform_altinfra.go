@@ -0,0 +1,22 @@
+//go:build altinfra
+// +build altinfra
+
+package main
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newMirrorTerraformCmd(_ context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "terraform DIR",
+		Short: "Export terraform, its providers and its modules into a filesystem mirror",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return errors.New("terraform is not included in the altinfra Installer build")
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/cmd/openshift-install/command"
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
+)
+
+// addPlanOnlyFlag adds the --plan-only flag to the create cluster command,
+// which writes the Terraform plans of the infrastructure instead of creating
+// the cluster.
+func addPlanOnlyFlag(ctx context.Context, cmd *cobra.Command) {
+	var planOnly bool
+	cmd.Flags().BoolVar(&planOnly, "plan-only", false, "write the Terraform plans of the infrastructure, terraform.<stage>.plan.txt and terraform.<stage>.plan.json, without creating any resource, and exit")
+
+	run := cmd.Run
+	plan := runTargetCmd(ctx, targetassets.ClusterPlan...)
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if planOnly {
+			plan(cmd, args)
+			logrus.Infof("The infrastructure plans have been written to %s", command.RootOpts.Dir)
+			return
+		}
+		run(cmd, args)
+	}
+
+	postRun := cmd.PostRun
+	cmd.PostRun = func(cmd *cobra.Command, args []string) {
+		if planOnly {
+			return
+		}
+		postRun(cmd, args)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	routeclient "github.com/openshift/client-go/route/clientset/versioned"
+	timer "github.com/openshift/installer/pkg/metrics/timer"
+	"github.com/openshift/library-go/pkg/route/routeapihelpers"
+)
+
+const (
+	canaryNamespace = "openshift-ingress-canary"
+	canaryRouteName = "canary"
+
+	probeRequestTimeout = 10 * time.Second
+)
+
+// probeFailure is the part of the path to a route a failed probe points at.
+type probeFailure string
+
+const (
+	// networkPathFailure is a route that cannot be reached: its name does
+	// not resolve, or the ingress load balancer does not accept or answer
+	// the connection.
+	networkPathFailure probeFailure = "network path"
+	// certificateFailure is a route whose certificate chain the ingress CA
+	// does not validate.
+	certificateFailure probeFailure = "certificate"
+	// backendFailure is a route the router answers for without reaching a
+	// healthy backend.
+	backendFailure probeFailure = "backend"
+)
+
+// probeError is the failure of the probe of a route.
+type probeError struct {
+	route   string
+	url     string
+	failure probeFailure
+	err     error
+}
+
+func (e *probeError) Error() string {
+	return fmt.Sprintf("%s route (%s): %s problem: %v", e.route, e.url, e.failure, e.err)
+}
+
+func (e *probeError) Unwrap() error {
+	return e.err
+}
+
+// hint returns what to look at to fix the failure.
+func (e *probeError) hint() string {
+	switch e.failure {
+	case networkPathFailure:
+		return "check the DNS records of the ingress, and the security groups or firewall rules and the targets of the ingress load balancer"
+	case certificateFailure:
+		return "check the default certificate of the ingress controller and the CA bundle of the default-ingress-cert config map in openshift-config-managed"
+	default:
+		return "check the ingress and console cluster operators and the pods behind the route"
+	}
+}
+
+// probeIngressRoutes waits for the console and canary routes to answer
+// through the ingress load balancer, and reports the failures of the last
+// probes, telling network path problems apart from certificate and backend
+// problems.
+func probeIngressRoutes(ctx context.Context, config *rest.Config, consoleURL string) error {
+	timer.StartTimer("Ingress Routes")
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a Kubernetes client")
+	}
+	caConfigMap, err := client.CoreV1().ConfigMaps("openshift-config-managed").Get(ctx, "default-ingress-cert", metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "fetching default-ingress-cert configmap from openshift-config-managed namespace")
+	}
+	httpClient, err := newProbeClient([]byte(caConfigMap.Data["ca-bundle.crt"]))
+	if err != nil {
+		return err
+	}
+
+	rc, err := routeclient.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a route client")
+	}
+	canary, err := rc.RouteV1().Routes(canaryNamespace).Get(ctx, canaryRouteName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "fetching the route %s/%s", canaryNamespace, canaryRouteName)
+	}
+	canaryURL, _, err := routeapihelpers.IngressURI(canary, "")
+	if err != nil {
+		return errors.Wrapf(err, "the route %s/%s is not admitted", canaryNamespace, canaryRouteName)
+	}
+
+	routes := map[string]string{"canary": canaryURL.String()}
+	if consoleURL != "" {
+		routes["console"] = consoleURL
+	}
+
+	timeout := phaseTimeout(ctx, 5*time.Minute)
+	untilTime := time.Now().Add(timeout)
+	timezone, _ := untilTime.Zone()
+	logrus.Infof("Waiting up to %v (until %v %s) for the ingress routes to answer...",
+		timeout, untilTime.Format(time.Kitchen), timezone)
+
+	var failures []*probeError
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		failures = nil
+		for name, url := range routes {
+			if err := probeRoute(ctx, httpClient, name, url); err != nil {
+				logrus.Debugf("Still waiting for the ingress routes: %v", err)
+				failures = append(failures, err)
+			}
+		}
+		return len(failures) == 0, nil
+	})
+	if err != nil {
+		for _, failure := range failures {
+			logrus.Errorf("The %s; %s", failure, failure.hint())
+		}
+		return errors.Wrap(err, "waiting for the ingress routes to answer")
+	}
+
+	timer.StopTimer("Ingress Routes")
+	logrus.Info("The ingress routes answer")
+	return nil
+}
+
+// newProbeClient returns an HTTP client validating the certificates of the
+// routes against the system roots and the ingress CA bundle.
+func newProbeClient(ingressCABundle []byte) (*http.Client, error) {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(ingressCABundle) {
+		return nil, errors.New("ca-bundle.crt from default-ingress-cert configmap not valid PEM format")
+	}
+	return &http.Client{
+		Timeout: probeRequestTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+		},
+	}, nil
+}
+
+// probeRoute requests the URL of a route and classifies its failure.
+func probeRoute(ctx context.Context, client *http.Client, name, url string) *probeError {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return &probeError{route: name, url: url, failure: networkPathFailure, err: err}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return &probeError{route: name, url: url, failure: classifyProbeError(err), err: err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return &probeError{route: name, url: url, failure: backendFailure, err: errors.Errorf("the router answered %s", resp.Status)}
+	}
+	return nil
+}
+
+// classifyProbeError returns the part of the path to a route a failed
+// request points at. A request which fails without an answer of the router
+// is a network path problem, unless the certificate chain did not validate.
+func classifyProbeError(err error) probeFailure {
+	var (
+		verificationErr *tls.CertificateVerificationError
+		unknownAuthErr  x509.UnknownAuthorityError
+		hostnameErr     x509.HostnameError
+		invalidErr      x509.CertificateInvalidError
+	)
+	if errors.As(err, &verificationErr) || errors.As(err, &unknownAuthErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return certificateFailure
+	}
+	return networkPathFailure
+}
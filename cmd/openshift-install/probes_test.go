@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeRoute(t *testing.T) {
+	healthy := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Healthcheck requested"))
+	}))
+	defer healthy.Close()
+	unavailable := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	stopped := httptest.NewTLSServer(http.NotFoundHandler())
+	stopped.Close()
+
+	// The test servers share their certificate, so its PEM encoding stands
+	// for the ingress CA bundle.
+	ingressCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: healthy.Certificate().Raw})
+	client, err := newProbeClient(ingressCA)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// A client which only trusts the system roots does not validate the
+	// certificate of the test servers.
+	untrusted := &http.Client{Transport: &http.Transport{}}
+
+	cases := []struct {
+		name            string
+		client          *http.Client
+		url             string
+		expectedFailure probeFailure
+	}{
+		{
+			name:   "healthy",
+			client: client,
+			url:    healthy.URL,
+		},
+		{
+			name:            "no healthy backend",
+			client:          client,
+			url:             unavailable.URL,
+			expectedFailure: backendFailure,
+		},
+		{
+			name:            "load balancer unreachable",
+			client:          client,
+			url:             stopped.URL,
+			expectedFailure: networkPathFailure,
+		},
+		{
+			name:            "untrusted certificate",
+			client:          untrusted,
+			url:             healthy.URL,
+			expectedFailure: certificateFailure,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := probeRoute(context.Background(), tc.client, "canary", tc.url)
+			if tc.expectedFailure == "" {
+				assert.Nil(t, err)
+				return
+			}
+			if assert.NotNil(t, err) {
+				assert.Equal(t, tc.expectedFailure, err.failure)
+			}
+		})
+	}
+}
+
+func TestNewProbeClientInvalidCABundle(t *testing.T) {
+	_, err := newProbeClient([]byte("not a certificate"))
+	assert.EqualError(t, err, "ca-bundle.crt from default-ingress-cert configmap not valid PEM format")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/provenance"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// signingKeyEnvVar is the environment variable providing the signing key
+// when the --sign-key flag is not set.
+const signingKeyEnvVar = "OPENSHIFT_INSTALL_SIGNING_KEY"
+
+var signingKey string
+
+func addSigningKeyFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&signingKey, "sign-key", "", fmt.Sprintf(`sign the generated files and write a signed in-toto provenance attestation, %s, with the PEM-encoded private key at this path or with the GPG key "gpg:<key id>"; defaults to $%s`, provenance.StatementFilename, signingKeyEnvVar))
+}
+
+// signTargets signs the files of the targets in the directory and writes
+// their provenance attestation, when a signing key is configured.
+func signTargets(target string, directory string, targets []asset.WritableAsset) error {
+	spec := signingKey
+	if spec == "" {
+		spec = os.Getenv(signingKeyEnvVar)
+	}
+	if spec == "" {
+		return nil
+	}
+
+	signer, err := provenance.NewSigner(spec)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, a := range targets {
+		for _, f := range a.Files() {
+			// Some files are consumed by the later assets of the target.
+			if _, err := os.Stat(filepath.Join(directory, f.Filename)); err == nil {
+				files = append(files, f.Filename)
+			}
+		}
+	}
+
+	inputs, err := provenanceInputs(target, directory)
+	if err != nil {
+		return err
+	}
+	if err := provenance.SignAndAttest(directory, files, inputs, signer); err != nil {
+		return err
+	}
+	logrus.Infof("Signed %d files and wrote the provenance attestation to %s", len(files), filepath.Join(directory, provenance.StatementFilename))
+	return nil
+}
+
+// provenanceInputs returns the installer version and the inputs recorded in
+// the state of the assets directory.
+func provenanceInputs(target string, directory string) (provenance.Inputs, error) {
+	inputs := provenance.Inputs{
+		Target:          target,
+		InstallerCommit: version.Commit,
+	}
+	var err error
+	if inputs.InstallerVersion, err = version.Version(); err != nil {
+		return inputs, err
+	}
+
+	store, err := assetstore.NewStore(directory)
+	if err != nil {
+		return inputs, errors.Wrap(err, "failed to create asset store")
+	}
+	if a, err := store.Load(&installconfig.InstallConfig{}); err != nil {
+		return inputs, errors.Wrap(err, "failed to load the install-config")
+	} else if a != nil {
+		if files := a.(*installconfig.InstallConfig).Files(); len(files) > 0 {
+			inputs.InstallConfig = files[0].Data
+		}
+	}
+	if a, err := store.Load(&releaseimage.Image{}); err != nil {
+		return inputs, errors.Wrap(err, "failed to load the release image")
+	} else if a != nil {
+		inputs.ReleaseImage = a.(*releaseimage.Image).PullSpec
+	}
+	return inputs, nil
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	timer "github.com/openshift/installer/pkg/metrics/timer"
+	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+)
+
+const (
+	operatorsGate = "operators"
+	ingressGate   = "ingress"
+	consoleGate   = "console"
+	routesGate    = "routes"
+
+	allOperators = "all"
+
+	ingressOperatorName = "ingress"
+)
+
+// readinessGates are the conditions that must be met, once the cluster
+// version has been initialized, for the installation to be complete.
+type readinessGates struct {
+	// allOperators requires every cluster operator to be stable.
+	allOperators bool
+	// operators are the names of the cluster operators that must be stable
+	// when allOperators is not set.
+	operators sets.Set[string]
+	// ingress requires the ingress cluster operator to be available.
+	ingress bool
+	// console requires the console route to be admitted. Without it, a
+	// missing console only produces a warning.
+	console bool
+	// routes requires the console and canary routes to answer through the
+	// ingress load balancer with a certificate chain the ingress CA
+	// validates.
+	routes bool
+}
+
+// defaultReadinessGates returns the gates used when none are requested:
+// every cluster operator must be stable.
+func defaultReadinessGates() *readinessGates {
+	return &readinessGates{allOperators: true, operators: sets.New[string]()}
+}
+
+// parseReadinessGates parses gates of the form "operators=all",
+// "operators=<name>", "ingress", "console" and "routes". The operators gate
+// may be repeated to select several cluster operators.
+func parseReadinessGates(values []string) (*readinessGates, error) {
+	if len(values) == 0 {
+		return defaultReadinessGates(), nil
+	}
+
+	gates := &readinessGates{operators: sets.New[string]()}
+	for _, value := range values {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(value), "=")
+		switch name {
+		case operatorsGate:
+			if !hasArg || arg == allOperators {
+				gates.allOperators = true
+				continue
+			}
+			if arg == "" {
+				return nil, errors.Errorf("readiness gate %q requires an operator name or %q", value, allOperators)
+			}
+			gates.operators.Insert(arg)
+		case ingressGate, consoleGate, routesGate:
+			if hasArg {
+				return nil, errors.Errorf("readiness gate %q does not take a value", name)
+			}
+			switch name {
+			case ingressGate:
+				gates.ingress = true
+			case consoleGate:
+				gates.console = true
+			default:
+				gates.routes = true
+			}
+		default:
+			return nil, errors.Errorf("unknown readiness gate %q, supported gates are %s=<name>|%s, %s, %s and %s",
+				value, operatorsGate, allOperators, ingressGate, consoleGate, routesGate)
+		}
+	}
+	return gates, nil
+}
+
+// checksOperators returns true if the stability of any cluster operator is
+// required.
+func (g *readinessGates) checksOperators() bool {
+	return g.allOperators || g.operators.Len() > 0
+}
+
+// includesOperator returns true if the stability of the named cluster
+// operator is required.
+func (g *readinessGates) includesOperator(name string) bool {
+	return g.allOperators || g.operators.Has(name)
+}
+
+// phaseTimeout returns how long a phase of the installation may be waited
+// on. A deadline set on the context, for instance by the --timeout flag,
+// takes precedence over the default of the phase.
+func phaseTimeout(ctx context.Context, defaultTimeout time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return defaultTimeout
+}
+
+// waitForIngress waits for the ingress cluster operator to be available
+// and not degraded.
+func waitForIngress(ctx context.Context, config *rest.Config) error {
+	timer.StartTimer("Ingress Available")
+
+	timeout := phaseTimeout(ctx, 10*time.Minute)
+	untilTime := time.Now().Add(timeout)
+	timezone, _ := untilTime.Zone()
+	logrus.Infof("Waiting up to %v (until %v %s) for the ingress to be available...",
+		timeout, untilTime.Format(time.Kitchen), timezone)
+
+	cc, err := configclient.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create a config client")
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		co, err := cc.ConfigV1().ClusterOperators().Get(ctx, ingressOperatorName, metav1.GetOptions{})
+		if err != nil {
+			logrus.Debugf("Still waiting for the ingress: %v", err)
+			return false, nil
+		}
+		if cov1helpers.IsStatusConditionTrue(co.Status.Conditions, configv1.OperatorAvailable) &&
+			cov1helpers.IsStatusConditionFalse(co.Status.Conditions, configv1.OperatorDegraded) {
+			return true, nil
+		}
+		logrus.Debug("Still waiting for the ingress to be available...")
+		return false, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "waiting for the ingress to be available")
+	}
+
+	timer.StopTimer("Ingress Available")
+	logrus.Info("The ingress is available")
+	return nil
+}
+
+// operatorReadiness is the state of a cluster operator that is not ready.
+type operatorReadiness struct {
+	name        string
+	available   configv1.ConditionStatus
+	progressing configv1.ConditionStatus
+	degraded    configv1.ConditionStatus
+	reason      string
+	message     string
+}
+
+// notReadyOperators returns the cluster operators selected by the gates that
+// are not available, are progressing or are degraded, sorted by name.
+func notReadyOperators(operators []configv1.ClusterOperator, gates *readinessGates) []operatorReadiness {
+	var notReady []operatorReadiness
+	for _, co := range operators {
+		if !gates.includesOperator(co.Name) && !(gates.ingress && co.Name == ingressOperatorName) {
+			continue
+		}
+		state := operatorReadiness{
+			name:        co.Name,
+			available:   conditionStatus(co.Status.Conditions, configv1.OperatorAvailable),
+			progressing: conditionStatus(co.Status.Conditions, configv1.OperatorProgressing),
+			degraded:    conditionStatus(co.Status.Conditions, configv1.OperatorDegraded),
+		}
+		if state.available == configv1.ConditionTrue && state.progressing == configv1.ConditionFalse && state.degraded == configv1.ConditionFalse {
+			continue
+		}
+
+		// Report the condition that best explains why the operator is not ready.
+		for _, condType := range []configv1.ClusterStatusConditionType{configv1.OperatorDegraded, configv1.OperatorAvailable, configv1.OperatorProgressing} {
+			cond := cov1helpers.FindStatusCondition(co.Status.Conditions, condType)
+			if cond == nil {
+				continue
+			}
+			// Available is the only condition that must be true to be ready.
+			unhealthy := cond.Status == configv1.ConditionTrue
+			if condType == configv1.OperatorAvailable {
+				unhealthy = !unhealthy
+			}
+			if unhealthy {
+				state.reason, state.message = cond.Reason, cond.Message
+				break
+			}
+		}
+		notReady = append(notReady, state)
+	}
+	sort.Slice(notReady, func(i, j int) bool { return notReady[i].name < notReady[j].name })
+	return notReady
+}
+
+func conditionStatus(conditions []configv1.ClusterOperatorStatusCondition, condType configv1.ClusterStatusConditionType) configv1.ConditionStatus {
+	if cond := cov1helpers.FindStatusCondition(conditions, condType); cond != nil {
+		return cond.Status
+	}
+	return configv1.ConditionUnknown
+}
+
+// logReadinessSummary logs which of the cluster operators selected by the
+// gates are not ready.
+func logReadinessSummary(ctx context.Context, config *rest.Config, gates *readinessGates) error {
+	cc, err := configclient.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("creating a config client: %w", err)
+	}
+
+	operators, err := cc.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing ClusterOperator objects: %w", err)
+	}
+
+	notReady := notReadyOperators(operators.Items, gates)
+	if len(notReady) == 0 {
+		logrus.Info("All the required cluster operators are ready")
+		return nil
+	}
+
+	logrus.Errorf("%d cluster operator(s) are not ready:", len(notReady))
+	for _, co := range notReady {
+		logrus.Errorf("  %s: Available=%s Progressing=%s Degraded=%s %s: %s",
+			co.name, co.available, co.progressing, co.degraded, co.reason, co.message)
+	}
+	return nil
+}
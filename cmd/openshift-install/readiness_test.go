@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func TestParseReadinessGates(t *testing.T) {
+	cases := []struct {
+		name          string
+		values        []string
+		expected      *readinessGates
+		expectedError string
+	}{
+		{
+			name:     "default",
+			expected: &readinessGates{allOperators: true, operators: sets.New[string]()},
+		},
+		{
+			name:     "all gates",
+			values:   []string{"operators=all", "ingress", "console"},
+			expected: &readinessGates{allOperators: true, operators: sets.New[string](), ingress: true, console: true},
+		},
+		{
+			name:     "named operators",
+			values:   []string{"operators=etcd", "operators=kube-apiserver"},
+			expected: &readinessGates{operators: sets.New("etcd", "kube-apiserver")},
+		},
+		{
+			name:     "routes",
+			values:   []string{"ingress", "routes"},
+			expected: &readinessGates{operators: sets.New[string](), ingress: true, routes: true},
+		},
+		{
+			name:     "console only",
+			values:   []string{"console"},
+			expected: &readinessGates{operators: sets.New[string](), console: true},
+		},
+		{
+			name:          "empty operator name",
+			values:        []string{"operators="},
+			expectedError: `readiness gate "operators=" requires an operator name or "all"`,
+		},
+		{
+			name:          "unexpected value",
+			values:        []string{"ingress=default"},
+			expectedError: `readiness gate "ingress" does not take a value`,
+		},
+		{
+			name:          "unknown gate",
+			values:        []string{"nodes"},
+			expectedError: `unknown readiness gate "nodes", supported gates are operators=<name>|all, ingress, console and routes`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gates, err := parseReadinessGates(tc.values)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, gates)
+		})
+	}
+}
+
+func TestNotReadyOperators(t *testing.T) {
+	operator := func(name string, available, progressing, degraded configv1.ConditionStatus, reason string) configv1.ClusterOperator {
+		co := configv1.ClusterOperator{}
+		co.Name = name
+		co.Status.Conditions = []configv1.ClusterOperatorStatusCondition{
+			{Type: configv1.OperatorAvailable, Status: available, Reason: reason},
+			{Type: configv1.OperatorProgressing, Status: progressing, Reason: reason},
+			{Type: configv1.OperatorDegraded, Status: degraded, Reason: reason},
+		}
+		return co
+	}
+	operators := []configv1.ClusterOperator{
+		operator("monitoring", configv1.ConditionFalse, configv1.ConditionTrue, configv1.ConditionFalse, "Rollout"),
+		operator("etcd", configv1.ConditionTrue, configv1.ConditionFalse, configv1.ConditionFalse, "AsExpected"),
+		operator("ingress", configv1.ConditionTrue, configv1.ConditionFalse, configv1.ConditionTrue, "IngressDegraded"),
+	}
+
+	notReady := notReadyOperators(operators, defaultReadinessGates())
+	assert.Equal(t, []operatorReadiness{
+		{name: "ingress", available: configv1.ConditionTrue, progressing: configv1.ConditionFalse, degraded: configv1.ConditionTrue, reason: "IngressDegraded"},
+		{name: "monitoring", available: configv1.ConditionFalse, progressing: configv1.ConditionTrue, degraded: configv1.ConditionFalse, reason: "Rollout"},
+	}, notReady)
+
+	gates := &readinessGates{operators: sets.New("etcd"), ingress: true}
+	notReady = notReadyOperators(operators, gates)
+	assert.Len(t, notReady, 1)
+	assert.Equal(t, "ingress", notReady[0].name)
+}
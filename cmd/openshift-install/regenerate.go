@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/installer/cmd/openshift-install/command"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/kubeconfig"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+var (
+	regenerateKubeconfigOpts struct {
+		validity    time.Duration
+		fromCluster bool
+		kubeconfig  string
+	}
+)
+
+func newRegenerateCmd(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "regenerate",
+		Short: "Regenerate the credentials of an installed cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newRegenerateKubeconfigCmd(ctx))
+	return cmd
+}
+
+func newRegenerateKubeconfigCmd(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kubeconfig",
+		Short: "Regenerate the admin kubeconfig with a new client certificate",
+		Long: `Regenerate the admin kubeconfig with a new client certificate.
+
+By default the client certificate is signed with the admin kubeconfig signer
+recorded in the state of the installation directory, which the cluster trusts
+unless it was removed from the admin-kubeconfig-client-ca config map in
+openshift-config. With --from-cluster, the client certificate is requested
+from the cluster instead, through a certificate signing request created and
+approved with the still valid credentials of --kubeconfig; the cluster may
+issue it for less than --validity.
+
+The previous admin kubeconfig is kept next to the regenerated one.`,
+		Example: `  # Regenerate the admin kubeconfig after its client certificate expired
+  openshift-install regenerate kubeconfig --dir ./mycluster
+
+  # Regenerate it without the state of the installation, with the
+  # credentials of another cluster administrator
+  openshift-install regenerate kubeconfig --dir ./mycluster --from-cluster --kubeconfig ~/.kube/config`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := command.SetupFileHook(command.RootOpts.Dir)
+			defer cleanup()
+
+			if err := regenerateKubeconfig(ctx, command.RootOpts.Dir); err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().DurationVar(&regenerateKubeconfigOpts.validity, "validity", tls.ValidityOneYear, "Validity of the new client certificate")
+	cmd.Flags().BoolVar(&regenerateKubeconfigOpts.fromCluster, "from-cluster", false, "Request the new client certificate from the cluster rather than signing it with the state of the installation directory")
+	cmd.Flags().StringVar(&regenerateKubeconfigOpts.kubeconfig, "kubeconfig", "", "Kubeconfig with valid cluster administrator credentials, used with --from-cluster")
+	return cmd
+}
+
+// regenerateKubeconfig replaces the client certificate of the admin
+// kubeconfig of the installation directory and writes it, after backing up
+// the previous one.
+func regenerateKubeconfig(ctx context.Context, directory string) error {
+	opts := regenerateKubeconfigOpts
+	if opts.validity <= 0 {
+		return errors.Errorf("invalid --validity %s: must be positive", opts.validity)
+	}
+
+	store, err := assetstore.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	a, err := store.Load(&kubeconfig.AdminClient{})
+	if err != nil {
+		return errors.Wrap(err, "failed to load the admin kubeconfig")
+	}
+	if a == nil {
+		return errors.Errorf("no admin kubeconfig in %s or in the state of the installation", directory)
+	}
+	adminClient := a.(*kubeconfig.AdminClient)
+	logAdminClientCertExpiry(adminClient)
+
+	var clientCertKey tls.CertKeyInterface
+	if opts.fromCluster {
+		clientCertKey, err = requestAdminClientCertKey(ctx, opts.kubeconfig, opts.validity)
+	} else {
+		clientCertKey, err = signAdminClientCertKey(store, opts.validity)
+	}
+	if err != nil {
+		return err
+	}
+	if err := adminClient.ReplaceClientCertKey(clientCertKey); err != nil {
+		return errors.Wrap(err, "failed to update the admin kubeconfig")
+	}
+
+	path := filepath.Join(directory, adminClient.Files()[0].Filename)
+	if _, err := os.Stat(path); err == nil {
+		backup := path + "." + time.Now().UTC().Format("20060102T150405Z") + ".bak"
+		if err := os.Rename(path, backup); err != nil {
+			return errors.Wrap(err, "failed to back up the admin kubeconfig")
+		}
+		logrus.Infof("Moved the previous admin kubeconfig to %s", backup)
+	}
+	if err := asset.PersistToFile(adminClient, directory); err != nil {
+		return errors.Wrap(err, "failed to write the admin kubeconfig")
+	}
+
+	cert, err := tls.PemToCertificate(clientCertKey.Cert())
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the new client certificate")
+	}
+	logrus.Infof("Wrote the regenerated admin kubeconfig to %s; its client certificate expires on %s", path, cert.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// logAdminClientCertExpiry logs when the client certificate of the admin
+// kubeconfig being replaced expired or expires.
+func logAdminClientCertExpiry(adminClient *kubeconfig.AdminClient) {
+	for _, authInfo := range adminClient.Config.AuthInfos {
+		if authInfo.Name != "admin" || len(authInfo.AuthInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		cert, err := tls.PemToCertificate(authInfo.AuthInfo.ClientCertificateData)
+		if err != nil {
+			logrus.Debugf("Could not parse the client certificate of the admin kubeconfig: %v", err)
+			return
+		}
+		if time.Now().After(cert.NotAfter) {
+			logrus.Infof("The client certificate of the admin kubeconfig expired on %s", cert.NotAfter.Format(time.RFC3339))
+		} else {
+			logrus.Infof("The client certificate of the admin kubeconfig expires on %s", cert.NotAfter.Format(time.RFC3339))
+		}
+		return
+	}
+}
+
+// signAdminClientCertKey signs a new admin client certificate with the admin
+// kubeconfig signer recorded in the state of the installation.
+func signAdminClientCertKey(store asset.Store, validity time.Duration) (tls.CertKeyInterface, error) {
+	a, err := store.Load(&tls.AdminKubeConfigSignerCertKey{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the admin kubeconfig signer")
+	}
+	if a == nil {
+		return nil, errors.New("no admin kubeconfig signer in the state of the installation; use --from-cluster to request the client certificate from the cluster")
+	}
+
+	clientCertKey := &tls.AdminKubeConfigClientCertKey{}
+	if err := clientCertKey.Regenerate(a.(*tls.AdminKubeConfigSignerCertKey), validity); err != nil {
+		return nil, errors.Wrap(err, "failed to sign the admin client certificate")
+	}
+	return clientCertKey, nil
+}
+
+// requestAdminClientCertKey requests a new admin client certificate from the
+// kube-apiserver-client signer of the cluster, with a certificate signing
+// request approved with the credentials of the given kubeconfig.
+func requestAdminClientCertKey(ctx context.Context, kubeconfigPath string, validity time.Duration) (tls.CertKeyInterface, error) {
+	if kubeconfigPath == "" {
+		return nil, errors.New("--from-cluster requires the --kubeconfig of a cluster administrator")
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubeconfig")
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	key, err := tls.PrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate private key")
+	}
+	csrTmpl := x509.CertificateRequest{Subject: pkix.Name{CommonName: "system:admin", Organization: []string{"system:masters"}}}
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &csrTmpl, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create certificate request")
+	}
+
+	expirationSeconds := int32(validity.Seconds())
+	csrs := client.CertificatesV1().CertificateSigningRequests()
+	csr, err := csrs.Create(ctx, &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "openshift-install-admin-"},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrBytes}),
+			SignerName:        certificatesv1.KubeAPIServerClientSignerName,
+			ExpirationSeconds: &expirationSeconds,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageClientAuth,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating the certificate signing request")
+	}
+	logrus.Infof("Created the certificate signing request %s", csr.Name)
+
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:    certificatesv1.CertificateApproved,
+		Status:  corev1.ConditionTrue,
+		Reason:  "OpenShiftInstallerRegenerateKubeconfig",
+		Message: "Approved by openshift-install regenerate kubeconfig",
+	})
+	if _, err := csrs.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+		return nil, errors.Wrapf(err, "approving the certificate signing request %s", csr.Name)
+	}
+
+	var certPEM []byte
+	err = wait.PollUntilContextTimeout(ctx, 2*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		issued, err := csrs.Get(ctx, csr.Name, metav1.GetOptions{})
+		if err != nil {
+			logrus.Debugf("Still waiting for the certificate signing request %s: %v", csr.Name, err)
+			return false, nil
+		}
+		for _, condition := range issued.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+				return false, errors.Errorf("the certificate signing request %s is %s: %s", csr.Name, condition.Type, condition.Message)
+			}
+		}
+		certPEM = issued.Status.Certificate
+		return len(certPEM) > 0, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "waiting for the certificate of the certificate signing request %s", csr.Name)
+	}
+
+	return &tls.CertKey{CertRaw: certPEM, KeyRaw: tls.PrivateKeyToPem(key)}, nil
+}
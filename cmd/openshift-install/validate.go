@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/cmd/openshift-install/command"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/manifests/lint"
+	"github.com/openshift/installer/pkg/asset/quota"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
+)
+
+func newValidateCmd(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the install-config and the manifests without creating any resources",
+		Long:  "",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newValidateQuotaCmd(ctx))
+	cmd.AddCommand(newValidateManifestsCmd(ctx))
+	return cmd
+}
+
+func newValidateQuotaCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "quota",
+		Short: "Check that the platform quotas are sufficient for the cluster",
+		Long: `Computes the vCPUs, IP addresses, load balancers and disks required by the
+machine pools of the install-config, and compares them with the live service
+quotas of the AWS, GCP or Azure account. The quotas that are not sufficient
+are reported in a table.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := command.SetupFileHook(command.RootOpts.Dir)
+			defer cleanup()
+
+			store, err := assetstore.NewStore(command.RootOpts.Dir)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to create asset store"))
+			}
+			quotaCheck := &quota.PlatformQuotaCheck{}
+			if err := store.Fetch(ctx, quotaCheck); err != nil {
+				logrus.Fatal(errors.Wrapf(err, "failed to fetch %s", quotaCheck.Name()))
+			}
+			logrus.Info("The platform quotas are sufficient for the cluster")
+		},
+	}
+}
+
+func newValidateManifestsCmd(ctx context.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "manifests",
+		Short: "Check the generated and user-added manifests before they are embedded in the ignition configs",
+		Long: `Renders the manifests, or loads them from the asset directory along with the
+ones added by the user, and validates every object in the manifests and
+openshift directories. The objects are decoded strictly against the API types
+built into the installer, or validated against the schema of the custom
+resource definitions among the manifests, to catch the misspelled fields that
+the API server would reject or silently drop. The constraints enforced at
+admission, such as the names, the namespaces and the names of the singleton
+configuration objects, are checked as well.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := command.SetupFileHook(command.RootOpts.Dir)
+			defer cleanup()
+
+			store, err := assetstore.NewStore(command.RootOpts.Dir)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to create asset store"))
+			}
+			var files []*asset.File
+			for _, a := range targetassets.Manifests {
+				if err := store.Fetch(ctx, a); err != nil {
+					logrus.Fatal(errors.Wrapf(err, "failed to fetch %s", a.Name()))
+				}
+				for _, f := range a.Files() {
+					switch strings.SplitN(filepath.ToSlash(f.Filename), "/", 2)[0] {
+					case "manifests", "openshift":
+						files = append(files, f)
+					}
+				}
+			}
+
+			findings, err := lint.Lint(files)
+			if err != nil {
+				logrus.Fatal(errors.Wrap(err, "failed to validate the manifests"))
+			}
+			var failed int
+			for _, f := range findings {
+				if f.Severity == lint.SeverityError {
+					failed++
+					logrus.Error(f)
+				} else {
+					logrus.Warn(f)
+				}
+			}
+			if failed > 0 {
+				logrus.Fatalf("%d problems found in the manifests", failed)
+			}
+			logrus.Infof("Validated %d manifests", len(files))
+		},
+	}
+}
@@ -1,23 +1,48 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/version"
 )
 
+var (
+	versionOpts struct {
+		releaseInfo      bool
+		registryConfig   string
+		signatureKeyring string
+		signatureStore   string
+	}
+)
+
 func newVersionCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
-		Long:  "",
-		Args:  cobra.ExactArgs(0),
-		RunE:  runVersionCmd,
+		Long: `Print version information.
+
+With --release-info, the release payload pinned in the installer, or its
+override, is pulled with oc to print its version, architectures, digest,
+component versions and component images, and its signature is verified
+against the signature store, so that what will be installed can be confirmed
+before starting.`,
+		Args: cobra.ExactArgs(0),
+		RunE: runVersionCmd,
 	}
+	cmd.Flags().BoolVar(&versionOpts.releaseInfo, "release-info", false, "Inspect the release payload and verify its signature")
+	cmd.Flags().StringVar(&versionOpts.registryConfig, "registry-config", "", "Path to the pull secret used to pull the release payload; defaults to the credentials of oc")
+	cmd.Flags().StringVar(&versionOpts.signatureKeyring, "signature-keyring", releaseimage.DefaultSignatureKeyring, "Keyring of the keys signing the release payloads")
+	cmd.Flags().StringVar(&versionOpts.signatureStore, "signature-store", releaseimage.DefaultSignatureStore, "URL of the store of the release payload signatures")
+	return cmd
 }
 
 func runVersionCmd(cmd *cobra.Command, args []string) error {
@@ -34,5 +59,56 @@ func runVersionCmd(cmd *cobra.Command, args []string) error {
 		fmt.Printf("release image %s\n", image)
 	}
 	fmt.Printf("release architecture %s\n", version.DefaultArch())
+
+	if versionOpts.releaseInfo {
+		return printReleaseInfo(cmd.Context())
+	}
 	return nil
 }
+
+// printReleaseInfo prints the metadata of the release payload and the result
+// of the verification of its signature. It fails if the signature is invalid.
+func printReleaseInfo(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	releaseImage := &releaseimage.Image{}
+	if err := releaseImage.Generate(ctx, asset.Parents{}); err != nil {
+		return errors.Wrap(err, "failed to determine release image")
+	}
+	var pullSecret string
+	if versionOpts.registryConfig != "" {
+		data, err := os.ReadFile(versionOpts.registryConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the registry config")
+		}
+		pullSecret = string(data)
+	}
+
+	info, err := releaseimage.Inspect(pullSecret, releaseImage.PullSpec)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nrelease payload %s\n", info.PullSpec)
+	fmt.Printf("  version: %s\n", info.Version)
+	fmt.Printf("  digest: %s\n", info.Digest)
+	fmt.Printf("  architectures: %s\n", strings.Join(info.Architectures, ", "))
+
+	sigErr := releaseimage.VerifySignature(ctx, info.Digest, versionOpts.signatureKeyring, versionOpts.signatureStore)
+	if sigErr != nil {
+		fmt.Printf("  signature: not verified\n")
+	} else {
+		fmt.Printf("  signature: verified with %s\n", versionOpts.signatureKeyring)
+	}
+
+	fmt.Printf("components:\n")
+	for _, name := range sets.List(sets.KeySet(info.Components)) {
+		fmt.Printf("  %s %s\n", name, info.Components[name])
+	}
+	fmt.Printf("component images:\n")
+	for _, name := range sets.List(sets.KeySet(info.Images)) {
+		fmt.Printf("  %s %s\n", name, info.Images[name])
+	}
+
+	return errors.Wrapf(sigErr, "failed to verify the signature of release image %s", info.PullSpec)
+}
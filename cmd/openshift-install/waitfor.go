@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -68,11 +69,33 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 	}
 }
 
+var waitForInstallCompleteOpts struct {
+	gates   []string
+	timeout time.Duration
+}
+
 func newWaitForInstallCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "install-complete",
 		Short: "Wait until the cluster is ready",
-		Args:  cobra.ExactArgs(0),
+		Long: `Wait until the cluster is ready.
+
+By default the cluster is ready once it is initialized and all the cluster
+operators are stable. The --wait-for flag replaces these defaults with the
+given readiness gates:
+
+  operators=all     all the cluster operators are stable
+  operators=<name>  the named cluster operator is stable (may be repeated)
+  ingress           the ingress is available
+  console           the console route is admitted
+  routes            the console and canary routes answer through the ingress
+                    load balancer, with a certificate chain the ingress CA
+                    validates
+
+The --timeout flag bounds the whole wait, replacing the default timeouts of
+each phase.`,
+		Example: `  openshift-install wait-for install-complete --wait-for=operators=all,ingress,console,routes --timeout=90m`,
+		Args:    cobra.ExactArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
 			timer.StartTimer(timer.TotalTimeElapsed)
 			ctx := context.Background()
@@ -80,16 +103,31 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			cleanup := command.SetupFileHook(command.RootOpts.Dir)
 			defer cleanup()
 
+			gates, err := parseReadinessGates(waitForInstallCompleteOpts.gates)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+
 			config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(command.RootOpts.Dir, "auth", "kubeconfig"))
 			if err != nil {
 				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 			}
 
-			err = waitForInstallComplete(ctx, config, command.RootOpts.Dir)
+			waitCtx := ctx
+			if waitForInstallCompleteOpts.timeout > 0 {
+				var cancel context.CancelFunc
+				waitCtx, cancel = context.WithTimeout(ctx, waitForInstallCompleteOpts.timeout)
+				defer cancel()
+			}
+
+			err = waitForInstallComplete(waitCtx, config, command.RootOpts.Dir, gates)
 			if err != nil {
 				if err2 := logClusterOperatorConditions(ctx, config); err2 != nil {
 					logrus.Error("Attempted to gather ClusterOperator status after wait failure: ", err2)
 				}
+				if err2 := logReadinessSummary(ctx, config, gates); err2 != nil {
+					logrus.Error("Attempted to gather ClusterOperator readiness after wait failure: ", err2)
+				}
 				logTroubleshootingLink()
 				logrus.Error(err)
 				logrus.Exit(exitCodeInstallFailed)
@@ -98,4 +136,7 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			timer.LogSummary()
 		},
 	}
+	cmd.Flags().StringSliceVar(&waitForInstallCompleteOpts.gates, "wait-for", nil, "readiness gates to wait for (e.g. \"operators=all,ingress,console\")")
+	cmd.Flags().DurationVar(&waitForInstallCompleteOpts.timeout, "timeout", 0, "maximum time to wait for the cluster to be ready (e.g. \"90m\"), 0 uses the default timeout of each phase")
+	return cmd
 }
@@ -48,6 +48,7 @@ type clusterInstallStatusHistory struct {
 	RestAPIClusterStatusPreparingForInstallationSeen    bool
 	RestAPIClusterStatusReadySeen                       bool
 	RestAPIInfraEnvEventList                            models.EventList
+	RestAPIClusterEventCursor                           EventCursor
 	RestAPIPreviousClusterStatus                        string
 	RestAPIPreviousEventMessage                         string
 	RestAPIHostValidationsPassed                        bool
@@ -211,6 +212,13 @@ func (czero *Cluster) IsBootstrapComplete() (bool, bool, error) {
 			return false, false, errors.New("cluster metadata returned nil from Agent Rest API")
 		}
 
+		switch *clusterMetadata.Status {
+		case models.ClusterStatusInsufficient, models.ClusterStatusReady, models.ClusterStatusPendingForInput:
+			if err := czero.API.Rest.applyHostPolicies(clusterMetadata); err != nil {
+				logrus.Warnf("Failed to apply the host policies: %v", err)
+			}
+		}
+
 		czero.PrintInstallStatus(clusterMetadata)
 
 		// If status indicates pending action, log host info to help pinpoint what is missing
@@ -271,6 +279,7 @@ func (czero *Cluster) IsBootstrapComplete() (bool, bool, error) {
 			czero.installHistory.RestAPIInfraEnvEventList = eventList
 		}
 
+		czero.PrintClusterRestAPIWarnings()
 	}
 
 	// cluster bootstrap is not complete
@@ -408,6 +417,27 @@ func (czero *Cluster) PrintInfraEnvRestAPIEventList() {
 	}
 }
 
+// PrintClusterRestAPIWarnings Prints the warning and error events of the
+// cluster logged since the last call, as they occur
+func (czero *Cluster) PrintClusterRestAPIWarnings() {
+	if czero.clusterID == nil {
+		return
+	}
+	severities := []string{models.EventSeverityWarning, models.EventSeverityError, models.EventSeverityCritical}
+	eventList, cursor, err := czero.API.Rest.GetClusterEvents(czero.clusterID, severities, czero.installHistory.RestAPIClusterEventCursor)
+	for _, event := range eventList {
+		if *event.Severity == models.EventSeverityWarning {
+			logrus.Warn(*event.Message)
+		} else {
+			logrus.Error(*event.Message)
+		}
+	}
+	czero.installHistory.RestAPIClusterEventCursor = cursor
+	if err != nil {
+		logrus.Debugf("Unable to retrieve the events of the cluster from the Agent Rest API: %v", err)
+	}
+}
+
 // PrintInstallationComplete Prints the installation complete information
 func (czero *Cluster) PrintInstallationComplete() error {
 	absDir, err := filepath.Abs(czero.assetDir)
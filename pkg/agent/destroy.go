@@ -0,0 +1,154 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/client/installer"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/installer/pkg/asset/agent/manifests"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+)
+
+// generatedISOPatterns are the patterns of the ISOs written to the asset
+// directory by the agent image, add-nodes image and config image commands.
+var generatedISOPatterns = []string{"agent.*.iso", "node.*.iso", "agentconfig.noarch.iso"}
+
+// DestroyInfraEnv deregisters the cluster and infraenv of an agent-based
+// install and removes the ISOs generated in the asset directory. When
+// hubKubeconfig is set, the InfraEnv and cluster resources are deleted from
+// the hub cluster (ZTP flow), otherwise they are deregistered from the
+// assisted-service running on node zero.
+func DestroyInfraEnv(ctx context.Context, assetDir, hubKubeconfig string) error {
+	if hubKubeconfig != "" {
+		if err := deleteHubResources(ctx, assetDir, hubKubeconfig); err != nil {
+			return err
+		}
+	} else {
+		restClient, err := NewNodeZeroRestClient(ctx, assetDir)
+		if err != nil {
+			return err
+		}
+		if err := restClient.Deregister(); err != nil {
+			return err
+		}
+	}
+	return removeGeneratedISOs(assetDir)
+}
+
+// Deregister removes the cluster and its infraenv from the Agent Rest API on
+// node zero. Missing records are skipped.
+func (rest *NodeZeroRestClient) Deregister() error {
+	clusterID, err := rest.getClusterID()
+	if err != nil {
+		return errors.Wrap(err, "failed to get cluster ID")
+	}
+	infraEnvID, err := rest.getClusterInfraEnvID()
+	if err != nil {
+		return errors.Wrap(err, "failed to get infraenv ID")
+	}
+
+	// The infraenv references the cluster, so it is removed first.
+	if infraEnvID != nil {
+		params := installer.NewDeregisterInfraEnvParams().WithInfraEnvID(*infraEnvID)
+		if _, err := rest.Client.Installer.DeregisterInfraEnv(rest.ctx, params); err != nil {
+			return errors.Wrapf(err, "failed to deregister infraenv %s", *infraEnvID)
+		}
+		logrus.Infof("Deregistered infraenv %s", *infraEnvID)
+	}
+	if clusterID != nil {
+		params := installer.NewV2DeregisterClusterParams().WithClusterID(*clusterID)
+		if _, err := rest.Client.Installer.V2DeregisterCluster(rest.ctx, params); err != nil {
+			return errors.Wrapf(err, "failed to deregister cluster %s", *clusterID)
+		}
+		logrus.Infof("Deregistered cluster %s", *clusterID)
+	}
+	return nil
+}
+
+// deleteHubResources deletes the InfraEnv, NMStateConfigs, AgentClusterInstall
+// and ClusterDeployment generated from the agent manifests from the hub
+// cluster.
+func deleteHubResources(ctx context.Context, assetDir, hubKubeconfig string) error {
+	assetStore, err := assetstore.NewStore(assetDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	agentManifestsAsset := &manifests.AgentManifests{}
+	agentManifests, err := assetStore.Load(agentManifestsAsset)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load %s", agentManifestsAsset.Name())
+	}
+	if agentManifests == nil {
+		return errors.Errorf("%s not found in %s", agentManifestsAsset.Name(), assetDir)
+	}
+	m := agentManifests.(*manifests.AgentManifests)
+
+	config, err := clientcmd.BuildConfigFromFlags("", hubKubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "error loading hub kubeconfig")
+	}
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{aiv1beta1.AddToScheme, hiveext.AddToScheme, hivev1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			return errors.Wrap(err, "failed to build hub client scheme")
+		}
+	}
+	hubClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrap(err, "creating a hub cluster client failed")
+	}
+
+	var objs []client.Object
+	if m.InfraEnv != nil {
+		objs = append(objs, m.InfraEnv)
+	}
+	for _, nmStateConfig := range m.NMStateConfigs {
+		objs = append(objs, nmStateConfig)
+	}
+	if m.AgentClusterInstall != nil {
+		objs = append(objs, m.AgentClusterInstall)
+	}
+	if m.ClusterDeployment != nil {
+		objs = append(objs, m.ClusterDeployment)
+	}
+	for _, obj := range objs {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if err := hubClient.Delete(ctx, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				logrus.Debugf("%s %s/%s not found on the hub cluster", kind, obj.GetNamespace(), obj.GetName())
+				continue
+			}
+			return errors.Wrapf(err, "failed to delete %s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+		}
+		logrus.Infof("Deleted %s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+	}
+	return nil
+}
+
+// removeGeneratedISOs deletes the ISOs generated in the asset directory.
+func removeGeneratedISOs(assetDir string) error {
+	for _, pattern := range generatedISOPatterns {
+		matches, err := filepath.Glob(filepath.Join(assetDir, pattern))
+		if err != nil {
+			return err
+		}
+		for _, iso := range matches {
+			if err := os.Remove(iso); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed to remove %s", iso)
+			}
+			logrus.Infof("Removed %s", iso)
+		}
+	}
+	return nil
+}
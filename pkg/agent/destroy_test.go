@@ -0,0 +1,26 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveGeneratedISOs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"agent.x86_64.iso", "node.aarch64.iso", "agentconfig.noarch.iso", "install-config.yaml", "custom.iso"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0o600))
+	}
+
+	assert.NoError(t, removeGeneratedISOs(dir))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"install-config.yaml", "custom.iso"}, remaining)
+}
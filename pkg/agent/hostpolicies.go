@@ -0,0 +1,172 @@
+package agent
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/assisted-service/client/installer"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+// discoveredHost is a host registered in the Agent Rest API.
+type discoveredHost struct {
+	id           strfmt.UUID
+	infraEnvID   strfmt.UUID
+	role         string
+	hostname     string
+	macAddresses []string
+}
+
+// hostAssignment is the role and hostname assigned to a discovered host by
+// a host policy. An empty field is left unchanged.
+type hostAssignment struct {
+	host     discoveredHost
+	role     string
+	hostname string
+}
+
+// assignHosts returns the changes of the roles and hostnames of the hosts
+// required by the first policy each host matches. The hosts are indexed in
+// the order of their IDs, and a host which already has the hostname of an
+// index keeps it, so that the assignment does not change as more hosts are
+// discovered.
+func assignHosts(policies []agent.HostPolicy, hosts []discoveredHost) ([]hostAssignment, error) {
+	sorted := append([]discoveredHost{}, hosts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].id < sorted[j].id })
+
+	matched := make([][]discoveredHost, len(policies))
+	for _, host := range sorted {
+		for i := range policies {
+			if policies[i].Matches(host.macAddresses) {
+				matched[i] = append(matched[i], host)
+				break
+			}
+		}
+	}
+
+	var assignments []hostAssignment
+	for i := range policies {
+		policy := &policies[i]
+		roleOf := func(host discoveredHost) string {
+			if policy.Role != "" {
+				return policy.Role
+			}
+			return host.role
+		}
+
+		hostnames := make([]string, len(matched[i]))
+		if policy.HostnameTemplate != "" {
+			used := map[int]bool{}
+			for j, host := range matched[i] {
+				for index := 0; index < len(sorted); index++ {
+					name, err := policy.Hostname(index, roleOf(host))
+					if err != nil {
+						return nil, err
+					}
+					if !used[index] && host.hostname != "" && name == host.hostname {
+						used[index] = true
+						hostnames[j] = name
+						break
+					}
+				}
+			}
+			next := 0
+			for j, host := range matched[i] {
+				if hostnames[j] != "" {
+					continue
+				}
+				for used[next] {
+					next++
+				}
+				name, err := policy.Hostname(next, roleOf(host))
+				if err != nil {
+					return nil, err
+				}
+				used[next] = true
+				hostnames[j] = name
+			}
+		}
+
+		for j, host := range matched[i] {
+			assignment := hostAssignment{host: host}
+			if role := roleOf(host); role != host.role {
+				assignment.role = role
+			}
+			if hostnames[j] != "" && hostnames[j] != host.hostname {
+				assignment.hostname = hostnames[j]
+			}
+			if assignment.role != "" || assignment.hostname != "" {
+				assignments = append(assignments, assignment)
+			}
+		}
+	}
+	return assignments, nil
+}
+
+// applyHostPolicies assigns the roles and hostnames of the host policies of
+// the agent-config to the discovered hosts which are not configured in its
+// hosts.
+func (rest *NodeZeroRestClient) applyHostPolicies(cluster *models.Cluster) error {
+	if len(rest.hostPolicies) == 0 {
+		return nil
+	}
+
+	var hosts []discoveredHost
+	for _, host := range cluster.Hosts {
+		if host.ID == nil || host.Inventory == "" {
+			continue
+		}
+		inventory := &models.Inventory{}
+		if err := json.Unmarshal([]byte(host.Inventory), inventory); err != nil {
+			return errors.Wrapf(err, "failed to parse the inventory of host %s", *host.ID)
+		}
+		discovered := discoveredHost{
+			id:         *host.ID,
+			infraEnvID: host.InfraEnvID,
+			role:       string(host.Role),
+			hostname:   host.RequestedHostname,
+		}
+		configured := false
+		for _, iface := range inventory.Interfaces {
+			if iface == nil || iface.MacAddress == "" {
+				continue
+			}
+			discovered.macAddresses = append(discovered.macAddresses, iface.MacAddress)
+			configured = configured || rest.configuredMACs[strings.ToLower(iface.MacAddress)]
+		}
+		if !configured {
+			hosts = append(hosts, discovered)
+		}
+	}
+
+	assignments, err := assignHosts(rest.hostPolicies, hosts)
+	if err != nil {
+		return err
+	}
+	for _, assignment := range assignments {
+		params := &models.HostUpdateParams{}
+		if assignment.role != "" {
+			role := assignment.role
+			params.HostRole = &role
+		}
+		if assignment.hostname != "" {
+			hostname := assignment.hostname
+			params.HostName = &hostname
+		}
+		updateHostParams := installer.NewV2UpdateHostParams().
+			WithInfraEnvID(assignment.host.infraEnvID).
+			WithHostID(assignment.host.id).
+			WithHostUpdateParams(params)
+		if _, err := rest.Client.Installer.V2UpdateHost(rest.ctx, updateHostParams); err != nil {
+			return errors.Wrapf(err, "failed to update host %s", assignment.host.id)
+		}
+		logrus.Infof("Host %s assigned by the host policies: role %q, hostname %q", assignment.host.id, assignment.role, assignment.hostname)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func TestAssignHosts(t *testing.T) {
+	policies := []agent.HostPolicy{
+		{
+			MACPrefixes:      []string{"3c:fd:fe"},
+			Role:             "master",
+			HostnameTemplate: "control-{{.Index}}",
+		},
+		{
+			Role:             "worker",
+			HostnameTemplate: "worker-{{.Index}}",
+		},
+	}
+
+	cases := []struct {
+		name     string
+		hosts    []discoveredHost
+		expected []hostAssignment
+	}{
+		{
+			name: "new hosts",
+			hosts: []discoveredHost{
+				{id: "3", role: "auto-assign", macAddresses: []string{"52:54:00:00:00:03"}},
+				{id: "2", role: "auto-assign", macAddresses: []string{"3C:FD:FE:00:00:02"}},
+				{id: "1", role: "auto-assign", macAddresses: []string{"52:54:00:00:00:01"}},
+			},
+			expected: []hostAssignment{
+				{host: discoveredHost{id: "2", role: "auto-assign", macAddresses: []string{"3C:FD:FE:00:00:02"}}, role: "master", hostname: "control-0"},
+				{host: discoveredHost{id: "1", role: "auto-assign", macAddresses: []string{"52:54:00:00:00:01"}}, role: "worker", hostname: "worker-0"},
+				{host: discoveredHost{id: "3", role: "auto-assign", macAddresses: []string{"52:54:00:00:00:03"}}, role: "worker", hostname: "worker-1"},
+			},
+		},
+		{
+			name: "assigned hosts keep their hostname",
+			hosts: []discoveredHost{
+				{id: "1", role: "auto-assign", macAddresses: []string{"52:54:00:00:00:01"}},
+				{id: "2", role: "worker", hostname: "worker-0", macAddresses: []string{"52:54:00:00:00:02"}},
+				{id: "3", role: "worker", hostname: "worker-2", macAddresses: []string{"52:54:00:00:00:03"}},
+			},
+			expected: []hostAssignment{
+				{host: discoveredHost{id: "1", role: "auto-assign", macAddresses: []string{"52:54:00:00:00:01"}}, role: "worker", hostname: "worker-1"},
+			},
+		},
+		{
+			name: "hostname set outside of the policies",
+			hosts: []discoveredHost{
+				{id: "1", role: "worker", hostname: "infra-0", macAddresses: []string{"52:54:00:00:00:01"}},
+			},
+			expected: []hostAssignment{
+				{host: discoveredHost{id: "1", role: "worker", hostname: "infra-0", macAddresses: []string{"52:54:00:00:00:01"}}, hostname: "worker-0"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assignments, err := assignHosts(policies, tc.hosts)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, assignments)
+			}
+		})
+	}
+}
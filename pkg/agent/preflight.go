@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	agentRestAPIPort          = 8090
+	machineConfigServerPort   = 22624
+	kubeAPIServerPort         = 6443
+	preflightDialTimeout      = 5 * time.Second
+	preflightFailureRefused   = "refused"
+	preflightFailureTimeout   = "timeout"
+	preflightFailureNoRoute   = "no-route"
+	preflightFailureUnhandled = "unknown"
+)
+
+// dialTCP opens a TCP connection to the address. It is a variable so that it
+// can be replaced in tests.
+var dialTCP = func(ctx context.Context, address string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", address)
+}
+
+// PreflightError is a connectivity failure detected before polling the
+// Agent Rest API.
+type PreflightError struct {
+	Service string
+	Address string
+	Failure string
+	Err     error
+}
+
+func (e *PreflightError) Error() string {
+	switch e.Failure {
+	case preflightFailureRefused:
+		return fmt.Sprintf("the %s is not listening on %s: the rendezvous host is up, check that it booted from the agent ISO and that its services started", e.Service, e.Address)
+	case preflightFailureTimeout:
+		return fmt.Sprintf("connections to the %s on %s time out: check that the rendezvous host is powered on, and that no firewall drops traffic to the port", e.Service, e.Address)
+	case preflightFailureNoRoute:
+		return fmt.Sprintf("the %s on %s cannot be reached: check that this machine has a route to the rendezvous host network", e.Service, e.Address)
+	default:
+		return fmt.Sprintf("failed to connect to the %s on %s: %v", e.Service, e.Address, e.Err)
+	}
+}
+
+func (e *PreflightError) Unwrap() error {
+	return e.Err
+}
+
+// checkPort checks that a TCP connection can be opened to the port of the
+// host, and classifies the failure when it cannot.
+func checkPort(ctx context.Context, service, host string, port int) *PreflightError {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	ctx, cancel := context.WithTimeout(ctx, preflightDialTimeout)
+	defer cancel()
+
+	conn, err := dialTCP(ctx, address)
+	if err == nil {
+		conn.Close()
+		return nil
+	}
+
+	failure := preflightFailureUnhandled
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		failure = preflightFailureRefused
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		failure = preflightFailureNoRoute
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		failure = preflightFailureTimeout
+	}
+	return &PreflightError{Service: service, Address: address, Failure: failure, Err: err}
+}
+
+// PreflightChecks verifies the connectivity to the rendezvous host before
+// the Agent Rest API is polled. The Agent Rest API must be reachable on
+// port 8090. The machine config server and the Kubernetes API server are
+// only started later in the installation, so a refused connection to them
+// is expected, but a timeout or a missing route indicates a firewall or
+// routing problem that would make the installation wait forever.
+func (czero *Cluster) PreflightChecks() []error {
+	host := czero.API.Rest.NodeZeroIP
+	if host == "" {
+		return nil
+	}
+
+	restErr := checkPort(czero.Ctx, "Agent Rest API", host, agentRestAPIPort)
+	if restErr != nil && restErr.Failure != preflightFailureRefused {
+		// The host itself cannot be reached, so checking the other ports
+		// would only repeat the same failure.
+		return []error{restErr}
+	}
+
+	var errs []error
+	if restErr != nil {
+		errs = append(errs, restErr)
+	}
+	for _, check := range []struct {
+		service string
+		port    int
+	}{
+		{service: "machine config server", port: machineConfigServerPort},
+		{service: "Kubernetes API server", port: kubeAPIServerPort},
+	} {
+		err := checkPort(czero.Ctx, check.service, host, check.port)
+		switch {
+		case err == nil:
+		case err.Failure == preflightFailureRefused:
+			logrus.Debugf("The %s is not listening on %s yet", check.service, err.Address)
+		default:
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
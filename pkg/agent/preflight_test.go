@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreflightChecks(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	noRoute := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)}
+
+	cases := []struct {
+		name           string
+		results        map[string]error
+		expectedErrors []string
+	}{
+		{
+			name:    "all ports open",
+			results: map[string]error{},
+		},
+		{
+			name: "cluster services not started yet",
+			results: map[string]error{
+				"192.168.111.80:22624": refused,
+				"192.168.111.80:6443":  refused,
+			},
+		},
+		{
+			name: "agent rest api not started",
+			results: map[string]error{
+				"192.168.111.80:8090":  refused,
+				"192.168.111.80:22624": refused,
+				"192.168.111.80:6443":  refused,
+			},
+			expectedErrors: []string{
+				"the Agent Rest API is not listening on 192.168.111.80:8090: the rendezvous host is up, check that it booted from the agent ISO and that its services started",
+			},
+		},
+		{
+			name: "rendezvous host unreachable",
+			results: map[string]error{
+				"192.168.111.80:8090": noRoute,
+			},
+			expectedErrors: []string{
+				"the Agent Rest API on 192.168.111.80:8090 cannot be reached: check that this machine has a route to the rendezvous host network",
+			},
+		},
+		{
+			name: "firewall drops kube api server traffic",
+			results: map[string]error{
+				"192.168.111.80:22624": refused,
+				"192.168.111.80:6443":  context.DeadlineExceeded,
+			},
+			expectedErrors: []string{
+				"connections to the Kubernetes API server on 192.168.111.80:6443 time out: check that the rendezvous host is powered on, and that no firewall drops traffic to the port",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func(context.Context, string) (net.Conn, error)) { dialTCP = f }(dialTCP)
+			dialTCP = func(_ context.Context, address string) (net.Conn, error) {
+				if err, ok := tc.results[address]; ok {
+					return nil, err
+				}
+				client, server := net.Pipe()
+				server.Close()
+				return client, nil
+			}
+
+			cluster := &Cluster{
+				Ctx: context.Background(),
+				API: &clientSet{Rest: &NodeZeroRestClient{NodeZeroIP: "192.168.111.80"}},
+			}
+			errs := cluster.PreflightChecks()
+			messages := []string{}
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			if len(tc.expectedErrors) == 0 {
+				assert.Empty(t, messages)
+			} else {
+				assert.Equal(t, tc.expectedErrors, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// proxyTransport returns a transport connecting through the cluster-wide
+// proxy of the install-config. The proxy environment variables take
+// precedence when set, since they describe the network the installer runs in.
+func proxyTransport(proxy *types.Proxy) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyFromEnvironmentSet() {
+		transport.Proxy = http.ProxyFromEnvironment
+		return transport
+	}
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if matchesNoProxy(req.URL.Hostname(), proxy.NoProxy) {
+			return nil, nil
+		}
+		proxyURL := proxy.HTTPProxy
+		if req.URL.Scheme == "https" && proxy.HTTPSProxy != "" {
+			proxyURL = proxy.HTTPSProxy
+		}
+		if proxyURL == "" {
+			return nil, nil
+		}
+		return url.Parse(proxyURL)
+	}
+	return transport
+}
+
+func proxyFromEnvironmentSet() bool {
+	for _, name := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNoProxy returns whether host is excluded from the proxy by the
+// comma-separated noProxy list of domains, IPs and CIDRs.
+func matchesNoProxy(host, noProxy string) bool {
+	ip := net.ParseIP(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case ip != nil:
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+			if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+		default:
+			domain := strings.TrimPrefix(entry, ".")
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
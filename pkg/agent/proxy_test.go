@@ -0,0 +1,30 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesNoProxy(t *testing.T) {
+	noProxy := ".example.com,api.test,192.168.111.0/24,fd00::1"
+	cases := []struct {
+		host     string
+		expected bool
+	}{
+		{host: "node.example.com", expected: true},
+		{host: "example.com", expected: true},
+		{host: "badexample.com", expected: false},
+		{host: "api.test", expected: true},
+		{host: "192.168.111.80", expected: true},
+		{host: "192.168.112.80", expected: false},
+		{host: "fd00::1", expected: true},
+		{host: "quay.io", expected: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchesNoProxy(tc.host, noProxy))
+		})
+	}
+	assert.True(t, matchesNoProxy("quay.io", "*"))
+}
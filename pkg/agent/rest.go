@@ -4,10 +4,12 @@ import (
 	"context"
 	"net"
 	"net/url"
+	"strings"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/utils/ptr"
 
 	"github.com/openshift/assisted-service/api/v1beta1"
 	"github.com/openshift/assisted-service/client"
@@ -22,6 +24,17 @@ import (
 	"github.com/openshift/installer/pkg/types/agent"
 )
 
+// eventsPageSize is the number of events requested at once when paging
+// through the events of the Agent Rest API.
+const eventsPageSize = 100
+
+// EventCursor is the position in the events of a cluster after which
+// GetClusterEvents returns the new events. The zero value is the start of
+// the events.
+type EventCursor struct {
+	offset int64
+}
+
 // NodeZeroRestClient is a struct to interact with the Agent Rest API that is on node zero.
 type NodeZeroRestClient struct {
 	Client     *client.AssistedInstall
@@ -29,6 +42,9 @@ type NodeZeroRestClient struct {
 	config     client.Config
 	NodeZeroIP string
 	NodeSSHKey []string
+
+	hostPolicies   []agent.HostPolicy
+	configuredMACs map[string]bool
 }
 
 // NewNodeZeroRestClient Initialize a new rest client to interact with the Agent Rest API on node zero.
@@ -82,9 +98,34 @@ func NewNodeZeroRestClient(ctx context.Context, assetDir string) (*NodeZeroRestC
 		return nil, rendezvousIPError
 	}
 
+	// With DHCP the address of node zero may differ from the configured one,
+	// prefer resolving it over mDNS when its hostname is known.
+	if agentConfig != nil {
+		if hostname := agentConfig.(*agentconfig.AgentConfig).Config.RendezvousHostname; hostname != "" {
+			if ip, err := resolveNodeZero(ctx, hostname); err != nil {
+				logrus.Debugf("failed to resolve node zero over mDNS, using %s: %v", RendezvousIP, err)
+			} else {
+				RendezvousIP = ip
+			}
+		}
+	}
+
+	// The host policies apply to the hosts not configured in agent-config.
+	if agentConfig != nil {
+		restClient.hostPolicies = agentConfig.(*agentconfig.AgentConfig).Config.HostPolicies
+	}
+	restClient.configuredMACs = map[string]bool{}
+	if agentHosts != nil {
+		for _, host := range agentHosts.(*agentconfig.AgentHosts).Hosts {
+			for _, iface := range host.Interfaces {
+				restClient.configuredMACs[strings.ToLower(iface.MacAddress)] = true
+			}
+		}
+	}
+
 	// Get SSH Keys which can be used to determine if Rest API failures are due to network connectivity issues
 	if installConfig != nil {
-		restClient.NodeSSHKey = append(restClient.NodeSSHKey, installConfig.(*installconfig.InstallConfig).Config.SSHKey)
+		restClient.NodeSSHKey = append(restClient.NodeSSHKey, installConfig.(*installconfig.InstallConfig).Config.AuthorizedSSHKeys()...)
 	}
 
 	config := client.Config{}
@@ -93,6 +134,9 @@ func NewNodeZeroRestClient(ctx context.Context, assetDir string) (*NodeZeroRestC
 		Host:   net.JoinHostPort(RendezvousIP, "8090"),
 		Path:   client.DefaultBasePath,
 	}
+	if installConfig != nil && installConfig.(*installconfig.InstallConfig).Config.Proxy != nil {
+		config.Transport = proxyTransport(installConfig.(*installconfig.InstallConfig).Config.Proxy)
+	}
 	client := client.New(config)
 
 	restClient.Client = client
@@ -103,6 +147,20 @@ func NewNodeZeroRestClient(ctx context.Context, assetDir string) (*NodeZeroRestC
 	return restClient, nil
 }
 
+// resolveNodeZero returns the address advertised over mDNS by node zero as
+// <hostname>.local.
+func resolveNodeZero(ctx context.Context, hostname string) (string, error) {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, hostname+".local")
+	if err != nil {
+		return "", err
+	}
+	if len(addrs) == 0 {
+		return "", errors.Errorf("no address found for %s.local", hostname)
+	}
+	logrus.Debugf("Resolved node zero %s.local to %s", hostname, addrs[0])
+	return addrs[0], nil
+}
+
 // IsRestAPILive Determine if the Agent Rest API on node zero has initialized
 func (rest *NodeZeroRestClient) IsRestAPILive() bool {
 	// GET /v2/infraenvs
@@ -129,6 +187,32 @@ func (rest *NodeZeroRestClient) GetInfraEnvEvents(infraEnvID *strfmt.UUID) (mode
 	return clusterEventsResult.Payload, nil
 }
 
+// GetClusterEvents pages through the events of the cluster logged after the
+// cursor, oldest first, keeping only those of the given severities, or all
+// of them when none is given. It returns the events with the cursor after
+// the last of them, so that a cursor is reused with the same severities.
+func (rest *NodeZeroRestClient) GetClusterEvents(clusterID *strfmt.UUID, severities []string, cursor EventCursor) (models.EventList, EventCursor, error) {
+	var eventList models.EventList
+	for {
+		// GET /v2/events?cluster_id={cluster_zero_id}
+		listEventsParams := events.NewV2ListEventsParams().
+			WithClusterID(clusterID).
+			WithSeverities(severities).
+			WithOrder(ptr.To("ascending")).
+			WithOffset(ptr.To(cursor.offset)).
+			WithLimit(ptr.To[int64](eventsPageSize))
+		result, err := rest.Client.Events.V2ListEvents(rest.ctx, listEventsParams)
+		if err != nil {
+			return eventList, cursor, err
+		}
+		eventList = append(eventList, result.Payload...)
+		cursor.offset += int64(len(result.Payload))
+		if len(result.Payload) < eventsPageSize {
+			return eventList, cursor, nil
+		}
+	}
+}
+
 // getClusterID Return the cluster ID assigned by the Agent Rest API
 func (rest *NodeZeroRestClient) getClusterID() (*strfmt.UUID, error) {
 	// GET /v2/clusters and return first result
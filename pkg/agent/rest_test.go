@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/assisted-service/client"
+	"github.com/openshift/assisted-service/models"
+)
+
+func TestGetClusterEvents(t *testing.T) {
+	clusterID := strfmt.UUID("1a2b3c4d-0000-0000-0000-000000000000")
+
+	var eventList models.EventList
+	for i := 0; i < 250; i++ {
+		eventList = append(eventList, &models.Event{
+			ClusterID: &clusterID,
+			Message:   ptr.To(fmt.Sprintf("event %d", i)),
+			Severity:  ptr.To(models.EventSeverityWarning),
+		})
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/api/assisted-install/v2/events", r.URL.Path)
+		assert.Equal(t, clusterID.String(), query.Get("cluster_id"))
+		assert.Equal(t, "warning,error", query.Get("severities"))
+		assert.Equal(t, "ascending", query.Get("order"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		end := min(offset+limit, len(eventList))
+		page := models.EventList{}
+		if offset < end {
+			page = eventList[offset:end]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(page))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	serverURL.Path = client.DefaultBasePath
+	rest := &NodeZeroRestClient{
+		Client: client.New(client.Config{URL: serverURL}),
+		ctx:    context.Background(),
+	}
+	severities := []string{models.EventSeverityWarning, models.EventSeverityError}
+
+	events, cursor, err := rest.GetClusterEvents(&clusterID, severities, EventCursor{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 250)
+	assert.Equal(t, "event 249", *events[249].Message)
+
+	events, cursor, err = rest.GetClusterEvents(&clusterID, severities, cursor)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, EventCursor{offset: 250}, cursor)
+}
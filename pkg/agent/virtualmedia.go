@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/bmc"
+)
+
+// BootHostsFromImage inserts the image at imageURL in the virtual CD drive
+// of the hosts through the Redfish API of their BMC, and boots them from it.
+// The hosts are those of the agent-config, or of the install-config when the
+// agent-config lists none. Hosts without BMC are left to be booted manually.
+func BootHostsFromImage(ctx context.Context, assetDir, imageURL string) error {
+	assetStore, err := assetstore.NewStore(assetDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	agentHostsAsset := &agentconfig.AgentHosts{}
+	agentHosts, err := assetStore.Load(agentHostsAsset)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load %s", agentHostsAsset.Name())
+	}
+	if agentHosts == nil || len(agentHosts.(*agentconfig.AgentHosts).Hosts) == 0 {
+		logrus.Warn("No hosts are configured, boot the hosts from the image manually")
+		return nil
+	}
+
+	for _, host := range agentHosts.(*agentconfig.AgentHosts).Hosts {
+		name := host.Hostname
+		if name == "" && len(host.Interfaces) > 0 {
+			name = host.Interfaces[0].MacAddress
+		}
+		if host.BMC.Address == "" {
+			logrus.Warnf("Host %s has no BMC, boot it from the image manually", name)
+			continue
+		}
+		if host.BMC.Username == "" || host.BMC.Password == "" {
+			return errors.Errorf("the username and password of the BMC of host %s must be set in the host configuration", name)
+		}
+		client, err := bmc.NewClient(host.BMC.Address, host.BMC.Username, host.BMC.Password, bmc.WithInsecureSkipVerify(host.BMC.DisableCertificateVerification))
+		if err == nil {
+			err = client.BootFromImage(ctx, imageURL)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to boot host %s from the image", name)
+		}
+		logrus.Infof("Host %s is booting from the image", name)
+	}
+	return nil
+}
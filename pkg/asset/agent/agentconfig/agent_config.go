@@ -1,12 +1,20 @@
 package agentconfig
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
+	dockerref "github.com/containers/image/docker/reference"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -17,6 +25,10 @@ import (
 
 var (
 	agentConfigFilename = "agent-config.yaml"
+
+	// defaultCatalogSources are the catalog sources available in a
+	// connected cluster.
+	defaultCatalogSources = []string{"redhat-operators", "certified-operators", "community-operators", "redhat-marketplace"}
 )
 
 // AgentConfig reads the agent-config.yaml file.
@@ -40,7 +52,7 @@ func (*AgentConfig) Dependencies() []asset.Asset {
 }
 
 // Generate generates the Agent Config manifest.
-func (a *AgentConfig) Generate(dependencies asset.Parents) error {
+func (a *AgentConfig) Generate(_ context.Context, dependencies asset.Parents) error {
 	// TODO: We are temporarily generating a template of the agent-config.yaml
 	// Change this when its interactive survey is implemented.
 	agentConfigTemplate := `#
@@ -165,6 +177,10 @@ func (a *AgentConfig) validateAgent() field.ErrorList {
 		allErrs = append(allErrs, err...)
 	}
 
+	if err := a.validateDHCP(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
 	if err := a.validateAdditionalNTPSources(field.NewPath("AdditionalNTPSources"), a.Config.AdditionalNTPSources); err != nil {
 		allErrs = append(allErrs, err...)
 	}
@@ -173,6 +189,34 @@ func (a *AgentConfig) validateAgent() field.ErrorList {
 		allErrs = append(allErrs, err...)
 	}
 
+	if err := a.validateCatalogSources(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	if err := a.validateOperators(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	if err := a.validateExtraManifests(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	if err := a.validateHostPolicies(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	if err := a.validateMinimalHardwareRequirements(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	if err := ValidateImageCustomization(field.NewPath("imageCustomization"), a.Config.ImageCustomization); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
+	if err := a.validateAppliance(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
+
 	return allErrs
 }
 
@@ -193,6 +237,36 @@ func (a *AgentConfig) validateRendezvousIP() field.ErrorList {
 	return allErrs
 }
 
+func (a *AgentConfig) validateDHCP() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if a.Config.RendezvousHostname != "" {
+		for _, msg := range k8svalidation.IsDNS1123Label(a.Config.RendezvousHostname) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("rendezvousHostname"), a.Config.RendezvousHostname, msg))
+		}
+	}
+
+	if !a.Config.DHCP {
+		return allErrs
+	}
+
+	// With DHCP the rendezvous IP cannot be derived from a static network
+	// configuration.
+	if a.Config.RendezvousIP == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("rendezvousIP"), "rendezvousIP is required when dhcp is set"))
+	}
+	for i, host := range a.Config.Hosts {
+		if len(host.NetworkConfig.Raw) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Hosts").Index(i).Child("networkConfig"), "networkConfig cannot be set when dhcp is set"))
+		}
+		if host.Network != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Hosts").Index(i).Child("network"), "network cannot be set when dhcp is set"))
+		}
+	}
+
+	return allErrs
+}
+
 func (a *AgentConfig) validateAdditionalNTPSources(additionalNTPSourcesPath *field.Path, sources []string) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -226,6 +300,208 @@ func (a *AgentConfig) validateBootArtifactsBaseURL() field.ErrorList {
 	return allErrs
 }
 
+func (a *AgentConfig) validateCatalogSources() field.ErrorList {
+	var allErrs field.ErrorList
+
+	catalogSourcesPath := field.NewPath("catalogSources")
+	names := sets.New[string]()
+	for i, cs := range a.Config.CatalogSources {
+		if cs.Name == "" {
+			allErrs = append(allErrs, field.Required(catalogSourcesPath.Index(i).Child("name"), "catalog source name is required"))
+		} else {
+			for _, msg := range k8svalidation.IsDNS1123Subdomain(cs.Name) {
+				allErrs = append(allErrs, field.Invalid(catalogSourcesPath.Index(i).Child("name"), cs.Name, msg))
+			}
+			if names.Has(cs.Name) {
+				allErrs = append(allErrs, field.Duplicate(catalogSourcesPath.Index(i).Child("name"), cs.Name))
+			}
+			names.Insert(cs.Name)
+		}
+		if cs.Image == "" {
+			allErrs = append(allErrs, field.Required(catalogSourcesPath.Index(i).Child("image"), "catalog source image is required"))
+		}
+	}
+
+	return allErrs
+}
+
+func (a *AgentConfig) validateOperators() field.ErrorList {
+	var allErrs field.ErrorList
+
+	operatorsPath := field.NewPath("operators")
+	sources := sets.New[string](defaultCatalogSources...)
+	for _, cs := range a.Config.CatalogSources {
+		sources.Insert(cs.Name)
+	}
+	names := sets.New[string]()
+	for i, operator := range a.Config.Operators {
+		if operator.Name == "" {
+			allErrs = append(allErrs, field.Required(operatorsPath.Index(i).Child("name"), "operator name is required"))
+			continue
+		}
+		for _, msg := range k8svalidation.IsDNS1123Subdomain(operator.Name) {
+			allErrs = append(allErrs, field.Invalid(operatorsPath.Index(i).Child("name"), operator.Name, msg))
+		}
+		if names.Has(operator.Name) {
+			allErrs = append(allErrs, field.Duplicate(operatorsPath.Index(i).Child("name"), operator.Name))
+		}
+		names.Insert(operator.Name)
+
+		if operator.Namespace != "" {
+			for _, msg := range k8svalidation.IsDNS1123Label(operator.Namespace) {
+				allErrs = append(allErrs, field.Invalid(operatorsPath.Index(i).Child("namespace"), operator.Namespace, msg))
+			}
+		}
+		if operator.Source != "" && !sources.Has(operator.Source) {
+			allErrs = append(allErrs, field.Invalid(operatorsPath.Index(i).Child("source"), operator.Source,
+				fmt.Sprintf("catalog source must be one of the default catalog sources or be listed in catalogSources: %s", strings.Join(sets.List(sources), ", "))))
+		}
+	}
+
+	return allErrs
+}
+
+func (a *AgentConfig) validateExtraManifests() field.ErrorList {
+	var allErrs field.ErrorList
+
+	extraManifestsPath := field.NewPath("extraManifests")
+	names := sets.New[string]()
+	for i, manifest := range a.Config.ExtraManifests {
+		namePath := extraManifestsPath.Index(i).Child("name")
+		switch {
+		case manifest.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "manifest name is required"))
+		case filepath.Base(manifest.Name) != manifest.Name:
+			allErrs = append(allErrs, field.Invalid(namePath, manifest.Name, "manifest name must be a file name, not a path"))
+		case filepath.Ext(manifest.Name) != ".yaml" && filepath.Ext(manifest.Name) != ".yml":
+			allErrs = append(allErrs, field.Invalid(namePath, manifest.Name, "manifest name must end in .yaml or .yml"))
+		case names.Has(manifest.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, manifest.Name))
+		}
+		names.Insert(manifest.Name)
+
+		if err := validateManifestContent(manifest.Content); err != nil {
+			allErrs = append(allErrs, field.Invalid(extraManifestsPath.Index(i).Child("content"), manifest.Name, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+var macPrefixRegexp = regexp.MustCompile(`^[0-9A-Fa-f]{2}(:[0-9A-Fa-f]{2}){0,5}$`)
+
+func (a *AgentConfig) validateHostPolicies() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, policy := range a.Config.HostPolicies {
+		policyPath := field.NewPath("hostPolicies").Index(i)
+		if policy.Role == "" && policy.HostnameTemplate == "" {
+			allErrs = append(allErrs, field.Required(policyPath, "a host policy must set a role or a hostnameTemplate"))
+		}
+		if policy.Role != "" && policy.Role != masterRole && policy.Role != workerRole {
+			allErrs = append(allErrs, field.NotSupported(policyPath.Child("role"), policy.Role, []string{masterRole, workerRole}))
+		}
+		for j, prefix := range policy.MACPrefixes {
+			if !macPrefixRegexp.MatchString(prefix) {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("macPrefixes").Index(j), prefix, "must be a MAC address prefix of whole bytes, e.g. 3c:fd:fe"))
+			}
+		}
+		if policy.HostnameTemplate != "" {
+			allErrs = append(allErrs, validateHostnameTemplate(policyPath.Child("hostnameTemplate"), &policy)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateHostnameTemplate checks that the template renders valid hostnames
+// that differ from one host to the next.
+func validateHostnameTemplate(fldPath *field.Path, policy *agent.HostPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+
+	first, err := policy.Hostname(0, policy.Role)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, policy.HostnameTemplate, err.Error()))
+	}
+	for _, msg := range k8svalidation.IsDNS1123Subdomain(first) {
+		allErrs = append(allErrs, field.Invalid(fldPath, policy.HostnameTemplate, fmt.Sprintf("renders the invalid hostname %q: %s", first, msg)))
+	}
+	if second, err := policy.Hostname(1, policy.Role); err == nil && second == first {
+		allErrs = append(allErrs, field.Invalid(fldPath, policy.HostnameTemplate, "must use .Index so that every host gets a different hostname"))
+	}
+
+	return allErrs
+}
+
+func (a *AgentConfig) validateMinimalHardwareRequirements() field.ErrorList {
+	var allErrs field.ErrorList
+
+	requirements := a.Config.MinimalHardwareRequirements
+	if requirements == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("minimalHardwareRequirements")
+	allErrs = append(allErrs, validateHardware(fldPath.Child("master"), requirements.Master)...)
+	allErrs = append(allErrs, validateHardware(fldPath.Child("worker"), requirements.Worker)...)
+	allErrs = append(allErrs, validateHardware(fldPath.Child("sno"), requirements.SNO)...)
+	return allErrs
+}
+
+func (a *AgentConfig) validateAppliance() field.ErrorList {
+	var allErrs field.ErrorList
+
+	appliance := a.Config.Appliance
+	if appliance == nil {
+		return allErrs
+	}
+	appliancePath := field.NewPath("appliance")
+	images := sets.New[string]()
+	for i, image := range appliance.Images {
+		imagePath := appliancePath.Child("images").Index(i)
+		for _, msg := range k8svalidation.IsDNS1123Subdomain(image) {
+			allErrs = append(allErrs, field.Invalid(imagePath, image, msg))
+		}
+		if images.Has(image) {
+			allErrs = append(allErrs, field.Duplicate(imagePath, image))
+		}
+		images.Insert(image)
+	}
+	if appliance.RegistryImage != "" {
+		if _, err := dockerref.ParseNormalizedNamed(appliance.RegistryImage); err != nil {
+			allErrs = append(allErrs, field.Invalid(appliancePath.Child("registryImage"), appliance.RegistryImage, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateManifestContent checks that the content holds at least one
+// Kubernetes object, and that every object has an apiVersion and a kind.
+func validateManifestContent(content string) error {
+	dec := k8syaml.NewYAMLToJSONDecoder(strings.NewReader(content))
+	count := 0
+	for {
+		var obj map[string]interface{}
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "manifest is not valid YAML")
+		}
+		if obj == nil {
+			continue
+		}
+		if obj["apiVersion"] == nil || obj["kind"] == nil {
+			return errors.New("every object in the manifest must have an apiVersion and a kind")
+		}
+		count++
+	}
+	if count == 0 {
+		return errors.New("manifest content is empty")
+	}
+	return nil
+}
+
 func unmarshalJSON(b []byte) []byte {
 	output, _ := yaml.JSONToYAML(b)
 	return output
@@ -87,6 +87,60 @@ rendezvousIP: not-a-valid-ip`,
 			expectedFound: false,
 			expectedError: "invalid Agent Config configuration: rendezvousIP: Invalid value: \"not-a-valid-ip\": \"not-a-valid-ip\" is not a valid IP",
 		},
+		{
+			name: "dhcp",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+rendezvousHostname: master-0
+dhcp: true`,
+
+			expectedFound:  true,
+			expectedConfig: agentConfig().dhcp("master-0"),
+		},
+		{
+			name: "dhcp-missing-rendezvousIP",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+dhcp: true`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: rendezvousIP: Required value: rendezvousIP is required when dhcp is set",
+		},
+		{
+			name: "dhcp-with-host-networkConfig",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+dhcp: true
+hosts:
+  - hostname: master-0
+    networkConfig:
+      interfaces:
+        - name: eth0
+          type: ethernet`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: Hosts[0].networkConfig: Forbidden: networkConfig cannot be set when dhcp is set",
+		},
+		{
+			name: "invalid-rendezvousHostname",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+rendezvousHostname: Master_0`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: rendezvousHostname: Invalid value: \"Master_0\": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name', or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+		},
 		{
 			name: "empty-bootArtifactsBaseURL",
 			data: `
@@ -125,6 +179,145 @@ rendezvousIP: 192.168.111.80`,
 			expectedFound: false,
 			expectedError: "invalid Agent Config configuration: AdditionalNTPSources[4]: Invalid value: \"invalid_pool.ntp.org\": NTP source is not a valid domain name nor a valid IP",
 		},
+		{
+			name: "operators",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+catalogSources:
+  - name: my-catalog
+    image: registry.example.com/catalog:latest
+operators:
+  - name: lvms-operator
+    namespace: openshift-storage
+    source: my-catalog
+extraManifests:
+  - name: lvmcluster.yaml
+    content: |
+      apiVersion: lvm.topolvm.io/v1alpha1
+      kind: LVMCluster
+      metadata:
+        name: lvmcluster
+        namespace: openshift-storage
+`,
+
+			expectedFound: true,
+			expectedConfig: agentConfig().
+				catalogSources(agent.CatalogSource{Name: "my-catalog", Image: "registry.example.com/catalog:latest"}).
+				operators(agent.Operator{Name: "lvms-operator", Namespace: "openshift-storage", Source: "my-catalog"}).
+				extraManifests(agent.ExtraManifest{Name: "lvmcluster.yaml", Content: "apiVersion: lvm.topolvm.io/v1alpha1\nkind: LVMCluster\nmetadata:\n  name: lvmcluster\n  namespace: openshift-storage\n"}),
+		},
+		{
+			name: "invalid-operator-source",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+operators:
+  - name: lvms-operator
+    source: my-catalog`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: operators[0].source: Invalid value: \"my-catalog\": catalog source must be one of the default catalog sources or be listed in catalogSources: certified-operators, community-operators, redhat-marketplace, redhat-operators",
+		},
+		{
+			name: "invalid-extra-manifest",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+extraManifests:
+  - name: lvmcluster.json
+    content: |
+      metadata:
+        name: lvmcluster`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: [extraManifests[0].name: Invalid value: \"lvmcluster.json\": manifest name must end in .yaml or .yml, extraManifests[0].content: Invalid value: \"lvmcluster.json\": every object in the manifest must have an apiVersion and a kind]",
+		},
+		{
+			name: "invalid-host-policy",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+hostPolicies:
+  - macPrefixes:
+      - 3c:fd:f
+    role: infra`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: [hostPolicies[0].role: Unsupported value: \"infra\": supported values: \"master\", \"worker\", hostPolicies[0].macPrefixes[0]: Invalid value: \"3c:fd:f\": must be a MAC address prefix of whole bytes, e.g. 3c:fd:fe]",
+		},
+		{
+			name: "host-policy-hostname-without-index",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+hostPolicies:
+  - role: worker
+    hostnameTemplate: "{{.Role}}"`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: hostPolicies[0].hostnameTemplate: Invalid value: \"{{.Role}}\": must use .Index so that every host gets a different hostname",
+		},
+		{
+			name: "negative-minimal-hardware-requirement",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+minimalHardwareRequirements:
+  worker:
+    memoryMiB: -8192`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: minimalHardwareRequirements.worker.memoryMiB: Invalid value: -8192: must be positive",
+		},
+		{
+			name: "invalid-image-customization",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+imageCustomization:
+  files:
+  - path: etc/vpn.conf
+    contents: remote vpn.example.com
+  systemdUnits:
+  - name: vpn
+    contents: "[Service]"
+  kernelArguments:
+  - console=ttyS1,115200n8 quiet`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: [imageCustomization.files[0].path: Invalid value: \"etc/vpn.conf\": must be an absolute and clean path, imageCustomization.systemdUnits[0].name: Invalid value: \"vpn\": must be a unit file name ending in .service, .socket, .timer, .path, .target, .mount, imageCustomization.kernelArguments[0]: Invalid value: \"console=ttyS1,115200n8 quiet\": must be a single kernel argument without whitespace]",
+		},
+		{
+			name: "invalid-appliance",
+			data: `
+apiVersion: v1beta1
+metadata:
+  name: agent-config-cluster0
+rendezvousIP: 192.168.111.80
+appliance:
+  images:
+  - etcd
+  - etcd
+  registryImage: Registry:2`,
+
+			expectedFound: false,
+			expectedError: "invalid Agent Config configuration: [appliance.images[1]: Duplicate value: \"etcd\", appliance.registryImage: Invalid value: \"Registry:2\": invalid reference format: repository name must be lowercase]",
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -184,7 +377,28 @@ func (acb *AgentConfigBuilder) rendezvousIP(ip string) *AgentConfigBuilder {
 	return acb
 }
 
+func (acb *AgentConfigBuilder) dhcp(rendezvousHostname string) *AgentConfigBuilder {
+	acb.Config.DHCP = true
+	acb.Config.RendezvousHostname = rendezvousHostname
+	return acb
+}
+
 func (acb *AgentConfigBuilder) bootArtifactsBaseURL(url string) *AgentConfigBuilder {
 	acb.Config.BootArtifactsBaseURL = url
 	return acb
 }
+
+func (acb *AgentConfigBuilder) catalogSources(catalogSources ...agent.CatalogSource) *AgentConfigBuilder {
+	acb.Config.CatalogSources = append(acb.Config.CatalogSources, catalogSources...)
+	return acb
+}
+
+func (acb *AgentConfigBuilder) operators(operators ...agent.Operator) *AgentConfigBuilder {
+	acb.Config.Operators = append(acb.Config.Operators, operators...)
+	return acb
+}
+
+func (acb *AgentConfigBuilder) extraManifests(extraManifests ...agent.ExtraManifest) *AgentConfigBuilder {
+	acb.Config.ExtraManifests = append(acb.Config.ExtraManifests, extraManifests...)
+	return acb
+}
@@ -1,8 +1,12 @@
 package agentconfig
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,8 +19,11 @@ import (
 	agentAsset "github.com/openshift/installer/pkg/asset/agent"
 	"github.com/openshift/installer/pkg/asset/agent/joiner"
 	"github.com/openshift/installer/pkg/asset/agent/workflow"
+	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types/agent"
 	"github.com/openshift/installer/pkg/types/baremetal/validation"
+	"github.com/openshift/installer/pkg/types/external"
+	"github.com/openshift/installer/pkg/types/none"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -39,8 +46,11 @@ type nmStateInterface struct {
 // AgentHosts generates the hosts information from the AgentConfig and
 // OptionalInstallConfig assets.
 type AgentHosts struct {
-	Hosts        []agent.Host
-	rendezvousIP string
+	Hosts []agent.Host
+	// MachineNetworks are the machine networks derived from the static
+	// addresses of the hosts, when the install-config sets none.
+	MachineNetworks []ipnet.IPNet
+	rendezvousIP    string
 }
 
 // Name returns a human friendly name.
@@ -59,7 +69,7 @@ func (a *AgentHosts) Dependencies() []asset.Asset {
 }
 
 // Generate generates the Hosts data.
-func (a *AgentHosts) Generate(dependencies asset.Parents) error {
+func (a *AgentHosts) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	addNodesConfig := &joiner.AddNodesConfig{}
 	agentConfig := &AgentConfig{}
@@ -95,10 +105,40 @@ func (a *AgentHosts) Generate(dependencies asset.Parents) error {
 		return fmt.Errorf("AgentWorkflowType value not supported: %s", agentWorkflow.Workflow)
 	}
 
+	if err := a.expandHostNetworks(); err != nil {
+		return errors.Wrapf(err, "invalid Hosts configuration")
+	}
+
 	if err := a.validateAgentHosts().ToAggregate(); err != nil {
 		return errors.Wrapf(err, "invalid Hosts configuration")
 	}
 
+	if agentWorkflow.Workflow == workflow.AgentWorkflowTypeInstall && installConfig.Config != nil {
+		var overrides *agent.MinimalHardwareRequirements
+		if agentConfig.Config != nil {
+			overrides = agentConfig.Config.MinimalHardwareRequirements
+		}
+		if err := validateHostPreflight(a.Hosts, installConfig.Config, installConfig.ReleaseArchitecture, overrides).ToAggregate(); err != nil {
+			return errors.Wrapf(err, "hosts failed the preflight checks")
+		}
+	}
+
+	// The VIPs of the other platforms are validated against the machine
+	// network when the install-config is loaded, so it cannot be derived.
+	if agentWorkflow.Workflow == workflow.AgentWorkflowTypeInstall && installConfig.Config != nil && installConfig.MachineNetworkDefaulted {
+		switch installConfig.Config.Platform.Name() {
+		case none.Name, external.Name:
+			machineNetworks, err := deriveMachineNetworks(a.Hosts, a.rendezvousIP)
+			if err != nil {
+				return errors.Wrapf(err, "cannot derive the machine network from the hosts")
+			}
+			if len(machineNetworks) > 0 {
+				a.MachineNetworks = machineNetworks
+				logrus.Infof("Using the machine networks %v of the static addresses of the hosts", machineNetworks)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -171,7 +211,13 @@ func (a *AgentHosts) validateHostInterfaces(hostPath *field.Path, host agent.Hos
 
 func (a *AgentHosts) validateHostRootDeviceHints(hostPath *field.Path, host agent.Host) field.ErrorList {
 	rdhPath := hostPath.Child("rootDeviceHints")
-	allErrs := validation.ValidateHostRootDeviceHints(&host.RootDeviceHints, rdhPath)
+
+	var allErrs field.ErrorList
+	if host.RootDeviceHints.Multipath {
+		allErrs = validateMultipathRootDeviceHints(&host.RootDeviceHints, rdhPath)
+	} else {
+		allErrs = validation.ValidateHostRootDeviceHints(&host.RootDeviceHints.RootDeviceHints, rdhPath)
+	}
 
 	if host.RootDeviceHints.WWNWithExtension != "" {
 		allErrs = append(allErrs, field.Forbidden(
@@ -182,6 +228,68 @@ func (a *AgentHosts) validateHostRootDeviceHints(hostPath *field.Path, host agen
 		allErrs = append(allErrs, field.Forbidden(rdhPath.Child("wwnVendorExtension"), "WWN vendor extensions are not supported in root device hints"))
 	}
 
+	if host.RootDeviceHints.ISCSI != nil {
+		allErrs = append(allErrs, validateISCSITarget(host.RootDeviceHints.ISCSI, rdhPath.Child("iscsi"))...)
+	}
+
+	return allErrs
+}
+
+// validateMultipathRootDeviceHints checks that the hints identify a
+// device-mapper multipath device rather than one of its paths.
+func validateMultipathRootDeviceHints(rdh *agent.RootDeviceHints, rdhPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if rdh.DeviceName == "" && rdh.WWN == "" {
+		allErrs = append(allErrs, field.Required(rdhPath, "either deviceName or wwn must be set when multipath is enabled"))
+	}
+
+	if rdh.DeviceName != "" {
+		name, isMapper := strings.CutPrefix(rdh.DeviceName, "/dev/mapper/")
+		if !isMapper {
+			name, _ = strings.CutPrefix(rdh.DeviceName, "/dev/disk/by-id/dm-uuid-mpath-")
+		}
+		if name == rdh.DeviceName || name == "" || strings.Contains(name, "/") {
+			allErrs = append(allErrs, field.Invalid(rdhPath.Child("deviceName"), rdh.DeviceName,
+				"Device Name of a multipath root device hint must be path in /dev/mapper/ or /dev/disk/by-id/dm-uuid-mpath-"))
+		}
+	}
+
+	return allErrs
+}
+
+var iqnRegexp = regexp.MustCompile(`^(iqn\.[0-9]{4}-[0-9]{2}\.[a-z0-9.-]+(:.+)?|eui\.[0-9A-Fa-f]{16}|naa\.[0-9A-Fa-f]{16}([0-9A-Fa-f]{16})?)$`)
+
+func validateISCSITarget(target *agent.ISCSITarget, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if target.Portal == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("portal"), "the iSCSI target portal is required"))
+	} else if host, port, err := net.SplitHostPort(target.Portal); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("portal"), target.Portal, "portal must be in the format host:port"))
+	} else {
+		if validate.Host(host) != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("portal"), target.Portal, "portal host must be a valid IP address or host name"))
+		}
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("portal"), target.Portal, "portal port must be between 1 and 65535"))
+		}
+	}
+
+	if target.IQN == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("iqn"), "the iSCSI target IQN is required"))
+	} else if !iqnRegexp.MatchString(target.IQN) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("iqn"), target.IQN, "must be a valid iqn., eui. or naa. iSCSI name"))
+	}
+
+	if target.LUN < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("lun"), target.LUN, "LUN must be zero or positive"))
+	}
+
+	if target.InitiatorName != "" && !iqnRegexp.MatchString(target.InitiatorName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initiatorName"), target.InitiatorName, "must be a valid iqn., eui. or naa. iSCSI name"))
+	}
+
 	return allErrs
 }
 
@@ -222,7 +330,7 @@ func (a *AgentHosts) getInstallConfigDefaults(installConfig *agentAsset.Optional
 			Role:     icHost.Role,
 		}
 		if icHost.RootDeviceHints != nil {
-			host.RootDeviceHints = *icHost.RootDeviceHints
+			host.RootDeviceHints = agent.RootDeviceHints{RootDeviceHints: *icHost.RootDeviceHints}
 		}
 		if icHost.NetworkConfig != nil {
 			contents, err := yaml.JSONToYAML(icHost.NetworkConfig.Raw)
@@ -1,6 +1,7 @@
 package agentconfig
 
 import (
+	"context"
 	"net"
 	"testing"
 
@@ -191,6 +192,39 @@ func TestAgentHosts_Generate(t *testing.T) {
 			expectedError:  "invalid Hosts configuration: Hosts[0].rootDeviceHints.wwnVendorExtension: Forbidden: WWN vendor extensions are not supported in root device hints",
 			expectedConfig: nil,
 		},
+		{
+			name: "multipath-device-name-agent-config",
+			dependencies: []asset.Asset{
+				&workflow.AgentWorkflow{Workflow: workflow.AgentWorkflowTypeInstall},
+				&joiner.AddNodesConfig{},
+				getInstallConfigSingleHost(),
+				getAgentConfigMultipathDeviceName("/dev/disk/by-id/dm-uuid-mpath-3600508e000000000ce506dc50ab0ad05"),
+			},
+			expectedConfig: agentHosts().hosts(
+				agentHost().name("test").role("master").interfaces(iface("enp3s1", "28:d2:44:d2:b2:1a")).multipathDeviceHint("/dev/disk/by-id/dm-uuid-mpath-3600508e000000000ce506dc50ab0ad05")),
+		},
+		{
+			name: "invalid-multipath-device-name-agent-config",
+			dependencies: []asset.Asset{
+				&workflow.AgentWorkflow{Workflow: workflow.AgentWorkflowTypeInstall},
+				&joiner.AddNodesConfig{},
+				getInstallConfigSingleHost(),
+				getAgentConfigMultipathDeviceName("/dev/sda"),
+			},
+			expectedError:  "invalid Hosts configuration: Hosts[0].rootDeviceHints.deviceName: Invalid value: \"/dev/sda\": Device Name of a multipath root device hint must be path in /dev/mapper/ or /dev/disk/by-id/dm-uuid-mpath-",
+			expectedConfig: nil,
+		},
+		{
+			name: "invalid-iscsi-target-agent-config",
+			dependencies: []asset.Asset{
+				&workflow.AgentWorkflow{Workflow: workflow.AgentWorkflowTypeInstall},
+				&joiner.AddNodesConfig{},
+				getInstallConfigSingleHost(),
+				getAgentConfigInvalidISCSITarget(),
+			},
+			expectedError:  "invalid Hosts configuration: [Hosts[0].rootDeviceHints.iscsi.portal: Invalid value: \"192.168.111.5\": portal must be in the format host:port, Hosts[0].rootDeviceHints.iscsi.iqn: Invalid value: \"target\": must be a valid iqn., eui. or naa. iSCSI name]",
+			expectedConfig: nil,
+		},
 		{
 			name: "node-hostname-and-role-are-not-required",
 			dependencies: []asset.Asset{
@@ -296,7 +330,7 @@ func TestAgentHosts_Generate(t *testing.T) {
 			parents.Add(tc.dependencies...)
 
 			asset := &AgentHosts{}
-			err := asset.Generate(parents)
+			err := asset.Generate(context.Background(), parents)
 
 			if tc.expectedError != "" {
 				assert.Equal(t, tc.expectedError, err.Error())
@@ -388,8 +422,10 @@ func getAgentConfigSingleHost() *AgentConfig {
 					MacAddress: "28:d2:44:d2:b2:1a",
 				},
 			},
-			RootDeviceHints: baremetal.RootDeviceHints{
-				DeviceName: "/dev/sda",
+			RootDeviceHints: agent.RootDeviceHints{
+				RootDeviceHints: baremetal.RootDeviceHints{
+					DeviceName: "/dev/sda",
+				},
 			},
 		},
 	}
@@ -418,16 +454,40 @@ func getAgentConfigMultiHost() *AgentConfig {
 
 func getAgentConfigUnsupportedDeviceName() *AgentConfig {
 	a := getAgentConfigSingleHost()
-	a.Config.Hosts[0].RootDeviceHints = baremetal.RootDeviceHints{
-		DeviceName: "/dev/disk/by-id/wwn-0x600508e000000000ce506dc50ab0ad05",
+	a.Config.Hosts[0].RootDeviceHints = agent.RootDeviceHints{
+		RootDeviceHints: baremetal.RootDeviceHints{
+			DeviceName: "/dev/disk/by-id/wwn-0x600508e000000000ce506dc50ab0ad05",
+		},
 	}
 	return a
 }
 
 func getAgentConfigUnsupportedWWNVendorExtension() *AgentConfig {
 	a := getAgentConfigSingleHost()
-	a.Config.Hosts[0].RootDeviceHints = baremetal.RootDeviceHints{
-		WWNVendorExtension: "wwn-with-vendor-extension-value",
+	a.Config.Hosts[0].RootDeviceHints = agent.RootDeviceHints{
+		RootDeviceHints: baremetal.RootDeviceHints{
+			WWNVendorExtension: "wwn-with-vendor-extension-value",
+		},
+	}
+	return a
+}
+
+func getAgentConfigMultipathDeviceName(deviceName string) *AgentConfig {
+	a := getAgentConfigSingleHost()
+	a.Config.Hosts[0].RootDeviceHints = agent.RootDeviceHints{
+		RootDeviceHints: baremetal.RootDeviceHints{
+			DeviceName: deviceName,
+		},
+		Multipath: true,
+	}
+	return a
+}
+
+func getAgentConfigInvalidISCSITarget() *AgentConfig {
+	a := getAgentConfigSingleHost()
+	a.Config.Hosts[0].RootDeviceHints.ISCSI = &agent.ISCSITarget{
+		Portal: "192.168.111.5",
+		IQN:    "target",
 	}
 	return a
 }
@@ -666,8 +726,20 @@ func (hb *HostBuilder) networkConfig(raw string) *HostBuilder {
 }
 
 func (hb *HostBuilder) deviceHint() *HostBuilder {
-	hb.Host.RootDeviceHints = baremetal.RootDeviceHints{
-		DeviceName: "/dev/sda",
+	hb.Host.RootDeviceHints = agent.RootDeviceHints{
+		RootDeviceHints: baremetal.RootDeviceHints{
+			DeviceName: "/dev/sda",
+		},
+	}
+	return hb
+}
+
+func (hb *HostBuilder) multipathDeviceHint(deviceName string) *HostBuilder {
+	hb.Host.RootDeviceHints = agent.RootDeviceHints{
+		RootDeviceHints: baremetal.RootDeviceHints{
+			DeviceName: deviceName,
+		},
+		Multipath: true,
 	}
 	return hb
 }
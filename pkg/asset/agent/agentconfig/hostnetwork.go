@@ -0,0 +1,292 @@
+package agentconfig
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+// maxInterfaceNameLength is the maximum length of a Linux network interface
+// name.
+const maxInterfaceNameLength = 15
+
+var bondModes = []string{"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"}
+
+// expandHostNetworks validates the simplified network configuration of the
+// hosts and merges the NMState generated from it into their networkConfig.
+func (a *AgentHosts) expandHostNetworks() error {
+	allErrs := field.ErrorList{}
+	for i, host := range a.Hosts {
+		if host.Network != nil {
+			allErrs = append(allErrs, validateHostNetwork(field.NewPath("Hosts").Index(i).Child("network"), &host)...)
+		}
+	}
+	if err := allErrs.ToAggregate(); err != nil {
+		return err
+	}
+
+	for i := range a.Hosts {
+		host := &a.Hosts[i]
+		if host.Network == nil {
+			continue
+		}
+		networkConfig, err := mergeNMState(host.NetworkConfig.Raw, hostNetworkNMState(host))
+		if err != nil {
+			return errors.Wrapf(err, "failed to merge the network of host %s with its networkConfig", host.Hostname)
+		}
+		host.NetworkConfig.Raw = networkConfig
+	}
+	return nil
+}
+
+func validateHostNetwork(fldPath *field.Path, host *agent.Host) field.ErrorList {
+	var allErrs field.ErrorList
+
+	hostInterfaces := sets.New[string]()
+	for _, iface := range host.Interfaces {
+		hostInterfaces.Insert(iface.Name)
+	}
+	names := sets.New[string]()
+	members := sets.New[string]()
+	gateways := map[bool]bool{}
+
+	validateName := func(namePath *field.Path, name string) {
+		switch {
+		case name == "":
+			allErrs = append(allErrs, field.Required(namePath, "interface name is required"))
+		case len(name) > maxInterfaceNameLength || strings.ContainsAny(name, "/: \t"):
+			allErrs = append(allErrs, field.Invalid(namePath, name, fmt.Sprintf("must be a valid interface name of at most %d characters", maxInterfaceNameLength)))
+		case names.Has(name) || hostInterfaces.Has(name):
+			allErrs = append(allErrs, field.Duplicate(namePath, name))
+		}
+		names.Insert(name)
+	}
+	validateAddresses := func(ifacePath *field.Path, addresses []string, gateway string) {
+		families := map[bool]bool{}
+		for i, address := range addresses {
+			ip, _, err := net.ParseCIDR(address)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(ifacePath.Child("addresses").Index(i), address, "must be an IP address in CIDR notation"))
+				continue
+			}
+			families[ip.To4() != nil] = true
+		}
+		if gateway == "" {
+			return
+		}
+		ip := net.ParseIP(gateway)
+		switch {
+		case ip == nil:
+			allErrs = append(allErrs, field.Invalid(ifacePath.Child("gateway"), gateway, "must be an IP address"))
+		case !families[ip.To4() != nil]:
+			allErrs = append(allErrs, field.Invalid(ifacePath.Child("gateway"), gateway, "the interface has no address of the family of the gateway"))
+		case gateways[ip.To4() != nil]:
+			allErrs = append(allErrs, field.Invalid(ifacePath.Child("gateway"), gateway, "only one default gateway per IP family can be set"))
+		default:
+			gateways[ip.To4() != nil] = true
+		}
+	}
+
+	for i, bond := range host.Network.Bonds {
+		bondPath := fldPath.Child("bonds").Index(i)
+		validateName(bondPath.Child("name"), bond.Name)
+		if !sets.New(bondModes...).Has(bond.Mode) {
+			allErrs = append(allErrs, field.NotSupported(bondPath.Child("mode"), bond.Mode, bondModes))
+		}
+		if len(bond.Members) == 0 {
+			allErrs = append(allErrs, field.Required(bondPath.Child("members"), "a bond must have at least one member"))
+		}
+		for j, member := range bond.Members {
+			memberPath := bondPath.Child("members").Index(j)
+			if !hostInterfaces.Has(member) {
+				allErrs = append(allErrs, field.Invalid(memberPath, member, "must be the name of one of the interfaces of the host"))
+			} else if members.Has(member) {
+				allErrs = append(allErrs, field.Invalid(memberPath, member, "an interface can only be the member of one bond"))
+			}
+			members.Insert(member)
+		}
+		if bond.MTU < 0 {
+			allErrs = append(allErrs, field.Invalid(bondPath.Child("mtu"), bond.MTU, "must be positive"))
+		}
+		validateAddresses(bondPath, bond.Addresses, bond.Gateway)
+	}
+
+	for i, vlan := range host.Network.VLANs {
+		vlanPath := fldPath.Child("vlans").Index(i)
+		if vlan.BaseInterface == "" {
+			allErrs = append(allErrs, field.Required(vlanPath.Child("baseInterface"), "the base interface of a VLAN is required"))
+		} else if !hostInterfaces.Has(vlan.BaseInterface) && !names.Has(vlan.BaseInterface) {
+			allErrs = append(allErrs, field.Invalid(vlanPath.Child("baseInterface"), vlan.BaseInterface, "must be the name of one of the interfaces or bonds of the host"))
+		}
+		if vlan.ID < 1 || vlan.ID > 4094 {
+			allErrs = append(allErrs, field.Invalid(vlanPath.Child("id"), vlan.ID, "must be between 1 and 4094"))
+		}
+		validateName(vlanPath.Child("name"), vlan.InterfaceName())
+		validateAddresses(vlanPath, vlan.Addresses, vlan.Gateway)
+	}
+
+	for i, server := range host.Network.DNSServers {
+		if net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsServers").Index(i), server, "must be an IP address"))
+		}
+	}
+
+	return allErrs
+}
+
+// hostNetworkNMState returns the NMState of the simplified network
+// configuration of a host.
+func hostNetworkNMState(host *agent.Host) map[string]interface{} {
+	macAddresses := map[string]string{}
+	for _, iface := range host.Interfaces {
+		macAddresses[iface.Name] = iface.MacAddress
+	}
+
+	interfaces := []interface{}{}
+	routes := []interface{}{}
+	addRoute := func(ifaceName, gateway string) {
+		if gateway == "" {
+			return
+		}
+		destination := "0.0.0.0/0"
+		if net.ParseIP(gateway).To4() == nil {
+			destination = "::/0"
+		}
+		routes = append(routes, map[string]interface{}{
+			"destination":        destination,
+			"next-hop-address":   gateway,
+			"next-hop-interface": ifaceName,
+			"table-id":           254,
+		})
+	}
+
+	for _, bond := range host.Network.Bonds {
+		for _, member := range bond.Members {
+			interfaces = append(interfaces, map[string]interface{}{
+				"name":        member,
+				"type":        "ethernet",
+				"state":       "up",
+				"mac-address": macAddresses[member],
+			})
+		}
+		iface := nmStateInterfaceWithAddresses(bond.Name, "bond", bond.Addresses)
+		iface["link-aggregation"] = map[string]interface{}{
+			"mode":    bond.Mode,
+			"options": map[string]interface{}{"miimon": "100"},
+			"port":    bond.Members,
+		}
+		if bond.MTU > 0 {
+			iface["mtu"] = bond.MTU
+		}
+		interfaces = append(interfaces, iface)
+		addRoute(bond.Name, bond.Gateway)
+	}
+
+	for _, vlan := range host.Network.VLANs {
+		iface := nmStateInterfaceWithAddresses(vlan.InterfaceName(), "vlan", vlan.Addresses)
+		iface["vlan"] = map[string]interface{}{
+			"base-iface": vlan.BaseInterface,
+			"id":         vlan.ID,
+		}
+		interfaces = append(interfaces, iface)
+		addRoute(vlan.InterfaceName(), vlan.Gateway)
+	}
+
+	nmState := map[string]interface{}{
+		"interfaces": interfaces,
+	}
+	if len(routes) > 0 {
+		nmState["routes"] = map[string]interface{}{"config": routes}
+	}
+	if len(host.Network.DNSServers) > 0 {
+		nmState["dns-resolver"] = map[string]interface{}{
+			"config": map[string]interface{}{"server": host.Network.DNSServers},
+		}
+	}
+	return nmState
+}
+
+func nmStateInterfaceWithAddresses(name, ifaceType string, addresses []string) map[string]interface{} {
+	ipv4 := []interface{}{}
+	ipv6 := []interface{}{}
+	for _, address := range addresses {
+		// The addresses were validated.
+		ip, ipNet, _ := net.ParseCIDR(address)
+		prefixLength, _ := ipNet.Mask.Size()
+		entry := map[string]interface{}{"ip": ip.String(), "prefix-length": prefixLength}
+		if ip.To4() != nil {
+			ipv4 = append(ipv4, entry)
+		} else {
+			ipv6 = append(ipv6, entry)
+		}
+	}
+
+	ipConfig := func(addresses []interface{}) map[string]interface{} {
+		if len(addresses) == 0 {
+			return map[string]interface{}{"enabled": false}
+		}
+		return map[string]interface{}{"enabled": true, "dhcp": false, "address": addresses}
+	}
+	return map[string]interface{}{
+		"name":  name,
+		"type":  ifaceType,
+		"state": "up",
+		"ipv4":  ipConfig(ipv4),
+		"ipv6":  ipConfig(ipv6),
+	}
+}
+
+// mergeNMState merges the generated NMState into the raw NMState of a host.
+// The interfaces and routes are appended, and an interface or DNS resolver
+// configured in both is an error rather than silently overridden.
+func mergeNMState(raw []byte, generated map[string]interface{}) ([]byte, error) {
+	merged := map[string]interface{}{}
+	if len(raw) > 0 {
+		if err := yaml.Unmarshal(raw, &merged); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal networkConfig")
+		}
+	}
+
+	rawInterfaces, _ := merged["interfaces"].([]interface{})
+	rawNames := sets.New[string]()
+	for _, iface := range rawInterfaces {
+		if m, ok := iface.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				rawNames.Insert(name)
+			}
+		}
+	}
+	for _, iface := range generated["interfaces"].([]interface{}) {
+		name := iface.(map[string]interface{})["name"].(string)
+		if rawNames.Has(name) {
+			return nil, errors.Errorf("interface %s is configured both in network and in networkConfig", name)
+		}
+	}
+	merged["interfaces"] = append(rawInterfaces, generated["interfaces"].([]interface{})...)
+
+	if generatedRoutes, ok := generated["routes"].(map[string]interface{}); ok {
+		rawRoutes, _ := merged["routes"].(map[string]interface{})
+		if rawRoutes == nil {
+			rawRoutes = map[string]interface{}{}
+		}
+		rawConfig, _ := rawRoutes["config"].([]interface{})
+		rawRoutes["config"] = append(rawConfig, generatedRoutes["config"].([]interface{})...)
+		merged["routes"] = rawRoutes
+	}
+
+	if dnsResolver, ok := generated["dns-resolver"]; ok {
+		if _, ok := merged["dns-resolver"]; ok {
+			return nil, errors.New("the DNS servers are configured both in network and in networkConfig")
+		}
+		merged["dns-resolver"] = dnsResolver
+	}
+
+	return yaml.Marshal(merged)
+}
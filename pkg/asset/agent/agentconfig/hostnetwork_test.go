@@ -0,0 +1,320 @@
+package agentconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func bondedHost() agent.Host {
+	return agent.Host{
+		Hostname: "control-0",
+		Interfaces: []*aiv1beta1.Interface{
+			{Name: "eno1", MacAddress: "28:d2:44:d2:b2:1a"},
+			{Name: "eno2", MacAddress: "28:d2:44:d2:b2:1b"},
+		},
+		Network: &agent.HostNetwork{
+			Bonds: []agent.Bond{{
+				Name:    "bond0",
+				Mode:    "802.3ad",
+				Members: []string{"eno1", "eno2"},
+				MTU:     9000,
+			}},
+			VLANs: []agent.VLAN{{
+				BaseInterface: "bond0",
+				ID:            100,
+				Addresses:     []string{"192.168.111.80/24", "fd2e:6f44:5dd8::80/64"},
+				Gateway:       "192.168.111.1",
+			}},
+			DNSServers: []string{"192.168.111.1"},
+		},
+	}
+}
+
+func TestExpandHostNetworks(t *testing.T) {
+	cases := []struct {
+		name                  string
+		networkConfig         string
+		expectedNetworkConfig string
+		expectedError         string
+	}{
+		{
+			name: "network only",
+			expectedNetworkConfig: `interfaces:
+- name: eno1
+  type: ethernet
+  state: up
+  mac-address: 28:d2:44:d2:b2:1a
+- name: eno2
+  type: ethernet
+  state: up
+  mac-address: 28:d2:44:d2:b2:1b
+- name: bond0
+  type: bond
+  state: up
+  mtu: 9000
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+  link-aggregation:
+    mode: 802.3ad
+    options:
+      miimon: "100"
+    port:
+    - eno1
+    - eno2
+- name: bond0.100
+  type: vlan
+  state: up
+  ipv4:
+    enabled: true
+    dhcp: false
+    address:
+    - ip: 192.168.111.80
+      prefix-length: 24
+  ipv6:
+    enabled: true
+    dhcp: false
+    address:
+    - ip: fd2e:6f44:5dd8::80
+      prefix-length: 64
+  vlan:
+    base-iface: bond0
+    id: 100
+routes:
+  config:
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.111.1
+    next-hop-interface: bond0.100
+    table-id: 254
+dns-resolver:
+  config:
+    server:
+    - 192.168.111.1
+`,
+		},
+		{
+			name: "merged with networkConfig",
+			networkConfig: `interfaces:
+- name: eno3
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    dhcp: true
+routes:
+  config:
+  - destination: 10.0.0.0/8
+    next-hop-address: 192.168.111.254
+    next-hop-interface: bond0.100
+`,
+			expectedNetworkConfig: `interfaces:
+- name: eno3
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    dhcp: true
+- name: eno1
+  type: ethernet
+  state: up
+  mac-address: 28:d2:44:d2:b2:1a
+- name: eno2
+  type: ethernet
+  state: up
+  mac-address: 28:d2:44:d2:b2:1b
+- name: bond0
+  type: bond
+  state: up
+  mtu: 9000
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+  link-aggregation:
+    mode: 802.3ad
+    options:
+      miimon: "100"
+    port:
+    - eno1
+    - eno2
+- name: bond0.100
+  type: vlan
+  state: up
+  ipv4:
+    enabled: true
+    dhcp: false
+    address:
+    - ip: 192.168.111.80
+      prefix-length: 24
+  ipv6:
+    enabled: true
+    dhcp: false
+    address:
+    - ip: fd2e:6f44:5dd8::80
+      prefix-length: 64
+  vlan:
+    base-iface: bond0
+    id: 100
+routes:
+  config:
+  - destination: 10.0.0.0/8
+    next-hop-address: 192.168.111.254
+    next-hop-interface: bond0.100
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.111.1
+    next-hop-interface: bond0.100
+    table-id: 254
+dns-resolver:
+  config:
+    server:
+    - 192.168.111.1
+`,
+		},
+		{
+			name: "interface in both",
+			networkConfig: `interfaces:
+- name: bond0
+  type: bond
+  state: up
+`,
+			expectedError: "failed to merge the network of host control-0 with its networkConfig: interface bond0 is configured both in network and in networkConfig",
+		},
+		{
+			name: "dns servers in both",
+			networkConfig: `dns-resolver:
+  config:
+    server:
+    - 192.168.111.2
+`,
+			expectedError: "failed to merge the network of host control-0 with its networkConfig: the DNS servers are configured both in network and in networkConfig",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			host := bondedHost()
+			host.NetworkConfig.Raw = []byte(tc.networkConfig)
+			a := &AgentHosts{Hosts: []agent.Host{host}}
+
+			err := a.expandHostNetworks()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.YAMLEq(t, tc.expectedNetworkConfig, string(a.Hosts[0].NetworkConfig.Raw))
+			}
+		})
+	}
+}
+
+func TestValidateHostNetwork(t *testing.T) {
+	cases := []struct {
+		name          string
+		network       func(*agent.HostNetwork)
+		expectedError string
+	}{
+		{
+			name:    "valid",
+			network: func(*agent.HostNetwork) {},
+		},
+		{
+			name: "unsupported bond mode",
+			network: func(n *agent.HostNetwork) {
+				n.Bonds[0].Mode = "lacp"
+			},
+			expectedError: `network.bonds[0].mode: Unsupported value: "lacp": supported values: "balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"`,
+		},
+		{
+			name: "unknown bond member",
+			network: func(n *agent.HostNetwork) {
+				n.Bonds[0].Members = []string{"eno1", "eno3"}
+			},
+			expectedError: `network.bonds[0].members[1]: Invalid value: "eno3": must be the name of one of the interfaces of the host`,
+		},
+		{
+			name: "member of two bonds",
+			network: func(n *agent.HostNetwork) {
+				n.Bonds = append(n.Bonds, agent.Bond{Name: "bond1", Mode: "active-backup", Members: []string{"eno2"}})
+			},
+			expectedError: `network.bonds[1].members[0]: Invalid value: "eno2": an interface can only be the member of one bond`,
+		},
+		{
+			name: "bond named after an interface",
+			network: func(n *agent.HostNetwork) {
+				n.Bonds[0].Name = "eno1"
+				n.VLANs[0].BaseInterface = "eno1"
+			},
+			expectedError: `network.bonds[0].name: Duplicate value: "eno1"`,
+		},
+		{
+			name: "invalid VLAN ID",
+			network: func(n *agent.HostNetwork) {
+				n.VLANs[0].ID = 4095
+			},
+			expectedError: `network.vlans[0].id: Invalid value: 4095: must be between 1 and 4094`,
+		},
+		{
+			name: "unknown VLAN base interface",
+			network: func(n *agent.HostNetwork) {
+				n.VLANs[0].BaseInterface = "bond1"
+			},
+			expectedError: `network.vlans[0].baseInterface: Invalid value: "bond1": must be the name of one of the interfaces or bonds of the host`,
+		},
+		{
+			name: "VLAN name too long",
+			network: func(n *agent.HostNetwork) {
+				n.VLANs[0].Name = "bond0.vlan-internal"
+			},
+			expectedError: `network.vlans[0].name: Invalid value: "bond0.vlan-internal": must be a valid interface name of at most 15 characters`,
+		},
+		{
+			name: "invalid address",
+			network: func(n *agent.HostNetwork) {
+				n.VLANs[0].Addresses = []string{"192.168.111.80"}
+				n.VLANs[0].Gateway = ""
+			},
+			expectedError: `network.vlans[0].addresses[0]: Invalid value: "192.168.111.80": must be an IP address in CIDR notation`,
+		},
+		{
+			name: "gateway of another family",
+			network: func(n *agent.HostNetwork) {
+				n.VLANs[0].Addresses = []string{"fd2e:6f44:5dd8::80/64"}
+			},
+			expectedError: `network.vlans[0].gateway: Invalid value: "192.168.111.1": the interface has no address of the family of the gateway`,
+		},
+		{
+			name: "two default gateways",
+			network: func(n *agent.HostNetwork) {
+				n.Bonds[0].Addresses = []string{"10.0.0.10/24"}
+				n.Bonds[0].Gateway = "10.0.0.1"
+			},
+			expectedError: `network.vlans[0].gateway: Invalid value: "192.168.111.1": only one default gateway per IP family can be set`,
+		},
+		{
+			name: "invalid DNS server",
+			network: func(n *agent.HostNetwork) {
+				n.DNSServers = []string{"dns.example.com"}
+			},
+			expectedError: `network.dnsServers[0]: Invalid value: "dns.example.com": must be an IP address`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			host := bondedHost()
+			tc.network(host.Network)
+
+			err := validateHostNetwork(field.NewPath("network"), &host).ToAggregate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
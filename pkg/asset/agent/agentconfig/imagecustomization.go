@@ -0,0 +1,73 @@
+package agentconfig
+
+import (
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+var systemdUnitTypes = []string{".service", ".socket", ".timer", ".path", ".target", ".mount"}
+
+// ValidateImageCustomization checks the files, systemd units and kernel
+// arguments added to the agent image.
+func ValidateImageCustomization(fldPath *field.Path, customization *agent.ImageCustomization) field.ErrorList {
+	var allErrs field.ErrorList
+	if customization == nil {
+		return allErrs
+	}
+
+	paths := sets.New[string]()
+	for i, file := range customization.Files {
+		filePath := fldPath.Child("files").Index(i)
+		switch {
+		case file.Path == "":
+			allErrs = append(allErrs, field.Required(filePath.Child("path"), "the path of the file is required"))
+		case !path.IsAbs(file.Path) || path.Clean(file.Path) != file.Path:
+			allErrs = append(allErrs, field.Invalid(filePath.Child("path"), file.Path, "must be an absolute and clean path"))
+		case paths.Has(file.Path):
+			allErrs = append(allErrs, field.Duplicate(filePath.Child("path"), file.Path))
+		}
+		paths.Insert(file.Path)
+		if file.Mode != nil && (*file.Mode < 0 || *file.Mode > 0o777) {
+			allErrs = append(allErrs, field.Invalid(filePath.Child("mode"), *file.Mode, "must be a permission mode between 0 and 511 (0777)"))
+		}
+	}
+
+	names := sets.New[string]()
+	for i, unit := range customization.SystemdUnits {
+		unitPath := fldPath.Child("systemdUnits").Index(i)
+		switch {
+		case unit.Name == "":
+			allErrs = append(allErrs, field.Required(unitPath.Child("name"), "the name of the unit is required"))
+		case strings.Contains(unit.Name, "/") || !hasSuffix(unit.Name, systemdUnitTypes):
+			allErrs = append(allErrs, field.Invalid(unitPath.Child("name"), unit.Name, "must be a unit file name ending in "+strings.Join(systemdUnitTypes, ", ")))
+		case names.Has(unit.Name):
+			allErrs = append(allErrs, field.Duplicate(unitPath.Child("name"), unit.Name))
+		}
+		names.Insert(unit.Name)
+		if strings.TrimSpace(unit.Contents) == "" {
+			allErrs = append(allErrs, field.Required(unitPath.Child("contents"), "the content of the unit is required"))
+		}
+	}
+
+	for i, karg := range customization.KernelArguments {
+		if karg == "" || strings.ContainsAny(karg, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kernelArguments").Index(i), karg, "must be a single kernel argument without whitespace"))
+		}
+	}
+
+	return allErrs
+}
+
+func hasSuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
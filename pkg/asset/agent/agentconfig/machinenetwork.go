@@ -0,0 +1,138 @@
+package agentconfig
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	agentAsset "github.com/openshift/installer/pkg/asset/agent"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+type nmStateAddresses struct {
+	Interfaces []struct {
+		Name  string           `json:"name,omitempty"`
+		State string           `json:"state,omitempty"`
+		IPv4  *nmStateIPConfig `json:"ipv4,omitempty"`
+		IPv6  *nmStateIPConfig `json:"ipv6,omitempty"`
+	} `json:"interfaces,omitempty"`
+}
+
+type nmStateIPConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+	Address []struct {
+		IP           string `json:"ip"`
+		PrefixLength int    `json:"prefix-length"`
+	} `json:"address,omitempty"`
+}
+
+// hostNetworks returns the networks of the static addresses of the host, as
+// configured by its NMState. Link-local addresses are ignored.
+func hostNetworks(host agent.Host) ([]*net.IPNet, error) {
+	if len(host.NetworkConfig.Raw) == 0 {
+		return nil, nil
+	}
+	nmState := &nmStateAddresses{}
+	if err := yaml.Unmarshal(host.NetworkConfig.Raw, nmState); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the networkConfig of host %s", host.Hostname)
+	}
+
+	var networks []*net.IPNet
+	seen := map[string]bool{}
+	for _, iface := range nmState.Interfaces {
+		if iface.State == "absent" || iface.State == "down" {
+			continue
+		}
+		for _, ipConfig := range []*nmStateIPConfig{iface.IPv4, iface.IPv6} {
+			if ipConfig == nil || !ipConfig.Enabled {
+				continue
+			}
+			for _, address := range ipConfig.Address {
+				ip, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", address.IP, address.PrefixLength))
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid address of interface %s of host %s", iface.Name, host.Hostname)
+				}
+				if ip.IsLinkLocalUnicast() || ip.IsLoopback() || seen[network.String()] {
+					continue
+				}
+				seen[network.String()] = true
+				networks = append(networks, network)
+			}
+		}
+	}
+	return networks, nil
+}
+
+// deriveMachineNetworks returns the machine networks of the hosts: the
+// networks of their static addresses that all the hosts with static
+// addresses share. It returns no network when no host has static addresses.
+// The networks must be consistent: the same across the hosts, and the
+// rendezvous IP, when set, must be in one of them.
+func deriveMachineNetworks(hosts []agent.Host, rendezvousIP string) ([]ipnet.IPNet, error) {
+	var common []*net.IPNet
+	var firstHost string
+	for _, host := range hosts {
+		networks, err := hostNetworks(host)
+		if err != nil {
+			return nil, err
+		}
+		if len(networks) == 0 {
+			continue
+		}
+		if common == nil {
+			common, firstHost = networks, host.Hostname
+			continue
+		}
+		var shared []*net.IPNet
+		for _, c := range common {
+			for _, n := range networks {
+				switch {
+				case c.String() == n.String():
+					shared = append(shared, c)
+				case c.Contains(n.IP) || n.Contains(c.IP):
+					return nil, errors.Errorf("hosts %s and %s have addresses in the overlapping networks %s and %s, their prefix lengths must match", firstHost, host.Hostname, c, n)
+				}
+			}
+		}
+		common = shared
+		if len(common) == 0 {
+			return nil, errors.Errorf("host %s shares no network with host %s, set networking.machineNetwork in %s", host.Hostname, firstHost, agentAsset.InstallConfigFilename)
+		}
+	}
+	if common == nil {
+		return nil, nil
+	}
+
+	if ip := net.ParseIP(rendezvousIP); ip != nil {
+		found := false
+		for _, network := range common {
+			if network.Contains(ip) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("the rendezvousIP %s is not in the networks %v shared by the hosts", rendezvousIP, common)
+		}
+	}
+
+	// The networks of the IPv4 family come first, as in the install-config
+	// of a dual-stack cluster.
+	sort.SliceStable(common, func(i, j int) bool {
+		iv4, jv4 := common[i].IP.To4() != nil, common[j].IP.To4() != nil
+		if iv4 != jv4 {
+			return iv4
+		}
+		return bytes.Compare(common[i].IP.To16(), common[j].IP.To16()) < 0
+	})
+	machineNetworks := make([]ipnet.IPNet, 0, len(common))
+	for _, network := range common {
+		machineNetworks = append(machineNetworks, ipnet.IPNet{IPNet: *network})
+	}
+	return machineNetworks, nil
+}
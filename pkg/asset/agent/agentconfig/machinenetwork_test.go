@@ -0,0 +1,144 @@
+package agentconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func hostWithNetworkConfig(name, networkConfig string) agent.Host {
+	return agent.Host{
+		Hostname:      name,
+		NetworkConfig: aiv1beta1.NetConfig{Raw: []byte(networkConfig)},
+	}
+}
+
+const (
+	dualStackNetworkConfig = `interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 192.168.111.80
+      prefix-length: 24
+  ipv6:
+    enabled: true
+    address:
+    - ip: fd2e:6f44:5dd8:c956::50
+      prefix-length: 64
+    - ip: fe80::1
+      prefix-length: 64
+- name: eth1
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 172.22.0.10
+      prefix-length: 24
+`
+	ipv4NetworkConfig = `interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 192.168.111.81
+      prefix-length: 24
+  ipv6:
+    enabled: true
+    address:
+    - ip: fd2e:6f44:5dd8:c956::51
+      prefix-length: 64
+`
+	otherNetworkConfig = `interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 10.10.10.10
+      prefix-length: 24
+`
+	overlappingNetworkConfig = `interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: 192.168.111.82
+      prefix-length: 25
+`
+)
+
+func TestDeriveMachineNetworks(t *testing.T) {
+	cases := []struct {
+		name          string
+		hosts         []agent.Host
+		rendezvousIP  string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:  "dhcp hosts",
+			hosts: []agent.Host{{Hostname: "master-0"}, {Hostname: "master-1"}},
+		},
+		{
+			name: "shared networks",
+			hosts: []agent.Host{
+				hostWithNetworkConfig("master-0", dualStackNetworkConfig),
+				hostWithNetworkConfig("master-1", ipv4NetworkConfig),
+				{Hostname: "worker-0"},
+			},
+			rendezvousIP: "192.168.111.80",
+			expected:     []string{"192.168.111.0/24", "fd2e:6f44:5dd8:c956::/64"},
+		},
+		{
+			name: "no shared network",
+			hosts: []agent.Host{
+				hostWithNetworkConfig("master-0", dualStackNetworkConfig),
+				hostWithNetworkConfig("master-1", otherNetworkConfig),
+			},
+			expectedError: "host master-1 shares no network with host master-0, set networking.machineNetwork in install-config.yaml",
+		},
+		{
+			name: "different prefix lengths",
+			hosts: []agent.Host{
+				hostWithNetworkConfig("master-0", ipv4NetworkConfig),
+				hostWithNetworkConfig("master-1", overlappingNetworkConfig),
+			},
+			expectedError: "hosts master-0 and master-1 have addresses in the overlapping networks 192.168.111.0/24 and 192.168.111.0/25, their prefix lengths must match",
+		},
+		{
+			name: "rendezvous IP outside of the networks",
+			hosts: []agent.Host{
+				hostWithNetworkConfig("master-0", ipv4NetworkConfig),
+			},
+			rendezvousIP:  "10.10.10.10",
+			expectedError: "the rendezvousIP 10.10.10.10 is not in the networks [192.168.111.0/24 fd2e:6f44:5dd8:c956::/64] shared by the hosts",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			networks, err := deriveMachineNetworks(tc.hosts, tc.rendezvousIP)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			var actual []string
+			for _, network := range networks {
+				actual = append(actual, network.String())
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
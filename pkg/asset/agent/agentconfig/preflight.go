@@ -0,0 +1,144 @@
+package agentconfig
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	agentAsset "github.com/openshift/installer/pkg/asset/agent"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+// multiArchitecture is the architecture of a multi-architecture release
+// image.
+const multiArchitecture = "multi"
+
+var supportedArchitectures = []string{types.ArchitectureAMD64, types.ArchitectureARM64, types.ArchitecturePPC64LE, types.ArchitectureS390X}
+
+// defaultMinimalHardware are the minimal hardware requirements enforced by
+// the assisted service when they are not overridden.
+var defaultMinimalHardware = agent.MinimalHardwareRequirements{
+	Master: &agent.Hardware{CPUCores: 4, MemoryMiB: 16384, DiskSizeGB: 100},
+	Worker: &agent.Hardware{CPUCores: 2, MemoryMiB: 8192, DiskSizeGB: 100},
+	SNO:    &agent.Hardware{CPUCores: 8, MemoryMiB: 16384, DiskSizeGB: 100},
+}
+
+// MinimalHardwareRequirements returns the minimal hardware requirements of
+// the hosts, where the requirements set in overrides replace the defaults.
+func MinimalHardwareRequirements(overrides *agent.MinimalHardwareRequirements) agent.MinimalHardwareRequirements {
+	if overrides == nil {
+		overrides = &agent.MinimalHardwareRequirements{}
+	}
+	return agent.MinimalHardwareRequirements{
+		Master: mergeHardware(defaultMinimalHardware.Master, overrides.Master),
+		Worker: mergeHardware(defaultMinimalHardware.Worker, overrides.Worker),
+		SNO:    mergeHardware(defaultMinimalHardware.SNO, overrides.SNO),
+	}
+}
+
+func mergeHardware(defaults, override *agent.Hardware) *agent.Hardware {
+	merged := *defaults
+	if override == nil {
+		return &merged
+	}
+	if override.CPUCores != 0 {
+		merged.CPUCores = override.CPUCores
+	}
+	if override.MemoryMiB != 0 {
+		merged.MemoryMiB = override.MemoryMiB
+	}
+	if override.DiskSizeGB != 0 {
+		merged.DiskSizeGB = override.DiskSizeGB
+	}
+	return &merged
+}
+
+func validateHardware(fldPath *field.Path, hardware *agent.Hardware) field.ErrorList {
+	var allErrs field.ErrorList
+	if hardware == nil {
+		return allErrs
+	}
+	if hardware.CPUCores < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpuCores"), hardware.CPUCores, "must be positive"))
+	}
+	if hardware.MemoryMiB < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), hardware.MemoryMiB, "must be positive"))
+	}
+	if hardware.DiskSizeGB < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), hardware.DiskSizeGB, "must be positive"))
+	}
+	return allErrs
+}
+
+// validateHostPreflight checks the declared CPU architecture and hardware of
+// the hosts against the release image architecture, the architectures of the
+// machine pools and the minimal hardware requirements of the topology of the
+// cluster, so that a host which cannot be installed is refused before the
+// image is generated rather than once it booted.
+func validateHostPreflight(hosts []agent.Host, installConfig *types.InstallConfig, releaseArch string, overrides *agent.MinimalHardwareRequirements) field.ErrorList {
+	var allErrs field.ErrorList
+
+	numMasters, numWorkers := agentAsset.GetReplicaCount(installConfig)
+	sno := numMasters == 1 && numWorkers == 0
+	requirements := MinimalHardwareRequirements(overrides)
+
+	var controlPlaneArch string
+	if installConfig.ControlPlane != nil {
+		controlPlaneArch = string(installConfig.ControlPlane.Architecture)
+	}
+	computeArch := controlPlaneArch
+	if len(installConfig.Compute) > 0 {
+		computeArch = string(installConfig.Compute[0].Architecture)
+	}
+
+	for i, host := range hosts {
+		hostPath := field.NewPath("Hosts").Index(i)
+
+		if host.CPUArchitecture != "" {
+			archPath := hostPath.Child("cpuArchitecture")
+			switch {
+			case !slices.Contains(supportedArchitectures, host.CPUArchitecture):
+				allErrs = append(allErrs, field.NotSupported(archPath, host.CPUArchitecture, supportedArchitectures))
+			case releaseArch != "" && releaseArch != multiArchitecture && host.CPUArchitecture != releaseArch:
+				allErrs = append(allErrs, field.Invalid(archPath, host.CPUArchitecture, fmt.Sprintf("does not match the architecture %s of the release image", releaseArch)))
+			case host.Role == masterRole && host.CPUArchitecture != controlPlaneArch:
+				allErrs = append(allErrs, field.Invalid(archPath, host.CPUArchitecture, fmt.Sprintf("a control plane host must have the architecture %s of controlPlane", controlPlaneArch)))
+			case host.Role == workerRole && host.CPUArchitecture != computeArch:
+				allErrs = append(allErrs, field.Invalid(archPath, host.CPUArchitecture, fmt.Sprintf("a compute host must have the architecture %s of compute", computeArch)))
+			case host.Role == "" && host.CPUArchitecture != controlPlaneArch && host.CPUArchitecture != computeArch:
+				allErrs = append(allErrs, field.Invalid(archPath, host.CPUArchitecture, fmt.Sprintf("must be the architecture %s of controlPlane or %s of compute", controlPlaneArch, computeArch)))
+			}
+		}
+
+		if host.Hardware != nil {
+			// A host without role may become a compute host, so it is
+			// held to the lowest requirements.
+			required, description := requirements.Worker, "compute"
+			switch {
+			case sno:
+				required, description = requirements.SNO, "single node cluster"
+			case host.Role == masterRole:
+				required, description = requirements.Master, "control plane"
+			}
+			allErrs = append(allErrs, validateHostHardware(hostPath.Child("hardware"), host.Hardware, required, description)...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateHostHardware(fldPath *field.Path, hardware, required *agent.Hardware, description string) field.ErrorList {
+	var allErrs field.ErrorList
+	if hardware.CPUCores != 0 && hardware.CPUCores < required.CPUCores {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpuCores"), hardware.CPUCores, fmt.Sprintf("a %s host requires at least %d CPU cores", description, required.CPUCores)))
+	}
+	if hardware.MemoryMiB != 0 && hardware.MemoryMiB < required.MemoryMiB {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), hardware.MemoryMiB, fmt.Sprintf("a %s host requires at least %d MiB of memory", description, required.MemoryMiB)))
+	}
+	if hardware.DiskSizeGB != 0 && hardware.DiskSizeGB < required.DiskSizeGB {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), hardware.DiskSizeGB, fmt.Sprintf("a %s host requires an installation disk of at least %d GB", description, required.DiskSizeGB)))
+	}
+	return allErrs
+}
@@ -0,0 +1,128 @@
+package agentconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func preflightInstallConfig(masters, workers int64) *types.InstallConfig {
+	return &types.InstallConfig{
+		ControlPlane: &types.MachinePool{
+			Name:         "master",
+			Replicas:     ptr.To(masters),
+			Architecture: types.ArchitectureAMD64,
+		},
+		Compute: []types.MachinePool{{
+			Name:         "worker",
+			Replicas:     ptr.To(workers),
+			Architecture: types.ArchitectureARM64,
+		}},
+	}
+}
+
+func TestValidateHostPreflight(t *testing.T) {
+	cases := []struct {
+		name          string
+		hosts         []agent.Host
+		masters       int64
+		workers       int64
+		releaseArch   string
+		overrides     *agent.MinimalHardwareRequirements
+		expectedError string
+	}{
+		{
+			name: "valid",
+			hosts: []agent.Host{
+				{Role: "master", CPUArchitecture: "amd64", Hardware: &agent.Hardware{CPUCores: 4, MemoryMiB: 16384, DiskSizeGB: 120}},
+				{Role: "worker", CPUArchitecture: "arm64", Hardware: &agent.Hardware{CPUCores: 2, MemoryMiB: 8192}},
+				{CPUArchitecture: "arm64"},
+			},
+			masters:     3,
+			workers:     2,
+			releaseArch: "multi",
+		},
+		{
+			name: "unsupported architecture",
+			hosts: []agent.Host{
+				{Role: "master", CPUArchitecture: "x86_64"},
+			},
+			masters:       3,
+			expectedError: `Hosts[0].cpuArchitecture: Unsupported value: "x86_64": supported values: "amd64", "arm64", "ppc64le", "s390x"`,
+		},
+		{
+			name: "release image architecture mismatch",
+			hosts: []agent.Host{
+				{Role: "worker", CPUArchitecture: "arm64"},
+			},
+			masters:       3,
+			workers:       2,
+			releaseArch:   "amd64",
+			expectedError: `Hosts[0].cpuArchitecture: Invalid value: "arm64": does not match the architecture amd64 of the release image`,
+		},
+		{
+			name: "control plane architecture mismatch",
+			hosts: []agent.Host{
+				{Role: "master", CPUArchitecture: "arm64"},
+			},
+			masters:       3,
+			workers:       2,
+			releaseArch:   "multi",
+			expectedError: `Hosts[0].cpuArchitecture: Invalid value: "arm64": a control plane host must have the architecture amd64 of controlPlane`,
+		},
+		{
+			name: "compute architecture mismatch",
+			hosts: []agent.Host{
+				{Role: "worker", CPUArchitecture: "amd64"},
+			},
+			masters:       3,
+			workers:       2,
+			expectedError: `Hosts[0].cpuArchitecture: Invalid value: "amd64": a compute host must have the architecture arm64 of compute`,
+		},
+		{
+			name: "undersized control plane host",
+			hosts: []agent.Host{
+				{Role: "master", Hardware: &agent.Hardware{CPUCores: 2, MemoryMiB: 8192, DiskSizeGB: 120}},
+			},
+			masters:       3,
+			workers:       2,
+			expectedError: `[Hosts[0].hardware.cpuCores: Invalid value: 2: a control plane host requires at least 4 CPU cores, Hosts[0].hardware.memoryMiB: Invalid value: 8192: a control plane host requires at least 16384 MiB of memory]`,
+		},
+		{
+			name: "undersized control plane host with overrides",
+			hosts: []agent.Host{
+				{Role: "master", Hardware: &agent.Hardware{CPUCores: 2, MemoryMiB: 8192, DiskSizeGB: 120}},
+			},
+			masters: 3,
+			workers: 2,
+			overrides: &agent.MinimalHardwareRequirements{
+				Master: &agent.Hardware{CPUCores: 2, MemoryMiB: 8192},
+			},
+		},
+		{
+			name: "undersized single node",
+			hosts: []agent.Host{
+				{Hardware: &agent.Hardware{CPUCores: 4, DiskSizeGB: 60}},
+			},
+			masters: 1,
+			overrides: &agent.MinimalHardwareRequirements{
+				SNO: &agent.Hardware{DiskSizeGB: 50},
+			},
+			expectedError: `Hosts[0].hardware.cpuCores: Invalid value: 4: a single node cluster host requires at least 8 CPU cores`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHostPreflight(tc.hosts, preflightInstallConfig(tc.masters, tc.workers), tc.releaseArch, tc.overrides).ToAggregate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
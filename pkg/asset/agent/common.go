@@ -4,6 +4,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	"github.com/openshift/installer/pkg/types/external"
@@ -61,18 +62,7 @@ func IsSupportedPlatform(platform hiveext.PlatformType) bool {
 
 // DetermineReleaseImageArch returns the arch of the release image.
 func DetermineReleaseImageArch(pullSecret, pullSpec string) (string, error) {
-	templateFilter := "-o=go-template={{if and .metadata.metadata (index . \"metadata\" \"metadata\" \"release.openshift.io/architecture\")}}{{index . \"metadata\" \"metadata\" \"release.openshift.io/architecture\"}}{{else}}{{.config.architecture}}{{end}}"
-
-	var getReleaseArch = []string{
-		"oc",
-		"adm",
-		"release",
-		"info",
-		pullSpec,
-		templateFilter,
-	}
-
-	releaseArch, err := ExecuteOC(pullSecret, getReleaseArch)
+	releaseArch, err := releaseimage.Architecture(pullSecret, pullSpec)
 	if err != nil {
 		logrus.Errorf("Release Image arch could not be found: %s", err)
 		return "", err
@@ -2,6 +2,7 @@ package gencrypto
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -24,7 +25,7 @@ func (a *AuthConfig) Dependencies() []asset.Asset {
 }
 
 // Generate generates the auth config for agent installer APIs.
-func (a *AuthConfig) Generate(dependencies asset.Parents) error {
+func (a *AuthConfig) Generate(_ context.Context, dependencies asset.Parents) error {
 	PublicKey, PrivateKey, err := keyPairPEM()
 	if err != nil {
 		return err
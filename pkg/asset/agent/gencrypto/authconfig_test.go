@@ -1,6 +1,7 @@
 package gencrypto
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,7 +18,7 @@ func TestAuthConfig_Generate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			authConfigAsset := &AuthConfig{}
-			err := authConfigAsset.Generate(nil)
+			err := authConfigAsset.Generate(context.Background(), nil)
 
 			assert.NoError(t, err)
 
@@ -1,18 +1,22 @@
 package image
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	"github.com/openshift/assisted-image-service/pkg/isoeditor"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/installer/pkg/asset"
 	config "github.com/openshift/installer/pkg/asset/agent/agentconfig"
 	"github.com/openshift/installer/pkg/asset/agent/manifests"
 	"github.com/openshift/installer/pkg/asset/agent/mirror"
+	"github.com/openshift/installer/pkg/types/agent"
 )
 
 const (
@@ -32,6 +36,9 @@ type AgentArtifacts struct {
 	Kargs                []byte
 	ISOPath              string
 	BootArtifactsBaseURL string
+	// AppliancePath is the directory holding the content of the data ISO,
+	// when the release payload is embedded into a data ISO.
+	AppliancePath string
 }
 
 // Dependencies returns the assets on which the AgentArtifacts asset depends.
@@ -48,7 +55,7 @@ func (a *AgentArtifacts) Dependencies() []asset.Asset {
 }
 
 // Generate generates the configurations for the agent ISO image and PXE assets.
-func (a *AgentArtifacts) Generate(dependencies asset.Parents) error {
+func (a *AgentArtifacts) Generate(ctx context.Context, dependencies asset.Parents) error {
 	ignition := &Ignition{}
 	kargs := &Kargs{}
 	baseIso := &BaseIso{}
@@ -86,9 +93,29 @@ func (a *AgentArtifacts) Generate(dependencies asset.Parents) error {
 		return err
 	}
 
+	if agentconfig.Config != nil && agentconfig.Config.Appliance != nil {
+		if err := a.embedAppliancePayload(ctx, agentconfig.Config.Appliance, agentManifests); err != nil {
+			return errors.Wrap(err, "failed to embed the release payload")
+		}
+	}
+
 	return nil
 }
 
+// embedAppliancePayload writes the release payload into the content of the
+// agent ISO, or into the content of the data ISO.
+func (a *AgentArtifacts) embedAppliancePayload(ctx context.Context, appliance *agent.Appliance, agentManifests *manifests.AgentManifests) error {
+	dir := a.TmpPath
+	if appliance.DataISO {
+		var err error
+		if a.AppliancePath, err = os.MkdirTemp("", "agentdata"); err != nil {
+			return err
+		}
+		dir = a.AppliancePath
+	}
+	return mirrorAppliancePayload(ctx, dir, agentManifests.ClusterImageSet.Spec.ReleaseImage, agentManifests.GetPullSecretData(), a.CPUArch, appliance)
+}
+
 func (a *AgentArtifacts) fetchAgentTuiFiles(releaseImage string, pullSecret string, mirrorConfig []mirror.RegistriesConfig) ([]string, error) {
 	release := NewRelease(
 		Config{MaxTries: OcDefaultTries, RetryDelay: OcDefaultRetryDelay},
@@ -1,6 +1,7 @@
 package image
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -36,6 +37,7 @@ type AgentImage struct {
 	bootArtifactsBaseURL string
 	platform             hiveext.PlatformType
 	isoFilename          string
+	appliancePath        string
 }
 
 var _ asset.WritableAsset = (*AgentImage)(nil)
@@ -52,7 +54,7 @@ func (a *AgentImage) Dependencies() []asset.Asset {
 }
 
 // Generate generates the image file for to ISO asset.
-func (a *AgentImage) Generate(dependencies asset.Parents) error {
+func (a *AgentImage) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	clusterInfo := &joiner.ClusterInfo{}
 	agentArtifacts := &AgentArtifacts{}
@@ -78,6 +80,7 @@ func (a *AgentImage) Generate(dependencies asset.Parents) error {
 	a.tmpPath = agentArtifacts.TmpPath
 	a.isoPath = agentArtifacts.ISOPath
 	a.bootArtifactsBaseURL = agentArtifacts.BootArtifactsBaseURL
+	a.appliancePath = agentArtifacts.AppliancePath
 
 	volumeID, err := isoeditor.VolumeIdentifier(a.isoPath)
 	if err != nil {
@@ -226,6 +229,9 @@ func (a *AgentImage) normalizeFilesExtension() error {
 // PersistToFile writes the iso image in the assets folder
 func (a *AgentImage) PersistToFile(directory string) error {
 	defer os.RemoveAll(a.tmpPath)
+	if a.appliancePath != "" {
+		defer os.RemoveAll(a.appliancePath)
+	}
 
 	// If the volumeId or tmpPath are not set then it means that either one of the AgentImage
 	// dependencies or the asset itself failed for some reason
@@ -272,6 +278,17 @@ func (a *AgentImage) PersistToFile(directory string) error {
 		logrus.Infof("Generated ISO at %s", agentIsoFile)
 	}
 
+	// The release payload is embedded into a data ISO, attached to the
+	// hosts along with the agent ISO.
+	if a.appliancePath != "" {
+		dataIsoFile := filepath.Join(directory, fmt.Sprintf(agentDataISOFilename, a.cpuArch))
+		os.Remove(dataIsoFile)
+		if err := isoeditor.Create(dataIsoFile, a.appliancePath, agentDataVolumeID); err != nil {
+			return err
+		}
+		logrus.Infof("Generated data ISO at %s", dataIsoFile)
+	}
+
 	err = os.WriteFile(filepath.Join(directory, "rendezvousIP"), []byte(a.rendezvousIP), 0o644) //nolint:gosec // no sensitive info
 	if err != nil {
 		return err
@@ -0,0 +1,222 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	dockerref "github.com/containers/image/docker/reference"
+	"github.com/containers/image/pkg/sysregistriesv2"
+	"github.com/coreos/stream-metadata-go/arch"
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	agentcommon "github.com/openshift/installer/pkg/asset/agent"
+	"github.com/openshift/installer/pkg/asset/machines/machineconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+const (
+	agentDataISOFilename = "agent.%s.data.iso"
+	agentDataVolumeID    = "agentdata"
+	registryBinaryPath   = "/bin/registry"
+)
+
+// applianceImages returns the images of the release payload embedded into
+// the agent image, by pull spec: the release image and its selected
+// component images.
+func applianceImages(info *releaseimage.Info, appliance *agent.Appliance) ([]string, error) {
+	images := sets.New[string](info.PullSpec)
+	if len(appliance.Images) == 0 {
+		for _, pullSpec := range info.Images {
+			images.Insert(pullSpec)
+		}
+		return sets.List(images), nil
+	}
+	for _, name := range appliance.Images {
+		pullSpec, ok := info.Images[name]
+		if !ok {
+			return nil, errors.Errorf("image %s is not in the release payload %s", name, info.PullSpec)
+		}
+		images.Insert(pullSpec)
+	}
+	return sets.List(images), nil
+}
+
+// applianceMapping returns the oc image mirror mapping of the images to the
+// registry at the given address, keeping their repository, and the
+// repositories mirrored.
+func applianceMapping(images []string, registry string) (string, []string, error) {
+	var mapping strings.Builder
+	repositories := sets.New[string]()
+	for _, image := range images {
+		ref, err := dockerref.ParseNormalizedNamed(image)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "invalid image %s", image)
+		}
+		dest := registry + "/" + ref.Name()
+		if tagged, ok := ref.(dockerref.NamedTagged); ok {
+			dest += ":" + tagged.Tag()
+		}
+		fmt.Fprintf(&mapping, "%s=%s\n", image, dest)
+		repositories.Insert(ref.Name())
+	}
+	return mapping.String(), sets.List(repositories), nil
+}
+
+// applianceRegistriesConf returns the registries.conf drop-in mirroring the
+// repositories to the registry serving the payload on the hosts.
+func applianceRegistriesConf(repositories []string) ([]byte, error) {
+	registries := &sysregistriesv2.V2RegistriesConf{}
+	for _, repository := range repositories {
+		registry := sysregistriesv2.Registry{}
+		registry.Endpoint.Location = repository
+		registry.Mirrors = []sysregistriesv2.Endpoint{{
+			Location: fmt.Sprintf("localhost:%d/%s", machineconfig.ApplianceRegistryPort, repository),
+			Insecure: true,
+		}}
+		registries.Registries = append(registries.Registries, registry)
+	}
+	return toml.Marshal(registries)
+}
+
+// mirrorAppliancePayload writes the release payload, or its selected
+// component images, into dir, in the storage of the registry serving it on
+// the hosts, along with the registry binary and the registries.conf drop-in
+// mirroring the release repositories to it. The images are pushed by oc
+// into a registry run from the registry image on the local host.
+func mirrorAppliancePayload(ctx context.Context, dir string, releaseImage string, pullSecret string, cpuArch string, appliance *agent.Appliance) error {
+	if runtime.GOOS != "linux" {
+		return errors.New("the release payload can only be embedded into the agent image on Linux")
+	}
+	registryImage := appliance.RegistryImage
+	if registryImage == "" {
+		registryImage = agent.DefaultApplianceRegistryImage
+	}
+
+	dataDir := filepath.Join(dir, machineconfig.ApplianceDataDir)
+	if err := os.MkdirAll(filepath.Join(dataDir, machineconfig.ApplianceRegistryStorage), 0o755); err != nil {
+		return err
+	}
+	if err := extractRegistryBinary(registryImage, pullSecret, arch.GoArch(cpuArch), dataDir); err != nil {
+		return err
+	}
+
+	info, err := releaseimage.Inspect(pullSecret, releaseImage)
+	if err != nil {
+		return err
+	}
+	images, err := applianceImages(info, appliance)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "appliance")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// The payload is pushed into a registry run on the local host, with the
+	// same storage as the registry serving it on the hosts.
+	if err := extractRegistryBinary(registryImage, pullSecret, runtime.GOARCH, tmpDir); err != nil {
+		return err
+	}
+	address, stop, err := startLocalRegistry(ctx, tmpDir, filepath.Join(dataDir, machineconfig.ApplianceRegistryStorage))
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	mapping, repositories, err := applianceMapping(images, address)
+	if err != nil {
+		return err
+	}
+	mappingFile := filepath.Join(tmpDir, "mapping.txt")
+	if err := os.WriteFile(mappingFile, []byte(mapping), 0o600); err != nil {
+		return err
+	}
+	logrus.Infof("Embedding %d images of the release payload %s into the agent image", len(images), info.PullSpec)
+	if _, err := agentcommon.ExecuteOC(pullSecret, []string{
+		"oc", "image", "mirror",
+		"--filename=" + mappingFile,
+		"--keep-manifest-list=true",
+		"--insecure=true",
+	}); err != nil {
+		return errors.Wrap(err, "failed to mirror the release payload")
+	}
+
+	registriesConf, err := applianceRegistriesConf(repositories)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dataDir, machineconfig.ApplianceRegistriesConf), registriesConf, 0o644) //nolint:gosec // no sensitive info
+}
+
+// extractRegistryBinary extracts the registry binary of the given
+// architecture from the registry image into dir.
+func extractRegistryBinary(registryImage, pullSecret, goArch, dir string) error {
+	_, err := agentcommon.ExecuteOC(pullSecret, []string{
+		"oc", "image", "extract",
+		"--path=" + registryBinaryPath + ":" + dir,
+		"--filter-by-os=linux/" + goArch,
+		"--confirm",
+		registryImage,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to extract the registry binary from %s", registryImage)
+	}
+	return os.Chmod(filepath.Join(dir, machineconfig.ApplianceRegistryBinary), 0o755)
+}
+
+// startLocalRegistry runs the registry binary of dir on a free port of the
+// loopback interface, with the given storage, until the returned stop
+// function is called.
+func startLocalRegistry(ctx context.Context, dir, storage string) (string, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	config := fmt.Sprintf("version: 0.1\nstorage:\n  filesystem:\n    rootdirectory: %s\nhttp:\n  addr: %s\n", storage, address)
+	configFile := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		return "", nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, filepath.Join(dir, machineconfig.ApplianceRegistryBinary), "serve", configFile) // #nosec G204
+	if err := cmd.Start(); err != nil {
+		return "", nil, errors.Wrap(err, "failed to start the local registry")
+	}
+	stop := func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, time.Second, time.Minute, true, func(ctx context.Context) (bool, error) {
+		resp, err := http.Get(fmt.Sprintf("http://%s/v2/", address)) //nolint:noctx
+		if err != nil {
+			return false, nil
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK, nil
+	})
+	if err != nil {
+		stop()
+		return "", nil, errors.Wrap(err, "the local registry did not start")
+	}
+	return address, stop, nil
+}
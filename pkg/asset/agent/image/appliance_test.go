@@ -0,0 +1,70 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func TestApplianceMapping(t *testing.T) {
+	info := &releaseimage.Info{
+		PullSpec: "quay.io/openshift-release-dev/ocp-release:4.17.0-x86_64",
+		Images: map[string]string{
+			"etcd":                    "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			"machine-config-operator": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		},
+	}
+
+	cases := []struct {
+		name                 string
+		appliance            *agent.Appliance
+		expectedMapping      string
+		expectedRepositories []string
+		expectedError        string
+	}{
+		{
+			name:      "whole-payload",
+			appliance: &agent.Appliance{},
+			expectedMapping: `quay.io/openshift-release-dev/ocp-release:4.17.0-x86_64=127.0.0.1:5000/quay.io/openshift-release-dev/ocp-release:4.17.0-x86_64
+quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:1111111111111111111111111111111111111111111111111111111111111111=127.0.0.1:5000/quay.io/openshift-release-dev/ocp-v4.0-art-dev
+quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:2222222222222222222222222222222222222222222222222222222222222222=127.0.0.1:5000/quay.io/openshift-release-dev/ocp-v4.0-art-dev
+`,
+			expectedRepositories: []string{"quay.io/openshift-release-dev/ocp-release", "quay.io/openshift-release-dev/ocp-v4.0-art-dev"},
+		},
+		{
+			name:      "subset",
+			appliance: &agent.Appliance{Images: []string{"etcd"}},
+			expectedMapping: `quay.io/openshift-release-dev/ocp-release:4.17.0-x86_64=127.0.0.1:5000/quay.io/openshift-release-dev/ocp-release:4.17.0-x86_64
+quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:1111111111111111111111111111111111111111111111111111111111111111=127.0.0.1:5000/quay.io/openshift-release-dev/ocp-v4.0-art-dev
+`,
+			expectedRepositories: []string{"quay.io/openshift-release-dev/ocp-release", "quay.io/openshift-release-dev/ocp-v4.0-art-dev"},
+		},
+		{
+			name:          "unknown-image",
+			appliance:     &agent.Appliance{Images: []string{"unknown"}},
+			expectedError: "image unknown is not in the release payload quay.io/openshift-release-dev/ocp-release:4.17.0-x86_64",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			images, err := applianceImages(info, tc.appliance)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			mapping, repositories, err := applianceMapping(images, "127.0.0.1:5000")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMapping, mapping)
+			assert.Equal(t, tc.expectedRepositories, repositories)
+
+			registriesConf, err := applianceRegistriesConf(repositories)
+			assert.NoError(t, err)
+			assert.Contains(t, string(registriesConf), `location = "localhost:22625/quay.io/openshift-release-dev/ocp-release"`)
+		})
+	}
+}
@@ -142,7 +142,7 @@ func (i *BaseIso) checkReleasePayloadBaseISOVersion(r Release, archName string)
 }
 
 // Generate the baseIso
-func (i *BaseIso) Generate(dependencies asset.Parents) error {
+func (i *BaseIso) Generate(_ context.Context, dependencies asset.Parents) error {
 	var err error
 	var baseIsoFileName string
 
@@ -0,0 +1,134 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+// CustomizationFilename is the file holding the image customization applied
+// in addition to the imageCustomization of the agent-config.
+const CustomizationFilename = "agent-image-customization.yaml"
+
+// Customization reads the agent-image-customization.yaml file, which holds
+// the same fields as the imageCustomization of the agent-config.
+type Customization struct {
+	File   *asset.File
+	Config *agent.ImageCustomization
+}
+
+var _ asset.WritableAsset = (*Customization)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*Customization) Name() string {
+	return "Agent Image Customization"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*Customization) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate does nothing, since the customization is optional.
+func (*Customization) Generate(_ context.Context, _ asset.Parents) error {
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (c *Customization) Files() []*asset.File {
+	if c.File != nil {
+		return []*asset.File{c.File}
+	}
+	return []*asset.File{}
+}
+
+// Load returns the image customization from the disk.
+func (c *Customization) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(CustomizationFilename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, fmt.Sprintf("failed to load %s file", CustomizationFilename))
+	}
+
+	config := &agent.ImageCustomization{}
+	if err := yaml.UnmarshalStrict(file.Data, config); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal %s", CustomizationFilename)
+	}
+	if err := agentconfig.ValidateImageCustomization(nil, config).ToAggregate(); err != nil {
+		return false, errors.Wrapf(err, "invalid %s", CustomizationFilename)
+	}
+
+	c.File, c.Config = file, config
+	return true, nil
+}
+
+// imageCustomizations returns the image customizations of the agent-config
+// and of the customization file, in the order they are applied.
+func imageCustomizations(agentConfig *agentconfig.AgentConfig, customization *Customization) []*agent.ImageCustomization {
+	var customizations []*agent.ImageCustomization
+	if agentConfig.Config != nil && agentConfig.Config.ImageCustomization != nil {
+		customizations = append(customizations, agentConfig.Config.ImageCustomization)
+	}
+	if customization.Config != nil {
+		customizations = append(customizations, customization.Config)
+	}
+	return customizations
+}
+
+// addImageCustomizations adds the files and systemd units of the
+// customizations to the ignition. Replacing a file or unit generated by the
+// installer, or added by another customization, is an error since the image
+// would not work as expected.
+func addImageCustomizations(config *igntypes.Config, customizations []*agent.ImageCustomization) error {
+	paths := map[string]bool{}
+	for _, file := range config.Storage.Files {
+		paths[file.Path] = true
+	}
+	units := map[string]bool{}
+	for _, unit := range config.Systemd.Units {
+		units[unit.Name] = true
+	}
+
+	for _, customization := range customizations {
+		for _, file := range customization.Files {
+			if paths[file.Path] {
+				return errors.Errorf("the image customization cannot replace the file %s", file.Path)
+			}
+			paths[file.Path] = true
+			mode := 0644
+			if file.Mode != nil {
+				mode = *file.Mode
+			}
+			config.Storage.Files = append(config.Storage.Files, ignition.FileFromString(file.Path, "root", mode, file.Contents))
+		}
+		for _, unit := range customization.SystemdUnits {
+			if units[unit.Name] {
+				return errors.Errorf("the image customization cannot replace the systemd unit %s", unit.Name)
+			}
+			units[unit.Name] = true
+			enabled := true
+			if unit.Enabled != nil {
+				enabled = *unit.Enabled
+			}
+			config.Systemd.Units = append(config.Systemd.Units, igntypes.Unit{
+				Name:     unit.Name,
+				Enabled:  ptr.To(enabled),
+				Contents: ptr.To(unit.Contents),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package image
+
+import (
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func TestAddImageCustomizations(t *testing.T) {
+	agentConfig := &agentconfig.AgentConfig{
+		Config: &agent.Config{
+			ImageCustomization: &agent.ImageCustomization{
+				Files: []agent.ImageFile{
+					{Path: "/etc/vpn/client.conf", Mode: ptr.To(0600), Contents: "remote vpn.example.com"},
+				},
+				SystemdUnits: []agent.SystemdUnit{
+					{Name: "vpn-client.service", Contents: "[Service]\nExecStart=/usr/bin/vpn-client\n"},
+				},
+				KernelArguments: []string{"console=ttyS1,115200n8"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name          string
+		customization *Customization
+		expectedFiles []string
+		expectedUnits map[string]bool
+		expectedError string
+	}{
+		{
+			name:          "agent-config only",
+			customization: &Customization{},
+			expectedFiles: []string{"/etc/assisted/rendezvous-host.env", "/etc/vpn/client.conf"},
+			expectedUnits: map[string]bool{"agent.service": true, "vpn-client.service": true},
+		},
+		{
+			name: "agent-config and customization file",
+			customization: &Customization{
+				Config: &agent.ImageCustomization{
+					Files: []agent.ImageFile{
+						{Path: "/etc/node-exporter.env", Contents: "PORT=9100"},
+					},
+					SystemdUnits: []agent.SystemdUnit{
+						{Name: "node-exporter.service", Contents: "[Service]\n", Enabled: ptr.To(false)},
+					},
+				},
+			},
+			expectedFiles: []string{"/etc/assisted/rendezvous-host.env", "/etc/vpn/client.conf", "/etc/node-exporter.env"},
+			expectedUnits: map[string]bool{"agent.service": true, "vpn-client.service": true, "node-exporter.service": false},
+		},
+		{
+			name: "replaced installer file",
+			customization: &Customization{
+				Config: &agent.ImageCustomization{
+					Files: []agent.ImageFile{
+						{Path: "/etc/assisted/rendezvous-host.env", Contents: "NODE_ZERO_IP=192.168.111.81"},
+					},
+				},
+			},
+			expectedError: "the image customization cannot replace the file /etc/assisted/rendezvous-host.env",
+		},
+		{
+			name: "unit in both customizations",
+			customization: &Customization{
+				Config: &agent.ImageCustomization{
+					SystemdUnits: []agent.SystemdUnit{
+						{Name: "vpn-client.service", Contents: "[Service]\n"},
+					},
+				},
+			},
+			expectedError: "the image customization cannot replace the systemd unit vpn-client.service",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &igntypes.Config{}
+			config.Storage.Files = append(config.Storage.Files, ignition.FileFromString("/etc/assisted/rendezvous-host.env", "root", 0644, "NODE_ZERO_IP=192.168.111.80"))
+			config.Systemd.Units = append(config.Systemd.Units, igntypes.Unit{Name: "agent.service", Enabled: ptr.To(true)})
+
+			err := addImageCustomizations(config, imageCustomizations(agentConfig, tc.customization))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var files []string
+			for _, file := range config.Storage.Files {
+				files = append(files, file.Path)
+				if file.Path == "/etc/vpn/client.conf" {
+					assert.Equal(t, 0600, *file.Mode)
+				}
+			}
+			assert.Equal(t, tc.expectedFiles, files)
+			units := map[string]bool{}
+			for _, unit := range config.Systemd.Units {
+				units[unit.Name] = *unit.Enabled
+			}
+			assert.Equal(t, tc.expectedUnits, units)
+		})
+	}
+}
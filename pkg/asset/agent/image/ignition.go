@@ -31,6 +31,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/agent/workflow"
 	"github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
+	"github.com/openshift/installer/pkg/asset/machines/machineconfig"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
@@ -40,6 +41,7 @@ import (
 
 const addNodesEnvPath = "/etc/assisted/add-nodes.env"
 const rendezvousHostEnvPath = "/etc/assisted/rendezvous-host.env"
+const proxyConfPath = "/etc/systemd/system.conf.d/10-agent-proxy.conf"
 const manifestPath = "/etc/assisted/manifests"
 const hostnamesPath = "/etc/assisted/hostnames"
 const nmConnectionsPath = "/etc/assisted/network"
@@ -97,6 +99,7 @@ func (a *Ignition) Dependencies() []asset.Asset {
 		&password.KubeadminPassword{},
 		&agentconfig.AgentConfig{},
 		&agentconfig.AgentHosts{},
+		&Customization{},
 		&mirror.RegistriesConf{},
 		&mirror.CaBundle{},
 		&gencrypto.AuthConfig{},
@@ -104,7 +107,7 @@ func (a *Ignition) Dependencies() []asset.Asset {
 }
 
 // Generate generates the agent installer ignition.
-func (a *Ignition) Generate(dependencies asset.Parents) error {
+func (a *Ignition) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	clusterInfo := &joiner.ClusterInfo{}
 	addNodesConfig := &joiner.AddNodesConfig{}
@@ -113,7 +116,8 @@ func (a *Ignition) Generate(dependencies asset.Parents) error {
 	agentHostsAsset := &agentconfig.AgentHosts{}
 	extraManifests := &manifests.ExtraManifests{}
 	keyPairAsset := &gencrypto.AuthConfig{}
-	dependencies.Get(agentManifests, agentConfigAsset, agentHostsAsset, extraManifests, keyPairAsset, agentWorkflow, clusterInfo, addNodesConfig)
+	customization := &Customization{}
+	dependencies.Get(agentManifests, agentConfigAsset, agentHostsAsset, extraManifests, keyPairAsset, agentWorkflow, clusterInfo, addNodesConfig, customization)
 
 	pwd := &password.KubeadminPassword{}
 	dependencies.Get(pwd)
@@ -225,6 +229,10 @@ func (a *Ignition) Generate(dependencies asset.Parents) error {
 	registryCABundle := &mirror.CaBundle{}
 	dependencies.Get(registriesConfig, registryCABundle)
 
+	if err := validateMirrorHosts(registriesConfig, agentManifests.NMStateConfigs); err != nil {
+		return err
+	}
+
 	publicContainerRegistries := getPublicContainerRegistries(registriesConfig)
 
 	releaseImageMirror := mirror.GetMirrorFromRelease(agentManifests.ClusterImageSet.Spec.ReleaseImage, registriesConfig)
@@ -267,6 +275,15 @@ func (a *Ignition) Generate(dependencies asset.Parents) error {
 		getRendezvousHostEnv(agentTemplateData.ServiceProtocol, a.RendezvousIP, agentWorkflow.Workflow))
 	config.Storage.Files = append(config.Storage.Files, rendezvousHostFile)
 
+	// Make the proxy settings available to all the services, including the
+	// agent and assisted-service containers.
+	if infraEnv.Spec.Proxy != nil {
+		proxyFile := ignition.FileFromString(proxyConfPath,
+			"root", 0644,
+			getProxyConf(infraEnv.Spec.Proxy, a.RendezvousIP))
+		config.Storage.Files = append(config.Storage.Files, proxyFile)
+	}
+
 	err = addBootstrapScripts(&config, agentManifests.ClusterImageSet.Spec.ReleaseImage)
 	if err != nil {
 		return err
@@ -312,11 +329,31 @@ func (a *Ignition) Generate(dependencies asset.Parents) error {
 		return err
 	}
 
-	err = addExtraManifests(&config, extraManifests)
+	addRootDeviceConfig(&config, agentHostsAsset)
+
+	// The hosts serve the release payload embedded into the image.
+	if agentConfigAsset.Config != nil && agentConfigAsset.Config.Appliance != nil {
+		config.Storage.Files = append(config.Storage.Files, machineconfig.ApplianceRegistryFiles()...)
+		config.Systemd.Units = append(config.Systemd.Units, machineconfig.ApplianceRegistryUnits()...)
+	}
+
+	agentConfigManifests, err := manifests.AgentConfigManifests(agentConfigAsset.Config)
 	if err != nil {
 		return err
 	}
 
+	manifestFiles := append([]*asset.File{}, extraManifests.FileList...)
+	err = addExtraManifests(&config, append(manifestFiles, agentConfigManifests...))
+	if err != nil {
+		return err
+	}
+
+	// The customizations are added last, so that they cannot silently
+	// replace a file or unit generated by the installer.
+	if err := addImageCustomizations(&config, imageCustomizations(agentConfigAsset, customization)); err != nil {
+		return err
+	}
+
 	a.Config = &config
 	return nil
 }
@@ -413,6 +450,28 @@ WORKFLOW_TYPE=%s
 `, nodeZeroIP, serviceBaseURL.String(), imageServiceBaseURL.String(), workflowType)
 }
 
+// getProxyConf returns the systemd manager configuration setting the proxy
+// environment of the services. The rendezvous host is always excluded from the
+// proxy, so that the agents reach the assisted-service directly.
+func getProxyConf(proxy *v1beta1.Proxy, nodeZeroIP string) string {
+	noProxy := nodeZeroIP
+	if proxy.NoProxy != "" {
+		noProxy = proxy.NoProxy + "," + nodeZeroIP
+	}
+	var env []string
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", proxy.HTTPProxy},
+		{"HTTPS_PROXY", proxy.HTTPSProxy},
+		{"NO_PROXY", noProxy},
+	} {
+		if v.value == "" {
+			continue
+		}
+		env = append(env, fmt.Sprintf("%q %q", v.name+"="+v.value, strings.ToLower(v.name)+"="+v.value))
+	}
+	return fmt.Sprintf("[Manager]\nDefaultEnvironment=%s\n", strings.Join(env, " "))
+}
+
 func getAddNodesEnv(clusterInfo joiner.ClusterInfo) string {
 	return fmt.Sprintf(`CLUSTER_ID=%s
 CLUSTER_NAME=%s
@@ -529,7 +588,7 @@ func addHostConfig(config *igntypes.Config, agentHosts *agentconfig.AgentHosts)
 	return nil
 }
 
-func addExtraManifests(config *igntypes.Config, extraManifests *manifests.ExtraManifests) error {
+func addExtraManifests(config *igntypes.Config, extraManifests []*asset.File) error {
 
 	user := "root"
 	mode := 0644
@@ -547,7 +606,7 @@ func addExtraManifests(config *igntypes.Config, extraManifests *manifests.ExtraM
 		},
 	})
 
-	for _, file := range extraManifests.FileList {
+	for _, file := range extraManifests {
 
 		type unstructured map[string]interface{}
 
@@ -1,6 +1,7 @@
 package image
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
@@ -118,6 +119,41 @@ func TestIgnition_getRendezvousHostEnv(t *testing.T) {
 		rendezvousHostEnv)
 }
 
+func TestIgnition_getProxyConf(t *testing.T) {
+	cases := []struct {
+		name     string
+		proxy    *aiv1beta1.Proxy
+		expected string
+	}{
+		{
+			name: "http and https proxy",
+			proxy: &aiv1beta1.Proxy{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "http://proxy.example.com:3129",
+				NoProxy:    ".example.com",
+			},
+			expected: "[Manager]\nDefaultEnvironment=" +
+				`"HTTP_PROXY=http://proxy.example.com:3128" "http_proxy=http://proxy.example.com:3128" ` +
+				`"HTTPS_PROXY=http://proxy.example.com:3129" "https_proxy=http://proxy.example.com:3129" ` +
+				`"NO_PROXY=.example.com,192.168.111.80" "no_proxy=.example.com,192.168.111.80"` + "\n",
+		},
+		{
+			name: "https proxy only",
+			proxy: &aiv1beta1.Proxy{
+				HTTPSProxy: "http://proxy.example.com:3129",
+			},
+			expected: "[Manager]\nDefaultEnvironment=" +
+				`"HTTPS_PROXY=http://proxy.example.com:3129" "https_proxy=http://proxy.example.com:3129" ` +
+				`"NO_PROXY=192.168.111.80" "no_proxy=192.168.111.80"` + "\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getProxyConf(tc.proxy, "192.168.111.80"))
+		})
+	}
+}
+
 func TestIgnition_addStaticNetworkConfig(t *testing.T) {
 	_, execErr := exec.LookPath("nmstatectl")
 	if execErr != nil {
@@ -511,7 +547,7 @@ metadata:
 			parents.Add(deps...)
 
 			ignitionAsset := &Ignition{}
-			err := ignitionAsset.Generate(parents)
+			err := ignitionAsset.Generate(context.Background(), parents)
 
 			if tc.expectedError != "" {
 				assert.Equal(t, tc.expectedError, err.Error())
@@ -660,6 +696,7 @@ func buildIgnitionAssetDefaultDependencies(t *testing.T) []asset.Asset {
 			},
 		},
 		&agentconfig.AgentHosts{},
+		&Customization{},
 		&manifests.ExtraManifests{},
 		&mirror.RegistriesConf{},
 		&mirror.CaBundle{},
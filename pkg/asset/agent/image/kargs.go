@@ -1,11 +1,14 @@
 package image
 
 import (
+	"context"
+
 	"github.com/sirupsen/logrus"
 
 	hiveext "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
 	"github.com/openshift/installer/pkg/asset/agent/manifests"
 	"github.com/openshift/installer/pkg/asset/agent/workflow"
 )
@@ -14,6 +17,7 @@ import (
 type Kargs struct {
 	consoleArgs string
 	fips        bool
+	customArgs  []string
 }
 
 // Dependencies returns the assets on which the Kargs asset depends.
@@ -21,14 +25,18 @@ func (a *Kargs) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&workflow.AgentWorkflow{},
 		&manifests.AgentClusterInstall{},
+		&agentconfig.AgentConfig{},
+		&Customization{},
 	}
 }
 
 // Generate generates the kernel args configurations for the agent ISO image and PXE assets.
-func (a *Kargs) Generate(dependencies asset.Parents) error {
+func (a *Kargs) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	agentClusterInstall := &manifests.AgentClusterInstall{}
-	dependencies.Get(agentClusterInstall, agentWorkflow)
+	agentConfig := &agentconfig.AgentConfig{}
+	customization := &Customization{}
+	dependencies.Get(agentClusterInstall, agentWorkflow, agentConfig, customization)
 
 	// Not required for AddNodes workflow
 	if agentWorkflow.Workflow == workflow.AgentWorkflowTypeAddNodes {
@@ -43,6 +51,10 @@ func (a *Kargs) Generate(dependencies asset.Parents) error {
 
 	a.fips = agentClusterInstall.FIPSEnabled()
 
+	for _, c := range imageCustomizations(agentConfig, customization) {
+		a.customArgs = append(a.customArgs, c.KernelArguments...)
+	}
+
 	return nil
 }
 
@@ -57,5 +69,8 @@ func (a *Kargs) KernelCmdLine() []byte {
 	if a.fips {
 		cmdLine += " fips=1"
 	}
+	for _, arg := range a.customArgs {
+		cmdLine += " " + arg
+	}
 	return []byte(cmdLine)
 }
@@ -0,0 +1,109 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/installer/pkg/asset/agent/manifests"
+	"github.com/openshift/installer/pkg/asset/agent/mirror"
+)
+
+const mirrorLookupTimeout = 10 * time.Second
+
+// lookupHost resolves the host with the given DNS servers. It is a variable
+// so that it can be replaced in tests.
+var lookupHost = func(ctx context.Context, servers []string, host string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			var err error
+			for _, server := range servers {
+				var conn net.Conn
+				if conn, err = d.DialContext(ctx, network, net.JoinHostPort(server, "53")); err == nil {
+					return conn, nil
+				}
+			}
+			return nil, err
+		},
+	}
+	return resolver.LookupHost(ctx, host)
+}
+
+// mirrorHosts returns the host names of the mirror registries configured in
+// registries.conf. Mirrors addressed by IP are skipped, since they do not
+// need to be resolved.
+func mirrorHosts(registriesConfig *mirror.RegistriesConf) []string {
+	hosts := sets.New[string]()
+	addHost := func(location string) {
+		host := strings.SplitN(location, "/", 2)[0]
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, "[]")
+		if host != "" && net.ParseIP(host) == nil {
+			hosts.Insert(host)
+		}
+	}
+
+	if registriesConfig.Config != nil {
+		for _, registry := range registriesConfig.Config.Registries {
+			for _, m := range registry.Mirrors {
+				addHost(m.Location)
+			}
+		}
+	} else {
+		for _, config := range registriesConfig.MirrorConfig {
+			addHost(config.Mirror)
+		}
+	}
+	return sets.List(hosts)
+}
+
+// validateMirrorHosts checks that the mirror registries can be resolved by
+// hosts using a static network config. Every static network config must
+// configure a DNS server, and the mirror host names must resolve with the
+// configured DNS servers. A DNS server that cannot be reached from the
+// machine generating the ISO only produces a warning, since it may only be
+// reachable from the cluster network.
+func validateMirrorHosts(registriesConfig *mirror.RegistriesConf, nmStateConfigs []*aiv1beta1.NMStateConfig) error {
+	hosts := mirrorHosts(registriesConfig)
+	if len(hosts) == 0 || len(nmStateConfigs) == 0 {
+		return nil
+	}
+
+	servers := sets.New[string]()
+	for _, nmStateConfig := range nmStateConfigs {
+		dnsServers, err := manifests.GetDNSServers(nmStateConfig.Spec.NetConfig.Raw)
+		if err != nil {
+			return err
+		}
+		if len(dnsServers) == 0 {
+			return fmt.Errorf("the static network config of %s does not configure a DNS server, so the mirror registry %s cannot be resolved", nmStateConfig.Name, hosts[0])
+		}
+		servers.Insert(dnsServers...)
+	}
+
+	for _, host := range hosts {
+		ctx, cancel := context.WithTimeout(context.Background(), mirrorLookupTimeout)
+		_, err := lookupHost(ctx, sets.List(servers), host)
+		cancel()
+		if err == nil {
+			continue
+		}
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Errorf("the mirror registry %s cannot be resolved by the DNS servers of the static network config (%s)", host, strings.Join(sets.List(servers), ", "))
+		}
+		logrus.Warnf("Unable to verify that the mirror registry %s can be resolved by the DNS servers of the static network config: %v", host, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/installer/pkg/asset/agent/mirror"
+)
+
+func TestValidateMirrorHosts(t *testing.T) {
+	registriesConf := &mirror.RegistriesConf{
+		MirrorConfig: []mirror.RegistriesConfig{
+			{Location: "quay.io/openshift-release-dev/ocp-release", Mirror: "mirror.example.com:5000/ocp/release"},
+			{Location: "quay.io/openshift-release-dev/ocp-v4.0-art-dev", Mirror: "192.168.111.1:5000/ocp/art-dev"},
+		},
+	}
+	nmStateConfig := func(raw string) *aiv1beta1.NMStateConfig {
+		return &aiv1beta1.NMStateConfig{ObjectMeta: metav1.ObjectMeta{Name: "ostest-0"}, Spec: aiv1beta1.NMStateConfigSpec{NetConfig: aiv1beta1.NetConfig{Raw: []byte(raw)}}}
+	}
+	withDNS := nmStateConfig(`
+dns-resolver:
+  config:
+    server:
+    - 192.168.111.1
+interfaces:
+- name: eth0
+  type: ethernet
+`)
+
+	cases := []struct {
+		name           string
+		registriesConf *mirror.RegistriesConf
+		nmStateConfigs []*aiv1beta1.NMStateConfig
+		lookupErr      error
+		expectedError  string
+	}{
+		{
+			name:           "no mirrors",
+			registriesConf: &mirror.RegistriesConf{},
+			nmStateConfigs: []*aiv1beta1.NMStateConfig{withDNS},
+		},
+		{
+			name:           "no static network config",
+			registriesConf: registriesConf,
+		},
+		{
+			name:           "resolvable",
+			registriesConf: registriesConf,
+			nmStateConfigs: []*aiv1beta1.NMStateConfig{withDNS},
+		},
+		{
+			name:           "no dns server",
+			registriesConf: registriesConf,
+			nmStateConfigs: []*aiv1beta1.NMStateConfig{nmStateConfig("interfaces: []\n")},
+			expectedError:  "the static network config of ostest-0 does not configure a DNS server, so the mirror registry mirror.example.com cannot be resolved",
+		},
+		{
+			name:           "not found",
+			registriesConf: registriesConf,
+			nmStateConfigs: []*aiv1beta1.NMStateConfig{withDNS},
+			lookupErr:      &net.DNSError{Err: "no such host", Name: "mirror.example.com", IsNotFound: true},
+			expectedError:  "the mirror registry mirror.example.com cannot be resolved by the DNS servers of the static network config (192.168.111.1)",
+		},
+		{
+			name:           "dns server unreachable",
+			registriesConf: registriesConf,
+			nmStateConfigs: []*aiv1beta1.NMStateConfig{withDNS},
+			lookupErr:      errors.New("i/o timeout"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func(context.Context, []string, string) ([]string, error)) { lookupHost = f }(lookupHost)
+			lookupHost = func(_ context.Context, servers []string, host string) ([]string, error) {
+				assert.Equal(t, "mirror.example.com", host)
+				assert.Equal(t, []string{"192.168.111.1"}, servers)
+				if tc.lookupErr != nil {
+					return nil, tc.lookupErr
+				}
+				return []string{"192.168.111.1"}, nil
+			}
+
+			err := validateMirrorHosts(tc.registriesConf, tc.nmStateConfigs)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
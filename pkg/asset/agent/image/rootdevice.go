@@ -0,0 +1,110 @@
+package image
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+const (
+	iscsiTargetsPath     = "/etc/assisted/iscsi"
+	iscsiLoginScriptPath = "/usr/local/bin/agent-iscsi-login.sh"
+	multipathRulesPath   = "/etc/udev/rules.d/99-agent-multipath.rules"
+)
+
+// multipathRules makes the WWN of a multipath device visible to the agent
+// inventory on the device-mapper device instead of on each of its paths, so
+// that a wwn root device hint selects the multipath device.
+const multipathRules = `ACTION=="remove", GOTO="agent_multipath_end"
+SUBSYSTEM!="block", GOTO="agent_multipath_end"
+ENV{DM_MULTIPATH_DEVICE_PATH}=="1", ENV{ID_WWN}="", ENV{ID_WWN_WITH_EXTENSION}=""
+KERNEL=="dm-*", ENV{DM_UUID}=="mpath-*", IMPORT{program}="/bin/sh -c 'echo ID_WWN=0x$${DM_UUID#mpath-?}'"
+KERNEL=="dm-*", ENV{DM_UUID}=="mpath-*", ENV{ID_WWN_WITH_EXTENSION}="$env{ID_WWN}"
+LABEL="agent_multipath_end"
+`
+
+// iscsiLoginScript logs in to the iSCSI target configured for the host, which
+// is found by matching the MAC addresses of the local interfaces against the
+// files in iscsiTargetsPath.
+const iscsiLoginScript = `#!/bin/bash
+set -euo pipefail
+
+for mac in $(cat /sys/class/net/*/address); do
+    target="` + iscsiTargetsPath + `/${mac}"
+    if [ -f "${target}" ]; then
+        # shellcheck disable=SC1090
+        source "${target}"
+        if [ -n "${ISCSI_INITIATOR_NAME:-}" ]; then
+            echo "InitiatorName=${ISCSI_INITIATOR_NAME}" > /etc/iscsi/initiatorname.iscsi
+            systemctl restart iscsid.service
+        fi
+        iscsiadm --mode discovery --type sendtargets --portal "${ISCSI_PORTAL}"
+        iscsiadm --mode node --targetname "${ISCSI_IQN}" --portal "${ISCSI_PORTAL}" --login
+        udevadm settle
+        exit 0
+    fi
+done
+
+echo "No iSCSI target is configured for this host"
+`
+
+const iscsiLoginUnit = `[Unit]
+Description=Log in to the iSCSI target holding the root device
+Wants=network-online.target iscsid.service
+After=network-online.target iscsid.service
+Before=agent.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=` + iscsiLoginScriptPath + `
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// addRootDeviceConfig adds the configuration required by the agent to find
+// root devices reached over multipath or iSCSI.
+func addRootDeviceConfig(config *igntypes.Config, agentHosts *agentconfig.AgentHosts) {
+	var multipath bool
+	var iscsiTargets []igntypes.File
+	for _, host := range agentHosts.Hosts {
+		rdh := host.RootDeviceHints
+		if rdh.Multipath {
+			multipath = true
+		}
+		if rdh.ISCSI == nil || len(host.Interfaces) == 0 {
+			continue
+		}
+		env := fmt.Sprintf("ISCSI_PORTAL=%s\nISCSI_IQN=%s\nISCSI_LUN=%d\n", rdh.ISCSI.Portal, rdh.ISCSI.IQN, rdh.ISCSI.LUN)
+		if rdh.ISCSI.InitiatorName != "" {
+			env += fmt.Sprintf("ISCSI_INITIATOR_NAME=%s\n", rdh.ISCSI.InitiatorName)
+		}
+		for _, iface := range host.Interfaces {
+			iscsiTargets = append(iscsiTargets, ignition.FileFromString(
+				filepath.Join(iscsiTargetsPath, strings.ToLower(iface.MacAddress)), "root", 0600, env))
+		}
+	}
+
+	if multipath {
+		config.Storage.Files = append(config.Storage.Files,
+			ignition.FileFromString(multipathRulesPath, "root", 0644, multipathRules))
+	}
+
+	if len(iscsiTargets) > 0 {
+		config.Storage.Files = append(config.Storage.Files, iscsiTargets...)
+		config.Storage.Files = append(config.Storage.Files,
+			ignition.FileFromString(iscsiLoginScriptPath, "root", 0755, iscsiLoginScript))
+		config.Systemd.Units = append(config.Systemd.Units, igntypes.Unit{
+			Name:     "agent-iscsi-login.service",
+			Enabled:  ptr.To(true),
+			Contents: ptr.To(iscsiLoginUnit),
+		})
+	}
+}
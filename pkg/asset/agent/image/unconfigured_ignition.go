@@ -1,6 +1,7 @@
 package image
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -82,7 +83,7 @@ func (a *UnconfiguredIgnition) Dependencies() []asset.Asset {
 }
 
 // Generate generates the agent installer unconfigured ignition.
-func (a *UnconfiguredIgnition) Generate(dependencies asset.Parents) error {
+func (a *UnconfiguredIgnition) Generate(_ context.Context, dependencies asset.Parents) error {
 	infraEnvAsset := &manifests.InfraEnv{}
 	clusterImageSetAsset := &manifests.ClusterImageSet{}
 	pullSecretAsset := &manifests.AgentPullSecret{}
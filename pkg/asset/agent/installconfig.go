@@ -1,12 +1,14 @@
 package agent
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
 
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/assisted-service/models"
@@ -33,6 +35,14 @@ const (
 type OptionalInstallConfig struct {
 	installconfig.AssetBase
 	Supplied bool
+	// ReleaseArchitecture is the architecture of the release image, or
+	// "multi" for a multi-architecture release image. It is empty when it
+	// could not be determined.
+	ReleaseArchitecture string
+	// MachineNetworkDefaulted is true when the install-config sets no
+	// machine network, so that the default one can be replaced by the
+	// networks of the host addresses.
+	MachineNetworkDefaulted bool
 }
 
 var _ asset.WritableAsset = (*OptionalInstallConfig)(nil)
@@ -47,7 +57,7 @@ func (a *OptionalInstallConfig) Dependencies() []asset.Asset {
 }
 
 // Generate generates the install-config.yaml file.
-func (a *OptionalInstallConfig) Generate(parents asset.Parents) error {
+func (a *OptionalInstallConfig) Generate(_ context.Context, parents asset.Parents) error {
 	// Just generate an empty install config, since we have no dependencies.
 	return nil
 }
@@ -57,6 +67,11 @@ func (a *OptionalInstallConfig) Load(f asset.FileFetcher) (bool, error) {
 	found, err := a.LoadFromFile(f)
 	if found && err == nil {
 		a.Supplied = true
+		file, err := f.FetchByName(InstallConfigFilename)
+		if err != nil {
+			return false, err
+		}
+		a.MachineNetworkDefaulted = machineNetworkOmitted(file.Data)
 		if err := a.validateInstallConfig(a.Config).ToAggregate(); err != nil {
 			return false, errors.Wrapf(err, "invalid install-config configuration")
 		}
@@ -67,6 +82,21 @@ func (a *OptionalInstallConfig) Load(f asset.FileFetcher) (bool, error) {
 	return found, err
 }
 
+// machineNetworkOmitted returns true if the install-config sets no machine
+// network, in either its current or its deprecated field.
+func machineNetworkOmitted(data []byte) bool {
+	config := struct {
+		Networking *struct {
+			MachineNetwork []interface{} `json:"machineNetwork"`
+			MachineCIDR    string        `json:"machineCIDR"`
+		} `json:"networking"`
+	}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return false
+	}
+	return config.Networking == nil || (len(config.Networking.MachineNetwork) == 0 && config.Networking.MachineCIDR == "")
+}
+
 func (a *OptionalInstallConfig) validateInstallConfig(installConfig *types.InstallConfig) field.ErrorList {
 	var allErrs field.ErrorList
 	if err := validation.ValidateInstallConfig(a.Config, true); err != nil {
@@ -88,6 +118,10 @@ func (a *OptionalInstallConfig) validateInstallConfig(installConfig *types.Insta
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("FeatureSet"), installConfig.FeatureSet, []string{string(configv1.Default)}))
 	}
 
+	if installConfig.GenerateSSHKey {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("generateSSHKey"), "generated SSH keys are not supported by the agent-based installer"))
+	}
+
 	warnUnusedConfig(installConfig)
 
 	numMasters, numWorkers := GetReplicaCount(installConfig)
@@ -142,7 +176,7 @@ func (a *OptionalInstallConfig) validateReleaseArch(installConfig *types.Install
 
 	fieldPath := field.NewPath("ControlPlane", "Architecture")
 	releaseImage := &releaseimage.Image{}
-	asseterr := releaseImage.Generate(asset.Parents{})
+	asseterr := releaseImage.Generate(context.TODO(), asset.Parents{})
 	if asseterr != nil {
 		allErrs = append(allErrs, field.InternalError(fieldPath, asseterr))
 	}
@@ -150,6 +184,7 @@ func (a *OptionalInstallConfig) validateReleaseArch(installConfig *types.Install
 	if err != nil {
 		logrus.Warnf("Unable to validate the release image architecture, skipping validation")
 	} else {
+		a.ReleaseArchitecture = releaseArch
 		// Validate that the release image supports the install-config architectures.
 		switch releaseArch {
 		// Check the release image to see if it is multi.
@@ -162,6 +197,7 @@ func (a *OptionalInstallConfig) validateReleaseArch(installConfig *types.Install
 			allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("unsupported release image architecture. ControlPlane Arch: %s doesn't match Release Image Arch: %s", installConfig.ControlPlane.Architecture, releaseArch)))
 		}
 	}
+	allErrs = append(allErrs, validation.ValidateReleaseArchitecture(installConfig, releaseArch)...)
 	return allErrs
 }
 
@@ -1026,3 +1026,35 @@ pullSecret: "{\"auths\":{\"example.com\":{\"auth\":\"c3VwZXItc2VjcmV0Cg==\"}}}"
 		})
 	}
 }
+
+func TestMachineNetworkOmitted(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected bool
+	}{
+		{
+			name:     "no networking",
+			data:     "baseDomain: test-domain\n",
+			expected: true,
+		},
+		{
+			name:     "no machine network",
+			data:     "networking:\n  networkType: OVNKubernetes\n",
+			expected: true,
+		},
+		{
+			name: "machine network",
+			data: "networking:\n  machineNetwork:\n  - cidr: 192.168.111.0/24\n",
+		},
+		{
+			name: "deprecated machine CIDR",
+			data: "networking:\n  machineCIDR: 192.168.111.0/24\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, machineNetworkOmitted([]byte(tc.data)))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/coreos/stream-metadata-go/arch"
 	"github.com/coreos/stream-metadata-go/stream"
@@ -64,7 +65,7 @@ func (*ClusterInfo) Dependencies() []asset.Asset {
 }
 
 // Generate generates the ClusterInfo.
-func (ci *ClusterInfo) Generate(dependencies asset.Parents) error {
+func (ci *ClusterInfo) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	addNodesConfig := &AddNodesConfig{}
 	dependencies.Get(agentWorkflow, addNodesConfig)
@@ -225,7 +226,7 @@ func (ci *ClusterInfo) retrieveInstallConfigData() error {
 	ci.ImageDigestSources = installConfig.ImageDigestSources
 	ci.DeprecatedImageContentSources = installConfig.DeprecatedImageContentSources
 	ci.PlatformType = agent.HivePlatformType(installConfig.Platform)
-	ci.SSHKey = installConfig.SSHKey
+	ci.SSHKey = strings.Join(installConfig.AuthorizedSSHKeys(), "\n")
 	ci.ClusterName = installConfig.ObjectMeta.Name
 	ci.APIDNSName = fmt.Sprintf("api.%s.%s", ci.ClusterName, installConfig.BaseDomain)
 
@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -133,7 +134,7 @@ func (*AgentClusterInstall) Dependencies() []asset.Asset {
 }
 
 // Generate generates the AgentClusterInstall manifest.
-func (a *AgentClusterInstall) Generate(dependencies asset.Parents) error {
+func (a *AgentClusterInstall) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	installConfig := &agent.OptionalInstallConfig{}
 	agentHosts := &agentconfig.AgentHosts{}
@@ -171,6 +172,12 @@ func (a *AgentClusterInstall) Generate(dependencies asset.Parents) error {
 			}
 			machineNetwork = append(machineNetwork, entry)
 		}
+		if len(agentHosts.MachineNetworks) > 0 {
+			machineNetwork = []hiveext.MachineNetworkEntry{}
+			for _, mn := range agentHosts.MachineNetworks {
+				machineNetwork = append(machineNetwork, hiveext.MachineNetworkEntry{CIDR: mn.String()})
+			}
+		}
 
 		agentClusterInstall := &hiveext.AgentClusterInstall{
 			TypeMeta: metav1.TypeMeta{
@@ -193,7 +200,7 @@ func (a *AgentClusterInstall) Generate(dependencies asset.Parents) error {
 					ClusterNetwork: clusterNetwork,
 					ServiceNetwork: serviceNetwork,
 				},
-				SSHPublicKey: strings.Trim(installConfig.Config.SSHKey, "|\n\t"),
+				SSHPublicKey: strings.Join(installConfig.Config.AuthorizedSSHKeys(), "\n"),
 				ProvisionRequirements: hiveext.ProvisionRequirements{
 					ControlPlaneAgents: int(*installConfig.Config.ControlPlane.Replicas),
 					WorkerAgents:       numberOfWorkers,
@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -84,7 +85,7 @@ func TestAgentPullSecret_Generate(t *testing.T) {
 			parents.Add(tc.dependencies...)
 
 			asset := &AgentPullSecret{}
-			err := asset.Generate(parents)
+			err := asset.Generate(context.Background(), parents)
 
 			if tc.expectedError != "" {
 				assert.Equal(t, tc.expectedError, err.Error())
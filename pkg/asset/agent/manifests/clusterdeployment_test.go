@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -73,7 +74,7 @@ func TestClusterDeployment_Generate(t *testing.T) {
 			parents.Add(tc.dependencies...)
 
 			asset := &ClusterDeployment{}
-			err := asset.Generate(parents)
+			err := asset.Generate(context.Background(), parents)
 
 			if tc.expectedError != "" {
 				assert.Equal(t, tc.expectedError, err.Error())
@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -48,7 +49,7 @@ func (*ClusterImageSet) Dependencies() []asset.Asset {
 }
 
 // Generate generates the ClusterImageSet manifest.
-func (a *ClusterImageSet) Generate(dependencies asset.Parents) error {
+func (a *ClusterImageSet) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	clusterInfo := &joiner.ClusterInfo{}
 	releaseImage := &releaseimage.Image{}
@@ -182,7 +183,7 @@ func (a *ClusterImageSet) validateReleaseVersion(workflowType workflow.AgentWork
 	fieldPath := field.NewPath("Spec", "ReleaseImage")
 
 	releaseImage := &releaseimage.Image{}
-	releaseImage.Generate(asset.Parents{})
+	releaseImage.Generate(context.TODO(), asset.Parents{})
 
 	if a.Config.Spec.ReleaseImage != releaseImage.PullSpec {
 		allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("value must be equal to %s", releaseImage.PullSpec)))
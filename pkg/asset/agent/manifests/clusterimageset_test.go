@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -121,7 +122,7 @@ func TestClusterImageSet_Generate(t *testing.T) {
 			parents.Add(tc.dependencies...)
 
 			asset := &ClusterImageSet{}
-			err := asset.Generate(parents)
+			err := asset.Generate(context.Background(), parents)
 
 			if tc.expectedError != "" {
 				assert.Equal(t, tc.expectedError, err.Error())
@@ -1,9 +1,12 @@
 package manifests
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/coreos/stream-metadata-go/arch"
@@ -25,6 +28,10 @@ var (
 	infraEnvFilename = filepath.Join(clusterManifestDir, "infraenv.yaml")
 )
 
+// minimalHardwareRequirementsAnnotation holds the minimal hardware
+// requirements of the hosts, as JSON, when agent-config overrides them.
+const minimalHardwareRequirementsAnnotation = "agent-install.openshift.io/minimal-hardware-requirements"
+
 // InfraEnv generates the infraenv.yaml file.
 type InfraEnv struct {
 	File   *asset.File
@@ -50,7 +57,7 @@ func (*InfraEnv) Dependencies() []asset.Asset {
 }
 
 // Generate generates the InfraEnv manifest.
-func (i *InfraEnv) Generate(dependencies asset.Parents) error {
+func (i *InfraEnv) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	clusterInfo := &joiner.ClusterInfo{}
 	installConfig := &agent.OptionalInstallConfig{}
@@ -60,15 +67,31 @@ func (i *InfraEnv) Generate(dependencies asset.Parents) error {
 	switch agentWorkflow.Workflow {
 	case workflow.AgentWorkflowTypeInstall:
 		if installConfig.Config != nil {
-			err := i.generateManifest(installConfig.ClusterName(), installConfig.ClusterNamespace(), installConfig.Config.SSHKey, installConfig.Config.AdditionalTrustBundle, installConfig.Config.Proxy, string(installConfig.Config.ControlPlane.Architecture))
+			err := i.generateManifest(installConfig.ClusterName(), installConfig.ClusterNamespace(), strings.Join(installConfig.Config.AuthorizedSSHKeys(), "\n"), installConfig.Config.AdditionalTrustBundle, installConfig.Config.Proxy, string(installConfig.Config.ControlPlane.Architecture))
 			if err != nil {
 				return err
 			}
 
+			var ntpSources []string
+			ntpSources = append(ntpSources, installConfig.Config.AdditionalNTPServers...)
 			if agentConfig.Config != nil {
-				i.Config.Spec.AdditionalNTPSources = agentConfig.Config.AdditionalNTPSources
+				for _, source := range agentConfig.Config.AdditionalNTPSources {
+					if !slices.Contains(ntpSources, source) {
+						ntpSources = append(ntpSources, source)
+					}
+				}
+			}
+			i.Config.Spec.AdditionalNTPSources = ntpSources
+
+			if agentConfig.Config != nil && agentConfig.Config.MinimalHardwareRequirements != nil {
+				requirements, err := json.Marshal(agentconfig.MinimalHardwareRequirements(agentConfig.Config.MinimalHardwareRequirements))
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal the minimal hardware requirements")
+				}
+				i.Config.SetAnnotations(map[string]string{
+					minimalHardwareRequirementsAnnotation: string(requirements),
+				})
 			}
-
 		}
 
 	case workflow.AgentWorkflowTypeAddNodes:
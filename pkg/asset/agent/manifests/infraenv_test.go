@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"errors"
 	"os"
 	"strings"
@@ -139,6 +140,41 @@ func TestInfraEnv_Generate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "minimal hardware requirements",
+			dependencies: []asset.Asset{
+				&workflow.AgentWorkflow{Workflow: workflow.AgentWorkflowTypeInstall},
+				&joiner.ClusterInfo{},
+				getValidOptionalInstallConfig(),
+				getValidAgentConfigWithMinimalHardwareRequirements(),
+			},
+			expectedConfig: &aiv1beta1.InfraEnv{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "InfraEnv",
+					APIVersion: "agent-install.openshift.io/v1beta1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      getValidOptionalInstallConfig().ClusterName(),
+					Namespace: getValidOptionalInstallConfig().ClusterNamespace(),
+					Annotations: map[string]string{
+						"agent-install.openshift.io/minimal-hardware-requirements": `{"master":{"cpuCores":2,"memoryMiB":8192,"diskSizeGB":100},"worker":{"cpuCores":2,"memoryMiB":8192,"diskSizeGB":50},"sno":{"cpuCores":8,"memoryMiB":16384,"diskSizeGB":100}}`,
+					},
+				},
+				Spec: aiv1beta1.InfraEnvSpec{
+					ClusterRef: &aiv1beta1.ClusterReference{
+						Name:      getClusterDeploymentName(getValidOptionalInstallConfig()),
+						Namespace: getValidOptionalInstallConfig().ClusterNamespace(),
+					},
+					SSHAuthorizedKey: strings.Trim(testSSHKey, "|\n\t"),
+					PullSecretRef: &corev1.LocalObjectReference{
+						Name: getPullSecretName(getValidOptionalInstallConfig().ClusterName()),
+					},
+					NMStateConfigLabelSelector: metav1.LabelSelector{
+						MatchLabels: getNMStateConfigLabels(getValidOptionalInstallConfig().ClusterName()),
+					},
+				},
+			},
+		},
 		{
 			name: "AdditionalTrustBundle",
 			dependencies: []asset.Asset{
@@ -225,7 +261,7 @@ func TestInfraEnv_Generate(t *testing.T) {
 			parents.Add(tc.dependencies...)
 
 			asset := &InfraEnv{}
-			err := asset.Generate(parents)
+			err := asset.Generate(context.Background(), parents)
 
 			if tc.expectedError != "" {
 				assert.Equal(t, tc.expectedError, err.Error())
@@ -52,6 +52,11 @@ type nmStateConfig struct {
 			} `yaml:"address,omitempty"`
 		} `yaml:"ipv6,omitempty"`
 	} `yaml:"interfaces,omitempty"`
+	DNSResolver struct {
+		Config struct {
+			Server []string `yaml:"server,omitempty"`
+		} `yaml:"config,omitempty"`
+	} `yaml:"dns-resolver,omitempty" json:"dns-resolver,omitempty"`
 }
 
 var _ asset.WritableAsset = (*NMStateConfig)(nil)
@@ -73,7 +78,7 @@ func (*NMStateConfig) Dependencies() []asset.Asset {
 }
 
 // Generate generates the NMStateConfig manifest.
-func (n *NMStateConfig) Generate(dependencies asset.Parents) error {
+func (n *NMStateConfig) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	clusterInfo := &joiner.ClusterInfo{}
 	agentHosts := &agentconfig.AgentHosts{}
@@ -283,6 +288,17 @@ func getFirstIP(nmstateRaw []byte) (string, error) {
 	return "", nil
 }
 
+// GetDNSServers returns the DNS servers configured by a static network config.
+func GetDNSServers(nmstateRaw []byte) ([]string, error) {
+	var nmStateConfig nmStateConfig
+	err := yaml.Unmarshal(nmstateRaw, &nmStateConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling NMStateConfig: %w", err)
+	}
+
+	return nmStateConfig.DNSResolver.Config.Server, nil
+}
+
 // GetNodeZeroIP retrieves the first IP to be set as the node0 IP.
 // The method prioritizes the search by trying to scan first the NMState configs defined
 // in the agent-config hosts - so that it would be possible to skip the worker nodes - and then
@@ -0,0 +1,166 @@
+package manifests
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/machines/machineconfig"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+const (
+	marketplaceNamespace   = "openshift-marketplace"
+	defaultOperatorSource  = "redhat-operators"
+	operatorsAPIVersion    = "operators.coreos.com/v1alpha1"
+	operatorGroupsVersion  = "operators.coreos.com/v1"
+	agentConfigFilePrefix  = "agent-config-"
+	catalogSourceFilename  = agentConfigFilePrefix + "catalogsource-%s.yaml"
+	operatorFilename       = agentConfigFilePrefix + "operator-%s.yaml"
+	extraManifestsFilename = agentConfigFilePrefix + "%s"
+	applianceFilename      = agentConfigFilePrefix + "appliance-registry-%s.yaml"
+)
+
+// OperatorNamespace returns the namespace the operator is installed in.
+func OperatorNamespace(operator agent.Operator) string {
+	if operator.Namespace != "" {
+		return operator.Namespace
+	}
+	return "openshift-" + operator.Name
+}
+
+// AgentConfigManifests returns the day-0 manifests defined in the
+// agent-config: the catalog sources, the namespace, operator group and
+// subscription of each operator, the extra manifests, and the machine configs
+// serving the embedded release payload in appliance mode. The manifests are
+// embedded in the ISO alongside the manifests of the openshift directory.
+func AgentConfigManifests(config *agent.Config) ([]*asset.File, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	files := []*asset.File{}
+	for _, cs := range config.CatalogSources {
+		data, err := yaml.Marshal(catalogSource(cs))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal catalog source %s", cs.Name)
+		}
+		files = append(files, &asset.File{
+			Filename: filepath.Join(openshiftManifestDir, fmt.Sprintf(catalogSourceFilename, cs.Name)),
+			Data:     data,
+		})
+	}
+
+	for _, operator := range config.Operators {
+		data := []byte{}
+		for _, manifest := range operatorManifests(operator) {
+			m, err := yaml.Marshal(manifest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to marshal manifests for operator %s", operator.Name)
+			}
+			if len(data) > 0 {
+				data = append(data, []byte("---\n")...)
+			}
+			data = append(data, m...)
+		}
+		files = append(files, &asset.File{
+			Filename: filepath.Join(openshiftManifestDir, fmt.Sprintf(operatorFilename, operator.Name)),
+			Data:     data,
+		})
+	}
+
+	for _, manifest := range config.ExtraManifests {
+		files = append(files, &asset.File{
+			Filename: filepath.Join(openshiftManifestDir, fmt.Sprintf(extraManifestsFilename, manifest.Name)),
+			Data:     []byte(manifest.Content),
+		})
+	}
+
+	if config.Appliance != nil {
+		for _, role := range []string{"master", "worker"} {
+			mc, err := machineconfig.ForApplianceRegistry(role)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create the appliance registry machine config for %s", role)
+			}
+			data, err := yaml.Marshal(mc)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to marshal the appliance registry machine config for %s", role)
+			}
+			files = append(files, &asset.File{
+				Filename: filepath.Join(openshiftManifestDir, fmt.Sprintf(applianceFilename, role)),
+				Data:     data,
+			})
+		}
+	}
+
+	return files, nil
+}
+
+func catalogSource(cs agent.CatalogSource) map[string]interface{} {
+	spec := map[string]interface{}{
+		"sourceType": "grpc",
+		"image":      cs.Image,
+	}
+	if cs.DisplayName != "" {
+		spec["displayName"] = cs.DisplayName
+	}
+	return map[string]interface{}{
+		"apiVersion": operatorsAPIVersion,
+		"kind":       "CatalogSource",
+		"metadata": map[string]interface{}{
+			"name":      cs.Name,
+			"namespace": marketplaceNamespace,
+		},
+		"spec": spec,
+	}
+}
+
+func operatorManifests(operator agent.Operator) []map[string]interface{} {
+	namespace := OperatorNamespace(operator)
+	source := operator.Source
+	if source == "" {
+		source = defaultOperatorSource
+	}
+
+	subscriptionSpec := map[string]interface{}{
+		"name":            operator.Name,
+		"source":          source,
+		"sourceNamespace": marketplaceNamespace,
+	}
+	if operator.Channel != "" {
+		subscriptionSpec["channel"] = operator.Channel
+	}
+
+	return []map[string]interface{}{
+		{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": namespace,
+			},
+		},
+		{
+			"apiVersion": operatorGroupsVersion,
+			"kind":       "OperatorGroup",
+			"metadata": map[string]interface{}{
+				"name":      operator.Name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"targetNamespaces": []string{namespace},
+			},
+		},
+		{
+			"apiVersion": operatorsAPIVersion,
+			"kind":       "Subscription",
+			"metadata": map[string]interface{}{
+				"name":      operator.Name,
+				"namespace": namespace,
+			},
+			"spec": subscriptionSpec,
+		},
+	}
+}
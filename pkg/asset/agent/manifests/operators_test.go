@@ -0,0 +1,74 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+func TestAgentConfigManifests(t *testing.T) {
+	config := &agent.Config{
+		CatalogSources: []agent.CatalogSource{
+			{Name: "my-catalog", Image: "registry.example.com/catalog:latest"},
+		},
+		Operators: []agent.Operator{
+			{Name: "lvms-operator", Namespace: "openshift-storage", Channel: "stable", Source: "my-catalog"},
+		},
+		ExtraManifests: []agent.ExtraManifest{
+			{Name: "lvmcluster.yaml", Content: "apiVersion: lvm.topolvm.io/v1alpha1\nkind: LVMCluster\n"},
+		},
+	}
+
+	files, err := AgentConfigManifests(config)
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	assert.Equal(t, "openshift/agent-config-catalogsource-my-catalog.yaml", files[0].Filename)
+	assert.Equal(t, `apiVersion: operators.coreos.com/v1alpha1
+kind: CatalogSource
+metadata:
+  name: my-catalog
+  namespace: openshift-marketplace
+spec:
+  image: registry.example.com/catalog:latest
+  sourceType: grpc
+`, string(files[0].Data))
+
+	assert.Equal(t, "openshift/agent-config-operator-lvms-operator.yaml", files[1].Filename)
+	assert.Equal(t, `apiVersion: v1
+kind: Namespace
+metadata:
+  name: openshift-storage
+---
+apiVersion: operators.coreos.com/v1
+kind: OperatorGroup
+metadata:
+  name: lvms-operator
+  namespace: openshift-storage
+spec:
+  targetNamespaces:
+  - openshift-storage
+---
+apiVersion: operators.coreos.com/v1alpha1
+kind: Subscription
+metadata:
+  name: lvms-operator
+  namespace: openshift-storage
+spec:
+  channel: stable
+  name: lvms-operator
+  source: my-catalog
+  sourceNamespace: openshift-marketplace
+`, string(files[1].Data))
+
+	assert.Equal(t, "openshift/agent-config-lvmcluster.yaml", files[2].Filename)
+	assert.Equal(t, config.ExtraManifests[0].Content, string(files[2].Data))
+}
+
+func TestAgentConfigManifestsNoConfig(t *testing.T) {
+	files, err := AgentConfigManifests(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
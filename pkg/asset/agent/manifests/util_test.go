@@ -248,17 +248,19 @@ func getValidAgentHostsConfig() *agentconfig.AgentHosts {
 			{
 				Hostname: "control-0.example.org",
 				Role:     "master",
-				RootDeviceHints: baremetal.RootDeviceHints{
-					DeviceName:         "/dev/sda",
-					HCTL:               "hctl-value",
-					Model:              "model-value",
-					Vendor:             "vendor-value",
-					SerialNumber:       "serial-number-value",
-					MinSizeGigabytes:   20,
-					WWN:                "wwn-value",
-					WWNWithExtension:   "wwn-with-extension-value",
-					WWNVendorExtension: "wwn-vendor-extension-value",
-					Rotational:         new(bool),
+				RootDeviceHints: agenttypes.RootDeviceHints{
+					RootDeviceHints: baremetal.RootDeviceHints{
+						DeviceName:         "/dev/sda",
+						HCTL:               "hctl-value",
+						Model:              "model-value",
+						Vendor:             "vendor-value",
+						SerialNumber:       "serial-number-value",
+						MinSizeGigabytes:   20,
+						WWN:                "wwn-value",
+						WWNWithExtension:   "wwn-with-extension-value",
+						WWNVendorExtension: "wwn-vendor-extension-value",
+						Rotational:         new(bool),
+					},
 				},
 				Interfaces: []*v1beta1.Interface{
 					{
@@ -277,17 +279,19 @@ func getValidAgentHostsConfig() *agentconfig.AgentHosts {
 			{
 				Hostname: "control-1.example.org",
 				Role:     "master",
-				RootDeviceHints: baremetal.RootDeviceHints{
-					DeviceName:         "/dev/sdb",
-					HCTL:               "hctl-value",
-					Model:              "model-value",
-					Vendor:             "vendor-value",
-					SerialNumber:       "serial-number-value",
-					MinSizeGigabytes:   40,
-					WWN:                "wwn-value",
-					WWNWithExtension:   "wwn-with-extension-value",
-					WWNVendorExtension: "wwn-vendor-extension-value",
-					Rotational:         new(bool),
+				RootDeviceHints: agenttypes.RootDeviceHints{
+					RootDeviceHints: baremetal.RootDeviceHints{
+						DeviceName:         "/dev/sdb",
+						HCTL:               "hctl-value",
+						Model:              "model-value",
+						Vendor:             "vendor-value",
+						SerialNumber:       "serial-number-value",
+						MinSizeGigabytes:   40,
+						WWN:                "wwn-value",
+						WWNWithExtension:   "wwn-with-extension-value",
+						WWNVendorExtension: "wwn-vendor-extension-value",
+						Rotational:         new(bool),
+					},
 				},
 				Interfaces: []*v1beta1.Interface{
 					{
@@ -302,17 +306,19 @@ func getValidAgentHostsConfig() *agentconfig.AgentHosts {
 			{
 				Hostname: "control-2.example.org",
 				Role:     "master",
-				RootDeviceHints: baremetal.RootDeviceHints{
-					DeviceName:         "/dev/sdc",
-					HCTL:               "hctl-value",
-					Model:              "model-value",
-					Vendor:             "vendor-value",
-					SerialNumber:       "serial-number-value",
-					MinSizeGigabytes:   60,
-					WWN:                "wwn-value",
-					WWNWithExtension:   "wwn-with-extension-value",
-					WWNVendorExtension: "wwn-vendor-extension-value",
-					Rotational:         new(bool),
+				RootDeviceHints: agenttypes.RootDeviceHints{
+					RootDeviceHints: baremetal.RootDeviceHints{
+						DeviceName:         "/dev/sdc",
+						HCTL:               "hctl-value",
+						Model:              "model-value",
+						Vendor:             "vendor-value",
+						SerialNumber:       "serial-number-value",
+						MinSizeGigabytes:   60,
+						WWN:                "wwn-value",
+						WWNWithExtension:   "wwn-with-extension-value",
+						WWNVendorExtension: "wwn-vendor-extension-value",
+						Rotational:         new(bool),
+					},
 				},
 				Interfaces: []*v1beta1.Interface{
 					{
@@ -337,23 +343,34 @@ func getValidAgentConfigWithAdditionalNTPSources() *agentconfig.AgentConfig {
 	return validAC
 }
 
+func getValidAgentConfigWithMinimalHardwareRequirements() *agentconfig.AgentConfig {
+	validAC := getValidAgentConfig()
+	validAC.Config.MinimalHardwareRequirements = &agenttypes.MinimalHardwareRequirements{
+		Master: &agenttypes.Hardware{CPUCores: 2, MemoryMiB: 8192},
+		Worker: &agenttypes.Hardware{DiskSizeGB: 50},
+	}
+	return validAC
+}
+
 func getAgentHostsWithSomeHostsWithoutNetworkConfig() *agentconfig.AgentHosts {
 	return &agentconfig.AgentHosts{
 		Hosts: []agenttypes.Host{
 			{
 				Hostname: "control-0.example.org",
 				Role:     "master",
-				RootDeviceHints: baremetal.RootDeviceHints{
-					DeviceName:         "/dev/sda",
-					HCTL:               "hctl-value",
-					Model:              "model-value",
-					Vendor:             "vendor-value",
-					SerialNumber:       "serial-number-value",
-					MinSizeGigabytes:   20,
-					WWN:                "wwn-value",
-					WWNWithExtension:   "wwn-with-extension-value",
-					WWNVendorExtension: "wwn-vendor-extension-value",
-					Rotational:         new(bool),
+				RootDeviceHints: agenttypes.RootDeviceHints{
+					RootDeviceHints: baremetal.RootDeviceHints{
+						DeviceName:         "/dev/sda",
+						HCTL:               "hctl-value",
+						Model:              "model-value",
+						Vendor:             "vendor-value",
+						SerialNumber:       "serial-number-value",
+						MinSizeGigabytes:   20,
+						WWN:                "wwn-value",
+						WWNWithExtension:   "wwn-with-extension-value",
+						WWNVendorExtension: "wwn-vendor-extension-value",
+						Rotational:         new(bool),
+					},
 				},
 				Interfaces: []*v1beta1.Interface{
 					{
@@ -368,17 +385,19 @@ func getAgentHostsWithSomeHostsWithoutNetworkConfig() *agentconfig.AgentHosts {
 			{
 				Hostname: "control-1.example.org",
 				Role:     "master",
-				RootDeviceHints: baremetal.RootDeviceHints{
-					DeviceName:         "/dev/sdb",
-					HCTL:               "hctl-value",
-					Model:              "model-value",
-					Vendor:             "vendor-value",
-					SerialNumber:       "serial-number-value",
-					MinSizeGigabytes:   40,
-					WWN:                "wwn-value",
-					WWNWithExtension:   "wwn-with-extension-value",
-					WWNVendorExtension: "wwn-vendor-extension-value",
-					Rotational:         new(bool),
+				RootDeviceHints: agenttypes.RootDeviceHints{
+					RootDeviceHints: baremetal.RootDeviceHints{
+						DeviceName:         "/dev/sdb",
+						HCTL:               "hctl-value",
+						Model:              "model-value",
+						Vendor:             "vendor-value",
+						SerialNumber:       "serial-number-value",
+						MinSizeGigabytes:   40,
+						WWN:                "wwn-value",
+						WWNWithExtension:   "wwn-with-extension-value",
+						WWNVendorExtension: "wwn-vendor-extension-value",
+						Rotational:         new(bool),
+					},
 				},
 				Interfaces: []*v1beta1.Interface{
 					{
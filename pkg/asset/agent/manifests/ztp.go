@@ -0,0 +1,216 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	baremetalhost "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
+	"github.com/openshift/installer/pkg/types/agent"
+)
+
+const (
+	// ztpManifestDir is the directory of the manifests to be applied to a
+	// hub cluster running assisted-service.
+	ztpManifestDir = "ztp-manifests"
+
+	// infraEnvLabel binds a BareMetalHost to an InfraEnv.
+	infraEnvLabel = "infraenvs.agent-install.openshift.io"
+	// bmacHostnameAnnotation and bmacRoleAnnotation are copied by the
+	// BareMetalAgentController to the Agent registered for the host.
+	bmacHostnameAnnotation = "bmac.agent-install.openshift.io/hostname"
+	bmacRoleAnnotation     = "bmac.agent-install.openshift.io/role"
+	// inspectAnnotation disables the ironic inspection, the hardware is
+	// inventoried by the agent instead.
+	inspectAnnotation = "inspect.metal3.io"
+)
+
+var (
+	ztpNamespaceFilename      = filepath.Join(ztpManifestDir, "namespace.yaml")
+	ztpBareMetalHostsFilename = filepath.Join(ztpManifestDir, "baremetalhosts.yaml")
+
+	_ asset.WritableAsset = (*ZTPManifests)(nil)
+)
+
+// ZTPManifests generates the agent manifests wired for a hub cluster running
+// assisted-service: the cluster resources, their namespace, and a
+// BareMetalHost with its BMC credentials for each host of the agent config.
+type ZTPManifests struct {
+	FileList []*asset.File
+
+	Namespace      *corev1.Namespace
+	BareMetalHosts []*baremetalhost.BareMetalHost
+	BMCSecrets     []*corev1.Secret
+}
+
+// Name returns a human friendly name.
+func (z *ZTPManifests) Name() string {
+	return "ZTP Manifests"
+}
+
+// Dependencies returns all of the dependencies directly needed the asset.
+func (z *ZTPManifests) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&AgentManifests{},
+		&agentconfig.AgentHosts{},
+	}
+}
+
+// Generate generates the respective manifest files.
+func (z *ZTPManifests) Generate(_ context.Context, dependencies asset.Parents) error {
+	agentManifests := &AgentManifests{}
+	agentHosts := &agentconfig.AgentHosts{}
+	dependencies.Get(agentManifests, agentHosts)
+
+	if agentManifests.ClusterDeployment == nil || agentManifests.InfraEnv == nil {
+		return errors.New("the ClusterDeployment and InfraEnv manifests are required to generate the ZTP manifests")
+	}
+	namespace := agentManifests.ClusterDeployment.Namespace
+
+	z.Namespace = &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	}
+	namespaceData, err := k8syaml.Marshal(z.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ZTP namespace")
+	}
+	z.FileList = append(z.FileList, &asset.File{Filename: ztpNamespaceFilename, Data: namespaceData})
+
+	// The cluster manifests are applied as is, only moved to the ZTP
+	// manifests directory.
+	for _, f := range agentManifests.FileList {
+		z.FileList = append(z.FileList, &asset.File{
+			Filename: filepath.Join(ztpManifestDir, filepath.Base(f.Filename)),
+			Data:     f.Data,
+		})
+	}
+
+	var hostsData string
+	for i := range agentHosts.Hosts {
+		host := &agentHosts.Hosts[i]
+		bmh, secret, err := ztpBareMetalHost(host, i, agentManifests.InfraEnv.Name, namespace)
+		if err != nil {
+			return err
+		}
+		if bmh == nil {
+			continue
+		}
+		if secret != nil {
+			z.BMCSecrets = append(z.BMCSecrets, secret)
+			secretData, err := k8syaml.Marshal(secret)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal BMC secret")
+			}
+			hostsData = fmt.Sprint(hostsData, string(secretData), "---\n")
+		}
+		z.BareMetalHosts = append(z.BareMetalHosts, bmh)
+		bmhData, err := k8syaml.Marshal(bmh)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal BareMetalHost")
+		}
+		hostsData = fmt.Sprint(hostsData, string(bmhData), "---\n")
+	}
+	if hostsData != "" {
+		z.FileList = append(z.FileList, &asset.File{Filename: ztpBareMetalHostsFilename, Data: []byte(hostsData)})
+	}
+
+	asset.SortFiles(z.FileList)
+	return nil
+}
+
+// ztpBareMetalHost returns the BareMetalHost, and the Secret holding its BMC
+// credentials when they are inlined in the agent config, for a host. Hosts
+// without a BMC address are booted from the ISO by other means and have no
+// BareMetalHost.
+func ztpBareMetalHost(host *agent.Host, index int, infraEnvName, namespace string) (*baremetalhost.BareMetalHost, *corev1.Secret, error) {
+	if host.BMC.Address == "" {
+		return nil, nil, nil
+	}
+	name := host.Hostname
+	if name == "" {
+		name = fmt.Sprintf("%s-host-%d", infraEnvName, index)
+	}
+	if len(host.Interfaces) == 0 {
+		return nil, nil, errors.Errorf("host %s has a BMC address but no interfaces to boot from", name)
+	}
+
+	var secret *corev1.Secret
+	credentialsName := host.BMC.CredentialsName
+	if credentialsName == "" {
+		secret = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-bmc-secret", name),
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				"username": []byte(host.BMC.Username),
+				"password": []byte(host.BMC.Password),
+			},
+		}
+		credentialsName = secret.Name
+	}
+
+	annotations := map[string]string{
+		inspectAnnotation: "disabled",
+	}
+	if host.Hostname != "" {
+		annotations[bmacHostnameAnnotation] = host.Hostname
+	}
+	if host.Role != "" {
+		annotations[bmacRoleAnnotation] = host.Role
+	}
+
+	bmh := &baremetalhost.BareMetalHost{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: baremetalhost.GroupVersion.String(),
+			Kind:       "BareMetalHost",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				infraEnvLabel: infraEnvName,
+			},
+			Annotations: annotations,
+		},
+		Spec: baremetalhost.BareMetalHostSpec{
+			Online: true,
+			BMC: baremetalhost.BMCDetails{
+				Address:                        host.BMC.Address,
+				CredentialsName:                credentialsName,
+				DisableCertificateVerification: host.BMC.DisableCertificateVerification,
+			},
+			BootMACAddress:        host.Interfaces[0].MacAddress,
+			AutomatedCleaningMode: baremetalhost.CleaningModeDisabled,
+			RootDeviceHints:       host.RootDeviceHints.MakeCRDHints(),
+		},
+	}
+	return bmh, secret, nil
+}
+
+// Files returns the files generated by the asset.
+func (z *ZTPManifests) Files() []*asset.File {
+	return z.FileList
+}
+
+// Load currently does nothing
+func (z *ZTPManifests) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
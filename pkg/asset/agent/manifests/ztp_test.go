@@ -0,0 +1,108 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/agent/agentconfig"
+	"github.com/openshift/installer/pkg/types/agent"
+	"github.com/openshift/installer/pkg/types/baremetal"
+)
+
+func TestZTPManifests_Generate(t *testing.T) {
+	agentManifests := &AgentManifests{
+		FileList: []*asset.File{
+			{Filename: "cluster-manifests/infraenv.yaml", Data: []byte("infraenv")},
+		},
+		InfraEnv: &aiv1beta1.InfraEnv{
+			ObjectMeta: metav1.ObjectMeta{Name: "ostest", Namespace: "cluster0"},
+		},
+		ClusterDeployment: &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ostest", Namespace: "cluster0"},
+		},
+	}
+
+	cases := []struct {
+		name          string
+		hosts         []agent.Host
+		expectedFiles []string
+		expectedHosts []string
+		expectedError string
+	}{
+		{
+			name:          "no hosts",
+			expectedFiles: []string{"ztp-manifests/infraenv.yaml", "ztp-manifests/namespace.yaml"},
+		},
+		{
+			name: "hosts with and without BMC",
+			hosts: []agent.Host{
+				{
+					Hostname:   "master-0",
+					Role:       "master",
+					Interfaces: []*aiv1beta1.Interface{{Name: "eth0", MacAddress: "52:54:01:aa:aa:a1"}},
+					BMC:        baremetal.BMC{Address: "redfish-virtualmedia://10.0.0.1/redfish/v1/Systems/1", Username: "admin", Password: "pass"},
+				},
+				{
+					Interfaces: []*aiv1beta1.Interface{{Name: "eth0", MacAddress: "52:54:01:aa:aa:a2"}},
+					BMC:        baremetal.BMC{Address: "redfish-virtualmedia://10.0.0.2/redfish/v1/Systems/1", CredentialsName: "rack-1-bmc"},
+				},
+				{
+					Hostname:   "worker-0",
+					Interfaces: []*aiv1beta1.Interface{{Name: "eth0", MacAddress: "52:54:01:aa:aa:a3"}},
+				},
+			},
+			expectedFiles: []string{"ztp-manifests/baremetalhosts.yaml", "ztp-manifests/infraenv.yaml", "ztp-manifests/namespace.yaml"},
+			expectedHosts: []string{"master-0", "ostest-host-1"},
+		},
+		{
+			name: "BMC without interfaces",
+			hosts: []agent.Host{
+				{
+					Hostname: "master-0",
+					BMC:      baremetal.BMC{Address: "redfish-virtualmedia://10.0.0.1/redfish/v1/Systems/1", Username: "admin", Password: "pass"},
+				},
+			},
+			expectedError: "host master-0 has a BMC address but no interfaces to boot from",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(agentManifests, &agentconfig.AgentHosts{Hosts: tc.hosts})
+
+			ztp := &ZTPManifests{}
+			err := ztp.Generate(context.Background(), parents)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			var files []string
+			for _, f := range ztp.Files() {
+				files = append(files, f.Filename)
+			}
+			assert.Equal(t, tc.expectedFiles, files)
+			assert.Equal(t, "cluster0", ztp.Namespace.Name)
+
+			var hosts []string
+			for _, bmh := range ztp.BareMetalHosts {
+				hosts = append(hosts, bmh.Name)
+				assert.Equal(t, "ostest", bmh.Labels[infraEnvLabel])
+				assert.Equal(t, "cluster0", bmh.Namespace)
+			}
+			assert.Equal(t, tc.expectedHosts, hosts)
+			if len(tc.expectedHosts) > 0 {
+				assert.Len(t, ztp.BMCSecrets, 1)
+				assert.Equal(t, "master-0-bmc-secret", ztp.BareMetalHosts[0].Spec.BMC.CredentialsName)
+				assert.Equal(t, "rack-1-bmc", ztp.BareMetalHosts[1].Spec.BMC.CredentialsName)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package mirror
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -138,7 +139,7 @@ func (*RegistriesConf) Dependencies() []asset.Asset {
 }
 
 // Generate generates the registries.conf file from install-config.
-func (i *RegistriesConf) Generate(dependencies asset.Parents) error {
+func (i *RegistriesConf) Generate(_ context.Context, dependencies asset.Parents) error {
 	agentWorkflow := &workflow.AgentWorkflow{}
 	clusterInfo := &joiner.ClusterInfo{}
 	installConfig := &agent.OptionalInstallConfig{}
@@ -238,7 +239,7 @@ func (i *RegistriesConf) Files() []*asset.File {
 func (i *RegistriesConf) Load(f asset.FileFetcher) (bool, error) {
 
 	releaseImage := &releaseimage.Image{}
-	releaseImage.Generate(asset.Parents{})
+	releaseImage.Generate(context.TODO(), asset.Parents{})
 
 	file, err := f.FetchByName(RegistriesConfFilename)
 	if err != nil {
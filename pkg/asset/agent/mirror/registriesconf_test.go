@@ -1,6 +1,7 @@
 package mirror
 
 import (
+	"context"
 	"errors"
 	"os"
 	"testing"
@@ -257,7 +258,7 @@ func TestRegistriesConf_Generate(t *testing.T) {
 			parents.Add(tc.dependencies...)
 
 			asset := &RegistriesConf{}
-			err := asset.Generate(parents)
+			err := asset.Generate(context.Background(), parents)
 
 			assert.NoError(t, err)
 
@@ -1,10 +1,12 @@
 package asset
 
 import (
+	"context"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -24,12 +26,25 @@ type Asset interface {
 	Dependencies() []Asset
 
 	// Generate generates this asset given the states of its parent assets.
-	Generate(Parents) error
+	// The context is cancelled when the user interrupts the installer or
+	// the asset exceeds its generation timeout, so any call to a remote
+	// API should be made with it.
+	Generate(context.Context, Parents) error
 
 	// Name returns the human-friendly name of the asset.
 	Name() string
 }
 
+// TimedAsset is an Asset whose generation calls remote APIs that may hang.
+// The store cancels the context of Generate when the generation exceeds the
+// timeout of the asset.
+type TimedAsset interface {
+	Asset
+
+	// GenerateTimeout returns how long Generate may run.
+	GenerateTimeout() time.Duration
+}
+
 // WritableAsset is an Asset that has files that can be written to disk.
 // It can also be loaded from disk.
 type WritableAsset interface {
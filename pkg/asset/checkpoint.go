@@ -0,0 +1,122 @@
+package asset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// StateFileName is the name of the file in the install dir where the
+	// state of the assets is stored.
+	StateFileName = ".openshift_install_state.json"
+
+	// CheckpointsKey is the key of the checkpoints in the state file. It
+	// cannot collide with the keys of the assets, which are Go type names.
+	CheckpointsKey = "checkpoints"
+)
+
+// Checkpoints records the infrastructure provisioning stages completed by
+// `create cluster`. They are persisted in the state file as soon as a stage
+// completes, so that an interrupted run can be resumed from the last
+// completed stage.
+type Checkpoints struct {
+	dir string
+
+	// Stages are the names of the completed stages, in completion order.
+	Stages []string `json:"stages"`
+}
+
+// LoadCheckpoints returns the checkpoints recorded in the state file of the
+// given directory.
+func LoadCheckpoints(dir string) (*Checkpoints, error) {
+	c := &Checkpoints{dir: dir}
+	state, err := ReadStateFile(filepath.Join(dir, StateFileName))
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := state[CheckpointsKey]; ok {
+		if err := json.Unmarshal(raw, c); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal checkpoints")
+		}
+	}
+	return c, nil
+}
+
+// Any returns true if any stage was completed.
+func (c *Checkpoints) Any() bool {
+	return len(c.Stages) > 0
+}
+
+// Completed returns true if the named stage was completed.
+func (c *Checkpoints) Completed(stage string) bool {
+	return slices.Contains(c.Stages, stage)
+}
+
+// Complete records that the named stage was completed.
+func (c *Checkpoints) Complete(stage string) error {
+	if c.Completed(stage) {
+		return nil
+	}
+	c.Stages = append(c.Stages, stage)
+	return c.save()
+}
+
+// Clear removes the checkpoints from the state file, once the infrastructure
+// has been entirely provisioned.
+func (c *Checkpoints) Clear() error {
+	c.Stages = nil
+	return c.save()
+}
+
+func (c *Checkpoints) save() error {
+	path := filepath.Join(c.dir, StateFileName)
+	state, err := ReadStateFile(path)
+	if err != nil {
+		return err
+	}
+	if len(c.Stages) == 0 {
+		delete(state, CheckpointsKey)
+	} else {
+		data, err := json.MarshalIndent(c, "", "    ")
+		if err != nil {
+			return err
+		}
+		state[CheckpointsKey] = data
+	}
+	return errors.Wrap(WriteStateFile(path, state), "failed to save checkpoints")
+}
+
+// ReadStateFile returns the contents of the state file, or an empty map if
+// the file does not exist.
+func ReadStateFile(path string) (map[string]json.RawMessage, error) {
+	assets := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return assets, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &assets); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal state file %q", path)
+	}
+	return assets, nil
+}
+
+// WriteStateFile writes the contents of the state file. It is only readable
+// by its owner, since the assets it records, such as the install-config and
+// the ignition configs, hold the pull secret in plaintext.
+func WriteStateFile(path string, assets map[string]json.RawMessage) error {
+	data, err := json.MarshalIndent(assets, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,39 @@
+package asset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, StateFileName)
+	assert.NoError(t, os.WriteFile(statePath, []byte(`{"*installconfig.ClusterID": {"InfraID": "test-abcde"}}`), 0o640))
+
+	checkpoints, err := LoadCheckpoints(dir)
+	assert.NoError(t, err)
+	assert.False(t, checkpoints.Any())
+
+	assert.NoError(t, checkpoints.Complete("cluster"))
+	assert.NoError(t, checkpoints.Complete("bootstrap"))
+	assert.NoError(t, checkpoints.Complete("cluster"))
+
+	checkpoints, err = LoadCheckpoints(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cluster", "bootstrap"}, checkpoints.Stages)
+	assert.True(t, checkpoints.Completed("bootstrap"))
+	assert.False(t, checkpoints.Completed("post-bootstrap"))
+
+	assert.NoError(t, checkpoints.Clear())
+	state, err := ReadStateFile(statePath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]json.RawMessage{
+		"*installconfig.ClusterID": json.RawMessage(`{
+        "InfraID": "test-abcde"
+    }`),
+	}, state)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/quota"
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	infra "github.com/openshift/installer/pkg/infrastructure/platform"
+	"github.com/openshift/installer/pkg/types"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	typesazure "github.com/openshift/installer/pkg/types/azure"
 	typesopenstack "github.com/openshift/installer/pkg/types/openstack"
@@ -42,7 +43,6 @@ type Cluster struct {
 }
 
 var _ asset.WritableAsset = (*Cluster)(nil)
-var _ asset.Generator = (*Cluster)(nil)
 
 // Name returns the human-friendly name of the asset.
 func (c *Cluster) Name() string {
@@ -62,6 +62,9 @@ func (c *Cluster) Dependencies() []asset.Asset {
 		&installconfig.PlatformCredsCheck{},
 		&installconfig.PlatformPermsCheck{},
 		&installconfig.PlatformProvisionCheck{},
+		// CredentialsModeCheck verifies the credentials provided in the
+		// manifests for the Manual credentials mode.
+		&manifests.CredentialsModeCheck{},
 		new(rhcos.Image),
 		&quota.PlatformQuotaCheck{},
 		&tfvars.TerraformVariables{},
@@ -71,6 +74,7 @@ func (c *Cluster) Dependencies() []asset.Asset {
 		&kubeconfig.AdminClient{},
 		&bootstrap.Bootstrap{},
 		&machine.Master{},
+		&machine.Bastion{},
 		&machines.Worker{},
 		&machines.ClusterAPI{},
 		new(rhcos.Image),
@@ -79,7 +83,7 @@ func (c *Cluster) Dependencies() []asset.Asset {
 }
 
 // Generate launches the cluster and generates the terraform state file on disk.
-func (c *Cluster) GenerateWithContext(ctx context.Context, parents asset.Parents) (err error) {
+func (c *Cluster) Generate(ctx context.Context, parents asset.Parents) (err error) {
 	if InstallDir == "" {
 		logrus.Fatalf("InstallDir has not been set for the %q asset", c.Name())
 	}
@@ -102,22 +106,29 @@ func (c *Cluster) GenerateWithContext(ctx context.Context, parents asset.Parents
 		return errors.New("cluster cannot be created with bootstrapInPlace set")
 	}
 
-	platform := installConfig.Config.Platform.Name()
-
-	if azure := installConfig.Config.Platform.Azure; azure != nil && azure.CloudName == typesazure.StackCloud {
-		platform = typesazure.StackTerraformName
+	// The resources of an interrupted run are adopted when it is resumed.
+	checkpoints, err := asset.LoadCheckpoints(InstallDir)
+	if err != nil {
+		return err
+	}
+	if !checkpoints.Any() {
+		if err := installconfig.ValidateClusterConflicts(ctx, installConfig, clusterID.InfraID); err != nil {
+			return err
+		}
 	}
 
+	platform := provisioningPlatform(installConfig.Config)
+
 	// TODO(padillon): determine whether CAPI handles tagging shared subnets, in which case we should be able
 	// to encapsulate these into the terraform package.
 	logrus.Infof("Creating infrastructure resources...")
 	switch platform {
 	case typesaws.Name:
-		if err := aws.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+		if err := aws.PreTerraform(ctx, clusterID.InfraID, installConfig); err != nil {
 			return err
 		}
 	case typesazure.Name, typesazure.StackTerraformName:
-		if err := azure.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
+		if err := azure.PreTerraform(ctx, clusterID.InfraID, installConfig); err != nil {
 			return err
 		}
 	case typesopenstack.Name:
@@ -128,7 +139,7 @@ func (c *Cluster) GenerateWithContext(ctx context.Context, parents asset.Parents
 				break
 			}
 		}
-		if err := openstack.PreTerraform(context.TODO(), tfvarsFile, installConfig, clusterID, rhcosImage); err != nil {
+		if err := openstack.PreTerraform(ctx, tfvarsFile, installConfig, clusterID, rhcosImage); err != nil {
 			return err
 		}
 	}
@@ -148,14 +159,35 @@ func (c *Cluster) GenerateWithContext(ctx context.Context, parents asset.Parents
 	return nil
 }
 
+// provisioningPlatform returns the name of the platform the infrastructure
+// is provisioned for. Azure Stack Hub has its own Terraform stages.
+func provisioningPlatform(config *types.InstallConfig) string {
+	if azure := config.Platform.Azure; azure != nil && azure.CloudName == typesazure.StackCloud {
+		return typesazure.StackTerraformName
+	}
+	return config.Platform.Name()
+}
+
 // Files returns the FileList generated by the asset.
 func (c *Cluster) Files() []*asset.File {
 	return c.FileList
 }
 
 // Load returns error if the tfstate file is already on-disk, because we want to
-// prevent user from accidentally re-launching the cluster.
+// prevent user from accidentally re-launching the cluster. The tfstate files
+// of an interrupted run are expected though, as provisioning resumes from them.
 func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
+	if InstallDir != "" {
+		checkpoints, err := asset.LoadCheckpoints(InstallDir)
+		if err != nil {
+			return true, err
+		}
+		if checkpoints.Any() {
+			logrus.Infof("Resuming the creation of the cluster after the %q stage", checkpoints.Stages[len(checkpoints.Stages)-1])
+			return false, nil
+		}
+	}
+
 	matches, err := filepath.Glob("terraform(.*)?.tfstate")
 	if err != nil {
 		return true, err
@@ -166,9 +198,3 @@ func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 
 	return false, nil
 }
-
-// Generate is implemented so the Cluster Asset maintains compatibility
-// with the Asset interface. It should never be called.
-func (c *Cluster) Generate(_ asset.Parents) (err error) {
-	panic("Cluster.Generate was called instead of Cluster.GenerateWithContext")
-}
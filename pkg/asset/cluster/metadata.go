@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/pkg/errors"
@@ -21,6 +22,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/cluster/vsphere"
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
@@ -57,14 +59,16 @@ func (m *Metadata) Dependencies() []asset.Asset {
 		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
 		&bootstrap.Bootstrap{},
+		&tls.CoreSSHKeyPair{},
 	}
 }
 
 // Generate generates the metadata asset.
-func (m *Metadata) Generate(parents asset.Parents) (err error) {
+func (m *Metadata) Generate(_ context.Context, parents asset.Parents) (err error) {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
-	parents.Get(clusterID, installConfig)
+	coreSSHKeyPair := &tls.CoreSSHKeyPair{}
+	parents.Get(clusterID, installConfig, coreSSHKeyPair)
 
 	featureSet := installConfig.Config.FeatureSet
 	var customFS *configv1.CustomFeatureGates
@@ -73,12 +77,17 @@ func (m *Metadata) Generate(parents asset.Parents) (err error) {
 	}
 
 	metadata := &types.ClusterMetadata{
+		Version:          types.ClusterMetadataVersion,
 		ClusterName:      installConfig.Config.ObjectMeta.Name,
 		ClusterID:        clusterID.UUID,
 		InfraID:          clusterID.InfraID,
 		FeatureSet:       featureSet,
 		CustomFeatureSet: customFS,
 	}
+	if len(coreSSHKeyPair.Files()) > 0 {
+		metadata.SSHPrivateKeyPath = tls.CoreSSHKeyPairPrivateKeyPath
+	}
+	metadata.Tags = userTags(installConfig.Config)
 
 	switch installConfig.Config.Platform.Name() {
 	case awstypes.Name:
@@ -121,6 +130,24 @@ func (m *Metadata) Generate(parents asset.Parents) (err error) {
 	return nil
 }
 
+// userTags returns the user tags, or GCP user labels, added to the cluster
+// resources.
+func userTags(config *types.InstallConfig) map[string]string {
+	var tags map[string]string
+	switch {
+	case config.Platform.AWS != nil && len(config.Platform.AWS.UserTags) > 0:
+		tags = config.Platform.AWS.UserTags
+	case config.Platform.Azure != nil && len(config.Platform.Azure.UserTags) > 0:
+		tags = config.Platform.Azure.UserTags
+	case config.Platform.GCP != nil && len(config.Platform.GCP.UserLabels) > 0:
+		tags = make(map[string]string, len(config.Platform.GCP.UserLabels))
+		for _, label := range config.Platform.GCP.UserLabels {
+			tags[label.Key] = label.Value
+		}
+	}
+	return tags
+}
+
 // Files returns the metadata file generated by the asset.
 func (m *Metadata) Files() []*asset.File {
 	if m.File != nil {
@@ -5,13 +5,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/types"
 )
 
 const (
 	// FileName is the filename for the cluster metadata.json file.
 	FileName = "metadata.json"
+
+	// versionV1 is the version of metadata written before the schema was
+	// versioned.
+	versionV1 = "v1"
 )
 
 // Load loads the cluster metadata from an asset directory.
@@ -22,10 +28,77 @@ func Load(dir string) (*types.ClusterMetadata, error) {
 		return nil, err
 	}
 
+	metadata, err := Unmarshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", path, err)
+	}
+	return metadata, nil
+}
+
+// Unmarshal parses metadata.json data. Metadata written before the schema
+// was versioned is returned with version v1, and metadata written by a
+// newer installer with an unknown schema version is rejected.
+func Unmarshal(data []byte) (*types.ClusterMetadata, error) {
 	var metadata *types.ClusterMetadata
-	if err = json.Unmarshal(raw, &metadata); err != nil {
-		return nil, fmt.Errorf("failed to Unmarshal data from %q to types.ClusterMetadata: %w", path, err)
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to Unmarshal data to types.ClusterMetadata: %w", err)
+	}
+	if metadata == nil {
+		return nil, fmt.Errorf("cluster metadata is empty")
+	}
+
+	switch metadata.Version {
+	case "":
+		metadata.Version = versionV1
+	case versionV1, types.ClusterMetadataVersion:
+	default:
+		return nil, fmt.Errorf("cluster metadata version %q is not supported, the latest supported version is %q", metadata.Version, types.ClusterMetadataVersion)
+	}
+	return metadata, nil
+}
+
+// WithResources returns the metadata.json file of the asset directory with
+// the identifiers of the cluster resources recorded in it, so that the
+// resources do not need to be discovered again by their tags. The file is
+// expected to be returned by the infrastructure provider, and written by the
+// Cluster asset.
+func WithResources(dir string, resources *types.ClusterResources) (*asset.File, error) {
+	metadata, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata.Version = types.ClusterMetadataVersion
+	metadata.Resources = resources
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to Marshal ClusterMetadata: %w", err)
 	}
+	return &asset.File{Filename: FileName, Data: data}, nil
+}
 
-	return metadata, err
+// productionTagKeys and productionTagValues are the tag keys and values, in
+// lower case, marking a cluster as production.
+var (
+	productionTagKeys   = []string{"environment", "env"}
+	productionTagValues = []string{"production", "prod"}
+)
+
+// IsProduction returns true if the cluster is tagged as production, e.g.
+// with environment=production, so that destroying it must be confirmed.
+func IsProduction(metadata *types.ClusterMetadata) bool {
+	for key, value := range metadata.Tags {
+		key, value = strings.ToLower(key), strings.ToLower(value)
+		for _, k := range productionTagKeys {
+			if key != k {
+				continue
+			}
+			for _, v := range productionTagValues {
+				if value == v {
+					return true
+				}
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,117 @@
+package metadata
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestUnmarshal(t *testing.T) {
+	cases := []struct {
+		name            string
+		data            string
+		expectedVersion string
+		expectedError   string
+	}{
+		{
+			name:            "unversioned",
+			data:            `{"clusterName":"test-cluster","infraID":"test-cluster-abcde"}`,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "v1",
+			data:            `{"version":"v1","clusterName":"test-cluster","infraID":"test-cluster-abcde"}`,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "v2",
+			data:            `{"version":"v2","clusterName":"test-cluster","infraID":"test-cluster-abcde","resources":{"networkID":"vpc-1"}}`,
+			expectedVersion: "v2",
+		},
+		{
+			name:          "unknown version",
+			data:          `{"version":"v3","clusterName":"test-cluster","infraID":"test-cluster-abcde"}`,
+			expectedError: `cluster metadata version "v3" is not supported, the latest supported version is "v2"`,
+		},
+		{
+			name:          "empty",
+			data:          `null`,
+			expectedError: "cluster metadata is empty",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := Unmarshal([]byte(tc.data))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, metadata.Version)
+			assert.Equal(t, "test-cluster-abcde", metadata.InfraID)
+		})
+	}
+}
+
+func TestWithResources(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"clusterName":"test-cluster","infraID":"test-cluster-abcde"}`), 0o600)
+	assert.NoError(t, err)
+
+	resources := &types.ClusterResources{
+		NetworkID: "vpc-1",
+		SubnetIDs: []string{"subnet-1", "subnet-2"},
+	}
+	file, err := WithResources(dir, resources)
+	assert.NoError(t, err)
+	assert.Equal(t, FileName, file.Filename)
+
+	var metadata types.ClusterMetadata
+	assert.NoError(t, json.Unmarshal(file.Data, &metadata))
+	assert.Equal(t, types.ClusterMetadataVersion, metadata.Version)
+	assert.Equal(t, "test-cluster-abcde", metadata.InfraID)
+	assert.Equal(t, resources, metadata.Resources)
+}
+
+func TestIsProduction(t *testing.T) {
+	cases := []struct {
+		name     string
+		tags     map[string]string
+		expected bool
+	}{
+		{
+			name:     "no tags",
+			expected: false,
+		},
+		{
+			name:     "environment production",
+			tags:     map[string]string{"owner": "team-a", "environment": "production"},
+			expected: true,
+		},
+		{
+			name:     "Env Prod",
+			tags:     map[string]string{"Env": "Prod"},
+			expected: true,
+		},
+		{
+			name:     "environment staging",
+			tags:     map[string]string{"environment": "staging"},
+			expected: false,
+		},
+		{
+			name:     "production owner",
+			tags:     map[string]string{"owner": "production"},
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsProduction(&types.ClusterMetadata{Tags: tc.tags}))
+		})
+	}
+}
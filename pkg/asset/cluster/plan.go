@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster/tfvars"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/infrastructure"
+	infra "github.com/openshift/installer/pkg/infrastructure/platform"
+)
+
+// Plan previews the infrastructure resources that launching the cluster
+// would create, without creating them.
+type Plan struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Plan)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (p *Plan) Name() string {
+	return "Infrastructure Plan"
+}
+
+// Dependencies returns the direct dependencies for planning the
+// infrastructure.
+func (p *Plan) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		// PlatformCredsCheck verifies the credentials the plan refreshes
+		// the existing resources with.
+		&installconfig.PlatformCredsCheck{},
+		&tfvars.TerraformVariables{},
+	}
+}
+
+// Generate plans the infrastructure of the cluster. The resources that
+// launching the cluster adjusts before provisioning, such as the tags of
+// shared subnets, are not part of the plan.
+func (p *Plan) Generate(ctx context.Context, parents asset.Parents) error {
+	if InstallDir == "" {
+		logrus.Fatalf("InstallDir has not been set for the %q asset", p.Name())
+	}
+
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	platform := provisioningPlatform(installConfig.Config)
+	provider, err := infra.ProviderForPlatform(platform, installConfig.Config.EnabledFeatureGates())
+	if err != nil {
+		return fmt.Errorf("error getting infrastructure provider: %w", err)
+	}
+	planner, ok := provider.(infrastructure.Planner)
+	if !ok {
+		return fmt.Errorf("the infrastructure of platform %q is not provisioned with Terraform and cannot be planned", platform)
+	}
+
+	logrus.Infof("Planning infrastructure resources...")
+	files, err := planner.Plan(ctx, InstallDir, parents)
+	if err != nil {
+		return err
+	}
+	p.FileList = files
+	return nil
+}
+
+// Files returns the FileList generated by the asset.
+func (p *Plan) Files() []*asset.File {
+	return p.FileList
+}
+
+// Load returns false since the plan is always generated again.
+func (p *Plan) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
@@ -124,8 +124,7 @@ func (t *TerraformVariables) Dependencies() []asset.Asset {
 // Generate generates the terraform.tfvars file.
 //
 //nolint:gocyclo // legacy, pre-linter cyclomatic complexity
-func (t *TerraformVariables) Generate(parents asset.Parents) error {
-	ctx := context.TODO()
+func (t *TerraformVariables) Generate(ctx context.Context, parents asset.Parents) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	bootstrapIgnAsset := &bootstrap.Bootstrap{}
@@ -283,7 +282,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		for i, m := range workers {
 			workerConfigs[i] = m.Spec.Template.Spec.ProviderSpec.Value.Object.(*machinev1beta1.AWSMachineProviderConfig) //nolint:errcheck // legacy, pre-linter
 		}
-		osImage := strings.SplitN(string(*rhcosImage), ",", 2)
+		osImage := strings.SplitN(rhcosImage.ControlPlane, ",", 2)
 		osImageID := osImage[0]
 		osImageRegion := installConfig.Config.AWS.Region
 		if len(osImage) == 2 {
@@ -303,11 +302,15 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			securityGroups = mp.AdditionalSecurityGroupIDs
 		}
 		masterIAMRoleName := ""
+		var masterHostPlacement *aws.HostPlacement
+		masterCapacityReservationID := ""
 		if mp := installConfig.Config.ControlPlane; mp != nil {
 			awsMP := &aws.MachinePool{}
 			awsMP.Set(installConfig.Config.AWS.DefaultMachinePlatform)
 			awsMP.Set(mp.Platform.AWS)
 			masterIAMRoleName = awsMP.IAMRole
+			masterHostPlacement = awsMP.HostPlacement
+			masterCapacityReservationID = awsMP.CapacityReservationID
 			if len(awsMP.AdditionalSecurityGroupIDs) > 0 {
 				securityGroups = awsMP.AdditionalSecurityGroupIDs
 			}
@@ -342,6 +345,11 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			PreserveBootstrapIgnition: installConfig.Config.AWS.PreserveBootstrapIgnition,
 			MasterSecurityGroups:      securityGroups,
 			PublicIpv4Pool:            installConfig.Config.AWS.PublicIpv4Pool,
+			IPFamily:                  installConfig.Config.AWS.IPFamily,
+			IPv6Egress:                installConfig.Config.AWS.IPv6Egress,
+			MasterHostPlacement:       masterHostPlacement,
+			MasterReservationID:       masterCapacityReservationID,
+			APILoadBalancer:           installConfig.Config.AWS.APILoadBalancer,
 		})
 		if err != nil {
 			return errors.Wrapf(err, "failed to get %s Terraform variables", platform)
@@ -389,7 +397,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			workerConfigs[i] = w.Spec.Template.Spec.ProviderSpec.Value.Object.(*machinev1beta1.AzureMachineProviderSpec) //nolint:errcheck // legacy, pre-linter
 		}
 		client := aztypes.NewClient(session)
-		hyperVGeneration, err := client.GetHyperVGenerationVersion(context.TODO(), masterConfigs[0].VMSize, masterConfigs[0].Location, "")
+		hyperVGeneration, err := client.GetHyperVGenerationVersion(ctx, masterConfigs[0].VMSize, masterConfigs[0].Location, "")
 		if err != nil {
 			return err
 		}
@@ -428,11 +436,12 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				Auth:                            auth,
 				CloudName:                       installConfig.Config.Azure.CloudName,
 				ARMEndpoint:                     installConfig.Config.Azure.ARMEndpoint,
+				ServiceEndpoints:                installConfig.Config.Azure.ServiceEndpoints,
 				ResourceGroupName:               installConfig.Config.Azure.ResourceGroupName,
 				BaseDomainResourceGroupName:     installConfig.Config.Azure.BaseDomainResourceGroupName,
 				MasterConfigs:                   masterConfigs,
 				WorkerConfigs:                   workerConfigs,
-				ImageURL:                        string(*rhcosImage),
+				ImageURL:                        rhcosImage.ControlPlane,
 				ImageRelease:                    rhcosRelease.GetAzureReleaseVersion(),
 				PreexistingNetwork:              preexistingnetwork,
 				Publish:                         installConfig.Config.Publish,
@@ -466,7 +475,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			ServiceAccount:   string(sess.Credentials.JSON),
 		}
 
-		client, err := gcpconfig.NewClient(context.Background())
+		client, err := gcpconfig.NewClient(ctx, installConfig.Config.GCP.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -474,7 +483,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		// In the case of a shared vpn, the firewall rules should only be created if the user has permissions to do so
 		createFirewallRules := true
 		if installConfig.Config.GCP.NetworkProjectID != "" {
-			permissions, err := client.GetProjectPermissions(context.Background(), installConfig.Config.GCP.NetworkProjectID, []string{
+			permissions, err := client.GetProjectPermissions(ctx, installConfig.Config.GCP.NetworkProjectID, []string{
 				GCPFirewallPermission,
 			})
 			if err != nil {
@@ -517,7 +526,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		publicZoneName := ""
 		privateZoneName := ""
 
-		if installConfig.Config.GCP.UserProvisionedDNS != gcp.UserProvisionedDNSEnabled {
+		if !installConfig.Config.ClusterHostedDNS() {
 			if installConfig.Config.Publish == types.ExternalPublishingStrategy {
 				publicZone, err := client.GetDNSZone(ctx, installConfig.Config.GCP.ProjectID, installConfig.Config.BaseDomain, true)
 				if err != nil {
@@ -537,17 +546,22 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			}
 		}
 
-		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 		defer cancel()
 
-		url, err := gcpbootstrap.CreateSignedURL(clusterID.InfraID)
-		if err != nil {
-			return fmt.Errorf("failed to provision gcp bootstrap storage resources: %w", err)
-		}
+		// With the inline delivery, the shim embeds the bootstrap ignition
+		// and is generated when it is final.
+		var shim []byte
+		if installConfig.Config.BootstrapIgnitionDelivery() != types.BootstrapIgnitionDeliveryInline {
+			url, err := gcpbootstrap.CreateSignedURL(clusterID.InfraID, installConfig.Config.GCP.ServiceEndpoints)
+			if err != nil {
+				return fmt.Errorf("failed to provision gcp bootstrap storage resources: %w", err)
+			}
 
-		shim, err := bootstrap.GenerateIgnitionShimWithCertBundleAndProxy(url, installConfig.Config.AdditionalTrustBundle, installConfig.Config.Proxy)
-		if err != nil {
-			return fmt.Errorf("failed to create gcp ignition shim: %w", err)
+			shim, err = bootstrap.GenerateIgnitionShimWithCertBundleAndProxy(url, installConfig.Config.AdditionalTrustBundle, installConfig.Config.Proxy)
+			if err != nil {
+				return fmt.Errorf("failed to create gcp ignition shim: %w", err)
+			}
 		}
 
 		archName := coreosarch.RpmArch(string(installConfig.Config.ControlPlane.Architecture))
@@ -583,10 +597,14 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				PrivateZoneName:     privateZoneName,
 				PublishStrategy:     installConfig.Config.Publish,
 				InfrastructureName:  clusterID.InfraID,
-				UserProvisionedDNS:  installConfig.Config.GCP.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled,
+				UserProvisionedDNS:  installConfig.Config.ClusterHostedDNS(),
 				UserTags:            tags,
 				IgnitionShim:        string(shim),
 				PresignedURL:        url,
+				ServiceEndpoints:    installConfig.Config.GCP.ServiceEndpoints,
+				GoogleAPIAccess:     installConfig.Config.GCP.GoogleAPIAccess,
+				// compact clusters are rejected above, so there is at least one compute pool
+				WorkerServiceAccount: gcpconfig.ComputeServiceAccount(installConfig.Config, &installConfig.Config.Compute[0]),
 			},
 		)
 		if err != nil {
@@ -742,7 +760,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				CISInstanceCRN:             cisCRN,
 				DNSInstanceID:              dnsID,
 				EndpointsJSONFile:          endpointsJSONFile,
-				ImageURL:                   string(*rhcosImage),
+				ImageURL:                   rhcosImage.ControlPlane,
 				MasterConfigs:              masterConfigs,
 				MasterDedicatedHosts:       masterDedicatedHosts,
 				NetworkResourceGroupName:   installConfig.Config.Platform.IBMCloud.NetworkResourceGroupName,
@@ -767,6 +785,9 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		if err != nil {
 			return err
 		}
+		controlPlanePool := libvirt.MachinePool{}
+		controlPlanePool.Set(installConfig.Config.Platform.Libvirt.DefaultMachinePlatform)
+		controlPlanePool.Set(installConfig.Config.ControlPlane.Platform.Libvirt)
 		// convert options list to a list of mappings which can be consumed by terraform
 		var dnsmasqoptions []map[string]string
 		for _, option := range installConfig.Config.Platform.Libvirt.Network.DnsmasqOptions {
@@ -779,12 +800,15 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		data, err = libvirttfvars.TFVars(
 			libvirttfvars.TFVarsSources{
 				MasterConfig:   masters[0].Spec.ProviderSpec.Value.Object.(*libvirtprovider.LibvirtMachineProviderConfig),
-				OsImage:        string(*rhcosImage),
+				OsImage:        rhcosImage.ControlPlane,
 				MachineCIDR:    &installConfig.Config.Networking.MachineNetwork[0].CIDR.IPNet,
 				Bridge:         installConfig.Config.Platform.Libvirt.Network.IfName,
 				MasterCount:    masterCount,
 				Architecture:   installConfig.Config.ControlPlane.Architecture,
 				DnsmasqOptions: dnsmasqoptions,
+
+				AdditionalNetworks: controlPlanePool.AdditionalNetworks,
+				HostDevices:        controlPlanePool.HostDevices,
 			},
 		)
 		if err != nil {
@@ -799,7 +823,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			installConfig,
 			mastersAsset,
 			workersAsset,
-			string(*rhcosImage),
+			rhcosImage.ControlPlane,
 			clusterID,
 			bootstrapIgn,
 		)
@@ -811,12 +835,19 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			Data:     data,
 		})
 	case baremetal.Name:
+		// Without a provisioning network, the bootstrap VM serves Ironic on
+		// the external network only and must not be attached to a
+		// provisioning bridge.
+		provisioningBridge := installConfig.Config.Platform.BareMetal.ProvisioningBridge
+		if installConfig.Config.Platform.BareMetal.ProvisioningNetwork == baremetal.DisabledProvisioningNetwork {
+			provisioningBridge = ""
+		}
 		data, err = baremetaltfvars.TFVars(
 			installConfig.Config.Platform.BareMetal.LibvirtURI,
 			string(*rhcosBootstrapImage),
 			installConfig.Config.Platform.BareMetal.ExternalBridge,
 			installConfig.Config.Platform.BareMetal.ExternalMACAddress,
-			installConfig.Config.Platform.BareMetal.ProvisioningBridge,
+			provisioningBridge,
 			installConfig.Config.Platform.BareMetal.ProvisioningMACAddress,
 		)
 		if err != nil {
@@ -871,7 +902,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			installConfig.Config.Platform.Ovirt.StorageDomainID,
 			installConfig.Config.Platform.Ovirt.NetworkName,
 			installConfig.Config.Platform.Ovirt.VNICProfileID,
-			string(*rhcosImage),
+			rhcosImage.ControlPlane,
 			clusterID.InfraID,
 			masters[0].Spec.ProviderSpec.Value.Object.(*ovirtprovider.OvirtMachineProviderSpec),
 			installConfig.Config.Platform.Ovirt.AffinityGroups,
@@ -1009,7 +1040,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			return err
 		}
 
-		osImage := strings.SplitN(string(*rhcosImage), "/", 2)
+		osImage := strings.SplitN(rhcosImage.ControlPlane, "/", 2)
 		data, err = powervstfvars.TFVars(
 			powervstfvars.TFVarsSources{
 				MasterConfigs:          masterConfigs,
@@ -1046,10 +1077,10 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 
 	case vsphere.Name:
 		networkFailureDomainMap := make(map[string]string)
-		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 		defer cancel()
 
-		vim25Client, _, cleanup, err := vsphereconfig.CreateVSphereClients(context.TODO(),
+		vim25Client, _, cleanup, err := vsphereconfig.CreateVSphereClients(ctx,
 			installConfig.Config.VSphere.VCenters[0].Server,
 			installConfig.Config.VSphere.VCenters[0].Username,
 			installConfig.Config.VSphere.VCenters[0].Password)
@@ -1125,7 +1156,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		data, err = vspheretfvars.TFVars(
 			vspheretfvars.TFVarsSources{
 				ControlPlaneConfigs:     controlPlaneConfigs,
-				ImageURL:                string(*rhcosImage),
+				ImageURL:                rhcosImage.ControlPlane,
 				DiskType:                installConfig.Config.Platform.VSphere.DiskType,
 				NetworksInFailureDomain: networkFailureDomainMap,
 				InfraID:                 clusterID.InfraID,
@@ -1151,7 +1182,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			controlPlaneConfigs[i] = c.Spec.ProviderSpec.Value.Object.(*machinev1.NutanixMachineProviderConfig) //nolint:errcheck // legacy, pre-linter
 		}
 
-		imgURI := string(*rhcosImage)
+		imgURI := rhcosImage.ControlPlane
 		if installConfig.Config.Nutanix.ClusterOSImage != "" {
 			imgURI = installConfig.Config.Nutanix.ClusterOSImage
 		}
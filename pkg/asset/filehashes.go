@@ -0,0 +1,80 @@
+package asset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileHashesKey is the key of the file hashes in the state file. It cannot
+// collide with the keys of the assets, which are Go type names.
+const FileHashesKey = "fileHashes"
+
+// FileHashes maps the files written by the installer to the target
+// directory to the SHA-256 of the content written. They mark the files as
+// owned by the installer, so that the edits made to them afterwards can be
+// told apart from the files as they were generated.
+type FileHashes map[string]string
+
+// HashFileData returns the hash of the file content recorded in the file
+// hashes.
+func HashFileData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadFileHashes returns the file hashes recorded in the state file of the
+// given directory.
+func LoadFileHashes(dir string) (FileHashes, error) {
+	hashes := FileHashes{}
+	state, err := ReadStateFile(filepath.Join(dir, StateFileName))
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := state[FileHashesKey]; ok {
+		if err := json.Unmarshal(raw, &hashes); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal file hashes")
+		}
+	}
+	return hashes, nil
+}
+
+// RecordFileHashes records the hashes of the files written to the given
+// directory in its state file.
+func RecordFileHashes(dir string, files []*File) error {
+	path := filepath.Join(dir, StateFileName)
+	state, err := ReadStateFile(path)
+	if err != nil {
+		return err
+	}
+	hashes := FileHashes{}
+	if raw, ok := state[FileHashesKey]; ok {
+		if err := json.Unmarshal(raw, &hashes); err != nil {
+			return errors.Wrap(err, "failed to unmarshal file hashes")
+		}
+	}
+	for _, f := range files {
+		hashes[f.Filename] = HashFileData(f.Data)
+	}
+	data, err := json.MarshalIndent(hashes, "", "    ")
+	if err != nil {
+		return err
+	}
+	state[FileHashesKey] = data
+	return errors.Wrap(WriteStateFile(path, state), "failed to save file hashes")
+}
+
+// Edited returns the files whose content differs from the content recorded
+// when the installer wrote them, and the files the installer did not write.
+func (h FileHashes) Edited(files []*File) []string {
+	var edited []string
+	for _, f := range files {
+		if hash, ok := h[f.Filename]; !ok || hash != HashFileData(f.Data) {
+			edited = append(edited, f.Filename)
+		}
+	}
+	return edited
+}
@@ -0,0 +1,36 @@
+package asset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileHashes(t *testing.T) {
+	dir := t.TempDir()
+
+	hashes, err := LoadFileHashes(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+
+	assert.NoError(t, RecordFileHashes(dir, []*File{
+		{Filename: "manifests/cluster-config.yaml", Data: []byte("a")},
+		{Filename: "manifests/cluster-dns-02-config.yml", Data: []byte("b")},
+	}))
+	assert.NoError(t, RecordFileHashes(dir, []*File{
+		{Filename: "manifests/cluster-dns-02-config.yml", Data: []byte("c")},
+	}))
+
+	hashes, err = LoadFileHashes(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, FileHashes{
+		"manifests/cluster-config.yaml":       HashFileData([]byte("a")),
+		"manifests/cluster-dns-02-config.yml": HashFileData([]byte("c")),
+	}, hashes)
+
+	assert.Equal(t, []string{"manifests/cluster-dns-02-config.yml", "manifests/custom.yaml"}, hashes.Edited([]*File{
+		{Filename: "manifests/cluster-config.yaml", Data: []byte("a")},
+		{Filename: "manifests/cluster-dns-02-config.yml", Data: []byte("b")},
+		{Filename: "manifests/custom.yaml", Data: []byte("d")},
+	}))
+}
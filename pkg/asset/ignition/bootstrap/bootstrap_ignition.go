@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/pem"
 	"fmt"
 	"strings"
@@ -39,13 +40,41 @@ func parseCertificateBundle(userCA []byte) ([]igntypes.Resource, error) {
 // GenerateIgnitionShimWithCertBundleAndProxy is used to generate an ignition file that contains both a user ca bundle
 // in its Security section and proxy settings (if any).
 func GenerateIgnitionShimWithCertBundleAndProxy(bootstrapConfigURL string, userCA string, proxy *types.Proxy) ([]byte, error) {
+	return generateIgnitionShim(igntypes.Resource{Source: ignutil.StrToPtr(bootstrapConfigURL)}, userCA, proxy)
+}
+
+// GenerateInlineIgnitionShim is used to generate an ignition file embedding the bootstrap ignition, gzipped, with
+// the user ca bundle and proxy settings (if any), to be passed in the instance metadata of the bootstrap machine
+// instead of a URL. It fails if the shim is larger than maxSize bytes.
+func GenerateInlineIgnitionShim(bootstrapIgn []byte, userCA string, proxy *types.Proxy, maxSize int) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(bootstrapIgn); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	data, err := generateIgnitionShim(igntypes.Resource{
+		Source:      ignutil.StrToPtr(dataurl.New(buf.Bytes(), "application/octet-stream").String()),
+		Compression: ignutil.StrToPtr("gzip"),
+	}, userCA, proxy)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("the inline bootstrap ignition is %d bytes, more than the %d bytes of the instance metadata", len(data), maxSize)
+	}
+	return data, nil
+}
+
+func generateIgnitionShim(config igntypes.Resource, userCA string, proxy *types.Proxy) ([]byte, error) {
 	ign := igntypes.Config{
 		Ignition: igntypes.Ignition{
 			Version: igntypes.MaxVersion.String(),
 			Config: igntypes.IgnitionConfig{
-				Replace: igntypes.Resource{
-					Source: ignutil.StrToPtr(bootstrapConfigURL),
-				},
+				Replace: config,
 			},
 		},
 	}
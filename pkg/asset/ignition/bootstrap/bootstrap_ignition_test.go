@@ -1,10 +1,15 @@
 package bootstrap
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
 	"testing"
 
 	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/vincent-petithory/dataurl"
 )
 
 func Test_parseCertificateBundle(t *testing.T) {
@@ -314,3 +319,39 @@ invalid
 		})
 	}
 }
+
+func TestGenerateInlineIgnitionShim(t *testing.T) {
+	bootstrapIgn := []byte(`{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/opt/openshift/bootstrap.txt"}]}}`)
+
+	data, err := GenerateInlineIgnitionShim(bootstrapIgn, "", nil, 1<<16)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var shim igntypes.Config
+	if err := json.Unmarshal(data, &shim); err != nil {
+		t.Fatalf("failed to unmarshal the shim: %v", err)
+	}
+	replace := shim.Ignition.Config.Replace
+	if replace.Compression == nil || *replace.Compression != "gzip" {
+		t.Fatalf("expected gzip compression, found %v", replace.Compression)
+	}
+	u, err := dataurl.DecodeString(*replace.Source)
+	if err != nil {
+		t.Fatalf("failed to decode the source: %v", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(u.Data))
+	if err != nil {
+		t.Fatalf("failed to read the source: %v", err)
+	}
+	decompressed, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("failed to decompress the source: %v", err)
+	}
+	if !bytes.Equal(decompressed, bootstrapIgn) {
+		t.Errorf("expected the bootstrap ignition, found %s", decompressed)
+	}
+
+	if _, err := GenerateInlineIgnitionShim(bootstrapIgn, "", nil, 64); err == nil {
+		t.Error("expected an error for a shim larger than the instance metadata")
+	}
+}
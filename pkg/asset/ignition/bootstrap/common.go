@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -33,6 +34,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/kubeconfig"
 	"github.com/openshift/installer/pkg/asset/machines"
+	"github.com/openshift/installer/pkg/asset/machines/machineconfig"
 	"github.com/openshift/installer/pkg/asset/manifests"
 	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/asset/rhcos"
@@ -90,6 +92,10 @@ type bootstrapTemplateData struct {
 	FeatureSet            configv1.FeatureSet
 	Invoker               string
 	ClusterDomain         string
+	// UserManagedLoadBalancer is set when the API and ingress endpoints are
+	// fronted by an external load balancer, in which case the keepalived and
+	// haproxy static pods are not rendered.
+	UserManagedLoadBalancer bool
 }
 
 // platformTemplateData is the data to use to replace values in bootstrap
@@ -130,6 +136,7 @@ func (a *Common) Dependencies() []asset.Asset {
 		&tls.BootstrapSSHKeyPair{},
 		&tls.BoundSASigningKey{},
 		&tls.CloudProviderCABundle{},
+		&tls.CoreSSHKeyPair{},
 		&tls.JournalCertKey{},
 		&tls.KubeAPIServerLBCABundle{},
 		&tls.KubeAPIServerExternalLBServerCertKey{},
@@ -167,7 +174,8 @@ func (a *Common) Dependencies() []asset.Asset {
 func (a *Common) generateConfig(dependencies asset.Parents, templateData *bootstrapTemplateData) error {
 	installConfig := &installconfig.InstallConfig{}
 	bootstrapSSHKeyPair := &tls.BootstrapSSHKeyPair{}
-	dependencies.Get(installConfig, bootstrapSSHKeyPair)
+	coreSSHKeyPair := &tls.CoreSSHKeyPair{}
+	dependencies.Get(installConfig, bootstrapSSHKeyPair, coreSSHKeyPair)
 
 	a.Config = &igntypes.Config{
 		Ignition: igntypes.Ignition{
@@ -178,7 +186,14 @@ func (a *Common) generateConfig(dependencies asset.Parents, templateData *bootst
 	if err := AddStorageFiles(a.Config, "/", "bootstrap/files", templateData); err != nil {
 		return err
 	}
-	if err := AddSystemdUnits(a.Config, "bootstrap/systemd/common/units", templateData, commonEnabledServices); err != nil {
+	enabledServices := commonEnabledServices
+	if templateData.UserManagedLoadBalancer {
+		// The API VIP is served by the external load balancer, so keepalived
+		// must not claim it on the bootstrap node.
+		enabledServices = removeService(enabledServices, "keepalived.service")
+	}
+
+	if err := AddSystemdUnits(a.Config, "bootstrap/systemd/common/units", templateData, enabledServices); err != nil {
 		return err
 	}
 	if !templateData.IsOKD {
@@ -203,19 +218,39 @@ func (a *Common) generateConfig(dependencies asset.Parents, templateData *bootst
 	directory, err = data.Assets.Open(platformUnitPath)
 	if err == nil {
 		directory.Close()
-		if err = AddSystemdUnits(a.Config, platformUnitPath, templateData, commonEnabledServices); err != nil {
+		if err = AddSystemdUnits(a.Config, platformUnitPath, templateData, enabledServices); err != nil {
 			return err
 		}
 	}
 
 	a.addParentFiles(dependencies)
 
+	if servers := installConfig.Config.AdditionalNTPServers; len(servers) > 0 {
+		a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files,
+			ignition.FileFromString(machineconfig.ChronyConfPath, "root", 0644, machineconfig.ChronyConf(servers)))
+	}
+
+	// The nodes get the CAs of the registries from the image config, but the
+	// bootstrap node pulls from the mirrors before the cluster is up.
+	registries := make([]string, 0, len(installConfig.Config.AdditionalTrustedRegistryCAs))
+	for registry := range installConfig.Config.AdditionalTrustedRegistryCAs {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	for _, registry := range registries {
+		a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files,
+			ignition.FileFromString(filepath.Join("/etc/containers/certs.d", registry, "ca.crt"), "root", 0644, installConfig.Config.AdditionalTrustedRegistryCAs[registry]))
+	}
+
+	sshKeys := []igntypes.SSHAuthorizedKey{}
+	for _, key := range coreSSHKeyPair.AuthorizedKeys(installConfig.Config) {
+		sshKeys = append(sshKeys, igntypes.SSHAuthorizedKey(key))
+	}
 	a.Config.Passwd.Users = append(
 		a.Config.Passwd.Users,
-		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{
-			igntypes.SSHAuthorizedKey(installConfig.Config.SSHKey),
+		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: append(sshKeys,
 			igntypes.SSHAuthorizedKey(string(bootstrapSSHKeyPair.Public())),
-		}},
+		)},
 	)
 
 	return nil
@@ -323,8 +358,8 @@ func (a *Common) getTemplateData(dependencies asset.Parents, bootstrapInPlace bo
 		bootstrapInPlaceConfig = installConfig.Config.BootstrapInPlace
 	}
 
-	apiURL := fmt.Sprintf("api.%s", installConfig.Config.ClusterDomain())
-	apiIntURL := fmt.Sprintf("api-int.%s", installConfig.Config.ClusterDomain())
+	apiURL := installConfig.Config.APIHostname()
+	apiIntURL := installConfig.Config.APIInternalHostname()
 
 	openshiftInstallInvoker := os.Getenv("OPENSHIFT_INSTALL_INVOKER")
 
@@ -333,11 +368,11 @@ func (a *Common) getTemplateData(dependencies asset.Parents, bootstrapInPlace bo
 		FIPS:                  installConfig.Config.FIPS,
 		PullSecret:            installConfig.Config.PullSecret,
 		SSHKey:                installConfig.Config.SSHKey,
-		ReleaseImage:          releaseImage.PullSpec,
+		ReleaseImage:          releaseImage.PullSpecFor(installConfig.Config),
 		EtcdCluster:           strings.Join(etcdEndpoints, ","),
 		Proxy:                 &proxy.Config.Status,
 		Registries:            registries,
-		BootImage:             string(*rhcosImage),
+		BootImage:             rhcosImage.ControlPlane,
 		PlatformData:          platformData,
 		ClusterProfile:        clusterProfile,
 		BootstrapInPlace:      bootstrapInPlaceConfig,
@@ -352,6 +387,8 @@ func (a *Common) getTemplateData(dependencies asset.Parents, bootstrapInPlace bo
 		FeatureSet:            installConfig.Config.FeatureSet,
 		Invoker:               openshiftInstallInvoker,
 		ClusterDomain:         installConfig.Config.ClusterDomain(),
+
+		UserManagedLoadBalancer: loadBalancerType(&installConfig.Config.Platform) == configv1.LoadBalancerTypeUserManaged,
 	}
 }
 
@@ -725,6 +762,39 @@ func apiVIPs(p *types.Platform) []string {
 	}
 }
 
+// loadBalancerType returns the type of the load balancer configured for the
+// platform's API and ingress VIPs. It returns an empty type if the platform
+// does not configure a load balancer.
+func loadBalancerType(p *types.Platform) configv1.PlatformLoadBalancerType {
+	switch {
+	case p == nil:
+		return ""
+	case p.BareMetal != nil && p.BareMetal.LoadBalancer != nil:
+		return p.BareMetal.LoadBalancer.Type
+	case p.OpenStack != nil && p.OpenStack.LoadBalancer != nil:
+		return p.OpenStack.LoadBalancer.Type
+	case p.VSphere != nil && p.VSphere.LoadBalancer != nil:
+		return p.VSphere.LoadBalancer.Type
+	case p.Ovirt != nil && p.Ovirt.LoadBalancer != nil:
+		return p.Ovirt.LoadBalancer.Type
+	case p.Nutanix != nil && p.Nutanix.LoadBalancer != nil:
+		return p.Nutanix.LoadBalancer.Type
+	default:
+		return ""
+	}
+}
+
+// removeService returns a copy of services without the named service.
+func removeService(services []string, name string) []string {
+	filtered := make([]string, 0, len(services))
+	for _, s := range services {
+		if s != name {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
 // firstAPIVIP returns the first VIP of the API server (e.g. in case of
 // dual-stack)
 func firstAPIVIP(p *types.Platform) string {
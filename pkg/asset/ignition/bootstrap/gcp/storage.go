@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	gcpic "github.com/openshift/installer/pkg/asset/installconfig/gcp"
 	gcpconsts "github.com/openshift/installer/pkg/constants/gcp"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 )
 
 const (
@@ -22,14 +24,22 @@ func GetBootstrapStorageName(clusterID string) string {
 	return fmt.Sprintf("%s-bootstrap-ignition", clusterID)
 }
 
-// NewStorageClient creates a new Google storage client.
-func NewStorageClient(ctx context.Context) (*storage.Client, error) {
+// NewStorageClient creates a new Google storage client. The endpoints override
+// the default endpoint of the storage service.
+func NewStorageClient(ctx context.Context, endpoints []gcptypes.ServiceEndpoint) (*storage.Client, error) {
 	ssn, err := gcpic.GetSession(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session while creating gcp storage client: %w", err)
 	}
 
-	client, err := storage.NewClient(ctx, option.WithCredentials(ssn.Credentials))
+	opts := []option.ClientOption{option.WithCredentials(ssn.Credentials)}
+	for _, e := range endpoints {
+		if e.Name == gcptypes.StorageServiceEndpoint {
+			opts = append(opts, option.WithEndpoint(e.URL))
+		}
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
@@ -38,11 +48,11 @@ func NewStorageClient(ctx context.Context) (*storage.Client, error) {
 }
 
 // CreateBucketHandle will create the bucket handle that can be used as a reference for other storage resources.
-func CreateBucketHandle(ctx context.Context, bucketName string) (*storage.BucketHandle, error) {
+func CreateBucketHandle(ctx context.Context, bucketName string, endpoints []gcptypes.ServiceEndpoint) (*storage.BucketHandle, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*1)
 	defer cancel()
 
-	client, err := NewStorageClient(ctx)
+	client, err := NewStorageClient(ctx, endpoints)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage client: %w", err)
 	}
@@ -77,9 +87,9 @@ func CreateStorage(ctx context.Context, ic *installconfig.InstallConfig, bucketH
 }
 
 // CreateSignedURL creates a signed url and correlates the signed url with a storage bucket.
-func CreateSignedURL(clusterID string) (string, error) {
+func CreateSignedURL(clusterID string, endpoints []gcptypes.ServiceEndpoint) (string, error) {
 	bucketName := GetBootstrapStorageName(clusterID)
-	handle, err := CreateBucketHandle(context.Background(), bucketName)
+	handle, err := CreateBucketHandle(context.Background(), bucketName, endpoints)
 	if err != nil {
 		return "", fmt.Errorf("creating presigned url, failed to create bucket handle: %w", err)
 	}
@@ -121,18 +131,19 @@ func FillBucket(ctx context.Context, bucketHandle *storage.BucketHandle, content
 }
 
 // DestroyStorage Destroy the bucket and the bucket objects that are associated with the bucket.
-func DestroyStorage(ctx context.Context, clusterID string) error {
+func DestroyStorage(ctx context.Context, clusterID string, endpoints []gcptypes.ServiceEndpoint) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*1)
 	defer cancel()
 
-	client, err := NewStorageClient(ctx)
+	client, err := NewStorageClient(ctx, endpoints)
 	if err != nil {
 		return fmt.Errorf("failed to create storage client: %w", err)
 	}
 	bucketName := GetBootstrapStorageName(clusterID)
 
-	// Deleting a bucket will delete the managed folders and bucket objects.
-	if err := client.Bucket(bucketName).Delete(ctx); err != nil {
+	// Deleting a bucket will delete the managed folders and bucket objects. There is no bucket when the
+	// bootstrap ignition was delivered inline.
+	if err := client.Bucket(bucketName).Delete(ctx); err != nil && !errors.Is(err, storage.ErrBucketNotExist) {
 		return fmt.Errorf("failed to delete bucket %s: %w", bucketName, err)
 	}
 	return nil
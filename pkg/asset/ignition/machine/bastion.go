@@ -0,0 +1,110 @@
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+const (
+	bastionIgnFilename = "bastion.ign"
+)
+
+// Bastion is an asset that generates the ignition config for the SSH bastion
+// host of a private cluster. The bastion only authorizes the SSH keys of the
+// core user, and does not join the cluster.
+type Bastion struct {
+	Config *igntypes.Config
+	File   *asset.File
+}
+
+var _ asset.WritableAsset = (*Bastion)(nil)
+
+// Dependencies returns the assets on which the Bastion asset depends.
+func (a *Bastion) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&tls.BootstrapSSHKeyPair{},
+		&tls.CoreSSHKeyPair{},
+	}
+}
+
+// Generate generates the ignition config for the Bastion asset.
+func (a *Bastion) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	bootstrapSSHKeyPair := &tls.BootstrapSSHKeyPair{}
+	coreSSHKeyPair := &tls.CoreSSHKeyPair{}
+	dependencies.Get(installConfig, bootstrapSSHKeyPair, coreSSHKeyPair)
+
+	if installConfig.Config.Bastion == nil {
+		return nil
+	}
+
+	// The bootstrap key lets the installer jump through the bastion when
+	// gathering the bootstrap logs.
+	sshKeys := []igntypes.SSHAuthorizedKey{}
+	for _, key := range coreSSHKeyPair.AuthorizedKeys(installConfig.Config) {
+		sshKeys = append(sshKeys, igntypes.SSHAuthorizedKey(key))
+	}
+	sshKeys = append(sshKeys, igntypes.SSHAuthorizedKey(string(bootstrapSSHKeyPair.Public())))
+
+	a.Config = &igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+		Passwd: igntypes.Passwd{
+			Users: []igntypes.PasswdUser{{Name: "core", SSHAuthorizedKeys: sshKeys}},
+		},
+	}
+
+	data, err := ignition.Marshal(a.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal Ignition config")
+	}
+	a.File = &asset.File{
+		Filename: bastionIgnFilename,
+		Data:     data,
+	}
+
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (a *Bastion) Name() string {
+	return "Bastion Ignition Config"
+}
+
+// Files returns the files generated by the asset.
+func (a *Bastion) Files() []*asset.File {
+	if a.File != nil {
+		return []*asset.File{a.File}
+	}
+	return []*asset.File{}
+}
+
+// Load returns the bastion ignition from disk.
+func (a *Bastion) Load(f asset.FileFetcher) (found bool, err error) {
+	file, err := f.FetchByName(bastionIgnFilename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(file.Data, config); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal %s", bastionIgnFilename)
+	}
+
+	a.File, a.Config = file, config
+	return true, nil
+}
@@ -0,0 +1,79 @@
+package machine
+
+import (
+	"context"
+	"testing"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+// TestBastionGenerate tests generating the bastion asset.
+func TestBastionGenerate(t *testing.T) {
+	cases := []struct {
+		name    string
+		bastion *types.Bastion
+		files   []string
+	}{
+		{
+			name:  "no bastion",
+			files: []string{},
+		},
+		{
+			name:    "bastion",
+			bastion: &types.Bastion{},
+			files:   []string{"bastion.ign"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			installConfig := installconfig.MakeAsset(
+				&types.InstallConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-cluster",
+					},
+					BaseDomain: "test-domain",
+					SSHKey:     "ssh-ed25519 AAAA user@example.com",
+					Platform: types.Platform{
+						AWS: &aws.Platform{
+							Region: "us-east-1",
+						},
+					},
+					Publish: types.InternalPublishingStrategy,
+					Bastion: tc.bastion,
+				})
+
+			bootstrapSSHKeyPair := &tls.BootstrapSSHKeyPair{}
+			err := bootstrapSSHKeyPair.Generate(context.Background(), nil)
+			assert.NoError(t, err, "unexpected error generating bootstrap SSH key pair")
+
+			parents := asset.Parents{}
+			parents.Add(installConfig, bootstrapSSHKeyPair, &tls.CoreSSHKeyPair{})
+
+			bastion := &Bastion{}
+			err = bastion.Generate(context.Background(), parents)
+			assert.NoError(t, err, "unexpected error generating bastion asset")
+
+			var names []string
+			for _, f := range bastion.Files() {
+				names = append(names, f.Filename)
+			}
+			if len(tc.files) == 0 {
+				assert.Empty(t, names)
+				return
+			}
+			assert.Equal(t, tc.files, names)
+			assert.Equal(t, []igntypes.SSHAuthorizedKey{
+				"ssh-ed25519 AAAA user@example.com",
+				igntypes.SSHAuthorizedKey(bootstrapSSHKeyPair.Public()),
+			}, bastion.Config.Passwd.Users[0].SSHAuthorizedKeys)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package machine
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 
@@ -34,17 +35,23 @@ func (a *Master) Dependencies() []asset.Asset {
 }
 
 // Generate generates the ignition config for the Master asset.
-func (a *Master) Generate(dependencies asset.Parents) error {
+func (a *Master) Generate(_ context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
 	dependencies.Get(installConfig, rootCA)
 
 	a.Config = pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "master")
+	if err := applyIgnitionOverrides(a.Config, installConfig.Config, "master"); err != nil {
+		return err
+	}
 
 	data, err := ignition.Marshal(a.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal Ignition config")
 	}
+	if err := validateUserDataSize(data, installConfig.Config.Platform.Name(), "master"); err != nil {
+		return err
+	}
 	a.File = &asset.File{
 		Filename: masterIgnFilename,
 		Data:     data,
@@ -1,6 +1,7 @@
 package machine
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -37,13 +38,16 @@ func (a *MasterIgnitionCustomizations) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *MasterIgnitionCustomizations) Generate(dependencies asset.Parents) error {
+func (a *MasterIgnitionCustomizations) Generate(_ context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
 	master := &Master{}
 	dependencies.Get(installConfig, rootCA, master)
 
 	defaultPointerIgnition := pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "master")
+	if err := applyIgnitionOverrides(defaultPointerIgnition, installConfig.Config, "master"); err != nil {
+		return err
+	}
 	savedPointerIgnition := master.Config
 
 	savedPointerIgnitionJSON, err := ignition.Marshal(savedPointerIgnition)
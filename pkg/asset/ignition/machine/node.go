@@ -27,7 +27,7 @@ const directory = "openshift"
 func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, role string) *igntypes.Config {
 	var ignitionHost string
 	// Default platform independent ignitionHost
-	ignitionHost = fmt.Sprintf("api-int.%s:22623", installConfig.ClusterDomain())
+	ignitionHost = net.JoinHostPort(installConfig.APIInternalHostname(), "22623")
 	// Update ignitionHost as necessary for platform
 	switch installConfig.Platform.Name() {
 	case baremetaltypes.Name:
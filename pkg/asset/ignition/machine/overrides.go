@@ -0,0 +1,65 @@
+package machine
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// applyIgnitionOverrides merges the ignition overrides of the role, gzipped,
+// into the pointer ignition config.
+func applyIgnitionOverrides(config *igntypes.Config, installConfig *types.InstallConfig, role string) error {
+	for i, o := range installConfig.IgnitionOverrides {
+		if o.Role != role {
+			continue
+		}
+		snippet := []byte(o.Config)
+		if o.Format == types.IgnitionOverrideFormatButane {
+			var err error
+			if snippet, err = translateButane(snippet); err != nil {
+				return fmt.Errorf("failed to translate ignitionOverrides[%d]: %w", i, err)
+			}
+		}
+		source, err := types.CompressedDataURL(snippet)
+		if err != nil {
+			return fmt.Errorf("failed to compress ignitionOverrides[%d]: %w", i, err)
+		}
+		config.Ignition.Config.Merge = append(config.Ignition.Config.Merge, igntypes.Resource{
+			Source:      ignutil.StrToPtr(source),
+			Compression: ignutil.StrToPtr("gzip"),
+		})
+	}
+	return nil
+}
+
+// translateButane translates a butane config into an ignition config with
+// the butane tool.
+func translateButane(config []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("butane", "--strict")
+	cmd.Stdin = bytes.NewReader(config)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// validateUserDataSize checks that the pointer ignition config fits in the
+// user data of the machines of the platform.
+func validateUserDataSize(data []byte, platform string, role string) error {
+	if limit := types.MaxUserDataSize(platform); limit > 0 && len(data) > limit {
+		return fmt.Errorf("the %s pointer ignition config is %d bytes, more than the %d bytes of user data on %s", role, len(data), limit, platform)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package machine
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 
@@ -34,17 +35,23 @@ func (a *Worker) Dependencies() []asset.Asset {
 }
 
 // Generate generates the ignition config for the Worker asset.
-func (a *Worker) Generate(dependencies asset.Parents) error {
+func (a *Worker) Generate(_ context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
 	dependencies.Get(installConfig, rootCA)
 
 	a.Config = pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "worker")
+	if err := applyIgnitionOverrides(a.Config, installConfig.Config, "worker"); err != nil {
+		return err
+	}
 
 	data, err := ignition.Marshal(a.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal Ignition config")
 	}
+	if err := validateUserDataSize(data, installConfig.Config.Platform.Name(), "worker"); err != nil {
+		return err
+	}
 	a.File = &asset.File{
 		Filename: workerIgnFilename,
 		Data:     data,
@@ -1,6 +1,7 @@
 package machine
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/pkg/errors"
@@ -37,13 +38,16 @@ func (a *WorkerIgnitionCustomizations) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *WorkerIgnitionCustomizations) Generate(dependencies asset.Parents) error {
+func (a *WorkerIgnitionCustomizations) Generate(_ context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
 	worker := &Worker{}
 	dependencies.Get(installConfig, rootCA, worker)
 
 	defaultPointerIgnition := pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "worker")
+	if err := applyIgnitionOverrides(defaultPointerIgnition, installConfig.Config, "worker"); err != nil {
+		return err
+	}
 	savedPointerIgnition := worker.Config
 
 	// Create a machineconfig if the ignition has been modified
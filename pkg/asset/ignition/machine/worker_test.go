@@ -1,6 +1,7 @@
 package machine
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,17 +29,57 @@ func TestWorkerGenerate(t *testing.T) {
 		})
 
 	rootCA := &tls.RootCA{}
-	err := rootCA.Generate(nil)
+	err := rootCA.Generate(context.Background(), nil)
 	assert.NoError(t, err, "unexpected error generating root CA")
 
 	parents := asset.Parents{}
 	parents.Add(installConfig, rootCA)
 
 	worker := &Worker{}
-	err = worker.Generate(parents)
+	err = worker.Generate(context.Background(), parents)
 	assert.NoError(t, err, "unexpected error generating worker asset")
 
 	actualFiles := worker.Files()
 	assert.Equal(t, 1, len(actualFiles), "unexpected number of files in worker state")
 	assert.Equal(t, "worker.ign", actualFiles[0].Filename, "unexpected name for worker ignition config")
 }
+
+// TestWorkerGenerateWithIgnitionOverrides tests merging the ignition overrides
+// into the worker pointer ignition config.
+func TestWorkerGenerateWithIgnitionOverrides(t *testing.T) {
+	snippet := `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/motd","contents":{"source":"data:,hello"}}]}}`
+	installConfig := installconfig.MakeAsset(
+		&types.InstallConfig{
+			Networking: &types.Networking{
+				ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("10.0.1.0/24")},
+			},
+			Platform: types.Platform{
+				AWS: &aws.Platform{
+					Region: "us-east",
+				},
+			},
+			IgnitionOverrides: []types.IgnitionOverride{
+				{Role: "worker", Config: snippet},
+				{Role: "master", Config: `{"ignition":{"version":"3.2.0"}}`},
+			},
+		})
+
+	rootCA := &tls.RootCA{}
+	err := rootCA.Generate(context.Background(), nil)
+	assert.NoError(t, err, "unexpected error generating root CA")
+
+	parents := asset.Parents{}
+	parents.Add(installConfig, rootCA)
+
+	worker := &Worker{}
+	err = worker.Generate(context.Background(), parents)
+	assert.NoError(t, err, "unexpected error generating worker asset")
+
+	merge := worker.Config.Ignition.Config.Merge
+	if assert.Len(t, merge, 2, "unexpected number of merged configs") {
+		source, err := types.CompressedDataURL([]byte(snippet))
+		assert.NoError(t, err)
+		assert.Equal(t, source, *merge[1].Source)
+		assert.Equal(t, "gzip", *merge[1].Compression)
+	}
+}
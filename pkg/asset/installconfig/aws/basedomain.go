@@ -103,3 +103,20 @@ func GetPublicZone(sess *session.Session, name string) (*route53.HostedZone, err
 	}
 	return res, nil
 }
+
+// GetPublicZoneNameServers returns the name servers Route53 assigned to the
+// public zone that matches the name.
+func GetPublicZoneNameServers(sess *session.Session, name string) ([]string, error) {
+	zone, err := GetPublicZone(sess, name)
+	if err != nil {
+		return nil, err
+	}
+	out, err := route53.New(sess).GetHostedZone(&route53.GetHostedZoneInput{Id: zone.Id})
+	if err != nil {
+		return nil, fmt.Errorf("getting hosted zone %s: %w", aws.StringValue(zone.Id), err)
+	}
+	if out.DelegationSet == nil {
+		return nil, nil
+	}
+	return aws.StringValueSlice(out.DelegationSet.NameServers), nil
+}
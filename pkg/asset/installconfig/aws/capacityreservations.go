@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// CapacityReservation holds metadata for an on-demand capacity reservation.
+type CapacityReservation struct {
+	ID            string
+	InstanceType  string
+	Zone          string
+	State         string
+	Tenancy       string
+	MatchCriteria string
+	// Available is the number of instances which can still be launched into the reservation.
+	Available int64
+}
+
+// capacityReservations retrieves the capacity reservations with the given IDs in the given region.
+func capacityReservations(ctx context.Context, session *session.Session, region string, ids []string) (map[string]CapacityReservation, error) {
+	reservations := map[string]CapacityReservation{}
+
+	client := ec2.New(session, aws.NewConfig().WithRegion(region))
+	if err := client.DescribeCapacityReservationsPagesWithContext(ctx,
+		&ec2.DescribeCapacityReservationsInput{CapacityReservationIds: aws.StringSlice(ids)},
+		func(page *ec2.DescribeCapacityReservationsOutput, lastPage bool) bool {
+			for _, info := range page.CapacityReservations {
+				reservation := CapacityReservation{
+					ID:            aws.StringValue(info.CapacityReservationId),
+					InstanceType:  aws.StringValue(info.InstanceType),
+					Zone:          aws.StringValue(info.AvailabilityZone),
+					State:         aws.StringValue(info.State),
+					Tenancy:       aws.StringValue(info.Tenancy),
+					MatchCriteria: aws.StringValue(info.InstanceMatchCriteria),
+					Available:     aws.Int64Value(info.AvailableInstanceCount),
+				}
+				reservations[reservation.ID] = reservation
+			}
+			return !lastPage
+		}); err != nil {
+		return nil, fmt.Errorf("fetching capacity reservations: %w", err)
+	}
+
+	return reservations, nil
+}
@@ -0,0 +1,38 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+// GetClusterResources returns the ARNs of the resources of the region tagged
+// as belonging to the cluster with the infra ID, at most limit of them.
+func GetClusterResources(ctx context.Context, sess *session.Session, region, infraID string, limit int) ([]string, error) {
+	client := resourcegroupstaggingapi.New(sess, aws.NewConfig().WithRegion(region))
+
+	var arns []string
+	err := client.GetResourcesPagesWithContext(ctx,
+		&resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+				Key: aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID)),
+			}},
+		},
+		func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+			for _, resource := range page.ResourceTagMappingList {
+				arns = append(arns, aws.StringValue(resource.ResourceARN))
+			}
+			return len(arns) < limit
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the resources tagged for cluster %s: %w", infraID, err)
+	}
+	if len(arns) > limit {
+		arns = arns[:limit]
+	}
+	return arns, nil
+}
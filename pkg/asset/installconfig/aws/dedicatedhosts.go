@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Host holds metadata for a dedicated host.
+type Host struct {
+	ID    string
+	Zone  string
+	State string
+	// Capacity is the number of additional instances of each supported
+	// instance type that can be launched on the host.
+	Capacity map[string]int64
+}
+
+// dedicatedHosts retrieves the dedicated hosts with the given IDs in the given region.
+func dedicatedHosts(ctx context.Context, session *session.Session, region string, ids []string) (map[string]Host, error) {
+	hosts := map[string]Host{}
+
+	client := ec2.New(session, aws.NewConfig().WithRegion(region))
+	if err := client.DescribeHostsPagesWithContext(ctx,
+		&ec2.DescribeHostsInput{HostIds: aws.StringSlice(ids)},
+		func(page *ec2.DescribeHostsOutput, lastPage bool) bool {
+			for _, info := range page.Hosts {
+				host := Host{
+					ID:       aws.StringValue(info.HostId),
+					Zone:     aws.StringValue(info.AvailabilityZone),
+					State:    aws.StringValue(info.State),
+					Capacity: map[string]int64{},
+				}
+				if info.AvailableCapacity != nil {
+					for _, capacity := range info.AvailableCapacity.AvailableInstanceCapacity {
+						host.Capacity[aws.StringValue(capacity.InstanceType)] = aws.Int64Value(capacity.AvailableCapacity)
+					}
+				}
+				hosts[host.ID] = host
+			}
+			return !lastPage
+		}); err != nil {
+		return nil, fmt.Errorf("fetching dedicated hosts: %w", err)
+	}
+
+	return hosts, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // InstanceType holds metadata for an instance type.
@@ -14,6 +15,10 @@ type InstanceType struct {
 	DefaultVCpus int64
 	MemInMiB     int64
 	Arches       []string
+	// IPv6 is true if the instance type supports IPv6 addresses.
+	IPv6 bool
+	// Spot is true if the instance type can be launched as a spot instance.
+	Spot bool
 }
 
 // instanceTypes retrieves a list of instance types for the given region.
@@ -29,6 +34,8 @@ func instanceTypes(ctx context.Context, session *session.Session, region string)
 					DefaultVCpus: aws.Int64Value(info.VCpuInfo.DefaultVCpus),
 					MemInMiB:     aws.Int64Value(info.MemoryInfo.SizeInMiB),
 					Arches:       aws.StringValueSlice(info.ProcessorInfo.SupportedArchitectures),
+					IPv6:         info.NetworkInfo != nil && aws.BoolValue(info.NetworkInfo.Ipv6Supported),
+					Spot:         sets.New(aws.StringValueSlice(info.SupportedUsageClasses)...).Has(ec2.UsageClassTypeSpot),
 				}
 			}
 			return !lastPage
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KMSKey holds metadata for a KMS key.
+type KMSKey struct {
+	ARN      string
+	State    string
+	KeyUsage string
+	KeySpec  string
+}
+
+// kmsKey retrieves the KMS key with the given ARN in the given region.
+func kmsKey(ctx context.Context, session *session.Session, region string, keyARN string) (KMSKey, error) {
+	client := kms.New(session, aws.NewConfig().WithRegion(region))
+	out, err := client.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyARN)})
+	if err != nil {
+		return KMSKey{}, fmt.Errorf("describing KMS key: %w", err)
+	}
+	return KMSKey{
+		ARN:      aws.StringValue(out.KeyMetadata.Arn),
+		State:    aws.StringValue(out.KeyMetadata.KeyState),
+		KeyUsage: aws.StringValue(out.KeyMetadata.KeyUsage),
+		KeySpec:  aws.StringValue(out.KeyMetadata.KeySpec),
+	}, nil
+}
@@ -16,14 +16,17 @@ import (
 // does not need to be user-supplied (e.g. because it can be retrieved
 // from external APIs).
 type Metadata struct {
-	session           *session.Session
-	availabilityZones []string
-	edgeZones         []string
-	privateSubnets    Subnets
-	publicSubnets     Subnets
-	edgeSubnets       Subnets
-	vpc               string
-	instanceTypes     map[string]InstanceType
+	session              *session.Session
+	availabilityZones    []string
+	edgeZones            []string
+	privateSubnets       Subnets
+	publicSubnets        Subnets
+	edgeSubnets          Subnets
+	vpc                  string
+	instanceTypes        map[string]InstanceType
+	hosts                map[string]Host
+	capacityReservations map[string]CapacityReservation
+	kmsKeys              map[string]KMSKey
 
 	Region   string                     `json:"region,omitempty"`
 	Subnets  []string                   `json:"subnets,omitempty"`
@@ -238,3 +241,93 @@ func (m *Metadata) InstanceTypes(ctx context.Context) (map[string]InstanceType,
 
 	return m.instanceTypes, nil
 }
+
+// DedicatedHosts retrieves dedicated host metadata indexed by host ID. Hosts
+// which have not been retrieved before are fetched from the configured region.
+func (m *Metadata) DedicatedHosts(ctx context.Context, ids []string) (map[string]Host, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := m.hosts[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		session, err := m.unlockedSession(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		hosts, err := dedicatedHosts(ctx, session, m.Region, missing)
+		if err != nil {
+			return nil, fmt.Errorf("error listing dedicated hosts: %w", err)
+		}
+		if m.hosts == nil {
+			m.hosts = map[string]Host{}
+		}
+		for id, host := range hosts {
+			m.hosts[id] = host
+		}
+	}
+
+	return m.hosts, nil
+}
+
+// CapacityReservations retrieves capacity reservation metadata indexed by ID. Reservations
+// which have not been retrieved before are fetched from the configured region.
+func (m *Metadata) CapacityReservations(ctx context.Context, ids []string) (map[string]CapacityReservation, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := m.capacityReservations[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		session, err := m.unlockedSession(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		reservations, err := capacityReservations(ctx, session, m.Region, missing)
+		if err != nil {
+			return nil, fmt.Errorf("error listing capacity reservations: %w", err)
+		}
+		if m.capacityReservations == nil {
+			m.capacityReservations = map[string]CapacityReservation{}
+		}
+		for id, reservation := range reservations {
+			m.capacityReservations[id] = reservation
+		}
+	}
+
+	return m.capacityReservations, nil
+}
+
+// KMSKey retrieves the metadata of the KMS key with the given ARN. Keys are
+// cached after the first lookup.
+func (m *Metadata) KMSKey(ctx context.Context, keyARN string) (KMSKey, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if key, ok := m.kmsKeys[keyARN]; ok {
+		return key, nil
+	}
+	session, err := m.unlockedSession(ctx)
+	if err != nil {
+		return KMSKey{}, err
+	}
+	key, err := kmsKey(ctx, session, m.Region, keyARN)
+	if err != nil {
+		return KMSKey{}, fmt.Errorf("error getting KMS key: %w", err)
+	}
+	if m.kmsKeys == nil {
+		m.kmsKeys = map[string]KMSKey{}
+	}
+	m.kmsKeys[keyARN] = key
+	return key, nil
+}
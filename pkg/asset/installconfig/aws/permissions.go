@@ -45,6 +45,10 @@ const (
 
 	// PermissionPublicIpv4Pool is an additional set of permissions required when the installer uses public IPv4 pools.
 	PermissionPublicIpv4Pool PermissionGroup = "public-ipv4-pool"
+
+	// PermissionBootstrapIgnitionVPCEndpoint is an additional set of permissions required when the bootstrap ignition
+	// bucket is restricted to a VPC endpoint.
+	PermissionBootstrapIgnitionVPCEndpoint PermissionGroup = "bootstrap-ignition-vpc-endpoint"
 )
 
 var permissions = map[PermissionGroup][]string{
@@ -266,6 +270,10 @@ var permissions = map[PermissionGroup][]string{
 		// Needed by terraform because of bootstrap EIP created
 		"ec2:DisassociateAddress",
 	},
+	PermissionBootstrapIgnitionVPCEndpoint: {
+		"s3:GetBucketPolicy",
+		"s3:PutBucketPolicy",
+	},
 }
 
 // ValidateCreds will try to create an AWS session, and also verify that the current credentials
@@ -1,20 +1,31 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	survey "github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/core"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/aws/defaults"
 	"github.com/openshift/installer/pkg/version"
 )
 
-// Platform collects AWS-specific configuration.
-func Platform() (*aws.Platform, error) {
+// Platform collects AWS-specific configuration, and the machine networks of
+// the existing VPC when one is chosen.
+func Platform() (*aws.Platform, []types.MachineNetworkEntry, error) {
 	architecture := version.DefaultArch()
 	regions := knownPublicRegions(architecture)
 	longRegions := make([]string, 0, len(regions))
@@ -40,7 +51,7 @@ func Platform() (*aws.Platform, error) {
 
 	ssn, err := GetSession()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defaultRegionPointer := ssn.Config.Region
@@ -76,10 +87,235 @@ func Platform() (*aws.Platform, error) {
 		},
 	}, &region)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &aws.Platform{
+	platform := &aws.Platform{
 		Region: region,
-	}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 2*time.Minute)
+	defer cancel()
+
+	zones, machineNetwork, err := selectSubnets(ctx, ssn, platform)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := selectInstanceType(ctx, ssn, platform, zones, architecture); err != nil {
+		return nil, nil, err
+	}
+	return platform, machineNetwork, nil
+}
+
+// newVPCOption is the option to let the installer create the VPC.
+const newVPCOption = "Create a new VPC"
+
+// selectSubnets offers the VPCs of the region and, when an existing VPC is
+// chosen, its subnets. It returns the availability zones of the chosen
+// subnets and the IPv4 CIDR blocks of the VPC as machine networks. When the
+// VPCs cannot be listed, a new VPC is used.
+func selectSubnets(ctx context.Context, ssn *session.Session, platform *aws.Platform) ([]string, []types.MachineNetworkEntry, error) {
+	client := ec2.New(ssn, awssdk.NewConfig().WithRegion(platform.Region))
+
+	vpcs := map[string]*ec2.Vpc{}
+	options := []string{}
+	if err := client.DescribeVpcsPagesWithContext(ctx, &ec2.DescribeVpcsInput{}, func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+		for _, vpc := range page.Vpcs {
+			option := fmt.Sprintf("%s (%s)", awssdk.StringValue(vpc.VpcId), describe(vpc.Tags, awssdk.StringValue(vpc.CidrBlock)))
+			vpcs[option] = vpc
+			options = append(options, option)
+		}
+		return !lastPage
+	}); err != nil {
+		logrus.Warnf("Failed to list the VPCs of %s, a new VPC will be created: %v", platform.Region, err)
+		return nil, nil, nil
+	}
+	if len(options) == 0 {
+		return nil, nil, nil
+	}
+	sort.Strings(options)
+
+	var selectedVPC string
+	if err := survey.AskOne(&survey.Select{
+		Message: "VPC",
+		Help:    "The VPC in which the cluster will be installed. The installer creates a VPC, with its subnets, NAT gateways and route tables, when no existing VPC is chosen.",
+		Default: newVPCOption,
+		Options: append([]string{newVPCOption}, options...),
+	}, &selectedVPC); err != nil {
+		return nil, nil, fmt.Errorf("failed UserInput: %w", err)
+	}
+	if selectedVPC == newVPCOption {
+		return nil, nil, nil
+	}
+
+	vpcID := awssdk.StringValue(vpcs[selectedVPC].VpcId)
+	subnets := map[string]*ec2.Subnet{}
+	options = []string{}
+	if err := client.DescribeSubnetsPagesWithContext(ctx, &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{{
+			Name:   awssdk.String("vpc-id"),
+			Values: []*string{awssdk.String(vpcID)},
+		}},
+	}, func(page *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		for _, subnet := range page.Subnets {
+			option := fmt.Sprintf("%s (%s, %s)", awssdk.StringValue(subnet.SubnetId), awssdk.StringValue(subnet.AvailabilityZone), describe(subnet.Tags, awssdk.StringValue(subnet.CidrBlock)))
+			subnets[option] = subnet
+			options = append(options, option)
+		}
+		return !lastPage
+	}); err != nil {
+		return nil, nil, fmt.Errorf("listing the subnets of %s: %w", vpcID, err)
+	}
+	if len(options) == 0 {
+		return nil, nil, fmt.Errorf("VPC %s has no subnets", vpcID)
+	}
+	sort.Strings(options)
+
+	var selectedSubnets []string
+	if err := survey.AskOne(&survey.MultiSelect{
+		Message: "Subnets",
+		Help:    "The subnets in which the cluster machines and load balancers will be created. Choose a private subnet, and a public subnet unless the cluster is private, in each availability zone to be used.",
+		Options: options,
+	}, &selectedSubnets, survey.WithValidator(survey.Required)); err != nil {
+		return nil, nil, fmt.Errorf("failed UserInput: %w", err)
+	}
+
+	zones := sets.New[string]()
+	for _, option := range selectedSubnets {
+		subnet := subnets[option]
+		platform.Subnets = append(platform.Subnets, awssdk.StringValue(subnet.SubnetId))
+		zones.Insert(awssdk.StringValue(subnet.AvailabilityZone))
+	}
+	machineNetwork, err := vpcMachineNetwork(vpcs[selectedVPC])
+	if err != nil {
+		return nil, nil, err
+	}
+	return sets.List(zones), machineNetwork, nil
+}
+
+// vpcMachineNetwork returns the IPv4 CIDR blocks associated with the VPC, in
+// which the subnets of the cluster are, as machine networks.
+func vpcMachineNetwork(vpc *ec2.Vpc) ([]types.MachineNetworkEntry, error) {
+	cidrs := []string{awssdk.StringValue(vpc.CidrBlock)}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		cidr := awssdk.StringValue(association.CidrBlock)
+		if cidr == cidrs[0] || association.CidrBlockState == nil || awssdk.StringValue(association.CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	machineNetwork := make([]types.MachineNetworkEntry, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		network, err := ipnet.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the CIDR block %s of %s: %w", cidr, awssdk.StringValue(vpc.VpcId), err)
+		}
+		machineNetwork = append(machineNetwork, types.MachineNetworkEntry{CIDR: *network})
+	}
+	return machineNetwork, nil
+}
+
+// selectInstanceType offers the instance types of the architecture that meet
+// the control plane requirements and are offered in all the zones, or in all
+// the availability zones of the region when no zones are given. The chosen
+// instance type is set in the default machine platform unless it is the
+// installer default.
+func selectInstanceType(ctx context.Context, ssn *session.Session, platform *aws.Platform, zones []string, arch types.Architecture) error {
+	if len(zones) == 0 {
+		var err error
+		zones, err = availabilityZones(ctx, ssn, platform.Region)
+		if err != nil {
+			logrus.Warnf("Failed to list the availability zones of %s, the default instance type will be used: %v", platform.Region, err)
+			return nil
+		}
+	}
+
+	offered, err := instanceTypeOfferings(ctx, ssn, platform.Region, zones)
+	if err != nil {
+		logrus.Warnf("Failed to list the instance types offered in %s, the default instance type will be used: %v", platform.Region, err)
+		return nil
+	}
+	infos, err := instanceTypes(ctx, ssn, platform.Region)
+	if err != nil {
+		logrus.Warnf("Failed to describe the instance types of %s, the default instance type will be used: %v", platform.Region, err)
+		return nil
+	}
+
+	names := map[string]string{}
+	options := []string{}
+	for name, info := range infos {
+		if offered[name] != len(zones) || !translateEC2Arches(info.Arches).Has(string(arch)) ||
+			info.DefaultVCpus < controlPlaneReq.minimumVCpus || info.MemInMiB < controlPlaneReq.minimumMemory {
+			continue
+		}
+		option := fmt.Sprintf("%s (%d vCPUs, %d GiB)", name, info.DefaultVCpus, info.MemInMiB/1024)
+		names[option] = name
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		logrus.Warnf("No instance type meeting the control plane requirements is offered in %s, the default instance type will be used", strings.Join(zones, ", "))
+		return nil
+	}
+	sort.Strings(options)
+
+	defaultOption := ""
+	defaultType := ""
+	for _, t := range defaults.InstanceTypes(platform.Region, arch, configv1.HighlyAvailableTopologyMode) {
+		for option, name := range names {
+			if name == t {
+				defaultOption, defaultType = option, name
+				break
+			}
+		}
+		if defaultOption != "" {
+			break
+		}
+	}
+
+	var selected string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Instance Type",
+		Help:    "The instance type of the control plane and compute machines. Only the instance types offered in all the zones of the cluster and meeting the control plane requirements are listed.",
+		Default: defaultOption,
+		Options: options,
+	}, &selected); err != nil {
+		return fmt.Errorf("failed UserInput: %w", err)
+	}
+	if names[selected] != defaultType {
+		platform.DefaultMachinePlatform = &aws.MachinePool{InstanceType: names[selected]}
+	}
+	return nil
+}
+
+// instanceTypeOfferings returns the number of the zones in which each
+// instance type is offered.
+func instanceTypeOfferings(ctx context.Context, ssn *session.Session, region string, zones []string) (map[string]int, error) {
+	client := ec2.New(ssn, awssdk.NewConfig().WithRegion(region))
+	offered := map[string]int{}
+	if err := client.DescribeInstanceTypeOfferingsPagesWithContext(ctx, &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: awssdk.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{{
+			Name:   awssdk.String("location"),
+			Values: awssdk.StringSlice(zones),
+		}},
+	}, func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+		for _, offering := range page.InstanceTypeOfferings {
+			offered[awssdk.StringValue(offering.InstanceType)]++
+		}
+		return !lastPage
+	}); err != nil {
+		return nil, fmt.Errorf("fetching instance type offerings: %w", err)
+	}
+	return offered, nil
+}
+
+// describe returns the Name tag and the CIDR of a resource.
+func describe(tags []*ec2.Tag, cidr string) string {
+	for _, tag := range tags {
+		if awssdk.StringValue(tag.Key) == "Name" && awssdk.StringValue(tag.Value) != "" {
+			return fmt.Sprintf("%s, %s", awssdk.StringValue(tag.Value), cidr)
+		}
+	}
+	return cidr
 }
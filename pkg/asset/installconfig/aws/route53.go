@@ -160,19 +160,23 @@ func GetR53ClientCfg(sess *awss.Session, roleARN string) *aws.Config {
 func (c *Client) CreateOrUpdateRecord(ctx context.Context, ic *types.InstallConfig, target string, intTarget string, phzID string) error {
 	useCNAME := cnameRegions.Has(ic.AWS.Region)
 	aliasZoneID := hostedZoneIDPerRegionNLBMap[ic.AWS.Region]
+	recordType := route53.RRTypeA
+	if ic.AWS.IsIPv6Only() {
+		recordType = route53.RRTypeAaaa
+	}
 
 	apiName := fmt.Sprintf("api.%s.", ic.ClusterDomain())
 	apiIntName := fmt.Sprintf("api-int.%s.", ic.ClusterDomain())
 
 	// Create api record in public zone
-	if ic.Publish == types.ExternalPublishingStrategy {
+	if ic.PublicAPI() {
 		zone, err := c.GetBaseDomain(ic.BaseDomain)
 		if err != nil {
 			return err
 		}
 
 		svc := route53.New(c.ssn) // we dont want to assume role here
-		if _, err := createRecord(ctx, svc, aws.StringValue(zone.Id), apiName, target, aliasZoneID, useCNAME); err != nil {
+		if _, err := createRecord(ctx, svc, aws.StringValue(zone.Id), apiName, target, aliasZoneID, recordType, useCNAME); err != nil {
 			return fmt.Errorf("failed to create records for api: %w", err)
 		}
 		logrus.Debugln("Created public API record in public zone")
@@ -182,13 +186,13 @@ func (c *Client) CreateOrUpdateRecord(ctx context.Context, ic *types.InstallConf
 	svc := route53.New(c.ssn, GetR53ClientCfg(c.ssn, ic.AWS.HostedZoneRole))
 
 	// Create api record in private zone
-	if _, err := createRecord(ctx, svc, phzID, apiName, intTarget, aliasZoneID, useCNAME); err != nil {
+	if _, err := createRecord(ctx, svc, phzID, apiName, intTarget, aliasZoneID, recordType, useCNAME); err != nil {
 		return fmt.Errorf("failed to create records for api: %w", err)
 	}
 	logrus.Debugln("Created public API record in private zone")
 
 	// Create api-int record in private zone
-	if _, err := createRecord(ctx, svc, phzID, apiIntName, intTarget, aliasZoneID, useCNAME); err != nil {
+	if _, err := createRecord(ctx, svc, phzID, apiIntName, intTarget, aliasZoneID, recordType, useCNAME); err != nil {
 		return fmt.Errorf("failed to create records for api-int: %w", err)
 	}
 	logrus.Debugln("Created private API record in private zone")
@@ -196,7 +200,7 @@ func (c *Client) CreateOrUpdateRecord(ctx context.Context, ic *types.InstallConf
 	return nil
 }
 
-func createRecord(ctx context.Context, client *route53.Route53, zoneID, name, dnsName, aliasZoneID string, useCNAME bool) (*route53.ChangeInfo, error) {
+func createRecord(ctx context.Context, client *route53.Route53, zoneID, name, dnsName, aliasZoneID, recordType string, useCNAME bool) (*route53.ChangeInfo, error) {
 	recordSet := &route53.ResourceRecordSet{
 		Name: aws.String(name),
 	}
@@ -207,7 +211,7 @@ func createRecord(ctx context.Context, client *route53.Route53, zoneID, name, dn
 			{Value: aws.String(dnsName)},
 		})
 	} else {
-		recordSet.SetType("A")
+		recordSet.SetType(recordType)
 		recordSet.SetAliasTarget(&route53.AliasTarget{
 			DNSName:              aws.String(dnsName),
 			HostedZoneId:         aws.String(aliasZoneID),
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -19,6 +20,7 @@ import (
 	"github.com/sirupsen/logrus"
 	ini "gopkg.in/ini.v1"
 
+	"github.com/openshift/installer/pkg/credentialsource"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/version"
 )
@@ -66,15 +68,23 @@ func GetSessionWithOptions(optFuncs ...SessionOptions) (*session.Session, error)
 		optFunc(&options)
 	}
 
-	_, err := getCredentials(options)
-	if err != nil && errCodeEquals(err, "NoCredentialProviders") {
-		if err = getUserCredentials(); err != nil {
-			return nil, err
-		}
-	}
+	sourceCreds, err := credentialsFromSource()
 	if err != nil {
 		return nil, err
 	}
+	if sourceCreds != nil {
+		options.Config.Credentials = sourceCreds
+	} else {
+		_, err = getCredentials(options)
+		if err != nil && errCodeEquals(err, "NoCredentialProviders") {
+			if err = getUserCredentials(); err != nil {
+				return nil, err
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	ssn := session.Must(session.NewSessionWithOptions(options))
 	ssn = ssn.Copy(&aws.Config{MaxRetries: aws.Int(25)})
@@ -85,6 +95,19 @@ func GetSessionWithOptions(optFuncs ...SessionOptions) (*session.Session, error)
 	return ssn, nil
 }
 
+// credentialsFromSource returns the credentials provided by the credential
+// source configured in the environment, or nil when none is configured.
+func credentialsFromSource() (*credentials.Credentials, error) {
+	values, err := credentialsource.Fetch(context.TODO(), typesaws.Name)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	if values["aws_access_key_id"] == "" || values["aws_secret_access_key"] == "" {
+		return nil, errors.New("the credential source must provide aws_access_key_id and aws_secret_access_key")
+	}
+	return credentials.NewStaticCredentials(values["aws_access_key_id"], values["aws_secret_access_key"], values["aws_session_token"]), nil
+}
+
 func getCredentials(options session.Options) (*credentials.Credentials, error) {
 	sharedCredentialsProvider := &credentials.SharedCredentialsProvider{}
 	providers := []credentials.Provider{
@@ -30,8 +30,24 @@ type Subnet struct {
 
 	// Public is the flag to define the subnet public.
 	Public bool
+
+	// IPv6CIDR is the subnet's IPv6 CIDR block, if any.
+	IPv6CIDR string
+
+	// IPv6Native is the flag to define the subnet IPv6-only.
+	IPv6Native bool
+
+	// DNS64 is the flag to define DNS64 enabled on the subnet.
+	DNS64 bool
+
+	// NAT64 is the flag to define the subnet routes the NAT64 prefix to a
+	// NAT gateway.
+	NAT64 bool
 }
 
+// nat64Prefix is the well-known prefix translated by AWS NAT gateways.
+const nat64Prefix = "64:ff9b::/96"
+
 // Subnets is the map for the Subnet metadata indexed by zone.
 type Subnets map[string]Subnet
 
@@ -97,6 +113,10 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 					Zone:   &Zone{Name: aws.StringValue(subnet.AvailabilityZone)},
 					CIDR:   aws.StringValue(subnet.CidrBlock),
 					Public: false,
+
+					IPv6CIDR:   ipv6CIDR(subnet),
+					IPv6Native: aws.BoolValue(subnet.Ipv6Native),
+					DNS64:      aws.BoolValue(subnet.EnableDns64),
 				}
 				zoneNames = append(zoneNames, subnet.AvailabilityZone)
 			}
@@ -144,11 +164,12 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 			return subnetGroups, fmt.Errorf("failed to find %s", id)
 		}
 
-		isPublic, err := isSubnetPublic(routeTables, id)
+		subnetTable, err := subnetRouteTable(routeTables, id)
 		if err != nil {
 			return subnetGroups, err
 		}
-		meta.Public = isPublic
+		meta.Public = isSubnetPublic(subnetTable)
+		meta.NAT64 = hasNAT64Route(subnetTable)
 
 		zoneName := meta.Zone.Name
 		if _, ok := availabilityZones[zoneName]; !ok {
@@ -186,8 +207,19 @@ func subnets(ctx context.Context, session *session.Session, region string, ids [
 	return subnetGroups, nil
 }
 
+// ipv6CIDR returns the first associated IPv6 CIDR block of the subnet.
+func ipv6CIDR(subnet *ec2.Subnet) string {
+	for _, assoc := range subnet.Ipv6CidrBlockAssociationSet {
+		if assoc.Ipv6CidrBlockState != nil && aws.StringValue(assoc.Ipv6CidrBlockState.State) != ec2.SubnetCidrBlockStateCodeAssociated {
+			continue
+		}
+		return aws.StringValue(assoc.Ipv6CidrBlock)
+	}
+	return ""
+}
+
 // https://github.com/kubernetes/kubernetes/blob/9f036cd43d35a9c41d7ac4ca82398a6d0bef957b/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L3376-L3419
-func isSubnetPublic(rt []*ec2.RouteTable, subnetID string) (bool, error) {
+func subnetRouteTable(rt []*ec2.RouteTable, subnetID string) (*ec2.RouteTable, error) {
 	var subnetTable *ec2.RouteTable
 	for _, table := range rt {
 		for _, assoc := range table.Associations {
@@ -214,9 +246,12 @@ func isSubnetPublic(rt []*ec2.RouteTable, subnetID string) (bool, error) {
 	}
 
 	if subnetTable == nil {
-		return false, fmt.Errorf("could not locate routing table for %s", subnetID)
+		return nil, fmt.Errorf("could not locate routing table for %s", subnetID)
 	}
+	return subnetTable, nil
+}
 
+func isSubnetPublic(subnetTable *ec2.RouteTable) bool {
 	for _, route := range subnetTable.Routes {
 		// There is no direct way in the AWS API to determine if a subnet is public or private.
 		// A public subnet is one which has an internet gateway route
@@ -225,12 +260,23 @@ func isSubnetPublic(rt []*ec2.RouteTable, subnetID string) (bool, error) {
 		// or other virtual gateway (starting with vgv)
 		// or vpc peering connections (starting with pcx).
 		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw") {
-			return true, nil
+			return true
 		}
 		if strings.HasPrefix(aws.StringValue(route.CarrierGatewayId), "cagw") {
-			return true, nil
+			return true
 		}
 	}
 
-	return false, nil
+	return false
+}
+
+// hasNAT64Route returns true if the route table sends the NAT64 prefix to a
+// NAT gateway.
+func hasNAT64Route(subnetTable *ec2.RouteTable) bool {
+	for _, route := range subnetTable.Routes {
+		if aws.StringValue(route.DestinationIpv6CidrBlock) == nat64Prefix && aws.StringValue(route.NatGatewayId) != "" {
+			return true
+		}
+	}
+	return false
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/route53"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -46,8 +47,10 @@ func Validate(ctx context.Context, meta *Metadata, config *types.InstallConfig)
 		return errors.New(field.Required(field.NewPath("platform", "aws"), "AWS validation requires an AWS platform configuration").Error())
 	}
 	allErrs = append(allErrs, validateAMI(ctx, config)...)
+	allErrs = append(allErrs, validateHeterogeneousAMI(config)...)
 	allErrs = append(allErrs, validatePublicIpv4Pool(ctx, meta, field.NewPath("platform", "aws", "publicIpv4PoolId"), config)...)
 	allErrs = append(allErrs, validatePlatform(ctx, meta, field.NewPath("platform", "aws"), config.Platform.AWS, config.Networking, config.Publish)...)
+	allErrs = append(allErrs, validateEtcdEncryptionKey(ctx, meta, field.NewPath("etcdEncryption", "kms", "aws", "keyARN"), config.EtcdEncryption)...)
 
 	if config.ControlPlane != nil {
 		arch := string(config.ControlPlane.Architecture)
@@ -55,6 +58,8 @@ func Validate(ctx context.Context, meta *Metadata, config *types.InstallConfig)
 		pool.Set(config.AWS.DefaultMachinePlatform)
 		pool.Set(config.ControlPlane.Platform.AWS)
 		allErrs = append(allErrs, validateMachinePool(ctx, meta, field.NewPath("controlPlane", "platform", "aws"), config.Platform.AWS, pool, controlPlaneReq, "", arch)...)
+		allErrs = append(allErrs, validateDedicatedHosts(ctx, meta, field.NewPath("controlPlane", "platform", "aws"), pool, config.ControlPlane.Replicas)...)
+		allErrs = append(allErrs, validateCapacityReservation(ctx, meta, field.NewPath("controlPlane", "platform", "aws"), pool, config.ControlPlane.Replicas, true)...)
 	}
 
 	for idx, compute := range config.Compute {
@@ -72,6 +77,7 @@ func Validate(ctx context.Context, meta *Metadata, config *types.InstallConfig)
 		pool.Set(config.AWS.DefaultMachinePlatform)
 		pool.Set(compute.Platform.AWS)
 		allErrs = append(allErrs, validateMachinePool(ctx, meta, fldPath.Child("platform", "aws"), config.Platform.AWS, pool, computeReq, compute.Name, arch)...)
+		allErrs = append(allErrs, validateCapacityReservation(ctx, meta, fldPath.Child("platform", "aws"), pool, compute.Replicas, false)...)
 	}
 	return allErrs.ToAggregate()
 }
@@ -87,7 +93,13 @@ func validatePlatform(ctx context.Context, meta *Metadata, fldPath *field.Path,
 	}
 
 	if len(platform.Subnets) > 0 {
-		allErrs = append(allErrs, validateSubnets(ctx, meta, fldPath.Child("subnets"), platform.Subnets, networking, publish)...)
+		allErrs = append(allErrs, validateSubnets(ctx, meta, fldPath.Child("subnets"), platform.Subnets, networking, publish, platform.IsIPv6Only())...)
+		if platform.IsIPv6Only() {
+			allErrs = append(allErrs, validateIPv6Subnets(ctx, meta, fldPath.Child("subnets"), platform)...)
+		}
+	}
+	if platform.APILoadBalancer != nil && len(platform.APILoadBalancer.Subnets) > 0 {
+		allErrs = append(allErrs, validateAPILoadBalancerSubnets(ctx, meta, fldPath.Child("apiLoadBalancer", "subnets"), platform.APILoadBalancer.Subnets)...)
 	}
 	if platform.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, validateMachinePool(ctx, meta, fldPath.Child("defaultMachinePlatform"), platform, platform.DefaultMachinePlatform, controlPlaneReq, "", "")...)
@@ -106,6 +118,11 @@ func validateAMI(ctx context.Context, config *types.InstallConfig) field.ErrorLi
 		return nil
 	}
 
+	// accept AMI specified as boot image override
+	if config.BootImage(config.ControlPlane.Architecture) != "" {
+		return nil
+	}
+
 	// accept AMI specified for the default machine platform
 	if config.Platform.AWS.DefaultMachinePlatform != nil {
 		if config.Platform.AWS.DefaultMachinePlatform.AMIID != "" {
@@ -142,6 +159,33 @@ func validateAMI(ctx context.Context, config *types.InstallConfig) field.ErrorLi
 	return field.ErrorList{field.Required(field.NewPath("platform", "aws", "amiID"), "AMI must be provided")}
 }
 
+// validateHeterogeneousAMI checks that compute pools whose architecture differs
+// from the control plane have an AMI in the region. The AMI copied into the
+// region during install is built for the control plane architecture, so it
+// cannot be used by those pools.
+func validateHeterogeneousAMI(config *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config.ControlPlane == nil {
+		return allErrs
+	}
+	for i, c := range config.Compute {
+		if c.Architecture == config.ControlPlane.Architecture {
+			continue
+		}
+		if c.Replicas != nil && *c.Replicas == 0 {
+			continue
+		}
+		if rhcos.AMIRegions(c.Architecture).Has(config.Platform.AWS.Region) || config.BootImage(c.Architecture) != "" {
+			continue
+		}
+		if c.Platform.AWS != nil && c.Platform.AWS.AMIID != "" {
+			continue
+		}
+		allErrs = append(allErrs, field.Required(field.NewPath("compute").Index(i).Child("platform", "aws", "amiID"), fmt.Sprintf("no %s AMI is published in %s; an AMI must be provided", c.Architecture, config.Platform.AWS.Region)))
+	}
+	return allErrs
+}
+
 func validatePublicIpv4Pool(ctx context.Context, meta *Metadata, fldPath *field.Path, config *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -183,7 +227,7 @@ func validatePublicIpv4Pool(ctx context.Context, meta *Metadata, fldPath *field.
 	return nil
 }
 
-func validateSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, subnets []string, networking *types.Networking, publish types.PublishingStrategy) field.ErrorList {
+func validateSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, subnets []string, networking *types.Networking, publish types.PublishingStrategy, ipv6 bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	privateSubnets, err := meta.PrivateSubnets(ctx)
 	if err != nil {
@@ -221,8 +265,8 @@ func validateSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, s
 		}
 	}
 
-	allErrs = append(allErrs, validateSubnetCIDR(fldPath, privateSubnets, privateSubnetsIdx, networking.MachineNetwork)...)
-	allErrs = append(allErrs, validateSubnetCIDR(fldPath, publicSubnets, publicSubnetsIdx, networking.MachineNetwork)...)
+	allErrs = append(allErrs, validateSubnetCIDR(fldPath, privateSubnets, privateSubnetsIdx, networking.MachineNetwork, ipv6)...)
+	allErrs = append(allErrs, validateSubnetCIDR(fldPath, publicSubnets, publicSubnetsIdx, networking.MachineNetwork, ipv6)...)
 	allErrs = append(allErrs, validateDuplicateSubnetZones(fldPath, privateSubnets, privateSubnetsIdx, "private")...)
 	allErrs = append(allErrs, validateDuplicateSubnetZones(fldPath, publicSubnets, publicSubnetsIdx, "public")...)
 	allErrs = append(allErrs, validateDuplicateSubnetZones(fldPath, edgeSubnets, edgeSubnetsIdx, "edge")...)
@@ -235,7 +279,7 @@ func validateSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, s
 	for _, subnet := range publicSubnets {
 		publicZones.Insert(subnet.Zone.Name)
 	}
-	if publish == types.ExternalPublishingStrategy && !publicZones.IsSuperset(privateZones) {
+	if publish != types.InternalPublishingStrategy && !publicZones.IsSuperset(privateZones) {
 		errMsg := fmt.Sprintf("No public subnet provided for zones %s", sets.List(privateZones.Difference(publicZones)))
 		allErrs = append(allErrs, field.Invalid(fldPath, subnets, errMsg))
 	}
@@ -243,6 +287,60 @@ func validateSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, s
 	return allErrs
 }
 
+// validateIPv6Subnets checks that the subnets of an IPv6 cluster are
+// dual-stack, since the network load balancers cannot be placed in IPv6-only
+// subnets, and, when the machines reach IPv4 destinations through NAT64,
+// that the private subnets resolve with DNS64 and route the NAT64 prefix to
+// a NAT gateway.
+func validateIPv6Subnets(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform) field.ErrorList {
+	allErrs := field.ErrorList{}
+	privateSubnets, err := meta.PrivateSubnets(ctx)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, platform.Subnets, err.Error()))
+	}
+	publicSubnets, err := meta.PublicSubnets(ctx)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, platform.Subnets, err.Error()))
+	}
+	nat64 := platform.IPv6Egress != awstypes.NoneIPv6Egress
+
+	for idx, id := range platform.Subnets {
+		fp := fldPath.Index(idx)
+		if subnet, ok := privateSubnets[id]; ok {
+			if subnet.IPv6Native || subnet.CIDR == "" || subnet.IPv6CIDR == "" {
+				allErrs = append(allErrs, field.Invalid(fp, id, "subnet must be dual-stack when ipFamily is IPv6, load balancers cannot be placed in IPv6-only subnets"))
+			}
+			if nat64 && !subnet.DNS64 {
+				allErrs = append(allErrs, field.Invalid(fp, id, "DNS64 must be enabled on the subnet when ipv6Egress is NAT64"))
+			}
+			if nat64 && !subnet.NAT64 {
+				allErrs = append(allErrs, field.Invalid(fp, id, fmt.Sprintf("the subnet route table must route %s to a NAT gateway when ipv6Egress is NAT64", nat64Prefix)))
+			}
+			continue
+		}
+		if subnet, ok := publicSubnets[id]; ok && subnet.IPv6CIDR == "" {
+			allErrs = append(allErrs, field.Invalid(fp, id, "subnet must have an IPv6 CIDR block when ipFamily is IPv6"))
+		}
+	}
+	return allErrs
+}
+
+// validateAPILoadBalancerSubnets checks that the subnets of the external API
+// load balancer are public.
+func validateAPILoadBalancerSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, subnets []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	publicSubnets, err := meta.PublicSubnets(ctx)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, subnets, err.Error()))
+	}
+	for idx, id := range subnets {
+		if _, ok := publicSubnets[id]; !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx), id, "subnet of the external API load balancer must be public"))
+		}
+	}
+	return allErrs
+}
+
 func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform, pool *awstypes.MachinePool, req resourceRequirements, poolName string, arch string) field.ErrorList {
 	var err error
 	allErrs := field.ErrorList{}
@@ -333,6 +431,12 @@ func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Pat
 				errMsg := fmt.Sprintf("instance type supported architectures %s do not match specified architecture %s", sets.List(instanceArches), arch)
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), pool.InstanceType, errMsg))
 			}
+			if platform.IsIPv6Only() && !typeMeta.IPv6 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), pool.InstanceType, "instance type does not support IPv6"))
+			}
+			if pool.SpotMarketOptions != nil && !typeMeta.Spot {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), pool.InstanceType, "instance type is not available as a spot instance"))
+			}
 		} else {
 			errMsg := fmt.Sprintf("instance type %s not found", pool.InstanceType)
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), pool.InstanceType, errMsg))
@@ -346,6 +450,146 @@ func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Pat
 	return allErrs
 }
 
+// validateDedicatedHosts checks that the dedicated hosts of the control plane are available, are
+// located in the zones of the pool and have the capacity to launch the replicas of each zone,
+// the replicas being spread across the zones in order.
+func validateDedicatedHosts(ctx context.Context, meta *Metadata, fldPath *field.Path, pool *awstypes.MachinePool, replicas *int64) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if pool.Tenancy != awstypes.HostTenancy || pool.HostPlacement == nil || len(pool.HostPlacement.HostIDs) == 0 {
+		return allErrs
+	}
+	// Dedicated hosts only support the instance types of a single family, so
+	// the default instance type cannot be relied upon.
+	if pool.InstanceType == "" {
+		return append(allErrs, field.Required(fldPath.Child("type"), "instance type must be set when launching on dedicated hosts"))
+	}
+	if len(pool.Zones) == 0 {
+		return append(allErrs, field.Required(fldPath.Child("zones"), "zones must be set when launching on dedicated hosts"))
+	}
+
+	hosts, err := meta.DedicatedHosts(ctx, pool.HostPlacement.HostIDs)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath.Child("hostPlacement", "hostIDs"), err))
+	}
+
+	zones := sets.New(pool.Zones...)
+	capacity := map[string]int64{}
+	for i, id := range pool.HostPlacement.HostIDs {
+		idPath := fldPath.Child("hostPlacement", "hostIDs").Index(i)
+		host, ok := hosts[id]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(idPath, id))
+			continue
+		}
+		if host.State != ec2.AllocationStateAvailable {
+			allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("dedicated host is %s", host.State)))
+			continue
+		}
+		if zones.Len() > 0 && !zones.Has(host.Zone) {
+			allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("dedicated host is in zone %s, which is not one of the zones of the machine pool %s", host.Zone, sets.List(zones))))
+			continue
+		}
+		hostCapacity, ok := host.Capacity[pool.InstanceType]
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("dedicated host does not support instance type %s", pool.InstanceType)))
+			continue
+		}
+		capacity[host.Zone] += hostCapacity
+	}
+	if len(allErrs) > 0 || replicas == nil {
+		return allErrs
+	}
+
+	required := map[string]int64{}
+	for i := int64(0); i < *replicas; i++ {
+		required[pool.Zones[i%int64(len(pool.Zones))]]++
+	}
+	for _, zone := range sets.List(zones) {
+		if capacity[zone] < required[zone] {
+			errMsg := fmt.Sprintf("dedicated hosts in zone %s have capacity for %d %s instances, %d are required", zone, capacity[zone], pool.InstanceType, required[zone])
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPlacement", "hostIDs"), pool.HostPlacement.HostIDs, errMsg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCapacityReservation checks that the capacity reservation of a machine pool is active, matches
+// the instance type, zones and tenancy of the pool and has the capacity to launch its replicas. Compute
+// instances are launched by the machine API, which cannot target a reservation, so the reservation
+// must accept instances with open matching.
+func validateCapacityReservation(ctx context.Context, meta *Metadata, fldPath *field.Path, pool *awstypes.MachinePool, replicas *int64, controlPlane bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	id := pool.CapacityReservationID
+	if id == "" {
+		return allErrs
+	}
+	idPath := fldPath.Child("capacityReservationID")
+	// Capacity reservations are for a single instance type, so the default
+	// instance type cannot be relied upon.
+	if pool.InstanceType == "" {
+		return append(allErrs, field.Required(fldPath.Child("type"), "instance type must be set when launching into a capacity reservation"))
+	}
+
+	reservations, err := meta.CapacityReservations(ctx, []string{id})
+	if err != nil {
+		return append(allErrs, field.InternalError(idPath, err))
+	}
+	reservation, ok := reservations[id]
+	if !ok {
+		return append(allErrs, field.NotFound(idPath, id))
+	}
+
+	if reservation.State != ec2.CapacityReservationStateActive {
+		return append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("capacity reservation is %s", reservation.State)))
+	}
+	if reservation.InstanceType != pool.InstanceType {
+		allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("capacity reservation is for instance type %s, not %s", reservation.InstanceType, pool.InstanceType)))
+	}
+	if len(pool.Zones) != 1 || pool.Zones[0] != reservation.Zone {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("zones"), pool.Zones, fmt.Sprintf("machine pool must be limited to the zone %s of the capacity reservation", reservation.Zone)))
+	}
+	tenancy := string(pool.Tenancy)
+	if tenancy == "" {
+		tenancy = string(awstypes.DefaultTenancy)
+	}
+	if reservation.Tenancy != tenancy {
+		allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("capacity reservation tenancy %s does not match the machine pool tenancy %s", reservation.Tenancy, tenancy)))
+	}
+	if !controlPlane && reservation.MatchCriteria != ec2.InstanceMatchCriteriaOpen {
+		allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("capacity reservation for compute instances must accept %s instance matching", ec2.InstanceMatchCriteriaOpen)))
+	}
+	if replicas != nil && reservation.Available < *replicas {
+		allErrs = append(allErrs, field.Invalid(idPath, id, fmt.Sprintf("capacity reservation has capacity for %d instances, %d are required", reservation.Available, *replicas)))
+	}
+
+	return allErrs
+}
+
+// validateEtcdEncryptionKey checks that the KMS key encrypting etcd is an
+// enabled symmetric encryption key, so that the API servers can use it.
+func validateEtcdEncryptionKey(ctx context.Context, meta *Metadata, fldPath *field.Path, encryption *types.EtcdEncryption) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if encryption == nil || encryption.KMS == nil || encryption.KMS.AWS == nil || encryption.KMS.AWS.KeyARN == "" {
+		return allErrs
+	}
+	keyARN := encryption.KMS.AWS.KeyARN
+
+	key, err := meta.KMSKey(ctx, keyARN)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, keyARN, fmt.Sprintf("the KMS key is not accessible: %v", err)))
+	}
+	if key.State != kms.KeyStateEnabled {
+		allErrs = append(allErrs, field.Invalid(fldPath, keyARN, fmt.Sprintf("the KMS key is %s", key.State)))
+	}
+	if key.KeyUsage != kms.KeyUsageTypeEncryptDecrypt || key.KeySpec != kms.KeySpecSymmetricDefault {
+		allErrs = append(allErrs, field.Invalid(fldPath, keyARN, fmt.Sprintf("the KMS key must be a %s key for %s", kms.KeySpecSymmetricDefault, kms.KeyUsageTypeEncryptDecrypt)))
+	}
+	return allErrs
+}
+
 func translateEC2Arches(arches []string) sets.Set[string] {
 	res := sets.New[string]()
 	for _, arch := range arches {
@@ -386,11 +630,17 @@ func validateSecurityGroupIDs(ctx context.Context, meta *Metadata, fldPath *fiel
 	return allErrs
 }
 
-func validateSubnetCIDR(fldPath *field.Path, subnets Subnets, idxMap map[string]int, networks []types.MachineNetworkEntry) field.ErrorList {
+func validateSubnetCIDR(fldPath *field.Path, subnets Subnets, idxMap map[string]int, networks []types.MachineNetworkEntry, ipv6 bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for id, v := range subnets {
 		fp := fldPath.Index(idxMap[id])
-		cidr, _, err := net.ParseCIDR(v.CIDR)
+		subnetCIDR := v.CIDR
+		if ipv6 {
+			// The machines of IPv6 clusters are addressed from the IPv6
+			// CIDR block of the dual-stack subnets.
+			subnetCIDR = v.IPv6CIDR
+		}
+		cidr, _, err := net.ParseCIDR(subnetCIDR)
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(fp, id, err.Error()))
 			continue
@@ -533,7 +783,7 @@ var requiredServices = []string{
 }
 
 // ValidateForProvisioning validates if the install config is valid for provisioning the cluster.
-func ValidateForProvisioning(client API, ic *types.InstallConfig, metadata *Metadata) error {
+func ValidateForProvisioning(ctx context.Context, client API, ic *types.InstallConfig, metadata *Metadata) error {
 	if ic.Publish == types.InternalPublishingStrategy && ic.AWS.HostedZone == "" {
 		return nil
 	}
@@ -556,7 +806,7 @@ func ValidateForProvisioning(client API, ic *types.InstallConfig, metadata *Meta
 			}.ToAggregate()
 		}
 
-		if errs := validateHostedZone(zoneOutput, zonePath, zoneName, metadata); len(errs) > 0 {
+		if errs := validateHostedZone(ctx, zoneOutput, zonePath, zoneName, metadata); len(errs) > 0 {
 			allErrs = append(allErrs, errs...)
 		}
 
@@ -581,11 +831,11 @@ func ValidateForProvisioning(client API, ic *types.InstallConfig, metadata *Meta
 	return allErrs.ToAggregate()
 }
 
-func validateHostedZone(hostedZoneOutput *route53.GetHostedZoneOutput, hostedZonePath *field.Path, hostedZoneName string, metadata *Metadata) field.ErrorList {
+func validateHostedZone(ctx context.Context, hostedZoneOutput *route53.GetHostedZoneOutput, hostedZonePath *field.Path, hostedZoneName string, metadata *Metadata) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	// validate that the hosted zone is associated with the VPC containing the existing subnets for the cluster
-	vpcID, err := metadata.VPC(context.TODO())
+	vpcID, err := metadata.VPC(ctx)
 	if err == nil {
 		if !isHostedZoneAssociatedWithVPC(hostedZoneOutput, vpcID) {
 			allErrs = append(allErrs, field.Invalid(hostedZonePath, hostedZoneName, "hosted zone is not associated with the VPC"))
@@ -171,6 +171,34 @@ func validEdgeSubnets() Subnets {
 	}
 }
 
+// validIPv6Subnets returns dual-stack private and public subnets, indexed
+// like validPrivateSubnets and validPublicSubnets.
+func validIPv6Subnets() (Subnets, Subnets) {
+	private, public := Subnets{}, Subnets{}
+	for i, zone := range validAvailZones() {
+		private["valid-private-subnet-"+zone] = Subnet{
+			Zone:     &Zone{Name: zone},
+			CIDR:     fmt.Sprintf("10.0.%d.0/24", i+1),
+			IPv6CIDR: fmt.Sprintf("2600:1f18:0:%d::/64", i+1),
+			DNS64:    true,
+			NAT64:    true,
+		}
+		public["valid-public-subnet-"+zone] = Subnet{
+			Zone:     &Zone{Name: zone},
+			CIDR:     fmt.Sprintf("10.0.%d.0/24", i+4),
+			IPv6CIDR: fmt.Sprintf("2600:1f18:0:%d::/64", i+4),
+		}
+	}
+	return private, public
+}
+
+func validIPv6InstallConfig() *types.InstallConfig {
+	c := validInstallConfig()
+	c.Networking.MachineNetwork = append(c.Networking.MachineNetwork, types.MachineNetworkEntry{CIDR: *ipnet.MustParseCIDR("2600:1f18::/56")})
+	c.Platform.AWS.IPFamily = aws.IPv6IPFamily
+	return c
+}
+
 func validServiceEndpoints() []aws.ServiceEndpoint {
 	return []aws.ServiceEndpoint{{
 		Name: "ec2",
@@ -206,6 +234,69 @@ func invalidServiceEndpoint() []aws.ServiceEndpoint {
 	}}
 }
 
+// validDedicatedHostsInstallConfig returns an install-config which launches
+// the control plane on one dedicated host in each zone.
+func validDedicatedHostsInstallConfig() *types.InstallConfig {
+	c := validInstallConfig()
+	c.ControlPlane.Platform.AWS.Zones = []string{"a", "b", "c"}
+	c.ControlPlane.Platform.AWS.InstanceType = "m5.xlarge"
+	c.ControlPlane.Platform.AWS.Tenancy = aws.HostTenancy
+	c.ControlPlane.Platform.AWS.HostPlacement = &aws.HostPlacement{
+		HostIDs: []string{"h-0123456789abcdef0", "h-0123456789abcdef1", "h-0123456789abcdef2"},
+	}
+	return c
+}
+
+func validDedicatedHosts() map[string]Host {
+	hosts := map[string]Host{}
+	for i, zone := range []string{"a", "b", "c"} {
+		id := fmt.Sprintf("h-0123456789abcdef%d", i)
+		hosts[id] = Host{
+			ID:       id,
+			Zone:     zone,
+			State:    ec2.AllocationStateAvailable,
+			Capacity: map[string]int64{"m5.large": 2, "m5.xlarge": 1},
+		}
+	}
+	return hosts
+}
+
+// validCapacityReservationInstallConfig returns an install-config which launches
+// the control plane and compute instances into capacity reservations in zone a.
+func validCapacityReservationInstallConfig() *types.InstallConfig {
+	c := validInstallConfig()
+	c.ControlPlane.Platform.AWS.Zones = []string{"a"}
+	c.ControlPlane.Platform.AWS.InstanceType = "m5.xlarge"
+	c.ControlPlane.Platform.AWS.CapacityReservationID = "cr-0123456789abcdef0"
+	c.Compute[0].Platform.AWS.Zones = []string{"a"}
+	c.Compute[0].Platform.AWS.InstanceType = "m5.large"
+	c.Compute[0].Platform.AWS.CapacityReservationID = "cr-0123456789abcdef1"
+	return c
+}
+
+func validCapacityReservations() map[string]CapacityReservation {
+	return map[string]CapacityReservation{
+		"cr-0123456789abcdef0": {
+			ID:            "cr-0123456789abcdef0",
+			InstanceType:  "m5.xlarge",
+			Zone:          "a",
+			State:         ec2.CapacityReservationStateActive,
+			Tenancy:       ec2.CapacityReservationTenancyDefault,
+			MatchCriteria: ec2.InstanceMatchCriteriaTargeted,
+			Available:     3,
+		},
+		"cr-0123456789abcdef1": {
+			ID:            "cr-0123456789abcdef1",
+			InstanceType:  "m5.large",
+			Zone:          "a",
+			State:         ec2.CapacityReservationStateActive,
+			Tenancy:       ec2.CapacityReservationTenancyDefault,
+			MatchCriteria: ec2.InstanceMatchCriteriaOpen,
+			Available:     5,
+		},
+	}
+}
+
 func validInstanceTypes() map[string]InstanceType {
 	return map[string]InstanceType{
 		"t2.small": {
@@ -217,16 +308,20 @@ func validInstanceTypes() map[string]InstanceType {
 			DefaultVCpus: 2,
 			MemInMiB:     8192,
 			Arches:       []string{ec2.ArchitectureTypeX8664},
+			IPv6:         true,
+			Spot:         true,
 		},
 		"m5.xlarge": {
 			DefaultVCpus: 4,
 			MemInMiB:     16384,
 			Arches:       []string{ec2.ArchitectureTypeX8664},
+			IPv6:         true,
 		},
 		"m6g.xlarge": {
 			DefaultVCpus: 4,
 			MemInMiB:     16384,
 			Arches:       []string{ec2.ArchitectureTypeArm64},
+			IPv6:         true,
 		},
 	}
 }
@@ -266,6 +361,9 @@ func TestValidate(t *testing.T) {
 		publicSubnets  Subnets
 		edgeSubnets    Subnets
 		instanceTypes  map[string]InstanceType
+		hosts          map[string]Host
+		reservations   map[string]CapacityReservation
+		kmsKeys        map[string]KMSKey
 		proxy          string
 		expectErr      string
 	}{{
@@ -319,6 +417,31 @@ func TestValidate(t *testing.T) {
 		}(),
 		availZones:     validAvailZones(),
 		privateSubnets: validPrivateSubnets(),
+	}, {
+		name: "valid API load balancer subnets",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Platform.AWS.APILoadBalancer = &aws.APILoadBalancer{
+				Subnets: []string{"valid-public-subnet-a", "valid-public-subnet-b"},
+			}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+	}, {
+		name: "private API load balancer subnet",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Platform.AWS.APILoadBalancer = &aws.APILoadBalancer{
+				Subnets: []string{"valid-public-subnet-a", "valid-private-subnet-b"},
+			}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		expectErr:      `^\Qplatform.aws.apiLoadBalancer.subnets[1]: Invalid value: "valid-private-subnet-b": subnet of the external API load balancer must be public\E$`,
 	}, {
 		name: "valid instance types",
 		installConfig: func() *types.InstallConfig {
@@ -720,6 +843,17 @@ func TestValidate(t *testing.T) {
 		availZones:     validAvailZones(),
 		privateSubnets: validPrivateSubnets(),
 		publicSubnets:  validPublicSubnets(),
+	}, {
+		name: "accept AMI from boot images",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Platform.AWS.Region = "us-gov-east-1"
+			c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "ami-0123456789abcdef0"}}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
 	}, {
 		name: "accept AMI from default machine platform",
 		installConfig: func() *types.InstallConfig {
@@ -755,6 +889,32 @@ func TestValidate(t *testing.T) {
 		availZones:     validAvailZones(),
 		privateSubnets: validPrivateSubnets(),
 		publicSubnets:  validPublicSubnets(),
+	}, {
+		name: "AMI required for heterogeneous compute pool",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Platform.AWS.Region = "us-gov-east-1"
+			c.Platform.AWS.AMIID = "custom-ami"
+			c.Compute[0].Architecture = types.ArchitectureARM64
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		expectErr:      `^compute\[0\]\.platform\.aws\.amiID: Required value: no arm64 AMI is published in us-gov-east-1; an AMI must be provided$`,
+	}, {
+		name: "accept AMI for heterogeneous compute pool",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Platform.AWS.Region = "us-gov-east-1"
+			c.Platform.AWS.AMIID = "custom-ami"
+			c.Compute[0].Architecture = types.ArchitectureARM64
+			c.Compute[0].Platform.AWS.AMIID = "custom-arm64-ami"
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
 	}, {
 		name: "AMI not provided for unknown region",
 		installConfig: func() *types.InstallConfig {
@@ -807,6 +967,79 @@ func TestValidate(t *testing.T) {
 		publicSubnets:  validPublicSubnets(),
 		proxy:          "http://proxy.com",
 		expectErr:      `^\Qplatform.aws.serviceEndpoints[0].url: Invalid value: "http://test": Head "http://test": dial tcp: lookup test\E.*: no such host$`,
+	}, {
+		name: "valid dual-stack subnets with IPv6 ipFamily",
+		installConfig: func() *types.InstallConfig {
+			c := validIPv6InstallConfig()
+			c.ControlPlane.Platform.AWS.InstanceType = "m5.xlarge"
+			return c
+		}(),
+		availZones: validAvailZones(),
+		privateSubnets: func() Subnets {
+			private, _ := validIPv6Subnets()
+			return private
+		}(),
+		publicSubnets: func() Subnets {
+			_, public := validIPv6Subnets()
+			return public
+		}(),
+		instanceTypes: validInstanceTypes(),
+	}, {
+		name:          "invalid dual-stack subnets without DNS64 and NAT64",
+		installConfig: validIPv6InstallConfig(),
+		availZones:    validAvailZones(),
+		privateSubnets: func() Subnets {
+			private, _ := validIPv6Subnets()
+			subnet := private["valid-private-subnet-b"]
+			subnet.DNS64 = false
+			subnet.NAT64 = false
+			private["valid-private-subnet-b"] = subnet
+			return private
+		}(),
+		publicSubnets: func() Subnets {
+			_, public := validIPv6Subnets()
+			return public
+		}(),
+		expectErr: `^\Q[platform.aws.subnets[1]: Invalid value: "valid-private-subnet-b": DNS64 must be enabled on the subnet when ipv6Egress is NAT64, platform.aws.subnets[1]: Invalid value: "valid-private-subnet-b": the subnet route table must route 64:ff9b::/96 to a NAT gateway when ipv6Egress is NAT64\E`,
+	}, {
+		name: "invalid IPv6-only private subnets with IPv6 ipFamily",
+		installConfig: func() *types.InstallConfig {
+			c := validIPv6InstallConfig()
+			c.Platform.AWS.IPv6Egress = aws.NoneIPv6Egress
+			return c
+		}(),
+		availZones: validAvailZones(),
+		privateSubnets: func() Subnets {
+			private, _ := validIPv6Subnets()
+			subnet := private["valid-private-subnet-a"]
+			subnet.CIDR = ""
+			subnet.IPv6Native = true
+			private["valid-private-subnet-a"] = subnet
+			return private
+		}(),
+		publicSubnets: func() Subnets {
+			_, public := validIPv6Subnets()
+			return public
+		}(),
+		expectErr: `platform\.aws\.subnets\[0\]: Invalid value: "valid-private-subnet-a": subnet must be dual-stack when ipFamily is IPv6, load balancers cannot be placed in IPv6-only subnets`,
+	}, {
+		name: "invalid instance type without IPv6 support",
+		installConfig: func() *types.InstallConfig {
+			c := validIPv6InstallConfig()
+			c.ControlPlane.Platform.AWS.InstanceType = "t2.small"
+			return c
+		}(),
+		availZones: validAvailZones(),
+		privateSubnets: func() Subnets {
+			private, _ := validIPv6Subnets()
+			return private
+		}(),
+		publicSubnets: func() Subnets {
+			_, public := validIPv6Subnets()
+			return public
+		}(),
+		instanceTypes: validInstanceTypes(),
+		expectErr:     `controlPlane\.platform\.aws\.type: Invalid value: "t2\.small": instance type does not support IPv6`,
 	}, {
 		name: "invalid public ipv4 pool private installation",
 		installConfig: func() *types.InstallConfig {
@@ -818,17 +1051,223 @@ func TestValidate(t *testing.T) {
 		}(),
 		availZones: validAvailZones(),
 		expectErr:  `^platform.aws.publicIpv4PoolId: Invalid value: "ipv4pool-ec2-123": publish strategy Internal can't be used with custom Public IPv4 Pools$`,
+	}, {
+		name:           "valid dedicated hosts",
+		installConfig:  validDedicatedHostsInstallConfig(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts:          validDedicatedHosts(),
+	}, {
+		name: "invalid dedicated hosts without instance type",
+		installConfig: func() *types.InstallConfig {
+			c := validDedicatedHostsInstallConfig()
+			c.ControlPlane.Platform.AWS.InstanceType = ""
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts:          validDedicatedHosts(),
+		expectErr:      `^controlPlane\.platform\.aws\.type: Required value: instance type must be set when launching on dedicated hosts$`,
+	}, {
+		name:           "invalid dedicated hosts unavailable",
+		installConfig:  validDedicatedHostsInstallConfig(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts: func() map[string]Host {
+			hosts := validDedicatedHosts()
+			host := hosts["h-0123456789abcdef1"]
+			host.State = ec2.AllocationStateUnderAssessment
+			hosts["h-0123456789abcdef1"] = host
+			return hosts
+		}(),
+		expectErr: `^controlPlane\.platform\.aws\.hostPlacement\.hostIDs\[1\]: Invalid value: "h-0123456789abcdef1": dedicated host is under-assessment$`,
+	}, {
+		name: "invalid dedicated hosts zone",
+		installConfig: func() *types.InstallConfig {
+			c := validDedicatedHostsInstallConfig()
+			c.ControlPlane.Platform.AWS.Zones = []string{"a", "b"}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts:          validDedicatedHosts(),
+		expectErr:      `^controlPlane\.platform\.aws\.hostPlacement\.hostIDs\[2\]: Invalid value: "h-0123456789abcdef2": dedicated host is in zone c, which is not one of the zones of the machine pool \[a b\]$`,
+	}, {
+		name: "invalid dedicated hosts instance type",
+		installConfig: func() *types.InstallConfig {
+			c := validDedicatedHostsInstallConfig()
+			c.ControlPlane.Platform.AWS.InstanceType = "m6g.xlarge"
+			c.ControlPlane.Architecture = types.ArchitectureARM64
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts:          validDedicatedHosts(),
+		expectErr:      `^\[controlPlane\.platform\.aws\.hostPlacement\.hostIDs\[0\]: Invalid value: "h-0123456789abcdef0": dedicated host does not support instance type m6g\.xlarge, .*\]$`,
+	}, {
+		name:           "invalid dedicated hosts capacity",
+		installConfig:  validDedicatedHostsInstallConfig(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts: func() map[string]Host {
+			hosts := validDedicatedHosts()
+			hosts["h-0123456789abcdef2"].Capacity["m5.xlarge"] = 0
+			return hosts
+		}(),
+		expectErr: `^controlPlane\.platform\.aws\.hostPlacement\.hostIDs: Invalid value: \[\]string{"h-0123456789abcdef0", "h-0123456789abcdef1", "h-0123456789abcdef2"}: dedicated hosts in zone c have capacity for 0 m5\.xlarge instances, 1 are required$`,
+	}, {
+		name: "invalid dedicated hosts capacity of a zone",
+		installConfig: func() *types.InstallConfig {
+			c := validDedicatedHostsInstallConfig()
+			c.ControlPlane.Platform.AWS.Zones = []string{"a", "b"}
+			c.ControlPlane.Platform.AWS.HostPlacement.HostIDs = []string{"h-0123456789abcdef0", "h-0123456789abcdef1"}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		hosts:          validDedicatedHosts(),
+		expectErr:      `^controlPlane\.platform\.aws\.hostPlacement\.hostIDs: Invalid value: \[\]string{"h-0123456789abcdef0", "h-0123456789abcdef1"}: dedicated hosts in zone a have capacity for 1 m5\.xlarge instances, 2 are required$`,
+	}, {
+		name:           "valid capacity reservations",
+		installConfig:  validCapacityReservationInstallConfig(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		reservations:   validCapacityReservations(),
+	}, {
+		name:           "invalid targeted capacity reservation for compute",
+		installConfig:  validCapacityReservationInstallConfig(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		reservations: func() map[string]CapacityReservation {
+			reservations := validCapacityReservations()
+			reservation := reservations["cr-0123456789abcdef1"]
+			reservation.MatchCriteria = ec2.InstanceMatchCriteriaTargeted
+			reservations["cr-0123456789abcdef1"] = reservation
+			return reservations
+		}(),
+		expectErr: `^compute\[0\]\.platform\.aws\.capacityReservationID: Invalid value: "cr-0123456789abcdef1": capacity reservation for compute instances must accept open instance matching$`,
+	}, {
+		name: "invalid capacity reservation zone and instance type",
+		installConfig: func() *types.InstallConfig {
+			c := validCapacityReservationInstallConfig()
+			c.ControlPlane.Platform.AWS.Zones = []string{"a", "b"}
+			c.ControlPlane.Platform.AWS.InstanceType = "m6g.xlarge"
+			c.ControlPlane.Architecture = types.ArchitectureARM64
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		reservations:   validCapacityReservations(),
+		expectErr:      `^\[controlPlane\.platform\.aws\.capacityReservationID: Invalid value: "cr-0123456789abcdef0": capacity reservation is for instance type m5\.xlarge, not m6g\.xlarge, controlPlane\.platform\.aws\.zones: Invalid value: \[\]string{"a", "b"}: machine pool must be limited to the zone a of the capacity reservation\]$`,
+	}, {
+		name:           "invalid capacity reservation capacity",
+		installConfig:  validCapacityReservationInstallConfig(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		reservations: func() map[string]CapacityReservation {
+			reservations := validCapacityReservations()
+			reservation := reservations["cr-0123456789abcdef0"]
+			reservation.Available = 2
+			reservations["cr-0123456789abcdef0"] = reservation
+			return reservations
+		}(),
+		expectErr: `^controlPlane\.platform\.aws\.capacityReservationID: Invalid value: "cr-0123456789abcdef0": capacity reservation has capacity for 2 instances, 3 are required$`,
+	}, {
+		name: "valid etcd encryption KMS key",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.EtcdEncryption = &types.EtcdEncryption{
+				Type: types.EtcdEncryptionTypeKMS,
+				KMS:  &types.EtcdEncryptionKMS{AWS: &types.AWSKMSKey{KeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}},
+			}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		kmsKeys: map[string]KMSKey{
+			"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab": {ARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", State: "Enabled", KeyUsage: "ENCRYPT_DECRYPT", KeySpec: "SYMMETRIC_DEFAULT"},
+		},
+	}, {
+		name: "disabled asymmetric etcd encryption KMS key",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.EtcdEncryption = &types.EtcdEncryption{
+				Type: types.EtcdEncryptionTypeKMS,
+				KMS:  &types.EtcdEncryptionKMS{AWS: &types.AWSKMSKey{KeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}},
+			}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		kmsKeys: map[string]KMSKey{
+			"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab": {ARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", State: "Disabled", KeyUsage: "SIGN_VERIFY", KeySpec: "RSA_2048"},
+		},
+		expectErr: `^\[etcdEncryption\.kms\.aws\.keyARN: Invalid value: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab": the KMS key is Disabled, etcdEncryption\.kms\.aws\.keyARN: Invalid value: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab": the KMS key must be a SYMMETRIC_DEFAULT key for ENCRYPT_DECRYPT\]$`,
+	}, {
+		name: "valid spot compute pool",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Compute[0].Platform.AWS.InstanceType = "m5.large"
+			c.Compute[0].Platform.AWS.SpotMarketOptions = &aws.SpotMarketOptions{MaxPrice: "0.05"}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+	}, {
+		name: "invalid spot instance type",
+		installConfig: func() *types.InstallConfig {
+			c := validInstallConfig()
+			c.Compute[0].Platform.AWS.InstanceType = "m5.xlarge"
+			c.Compute[0].Platform.AWS.SpotMarketOptions = &aws.SpotMarketOptions{}
+			return c
+		}(),
+		availZones:     validAvailZones(),
+		privateSubnets: validPrivateSubnets(),
+		publicSubnets:  validPublicSubnets(),
+		instanceTypes:  validInstanceTypes(),
+		expectErr:      `^compute\[0\]\.platform\.aws\.type: Invalid value: "m5\.xlarge": instance type is not available as a spot instance$`,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			meta := &Metadata{
-				availabilityZones: test.availZones,
-				privateSubnets:    test.privateSubnets,
-				publicSubnets:     test.publicSubnets,
-				edgeSubnets:       test.edgeSubnets,
-				instanceTypes:     test.instanceTypes,
-				Subnets:           test.installConfig.Platform.AWS.Subnets,
+				availabilityZones:    test.availZones,
+				privateSubnets:       test.privateSubnets,
+				publicSubnets:        test.publicSubnets,
+				edgeSubnets:          test.edgeSubnets,
+				instanceTypes:        test.instanceTypes,
+				hosts:                test.hosts,
+				capacityReservations: test.reservations,
+				kmsKeys:              test.kmsKeys,
+				Subnets:              test.installConfig.Platform.AWS.Subnets,
 			}
 			if test.proxy != "" {
 				os.Setenv("HTTP_PROXY", test.proxy)
@@ -964,7 +1403,7 @@ func TestValidateForProvisioning(t *testing.T) {
 				Subnets:           editedInstallConfig.Platform.AWS.Subnets,
 			}
 
-			err := ValidateForProvisioning(route53Client, editedInstallConfig, meta)
+			err := ValidateForProvisioning(context.TODO(), route53Client, editedInstallConfig, meta)
 			if test.expectedErr == "" {
 				assert.NoError(t, err)
 			} else {
@@ -4,38 +4,46 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	survey "github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/core"
+	aznetwork "github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/network/mgmt/network"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/azure/defaults"
+	"github.com/openshift/installer/pkg/version"
 )
 
 const (
 	defaultRegion string = "eastus"
 )
 
-// Platform collects azure-specific configuration.
-func Platform() (*azure.Platform, error) {
+// Platform collects azure-specific configuration, and the machine networks
+// of the existing virtual network when one is chosen.
+func Platform() (*azure.Platform, []types.MachineNetworkEntry, error) {
 	// Create client using public cloud because install config has not been generated yet.
 	const cloudName = azure.PublicCloud
 	ssn, err := GetSession(cloudName, "")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client := NewClient(ssn)
 
 	regions, err := getRegions(context.TODO(), client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get list of regions: %w", err)
+		return nil, nil, fmt.Errorf("failed to get list of regions: %w", err)
 	}
 
 	resourceCapableRegions, err := getResourceCapableRegions(context.TODO(), client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get list of resources to check available regions: %w", err)
+		return nil, nil, fmt.Errorf("failed to get list of resources to check available regions: %w", err)
 	}
 
 	longRegions := make([]string, 0, len(regions))
@@ -62,7 +70,7 @@ func Platform() (*azure.Platform, error) {
 
 	_, ok := regions[defaultRegion]
 	if !ok {
-		return nil, fmt.Errorf("installer bug: invalid default azure region %q", defaultRegion)
+		return nil, nil, fmt.Errorf("installer bug: invalid default azure region %q", defaultRegion)
 	}
 
 	sort.Strings(longRegions)
@@ -89,13 +97,187 @@ func Platform() (*azure.Platform, error) {
 		},
 	}, &region)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &azure.Platform{
+	platform := &azure.Platform{
 		Region:    region,
 		CloudName: cloudName,
-	}, nil
+	}
+	machineNetwork, err := selectVirtualNetwork(context.TODO(), client, platform)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := selectInstanceType(context.TODO(), client, platform); err != nil {
+		return nil, nil, err
+	}
+	return platform, machineNetwork, nil
+}
+
+// newVirtualNetworkOption is the option to let the installer create the
+// virtual network.
+const newVirtualNetworkOption = "Create a new virtual network"
+
+// selectVirtualNetwork offers the virtual networks of the region and, when an
+// existing virtual network is chosen, its subnets for the control plane and
+// the compute machines. It returns the address prefixes of the chosen virtual
+// network as machine networks. When the virtual networks cannot be listed, a
+// new virtual network is used.
+func selectVirtualNetwork(ctx context.Context, client *Client, platform *azure.Platform) ([]types.MachineNetworkEntry, error) {
+	vnets, err := client.ListVirtualNetworks(ctx, platform.Region)
+	if err != nil {
+		logrus.Warnf("Failed to list the virtual networks of %s, a new virtual network will be created: %v", platform.Region, err)
+		return nil, nil
+	}
+	if len(vnets) == 0 {
+		return nil, nil
+	}
+
+	byOption := make(map[string]aznetwork.VirtualNetwork, len(vnets))
+	options := make([]string, 0, len(vnets))
+	for _, vnet := range vnets {
+		option := fmt.Sprintf("%s (%s)", to.String(vnet.Name), resourceGroupOf(to.String(vnet.ID)))
+		byOption[option] = vnet
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	var selected string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Virtual Network",
+		Help:    "The virtual network in which the cluster will be installed. The installer creates a virtual network, with its subnets, when no existing virtual network is chosen.",
+		Default: newVirtualNetworkOption,
+		Options: append([]string{newVirtualNetworkOption}, options...),
+	}, &selected); err != nil {
+		return nil, fmt.Errorf("failed UserInput: %w", err)
+	}
+	if selected == newVirtualNetworkOption {
+		return nil, nil
+	}
+
+	vnet := byOption[selected]
+	var subnets []string
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.Subnets != nil {
+		for _, subnet := range *vnet.Subnets {
+			subnets = append(subnets, fmt.Sprintf("%s (%s)", to.String(subnet.Name), to.String(subnet.AddressPrefix)))
+		}
+	}
+	if len(subnets) == 0 {
+		return nil, fmt.Errorf("virtual network %s has no subnets", to.String(vnet.Name))
+	}
+	sort.Strings(subnets)
+
+	var controlPlaneSubnet, computeSubnet string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Control Plane Subnet",
+		Help:    "The subnet in which the control plane machines and the internal load balancer will be created.",
+		Options: subnets,
+	}, &controlPlaneSubnet); err != nil {
+		return nil, fmt.Errorf("failed UserInput: %w", err)
+	}
+	if err := survey.AskOne(&survey.Select{
+		Message: "Compute Subnet",
+		Help:    "The subnet in which the compute machines will be created.",
+		Options: subnets,
+	}, &computeSubnet); err != nil {
+		return nil, fmt.Errorf("failed UserInput: %w", err)
+	}
+
+	platform.NetworkResourceGroupName = resourceGroupOf(to.String(vnet.ID))
+	platform.VirtualNetwork = to.String(vnet.Name)
+	platform.ControlPlaneSubnet = strings.SplitN(controlPlaneSubnet, " ", 2)[0]
+	platform.ComputeSubnet = strings.SplitN(computeSubnet, " ", 2)[0]
+
+	var machineNetwork []types.MachineNetworkEntry
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.AddressSpace != nil && vnet.AddressSpace.AddressPrefixes != nil {
+		for _, prefix := range *vnet.AddressSpace.AddressPrefixes {
+			network, err := ipnet.ParseCIDR(prefix)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the address prefix %s of virtual network %s: %w", prefix, to.String(vnet.Name), err)
+			}
+			machineNetwork = append(machineNetwork, types.MachineNetworkEntry{CIDR: *network})
+		}
+	}
+	return machineNetwork, nil
+}
+
+// selectInstanceType offers the VM sizes of the architecture that meet the
+// control plane requirements and are available to the subscription in the
+// region. The chosen VM size is set in the default machine platform unless it
+// is the installer default of the control plane.
+func selectInstanceType(ctx context.Context, client *Client, platform *azure.Platform) error {
+	skus, err := client.ListVirtualMachineSkus(ctx, platform.Region)
+	if err != nil {
+		logrus.Warnf("Failed to list the VM sizes of %s, the default VM sizes will be used: %v", platform.Region, err)
+		return nil
+	}
+
+	arch := version.DefaultArch()
+	cpuArchitecture := "x64"
+	if arch == types.ArchitectureARM64 {
+		cpuArchitecture = "Arm64"
+	}
+	defaultType := defaults.ControlPlaneInstanceType(platform.CloudName, platform.Region, arch)
+	defaultOption := ""
+	names := map[string]string{}
+	options := []string{}
+	for _, sku := range skus {
+		capabilities := map[string]string{}
+		if sku.Capabilities != nil {
+			for _, capability := range *sku.Capabilities {
+				capabilities[to.String(capability.Name)] = to.String(capability.Value)
+			}
+		}
+		vCPUs, err := strconv.ParseFloat(capabilities["vCPUsAvailable"], 64)
+		if err != nil {
+			continue
+		}
+		memory, err := strconv.ParseFloat(capabilities["MemoryGB"], 64)
+		if err != nil {
+			continue
+		}
+		if !strings.EqualFold(capabilities["CpuArchitectureType"], cpuArchitecture) ||
+			vCPUs < float64(controlPlaneReq.minimumVCpus) || memory < float64(controlPlaneReq.minimumMemory) {
+			continue
+		}
+		name := to.String(sku.Name)
+		option := fmt.Sprintf("%s (%g vCPUs, %g GiB)", name, vCPUs, memory)
+		if name == defaultType {
+			defaultOption = option
+		}
+		names[option] = name
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		logrus.Warnf("No VM size meeting the control plane requirements is available in %s, the default VM sizes will be used", platform.Region)
+		return nil
+	}
+	sort.Strings(options)
+
+	var selected string
+	if err := survey.AskOne(&survey.Select{
+		Message: "VM Size",
+		Help:    "The VM size of the control plane and compute machines. Only the VM sizes available to the subscription in the region and meeting the control plane requirements are listed.",
+		Default: defaultOption,
+		Options: options,
+	}, &selected); err != nil {
+		return fmt.Errorf("failed UserInput: %w", err)
+	}
+	if names[selected] != defaultType {
+		platform.DefaultMachinePlatform = &azure.MachinePool{InstanceType: names[selected]}
+	}
+	return nil
+}
+
+// resourceGroupOf returns the resource group of an Azure resource ID.
+func resourceGroupOf(id string) string {
+	parts := strings.Split(id, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if strings.EqualFold(parts[i], "resourceGroups") {
+			return parts[i+1]
+		}
+	}
+	return ""
 }
 
 func getRegions(ctx context.Context, client API) (map[string]string, error) {
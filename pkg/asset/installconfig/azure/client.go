@@ -22,6 +22,7 @@ type API interface {
 	GetVirtualNetwork(ctx context.Context, resourceGroupName, virtualNetwork string) (*aznetwork.VirtualNetwork, error)
 	GetComputeSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*aznetwork.Subnet, error)
 	GetControlPlaneSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*aznetwork.Subnet, error)
+	GetRouteTable(ctx context.Context, resourceGroupName, routeTable string) (*aznetwork.RouteTable, error)
 	ListLocations(ctx context.Context) (*[]azsubs.Location, error)
 	GetResourcesProvider(ctx context.Context, resourceProviderNamespace string) (*azres.Provider, error)
 	GetVirtualMachineSku(ctx context.Context, name, region string) (*azenc.ResourceSku, error)
@@ -70,6 +71,34 @@ func (c *Client) GetVirtualNetwork(ctx context.Context, resourceGroupName, virtu
 	return &vnet, nil
 }
 
+// ListVirtualNetworks lists the virtual networks in the region.
+func (c *Client) ListVirtualNetworks(ctx context.Context, region string) ([]aznetwork.VirtualNetwork, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	vnetClient, err := c.getVirtualNetworksClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var vnets []aznetwork.VirtualNetwork
+	page, err := vnetClient.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual networks: %w", err)
+	}
+	for ; page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("error fetching virtual network pages: %w", err)
+		}
+		for _, vnet := range page.Values() {
+			if strings.EqualFold(to.String(vnet.Location), region) {
+				vnets = append(vnets, vnet)
+			}
+		}
+	}
+	return vnets, nil
+}
+
 // getSubnet gets an Azure subnet by name
 func (c *Client) getSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subNetwork string) (*aznetwork.Subnet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -98,6 +127,22 @@ func (c *Client) GetControlPlaneSubnet(ctx context.Context, resourceGroupName, v
 	return c.getSubnet(ctx, resourceGroupName, virtualNetwork, subNetwork)
 }
 
+// GetRouteTable gets an Azure route table by name
+func (c *Client) GetRouteTable(ctx context.Context, resourceGroupName, routeTable string) (*aznetwork.RouteTable, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	routeTablesClient := aznetwork.NewRouteTablesClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	routeTablesClient.Authorizer = c.ssn.Authorizer
+
+	table, err := routeTablesClient.Get(ctx, resourceGroupName, routeTable, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get route table %s: %w", routeTable, err)
+	}
+
+	return &table, nil
+}
+
 // getVnetsClient sets up a new client to retrieve vnets
 func (c *Client) getVirtualNetworksClient(ctx context.Context) (*aznetwork.VirtualNetworksClient, error) {
 	vnetsClient := aznetwork.NewVirtualNetworksClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
@@ -242,6 +287,46 @@ func (c *Client) ListResourceIDsByGroup(ctx context.Context, groupName string) (
 	return res, nil
 }
 
+// ListVirtualMachineSkus lists the virtual machine SKUs that are available
+// to the subscription in the region.
+func (c *Client) ListVirtualMachineSkus(ctx context.Context, region string) ([]azenc.ResourceSku, error) {
+	client := azenc.NewResourceSkusClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
+	client.Authorizer = c.ssn.Authorizer
+
+	// See https://issues.redhat.com/browse/OCPBUGS-29469 before changing this timeout
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	var skus []azenc.ResourceSku
+	filter := fmt.Sprintf("location eq '%s'", region)
+	page, err := client.List(ctx, filter, "false")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SKUs: %w", err)
+	}
+	for ; page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("error fetching SKU pages: %w", err)
+		}
+		for _, sku := range page.Values() {
+			if !strings.EqualFold("virtualMachines", to.String(sku.ResourceType)) {
+				continue
+			}
+			restricted := false
+			if sku.Restrictions != nil {
+				for _, restriction := range *sku.Restrictions {
+					if restriction.Type == azenc.Location {
+						restricted = true
+					}
+				}
+			}
+			if !restricted {
+				skus = append(skus, sku)
+			}
+		}
+	}
+	return skus, nil
+}
+
 // GetVirtualMachineSku retrieves the resource SKU of a specified virtual machine SKU in the specified region.
 func (c *Client) GetVirtualMachineSku(ctx context.Context, name, region string) (*azenc.ResourceSku, error) {
 	client := azenc.NewResourceSkusClientWithBaseURI(c.ssn.Environment.ResourceManagerEndpoint, c.ssn.Credentials.SubscriptionID)
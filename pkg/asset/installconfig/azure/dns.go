@@ -101,6 +101,24 @@ func (config DNSConfig) GetDNSRecordSet(rgName string, zoneName string, relative
 	return recordsetsClient.GetRecordSet(rgName, zoneName, relativeRecordSetName, recordType)
 }
 
+// GetDNSZoneNameServers returns the name servers Azure assigned to the public
+// DNS zone.
+func (config DNSConfig) GetDNSZoneNameServers(rgName string, zoneName string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	azureClient := azdns.NewZonesClientWithBaseURI(config.session.Environment.ResourceManagerEndpoint, config.session.Credentials.SubscriptionID)
+	azureClient.Authorizer = config.session.Authorizer
+	zone, err := azureClient.Get(ctx, rgName, zoneName)
+	if err != nil {
+		return nil, err
+	}
+	if zone.ZoneProperties == nil || zone.ZoneProperties.NameServers == nil {
+		return nil, nil
+	}
+	return *zone.ZoneProperties.NameServers, nil
+}
+
 // NewDNSConfig returns a new DNSConfig struct that helps configuring the DNS
 // by querying your subscription and letting you choose
 // which domain you wish to use for the cluster
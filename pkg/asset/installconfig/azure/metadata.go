@@ -21,6 +21,10 @@ type Metadata struct {
 	// ARMEndpoint indicates the resource management API endpoint used by AzureStack.
 	ARMEndpoint string `json:"armEndpoint,omitempty"`
 
+	// ServiceEndpoints override the endpoints of the Azure services of the
+	// cloud environment.
+	ServiceEndpoints []typesazure.ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
 	// Credentials hold prepopulated Azure credentials.
 	// At the moment the installer doesn't use it and reads credentials
 	// from the file system, but external consumers of the package can
@@ -60,7 +64,7 @@ func (m *Metadata) Session() (*Session, error) {
 func (m *Metadata) unlockedSession() (*Session, error) {
 	if m.session == nil {
 		var err error
-		m.session, err = GetSessionWithCredentials(m.CloudName, m.ARMEndpoint, m.Credentials)
+		m.session, err = GetSessionWithEndpoints(m.CloudName, m.ARMEndpoint, m.ServiceEndpoints, m.Credentials)
 		if err != nil {
 			return nil, fmt.Errorf("creating Azure session: %w", err)
 		}
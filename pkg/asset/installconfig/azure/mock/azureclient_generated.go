@@ -203,6 +203,21 @@ func (mr *MockAPIMockRecorder) GetResourcesProvider(ctx, resourceProviderNamespa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesProvider", reflect.TypeOf((*MockAPI)(nil).GetResourcesProvider), ctx, resourceProviderNamespace)
 }
 
+// GetRouteTable mocks base method.
+func (m *MockAPI) GetRouteTable(ctx context.Context, resourceGroupName, routeTable string) (*network.RouteTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouteTable", ctx, resourceGroupName, routeTable)
+	ret0, _ := ret[0].(*network.RouteTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouteTable indicates an expected call of GetRouteTable.
+func (mr *MockAPIMockRecorder) GetRouteTable(ctx, resourceGroupName, routeTable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouteTable", reflect.TypeOf((*MockAPI)(nil).GetRouteTable), ctx, resourceGroupName, routeTable)
+}
+
 // GetStorageEndpointSuffix mocks base method.
 func (m *MockAPI) GetStorageEndpointSuffix(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,7 @@ import (
 	azurekiota "github.com/microsoft/kiota-authentication-azure-go"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/credentialsource"
 	"github.com/openshift/installer/pkg/types/azure"
 )
 
@@ -71,6 +73,12 @@ func GetSession(cloudName azure.CloudEnvironment, armEndpoint string) (*Session,
 // If there are no prepopulated credentials it falls back to reading credentials from file system
 // or from user input.
 func GetSessionWithCredentials(cloudName azure.CloudEnvironment, armEndpoint string, credentials *Credentials) (*Session, error) {
+	return GetSessionWithEndpoints(cloudName, armEndpoint, nil, credentials)
+}
+
+// GetSessionWithEndpoints returns an Azure session like GetSessionWithCredentials,
+// with the endpoints of the cloud environment overridden by the service endpoints.
+func GetSessionWithEndpoints(cloudName azure.CloudEnvironment, armEndpoint string, endpoints []azure.ServiceEndpoint, credentials *Credentials) (*Session, error) {
 	var cloudEnv azureenv.Environment
 	var err error
 	switch cloudName {
@@ -102,7 +110,16 @@ func GetSessionWithCredentials(cloudName azure.CloudEnvironment, armEndpoint str
 	default:
 		cloudConfig = cloud.AzurePublic
 	}
+	if len(endpoints) > 0 {
+		cloudConfig = overrideServiceEndpoints(&cloudEnv, cloudConfig, endpoints)
+	}
 
+	if credentials == nil {
+		credentials, err = credentialsFromSource()
+		if err != nil {
+			return nil, err
+		}
+	}
 	if credentials == nil {
 		credentials, err = credentialsFromFileOrUser()
 		if err != nil {
@@ -135,6 +152,57 @@ func GetSessionWithCredentials(cloudName azure.CloudEnvironment, armEndpoint str
 	return session, nil
 }
 
+// overrideServiceEndpoints sets the service endpoints in the environment and
+// returns a copy of the cloud configuration using them. The services of the
+// configuration are copied, as the predefined configurations share them.
+func overrideServiceEndpoints(cloudEnv *azureenv.Environment, cloudConfig cloud.Configuration, endpoints []azure.ServiceEndpoint) cloud.Configuration {
+	services := make(map[cloud.ServiceName]cloud.ServiceConfiguration, len(cloudConfig.Services))
+	for name, service := range cloudConfig.Services {
+		services[name] = service
+	}
+	cloudConfig.Services = services
+
+	for _, e := range endpoints {
+		switch e.Name {
+		case azure.ResourceManagerServiceEndpoint:
+			cloudEnv.ResourceManagerEndpoint = e.URL
+			service := cloudConfig.Services[cloud.ResourceManager]
+			service.Endpoint = e.URL
+			cloudConfig.Services[cloud.ResourceManager] = service
+		case azure.ActiveDirectoryServiceEndpoint:
+			cloudEnv.ActiveDirectoryEndpoint = e.URL
+			cloudConfig.ActiveDirectoryAuthorityHost = e.URL
+		case azure.GraphServiceEndpoint:
+			cloudEnv.MicrosoftGraphEndpoint = e.URL
+		case azure.KeyVaultServiceEndpoint:
+			cloudEnv.KeyVaultEndpoint = e.URL
+		}
+	}
+	return cloudConfig
+}
+
+// credentialsFromSource returns the credentials provided by the credential
+// source configured in the environment, or nil when none is configured. The
+// values are named like the fields of the auth file.
+func credentialsFromSource() (*Credentials, error) {
+	values, err := credentialsource.Fetch(context.TODO(), azure.Name)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, err
+	}
+	if err := checkCredentials(creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
 // credentialsFromFileOrUser returns credentials found
 // in ~/.azure/osServicePrincipal.json and, if no creds are found,
 // asks for them and stores them on disk in a config file
@@ -12,6 +12,7 @@ import (
 	azdns "github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/dns/mgmt/dns"
 	aznetwork "github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/network/mgmt/network"
 	azenc "github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -517,11 +518,49 @@ func validateNetworks(client API, p *aztypes.Platform, machineNetworks []types.M
 		}
 
 		allErrs = append(allErrs, validateSubnet(client, fieldPath.Child("controlPlaneSubnet"), controlPlaneSubnet, p.ControlPlaneSubnet, machineNetworks)...)
+
+		if p.OutboundType == aztypes.UserDefinedRoutingOutboundType {
+			allErrs = append(allErrs, validateSubnetEgress(client, fieldPath.Child("computeSubnet"), computeSubnet, p.ComputeSubnet)...)
+			allErrs = append(allErrs, validateSubnetEgress(client, fieldPath.Child("controlPlaneSubnet"), controlPlaneSubnet, p.ControlPlaneSubnet)...)
+		}
 	}
 
 	return allErrs
 }
 
+// validateSubnetEgress checks that the route table of the subnet provides the
+// egress of the cluster with user-defined routing, that is it has a default
+// route to a virtual appliance, a virtual network gateway or the Internet.
+func validateSubnetEgress(client API, fieldPath *field.Path, subnet *aznetwork.Subnet, subnetName string) field.ErrorList {
+	if subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil || to.String(subnet.RouteTable.ID) == "" {
+		return field.ErrorList{field.Invalid(fieldPath, subnetName, fmt.Sprintf("subnet must have a route table when outboundType is %s", aztypes.UserDefinedRoutingOutboundType))}
+	}
+
+	id, err := arm.ParseResourceID(to.String(subnet.RouteTable.ID))
+	if err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, subnetName, fmt.Sprintf("failed to parse the route table ID: %v", err))}
+	}
+	routeTable, err := client.GetRouteTable(context.TODO(), id.ResourceGroupName, id.Name)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, subnetName, fmt.Sprintf("failed to retrieve route table %s: %v", id.Name, err))}
+	}
+
+	if routeTable.RouteTablePropertiesFormat != nil && routeTable.Routes != nil {
+		for _, route := range *routeTable.Routes {
+			if route.RoutePropertiesFormat == nil || to.String(route.AddressPrefix) != "0.0.0.0/0" {
+				continue
+			}
+			switch route.NextHopType {
+			case aznetwork.RouteNextHopTypeVirtualAppliance, aznetwork.RouteNextHopTypeVirtualNetworkGateway, aznetwork.RouteNextHopTypeInternet:
+				return nil
+			default:
+				return field.ErrorList{field.Invalid(fieldPath, subnetName, fmt.Sprintf("the default route of route table %s has next hop type %s, which provides no egress", id.Name, route.NextHopType))}
+			}
+		}
+	}
+	return field.ErrorList{field.Invalid(fieldPath, subnetName, fmt.Sprintf("route table %s has no default route (0.0.0.0/0) for the egress of the cluster", id.Name))}
+}
+
 // validateSubnet checks that the subnet is in the same network as the machine CIDR
 func validateSubnet(client API, fieldPath *field.Path, subnet *aznetwork.Subnet, subnetName string, networks []types.MachineNetworkEntry) field.ErrorList {
 	allErrs := field.ErrorList{}
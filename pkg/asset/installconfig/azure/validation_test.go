@@ -715,6 +715,79 @@ func Test_validateResourceGroup(t *testing.T) {
 	}
 }
 
+func TestValidateSubnetEgress(t *testing.T) {
+	routeTableID := func(name string) *aznetwork.RouteTable {
+		return &aznetwork.RouteTable{ID: to.StringPtr(fmt.Sprintf("/subscriptions/sub/resourceGroups/%s/providers/Microsoft.Network/routeTables/%s", validNetworkResourceGroup, name))}
+	}
+	routeTable := func(prefix string, nextHop aznetwork.RouteNextHopType) *aznetwork.RouteTable {
+		return &aznetwork.RouteTable{
+			RouteTablePropertiesFormat: &aznetwork.RouteTablePropertiesFormat{
+				Routes: &[]aznetwork.Route{{
+					RoutePropertiesFormat: &aznetwork.RoutePropertiesFormat{
+						AddressPrefix: to.StringPtr(prefix),
+						NextHopType:   nextHop,
+					},
+				}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name       string
+		routeTable *aznetwork.RouteTable
+		err        string
+	}{{
+		name:       "default route to a virtual appliance",
+		routeTable: routeTableID("appliance"),
+	}, {
+		name:       "default route to a virtual network gateway",
+		routeTable: routeTableID("gateway"),
+	}, {
+		name: "no route table",
+		err:  `^\Qplatform.azure.computeSubnet: Invalid value: "valid-compute-subnet": subnet must have a route table when outboundType is UserDefinedRouting\E$`,
+	}, {
+		name:       "no default route",
+		routeTable: routeTableID("local"),
+		err:        `^\Qplatform.azure.computeSubnet: Invalid value: "valid-compute-subnet": route table local has no default route (0.0.0.0/0) for the egress of the cluster\E$`,
+	}, {
+		name:       "default route to nowhere",
+		routeTable: routeTableID("blackhole"),
+		err:        `^\Qplatform.azure.computeSubnet: Invalid value: "valid-compute-subnet": the default route of route table blackhole has next hop type None, which provides no egress\E$`,
+	}, {
+		name:       "missing route table",
+		routeTable: routeTableID("missing"),
+		err:        `^\Qplatform.azure.computeSubnet: Invalid value: "valid-compute-subnet": failed to retrieve route table missing: not found\E$`,
+	}}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	azureClient := mock.NewMockAPI(mockCtrl)
+	azureClient.EXPECT().GetRouteTable(gomock.Any(), validNetworkResourceGroup, "appliance").Return(routeTable("0.0.0.0/0", aznetwork.RouteNextHopTypeVirtualAppliance), nil).AnyTimes()
+	azureClient.EXPECT().GetRouteTable(gomock.Any(), validNetworkResourceGroup, "gateway").Return(routeTable("0.0.0.0/0", aznetwork.RouteNextHopTypeVirtualNetworkGateway), nil).AnyTimes()
+	azureClient.EXPECT().GetRouteTable(gomock.Any(), validNetworkResourceGroup, "local").Return(routeTable("10.0.0.0/16", aznetwork.RouteNextHopTypeVnetLocal), nil).AnyTimes()
+	azureClient.EXPECT().GetRouteTable(gomock.Any(), validNetworkResourceGroup, "blackhole").Return(routeTable("0.0.0.0/0", aznetwork.RouteNextHopTypeNone), nil).AnyTimes()
+	azureClient.EXPECT().GetRouteTable(gomock.Any(), validNetworkResourceGroup, "missing").Return(nil, fmt.Errorf("not found")).AnyTimes()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnet := &aznetwork.Subnet{
+				Name: &validComputeSubnet,
+				SubnetPropertiesFormat: &aznetwork.SubnetPropertiesFormat{
+					AddressPrefix: &validComputeSubnetCIDR,
+					RouteTable:    tc.routeTable,
+				},
+			}
+			err := validateSubnetEgress(azureClient, field.NewPath("platform").Child("azure").Child("computeSubnet"), subnet, validComputeSubnet)
+			if tc.err != "" {
+				assert.Regexp(t, tc.err, err.ToAggregate())
+			} else {
+				assert.NoError(t, err.ToAggregate())
+			}
+		})
+	}
+}
+
 func TestCheckAzureStackClusterOSImageSet(t *testing.T) {
 	cases := []struct {
 		ClusterOSImage string
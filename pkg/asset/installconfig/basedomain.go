@@ -1,6 +1,8 @@
 package installconfig
 
 import (
+	"context"
+
 	survey "github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/pkg/errors"
@@ -33,7 +35,7 @@ func (a *baseDomain) Dependencies() []asset.Asset {
 }
 
 // Generate queries for the base domain from the user.
-func (a *baseDomain) Generate(parents asset.Parents) error {
+func (a *baseDomain) Generate(_ context.Context, parents asset.Parents) error {
 	platform := &platform{}
 	parents.Get(platform)
 
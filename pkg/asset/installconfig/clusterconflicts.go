@@ -0,0 +1,155 @@
+package installconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/sirupsen/logrus"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+const (
+	clusterConflictsTimeout = 2 * time.Minute
+
+	// maxConflictingResources is the number of resources of an existing
+	// cluster listed in the conflict report.
+	maxConflictingResources = 5
+)
+
+// lookupHost resolves the addresses of a host. It is a variable so that
+// tests can replace the public DNS.
+var lookupHost = func(ctx context.Context, host string) ([]string, error) {
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// ValidateClusterConflicts looks for the resources tagged with the infra ID
+// of the cluster, left by a previous attempt from the same installation
+// directory. All the conflicts found are reported at once, before any
+// resource is created, rather than failing midway through provisioning. It
+// must not be called when resuming an interrupted run, whose resources are
+// expected. A name of the API which already resolves only yields a warning,
+// since wildcard and split-horizon DNS resolve names which do not exist.
+func ValidateClusterConflicts(ctx context.Context, ic *InstallConfig, infraID string) error {
+	config := ic.Config
+	switch config.Platform.Name() {
+	case aws.Name, azure.Name, gcp.Name:
+	default:
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterConflictsTimeout)
+	defer cancel()
+
+	conflicts, err := platformClusterConflicts(ctx, ic, infraID)
+	if err != nil {
+		// The credentials may not be allowed to list the resources, which
+		// does not mean the installation would fail.
+		logrus.Warnf("Could not look for the resources of an existing cluster %s: %v", infraID, err)
+	}
+	if config.PublicAPI() && !config.ClusterHostedDNS() {
+		if conflict := apiRecordConflict(ctx, config.APIHostname()); conflict != "" {
+			logrus.Warnf("%s, the cluster may conflict with an existing cluster", conflict)
+		}
+	}
+	return clusterConflictReport(config.ObjectMeta.Name, infraID, conflicts)
+}
+
+func platformClusterConflicts(ctx context.Context, ic *InstallConfig, infraID string) ([]string, error) {
+	config := ic.Config
+	switch config.Platform.Name() {
+	case aws.Name:
+		session, err := ic.AWS.Session(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns, err := awsconfig.GetClusterResources(ctx, session, config.AWS.Region, infraID, maxConflictingResources)
+		if err != nil {
+			return nil, err
+		}
+		if len(arns) > 0 {
+			return []string{fmt.Sprintf("resources are tagged kubernetes.io/cluster/%s in region %s: %s", infraID, config.AWS.Region, strings.Join(arns, ", "))}, nil
+		}
+	case azure.Name:
+		// A resource group provided in the install-config is checked to be
+		// empty by the platform validation.
+		if config.Azure.ResourceGroupName != "" {
+			return nil, nil
+		}
+		client, err := ic.Azure.Client()
+		if err != nil {
+			return nil, err
+		}
+		groupName := config.Azure.ClusterResourceGroupName(infraID)
+		if _, err := client.GetGroup(ctx, groupName); err != nil {
+			var detailedErr autorest.DetailedError
+			if errors.As(err, &detailedErr) && detailedErr.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []string{fmt.Sprintf("resource group %s already exists", groupName)}, nil
+	case gcp.Name:
+		// A network provided in the install-config is shared by design.
+		if config.GCP.Network != "" {
+			return nil, nil
+		}
+		client, err := gcpconfig.NewClient(ctx, config.GCP.ServiceEndpoints)
+		if err != nil {
+			return nil, err
+		}
+		networks, err := client.GetNetworks(ctx, config.GCP.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		networkName := fmt.Sprintf("%s-network", infraID)
+		for _, network := range networks {
+			if network == networkName {
+				return []string{fmt.Sprintf("network %s already exists in project %s", networkName, config.GCP.ProjectID)}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// apiRecordConflict returns the conflict of the API hostname of the cluster
+// when it already resolves, or an empty string.
+func apiRecordConflict(ctx context.Context, hostname string) string {
+	addrs, err := lookupHost(ctx, hostname)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			logrus.Debugf("Could not resolve %s: %v", hostname, err)
+		}
+		return ""
+	}
+	if len(addrs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s already resolves to %s", hostname, strings.Join(addrs, ", "))
+}
+
+// clusterConflictReport returns an error listing the conflicts, or nil when
+// there are none.
+func clusterConflictReport(clusterName, infraID string, conflicts []string) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	var report strings.Builder
+	fmt.Fprintf(&report, "cluster %s (infra ID %s) conflicts with an existing cluster:", clusterName, infraID)
+	for _, conflict := range conflicts {
+		fmt.Fprintf(&report, "\n  - %s", conflict)
+	}
+	report.WriteString("\ndestroy the existing cluster, or install from a new directory with a different cluster name")
+	return errors.New(report.String())
+}
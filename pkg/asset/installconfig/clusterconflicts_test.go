@@ -0,0 +1,55 @@
+package installconfig
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRecordConflict(t *testing.T) {
+	cases := []struct {
+		name             string
+		addrs            []string
+		lookupErr        error
+		expectedConflict string
+	}{
+		{
+			name:             "resolves",
+			addrs:            []string{"192.0.2.10", "192.0.2.11"},
+			expectedConflict: "api.test-cluster.example.com already resolves to 192.0.2.10, 192.0.2.11",
+		},
+		{
+			name:      "does not resolve",
+			lookupErr: &net.DNSError{Err: "no such host", Name: "api.test-cluster.example.com", IsNotFound: true},
+		},
+		{
+			name:      "resolver unavailable",
+			lookupErr: errors.New("i/o timeout"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(lookup func(context.Context, string) ([]string, error)) { lookupHost = lookup }(lookupHost)
+			lookupHost = func(context.Context, string) ([]string, error) {
+				return tc.addrs, tc.lookupErr
+			}
+			assert.Equal(t, tc.expectedConflict, apiRecordConflict(context.Background(), "api.test-cluster.example.com"))
+		})
+	}
+}
+
+func TestClusterConflictReport(t *testing.T) {
+	assert.NoError(t, clusterConflictReport("test-cluster", "test-cluster-x7k2p", nil))
+
+	err := clusterConflictReport("test-cluster", "test-cluster-x7k2p", []string{
+		"resource group test-cluster-x7k2p-rg already exists",
+		"api.test-cluster.example.com already resolves to 192.0.2.10",
+	})
+	assert.EqualError(t, err, `cluster test-cluster (infra ID test-cluster-x7k2p) conflicts with an existing cluster:
+  - resource group test-cluster-x7k2p-rg already exists
+  - api.test-cluster.example.com already resolves to 192.0.2.10
+destroy the existing cluster, or install from a new directory with a different cluster name`)
+}
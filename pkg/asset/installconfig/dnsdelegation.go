@@ -0,0 +1,121 @@
+package installconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+const dnsDelegationTimeout = 10 * time.Second
+
+// lookupNS resolves the NS records of a domain. It is a variable so that
+// tests can replace the public DNS.
+var lookupNS = func(ctx context.Context, domain string) ([]*net.NS, error) {
+	return net.DefaultResolver.LookupNS(ctx, domain)
+}
+
+// validatePublicZoneDelegation verifies that the public zone of the base
+// domain, on the platforms where the installer creates the public records
+// of the cluster, is the one the public DNS delegates the base domain to.
+func validatePublicZoneDelegation(ctx context.Context, ic *InstallConfig) error {
+	config := ic.Config
+	if config.Publish == types.InternalPublishingStrategy || config.ClusterHostedDNS() {
+		return nil
+	}
+
+	var nameServers []string
+	switch config.Platform.Name() {
+	case aws.Name:
+		session, err := ic.AWS.Session(ctx)
+		if err != nil {
+			return err
+		}
+		nameServers, err = awsconfig.GetPublicZoneNameServers(session, config.BaseDomain)
+		if err != nil {
+			return fmt.Errorf("failed to get the name servers of the public zone of %s: %w", config.BaseDomain, err)
+		}
+	case azure.Name:
+		if config.Azure.CloudName == azure.StackCloud {
+			return nil
+		}
+		dnsConfig, err := ic.Azure.DNSConfig()
+		if err != nil {
+			return err
+		}
+		nameServers, err = dnsConfig.GetDNSZoneNameServers(config.Azure.BaseDomainResourceGroupName, config.BaseDomain)
+		if err != nil {
+			return fmt.Errorf("failed to get the name servers of the public zone of %s: %w", config.BaseDomain, err)
+		}
+	case gcp.Name:
+		client, err := gcpconfig.NewClient(ctx, config.GCP.ServiceEndpoints)
+		if err != nil {
+			return err
+		}
+		zone, err := client.GetDNSZone(ctx, config.GCP.ProjectID, config.BaseDomain, true)
+		if err != nil {
+			return fmt.Errorf("failed to get the public zone of %s: %w", config.BaseDomain, err)
+		}
+		nameServers = zone.NameServers
+	default:
+		return nil
+	}
+	if len(nameServers) == 0 {
+		return nil
+	}
+	return validateBaseDomainDelegation(ctx, config.BaseDomain, nameServers)
+}
+
+// validateBaseDomainDelegation verifies that the public DNS delegates the base
+// domain to the name servers of the public zone created in the cloud.
+// Otherwise the zone exists but the names of the cluster never resolve, and
+// the installation only fails once it waits for the API.
+func validateBaseDomainDelegation(ctx context.Context, baseDomain string, zoneNameServers []string) error {
+	ctx, cancel := context.WithTimeout(ctx, dnsDelegationTimeout)
+	defer cancel()
+
+	records, err := lookupNS(ctx, baseDomain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fmt.Errorf("base domain %s is not delegated to its public zone: the public DNS has no NS records for it, add NS records for %s to the parent domain", baseDomain, strings.Join(zoneNameServers, ", "))
+		}
+		// The resolvers of this host may not be reachable or may not
+		// answer for public domains, which does not mean the delegation
+		// is broken.
+		logrus.Warnf("Could not verify the delegation of base domain %s: %v", baseDomain, err)
+		return nil
+	}
+
+	expected := sets.New[string]()
+	for _, nameServer := range zoneNameServers {
+		expected.Insert(normalizeNameServer(nameServer))
+	}
+	delegated := sets.New[string]()
+	for _, record := range records {
+		delegated.Insert(normalizeNameServer(record.Host))
+	}
+	if !expected.HasAny(sets.List(delegated)...) {
+		return fmt.Errorf("base domain %s is not delegated to its public zone: the public DNS delegates it to %s instead of %s", baseDomain, strings.Join(sets.List(delegated), ", "), strings.Join(sets.List(expected), ", "))
+	}
+	if extra := delegated.Difference(expected); extra.Len() > 0 {
+		logrus.Warnf("Base domain %s is only partly delegated to its public zone: the public DNS also delegates it to %s", baseDomain, strings.Join(sets.List(extra), ", "))
+	}
+	return nil
+}
+
+func normalizeNameServer(nameServer string) string {
+	return strings.ToLower(strings.TrimSuffix(nameServer, "."))
+}
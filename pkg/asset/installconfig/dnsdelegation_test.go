@@ -0,0 +1,53 @@
+package installconfig
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBaseDomainDelegation(t *testing.T) {
+	zoneNameServers := []string{"ns-1.awsdns-01.org.", "ns-2.awsdns-02.com."}
+	cases := []struct {
+		name          string
+		records       []*net.NS
+		lookupErr     error
+		expectedError string
+	}{
+		{
+			name:    "delegated",
+			records: []*net.NS{{Host: "ns-2.awsdns-02.com."}, {Host: "NS-1.awsdns-01.org."}},
+		},
+		{
+			name:          "delegated elsewhere",
+			records:       []*net.NS{{Host: "ns1.registrar.example."}},
+			expectedError: "base domain example.com is not delegated to its public zone: the public DNS delegates it to ns1.registrar.example instead of ns-1.awsdns-01.org, ns-2.awsdns-02.com",
+		},
+		{
+			name:          "not delegated",
+			lookupErr:     &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true},
+			expectedError: "base domain example.com is not delegated to its public zone: the public DNS has no NS records for it, add NS records for ns-1.awsdns-01.org., ns-2.awsdns-02.com. to the parent domain",
+		},
+		{
+			name:      "resolver unavailable",
+			lookupErr: errors.New("i/o timeout"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(lookup func(context.Context, string) ([]*net.NS, error)) { lookupNS = lookup }(lookupNS)
+			lookupNS = func(context.Context, string) ([]*net.NS, error) {
+				return tc.records, tc.lookupErr
+			}
+			err := validateBaseDomainDelegation(context.Background(), "example.com", zoneNameServers)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	gcpconsts "github.com/openshift/installer/pkg/constants/gcp"
+	"github.com/openshift/installer/pkg/types/gcp"
 )
 
 //go:generate mockgen -source=./client.go -destination=./mock/gcpclient_generated.go -package=mock
@@ -39,12 +40,15 @@ type API interface {
 	GetDNSZone(ctx context.Context, project, baseDomain string, isPublic bool) (*dns.ManagedZone, error)
 	GetDNSZoneByName(ctx context.Context, project, zoneName string) (*dns.ManagedZone, error)
 	GetSubnetworks(ctx context.Context, network, project, region string) ([]*compute.Subnetwork, error)
+	GetRoutes(ctx context.Context, network, project string) ([]*compute.Route, error)
+	GetGlobalForwardingRules(ctx context.Context, network, project string) ([]*compute.ForwardingRule, error)
 	GetProjects(ctx context.Context) (map[string]string, error)
 	GetRegions(ctx context.Context, project string) ([]string, error)
 	GetRecordSets(ctx context.Context, project, zone string) ([]*dns.ResourceRecordSet, error)
 	GetZones(ctx context.Context, project, filter string) ([]*compute.Zone, error)
 	GetEnabledServices(ctx context.Context, project string) ([]string, error)
 	GetServiceAccount(ctx context.Context, project, serviceAccount string) (string, error)
+	GetServiceAccountRoles(ctx context.Context, project, serviceAccount string) (sets.Set[string], error)
 	GetCredentials() *googleoauth.Credentials
 	GetImage(ctx context.Context, name string, project string) (*compute.Image, error)
 	GetProjectPermissions(ctx context.Context, project string, permissions []string) (sets.Set[string], error)
@@ -56,22 +60,37 @@ type API interface {
 
 // Client makes calls to the GCP API.
 type Client struct {
-	ssn *Session
+	ssn       *Session
+	endpoints []gcp.ServiceEndpoint
 }
 
-// NewClient initializes a client with a session.
-func NewClient(ctx context.Context) (*Client, error) {
+// NewClient initializes a client with a session. The endpoints override the
+// default endpoints of the services the client calls.
+func NewClient(ctx context.Context, endpoints []gcp.ServiceEndpoint) (*Client, error) {
 	ssn, err := GetSession(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get session")
 	}
 
 	client := &Client{
-		ssn: ssn,
+		ssn:       ssn,
+		endpoints: endpoints,
 	}
 	return client, nil
 }
 
+// serviceOptions returns the options of the clients of the service, using
+// the credentials of the session and the endpoint overriding the default one.
+func (c *Client) serviceOptions(name gcp.ServiceEndpointName) []option.ClientOption {
+	opts := []option.ClientOption{option.WithCredentials(c.ssn.Credentials)}
+	for _, e := range c.endpoints {
+		if e.Name == name {
+			opts = append(opts, option.WithEndpoint(e.URL))
+		}
+	}
+	return opts
+}
+
 // GetMachineType uses the GCP Compute Service API to get the specified machine type.
 func (c *Client) GetMachineType(ctx context.Context, project, zone, machineType string) (*compute.MachineType, error) {
 	svc, err := c.getComputeService(ctx)
@@ -294,8 +313,106 @@ func (c *Client) GetSubnetworks(ctx context.Context, network, project, region st
 	return res, nil
 }
 
+// GetRoutes uses the GCP Compute Service API to get the routes of a network.
+func (c *Client) GetRoutes(ctx context.Context, network, project string) ([]*compute.Route, error) {
+	svc, err := c.getComputeService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := fmt.Sprintf("network eq .*%s", network)
+	req := svc.Routes.List(project).Filter(filter)
+	var res []*compute.Route
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := req.Pages(ctx, func(page *compute.RouteList) error {
+		res = append(res, page.Items...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetGlobalForwardingRules uses the GCP Compute Service API to get the global
+// forwarding rules of a network, such as its Private Service Connect endpoints.
+func (c *Client) GetGlobalForwardingRules(ctx context.Context, network, project string) ([]*compute.ForwardingRule, error) {
+	svc, err := c.getComputeService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := fmt.Sprintf("network eq .*%s", network)
+	req := svc.GlobalForwardingRules.List(project).Filter(filter)
+	var res []*compute.ForwardingRule
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := req.Pages(ctx, func(page *compute.ForwardingRuleList) error {
+		res = append(res, page.Items...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetNetworks uses the GCP Compute Service API to get the names of the networks of a project.
+func (c *Client) GetNetworks(ctx context.Context, project string) ([]string, error) {
+	svc, err := c.getComputeService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var res []string
+	if err := svc.Networks.List(project).Fields("nextPageToken", "items/name").Pages(ctx, func(page *compute.NetworkList) error {
+		for _, network := range page.Items {
+			res = append(res, network.Name)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the networks of project %s", project)
+	}
+	return res, nil
+}
+
+// GetMachineTypes uses the GCP Compute Service API to get the machine types
+// of a region. It returns the machine types, by name, and the number of the
+// zones of the region in which each machine type is available.
+func (c *Client) GetMachineTypes(ctx context.Context, project, region string) (map[string]*compute.MachineType, map[string]int, error) {
+	svc, err := c.getComputeService(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	machineTypes := map[string]*compute.MachineType{}
+	zones := map[string]int{}
+	req := svc.MachineTypes.AggregatedList(project).Filter(fmt.Sprintf("zone : %s-*", region))
+	if err := req.Pages(ctx, func(page *compute.MachineTypeAggregatedList) error {
+		for _, scopedList := range page.Items {
+			for _, machineType := range scopedList.MachineTypes {
+				machineTypes[machineType.Name] = machineType
+				zones[machineType.Name]++
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to list the machine types of region %s", region)
+	}
+	return machineTypes, zones, nil
+}
+
 func (c *Client) getComputeService(ctx context.Context) (*compute.Service, error) {
-	svc, err := compute.NewService(ctx, option.WithCredentials(c.ssn.Credentials))
+	svc, err := compute.NewService(ctx, c.serviceOptions(gcp.ComputeServiceEndpoint)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create compute service")
 	}
@@ -303,7 +420,7 @@ func (c *Client) getComputeService(ctx context.Context) (*compute.Service, error
 }
 
 func (c *Client) getDNSService(ctx context.Context) (*dns.Service, error) {
-	svc, err := dns.NewService(ctx, option.WithCredentials(c.ssn.Credentials))
+	svc, err := dns.NewService(ctx, c.serviceOptions(gcp.DNSServiceEndpoint)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create dns service")
 	}
@@ -400,7 +517,7 @@ func (c *Client) GetZones(ctx context.Context, project, filter string) ([]*compu
 }
 
 func (c *Client) getCloudResourceService(ctx context.Context) (*cloudresourcemanager.Service, error) {
-	svc, err := cloudresourcemanager.NewService(ctx, option.WithCredentials(c.ssn.Credentials))
+	svc, err := cloudresourcemanager.NewService(ctx, c.serviceOptions(gcp.CloudResourceManagerServiceEndpoint)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create cloud resource service")
 	}
@@ -435,7 +552,7 @@ func (c *Client) GetEnabledServices(ctx context.Context, project string) ([]stri
 }
 
 func (c *Client) getServiceUsageService(ctx context.Context) (*serviceusage.Service, error) {
-	svc, err := serviceusage.NewService(ctx, option.WithCredentials(c.ssn.Credentials))
+	svc, err := serviceusage.NewService(ctx, c.serviceOptions(gcp.ServiceUsageServiceEndpoint)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create service usage service")
 	}
@@ -444,7 +561,7 @@ func (c *Client) getServiceUsageService(ctx context.Context) (*serviceusage.Serv
 
 // GetServiceAccount retrieves a service account from a project if it exists.
 func (c *Client) GetServiceAccount(ctx context.Context, project, serviceAccount string) (string, error) {
-	svc, err := iam.NewService(ctx)
+	svc, err := iam.NewService(ctx, c.serviceOptions(gcp.IAMServiceEndpoint)...)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed create IAM service")
 	}
@@ -460,6 +577,35 @@ func (c *Client) GetServiceAccount(ctx context.Context, project, serviceAccount
 	return rsp.Name, nil
 }
 
+// GetServiceAccountRoles returns the roles granted to the service account in
+// the IAM policy of the project.
+func (c *Client) GetServiceAccountRoles(ctx context.Context, project, serviceAccount string) (sets.Set[string], error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	service, err := c.getCloudResourceService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := service.Projects.GetIamPolicy(fmt.Sprintf(gcpconsts.ProjectNameFmt, project), &cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the IAM policy of project %s", project)
+	}
+
+	member := fmt.Sprintf("serviceAccount:%s", serviceAccount)
+	roles := sets.New[string]()
+	for _, binding := range policy.Bindings {
+		for _, m := range binding.Members {
+			if m == member {
+				roles.Insert(binding.Role)
+				break
+			}
+		}
+	}
+	return roles, nil
+}
+
 // GetCredentials returns the credentials used to authenticate the GCP session.
 func (c *Client) GetCredentials() *googleoauth.Credentials {
 	return c.ssn.Credentials
@@ -14,7 +14,7 @@ import (
 
 // GetBaseDomain returns a base domain chosen from among the project's public DNS zones.
 func GetBaseDomain(project string) (string, error) {
-	client, err := NewClient(context.TODO())
+	client, err := NewClient(context.TODO(), nil)
 	if err != nil {
 		return "", err
 	}
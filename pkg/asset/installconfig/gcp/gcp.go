@@ -10,9 +10,12 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/types/gcp"
 	gcpValidation "github.com/openshift/installer/pkg/types/gcp/validation"
+	"github.com/openshift/installer/pkg/version"
+	mapiutil "github.com/openshift/machine-api-provider-gcp/pkg/cloud/gcp/actuators/util"
 )
 
 // Platform collects GCP-specific configuration.
@@ -29,10 +32,17 @@ func Platform() (*gcp.Platform, error) {
 		return nil, err
 	}
 
-	return &gcp.Platform{
+	platform := &gcp.Platform{
 		ProjectID: project,
 		Region:    region,
-	}, nil
+	}
+	if err := selectNetwork(context.TODO(), platform); err != nil {
+		return nil, err
+	}
+	if err := selectMachineType(context.TODO(), platform); err != nil {
+		return nil, err
+	}
+	return platform, nil
 }
 
 func selectProject(ctx context.Context) (string, error) {
@@ -171,3 +181,142 @@ func selectRegion(ctx context.Context, project string) (string, error) {
 
 	return selectedRegion, nil
 }
+
+// newNetworkOption is the option to let the installer create the network.
+const newNetworkOption = "Create a new network"
+
+// selectNetwork offers the networks of the project and, when an existing
+// network is chosen, its subnets in the region for the control plane and the
+// compute machines. When the networks cannot be listed, a new network is used.
+func selectNetwork(ctx context.Context, platform *gcp.Platform) error {
+	ssn, err := GetSession(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get session")
+	}
+	client := &Client{
+		ssn: ssn,
+	}
+
+	networks, err := client.GetNetworks(ctx, platform.ProjectID)
+	if err != nil {
+		logrus.Warnf("Failed to list the networks of project %s, a new network will be created: %v", platform.ProjectID, err)
+		return nil
+	}
+	if len(networks) == 0 {
+		return nil
+	}
+	sort.Strings(networks)
+
+	var network string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Network",
+		Help:    "The VPC network in which the cluster will be installed. The installer creates a network, with its subnets and Cloud NAT, when no existing network is chosen.",
+		Default: newNetworkOption,
+		Options: append([]string{newNetworkOption}, networks...),
+	}, &network); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	if network == newNetworkOption {
+		return nil
+	}
+
+	subnets, err := client.GetSubnetworks(ctx, network, platform.ProjectID, platform.Region)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the subnets of network %s", network)
+	}
+	if len(subnets) == 0 {
+		return errors.Errorf("network %s has no subnets in region %s", network, platform.Region)
+	}
+	options := make([]string, 0, len(subnets))
+	names := make(map[string]string, len(subnets))
+	for _, subnet := range subnets {
+		option := fmt.Sprintf("%s (%s)", subnet.Name, subnet.IpCidrRange)
+		names[option] = subnet.Name
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	var controlPlaneSubnet, computeSubnet string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Control Plane Subnet",
+		Help:    "The subnet in which the control plane machines and the internal load balancers will be created.",
+		Options: options,
+	}, &controlPlaneSubnet); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	if err := survey.AskOne(&survey.Select{
+		Message: "Compute Subnet",
+		Help:    "The subnet in which the compute machines will be created.",
+		Default: controlPlaneSubnet,
+		Options: options,
+	}, &computeSubnet); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+
+	platform.Network = network
+	platform.ControlPlaneSubnet = names[controlPlaneSubnet]
+	platform.ComputeSubnet = names[computeSubnet]
+	return nil
+}
+
+// selectMachineType offers the machine types of the architecture that meet
+// the control plane requirements and are available in all the zones of the
+// region. The chosen machine type is set in the default machine platform
+// unless it is the installer default.
+func selectMachineType(ctx context.Context, platform *gcp.Platform) error {
+	ssn, err := GetSession(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get session")
+	}
+	client := &Client{
+		ssn: ssn,
+	}
+
+	zones, err := client.GetZones(ctx, platform.ProjectID, fmt.Sprintf("region eq .*%s", platform.Region))
+	if err != nil {
+		logrus.Warnf("Failed to list the zones of %s, the default machine type will be used: %v", platform.Region, err)
+		return nil
+	}
+	machineTypes, available, err := client.GetMachineTypes(ctx, platform.ProjectID, platform.Region)
+	if err != nil {
+		logrus.Warnf("Failed to list the machine types of %s, the default machine type will be used: %v", platform.Region, err)
+		return nil
+	}
+
+	arch := version.DefaultArch()
+	defaultType := DefaultInstanceTypeForArch(arch)
+	defaultOption := ""
+	names := map[string]string{}
+	options := []string{}
+	for name, machineType := range machineTypes {
+		if available[name] != len(zones) || string(mapiutil.CPUArchitecture(name)) != string(arch) ||
+			machineType.GuestCpus < controlPlaneReq.minimumVCpus || machineType.MemoryMb < controlPlaneReq.minimumMemory {
+			continue
+		}
+		option := fmt.Sprintf("%s (%d vCPUs, %d GiB)", name, machineType.GuestCpus, machineType.MemoryMb/1024)
+		if name == defaultType {
+			defaultOption = option
+		}
+		names[option] = name
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		logrus.Warnf("No machine type meeting the control plane requirements is available in all the zones of %s, the default machine type will be used", platform.Region)
+		return nil
+	}
+	sort.Strings(options)
+
+	var selected string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Machine Type",
+		Help:    "The machine type of the control plane and compute machines. Only the machine types available in all the zones of the region and meeting the control plane requirements are listed.",
+		Default: defaultOption,
+		Options: options,
+	}, &selected); err != nil {
+		return errors.Wrap(err, "failed UserInput")
+	}
+	if names[selected] != defaultType {
+		platform.DefaultMachinePlatform = &gcp.MachinePool{InstanceType: names[selected]}
+	}
+	return nil
+}
@@ -98,6 +98,21 @@ func (mr *MockAPIMockRecorder) GetEnabledServices(ctx, project interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledServices", reflect.TypeOf((*MockAPI)(nil).GetEnabledServices), ctx, project)
 }
 
+// GetGlobalForwardingRules mocks base method.
+func (m *MockAPI) GetGlobalForwardingRules(ctx context.Context, network, project string) ([]*compute.ForwardingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGlobalForwardingRules", ctx, network, project)
+	ret0, _ := ret[0].([]*compute.ForwardingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGlobalForwardingRules indicates an expected call of GetGlobalForwardingRules.
+func (mr *MockAPIMockRecorder) GetGlobalForwardingRules(ctx, network, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlobalForwardingRules", reflect.TypeOf((*MockAPI)(nil).GetGlobalForwardingRules), ctx, network, project)
+}
+
 // GetImage mocks base method.
 func (m *MockAPI) GetImage(ctx context.Context, name, project string) (*compute.Image, error) {
 	m.ctrl.T.Helper()
@@ -279,6 +294,21 @@ func (mr *MockAPIMockRecorder) GetRegions(ctx, project interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegions", reflect.TypeOf((*MockAPI)(nil).GetRegions), ctx, project)
 }
 
+// GetRoutes mocks base method.
+func (m *MockAPI) GetRoutes(ctx context.Context, network, project string) ([]*compute.Route, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutes", ctx, network, project)
+	ret0, _ := ret[0].([]*compute.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoutes indicates an expected call of GetRoutes.
+func (mr *MockAPIMockRecorder) GetRoutes(ctx, network, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutes", reflect.TypeOf((*MockAPI)(nil).GetRoutes), ctx, network, project)
+}
+
 // GetServiceAccount mocks base method.
 func (m *MockAPI) GetServiceAccount(ctx context.Context, project, serviceAccount string) (string, error) {
 	m.ctrl.T.Helper()
@@ -294,6 +324,21 @@ func (mr *MockAPIMockRecorder) GetServiceAccount(ctx, project, serviceAccount in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockAPI)(nil).GetServiceAccount), ctx, project, serviceAccount)
 }
 
+// GetServiceAccountRoles mocks base method.
+func (m *MockAPI) GetServiceAccountRoles(ctx context.Context, project, serviceAccount string) (sets.Set[string], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAccountRoles", ctx, project, serviceAccount)
+	ret0, _ := ret[0].(sets.Set[string])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAccountRoles indicates an expected call of GetServiceAccountRoles.
+func (mr *MockAPIMockRecorder) GetServiceAccountRoles(ctx, project, serviceAccount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccountRoles", reflect.TypeOf((*MockAPI)(nil).GetServiceAccountRoles), ctx, project, serviceAccount)
+}
+
 // GetSubnetworks mocks base method.
 func (m *MockAPI) GetSubnetworks(ctx context.Context, network, project, region string) ([]*compute.Subnetwork, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,41 @@
+package gcp
+
+import (
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ComputeRoles are the roles required by the service account of compute
+// nodes. The installer grants them to the service accounts it creates and
+// pre-created service accounts must already be granted them.
+var ComputeRoles = []string{
+	"roles/compute.viewer",
+	"roles/storage.admin",
+}
+
+// ComputeServiceAccount returns the pre-created service account of the
+// compute pool, from the pool or from the default machine platform. It
+// returns an empty string when the installer creates the service account.
+func ComputeServiceAccount(ic *types.InstallConfig, pool *types.MachinePool) string {
+	if pool.Platform.GCP != nil && pool.Platform.GCP.ServiceAccount != "" {
+		return pool.Platform.GCP.ServiceAccount
+	}
+	if ic.GCP.DefaultMachinePlatform != nil {
+		return ic.GCP.DefaultMachinePlatform.ServiceAccount
+	}
+	return ""
+}
+
+// ComputeServiceAccountsProvided returns true if every compute pool runs as a
+// pre-created service account, in which case the installer does not create
+// one for compute nodes.
+func ComputeServiceAccountsProvided(ic *types.InstallConfig) bool {
+	if len(ic.Compute) == 0 {
+		return false
+	}
+	for i := range ic.Compute {
+		if ComputeServiceAccount(ic, &ic.Compute[i]) == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -48,25 +48,27 @@ var (
 const unknownArchitecture = ""
 
 // Validate executes platform-specific validation.
-func Validate(client API, ic *types.InstallConfig) error {
+func Validate(ctx context.Context, client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 
 	if err := validate.GCPClusterName(ic.ObjectMeta.Name); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("clusterName"), ic.ObjectMeta.Name, err.Error()))
 	}
 
-	allErrs = append(allErrs, validateProject(client, ic, field.NewPath("platform").Child("gcp"))...)
-	allErrs = append(allErrs, validateNetworkProject(client, ic, field.NewPath("platform").Child("gcp"))...)
-	allErrs = append(allErrs, validateRegion(client, ic, field.NewPath("platform").Child("gcp"))...)
-	allErrs = append(allErrs, validateZones(client, ic)...)
-	allErrs = append(allErrs, validateNetworks(client, ic, field.NewPath("platform").Child("gcp"))...)
-	allErrs = append(allErrs, validateInstanceTypes(client, ic)...)
+	allErrs = append(allErrs, validateProject(ctx, client, ic, field.NewPath("platform").Child("gcp"))...)
+	allErrs = append(allErrs, validateNetworkProject(ctx, client, ic, field.NewPath("platform").Child("gcp"))...)
+	allErrs = append(allErrs, validateRegion(ctx, client, ic, field.NewPath("platform").Child("gcp"))...)
+	allErrs = append(allErrs, validateZones(ctx, client, ic)...)
+	allErrs = append(allErrs, validateNetworks(ctx, client, ic, field.NewPath("platform").Child("gcp"))...)
+	allErrs = append(allErrs, validateInstanceTypes(ctx, client, ic)...)
+	allErrs = append(allErrs, validateShieldedAndConfidentialVMs(ic)...)
 	allErrs = append(allErrs, ValidateCredentialMode(client, ic)...)
-	allErrs = append(allErrs, validatePreexistingServiceAccountXpn(client, ic)...)
+	allErrs = append(allErrs, validatePreexistingServiceAccountXpn(ctx, client, ic)...)
 	allErrs = append(allErrs, validateServiceAccountPresent(client, ic)...)
-	allErrs = append(allErrs, validateMarketplaceImages(client, ic)...)
+	allErrs = append(allErrs, validateComputeServiceAccounts(ctx, client, ic)...)
+	allErrs = append(allErrs, validateMarketplaceImages(ctx, client, ic)...)
 
-	if err := validateUserTags(client, ic.Platform.GCP.ProjectID, ic.Platform.GCP.UserTags); err != nil {
+	if err := validateUserTags(ctx, client, ic.Platform.GCP.ProjectID, ic.Platform.GCP.UserTags); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("platform").Child("gcp").Child("userTags"), ic.Platform.GCP.UserTags, err.Error()))
 	}
 
@@ -74,10 +76,10 @@ func Validate(client API, ic *types.InstallConfig) error {
 }
 
 // ValidateInstanceType ensures the instance type has sufficient Vcpu and Memory.
-func ValidateInstanceType(client API, fieldPath *field.Path, project, region string, zones []string, instanceType string, req resourceRequirements, arch string) field.ErrorList {
+func ValidateInstanceType(ctx context.Context, client API, fieldPath *field.Path, project, region string, zones []string, instanceType string, req resourceRequirements, arch string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	typeMeta, typeZones, err := client.GetMachineTypeWithZones(context.TODO(), project, region, instanceType)
+	typeMeta, typeZones, err := client.GetMachineTypeWithZones(ctx, project, region, instanceType)
 	if err != nil {
 		if _, ok := err.(*googleapi.Error); ok {
 			return append(allErrs, field.Invalid(fieldPath.Child("type"), instanceType, err.Error()))
@@ -137,7 +139,7 @@ func DefaultInstanceTypeForArch(arch types.Architecture) string {
 }
 
 // validateInstanceTypes checks that the user-provided instance types are valid.
-func validateInstanceTypes(client API, ic *types.InstallConfig) field.ErrorList {
+func validateInstanceTypes(ctx context.Context, client API, ic *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	defaultInstanceType := ""
@@ -156,6 +158,7 @@ func validateInstanceTypes(client API, ic *types.InstallConfig) field.ErrorList
 		if ic.GCP.DefaultMachinePlatform.InstanceType != "" {
 			allErrs = append(allErrs,
 				ValidateInstanceType(
+					ctx,
 					client,
 					field.NewPath("platform", "gcp", "defaultMachinePlatform"),
 					ic.GCP.ProjectID,
@@ -187,6 +190,7 @@ func validateInstanceTypes(client API, ic *types.InstallConfig) field.ErrorList
 	}
 	allErrs = append(allErrs,
 		ValidateInstanceType(
+			ctx,
 			client,
 			field.NewPath("controlPlane", "platform", "gcp"),
 			ic.GCP.ProjectID,
@@ -215,6 +219,7 @@ func validateInstanceTypes(client API, ic *types.InstallConfig) field.ErrorList
 		}
 		allErrs = append(allErrs,
 			ValidateInstanceType(
+				ctx,
 				client,
 				fieldPath.Child("platform", "gcp"),
 				ic.GCP.ProjectID,
@@ -229,7 +234,52 @@ func validateInstanceTypes(client API, ic *types.InstallConfig) field.ErrorList
 	return allErrs
 }
 
-func validatePreexistingServiceAccountXpn(client API, ic *types.InstallConfig) field.ErrorList {
+// validateShieldedAndConfidentialVMs checks that the shielded and confidential vm settings of each
+// machine pool are consistent and supported by the instance type of the pool.
+func validateShieldedAndConfidentialVMs(ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ic.ControlPlane != nil {
+		allErrs = append(allErrs, validateMachineSecurity(ic, ic.ControlPlane, field.NewPath("controlPlane", "platform", "gcp"))...)
+	}
+	for idx := range ic.Compute {
+		allErrs = append(allErrs, validateMachineSecurity(ic, &ic.Compute[idx], field.NewPath("compute").Index(idx).Child("platform", "gcp"))...)
+	}
+
+	return allErrs
+}
+
+func validateMachineSecurity(ic *types.InstallConfig, pool *types.MachinePool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	mpool := &gcp.MachinePool{}
+	mpool.Set(ic.GCP.DefaultMachinePlatform)
+	mpool.Set(pool.Platform.GCP)
+	instanceType := mpool.InstanceType
+	if instanceType == "" {
+		instanceType = DefaultInstanceTypeForArch(pool.Architecture)
+	}
+
+	if mpool.ConfidentialCompute == string(gcp.EnabledFeature) {
+		if mpool.OnHostMaintenance != string(gcp.OnHostMaintenanceTerminate) {
+			errMsg := fmt.Sprintf("onHostMaintenance must be set to %s when confidentialCompute is %s", gcp.OnHostMaintenanceTerminate, gcp.EnabledFeature)
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("onHostMaintenance"), mpool.OnHostMaintenance, errMsg))
+		}
+		if family := strings.Split(instanceType, "-")[0]; !sets.New(gcp.ConfidentialComputeMachineFamilies...).Has(family) {
+			errMsg := fmt.Sprintf("confidential compute is only supported by the %s machine families", strings.Join(gcp.ConfidentialComputeMachineFamilies, ", "))
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("type"), instanceType, errMsg))
+		}
+	}
+
+	if mpool.IntegrityMonitoring == string(gcp.EnabledFeature) && mpool.VirtualizedTrustedPlatformModule == string(gcp.DisabledFeature) {
+		errMsg := "integrity monitoring requires the virtualized trusted platform module"
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("integrityMonitoring"), mpool.IntegrityMonitoring, errMsg))
+	}
+
+	return allErrs
+}
+
+func validatePreexistingServiceAccountXpn(ctx context.Context, client API, ic *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if ic.GCP.NetworkProjectID != "" {
@@ -237,7 +287,7 @@ func validatePreexistingServiceAccountXpn(client API, ic *types.InstallConfig) f
 			fldPath := field.NewPath("controlPlane").Child("platform").Child("gcp").Child("serviceAccount")
 
 			// The service account is required for resources in the host project.
-			serviceAccount, err := client.GetServiceAccount(context.Background(), ic.GCP.ProjectID, ic.ControlPlane.Platform.GCP.ServiceAccount)
+			serviceAccount, err := client.GetServiceAccount(ctx, ic.GCP.ProjectID, ic.ControlPlane.Platform.GCP.ServiceAccount)
 			if err != nil {
 				return append(allErrs, field.InternalError(fldPath, err))
 			}
@@ -250,34 +300,74 @@ func validatePreexistingServiceAccountXpn(client API, ic *types.InstallConfig) f
 	return allErrs
 }
 
+// validateComputeServiceAccounts checks that the pre-created service accounts
+// of the compute pools exist and are granted the roles required by compute nodes.
+func validateComputeServiceAccounts(ctx context.Context, client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i := range ic.Compute {
+		pool := &ic.Compute[i]
+		serviceAccount := ComputeServiceAccount(ic, pool)
+		if serviceAccount == "" {
+			continue
+		}
+		fldPath := field.NewPath("compute").Index(i).Child("platform", "gcp", "serviceAccount")
+		if pool.Platform.GCP == nil || pool.Platform.GCP.ServiceAccount == "" {
+			fldPath = field.NewPath("platform", "gcp", "defaultMachinePlatform", "serviceAccount")
+		}
+
+		found, err := client.GetServiceAccount(ctx, ic.GCP.ProjectID, serviceAccount)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+			continue
+		}
+		if found == "" {
+			allErrs = append(allErrs, field.NotFound(fldPath, serviceAccount))
+			continue
+		}
+
+		roles, err := client.GetServiceAccountRoles(ctx, ic.GCP.ProjectID, serviceAccount)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+			continue
+		}
+		if missing := sets.New(ComputeRoles...).Difference(roles); missing.Len() > 0 {
+			errMsg := fmt.Sprintf("service account is missing the required roles %s in project %s", strings.Join(sets.List(missing), ", "), ic.GCP.ProjectID)
+			allErrs = append(allErrs, field.Invalid(fldPath, serviceAccount, errMsg))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidatePreExistingPublicDNS ensure no pre-existing DNS record exists in the public
 // DNS zone for cluster's Kubernetes API. If a PublicDNSZone is provided, the provided
 // zone is verified against the BaseDomain. If no zone is provided, the base domain is
 // checked for any public zone that can be used.
-func ValidatePreExistingPublicDNS(client API, ic *types.InstallConfig) *field.Error {
-	// If this is an internal cluster, this check is not necessary
-	if ic.Publish == types.InternalPublishingStrategy {
+func ValidatePreExistingPublicDNS(ctx context.Context, client API, ic *types.InstallConfig) *field.Error {
+	// If the API is not published, this check is not necessary
+	if !ic.PublicAPI() {
 		return nil
 	}
 
-	zone, err := client.GetDNSZone(context.TODO(), ic.Platform.GCP.ProjectID, ic.BaseDomain, true)
+	zone, err := client.GetDNSZone(ctx, ic.Platform.GCP.ProjectID, ic.BaseDomain, true)
 	if err != nil {
 		if IsNotFound(err) {
 			return field.NotFound(field.NewPath("baseDomain"), fmt.Sprintf("Public DNS Zone (%s/%s)", ic.Platform.GCP.ProjectID, ic.BaseDomain))
 		}
 		return field.InternalError(field.NewPath("baseDomain"), err)
 	}
-	return checkRecordSets(client, ic, zone, []string{apiRecordType(ic)})
+	return checkRecordSets(ctx, client, ic, zone, []string{apiRecordType(ic)})
 }
 
 // ValidatePrivateDNSZone ensure no pre-existing DNS record exists in the private dns zone
 // matching the name that will be used for this installation.
-func ValidatePrivateDNSZone(client API, ic *types.InstallConfig) *field.Error {
+func ValidatePrivateDNSZone(ctx context.Context, client API, ic *types.InstallConfig) *field.Error {
 	if ic.GCP.Network == "" || ic.GCP.NetworkProjectID == "" {
 		return nil
 	}
 
-	zone, err := client.GetDNSZone(context.TODO(), ic.GCP.ProjectID, ic.ClusterDomain(), false)
+	zone, err := client.GetDNSZone(ctx, ic.GCP.ProjectID, ic.ClusterDomain(), false)
 	if err != nil {
 		logrus.Debug("No private DNS Zone found")
 		if IsNotFound(err) {
@@ -288,13 +378,13 @@ func ValidatePrivateDNSZone(client API, ic *types.InstallConfig) *field.Error {
 
 	// Private Zone can be nil, check to see if it was found or not
 	if zone != nil {
-		return checkRecordSets(client, ic, zone, []string{apiRecordType(ic), apiIntRecordName(ic)})
+		return checkRecordSets(ctx, client, ic, zone, []string{apiRecordType(ic), apiIntRecordName(ic)})
 	}
 	return nil
 }
 
-func checkRecordSets(client API, ic *types.InstallConfig, zone *dns.ManagedZone, records []string) *field.Error {
-	rrSets, err := client.GetRecordSets(context.TODO(), ic.GCP.ProjectID, zone.Name)
+func checkRecordSets(ctx context.Context, client API, ic *types.InstallConfig, zone *dns.ManagedZone, records []string) *field.Error {
+	rrSets, err := client.GetRecordSets(ctx, ic.GCP.ProjectID, zone.Name)
 	if err != nil {
 		return field.InternalError(field.NewPath("baseDomain"), err)
 	}
@@ -313,34 +403,34 @@ func checkRecordSets(client API, ic *types.InstallConfig, zone *dns.ManagedZone,
 }
 
 // ValidateForProvisioning validates that the install config is valid for provisioning the cluster.
-func ValidateForProvisioning(ic *types.InstallConfig) error {
-	if ic.Platform.GCP.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled {
+func ValidateForProvisioning(ctx context.Context, ic *types.InstallConfig) error {
+	if ic.ClusterHostedDNS() {
 		return nil
 	}
 
 	allErrs := field.ErrorList{}
 
-	client, err := NewClient(context.TODO())
+	client, err := NewClient(ctx, ic.GCP.ServiceEndpoints)
 	if err != nil {
 		return err
 	}
 
-	if err := ValidatePreExistingPublicDNS(client, ic); err != nil {
+	if err := ValidatePreExistingPublicDNS(ctx, client, ic); err != nil {
 		allErrs = append(allErrs, err)
 	}
 
-	if err := ValidatePrivateDNSZone(client, ic); err != nil {
+	if err := ValidatePrivateDNSZone(ctx, client, ic); err != nil {
 		allErrs = append(allErrs, err)
 	}
 
 	return allErrs.ToAggregate()
 }
 
-func validateProject(client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
+func validateProject(ctx context.Context, client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if ic.GCP.ProjectID != "" {
-		_, err := client.GetProjectByID(context.TODO(), ic.GCP.ProjectID)
+		_, err := client.GetProjectByID(ctx, ic.GCP.ProjectID)
 		if err != nil {
 			if IsNotFound(err) {
 				return append(allErrs, field.Invalid(fieldPath.Child("project"), ic.GCP.ProjectID, "invalid project ID"))
@@ -352,11 +442,11 @@ func validateProject(client API, ic *types.InstallConfig, fieldPath *field.Path)
 	return allErrs
 }
 
-func validateNetworkProject(client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
+func validateNetworkProject(ctx context.Context, client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if ic.GCP.NetworkProjectID != "" {
-		_, err := client.GetProjectByID(context.TODO(), ic.GCP.NetworkProjectID)
+		_, err := client.GetProjectByID(ctx, ic.GCP.NetworkProjectID)
 		if err != nil {
 			if IsNotFound(err) {
 				return append(allErrs, field.Invalid(fieldPath.Child("networkProjectID"), ic.GCP.NetworkProjectID, "invalid project ID"))
@@ -369,7 +459,7 @@ func validateNetworkProject(client API, ic *types.InstallConfig, fieldPath *fiel
 }
 
 // validateNetworks checks that the user-provided VPC is in the project and the provided subnets are valid.
-func validateNetworks(client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
+func validateNetworks(ctx context.Context, client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	networkProjectID := ic.GCP.NetworkProjectID
@@ -378,23 +468,88 @@ func validateNetworks(client API, ic *types.InstallConfig, fieldPath *field.Path
 	}
 
 	if ic.GCP.Network != "" {
-		_, err := client.GetNetwork(context.TODO(), ic.GCP.Network, networkProjectID)
+		_, err := client.GetNetwork(ctx, ic.GCP.Network, networkProjectID)
 		if err != nil {
 			return append(allErrs, field.Invalid(fieldPath.Child("network"), ic.GCP.Network, err.Error()))
 		}
 
-		subnets, err := client.GetSubnetworks(context.TODO(), ic.GCP.Network, networkProjectID, ic.GCP.Region)
+		subnets, err := client.GetSubnetworks(ctx, ic.GCP.Network, networkProjectID, ic.GCP.Region)
 		if err != nil {
 			return append(allErrs, field.Invalid(fieldPath.Child("network"), ic.GCP.Network, "failed to retrieve subnets"))
 		}
 
 		allErrs = append(allErrs, validateSubnet(client, ic, fieldPath.Child("computeSubnet"), subnets, ic.GCP.ComputeSubnet)...)
 		allErrs = append(allErrs, validateSubnet(client, ic, fieldPath.Child("controlPlaneSubnet"), subnets, ic.GCP.ControlPlaneSubnet)...)
+
+		if ic.GCP.GoogleAPIAccess != nil {
+			allErrs = append(allErrs, validateGoogleAPIAccess(ctx, client, ic, fieldPath, subnets)...)
+		}
 	}
 
 	return allErrs
 }
 
+// validateGoogleAPIAccess checks that the Google APIs are reachable from the
+// subnets of the cluster with the configured access: Private Google Access
+// needs to be enabled on the subnets and the addresses of the APIs routed to
+// the default Internet gateway, while a Private Service Connect endpoint needs
+// to exist in the network.
+func validateGoogleAPIAccess(ctx context.Context, client API, ic *types.InstallConfig, fieldPath *field.Path, subnets []*compute.Subnetwork) field.ErrorList {
+	allErrs := field.ErrorList{}
+	access := ic.GCP.GoogleAPIAccess
+	accessPath := fieldPath.Child("googleAPIAccess")
+
+	networkProjectID := ic.GCP.NetworkProjectID
+	if networkProjectID == "" {
+		networkProjectID = ic.GCP.ProjectID
+	}
+
+	if access.Type == gcp.PrivateServiceConnect {
+		rules, err := client.GetGlobalForwardingRules(ctx, ic.GCP.Network, networkProjectID)
+		if err != nil {
+			return append(allErrs, field.InternalError(accessPath.Child("privateServiceConnectEndpoint"), fmt.Errorf("failed to retrieve the forwarding rules of network %s: %w", ic.GCP.Network, err)))
+		}
+		for _, rule := range rules {
+			if rule.IPAddress == access.PrivateServiceConnectEndpoint && rule.Target != "" {
+				return allErrs
+			}
+		}
+		return append(allErrs, field.Invalid(accessPath.Child("privateServiceConnectEndpoint"), access.PrivateServiceConnectEndpoint, fmt.Sprintf("no Private Service Connect endpoint with this address in network %s", ic.GCP.Network)))
+	}
+
+	for _, s := range []struct {
+		path *field.Path
+		name string
+	}{
+		{path: fieldPath.Child("computeSubnet"), name: ic.GCP.ComputeSubnet},
+		{path: fieldPath.Child("controlPlaneSubnet"), name: ic.GCP.ControlPlaneSubnet},
+	} {
+		if subnet, _ := findSubnet(subnets, s.name, ic.GCP.Network, ic.GCP.Region); subnet != nil && !subnet.PrivateIpGoogleAccess {
+			allErrs = append(allErrs, field.Invalid(s.path, s.name, fmt.Sprintf("subnet must have Private Google Access enabled when the access to the Google APIs is %s", access.Type)))
+		}
+	}
+
+	routes, err := client.GetRoutes(ctx, ic.GCP.Network, networkProjectID)
+	if err != nil {
+		return append(allErrs, field.InternalError(accessPath.Child("type"), fmt.Errorf("failed to retrieve the routes of network %s: %w", ic.GCP.Network, err)))
+	}
+	_, addressRange, err := net.ParseCIDR(access.AddressRange())
+	if err != nil {
+		return append(allErrs, field.InternalError(accessPath.Child("type"), err))
+	}
+	for _, route := range routes {
+		if !strings.HasSuffix(route.NextHopGateway, "/default-internet-gateway") {
+			continue
+		}
+		if _, dest, err := net.ParseCIDR(route.DestRange); err == nil && dest.Contains(addressRange.IP) {
+			if ones, _ := dest.Mask.Size(); ones <= 30 {
+				return allErrs
+			}
+		}
+	}
+	return append(allErrs, field.Invalid(accessPath.Child("type"), access.Type, fmt.Sprintf("network %s has no route for %s to the default Internet gateway", ic.GCP.Network, access.AddressRange())))
+}
+
 func validateSubnet(client API, ic *types.InstallConfig, fieldPath *field.Path, subnets []*compute.Subnetwork, name string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -466,12 +621,12 @@ func ValidateEnabledServices(ctx context.Context, client API, project string) er
 }
 
 // ValidateProjectRegion determines whether the region is valid for the project
-func validateRegion(client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
+func validateRegion(ctx context.Context, client API, ic *types.InstallConfig, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	regionFound := false
 
 	if ic.GCP.ProjectID != "" && ic.GCP.Region != "" {
-		computeRegions, err := client.GetRegions(context.TODO(), ic.GCP.ProjectID)
+		computeRegions, err := client.GetRegions(ctx, ic.GCP.ProjectID)
 		if err != nil {
 			return append(allErrs, field.InternalError(fieldPath.Child("project"), err))
 		} else if len(computeRegions) == 0 {
@@ -520,10 +675,10 @@ func ValidateCredentialMode(client API, ic *types.InstallConfig) field.ErrorList
 	return allErrs
 }
 
-func validateZones(client API, ic *types.InstallConfig) field.ErrorList {
+func validateZones(ctx context.Context, client API, ic *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	zones, err := client.GetZones(context.TODO(), ic.GCP.ProjectID, fmt.Sprintf("region eq .*%s", ic.GCP.Region))
+	zones, err := client.GetZones(ctx, ic.GCP.ProjectID, fmt.Sprintf("region eq .*%s", ic.GCP.Region))
 	if err != nil {
 		return append(allErrs, field.InternalError(nil, err))
 	} else if len(zones) == 0 {
@@ -564,7 +719,7 @@ func validateZones(client API, ic *types.InstallConfig) field.ErrorList {
 	return allErrs
 }
 
-func validateMarketplaceImages(client API, ic *types.InstallConfig) field.ErrorList {
+func validateMarketplaceImages(ctx context.Context, client API, ic *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	const errorMessage string = "could not find the boot image: %v"
@@ -574,7 +729,7 @@ func validateMarketplaceImages(client API, ic *types.InstallConfig) field.ErrorL
 
 	if ic.GCP.DefaultMachinePlatform != nil && ic.GCP.DefaultMachinePlatform.OSImage != nil {
 		defaultOsImage = ic.GCP.DefaultMachinePlatform.OSImage
-		defaultImage, err = client.GetImage(context.TODO(), defaultOsImage.Name, defaultOsImage.Project)
+		defaultImage, err = client.GetImage(ctx, defaultOsImage.Name, defaultOsImage.Project)
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("platform", "gcp", "defaultMachinePlatform", "osImage"), *defaultOsImage, fmt.Sprintf(errorMessage, err)))
 		}
@@ -585,7 +740,7 @@ func validateMarketplaceImages(client API, ic *types.InstallConfig) field.ErrorL
 		osImage := defaultOsImage
 		if ic.ControlPlane.Platform.GCP != nil && ic.ControlPlane.Platform.GCP.OSImage != nil {
 			osImage = ic.ControlPlane.Platform.GCP.OSImage
-			image, err = client.GetImage(context.TODO(), osImage.Name, osImage.Project)
+			image, err = client.GetImage(ctx, osImage.Name, osImage.Project)
 			if err != nil {
 				allErrs = append(allErrs, field.Invalid(field.NewPath("controlPlane", "platform", "gcp", "osImage"), *osImage, fmt.Sprintf(errorMessage, err)))
 			}
@@ -603,7 +758,7 @@ func validateMarketplaceImages(client API, ic *types.InstallConfig) field.ErrorL
 		fieldPath := field.NewPath("compute").Index(idx)
 		if compute.Platform.GCP != nil && compute.Platform.GCP.OSImage != nil {
 			osImage = compute.Platform.GCP.OSImage
-			image, err = client.GetImage(context.TODO(), osImage.Name, osImage.Project)
+			image, err = client.GetImage(ctx, osImage.Name, osImage.Project)
 			if err != nil {
 				allErrs = append(allErrs, field.Invalid(fieldPath.Child("platform", "gcp", "osImage"), *osImage, fmt.Sprintf(errorMessage, err)))
 			}
@@ -640,6 +795,6 @@ func checkArchitecture(imageArch string, icArch types.Architecture, role string)
 
 // validateUserTags check for existence and accessibility of user-defined tags and persists
 // validated tags in-memory.
-func validateUserTags(client API, projectID string, userTags []gcp.UserTag) error {
-	return NewTagManager(client).validateAndPersistUserTags(context.Background(), projectID, userTags)
+func validateUserTags(ctx context.Context, client API, projectID string, userTags []gcp.UserTag) error {
+	return NewTagManager(client).validateAndPersistUserTags(ctx, projectID, userTags)
 }
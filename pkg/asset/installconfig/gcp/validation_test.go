@@ -45,6 +45,8 @@ var (
 	validBaseDomain    = "example.installer.domain."
 	validXpnSA         = "valid-example-sa@gcloud.serviceaccount.com"
 	invalidXpnSA       = "invalid-example-sa@gcloud.serviceaccount.com"
+	validComputeSA     = "valid-compute-sa@gcloud.serviceaccount.com"
+	viewerComputeSA    = "viewer-compute-sa@gcloud.serviceaccount.com"
 
 	// #nosec G101
 	fakeCreds = `{
@@ -105,6 +107,9 @@ var (
 	validNetworkProject      = func(ic *types.InstallConfig) { ic.GCP.NetworkProjectID = validProjectName }
 	validateXpnSA            = func(ic *types.InstallConfig) { ic.ControlPlane.Platform.GCP.ServiceAccount = validXpnSA }
 	invalidateXpnSA          = func(ic *types.InstallConfig) { ic.ControlPlane.Platform.GCP.ServiceAccount = invalidXpnSA }
+	validateComputeSA        = func(ic *types.InstallConfig) { ic.Compute[0].Platform.GCP.ServiceAccount = validComputeSA }
+	invalidateComputeSA      = func(ic *types.InstallConfig) { ic.Compute[0].Platform.GCP.ServiceAccount = viewerComputeSA }
+	defaultComputeSA         = func(ic *types.InstallConfig) { ic.GCP.DefaultMachinePlatform.ServiceAccount = invalidXpnSA }
 
 	machineTypeAPIResult = map[string]*compute.MachineType{
 		"n1-standard-1":  {GuestCpus: 1, MemoryMb: 3840},
@@ -310,6 +315,23 @@ func TestGCPInstallConfigValidation(t *testing.T) {
 			expectedError:  true,
 			expectedErrMsg: "controlPlane.platform.gcp.serviceAccount: Internal error\"",
 		},
+		{
+			name:          "Valid compute service account",
+			edits:         editFunctions{validateComputeSA},
+			expectedError: false,
+		},
+		{
+			name:           "Compute service account missing roles",
+			edits:          editFunctions{invalidateComputeSA},
+			expectedError:  true,
+			expectedErrMsg: `compute\[0\]\.platform\.gcp\.serviceAccount: Invalid value: "viewer-compute-sa@gcloud\.serviceaccount\.com": service account is missing the required roles roles/storage\.admin in project valid-project`,
+		},
+		{
+			name:           "Invalid default compute service account",
+			edits:          editFunctions{defaultComputeSA},
+			expectedError:  true,
+			expectedErrMsg: `platform\.gcp\.defaultMachinePlatform\.serviceAccount: Internal error`,
+		},
 	}
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -365,6 +387,10 @@ func TestGCPInstallConfigValidation(t *testing.T) {
 
 	gcpClient.EXPECT().GetServiceAccount(gomock.Any(), validProjectName, validXpnSA).Return(validXpnSA, nil).AnyTimes()
 	gcpClient.EXPECT().GetServiceAccount(gomock.Any(), validProjectName, invalidXpnSA).Return("", fmt.Errorf("controlPlane.platform.gcp.serviceAccount: Internal error\"")).AnyTimes()
+	gcpClient.EXPECT().GetServiceAccount(gomock.Any(), validProjectName, validComputeSA).Return(validComputeSA, nil).AnyTimes()
+	gcpClient.EXPECT().GetServiceAccount(gomock.Any(), validProjectName, viewerComputeSA).Return(viewerComputeSA, nil).AnyTimes()
+	gcpClient.EXPECT().GetServiceAccountRoles(gomock.Any(), validProjectName, validComputeSA).Return(sets.New(ComputeRoles...), nil).AnyTimes()
+	gcpClient.EXPECT().GetServiceAccountRoles(gomock.Any(), validProjectName, viewerComputeSA).Return(sets.New("roles/compute.viewer"), nil).AnyTimes()
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -373,7 +399,7 @@ func TestGCPInstallConfigValidation(t *testing.T) {
 				edit(editedInstallConfig)
 			}
 
-			errs := Validate(gcpClient, editedInstallConfig)
+			errs := Validate(context.TODO(), gcpClient, editedInstallConfig)
 			if tc.expectedError {
 				assert.Regexp(t, tc.expectedErrMsg, errs)
 			} else {
@@ -413,7 +439,7 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 			gcpClient.EXPECT().GetDNSZone(gomock.Any(), "project-id", "base-domain", true).Return(&dns.ManagedZone{Name: "zone-name"}, nil).AnyTimes()
 			gcpClient.EXPECT().GetRecordSets(gomock.Any(), gomock.Eq("project-id"), gomock.Eq("zone-name")).Return(test.records, nil).AnyTimes()
 
-			err := ValidatePreExistingPublicDNS(gcpClient, &types.InstallConfig{
+			err := ValidatePreExistingPublicDNS(context.TODO(), gcpClient, &types.InstallConfig{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster-name"},
 				BaseDomain: "base-domain",
 				Platform:   types.Platform{GCP: &gcp.Platform{ProjectID: "project-id"}},
@@ -427,6 +453,76 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 	}
 }
 
+func TestValidateGoogleAPIAccess(t *testing.T) {
+	accessibleSubnets := []*compute.Subnetwork{
+		{Name: validCPSubnet, IpCidrRange: validCIDR, PrivateIpGoogleAccess: true},
+		{Name: validComputeSubnet, IpCidrRange: validCIDR, PrivateIpGoogleAccess: true},
+	}
+	defaultRoute := &compute.Route{DestRange: "0.0.0.0/0", NextHopGateway: "projects/valid-project/global/gateways/default-internet-gateway"}
+	restrictedRoute := &compute.Route{DestRange: "199.36.153.4/30", NextHopGateway: "projects/valid-project/global/gateways/default-internet-gateway"}
+	pscEndpoint := &compute.ForwardingRule{IPAddress: "10.100.0.2", Target: "all-apis"}
+
+	cases := []struct {
+		name    string
+		access  *gcp.GoogleAPIAccess
+		subnets []*compute.Subnetwork
+		routes  []*compute.Route
+		rules   []*compute.ForwardingRule
+		err     string
+	}{{
+		name:    "private google access with a default route",
+		access:  &gcp.GoogleAPIAccess{Type: gcp.PrivateGoogleAccess},
+		subnets: accessibleSubnets,
+		routes:  []*compute.Route{defaultRoute},
+	}, {
+		name:    "restricted google access with a route to the restricted addresses",
+		access:  &gcp.GoogleAPIAccess{Type: gcp.RestrictedGoogleAccess},
+		subnets: accessibleSubnets,
+		routes:  []*compute.Route{restrictedRoute},
+	}, {
+		name:    "private google access without a route to the private addresses",
+		access:  &gcp.GoogleAPIAccess{Type: gcp.PrivateGoogleAccess},
+		subnets: accessibleSubnets,
+		routes:  []*compute.Route{restrictedRoute},
+		err:     `^\Qplatform.gcp.googleAPIAccess.type: Invalid value: "PrivateGoogleAccess": network valid-vpc has no route for 199.36.153.8/30 to the default Internet gateway\E$`,
+	}, {
+		name:    "private google access disabled on the subnets",
+		access:  &gcp.GoogleAPIAccess{Type: gcp.PrivateGoogleAccess},
+		subnets: subnetAPIResult,
+		routes:  []*compute.Route{defaultRoute},
+		err:     `^\Q[platform.gcp.computeSubnet: Invalid value: "valid-compute-subnet": subnet must have Private Google Access enabled when the access to the Google APIs is PrivateGoogleAccess, platform.gcp.controlPlaneSubnet: Invalid value: "valid-controlplane-subnet": subnet must have Private Google Access enabled when the access to the Google APIs is PrivateGoogleAccess]\E$`,
+	}, {
+		name:   "private service connect endpoint",
+		access: &gcp.GoogleAPIAccess{Type: gcp.PrivateServiceConnect, PrivateServiceConnectEndpoint: "10.100.0.2"},
+		rules:  []*compute.ForwardingRule{pscEndpoint},
+	}, {
+		name:   "missing private service connect endpoint",
+		access: &gcp.GoogleAPIAccess{Type: gcp.PrivateServiceConnect, PrivateServiceConnectEndpoint: "10.100.0.3"},
+		rules:  []*compute.ForwardingRule{pscEndpoint},
+		err:    `^\Qplatform.gcp.googleAPIAccess.privateServiceConnectEndpoint: Invalid value: "10.100.0.3": no Private Service Connect endpoint with this address in network valid-vpc\E$`,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			gcpClient := mock.NewMockAPI(mockCtrl)
+
+			gcpClient.EXPECT().GetRoutes(gomock.Any(), validNetworkName, validProjectName).Return(test.routes, nil).AnyTimes()
+			gcpClient.EXPECT().GetGlobalForwardingRules(gomock.Any(), validNetworkName, validProjectName).Return(test.rules, nil).AnyTimes()
+
+			ic := validInstallConfig()
+			ic.GCP.GoogleAPIAccess = test.access
+			err := validateGoogleAPIAccess(context.TODO(), gcpClient, ic, field.NewPath("platform").Child("gcp"), test.subnets).ToAggregate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, test.err, err)
+			}
+		})
+	}
+}
+
 func TestValidatePrivateDNSZone(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -457,7 +553,7 @@ func TestValidatePrivateDNSZone(t *testing.T) {
 			gcpClient.EXPECT().GetDNSZone(gomock.Any(), "project-id", "cluster-name.base-domain", false).Return(&dns.ManagedZone{Name: "zone-name"}, nil).AnyTimes()
 			gcpClient.EXPECT().GetRecordSets(gomock.Any(), gomock.Eq("project-id"), gomock.Eq("zone-name")).Return(test.records, nil).AnyTimes()
 
-			err := ValidatePrivateDNSZone(gcpClient, &types.InstallConfig{
+			err := ValidatePrivateDNSZone(context.TODO(), gcpClient, &types.InstallConfig{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster-name"},
 				BaseDomain: "base-domain",
 				Platform:   types.Platform{GCP: &gcp.Platform{ProjectID: "project-id", Network: "shared-vpc", NetworkProjectID: "test-network-project"}},
@@ -650,6 +746,72 @@ func TestValidateServiceAccountPresent(t *testing.T) {
 	}
 }
 
+func TestValidateShieldedAndConfidentialVMs(t *testing.T) {
+	cases := []struct {
+		name          string
+		edits         editFunctions
+		expectedError string
+	}{
+		{
+			name: "Valid shielded vm",
+			edits: editFunctions{func(ic *types.InstallConfig) {
+				ic.GCP.DefaultMachinePlatform.SecureBoot = "Enabled"
+				ic.GCP.DefaultMachinePlatform.VirtualizedTrustedPlatformModule = "Enabled"
+				ic.GCP.DefaultMachinePlatform.IntegrityMonitoring = "Enabled"
+			}},
+		},
+		{
+			name: "Valid confidential vm",
+			edits: editFunctions{func(ic *types.InstallConfig) {
+				ic.Compute[0].Platform.GCP.InstanceType = "n2d-standard-4"
+				ic.Compute[0].Platform.GCP.ConfidentialCompute = "Enabled"
+				ic.Compute[0].Platform.GCP.OnHostMaintenance = "Terminate"
+			}},
+		},
+		{
+			name: "Confidential vm without terminate on host maintenance",
+			edits: editFunctions{func(ic *types.InstallConfig) {
+				ic.Compute[0].Platform.GCP.InstanceType = "n2d-standard-4"
+				ic.Compute[0].Platform.GCP.ConfidentialCompute = "Enabled"
+			}},
+			expectedError: `^compute\[0\]\.platform\.gcp\.onHostMaintenance: Invalid value: "": onHostMaintenance must be set to Terminate when confidentialCompute is Enabled$`,
+		},
+		{
+			name: "Confidential vm on unsupported default instance type",
+			edits: editFunctions{func(ic *types.InstallConfig) {
+				ic.GCP.DefaultMachinePlatform.ConfidentialCompute = "Enabled"
+				ic.GCP.DefaultMachinePlatform.OnHostMaintenance = "Terminate"
+				ic.ControlPlane.Platform.GCP.InstanceType = "c2d-standard-4"
+			}},
+			expectedError: `^compute\[0\]\.platform\.gcp\.type: Invalid value: "n2-standard-4": confidential compute is only supported by the c2d, c3d, n2d machine families$`,
+		},
+		{
+			name: "Integrity monitoring without vtpm",
+			edits: editFunctions{func(ic *types.InstallConfig) {
+				ic.GCP.DefaultMachinePlatform.VirtualizedTrustedPlatformModule = "Disabled"
+				ic.Compute[0].Platform.GCP.IntegrityMonitoring = "Enabled"
+			}},
+			expectedError: `^compute\[0\]\.platform\.gcp\.integrityMonitoring: Invalid value: "Enabled": integrity monitoring requires the virtualized trusted platform module$`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := validInstallConfig()
+			for _, edit := range tc.edits {
+				edit(ic)
+			}
+
+			err := validateShieldedAndConfidentialVMs(ic).ToAggregate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
+
 func TestValidateZones(t *testing.T) {
 	validZonesDefaultMachine := func(ic *types.InstallConfig) {
 		ic.Platform.GCP.DefaultMachinePlatform.Zones = []string{"us-central1-a", "us-central1-c"}
@@ -735,7 +897,7 @@ func TestValidateZones(t *testing.T) {
 				edit(editedInstallConfig)
 			}
 
-			errs := validateZones(gcpClient, editedInstallConfig)
+			errs := validateZones(context.TODO(), gcpClient, editedInstallConfig)
 			if tc.expectedError {
 				assert.Regexp(t, tc.expectedErrMsg, errs)
 			} else {
@@ -840,7 +1002,7 @@ func TestValidateInstanceType(t *testing.T) {
 
 	for _, test := range cases {
 		t.Run(test.name, func(t *testing.T) {
-			errs := ValidateInstanceType(gcpClient, field.NewPath("instance"), "project-id", "region", test.zones, test.instanceType, controlPlaneReq, test.arch)
+			errs := ValidateInstanceType(context.TODO(), gcpClient, field.NewPath("instance"), "project-id", "region", test.zones, test.instanceType, controlPlaneReq, test.arch)
 			if test.expectedError {
 				assert.Regexp(t, test.expectedErrMsg, errs)
 			} else {
@@ -1034,7 +1196,7 @@ func TestValidateMarketplaceImages(t *testing.T) {
 			}
 
 			hook := logrusTest.NewGlobal()
-			errs := validateMarketplaceImages(gcpClient, editedInstallConfig)
+			errs := validateMarketplaceImages(context.TODO(), gcpClient, editedInstallConfig)
 			if tc.expectedError {
 				assert.Regexp(t, tc.expectedErrMsg, errs)
 			} else {
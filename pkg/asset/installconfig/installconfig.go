@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	dockerref "github.com/containers/image/docker/reference"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -18,6 +21,7 @@ import (
 	icovirt "github.com/openshift/installer/pkg/asset/installconfig/ovirt"
 	icpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	icvsphere "github.com/openshift/installer/pkg/asset/installconfig/vsphere"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/defaults"
 	"github.com/openshift/installer/pkg/types/validation"
@@ -61,7 +65,7 @@ func (a *InstallConfig) Dependencies() []asset.Asset {
 }
 
 // Generate generates the install-config.yaml file.
-func (a *InstallConfig) Generate(parents asset.Parents) error {
+func (a *InstallConfig) Generate(ctx context.Context, parents asset.Parents) error {
 	sshPublicKey := &sshPublicKey{}
 	baseDomain := &baseDomain{}
 	clusterName := &clusterName{}
@@ -99,17 +103,20 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 	a.Config.Ovirt = platform.Ovirt
 	a.Config.PowerVS = platform.PowerVS
 	a.Config.Nutanix = platform.Nutanix
+	if len(platform.machineNetwork) > 0 {
+		a.Config.Networking = &types.Networking{MachineNetwork: platform.machineNetwork}
+	}
 
 	defaults.SetInstallConfigDefaults(a.Config)
 
-	return a.finish("")
+	return a.finish(ctx, "")
 }
 
 // Load returns the installconfig from disk.
 func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 	found, err = a.LoadFromFile(f)
 	if found && err == nil {
-		if err := a.finish(installConfigFilename); err != nil {
+		if err := a.finish(context.TODO(), installConfigFilename); err != nil {
 			return false, errors.Wrap(err, asset.InstallConfigError)
 		}
 	}
@@ -118,11 +125,11 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 }
 
 // finishAWS set defaults for AWS Platform before the config validation.
-func (a *InstallConfig) finishAWS() error {
+func (a *InstallConfig) finishAWS(ctx context.Context) error {
 	// Set the Default Edge Compute pool when the subnets in AWS Local Zones are defined,
 	// when installing a cluster in existing VPC.
 	if len(a.Config.Platform.AWS.Subnets) > 0 {
-		edgeSubnets, err := a.AWS.EdgeSubnets(context.TODO())
+		edgeSubnets, err := a.AWS.EdgeSubnets(ctx)
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("unable to load edge subnets: %v", err))
 		}
@@ -137,15 +144,16 @@ func (a *InstallConfig) finishAWS() error {
 	return nil
 }
 
-func (a *InstallConfig) finish(filename string) error {
+func (a *InstallConfig) finish(ctx context.Context, filename string) error {
 	if a.Config.AWS != nil {
 		a.AWS = aws.NewMetadata(a.Config.Platform.AWS.Region, a.Config.Platform.AWS.Subnets, a.Config.AWS.ServiceEndpoints)
-		if err := a.finishAWS(); err != nil {
+		if err := a.finishAWS(ctx); err != nil {
 			return err
 		}
 	}
 	if a.Config.Azure != nil {
 		a.Azure = icazure.NewMetadata(a.Config.Azure.CloudName, a.Config.Azure.ARMEndpoint)
+		a.Azure.ServiceEndpoints = a.Config.Azure.ServiceEndpoints
 	}
 	if a.Config.IBMCloud != nil {
 		a.IBMCloud = icibmcloud.NewMetadata(a.Config)
@@ -156,6 +164,9 @@ func (a *InstallConfig) finish(filename string) error {
 	if a.Config.VSphere != nil {
 		a.VSphere = icvsphere.NewMetadata()
 
+		if err := icvsphere.PasswordsFromSource(ctx, a.Config.VSphere.VCenters); err != nil {
+			return err
+		}
 		for _, v := range a.Config.VSphere.VCenters {
 			_ = a.VSphere.AddCredentials(v.Server, v.Username, v.Password)
 		}
@@ -168,17 +179,85 @@ func (a *InstallConfig) finish(filename string) error {
 		return errors.Wrapf(err, "invalid %q file", filename)
 	}
 
-	if err := a.platformValidation(); err != nil {
+	if err := a.releaseArchitectureValidation(ctx); err != nil {
+		return err
+	}
+
+	if err := a.releaseImageVerification(ctx); err != nil {
+		return err
+	}
+
+	if err := a.platformValidation(ctx); err != nil {
 		return err
 	}
 
 	return a.RecordFile()
 }
 
+// releaseArchitectureValidation checks that heterogeneous clusters are
+// installed from a multi-arch release payload. The payload is only inspected
+// when a compute pool architecture differs from the control plane.
+func (a *InstallConfig) releaseArchitectureValidation(ctx context.Context) error {
+	heterogeneous := false
+	for _, p := range a.Config.Compute {
+		if a.Config.ControlPlane != nil && p.Architecture != a.Config.ControlPlane.Architecture {
+			heterogeneous = true
+		}
+	}
+	if !heterogeneous {
+		return nil
+	}
+
+	releaseImage := &releaseimage.Image{}
+	if err := releaseImage.Generate(ctx, asset.Parents{}); err != nil {
+		return errors.Wrap(err, "failed to determine release image")
+	}
+	pullSpec := releaseImage.PullSpecFor(a.Config)
+	releaseArch, err := releaseimage.Architecture(a.Config.PullSecret, pullSpec)
+	if err != nil {
+		logrus.Warnf("Unable to determine the architecture of release image %s: %v", pullSpec, err)
+	} else {
+		logrus.Debugf("Release image %s architecture is %s", pullSpec, releaseArch)
+	}
+	return validation.ValidateReleaseArchitecture(a.Config, releaseArch).ToAggregate()
+}
+
+// releaseImageVerification checks the signatures of the release image
+// overrides when the install config requires it.
+func (a *InstallConfig) releaseImageVerification(ctx context.Context) error {
+	v := a.Config.ReleaseImageVerification
+	if v == nil {
+		return nil
+	}
+	store := v.SignatureStore
+	if store == "" {
+		store = releaseimage.DefaultSignatureStore
+	}
+	var errs []error
+	for _, r := range a.Config.ReleaseImages {
+		ref, err := dockerref.ParseNormalizedNamed(r.PullSpec)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to parse release image %s", r.PullSpec))
+			continue
+		}
+		digested, ok := ref.(dockerref.Digested)
+		if !ok {
+			errs = append(errs, errors.Errorf("release image %s is not pinned by digest", r.PullSpec))
+			continue
+		}
+		if err := releaseimage.VerifySignatureWithKeys(ctx, digested.Digest().String(), v.PublicKeys, store); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to verify the signature of release image %s", r.PullSpec))
+			continue
+		}
+		logrus.Infof("Verified the signature of release image %s", r.PullSpec)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // platformValidation runs validations that require connecting to the
 // underlying platform. In some cases, platforms also duplicate validations
 // that have already been checked by validation.ValidateInstallConfig().
-func (a *InstallConfig) platformValidation() error {
+func (a *InstallConfig) platformValidation(ctx context.Context) error {
 	if a.Config.Platform.Azure != nil {
 		if a.Config.Platform.Azure.IsARO() {
 			// ARO performs platform validation in the Resource Provider before
@@ -192,11 +271,11 @@ func (a *InstallConfig) platformValidation() error {
 		return icazure.Validate(client, a.Config)
 	}
 	if a.Config.Platform.GCP != nil {
-		client, err := icgcp.NewClient(context.TODO())
+		client, err := icgcp.NewClient(ctx, a.Config.GCP.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
-		return icgcp.Validate(client, a.Config)
+		return icgcp.Validate(ctx, client, a.Config)
 	}
 	if a.Config.Platform.IBMCloud != nil {
 		// Validate the Service Endpoints now, before performing any additional validation of the InstallConfig
@@ -211,7 +290,7 @@ func (a *InstallConfig) platformValidation() error {
 		return icibmcloud.Validate(client, a.Config)
 	}
 	if a.Config.Platform.AWS != nil {
-		return aws.Validate(context.TODO(), a.AWS, a.Config)
+		return aws.Validate(ctx, a.AWS, a.Config)
 	}
 	if a.Config.Platform.VSphere != nil {
 		return icvsphere.Validate(a.Config)
@@ -1,6 +1,7 @@
 package installconfig
 
 import (
+	"context"
 	"errors"
 	"os"
 	"testing"
@@ -35,7 +36,7 @@ func TestInstallConfigGenerate_FillsInDefaults(t *testing.T) {
 		pullSecret,
 		platform,
 	)
-	if err := installConfig.Generate(parents); err != nil {
+	if err := installConfig.Generate(context.Background(), parents); err != nil {
 		t.Errorf("unexpected error generating install config: %v", err)
 	}
 	expected := &types.InstallConfig{
@@ -508,3 +509,37 @@ pullSecret: "{\"auths\":{\"example.com\":{\"auth\":\"authorization value\"}}}"
 		})
 	}
 }
+
+func TestInstallConfigLoadSecretsFromEnvironment(t *testing.T) {
+	const pullSecret = `{"auths":{"example.com":{"auth":"authorization value"}}}`
+	const sshKey = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQD1+D0ns3LYRPeFK2nqOtVKBGueBQGdBLre5A+afvjaIj/QgtJuwv3rb6Uso8GMPbFlj693/b9BcV0TGxa5lC8cAGKrpxKUPvZ0WLRFLMP5HKBFf6+N4SQR9NKi7Liw8Km1GW9l+s/gMFz/ypANTg8PqvR4yglW+6jJEuKdCy/q14s9kEn4czifBzqiBw60gUiDdWbawl8yF+TxiqeKTCfw4HTeY6j1vui0ROuN2XAWgdH999rNAr1QY8BPMTjQJ5X7jeFgagq7u+snXgWycoDsn4fZP1XL91nQXLdZZgJ3T/qtjUbQt4wUuiqCu4cyN8KRoFQBtX9X7TKU8aH/Kkf+t67zS/SE0ZgvCkNr+iaqYVyHpmBoLh3AaWUYJ2bQ7fx9FvEGLcDYNkwqBED6VwuqB7nw+zGYVouGLs+2UKjfc+A1BOP0Q/2ACEkt1u5iLA+dfEC5nMMThIMNgXpjpsYLsGDKV+e9fEzrTphYtYs/XKaYlG634kGMk7wdgsHoTL0= localhost"
+	t.Setenv(pullSecretEnvVar, pullSecret)
+	t.Setenv(sshKeyEnvVar, sshKey)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+	fileFetcher.EXPECT().FetchByName(installConfigFilename).
+		Return(
+			&asset.File{
+				Filename: installConfigFilename,
+				Data: []byte(`
+apiVersion: v1
+metadata:
+  name: test-cluster
+baseDomain: test-domain
+platform:
+  aws:
+    region: us-east-1
+`)},
+			nil,
+		)
+
+	ic := &InstallConfig{}
+	found, err := ic.Load(fileFetcher)
+	assert.True(t, found)
+	assert.NoError(t, err)
+	assert.Equal(t, pullSecret, ic.Config.PullSecret)
+	assert.Equal(t, sshKey, ic.Config.SSHKey)
+}
@@ -14,6 +14,18 @@ import (
 	"github.com/openshift/installer/pkg/types/defaults"
 )
 
+const (
+	// pullSecretEnvVar is the environment variable providing the pull
+	// secret when the install-config does not set it. It keeps the pull
+	// secret out of the install-config file only: the state file of the
+	// installation directory and the ignition configs still hold it in
+	// plaintext.
+	pullSecretEnvVar = "OPENSHIFT_INSTALL_PULL_SECRET"
+	// sshKeyEnvVar is the environment variable providing the SSH public
+	// key when the install-config does not set it.
+	sshKeyEnvVar = "OPENSHIFT_INSTALL_SSH_PUB_KEY"
+)
+
 // AssetBase is the base structure for the separate InstallConfig assets used
 // in the agent-based and IPI/UPI installation methods.
 type AssetBase struct {
@@ -60,6 +72,15 @@ func (a *AssetBase) LoadFromFile(f asset.FileFetcher) (found bool, err error) {
 	}
 	a.Config = config
 
+	// The secrets can be kept out of the install-config and provided by
+	// the environment instead.
+	if a.Config.PullSecret == "" {
+		a.Config.PullSecret = os.Getenv(pullSecretEnvVar)
+	}
+	if a.Config.SSHKey == "" {
+		a.Config.SSHKey = os.Getenv(sshKeyEnvVar)
+	}
+
 	// Upconvert any deprecated fields
 	if err := conversion.ConvertInstallConfig(a.Config); err != nil {
 		return false, errors.Wrap(errors.Wrap(err, "failed to upconvert install config"), asset.InstallConfigError)
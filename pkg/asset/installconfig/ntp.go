@@ -0,0 +1,45 @@
+package installconfig
+
+import (
+	"net"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const ntpProbeTimeout = 5 * time.Second
+
+// warnUnreachableNTPServers sends an NTP client request to each of the
+// servers and logs a warning for the ones that do not answer. The servers
+// may only be reachable from the machine network, so an unreachable server
+// does not prevent the installation.
+func warnUnreachableNTPServers(servers []string) {
+	for _, server := range servers {
+		if err := probeNTPServer(server); err != nil {
+			logrus.Warnf("NTP server %s did not respond from this host: %v. Ensure the cluster nodes can reach it to avoid clock skew.", server, err)
+		}
+	}
+}
+
+func probeNTPServer(server string) error {
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(server, "123"), ntpProbeTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(ntpProbeTimeout)); err != nil {
+		return err
+	}
+
+	// A minimal SNTP client request: LI = 0, VN = 4, Mode = 3 (client).
+	req := make([]byte, 48)
+	req[0] = 0x23
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	resp := make([]byte, 48)
+	_, err = conn.Read(resp)
+	return err
+}
@@ -59,10 +59,12 @@ type clients struct {
 }
 
 // Flavor embeds information from the Gophercloud Flavor struct and adds
-// information on whether a flavor is of baremetal type.
+// information on whether a flavor is of baremetal type, along with its extra
+// specs.
 type Flavor struct {
 	flavors.Flavor
-	Baremetal bool
+	Baremetal  bool
+	ExtraSpecs map[string]string
 }
 
 var ci *CloudInfo
@@ -275,26 +277,24 @@ func (ci *CloudInfo) getFlavor(flavorName string) (Flavor, error) {
 		return Flavor{}, err
 	}
 
-	var baremetal bool
-	{
-		const baremetalProperty = "baremetal"
-
-		m, err := flavors.GetExtraSpec(ci.clients.computeClient, flavorID, baremetalProperty).Extract()
-		if err != nil && !isNotFoundError(err) {
+	extraSpecs, err := flavors.ListExtraSpecs(ci.clients.computeClient, flavorID).Extract()
+	if err != nil {
+		switch {
+		case isUnauthorized(err):
+			logrus.Warnf("Missing permissions to fetch the extra specs of flavor %q and therefore will skip checking them: %v", flavorName, err)
+		case isNotFoundError(err):
+		default:
 			return Flavor{}, err
 		}
-
-		if m != nil && m[baremetalProperty] == "true" {
-			baremetal = true
-		}
 	}
 
 	// NOTE(mdbooth): The dereference of flavor is safe here because
 	// flavors.Get().Extract() should have raised an error above if the flavor
 	// was not found.
 	return Flavor{
-		Flavor:    *flavor,
-		Baremetal: baremetal,
+		Flavor:     *flavor,
+		Baremetal:  extraSpecs["baremetal"] == "true",
+		ExtraSpecs: extraSpecs,
 	}, nil
 }
 
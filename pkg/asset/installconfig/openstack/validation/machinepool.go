@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/quota"
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
@@ -65,13 +66,43 @@ func ValidateMachinePool(p *openstack.MachinePool, ci *CloudInfo, controlPlane b
 		allErrs = append(allErrs, validateFlavor(p.FlavorName, ci, computeFlavorMinimums, fldPath.Child("type"), checkStorageFlavor)...)
 	}
 
+	if flavor, ok := ci.Flavors[p.FlavorName]; ok && !flavor.Baremetal {
+		warnFlavorExtraSpecs(p, flavor, controlPlane)
+		warnFlavorQuota(flavor, ci.Quotas)
+	}
+
 	allErrs = append(allErrs, validateZones(p.Zones, ci.ComputeZones, fldPath.Child("zones"))...)
 	allErrs = append(allErrs, validateUUIDV4s(p.AdditionalNetworkIDs, fldPath.Child("additionalNetworkIDs"))...)
 	allErrs = append(allErrs, validateUUIDV4s(p.AdditionalSecurityGroupIDs, fldPath.Child("additionalSecurityGroupIDs"))...)
+	allErrs = append(allErrs, validateAdditionalPorts(p.AdditionalPorts, ci, fldPath.Child("additionalPorts"))...)
+
+	return allErrs
+}
+
+func validateAdditionalPorts(ports []openstack.AdditionalPort, ci *CloudInfo, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for idx, port := range ports {
+		if port.NetworkID != "" && !ValidUUIDv4(port.NetworkID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx).Child("networkID"), port.NetworkID, "valid UUID v4 must be specified"))
+		}
+		allErrs = append(allErrs, validateUUIDV4s(port.SubnetIDs, fldPath.Index(idx).Child("subnetIDs"))...)
+		if port.Trunk && !hasNetworkExtension(ci, "trunk") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx).Child("trunk"), port.Trunk, "the trunk network extension is not available in this cloud"))
+		}
+	}
 
 	return allErrs
 }
 
+func hasNetworkExtension(ci *CloudInfo, alias string) bool {
+	for _, extension := range ci.NetworkExtensions {
+		if extension.Alias == alias {
+			return true
+		}
+	}
+	return false
+}
+
 func validateZones(input []string, available []string, fldPath *field.Path) field.ErrorList {
 	// check if machinepool default
 	if len(input) == 1 && input[0] == "" {
@@ -131,6 +162,57 @@ func ValidUUIDv4(s string) bool {
 	return true
 }
 
+// warnFlavorExtraSpecs warns when the flavor of a machine pool attaching
+// accelerated (SR-IOV, DPDK) ports lacks the extra specs those ports rely on:
+// hugepages, and CPU pinning for the control plane. Nova would otherwise
+// schedule the instances and the ports would fail to bind after boot.
+func warnFlavorExtraSpecs(p *openstack.MachinePool, flavor Flavor, controlPlane bool) {
+	if !hasAcceleratedPorts(p) || flavor.ExtraSpecs == nil {
+		return
+	}
+
+	switch pageSize := flavor.ExtraSpecs["hw:mem_page_size"]; pageSize {
+	case "", "small", "4", "4KB":
+		logrus.Warnf("Flavor %q does not request hugepages (hw:mem_page_size), which are required by the accelerated ports of the machine pool", flavor.Name)
+	}
+
+	if controlPlane && flavor.ExtraSpecs["hw:cpu_policy"] != "dedicated" {
+		logrus.Warnf("Flavor %q does not request CPU pinning (hw:cpu_policy=dedicated), which is recommended for control plane machines with accelerated ports", flavor.Name)
+	}
+}
+
+func hasAcceleratedPorts(p *openstack.MachinePool) bool {
+	for _, port := range p.AdditionalPorts {
+		if port.VNICType != "" && port.VNICType != "normal" {
+			return true
+		}
+	}
+	return false
+}
+
+// warnFlavorQuota warns when a single instance of the flavor does not fit in
+// the remaining compute quota of the project. The quota check for the whole
+// cluster is performed later, when the machines are known.
+func warnFlavorQuota(flavor Flavor, quotas []quota.Quota) {
+	for _, q := range quotas {
+		if q.Service != "compute" || q.Unlimited {
+			continue
+		}
+		var required int64
+		switch q.Name {
+		case "Cores":
+			required = int64(flavor.VCPUs)
+		case "RAM":
+			required = int64(flavor.RAM)
+		default:
+			continue
+		}
+		if available := q.Limit - q.InUse; required > available {
+			logrus.Warnf("Flavor %q requires %d %s but only %d are available in the project quota", flavor.Name, required, q.Name, available)
+		}
+	}
+}
+
 // validate flavor checks to make sure that a given flavor exists and meets the minimum requrement to run a cluster
 // this function does not validate proper install config usage
 func validateFlavor(flavorName string, ci *CloudInfo, req flavorRequirements, fldPath *field.Path, storage bool) field.ErrorList {
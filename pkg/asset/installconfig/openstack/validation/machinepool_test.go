@@ -3,11 +3,13 @@ package validation
 import (
 	"testing"
 
+	"github.com/gophercloud/gophercloud/openstack/common/extensions"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	logrusTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/quota"
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
@@ -407,6 +409,122 @@ func TestOpenStackMachinepoolValidation(t *testing.T) {
 			expectedError:  false,
 			expectedErrMsg: "",
 		},
+		{
+			name:         "valid additional ports",
+			controlPlane: false,
+			mpool: func() *openstack.MachinePool {
+				mp := validMachinePool()
+				mp.FlavorName = validComputeFlavor
+				mp.AdditionalPorts = []openstack.AdditionalPort{
+					{
+						NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11",
+						SubnetIDs: []string{"a6b5d1f0-3c8e-4e4b-9a57-2f4bdb8c1e42"},
+						VNICType:  "direct",
+						Trunk:     true,
+					},
+				}
+				return mp
+			}(),
+			cloudInfo: func() *CloudInfo {
+				ci := validMpoolCloudInfo()
+				ci.NetworkExtensions = []extensions.Extension{{Alias: "trunk"}}
+				return ci
+			}(),
+			expectedError:  false,
+			expectedErrMsg: "",
+		},
+		{
+			name:         "invalid additional port subnet ID",
+			controlPlane: false,
+			mpool: func() *openstack.MachinePool {
+				mp := validMachinePool()
+				mp.FlavorName = validComputeFlavor
+				mp.AdditionalPorts = []openstack.AdditionalPort{
+					{
+						NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11",
+						SubnetIDs: []string{"not-a-uuid"},
+					},
+				}
+				return mp
+			}(),
+			cloudInfo:      validMpoolCloudInfo(),
+			expectedError:  true,
+			expectedErrMsg: `compute\[0\].platform.openstack.additionalPorts\[0\].subnetIDs\[0\]: Invalid value: "not-a-uuid": valid UUID v4 must be specified`,
+		},
+		{
+			name:         "additional port trunk without trunk extension",
+			controlPlane: false,
+			mpool: func() *openstack.MachinePool {
+				mp := validMachinePool()
+				mp.FlavorName = validComputeFlavor
+				mp.AdditionalPorts = []openstack.AdditionalPort{
+					{
+						NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11",
+						Trunk:     true,
+					},
+				}
+				return mp
+			}(),
+			cloudInfo:      validMpoolCloudInfo(),
+			expectedError:  true,
+			expectedErrMsg: `compute\[0\].platform.openstack.additionalPorts\[0\].trunk: Invalid value: true: the trunk network extension is not available in this cloud`,
+		},
+		{
+			name:         "flavor without hugepages for accelerated ports",
+			controlPlane: false,
+			mpool: func() *openstack.MachinePool {
+				mp := validMachinePool()
+				mp.FlavorName = validComputeFlavor
+				mp.AdditionalPorts = []openstack.AdditionalPort{
+					{NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11", VNICType: "direct"},
+				}
+				return mp
+			}(),
+			cloudInfo: func() *CloudInfo {
+				ci := validMpoolCloudInfo()
+				flavor := ci.Flavors[validComputeFlavor]
+				flavor.ExtraSpecs = map[string]string{"hw:cpu_policy": "dedicated"}
+				ci.Flavors[validComputeFlavor] = flavor
+				return ci
+			}(),
+			expectedError:   false,
+			expectedWarnMsg: `Flavor "valid-compute-flavor" does not request hugepages \(hw:mem_page_size\), which are required by the accelerated ports of the machine pool`,
+		},
+		{
+			name:         "control plane flavor without CPU pinning for accelerated ports",
+			controlPlane: true,
+			mpool: func() *openstack.MachinePool {
+				mp := validMachinePool()
+				mp.AdditionalPorts = []openstack.AdditionalPort{
+					{NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11", VNICType: "direct"},
+				}
+				return mp
+			}(),
+			cloudInfo: func() *CloudInfo {
+				ci := validMpoolCloudInfo()
+				flavor := ci.Flavors[validCtrlPlaneFlavor]
+				flavor.ExtraSpecs = map[string]string{"hw:mem_page_size": "large"}
+				ci.Flavors[validCtrlPlaneFlavor] = flavor
+				return ci
+			}(),
+			expectedError:   false,
+			expectedWarnMsg: `Flavor "valid-control-plane-flavor" does not request CPU pinning \(hw:cpu_policy=dedicated\), which is recommended for control plane machines with accelerated ports`,
+		},
+		{
+			name:         "flavor exceeding the remaining quota",
+			controlPlane: true,
+			mpool:        validMachinePool(),
+			cloudInfo: func() *CloudInfo {
+				ci := validMpoolCloudInfo()
+				ci.Quotas = []quota.Quota{
+					{Service: "compute", Name: "Cores", InUse: 18, Limit: 20},
+					{Service: "compute", Name: "RAM", Unlimited: true},
+				}
+				return ci
+			}(),
+			expectedError:   false,
+			expectedWarnMsg: `Flavor "valid-control-plane-flavor" requires 4 Cores but only 2 are available in the project quota`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -7,6 +7,7 @@ import (
 	ovirtsdk "github.com/ovirt/go-ovirt"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -118,6 +119,98 @@ func validateClusterResources(con *ovirtsdk.Connection, ic *types.InstallConfig)
 	return nil
 }
 
+// affinityGroupsPolicyUnit is the name of the scheduling policy unit which
+// enforces VM affinity groups, both as a filter (hard rules) and as a weight
+// (soft rules).
+const affinityGroupsPolicyUnit = "VmAffinityGroups"
+
+// validateClusterSchedulingPolicy checks that the scheduling policy of the
+// cluster honors the affinity groups assigned to the machine pools. Enforcing
+// groups require the affinity filter, otherwise the engine silently ignores
+// them; non-enforcing groups only warn when the affinity weight is missing.
+func validateClusterSchedulingPolicy(con *ovirtsdk.Connection, ic *types.InstallConfig) error {
+	used := sets.New[string]()
+	if ic.ControlPlane != nil && ic.ControlPlane.Platform.Ovirt != nil {
+		used.Insert(ic.ControlPlane.Platform.Ovirt.AffinityGroupsNames...)
+	}
+	for _, compute := range ic.Compute {
+		if compute.Platform.Ovirt != nil {
+			used.Insert(compute.Platform.Ovirt.AffinityGroupsNames...)
+		}
+	}
+	var enforcing, soft []string
+	for _, ag := range ic.Ovirt.AffinityGroups {
+		if !used.Has(ag.Name) {
+			continue
+		}
+		if ag.Enforcing {
+			enforcing = append(enforcing, ag.Name)
+		} else {
+			soft = append(soft, ag.Name)
+		}
+	}
+	if len(enforcing) == 0 && len(soft) == 0 {
+		return nil
+	}
+
+	clusterResponse, err := con.SystemService().ClustersService().ClusterService(ic.Ovirt.ClusterID).Get().Send()
+	if err != nil {
+		return fmt.Errorf("failed to fetch cluster %s (%w)", ic.Ovirt.ClusterID, err)
+	}
+	cluster, ok := clusterResponse.Cluster()
+	if !ok {
+		return fmt.Errorf("failed to find cluster with id %s", ic.Ovirt.ClusterID)
+	}
+	schedulingPolicy, ok := cluster.SchedulingPolicy()
+	if !ok {
+		logrus.Warnf("Unable to determine the scheduling policy of cluster %s, skipping affinity groups validation", ic.Ovirt.ClusterID)
+		return nil
+	}
+	policyService := con.SystemService().SchedulingPoliciesService().PolicyService(schedulingPolicy.MustId())
+	policyResponse, err := policyService.Get().Send()
+	if err != nil {
+		return fmt.Errorf("failed to fetch scheduling policy %s (%w)", schedulingPolicy.MustId(), err)
+	}
+	policyName := policyResponse.MustPolicy().MustName()
+
+	if len(enforcing) > 0 {
+		filtersResponse, err := policyService.FiltersService().List().Follow("scheduling_policy_unit").Send()
+		if err != nil {
+			return fmt.Errorf("failed to list filters of scheduling policy %s (%w)", policyName, err)
+		}
+		found := false
+		for _, filter := range filtersResponse.MustFilters().Slice() {
+			if unit, ok := filter.SchedulingPolicyUnit(); ok && unit.MustName() == affinityGroupsPolicyUnit {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("enforcing affinity groups %v cannot be fulfilled, scheduling policy %s of cluster %s does not include the %s filter",
+				enforcing, policyName, ic.Ovirt.ClusterID, affinityGroupsPolicyUnit)
+		}
+	}
+
+	if len(soft) > 0 {
+		weightsResponse, err := policyService.WeightsService().List().Follow("scheduling_policy_unit").Send()
+		if err != nil {
+			return fmt.Errorf("failed to list weights of scheduling policy %s (%w)", policyName, err)
+		}
+		found := false
+		for _, weight := range weightsResponse.MustWeights().Slice() {
+			if unit, ok := weight.SchedulingPolicyUnit(); ok && unit.MustName() == affinityGroupsPolicyUnit {
+				found = true
+				break
+			}
+		}
+		if !found {
+			logrus.Warnf("Affinity groups %v will be ignored, scheduling policy %s of cluster %s does not include the %s weight",
+				soft, policyName, ic.Ovirt.ClusterID, affinityGroupsPolicyUnit)
+		}
+	}
+	return nil
+}
+
 func validateInstanceTypeID(con *ovirtsdk.Connection, child *field.Path, machinePool *ovirt.MachinePool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if machinePool.InstanceTypeID != "" {
@@ -229,5 +322,8 @@ func ValidateForProvisioning(ic *types.InstallConfig) error {
 	if err := validateExistingAffinityGroup(con, *ic.Ovirt); err != nil {
 		return err
 	}
+	if err := validateClusterSchedulingPolicy(con, ic); err != nil {
+		return err
+	}
 	return nil
 }
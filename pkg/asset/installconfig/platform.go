@@ -1,6 +1,7 @@
 package installconfig
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -39,6 +40,10 @@ import (
 // the cluster.
 type platform struct {
 	types.Platform
+
+	// machineNetwork holds the networks of the existing network chosen for
+	// the cluster, when the platform offers one.
+	machineNetwork []types.MachineNetworkEntry
 }
 
 var _ asset.Asset = (*platform)(nil)
@@ -49,7 +54,7 @@ func (a *platform) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *platform) Generate(asset.Parents) error {
+func (a *platform) Generate(context.Context, asset.Parents) error {
 	platform, err := a.queryUserForPlatform()
 	if err != nil {
 		return err
@@ -57,12 +62,12 @@ func (a *platform) Generate(asset.Parents) error {
 
 	switch platform {
 	case aws.Name:
-		a.AWS, err = awsconfig.Platform()
+		a.AWS, a.machineNetwork, err = awsconfig.Platform()
 		if err != nil {
 			return err
 		}
 	case azure.Name:
-		a.Azure, err = azureconfig.Platform()
+		a.Azure, a.machineNetwork, err = azureconfig.Platform()
 		if err != nil {
 			return err
 		}
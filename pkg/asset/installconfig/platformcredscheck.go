@@ -3,6 +3,7 @@ package installconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -35,6 +36,7 @@ type PlatformCredsCheck struct {
 }
 
 var _ asset.Asset = (*PlatformCredsCheck)(nil)
+var _ asset.TimedAsset = (*PlatformCredsCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformCredsCheck
 func (a *PlatformCredsCheck) Dependencies() []asset.Asset {
@@ -44,8 +46,7 @@ func (a *PlatformCredsCheck) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
-	ctx := context.TODO()
+func (a *PlatformCredsCheck) Generate(ctx context.Context, dependencies asset.Parents) error {
 	ic := &InstallConfig{}
 	dependencies.Get(ic)
 
@@ -58,7 +59,7 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 	case gcp.Name:
-		client, err := gcpconfig.NewClient(context.TODO())
+		client, err := gcpconfig.NewClient(ctx, ic.Config.GCP.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -113,6 +114,11 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 	return err
 }
 
+// GenerateTimeout returns how long the credentials may take to be checked.
+func (a *PlatformCredsCheck) GenerateTimeout() time.Duration {
+	return 5 * time.Minute
+}
+
 // Name returns the human-friendly name of the asset.
 func (a *PlatformCredsCheck) Name() string {
 	return "Platform Credentials Check"
@@ -3,6 +3,7 @@ package installconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -32,6 +33,7 @@ type PlatformPermsCheck struct {
 }
 
 var _ asset.Asset = (*PlatformPermsCheck)(nil)
+var _ asset.TimedAsset = (*PlatformPermsCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformPermsCheck
 func (a *PlatformPermsCheck) Dependencies() []asset.Asset {
@@ -41,8 +43,7 @@ func (a *PlatformPermsCheck) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
-	ctx := context.TODO()
+func (a *PlatformPermsCheck) Generate(ctx context.Context, dependencies asset.Parents) error {
 	ic := &InstallConfig{}
 	dependencies.Get(ic)
 
@@ -79,8 +80,9 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 			ic.Config.ControlPlane.Platform.AWS.EC2RootVolume != ec2RootVolume {
 			masterMachinePoolUsingKMS = len(ic.Config.ControlPlane.Platform.AWS.EC2RootVolume.KMSKeyARN) != 0
 		}
+		etcdUsingKMS := ic.Config.EtcdEncryption != nil && ic.Config.EtcdEncryption.Type == types.EtcdEncryptionTypeKMS
 		// Add KMS encryption keys, if provided.
-		if awsMachinePoolUsingKMS || masterMachinePoolUsingKMS {
+		if awsMachinePoolUsingKMS || masterMachinePoolUsingKMS || etcdUsingKMS {
 			logrus.Debugf("Adding %s to the group of permissions to validate", awsconfig.PermissionKMSEncryptionKeys)
 			permissionGroups = append(permissionGroups, awsconfig.PermissionKMSEncryptionKeys)
 		}
@@ -102,6 +104,10 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 			permissionGroups = append(permissionGroups, awsconfig.PermissionPublicIpv4Pool)
 		}
 
+		if ic.Config.BootstrapIgnitionDelivery() == types.BootstrapIgnitionDeliveryVPCEndpoint {
+			permissionGroups = append(permissionGroups, awsconfig.PermissionBootstrapIgnitionVPCEndpoint)
+		}
+
 		ssn, err := ic.AWS.Session(ctx)
 		if err != nil {
 			return err
@@ -112,7 +118,7 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "validate AWS credentials")
 		}
 	case gcp.Name:
-		client, err := gcpconfig.NewClient(context.TODO())
+		client, err := gcpconfig.NewClient(ctx, ic.Config.GCP.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
@@ -132,6 +138,11 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 	return err
 }
 
+// GenerateTimeout returns how long the permissions may take to be checked.
+func (a *PlatformPermsCheck) GenerateTimeout() time.Duration {
+	return 5 * time.Minute
+}
+
 // Name returns the human-friendly name of the asset.
 func (a *PlatformPermsCheck) Name() string {
 	return "Platform Permissions Check"
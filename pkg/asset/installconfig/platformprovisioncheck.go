@@ -4,8 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
-	"k8s.io/apimachinery/pkg/util/sets"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset"
@@ -40,6 +39,7 @@ type PlatformProvisionCheck struct {
 }
 
 var _ asset.Asset = (*PlatformProvisionCheck)(nil)
+var _ asset.TimedAsset = (*PlatformProvisionCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformProvisionCheck
 func (a *PlatformProvisionCheck) Dependencies() []asset.Asset {
@@ -49,45 +49,30 @@ func (a *PlatformProvisionCheck) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
+func (a *PlatformProvisionCheck) Generate(ctx context.Context, dependencies asset.Parents) error {
 	ic := &InstallConfig{}
 	dependencies.Get(ic)
 	platform := ic.Config.Platform.Name()
 
 	// IPI requires MachineAPI capability
-	enabledCaps := sets.NewString()
-	if ic.Config.Capabilities == nil || ic.Config.Capabilities.BaselineCapabilitySet == "" {
-		// when Capabilities and/or BaselineCapabilitySet is not specified, default is vCurrent
-		baseSet := configv1.ClusterVersionCapabilitySets[configv1.ClusterVersionCapabilitySetCurrent]
-		for _, cap := range baseSet {
-			enabledCaps.Insert(string(cap))
-		}
-	}
-	if ic.Config.Capabilities != nil {
-		if ic.Config.Capabilities.BaselineCapabilitySet != "" {
-			baseSet := configv1.ClusterVersionCapabilitySets[ic.Config.Capabilities.BaselineCapabilitySet]
-			for _, cap := range baseSet {
-				enabledCaps.Insert(string(cap))
-			}
-		}
-		if ic.Config.Capabilities.AdditionalEnabledCapabilities != nil {
-			for _, cap := range ic.Config.Capabilities.AdditionalEnabledCapabilities {
-				enabledCaps.Insert(string(cap))
-			}
-		}
-	}
-	if !enabledCaps.Has(string(configv1.ClusterVersionCapabilityMachineAPI)) {
+	if !ic.Config.EnabledCapabilities().Has(configv1.ClusterVersionCapabilityMachineAPI) {
 		return errors.New("IPI requires MachineAPI capability")
 	}
 
+	if len(ic.Config.AdditionalNTPServers) > 0 {
+		warnUnreachableNTPServers(ic.Config.AdditionalNTPServers)
+	}
+
 	switch platform {
 	case aws.Name:
-		session, err := ic.AWS.Session(context.TODO())
+		session, err := ic.AWS.Session(ctx)
 		if err != nil {
 			return err
 		}
 		client := awsconfig.NewClient(session)
-		return awsconfig.ValidateForProvisioning(client, ic.Config, ic.AWS)
+		if err := awsconfig.ValidateForProvisioning(ctx, client, ic.Config, ic.AWS); err != nil {
+			return err
+		}
 	case azure.Name:
 		dnsConfig, err := ic.Azure.DNSConfig()
 		if err != nil {
@@ -101,7 +86,9 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return err
 		}
-		return azconfig.ValidateForProvisioning(client, ic.Config)
+		if err := azconfig.ValidateForProvisioning(client, ic.Config); err != nil {
+			return err
+		}
 	case baremetal.Name:
 		err := bmconfig.ValidateBaremetalPlatformSet(ic.Config)
 		if err != nil {
@@ -116,7 +103,7 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 	case gcp.Name:
-		err := gcpconfig.ValidateForProvisioning(ic.Config)
+		err := gcpconfig.ValidateForProvisioning(ctx, ic.Config)
 		if err != nil {
 			return err
 		}
@@ -150,22 +137,22 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 
-		err = powervsconfig.ValidatePERAvailability(client, ic.Config)
+		err = powervsconfig.ValidatePERAvailability(ctx, client, ic.Config)
 		if err != nil {
 			return err
 		}
 
-		err = powervsconfig.ValidatePreExistingDNS(client, ic.Config, ic.PowerVS)
+		err = powervsconfig.ValidatePreExistingDNS(ctx, client, ic.Config, ic.PowerVS)
 		if err != nil {
 			return err
 		}
 
-		err = powervsconfig.ValidateCustomVPCSetup(client, ic.Config)
+		err = powervsconfig.ValidateCustomVPCSetup(ctx, client, ic.Config)
 		if err != nil {
 			return err
 		}
 
-		err = powervsconfig.ValidateResourceGroup(client, ic.Config)
+		err = powervsconfig.ValidateResourceGroup(ctx, client, ic.Config)
 		if err != nil {
 			return err
 		}
@@ -175,7 +162,7 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 
-		err = powervsconfig.ValidateServiceInstance(client, ic.Config)
+		err = powervsconfig.ValidateServiceInstance(ctx, client, ic.Config)
 		if err != nil {
 			return err
 		}
@@ -189,7 +176,15 @@ func (a *PlatformProvisionCheck) Generate(dependencies asset.Parents) error {
 	default:
 		return fmt.Errorf("unknown platform type %q", platform)
 	}
-	return nil
+
+	return validatePublicZoneDelegation(ctx, ic)
+}
+
+// GenerateTimeout returns how long the platform may take to be checked.
+// The checks of some platforms list many resources, so they get more time
+// than the credentials and permissions checks.
+func (a *PlatformProvisionCheck) GenerateTimeout() time.Duration {
+	return 10 * time.Minute
 }
 
 // Name returns the human-friendly name of the asset.
@@ -50,8 +50,8 @@ func validateMachinePool(fldPath *field.Path, machinePool *types.MachinePool) fi
 }
 
 // ValidatePERAvailability ensures the target datacenter has PER enabled.
-func ValidatePERAvailability(client API, ic *types.InstallConfig) error {
-	capabilities, err := client.GetDatacenterCapabilities(context.TODO(), ic.PowerVS.Zone)
+func ValidatePERAvailability(ctx context.Context, client API, ic *types.InstallConfig) error {
+	capabilities, err := client.GetDatacenterCapabilities(ctx, ic.PowerVS.Zone)
 	if err != nil {
 		return err
 	}
@@ -69,29 +69,29 @@ func ValidatePERAvailability(client API, ic *types.InstallConfig) error {
 
 // ValidatePreExistingDNS ensures no pre-existing DNS record exists in the CIS
 // DNS zone or IBM DNS zone for cluster's Kubernetes API.
-func ValidatePreExistingDNS(client API, ic *types.InstallConfig, metadata MetadataAPI) error {
+func ValidatePreExistingDNS(ctx context.Context, client API, ic *types.InstallConfig, metadata MetadataAPI) error {
 	allErrs := field.ErrorList{}
 
 	fldPath := field.NewPath("baseDomain")
 	if ic.Publish == types.ExternalPublishingStrategy {
-		allErrs = append(allErrs, validatePreExistingPublicDNS(fldPath, client, ic, metadata)...)
+		allErrs = append(allErrs, validatePreExistingPublicDNS(ctx, fldPath, client, ic, metadata)...)
 	} else {
-		allErrs = append(allErrs, validatePreExistingPrivateDNS(fldPath, client, ic, metadata)...)
+		allErrs = append(allErrs, validatePreExistingPrivateDNS(ctx, fldPath, client, ic, metadata)...)
 	}
 
 	return allErrs.ToAggregate()
 }
 
-func validatePreExistingPublicDNS(fldPath *field.Path, client API, ic *types.InstallConfig, metadata MetadataAPI) field.ErrorList {
+func validatePreExistingPublicDNS(ctx context.Context, fldPath *field.Path, client API, ic *types.InstallConfig, metadata MetadataAPI) field.ErrorList {
 	allErrs := field.ErrorList{}
 	// Get CIS CRN
-	crn, err := metadata.CISInstanceCRN(context.TODO())
+	crn, err := metadata.CISInstanceCRN(ctx)
 	if err != nil {
 		return append(allErrs, field.InternalError(fldPath, err))
 	}
 
 	// Get CIS zone ID by name
-	zoneID, err := client.GetDNSZoneIDByName(context.TODO(), ic.BaseDomain, types.ExternalPublishingStrategy)
+	zoneID, err := client.GetDNSZoneIDByName(ctx, ic.BaseDomain, types.ExternalPublishingStrategy)
 	if err != nil {
 		return append(allErrs, field.InternalError(fldPath, err))
 	}
@@ -99,7 +99,7 @@ func validatePreExistingPublicDNS(fldPath *field.Path, client API, ic *types.Ins
 	// Search for existing records
 	recordNames := [...]string{fmt.Sprintf("api.%s", ic.ClusterDomain()), fmt.Sprintf("api-int.%s", ic.ClusterDomain())}
 	for _, recordName := range recordNames {
-		records, err := client.GetDNSRecordsByName(context.TODO(), crn, zoneID, recordName, types.ExternalPublishingStrategy)
+		records, err := client.GetDNSRecordsByName(ctx, crn, zoneID, recordName, types.ExternalPublishingStrategy)
 		if err != nil {
 			allErrs = append(allErrs, field.InternalError(fldPath, err))
 		}
@@ -112,16 +112,16 @@ func validatePreExistingPublicDNS(fldPath *field.Path, client API, ic *types.Ins
 	return allErrs
 }
 
-func validatePreExistingPrivateDNS(fldPath *field.Path, client API, ic *types.InstallConfig, metadata MetadataAPI) field.ErrorList {
+func validatePreExistingPrivateDNS(ctx context.Context, fldPath *field.Path, client API, ic *types.InstallConfig, metadata MetadataAPI) field.ErrorList {
 	allErrs := field.ErrorList{}
 	// Get DNS CRN
-	crn, err := metadata.DNSInstanceCRN(context.TODO())
+	crn, err := metadata.DNSInstanceCRN(ctx)
 	if err != nil {
 		return append(allErrs, field.InternalError(fldPath, err))
 	}
 
 	// Get CIS zone ID by name
-	zoneID, err := client.GetDNSZoneIDByName(context.TODO(), ic.BaseDomain, types.InternalPublishingStrategy)
+	zoneID, err := client.GetDNSZoneIDByName(ctx, ic.BaseDomain, types.InternalPublishingStrategy)
 	if err != nil {
 		return append(allErrs, field.InternalError(fldPath, err))
 	}
@@ -129,7 +129,7 @@ func validatePreExistingPrivateDNS(fldPath *field.Path, client API, ic *types.In
 	// Search for existing records
 	recordNames := [...]string{fmt.Sprintf("api-int.%s", ic.ClusterDomain())}
 	for _, recordName := range recordNames {
-		records, err := client.GetDNSRecordsByName(context.TODO(), crn, zoneID, recordName, types.InternalPublishingStrategy)
+		records, err := client.GetDNSRecordsByName(ctx, crn, zoneID, recordName, types.InternalPublishingStrategy)
 		if err != nil {
 			allErrs = append(allErrs, field.InternalError(fldPath, err))
 		}
@@ -143,7 +143,7 @@ func validatePreExistingPrivateDNS(fldPath *field.Path, client API, ic *types.In
 }
 
 // ValidateCustomVPCSetup ensures optional VPC settings, if specified, are all legit.
-func ValidateCustomVPCSetup(client API, ic *types.InstallConfig) error {
+func ValidateCustomVPCSetup(ctx context.Context, client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 	var vpcRegion = ic.PowerVS.VPCRegion
 	var vpcName = ic.PowerVS.VPCName
@@ -162,8 +162,8 @@ func ValidateCustomVPCSetup(client API, ic *types.InstallConfig) error {
 	}
 
 	if vpcName != "" {
-		allErrs = append(allErrs, findVPCInRegion(client, vpcName, vpcRegion, fldPath)...)
-		allErrs = append(allErrs, findSubnetInVPC(client, ic.PowerVS.VPCSubnets, vpcRegion, vpcName, fldPath)...)
+		allErrs = append(allErrs, findVPCInRegion(ctx, client, vpcName, vpcRegion, fldPath)...)
+		allErrs = append(allErrs, findSubnetInVPC(ctx, client, ic.PowerVS.VPCSubnets, vpcRegion, vpcName, fldPath)...)
 	} else if len(ic.PowerVS.VPCSubnets) != 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcSubnets"), nil, "invalid without vpcName"))
 	}
@@ -171,14 +171,14 @@ func ValidateCustomVPCSetup(client API, ic *types.InstallConfig) error {
 	return allErrs.ToAggregate()
 }
 
-func findVPCInRegion(client API, name string, region string, path *field.Path) field.ErrorList {
+func findVPCInRegion(ctx context.Context, client API, name string, region string, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if name == "" {
 		return allErrs
 	}
 
-	vpcs, err := client.GetVPCs(context.TODO(), region)
+	vpcs, err := client.GetVPCs(ctx, region)
 	if err != nil {
 		return append(allErrs, field.InternalError(path.Child("vpcRegion"), err))
 	}
@@ -197,14 +197,14 @@ func findVPCInRegion(client API, name string, region string, path *field.Path) f
 	return allErrs
 }
 
-func findSubnetInVPC(client API, subnets []string, region string, name string, path *field.Path) field.ErrorList {
+func findSubnetInVPC(ctx context.Context, client API, subnets []string, region string, name string, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if len(subnets) == 0 {
 		return allErrs
 	}
 
-	subnet, err := client.GetSubnetByName(context.TODO(), subnets[0], region)
+	subnet, err := client.GetSubnetByName(ctx, subnets[0], region)
 	if err != nil {
 		allErrs = append(allErrs, field.InternalError(path.Child("vpcSubnets"), err))
 	} else if *subnet.VPC.Name != name {
@@ -215,8 +215,8 @@ func findSubnetInVPC(client API, subnets []string, region string, name string, p
 }
 
 // ValidateResourceGroup validates the resource group in our install config.
-func ValidateResourceGroup(client API, ic *types.InstallConfig) error {
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+func ValidateResourceGroup(ctx context.Context, client API, ic *types.InstallConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	resourceGroups, err := client.ListResourceGroups(ctx)
@@ -279,8 +279,8 @@ func ValidateSystemTypeForRegion(client API, ic *types.InstallConfig) error {
 }
 
 // ValidateServiceInstance validates the optional service instance GUID in our install config.
-func ValidateServiceInstance(client API, ic *types.InstallConfig) error {
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+func ValidateServiceInstance(ctx context.Context, client API, ic *types.InstallConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	serviceInstances, err := client.ListServiceInstances(ctx)
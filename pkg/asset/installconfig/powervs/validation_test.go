@@ -1,6 +1,7 @@
 package powervs_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -282,7 +283,7 @@ func TestValidatePreExistingPublicDNS(t *testing.T) {
 	// Run tests
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			aggregatedErrors := powervs.ValidatePreExistingDNS(powervsClient, validInstallConfig(), metadata)
+			aggregatedErrors := powervs.ValidatePreExistingDNS(context.TODO(), powervsClient, validInstallConfig(), metadata)
 			if tc.errorMsg != "" {
 				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
 			} else {
@@ -439,7 +440,7 @@ func TestValidateCustomVPCSettings(t *testing.T) {
 				edit(editedInstallConfig)
 			}
 
-			aggregatedErrors := powervs.ValidateCustomVPCSetup(powervsClient, editedInstallConfig)
+			aggregatedErrors := powervs.ValidateCustomVPCSetup(context.TODO(), powervsClient, editedInstallConfig)
 			if tc.errorMsg != "" {
 				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
 			} else {
@@ -551,7 +552,7 @@ func TestValidatePERAvailability(t *testing.T) {
 				edit(editedInstallConfig)
 			}
 
-			aggregatedErrors := powervs.ValidatePERAvailability(powervsClient, editedInstallConfig)
+			aggregatedErrors := powervs.ValidatePERAvailability(context.TODO(), powervsClient, editedInstallConfig)
 			if tc.errorMsg != "" {
 				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
 			} else {
@@ -683,7 +684,7 @@ func TestValidateServiceInstance(t *testing.T) {
 				edit(editedInstallConfig)
 			}
 
-			aggregatedErrors := powervs.ValidateServiceInstance(powervsClient, editedInstallConfig)
+			aggregatedErrors := powervs.ValidateServiceInstance(context.TODO(), powervsClient, editedInstallConfig)
 			if tc.errorMsg != "" {
 				assert.Regexp(t, tc.errorMsg, aggregatedErrors)
 			} else {
@@ -1,6 +1,9 @@
 package installconfig
 
 import (
+	"context"
+	"os"
+
 	survey "github.com/AlecAivazis/survey/v2"
 	"github.com/pkg/errors"
 
@@ -20,7 +23,15 @@ func (a *pullSecret) Dependencies() []asset.Asset {
 }
 
 // Generate queries for the pull secret from the user.
-func (a *pullSecret) Generate(asset.Parents) error {
+func (a *pullSecret) Generate(context.Context, asset.Parents) error {
+	if ps := os.Getenv(pullSecretEnvVar); ps != "" {
+		if err := validate.ImagePullSecret(ps); err != nil {
+			return errors.Wrapf(err, "invalid pull secret in %s", pullSecretEnvVar)
+		}
+		a.PullSecret = ps
+		return nil
+	}
+
 	if err := survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Password{
@@ -1,6 +1,7 @@
 package installconfig
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -46,7 +47,15 @@ func readSSHKey(path string) (string, error) {
 }
 
 // Generate generates the SSH public key asset.
-func (a *sshPublicKey) Generate(asset.Parents) error {
+func (a *sshPublicKey) Generate(context.Context, asset.Parents) error {
+	if key := os.Getenv(sshKeyEnvVar); key != "" {
+		if err := validate.SSHPublicKey(key); err != nil {
+			return errors.Wrapf(err, "invalid SSH public key in %s", sshKeyEnvVar)
+		}
+		a.Key = key
+		return nil
+	}
+
 	pubKeys := map[string]string{
 		noSSHKey: "",
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/vmware/govmomi/vim25/soap"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
 
+	"github.com/openshift/installer/pkg/credentialsource"
 	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
@@ -57,6 +58,36 @@ func NewMetadata() *Metadata {
 	}
 }
 
+// PasswordsFromSource sets the passwords of the vCenters that have none from
+// the credential source configured in the environment. The password of a
+// vCenter is the value named after its server or, failing that, the password
+// value.
+func PasswordsFromSource(ctx context.Context, vcenters []vsphere.VCenter) error {
+	missing := false
+	for _, v := range vcenters {
+		missing = missing || v.Password == ""
+	}
+	if !missing {
+		return nil
+	}
+
+	values, err := credentialsource.Fetch(ctx, vsphere.Name)
+	if err != nil || values == nil {
+		return err
+	}
+	for i := range vcenters {
+		if vcenters[i].Password != "" {
+			continue
+		}
+		if password, ok := values[vcenters[i].Server]; ok {
+			vcenters[i].Password = password
+		} else {
+			vcenters[i].Password = values["password"]
+		}
+	}
+	return nil
+}
+
 // AddCredentials creates a session param from the vCenter server, username and password
 // to the Credentials Map.
 func (m *Metadata) AddCredentials(server, username, password string) *session.Params {
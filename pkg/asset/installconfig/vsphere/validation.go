@@ -476,8 +476,8 @@ func ensureDNS(installConfig *types.InstallConfig, fldPath *field.Path, resolver
 	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer cancel()
 
-	uris = append(uris, fmt.Sprintf("api.%s", installConfig.ClusterDomain()))
-	uris = append(uris, fmt.Sprintf("api-int.%s", installConfig.ClusterDomain()))
+	uris = append(uris, installConfig.APIHostname())
+	uris = append(uris, installConfig.APIInternalHostname())
 
 	if resolver == nil {
 		resolver = &net.Resolver{
@@ -503,7 +503,7 @@ func ensureLoadBalancer(installConfig *types.InstallConfig) {
 	dialTimeout := time.Second
 	tcpTimeout := time.Second * 10
 	errorCount := 0
-	apiURIPort := fmt.Sprintf("api.%s:%s", installConfig.ClusterDomain(), "6443")
+	apiURIPort := net.JoinHostPort(installConfig.APIHostname(), strconv.Itoa(int(installConfig.APIPort())))
 	tcpContext, cancel := context.WithTimeout(context.TODO(), tcpTimeout)
 	defer cancel()
 
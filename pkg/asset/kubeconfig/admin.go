@@ -1,6 +1,7 @@
 package kubeconfig
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -29,7 +30,7 @@ func (k *AdminClient) Dependencies() []asset.Asset {
 }
 
 // Generate generates the kubeconfig.
-func (k *AdminClient) Generate(parents asset.Parents) error {
+func (k *AdminClient) Generate(_ context.Context, parents asset.Parents) error {
 	ca := &tls.KubeAPIServerCompleteCABundle{}
 	clientCertKey := &tls.AdminKubeConfigClientCertKey{}
 	installConfig := &installconfig.InstallConfig{}
@@ -45,6 +46,12 @@ func (k *AdminClient) Generate(parents asset.Parents) error {
 	)
 }
 
+// ReplaceClientCertKey replaces the client cert/key pair of the kubeconfig,
+// for example with one regenerated after the generated one expired.
+func (k *AdminClient) ReplaceClientCertKey(clientCertKey tls.CertKeyInterface) error {
+	return k.replaceClientCertKey(clientCertKey, "admin")
+}
+
 // Name returns the human-friendly name of the asset.
 func (k *AdminClient) Name() string {
 	return "Kubeconfig Admin Client"
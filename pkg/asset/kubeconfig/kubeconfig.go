@@ -2,7 +2,9 @@ package kubeconfig
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 
 	"github.com/pkg/errors"
 	clientcmd "k8s.io/client-go/tools/clientcmd/api/v1"
@@ -71,6 +73,39 @@ func (k *kubeconfig) generate(
 	return nil
 }
 
+// replaceClientCertKey replaces the client cert/key pair of the user in the
+// kubeconfig.
+func (k *kubeconfig) replaceClientCertKey(clientCertKey tls.CertKeyInterface, userName string) error {
+	if k.Config == nil || k.File == nil {
+		return errors.New("no kubeconfig to update")
+	}
+
+	found := false
+	for i := range k.Config.AuthInfos {
+		if k.Config.AuthInfos[i].Name != userName {
+			continue
+		}
+		authInfo := &k.Config.AuthInfos[i].AuthInfo
+		authInfo.ClientCertificate, authInfo.ClientKey = "", ""
+		authInfo.ClientCertificateData = clientCertKey.Cert()
+		authInfo.ClientKeyData = clientCertKey.Key()
+		found = true
+	}
+	if !found {
+		return errors.Errorf("user %q not found in %s", userName, k.File.Filename)
+	}
+
+	data, err := yaml.Marshal(k.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to Marshal kubeconfig")
+	}
+	k.File = &asset.File{
+		Filename: k.File.Filename,
+		Data:     data,
+	}
+	return nil
+}
+
 // Files returns the files generated by the asset.
 func (k *kubeconfig) Files() []*asset.File {
 	if k.File != nil {
@@ -99,11 +134,11 @@ func (k *kubeconfig) load(f asset.FileFetcher, name string) (found bool, err err
 }
 
 func getExtAPIServerURL(ic *types.InstallConfig) string {
-	return fmt.Sprintf("https://api.%s:6443", ic.ClusterDomain())
+	return fmt.Sprintf("https://%s", net.JoinHostPort(ic.APIHostname(), strconv.Itoa(int(ic.APIPort()))))
 }
 
 func getIntAPIServerURL(ic *types.InstallConfig) string {
-	return fmt.Sprintf("https://api-int.%s:6443", ic.ClusterDomain())
+	return fmt.Sprintf("https://%s", net.JoinHostPort(ic.APIInternalHostname(), strconv.Itoa(int(ic.APIPort()))))
 }
 
 func getLoopbackAPIServerURL(ic *types.InstallConfig) string {
@@ -119,3 +119,50 @@ users:
 	}
 
 }
+
+func TestKubeconfigReplaceClientCertKey(t *testing.T) {
+	rootCA := &testCertKey{
+		key:  "THIS IS ROOT CA KEY DATA",
+		cert: "THIS IS ROOT CA CERT DATA",
+	}
+
+	adminCert := &testCertKey{
+		key:  "THIS IS ADMIN KEY DATA",
+		cert: "THIS IS ADMIN CERT DATA",
+	}
+
+	regeneratedCert := &testCertKey{
+		key:  "THIS IS REGENERATED KEY DATA",
+		cert: "THIS IS REGENERATED CERT DATA",
+	}
+
+	kc := &kubeconfig{}
+	err := kc.generate(rootCA, adminCert, "https://api.test-cluster-name.test.example.com:6443", "test-cluster-name", "admin", "auth/kubeconfig")
+	if !assert.NoError(t, err, "unexpected error generating config") {
+		return
+	}
+
+	assert.EqualError(t, kc.replaceClientCertKey(regeneratedCert, "kubelet"), `user "kubelet" not found in auth/kubeconfig`)
+
+	if assert.NoError(t, kc.replaceClientCertKey(regeneratedCert, "admin")) {
+		assert.Equal(t, "auth/kubeconfig", kc.File.Filename, "unexpected file name")
+		assert.Equal(t, []byte(`clusters:
+- cluster:
+    certificate-authority-data: VEhJUyBJUyBST09UIENBIENFUlQgREFUQQ==
+    server: https://api.test-cluster-name.test.example.com:6443
+  name: test-cluster-name
+contexts:
+- context:
+    cluster: test-cluster-name
+    user: admin
+  name: admin
+current-context: admin
+preferences: {}
+users:
+- name: admin
+  user:
+    client-certificate-data: VEhJUyBJUyBSRUdFTkVSQVRFRCBDRVJUIERBVEE=
+    client-key-data: VEhJUyBJUyBSRUdFTkVSQVRFRCBLRVkgREFUQQ==
+`), kc.File.Data, "unexpected config")
+	}
+}
@@ -95,6 +95,7 @@ func GenerateMachines(clusterID string, in *MachineInput) ([]*asset.RuntimeFile,
 					HTTPTokens:   capa.HTTPTokensState(mpool.EC2Metadata.Authentication),
 					HTTPEndpoint: capa.InstanceMetadataEndpointStateEnabled,
 				},
+				Tenancy: string(mpool.Tenancy),
 			},
 		}
 		awsMachine.SetGroupVersionKind(capa.GroupVersion.WithKind("AWSMachine"))
@@ -102,6 +103,11 @@ func GenerateMachines(clusterID string, in *MachineInput) ([]*asset.RuntimeFile,
 		if in.Role == "bootstrap" {
 			awsMachine.Name = capiutils.GenerateBoostrapMachineName(clusterID)
 			awsMachine.Labels["install.openshift.io/bootstrap"] = ""
+			// The capacity of dedicated hosts is reserved for the control plane, the
+			// bootstrap machine still runs on single-tenant hardware.
+			if mpool.Tenancy == aws.HostTenancy {
+				awsMachine.Spec.Tenancy = string(aws.DedicatedTenancy)
+			}
 		}
 
 		// Handle additional security groups.
@@ -30,6 +30,8 @@ type machineProviderInput struct {
 	userDataSecret   string
 	root             *aws.EC2RootVolume
 	imds             aws.EC2Metadata
+	tenancy          aws.Tenancy
+	spot             *aws.SpotMarketOptions
 	userTags         map[string]string
 	publicSubnet     bool
 	securityGroupIDs []string
@@ -65,6 +67,7 @@ func Machines(clusterID string, region string, subnets map[string]string, pool *
 			userDataSecret:   userDataSecret,
 			root:             &mpool.EC2RootVolume,
 			imds:             mpool.EC2Metadata,
+			tenancy:          mpool.Tenancy,
 			userTags:         userTags,
 			publicSubnet:     false,
 			securityGroupIDs: pool.Platform.AWS.AdditionalSecurityGroupIDs,
@@ -247,7 +250,7 @@ func provider(in *machineProviderInput) (*machineapi.AWSMachineProviderConfig, e
 		},
 		UserDataSecret:    &corev1.LocalObjectReference{Name: in.userDataSecret},
 		CredentialsSecret: &corev1.LocalObjectReference{Name: "aws-cloud-credentials"},
-		Placement:         machineapi.Placement{Region: in.region, AvailabilityZone: in.zone},
+		Placement:         machineapi.Placement{Region: in.region, AvailabilityZone: in.zone, Tenancy: machineapi.InstanceTenancy(in.tenancy)},
 		SecurityGroups:    securityGroups,
 	}
 
@@ -286,6 +289,13 @@ func provider(in *machineProviderInput) (*machineapi.AWSMachineProviderConfig, e
 		config.MetadataServiceOptions.Authentication = machineapi.MetadataServiceAuthentication(in.imds.Authentication)
 	}
 
+	if in.spot != nil {
+		config.SpotMarketOptions = &machineapi.SpotMarketOptions{}
+		if in.spot.MaxPrice != "" {
+			config.SpotMarketOptions.MaxPrice = pointer.String(in.spot.MaxPrice)
+		}
+	}
+
 	return config, nil
 }
 
@@ -92,6 +92,8 @@ func MachineSets(in *MachineSetInput) ([]*machineapi.MachineSet, error) {
 			userDataSecret:   in.UserDataSecret,
 			root:             &mpool.EC2RootVolume,
 			imds:             mpool.EC2Metadata,
+			tenancy:          mpool.Tenancy,
+			spot:             mpool.SpotMarketOptions,
 			userTags:         in.InstallConfigPlatformAWS.UserTags,
 			publicSubnet:     publicSubnet,
 			securityGroupIDs: in.Pool.Platform.AWS.AdditionalSecurityGroupIDs,
@@ -134,6 +134,14 @@ func GenerateMachines(platform *azure.Platform, pool *types.MachinePool, userDat
 				SecurityProfile:        securityProfile,
 			},
 		}
+		if pool.EtcdDisk != nil {
+			azureMachine.Spec.DataDisks = []capz.DataDisk{{
+				NameSuffix:  "etcd",
+				DiskSizeGB:  pool.EtcdDisk.DiskSizeGB,
+				Lun:         ptr.To(azure.EtcdDiskLun),
+				CachingType: "None",
+			}}
+		}
 		azureMachine.SetGroupVersionKind(capz.GroupVersion.WithKind("AzureMachine"))
 		result = append(result, &asset.RuntimeFile{
 			File:   asset.File{Filename: fmt.Sprintf("10_inframachine_%s.yaml", azureMachine.Name)},
@@ -58,6 +58,15 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to create provider")
 		}
+		if pool.EtcdDisk != nil {
+			provider.DataDisks = append(provider.DataDisks, machineapi.DataDisk{
+				NameSuffix:     "etcd",
+				DiskSizeGB:     pool.EtcdDisk.DiskSizeGB,
+				Lun:            azure.EtcdDiskLun,
+				CachingType:    machineapi.CachingTypeNone,
+				DeletionPolicy: machineapi.DiskDeletionPolicyTypeDelete,
+			})
+		}
 		machine := machineapi.Machine{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "machine.openshift.io/v1beta1",
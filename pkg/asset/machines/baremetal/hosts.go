@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
@@ -78,6 +79,36 @@ func createSecret(host *baremetal.Host) (*corev1.Secret, baremetalhost.BMCDetail
 	return nil, bmc
 }
 
+// createReferencedSecret returns a copy of the user-provided Secret holding
+// the credentials referenced by the host BMC, placed in the namespace of the
+// BareMetalHosts.
+func createReferencedSecret(host *baremetal.Host, credentials map[string]*corev1.Secret) (*corev1.Secret, baremetalhost.BMCDetails, error) {
+	provided, ok := credentials[host.BMC.CredentialsName]
+	if !ok {
+		return nil, baremetalhost.BMCDetails{}, fmt.Errorf("BMC credentials secret %q referenced by host %s was not provided", host.BMC.CredentialsName, host.Name)
+	}
+	for _, key := range []string{"username", "password"} {
+		_, inData := provided.Data[key]
+		_, inStringData := provided.StringData[key]
+		if !inData && !inStringData {
+			return nil, baremetalhost.BMCDetails{}, fmt.Errorf("BMC credentials secret %q referenced by host %s is missing the %s key", provided.Name, host.Name, key)
+		}
+	}
+
+	secret := provided.DeepCopy()
+	secret.TypeMeta = metav1.TypeMeta{
+		APIVersion: "v1",
+		Kind:       "Secret",
+	}
+	secret.Namespace = "openshift-machine-api"
+
+	return secret, baremetalhost.BMCDetails{
+		Address:                        host.BMC.Address,
+		CredentialsName:                secret.Name,
+		DisableCertificateVerification: host.BMC.DisableCertificateVerification,
+	}, nil
+}
+
 func createBaremetalHost(host *baremetal.Host, bmc baremetalhost.BMCDetails) baremetalhost.BareMetalHost {
 
 	// Map string 'default' to hardware.DefaultProfileName
@@ -108,19 +139,44 @@ func createBaremetalHost(host *baremetal.Host, bmc baremetalhost.BMCDetails) bar
 }
 
 // Hosts returns the HostSettings with details of the hardware being
-// used to construct the cluster.
-func Hosts(config *types.InstallConfig, machines []machineapi.Machine, userDataSecret string) (*HostSettings, error) {
+// used to construct the cluster. The bmcCredentials are the Secrets that
+// hosts can reference by name instead of inlining their BMC credentials.
+func Hosts(config *types.InstallConfig, machines []machineapi.Machine, userDataSecret string, bmcCredentials []corev1.Secret) (*HostSettings, error) {
 	settings := &HostSettings{}
 
 	if config.Platform.BareMetal == nil {
 		return nil, fmt.Errorf("no baremetal platform in configuration")
 	}
 
+	credentials := make(map[string]*corev1.Secret, len(bmcCredentials))
+	for i := range bmcCredentials {
+		credentials[bmcCredentials[i].Name] = &bmcCredentials[i]
+	}
+	referencedSecrets := sets.New[string]()
+
 	numRequiredMasters := len(machines)
 	numMasters := 0
 	for _, host := range config.Platform.BareMetal.Hosts {
 
-		secret, bmc := createSecret(host)
+		var (
+			secret *corev1.Secret
+			bmc    baremetalhost.BMCDetails
+		)
+		if host.BMC.CredentialsName != "" {
+			var err error
+			secret, bmc, err = createReferencedSecret(host, credentials)
+			if err != nil {
+				return nil, err
+			}
+			// Several hosts may share the same credentials.
+			if referencedSecrets.Has(secret.Name) {
+				secret = nil
+			} else {
+				referencedSecrets.Insert(secret.Name)
+			}
+		} else {
+			secret, bmc = createSecret(host)
+		}
 		if secret != nil {
 			settings.Secrets = append(settings.Secrets, *secret)
 		}
@@ -33,6 +33,7 @@ routes:
 		Scenario        string
 		Machines        []machineapi.Machine
 		Config          *types.InstallConfig
+		BMCCredentials  []corev1.Secret
 		ExpectedSecrets []corev1.Secret
 		ExpectedHosts   []baremetalhost.BareMetalHost
 		ExpectedError   string
@@ -265,11 +266,53 @@ routes:
 					host("master-2").label("installer.openshift.io/role", "control-plane").userDataRef("user-data-secret").consumerRef("machine-2").customDeploy(),
 					host("worker-0").annotation("baremetalhost.metal3.io/paused", "")).build(),
 		},
+		{
+			Scenario: "bmc-credentials-name",
+			Machines: machines(
+				machine("machine-0"),
+				machine("machine-1")),
+			Config: configHosts(
+				hostType("master-0").bmcCredentialsName("rack-1-bmc").role("master"),
+				hostType("master-1").bmcCredentialsName("rack-1-bmc").role("master")),
+			BMCCredentials: []corev1.Secret{
+				{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+					ObjectMeta: metav1.ObjectMeta{Name: "rack-1-bmc"},
+					Data:       map[string][]byte{"username": []byte("usr0"), "password": []byte("pwd0")},
+				},
+			},
+
+			ExpectedSetting: settings().
+				secrets(secret("rack-1-bmc").creds("usr0", "pwd0")).
+				hosts(
+					host("master-0").bmcCredentialsName("rack-1-bmc").label("installer.openshift.io/role", "control-plane").userDataRef("user-data-secret").consumerRef("machine-0").customDeploy(),
+					host("master-1").bmcCredentialsName("rack-1-bmc").label("installer.openshift.io/role", "control-plane").userDataRef("user-data-secret").consumerRef("machine-1").customDeploy()).build(),
+		},
+		{
+			Scenario: "bmc-credentials-name-not-provided",
+			Machines: machines(machine("machine-0")),
+			Config:   configHosts(hostType("master-0").bmcCredentialsName("rack-1-bmc").role("master")),
+
+			ExpectedError: `BMC credentials secret "rack-1-bmc" referenced by host master-0 was not provided`,
+		},
+		{
+			Scenario: "bmc-credentials-name-missing-password",
+			Machines: machines(machine("machine-0")),
+			Config:   configHosts(hostType("master-0").bmcCredentialsName("rack-1-bmc").role("master")),
+			BMCCredentials: []corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "rack-1-bmc"},
+					StringData: map[string]string{"username": "usr0"},
+				},
+			},
+
+			ExpectedError: `BMC credentials secret "rack-1-bmc" referenced by host master-0 is missing the password key`,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.Scenario, func(t *testing.T) {
-			settings, err := Hosts(tc.Config, tc.Machines, "user-data-secret")
+			settings, err := Hosts(tc.Config, tc.Machines, "user-data-secret", tc.BMCCredentials)
 
 			if tc.ExpectedError != "" {
 				assert.EqualError(t, err, tc.ExpectedError)
@@ -357,6 +400,13 @@ func (htb *hostTypeBuilder) bmc(user, password string) *hostTypeBuilder {
 	return htb
 }
 
+func (htb *hostTypeBuilder) bmcCredentialsName(name string) *hostTypeBuilder {
+	htb.BMC = baremetaltypes.BMC{
+		CredentialsName: name,
+	}
+	return htb
+}
+
 func (htb *hostTypeBuilder) networkConfig(config string) *hostTypeBuilder {
 	yaml.Unmarshal([]byte(config), &htb.NetworkConfig)
 	return htb
@@ -429,6 +479,11 @@ func (hb *hostBuilder) build() *baremetalhost.BareMetalHost {
 	return &hb.BareMetalHost
 }
 
+func (hb *hostBuilder) bmcCredentialsName(name string) *hostBuilder {
+	hb.Spec.BMC.CredentialsName = name
+	return hb
+}
+
 func (hb *hostBuilder) externallyProvisioned() *hostBuilder {
 	hb.Spec.ExternallyProvisioned = true
 	return hb
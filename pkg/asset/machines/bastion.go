@@ -0,0 +1,151 @@
+package machines
+
+import (
+	"fmt"
+
+	"k8s.io/utils/ptr"
+	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	capz "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	capg "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+const (
+	// bastionLabel identifies the machine of the SSH bastion host.
+	bastionLabel = "install.openshift.io/bastion"
+
+	// bastionTagKey and bastionTagValue tag the cloud resources of the
+	// bastion host, in addition to the tags of the cluster.
+	bastionTagKey   = "openshift-installer-role"
+	bastionTagValue = "bastion"
+)
+
+// defaultBastionInstanceType returns a small instance type of the platform
+// for the bastion host, which runs no workload.
+func defaultBastionInstanceType(platform string, arch types.Architecture) string {
+	arm := arch == types.ArchitectureARM64
+	switch platform {
+	case awstypes.Name:
+		if arm {
+			return "t4g.small"
+		}
+		return "t3.small"
+	case azuretypes.Name:
+		if arm {
+			return "Standard_D2ps_v5"
+		}
+		return "Standard_B2s"
+	case gcptypes.Name:
+		if arm {
+			return "t2a-standard-1"
+		}
+		return "e2-small"
+	}
+	return ""
+}
+
+// bastionMachines returns the manifests of the SSH bastion host, derived from
+// those of the bootstrap machine so that it runs the same image in the same
+// network, but always with a public address. Its ignition is read from the
+// <infraID>-bastion secret.
+func bastionMachines(ic *types.InstallConfig, infraID string, files []*asset.RuntimeFile) ([]*asset.RuntimeFile, error) {
+	instanceType := ic.Bastion.InstanceType
+	if instanceType == "" {
+		instanceType = defaultBastionInstanceType(ic.Platform.Name(), ic.ControlPlane.Architecture)
+	}
+	name := capiutils.GenerateBastionMachineName(infraID)
+
+	var infraMachine, machine *asset.RuntimeFile
+	var bootstrapName string
+	for _, f := range files {
+		if _, ok := f.Object.GetLabels()["install.openshift.io/bootstrap"]; !ok {
+			continue
+		}
+		bootstrapName = f.Object.GetName()
+		switch m := f.Object.(type) {
+		case *capa.AWSMachine:
+			m = m.DeepCopy()
+			m.Spec.InstanceType = instanceType
+			m.Spec.PublicIP = ptr.To(true)
+			// The bastion does not need the single-tenant hardware the
+			// cluster may require, which small instance types lack.
+			m.Spec.Tenancy = ""
+			if m.Spec.AdditionalTags == nil {
+				m.Spec.AdditionalTags = capa.Tags{}
+			}
+			m.Spec.AdditionalTags[bastionTagKey] = bastionTagValue
+			infraMachine = bastionInfraMachine(m, name)
+		case *capz.AzureMachine:
+			m = m.DeepCopy()
+			m.Spec.VMSize = instanceType
+			m.Spec.AllocatePublicIP = true
+			m.Spec.SecurityProfile = nil
+			if m.Spec.AdditionalTags == nil {
+				m.Spec.AdditionalTags = capz.Tags{}
+			}
+			m.Spec.AdditionalTags[bastionTagKey] = bastionTagValue
+			infraMachine = bastionInfraMachine(m, name)
+		case *capg.GCPMachine:
+			m = m.DeepCopy()
+			m.Spec.InstanceType = instanceType
+			m.Spec.PublicIP = ptr.To(true)
+			m.Spec.ConfidentialCompute = nil
+			m.Spec.OnHostMaintenance = nil
+			m.Spec.AdditionalNetworkTags = append(m.Spec.AdditionalNetworkTags, capiutils.BastionNetworkTag(infraID))
+			if m.Spec.AdditionalLabels == nil {
+				m.Spec.AdditionalLabels = capg.Labels{}
+			}
+			m.Spec.AdditionalLabels[bastionTagKey] = bastionTagValue
+			infraMachine = bastionInfraMachine(m, name)
+		}
+	}
+	if infraMachine == nil {
+		return nil, fmt.Errorf("no bootstrap machine to derive the bastion machine from on platform %s", ic.Platform.Name())
+	}
+
+	for _, f := range files {
+		m, ok := f.Object.(*capi.Machine)
+		if !ok || m.Spec.InfrastructureRef.Name != bootstrapName {
+			continue
+		}
+		m = m.DeepCopy()
+		m.Name = name
+		m.Spec.InfrastructureRef.Name = name
+		m.Spec.Bootstrap.DataSecretName = ptr.To(fmt.Sprintf("%s-%s", infraID, "bastion"))
+		machine = &asset.RuntimeFile{
+			File:   asset.File{Filename: fmt.Sprintf("10_machine_%s.yaml", name)},
+			Object: m,
+		}
+	}
+	if machine == nil {
+		return nil, fmt.Errorf("no bootstrap machine to derive the bastion machine from on platform %s", ic.Platform.Name())
+	}
+
+	return []*asset.RuntimeFile{infraMachine, machine}, nil
+}
+
+// bastionInfraMachine renames the copy of the bootstrap infrastructure
+// machine, and labels it as the bastion.
+func bastionInfraMachine(m client.Object, name string) *asset.RuntimeFile {
+	m.SetName(name)
+	labels := map[string]string{}
+	for k, v := range m.GetLabels() {
+		if k != "install.openshift.io/bootstrap" {
+			labels[k] = v
+		}
+	}
+	labels[bastionLabel] = ""
+	m.SetLabels(labels)
+	return &asset.RuntimeFile{
+		File:   asset.File{Filename: fmt.Sprintf("10_inframachine_%s.yaml", name)},
+		Object: m,
+	}
+}
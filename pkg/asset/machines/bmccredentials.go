@@ -0,0 +1,78 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	// bmcCredentialsDirectory is the directory of the install dir where
+	// the Secrets referenced by the credentialsName of bare metal hosts
+	// BMCs are provided.
+	bmcCredentialsDirectory = "bmc-credentials"
+)
+
+var (
+	bmcCredentialsFileNamePatterns = []string{"*.yaml", "*.yml", "*.json"}
+
+	_ asset.WritableAsset = (*BMCCredentials)(nil)
+)
+
+// BMCCredentials holds the Secrets with the credentials of the BMCs of bare
+// metal hosts, referenced by name from the install-config. Keeping them out of
+// the install-config allows sourcing them from files rendered by a secrets
+// manager and rotating them without editing the install-config.
+type BMCCredentials struct {
+	Secrets  []corev1.Secret
+	FileList []*asset.File
+}
+
+// Dependencies returns no dependencies, the credentials are provided by the
+// user and cannot be generated.
+func (c *BMCCredentials) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate is a no-op: when no credentials were provided, hosts must carry
+// their BMC username and password in the install-config.
+func (c *BMCCredentials) Generate(context.Context, asset.Parents) error {
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (c *BMCCredentials) Name() string {
+	return "BMC Credentials"
+}
+
+// Files returns the files provided in the bmc-credentials directory.
+func (c *BMCCredentials) Files() []*asset.File {
+	return c.FileList
+}
+
+// Load reads the Secrets from the bmc-credentials directory.
+func (c *BMCCredentials) Load(f asset.FileFetcher) (bool, error) {
+	for _, pattern := range bmcCredentialsFileNamePatterns {
+		files, err := f.FetchByPattern(filepath.Join(bmcCredentialsDirectory, pattern))
+		if err != nil {
+			return false, err
+		}
+		for _, file := range files {
+			secret := corev1.Secret{}
+			if err := yaml.UnmarshalStrict(file.Data, &secret); err != nil {
+				return false, fmt.Errorf("failed to unmarshal %s: %w", file.Filename, err)
+			}
+			if secret.Kind != "Secret" {
+				return false, fmt.Errorf("%s: expected a Secret, found %q", file.Filename, secret.Kind)
+			}
+			c.Secrets = append(c.Secrets, secret)
+			c.FileList = append(c.FileList, file)
+		}
+	}
+	return len(c.FileList) > 0, nil
+}
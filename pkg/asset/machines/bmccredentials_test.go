@@ -0,0 +1,97 @@
+package machines
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+func TestBMCCredentialsLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		files         map[string][]*asset.File
+		expectedFound bool
+		expectedNames []string
+		expectedError string
+	}{
+		{
+			name:          "no credentials",
+			expectedFound: false,
+		},
+		{
+			name: "secrets",
+			files: map[string][]*asset.File{
+				"*.yaml": {
+					{
+						Filename: "bmc-credentials/rack-1.yaml",
+						Data: []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: rack-1-bmc
+stringData:
+  username: admin
+  password: secret
+`),
+					},
+				},
+				"*.json": {
+					{
+						Filename: "bmc-credentials/rack-2.json",
+						Data:     []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"rack-2-bmc"},"data":{"username":"YWRtaW4=","password":"c2VjcmV0"}}`),
+					},
+				},
+			},
+			expectedFound: true,
+			expectedNames: []string{"rack-1-bmc", "rack-2-bmc"},
+		},
+		{
+			name: "not a secret",
+			files: map[string][]*asset.File{
+				"*.yml": {
+					{
+						Filename: "bmc-credentials/rack-1.yml",
+						Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rack-1-bmc
+`),
+					},
+				},
+			},
+			expectedError: `bmc-credentials/rack-1.yml: expected a Secret, found "ConfigMap"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			for _, pattern := range bmcCredentialsFileNamePatterns {
+				fileFetcher.EXPECT().FetchByPattern(filepath.Join(bmcCredentialsDirectory, pattern)).
+					Return(tc.files[pattern], nil).
+					MaxTimes(1)
+			}
+
+			credentials := &BMCCredentials{}
+			found, err := credentials.Load(fileFetcher)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+
+			var names []string
+			for _, secret := range credentials.Secrets {
+				names = append(names, secret.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
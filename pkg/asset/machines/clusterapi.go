@@ -70,7 +70,7 @@ func (c *ClusterAPI) Dependencies() []asset.Asset {
 // Generate generates Cluster API machine manifests.
 //
 //nolint:gocyclo
-func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
+func (c *ClusterAPI) Generate(ctx context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	clusterID := &installconfig.ClusterID{}
 	rhcosImage := new(rhcos.Image)
@@ -86,7 +86,6 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 	var err error
 	ic := installConfig.Config
 	pool := *ic.ControlPlane
-	ctx := context.TODO()
 
 	switch ic.Platform.Name() {
 	case awstypes.Name:
@@ -103,7 +102,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 
 		mpool := defaultAWSMachinePoolPlatform("master")
 
-		osImage := strings.SplitN(string(*rhcosImage), ",", 2)
+		osImage := strings.SplitN(rhcosImage.ControlPlane, ",", 2)
 		osImageID := osImage[0]
 		if len(osImage) == 2 {
 			osImageID = "" // the AMI will be generated later on
@@ -213,7 +212,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 			mpool.Zones = []string{""}
 		}
 		if len(mpool.Zones) == 0 {
-			azs, err := client.GetAvailabilityZones(context.TODO(), ic.Platform.Azure.Region, mpool.InstanceType)
+			azs, err := client.GetAvailabilityZones(ctx, ic.Platform.Azure.Region, mpool.InstanceType)
 			if err != nil {
 				return fmt.Errorf("failed to fetch availability zones: %w", err)
 			}
@@ -227,7 +226,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 		// client.GetControlPlaneSubnet(context.TODO(), ic.Platform.Azure.ResourceGroupName, ic.Platform.Azure.VirtualNetwork, )
 
 		if mpool.OSImage.Publisher != "" {
-			img, ierr := client.GetMarketplaceImage(context.TODO(), ic.Platform.Azure.Region, mpool.OSImage.Publisher, mpool.OSImage.Offer, mpool.OSImage.SKU, mpool.OSImage.Version)
+			img, ierr := client.GetMarketplaceImage(ctx, ic.Platform.Azure.Region, mpool.OSImage.Publisher, mpool.OSImage.Offer, mpool.OSImage.SKU, mpool.OSImage.Version)
 			if ierr != nil {
 				return fmt.Errorf("failed to fetch marketplace image: %w", ierr)
 			}
@@ -241,7 +240,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 		pool.Platform.Azure = &mpool
 		subnet := ic.Azure.ControlPlaneSubnet
 
-		capabilities, err := client.GetVMCapabilities(context.TODO(), mpool.InstanceType, installConfig.Config.Platform.Azure.Region)
+		capabilities, err := client.GetVMCapabilities(ctx, mpool.InstanceType, installConfig.Config.Platform.Azure.Region)
 		if err != nil {
 			return err
 		}
@@ -278,7 +277,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 			installConfig,
 			clusterID.InfraID,
 			&pool,
-			string(*rhcosImage),
+			rhcosImage.ControlPlane,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create master machine objects %w", err)
@@ -291,7 +290,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 			installConfig,
 			clusterID.InfraID,
 			&pool,
-			string(*rhcosImage),
+			rhcosImage.ControlPlane,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create bootstrap machine objects %w", err)
@@ -316,10 +315,10 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 			// that the installer's install-config has been provided with bogus values.
 
 			// Timeout context for Lookup
-			ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+			lookupCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 			defer cancel()
 
-			_, err := resolver.LookupHost(ctx, v.Server)
+			_, err := resolver.LookupHost(lookupCtx, v.Server)
 			if err != nil {
 				logrus.Warnf("unable to resolve vSphere server %s", v.Server)
 				return nil
@@ -328,10 +327,10 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 			// Timeout context for Networks
 			// vCenter APIs can be unreliable in performance, extended this context
 			// timeout to 60 seconds.
-			ctx, cancel = context.WithTimeout(context.TODO(), 60*time.Second)
+			networksCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 			defer cancel()
 
-			err = installConfig.VSphere.Networks(ctx, v, platform.FailureDomains)
+			err = installConfig.VSphere.Networks(networksCtx, v, platform.FailureDomains)
 			if err != nil {
 				// If we are receiving an error as a Soap Fault this is caused by
 				// incorrect credentials and in the scenario of assisted installer
@@ -374,7 +373,7 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 		mpool.Set(pool.Platform.OpenStack)
 		pool.Platform.OpenStack = &mpool
 
-		imageName, _ := rhcosutils.GenerateOpenStackImageName(string(*rhcosImage), clusterID.InfraID)
+		imageName, _ := rhcosutils.GenerateOpenStackImageName(rhcosImage.ControlPlane, clusterID.InfraID)
 		trunkSupport, err := openstack.CheckNetworkExtensionAvailability(
 			ic.Platform.OpenStack.Cloud,
 			"trunk",
@@ -435,6 +434,14 @@ func (c *ClusterAPI) Generate(dependencies asset.Parents) error {
 		// TODO: support other platforms
 	}
 
+	if ic.Bastion != nil {
+		bastion, err := bastionMachines(ic, clusterID.InfraID, c.FileList)
+		if err != nil {
+			return fmt.Errorf("failed to create bastion machine objects: %w", err)
+		}
+		c.FileList = append(c.FileList, bastion...)
+	}
+
 	// Create the machine manifests.
 	for _, m := range c.FileList {
 		objData, err := yaml.Marshal(m.Object)
@@ -49,6 +49,13 @@ func GenerateMachines(installConfig *installconfig.InstallConfig, infraID string
 	for idx := int64(0); idx < total; idx++ {
 		name := fmt.Sprintf("%s-%s-%d", infraID, pool.Name, idx)
 		gcpMachine := createGCPMachine(name, installConfig, infraID, mpool, imageName)
+		if pool.EtcdDisk != nil {
+			gcpMachine.Spec.AdditionalDisks = append(gcpMachine.Spec.AdditionalDisks, capg.AttachedDiskSpec{
+				DeviceType:    ptr.To(capg.DiskType(mpool.OSDisk.DiskType)),
+				Size:          ptr.To(int64(pool.EtcdDisk.DiskSizeGB)),
+				EncryptionKey: gcpMachine.Spec.RootDiskEncryptionKey,
+			})
+		}
 
 		result = append(result, &asset.RuntimeFile{
 			File:   asset.File{Filename: fmt.Sprintf("10_inframachine_%s.yaml", gcpMachine.Name)},
@@ -147,10 +154,12 @@ func createGCPMachine(name string, installConfig *installconfig.InstallConfig, i
 	if mpool.ConfidentialCompute != "" {
 		gcpMachine.Spec.ConfidentialCompute = ptr.To(capg.ConfidentialComputePolicy(mpool.ConfidentialCompute))
 	}
-	if mpool.SecureBoot != "" {
-		shieldedInstanceConfig := capg.GCPShieldedInstanceConfig{}
-		shieldedInstanceConfig.SecureBoot = capg.SecureBootPolicyEnabled
-		gcpMachine.Spec.ShieldedInstanceConfig = ptr.To(shieldedInstanceConfig)
+	if mpool.SecureBoot != "" || mpool.VirtualizedTrustedPlatformModule != "" || mpool.IntegrityMonitoring != "" {
+		gcpMachine.Spec.ShieldedInstanceConfig = &capg.GCPShieldedInstanceConfig{
+			SecureBoot:                       capg.SecureBootPolicy(mpool.SecureBoot),
+			VirtualizedTrustedPlatformModule: capg.VirtualizedTrustedPlatformModulePolicy(mpool.VirtualizedTrustedPlatformModule),
+			IntegrityMonitoring:              capg.IntegrityMonitoringPolicy(mpool.IntegrityMonitoring),
+		}
 	}
 
 	serviceAccount := &capg.ServiceAccount{
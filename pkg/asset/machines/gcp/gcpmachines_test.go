@@ -55,6 +55,11 @@ func Test_GenerateMachines(t *testing.T) {
 			installConfig:     getICWithSecureBoot(),
 			expectedGCPConfig: getGCPMachineWithSecureBoot(),
 		},
+		{
+			name:              "shieldedvm",
+			installConfig:     getICWithShieldedVM(),
+			expectedGCPConfig: getGCPMachineWithShieldedVM(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -149,6 +154,15 @@ func getICWithSecureBoot() *installconfig.InstallConfig {
 	return ic
 }
 
+func getICWithShieldedVM() *installconfig.InstallConfig {
+	ic := getBaseInstallConfig()
+	ic.Config.Platform.GCP.DefaultMachinePlatform = &gcptypes.MachinePool{
+		VirtualizedTrustedPlatformModule: "Enabled",
+		IntegrityMonitoring:              "Enabled",
+	}
+	return ic
+}
+
 func getBaseGCPMachine() *capg.GCPMachine {
 	subnet := "012345678-master-subnet"
 	image := "rhcos-415-92-202311241643-0-gcp-x86-64"
@@ -209,6 +223,15 @@ func getGCPMachineWithSecureBoot() *capg.GCPMachine {
 	return gcpMachine
 }
 
+func getGCPMachineWithShieldedVM() *capg.GCPMachine {
+	gcpMachine := getBaseGCPMachine()
+	gcpMachine.Spec.ShieldedInstanceConfig = &capg.GCPShieldedInstanceConfig{
+		VirtualizedTrustedPlatformModule: capg.VirtualizedTrustedPlatformModulePolicyEnabled,
+		IntegrityMonitoring:              capg.IntegrityMonitoringPolicyEnabled,
+	}
+	return gcpMachine
+}
+
 func getBaseCapiMachine() *capi.Machine {
 	dataSecret := fmt.Sprintf("%s-master", "012345678")
 
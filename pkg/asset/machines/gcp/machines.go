@@ -47,6 +47,17 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to create provider")
 		}
+		if pool.EtcdDisk != nil {
+			// The etcd disk is attached right after the boot disk, so that it
+			// is exposed as gcp.EtcdDiskDevice.
+			provider.Disks = append(provider.Disks, &machineapi.GCPDisk{
+				AutoDelete:    true,
+				SizeGB:        int64(pool.EtcdDisk.DiskSizeGB),
+				Type:          mpool.OSDisk.DiskType,
+				Labels:        provider.Disks[0].Labels,
+				EncryptionKey: provider.Disks[0].EncryptionKey,
+			})
+		}
 		machine := machineapi.Machine{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "machine.openshift.io/v1beta1",
@@ -158,8 +169,11 @@ func provider(clusterID string, platform *gcp.Platform, mpool *gcp.MachinePool,
 	// The installer will create a service account for compute nodes with the above naming convention.
 	// The same service account will be used for control plane nodes during a vanilla installation. During a
 	// xpn installation, the installer will attempt to use an existing service account either through the
-	// credentials or through a user supplied value from the install-config.
-	if role == "master" && len(platform.NetworkProjectID) > 0 {
+	// credentials or through a user supplied value from the install-config. Compute pools may always run as
+	// a pre-created service account supplied in the install-config.
+	if role != "master" && mpool.ServiceAccount != "" {
+		instanceServiceAccount = mpool.ServiceAccount
+	} else if role == "master" && len(platform.NetworkProjectID) > 0 {
 		instanceServiceAccount = mpool.ServiceAccount
 
 		if instanceServiceAccount == "" {
@@ -187,9 +201,10 @@ func provider(clusterID string, platform *gcp.Platform, mpool *gcp.MachinePool,
 		}
 	}
 
-	shieldedInstanceConfig := machineapi.GCPShieldedInstanceConfig{}
-	if mpool.SecureBoot == string(machineapi.SecureBootPolicyEnabled) {
-		shieldedInstanceConfig.SecureBoot = machineapi.SecureBootPolicyEnabled
+	shieldedInstanceConfig := machineapi.GCPShieldedInstanceConfig{
+		SecureBoot:                       machineapi.SecureBootPolicy(mpool.SecureBoot),
+		VirtualizedTrustedPlatformModule: machineapi.VirtualizedTrustedPlatformModulePolicy(mpool.VirtualizedTrustedPlatformModule),
+		IntegrityMonitoring:              machineapi.IntegrityMonitoringPolicy(mpool.IntegrityMonitoring),
 	}
 	labels := make(map[string]string, len(platform.UserLabels))
 	for _, label := range platform.UserLabels {
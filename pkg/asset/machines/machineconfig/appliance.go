@@ -0,0 +1,153 @@
+package machineconfig
+
+import (
+	"fmt"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+const (
+	// ApplianceRegistryPort is the port of the local registry serving the
+	// release payload embedded into the agent image.
+	ApplianceRegistryPort = 22625
+
+	// ApplianceDataDir is the directory of the embedded payload on the
+	// media holding it.
+	ApplianceDataDir = "appliance"
+
+	// ApplianceRegistryBinary is the file name of the registry binary in
+	// ApplianceDataDir.
+	ApplianceRegistryBinary = "registry"
+
+	// ApplianceRegistryStorage is the directory of the registry storage in
+	// ApplianceDataDir.
+	ApplianceRegistryStorage = "storage"
+
+	// ApplianceRegistriesConf is the file name of the registries.conf
+	// drop-in, mirroring the release repositories to the local registry, in
+	// ApplianceDataDir. Its extension is kept to three characters so that
+	// it is not renamed in the ISO.
+	ApplianceRegistriesConf = "registries.cfg"
+
+	applianceScriptPath = "/usr/local/bin/appliance-registry.sh"
+	applianceUnitName   = "appliance-registry.service"
+)
+
+// applianceScript finds the media holding the embedded payload, and
+// prepares the registry serving it read-only.
+var applianceScript = fmt.Sprintf(`#!/bin/bash
+set -euo pipefail
+
+mount_dir=/run/media/appliance
+run_dir=/run/appliance-registry
+data_dir="${mount_dir}/%[1]s"
+
+mkdir -p "${mount_dir}" "${run_dir}"
+if ! mountpoint -q "${mount_dir}"; then
+    for dev in $(blkid -t TYPE=iso9660 -o device); do
+        mount -o ro "${dev}" "${mount_dir}" || continue
+        if [ -f "${data_dir}/%[2]s" ]; then
+            break
+        fi
+        umount "${mount_dir}"
+    done
+fi
+if [ ! -f "${data_dir}/%[2]s" ]; then
+    echo "No media holding the embedded release payload is attached" >&2
+    exit 1
+fi
+
+install -m 0755 "${data_dir}/%[3]s" "${run_dir}/registry"
+chcon system_u:object_r:bin_t:s0 "${run_dir}/registry"
+install -m 0644 "${data_dir}/%[2]s" /etc/containers/registries.conf.d/99-appliance.conf
+cat > "${run_dir}/config.yml" <<EOF
+version: 0.1
+storage:
+  filesystem:
+    rootdirectory: ${data_dir}/%[4]s
+  maintenance:
+    readonly:
+      enabled: true
+    uploadpurging:
+      enabled: false
+http:
+  addr: :%[5]d
+EOF
+`, ApplianceDataDir, ApplianceRegistriesConf, ApplianceRegistryBinary, ApplianceRegistryStorage, ApplianceRegistryPort)
+
+var applianceUnit = fmt.Sprintf(`[Unit]
+Description=Registry serving the embedded release payload
+Wants=network-online.target
+After=network-online.target
+Before=crio.service kubelet.service machine-config-daemon-pull.service agent.service assisted-service-pod.service
+
+[Service]
+ExecStartPre=%[1]s
+ExecStart=/run/appliance-registry/registry serve /run/appliance-registry/config.yml
+ExecStartPost=/bin/bash -c 'until curl -sf http://localhost:%[2]d/v2/; do sleep 1; done'
+Restart=on-failure
+RestartSec=10
+
+[Install]
+WantedBy=multi-user.target
+`, applianceScriptPath, ApplianceRegistryPort)
+
+// ApplianceRegistryFiles returns the files of the registry serving the
+// embedded release payload on the hosts.
+func ApplianceRegistryFiles() []igntypes.File {
+	return []igntypes.File{
+		ignition.FileFromString(applianceScriptPath, "root", 0755, applianceScript),
+	}
+}
+
+// ApplianceRegistryUnits returns the systemd units of the registry serving
+// the embedded release payload on the hosts.
+func ApplianceRegistryUnits() []igntypes.Unit {
+	return []igntypes.Unit{{
+		Name:     applianceUnitName,
+		Enabled:  ptr.To(true),
+		Contents: ptr.To(applianceUnit),
+	}}
+}
+
+// ForApplianceRegistry creates the MachineConfig running the registry
+// serving the embedded release payload on the installed nodes.
+func ForApplianceRegistry(role string) (*mcfgv1.MachineConfig, error) {
+	ignConfig := igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+		Storage: igntypes.Storage{
+			Files: ApplianceRegistryFiles(),
+		},
+		Systemd: igntypes.Systemd{
+			Units: ApplianceRegistryUnits(),
+		},
+	}
+
+	rawExt, err := ignition.ConvertToRawExtension(ignConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-appliance-registry", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: rawExt,
+		},
+	}, nil
+}
@@ -0,0 +1,181 @@
+package machineconfig
+
+import (
+	"fmt"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// rootPartLabel is the label of the root partition in the RHCOS disk image.
+	rootPartLabel = "root"
+	// luksRootName is the name of the device mapper device of the encrypted root.
+	luksRootName = "root"
+
+	biosBootTypeGUID = "21686148-6449-6E6F-744E-656564454649"
+	prepTypeGUID     = "9E1A2D38-C612-4316-AA26-8B49521E5A8B"
+	espTypeGUID      = "C12A7328-F81F-11D2-BA4B-00A0C93EC93B"
+	raidTypeGUID     = "A19D880F-05FC-4D3B-A006-743F0F84911E"
+)
+
+// ForBootDevice creates the MachineConfig that configures the boot device of
+// the machines in a pool: LUKS encryption of the root filesystem bound to
+// TPM2 and/or Tang servers, and mirroring of the boot disk with RAID1.
+// The layout matches the one produced by the boot_device section of Butane.
+func ForBootDevice(role string, arch types.Architecture, encryption *types.DiskEncryption, mirror *types.BootDiskMirror) (*mcfgv1.MachineConfig, error) {
+	storage := igntypes.Storage{}
+	rootDevice := fmt.Sprintf("/dev/disk/by-partlabel/%s", rootPartLabel)
+
+	if mirror != nil {
+		storage.Disks = mirroredDisks(arch, mirror.Devices)
+		bootDevices := make([]igntypes.Device, 0, len(mirror.Devices))
+		rootDevices := make([]igntypes.Device, 0, len(mirror.Devices))
+		for i := range mirror.Devices {
+			bootDevices = append(bootDevices, igntypes.Device(fmt.Sprintf("/dev/disk/by-partlabel/boot-%d", i+1)))
+			rootDevices = append(rootDevices, igntypes.Device(fmt.Sprintf("/dev/disk/by-partlabel/root-%d", i+1)))
+		}
+		storage.Raid = []igntypes.Raid{
+			{
+				Name:    "md-boot",
+				Level:   "raid1",
+				Devices: bootDevices,
+				// The boot partition must be readable by the firmware and the
+				// bootloader, which requires the superblock at the end of the device.
+				Options: []igntypes.RaidOption{"--metadata=1.0"},
+			},
+			{
+				Name:    "md-root",
+				Level:   "raid1",
+				Devices: rootDevices,
+			},
+		}
+		storage.Filesystems = append(storage.Filesystems, igntypes.Filesystem{
+			Device:         "/dev/md/md-boot",
+			Format:         ptr.To("ext4"),
+			Label:          ptr.To("boot"),
+			WipeFilesystem: ptr.To(true),
+		})
+		rootDevice = "/dev/md/md-root"
+	}
+
+	var kernelArgs []string
+	if encryption != nil {
+		clevis := &igntypes.Clevis{}
+		if encryption.TPM2 {
+			clevis.Tpm2 = ptr.To(true)
+		}
+		for _, tang := range encryption.Tang {
+			clevis.Tang = append(clevis.Tang, igntypes.Tang{
+				URL:        tang.URL,
+				Thumbprint: ptr.To(tang.Thumbprint),
+			})
+		}
+		if encryption.Threshold > 0 {
+			clevis.Threshold = ptr.To(encryption.Threshold)
+		}
+		storage.Luks = []igntypes.Luks{{
+			Name:       luksRootName,
+			Label:      ptr.To("luks-root"),
+			Device:     ptr.To(rootDevice),
+			Clevis:     clevis,
+			WipeVolume: ptr.To(true),
+		}}
+		rootDevice = fmt.Sprintf("/dev/mapper/%s", luksRootName)
+
+		// Tang servers are reached over the network from the initramfs.
+		if len(encryption.Tang) > 0 {
+			kernelArgs = append(kernelArgs, "rd.neednet=1")
+		}
+	}
+
+	storage.Filesystems = append(storage.Filesystems, igntypes.Filesystem{
+		Device:         rootDevice,
+		Format:         ptr.To("xfs"),
+		Label:          ptr.To("root"),
+		WipeFilesystem: ptr.To(true),
+	})
+
+	ignConfig := igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+		Storage: storage,
+	}
+
+	rawExt, err := ignition.ConvertToRawExtension(ignConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-boot-device", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config:          rawExt,
+			KernelArguments: kernelArgs,
+		},
+	}, nil
+}
+
+// mirroredDisks returns the partition tables for each of the mirrored
+// devices. Firmware partitions are replicated on every device so that the
+// machine can boot from any of them.
+func mirroredDisks(arch types.Architecture, devices []string) []igntypes.Disk {
+	disks := make([]igntypes.Disk, 0, len(devices))
+	for i, device := range devices {
+		n := i + 1
+		var partitions []igntypes.Partition
+		switch arch {
+		case types.ArchitectureAMD64:
+			partitions = append(partitions, igntypes.Partition{
+				Label:    ptr.To(fmt.Sprintf("bios-%d", n)),
+				SizeMiB:  ptr.To(1),
+				TypeGUID: ptr.To(biosBootTypeGUID),
+			})
+		case types.ArchitecturePPC64LE:
+			partitions = append(partitions, igntypes.Partition{
+				Label:    ptr.To(fmt.Sprintf("prep-%d", n)),
+				SizeMiB:  ptr.To(4),
+				TypeGUID: ptr.To(prepTypeGUID),
+			})
+		}
+		if arch == types.ArchitectureAMD64 || arch == types.ArchitectureARM64 {
+			partitions = append(partitions, igntypes.Partition{
+				Label:    ptr.To(fmt.Sprintf("esp-%d", n)),
+				SizeMiB:  ptr.To(127),
+				TypeGUID: ptr.To(espTypeGUID),
+			})
+		}
+		partitions = append(partitions,
+			igntypes.Partition{
+				Label:    ptr.To(fmt.Sprintf("boot-%d", n)),
+				SizeMiB:  ptr.To(384),
+				TypeGUID: ptr.To(raidTypeGUID),
+			},
+			igntypes.Partition{
+				Label:    ptr.To(fmt.Sprintf("root-%d", n)),
+				TypeGUID: ptr.To(raidTypeGUID),
+			},
+		)
+		disks = append(disks, igntypes.Disk{
+			Device:     device,
+			Partitions: partitions,
+			WipeTable:  ptr.To(true),
+		})
+	}
+	return disks
+}
@@ -0,0 +1,74 @@
+package machineconfig
+
+import (
+	"fmt"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+// ChronyConfPath is the path of the chrony configuration file on the hosts.
+const ChronyConfPath = "/etc/chrony.conf"
+
+// defaultChronyConf is the default chrony configuration shipped with RHCOS.
+const defaultChronyConf = `pool 2.rhel.pool.ntp.org iburst
+sourcedir /run/chrony-dhcp
+driftfile /var/lib/chrony/drift
+makestep 1.0 3
+rtcsync
+keyfile /etc/chrony.keys
+ntsdumpdir /var/lib/chrony
+leapsectz right/UTC
+logdir /var/log/chrony
+`
+
+// ChronyConf returns the content of the chrony configuration file that adds
+// the given NTP servers to the default sources.
+func ChronyConf(servers []string) string {
+	var sb strings.Builder
+	sb.WriteString(defaultChronyConf)
+	for _, server := range servers {
+		fmt.Fprintf(&sb, "server %s iburst\n", server)
+	}
+	return sb.String()
+}
+
+// ForAdditionalNTPServers creates the MachineConfig to configure chrony with
+// additional NTP servers.
+func ForAdditionalNTPServers(servers []string, role string) (*mcfgv1.MachineConfig, error) {
+	ignConfig := igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+		Storage: igntypes.Storage{
+			Files: []igntypes.File{
+				ignition.FileFromString(ChronyConfPath, "root", 0644, ChronyConf(servers)),
+			},
+		},
+	}
+
+	rawExt, err := ignition.ConvertToRawExtension(ignConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-chrony", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: rawExt,
+		},
+	}, nil
+}
@@ -0,0 +1,83 @@
+package machineconfig
+
+import (
+	"fmt"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset/ignition"
+)
+
+const (
+	etcdDataDir   = "/var/lib/etcd"
+	etcdDiskLabel = "etcd"
+)
+
+// ForEtcdDisk creates the MachineConfig that formats the dedicated etcd disk
+// and mounts it on the etcd data directory.
+func ForEtcdDisk(role string, device string) (*mcfgv1.MachineConfig, error) {
+	ignConfig := igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+		Storage: igntypes.Storage{
+			Filesystems: []igntypes.Filesystem{{
+				Device: device,
+				Format: ptr.To("xfs"),
+				Label:  ptr.To(etcdDiskLabel),
+				// The disk is only reformatted when it does not already hold
+				// an etcd filesystem, so that etcd data survives a reprovision
+				// of the machine config.
+				WipeFilesystem: ptr.To(false),
+			}},
+		},
+		Systemd: igntypes.Systemd{
+			Units: []igntypes.Unit{{
+				Name:     "var-lib-etcd.mount",
+				Enabled:  ptr.To(true),
+				Contents: ptr.To(etcdMountUnit()),
+			}},
+		},
+	}
+
+	rawExt, err := ignition.ConvertToRawExtension(ignConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("98-%s-etcd-disk", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: rawExt,
+		},
+	}, nil
+}
+
+func etcdMountUnit() string {
+	return fmt.Sprintf(`[Unit]
+Description=Mount the dedicated etcd disk on %[1]s
+Before=local-fs.target
+ConditionPathExists=/dev/disk/by-label/%[2]s
+
+[Mount]
+What=/dev/disk/by-label/%[2]s
+Where=%[1]s
+Type=xfs
+Options=defaults,prjquota,nofail
+
+[Install]
+WantedBy=local-fs.target
+`, etcdDataDir, etcdDiskLabel)
+}
@@ -0,0 +1,130 @@
+package machineconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset/ignition"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const hostnameScriptPath = "/usr/local/bin/set-hostname-pattern"
+
+// ForHostnamePattern creates the MachineConfig that sets the hostname of the
+// machines from the hostname pattern of their pool, before the kubelet starts.
+// The index of a machine is the offset of its IPv4 address in the IPv4 machine
+// networks, taken in order, so that it is unique across the cluster.
+func ForHostnamePattern(role string, pattern string, machineNetworks []types.MachineNetworkEntry) (*mcfgv1.MachineConfig, error) {
+	format, _, err := types.HostnamePatternFormat(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hostname pattern %q: %w", pattern, err)
+	}
+
+	var networks strings.Builder
+	offset := uint64(0)
+	for _, network := range machineNetworks {
+		ip := network.CIDR.IP.To4()
+		if ip == nil {
+			continue
+		}
+		ones, _ := network.CIDR.Mask.Size()
+		fmt.Fprintf(&networks, "\t\"%s %d %d\"\n", ip.Mask(network.CIDR.Mask), ones, offset)
+		offset += uint64(1) << (32 - ones)
+	}
+	if networks.Len() == 0 {
+		return nil, errors.New("hostname patterns need an IPv4 machine network")
+	}
+
+	ignConfig := igntypes.Config{
+		Ignition: igntypes.Ignition{
+			Version: igntypes.MaxVersion.String(),
+		},
+		Storage: igntypes.Storage{
+			Files: []igntypes.File{
+				ignition.FileFromString(hostnameScriptPath, "root", 0755, hostnameScript(format, networks.String())),
+			},
+		},
+		Systemd: igntypes.Systemd{
+			Units: []igntypes.Unit{{
+				Name:     "hostname-pattern.service",
+				Enabled:  ptr.To(true),
+				Contents: ptr.To(hostnameUnit()),
+			}},
+		},
+	}
+
+	rawExt, err := ignition.ConvertToRawExtension(ignConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-hostname", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: rawExt,
+		},
+	}, nil
+}
+
+// hostnameScript returns the script setting the hostname, with the machine
+// networks given as "<network address> <prefix length> <first index>" lines.
+func hostnameScript(format string, networks string) string {
+	return fmt.Sprintf(`#!/bin/bash
+set -euo pipefail
+
+ip2int() {
+	local IFS=.
+	read -r a b c d <<<"$1"
+	echo $(( (a << 24) + (b << 16) + (c << 8) + d ))
+}
+
+networks=(
+%s)
+
+for addr in $(ip -4 -o addr show scope global | awk '{split($4, a, "/"); print a[1]}'); do
+	n=$(ip2int "$addr")
+	for network in "${networks[@]}"; do
+		read -r base prefix first <<<"$network"
+		base=$(ip2int "$base")
+		if (( n >= base && n < base + (1 << (32 - prefix)) )); then
+			hostnamectl set-hostname "$(printf '%s' $(( first + n - base )))"
+			exit 0
+		fi
+	done
+done
+echo "no IPv4 address of the host is in the machine networks" >&2
+exit 1
+`, networks, format)
+}
+
+func hostnameUnit() string {
+	return fmt.Sprintf(`[Unit]
+Description=Set the hostname from the hostname pattern of the machine pool
+Wants=network-online.target
+After=network-online.target
+Before=node-valid-hostname.service kubelet-dependencies.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=%s
+
+[Install]
+WantedBy=kubelet-dependencies.target
+`, hostnameScriptPath)
+}
@@ -0,0 +1,98 @@
+package machineconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	kubeletConfigFileName = "99_openshift-kubeletconfig_%s.yaml"
+)
+
+var (
+	kubeletConfigFileNamePattern = fmt.Sprintf(kubeletConfigFileName, "*")
+)
+
+// ForKubeletConfig creates the KubeletConfig applying the kubelet settings of
+// a machine pool to the machine config pool of the role.
+func ForKubeletConfig(role string, config *types.KubeletConfig) (*mcfgv1.KubeletConfig, error) {
+	// Only the fields of the kubelet configuration the install-config
+	// exposes are set, the machine config operator merges them into its
+	// defaults.
+	kubelet := map[string]interface{}{}
+	if config.MaxPods > 0 {
+		kubelet["maxPods"] = config.MaxPods
+	}
+	if len(config.SystemReserved) > 0 {
+		kubelet["systemReserved"] = config.SystemReserved
+	}
+	if config.TopologyManagerPolicy != "" {
+		kubelet["topologyManagerPolicy"] = config.TopologyManagerPolicy
+	}
+	raw, err := json.Marshal(kubelet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcfgv1.KubeletConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "KubeletConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-kubelet", role),
+		},
+		Spec: mcfgv1.KubeletConfigSpec{
+			MachineConfigPoolSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					fmt.Sprintf("pools.operator.machineconfiguration.openshift.io/%s", role): "",
+				},
+			},
+			KubeletConfig: &runtime.RawExtension{Raw: raw},
+		},
+	}, nil
+}
+
+// KubeletConfigManifest creates the manifest file containing the
+// KubeletConfig of the role.
+func KubeletConfigManifest(config *mcfgv1.KubeletConfig, role, directory string) (*asset.File, error) {
+	if config == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return &asset.File{
+		Filename: filepath.Join(directory, fmt.Sprintf(kubeletConfigFileName, role)),
+		Data:     data,
+	}, nil
+}
+
+// IsKubeletConfigManifest tests whether the specified filename is a
+// KubeletConfig manifest.
+func IsKubeletConfigManifest(filename string) (bool, error) {
+	return filepath.Match(kubeletConfigFileNamePattern, filename)
+}
+
+// LoadKubeletConfig loads the KubeletConfig manifest of the role, if any.
+func LoadKubeletConfig(f asset.FileFetcher, role, directory string) (*asset.File, error) {
+	file, err := f.FetchByName(filepath.Join(directory, fmt.Sprintf(kubeletConfigFileName, role)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return file, nil
+}
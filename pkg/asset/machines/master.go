@@ -45,6 +45,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines/vsphere"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
 	"github.com/openshift/installer/pkg/asset/rhcos"
+	"github.com/openshift/installer/pkg/asset/tls"
 	rhcosutils "github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
@@ -70,6 +71,7 @@ import (
 type Master struct {
 	UserDataFile           *asset.File
 	MachineConfigFiles     []*asset.File
+	KubeletConfigFile      *asset.File
 	MachineFiles           []*asset.File
 	ControlPlaneMachineSet *asset.File
 	IPClaimFiles           []*asset.File
@@ -151,17 +153,20 @@ func (m *Master) Dependencies() []asset.Asset {
 		&installconfig.InstallConfig{},
 		new(rhcos.Image),
 		&machine.Master{},
+		&BMCCredentials{},
+		&tls.CoreSSHKeyPair{},
 	}
 }
 
 // Generate generates the Master asset.
-func (m *Master) Generate(dependencies asset.Parents) error {
-	ctx := context.TODO()
+func (m *Master) Generate(ctx context.Context, dependencies asset.Parents) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	rhcosImage := new(rhcos.Image)
 	mign := &machine.Master{}
-	dependencies.Get(clusterID, installConfig, rhcosImage, mign)
+	bmcCredentials := &BMCCredentials{}
+	coreSSHKeyPair := &tls.CoreSSHKeyPair{}
+	dependencies.Get(clusterID, installConfig, rhcosImage, mign, bmcCredentials, coreSSHKeyPair)
 
 	masterUserDataSecretName := "master-user-data"
 
@@ -188,7 +193,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 
 		mpool := defaultAWSMachinePoolPlatform("master")
 
-		osImage := strings.SplitN(string(*rhcosImage), ",", 2)
+		osImage := strings.SplitN(rhcosImage.ControlPlane, ",", 2)
 		osImageID := osImage[0]
 		if len(osImage) == 2 {
 			osImageID = "" // the AMI will be generated later on
@@ -245,7 +250,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
-		aws.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, ic.Publish)
+		aws.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, apiPublishingStrategy(ic))
 	case gcptypes.Name:
 		mpool := defaultGCPMachinePoolPlatform(pool.Architecture)
 		mpool.Set(ic.Platform.GCP.DefaultMachinePlatform)
@@ -258,11 +263,11 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 			mpool.Zones = azs
 		}
 		pool.Platform.GCP = &mpool
-		machines, controlPlaneMachineSet, err = gcp.Machines(clusterID.InfraID, ic, &pool, string(*rhcosImage), "master", masterUserDataSecretName)
+		machines, controlPlaneMachineSet, err = gcp.Machines(clusterID.InfraID, ic, &pool, rhcosImage.ControlPlane, "master", masterUserDataSecretName)
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
-		err := gcp.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, ic.Publish)
+		err := gcp.ConfigMasters(machines, controlPlaneMachineSet, clusterID.InfraID, apiPublishingStrategy(ic))
 		if err != nil {
 			return err
 		}
@@ -329,7 +334,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		mpool.Set(pool.Platform.OpenStack)
 		pool.Platform.OpenStack = &mpool
 
-		imageName, _ := rhcosutils.GenerateOpenStackImageName(string(*rhcosImage), clusterID.InfraID)
+		imageName, _ := rhcosutils.GenerateOpenStackImageName(rhcosImage.ControlPlane, clusterID.InfraID)
 
 		trunkSupport, err := openstack.CheckNetworkExtensionAvailability(
 			ic.Platform.OpenStack.Cloud,
@@ -365,7 +370,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 
 		client := icazure.NewClient(session)
 		if len(mpool.Zones) == 0 {
-			azs, err := client.GetAvailabilityZones(context.TODO(), ic.Platform.Azure.Region, mpool.InstanceType)
+			azs, err := client.GetAvailabilityZones(ctx, ic.Platform.Azure.Region, mpool.InstanceType)
 			if err != nil {
 				return errors.Wrap(err, "failed to fetch availability zones")
 			}
@@ -378,7 +383,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		}
 
 		if mpool.OSImage.Publisher != "" {
-			img, ierr := client.GetMarketplaceImage(context.TODO(), ic.Platform.Azure.Region, mpool.OSImage.Publisher, mpool.OSImage.Offer, mpool.OSImage.SKU, mpool.OSImage.Version)
+			img, ierr := client.GetMarketplaceImage(ctx, ic.Platform.Azure.Region, mpool.OSImage.Publisher, mpool.OSImage.Offer, mpool.OSImage.SKU, mpool.OSImage.Version)
 			if ierr != nil {
 				return fmt.Errorf("failed to fetch marketplace image: %w", ierr)
 			}
@@ -391,12 +396,12 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		}
 		pool.Platform.Azure = &mpool
 
-		capabilities, err := client.GetVMCapabilities(context.TODO(), mpool.InstanceType, installConfig.Config.Platform.Azure.Region)
+		capabilities, err := client.GetVMCapabilities(ctx, mpool.InstanceType, installConfig.Config.Platform.Azure.Region)
 		if err != nil {
 			return err
 		}
 		useImageGallery := installConfig.Azure.CloudName != azuretypes.StackCloud
-		machines, controlPlaneMachineSet, err = azure.Machines(clusterID.InfraID, ic, &pool, string(*rhcosImage), "master", masterUserDataSecretName, capabilities, useImageGallery)
+		machines, controlPlaneMachineSet, err = azure.Machines(clusterID.InfraID, ic, &pool, rhcosImage.ControlPlane, "master", masterUserDataSecretName, capabilities, useImageGallery)
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
@@ -418,7 +423,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
 
-		hostSettings, err := baremetal.Hosts(ic, machines, masterUserDataSecretName)
+		hostSettings, err := baremetal.Hosts(ic, machines, masterUserDataSecretName, bmcCredentials.Secrets)
 		if err != nil {
 			return errors.Wrap(err, "failed to assemble host data")
 		}
@@ -448,7 +453,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		mpool.Set(pool.Platform.Ovirt)
 		pool.Platform.Ovirt = &mpool
 
-		imageName, _ := rhcosutils.GenerateOpenStackImageName(string(*rhcosImage), clusterID.InfraID)
+		imageName, _ := rhcosutils.GenerateOpenStackImageName(rhcosImage.ControlPlane, clusterID.InfraID)
 
 		machines, err = ovirt.Machines(clusterID.InfraID, ic, &pool, imageName, "master", masterUserDataSecretName)
 		if err != nil {
@@ -545,13 +550,41 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		}
 		machineConfigs = append(machineConfigs, ignHT)
 	}
-	if ic.SSHKey != "" {
-		ignSSH, err := machineconfig.ForAuthorizedKeys(ic.SSHKey, "master")
+	if keys := coreSSHKeyPair.AuthorizedKeys(ic); len(keys) > 0 {
+		ignSSH, err := machineconfig.ForAuthorizedKeys(keys, "master")
 		if err != nil {
 			return errors.Wrap(err, "failed to create ignition for authorized SSH keys for master machines")
 		}
 		machineConfigs = append(machineConfigs, ignSSH)
 	}
+	if pool.DiskEncryption != nil || pool.BootDiskMirror != nil {
+		ignBootDevice, err := machineconfig.ForBootDevice("master", pool.Architecture, pool.DiskEncryption, pool.BootDiskMirror)
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for boot device configuration for master machines")
+		}
+		machineConfigs = append(machineConfigs, ignBootDevice)
+	}
+	if pool.EtcdDisk != nil {
+		ignEtcdDisk, err := machineconfig.ForEtcdDisk("master", pool.EtcdDisk.Device)
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for the etcd disk of master machines")
+		}
+		machineConfigs = append(machineConfigs, ignEtcdDisk)
+	}
+	if pool.HostnamePattern != "" {
+		ignHostname, err := machineconfig.ForHostnamePattern("master", pool.HostnamePattern, ic.MachineNetwork)
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for the hostname pattern of master machines")
+		}
+		machineConfigs = append(machineConfigs, ignHostname)
+	}
+	if len(ic.AdditionalNTPServers) > 0 {
+		ignChrony, err := machineconfig.ForAdditionalNTPServers(ic.AdditionalNTPServers, "master")
+		if err != nil {
+			return errors.Wrap(err, "failed to create ignition for additional NTP servers for master machines")
+		}
+		machineConfigs = append(machineConfigs, ignChrony)
+	}
 	if ic.FIPS {
 		ignFIPS, err := machineconfig.ForFIPSEnabled("master")
 		if err != nil {
@@ -594,6 +627,17 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		return errors.Wrap(err, "failed to create MachineConfig manifests for master machines")
 	}
 
+	if pool.KubeletConfig != nil {
+		kubeletConfig, err := machineconfig.ForKubeletConfig("master", pool.KubeletConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to create kubelet config for master machines")
+		}
+		m.KubeletConfigFile, err = machineconfig.KubeletConfigManifest(kubeletConfig, "master", directory)
+		if err != nil {
+			return errors.Wrap(err, "failed to create KubeletConfig manifest for master machines")
+		}
+	}
+
 	m.MachineFiles = make([]*asset.File, len(machines))
 	if controlPlaneMachineSet != nil && *pool.Replicas > 1 {
 		data, err := yaml.Marshal(controlPlaneMachineSet)
@@ -655,6 +699,9 @@ func (m *Master) Files() []*asset.File {
 		files = append(files, m.UserDataFile)
 	}
 	files = append(files, m.MachineConfigFiles...)
+	if m.KubeletConfigFile != nil {
+		files = append(files, m.KubeletConfigFile)
+	}
 	// Hosts refer to secrets, so place the secrets before the hosts
 	// to avoid unnecessary reconciliation errors.
 	files = append(files, m.SecretFiles...)
@@ -688,6 +735,11 @@ func (m *Master) Load(f asset.FileFetcher) (found bool, err error) {
 		return true, err
 	}
 
+	m.KubeletConfigFile, err = machineconfig.LoadKubeletConfig(f, "master", directory)
+	if err != nil {
+		return true, err
+	}
+
 	var fileList []*asset.File
 
 	fileList, err = f.FetchByPattern(filepath.Join(directory, secretFileNamePattern))
@@ -809,6 +861,11 @@ func IsMachineManifest(file *asset.File) bool {
 	} else if matched {
 		return true
 	}
+	if matched, err := machineconfig.IsKubeletConfigManifest(filename); err != nil {
+		panic(err)
+	} else if matched {
+		return true
+	}
 	for _, pattern := range []struct {
 		Pattern string
 		Type    string
@@ -885,3 +942,13 @@ func createAssetFiles(objects []interface{}, fileName string) ([]*asset.File, er
 
 	return assetFiles, nil
 }
+
+// apiPublishingStrategy returns the publishing strategy of the API load
+// balancers the control plane machines are attached to, which is the
+// strategy of the API server for the mixed publishing strategy.
+func apiPublishingStrategy(ic *types.InstallConfig) types.PublishingStrategy {
+	if ic.PublicAPI() {
+		return types.ExternalPublishingStrategy
+	}
+	return types.InternalPublishingStrategy
+}
@@ -1,6 +1,7 @@
 package machines
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -12,10 +13,12 @@ import (
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/yaml"
 
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/rhcos"
+	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/baremetal"
@@ -25,6 +28,7 @@ func TestMasterGenerateMachineConfigs(t *testing.T) {
 	cases := []struct {
 		name                  string
 		key                   string
+		keys                  []string
 		hyperthreading        types.HyperthreadingMode
 		expectedMachineConfig []string
 	}{
@@ -32,6 +36,36 @@ func TestMasterGenerateMachineConfigs(t *testing.T) {
 			name:           "no key hyperthreading enabled",
 			hyperthreading: types.HyperthreadingEnabled,
 		},
+		{
+			name:           "multiple keys present hyperthreading enabled",
+			key:            "ssh-rsa: dummy-key",
+			keys:           []string{"ssh-ed25519: other-key"},
+			hyperthreading: types.HyperthreadingEnabled,
+			expectedMachineConfig: []string{`apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  creationTimestamp: null
+  labels:
+    machineconfiguration.openshift.io/role: master
+  name: 99-master-ssh
+spec:
+  baseOSExtensionsContainerImage: ""
+  config:
+    ignition:
+      version: 3.2.0
+    passwd:
+      users:
+      - name: core
+        sshAuthorizedKeys:
+        - 'ssh-rsa: dummy-key'
+        - 'ssh-ed25519: other-key'
+  extensions: null
+  fips: false
+  kernelArguments: null
+  kernelType: ""
+  osImageURL: ""
+`},
+		},
 		{
 			name:           "key present hyperthreading enabled",
 			key:            "ssh-rsa: dummy-key",
@@ -144,6 +178,7 @@ spec:
 							Name: "test-cluster",
 						},
 						SSHKey:     tc.key,
+						SSHKeys:    tc.keys,
 						BaseDomain: "test-domain",
 						Platform: types.Platform{
 							AWS: &awstypes.Platform{
@@ -161,7 +196,7 @@ spec:
 							},
 						},
 					}),
-				(*rhcos.Image)(pointer.StringPtr("test-image")),
+				&rhcos.Image{ControlPlane: "test-image", Compute: "test-image"},
 				(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 				&machine.Master{
 					File: &asset.File{
@@ -169,9 +204,11 @@ spec:
 						Data:     []byte("test-ignition"),
 					},
 				},
+				&BMCCredentials{},
+				&tls.CoreSSHKeyPair{},
 			)
 			master := &Master{}
-			if err := master.Generate(parents); err != nil {
+			if err := master.Generate(context.Background(), parents); err != nil {
 				t.Fatalf("failed to generate master machines: %v", err)
 			}
 			expectedLen := len(tc.expectedMachineConfig)
@@ -186,6 +223,67 @@ spec:
 	}
 }
 
+func TestMasterGenerateKubeletConfig(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(
+		&installconfig.ClusterID{
+			UUID:    "test-uuid",
+			InfraID: "test-infra-id",
+		},
+		installconfig.MakeAsset(
+			&types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				BaseDomain: "test-domain",
+				Platform: types.Platform{
+					AWS: &awstypes.Platform{
+						Region: "us-east-1",
+					},
+				},
+				ControlPlane: &types.MachinePool{
+					Replicas: pointer.Int64Ptr(1),
+					Platform: types.MachinePoolPlatform{
+						AWS: &awstypes.MachinePool{
+							Zones:        []string{"us-east-1a"},
+							InstanceType: "m5.xlarge",
+						},
+					},
+					KubeletConfig: &types.KubeletConfig{
+						MaxPods:               500,
+						SystemReserved:        map[string]string{"cpu": "500m"},
+						TopologyManagerPolicy: "single-numa-node",
+					},
+				},
+			}),
+		&rhcos.Image{ControlPlane: "test-image", Compute: "test-image"},
+		(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
+		&machine.Master{
+			File: &asset.File{
+				Filename: "master-ignition",
+				Data:     []byte("test-ignition"),
+			},
+		},
+		&BMCCredentials{},
+		&tls.CoreSSHKeyPair{},
+	)
+	master := &Master{}
+	if err := master.Generate(context.Background(), parents); err != nil {
+		t.Fatalf("failed to generate master machines: %v", err)
+	}
+	if !assert.NotNil(t, master.KubeletConfigFile) {
+		return
+	}
+	assert.Equal(t, "openshift/99_openshift-kubeletconfig_master.yaml", master.KubeletConfigFile.Filename)
+
+	kubeletConfig := &mcfgv1.KubeletConfig{}
+	if assert.NoError(t, yaml.Unmarshal(master.KubeletConfigFile.Data, kubeletConfig)) {
+		assert.Equal(t, "99-master-kubelet", kubeletConfig.Name)
+		assert.Equal(t, map[string]string{"pools.operator.machineconfiguration.openshift.io/master": ""}, kubeletConfig.Spec.MachineConfigPoolSelector.MatchLabels)
+		assert.JSONEq(t, `{"maxPods":500,"systemReserved":{"cpu":"500m"},"topologyManagerPolicy":"single-numa-node"}`, string(kubeletConfig.Spec.KubeletConfig.Raw))
+	}
+}
+
 func TestControlPlaneIsNotModified(t *testing.T) {
 	parents := asset.Parents{}
 	installConfig := installconfig.MakeAsset(
@@ -220,7 +318,7 @@ func TestControlPlaneIsNotModified(t *testing.T) {
 			InfraID: "test-infra-id",
 		},
 		installConfig,
-		(*rhcos.Image)(pointer.StringPtr("test-image")),
+		&rhcos.Image{ControlPlane: "test-image", Compute: "test-image"},
 		(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 		&machine.Master{
 			File: &asset.File{
@@ -228,9 +326,11 @@ func TestControlPlaneIsNotModified(t *testing.T) {
 				Data:     []byte("test-ignition"),
 			},
 		},
+		&BMCCredentials{},
+		&tls.CoreSSHKeyPair{},
 	)
 	master := &Master{}
-	if err := master.Generate(parents); err != nil {
+	if err := master.Generate(context.Background(), parents); err != nil {
 		t.Fatalf("failed to generate master machines: %v", err)
 	}
 
@@ -289,7 +389,7 @@ func TestBaremetalGeneratedAssetFiles(t *testing.T) {
 			InfraID: "test-infra-id",
 		},
 		installConfig,
-		(*rhcos.Image)(pointer.StringPtr("test-image")),
+		&rhcos.Image{ControlPlane: "test-image", Compute: "test-image"},
 		(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 		&machine.Master{
 			File: &asset.File{
@@ -297,9 +397,11 @@ func TestBaremetalGeneratedAssetFiles(t *testing.T) {
 				Data:     []byte("test-ignition"),
 			},
 		},
+		&BMCCredentials{},
+		&tls.CoreSSHKeyPair{},
 	)
 	master := &Master{}
-	assert.NoError(t, master.Generate(parents))
+	assert.NoError(t, master.Generate(context.Background(), parents))
 
 	assert.Len(t, master.HostFiles, 2)
 	verifyHost(t, master.HostFiles[0], "openshift/99_openshift-cluster-api_hosts-0.yaml", "master-0")
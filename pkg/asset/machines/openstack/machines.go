@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	v1 "github.com/openshift/api/config/v1"
 	machinev1 "github.com/openshift/api/machine/v1"
@@ -212,6 +213,26 @@ func generateProviderSpec(clusterID string, platform *openstack.Platform, mpool
 		})
 	}
 
+	ports := make([]machinev1alpha1.PortOpts, 0, len(mpool.AdditionalPorts))
+	for _, additionalPort := range mpool.AdditionalPorts {
+		port := machinev1alpha1.PortOpts{
+			NetworkID:    additionalPort.NetworkID,
+			VNICType:     additionalPort.VNICType,
+			PortSecurity: additionalPort.PortSecurity,
+			Trunk:        ptr.To(additionalPort.Trunk),
+			Tags:         []string{fmt.Sprintf("openshiftClusterID=%s", clusterID)},
+		}
+		for _, subnetID := range additionalPort.SubnetIDs {
+			port.FixedIPs = append(port.FixedIPs, machinev1alpha1.FixedIPs{SubnetID: subnetID})
+		}
+		// Ports without port security cannot have security groups, so
+		// do not let them inherit the machine's.
+		if additionalPort.PortSecurity != nil && !*additionalPort.PortSecurity {
+			port.SecurityGroups = &[]string{}
+		}
+		ports = append(ports, port)
+	}
+
 	securityGroups := []machinev1alpha1.SecurityGroupParam{
 		{
 			Name: fmt.Sprintf("%s-%s", clusterID, role),
@@ -245,6 +266,7 @@ func generateProviderSpec(clusterID string, platform *openstack.Platform, mpool
 		CloudsSecret:     &corev1.SecretReference{Name: cloudsSecret, Namespace: cloudsSecretNamespace},
 		UserDataSecret:   &corev1.SecretReference{Name: userDataSecret},
 		Networks:         append([]machinev1alpha1.NetworkParam{controlPlaneNetwork}, additionalNetworks...),
+		Ports:            ports,
 		PrimarySubnet:    primarySubnet,
 		AvailabilityZone: failureDomain.AvailabilityZone,
 		SecurityGroups:   securityGroups,
@@ -287,3 +287,53 @@ func TestFailureDomains(t *testing.T) {
 
 func TestPruneFailureDomains(t *testing.T) {
 }
+
+func TestGenerateProviderSpecAdditionalPorts(t *testing.T) {
+	portSecurity := false
+	mpool := openstack.MachinePool{
+		FlavorName: "m1.large",
+		AdditionalPorts: []openstack.AdditionalPort{
+			{
+				NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11",
+				SubnetIDs: []string{"a6b5d1f0-3c8e-4e4b-9a57-2f4bdb8c1e42"},
+				VNICType:  "direct",
+			},
+			{
+				NetworkID:    "6a8c3b39-21de-4b5c-a6a8-8d3e6c7f9b20",
+				PortSecurity: &portSecurity,
+				Trunk:        true,
+			},
+		},
+	}
+
+	spec, err := generateProviderSpec("infra-id", &openstack.Platform{}, &mpool, "rhcos", "worker", "worker-user-data", false, machinev1.OpenStackFailureDomain{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if have := len(spec.Ports); have != 2 {
+		t.Fatalf("expected 2 ports, got %d", have)
+	}
+
+	sriov := spec.Ports[0]
+	if sriov.NetworkID != "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11" || sriov.VNICType != "direct" {
+		t.Errorf("unexpected SR-IOV port: %+v", sriov)
+	}
+	if len(sriov.FixedIPs) != 1 || sriov.FixedIPs[0].SubnetID != "a6b5d1f0-3c8e-4e4b-9a57-2f4bdb8c1e42" {
+		t.Errorf("unexpected SR-IOV port fixed IPs: %+v", sriov.FixedIPs)
+	}
+	if sriov.Trunk == nil || *sriov.Trunk {
+		t.Errorf("expected trunk to be disabled on the SR-IOV port")
+	}
+	if sriov.SecurityGroups != nil {
+		t.Errorf("expected the SR-IOV port to inherit the machine security groups")
+	}
+
+	trunk := spec.Ports[1]
+	if trunk.Trunk == nil || !*trunk.Trunk {
+		t.Errorf("expected trunk to be enabled on the trunk port")
+	}
+	if trunk.SecurityGroups == nil || len(*trunk.SecurityGroups) != 0 {
+		t.Errorf("expected no security groups on a port without port security")
+	}
+}
@@ -155,12 +155,29 @@ func generateMachineSpec(clusterID string, platform *openstack.Platform, mpool *
 		}
 	}
 
-	additionalPorts := make([]capo.PortOpts, 0, len(mpool.AdditionalNetworkIDs))
+	additionalPorts := make([]capo.PortOpts, 0, len(mpool.AdditionalNetworkIDs)+len(mpool.AdditionalPorts))
 	for i := range mpool.AdditionalNetworkIDs {
 		additionalPorts = append(additionalPorts, capo.PortOpts{
 			Network: &capo.NetworkParam{ID: &mpool.AdditionalNetworkIDs[i]},
 		})
 	}
+	for i := range mpool.AdditionalPorts {
+		additionalPort := &mpool.AdditionalPorts[i]
+		port := capo.PortOpts{
+			Network: &capo.NetworkParam{ID: &additionalPort.NetworkID},
+			Trunk:   ptr.To(additionalPort.Trunk),
+		}
+		for j := range additionalPort.SubnetIDs {
+			port.FixedIPs = append(port.FixedIPs, capo.FixedIP{Subnet: &capo.SubnetParam{ID: &additionalPort.SubnetIDs[j]}})
+		}
+		if additionalPort.VNICType != "" {
+			port.VNICType = &additionalPort.VNICType
+		}
+		if additionalPort.PortSecurity != nil {
+			port.DisablePortSecurity = ptr.To(!*additionalPort.PortSecurity)
+		}
+		additionalPorts = append(additionalPorts, port)
+	}
 
 	securityGroups := []capo.SecurityGroupParam{
 		{
@@ -46,6 +46,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines/powervs"
 	"github.com/openshift/installer/pkg/asset/machines/vsphere"
 	"github.com/openshift/installer/pkg/asset/rhcos"
+	"github.com/openshift/installer/pkg/asset/tls"
 	rhcosutils "github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
@@ -240,6 +241,7 @@ func awsSetPreferredInstanceByEdgeZone(ctx context.Context, defaultTypes []strin
 type Worker struct {
 	UserDataFile       *asset.File
 	MachineConfigFiles []*asset.File
+	KubeletConfigFile  *asset.File
 	MachineSetFiles    []*asset.File
 	MachineFiles       []*asset.File
 	IPClaimFiles       []*asset.File
@@ -264,23 +266,25 @@ func (w *Worker) Dependencies() []asset.Asset {
 		new(rhcos.Image),
 		new(rhcos.Release),
 		&machine.Worker{},
+		&tls.CoreSSHKeyPair{},
 	}
 }
 
 // Generate generates the Worker asset.
-func (w *Worker) Generate(dependencies asset.Parents) error {
-	ctx := context.TODO()
+func (w *Worker) Generate(ctx context.Context, dependencies asset.Parents) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	rhcosImage := new(rhcos.Image)
 	rhcosRelease := new(rhcos.Release)
 	wign := &machine.Worker{}
-	dependencies.Get(clusterID, installConfig, rhcosImage, rhcosRelease, wign)
+	coreSSHKeyPair := &tls.CoreSSHKeyPair{}
+	dependencies.Get(clusterID, installConfig, rhcosImage, rhcosRelease, wign, coreSSHKeyPair)
 
 	workerUserDataSecretName := "worker-user-data"
 
 	machines := []machinev1beta1.Machine{}
 	machineConfigs := []*mcfgv1.MachineConfig{}
+	var kubeletConfig *mcfgv1.KubeletConfig
 	machineSets := []runtime.Object{}
 	var ipClaims []ipamv1.IPAddressClaim
 	var ipAddrs []ipamv1.IPAddress
@@ -295,13 +299,27 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 			machineConfigs = append(machineConfigs, ignHT)
 		}
-		if ic.SSHKey != "" {
-			ignSSH, err := machineconfig.ForAuthorizedKeys(ic.SSHKey, "worker")
+		if keys := coreSSHKeyPair.AuthorizedKeys(ic); len(keys) > 0 {
+			ignSSH, err := machineconfig.ForAuthorizedKeys(keys, "worker")
 			if err != nil {
 				return errors.Wrap(err, "failed to create ignition for authorized SSH keys for worker machines")
 			}
 			machineConfigs = append(machineConfigs, ignSSH)
 		}
+		if pool.DiskEncryption != nil || pool.BootDiskMirror != nil {
+			ignBootDevice, err := machineconfig.ForBootDevice("worker", pool.Architecture, pool.DiskEncryption, pool.BootDiskMirror)
+			if err != nil {
+				return errors.Wrap(err, "failed to create ignition for boot device configuration for worker machines")
+			}
+			machineConfigs = append(machineConfigs, ignBootDevice)
+		}
+		if len(ic.AdditionalNTPServers) > 0 {
+			ignChrony, err := machineconfig.ForAdditionalNTPServers(ic.AdditionalNTPServers, "worker")
+			if err != nil {
+				return errors.Wrap(err, "failed to create ignition for additional NTP servers for worker machines")
+			}
+			machineConfigs = append(machineConfigs, ignChrony)
+		}
 		if ic.FIPS {
 			ignFIPS, err := machineconfig.ForFIPSEnabled("worker")
 			if err != nil {
@@ -338,6 +356,23 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 			machineConfigs = append(machineConfigs, ignIPv6)
 		}
+		// Validation allows a single compute pool to set the hostname pattern,
+		// since all of them share the worker machine config pool.
+		if pool.HostnamePattern != "" {
+			ignHostname, err := machineconfig.ForHostnamePattern("worker", pool.HostnamePattern, ic.MachineNetwork)
+			if err != nil {
+				return errors.Wrap(err, "failed to create ignition for the hostname pattern of worker machines")
+			}
+			machineConfigs = append(machineConfigs, ignHostname)
+		}
+		// Validation allows a single compute pool to set the kubelet config,
+		// since all of them share the worker machine config pool.
+		if pool.KubeletConfig != nil {
+			kubeletConfig, err = machineconfig.ForKubeletConfig("worker", pool.KubeletConfig)
+			if err != nil {
+				return errors.Wrap(err, "failed to create kubelet config for worker machines")
+			}
+		}
 
 		switch ic.Platform.Name() {
 		case awstypes.Name:
@@ -363,7 +398,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 			mpool := defaultAWSMachinePoolPlatform(pool.Name)
 
-			osImage := strings.SplitN(string(*rhcosImage), ",", 2)
+			osImage := strings.SplitN(rhcosImage.Compute, ",", 2)
 			osImageID := osImage[0]
 			if len(osImage) == 2 {
 				osImageID = "" // the AMI will be generated later on
@@ -461,7 +496,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 
 			client := icazure.NewClient(session)
 			if len(mpool.Zones) == 0 {
-				azs, err := client.GetAvailabilityZones(context.TODO(), ic.Platform.Azure.Region, mpool.InstanceType)
+				azs, err := client.GetAvailabilityZones(ctx, ic.Platform.Azure.Region, mpool.InstanceType)
 				if err != nil {
 					return errors.Wrap(err, "failed to fetch availability zones")
 				}
@@ -474,7 +509,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 
 			if mpool.OSImage.Publisher != "" {
-				img, ierr := client.GetMarketplaceImage(context.TODO(), ic.Platform.Azure.Region, mpool.OSImage.Publisher, mpool.OSImage.Offer, mpool.OSImage.SKU, mpool.OSImage.Version)
+				img, ierr := client.GetMarketplaceImage(ctx, ic.Platform.Azure.Region, mpool.OSImage.Publisher, mpool.OSImage.Offer, mpool.OSImage.SKU, mpool.OSImage.Version)
 				if ierr != nil {
 					return fmt.Errorf("failed to fetch marketplace image: %w", ierr)
 				}
@@ -487,13 +522,13 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 			pool.Platform.Azure = &mpool
 
-			capabilities, err := client.GetVMCapabilities(context.TODO(), mpool.InstanceType, installConfig.Config.Platform.Azure.Region)
+			capabilities, err := client.GetVMCapabilities(ctx, mpool.InstanceType, installConfig.Config.Platform.Azure.Region)
 			if err != nil {
 				return err
 			}
 
 			useImageGallery := ic.Platform.Azure.CloudName != azuretypes.StackCloud
-			sets, err := azure.MachineSets(clusterID.InfraID, ic, &pool, string(*rhcosImage), "worker", workerUserDataSecretName, capabilities, useImageGallery)
+			sets, err := azure.MachineSets(clusterID.InfraID, ic, &pool, rhcosImage.Compute, "worker", workerUserDataSecretName, capabilities, useImageGallery)
 			if err != nil {
 				return errors.Wrap(err, "failed to create worker machine objects")
 			}
@@ -528,7 +563,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				mpool.Zones = azs
 			}
 			pool.Platform.GCP = &mpool
-			sets, err := gcp.MachineSets(clusterID.InfraID, ic, &pool, string(*rhcosImage), "worker", workerUserDataSecretName)
+			sets, err := gcp.MachineSets(clusterID.InfraID, ic, &pool, rhcosImage.Compute, "worker", workerUserDataSecretName)
 			if err != nil {
 				return errors.Wrap(err, "failed to create worker machine objects")
 			}
@@ -582,7 +617,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			mpool.Set(pool.Platform.OpenStack)
 			pool.Platform.OpenStack = &mpool
 
-			imageName, _ := rhcosutils.GenerateOpenStackImageName(string(*rhcosImage), clusterID.InfraID)
+			imageName, _ := rhcosutils.GenerateOpenStackImageName(rhcosImage.Compute, clusterID.InfraID)
 
 			trunkSupport, err := openstack.CheckNetworkExtensionAvailability(
 				ic.Platform.OpenStack.Cloud,
@@ -640,7 +675,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			mpool.Set(pool.Platform.Ovirt)
 			pool.Platform.Ovirt = &mpool
 
-			imageName, _ := rhcosutils.GenerateOpenStackImageName(string(*rhcosImage), clusterID.InfraID)
+			imageName, _ := rhcosutils.GenerateOpenStackImageName(rhcosImage.Compute, clusterID.InfraID)
 
 			sets, err := ovirt.MachineSets(clusterID.InfraID, ic, &pool, imageName, "worker", workerUserDataSecretName)
 			if err != nil {
@@ -698,6 +733,11 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 		return errors.Wrap(err, "failed to create MachineConfig manifests for worker machines")
 	}
 
+	w.KubeletConfigFile, err = machineconfig.KubeletConfigManifest(kubeletConfig, "worker", directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create KubeletConfig manifest for worker machines")
+	}
+
 	w.MachineSetFiles = make([]*asset.File, len(machineSets))
 	padFormat := fmt.Sprintf("%%0%dd", len(fmt.Sprintf("%d", len(machineSets))))
 	for i, machineSet := range machineSets {
@@ -761,6 +801,9 @@ func (w *Worker) Files() []*asset.File {
 		files = append(files, w.UserDataFile)
 	}
 	files = append(files, w.MachineConfigFiles...)
+	if w.KubeletConfigFile != nil {
+		files = append(files, w.KubeletConfigFile)
+	}
 	files = append(files, w.MachineSetFiles...)
 	files = append(files, w.MachineFiles...)
 	files = append(files, w.IPClaimFiles...)
@@ -784,6 +827,11 @@ func (w *Worker) Load(f asset.FileFetcher) (found bool, err error) {
 		return true, err
 	}
 
+	w.KubeletConfigFile, err = machineconfig.LoadKubeletConfig(f, "worker", directory)
+	if err != nil {
+		return true, err
+	}
+
 	fileList, err := f.FetchByPattern(filepath.Join(directory, workerMachineSetFileNamePattern))
 	if err != nil {
 		return true, err
@@ -1,6 +1,7 @@
 package machines
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/rhcos"
+	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
@@ -157,7 +159,7 @@ spec:
 							},
 						},
 					}),
-				(*rhcos.Image)(pointer.StringPtr("test-image")),
+				&rhcos.Image{ControlPlane: "test-image", Compute: "test-image"},
 				(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 				&machine.Worker{
 					File: &asset.File{
@@ -165,9 +167,10 @@ spec:
 						Data:     []byte("test-ignition"),
 					},
 				},
+				&tls.CoreSSHKeyPair{},
 			)
 			worker := &Worker{}
-			if err := worker.Generate(parents); err != nil {
+			if err := worker.Generate(context.Background(), parents); err != nil {
 				t.Fatalf("failed to generate worker machines: %v", err)
 			}
 			expectedLen := len(tc.expectedMachineConfig)
@@ -219,7 +222,7 @@ func TestComputeIsNotModified(t *testing.T) {
 			InfraID: "test-infra-id",
 		},
 		installConfig,
-		(*rhcos.Image)(pointer.StringPtr("test-image")),
+		&rhcos.Image{ControlPlane: "test-image", Compute: "test-image"},
 		(*rhcos.Release)(pointer.StringPtr("412.86.202208101040-0")),
 		&machine.Worker{
 			File: &asset.File{
@@ -227,9 +230,10 @@ func TestComputeIsNotModified(t *testing.T) {
 				Data:     []byte("test-ignition"),
 			},
 		},
+		&tls.CoreSSHKeyPair{},
 	)
 	worker := &Worker{}
-	if err := worker.Generate(parents); err != nil {
+	if err := worker.Generate(context.Background(), parents); err != nil {
 		t.Fatalf("failed to generate master machines: %v", err)
 	}
 
@@ -0,0 +1,139 @@
+package manifests
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+var apiServerConfigFileName = filepath.Join(manifestDir, "cluster-apiserver-02-config.yml")
+
+// APIServer generates the cluster-apiserver-*.yml files.
+type APIServer struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*APIServer)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*APIServer) Name() string {
+	return "API Server Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*APIServer) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the APIServer config, serving the user-provided API
+// certificate for the API hostname and applying the audit configuration,
+// or the audit profile of the hardening profile, and the etcd encryption.
+// Nothing is generated when the install-config sets none of them.
+func (a *APIServer) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	a.FileList = []*asset.File{}
+	certs := installConfig.Config.ServingCertificates
+	audit := installConfig.Config.Audit
+	hardened := installConfig.Config.HardeningProfile == types.HardeningProfileCIS
+	encryption := installConfig.Config.EtcdEncryption
+	if (certs == nil || certs.APIServer == nil) && audit == nil && !hardened && encryption == nil {
+		return nil
+	}
+
+	config := &configv1.APIServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.GroupVersion.String(),
+			Kind:       "APIServer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+	}
+
+	if certs != nil && certs.APIServer != nil {
+		config.Spec.ServingCerts = configv1.APIServerServingCerts{
+			NamedCertificates: []configv1.APIServerNamedServingCert{{
+				Names: []string{installConfig.Config.APIHostname()},
+				ServingCertificate: configv1.SecretNameReference{
+					Name: apiServerServingCertSecretName,
+				},
+			}},
+		}
+	}
+
+	if audit != nil {
+		config.Spec.Audit = configv1.Audit{
+			Profile:     audit.Profile,
+			CustomRules: audit.CustomRules,
+		}
+	}
+	if config.Spec.Audit.Profile == "" && hardened {
+		// The CIS benchmark requires the request bodies of the changes to
+		// be logged.
+		config.Spec.Audit.Profile = configv1.WriteRequestBodiesAuditProfileType
+	}
+	if config.Spec.Audit.Profile == "" && audit != nil {
+		config.Spec.Audit.Profile = configv1.DefaultAuditProfileType
+	}
+
+	var obj interface{} = config
+	if encryption != nil {
+		config.Spec.Encryption.Type = configv1.EncryptionType(encryption.Type)
+		if encryption.Type == types.EtcdEncryptionTypeKMS {
+			// The vendored APIServer API predates the KMS encryption type,
+			// so the encryption is set on the unstructured config.
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(config)
+			if err != nil {
+				return errors.Wrap(err, "failed to convert API server config")
+			}
+			kms := map[string]interface{}{
+				"type": "AWS",
+				"aws": map[string]interface{}{
+					"keyARN": encryption.KMS.AWS.KeyARN,
+					"region": installConfig.Config.AWS.Region,
+				},
+			}
+			if err := unstructured.SetNestedField(u, kms, "spec", "encryption", "kms"); err != nil {
+				return errors.Wrap(err, "failed to set the KMS encryption of the API server config")
+			}
+			obj = u
+		}
+	}
+
+	configData, err := yaml.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "failed to create API server config")
+	}
+	a.FileList = append(a.FileList, &asset.File{
+		Filename: apiServerConfigFileName,
+		Data:     configData,
+	})
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *APIServer) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (a *APIServer) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,151 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestGenerateAPIServer(t *testing.T) {
+	cases := []struct {
+		name         string
+		certs        *types.ServingCertificates
+		audit        *types.Audit
+		hardening    types.HardeningProfile
+		encryption   *types.EtcdEncryption
+		expectedSpec *configv1.APIServerSpec
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:  "ingress certificate only",
+			certs: &types.ServingCertificates{Ingress: &types.CertificateKeyPair{}},
+		},
+		{
+			name:  "api certificate",
+			certs: &types.ServingCertificates{APIServer: &types.CertificateKeyPair{}},
+			expectedSpec: &configv1.APIServerSpec{
+				ServingCerts: configv1.APIServerServingCerts{
+					NamedCertificates: []configv1.APIServerNamedServingCert{{
+						Names:              []string{"api.test-cluster.test-domain"},
+						ServingCertificate: configv1.SecretNameReference{Name: "api-serving-cert"},
+					}},
+				},
+			},
+		},
+		{
+			name:  "audit custom rules",
+			audit: &types.Audit{CustomRules: []configv1.AuditCustomRule{{Group: "system:authenticated:oauth", Profile: configv1.AllRequestBodiesAuditProfileType}}},
+			expectedSpec: &configv1.APIServerSpec{
+				Audit: configv1.Audit{
+					Profile:     configv1.DefaultAuditProfileType,
+					CustomRules: []configv1.AuditCustomRule{{Group: "system:authenticated:oauth", Profile: configv1.AllRequestBodiesAuditProfileType}},
+				},
+			},
+		},
+		{
+			name:  "api certificate and audit profile",
+			certs: &types.ServingCertificates{APIServer: &types.CertificateKeyPair{}},
+			audit: &types.Audit{Profile: configv1.WriteRequestBodiesAuditProfileType},
+			expectedSpec: &configv1.APIServerSpec{
+				ServingCerts: configv1.APIServerServingCerts{
+					NamedCertificates: []configv1.APIServerNamedServingCert{{
+						Names:              []string{"api.test-cluster.test-domain"},
+						ServingCertificate: configv1.SecretNameReference{Name: "api-serving-cert"},
+					}},
+				},
+				Audit: configv1.Audit{Profile: configv1.WriteRequestBodiesAuditProfileType},
+			},
+		},
+		{
+			name:      "CIS hardening profile",
+			hardening: types.HardeningProfileCIS,
+			expectedSpec: &configv1.APIServerSpec{
+				Audit: configv1.Audit{Profile: configv1.WriteRequestBodiesAuditProfileType},
+			},
+		},
+		{
+			name:      "CIS hardening profile and audit profile",
+			hardening: types.HardeningProfileCIS,
+			audit:     &types.Audit{Profile: configv1.AllRequestBodiesAuditProfileType},
+			expectedSpec: &configv1.APIServerSpec{
+				Audit: configv1.Audit{Profile: configv1.AllRequestBodiesAuditProfileType},
+			},
+		},
+		{
+			name:       "etcd encryption",
+			encryption: &types.EtcdEncryption{Type: types.EtcdEncryptionTypeAESCBC},
+			expectedSpec: &configv1.APIServerSpec{
+				Encryption: configv1.APIServerEncryption{Type: configv1.EncryptionTypeAESCBC},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+				ObjectMeta:          metav1.ObjectMeta{Name: "test-cluster"},
+				BaseDomain:          "test-domain",
+				ServingCertificates: tc.certs,
+				Audit:               tc.audit,
+				HardeningProfile:    tc.hardening,
+				EtcdEncryption:      tc.encryption,
+			}))
+			apiServer := &APIServer{}
+			if !assert.NoError(t, apiServer.Generate(context.Background(), parents)) {
+				return
+			}
+			if tc.expectedSpec == nil {
+				assert.Empty(t, apiServer.Files())
+				return
+			}
+			if !assert.Len(t, apiServer.Files(), 1) {
+				return
+			}
+			config := &configv1.APIServer{}
+			if assert.NoError(t, yaml.Unmarshal(apiServer.Files()[0].Data, config)) {
+				assert.Equal(t, *tc.expectedSpec, config.Spec)
+			}
+		})
+	}
+}
+
+func TestGenerateAPIServerKMSEncryption(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+		Platform:   types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+		EtcdEncryption: &types.EtcdEncryption{
+			Type: types.EtcdEncryptionTypeKMS,
+			KMS:  &types.EtcdEncryptionKMS{AWS: &types.AWSKMSKey{KeyARN: "arn:aws:kms:us-east-1:123456789012:key/test"}},
+		},
+	}))
+	apiServer := &APIServer{}
+	if !assert.NoError(t, apiServer.Generate(context.Background(), parents)) || !assert.Len(t, apiServer.Files(), 1) {
+		return
+	}
+	config := map[string]interface{}{}
+	if assert.NoError(t, yaml.Unmarshal(apiServer.Files()[0].Data, &config)) {
+		assert.Equal(t, map[string]interface{}{
+			"type": "KMS",
+			"kms": map[string]interface{}{
+				"type": "AWS",
+				"aws": map[string]interface{}{
+					"keyARN": "arn:aws:kms:us-east-1:123456789012:key/test",
+					"region": "us-east-1",
+				},
+			},
+		}, config["spec"].(map[string]interface{})["encryption"])
+	}
+}
@@ -10,15 +10,16 @@ import (
 	"k8s.io/utils/ptr"
 	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
-	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 // GenerateClusterAssets generates the manifests for the cluster-api.
-func GenerateClusterAssets(ic *installconfig.InstallConfig, clusterID *installconfig.ClusterID) (*capiutils.GenerateClusterAssetsOutput, error) {
+func GenerateClusterAssets(ctx context.Context, ic *installconfig.InstallConfig, clusterID *installconfig.ClusterID) (*capiutils.GenerateClusterAssetsOutput, error) {
 	manifests := []*asset.RuntimeFile{}
 
 	tags, err := aws.CapaTagsFromUserTags(clusterID.InfraID, ic.Config.AWS.UserTags)
@@ -26,6 +27,12 @@ func GenerateClusterAssets(ic *installconfig.InstallConfig, clusterID *installco
 		return nil, fmt.Errorf("failed to get user tags: %w", err)
 	}
 
+	// The secondary control plane load balancer of CAPA, which is the
+	// external API load balancer, must be a Network Load Balancer.
+	if ic.Config.PublicAPI() && ic.Config.AWS.APILoadBalancerType() == configv1.Classic {
+		return nil, fmt.Errorf("a Classic external API load balancer is not supported when installing with Cluster API")
+	}
+
 	awsCluster := &capa.AWSCluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      clusterID.InfraID,
@@ -188,12 +195,13 @@ func GenerateClusterAssets(ic *installconfig.InstallConfig, clusterID *installco
 	}
 	awsCluster.SetGroupVersionKind(capa.GroupVersion.WithKind("AWSCluster"))
 
-	if ic.Config.Publish == types.ExternalPublishingStrategy {
+	if ic.Config.PublicAPI() {
 		awsCluster.Spec.SecondaryControlPlaneLoadBalancer = &capa.AWSLoadBalancerSpec{
 			Name:                   ptr.To(clusterID.InfraID + "-ext"),
 			LoadBalancerType:       capa.LoadBalancerTypeNLB,
 			Scheme:                 &capa.ELBSchemeInternetFacing,
 			CrossZoneLoadBalancing: true,
+			Subnets:                apiLoadBalancerSubnets(ic.Config.AWS),
 			HealthCheckProtocol:    &capa.ELBProtocolHTTPS,
 			HealthCheck: &capa.TargetGroupHealthCheckAPISpec{
 				IntervalSeconds:         ptr.To[int64](10),
@@ -215,7 +223,7 @@ func GenerateClusterAssets(ic *installconfig.InstallConfig, clusterID *installco
 
 	// Set the NetworkSpec.Subnets from VPC and zones (managed)
 	// or subnets (BYO VPC) based in the install-config.yaml.
-	err = setSubnets(context.TODO(), &zonesInput{
+	err = setSubnets(ctx, &zonesInput{
 		InstallConfig: ic,
 		ClusterID:     clusterID,
 		Cluster:       awsCluster,
@@ -256,3 +264,12 @@ func GenerateClusterAssets(ic *installconfig.InstallConfig, clusterID *installco
 		},
 	}, nil
 }
+
+// apiLoadBalancerSubnets returns the subnets of the external API load
+// balancer, or nil to let CAPA use the public subnets of the cluster.
+func apiLoadBalancerSubnets(platform *awstypes.Platform) []string {
+	if platform.APILoadBalancer == nil {
+		return nil
+	}
+	return platform.APILoadBalancer.Subnets
+}
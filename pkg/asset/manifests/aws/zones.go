@@ -209,7 +209,9 @@ func setSubnetsManagedVPC(in *zonesInput) error {
 		return fmt.Errorf("failed to get availability zones: %w", err)
 	}
 
-	isPublishingExternal := in.InstallConfig.Config.Publish == types.ExternalPublishingStrategy
+	// The public subnets host the load balancers of the endpoints published
+	// externally.
+	isPublishingExternal := in.InstallConfig.Config.PublicAPI() || in.InstallConfig.Config.PublicIngress()
 	allAvailabilityZones := out.GetAvailabilityZones()
 	allEdgeZones := out.GetEdgeZones()
 
@@ -68,8 +68,11 @@ func (params CloudProviderConfig) JSON() (string, error) {
 		config.authConfig.UseManagedIdentityExtension = false
 	}
 
+	// The endpoint of Azure Stack, or the override of the endpoint of the
+	// cloud environment.
+	config.authConfig.ResourceManagerEndpoint = params.ResourceManagerEndpoint
+
 	if params.CloudName == azure.StackCloud {
-		config.authConfig.ResourceManagerEndpoint = params.ResourceManagerEndpoint
 		config.authConfig.UseManagedIdentityExtension = false
 		config.LoadBalancerSku = "basic"
 		config.UseInstanceMetadata = false
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils/cidr"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
 )
 
 // GenerateClusterAssets generates the manifests for the cluster-api.
@@ -37,6 +38,23 @@ func GenerateClusterAssets(installConfig *installconfig.InstallConfig, clusterID
 		File:   asset.File{Filename: "00_azure-namespace.yaml"},
 	})
 
+	// CAPZ attaches a NAT gateway to the node subnet by default. The control
+	// plane subnet needs one as well when the egress of the cluster goes
+	// through NAT gateways rather than the outbound rules of the public
+	// load balancer.
+	var controlPlaneNatGateway capz.NatGateway
+	if installConfig.Config.Azure.OutboundType == azuretypes.NatGatewayOutboundType {
+		natGatewayName := fmt.Sprintf("%s-natgw", clusterID.InfraID)
+		controlPlaneNatGateway = capz.NatGateway{
+			NatGatewayIP: capz.PublicIPSpec{
+				Name: fmt.Sprintf("%s-pip", natGatewayName),
+			},
+			NatGatewayClassSpec: capz.NatGatewayClassSpec{
+				Name: natGatewayName,
+			},
+		}
+	}
+
 	resourceGroup := installConfig.Config.Platform.Azure.ClusterResourceGroupName(clusterID.InfraID)
 	azureCluster := &capz.AzureCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -86,6 +104,7 @@ func GenerateClusterAssets(installConfig *installconfig.InstallConfig, clusterID
 								subnets[0].String(),
 							},
 						},
+						NatGateway: controlPlaneNatGateway,
 					},
 					{
 						SubnetClassSpec: capz.SubnetClassSpec{
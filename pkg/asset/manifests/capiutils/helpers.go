@@ -34,3 +34,15 @@ func IsEnabled(installConfig *installconfig.InstallConfig) bool {
 func GenerateBoostrapMachineName(infraID string) string {
 	return infraID + "-bootstrap"
 }
+
+// GenerateBastionMachineName generates the name of the Cluster API Machine of
+// the SSH bastion host from the cluster ID.
+func GenerateBastionMachineName(infraID string) string {
+	return infraID + "-bastion"
+}
+
+// BastionNetworkTag returns the GCP network tag of the SSH bastion host,
+// targeted by the firewall rule allowing SSH from anywhere.
+func BastionNetworkTag(infraID string) string {
+	return infraID + "-bastion"
+}
@@ -75,7 +75,7 @@ func (*CloudProviderConfig) Dependencies() []asset.Asset {
 }
 
 // Generate generates the CloudProviderConfig.
-func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
+func (cpc *CloudProviderConfig) Generate(ctx context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	clusterID := &installconfig.ClusterID{}
 	dependencies.Get(installConfig, clusterID)
@@ -137,6 +137,10 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 		if installConfig.Config.Azure.ComputeSubnet != "" {
 			subnet = installConfig.Config.Azure.ComputeSubnet
 		}
+		armEndpoint := installConfig.Config.Azure.ARMEndpoint
+		if armEndpoint == "" {
+			armEndpoint = installConfig.Config.Azure.ServiceEndpointURL(azuretypes.ResourceManagerServiceEndpoint)
+		}
 		azureConfig, err := azure.CloudProviderConfig{
 			CloudName:                installConfig.Config.Azure.CloudName,
 			ResourceGroupName:        installConfig.Config.Azure.ClusterResourceGroupName(clusterID.InfraID),
@@ -148,7 +152,7 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 			NetworkSecurityGroupName: nsg,
 			VirtualNetworkName:       vnet,
 			SubnetName:               subnet,
-			ResourceManagerEndpoint:  installConfig.Config.Azure.ARMEndpoint,
+			ResourceManagerEndpoint:  armEndpoint,
 			ARO:                      installConfig.Config.Azure.IsARO(),
 		}.JSON()
 		if err != nil {
@@ -168,19 +172,19 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 		if installConfig.Config.GCP.ComputeSubnet != "" {
 			subnet = installConfig.Config.GCP.ComputeSubnet
 		}
-		gcpConfig, err := gcpmanifests.CloudProviderConfig(clusterID.InfraID, installConfig.Config.GCP.ProjectID, subnet, installConfig.Config.GCP.NetworkProjectID)
+		gcpConfig, err := gcpmanifests.CloudProviderConfig(clusterID.InfraID, installConfig.Config.GCP.ProjectID, subnet, installConfig.Config.GCP.NetworkProjectID, installConfig.Config.GCP.ServiceEndpointURL(gcptypes.ComputeServiceEndpoint))
 		if err != nil {
 			return errors.Wrap(err, "could not create cloud provider config")
 		}
 		cm.Data[cloudProviderConfigDataKey] = gcpConfig
 	case ibmcloudtypes.Name:
-		accountID, err := installConfig.IBMCloud.AccountID(context.TODO())
+		accountID, err := installConfig.IBMCloud.AccountID(ctx)
 		if err != nil {
 			return err
 		}
 
 		subnetNames := []string{}
-		cpSubnets, err := installConfig.IBMCloud.ControlPlaneSubnets(context.TODO())
+		cpSubnets, err := installConfig.IBMCloud.ControlPlaneSubnets(ctx)
 		if err != nil {
 			return errors.Wrap(err, "could not retrieve IBM Cloud control plane subnets")
 		}
@@ -188,7 +192,7 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 			subnetNames = append(subnetNames, cpSubnet.Name)
 		}
 
-		computeSubnets, err := installConfig.IBMCloud.ComputeSubnets(context.TODO())
+		computeSubnets, err := installConfig.IBMCloud.ComputeSubnets(ctx)
 		if err != nil {
 			return errors.Wrap(err, "could not retrieve IBM Cloud compute subnets")
 		}
@@ -237,7 +241,7 @@ func (cpc *CloudProviderConfig) Generate(dependencies asset.Parents) error {
 			err                  error
 		)
 
-		if accountID, err = installConfig.PowerVS.AccountID(context.TODO()); err != nil {
+		if accountID, err = installConfig.PowerVS.AccountID(ctx); err != nil {
 			return err
 		}
 
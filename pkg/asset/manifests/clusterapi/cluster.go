@@ -1,6 +1,7 @@
 package clusterapi
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -63,7 +64,7 @@ func (c *Cluster) Dependencies() []asset.Asset {
 }
 
 // Generate generates the respective operator config.yml files.
-func (c *Cluster) Generate(dependencies asset.Parents) error {
+func (c *Cluster) Generate(ctx context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	clusterID := &installconfig.ClusterID{}
 	openshiftInstall := &openshiftinstall.Config{}
@@ -104,7 +105,7 @@ func (c *Cluster) Generate(dependencies asset.Parents) error {
 	switch platform := installConfig.Config.Platform.Name(); platform {
 	case awstypes.Name:
 		var err error
-		out, err = aws.GenerateClusterAssets(installConfig, clusterID)
+		out, err = aws.GenerateClusterAssets(ctx, installConfig, clusterID)
 		if err != nil {
 			return errors.Wrap(err, "failed to generate AWS manifests")
 		}
@@ -134,8 +135,8 @@ func (c *Cluster) Generate(dependencies asset.Parents) error {
 		}
 	case powervstypes.Name:
 		var err error
-		osImage := strings.SplitN(string(*rhcosImage), "/", 2)
-		out, err = powervs.GenerateClusterAssets(installConfig, clusterID, osImage[0], osImage[1])
+		osImage := strings.SplitN(rhcosImage.ControlPlane, "/", 2)
+		out, err = powervs.GenerateClusterAssets(ctx, installConfig, clusterID, osImage[0], osImage[1])
 		if err != nil {
 			return fmt.Errorf("failed to generate PowerVS manifests %w", err)
 		}
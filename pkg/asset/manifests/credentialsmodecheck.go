@@ -0,0 +1,165 @@
+package manifests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+// credentialsSecretKeys are the sets of keys, any of which identifies a
+// secret holding the credentials of a CredentialsRequest, per platform: the
+// keys of the secrets ccoctl creates and, on AWS, the static credentials keys.
+var credentialsSecretKeys = map[string][][]string{
+	awstypes.Name:   {{"credentials"}, {"aws_access_key_id", "aws_secret_access_key"}},
+	azuretypes.Name: {{"azure_client_id"}},
+	gcptypes.Name:   {{"service_account.json"}},
+}
+
+// CredentialsModeCheck verifies, in the Manual credentials mode, that the
+// service account signing key matches the use of short-lived tokens (AWS STS,
+// GCP Workload Identity and Azure AD Workload Identity), and warns when the
+// manifests contain no credentials for the cluster components.
+type CredentialsModeCheck struct {
+}
+
+var _ asset.Asset = (*CredentialsModeCheck)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (*CredentialsModeCheck) Name() string {
+	return "Credentials Mode Check"
+}
+
+// Dependencies returns the dependencies for CredentialsModeCheck.
+func (*CredentialsModeCheck) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&Manifests{},
+		&Openshift{},
+		&tls.BoundSASigningKey{},
+	}
+}
+
+// Generate verifies the credentials provided for the Manual credentials mode.
+func (*CredentialsModeCheck) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	manifests := &Manifests{}
+	openshift := &Openshift{}
+	signingKey := &tls.BoundSASigningKey{}
+	dependencies.Get(installConfig, manifests, openshift, signingKey)
+
+	config := installConfig.Config
+	if config.CredentialsMode != types.ManualCredentialsMode {
+		return nil
+	}
+	secretKeys, ok := credentialsSecretKeys[config.Platform.Name()]
+	if !ok {
+		return nil
+	}
+
+	files := append(append([]*asset.File{}, manifests.Files()...), openshift.Files()...)
+	issuer, secrets := inspectCredentialsManifests(files, secretKeys)
+	hasSigningKey := len(signingKey.Files()) > 0
+
+	switch {
+	case issuer != "" && !hasSigningKey:
+		return errors.Errorf("the manifests set the service account issuer to %s, but tls/bound-service-account-signing-key.key is missing. Copy the private key generated by ccoctl to the tls directory.\n%s", issuer, ccoctlHint(config))
+	case issuer == "" && hasSigningKey:
+		return errors.Errorf("tls/bound-service-account-signing-key.key is provided, but no manifest sets the service account issuer of the cluster Authentication. Copy the cluster-authentication-02-config.yaml manifest generated by ccoctl to the manifests directory.\n%s", ccoctlHint(config))
+	case secrets == 0:
+		// The secrets may be created by other means once the cluster is up.
+		logrus.Warnf("credentialsMode is Manual, but the manifests contain no %s credentials secret for the cluster components.\n%s", config.Platform.Name(), ccoctlHint(config))
+		return nil
+	}
+	logrus.Debugf("Found %d credentials secrets in the manifests", secrets)
+	return nil
+}
+
+// inspectCredentialsManifests returns the service account issuer set by the
+// manifests, and the number of secrets with any of the sets of credentials
+// keys.
+func inspectCredentialsManifests(files []*asset.File, secretKeys [][]string) (string, int) {
+	issuer := ""
+	secrets := 0
+	for _, file := range files {
+		for _, doc := range bytes.Split(file.Data, []byte("\n---")) {
+			obj := struct {
+				Kind       string            `json:"kind"`
+				Data       map[string]string `json:"data"`
+				StringData map[string]string `json:"stringData"`
+				Spec       struct {
+					ServiceAccountIssuer string `json:"serviceAccountIssuer"`
+				} `json:"spec"`
+			}{}
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				// Only the secrets and the authentication config
+				// matter, other manifests may use any schema.
+				logrus.Debugf("Skipping %s in the credentials check: %v", file.Filename, err)
+				continue
+			}
+			switch obj.Kind {
+			case "Authentication":
+				if obj.Spec.ServiceAccountIssuer != "" {
+					issuer = obj.Spec.ServiceAccountIssuer
+				}
+			case "Secret":
+				if hasSecretKeys(obj.Data, obj.StringData, secretKeys) {
+					secrets++
+				}
+			}
+		}
+	}
+	return issuer, secrets
+}
+
+// hasSecretKeys returns true if the data of a secret holds all the keys of
+// any of the sets of keys.
+func hasSecretKeys(data, stringData map[string]string, secretKeys [][]string) bool {
+	for _, keys := range secretKeys {
+		found := true
+		for _, key := range keys {
+			_, inData := data[key]
+			_, inStringData := stringData[key]
+			if !inData && !inStringData {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// ccoctlHint returns the commands creating the credentials of the cluster
+// components with ccoctl.
+func ccoctlHint(config *types.InstallConfig) string {
+	var create string
+	switch config.Platform.Name() {
+	case awstypes.Name:
+		create = fmt.Sprintf("ccoctl aws create-all --name=%s --region=%s --credentials-requests-dir=credrequests --output-dir=ccoctl-output", config.ObjectMeta.Name, config.AWS.Region)
+	case azuretypes.Name:
+		create = fmt.Sprintf("ccoctl azure create-all --name=%s --region=%s --subscription-id=<subscription ID> --tenant-id=<tenant ID> --dnszone-resource-group-name=%s --credentials-requests-dir=credrequests --output-dir=ccoctl-output", config.ObjectMeta.Name, config.Azure.Region, config.Azure.BaseDomainResourceGroupName)
+	case gcptypes.Name:
+		create = fmt.Sprintf("ccoctl gcp create-all --name=%s --region=%s --project=%s --credentials-requests-dir=credrequests --output-dir=ccoctl-output", config.ObjectMeta.Name, config.GCP.Region, config.GCP.ProjectID)
+	}
+	return strings.Join([]string{
+		"Create the credentials with:",
+		"  oc adm release extract --credentials-requests --included --install-config=<installation directory>/install-config.yaml --to=credrequests <release image>",
+		"  " + create,
+		"and copy ccoctl-output/manifests to the manifests directory and ccoctl-output/tls to the tls directory of the installation.",
+	}, "\n")
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
@@ -34,7 +36,8 @@ import (
 )
 
 var (
-	dnsCfgFilename = filepath.Join(manifestDir, "cluster-dns-02-config.yml")
+	dnsCfgFilename     = filepath.Join(manifestDir, "cluster-dns-02-config.yml")
+	defaultDNSFilename = filepath.Join(manifestDir, "cluster-dns-default-dns.yaml")
 
 	combineGCPZoneInfo = func(project, zoneName string) string {
 		return fmt.Sprintf("project/%s/managedZones/%s", project, zoneName)
@@ -47,6 +50,12 @@ type DNS struct {
 }
 
 var _ asset.WritableAsset = (*DNS)(nil)
+var _ asset.TimedAsset = (*DNS)(nil)
+
+// GenerateTimeout returns how long the DNS zones may take to be looked up.
+func (*DNS) GenerateTimeout() time.Duration {
+	return 5 * time.Minute
+}
 
 // Name returns a human friendly name for the asset.
 func (*DNS) Name() string {
@@ -66,8 +75,9 @@ func (*DNS) Dependencies() []asset.Asset {
 	}
 }
 
-// Generate generates the DNS config and its CRD.
-func (d *DNS) Generate(dependencies asset.Parents) error {
+// Generate generates the DNS config and its CRD, and the default DNS of the
+// DNS operator when the install-config configures the cluster DNS.
+func (d *DNS) Generate(ctx context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	clusterID := &installconfig.ClusterID{}
 	dependencies.Get(installConfig, clusterID)
@@ -88,8 +98,10 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 
 	switch installConfig.Config.Platform.Name() {
 	case awstypes.Name:
-		if installConfig.Config.Publish == types.ExternalPublishingStrategy {
-			sess, err := installConfig.AWS.Session(context.TODO())
+		// The public zone is used by the ingress operator, to publish the
+		// records of the default ingress controller.
+		if installConfig.Config.PublicIngress() {
+			sess, err := installConfig.AWS.Session(ctx)
 			if err != nil {
 				return errors.Wrap(err, "failed to initialize session")
 			}
@@ -122,8 +134,7 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 			return err
 		}
 
-		if installConfig.Config.Publish == types.ExternalPublishingStrategy ||
-			(installConfig.Config.Publish == types.MixedPublishingStrategy && installConfig.Config.OperatorPublishingStrategy.Ingress != "Internal") {
+		if installConfig.Config.PublicIngress() {
 			//currently, this guesses the azure resource IDs from known parameter.
 			config.Spec.PublicZone = &configv1.DNSZone{
 				ID: dnsConfig.GetDNSZoneID(installConfig.Config.Azure.BaseDomainResourceGroupName, installConfig.Config.BaseDomain),
@@ -137,23 +148,23 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 	case gcptypes.Name:
 		// We donot want to configure cloud DNS when `UserProvisionedDNS` is enabled.
 		// So, do not set PrivateZone and PublicZone fields in the DNS manifest.
-		if installConfig.Config.GCP.UserProvisionedDNS == gcptypes.UserProvisionedDNSEnabled {
+		if installConfig.Config.ClusterHostedDNS() {
 			config.Spec.PublicZone = &configv1.DNSZone{ID: ""}
 			config.Spec.PrivateZone = &configv1.DNSZone{ID: ""}
 			break
 		}
-		client, err := icgcp.NewClient(context.Background())
+		client, err := icgcp.NewClient(ctx, installConfig.Config.GCP.ServiceEndpoints)
 		if err != nil {
 			return err
 		}
 
 		// Set the public zone
 		switch {
-		case installConfig.Config.Publish != types.ExternalPublishingStrategy:
-			// Do not use a public zone when not publishing externally.
+		case !installConfig.Config.PublicIngress():
+			// Do not use a public zone when not publishing the ingress externally.
 		default:
 			// Search the project for a zone with the specified base domain.
-			zone, err := client.GetDNSZone(context.TODO(), installConfig.Config.GCP.ProjectID, installConfig.Config.BaseDomain, true)
+			zone, err := client.GetDNSZone(ctx, installConfig.Config.GCP.ProjectID, installConfig.Config.BaseDomain, true)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get public zone for %q", installConfig.Config.BaseDomain)
 			}
@@ -162,7 +173,7 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 
 		// Set the private zone
 		privateZoneID := fmt.Sprintf("%s-private-zone", clusterID.InfraID)
-		zone, err := client.GetDNSZone(context.TODO(), installConfig.Config.GCP.ProjectID, installConfig.Config.ClusterDomain(), false)
+		zone, err := client.GetDNSZone(ctx, installConfig.Config.GCP.ProjectID, installConfig.Config.ClusterDomain(), false)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get private zone for %q", installConfig.Config.BaseDomain)
 		}
@@ -177,7 +188,7 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "failed to get IBM Cloud client")
 		}
 
-		zoneID, err := client.GetDNSZoneIDByName(context.TODO(), installConfig.Config.BaseDomain, installConfig.Config.Publish)
+		zoneID, err := client.GetDNSZoneIDByName(ctx, installConfig.Config.BaseDomain, installConfig.Config.Publish)
 		if err != nil {
 			return errors.Wrap(err, "failed to get DNS zone ID")
 		}
@@ -196,7 +207,7 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 			return errors.Wrap(err, "failed to get IBM PowerVS client")
 		}
 
-		zoneID, err := client.GetDNSZoneIDByName(context.TODO(), installConfig.Config.BaseDomain, installConfig.Config.Publish)
+		zoneID, err := client.GetDNSZoneIDByName(ctx, installConfig.Config.BaseDomain, installConfig.Config.Publish)
 		if err != nil {
 			return errors.Wrap(err, "failed to get DNS zone ID")
 		}
@@ -226,9 +237,66 @@ func (d *DNS) Generate(dependencies asset.Parents) error {
 		},
 	}
 
+	if clusterDNS := installConfig.Config.ClusterDNS; clusterDNS != nil && (len(clusterDNS.Forwarders) > 0 || clusterDNS.UpstreamResolvers != nil) {
+		defaultDNSData, err := generateDefaultDNS(clusterDNS)
+		if err != nil {
+			return errors.Wrap(err, "failed to create default DNS")
+		}
+		d.FileList = append(d.FileList, &asset.File{
+			Filename: defaultDNSFilename,
+			Data:     defaultDNSData,
+		})
+	}
+
 	return nil
 }
 
+// generateDefaultDNS renders the forwarding of the cluster DNS in the default
+// DNS of the DNS operator, which serves the cluster.local domain.
+func generateDefaultDNS(clusterDNS *types.ClusterDNS) ([]byte, error) {
+	obj := &operatorv1.DNS{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1.GroupVersion.String(),
+			Kind:       "DNS",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+			// not namespaced
+		},
+	}
+	for _, forwarder := range clusterDNS.Forwarders {
+		obj.Spec.Servers = append(obj.Spec.Servers, operatorv1.Server{
+			Name:  forwarder.Name,
+			Zones: forwarder.Zones,
+			ForwardPlugin: operatorv1.ForwardPlugin{
+				Upstreams: forwarder.Upstreams,
+				Policy:    forwarder.Policy,
+			},
+		})
+	}
+	if resolvers := clusterDNS.UpstreamResolvers; resolvers != nil {
+		obj.Spec.UpstreamResolvers.Policy = resolvers.Policy
+		for _, upstream := range resolvers.Upstreams {
+			address, port, err := types.SplitDNSUpstream(upstream)
+			if err != nil {
+				return nil, err
+			}
+			obj.Spec.UpstreamResolvers.Upstreams = append(obj.Spec.UpstreamResolvers.Upstreams, operatorv1.Upstream{
+				Type:    operatorv1.NetworkResolverType,
+				Address: address,
+				Port:    port,
+			})
+		}
+	} else {
+		// Keep the default of the DNS operator, the name servers of the
+		// nodes.
+		obj.Spec.UpstreamResolvers.Upstreams = []operatorv1.Upstream{{
+			Type: operatorv1.SystemResolveConfType,
+		}}
+	}
+	return yaml.Marshal(obj)
+}
+
 // Files returns the files generated by the asset.
 func (d *DNS) Files() []*asset.File {
 	return d.FileList
@@ -0,0 +1,98 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestGenerateDefaultDNS(t *testing.T) {
+	cases := []struct {
+		name         string
+		clusterDNS   *types.ClusterDNS
+		expectedSpec *operatorv1.DNSSpec
+	}{
+		{
+			name: "no cluster DNS",
+		},
+		{
+			name: "forwarders",
+			clusterDNS: &types.ClusterDNS{
+				Forwarders: []types.DNSForwarder{{
+					Name:      "corp",
+					Zones:     []string{"corp.example.com"},
+					Upstreams: []string{"10.0.0.53", "10.0.1.53:5353"},
+				}},
+			},
+			expectedSpec: &operatorv1.DNSSpec{
+				Servers: []operatorv1.Server{{
+					Name:  "corp",
+					Zones: []string{"corp.example.com"},
+					ForwardPlugin: operatorv1.ForwardPlugin{
+						Upstreams: []string{"10.0.0.53", "10.0.1.53:5353"},
+					},
+				}},
+				UpstreamResolvers: operatorv1.UpstreamResolvers{
+					Upstreams: []operatorv1.Upstream{{Type: operatorv1.SystemResolveConfType}},
+				},
+			},
+		},
+		{
+			name: "upstream resolvers",
+			clusterDNS: &types.ClusterDNS{
+				UpstreamResolvers: &types.DNSUpstreamResolvers{
+					Upstreams: []string{"10.0.0.53", "[fd00::53]:5353"},
+					Policy:    operatorv1.RoundRobinForwardingPolicy,
+				},
+			},
+			expectedSpec: &operatorv1.DNSSpec{
+				UpstreamResolvers: operatorv1.UpstreamResolvers{
+					Upstreams: []operatorv1.Upstream{
+						{Type: operatorv1.NetworkResolverType, Address: "10.0.0.53"},
+						{Type: operatorv1.NetworkResolverType, Address: "fd00::53", Port: 5353},
+					},
+					Policy: operatorv1.RoundRobinForwardingPolicy,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(
+				installconfig.MakeAsset(&types.InstallConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					BaseDomain: "test-domain",
+					Platform:   types.Platform{None: &none.Platform{}},
+					ClusterDNS: tc.clusterDNS,
+				}),
+				&installconfig.ClusterID{InfraID: "test-infra-id"},
+			)
+			dns := &DNS{}
+			if !assert.NoError(t, dns.Generate(context.Background(), parents)) {
+				return
+			}
+			if tc.expectedSpec == nil {
+				assert.Len(t, dns.Files(), 1)
+				return
+			}
+			if !assert.Len(t, dns.Files(), 2) {
+				return
+			}
+			assert.Equal(t, "manifests/cluster-dns-default-dns.yaml", dns.Files()[1].Filename)
+			config := &operatorv1.DNS{}
+			if assert.NoError(t, yaml.Unmarshal(dns.Files()[1].Data, config)) {
+				assert.Equal(t, *tc.expectedSpec, config.Spec)
+			}
+		})
+	}
+}
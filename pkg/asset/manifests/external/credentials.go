@@ -0,0 +1,43 @@
+package external
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+const (
+	// CloudControllerManagerNamespace is the namespace the external cloud
+	// controller manager is expected to run in.
+	CloudControllerManagerNamespace = "openshift-cloud-controller-manager"
+
+	// CloudControllerManagerCredentialsSecretName is the name of the secret
+	// holding the credentials of the external cloud controller manager.
+	CloudControllerManagerCredentialsSecretName = "cloud-controller-manager-credentials"
+)
+
+// CloudControllerManagerCredentialsSecret renders the secret holding the
+// credentials of the external cloud controller manager from the install-config.
+// It returns nil when the install-config sets no credentials.
+func CloudControllerManagerCredentialsSecret(ic *installconfig.InstallConfig) ([]byte, error) {
+	credentials := ic.Config.External.CloudControllerManagerCredentials
+	if len(credentials) == 0 {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: CloudControllerManagerNamespace,
+			Name:      CloudControllerManagerCredentialsSecretName,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: credentials,
+	}
+	return yaml.Marshal(secret)
+}
@@ -24,10 +24,14 @@ type global struct {
 	SubnetworkName string `gcfg:"subnetwork-name"`
 
 	NetworkProjectID string `gcfg:"network-project-id"`
+
+	APIEndpoint string `gcfg:"api-endpoint"`
 }
 
 // CloudProviderConfig generates the cloud provider config for the GCP platform.
-func CloudProviderConfig(infraID, projectID, subnet, networkProjectID string) (string, error) {
+// The API endpoint overrides the default endpoint of the Compute Engine API
+// when it is not empty.
+func CloudProviderConfig(infraID, projectID, subnet, networkProjectID, apiEndpoint string) (string, error) {
 	config := &config{
 		Global: global{
 			ProjectID: projectID,
@@ -46,6 +50,9 @@ func CloudProviderConfig(infraID, projectID, subnet, networkProjectID string) (s
 
 			// Used for shared vpc installations,
 			NetworkProjectID: networkProjectID,
+
+			// Used for private endpoints of the Compute Engine API
+			APIEndpoint: apiEndpoint,
 		},
 	}
 
@@ -67,6 +74,7 @@ node-tags       = {{$tag}}
 node-instance-prefix = {{.Global.NodeInstancePrefix}}
 external-instance-groups-prefix = {{.Global.ExternalInstanceGroupsPrefix}}
 subnetwork-name = {{.Global.SubnetworkName}}
-{{ if ne .Global.NetworkProjectID "" }}network-project-id = {{.Global.NetworkProjectID}}{{end}}
+{{ if ne .Global.NetworkProjectID "" }}network-project-id = {{.Global.NetworkProjectID}}{{end}}{{ if ne .Global.APIEndpoint "" }}
+api-endpoint = {{.Global.APIEndpoint}}{{end}}
 
 `
@@ -0,0 +1,159 @@
+package manifests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// hardenedNamespaces are the openshift-* namespaces without workloads that
+// get a network policy, so that every namespace of the cluster has one as
+// required by the CIS benchmark. The namespaces running the cluster
+// operands are left to their operators.
+var hardenedNamespaces = []string{
+	"openshift",
+	"openshift-config",
+	"openshift-config-managed",
+	"openshift-infra",
+}
+
+// Hardening generates the manifests of the hardening profile.
+type Hardening struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Hardening)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*Hardening) Name() string {
+	return "Hardening Profile"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*Hardening) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the manifests of the CIS hardening profile: the kubelet
+// protections of the master and worker pools, the network policies of the
+// openshift-* namespaces without workloads and the self-provisioners
+// binding without subjects. Its audit profile is set by the APIServer asset.
+// Nothing is generated when the install-config sets no hardening profile.
+func (h *Hardening) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	h.FileList = []*asset.File{}
+	if installConfig.Config.HardeningProfile != types.HardeningProfileCIS {
+		return nil
+	}
+
+	resources := map[string]interface{}{}
+	kubeletConfig, err := json.Marshal(map[string]interface{}{
+		// Idle streaming connections, e.g. exec and port-forward, are
+		// closed after 5 minutes.
+		"streamingConnectionIdleTimeout": "5m0s",
+		// The kubelet manages the iptables chains of the hosts.
+		"makeIPTablesUtilChains": true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the hardened kubelet config")
+	}
+	for _, role := range []string{types.MachinePoolControlPlaneRoleName, types.MachinePoolComputeRoleName} {
+		resources[fmt.Sprintf("kubeletconfig-%s", role)] = &mcfgv1.KubeletConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: mcfgv1.GroupVersion.String(),
+				Kind:       "KubeletConfig",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("cis-hardening-%s", role),
+				// not namespaced
+			},
+			Spec: mcfgv1.KubeletConfigSpec{
+				MachineConfigPoolSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						fmt.Sprintf("pools.operator.machineconfiguration.openshift.io/%s", role): "",
+					},
+				},
+				KubeletConfig: &runtime.RawExtension{Raw: kubeletConfig},
+			},
+		}
+	}
+
+	for _, namespace := range hardenedNamespaces {
+		resources[fmt.Sprintf("networkpolicy-%s", namespace)] = &networkingv1.NetworkPolicy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: networkingv1.SchemeGroupVersion.String(),
+				Kind:       "NetworkPolicy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "default-deny-ingress",
+				Namespace: namespace,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}
+	}
+
+	// The binding is not reconciled by the API server once its auto-update
+	// is disabled, so that authenticated users cannot create projects.
+	resources["self-provisioners"] = &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "self-provisioners",
+			Annotations: map[string]string{
+				"rbac.authorization.kubernetes.io/autoupdate": "false",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "self-provisioner",
+		},
+	}
+
+	for name, resource := range resources {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create hardening manifest %s", name)
+		}
+		h.FileList = append(h.FileList, &asset.File{
+			Filename: filepath.Join(manifestDir, fmt.Sprintf("cluster-hardening-%s.yml", name)),
+			Data:     data,
+		})
+	}
+	asset.SortFiles(h.FileList)
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (h *Hardening) Files() []*asset.File {
+	return h.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (h *Hardening) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,54 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestGenerateHardening(t *testing.T) {
+	for _, profile := range []types.HardeningProfile{"", types.HardeningProfileNone} {
+		parents := asset.Parents{}
+		parents.Add(installconfig.MakeAsset(&types.InstallConfig{HardeningProfile: profile}))
+		hardening := &Hardening{}
+		assert.NoError(t, hardening.Generate(context.Background(), parents))
+		assert.Empty(t, hardening.Files())
+	}
+
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		ObjectMeta:       metav1.ObjectMeta{Name: "test-cluster"},
+		HardeningProfile: types.HardeningProfileCIS,
+	}))
+	hardening := &Hardening{}
+	if !assert.NoError(t, hardening.Generate(context.Background(), parents)) {
+		return
+	}
+	files := map[string][]byte{}
+	for _, f := range hardening.Files() {
+		files[f.Filename] = f.Data
+	}
+	assert.Len(t, files, 7)
+
+	kubeletConfig := &mcfgv1.KubeletConfig{}
+	if assert.NoError(t, yaml.Unmarshal(files["manifests/cluster-hardening-kubeletconfig-worker.yml"], kubeletConfig)) {
+		assert.Equal(t, map[string]string{"pools.operator.machineconfiguration.openshift.io/worker": ""}, kubeletConfig.Spec.MachineConfigPoolSelector.MatchLabels)
+		assert.JSONEq(t, `{"makeIPTablesUtilChains":true,"streamingConnectionIdleTimeout":"5m0s"}`, string(kubeletConfig.Spec.KubeletConfig.Raw))
+	}
+	assert.Contains(t, files, "manifests/cluster-hardening-networkpolicy-openshift-config.yml")
+
+	binding := &rbacv1.ClusterRoleBinding{}
+	if assert.NoError(t, yaml.Unmarshal(files["manifests/cluster-hardening-self-provisioners.yml"], binding)) {
+		assert.Equal(t, "false", binding.Annotations["rbac.authorization.kubernetes.io/autoupdate"])
+		assert.Empty(t, binding.Subjects)
+	}
+}
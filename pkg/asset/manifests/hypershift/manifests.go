@@ -0,0 +1,336 @@
+// Package hypershift generates the manifests of a cluster with a hosted
+// control plane, managed by HyperShift, from the install-config.
+package hypershift
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	awsdefaults "github.com/openshift/installer/pkg/types/aws/defaults"
+	nonetypes "github.com/openshift/installer/pkg/types/none"
+)
+
+const (
+	// Namespace is the namespace of the management cluster the hosted
+	// cluster resources are created in.
+	Namespace = "clusters"
+
+	hostedClusterDir = "hosted-cluster"
+
+	// defaultRootVolumeSize is the size, in GiB, of the root volume of the
+	// nodes, like for the machines of a standalone cluster.
+	defaultRootVolumeSize = 120
+)
+
+// Manifests generates the HostedCluster and NodePool manifests of the
+// cluster, and the secrets they reference.
+type Manifests struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Manifests)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*Manifests) Name() string {
+	return "Hosted Cluster Manifests"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*Manifests) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&installconfig.ClusterID{},
+		&releaseimage.Image{},
+		&tls.CoreSSHKeyPair{},
+	}
+}
+
+// Generate generates the hosted cluster manifests.
+func (h *Manifests) Generate(ctx context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	clusterID := &installconfig.ClusterID{}
+	releaseImage := &releaseimage.Image{}
+	coreSSHKeyPair := &tls.CoreSSHKeyPair{}
+	dependencies.Get(installConfig, clusterID, releaseImage, coreSSHKeyPair)
+	ic := installConfig.Config
+
+	switch ic.Platform.Name() {
+	case awstypes.Name, nonetypes.Name:
+	default:
+		return errors.Errorf("the hosted control planes topology is not supported on platform %s", ic.Platform.Name())
+	}
+
+	pullSecret := secret(ic.ObjectMeta.Name+"-pull-secret", corev1.DockerConfigJsonKey, ic.PullSecret)
+	pullSecret.Type = corev1.SecretTypeDockerConfigJson
+	sshKey := secret(ic.ObjectMeta.Name+"-ssh-key", "id_rsa.pub", strings.Join(coreSSHKeyPair.AuthorizedKeys(ic), "\n"))
+
+	releasePullSpec := releaseImage.PullSpecFor(ic)
+	hostedCluster, err := hostedClusterFor(ctx, installConfig, clusterID.InfraID, releasePullSpec)
+	if err != nil {
+		return err
+	}
+	hostedCluster.Spec.PullSecret.Name = pullSecret.Name
+	hostedCluster.Spec.SSHKey.Name = sshKey.Name
+
+	nodePools, err := nodePoolsFor(ctx, installConfig, clusterID.InfraID, releasePullSpec)
+	if err != nil {
+		return err
+	}
+
+	resources := map[string]interface{}{
+		"namespace.yaml": &corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: Namespace},
+		},
+		"pull-secret.yaml":   pullSecret,
+		"ssh-key.yaml":       sshKey,
+		"hostedcluster.yaml": hostedCluster,
+	}
+	for _, nodePool := range nodePools {
+		resources[fmt.Sprintf("nodepool-%s.yaml", nodePool.Name)] = nodePool
+	}
+
+	h.FileList = make([]*asset.File, 0, len(resources))
+	for name, resource := range resources {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %s", name)
+		}
+		h.FileList = append(h.FileList, &asset.File{
+			Filename: filepath.Join(hostedClusterDir, name),
+			Data:     data,
+		})
+	}
+	sort.Slice(h.FileList, func(i, j int) bool { return h.FileList[i].Filename < h.FileList[j].Filename })
+
+	if ic.Platform.AWS != nil {
+		logrus.Warnf("The IAM roles of the hosted control plane must be added to spec.platform.aws.rolesRef of %s before it is applied", filepath.Join(hostedClusterDir, "hostedcluster.yaml"))
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (h *Manifests) Files() []*asset.File {
+	return h.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (h *Manifests) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
+
+func secret(name string, key string, value string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: Namespace},
+		Data:       map[string][]byte{key: []byte(value)},
+	}
+}
+
+func hostedClusterFor(ctx context.Context, installConfig *installconfig.InstallConfig, infraID string, release string) (*HostedCluster, error) {
+	ic := installConfig.Config
+	hc := &HostedCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: "HostedCluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: ic.ObjectMeta.Name, Namespace: Namespace},
+		Spec: HostedClusterSpec{
+			Release: Release{Image: release},
+			InfraID: infraID,
+			DNS:     DNSSpec{BaseDomain: ic.BaseDomain},
+			Etcd:    EtcdSpec{ManagementType: "Managed"},
+			FIPS:    ic.FIPS,
+
+			ControllerAvailabilityPolicy:     string(configv1.HighlyAvailableTopologyMode),
+			InfrastructureAvailabilityPolicy: string(configv1.HighlyAvailableTopologyMode),
+		},
+	}
+	if ic.ControlPlane != nil && ic.ControlPlane.Replicas != nil && *ic.ControlPlane.Replicas == 1 {
+		hc.Spec.ControllerAvailabilityPolicy = string(configv1.SingleReplicaTopologyMode)
+	}
+	var computeReplicas int64
+	for _, pool := range ic.Compute {
+		if pool.Replicas != nil {
+			computeReplicas += *pool.Replicas
+		}
+	}
+	if computeReplicas < 2 {
+		hc.Spec.InfrastructureAvailabilityPolicy = string(configv1.SingleReplicaTopologyMode)
+	}
+
+	networking := &hc.Spec.Networking
+	networking.NetworkType = ic.Networking.NetworkType
+	for _, network := range ic.Networking.MachineNetwork {
+		networking.MachineNetwork = append(networking.MachineNetwork, MachineNetworkEntry{CIDR: network.CIDR.String()})
+	}
+	for _, network := range ic.Networking.ClusterNetwork {
+		networking.ClusterNetwork = append(networking.ClusterNetwork, ClusterNetworkEntry{CIDR: network.CIDR.String(), HostPrefix: network.HostPrefix})
+	}
+	for _, network := range ic.Networking.ServiceNetwork {
+		networking.ServiceNetwork = append(networking.ServiceNetwork, ServiceNetworkEntry{CIDR: network.String()})
+	}
+
+	apiServer := ServicePublishingStrategy{Type: "Route", Route: &RoutePublishingStrategy{Hostname: fmt.Sprintf("api.%s.%s", ic.ObjectMeta.Name, ic.BaseDomain)}}
+	switch {
+	case ic.Platform.AWS != nil:
+		apiServer = ServicePublishingStrategy{Type: "LoadBalancer"}
+		aws := &AWSPlatformSpec{
+			Region:         ic.Platform.AWS.Region,
+			ResourceTags:   resourceTags(ic.Platform.AWS.UserTags),
+			EndpointAccess: "Public",
+		}
+		if ic.Publish == types.InternalPublishingStrategy {
+			aws.EndpointAccess = "Private"
+		}
+		if len(ic.Platform.AWS.Subnets) > 0 {
+			vpc, err := installConfig.AWS.VPC(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get the VPC of the subnets")
+			}
+			aws.CloudProviderConfig = &AWSCloudProviderConfig{VPC: vpc}
+		}
+		hc.Spec.Platform = PlatformSpec{Type: AWSPlatform, AWS: aws}
+	default:
+		hc.Spec.Platform = PlatformSpec{Type: NonePlatform}
+	}
+
+	hc.Spec.Services = []ServicePublishingStrategyMapping{{Service: "APIServer", ServicePublishingStrategy: apiServer}}
+	for _, service := range []string{"OAuthServer", "Konnectivity", "Ignition"} {
+		hc.Spec.Services = append(hc.Spec.Services, ServicePublishingStrategyMapping{
+			Service:                   service,
+			ServicePublishingStrategy: ServicePublishingStrategy{Type: "Route"},
+		})
+	}
+	return hc, nil
+}
+
+// nodePoolsFor returns the node pools of the compute pools. On AWS, a node
+// pool is created for each zone of a compute pool, as the nodes of a pool
+// are in a single subnet. The subnets are the private subnets of the
+// install-config, or the ones tagged <infraID>-private-<zone> otherwise, as
+// created by the HyperShift CLI.
+func nodePoolsFor(ctx context.Context, installConfig *installconfig.InstallConfig, infraID string, release string) ([]*NodePool, error) {
+	ic := installConfig.Config
+	newNodePool := func(name string, replicas int64, arch types.Architecture) *NodePool {
+		return &NodePool{
+			TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: "NodePool"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: Namespace},
+			Spec: NodePoolSpec{
+				ClusterName: ic.ObjectMeta.Name,
+				Release:     Release{Image: release},
+				Replicas:    pointer.Int32(int32(replicas)),
+				Management:  NodePoolManagement{UpgradeType: "Replace"},
+				Arch:        string(arch),
+			},
+		}
+	}
+
+	var nodePools []*NodePool
+	for _, pool := range ic.Compute {
+		if pool.Name == types.MachinePoolEdgeRoleName {
+			logrus.Warnf("Skipping the %s compute pool, which is not supported by the hosted control planes topology", pool.Name)
+			continue
+		}
+		var replicas int64
+		if pool.Replicas != nil {
+			replicas = *pool.Replicas
+		}
+		name := fmt.Sprintf("%s-%s", ic.ObjectMeta.Name, pool.Name)
+
+		if ic.Platform.AWS == nil {
+			nodePool := newNodePool(name, replicas, pool.Architecture)
+			nodePool.Spec.Platform.Type = NonePlatform
+			nodePools = append(nodePools, nodePool)
+			continue
+		}
+
+		mpool := awstypes.MachinePool{EC2RootVolume: awstypes.EC2RootVolume{Type: awstypes.VolumeTypeGp3, Size: defaultRootVolumeSize}}
+		mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
+		mpool.Set(pool.Platform.AWS)
+		if mpool.InstanceType == "" {
+			mpool.InstanceType = awsdefaults.InstanceTypes(ic.Platform.AWS.Region, pool.Architecture, configv1.HighlyAvailableTopologyMode)[0]
+		}
+
+		subnets := map[string]string{}
+		if len(ic.Platform.AWS.Subnets) > 0 {
+			privateSubnets, err := installConfig.AWS.PrivateSubnets(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get the private subnets")
+			}
+			for id, subnet := range privateSubnets {
+				if subnet.Zone != nil {
+					subnets[subnet.Zone.Name] = id
+				}
+			}
+		}
+		zones := mpool.Zones
+		if len(zones) == 0 {
+			if len(subnets) > 0 {
+				for zone := range subnets {
+					zones = append(zones, zone)
+				}
+				sort.Strings(zones)
+			} else {
+				var err error
+				zones, err = installConfig.AWS.AvailabilityZones(ctx)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to get the availability zones")
+				}
+			}
+		}
+
+		for i, zone := range zones {
+			// The replicas are spread across the zones, the first zones
+			// getting the remainder.
+			zoneReplicas := replicas / int64(len(zones))
+			if int64(i) < replicas%int64(len(zones)) {
+				zoneReplicas++
+			}
+			subnet := AWSResourceReference{Filters: []AWSFilter{{Name: "tag:Name", Values: []string{fmt.Sprintf("%s-private-%s", infraID, zone)}}}}
+			if id, ok := subnets[zone]; ok {
+				subnet = AWSResourceReference{ID: pointer.String(id)}
+			}
+			nodePool := newNodePool(fmt.Sprintf("%s-%s", name, zone), zoneReplicas, pool.Architecture)
+			nodePool.Spec.Platform = NodePoolPlatform{
+				Type: AWSPlatform,
+				AWS: &AWSNodePoolPlatform{
+					InstanceType: mpool.InstanceType,
+					Subnet:       subnet,
+					RootVolume: &Volume{
+						Size: int64(mpool.EC2RootVolume.Size),
+						Type: mpool.EC2RootVolume.Type,
+						IOPS: int64(mpool.EC2RootVolume.IOPS),
+					},
+					ResourceTags: resourceTags(ic.Platform.AWS.UserTags),
+				},
+			}
+			nodePools = append(nodePools, nodePool)
+		}
+	}
+	return nodePools, nil
+}
+
+func resourceTags(tags map[string]string) []AWSResourceTag {
+	resourceTags := make([]AWSResourceTag, 0, len(tags))
+	for key, value := range tags {
+		resourceTags = append(resourceTags, AWSResourceTag{Key: key, Value: value})
+	}
+	sort.Slice(resourceTags, func(i, j int) bool { return resourceTags[i].Key < resourceTags[j].Key })
+	return resourceTags
+}
@@ -0,0 +1,137 @@
+package hypershift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	nonetypes "github.com/openshift/installer/pkg/types/none"
+	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
+)
+
+func installConfig(platform types.Platform) *types.InstallConfig {
+	return &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		BaseDomain: "example.com",
+		PullSecret: `{"auths":{}}`,
+		SSHKey:     "ssh-ed25519 AAAA",
+		Networking: &types.Networking{
+			NetworkType:    "OVNKubernetes",
+			MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")}},
+			ClusterNetwork: []types.ClusterNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.128.0.0/14"), HostPrefix: 23}},
+			ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")},
+		},
+		ControlPlane: &types.MachinePool{Name: "master", Replicas: pointer.Int64(3)},
+		Compute: []types.MachinePool{{
+			Name:         "worker",
+			Replicas:     pointer.Int64(3),
+			Architecture: types.ArchitectureAMD64,
+			Platform: types.MachinePoolPlatform{
+				AWS: &awstypes.MachinePool{Zones: []string{"us-east-1a", "us-east-1b"}, InstanceType: "m6i.xlarge"},
+			},
+		}},
+		Platform: platform,
+		Publish:  types.ExternalPublishingStrategy,
+	}
+}
+
+func generate(ic *types.InstallConfig) (*Manifests, error) {
+	parents := asset.Parents{}
+	parents.Add(
+		installconfig.MakeAsset(ic),
+		&installconfig.ClusterID{InfraID: "test-abcde"},
+		&releaseimage.Image{PullSpec: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64"},
+		&tls.CoreSSHKeyPair{},
+	)
+	m := &Manifests{}
+	return m, m.Generate(context.Background(), parents)
+}
+
+func file(t *testing.T, m *Manifests, name string, into interface{}) {
+	t.Helper()
+	for _, f := range m.Files() {
+		if f.Filename == "hosted-cluster/"+name {
+			assert.NoError(t, yaml.Unmarshal(f.Data, into))
+			return
+		}
+	}
+	t.Fatalf("file %s not generated", name)
+}
+
+func TestGenerateAWS(t *testing.T) {
+	m, err := generate(installConfig(types.Platform{AWS: &awstypes.Platform{
+		Region:   "us-east-1",
+		UserTags: map[string]string{"owner": "team"},
+	}}))
+	assert.NoError(t, err)
+
+	var filenames []string
+	for _, f := range m.Files() {
+		filenames = append(filenames, f.Filename)
+	}
+	assert.Equal(t, []string{
+		"hosted-cluster/hostedcluster.yaml",
+		"hosted-cluster/namespace.yaml",
+		"hosted-cluster/nodepool-test-worker-us-east-1a.yaml",
+		"hosted-cluster/nodepool-test-worker-us-east-1b.yaml",
+		"hosted-cluster/pull-secret.yaml",
+		"hosted-cluster/ssh-key.yaml",
+	}, filenames)
+
+	hc := &HostedCluster{}
+	file(t, m, "hostedcluster.yaml", hc)
+	assert.Equal(t, "test-abcde", hc.Spec.InfraID)
+	assert.Equal(t, "test-pull-secret", hc.Spec.PullSecret.Name)
+	assert.Equal(t, "HighlyAvailable", hc.Spec.ControllerAvailabilityPolicy)
+	assert.Equal(t, []ClusterNetworkEntry{{CIDR: "10.128.0.0/14", HostPrefix: 23}}, hc.Spec.Networking.ClusterNetwork)
+	assert.Equal(t, &AWSPlatformSpec{
+		Region:         "us-east-1",
+		ResourceTags:   []AWSResourceTag{{Key: "owner", Value: "team"}},
+		EndpointAccess: "Public",
+	}, hc.Spec.Platform.AWS)
+	assert.Equal(t, "LoadBalancer", hc.Spec.Services[0].ServicePublishingStrategy.Type)
+
+	np := &NodePool{}
+	file(t, m, "nodepool-test-worker-us-east-1a.yaml", np)
+	assert.Equal(t, int32(2), *np.Spec.Replicas)
+	assert.Equal(t, "m6i.xlarge", np.Spec.Platform.AWS.InstanceType)
+	assert.Equal(t, []AWSFilter{{Name: "tag:Name", Values: []string{"test-abcde-private-us-east-1a"}}}, np.Spec.Platform.AWS.Subnet.Filters)
+	assert.Equal(t, &Volume{Size: 120, Type: "gp3"}, np.Spec.Platform.AWS.RootVolume)
+	file(t, m, "nodepool-test-worker-us-east-1b.yaml", np)
+	assert.Equal(t, int32(1), *np.Spec.Replicas)
+}
+
+func TestGenerateNone(t *testing.T) {
+	ic := installConfig(types.Platform{None: &nonetypes.Platform{}})
+	ic.ControlPlane.Replicas = pointer.Int64(1)
+	ic.Compute[0].Replicas = pointer.Int64(1)
+	m, err := generate(ic)
+	assert.NoError(t, err)
+
+	hc := &HostedCluster{}
+	file(t, m, "hostedcluster.yaml", hc)
+	assert.Equal(t, PlatformSpec{Type: NonePlatform}, hc.Spec.Platform)
+	assert.Equal(t, "SingleReplica", hc.Spec.ControllerAvailabilityPolicy)
+	assert.Equal(t, "SingleReplica", hc.Spec.InfrastructureAvailabilityPolicy)
+	assert.Equal(t, &RoutePublishingStrategy{Hostname: "api.test.example.com"}, hc.Spec.Services[0].ServicePublishingStrategy.Route)
+
+	np := &NodePool{}
+	file(t, m, "nodepool-test-worker.yaml", np)
+	assert.Equal(t, NodePoolPlatform{Type: NonePlatform}, np.Spec.Platform)
+}
+
+func TestGenerateUnsupportedPlatform(t *testing.T) {
+	_, err := generate(installConfig(types.Platform{VSphere: &vspheretypes.Platform{}}))
+	assert.EqualError(t, err, "the hosted control planes topology is not supported on platform vsphere")
+}
@@ -0,0 +1,183 @@
+package hypershift
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The types below are the subset of the hypershift.openshift.io/v1beta1 API
+// used to describe a hosted cluster from the install-config.
+
+// APIVersion is the API version of the HostedCluster and NodePool resources.
+const APIVersion = "hypershift.openshift.io/v1beta1"
+
+// Platform types of the hosted clusters and node pools.
+const (
+	AWSPlatform  = "AWS"
+	NonePlatform = "None"
+)
+
+// HostedCluster is a cluster whose control plane is hosted in a management
+// cluster.
+type HostedCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HostedClusterSpec `json:"spec"`
+}
+
+// HostedClusterSpec is the desired state of the hosted cluster.
+type HostedClusterSpec struct {
+	Release                          Release                            `json:"release"`
+	PullSecret                       corev1.LocalObjectReference        `json:"pullSecret"`
+	SSHKey                           corev1.LocalObjectReference        `json:"sshKey"`
+	InfraID                          string                             `json:"infraID"`
+	DNS                              DNSSpec                            `json:"dns"`
+	Networking                       ClusterNetworking                  `json:"networking"`
+	Platform                         PlatformSpec                       `json:"platform"`
+	Etcd                             EtcdSpec                           `json:"etcd"`
+	Services                         []ServicePublishingStrategyMapping `json:"services"`
+	ControllerAvailabilityPolicy     string                             `json:"controllerAvailabilityPolicy,omitempty"`
+	InfrastructureAvailabilityPolicy string                             `json:"infrastructureAvailabilityPolicy,omitempty"`
+	FIPS                             bool                               `json:"fips"`
+}
+
+// Release is the release image of a hosted cluster or node pool.
+type Release struct {
+	Image string `json:"image"`
+}
+
+// DNSSpec is the DNS configuration of the hosted cluster.
+type DNSSpec struct {
+	BaseDomain string `json:"baseDomain"`
+}
+
+// ClusterNetworking is the network configuration of the hosted cluster.
+type ClusterNetworking struct {
+	MachineNetwork []MachineNetworkEntry `json:"machineNetwork,omitempty"`
+	ClusterNetwork []ClusterNetworkEntry `json:"clusterNetwork"`
+	ServiceNetwork []ServiceNetworkEntry `json:"serviceNetwork"`
+	NetworkType    string                `json:"networkType"`
+}
+
+// MachineNetworkEntry is a network of the machines.
+type MachineNetworkEntry struct {
+	CIDR string `json:"cidr"`
+}
+
+// ClusterNetworkEntry is a network of the pods.
+type ClusterNetworkEntry struct {
+	CIDR       string `json:"cidr"`
+	HostPrefix int32  `json:"hostPrefix,omitempty"`
+}
+
+// ServiceNetworkEntry is a network of the services.
+type ServiceNetworkEntry struct {
+	CIDR string `json:"cidr"`
+}
+
+// PlatformSpec is the platform of the hosted cluster.
+type PlatformSpec struct {
+	Type string           `json:"type"`
+	AWS  *AWSPlatformSpec `json:"aws,omitempty"`
+}
+
+// AWSPlatformSpec is the AWS configuration of the hosted cluster.
+type AWSPlatformSpec struct {
+	Region              string                  `json:"region"`
+	CloudProviderConfig *AWSCloudProviderConfig `json:"cloudProviderConfig,omitempty"`
+	ResourceTags        []AWSResourceTag        `json:"resourceTags,omitempty"`
+	EndpointAccess      string                  `json:"endpointAccess,omitempty"`
+}
+
+// AWSCloudProviderConfig is the VPC the hosted cluster is in.
+type AWSCloudProviderConfig struct {
+	Subnet *AWSResourceReference `json:"subnet,omitempty"`
+	Zone   string                `json:"zone,omitempty"`
+	VPC    string                `json:"vpc"`
+}
+
+// AWSResourceTag is a tag of the AWS resources of the hosted cluster.
+type AWSResourceTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// AWSResourceReference references an AWS resource, by ID or by filters.
+type AWSResourceReference struct {
+	ID      *string     `json:"id,omitempty"`
+	Filters []AWSFilter `json:"filters,omitempty"`
+}
+
+// AWSFilter is a filter of AWS resources.
+type AWSFilter struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// EtcdSpec is the etcd of the hosted control plane.
+type EtcdSpec struct {
+	ManagementType string `json:"managementType"`
+}
+
+// ServicePublishingStrategyMapping is how a service of the hosted control
+// plane is published.
+type ServicePublishingStrategyMapping struct {
+	Service                   string                    `json:"service"`
+	ServicePublishingStrategy ServicePublishingStrategy `json:"servicePublishingStrategy"`
+}
+
+// ServicePublishingStrategy is how a service is published.
+type ServicePublishingStrategy struct {
+	Type  string                   `json:"type"`
+	Route *RoutePublishingStrategy `json:"route,omitempty"`
+}
+
+// RoutePublishingStrategy is the route a service is published with.
+type RoutePublishingStrategy struct {
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// NodePool is a pool of the compute nodes of a hosted cluster.
+type NodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodePoolSpec `json:"spec"`
+}
+
+// NodePoolSpec is the desired state of the node pool.
+type NodePoolSpec struct {
+	ClusterName string             `json:"clusterName"`
+	Release     Release            `json:"release"`
+	Platform    NodePoolPlatform   `json:"platform"`
+	Replicas    *int32             `json:"replicas,omitempty"`
+	Management  NodePoolManagement `json:"management"`
+	Arch        string             `json:"arch,omitempty"`
+}
+
+// NodePoolPlatform is the platform of the nodes.
+type NodePoolPlatform struct {
+	Type string               `json:"type"`
+	AWS  *AWSNodePoolPlatform `json:"aws,omitempty"`
+}
+
+// AWSNodePoolPlatform is the AWS configuration of the nodes.
+type AWSNodePoolPlatform struct {
+	InstanceType string               `json:"instanceType"`
+	Subnet       AWSResourceReference `json:"subnet"`
+	RootVolume   *Volume              `json:"rootVolume,omitempty"`
+	ResourceTags []AWSResourceTag     `json:"resourceTags,omitempty"`
+}
+
+// Volume is the root volume of the nodes.
+type Volume struct {
+	Size int64  `json:"size"`
+	Type string `json:"type,omitempty"`
+	IOPS int64  `json:"iops,omitempty"`
+}
+
+// NodePoolManagement is how the nodes are upgraded.
+type NodePoolManagement struct {
+	UpgradeType string `json:"upgradeType"`
+}
@@ -0,0 +1,123 @@
+package manifests
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var (
+	imageConfigFileName              = filepath.Join(manifestDir, "cluster-image-02-config.yml")
+	registryTrustedCAsConfigFileName = filepath.Join(manifestDir, "cluster-image-registry-cas-configmap.yaml")
+)
+
+const registryTrustedCAsConfigMapName = "image-registry-cas"
+
+// ImageConfig generates the cluster image config, with the CA bundles
+// trusted per image registry of the install-config, and the config map they
+// are stored in.
+type ImageConfig struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*ImageConfig)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*ImageConfig) Name() string {
+	return "Image Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*ImageConfig) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the image config. Nothing is generated when the
+// install-config trusts no CA for specific registries.
+func (ic *ImageConfig) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	ic.FileList = []*asset.File{}
+	if len(installConfig.Config.AdditionalTrustedRegistryCAs) == 0 {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-config",
+			Name:      registryTrustedCAsConfigMapName,
+		},
+		Data: make(map[string]string, len(installConfig.Config.AdditionalTrustedRegistryCAs)),
+	}
+	for registry, ca := range installConfig.Config.AdditionalTrustedRegistryCAs {
+		configMap.Data[registryTrustedCAKey(registry)] = ca
+	}
+	configMapData, err := yaml.Marshal(configMap)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create config map %s", registryTrustedCAsConfigMapName)
+	}
+
+	config := &configv1.Image{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.GroupVersion.String(),
+			Kind:       "Image",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: configv1.ImageSpec{
+			AdditionalTrustedCA: configv1.ConfigMapNameReference{Name: registryTrustedCAsConfigMapName},
+		},
+	}
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create image config")
+	}
+
+	ic.FileList = append(ic.FileList,
+		&asset.File{
+			Filename: registryTrustedCAsConfigFileName,
+			Data:     configMapData,
+		},
+		&asset.File{
+			Filename: imageConfigFileName,
+			Data:     configData,
+		},
+	)
+	return nil
+}
+
+// registryTrustedCAKey returns the key of the CA bundle of a registry in the
+// additionalTrustedCA config map, where the port of the registry is
+// separated by two dots since config map keys cannot contain colons.
+func registryTrustedCAKey(registry string) string {
+	return strings.Replace(registry, ":", "..", 1)
+}
+
+// Files returns the files generated by the asset.
+func (ic *ImageConfig) Files() []*asset.File {
+	return ic.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (ic *ImageConfig) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
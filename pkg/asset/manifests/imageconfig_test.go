@@ -0,0 +1,66 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestGenerateImageConfig(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+		AdditionalTrustedRegistryCAs: map[string]string{
+			"mirror.test-domain":      "test-mirror-ca",
+			"quay.test-domain:8443":   "test-quay-ca",
+			"registry.test-domain:80": "test-registry-ca",
+		},
+	}))
+	imageConfig := &ImageConfig{}
+	if !assert.NoError(t, imageConfig.Generate(context.Background(), parents)) {
+		return
+	}
+
+	if !assert.Len(t, imageConfig.Files(), 2) {
+		return
+	}
+	assert.Equal(t, "manifests/cluster-image-registry-cas-configmap.yaml", imageConfig.Files()[0].Filename)
+	assert.Equal(t, "manifests/cluster-image-02-config.yml", imageConfig.Files()[1].Filename)
+
+	configMap := &corev1.ConfigMap{}
+	if assert.NoError(t, yaml.Unmarshal(imageConfig.Files()[0].Data, configMap)) {
+		assert.Equal(t, "openshift-config", configMap.Namespace)
+		assert.Equal(t, map[string]string{
+			"mirror.test-domain":       "test-mirror-ca",
+			"quay.test-domain..8443":   "test-quay-ca",
+			"registry.test-domain..80": "test-registry-ca",
+		}, configMap.Data)
+	}
+
+	config := &configv1.Image{}
+	if assert.NoError(t, yaml.Unmarshal(imageConfig.Files()[1].Data, config)) {
+		assert.Equal(t, configMap.Name, config.Spec.AdditionalTrustedCA.Name)
+	}
+}
+
+func TestGenerateImageConfigWithoutRegistryCAs(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+	}))
+	imageConfig := &ImageConfig{}
+	if assert.NoError(t, imageConfig.Generate(context.Background(), parents)) {
+		assert.Empty(t, imageConfig.Files())
+	}
+}
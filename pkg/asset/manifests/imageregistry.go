@@ -0,0 +1,248 @@
+package manifests
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	imageRegistryNamespace = "openshift-image-registry"
+	// imageRegistryClaimName is the name of the claim the registry operator
+	// creates by default, reused for the claim rendered by the installer.
+	imageRegistryClaimName   = "image-registry-storage"
+	defaultImageRegistrySize = "100Gi"
+)
+
+var (
+	imageRegistryConfigFileName = filepath.Join(manifestDir, "cluster-image-registry-config.yml")
+	imageRegistryClaimFileName  = filepath.Join(manifestDir, "cluster-image-registry-pvc.yml")
+)
+
+// The image registry operator API is not vendored, so only the fields
+// rendered from the install-config are declared here.
+type imageRegistryConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec imageRegistryConfigSpec `json:"spec"`
+}
+
+type imageRegistryConfigSpec struct {
+	ManagementState string               `json:"managementState"`
+	Replicas        int32                `json:"replicas,omitempty"`
+	RolloutStrategy string               `json:"rolloutStrategy,omitempty"`
+	Storage         imageRegistryStorage `json:"storage"`
+}
+
+type imageRegistryStorage struct {
+	S3       *imageRegistryStorageS3    `json:"s3,omitempty"`
+	Azure    *imageRegistryStorageAzure `json:"azure,omitempty"`
+	GCS      *imageRegistryStorageGCS   `json:"gcs,omitempty"`
+	PVC      *imageRegistryStoragePVC   `json:"pvc,omitempty"`
+	EmptyDir *struct{}                  `json:"emptyDir,omitempty"`
+}
+
+type imageRegistryStorageS3 struct {
+	Bucket  string `json:"bucket"`
+	Region  string `json:"region"`
+	Encrypt bool   `json:"encrypt"`
+	KeyID   string `json:"keyID,omitempty"`
+}
+
+type imageRegistryStorageAzure struct {
+	AccountName string `json:"accountName"`
+	Container   string `json:"container"`
+}
+
+type imageRegistryStorageGCS struct {
+	Bucket    string `json:"bucket"`
+	Region    string `json:"region"`
+	ProjectID string `json:"projectID"`
+}
+
+type imageRegistryStoragePVC struct {
+	Claim string `json:"claim"`
+}
+
+// ImageRegistry generates the cluster configuration of the image registry
+// operator, with the storage set in the install-config.
+type ImageRegistry struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*ImageRegistry)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*ImageRegistry) Name() string {
+	return "Image Registry Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*ImageRegistry) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the image registry configuration, and the claim of its
+// persistent volume. Nothing is generated when the install-config sets no
+// image registry storage, and the operator picks the storage of the
+// platform.
+func (r *ImageRegistry) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	r.FileList = []*asset.File{}
+	registry := installConfig.Config.ImageRegistry
+	if registry == nil {
+		return nil
+	}
+	platform := installConfig.Config.Platform
+
+	config := &imageRegistryConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "imageregistry.operator.openshift.io/v1",
+			Kind:       "Config",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: imageRegistryConfigSpec{
+			// The registry is removed by default on the platforms without
+			// object storage, so it is managed explicitly.
+			ManagementState: "Managed",
+		},
+	}
+
+	var claim *corev1.PersistentVolumeClaim
+	storage := registry.Storage
+	switch {
+	case storage.S3 != nil:
+		region := storage.S3.Region
+		if region == "" && platform.AWS != nil {
+			region = platform.AWS.Region
+		}
+		config.Spec.Storage.S3 = &imageRegistryStorageS3{
+			Bucket:  storage.S3.Bucket,
+			Region:  region,
+			Encrypt: true,
+			KeyID:   storage.S3.KMSKeyID,
+		}
+	case storage.Azure != nil:
+		config.Spec.Storage.Azure = &imageRegistryStorageAzure{
+			AccountName: storage.Azure.AccountName,
+			Container:   storage.Azure.Container,
+		}
+	case storage.GCS != nil:
+		region, projectID := storage.GCS.Region, storage.GCS.ProjectID
+		if platform.GCP != nil {
+			if region == "" {
+				region = platform.GCP.Region
+			}
+			if projectID == "" {
+				projectID = platform.GCP.ProjectID
+			}
+		}
+		config.Spec.Storage.GCS = &imageRegistryStorageGCS{
+			Bucket:    storage.GCS.Bucket,
+			Region:    region,
+			ProjectID: projectID,
+		}
+	case storage.PVC != nil:
+		var err error
+		claim, err = imageRegistryClaim(storage.PVC)
+		if err != nil {
+			return err
+		}
+		config.Spec.Storage.PVC = &imageRegistryStoragePVC{Claim: imageRegistryClaimName}
+		if claim.Spec.AccessModes[0] == corev1.ReadWriteOnce {
+			// The volume is attached to a single node, so that the pod of
+			// the new rollout cannot start before the old one is gone.
+			config.Spec.Replicas = 1
+			config.Spec.RolloutStrategy = "Recreate"
+		}
+	case storage.EmptyDir != nil:
+		config.Spec.Storage.EmptyDir = &struct{}{}
+		config.Spec.Replicas = 1
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create image registry config")
+	}
+	r.FileList = append(r.FileList, &asset.File{
+		Filename: imageRegistryConfigFileName,
+		Data:     configData,
+	})
+
+	if claim != nil {
+		claimData, err := yaml.Marshal(claim)
+		if err != nil {
+			return errors.Wrap(err, "failed to create image registry claim")
+		}
+		r.FileList = append(r.FileList, &asset.File{
+			Filename: imageRegistryClaimFileName,
+			Data:     claimData,
+		})
+	}
+
+	return nil
+}
+
+func imageRegistryClaim(pvc *types.ImageRegistryStoragePVC) (*corev1.PersistentVolumeClaim, error) {
+	size := pvc.Size
+	if size == "" {
+		size = defaultImageRegistrySize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image registry volume size %q", size)
+	}
+	accessMode := corev1.ReadWriteMany
+	if pvc.AccessMode != "" {
+		accessMode = corev1.PersistentVolumeAccessMode(pvc.AccessMode)
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      imageRegistryClaimName,
+			Namespace: imageRegistryNamespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: quantity},
+			},
+		},
+	}
+	if pvc.StorageClassName != "" {
+		claim.Spec.StorageClassName = &pvc.StorageClassName
+	}
+	return claim, nil
+}
+
+// Files returns the files generated by the asset.
+func (r *ImageRegistry) Files() []*asset.File {
+	return r.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (r *ImageRegistry) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
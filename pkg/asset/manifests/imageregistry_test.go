@@ -0,0 +1,133 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestGenerateImageRegistry(t *testing.T) {
+	cases := []struct {
+		name     string
+		registry *types.ImageRegistry
+		expected map[string]string
+	}{
+		{
+			name:     "platform storage",
+			expected: map[string]string{},
+		},
+		{
+			name: "S3 bucket in the cluster region",
+			registry: &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+				S3: &types.ImageRegistryStorageS3{Bucket: "registry", KMSKeyID: "key"},
+			}},
+			expected: map[string]string{
+				"manifests/cluster-image-registry-config.yml": `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Managed
+  storage:
+    s3:
+      bucket: registry
+      encrypt: true
+      keyID: key
+      region: us-east-1
+`,
+			},
+		},
+		{
+			name: "ReadWriteOnce volume",
+			registry: &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+				PVC: &types.ImageRegistryStoragePVC{StorageClassName: "gp3-csi", AccessMode: "ReadWriteOnce"},
+			}},
+			expected: map[string]string{
+				"manifests/cluster-image-registry-config.yml": `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Managed
+  replicas: 1
+  rolloutStrategy: Recreate
+  storage:
+    pvc:
+      claim: image-registry-storage
+`,
+				"manifests/cluster-image-registry-pvc.yml": `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  creationTimestamp: null
+  name: image-registry-storage
+  namespace: openshift-image-registry
+spec:
+  accessModes:
+  - ReadWriteOnce
+  resources:
+    requests:
+      storage: 100Gi
+  storageClassName: gp3-csi
+status: {}
+`,
+			},
+		},
+		{
+			name: "emptyDir",
+			registry: &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+				EmptyDir: &types.ImageRegistryStorageEmptyDir{},
+			}},
+			expected: map[string]string{
+				"manifests/cluster-image-registry-config.yml": `apiVersion: imageregistry.operator.openshift.io/v1
+kind: Config
+metadata:
+  creationTimestamp: null
+  name: cluster
+spec:
+  managementState: Managed
+  replicas: 1
+  storage:
+    emptyDir: {}
+`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+				Platform:      types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+				ImageRegistry: tc.registry,
+			}))
+			registry := &ImageRegistry{}
+			if !assert.NoError(t, registry.Generate(context.Background(), parents)) {
+				return
+			}
+			actual := map[string]string{}
+			for _, f := range registry.Files() {
+				actual[f.Filename] = string(f.Data)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestImageRegistryClaimRoundTrip(t *testing.T) {
+	claim, err := imageRegistryClaim(&types.ImageRegistryStoragePVC{Size: "1Ti"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	data, err := yaml.Marshal(claim)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "storage: 1Ti")
+	assert.Contains(t, string(data), "- ReadWriteMany")
+}
@@ -38,6 +38,17 @@ var (
 	cloudControllerUIDFilename = filepath.Join(manifestDir, "cloud-controller-uid-config.yml")
 )
 
+const (
+	// apiServerPublishAnnotation records whether the API server of a cluster
+	// with the mixed publish strategy is published, Internal or External.
+	apiServerPublishAnnotation = "installer.openshift.io/api-server-publish"
+
+	// ingressPublishAnnotation records whether the default ingress of a
+	// cluster with the mixed publish strategy is published, Internal or
+	// External.
+	ingressPublishAnnotation = "installer.openshift.io/ingress-publish"
+)
+
 // Infrastructure generates the cluster-infrastructure-*.yml files.
 type Infrastructure struct {
 	FileList []*asset.File
@@ -62,7 +73,7 @@ func (*Infrastructure) Dependencies() []asset.Asset {
 }
 
 // Generate generates the Infrastructure config and its CRD.
-func (i *Infrastructure) Generate(dependencies asset.Parents) error {
+func (i *Infrastructure) Generate(ctx context.Context, dependencies asset.Parents) error {
 	cloudProviderConfigMapKey := cloudProviderConfigDataKey
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
@@ -96,6 +107,13 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 	config.Status.ControlPlaneTopology = controlPlaneTopology
 	config.Status.CPUPartitioning = determineCPUPartitioning(installConfig.Config)
 
+	if installConfig.Config.Publish == types.MixedPublishingStrategy {
+		config.Annotations = map[string]string{
+			apiServerPublishAnnotation: string(publishingStrategy(installConfig.Config.PublicAPI())),
+			ingressPublishAnnotation:   string(publishingStrategy(installConfig.Config.PublicIngress())),
+		}
+	}
+
 	switch installConfig.Config.Platform.Name() {
 	case aws.Name:
 		config.Spec.PlatformSpec.Type = configv1.AWSPlatformType
@@ -197,20 +215,20 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 		// DNS post-install.
 		config.Status.PlatformStatus.GCP.CloudLoadBalancerConfig = &configv1.CloudLoadBalancerConfig{}
 		config.Status.PlatformStatus.GCP.CloudLoadBalancerConfig.DNSType = configv1.PlatformDefaultDNSType
-		if installConfig.Config.GCP.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled {
+		if installConfig.Config.ClusterHostedDNS() {
 			config.Status.PlatformStatus.GCP.CloudLoadBalancerConfig.DNSType = configv1.ClusterHostedDNSType
 		}
 	case ibmcloud.Name:
 		config.Spec.PlatformSpec.Type = configv1.IBMCloudPlatformType
 		var cisInstanceCRN, dnsInstanceCRN string
 		if installConfig.Config.Publish == types.InternalPublishingStrategy {
-			dnsInstance, err := installConfig.IBMCloud.DNSInstance(context.TODO())
+			dnsInstance, err := installConfig.IBMCloud.DNSInstance(ctx)
 			if err != nil {
 				return errors.Wrap(err, "cannot retrieve IBM DNS Services instance CRN")
 			}
 			dnsInstanceCRN = dnsInstance.CRN
 		} else {
-			crn, err := installConfig.IBMCloud.CISInstanceCRN(context.TODO())
+			crn, err := installConfig.IBMCloud.CISInstanceCRN(ctx)
 			if err != nil {
 				return errors.Wrap(err, "cannot retrieve IBM Cloud Internet Services instance CRN")
 			}
@@ -258,7 +276,9 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 				LoadBalancer:         installConfig.Config.VSphere.LoadBalancer,
 			}
 		} else {
-			config.Status.PlatformStatus.VSphere = &configv1.VSpherePlatformStatus{}
+			config.Status.PlatformStatus.VSphere = &configv1.VSpherePlatformStatus{
+				LoadBalancer: installConfig.Config.VSphere.LoadBalancer,
+			}
 		}
 
 		config.Spec.PlatformSpec.VSphere = vsphereinfra.GetInfraPlatformSpec(installConfig, clusterID.InfraID)
@@ -282,12 +302,12 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 		var err error
 		switch installConfig.Config.Publish {
 		case types.InternalPublishingStrategy:
-			dnsInstanceCRN, err = installConfig.PowerVS.DNSInstanceCRN(context.TODO())
+			dnsInstanceCRN, err = installConfig.PowerVS.DNSInstanceCRN(ctx)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get instance CRN")
 			}
 		case types.ExternalPublishingStrategy:
-			cisInstanceCRN, err = installConfig.PowerVS.CISInstanceCRN(context.TODO())
+			cisInstanceCRN, err = installConfig.PowerVS.CISInstanceCRN(ctx)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get instance CRN")
 			}
@@ -319,6 +339,10 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 				IngressIPs:           installConfig.Config.Nutanix.IngressVIPs,
 				LoadBalancer:         installConfig.Config.Nutanix.LoadBalancer,
 			}
+		} else if installConfig.Config.Nutanix.LoadBalancer != nil {
+			config.Status.PlatformStatus.Nutanix = &configv1.NutanixPlatformStatus{
+				LoadBalancer: installConfig.Config.Nutanix.LoadBalancer,
+			}
 		}
 	default:
 		config.Spec.PlatformSpec.Type = configv1.NonePlatformType
@@ -347,6 +371,15 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 	return nil
 }
 
+// publishingStrategy returns the publishing strategy of an endpoint, External
+// if it is public.
+func publishingStrategy(public bool) types.PublishingStrategy {
+	if public {
+		return types.ExternalPublishingStrategy
+	}
+	return types.InternalPublishingStrategy
+}
+
 // Files returns the files generated by the asset.
 func (i *Infrastructure) Files() []*asset.File {
 	return i.FileList
@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,9 +11,11 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	baremetaltypes "github.com/openshift/installer/pkg/types/baremetal"
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 	nonetypes "github.com/openshift/installer/pkg/types/none"
 )
@@ -32,6 +35,19 @@ func TestGenerateInfrastructure(t *testing.T) {
 			infraBuild.withAWSPlatformStatus(),
 		),
 		expectedFilesGenerated: 1,
+	}, {
+		name: "aws mixed publish",
+		installConfig: icBuild.build(
+			icBuild.forAWS(),
+			icBuild.withMixedPublish("Internal", "External"),
+		),
+		expectedInfrastructure: infraBuild.build(
+			infraBuild.forPlatform(configv1.AWSPlatformType),
+			infraBuild.withAWSPlatformSpec(),
+			infraBuild.withAWSPlatformStatus(),
+			infraBuild.withPublishAnnotations("Internal", "External"),
+		),
+		expectedFilesGenerated: 1,
 	}, {
 		name: "service endpoints",
 		installConfig: icBuild.build(
@@ -73,6 +89,28 @@ func TestGenerateInfrastructure(t *testing.T) {
 			infraBuild.withGCPClusterHostedDNS("Enabled"),
 		),
 		expectedFilesGenerated: 2,
+	}, {
+		name: "GCP cluster hosted DNS",
+		installConfig: icBuild.build(
+			icBuild.forGCP(),
+			icBuild.withDNSType(configv1.ClusterHostedDNSType),
+		),
+		expectedInfrastructure: infraBuild.build(
+			infraBuild.forPlatform(configv1.GCPPlatformType),
+			infraBuild.withGCPClusterHostedDNS("Enabled"),
+		),
+		expectedFilesGenerated: 2,
+	}, {
+		name: "baremetal user-managed load balancer",
+		installConfig: icBuild.build(
+			icBuild.forBareMetal(),
+			icBuild.withBareMetalLoadBalancer(configv1.LoadBalancerTypeUserManaged),
+		),
+		expectedInfrastructure: infraBuild.build(
+			infraBuild.forPlatform(configv1.BareMetalPlatformType),
+			infraBuild.withBareMetalPlatform(configv1.LoadBalancerTypeUserManaged),
+		),
+		expectedFilesGenerated: 1,
 	}}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -87,7 +125,7 @@ func TestGenerateInfrastructure(t *testing.T) {
 				&AdditionalTrustBundleConfig{},
 			)
 			infraAsset := &Infrastructure{}
-			err := infraAsset.Generate(parents)
+			err := infraAsset.Generate(context.Background(), parents)
 			if !assert.NoError(t, err, "failed to generate asset") {
 				return
 			}
@@ -173,6 +211,13 @@ func (b icBuildNamespace) withLBType(lbType configv1.AWSLBType) icOption {
 	}
 }
 
+func (b icBuildNamespace) withAPILBType(lbType configv1.AWSLBType) icOption {
+	return func(ic *types.InstallConfig) {
+		b.forAWS()(ic)
+		ic.Platform.AWS.APILoadBalancer = &awstypes.APILoadBalancer{Type: lbType}
+	}
+}
+
 func (b icBuildNamespace) withGCPUserProvisionedDNS(enabled string) icOption {
 	return func(ic *types.InstallConfig) {
 		b.forGCP()(ic)
@@ -183,6 +228,12 @@ func (b icBuildNamespace) withGCPUserProvisionedDNS(enabled string) icOption {
 	}
 }
 
+func (b icBuildNamespace) withDNSType(dnsType configv1.DNSType) icOption {
+	return func(ic *types.InstallConfig) {
+		ic.ClusterDNS = &types.ClusterDNS{DNSType: dnsType}
+	}
+}
+
 type infraOption func(*configv1.Infrastructure)
 
 type infraBuildNamespace struct{}
@@ -274,6 +325,25 @@ func (b infraBuildNamespace) withAzurePlatformStatus() infraOption {
 	}
 }
 
+func (b icBuildNamespace) withMixedPublish(apiServer, ingress string) icOption {
+	return func(ic *types.InstallConfig) {
+		ic.Publish = types.MixedPublishingStrategy
+		ic.OperatorPublishingStrategy = &types.OperatorPublishingStrategy{
+			APIServer: apiServer,
+			Ingress:   ingress,
+		}
+	}
+}
+
+func (b infraBuildNamespace) withPublishAnnotations(apiServer, ingress string) infraOption {
+	return func(infra *configv1.Infrastructure) {
+		infra.Annotations = map[string]string{
+			apiServerPublishAnnotation: apiServer,
+			ingressPublishAnnotation:   ingress,
+		}
+	}
+}
+
 func (b icBuildNamespace) withResourceTags(tags map[string]string) icOption {
 	return func(ic *types.InstallConfig) {
 		b.forAzure()(ic)
@@ -307,3 +377,44 @@ func (b infraBuildNamespace) withGCPClusterHostedDNS(enabled string) infraOption
 		}
 	}
 }
+
+func (b icBuildNamespace) forBareMetal() icOption {
+	return func(ic *types.InstallConfig) {
+		if ic.Platform.BareMetal != nil {
+			return
+		}
+		ic.Networking = &types.Networking{
+			MachineNetwork: []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("192.168.111.0/24")}},
+		}
+		ic.Platform.BareMetal = &baremetaltypes.Platform{
+			APIVIPs:     []string{"192.168.111.5"},
+			IngressVIPs: []string{"192.168.111.4"},
+		}
+	}
+}
+
+func (b icBuildNamespace) withBareMetalLoadBalancer(lbType configv1.PlatformLoadBalancerType) icOption {
+	return func(ic *types.InstallConfig) {
+		b.forBareMetal()(ic)
+		ic.Platform.BareMetal.LoadBalancer = &configv1.BareMetalPlatformLoadBalancer{Type: lbType}
+	}
+}
+
+func (b infraBuildNamespace) withBareMetalPlatform(lbType configv1.PlatformLoadBalancerType) infraOption {
+	return func(infra *configv1.Infrastructure) {
+		machineNetworks := []configv1.CIDR{"192.168.111.0/24"}
+		infra.Spec.PlatformSpec.BareMetal = &configv1.BareMetalPlatformSpec{
+			APIServerInternalIPs: []configv1.IP{"192.168.111.5"},
+			IngressIPs:           []configv1.IP{"192.168.111.4"},
+			MachineNetworks:      machineNetworks,
+		}
+		infra.Status.PlatformStatus.BareMetal = &configv1.BareMetalPlatformStatus{
+			APIServerInternalIP:  "192.168.111.5",
+			IngressIP:            "192.168.111.4",
+			APIServerInternalIPs: []string{"192.168.111.5"},
+			IngressIPs:           []string{"192.168.111.4"},
+			LoadBalancer:         &configv1.BareMetalPlatformLoadBalancer{Type: lbType},
+			MachineNetworks:      machineNetworks,
+		}
+	}
+}
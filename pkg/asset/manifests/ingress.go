@@ -1,10 +1,11 @@
 package manifests
 
 import (
-	"fmt"
+	"context"
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
@@ -46,9 +47,10 @@ func (*Ingress) Dependencies() []asset.Asset {
 // A cluster ingress config is always created.
 //
 // A default ingresscontroller is only created if the cluster is using an internal
-// publishing strategy. In this case, the default ingresscontroller is also set
-// to use the internal publishing strategy.
-func (ing *Ingress) Generate(dependencies asset.Parents) error {
+// publishing strategy, or if a serving certificate is provided for ingress. In
+// the former case, the default ingresscontroller is set to use the internal
+// publishing strategy, in the latter it serves the provided certificate.
+func (ing *Ingress) Generate(_ context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(installConfig)
 
@@ -109,7 +111,7 @@ func (ing *Ingress) generateClusterConfig(config *types.InstallConfig) ([]byte,
 			// not namespaced
 		},
 		Spec: configv1.IngressSpec{
-			Domain: fmt.Sprintf("apps.%s", config.ClusterDomain()),
+			Domain: config.IngressDomain(),
 		},
 		Status: configv1.IngressStatus{
 			DefaultPlacement: defaultPlacement,
@@ -119,8 +121,12 @@ func (ing *Ingress) generateClusterConfig(config *types.InstallConfig) ([]byte,
 	switch config.Platform.Name() {
 	case aws.Name:
 		lbType := configv1.Classic
-		if config.AWS.LBType == configv1.NLB {
+		switch {
+		case config.AWS.LBType == configv1.NLB:
 			lbType = configv1.NLB
+		case config.AWS.LBType == "" && config.AWS.APILoadBalancer != nil && config.AWS.APILoadBalancer.Type != "":
+			// Default the ingress to the type explicitly chosen for the API.
+			lbType = config.AWS.APILoadBalancer.Type
 		}
 		obj.Spec.LoadBalancer = configv1.LoadBalancer{
 			Platform: configv1.IngressPlatformSpec{
@@ -135,34 +141,37 @@ func (ing *Ingress) generateClusterConfig(config *types.InstallConfig) ([]byte,
 }
 
 func (ing *Ingress) generateDefaultIngressController(config *types.InstallConfig) ([]byte, error) {
-	switch config.Publish {
-	case types.MixedPublishingStrategy:
-		if config.OperatorPublishingStrategy.Ingress != "Internal" {
-			break
-		}
-		fallthrough
-	case types.InternalPublishingStrategy:
-		obj := &operatorv1.IngressController{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: operatorv1.GroupVersion.String(),
-				Kind:       "IngressController",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "openshift-ingress-operator",
-				Name:      "default",
-			},
-			Spec: operatorv1.IngressControllerSpec{
-				EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
-					Type: operatorv1.LoadBalancerServiceStrategyType,
-					LoadBalancer: &operatorv1.LoadBalancerStrategy{
-						Scope: operatorv1.InternalLoadBalancer,
-					},
-				},
+	obj := &operatorv1.IngressController{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1.GroupVersion.String(),
+			Kind:       "IngressController",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-ingress-operator",
+			Name:      "default",
+		},
+	}
+	customized := false
+
+	if !config.PublicIngress() {
+		obj.Spec.EndpointPublishingStrategy = &operatorv1.EndpointPublishingStrategy{
+			Type: operatorv1.LoadBalancerServiceStrategyType,
+			LoadBalancer: &operatorv1.LoadBalancerStrategy{
+				Scope: operatorv1.InternalLoadBalancer,
 			},
 		}
-		return yaml.Marshal(obj)
+		customized = true
+	}
+
+	if config.ServingCertificates != nil && config.ServingCertificates.Ingress != nil {
+		obj.Spec.DefaultCertificate = &corev1.LocalObjectReference{Name: ingressServingCertSecretName}
+		customized = true
 	}
-	return nil, nil
+
+	if !customized {
+		return nil, nil
+	}
+	return yaml.Marshal(obj)
 }
 
 // Files returns the files generated by the asset.
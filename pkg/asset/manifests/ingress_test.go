@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -115,6 +116,15 @@ func TestGenerateIngerssDefaultPlacement(t *testing.T) {
 			expectedIngressAWSLBType:    configv1.Classic,
 			expectedIngressPlatformType: configv1.AWSPlatformType,
 		},
+		{
+			name:                        "test defaulting of aws lb type to the API load balancer type",
+			installConfigBuildOptions:   []icOption{icBuild.withAPILBType(configv1.NLB)},
+			controlPlaneTopology:        configv1.HighlyAvailableTopologyMode,
+			infrastructureTopology:      configv1.HighlyAvailableTopologyMode,
+			expectedIngressPlacement:    configv1.DefaultPlacementWorkers,
+			expectedIngressAWSLBType:    configv1.NLB,
+			expectedIngressPlatformType: configv1.AWSPlatformType,
+		},
 		{
 			name:                      "none-platform single node with 0 or 1 day-1 workers",
 			installConfigBuildOptions: []icOption{icBuild.forNone()},
@@ -164,7 +174,7 @@ func TestGenerateIngerssDefaultPlacement(t *testing.T) {
 				),
 			)
 			ingressAsset := &Ingress{}
-			err := ingressAsset.Generate(parents)
+			err := ingressAsset.Generate(context.Background(), parents)
 			if !assert.NoError(t, err, "failed to generate asset") {
 				return
 			}
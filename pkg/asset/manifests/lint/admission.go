@@ -0,0 +1,200 @@
+package lint
+
+import (
+	"strings"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// clusterScoped lists the kinds known to the installer that have no
+// namespace. The whole config.openshift.io group is cluster scoped.
+var clusterScoped = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}:        true,
+	{Kind: "Node"}:             true,
+	{Kind: "PersistentVolume"}: true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:                 true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                             true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                         true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                  true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                   true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                      true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                               true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                      true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                                true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:     true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"}:        true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"}: true,
+	{Group: "machineconfiguration.openshift.io", Kind: "MachineConfig"}:               true,
+	{Group: "machineconfiguration.openshift.io", Kind: "MachineConfigPool"}:           true,
+	{Group: "machineconfiguration.openshift.io", Kind: "KubeletConfig"}:               true,
+	{Group: "machineconfiguration.openshift.io", Kind: "ContainerRuntimeConfig"}:      true,
+	{Group: "machineconfiguration.openshift.io", Kind: "ControllerConfig"}:            true,
+	{Group: "operator.openshift.io", Kind: "Network"}:                                 true,
+	{Group: "operator.openshift.io", Kind: "Console"}:                                 true,
+	{Group: "operator.openshift.io", Kind: "Etcd"}:                                    true,
+	{Group: "operator.openshift.io", Kind: "KubeAPIServer"}:                           true,
+	{Group: "operator.openshift.io", Kind: "Storage"}:                                 true,
+}
+
+// namespaced lists the groups whose kinds, except the cluster scoped ones,
+// all live in a namespace.
+var namespaced = map[string]bool{
+	"":                          true,
+	"apps":                      true,
+	"batch":                     true,
+	"policy":                    true,
+	"rbac.authorization.k8s.io": true,
+	"networking.k8s.io":         true,
+	"machine.openshift.io":      true,
+}
+
+const configv1Group = "config.openshift.io"
+
+// configNotSingletons lists the config.openshift.io kinds that may have any
+// name. The operators only read the other ones under the name "cluster".
+var configNotSingletons = map[string]bool{
+	"ClusterOperator":      true,
+	"ClusterVersion":       true,
+	"ClusterImagePolicy":   true,
+	"ImageContentPolicy":   true,
+	"ImageDigestMirrorSet": true,
+	"ImageTagMirrorSet":    true,
+}
+
+// rbacKinds lists the kinds whose names are only validated as path segments.
+var rbacKinds = map[schema.GroupKind]bool{
+	{Group: "rbac.authorization.k8s.io", Kind: "Role"}:               true,
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}:        true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:        true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}: true,
+}
+
+// secretKeys maps the secret types to the keys the API server requires.
+var secretKeys = map[string][]string{
+	"kubernetes.io/dockerconfigjson": {".dockerconfigjson"},
+	"kubernetes.io/dockercfg":        {".dockercfg"},
+	"kubernetes.io/tls":              {"tls.crt", "tls.key"},
+}
+
+// isNamespaced returns whether the kind lives in a namespace, and whether
+// that is known at all.
+func (l *linter) isNamespaced(gvk schema.GroupVersionKind) (bool, bool) {
+	if resource, ok := l.crds[gvk]; ok {
+		return resource.namespaced, true
+	}
+	gk := gvk.GroupKind()
+	switch {
+	case clusterScoped[gk], gk.Group == configv1Group:
+		return false, true
+	case namespaced[gk.Group], gk == schema.GroupKind{Group: "operator.openshift.io", Kind: "IngressController"}:
+		return true, true
+	}
+	return false, false
+}
+
+// admit simulates the admission of the object by the API server and the
+// operators reading it.
+func (l *linter) admit(doc *document, gvk schema.GroupVersionKind) []Finding {
+	obj := doc.obj
+	gk := gvk.GroupKind()
+	var findings []Finding
+	errorf := func(format string, args ...interface{}) {
+		findings = append(findings, doc.finding(SeverityError, format, args...))
+	}
+
+	name := obj.GetName()
+	var nameErrs []string
+	switch {
+	case name == "":
+		nameErrs = []string{"metadata.name is required"}
+	case gk == schema.GroupKind{Kind: "Namespace"}:
+		nameErrs = validation.IsDNS1123Label(name)
+	case rbacKinds[gk]:
+		nameErrs = path.IsValidPathSegmentName(name)
+	default:
+		nameErrs = validation.IsDNS1123Subdomain(name)
+	}
+	for _, msg := range nameErrs {
+		errorf("invalid name %q: %s", name, msg)
+	}
+
+	if isNamespaced, known := l.isNamespaced(gvk); known {
+		ns := obj.GetNamespace()
+		switch {
+		case isNamespaced && ns == "":
+			errorf("metadata.namespace is required for %s", gk)
+		case !isNamespaced && ns != "":
+			errorf("metadata.namespace must not be set for the cluster scoped %s", gk)
+		case ns != "" && !l.namespaces[ns] && !wellKnownNamespace(ns):
+			findings = append(findings, doc.finding(SeverityWarning, "namespace %s is not created by any manifest", ns))
+		}
+	}
+
+	switch {
+	case gk == schema.GroupKind{Group: configv1Group, Kind: "ClusterVersion"} && name != "version":
+		errorf("%s is only read under the name \"version\"", gk)
+	case gk.Group == configv1Group && !configNotSingletons[gk.Kind] && name != "cluster":
+		errorf("%s is only read under the name \"cluster\"", gk)
+	}
+
+	for _, err := range metav1validation.ValidateLabels(obj.GetLabels(), field.NewPath("metadata", "labels")) {
+		errorf("%v", err)
+	}
+	for _, err := range apivalidation.ValidateAnnotations(obj.GetAnnotations(), field.NewPath("metadata", "annotations")) {
+		errorf("%v", err)
+	}
+
+	switch gk {
+	case schema.GroupKind{Group: "machineconfiguration.openshift.io", Kind: "MachineConfig"}:
+		findings = append(findings, admitMachineConfig(doc)...)
+	case schema.GroupKind{Kind: "Secret"}:
+		findings = append(findings, admitSecret(doc)...)
+	}
+	return findings
+}
+
+func wellKnownNamespace(ns string) bool {
+	return ns == "default" || strings.HasPrefix(ns, "kube-") || strings.HasPrefix(ns, "openshift-") || ns == "openshift"
+}
+
+// admitMachineConfig checks the constraints of the machine config operator,
+// which ignores the machine configs it cannot render.
+func admitMachineConfig(doc *document) []Finding {
+	obj := doc.obj
+	var findings []Finding
+	if _, ok := obj.GetLabels()["machineconfiguration.openshift.io/role"]; !ok {
+		findings = append(findings, doc.finding(SeverityError, "the machineconfiguration.openshift.io/role label is required to select a machine config pool"))
+	}
+	if strings.HasPrefix(obj.GetName(), "rendered-") {
+		findings = append(findings, doc.finding(SeverityError, "the rendered- name prefix is reserved for the machine config operator"))
+	}
+	version, found, _ := unstructured.NestedString(obj.Object, "spec", "config", "ignition", "version")
+	if found && !strings.HasPrefix(version, "3.") {
+		findings = append(findings, doc.finding(SeverityError, "spec.config.ignition.version %s is not supported, it must be 3.x", version))
+	}
+	return findings
+}
+
+// admitSecret checks that the typed secrets hold the keys of their type.
+func admitSecret(doc *document) []Finding {
+	obj := doc.obj
+	secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+	data, _, _ := unstructured.NestedMap(obj.Object, "data")
+	stringData, _, _ := unstructured.NestedMap(obj.Object, "stringData")
+	var findings []Finding
+	for _, key := range secretKeys[secretType] {
+		_, inData := data[key]
+		_, inStringData := stringData[key]
+		if !inData && !inStringData {
+			findings = append(findings, doc.finding(SeverityError, "secrets of type %s require the %s key", secretType, key))
+		}
+	}
+	return findings
+}
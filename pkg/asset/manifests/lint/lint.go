@@ -0,0 +1,307 @@
+// Package lint validates the rendered manifests before they are embedded in
+// the bootstrap ignition, where mistakes only surface once the cluster API
+// server rejects, or silently prunes, the objects.
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// Severity is the severity of a finding.
+type Severity string
+
+const (
+	// SeverityError is the severity of the findings that make the API server
+	// reject the object, or drop some of its fields.
+	SeverityError Severity = "error"
+	// SeverityWarning is the severity of the findings that could not be
+	// checked, or that are likely but not certainly mistakes.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a problem found in a manifest.
+type Finding struct {
+	// File is the path of the manifest.
+	File string
+	// Document is the 1-based index of the YAML document in the file.
+	Document int
+	// Object identifies the object as kind/name or kind/namespace/name. It
+	// is empty when the document could not be decoded.
+	Object   string
+	Severity Severity
+	Message  string
+}
+
+func (f Finding) String() string {
+	location := f.File
+	if f.Document > 1 {
+		location = fmt.Sprintf("%s#%d", location, f.Document)
+	}
+	if f.Object != "" {
+		location = fmt.Sprintf("%s (%s)", location, f.Object)
+	}
+	return fmt.Sprintf("%s: %s", location, f.Message)
+}
+
+// document is an object decoded from a manifest.
+type document struct {
+	file  string
+	index int
+	raw   []byte
+	obj   *unstructured.Unstructured
+}
+
+func (d *document) finding(severity Severity, format string, args ...interface{}) Finding {
+	f := Finding{
+		File:     d.file,
+		Document: d.index,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if d.obj != nil {
+		f.Object = objectName(d.obj)
+	}
+	return f
+}
+
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() != "" {
+		return fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+	return fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+}
+
+// Lint validates the YAML and JSON manifests among the files. Each object is
+// decoded strictly against the API types built into the installer, or
+// validated against the schema of the custom resource definitions found in
+// the files, and checked against the constraints enforced at admission. The
+// findings are sorted by file.
+func Lint(files []*asset.File) ([]Finding, error) {
+	var findings []Finding
+	var docs []*document
+	for _, file := range files {
+		switch filepath.Ext(file.Filename) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		fileDocs, fileFindings, err := splitDocuments(file)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, fileDocs...)
+		findings = append(findings, fileFindings...)
+	}
+
+	l, err := newLinter(docs)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		findings = append(findings, l.lint(doc)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Document < findings[j].Document
+	})
+	return findings, nil
+}
+
+// splitDocuments decodes the YAML documents of the file, skipping the empty
+// ones.
+func splitDocuments(file *asset.File) ([]*document, []Finding, error) {
+	var docs []*document
+	var findings []Finding
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(file.Data)))
+	for index := 1; ; index++ {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", file.Filename, err)
+		}
+		doc := &document{file: file.Filename, index: index}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		raw, err := yaml.YAMLToJSONStrict(data)
+		if err != nil {
+			findings = append(findings, doc.finding(SeverityError, "invalid YAML: %v", err))
+			continue
+		}
+		if string(raw) == "null" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			findings = append(findings, doc.finding(SeverityError, "not a Kubernetes object: %v", err))
+			continue
+		}
+		doc.raw, doc.obj = raw, obj
+		docs = append(docs, doc)
+	}
+	return docs, findings, nil
+}
+
+// customResource is a version of a custom resource defined in the manifests.
+type customResource struct {
+	namespaced bool
+	schema     *apiextv1.JSONSchemaProps
+}
+
+type linter struct {
+	scheme       *runtime.Scheme
+	deserializer runtime.Decoder
+	crds         map[schema.GroupVersionKind]customResource
+	crdGroups    map[string]bool
+	namespaces   map[string]bool
+	seen         map[string]*document
+}
+
+func newLinter(docs []*document) (*linter, error) {
+	scheme := runtime.NewScheme()
+	for _, install := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		apiextv1.AddToScheme,
+		configv1.Install,
+		operatorv1.Install,
+		mcfgv1.Install,
+		machinev1.Install,
+		machinev1beta1.Install,
+	} {
+		if err := install(scheme); err != nil {
+			return nil, fmt.Errorf("failed to build the API scheme: %w", err)
+		}
+	}
+
+	l := &linter{
+		scheme:       scheme,
+		deserializer: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDeserializer(),
+		crds:         map[schema.GroupVersionKind]customResource{},
+		crdGroups:    map[string]bool{},
+		namespaces:   map[string]bool{},
+		seen:         map[string]*document{},
+	}
+
+	// The definitions and the namespaces are collected first, since the
+	// manifests are applied all together regardless of their order.
+	for _, doc := range docs {
+		gvk := doc.obj.GroupVersionKind()
+		switch gvk.GroupKind() {
+		case schema.GroupKind{Kind: "Namespace"}:
+			l.namespaces[doc.obj.GetName()] = true
+		case apiextv1.Kind("CustomResourceDefinition"):
+			crd := &apiextv1.CustomResourceDefinition{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(doc.obj.Object, crd); err != nil {
+				// Reported when the definition itself is linted.
+				continue
+			}
+			l.crdGroups[crd.Spec.Group] = true
+			for _, version := range crd.Spec.Versions {
+				if !version.Served {
+					continue
+				}
+				resource := customResource{namespaced: crd.Spec.Scope == apiextv1.NamespaceScoped}
+				if version.Schema != nil {
+					resource.schema = version.Schema.OpenAPIV3Schema
+				}
+				l.crds[schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}] = resource
+			}
+		}
+	}
+	return l, nil
+}
+
+func (l *linter) lint(doc *document) []Finding {
+	obj := doc.obj
+	gvk := obj.GroupVersionKind()
+	if obj.GetAPIVersion() == "" || gvk.Kind == "" {
+		return []Finding{doc.finding(SeverityError, "apiVersion and kind are required")}
+	}
+
+	findings := l.validateFields(doc, gvk)
+	findings = append(findings, l.admit(doc, gvk)...)
+
+	key := strings.Join([]string{gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName()}, "/")
+	if first, ok := l.seen[key]; ok {
+		findings = append(findings, doc.finding(SeverityError, "duplicate of the object in %s", first.file))
+	} else {
+		l.seen[key] = doc
+	}
+	return findings
+}
+
+// validateFields validates the fields of the object against its type or its
+// custom resource definition.
+func (l *linter) validateFields(doc *document, gvk schema.GroupVersionKind) []Finding {
+	if l.scheme.Recognizes(gvk) {
+		_, _, err := l.deserializer.Decode(doc.raw, nil, nil)
+		if err == nil {
+			return nil
+		}
+		if strictErr, ok := runtime.AsStrictDecodingError(err); ok {
+			var findings []Finding
+			for _, e := range strictErr.Errors() {
+				findings = append(findings, doc.finding(SeverityError, "%v", e))
+			}
+			return findings
+		}
+		return []Finding{doc.finding(SeverityError, "%v", err)}
+	}
+
+	if resource, ok := l.crds[gvk]; ok {
+		var findings []Finding
+		for _, problem := range validateSchema("", doc.obj.Object, rootSchema(resource.schema)) {
+			findings = append(findings, doc.finding(SeverityError, "%s", problem))
+		}
+		return findings
+	}
+
+	if l.scheme.IsGroupRegistered(gvk.Group) || l.crdGroups[gvk.Group] {
+		return []Finding{doc.finding(SeverityError, "no kind %s is served in version %s", gvk.Kind, gvk.GroupVersion())}
+	}
+	return []Finding{doc.finding(SeverityWarning, "no schema is known for %s, the fields are not validated", gvk.GroupVersion())}
+}
+
+// rootSchema returns the schema of a custom resource, with the fields common
+// to all the objects, which the definitions do not always list.
+func rootSchema(s *apiextv1.JSONSchemaProps) *apiextv1.JSONSchemaProps {
+	if s == nil {
+		return nil
+	}
+	root := s.DeepCopy()
+	if root.Properties == nil {
+		return root
+	}
+	for _, name := range []string{"apiVersion", "kind"} {
+		root.Properties[name] = apiextv1.JSONSchemaProps{Type: "string"}
+	}
+	root.Properties["metadata"] = apiextv1.JSONSchemaProps{Type: "object"}
+	return root
+}
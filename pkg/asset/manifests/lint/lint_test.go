@@ -0,0 +1,218 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const widgetCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required:
+            - size
+            properties:
+              size:
+                type: integer
+              mode:
+                type: string
+                enum:
+                - Fast
+                - Slow
+`
+
+func TestLint(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name: "valid manifests",
+			files: map[string]string{
+				"manifests/cluster-config.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-config-v1
+  namespace: kube-system
+data:
+  install-config: ""
+`,
+				"manifests/cluster-proxy-01-config.yaml": `apiVersion: config.openshift.io/v1
+kind: Proxy
+metadata:
+  name: cluster
+spec:
+  trustedCA:
+    name: ""
+`,
+				"openshift/99_widgets.yaml": widgetCRD + `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: widgets
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: big
+  namespace: widgets
+spec:
+  size: 3
+  mode: Fast
+`,
+			},
+		},
+		{
+			name: "typo in a known type",
+			files: map[string]string{
+				"manifests/cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: openshift-config
+dta:
+  key: value
+`,
+			},
+			expected: []string{`manifests/cm.yaml (ConfigMap/openshift-config/cm): unknown field "dta"`},
+		},
+		{
+			name: "custom resource not matching its schema",
+			files: map[string]string{
+				"manifests/widget.yaml": widgetCRD + `---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: big
+  namespace: openshift-widgets
+spec:
+  size: "3"
+  mode: Medium
+  colour: blue
+---
+apiVersion: example.com/v2
+kind: Widget
+metadata:
+  name: small
+  namespace: openshift-widgets
+spec: {}
+`,
+			},
+			expected: []string{
+				"manifests/widget.yaml#2 (Widget/openshift-widgets/big): spec.colour: unknown field",
+				`manifests/widget.yaml#2 (Widget/openshift-widgets/big): spec.mode: must be one of "Fast", "Slow"`,
+				"manifests/widget.yaml#2 (Widget/openshift-widgets/big): spec.size: must be of type integer",
+				"manifests/widget.yaml#3 (Widget/openshift-widgets/small): no kind Widget is served in version example.com/v2",
+			},
+		},
+		{
+			name: "admission",
+			files: map[string]string{
+				"openshift/99_admission.yaml": `apiVersion: config.openshift.io/v1
+kind: Proxy
+metadata:
+  name: proxy
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: Invalid_Name
+  namespace: missing
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: pull-secret
+type: kubernetes.io/dockerconfigjson
+data: {}
+---
+apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: rendered-worker
+spec:
+  config:
+    ignition:
+      version: 2.2.0
+---
+apiVersion: config.openshift.io/v1
+kind: Proxy
+metadata:
+  name: proxy
+`,
+			},
+			expected: []string{
+				`openshift/99_admission.yaml (Proxy/proxy): Proxy.config.openshift.io is only read under the name "cluster"`,
+				`openshift/99_admission.yaml#2 (ConfigMap/missing/Invalid_Name): invalid name "Invalid_Name": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+				"openshift/99_admission.yaml#2 (ConfigMap/missing/Invalid_Name): namespace missing is not created by any manifest",
+				"openshift/99_admission.yaml#3 (Secret/pull-secret): metadata.namespace is required for Secret",
+				"openshift/99_admission.yaml#3 (Secret/pull-secret): secrets of type kubernetes.io/dockerconfigjson require the .dockerconfigjson key",
+				"openshift/99_admission.yaml#4 (MachineConfig/rendered-worker): the machineconfiguration.openshift.io/role label is required to select a machine config pool",
+				"openshift/99_admission.yaml#4 (MachineConfig/rendered-worker): the rendered- name prefix is reserved for the machine config operator",
+				"openshift/99_admission.yaml#4 (MachineConfig/rendered-worker): spec.config.ignition.version 2.2.0 is not supported, it must be 3.x",
+				`openshift/99_admission.yaml#5 (Proxy/proxy): Proxy.config.openshift.io is only read under the name "cluster"`,
+				"openshift/99_admission.yaml#5 (Proxy/proxy): duplicate of the object in openshift/99_admission.yaml",
+			},
+		},
+		{
+			name: "not a manifest",
+			files: map[string]string{
+				"manifests/kindless.yml": "metadata:\n  name: foo\n",
+				"manifests/unknown.yaml": "apiVersion: example.org/v1\nkind: Gadget\nmetadata:\n  name: foo\n",
+				"manifests/README.md":    "not: linted",
+			},
+			expected: []string{
+				"manifests/kindless.yml: not a Kubernetes object: Object 'Kind' is missing in '{\"metadata\":{\"name\":\"foo\"}}'",
+				"manifests/unknown.yaml (Gadget/foo): no schema is known for example.org/v1, the fields are not validated",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var files []*asset.File
+			for name, data := range tc.files {
+				files = append(files, &asset.File{Filename: name, Data: []byte(data)})
+			}
+			findings, err := Lint(files)
+			assert.NoError(t, err)
+			actual := make([]string, 0, len(findings))
+			for _, f := range findings {
+				actual = append(actual, f.String())
+			}
+			if tc.expected == nil {
+				tc.expected = []string{}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestLintInvalidYAML(t *testing.T) {
+	findings, err := Lint([]*asset.File{{Filename: "manifests/broken.yaml", Data: []byte("apiVersion: v1\nkind: [\n")}})
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, SeverityError, findings[0].Severity)
+		assert.Contains(t, findings[0].String(), "manifests/broken.yaml: invalid YAML: ")
+		assert.Contains(t, findings[0].Message, "line 2")
+	}
+}
@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// validateSchema checks the value against the structural schema of a custom
+// resource: the types, the enums, the required fields and the unknown
+// fields, which are pruned silently by the API server and thus mostly typos.
+// It returns the violations, prefixed by the path of the field.
+func validateSchema(path string, value interface{}, schema *apiextv1.JSONSchemaProps) []string {
+	if schema == nil {
+		return nil
+	}
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: must be of type %s, not null", path, schema.Type)}
+	}
+
+	if schema.XIntOrString {
+		switch value.(type) {
+		case string, int64, float64:
+			return nil
+		default:
+			return []string{fmt.Sprintf("%s: must be an integer or a string", path)}
+		}
+	}
+
+	var problems []string
+	if msg := checkType(value, schema.Type); msg != "" {
+		return []string{fmt.Sprintf("%s: %s", path, msg)}
+	}
+	if len(schema.Enum) > 0 && !inEnum(value, schema.Enum) {
+		allowed := make([]string, 0, len(schema.Enum))
+		for _, e := range schema.Enum {
+			allowed = append(allowed, string(e.Raw))
+		}
+		problems = append(problems, fmt.Sprintf("%s: must be one of %s", path, strings.Join(allowed, ", ")))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: required field %s is missing", path, name))
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if property, ok := schema.Properties[key]; ok {
+				problems = append(problems, validateSchema(childPath, v[key], &property)...)
+				continue
+			}
+			if schema.AdditionalProperties != nil {
+				if schema.AdditionalProperties.Schema != nil {
+					problems = append(problems, validateSchema(childPath, v[key], schema.AdditionalProperties.Schema)...)
+					continue
+				}
+				if schema.AdditionalProperties.Allows {
+					continue
+				}
+			}
+			if (schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields) || schema.XEmbeddedResource {
+				continue
+			}
+			if len(schema.Properties) == 0 && schema.AdditionalProperties == nil {
+				// An object without properties holds any field.
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("%s: unknown field", childPath))
+		}
+	case []interface{}:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, item := range v {
+				problems = append(problems, validateSchema(fmt.Sprintf("%s[%d]", path, i), item, schema.Items.Schema)...)
+			}
+		}
+	}
+	return problems
+}
+
+// checkType returns why the value, decoded from JSON into an unstructured
+// object, is not of the type of
+// the schema, or an empty string if it is.
+func checkType(value interface{}, schemaType string) string {
+	ok := true
+	switch schemaType {
+	case "object":
+		_, ok = value.(map[string]interface{})
+	case "array":
+		_, ok = value.([]interface{})
+	case "string":
+		_, ok = value.(string)
+	case "boolean":
+		_, ok = value.(bool)
+	case "number":
+		switch value.(type) {
+		case int64, float64:
+		default:
+			ok = false
+		}
+	case "integer":
+		switch v := value.(type) {
+		case int64:
+		case float64:
+			ok = v == math.Trunc(v)
+		default:
+			ok = false
+		}
+	}
+	if !ok {
+		return fmt.Sprintf("must be of type %s", schemaType)
+	}
+	return ""
+}
+
+// inEnum returns whether the value is one of the enum values. They are
+// compared in their JSON form, since the unstructured objects hold integers
+// as int64 and JSON decoding yields float64.
+func inEnum(value interface{}, enum []apiextv1.JSON) bool {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	for _, e := range enum {
+		var allowed interface{}
+		if err := json.Unmarshal(e.Raw, &allowed); err != nil {
+			continue
+		}
+		if encodedAllowed, err := json.Marshal(allowed); err == nil && bytes.Equal(encoded, encodedAllowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package manifests
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
@@ -46,7 +47,7 @@ func (no *Networking) Dependencies() []asset.Asset {
 }
 
 // Generate generates the network operator config.
-func (no *Networking) Generate(dependencies asset.Parents) error {
+func (no *Networking) Generate(_ context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(installConfig)
 
@@ -101,34 +102,39 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 		},
 	}
 
-	switch installConfig.Config.Platform.Name() {
-	case aws.Name:
-		defaultNetworkConfig, err := no.GenerateCustomNetworkConfigMTU(installConfig)
+	var defaultNetworkConfig *operatorv1.DefaultNetworkDefinition
+	if installConfig.Config.Platform.Name() == aws.Name {
+		defaultNetworkConfig, err = no.GenerateCustomNetworkConfigMTU(installConfig)
 		if err != nil {
 			return err
 		}
+	}
+	defaultNetworkConfig = customOVNKubernetesConfig(defaultNetworkConfig, netConfig)
+
+	switch {
+	case installConfig.Config.Platform.Name() == powervs.Name && netConfig.NetworkType == "OVNKubernetes":
+		var customConfig *operatorv1.OVNKubernetesConfig
 		if defaultNetworkConfig != nil {
-			cnoConfig, err := no.generateCustomCnoConfig(defaultNetworkConfig)
-			if err != nil {
-				return fmt.Errorf("cannot generate DefaultNetworkConfig for %s: %w", netConfig.NetworkType, err)
-			}
-			no.FileList = append(no.FileList, &asset.File{
-				Filename: cnoCfgFilename,
-				Data:     cnoConfig,
-			})
+			customConfig = defaultNetworkConfig.OVNKubernetesConfig
+		}
+		ovnConfig, err := OvnKubeConfig(clusterNet, serviceNet, true, customConfig)
+		if err != nil {
+			return errors.Wrapf(err, "cannot marshal Power VS OVNKube Config")
 		}
+		no.FileList = append(no.FileList, &asset.File{
+			Filename: cnoCfgFilename,
+			Data:     ovnConfig,
+		})
 
-	case powervs.Name:
-		if netConfig.NetworkType == "OVNKubernetes" {
-			ovnConfig, err := OvnKubeConfig(clusterNet, serviceNet, true)
-			if err != nil {
-				return errors.Wrapf(err, "cannot marshal Power VS OVNKube Config")
-			}
-			no.FileList = append(no.FileList, &asset.File{
-				Filename: cnoCfgFilename,
-				Data:     ovnConfig,
-			})
+	case defaultNetworkConfig != nil:
+		cnoConfig, err := no.generateCustomCnoConfig(defaultNetworkConfig)
+		if err != nil {
+			return fmt.Errorf("cannot generate DefaultNetworkConfig for %s: %w", netConfig.NetworkType, err)
 		}
+		no.FileList = append(no.FileList, &asset.File{
+			Filename: cnoCfgFilename,
+			Data:     cnoConfig,
+		})
 	}
 
 	return nil
@@ -222,3 +228,39 @@ func (no *Networking) GenerateCustomNetworkConfigMTU(ic *installconfig.InstallCo
 
 	return defNetCfg, nil
 }
+
+// customOVNKubernetesConfig merges the OVN-Kubernetes options of the
+// install-config into the DefaultNetwork configuration, creating it when
+// there are options. It returns the configuration unchanged otherwise.
+func customOVNKubernetesConfig(defaultNetwork *operatorv1.DefaultNetworkDefinition, netConfig *types.Networking) *operatorv1.DefaultNetworkDefinition {
+	if netConfig == nil || netConfig.OVNKubernetesConfig == nil || netConfig.NetworkType != string(operatorv1.NetworkTypeOVNKubernetes) {
+		return defaultNetwork
+	}
+	custom := netConfig.OVNKubernetesConfig
+
+	if defaultNetwork == nil {
+		defaultNetwork = &operatorv1.DefaultNetworkDefinition{Type: operatorv1.NetworkTypeOVNKubernetes}
+	}
+	if defaultNetwork.OVNKubernetesConfig == nil {
+		defaultNetwork.OVNKubernetesConfig = &operatorv1.OVNKubernetesConfig{}
+	}
+	ovnConfig := defaultNetwork.OVNKubernetesConfig
+
+	// The MTU of the install-config has precedence over the one of the edge zones.
+	if custom.MTU > 0 {
+		mtu := custom.MTU
+		ovnConfig.MTU = &mtu
+	}
+	if custom.GatewayMode != "" {
+		ovnConfig.GatewayConfig = &operatorv1.GatewayConfig{
+			RoutingViaHost: custom.GatewayMode == types.OVNGatewayModeLocal,
+		}
+	}
+	if custom.V4InternalSubnet != nil {
+		ovnConfig.V4InternalSubnet = custom.V4InternalSubnet.String()
+	}
+	if custom.IPsecMode != "" {
+		ovnConfig.IPsecConfig = &operatorv1.IPsecConfig{Mode: custom.IPsecMode}
+	}
+	return defaultNetwork
+}
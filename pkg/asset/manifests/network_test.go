@@ -12,6 +12,7 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
@@ -234,3 +235,116 @@ func TestNetworking_generateCustomCnoConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomOVNKubernetesConfig(t *testing.T) {
+	edgeMTU := &operatorv1.DefaultNetworkDefinition{
+		Type: operatorv1.NetworkTypeOVNKubernetes,
+		OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+			MTU: ptr.To(uint32(1200)),
+		},
+	}
+	tests := []struct {
+		name           string
+		defaultNetwork *operatorv1.DefaultNetworkDefinition
+		netConfig      *types.Networking
+		want           *operatorv1.DefaultNetworkDefinition
+	}{
+		{
+			name:      "no options",
+			netConfig: &types.Networking{NetworkType: "OVNKubernetes"},
+		},
+		{
+			name:           "no options with edge MTU",
+			defaultNetwork: edgeMTU,
+			netConfig:      &types.Networking{NetworkType: "OVNKubernetes"},
+			want:           edgeMTU,
+		},
+		{
+			name: "other network type",
+			netConfig: &types.Networking{
+				NetworkType:         "Calico",
+				OVNKubernetesConfig: &types.OVNKubernetesConfig{MTU: 1400},
+			},
+		},
+		{
+			name: "all options",
+			netConfig: &types.Networking{
+				NetworkType: "OVNKubernetes",
+				OVNKubernetesConfig: &types.OVNKubernetesConfig{
+					MTU:              8855,
+					GatewayMode:      types.OVNGatewayModeLocal,
+					V4InternalSubnet: ipnet.MustParseCIDR("100.65.0.0/16"),
+					IPsecMode:        operatorv1.IPsecModeFull,
+				},
+			},
+			want: &operatorv1.DefaultNetworkDefinition{
+				Type: operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+					MTU:              ptr.To(uint32(8855)),
+					GatewayConfig:    &operatorv1.GatewayConfig{RoutingViaHost: true},
+					V4InternalSubnet: "100.65.0.0/16",
+					IPsecConfig:      &operatorv1.IPsecConfig{Mode: operatorv1.IPsecModeFull},
+				},
+			},
+		},
+		{
+			name: "shared gateway mode with edge MTU",
+			defaultNetwork: &operatorv1.DefaultNetworkDefinition{
+				Type: operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+					MTU: ptr.To(uint32(1200)),
+				},
+			},
+			netConfig: &types.Networking{
+				NetworkType:         "OVNKubernetes",
+				OVNKubernetesConfig: &types.OVNKubernetesConfig{GatewayMode: types.OVNGatewayModeShared},
+			},
+			want: &operatorv1.DefaultNetworkDefinition{
+				Type: operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+					MTU:           ptr.To(uint32(1200)),
+					GatewayConfig: &operatorv1.GatewayConfig{},
+				},
+			},
+		},
+		{
+			name: "MTU overrides edge MTU",
+			defaultNetwork: &operatorv1.DefaultNetworkDefinition{
+				Type: operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+					MTU: ptr.To(uint32(1200)),
+				},
+			},
+			netConfig: &types.Networking{
+				NetworkType:         "OVNKubernetes",
+				OVNKubernetesConfig: &types.OVNKubernetesConfig{MTU: 1100},
+			},
+			want: &operatorv1.DefaultNetworkDefinition{
+				Type: operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+					MTU: ptr.To(uint32(1100)),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, customOVNKubernetesConfig(tt.defaultNetwork, tt.netConfig))
+		})
+	}
+}
+
+func TestOvnKubeConfigCustom(t *testing.T) {
+	data, err := OvnKubeConfig(
+		[]configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14", HostPrefix: 23}},
+		[]string{"172.30.0.0/16"},
+		true,
+		&operatorv1.OVNKubernetesConfig{
+			MTU:           ptr.To(uint32(1400)),
+			GatewayConfig: &operatorv1.GatewayConfig{},
+		},
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "        routingViaHost: true\n")
+	assert.Contains(t, string(data), "      mtu: 1400\n")
+}
@@ -28,8 +28,8 @@ func GenerateClusterAssets(installConfig *installconfig.InstallConfig, clusterID
 		},
 		Spec: capnv1.NutanixClusterSpec{
 			ControlPlaneEndpoint: capv1.APIEndpoint{
-				Host: fmt.Sprintf("api.%s.%s", installConfig.Config.ObjectMeta.Name, installConfig.Config.BaseDomain),
-				Port: 6443,
+				Host: installConfig.Config.APIHostname(),
+				Port: installConfig.Config.APIPort(),
 			},
 			PrismCentral: &credentialTypes.NutanixPrismEndpoint{
 				Address: ic.Platform.Nutanix.PrismCentral.Endpoint.Address,
@@ -0,0 +1,205 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var oauthConfigFileName = filepath.Join(manifestDir, "cluster-oauth-02-config.yml")
+
+// OAuth generates the cluster OAuth config, with the identity providers of
+// the install-config, and the secrets and config maps they reference.
+type OAuth struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*OAuth)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*OAuth) Name() string {
+	return "OAuth Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*OAuth) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the OAuth config. Nothing is generated when the
+// install-config configures no identity provider.
+func (o *OAuth) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	o.FileList = []*asset.File{}
+	if installConfig.Config.OAuth == nil || len(installConfig.Config.OAuth.IdentityProviders) == 0 {
+		return nil
+	}
+
+	config := &configv1.OAuth{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.GroupVersion.String(),
+			Kind:       "OAuth",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+	}
+
+	for i, idp := range installConfig.Config.OAuth.IdentityProviders {
+		provider := configv1.IdentityProvider{
+			Name:          idp.Name,
+			MappingMethod: idp.MappingMethod,
+		}
+		// The names of the identity providers are free-form, so the
+		// referenced objects are named after their index instead.
+		prefix := fmt.Sprintf("oauth-idp-%d", i)
+		switch {
+		case idp.HTPasswd != nil:
+			name := prefix + "-htpasswd"
+			if err := o.addSecret(name, "htpasswd", idp.HTPasswd.FileData); err != nil {
+				return err
+			}
+			provider.Type = configv1.IdentityProviderTypeHTPasswd
+			provider.HTPasswd = &configv1.HTPasswdIdentityProvider{
+				FileData: configv1.SecretNameReference{Name: name},
+			}
+		case idp.LDAP != nil:
+			provider.Type = configv1.IdentityProviderTypeLDAP
+			provider.LDAP = &configv1.LDAPIdentityProvider{
+				URL:        idp.LDAP.URL,
+				BindDN:     idp.LDAP.BindDN,
+				Insecure:   idp.LDAP.Insecure,
+				Attributes: idp.LDAP.Attributes,
+			}
+			if idp.LDAP.BindPassword != "" {
+				name := prefix + "-bind-password"
+				if err := o.addSecret(name, "bindPassword", idp.LDAP.BindPassword); err != nil {
+					return err
+				}
+				provider.LDAP.BindPassword = configv1.SecretNameReference{Name: name}
+			}
+			if idp.LDAP.CA != "" {
+				name := prefix + "-ca"
+				if err := o.addCAConfigMap(name, idp.LDAP.CA); err != nil {
+					return err
+				}
+				provider.LDAP.CA = configv1.ConfigMapNameReference{Name: name}
+			}
+		case idp.OpenID != nil:
+			name := prefix + "-client-secret"
+			if err := o.addSecret(name, "clientSecret", idp.OpenID.ClientSecret); err != nil {
+				return err
+			}
+			provider.Type = configv1.IdentityProviderTypeOpenID
+			provider.OpenID = &configv1.OpenIDIdentityProvider{
+				ClientID:     idp.OpenID.ClientID,
+				ClientSecret: configv1.SecretNameReference{Name: name},
+				Issuer:       idp.OpenID.Issuer,
+				ExtraScopes:  idp.OpenID.ExtraScopes,
+				Claims: configv1.OpenIDClaims{
+					PreferredUsername: []string{"preferred_username"},
+					Name:              []string{"name"},
+					Email:             []string{"email"},
+				},
+			}
+			if idp.OpenID.Claims != nil {
+				provider.OpenID.Claims = *idp.OpenID.Claims
+			}
+			if idp.OpenID.CA != "" {
+				name := prefix + "-ca"
+				if err := o.addCAConfigMap(name, idp.OpenID.CA); err != nil {
+					return err
+				}
+				provider.OpenID.CA = configv1.ConfigMapNameReference{Name: name}
+			}
+		}
+		config.Spec.IdentityProviders = append(config.Spec.IdentityProviders, provider)
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create OAuth config")
+	}
+	o.FileList = append(o.FileList, &asset.File{
+		Filename: oauthConfigFileName,
+		Data:     configData,
+	})
+
+	return nil
+}
+
+func (o *OAuth) addSecret(name, key, value string) error {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-config",
+			Name:      name,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			key: []byte(value),
+		},
+	}
+	data, err := yaml.Marshal(secret)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create secret %s", name)
+	}
+	o.FileList = append(o.FileList, &asset.File{
+		Filename: filepath.Join(manifestDir, fmt.Sprintf("cluster-%s-secret.yaml", name)),
+		Data:     data,
+	})
+	return nil
+}
+
+func (o *OAuth) addCAConfigMap(name, ca string) error {
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-config",
+			Name:      name,
+		},
+		Data: map[string]string{
+			"ca.crt": ca,
+		},
+	}
+	data, err := yaml.Marshal(configMap)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create config map %s", name)
+	}
+	o.FileList = append(o.FileList, &asset.File{
+		Filename: filepath.Join(manifestDir, fmt.Sprintf("cluster-%s-configmap.yaml", name)),
+		Data:     data,
+	})
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (o *OAuth) Files() []*asset.File {
+	return o.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (o *OAuth) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,95 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestGenerateOAuth(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+		OAuth: &types.OAuth{
+			IdentityProviders: []types.IdentityProvider{{
+				Name:     "local users",
+				HTPasswd: &types.HTPasswdIdentityProvider{FileData: "admin:hash"},
+			}, {
+				Name:          "sso",
+				MappingMethod: configv1.MappingMethodLookup,
+				OpenID: &types.OpenIDIdentityProvider{
+					ClientID:     "openshift",
+					ClientSecret: "secret",
+					Issuer:       "https://sso.test-domain",
+					CA:           "test-ca",
+				},
+			}},
+		},
+	}))
+	oauth := &OAuth{}
+	if !assert.NoError(t, oauth.Generate(context.Background(), parents)) {
+		return
+	}
+
+	var filenames []string
+	for _, f := range oauth.Files() {
+		filenames = append(filenames, f.Filename)
+	}
+	assert.Equal(t, []string{
+		"manifests/cluster-oauth-idp-0-htpasswd-secret.yaml",
+		"manifests/cluster-oauth-idp-1-client-secret-secret.yaml",
+		"manifests/cluster-oauth-idp-1-ca-configmap.yaml",
+		"manifests/cluster-oauth-02-config.yml",
+	}, filenames)
+
+	config := &configv1.OAuth{}
+	if assert.NoError(t, yaml.Unmarshal(oauth.Files()[3].Data, config)) {
+		assert.Equal(t, []configv1.IdentityProvider{{
+			Name: "local users",
+			IdentityProviderConfig: configv1.IdentityProviderConfig{
+				Type: configv1.IdentityProviderTypeHTPasswd,
+				HTPasswd: &configv1.HTPasswdIdentityProvider{
+					FileData: configv1.SecretNameReference{Name: "oauth-idp-0-htpasswd"},
+				},
+			},
+		}, {
+			Name:          "sso",
+			MappingMethod: configv1.MappingMethodLookup,
+			IdentityProviderConfig: configv1.IdentityProviderConfig{
+				Type: configv1.IdentityProviderTypeOpenID,
+				OpenID: &configv1.OpenIDIdentityProvider{
+					ClientID:     "openshift",
+					ClientSecret: configv1.SecretNameReference{Name: "oauth-idp-1-client-secret"},
+					CA:           configv1.ConfigMapNameReference{Name: "oauth-idp-1-ca"},
+					Issuer:       "https://sso.test-domain",
+					Claims: configv1.OpenIDClaims{
+						PreferredUsername: []string{"preferred_username"},
+						Name:              []string{"name"},
+						Email:             []string{"email"},
+					},
+				},
+			},
+		}}, config.Spec.IdentityProviders)
+	}
+}
+
+func TestGenerateOAuthWithoutIdentityProviders(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+	}))
+	oauth := &OAuth{}
+	if assert.NoError(t, oauth.Generate(context.Background(), parents)) {
+		assert.Empty(t, oauth.Files())
+	}
+}
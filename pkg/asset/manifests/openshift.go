@@ -22,6 +22,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig/ovirt"
 	"github.com/openshift/installer/pkg/asset/machines"
 	osmachine "github.com/openshift/installer/pkg/asset/machines/openstack"
+	externalmanifests "github.com/openshift/installer/pkg/asset/manifests/external"
 	openstackmanifests "github.com/openshift/installer/pkg/asset/manifests/openstack"
 	"github.com/openshift/installer/pkg/asset/openshiftinstall"
 	"github.com/openshift/installer/pkg/asset/password"
@@ -31,6 +32,7 @@ import (
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
 	baremetaltypes "github.com/openshift/installer/pkg/types/baremetal"
+	externaltypes "github.com/openshift/installer/pkg/types/external"
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
 	openstacktypes "github.com/openshift/installer/pkg/types/openstack"
@@ -76,7 +78,7 @@ func (o *Openshift) Dependencies() []asset.Asset {
 }
 
 // Generate generates the respective operator config.yml files
-func (o *Openshift) Generate(dependencies asset.Parents) error {
+func (o *Openshift) Generate(ctx context.Context, dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	clusterID := &installconfig.ClusterID{}
 	kubeadminPassword := &password.KubeadminPassword{}
@@ -87,7 +89,7 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 	platform := installConfig.Config.Platform.Name()
 	switch platform {
 	case awstypes.Name:
-		ssn, err := installConfig.AWS.Session(context.TODO())
+		ssn, err := installConfig.AWS.Session(ctx)
 		if err != nil {
 			return err
 		}
@@ -128,7 +130,7 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 			},
 		}
 	case gcptypes.Name:
-		session, err := gcp.GetSession(context.TODO())
+		session, err := gcp.GetSession(ctx)
 		if err != nil {
 			return err
 		}
@@ -252,8 +254,9 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 		baremetalConfig,
 		rhcosImage)
 
-	assetData := map[string][]byte{
-		"99_kubeadmin-password-secret.yaml": applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData),
+	assetData := map[string][]byte{}
+	if !installConfig.Config.KubeadminDisabled() {
+		assetData["99_kubeadmin-password-secret.yaml"] = applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData)
 	}
 
 	switch platform {
@@ -262,10 +265,27 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 			assetData["99_cloud-creds-secret.yaml"] = applyTemplateData(cloudCredsSecret.Files()[0].Data, templateData)
 		}
 		assetData["99_role-cloud-creds-secret-reader.yaml"] = applyTemplateData(roleCloudCredsSecretReader.Files()[0].Data, templateData)
+	case externaltypes.Name:
+		// The provider deploys its cloud controller manager with its own
+		// manifests, only the credentials come from the install-config.
+		credentialsSecret, err := externalmanifests.CloudControllerManagerCredentialsSecret(installConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the cloud controller manager credentials secret")
+		}
+		assetData["99_cloud-controller-manager-credentials-secret.yaml"] = credentialsSecret
 	case baremetaltypes.Name:
+		bmPlatform := installConfig.Config.Platform.BareMetal
+		if bmPlatform.ProvisioningNetwork == baremetaltypes.DisabledProvisioningNetwork {
+			// The hosts are provisioned through virtual media over the
+			// external network, so metal3 must not claim a provisioning
+			// interface on the control plane hosts.
+			platformCopy := *bmPlatform
+			platformCopy.ProvisioningNetworkInterface = ""
+			bmPlatform = &platformCopy
+		}
 		bmTemplateData := baremetalTemplateData{
-			Baremetal:                 installConfig.Config.Platform.BareMetal,
-			ProvisioningOSDownloadURL: string(*rhcosImage),
+			Baremetal:                 bmPlatform,
+			ProvisioningOSDownloadURL: rhcosImage.ControlPlane,
 		}
 		assetData["99_baremetal-provisioning-config.yaml"] = applyTemplateData(baremetalConfig.Files()[0].Data, bmTemplateData)
 	}
@@ -3,6 +3,7 @@ package manifests
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"path/filepath"
 	"strings"
@@ -64,6 +65,14 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&ImageContentSourcePolicy{},
 		&ClusterCSIDriverConfig{},
 		&ImageDigestMirrorSet{},
+		&ServingCertificates{},
+		&APIServer{},
+		&OAuth{},
+		&ImageConfig{},
+		&PerformanceProfile{},
+		&Hardening{},
+		&SecondaryNetworks{},
+		&ImageRegistry{},
 		&tls.RootCA{},
 		&tls.MCSCertKey{},
 
@@ -76,7 +85,7 @@ func (m *Manifests) Dependencies() []asset.Asset {
 }
 
 // Generate generates the respective operator config.yml files
-func (m *Manifests) Generate(dependencies asset.Parents) error {
+func (m *Manifests) Generate(_ context.Context, dependencies asset.Parents) error {
 	ingress := &Ingress{}
 	dns := &DNS{}
 	network := &Networking{}
@@ -87,8 +96,16 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	imageContentSourcePolicy := &ImageContentSourcePolicy{}
 	clusterCSIDriverConfig := &ClusterCSIDriverConfig{}
 	imageDigestMirrorSet := &ImageDigestMirrorSet{}
+	servingCertificates := &ServingCertificates{}
+	apiServer := &APIServer{}
+	oauth := &OAuth{}
+	imageConfig := &ImageConfig{}
+	performanceProfile := &PerformanceProfile{}
+	hardening := &Hardening{}
+	secondaryNetworks := &SecondaryNetworks{}
+	imageRegistry := &ImageRegistry{}
 
-	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy, imageDigestMirrorSet, clusterCSIDriverConfig)
+	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy, imageDigestMirrorSet, clusterCSIDriverConfig, servingCertificates, apiServer, oauth, imageConfig, performanceProfile, hardening, secondaryNetworks, imageRegistry)
 
 	redactedConfig, err := redactedInstallConfig(*installConfig.Config)
 	if err != nil {
@@ -125,6 +142,14 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	m.FileList = append(m.FileList, imageContentSourcePolicy.Files()...)
 	m.FileList = append(m.FileList, clusterCSIDriverConfig.Files()...)
 	m.FileList = append(m.FileList, imageDigestMirrorSet.Files()...)
+	m.FileList = append(m.FileList, servingCertificates.Files()...)
+	m.FileList = append(m.FileList, apiServer.Files()...)
+	m.FileList = append(m.FileList, oauth.Files()...)
+	m.FileList = append(m.FileList, imageConfig.Files()...)
+	m.FileList = append(m.FileList, performanceProfile.Files()...)
+	m.FileList = append(m.FileList, hardening.Files()...)
+	m.FileList = append(m.FileList, secondaryNetworks.Files()...)
+	m.FileList = append(m.FileList, imageRegistry.Files()...)
 
 	asset.SortFiles(m.FileList)
 
@@ -149,7 +174,7 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 	)
 
 	templateData := &bootkubeTemplateData{
-		CVOCapabilities:  installConfig.Config.Capabilities,
+		CVOCapabilities:  installConfig.Config.ResolvedCapabilities(),
 		CVOClusterID:     clusterID.UUID,
 		McsTLSCert:       base64.StdEncoding.EncodeToString(mcsCertKey.Cert()),
 		McsTLSKey:        base64.StdEncoding.EncodeToString(mcsCertKey.Key()),
@@ -236,6 +261,37 @@ func redactedInstallConfig(config types.InstallConfig) ([]byte, error) {
 	newConfig := config
 
 	newConfig.PullSecret = ""
+	if certs := config.ServingCertificates; certs != nil {
+		newCerts := types.ServingCertificates{}
+		if certs.APIServer != nil {
+			newCerts.APIServer = &types.CertificateKeyPair{Certificate: certs.APIServer.Certificate}
+		}
+		if certs.Ingress != nil {
+			newCerts.Ingress = &types.CertificateKeyPair{Certificate: certs.Ingress.Certificate}
+		}
+		newConfig.ServingCertificates = &newCerts
+	}
+	if config.OAuth != nil {
+		newOAuth := *config.OAuth
+		newOAuth.IdentityProviders = make([]types.IdentityProvider, len(config.OAuth.IdentityProviders))
+		for i, idp := range config.OAuth.IdentityProviders {
+			if idp.HTPasswd != nil {
+				idp.HTPasswd = &types.HTPasswdIdentityProvider{}
+			}
+			if idp.LDAP != nil {
+				ldap := *idp.LDAP
+				ldap.BindPassword = ""
+				idp.LDAP = &ldap
+			}
+			if idp.OpenID != nil {
+				openID := *idp.OpenID
+				openID.ClientSecret = ""
+				idp.OpenID = &openID
+			}
+			newOAuth.IdentityProviders[i] = idp
+		}
+		newConfig.OAuth = &newOAuth
+	}
 	if newConfig.Platform.VSphere != nil {
 		p := config.VSphere
 		newVCenters := make([]vsphere.VCenter, len(p.VCenters))
@@ -265,6 +321,11 @@ func redactedInstallConfig(config types.InstallConfig) ([]byte, error) {
 		}
 		newConfig.Platform.VSphere = &newVSpherePlatform
 	}
+	if config.Platform.External != nil && len(config.Platform.External.CloudControllerManagerCredentials) > 0 {
+		newExternalPlatform := *config.Platform.External
+		newExternalPlatform.CloudControllerManagerCredentials = nil
+		newConfig.Platform.External = &newExternalPlatform
+	}
 
 	return yaml.Marshal(newConfig)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/external"
 	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
 )
 
@@ -74,6 +75,25 @@ func TestRedactedInstallConfig(t *testing.T) {
 				},
 			},
 			PullSecret: "test-pull-secret",
+			ServingCertificates: &types.ServingCertificates{
+				APIServer: &types.CertificateKeyPair{
+					Certificate: "test-certificate",
+					Key:         "test-key",
+				},
+			},
+			OAuth: &types.OAuth{
+				IdentityProviders: []types.IdentityProvider{{
+					Name:     "htpasswd",
+					HTPasswd: &types.HTPasswdIdentityProvider{FileData: "test-htpasswd"},
+				}, {
+					Name: "sso",
+					OpenID: &types.OpenIDIdentityProvider{
+						ClientID:     "test-client",
+						ClientSecret: "test-client-secret",
+						Issuer:       "https://sso.test-domain",
+					},
+				}},
+			},
 		}
 	}
 	expectedConfig := createInstallConfig()
@@ -100,6 +120,16 @@ networking:
   networkType: test-network-type
   serviceNetwork:
   - 1.2.3.4/5
+oauth:
+  identityProviders:
+  - htpasswd:
+      fileData: ""
+    name: htpasswd
+  - name: sso
+    openID:
+      clientID: test-client
+      clientSecret: ""
+      issuer: https://sso.test-domain
 platform:
   vsphere:
     failureDomains:
@@ -122,6 +152,10 @@ platform:
       server: test-server-1
       user: ""
 pullSecret: ""
+servingCertificates:
+  apiServer:
+    certificate: test-certificate
+    key: ""
 sshKey: test-ssh-key
 `
 	ic := createInstallConfig()
@@ -131,3 +165,23 @@ sshKey: test-ssh-key
 	}
 	assert.Equal(t, expectedConfig, ic, "install config was unexpectedly modified")
 }
+
+func TestRedactedInstallConfigExternalCredentials(t *testing.T) {
+	ic := &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "test-domain",
+		Platform: types.Platform{
+			External: &external.Platform{
+				PlatformName:                      "partner",
+				CloudControllerManager:            external.CloudControllerManagerTypeExternal,
+				CloudControllerManagerCredentials: map[string]string{"api-token": "test-token"},
+			},
+		},
+	}
+	actualYaml, err := redactedInstallConfig(*ic)
+	if assert.NoError(t, err, "unexpected error") {
+		assert.Contains(t, string(actualYaml), "platformName: partner")
+		assert.NotContains(t, string(actualYaml), "test-token")
+	}
+	assert.Equal(t, map[string]string{"api-token": "test-token"}, ic.External.CloudControllerManagerCredentials, "install config was unexpectedly modified")
+}
@@ -8,8 +8,9 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 )
 
-// OvnKubeConfig creates a config file for the OVNKubernetes CNI provider
-func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouting bool) ([]byte, error) {
+// OvnKubeConfig creates a config file for the OVNKubernetes CNI provider,
+// on top of the custom OVNKubernetes configuration when it is not nil.
+func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouting bool, custom *operatorv1.OVNKubernetesConfig) ([]byte, error) {
 
 	operCNs := []operatorv1.ClusterNetworkEntry{}
 	for _, cn := range cns {
@@ -19,6 +20,13 @@ func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouti
 		}
 		operCNs = append(operCNs, ocn)
 	}
+	ovnKubeConfig := &operatorv1.OVNKubernetesConfig{}
+	if custom != nil {
+		ovnKubeConfig = custom.DeepCopy()
+	}
+	ovnKubeConfig.GatewayConfig = &operatorv1.GatewayConfig{
+		RoutingViaHost: useHostRouting,
+	}
 	ovnConfig := operatorv1.Network{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "operator.openshift.io/v1",
@@ -32,12 +40,8 @@ func OvnKubeConfig(cns []configv1.ClusterNetworkEntry, sn []string, useHostRouti
 			ClusterNetwork: operCNs,
 			ServiceNetwork: sn,
 			DefaultNetwork: operatorv1.DefaultNetworkDefinition{
-				Type: operatorv1.NetworkTypeOVNKubernetes,
-				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
-					GatewayConfig: &operatorv1.GatewayConfig{
-						RoutingViaHost: useHostRouting,
-					},
-				},
+				Type:                operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: ovnKubeConfig,
 			},
 		},
 		Status: operatorv1.NetworkStatus{},
@@ -0,0 +1,160 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+var performanceProfileFileName = filepath.Join(manifestDir, "cluster-performanceprofile-02-config.yml")
+
+// The PerformanceProfile API of the Node Tuning Operator is not vendored, so
+// only the fields rendered from the install-config are declared here.
+type performanceProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec performanceProfileSpec `json:"spec"`
+}
+
+type performanceProfileSpec struct {
+	CPU                       performanceProfileCPU `json:"cpu"`
+	HugePages                 *performanceHugePages `json:"hugepages,omitempty"`
+	RealTimeKernel            *performanceRealTime  `json:"realTimeKernel,omitempty"`
+	NodeSelector              map[string]string     `json:"nodeSelector"`
+	MachineConfigPoolSelector map[string]string     `json:"machineConfigPoolSelector"`
+}
+
+type performanceProfileCPU struct {
+	Reserved string `json:"reserved"`
+	Isolated string `json:"isolated"`
+}
+
+type performanceHugePages struct {
+	DefaultHugePagesSize string                `json:"defaultHugepagesSize,omitempty"`
+	Pages                []performanceHugePage `json:"pages,omitempty"`
+}
+
+type performanceHugePage struct {
+	Size  string `json:"size"`
+	Count int32  `json:"count"`
+	Node  *int32 `json:"node,omitempty"`
+}
+
+type performanceRealTime struct {
+	Enabled bool `json:"enabled"`
+}
+
+// PerformanceProfile generates the PerformanceProfile tuning the nodes for
+// low latency workloads.
+type PerformanceProfile struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*PerformanceProfile)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*PerformanceProfile) Name() string {
+	return "Performance Profile"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*PerformanceProfile) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the PerformanceProfile. Nothing is generated when the
+// install-config sets no performance profile.
+func (p *PerformanceProfile) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	p.FileList = []*asset.File{}
+	profile := installConfig.Config.PerformanceProfile
+	if profile == nil {
+		return nil
+	}
+
+	// The workloads run on the control plane when there are no compute
+	// replicas, e.g. on single-node DUs, so the profile tunes the masters.
+	role := types.MachinePoolComputeRoleName
+	computeReplicas := int64(0)
+	for _, pool := range installConfig.Config.Compute {
+		if pool.Replicas != nil {
+			computeReplicas += *pool.Replicas
+		}
+	}
+	if computeReplicas == 0 {
+		role = types.MachinePoolControlPlaneRoleName
+	}
+
+	config := &performanceProfile{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "performance.openshift.io/v2",
+			Kind:       "PerformanceProfile",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("openshift-%s-performance", role),
+			// not namespaced
+		},
+		Spec: performanceProfileSpec{
+			CPU: performanceProfileCPU{
+				Reserved: profile.ReservedCPUs,
+				Isolated: profile.IsolatedCPUs,
+			},
+			NodeSelector: map[string]string{
+				fmt.Sprintf("node-role.kubernetes.io/%s", role): "",
+			},
+			MachineConfigPoolSelector: map[string]string{
+				fmt.Sprintf("pools.operator.machineconfiguration.openshift.io/%s", role): "",
+			},
+		},
+	}
+	if profile.HugePages != nil {
+		config.Spec.HugePages = &performanceHugePages{
+			DefaultHugePagesSize: profile.HugePages.DefaultSize,
+		}
+		for _, page := range profile.HugePages.Pages {
+			config.Spec.HugePages.Pages = append(config.Spec.HugePages.Pages, performanceHugePage{
+				Size:  page.Size,
+				Count: page.Count,
+				Node:  page.Node,
+			})
+		}
+	}
+	if profile.RealTimeKernel {
+		config.Spec.RealTimeKernel = &performanceRealTime{Enabled: true}
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create performance profile")
+	}
+	p.FileList = append(p.FileList, &asset.File{
+		Filename: performanceProfileFileName,
+		Data:     configData,
+	})
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (p *PerformanceProfile) Files() []*asset.File {
+	return p.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (p *PerformanceProfile) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
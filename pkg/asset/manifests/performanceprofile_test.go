@@ -0,0 +1,113 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestGeneratePerformanceProfile(t *testing.T) {
+	cases := []struct {
+		name         string
+		compute      []types.MachinePool
+		profile      *types.PerformanceProfile
+		expectedData string
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name: "single node",
+			compute: []types.MachinePool{
+				{Name: "worker", Replicas: pointer.Int64(0)},
+			},
+			profile: &types.PerformanceProfile{
+				ReservedCPUs: "0-1",
+				IsolatedCPUs: "2-15",
+				HugePages: &types.HugePages{
+					DefaultSize: "1G",
+					Pages:       []types.HugePage{{Size: "1G", Count: 4, Node: pointer.Int32(0)}},
+				},
+				RealTimeKernel: true,
+			},
+			expectedData: `apiVersion: performance.openshift.io/v2
+kind: PerformanceProfile
+metadata:
+  creationTimestamp: null
+  name: openshift-master-performance
+spec:
+  cpu:
+    isolated: 2-15
+    reserved: 0-1
+  hugepages:
+    defaultHugepagesSize: 1G
+    pages:
+    - count: 4
+      node: 0
+      size: 1G
+  machineConfigPoolSelector:
+    pools.operator.machineconfiguration.openshift.io/master: ""
+  nodeSelector:
+    node-role.kubernetes.io/master: ""
+  realTimeKernel:
+    enabled: true
+`,
+		},
+		{
+			name: "compute nodes",
+			compute: []types.MachinePool{
+				{Name: "worker", Replicas: pointer.Int64(2)},
+			},
+			profile: &types.PerformanceProfile{
+				ReservedCPUs: "0-1",
+				IsolatedCPUs: "2-15",
+			},
+			expectedData: `apiVersion: performance.openshift.io/v2
+kind: PerformanceProfile
+metadata:
+  creationTimestamp: null
+  name: openshift-worker-performance
+spec:
+  cpu:
+    isolated: 2-15
+    reserved: 0-1
+  machineConfigPoolSelector:
+    pools.operator.machineconfiguration.openshift.io/worker: ""
+  nodeSelector:
+    node-role.kubernetes.io/worker: ""
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+				ObjectMeta:         metav1.ObjectMeta{Name: "test-cluster"},
+				BaseDomain:         "test-domain",
+				Platform:           types.Platform{None: &none.Platform{}},
+				Compute:            tc.compute,
+				PerformanceProfile: tc.profile,
+			}))
+			performanceProfile := &PerformanceProfile{}
+			if !assert.NoError(t, performanceProfile.Generate(context.Background(), parents)) {
+				return
+			}
+			if tc.expectedData == "" {
+				assert.Empty(t, performanceProfile.Files())
+				return
+			}
+			if assert.Len(t, performanceProfile.Files(), 1) {
+				assert.Equal(t, "manifests/cluster-performanceprofile-02-config.yml", performanceProfile.Files()[0].Filename)
+				assert.Equal(t, tc.expectedData, string(performanceProfile.Files()[0].Data))
+			}
+		})
+	}
+}
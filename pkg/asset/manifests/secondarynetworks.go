@@ -0,0 +1,154 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+// The NetworkAttachmentDefinition API of Multus and the EgressIP API of
+// OVN-Kubernetes are not vendored, so only the fields rendered from the
+// install-config are declared here.
+type networkAttachmentDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec networkAttachmentDefinitionSpec `json:"spec"`
+}
+
+type networkAttachmentDefinitionSpec struct {
+	Config string `json:"config"`
+}
+
+type egressIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec egressIPSpec `json:"spec"`
+}
+
+type egressIPSpec struct {
+	EgressIPs         []string              `json:"egressIPs"`
+	NamespaceSelector metav1.LabelSelector  `json:"namespaceSelector"`
+	PodSelector       *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// SecondaryNetworks generates the NetworkAttachmentDefinitions of the
+// secondary networks and the egress IPs of the install-config.
+type SecondaryNetworks struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*SecondaryNetworks)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*SecondaryNetworks) Name() string {
+	return "Secondary Networks"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*SecondaryNetworks) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates a NetworkAttachmentDefinition for each additional
+// network, with its namespace, and an EgressIP for each egress IP. Nothing
+// is generated when the install-config sets none.
+func (s *SecondaryNetworks) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	s.FileList = []*asset.File{}
+	netConfig := installConfig.Config.Networking
+	if netConfig == nil {
+		return nil
+	}
+
+	resources := map[string]interface{}{}
+	for _, network := range netConfig.AdditionalNetworks {
+		namespace := network.Namespace
+		if namespace == "" {
+			namespace = "default"
+		} else if namespace != "default" {
+			resources[fmt.Sprintf("namespace-%s", namespace)] = &corev1.Namespace{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Namespace",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			}
+		}
+		resources[fmt.Sprintf("nad-%s-%s", namespace, network.Name)] = &networkAttachmentDefinition{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "k8s.cni.cncf.io/v1",
+				Kind:       "NetworkAttachmentDefinition",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      network.Name,
+				Namespace: namespace,
+			},
+			Spec: networkAttachmentDefinitionSpec{
+				Config: network.Config,
+			},
+		}
+	}
+
+	for _, eip := range netConfig.EgressIPs {
+		resources[fmt.Sprintf("egressip-%s", eip.Name)] = &egressIP{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "k8s.ovn.org/v1",
+				Kind:       "EgressIP",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: eip.Name,
+				// not namespaced
+			},
+			Spec: egressIPSpec{
+				EgressIPs:         eip.EgressIPs,
+				NamespaceSelector: eip.NamespaceSelector,
+				PodSelector:       eip.PodSelector,
+			},
+		}
+	}
+	if len(netConfig.EgressIPs) > 0 {
+		logrus.Warn("The egress IPs are only assigned to the nodes labeled k8s.ovn.org/egress-assignable, label the nodes hosting them once the cluster is installed")
+	}
+
+	for name, resource := range resources {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create secondary network manifest %s", name)
+		}
+		s.FileList = append(s.FileList, &asset.File{
+			Filename: filepath.Join(manifestDir, fmt.Sprintf("cluster-network-%s.yml", name)),
+			Data:     data,
+		})
+	}
+	asset.SortFiles(s.FileList)
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (s *SecondaryNetworks) Files() []*asset.File {
+	return s.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (s *SecondaryNetworks) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
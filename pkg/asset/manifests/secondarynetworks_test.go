@@ -0,0 +1,65 @@
+package manifests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestGenerateSecondaryNetworks(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{Networking: &types.Networking{}}))
+	secondaryNetworks := &SecondaryNetworks{}
+	assert.NoError(t, secondaryNetworks.Generate(context.Background(), parents))
+	assert.Empty(t, secondaryNetworks.Files())
+
+	config := `{"cniVersion":"0.3.1","type":"macvlan","master":"ens4","ipam":{"type":"whereabouts","range":"192.168.10.0/24"}}`
+	parents = asset.Parents{}
+	parents.Add(installconfig.MakeAsset(&types.InstallConfig{
+		Networking: &types.Networking{
+			NetworkType: "OVNKubernetes",
+			AdditionalNetworks: []types.AdditionalNetwork{
+				{Name: "fronthaul", Namespace: "ran", Config: config},
+				{Name: "storage", Config: config},
+			},
+			EgressIPs: []types.EgressIP{{
+				Name:              "ran",
+				EgressIPs:         []string{"10.0.0.200"},
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "ran"}},
+			}},
+		},
+	}))
+	secondaryNetworks = &SecondaryNetworks{}
+	if !assert.NoError(t, secondaryNetworks.Generate(context.Background(), parents)) {
+		return
+	}
+	files := map[string][]byte{}
+	for _, f := range secondaryNetworks.Files() {
+		files[f.Filename] = f.Data
+	}
+	assert.Len(t, files, 4)
+	assert.Contains(t, files, "manifests/cluster-network-namespace-ran.yml")
+	assert.Contains(t, files, "manifests/cluster-network-nad-default-storage.yml")
+
+	nad := &networkAttachmentDefinition{}
+	if assert.NoError(t, yaml.Unmarshal(files["manifests/cluster-network-nad-ran-fronthaul.yml"], nad)) {
+		assert.Equal(t, "NetworkAttachmentDefinition", nad.Kind)
+		assert.Equal(t, "ran", nad.Namespace)
+		assert.Equal(t, config, nad.Spec.Config)
+	}
+
+	eip := &egressIP{}
+	if assert.NoError(t, yaml.Unmarshal(files["manifests/cluster-network-egressip-ran.yml"], eip)) {
+		assert.Equal(t, "k8s.ovn.org/v1", eip.APIVersion)
+		assert.Equal(t, []string{"10.0.0.200"}, eip.Spec.EgressIPs)
+		assert.Equal(t, map[string]string{"env": "ran"}, eip.Spec.NamespaceSelector.MatchLabels)
+		assert.Nil(t, eip.Spec.PodSelector)
+	}
+}
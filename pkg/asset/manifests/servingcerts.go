@@ -0,0 +1,113 @@
+package manifests
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+var (
+	apiServerServingCertSecretFileName = filepath.Join(manifestDir, "cluster-apiserver-serving-cert-secret.yaml")
+	ingressServingCertSecretFileName   = filepath.Join(manifestDir, "cluster-ingress-serving-cert-secret.yaml")
+)
+
+const (
+	apiServerServingCertSecretName = "api-serving-cert"
+	ingressServingCertSecretName   = "ingress-serving-cert"
+)
+
+// ServingCertificates generates the secrets for the user-provided serving
+// certificates of the API server and default ingress controller.
+type ServingCertificates struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*ServingCertificates)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*ServingCertificates) Name() string {
+	return "Serving Certificates"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*ServingCertificates) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the serving certificate secrets. The API certificate is
+// referenced by the APIServer config, and the ingress certificate by the
+// default ingresscontroller.
+func (sc *ServingCertificates) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	sc.FileList = []*asset.File{}
+	certs := installConfig.Config.ServingCertificates
+	if certs == nil {
+		return nil
+	}
+
+	if certs.APIServer != nil {
+		secret, err := servingCertSecret("openshift-config", apiServerServingCertSecretName, certs.APIServer)
+		if err != nil {
+			return errors.Wrap(err, "failed to create API server serving certificate secret")
+		}
+		sc.FileList = append(sc.FileList, &asset.File{
+			Filename: apiServerServingCertSecretFileName,
+			Data:     secret,
+		})
+	}
+
+	if certs.Ingress != nil {
+		secret, err := servingCertSecret("openshift-ingress", ingressServingCertSecretName, certs.Ingress)
+		if err != nil {
+			return errors.Wrap(err, "failed to create ingress serving certificate secret")
+		}
+		sc.FileList = append(sc.FileList, &asset.File{
+			Filename: ingressServingCertSecretFileName,
+			Data:     secret,
+		})
+	}
+
+	return nil
+}
+
+func servingCertSecret(namespace, name string, pair *types.CertificateKeyPair) ([]byte, error) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(pair.Certificate),
+			corev1.TLSPrivateKeyKey: []byte(pair.Key),
+		},
+	}
+	return yaml.Marshal(secret)
+}
+
+// Files returns the files generated by the asset.
+func (sc *ServingCertificates) Files() []*asset.File {
+	return sc.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (sc *ServingCertificates) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -2,6 +2,8 @@ package manifests
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -36,9 +38,9 @@ func configMap(namespace, name string, data genericData) *configurationObject {
 }
 
 func getAPIServerURL(ic *types.InstallConfig) string {
-	return fmt.Sprintf("https://api.%s:6443", ic.ClusterDomain())
+	return fmt.Sprintf("https://%s", net.JoinHostPort(ic.APIHostname(), strconv.Itoa(int(ic.APIPort()))))
 }
 
 func getInternalAPIServerURL(ic *types.InstallConfig) string {
-	return fmt.Sprintf("https://api-int.%s:6443", ic.ClusterDomain())
+	return fmt.Sprintf("https://%s", net.JoinHostPort(ic.APIInternalHostname(), strconv.Itoa(int(ic.APIPort()))))
 }
@@ -43,8 +43,8 @@ func GenerateClusterAssets(installConfig *installconfig.InstallConfig, clusterID
 		Spec: capv.VSphereClusterSpec{
 			Server: fmt.Sprintf("https://%s", vcenter.Server),
 			ControlPlaneEndpoint: capv.APIEndpoint{
-				Host: fmt.Sprintf("api.%s.%s", installConfig.Config.ObjectMeta.Name, installConfig.Config.BaseDomain),
-				Port: 6443,
+				Host: installConfig.Config.APIHostname(),
+				Port: installConfig.Config.APIPort(),
 			},
 			IdentityRef: &capv.VSphereIdentityReference{
 				Kind: capv.SecretKind,
@@ -0,0 +1,178 @@
+package azure
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+// Constraints returns a list of quota constraints based on the InstallConfig.
+// These constraints can be used to check if there is enough quota for creating a cluster
+// for the install config.
+func Constraints(config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet, instanceTypes map[string]InstanceTypeInfo) []quota.Constraint {
+	ctrplConfigs := make([]*machineapi.AzureMachineProviderSpec, len(controlPlanes))
+	for i, m := range controlPlanes {
+		ctrplConfigs[i] = m.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec)
+	}
+	computeReplicas := make([]int64, len(computes))
+	computeConfigs := make([]*machineapi.AzureMachineProviderSpec, len(computes))
+	for i, w := range computes {
+		computeReplicas[i] = int64(*w.Spec.Replicas)
+		computeConfigs[i] = w.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec)
+	}
+
+	var ret []quota.Constraint
+	for _, gen := range []constraintGenerator{
+		network(config),
+		controlPlane(config, ctrplConfigs, instanceTypes),
+		compute(config, computeReplicas, computeConfigs, instanceTypes),
+	} {
+		ret = append(ret, gen()...)
+	}
+	return aggregate(ret)
+}
+
+func aggregate(quotas []quota.Constraint) []quota.Constraint {
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return quotas[i].Name < quotas[j].Name
+	})
+
+	i := 0
+	for j := 1; j < len(quotas); j++ {
+		if quotas[i].Name == quotas[j].Name && quotas[i].Region == quotas[j].Region {
+			quotas[i].Count += quotas[j].Count
+		} else {
+			i++
+			if i != j {
+				quotas[i] = quotas[j]
+			}
+		}
+	}
+	return quotas[:i+1]
+}
+
+// constraintGenerator generates a list of constraints.
+type constraintGenerator func() []quota.Constraint
+
+func network(config *types.InstallConfig) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		region := config.Platform.Azure.Region
+		ret := []quota.Constraint{{
+			Name:   "network/NetworkSecurityGroups",
+			Region: region,
+			Count:  1,
+		}, {
+			Name:   "network/LoadBalancers", // internal API load balancer
+			Region: region,
+			Count:  1,
+		}}
+		if config.Platform.Azure.VirtualNetwork == "" {
+			ret = append(ret, quota.Constraint{
+				Name:   "network/VirtualNetworks",
+				Region: region,
+				Count:  1,
+			})
+		}
+
+		publicIPs := int64(0)
+		if config.Publish == types.ExternalPublishingStrategy {
+			publicIPs++ // external API load balancer
+		}
+		if outbound := config.Platform.Azure.OutboundType; outbound == "" || outbound == azuretypes.LoadbalancerOutboundType {
+			publicIPs++ // outbound load balancer
+		}
+		if publicIPs > 0 {
+			ret = append(ret, quota.Constraint{
+				Name:   "network/LoadBalancers", // external load balancer
+				Region: region,
+				Count:  1,
+			}, quota.Constraint{
+				Name:   "network/PublicIPAddresses",
+				Region: region,
+				Count:  publicIPs,
+			})
+		}
+		return ret
+	}
+}
+
+func controlPlane(config *types.InstallConfig, machines []*machineapi.AzureMachineProviderSpec, instanceTypes map[string]InstanceTypeInfo) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		var ret []quota.Constraint
+		for _, m := range machines {
+			ret = append(ret, machineToQuotas(config.Platform.Azure.Region, m, 1, instanceTypes)...)
+		}
+		return ret
+	}
+}
+
+func compute(config *types.InstallConfig, replicas []int64, machines []*machineapi.AzureMachineProviderSpec, instanceTypes map[string]InstanceTypeInfo) func() []quota.Constraint {
+	return func() []quota.Constraint {
+		var ret []quota.Constraint
+		for idx, m := range machines {
+			ret = append(ret, machineToQuotas(config.Platform.Azure.Region, m, replicas[idx], instanceTypes)...)
+		}
+		return ret
+	}
+}
+
+// machineToQuotas returns the vCPU, virtual machine and disk constraints of
+// count machines.
+func machineToQuotas(region string, m *machineapi.AzureMachineProviderSpec, count int64, instanceTypes map[string]InstanceTypeInfo) []quota.Constraint {
+	ret := []quota.Constraint{{
+		Name:   "compute/virtualMachines",
+		Region: region,
+		Count:  count,
+	}}
+
+	info, ok := instanceTypes[m.VMSize]
+	if !ok {
+		logrus.Warnf("The VM family is unknown for the VM size %q. The vCPU quota check will be skipped.", m.VMSize)
+	} else {
+		ret = append(ret, quota.Constraint{
+			Name:   "compute/cores",
+			Region: region,
+			Count:  info.vCPUs * count,
+		}, quota.Constraint{
+			Name:   fmt.Sprintf("compute/%s", info.Family),
+			Region: region,
+			Count:  info.vCPUs * count,
+		})
+	}
+
+	disks := []string{m.OSDisk.ManagedDisk.StorageAccountType}
+	for _, d := range m.DataDisks {
+		disks = append(disks, string(d.ManagedDisk.StorageAccountType))
+	}
+	for _, d := range disks {
+		if name := diskQuotaName(d); name != "" {
+			ret = append(ret, quota.Constraint{
+				Name:   name,
+				Region: region,
+				Count:  count,
+			})
+		}
+	}
+	return ret
+}
+
+// diskQuotaName returns the name of the quota limiting the number of managed
+// disks of a storage account type.
+func diskQuotaName(storageAccountType string) string {
+	switch storageAccountType {
+	case "Premium_LRS", "Premium_ZRS", "":
+		return "compute/PremiumDiskCount"
+	case "StandardSSD_LRS", "StandardSSD_ZRS":
+		return "compute/StandardSSDDiskCount"
+	case "Standard_LRS":
+		return "compute/StandardDiskCount"
+	default:
+		return ""
+	}
+}
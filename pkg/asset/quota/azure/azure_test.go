@@ -0,0 +1,75 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/quota"
+	"github.com/openshift/installer/pkg/types"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+func TestConstraints(t *testing.T) {
+	config := &types.InstallConfig{
+		Publish: types.ExternalPublishingStrategy,
+		Platform: types.Platform{
+			Azure: &azuretypes.Platform{Region: "eastus"},
+		},
+	}
+	providerSpec := func(vmSize string, storageAccountType string) machineapi.ProviderSpec {
+		return machineapi.ProviderSpec{Value: &runtime.RawExtension{Object: &machineapi.AzureMachineProviderSpec{
+			VMSize: vmSize,
+			OSDisk: machineapi.OSDisk{ManagedDisk: machineapi.OSDiskManagedDiskParameters{StorageAccountType: storageAccountType}},
+		}}}
+	}
+	master := machineapi.Machine{Spec: machineapi.MachineSpec{ProviderSpec: providerSpec("Standard_D8s_v3", "Premium_LRS")}}
+	worker := machineapi.MachineSet{Spec: machineapi.MachineSetSpec{
+		Replicas: ptr.To[int32](3),
+		Template: machineapi.MachineTemplateSpec{Spec: machineapi.MachineSpec{ProviderSpec: providerSpec("Standard_D4s_v3", "StandardSSD_LRS")}},
+	}}
+	instanceTypes := map[string]InstanceTypeInfo{
+		"Standard_D8s_v3": {Name: "Standard_D8s_v3", Family: "standardDSv3Family", vCPUs: 8},
+		"Standard_D4s_v3": {Name: "Standard_D4s_v3", Family: "standardDSv3Family", vCPUs: 4},
+	}
+
+	got := Constraints(config, []machineapi.Machine{master, master, master}, []machineapi.MachineSet{worker}, instanceTypes)
+	assert.Equal(t, []quota.Constraint{
+		{Name: "compute/PremiumDiskCount", Region: "eastus", Count: 3},
+		{Name: "compute/StandardSSDDiskCount", Region: "eastus", Count: 3},
+		{Name: "compute/cores", Region: "eastus", Count: 36},
+		{Name: "compute/standardDSv3Family", Region: "eastus", Count: 36},
+		{Name: "compute/virtualMachines", Region: "eastus", Count: 6},
+		{Name: "network/LoadBalancers", Region: "eastus", Count: 2},
+		{Name: "network/NetworkSecurityGroups", Region: "eastus", Count: 1},
+		{Name: "network/PublicIPAddresses", Region: "eastus", Count: 2},
+		{Name: "network/VirtualNetworks", Region: "eastus", Count: 1},
+	}, got)
+}
+
+func TestConstraintsUnknownVMSize(t *testing.T) {
+	config := &types.InstallConfig{
+		Publish: types.InternalPublishingStrategy,
+		Platform: types.Platform{
+			Azure: &azuretypes.Platform{
+				Region:         "eastus",
+				VirtualNetwork: "vnet",
+				OutboundType:   azuretypes.UserDefinedRoutingOutboundType,
+			},
+		},
+	}
+	master := machineapi.Machine{Spec: machineapi.MachineSpec{ProviderSpec: machineapi.ProviderSpec{Value: &runtime.RawExtension{Object: &machineapi.AzureMachineProviderSpec{
+		VMSize: "Standard_Unknown",
+	}}}}}
+
+	got := Constraints(config, []machineapi.Machine{master}, nil, nil)
+	assert.Equal(t, []quota.Constraint{
+		{Name: "compute/PremiumDiskCount", Region: "eastus", Count: 1},
+		{Name: "compute/virtualMachines", Region: "eastus", Count: 1},
+		{Name: "network/LoadBalancers", Region: "eastus", Count: 1},
+		{Name: "network/NetworkSecurityGroups", Region: "eastus", Count: 1},
+	}, got)
+}
@@ -0,0 +1,48 @@
+package azure
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+)
+
+// InstanceTypeInfo describes the VM size
+type InstanceTypeInfo struct {
+	Name   string
+	Family string
+	vCPUs  int64
+}
+
+// InstanceTypes returns information on the VM sizes in the region.
+// It returns a map of VM size name to its information. VM sizes that are
+// not found in the region are not included.
+func InstanceTypes(ctx context.Context, client azureconfig.API, region string, names ...string) (map[string]InstanceTypeInfo, error) {
+	ret := map[string]InstanceTypeInfo{}
+	for _, name := range names {
+		if _, ok := ret[name]; ok {
+			continue
+		}
+		sku, err := client.GetVirtualMachineSku(ctx, name, region)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get VM size %s", name)
+		}
+		if sku == nil || sku.Family == nil || sku.Capabilities == nil {
+			continue
+		}
+		for _, capability := range *sku.Capabilities {
+			if to.String(capability.Name) != "vCPUs" {
+				continue
+			}
+			vCPUs, err := strconv.ParseInt(to.String(capability.Value), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse the vCPUs of VM size %s", name)
+			}
+			ret[name] = InstanceTypeInfo{Name: name, Family: *sku.Family, vCPUs: vCPUs}
+		}
+	}
+	return ret, nil
+}
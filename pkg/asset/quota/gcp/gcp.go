@@ -145,6 +145,7 @@ func controlPlane(client MachineTypeGetter, config *types.InstallConfig, machine
 			q := machineTypeToQuota(client, m.Zone, m.MachineType)
 			q.Region = config.Platform.GCP.Region
 			ret = append(ret, q)
+			ret = append(ret, disksToQuotas(config.Platform.GCP.Region, m.Disks, 1)...)
 		}
 
 		ret = append(ret, quota.Constraint{
@@ -164,6 +165,7 @@ func compute(client MachineTypeGetter, config *types.InstallConfig, replicas []i
 			q.Count = q.Count * replicas[idx]
 			q.Region = config.Platform.GCP.Region
 			ret = append(ret, q)
+			ret = append(ret, disksToQuotas(config.Platform.GCP.Region, m.Disks, replicas[idx])...)
 		}
 
 		ret = append(ret, quota.Constraint{
@@ -187,6 +189,26 @@ func others() []quota.Constraint {
 	}}
 }
 
+// disksToQuotas returns the storage constraints, in GB, of the disks of count
+// machines. Persistent disks of the SSD and balanced types count towards the
+// SSD storage quota.
+func disksToQuotas(region string, disks []*machineapi.GCPDisk, count int64) []quota.Constraint {
+	var ret []quota.Constraint
+	for _, d := range disks {
+		var name string
+		switch d.Type {
+		case "pd-ssd", "pd-balanced":
+			name = "compute.googleapis.com/ssd_total_storage"
+		case "pd-standard":
+			name = "compute.googleapis.com/disks_total_storage"
+		default:
+			continue
+		}
+		ret = append(ret, quota.Constraint{Name: name, Region: region, Count: d.SizeGB * count})
+	}
+	return ret
+}
+
 func machineTypeToQuota(client MachineTypeGetter, zone string, machineType string) quota.Constraint {
 	var name string
 	class := strings.SplitN(machineType, "-", 2)[0]
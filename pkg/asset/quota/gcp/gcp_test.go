@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	computev1 "google.golang.org/api/compute/v1"
 
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/quota"
 )
 
@@ -253,3 +254,18 @@ func (fake *fakeMachineTypeGetter) GetMachineType(zone string, machineType strin
 	}
 	return mtype, nil
 }
+
+func Test_disksToQuotas(t *testing.T) {
+	disks := []*machineapi.GCPDisk{
+		{Boot: true, SizeGB: 128, Type: "pd-ssd"},
+		{SizeGB: 100, Type: "pd-balanced"},
+		{SizeGB: 200, Type: "pd-standard"},
+		{SizeGB: 300, Type: "hyperdisk-balanced"},
+	}
+	got := disksToQuotas("us-east1", disks, 3)
+	assert.EqualValues(t, []quota.Constraint{
+		{Name: "compute.googleapis.com/ssd_total_storage", Region: "us-east1", Count: 384},
+		{Name: "compute.googleapis.com/ssd_total_storage", Region: "us-east1", Count: 300},
+		{Name: "compute.googleapis.com/disks_total_storage", Region: "us-east1", Count: 600},
+	}, got)
+}
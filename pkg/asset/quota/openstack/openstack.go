@@ -81,7 +81,7 @@ func machineConstraints(ci *validation.CloudInfo, machine *machineapi.Machine) [
 		return nil
 	}
 	flavor := flavorInfo.Flavor
-	return []quota.Constraint{machineFlavorCoresToQuota(&flavor), machineFlavorRAMToQuota(&flavor), portConstraint(int64(len(osps.Networks)))}
+	return []quota.Constraint{machineFlavorCoresToQuota(&flavor), machineFlavorRAMToQuota(&flavor), portConstraint(int64(len(osps.Networks) + len(osps.Ports)))}
 }
 
 func machineSetConstraints(ci *validation.CloudInfo, ms *machineapi.MachineSet) []quota.Constraint {
@@ -112,7 +112,7 @@ func machineSetConstraints(ci *validation.CloudInfo, ms *machineapi.MachineSet)
 	coresConstraint.Count = coresConstraint.Count * int64(*replicas)
 	ramConstraint := machineFlavorRAMToQuota(&flavor)
 	ramConstraint.Count = ramConstraint.Count * int64(*replicas)
-	portConstraint := portConstraint((int64(len(osps.Networks)) * int64(*replicas)))
+	portConstraint := portConstraint(int64(len(osps.Networks)+len(osps.Ports)) * int64(*replicas))
 
 	return []quota.Constraint{coresConstraint, ramConstraint, portConstraint}
 }
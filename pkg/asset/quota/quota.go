@@ -1,14 +1,18 @@
 package quota
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	configgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
@@ -16,11 +20,13 @@ import (
 	configpowervs "github.com/openshift/installer/pkg/asset/installconfig/powervs"
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/quota/aws"
+	azurequota "github.com/openshift/installer/pkg/asset/quota/azure"
 	"github.com/openshift/installer/pkg/asset/quota/gcp"
 	"github.com/openshift/installer/pkg/asset/quota/openstack"
 	"github.com/openshift/installer/pkg/diagnostics"
 	"github.com/openshift/installer/pkg/quota"
 	quotaaws "github.com/openshift/installer/pkg/quota/aws"
+	quotaazure "github.com/openshift/installer/pkg/quota/azure"
 	quotagcp "github.com/openshift/installer/pkg/quota/gcp"
 	typesaws "github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
@@ -43,6 +49,7 @@ type PlatformQuotaCheck struct {
 }
 
 var _ asset.Asset = (*PlatformQuotaCheck)(nil)
+var _ asset.TimedAsset = (*PlatformQuotaCheck)(nil)
 
 // Dependencies returns the dependencies for PlatformQuotaCheck
 func (a *PlatformQuotaCheck) Dependencies() []asset.Asset {
@@ -54,7 +61,7 @@ func (a *PlatformQuotaCheck) Dependencies() []asset.Asset {
 }
 
 // Generate queries for input from the user.
-func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
+func (a *PlatformQuotaCheck) Generate(ctx context.Context, dependencies asset.Parents) error {
 	ic := &installconfig.InstallConfig{}
 	mastersAsset := &machines.Master{}
 	workersAsset := &machines.Worker{}
@@ -78,11 +85,11 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 			return nil
 		}
 		services := []string{"ec2", "vpc"}
-		session, err := ic.AWS.Session(context.TODO())
+		session, err := ic.AWS.Session(ctx)
 		if err != nil {
 			return errors.Wrap(err, "failed to load AWS session")
 		}
-		q, err := quotaaws.Load(context.TODO(), session, ic.AWS.Region, services...)
+		q, err := quotaaws.Load(ctx, session, ic.AWS.Region, services...)
 		if quotaaws.IsUnauthorized(err) {
 			logrus.Debugf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have `servicequotas:ListAWSDefaultServiceQuotas` permission available to the user.", err)
 			logrus.Info("Skipping quota checks")
@@ -91,7 +98,7 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to load Quota for services: %s", strings.Join(services, ", "))
 		}
-		instanceTypes, err := aws.InstanceTypes(context.TODO(), session, ic.AWS.Region)
+		instanceTypes, err := aws.InstanceTypes(ctx, session, ic.AWS.Region)
 		if quotaaws.IsUnauthorized(err) {
 			logrus.Warnf("Missing permissions to fetch instance types and therefore will skip checking Quotas: %v, make sure you have `ec2:DescribeInstanceTypes` permission available to the user.", err)
 			return nil
@@ -106,7 +113,7 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		summarizeReport(reports)
 	case typesgcp.Name:
 		services := []string{"compute.googleapis.com", "iam.googleapis.com"}
-		q, err := quotagcp.Load(context.TODO(), ic.Config.Platform.GCP.ProjectID, services...)
+		q, err := quotagcp.Load(ctx, ic.Config.Platform.GCP.ProjectID, services...)
 		if quotagcp.IsUnauthorized(err) {
 			logrus.Warnf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have `roles/servicemanagement.quotaViewer` assigned to the user.", err)
 			return nil
@@ -114,11 +121,11 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to load Quota for services: %s", strings.Join(services, ", "))
 		}
-		session, err := configgcp.GetSession(context.TODO())
+		session, err := configgcp.GetSession(ctx)
 		if err != nil {
 			return errors.Wrap(err, "failed to load GCP session")
 		}
-		client, err := gcp.NewClient(context.TODO(), session, ic.Config.Platform.GCP.ProjectID)
+		client, err := gcp.NewClient(ctx, session, ic.Config.Platform.GCP.ProjectID)
 		if err != nil {
 			return errors.Wrap(err, "failed to create client for quota constraints")
 		}
@@ -127,6 +134,37 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 			return summarizeFailingReport(reports)
 		}
 		summarizeReport(reports)
+	case azure.Name:
+		if ic.Config.Platform.Azure.CloudName == azure.StackCloud {
+			logrus.Debugf("%s does not support API for checking quotas, therefore skipping.", azure.StackCloud)
+			return nil
+		}
+		session, err := ic.Azure.Session()
+		if err != nil {
+			return errors.Wrap(err, "failed to load Azure session")
+		}
+		region := ic.Config.Platform.Azure.Region
+		q, err := quotaazure.Load(ctx, session, region)
+		if quotaazure.IsUnauthorized(err) {
+			logrus.Warnf("Missing permissions to fetch Quotas and therefore will skip checking them: %v, make sure you have the `Microsoft.Compute/locations/usages/read` and `Microsoft.Network/locations/usages/read` permissions available to the user.", err)
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to load Quota for region %s", region)
+		}
+		client, err := ic.Azure.Client()
+		if err != nil {
+			return errors.Wrap(err, "failed to create client for quota constraints")
+		}
+		instanceTypes, err := azurequota.InstanceTypes(ctx, client, region, vmSizes(masters, workers)...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load VM sizes for %s", region)
+		}
+		reports, err := quota.Check(q, azurequota.Constraints(ic.Config, masters, workers, instanceTypes))
+		if err != nil {
+			return summarizeFailingReport(reports)
+		}
+		summarizeReport(reports)
 	case typesopenstack.Name:
 		if skip := os.Getenv("OPENSHIFT_INSTALL_SKIP_PREFLIGHT_VALIDATIONS"); skip == "1" {
 			logrus.Warnf("OVERRIDE: pre-flight validation disabled.")
@@ -156,7 +194,7 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to create a new PISession")
 		}
-	case azure.Name, baremetal.Name, ibmcloud.Name, libvirt.Name, external.Name, none.Name, ovirt.Name, vsphere.Name, nutanix.Name:
+	case baremetal.Name, ibmcloud.Name, libvirt.Name, external.Name, none.Name, ovirt.Name, vsphere.Name, nutanix.Name:
 		// no special provisioning requirements to check
 	default:
 		err = fmt.Errorf("unknown platform type %q", platform)
@@ -164,11 +202,28 @@ func (a *PlatformQuotaCheck) Generate(dependencies asset.Parents) error {
 	return err
 }
 
+// GenerateTimeout returns how long the quotas may take to be checked.
+func (a *PlatformQuotaCheck) GenerateTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
 // Name returns the human-friendly name of the asset.
 func (a *PlatformQuotaCheck) Name() string {
 	return "Platform Quota Check"
 }
 
+// vmSizes returns the Azure VM sizes of the machines.
+func vmSizes(masters []machineapi.Machine, workers []machineapi.MachineSet) []string {
+	var sizes []string
+	for _, m := range masters {
+		sizes = append(sizes, m.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec).VMSize)
+	}
+	for _, w := range workers {
+		sizes = append(sizes, w.Spec.Template.Spec.ProviderSpec.Value.Object.(*machineapi.AzureMachineProviderSpec).VMSize)
+	}
+	return sizes
+}
+
 // summarizeFailingReport summarizes a report when there are failing constraints.
 func summarizeFailingReport(reports []quota.ConstraintReport) error {
 	var notavailable []string
@@ -196,6 +251,10 @@ func summarizeFailingReport(reports []quota.ConstraintReport) error {
 		return nil
 	}
 
+	for _, line := range strings.Split(strings.TrimRight(shortfallTable(reports), "\n"), "\n") {
+		logrus.Error(line)
+	}
+
 	msg := strings.Join(notavailable, ", ")
 	if len(unknown) > 0 {
 		msg = fmt.Sprintf("%s, and could not find information on %s", msg, strings.Join(unknown, ", "))
@@ -203,6 +262,26 @@ func summarizeFailingReport(reports []quota.ConstraintReport) error {
 	return &diagnostics.Err{Reason: "MissingQuota", Message: msg}
 }
 
+// shortfallTable returns a table of the constraints that are not available,
+// or could not be checked.
+func shortfallTable(reports []quota.ConstraintReport) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "QUOTA\tREGION\tREQUIRED\tRESULT\tREASON")
+	for _, report := range reports {
+		if report.Result != quota.NotAvailable && report.Result != quota.Unknown {
+			continue
+		}
+		region := report.For.Region
+		if region == "" {
+			region = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", report.For.Name, region, report.For.Count, report.Result, report.Message)
+	}
+	w.Flush()
+	return buf.String()
+}
+
 // summarizeReport summarizes a report when there are availble.
 func summarizeReport(reports []quota.ConstraintReport) {
 	var low []string
@@ -0,0 +1,60 @@
+package releaseimage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// archTemplate prints the release.openshift.io/architecture metadata of the
+// payload, which is set to "multi" for manifest-listed payloads, and falls
+// back to the architecture of the payload image otherwise.
+const archTemplate = `-o=go-template={{if and .metadata.metadata (index . "metadata" "metadata" "release.openshift.io/architecture")}}{{index . "metadata" "metadata" "release.openshift.io/architecture"}}{{else}}{{.config.architecture}}{{end}}`
+
+// Architecture returns the architecture of the release payload referenced by
+// pullSpec, as reported by `oc adm release info`. Multi-arch payloads report
+// "multi".
+func Architecture(pullSecret, pullSpec string) (string, error) {
+	out, err := runOC(pullSecret, "adm", "release", "info", pullSpec, archTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect release image %s: %w", pullSpec, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// runOC runs oc with the arguments, authenticating to the registries with
+// the pull secret when one is given, and returns its output.
+func runOC(pullSecret string, args ...string) ([]byte, error) {
+	if pullSecret != "" {
+		ps, err := os.CreateTemp("", "registry-config")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			ps.Close()
+			os.Remove(ps.Name())
+		}()
+		if _, err := ps.Write([]byte(pullSecret)); err != nil {
+			return nil, err
+		}
+		// flush the buffer to ensure the file can be read
+		ps.Close()
+		args = append(args, "--registry-config="+ps.Name())
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("oc", args...) // #nosec G204
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, errors.New(strings.TrimSpace(stderr.String()))
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
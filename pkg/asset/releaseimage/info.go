@@ -0,0 +1,132 @@
+package releaseimage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// multiArch is the architecture reported by the release payloads that are
+// manifest lists covering several architectures.
+const multiArch = "multi"
+
+// Info is the metadata of a release payload.
+type Info struct {
+	// PullSpec is the pull spec the payload was inspected from.
+	PullSpec string
+	// Digest is the digest of the payload, which its signatures sign.
+	Digest string
+	// Version is the OpenShift version of the payload.
+	Version string
+	// Architectures are the architectures of the payload, several for a
+	// multi-arch payload.
+	Architectures []string
+	// Components are the versions of the main components, e.g. Kubernetes
+	// and RHCOS, by display name.
+	Components map[string]string
+	// Images are the pull specs, by digest, of the component images.
+	Images map[string]string
+}
+
+// releaseInfo is the part of the output of `oc adm release info -o json`
+// making the release payload metadata.
+type releaseInfo struct {
+	Digest   string `json:"digest"`
+	Metadata struct {
+		Version  string            `json:"version"`
+		Metadata map[string]string `json:"metadata"`
+	} `json:"metadata"`
+	Config struct {
+		Architecture string `json:"architecture"`
+	} `json:"config"`
+	DisplayVersions map[string]struct {
+		Version     string `json:"version"`
+		DisplayName string `json:"displayName"`
+	} `json:"displayVersions"`
+	References struct {
+		Spec struct {
+			Tags []struct {
+				Name string `json:"name"`
+				From struct {
+					Name string `json:"name"`
+				} `json:"from"`
+			} `json:"tags"`
+		} `json:"spec"`
+	} `json:"references"`
+}
+
+// imageInfo is the part of an element of the output of
+// `oc image info --show-multiarch -o json` making the architecture of an
+// image of a manifest list.
+type imageInfo struct {
+	Config struct {
+		Architecture string `json:"architecture"`
+	} `json:"config"`
+}
+
+// Inspect returns the metadata of the release payload referenced by
+// pullSpec, as reported by oc.
+func Inspect(pullSecret, pullSpec string) (*Info, error) {
+	out, err := runOC(pullSecret, "adm", "release", "info", pullSpec, "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect release image %s: %w", pullSpec, err)
+	}
+	info, err := parseReleaseInfo(pullSpec, out)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(info.Architectures) == 1 && info.Architectures[0] == multiArch {
+		out, err := runOC(pullSecret, "image", "info", "--show-multiarch", "-o", "json", pullSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect the architectures of release image %s: %w", pullSpec, err)
+		}
+		if info.Architectures, err = parseArchitectures(out); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+func parseReleaseInfo(pullSpec string, data []byte) (*Info, error) {
+	var raw releaseInfo
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse the metadata of release image %s: %w", pullSpec, err)
+	}
+
+	arch := raw.Metadata.Metadata["release.openshift.io/architecture"]
+	if arch == "" {
+		arch = raw.Config.Architecture
+	}
+	info := &Info{
+		PullSpec:      pullSpec,
+		Digest:        raw.Digest,
+		Version:       raw.Metadata.Version,
+		Architectures: []string{arch},
+		Components:    map[string]string{},
+		Images:        map[string]string{},
+	}
+	for name, v := range raw.DisplayVersions {
+		if v.DisplayName != "" {
+			name = v.DisplayName
+		}
+		info.Components[name] = v.Version
+	}
+	for _, tag := range raw.References.Spec.Tags {
+		info.Images[tag.Name] = tag.From.Name
+	}
+	return info, nil
+}
+
+func parseArchitectures(data []byte) ([]string, error) {
+	var images []imageInfo
+	if err := json.Unmarshal(data, &images); err != nil {
+		return nil, fmt.Errorf("failed to parse the images of the manifest list: %w", err)
+	}
+	var archs []string
+	for _, image := range images {
+		archs = append(archs, image.Config.Architecture)
+	}
+	sort.Strings(archs)
+	return archs, nil
+}
@@ -0,0 +1,46 @@
+package releaseimage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReleaseInfo(t *testing.T) {
+	info, err := parseReleaseInfo("quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64", []byte(`{
+  "digest": "sha256:abcd",
+  "metadata": {"version": "4.16.0", "metadata": {"url": "https://access.redhat.com/errata"}},
+  "config": {"architecture": "amd64"},
+  "displayVersions": {
+    "kubernetes": {"Version": "1.29.5", "DisplayName": "Kubernetes"},
+    "machine-os": {"Version": "416.94.202406172220-0", "DisplayName": "Red Hat Enterprise Linux CoreOS"}
+  },
+  "references": {"spec": {"tags": [
+    {"name": "cli", "from": {"kind": "DockerImage", "name": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123"}}
+  ]}}
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &Info{
+		PullSpec:      "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64",
+		Digest:        "sha256:abcd",
+		Version:       "4.16.0",
+		Architectures: []string{"amd64"},
+		Components: map[string]string{
+			"Kubernetes":                      "1.29.5",
+			"Red Hat Enterprise Linux CoreOS": "416.94.202406172220-0",
+		},
+		Images: map[string]string{
+			"cli": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123",
+		},
+	}, info)
+
+	archs, err := parseArchitectures([]byte(`[{"config": {"architecture": "s390x"}}, {"config": {"architecture": "amd64"}}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"amd64", "s390x"}, archs)
+}
+
+func TestCheckSignedDigest(t *testing.T) {
+	content := []byte(`{"critical": {"type": "atomic container signature", "image": {"docker-manifest-digest": "sha256:abcd"}, "identity": {"docker-reference": "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64"}}, "optional": {"creator": "Red Hat OpenShift Signing Authority 0.0.1"}}`)
+	assert.NoError(t, checkSignedDigest(content, "sha256:abcd"))
+	assert.EqualError(t, checkSignedDigest(content, "sha256:0123"), "the signature is for sha256:abcd, not sha256:0123")
+}
@@ -1,6 +1,7 @@
 package releaseimage
 
 import (
+	"context"
 	"os"
 
 	dockerref "github.com/containers/image/docker/reference"
@@ -8,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // Image asset generates the release-image pullspec for the cluster
@@ -24,7 +26,7 @@ func (a *Image) Dependencies() []asset.Asset {
 }
 
 // Generate creates the asset using the dependencies.
-func (a *Image) Generate(dependencies asset.Parents) error {
+func (a *Image) Generate(_ context.Context, dependencies asset.Parents) error {
 	var pullSpec string
 	if ri, ok := os.LookupEnv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); ok && ri != "" {
 		logrus.Warnf("Found override for release image (%s). Please be warned, this is not advised", ri)
@@ -48,6 +50,23 @@ func (a *Image) Generate(dependencies asset.Parents) error {
 	return nil
 }
 
+// PullSpecFor returns the pull spec of the release image installed with the
+// install config: the release image override for the control plane
+// architecture, if any, or the release image.
+func (a *Image) PullSpecFor(config *types.InstallConfig) string {
+	if config == nil || config.ControlPlane == nil {
+		return a.PullSpec
+	}
+	pullSpec := config.ReleaseImage(config.ControlPlane.Architecture)
+	if pullSpec == "" {
+		return a.PullSpec
+	}
+	if _, ok := os.LookupEnv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); ok {
+		logrus.Warnf("Ignoring OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE, the release image is overridden in the install config")
+	}
+	return pullSpec
+}
+
 // Name is the human friendly name for the asset.
 func (a *Image) Name() string {
 	return "Release Image Pull Spec"
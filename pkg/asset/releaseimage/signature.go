@@ -0,0 +1,173 @@
+package releaseimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// DefaultSignatureStore is the store of the signatures of the OpenShift
+	// release payloads.
+	DefaultSignatureStore = "https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release"
+
+	// DefaultSignatureKeyring is the keyring of the Red Hat release keys,
+	// which sign the OpenShift release payloads.
+	DefaultSignatureKeyring = "/etc/pki/rpm-gpg/RPM-GPG-KEY-redhat-release"
+
+	// maxSignatures is the number of signatures looked up for a payload.
+	maxSignatures = 3
+)
+
+// atomicSignature is the content of an atomic container signature.
+type atomicSignature struct {
+	Critical struct {
+		Type  string `json:"type"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifySignature checks that the release payload with the given digest has
+// a signature in the signature store made by a key of the keyring. The
+// signatures of a payload are <store>/<algorithm>=<hash>/signature-<n>, as
+// published for the cluster version operator.
+func VerifySignature(ctx context.Context, digest, keyring, store string) error {
+	algorithm, hash, ok := strings.Cut(digest, ":")
+	if !ok {
+		return fmt.Errorf("invalid release image digest %q", digest)
+	}
+	if _, err := os.Stat(keyring); err != nil {
+		return fmt.Errorf("failed to read the signature keyring: %w", err)
+	}
+
+	var errs []error
+	for i := 1; i <= maxSignatures; i++ {
+		url := fmt.Sprintf("%s/%s=%s/signature-%d", strings.TrimSuffix(store, "/"), algorithm, hash, i)
+		signature, err := fetchSignature(ctx, url)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if signature == nil {
+			break
+		}
+		if err := verifySignature(signature, digest, keyring); err != nil {
+			errs = append(errs, fmt.Errorf("signature-%d: %w", i, err))
+			continue
+		}
+		return nil
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("no signature found for %s in %s", digest, store)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// VerifySignatureWithKeys checks the signature of the release payload with
+// the given digest like VerifySignature, against the given ASCII-armored
+// public keys.
+func VerifySignatureWithKeys(ctx context.Context, digest, publicKeys, store string) error {
+	dir, err := os.MkdirTemp("", "release-keyring")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	armoredPath := filepath.Join(dir, "keys.asc")
+	keyring := filepath.Join(dir, "keyring.gpg")
+	if err := os.WriteFile(armoredPath, []byte(publicKeys), 0o600); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("gpg", "--batch", "--yes", "--output", keyring, "--dearmor", armoredPath) // #nosec G204
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("failed to read the public keys: %s", strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return VerifySignature(ctx, digest, keyring, store)
+}
+
+// fetchSignature returns the signature at the URL, or nil if there is none.
+func fetchSignature(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signature: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to fetch signature %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// verifySignature checks the signature with gpgv, and that it signs the
+// digest of the payload.
+func verifySignature(signature []byte, digest, keyring string) error {
+	dir, err := os.MkdirTemp("", "release-signature")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	sigPath := filepath.Join(dir, "signature")
+	contentPath := filepath.Join(dir, "content")
+	if err := os.WriteFile(sigPath, signature, 0o600); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("gpgv", "--keyring", keyring, "--output", contentPath, sigPath) // #nosec G204
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("invalid signature: %s", strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+
+	content, err := os.ReadFile(contentPath)
+	if err != nil {
+		return err
+	}
+	return checkSignedDigest(content, digest)
+}
+
+// checkSignedDigest checks that the content of an atomic container signature
+// signs the digest.
+func checkSignedDigest(content []byte, digest string) error {
+	var sig atomicSignature
+	if err := json.Unmarshal(content, &sig); err != nil {
+		return fmt.Errorf("failed to parse the signed content: %w", err)
+	}
+	if sig.Critical.Type != "atomic container signature" {
+		return fmt.Errorf("unsupported signature type %q", sig.Critical.Type)
+	}
+	if sig.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("the signature is for %s, not %s", sig.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
@@ -33,7 +33,12 @@ import (
 // Image is location of RHCOS image.
 // This stores the location of the image based on the platform.
 // eg. on AWS this contains ami-id, on Livirt this can be the URI for QEMU image etc.
-type Image string
+// The compute image differs from the control plane image when the compute
+// pools use a different architecture (heterogeneous clusters).
+type Image struct {
+	ControlPlane string
+	Compute      string
+}
 
 var _ asset.Asset = (*Image)(nil)
 
@@ -50,29 +55,41 @@ func (i *Image) Dependencies() []asset.Asset {
 }
 
 // Generate the RHCOS image location.
-func (i *Image) Generate(p asset.Parents) error {
+func (i *Image) Generate(_ context.Context, p asset.Parents) error {
 	if oi, ok := os.LookupEnv("OPENSHIFT_INSTALL_OS_IMAGE_OVERRIDE"); ok && oi != "" {
 		logrus.Warn("Found override for OS Image. Please be warned, this is not advised")
-		*i = Image(oi)
+		*i = Image{ControlPlane: oi, Compute: oi}
 		return nil
 	}
 
 	ic := &installconfig.InstallConfig{}
 	p.Get(ic)
 	config := ic.Config
-	osimage, err := osImage(config)
+	controlPlane, err := osImage(config, config.ControlPlane.Architecture)
 	if err != nil {
 		return err
 	}
-	*i = Image(osimage)
+	compute := controlPlane
+	if len(config.Compute) > 0 && config.Compute[0].Architecture != config.ControlPlane.Architecture {
+		compute, err = osImage(config, config.Compute[0].Architecture)
+		if err != nil {
+			return err
+		}
+	}
+	*i = Image{ControlPlane: controlPlane, Compute: compute}
 	return nil
 }
 
-func osImage(config *types.InstallConfig) (string, error) {
+func osImage(config *types.InstallConfig, architecture types.Architecture) (string, error) {
+	if bi := config.BootImage(architecture); bi != "" {
+		logrus.Debugf("Using boot image override %s for %s", bi, architecture)
+		return bi, nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer cancel()
 
-	archName := arch.RpmArch(string(config.ControlPlane.Architecture))
+	archName := arch.RpmArch(string(architecture))
 
 	st, err := rhcos.FetchCoreOSBuild(ctx)
 	if err != nil {
@@ -88,7 +105,7 @@ func osImage(config *types.InstallConfig) (string, error) {
 			return config.Platform.AWS.AMIID, nil
 		}
 		region := config.Platform.AWS.Region
-		if !rhcos.AMIRegions(config.ControlPlane.Architecture).Has(region) {
+		if !rhcos.AMIRegions(architecture).Has(region) {
 			const globalResourceRegion = "us-east-1"
 			logrus.Debugf("No AMI found in %s. Using AMI from %s.", region, globalResourceRegion)
 			region = globalResourceRegion
@@ -35,7 +35,7 @@ func (r *Release) Dependencies() []asset.Asset {
 }
 
 // Generate the Release string.
-func (r *Release) Generate(p asset.Parents) error {
+func (r *Release) Generate(_ context.Context, p asset.Parents) error {
 	ic := &installconfig.InstallConfig{}
 	p.Get(ic)
 	config := ic.Config
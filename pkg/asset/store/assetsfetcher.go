@@ -18,12 +18,14 @@ type AssetsFetcher interface {
 
 type fetcher struct {
 	storeDir string
+	opts     []Option
 }
 
 // NewAssetsFetcher creates a new AssetsFetcher instance for the specified assets store folder.
-func NewAssetsFetcher(storeDir string) AssetsFetcher {
+func NewAssetsFetcher(storeDir string, opts ...Option) AssetsFetcher {
 	return &fetcher{
 		storeDir: storeDir,
+		opts:     opts,
 	}
 }
 
@@ -38,7 +40,7 @@ func asFileWriter(a asset.WritableAsset) asset.FileWriter {
 
 // Fetchs all the writable assets from the configured assets store.
 func (f *fetcher) FetchAndPersist(ctx context.Context, assets []asset.WritableAsset) error {
-	assetStore, err := NewStore(f.storeDir)
+	assetStore, err := NewStore(f.storeDir, f.opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create asset store: %w", err)
 	}
@@ -58,6 +60,11 @@ func (f *fetcher) FetchAndPersist(ctx context.Context, assets []asset.WritableAs
 			}
 			return err2
 		}
+		// Mark the files as written by the installer, to detect the edits
+		// made to them before the next invocation.
+		if err2 := asset.RecordFileHashes(f.storeDir, a.Files()); err2 != nil {
+			logrus.Warnf("Failed to record the hashes of the files of %s: %v", a.Name(), err2)
+		}
 
 		if err != nil {
 			return err
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/diff"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// DriftPolicy is what the store does with an asset whose files were edited in
+// the target directory after the installer wrote them, when the asset must be
+// regenerated because its dependencies changed.
+type DriftPolicy string
+
+const (
+	// DriftPolicyWarn regenerates the asset, warning about the edited files.
+	DriftPolicyWarn DriftPolicy = "warn"
+	// DriftPolicyDiff regenerates the asset, warning about the edited files
+	// and logging the edits that are discarded.
+	DriftPolicyDiff DriftPolicy = "diff"
+	// DriftPolicyPreserve keeps the edited asset instead of regenerating it.
+	DriftPolicyPreserve DriftPolicy = "preserve"
+)
+
+// DriftPolicies are the supported drift policies.
+var DriftPolicies = []DriftPolicy{DriftPolicyWarn, DriftPolicyDiff, DriftPolicyPreserve}
+
+// WithDriftPolicy sets what the store does with the assets edited in the
+// target directory that must be regenerated. The default is to warn.
+func WithDriftPolicy(policy DriftPolicy) Option {
+	return func(s *storeImpl) {
+		s.driftPolicy = policy
+	}
+}
+
+// logEdits logs the diff of the edited files of the on-disk asset with the
+// files of the asset in the state file, as they were generated.
+func (s *storeImpl) logEdits(a asset.Asset, onDiskAsset asset.WritableAsset, edited []string) {
+	generated := map[string][]byte{}
+	if s.isAssetInState(a) {
+		stateFileAsset := reflect.New(reflect.TypeOf(a).Elem()).Interface().(asset.WritableAsset)
+		if err := s.loadAssetFromState(stateFileAsset); err != nil {
+			logrus.Debugf("Failed to load %s from state file: %v", a.Name(), err)
+		} else {
+			for _, f := range stateFileAsset.Files() {
+				generated[f.Filename] = f.Data
+			}
+		}
+	}
+
+	editedFiles := map[string]bool{}
+	for _, name := range edited {
+		editedFiles[name] = true
+	}
+	for _, f := range onDiskAsset.Files() {
+		if !editedFiles[f.Filename] {
+			continue
+		}
+		var sb strings.Builder
+		if err := diff.Text("generated/"+f.Filename, f.Filename, string(generated[f.Filename]), string(f.Data), &sb); err != nil {
+			logrus.Debugf("Failed to compute the edits to %s: %v", f.Filename, err)
+			continue
+		}
+		logrus.Warnf("Discarded edits to %s:\n%s", f.Filename, sb.String())
+	}
+}
+
+// driftPolicyValues returns the supported drift policies as strings.
+func driftPolicyValues() []string {
+	values := make([]string, 0, len(DriftPolicies))
+	for _, p := range DriftPolicies {
+		values = append(values, string(p))
+	}
+	return values
+}
+
+// ParseDriftPolicy parses a drift policy.
+func ParseDriftPolicy(value string) (DriftPolicy, error) {
+	for _, p := range DriftPolicies {
+		if string(p) == value {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported drift policy %q, it must be one of %s", value, strings.Join(driftPolicyValues(), ", "))
+}
@@ -3,16 +3,22 @@ package store
 import (
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/openshift/installer/pkg/asset"
 )
 
 type fileFetcher struct {
 	directory string
+	// files are fetched in place of the files of the same name in the directory.
+	files map[string]*asset.File
 }
 
 // FetchByName returns the file with the given name.
 func (f *fileFetcher) FetchByName(name string) (*asset.File, error) {
+	if file, ok := f.files[name]; ok {
+		return file, nil
+	}
 	data, err := os.ReadFile(filepath.Join(f.directory, name))
 	if err != nil {
 		return nil, err
@@ -28,6 +34,13 @@ func (f *fileFetcher) FetchByPattern(pattern string) (files []*asset.File, err e
 	}
 
 	files = make([]*asset.File, 0, len(matches))
+	for name, file := range f.files {
+		if ok, err := filepath.Match(pattern, name); err != nil {
+			return nil, err
+		} else if ok {
+			files = append(files, file)
+		}
+	}
 	for _, path := range matches {
 		data, err := os.ReadFile(path)
 		if err != nil {
@@ -38,12 +51,16 @@ func (f *fileFetcher) FetchByPattern(pattern string) (files []*asset.File, err e
 		if err != nil {
 			return nil, err
 		}
+		if _, ok := f.files[filename]; ok {
+			continue
+		}
 
 		files = append(files, &asset.File{
 			Filename: filename,
 			Data:     data,
 		})
 	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Filename < files[j].Filename })
 
 	return files, nil
 }
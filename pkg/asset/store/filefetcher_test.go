@@ -165,3 +165,35 @@ func TestFetchByPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchInMemoryFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	for path, data := range map[string][]byte{
+		"install-config.yaml": []byte("on disk"),
+		"master-0.ign":        []byte("some data 0"),
+	} {
+		err := os.WriteFile(filepath.Join(tempDir, path), data, 0o666) //nolint:gosec // no sensitive data
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f := &fileFetcher{directory: tempDir, files: map[string]*asset.File{
+		"install-config.yaml": {Filename: "install-config.yaml", Data: []byte("in memory")},
+		"master-1.ign":        {Filename: "master-1.ign", Data: []byte("some data 1")},
+	}}
+
+	file, err := f.FetchByName("install-config.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &asset.File{Filename: "install-config.yaml", Data: []byte("in memory")}, file)
+	}
+
+	files, err := f.FetchByPattern("*")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*asset.File{
+			{Filename: "install-config.yaml", Data: []byte("in memory")},
+			{Filename: "master-0.ign", Data: []byte("some data 0")},
+			{Filename: "master-1.ign", Data: []byte("some data 1")},
+		}, files)
+	}
+}
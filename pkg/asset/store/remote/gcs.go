@@ -0,0 +1,73 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+type gcsBackend struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// newGCSBackend returns a backend storing the files as objects of the bucket,
+// using the application default credentials.
+func newGCSBackend(ctx context.Context, bucket, prefix string) (*gcsBackend, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &gcsBackend{bucket: client.Bucket(bucket), prefix: prefix}, nil
+}
+
+func (b *gcsBackend) object(name string) *storage.ObjectHandle {
+	return b.bucket.Object(path.Join(b.prefix, name))
+}
+
+func (b *gcsBackend) List(ctx context.Context) ([]string, error) {
+	query := &storage.Query{}
+	if b.prefix != "" {
+		query.Prefix = b.prefix + "/"
+	}
+	var names []string
+	it := b.bucket.Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, strings.TrimPrefix(attrs.Name, query.Prefix))
+	}
+}
+
+func (b *gcsBackend) Get(ctx context.Context, name string) ([]byte, error) {
+	r, err := b.object(name).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (b *gcsBackend) Put(ctx context.Context, name string, data []byte) error {
+	w := b.object(name).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (b *gcsBackend) Delete(ctx context.Context, name string) error {
+	return b.object(name).Delete(ctx)
+}
@@ -0,0 +1,126 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+)
+
+// httpIndexName is the file listing the files stored on an HTTP server, which
+// has no standard way of listing them.
+const httpIndexName = ".openshift_install_index.json"
+
+type httpBackend struct {
+	client  *http.Client
+	baseURL *url.URL
+}
+
+// newHTTPBackend returns a backend storing the files on an HTTP server
+// supporting GET, PUT and DELETE, such as a WebDAV server or a presigned
+// object storage endpoint.
+func newHTTPBackend(baseURL *url.URL) *httpBackend {
+	return &httpBackend{client: http.DefaultClient, baseURL: baseURL}
+}
+
+func (b *httpBackend) url(name string) string {
+	u := *b.baseURL
+	u.Path = path.Join(u.Path, name)
+	return u.String()
+}
+
+func (b *httpBackend) do(ctx context.Context, method, name string, body []byte) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.url(name), bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return nil, resp.StatusCode, fmt.Errorf("%s %s: %s", method, b.url(name), resp.Status)
+	}
+	return data, resp.StatusCode, nil
+}
+
+func (b *httpBackend) List(ctx context.Context) ([]string, error) {
+	data, status, err := b.do(ctx, http.MethodGet, httpIndexName, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", httpIndexName, err)
+	}
+	return names, nil
+}
+
+func (b *httpBackend) Get(ctx context.Context, name string) ([]byte, error) {
+	data, status, err := b.do(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%s not found", b.url(name))
+	}
+	return data, nil
+}
+
+func (b *httpBackend) Put(ctx context.Context, name string, data []byte) error {
+	if _, _, err := b.do(ctx, http.MethodPut, name, data); err != nil {
+		return err
+	}
+	return b.updateIndex(ctx, name, true)
+}
+
+func (b *httpBackend) Delete(ctx context.Context, name string) error {
+	if _, _, err := b.do(ctx, http.MethodDelete, name, nil); err != nil {
+		return err
+	}
+	return b.updateIndex(ctx, name, false)
+}
+
+// updateIndex adds the name to, or removes it from, the index.
+func (b *httpBackend) updateIndex(ctx context.Context, name string, present bool) error {
+	names, err := b.List(ctx)
+	if err != nil {
+		return err
+	}
+	index := make(map[string]bool, len(names)+1)
+	for _, n := range names {
+		index[n] = true
+	}
+	if index[name] == present {
+		return nil
+	}
+	if present {
+		index[name] = true
+	} else {
+		delete(index, name)
+	}
+	names = make([]string, 0, len(index))
+	for n := range index {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	data, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+	_, _, err = b.do(ctx, http.MethodPut, httpIndexName, data)
+	return err
+}
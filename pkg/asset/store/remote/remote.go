@@ -0,0 +1,118 @@
+// Package remote synchronizes the asset directory with a remote storage, so
+// that the commands creating the assets and the cluster can run in different
+// environments not sharing a filesystem.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Backend is a storage holding the files of an asset directory, named by
+// their slash-separated path relative to the directory.
+type Backend interface {
+	// List returns the names of the files in the storage.
+	List(ctx context.Context) ([]string, error)
+	// Get returns the content of the named file.
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Put writes the content of the named file.
+	Put(ctx context.Context, name string, data []byte) error
+	// Delete removes the named file.
+	Delete(ctx context.Context, name string) error
+}
+
+// New returns the backend for the storage URL. The supported schemes are
+// s3://<bucket>/<prefix>, gs://<bucket>/<prefix> and http(s)://<base-url>.
+func New(ctx context.Context, storageURL string) (Backend, error) {
+	u, err := url.Parse(storageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage URL %q: %w", storageURL, err)
+	}
+	prefix := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case "s3":
+		return newS3Backend(u.Host, prefix)
+	case "gs":
+		return newGCSBackend(ctx, u.Host, prefix)
+	case "http", "https":
+		return newHTTPBackend(u), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage URL scheme %q, expected one of s3, gs, http or https", u.Scheme)
+	}
+}
+
+// Pull downloads the files of the storage to the directory, overwriting the
+// local files of the same name.
+func Pull(ctx context.Context, b Backend, dir string) error {
+	names, err := b.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the remote files: %w", err)
+	}
+	for _, name := range names {
+		data, err := b.Get(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", name, err)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return err
+		}
+		logrus.Debugf("Downloaded %s", name)
+	}
+	return nil
+}
+
+// Push uploads the files of the directory to the storage, and removes from the
+// storage the files which were removed from the directory, such as the assets
+// consumed by the command.
+func Push(ctx context.Context, b Backend, dir string) error {
+	remote, err := b.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the remote files: %w", err)
+	}
+	local := sets.New[string]()
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(ctx, name, data); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", name, err)
+		}
+		logrus.Debugf("Uploaded %s", name)
+		local.Insert(name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range remote {
+		if local.Has(name) {
+			continue
+		}
+		if err := b.Delete(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+		logrus.Debugf("Deleted %s", name)
+	}
+	return nil
+}
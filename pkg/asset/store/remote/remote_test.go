@@ -0,0 +1,75 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer is an HTTP server storing the files in memory.
+func fakeServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	var lock sync.Mutex
+	files := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := files[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			files[r.URL.Path] = data
+		case http.MethodDelete:
+			delete(files, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, files
+}
+
+func TestPushPull(t *testing.T) {
+	ctx := context.Background()
+	server, files := fakeServer(t)
+	b, err := New(ctx, server.URL+"/cluster0")
+	assert.NoError(t, err)
+
+	src := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "install-config.yaml"), []byte("config"), 0600))
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "manifests"), 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "manifests", "cluster-config.yaml"), []byte("manifest"), 0600))
+	assert.NoError(t, Push(ctx, b, src))
+	assert.Equal(t, []byte("manifest"), files["/cluster0/manifests/cluster-config.yaml"])
+
+	// The consumed install-config is removed from the storage.
+	assert.NoError(t, os.Remove(filepath.Join(src, "install-config.yaml")))
+	assert.NoError(t, Push(ctx, b, src))
+	names, err := b.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"manifests/cluster-config.yaml"}, names)
+
+	dst := t.TempDir()
+	assert.NoError(t, Pull(ctx, b, dst))
+	data, err := os.ReadFile(filepath.Join(dst, "manifests", "cluster-config.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("manifest"), data)
+	_, err = os.Stat(filepath.Join(dst, "install-config.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNewUnsupportedScheme(t *testing.T) {
+	_, err := New(context.Background(), "ftp://example.com/cluster0")
+	assert.EqualError(t, err, `unsupported storage URL scheme "ftp", expected one of s3, gs, http or https`)
+}
@@ -0,0 +1,80 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+type s3Backend struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// newS3Backend returns a backend storing the files as objects of the bucket,
+// using the credentials and region of the default AWS credential chain.
+func newS3Backend(bucket, prefix string) (*s3Backend, error) {
+	ssn, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+	return &s3Backend{client: s3.New(ssn), bucket: bucket, prefix: prefix}, nil
+}
+
+func (b *s3Backend) key(name string) string {
+	return path.Join(b.prefix, name)
+}
+
+func (b *s3Backend) List(ctx context.Context) ([]string, error) {
+	var names []string
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(b.bucket)}
+	if b.prefix != "" {
+		input.Prefix = aws.String(b.prefix + "/")
+	}
+	err := b.client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(object.Key), aws.StringValue(input.Prefix)))
+		}
+		return true
+	})
+	return names, err
+}
+
+func (b *s3Backend) Get(ctx context.Context, name string) ([]byte, error) {
+	out, err := b.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (b *s3Backend) Put(ctx context.Context, name string, data []byte) error {
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (b *s3Backend) Delete(ctx context.Context, name string) error {
+	_, err := b.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key(name)),
+	})
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -14,7 +15,7 @@ import (
 )
 
 const (
-	stateFileName = ".openshift_install_state.json"
+	stateFileName = asset.StateFileName
 )
 
 // assetSource indicates from where the asset was fetched
@@ -50,18 +51,42 @@ type storeImpl struct {
 	assets          map[reflect.Type]*assetState
 	stateFileAssets map[string]json.RawMessage
 	fileFetcher     asset.FileFetcher
+	fileHashes      asset.FileHashes
+	driftPolicy     DriftPolicy
+}
+
+// Option configures an asset store.
+type Option func(*storeImpl)
+
+// WithFiles makes the store load the files as if they were in the store
+// directory, without writing them to the directory. A file takes precedence
+// over the file of the same name in the directory.
+func WithFiles(files ...*asset.File) Option {
+	return func(s *storeImpl) {
+		f := s.fileFetcher.(*fileFetcher)
+		if f.files == nil {
+			f.files = map[string]*asset.File{}
+		}
+		for _, file := range files {
+			f.files[file.Filename] = file
+		}
+	}
 }
 
 // NewStore returns an asset store that implements the asset.Store interface.
-func NewStore(dir string) (asset.Store, error) {
-	return newStore(dir)
+func NewStore(dir string, opts ...Option) (asset.Store, error) {
+	return newStore(dir, opts...)
 }
 
-func newStore(dir string) (*storeImpl, error) {
+func newStore(dir string, opts ...Option) (*storeImpl, error) {
 	store := &storeImpl{
 		directory:   dir,
 		fileFetcher: &fileFetcher{directory: dir},
 		assets:      map[reflect.Type]*assetState{},
+		driftPolicy: DriftPolicyWarn,
+	}
+	for _, opt := range opts {
+		opt(store)
 	}
 
 	if err := store.loadStateFile(); err != nil {
@@ -75,6 +100,13 @@ func newStore(dir string) (*storeImpl, error) {
 // assets in preserved will be purged.
 func (s *storeImpl) Fetch(ctx context.Context, a asset.Asset, preserved ...asset.WritableAsset) error {
 	if err := s.fetch(ctx, a, ""); err != nil {
+		if ctx.Err() != nil {
+			// Keep the assets generated before the interruption, so that
+			// the next invocation does not generate them again.
+			if err := s.saveStateFile(); err != nil {
+				logrus.Warnf("Failed to save state: %v", err)
+			}
+		}
 		return err
 	}
 	if err := s.saveStateFile(); err != nil {
@@ -128,21 +160,15 @@ func (s *storeImpl) DestroyState() error {
 // loadStateFile retrieves the state from the state file present in the given directory
 // and returns the assets map
 func (s *storeImpl) loadStateFile() error {
-	path := filepath.Join(s.directory, stateFileName)
-	assets := map[string]json.RawMessage{}
-	data, err := os.ReadFile(path)
+	assets, err := asset.ReadStateFile(filepath.Join(s.directory, stateFileName))
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
 		return err
 	}
-	err = json.Unmarshal(data, &assets)
-	if err != nil {
-		return errors.Wrapf(err, "failed to unmarshal state file %q", path)
+	if len(assets) > 0 {
+		s.stateFileAssets = assets
 	}
-	s.stateFileAssets = assets
-	return nil
+	s.fileHashes, err = asset.LoadFileHashes(s.directory)
+	return err
 }
 
 // loadAssetFromState renders the asset object arguments from the state file contents.
@@ -175,19 +201,25 @@ func (s *storeImpl) saveStateFile() error {
 		}
 		s.stateFileAssets[k.String()] = json.RawMessage(data)
 	}
-	data, err := json.MarshalIndent(s.stateFileAssets, "", "    ")
-	if err != nil {
-		return err
-	}
 
 	path := filepath.Join(s.directory, stateFileName)
-	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+
+	// The checkpoints and the file hashes are written directly to the state
+	// file, while the cluster is being created and as the assets are
+	// written, so the copy on disk is the current one.
+	onDisk, err := asset.ReadStateFile(path)
+	if err != nil {
 		return err
 	}
-	if err := os.WriteFile(path, data, 0o640); err != nil { //nolint:gosec // no sensitive info
-		return err
+	for _, key := range []string{asset.CheckpointsKey, asset.FileHashesKey} {
+		if data, ok := onDisk[key]; ok {
+			s.stateFileAssets[key] = data
+		} else {
+			delete(s.stateFileAssets, key)
+		}
 	}
-	return nil
+
+	return asset.WriteStateFile(path, s.stateFileAssets)
 }
 
 // fetch populates the given asset, generating it and its dependencies if
@@ -214,6 +246,10 @@ func (s *storeImpl) fetch(ctx context.Context, a asset.Asset, indent string) err
 		return nil
 	}
 
+	if err := ctx.Err(); err != nil {
+		return errors.Wrapf(err, "stopped before generating %q", a.Name())
+	}
+
 	// Re-generate the asset
 	dependencies := a.Dependencies()
 	parents := make(asset.Parents, len(dependencies))
@@ -224,7 +260,7 @@ func (s *storeImpl) fetch(ctx context.Context, a asset.Asset, indent string) err
 		parents.Add(d)
 	}
 	logrus.Debugf("%sGenerating %s...", indent, a.Name())
-	if err := asAssetGenerator(a).GenerateWithContext(ctx, parents); err != nil {
+	if err := generate(ctx, a, parents); err != nil {
 		return errors.Wrapf(err, "failed to generate asset %q", a.Name())
 	}
 	assetState.asset = a
@@ -232,6 +268,23 @@ func (s *storeImpl) fetch(ctx context.Context, a asset.Asset, indent string) err
 	return nil
 }
 
+// generate generates the asset, cancelling the generation when the asset
+// has a timeout and runs longer than it.
+func generate(ctx context.Context, a asset.Asset, parents asset.Parents) error {
+	ta, ok := a.(asset.TimedAsset)
+	if !ok {
+		return a.Generate(ctx, parents)
+	}
+	timeout := ta.GenerateTimeout()
+	generateCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := a.Generate(generateCtx, parents)
+	if err != nil && ctx.Err() == nil && errors.Is(generateCtx.Err(), context.DeadlineExceeded) {
+		return errors.Wrapf(err, "timed out after %v", timeout)
+	}
+	return err
+}
+
 // load loads the asset and all of its ancestors from on-disk and the state file.
 func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 	logrus.Debugf("%sLoading %s...", indent, a.Name())
@@ -300,11 +353,29 @@ func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 		assetToStore asset.Asset
 		source       assetSource
 	)
+	// The files on disk that the installer did not write as they are were
+	// edited, or provided, by the user.
+	var edited []string
+	if anyParentsDirty && foundOnDisk {
+		edited = s.fileHashes.Edited(onDiskAsset.Files())
+	}
+
 	switch {
+	// A parent is dirty, but the edits to the asset on disk are preserved.
+	// The asset is sourced from on disk.
+	case len(edited) > 0 && s.driftPolicy == DriftPolicyPreserve:
+		logrus.Warningf("%sKeeping the %s that was edited in the target directory (%s) although its dependencies are dirty", indent, a.Name(), strings.Join(edited, ", "))
+		assetToStore = onDiskAsset
+		source = onDiskSource
 	// A parent is dirty. The asset must be re-generated.
 	case anyParentsDirty:
-		if foundOnDisk {
-			logrus.Warningf("%sDiscarding the %s that was provided in the target directory because its dependencies are dirty and it needs to be regenerated", indent, a.Name())
+		if len(edited) > 0 {
+			logrus.Warningf("%sDiscarding the %s that was provided in the target directory because its dependencies are dirty and it needs to be regenerated; edited files: %s", indent, a.Name(), strings.Join(edited, ", "))
+			if s.driftPolicy == DriftPolicyDiff {
+				s.logEdits(a, onDiskAsset, edited)
+			}
+		} else if foundOnDisk {
+			logrus.Debugf("%sRegenerating the unedited %s in the target directory because its dependencies are dirty", indent, a.Name())
 		}
 		source = unfetched
 	// The asset is on disk and that differs from what is in the source file.
@@ -373,13 +444,44 @@ func (s *storeImpl) Load(a asset.Asset) (asset.Asset, error) {
 	return s.assets[reflect.TypeOf(a)].asset, nil
 }
 
-// asAssetGenerator determines if an asset implements the
-// Generate with context function, or if it needs an adapter.
-func asAssetGenerator(a asset.Asset) asset.Generator {
-	switch v := a.(type) {
-	case asset.Generator:
-		return v
-	default:
-		return asset.NewDefaultGenerator(a)
+// AssetStatus describes the state of an asset in the asset directory.
+type AssetStatus struct {
+	// OnDisk is true if the asset was provided in the asset directory.
+	OnDisk bool `json:"onDisk"`
+	// Generated is true if the asset was generated by a previous invocation
+	// and recorded in the state file.
+	Generated bool `json:"generated"`
+	// Dirty is true if the asset, or any of its dependencies, was provided
+	// in the asset directory and differs from the generated asset, so that
+	// the asset will be regenerated.
+	Dirty bool `json:"dirty"`
+}
+
+// LoadStatus returns the status of the given assets, and of all of their
+// dependencies, in the asset directory without generating any of them.
+func LoadStatus(dir string, assets ...asset.Asset) (map[reflect.Type]AssetStatus, error) {
+	s, err := newStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadStatus(assets...)
+}
+
+func (s *storeImpl) loadStatus(assets ...asset.Asset) (map[reflect.Type]AssetStatus, error) {
+	for _, a := range assets {
+		if _, err := s.load(a, ""); err != nil {
+			return nil, errors.Wrap(err, "failed to load asset")
+		}
+	}
+
+	status := make(map[reflect.Type]AssetStatus, len(s.assets))
+	for t, state := range s.assets {
+		_, inStateFile := s.stateFileAssets[t.String()]
+		status[t] = AssetStatus{
+			OnDisk:    state.presentOnDisk,
+			Generated: inStateFile,
+			Dirty:     state.anyParentsDirty || state.source == onDiskSource,
+		}
 	}
+	return status, nil
 }
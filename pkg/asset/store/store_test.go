@@ -2,10 +2,12 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -19,6 +21,7 @@ var (
 	generationLog []string
 	dependencies  map[reflect.Type][]asset.Asset
 	onDiskAssets  map[reflect.Type]bool
+	interrupt     context.CancelFunc
 )
 
 func clearAssetBehaviors() {
@@ -54,7 +57,7 @@ func (a *testStoreAssetA) Dependencies() []asset.Asset {
 	return dependenciesTestStoreAsset(a)
 }
 
-func (a *testStoreAssetA) Generate(asset.Parents) error {
+func (a *testStoreAssetA) Generate(context.Context, asset.Parents) error {
 	return generateTestStoreAsset(a)
 }
 
@@ -76,7 +79,7 @@ func (a *testStoreAssetB) Dependencies() []asset.Asset {
 	return dependenciesTestStoreAsset(a)
 }
 
-func (a *testStoreAssetB) Generate(asset.Parents) error {
+func (a *testStoreAssetB) Generate(context.Context, asset.Parents) error {
 	return generateTestStoreAsset(a)
 }
 
@@ -98,7 +101,7 @@ func (a *testStoreAssetC) Dependencies() []asset.Asset {
 	return dependenciesTestStoreAsset(a)
 }
 
-func (a *testStoreAssetC) Generate(asset.Parents) error {
+func (a *testStoreAssetC) Generate(context.Context, asset.Parents) error {
 	return generateTestStoreAsset(a)
 }
 
@@ -120,7 +123,7 @@ func (a *testStoreAssetD) Dependencies() []asset.Asset {
 	return dependenciesTestStoreAsset(a)
 }
 
-func (a *testStoreAssetD) Generate(asset.Parents) error {
+func (a *testStoreAssetD) Generate(context.Context, asset.Parents) error {
 	return generateTestStoreAsset(a)
 }
 
@@ -132,6 +135,42 @@ func (a *testStoreAssetD) Load(asset.FileFetcher) (bool, error) {
 	return loadTestStoreAsset(a)
 }
 
+// testStoreHungAsset blocks until its generation is cancelled.
+type testStoreHungAsset struct{}
+
+func (a *testStoreHungAsset) Name() string {
+	return "hung"
+}
+
+func (a *testStoreHungAsset) Dependencies() []asset.Asset {
+	return dependenciesTestStoreAsset(a)
+}
+
+func (a *testStoreHungAsset) Generate(ctx context.Context, _ asset.Parents) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (a *testStoreHungAsset) GenerateTimeout() time.Duration {
+	return 10 * time.Millisecond
+}
+
+// testStoreInterruptedAsset interrupts the fetch during its generation.
+type testStoreInterruptedAsset struct{}
+
+func (a *testStoreInterruptedAsset) Name() string {
+	return "interrupted"
+}
+
+func (a *testStoreInterruptedAsset) Dependencies() []asset.Asset {
+	return dependenciesTestStoreAsset(a)
+}
+
+func (a *testStoreInterruptedAsset) Generate(ctx context.Context, _ asset.Parents) error {
+	interrupt()
+	return ctx.Err()
+}
+
 func newTestStoreAsset(name string) asset.Asset {
 	switch name {
 	case "a":
@@ -142,6 +181,10 @@ func newTestStoreAsset(name string) asset.Asset {
 		return &testStoreAssetC{}
 	case "d":
 		return &testStoreAssetD{}
+	case "hung":
+		return &testStoreHungAsset{}
+	case "interrupted":
+		return &testStoreInterruptedAsset{}
 	default:
 		return nil
 	}
@@ -289,11 +332,53 @@ func TestStoreFetch(t *testing.T) {
 	}
 }
 
+func TestStoreFetchTimeout(t *testing.T) {
+	clearAssetBehaviors()
+	a, b, hung := &testStoreAssetA{}, &testStoreAssetB{}, &testStoreHungAsset{}
+	dependencies[reflect.TypeOf(a)] = []asset.Asset{b, hung}
+
+	store, err := newStore(t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = store.Fetch(context.Background(), a)
+	assert.Regexp(t, `failed to generate asset "hung": timed out after 10ms: context deadline exceeded$`, err)
+	assert.EqualValues(t, []string{"b"}, generationLog)
+}
+
+func TestStoreFetchInterrupted(t *testing.T) {
+	clearAssetBehaviors()
+	a, b, c, interrupted := &testStoreAssetA{}, &testStoreAssetB{}, &testStoreAssetC{}, &testStoreInterruptedAsset{}
+	dependencies[reflect.TypeOf(a)] = []asset.Asset{b, interrupted, c}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt = cancel
+
+	dir := t.TempDir()
+	store, err := newStore(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = store.Fetch(ctx, a)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualValues(t, []string{"b"}, generationLog)
+
+	// The assets generated before the interruption are kept in the state.
+	state, err := asset.ReadStateFile(filepath.Join(dir, stateFileName))
+	if assert.NoError(t, err) {
+		assert.Contains(t, state, reflect.TypeOf(b).String())
+		assert.NotContains(t, state, reflect.TypeOf(a).String())
+	}
+}
+
 func TestStoreFetchOnDiskAssets(t *testing.T) {
 	cases := []struct {
 		name                  string
 		assets                map[string][]string
 		onDiskAssets          []string
+		driftPolicy           DriftPolicy
+		fileHashes            asset.FileHashes
 		target                string
 		expectedGenerationLog []string
 		expectedDirty         bool
@@ -355,12 +440,40 @@ func TestStoreFetchOnDiskAssets(t *testing.T) {
 			expectedGenerationLog: []string{"a"},
 			expectedDirty:         true,
 		},
+		{
+			name: "edited on-disk asset is preserved",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			onDiskAssets:          []string{"a", "b"},
+			driftPolicy:           DriftPolicyPreserve,
+			fileHashes:            asset.FileHashes{"a": asset.HashFileData([]byte("generated"))},
+			target:                "a",
+			expectedGenerationLog: []string{},
+			expectedDirty:         true,
+		},
+		{
+			name: "unedited on-disk asset is re-generated",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			onDiskAssets:          []string{"a", "b"},
+			driftPolicy:           DriftPolicyPreserve,
+			fileHashes:            asset.FileHashes{"a": asset.HashFileData(nil)},
+			target:                "a",
+			expectedGenerationLog: []string{"a"},
+			expectedDirty:         true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			clearAssetBehaviors()
 			store := &storeImpl{
-				assets: map[reflect.Type]*assetState{},
+				assets:      map[reflect.Type]*assetState{},
+				driftPolicy: tc.driftPolicy,
+				fileHashes:  tc.fileHashes,
 			}
 			assets := make(map[string]asset.Asset, len(tc.assets))
 			for name := range tc.assets {
@@ -475,3 +588,24 @@ func TestStoreLoadOnDiskAssets(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreLoadStatus(t *testing.T) {
+	clearAssetBehaviors()
+	store := &storeImpl{
+		assets:          map[reflect.Type]*assetState{},
+		stateFileAssets: map[string]json.RawMessage{},
+	}
+	a, b, c := newTestStoreAsset("a"), newTestStoreAsset("b"), newTestStoreAsset("c")
+	dependencies[reflect.TypeOf(a)] = []asset.Asset{b}
+	dependencies[reflect.TypeOf(b)] = []asset.Asset{c}
+	onDiskAssets[reflect.TypeOf(b)] = true
+	store.stateFileAssets[reflect.TypeOf(c).String()] = json.RawMessage("{}")
+
+	status, err := store.loadStatus(a)
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, map[reflect.Type]AssetStatus{
+		reflect.TypeOf(a): {Dirty: true},
+		reflect.TypeOf(b): {OnDisk: true, Dirty: true},
+		reflect.TypeOf(c): {Generated: true},
+	}, status)
+}
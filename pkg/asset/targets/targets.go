@@ -11,6 +11,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/manifests"
 	"github.com/openshift/installer/pkg/asset/manifests/clusterapi"
+	"github.com/openshift/installer/pkg/asset/manifests/hypershift"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/asset/templates/content/bootkube"
 	"github.com/openshift/installer/pkg/asset/templates/content/openshift"
@@ -33,6 +34,12 @@ var (
 		&clusterapi.Cluster{},
 	}
 
+	// HostedManifests are the manifests targeted assets of the hosted control
+	// planes topology.
+	HostedManifests = []asset.WritableAsset{
+		&hypershift.Manifests{},
+	}
+
 	// ManifestTemplates are the manifest-templates targeted assets.
 	ManifestTemplates = []asset.WritableAsset{
 		&bootkube.KubeCloudConfig{},
@@ -50,8 +57,10 @@ var (
 	IgnitionConfigs = []asset.WritableAsset{
 		&kubeconfig.AdminClient{},
 		&password.KubeadminPassword{},
+		&tls.CoreSSHKeyPair{},
 		&machine.Master{},
 		&machine.Worker{},
+		&machine.Bastion{},
 		&bootstrap.Bootstrap{},
 		&cluster.Metadata{},
 	}
@@ -60,6 +69,7 @@ var (
 	SingleNodeIgnitionConfig = []asset.WritableAsset{
 		&kubeconfig.AdminClient{},
 		&password.KubeadminPassword{},
+		&tls.CoreSSHKeyPair{},
 		&machine.Worker{},
 		&bootstrap.SingleNodeBootstrapInPlace{},
 		&cluster.Metadata{},
@@ -73,7 +83,15 @@ var (
 		&tfvars.TerraformVariables{},
 		&kubeconfig.AdminClient{},
 		&password.KubeadminPassword{},
+		&tls.CoreSSHKeyPair{},
 		&tls.JournalCertKey{},
 		&cluster.Cluster{},
 	}
+
+	// ClusterPlan are the assets targeted by planning the cluster. The
+	// install-config and the manifests are targeted too, so that the plan,
+	// which is only a preview, does not consume them.
+	ClusterPlan = append(append([]asset.WritableAsset{
+		&installconfig.InstallConfig{},
+	}, Manifests...), &cluster.Plan{})
 )
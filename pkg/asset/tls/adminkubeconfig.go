@@ -1,8 +1,10 @@
 package tls
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"time"
 
 	"github.com/openshift/installer/pkg/asset"
 )
@@ -20,7 +22,7 @@ func (c *AdminKubeConfigSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *AdminKubeConfigSignerCertKey) Generate(parents asset.Parents) error {
+func (c *AdminKubeConfigSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "admin-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -57,7 +59,7 @@ func (a *AdminKubeConfigCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *AdminKubeConfigCABundle) Generate(deps asset.Parents) error {
+func (a *AdminKubeConfigCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -88,15 +90,21 @@ func (a *AdminKubeConfigClientCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *AdminKubeConfigClientCertKey) Generate(dependencies asset.Parents) error {
+func (a *AdminKubeConfigClientCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &AdminKubeConfigSignerCertKey{}
 	dependencies.Get(ca)
 
+	return a.Regenerate(ca, ValidityTenYears)
+}
+
+// Regenerate generates a new cert/key pair, valid for the given duration,
+// signed by the admin kubeconfig signer of an existing cluster.
+func (a *AdminKubeConfigClientCertKey) Regenerate(ca *AdminKubeConfigSignerCertKey, validity time.Duration) error {
 	cfg := &CertCfg{
 		Subject:      pkix.Name{CommonName: "system:admin", Organization: []string{"system:masters"}},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
-		Validity:     ValidityTenYears,
+		Validity:     validity,
 	}
 
 	return a.SignedCertKey.Generate(cfg, ca, "admin-kubeconfig-client", DoNotAppendParent)
@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 
@@ -23,7 +24,7 @@ func (a *AggregatorCA) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *AggregatorCA) Generate(dependencies asset.Parents) error {
+func (a *AggregatorCA) Generate(_ context.Context, dependencies asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "aggregator", OrganizationalUnit: []string{"bootkube"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -57,7 +58,7 @@ func (a *APIServerProxyCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *APIServerProxyCertKey) Generate(dependencies asset.Parents) error {
+func (a *APIServerProxyCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	aggregatorCA := &AggregatorCA{}
 	dependencies.Get(aggregatorCA)
 
@@ -89,7 +90,7 @@ func (c *AggregatorSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *AggregatorSignerCertKey) Generate(parents asset.Parents) error {
+func (c *AggregatorSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "aggregator-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -121,7 +122,7 @@ func (a *AggregatorCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *AggregatorCABundle) Generate(deps asset.Parents) error {
+func (a *AggregatorCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -150,7 +151,7 @@ func (a *AggregatorClientCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *AggregatorClientCertKey) Generate(dependencies asset.Parents) error {
+func (a *AggregatorClientCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &AggregatorSignerCertKey{}
 	dependencies.Get(ca)
 
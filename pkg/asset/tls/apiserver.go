@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"net"
@@ -24,7 +25,7 @@ func (c *KubeAPIServerToKubeletSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeAPIServerToKubeletSignerCertKey) Generate(parents asset.Parents) error {
+func (c *KubeAPIServerToKubeletSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kube-apiserver-to-kubelet-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -56,7 +57,7 @@ func (a *KubeAPIServerToKubeletCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeAPIServerToKubeletCABundle) Generate(deps asset.Parents) error {
+func (a *KubeAPIServerToKubeletCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -85,7 +86,7 @@ func (a *KubeAPIServerToKubeletClientCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeAPIServerToKubeletClientCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeAPIServerToKubeletClientCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeAPIServerToKubeletSignerCertKey{}
 	dependencies.Get(ca)
 
@@ -117,7 +118,7 @@ func (c *KubeAPIServerLocalhostSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeAPIServerLocalhostSignerCertKey) Generate(parents asset.Parents) error {
+func (c *KubeAPIServerLocalhostSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kube-apiserver-localhost-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -154,7 +155,7 @@ func (a *KubeAPIServerLocalhostCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeAPIServerLocalhostCABundle) Generate(deps asset.Parents) error {
+func (a *KubeAPIServerLocalhostCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -183,7 +184,7 @@ func (a *KubeAPIServerLocalhostServerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeAPIServerLocalhostServerCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeAPIServerLocalhostServerCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeAPIServerLocalhostSignerCertKey{}
 	dependencies.Get(ca)
 
@@ -219,7 +220,7 @@ func (c *KubeAPIServerServiceNetworkSignerCertKey) Dependencies() []asset.Asset
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeAPIServerServiceNetworkSignerCertKey) Generate(parents asset.Parents) error {
+func (c *KubeAPIServerServiceNetworkSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kube-apiserver-service-network-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -256,7 +257,7 @@ func (a *KubeAPIServerServiceNetworkCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeAPIServerServiceNetworkCABundle) Generate(deps asset.Parents) error {
+func (a *KubeAPIServerServiceNetworkCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -286,7 +287,7 @@ func (a *KubeAPIServerServiceNetworkServerCertKey) Dependencies() []asset.Asset
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeAPIServerServiceNetworkServerCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeAPIServerServiceNetworkServerCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeAPIServerServiceNetworkSignerCertKey{}
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(ca, installConfig)
@@ -332,7 +333,7 @@ func (c *KubeAPIServerLBSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeAPIServerLBSignerCertKey) Generate(parents asset.Parents) error {
+func (c *KubeAPIServerLBSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kube-apiserver-lb-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -369,7 +370,7 @@ func (a *KubeAPIServerLBCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeAPIServerLBCABundle) Generate(deps asset.Parents) error {
+func (a *KubeAPIServerLBCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -399,7 +400,7 @@ func (a *KubeAPIServerExternalLBServerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeAPIServerExternalLBServerCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeAPIServerExternalLBServerCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeAPIServerLBSignerCertKey{}
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(ca, installConfig)
@@ -438,7 +439,7 @@ func (a *KubeAPIServerInternalLBServerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeAPIServerInternalLBServerCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeAPIServerInternalLBServerCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeAPIServerLBSignerCertKey{}
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(ca, installConfig)
@@ -479,7 +480,7 @@ func (a *KubeAPIServerCompleteCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeAPIServerCompleteCABundle) Generate(deps asset.Parents) error {
+func (a *KubeAPIServerCompleteCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -513,7 +514,7 @@ func (a *KubeAPIServerCompleteClientCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeAPIServerCompleteClientCABundle) Generate(deps asset.Parents) error {
+func (a *KubeAPIServerCompleteClientCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
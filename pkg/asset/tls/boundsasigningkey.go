@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
@@ -32,7 +33,7 @@ func (*BoundSASigningKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the CloudProviderConfig.
-func (*BoundSASigningKey) Generate(dependencies asset.Parents) error { return nil }
+func (*BoundSASigningKey) Generate(_ context.Context, dependencies asset.Parents) error { return nil }
 
 // Files returns the files generated by the asset.
 func (sk *BoundSASigningKey) Files() []*asset.File {
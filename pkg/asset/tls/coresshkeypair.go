@@ -0,0 +1,101 @@
+package tls
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// CoreSSHKeyPairPrivateKeyPath is the path, relative to the asset directory,
+// of the private key generated for the core user.
+var CoreSSHKeyPairPrivateKeyPath = filepath.Join("auth", "id_ed25519")
+
+// CoreSSHKeyPair is an ed25519 key pair generated for the core user when the
+// install-config requests it and provides no SSH key of its own.
+type CoreSSHKeyPair struct {
+	Priv []byte // private key in OpenSSH format
+	Pub  []byte // public ssh key
+}
+
+var _ asset.WritableAsset = (*CoreSSHKeyPair)(nil)
+
+// Dependencies lists the assets required to generate the CoreSSHKeyPair.
+func (a *CoreSSHKeyPair) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Name defines a user friendly name for CoreSSHKeyPair.
+func (a *CoreSSHKeyPair) Name() string {
+	return "Core SSH Key Pair"
+}
+
+// Generate generates the key pair if the install-config asks for one.
+func (a *CoreSSHKeyPair) Generate(_ context.Context, dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	if !installConfig.Config.GenerateSSHKey || len(installConfig.Config.AuthorizedSSHKeys()) > 0 {
+		return nil
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ed25519 key")
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, "core@"+installConfig.Config.ClusterDomain())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal private SSH key")
+	}
+
+	publicSSHKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return errors.Wrap(err, "failed to create public SSH key from ed25519 key")
+	}
+
+	a.Priv = pem.EncodeToMemory(block)
+	a.Pub = ssh.MarshalAuthorizedKey(publicSSHKey)
+
+	return nil
+}
+
+// AuthorizedKeys returns the public SSH keys of the install-config together
+// with the generated public key, if any.
+func (a *CoreSSHKeyPair) AuthorizedKeys(ic *types.InstallConfig) []string {
+	keys := ic.AuthorizedSSHKeys()
+	if len(a.Pub) > 0 {
+		keys = append(keys, strings.TrimSpace(string(a.Pub)))
+	}
+	return keys
+}
+
+// Files returns the files generated by the asset.
+func (a *CoreSSHKeyPair) Files() []*asset.File {
+	if len(a.Priv) == 0 {
+		return []*asset.File{}
+	}
+	return []*asset.File{{
+		Filename: CoreSSHKeyPairPrivateKeyPath,
+		Data:     a.Priv,
+	}, {
+		Filename: CoreSSHKeyPairPrivateKeyPath + ".pub",
+		Data:     a.Pub,
+	}}
+}
+
+// Load is a no-op because the key pair is recorded in the state file.
+func (a *CoreSSHKeyPair) Load(asset.FileFetcher) (bool, error) {
+	return false, nil
+}
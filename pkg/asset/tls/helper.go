@@ -1,7 +1,6 @@
 package tls
 
 import (
-	"fmt"
 	"net"
 	"path/filepath"
 
@@ -19,11 +18,11 @@ func assetFilePath(filename string) string {
 }
 
 func apiAddress(cfg *types.InstallConfig) string {
-	return fmt.Sprintf("api.%s", cfg.ClusterDomain())
+	return cfg.APIHostname()
 }
 
 func internalAPIAddress(cfg *types.InstallConfig) string {
-	return fmt.Sprintf("api-int.%s", cfg.ClusterDomain())
+	return cfg.APIInternalHostname()
 }
 
 func cidrhost(network net.IPNet, hostNum int) (string, error) {
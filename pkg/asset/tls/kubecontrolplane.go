@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 
@@ -20,7 +21,7 @@ func (c *KubeControlPlaneSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeControlPlaneSignerCertKey) Generate(parents asset.Parents) error {
+func (c *KubeControlPlaneSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kube-control-plane-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -55,7 +56,7 @@ func (a *KubeControlPlaneCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeControlPlaneCABundle) Generate(deps asset.Parents) error {
+func (a *KubeControlPlaneCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -84,7 +85,7 @@ func (a *KubeControlPlaneKubeControllerManagerClientCertKey) Dependencies() []as
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeControlPlaneKubeControllerManagerClientCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeControlPlaneKubeControllerManagerClientCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeControlPlaneSignerCertKey{}
 	dependencies.Get(ca)
 
@@ -118,7 +119,7 @@ func (a *KubeControlPlaneKubeSchedulerClientCertKey) Dependencies() []asset.Asse
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeControlPlaneKubeSchedulerClientCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeControlPlaneKubeSchedulerClientCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeControlPlaneSignerCertKey{}
 	dependencies.Get(ca)
 
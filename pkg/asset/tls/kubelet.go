@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 
@@ -20,7 +21,7 @@ func (c *KubeletCSRSignerCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeletCSRSignerCertKey) Generate(parents asset.Parents) error {
+func (c *KubeletCSRSignerCertKey) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kubelet-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -52,7 +53,7 @@ func (a *KubeletClientCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeletClientCABundle) Generate(deps asset.Parents) error {
+func (a *KubeletClientCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -82,7 +83,7 @@ func (a *KubeletServingCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeletServingCABundle) Generate(deps asset.Parents) error {
+func (a *KubeletServingCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -110,7 +111,7 @@ func (c *KubeletBootstrapCertSigner) Dependencies() []asset.Asset {
 }
 
 // Generate generates the root-ca key and cert pair.
-func (c *KubeletBootstrapCertSigner) Generate(parents asset.Parents) error {
+func (c *KubeletBootstrapCertSigner) Generate(_ context.Context, parents asset.Parents) error {
 	cfg := &CertCfg{
 		Subject:   pkix.Name{CommonName: "kubelet-bootstrap-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
 		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
@@ -142,7 +143,7 @@ func (a *KubeletBootstrapCABundle) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert bundle based on its dependencies.
-func (a *KubeletBootstrapCABundle) Generate(deps asset.Parents) error {
+func (a *KubeletBootstrapCABundle) Generate(_ context.Context, deps asset.Parents) error {
 	var certs []CertInterface
 	for _, asset := range a.Dependencies() {
 		deps.Get(asset)
@@ -174,7 +175,7 @@ func (a *KubeletClientCertKey) Dependencies() []asset.Asset {
 }
 
 // Generate generates the cert/key pair based on its dependencies.
-func (a *KubeletClientCertKey) Generate(dependencies asset.Parents) error {
+func (a *KubeletClientCertKey) Generate(_ context.Context, dependencies asset.Parents) error {
 	ca := &KubeletBootstrapCertSigner{}
 	dependencies.Get(ca)
 
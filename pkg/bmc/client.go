@@ -0,0 +1,187 @@
+// Package bmc manages hosts through the Redfish API of their baseboard
+// management controller, to boot them from an ISO attached as virtual media.
+// The Dell iDRAC and HPE iLO deviations from the Redfish standard are handled
+// by per-vendor workarounds selected from the scheme of the BMC address.
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultTimeout is the timeout of each request to the BMC.
+const defaultTimeout = 1 * time.Minute
+
+// Client manages the computer system of a BMC through its Redfish API.
+type Client struct {
+	endpoint   string
+	systemPath string
+	username   string
+	password   string
+	vendor     vendor
+	httpClient *http.Client
+}
+
+type options struct {
+	insecureSkipVerify bool
+	caBundle           []byte
+	timeout            time.Duration
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithInsecureSkipVerify disables the verification of the certificate of
+// the BMC when insecure is true.
+func WithInsecureSkipVerify(insecure bool) Option {
+	return func(o *options) {
+		o.insecureSkipVerify = insecure
+	}
+}
+
+// WithCABundle verifies the certificate of the BMC against the PEM encoded
+// certificates of caBundle instead of the system trust store.
+func WithCABundle(caBundle []byte) Option {
+	return func(o *options) {
+		o.caBundle = caBundle
+	}
+}
+
+// WithTimeout sets the timeout of each request to the BMC.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// IsVirtualMediaAddress returns true if the BMC address is a Redfish address
+// through which an ISO can be attached as virtual media.
+func IsVirtualMediaAddress(address string) bool {
+	u, err := url.Parse(address)
+	if err != nil {
+		return false
+	}
+	_, ok := schemes[u.Scheme]
+	return ok
+}
+
+// NewClient returns a client of the computer system of the BMC, whose address
+// is of the form redfish-virtualmedia://<host>/redfish/v1/Systems/<id>.
+func NewClient(address, username, password string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid BMC address %s", address)
+	}
+	scheme, ok := schemes[u.Scheme]
+	if !ok {
+		return nil, errors.Errorf("BMC address %s is not a Redfish address, the image can only be attached through Redfish virtual media", address)
+	}
+	if u.Path == "" || u.Path == "/" {
+		return nil, errors.Errorf("BMC address %s has no system path, e.g. /redfish/v1/Systems/1", address)
+	}
+	if username == "" || password == "" {
+		return nil, errors.Errorf("the username and password of BMC %s are required", address)
+	}
+
+	o := &options{timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: o.insecureSkipVerify, //nolint:gosec // disabled explicitly by the user
+	}
+	if len(o.caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(o.caBundle) {
+			return nil, errors.Errorf("the CA bundle of BMC %s holds no PEM encoded certificate", address)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	return &Client{
+		endpoint:   fmt.Sprintf("%s://%s", scheme.protocol, u.Host),
+		systemPath: strings.TrimSuffix(u.Path, "/"),
+		username:   username,
+		password:   password,
+		vendor:     scheme.vendor,
+		httpClient: &http.Client{Transport: transport, Timeout: o.timeout},
+	}, nil
+}
+
+// httpError is returned for the responses of the BMC with an error status.
+type httpError struct {
+	method     string
+	path       string
+	status     string
+	statusCode int
+	message    string
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status %s: %s", e.method, e.path, e.status, e.message)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &httpError{
+			method:     method,
+			path:       path,
+			status:     resp.Status,
+			statusCode: resp.StatusCode,
+			message:    strings.TrimSpace(string(message)),
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// BootFromImage inserts the image at imageURL in the virtual CD drive of the
+// system, makes the system boot from it once, and powers the system on or
+// restarts it.
+func (c *Client) BootFromImage(ctx context.Context, imageURL string) error {
+	if err := c.InsertMedia(ctx, imageURL); err != nil {
+		return err
+	}
+	if err := c.SetBootOnceFromCD(ctx); err != nil {
+		return err
+	}
+	return c.PowerCycle(ctx)
+}
@@ -0,0 +1,235 @@
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const imageURL = "http://192.168.111.1:8080/agent.x86_64.iso"
+
+// fakeBMC serves the Redfish resources of a computer system with a virtual
+// CD drive, and records the requests changing them. InsertMedia requests
+// with optional parameters are rejected when rejectOptional is true.
+func fakeBMC(t *testing.T, powerState string, inserted, rejectOptional bool) (*httptest.Server, *[]string) {
+	t.Helper()
+	var requests []string
+	resources := map[string]interface{}{
+		"/redfish/v1/Systems/1": map[string]interface{}{
+			"PowerState": powerState,
+			"Links": map[string]interface{}{
+				"ManagedBy": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Managers/1"}},
+			},
+			"Actions": map[string]interface{}{
+				"#ComputerSystem.Reset": map[string]interface{}{"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"},
+			},
+		},
+		"/redfish/v1/Managers/1": map[string]interface{}{
+			"VirtualMedia": map[string]interface{}{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia"},
+		},
+		"/redfish/v1/Managers/1/VirtualMedia": map[string]interface{}{
+			"Members": []interface{}{
+				map[string]interface{}{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/Floppy"},
+				map[string]interface{}{"@odata.id": "/redfish/v1/Managers/1/VirtualMedia/Cd"},
+			},
+		},
+		"/redfish/v1/Managers/1/VirtualMedia/Floppy": map[string]interface{}{
+			"MediaTypes": []interface{}{"Floppy", "USBStick"},
+		},
+		"/redfish/v1/Managers/1/VirtualMedia/Cd": map[string]interface{}{
+			"MediaTypes": []interface{}{"CD", "DVD"},
+			"Inserted":   inserted,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodGet {
+			resource, ok := resources[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(resource))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// encoding/json escapes the angle brackets of the iDRAC profile.
+		unescaped := strings.NewReplacer(`\u003c`, "<", `\u003e`, ">").Replace(string(body))
+		requests = append(requests, strings.Join([]string{r.Method, r.URL.Path, unescaped}, " "))
+		if rejectOptional && strings.HasSuffix(r.URL.Path, "InsertMedia") && strings.Contains(string(body), "WriteProtected") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestBootFromImage(t *testing.T) {
+	cases := []struct {
+		name             string
+		scheme           string
+		powerState       string
+		inserted         bool
+		rejectOptional   bool
+		expectedRequests []string
+	}{
+		{
+			name:       "powered off",
+			scheme:     "redfish-virtualmedia+http",
+			powerState: "Off",
+			expectedRequests: []string{
+				`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.InsertMedia {"Image":"http://192.168.111.1:8080/agent.x86_64.iso","Inserted":true,"WriteProtected":true}`,
+				`PATCH /redfish/v1/Systems/1 {"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`,
+				`POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset {"ResetType":"On"}`,
+			},
+		},
+		{
+			name:       "powered on with media inserted",
+			scheme:     "redfish-virtualmedia+http",
+			powerState: "On",
+			inserted:   true,
+			expectedRequests: []string{
+				`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.EjectMedia {}`,
+				`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.InsertMedia {"Image":"http://192.168.111.1:8080/agent.x86_64.iso","Inserted":true,"WriteProtected":true}`,
+				`PATCH /redfish/v1/Systems/1 {"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`,
+				`POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset {"ResetType":"ForceRestart"}`,
+			},
+		},
+		{
+			name:           "optional insert parameters rejected",
+			scheme:         "redfish-virtualmedia+http",
+			powerState:     "Off",
+			rejectOptional: true,
+			expectedRequests: []string{
+				`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.InsertMedia {"Image":"http://192.168.111.1:8080/agent.x86_64.iso","Inserted":true,"WriteProtected":true}`,
+				`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.InsertMedia {"Image":"http://192.168.111.1:8080/agent.x86_64.iso"}`,
+				`PATCH /redfish/v1/Systems/1 {"Boot":{"BootSourceOverrideEnabled":"Once","BootSourceOverrideTarget":"Cd"}}`,
+				`POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset {"ResetType":"On"}`,
+			},
+		},
+		{
+			name:       "idrac",
+			scheme:     "idrac-virtualmedia+http",
+			powerState: "Off",
+			expectedRequests: []string{
+				`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.InsertMedia {"Image":"http://192.168.111.1:8080/agent.x86_64.iso","Inserted":true,"WriteProtected":true}`,
+				`POST /redfish/v1/Managers/1/Actions/Oem/EID_674_Manager.ImportSystemConfiguration {"ImportBuffer":"<SystemConfiguration><Component FQDD=\"iDRAC.Embedded.1\"><Attribute Name=\"ServerBoot.1#BootOnce\">Enabled</Attribute><Attribute Name=\"ServerBoot.1#FirstBootDevice\">VCD-DVD</Attribute></Component></SystemConfiguration>","ShareParameters":{"Target":"ALL"}}`,
+				`POST /redfish/v1/Systems/1/Actions/ComputerSystem.Reset {"ResetType":"On"}`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, requests := fakeBMC(t, tc.powerState, tc.inserted, tc.rejectOptional)
+			address := tc.scheme + "://" + strings.TrimPrefix(server.URL, "http://") + "/redfish/v1/Systems/1"
+			client, err := NewClient(address, "admin", "secret")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			err = client.BootFromImage(context.Background(), imageURL)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedRequests, *requests)
+			}
+		})
+	}
+}
+
+func TestInsertMediaHPE(t *testing.T) {
+	server, requests := fakeBMC(t, "On", false, false)
+	// The ilo5 schemes are https only, so the endpoint is set directly.
+	client, err := NewClient("ilo5-virtualmedia://"+strings.TrimPrefix(server.URL, "http://")+"/redfish/v1/Systems/1", "admin", "secret")
+	if !assert.NoError(t, err) {
+		return
+	}
+	client.endpoint = server.URL
+
+	err = client.InsertMedia(context.Background(), imageURL)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{
+			`POST /redfish/v1/Managers/1/VirtualMedia/Cd/Actions/VirtualMedia.InsertMedia {"Image":"http://192.168.111.1:8080/agent.x86_64.iso","Inserted":true,"WriteProtected":true}`,
+			`PATCH /redfish/v1/Managers/1/VirtualMedia/Cd {"Oem":{"Hpe":{"BootOnNextServerReset":true}}}`,
+		}, *requests)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	cases := []struct {
+		name               string
+		address            string
+		username           string
+		opts               []Option
+		expectedEndpoint   string
+		expectedSystemPath string
+		expectedVendor     vendor
+		expectedError      string
+	}{
+		{
+			name:               "redfish virtual media",
+			address:            "redfish-virtualmedia://10.0.0.10/redfish/v1/Systems/1",
+			username:           "admin",
+			expectedEndpoint:   "https://10.0.0.10",
+			expectedSystemPath: "/redfish/v1/Systems/1",
+			expectedVendor:     genericVendor,
+		},
+		{
+			name:               "idrac virtual media over http",
+			address:            "idrac-virtualmedia+http://10.0.0.10:8000/redfish/v1/Systems/System.Embedded.1/",
+			username:           "admin",
+			expectedEndpoint:   "http://10.0.0.10:8000",
+			expectedSystemPath: "/redfish/v1/Systems/System.Embedded.1",
+			expectedVendor:     dellVendor,
+		},
+		{
+			name:          "ipmi",
+			address:       "ipmi://10.0.0.10",
+			username:      "admin",
+			expectedError: "BMC address ipmi://10.0.0.10 is not a Redfish address, the image can only be attached through Redfish virtual media",
+		},
+		{
+			name:          "no system path",
+			address:       "redfish://10.0.0.10",
+			username:      "admin",
+			expectedError: "BMC address redfish://10.0.0.10 has no system path, e.g. /redfish/v1/Systems/1",
+		},
+		{
+			name:          "no username",
+			address:       "redfish://10.0.0.10/redfish/v1/Systems/1",
+			expectedError: "the username and password of BMC redfish://10.0.0.10/redfish/v1/Systems/1 are required",
+		},
+		{
+			name:          "invalid CA bundle",
+			address:       "redfish://10.0.0.10/redfish/v1/Systems/1",
+			username:      "admin",
+			opts:          []Option{WithCABundle([]byte("not a certificate"))},
+			expectedError: "the CA bundle of BMC redfish://10.0.0.10/redfish/v1/Systems/1 holds no PEM encoded certificate",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewClient(tc.address, tc.username, "secret", tc.opts...)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedEndpoint, client.endpoint)
+				assert.Equal(t, tc.expectedSystemPath, client.systemPath)
+				assert.Equal(t, tc.expectedVendor, client.vendor)
+			}
+		})
+	}
+}
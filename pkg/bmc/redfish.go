@@ -0,0 +1,206 @@
+package bmc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type odataID struct {
+	ID string `json:"@odata.id"`
+}
+
+type action struct {
+	Target string `json:"target"`
+}
+
+type computerSystem struct {
+	PowerState   string  `json:"PowerState"`
+	VirtualMedia odataID `json:"VirtualMedia"`
+	Links        struct {
+		ManagedBy []odataID `json:"ManagedBy"`
+	} `json:"Links"`
+	Actions struct {
+		Reset action `json:"#ComputerSystem.Reset"`
+	} `json:"Actions"`
+}
+
+type manager struct {
+	VirtualMedia odataID `json:"VirtualMedia"`
+}
+
+type collection struct {
+	Members []odataID `json:"Members"`
+}
+
+type virtualMedia struct {
+	MediaTypes []string `json:"MediaTypes"`
+	Inserted   bool     `json:"Inserted"`
+	Actions    struct {
+		InsertMedia action `json:"#VirtualMedia.InsertMedia"`
+		EjectMedia  action `json:"#VirtualMedia.EjectMedia"`
+	} `json:"Actions"`
+}
+
+func (c *Client) system(ctx context.Context) (*computerSystem, error) {
+	system := &computerSystem{}
+	if err := c.do(ctx, http.MethodGet, c.systemPath, nil, system); err != nil {
+		return nil, errors.Wrap(err, "failed to get the computer system")
+	}
+	return system, nil
+}
+
+// virtualMediaCollection returns the path of the virtual media collection of
+// the system. It is a resource of the system since Redfish 2020.3, and of its
+// manager before.
+func (c *Client) virtualMediaCollection(ctx context.Context) (string, error) {
+	system, err := c.system(ctx)
+	if err != nil {
+		return "", err
+	}
+	if system.VirtualMedia.ID != "" {
+		return system.VirtualMedia.ID, nil
+	}
+	if len(system.Links.ManagedBy) == 0 {
+		return "", errors.New("the computer system has no manager")
+	}
+	m := &manager{}
+	if err := c.do(ctx, http.MethodGet, system.Links.ManagedBy[0].ID, nil, m); err != nil {
+		return "", errors.Wrap(err, "failed to get the manager")
+	}
+	if m.VirtualMedia.ID == "" {
+		return "", errors.New("the manager has no virtual media")
+	}
+	return m.VirtualMedia.ID, nil
+}
+
+// cdMedia returns the path and state of the first CD or DVD virtual media of
+// the system.
+func (c *Client) cdMedia(ctx context.Context) (string, *virtualMedia, error) {
+	collectionPath, err := c.virtualMediaCollection(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	members := &collection{}
+	if err := c.do(ctx, http.MethodGet, collectionPath, nil, members); err != nil {
+		return "", nil, errors.Wrap(err, "failed to list the virtual media")
+	}
+	for _, member := range members.Members {
+		media := &virtualMedia{}
+		if err := c.do(ctx, http.MethodGet, member.ID, nil, media); err != nil {
+			return "", nil, errors.Wrap(err, "failed to get the virtual media")
+		}
+		for _, mediaType := range media.MediaTypes {
+			if mediaType == "CD" || mediaType == "DVD" {
+				return member.ID, media, nil
+			}
+		}
+	}
+	return "", nil, errors.New("the system has no CD or DVD virtual media")
+}
+
+// InsertMedia inserts the image at imageURL in the virtual CD drive of the
+// system, ejecting the media inserted before.
+func (c *Client) InsertMedia(ctx context.Context, imageURL string) error {
+	path, media, err := c.cdMedia(ctx)
+	if err != nil {
+		return err
+	}
+	if media.Inserted {
+		if err := c.ejectMedia(ctx, path, media); err != nil {
+			return err
+		}
+	}
+
+	target := media.Actions.InsertMedia.Target
+	if target == "" {
+		target = path + "/Actions/VirtualMedia.InsertMedia"
+	}
+	body := map[string]interface{}{
+		"Image":          imageURL,
+		"Inserted":       true,
+		"WriteProtected": true,
+	}
+	err = c.do(ctx, http.MethodPost, target, body, nil)
+	var httpErr *httpError
+	if errors.As(err, &httpErr) && httpErr.statusCode == http.StatusBadRequest {
+		// Some BMCs reject the optional parameters of InsertMedia.
+		logrus.Debugf("Inserting the virtual media without optional parameters: %v", err)
+		err = c.do(ctx, http.MethodPost, target, map[string]interface{}{"Image": imageURL}, nil)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to insert the virtual media")
+	}
+	return c.afterInsertMedia(ctx, path)
+}
+
+// EjectMedia ejects the media of the virtual CD drive of the system, if any.
+func (c *Client) EjectMedia(ctx context.Context) error {
+	path, media, err := c.cdMedia(ctx)
+	if err != nil {
+		return err
+	}
+	if !media.Inserted {
+		return nil
+	}
+	return c.ejectMedia(ctx, path, media)
+}
+
+func (c *Client) ejectMedia(ctx context.Context, path string, media *virtualMedia) error {
+	target := media.Actions.EjectMedia.Target
+	if target == "" {
+		target = path + "/Actions/VirtualMedia.EjectMedia"
+	}
+	return errors.Wrap(c.do(ctx, http.MethodPost, target, map[string]interface{}{}, nil), "failed to eject the virtual media")
+}
+
+// SetBootOnceFromCD makes the system boot from the virtual CD drive on its
+// next boot only, so that it boots from the installed disk afterwards.
+func (c *Client) SetBootOnceFromCD(ctx context.Context) error {
+	if done, err := c.setVendorBootOnceFromCD(ctx); done {
+		return err
+	}
+	body := map[string]interface{}{
+		"Boot": map[string]interface{}{
+			"BootSourceOverrideTarget":  "Cd",
+			"BootSourceOverrideEnabled": "Once",
+		},
+	}
+	return errors.Wrap(c.do(ctx, http.MethodPatch, c.systemPath, body, nil), "failed to set the boot source")
+}
+
+// PowerOn powers on the system, unless it is already on.
+func (c *Client) PowerOn(ctx context.Context) error {
+	system, err := c.system(ctx)
+	if err != nil {
+		return err
+	}
+	if system.PowerState != "Off" {
+		return nil
+	}
+	return c.reset(ctx, system, "On")
+}
+
+// PowerCycle powers on the system, or restarts it when it is already on so
+// that it boots from the boot source that was just set.
+func (c *Client) PowerCycle(ctx context.Context) error {
+	system, err := c.system(ctx)
+	if err != nil {
+		return err
+	}
+	resetType := "On"
+	if system.PowerState != "Off" {
+		resetType = "ForceRestart"
+	}
+	return c.reset(ctx, system, resetType)
+}
+
+func (c *Client) reset(ctx context.Context, system *computerSystem, resetType string) error {
+	target := system.Actions.Reset.Target
+	if target == "" {
+		target = c.systemPath + "/Actions/ComputerSystem.Reset"
+	}
+	return errors.Wrapf(c.do(ctx, http.MethodPost, target, map[string]interface{}{"ResetType": resetType}, nil), "failed to reset the system with %s", resetType)
+}
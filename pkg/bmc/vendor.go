@@ -0,0 +1,95 @@
+package bmc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// vendor selects the workarounds of a BMC deviating from the Redfish
+// standard.
+type vendor string
+
+const (
+	genericVendor vendor = "generic"
+	dellVendor    vendor = "dell"
+	hpeVendor     vendor = "hpe"
+)
+
+type scheme struct {
+	protocol string
+	vendor   vendor
+}
+
+// schemes maps the schemes of the BMC addresses, as used by the baremetal
+// platform, to the protocol of their Redfish endpoint and the vendor of the
+// BMC.
+var schemes = map[string]scheme{
+	"redfish":                    {protocol: "https", vendor: genericVendor},
+	"redfish+http":               {protocol: "http", vendor: genericVendor},
+	"redfish+https":              {protocol: "https", vendor: genericVendor},
+	"redfish-virtualmedia":       {protocol: "https", vendor: genericVendor},
+	"redfish-virtualmedia+http":  {protocol: "http", vendor: genericVendor},
+	"redfish-virtualmedia+https": {protocol: "https", vendor: genericVendor},
+	"idrac-redfish":              {protocol: "https", vendor: dellVendor},
+	"idrac-redfish+http":         {protocol: "http", vendor: dellVendor},
+	"idrac-redfish+https":        {protocol: "https", vendor: dellVendor},
+	"idrac-virtualmedia":         {protocol: "https", vendor: dellVendor},
+	"idrac-virtualmedia+http":    {protocol: "http", vendor: dellVendor},
+	"idrac-virtualmedia+https":   {protocol: "https", vendor: dellVendor},
+	"ilo5-redfish":               {protocol: "https", vendor: hpeVendor},
+	"ilo5-virtualmedia":          {protocol: "https", vendor: hpeVendor},
+}
+
+// idracBootOnceFromCDProfile is the server configuration profile making an
+// iDRAC boot once from its virtual CD drive. The iDRAC ignores the standard
+// boot source override to Cd for virtual media on firmwares before 6.0.
+const idracBootOnceFromCDProfile = `<SystemConfiguration>` +
+	`<Component FQDD="iDRAC.Embedded.1">` +
+	`<Attribute Name="ServerBoot.1#BootOnce">Enabled</Attribute>` +
+	`<Attribute Name="ServerBoot.1#FirstBootDevice">VCD-DVD</Attribute>` +
+	`</Component>` +
+	`</SystemConfiguration>`
+
+// afterInsertMedia applies the vendor workarounds needed once the media at
+// mediaPath is inserted.
+func (c *Client) afterInsertMedia(ctx context.Context, mediaPath string) error {
+	switch c.vendor {
+	case hpeVendor:
+		// The iLO only boots from the virtual media on the next reset when
+		// flagged so, whatever the boot source override.
+		body := map[string]interface{}{
+			"Oem": map[string]interface{}{
+				"Hpe": map[string]interface{}{"BootOnNextServerReset": true},
+			},
+		}
+		return errors.Wrap(c.do(ctx, http.MethodPatch, mediaPath, body, nil), "failed to boot from the virtual media on the next reset")
+	default:
+		return nil
+	}
+}
+
+// setVendorBootOnceFromCD sets the boot source of the next boot through the
+// vendor extensions of the BMC, and returns false when the vendor has none so
+// that the standard boot source override is used.
+func (c *Client) setVendorBootOnceFromCD(ctx context.Context) (bool, error) {
+	switch c.vendor {
+	case dellVendor:
+		system, err := c.system(ctx)
+		if err != nil {
+			return true, err
+		}
+		if len(system.Links.ManagedBy) == 0 {
+			return true, errors.New("the computer system has no manager")
+		}
+		body := map[string]interface{}{
+			"ShareParameters": map[string]interface{}{"Target": "ALL"},
+			"ImportBuffer":    idracBootOnceFromCDProfile,
+		}
+		target := system.Links.ManagedBy[0].ID + "/Actions/Oem/EID_674_Manager.ImportSystemConfiguration"
+		return true, errors.Wrap(c.do(ctx, http.MethodPost, target, body, nil), "failed to import the boot configuration")
+	default:
+		return false, nil
+	}
+}
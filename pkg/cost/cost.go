@@ -0,0 +1,290 @@
+package cost
+
+import (
+	_ "embed" // to bundle the price sheets
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+// HoursPerMonth is the number of hours used to convert hourly prices to
+// monthly prices.
+const HoursPerMonth = 730
+
+//go:embed pricesheet.json
+var priceSheetData []byte
+
+// priceSheet holds the approximate on-demand list prices, in USD, of a
+// platform in a reference region.
+type priceSheet struct {
+	// Region is the region the prices apply to.
+	Region string `json:"region"`
+	// Instances are the hourly prices of the instance types.
+	Instances map[string]float64 `json:"instances"`
+	// Disks are the monthly prices of a GB of the disk types.
+	Disks map[string]float64 `json:"disks"`
+	// LoadBalancer is the hourly price of a load balancer.
+	LoadBalancer float64 `json:"loadBalancer"`
+	// NATGateway is the hourly price of a NAT gateway.
+	NATGateway float64 `json:"natGateway"`
+	// PublicIP is the hourly price of a public IP address.
+	PublicIP float64 `json:"publicIP"`
+}
+
+// Item is the estimated monthly cost of a group of identical resources.
+type Item struct {
+	// Resource describes the resource.
+	Resource string
+	// Quantity is the number of resources.
+	Quantity int64
+	// MonthlyCost is the monthly cost of all the resources, in USD.
+	MonthlyCost float64
+}
+
+// Estimate is the estimated monthly cost of a cluster.
+type Estimate struct {
+	// Region is the region of the price sheet used for the estimate.
+	Region string
+	// Items are the resources with a known price.
+	Items []Item
+	// Unknown are the resources without a price in the price sheet.
+	Unknown []string
+}
+
+// Total returns the estimated monthly cost of the cluster, in USD.
+func (e *Estimate) Total() float64 {
+	var total float64
+	for _, item := range e.Items {
+		total += item.MonthlyCost
+	}
+	return total
+}
+
+// machine is a group of identical machines.
+type machine struct {
+	pool         string
+	instanceType string
+	disks        []disk
+	zone         string
+	count        int64
+}
+
+// disk is a disk attached to a machine.
+type disk struct {
+	diskType string
+	sizeGB   int64
+}
+
+// network are the billable network resources of the cluster.
+type network struct {
+	loadBalancers int64
+	natGateways   int64
+	publicIPs     int64
+}
+
+func loadPriceSheet(platform string) (*priceSheet, error) {
+	sheets := map[string]*priceSheet{}
+	if err := json.Unmarshal(priceSheetData, &sheets); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the price sheets")
+	}
+	sheet, ok := sheets[platform]
+	if !ok {
+		return nil, errors.Errorf("cost estimation is not supported on platform %q, it is only supported on %s, %s and %s", platform, aws.Name, azure.Name, gcp.Name)
+	}
+	return sheet, nil
+}
+
+// EstimateCost estimates the monthly cost of the control plane and compute
+// machines, their disks and the load balancers, NAT gateways and public IP
+// addresses of the cluster. The cost of the temporary bootstrap machine, of
+// the data transfer and of the storage used by the cluster workloads are not
+// included.
+func EstimateCost(config *types.InstallConfig, controlPlanes []machineapi.Machine, computes []machineapi.MachineSet) (*Estimate, error) {
+	platform := config.Platform.Name()
+	sheet, err := loadPriceSheet(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	var machines []machine
+	for _, m := range controlPlanes {
+		pm, err := providerMachine(platform, m.Spec.ProviderSpec)
+		if err != nil {
+			return nil, err
+		}
+		pm.pool, pm.count = "control plane", 1
+		machines = append(machines, pm)
+	}
+	for _, ms := range computes {
+		pm, err := providerMachine(platform, ms.Spec.Template.Spec.ProviderSpec)
+		if err != nil {
+			return nil, err
+		}
+		pm.pool, pm.count = "compute", 1
+		if ms.Spec.Replicas != nil {
+			pm.count = int64(*ms.Spec.Replicas)
+		}
+		machines = append(machines, pm)
+	}
+
+	estimate := &Estimate{Region: sheet.Region}
+	instances := map[string]*Item{}
+	disks := map[string]*Item{}
+	for _, m := range machines {
+		if m.count == 0 {
+			continue
+		}
+		name := fmt.Sprintf("%s instance %s", m.pool, m.instanceType)
+		price, ok := sheet.Instances[m.instanceType]
+		if !ok {
+			estimate.Unknown = append(estimate.Unknown, name)
+		} else {
+			addItem(instances, name, m.count, price*HoursPerMonth*float64(m.count))
+		}
+		for _, d := range m.disks {
+			name := fmt.Sprintf("%s disk %s %d GB", m.pool, d.diskType, d.sizeGB)
+			price, ok := sheet.Disks[d.diskType]
+			if !ok {
+				estimate.Unknown = append(estimate.Unknown, name)
+				continue
+			}
+			addItem(disks, name, m.count, price*float64(d.sizeGB)*float64(m.count))
+		}
+	}
+	estimate.Items = append(estimate.Items, sortedItems(instances)...)
+	estimate.Items = append(estimate.Items, sortedItems(disks)...)
+
+	n := networkResources(config, machines)
+	for _, r := range []struct {
+		name  string
+		count int64
+		price float64
+	}{
+		{name: "load balancer", count: n.loadBalancers, price: sheet.LoadBalancer},
+		{name: "NAT gateway", count: n.natGateways, price: sheet.NATGateway},
+		{name: "public IP address", count: n.publicIPs, price: sheet.PublicIP},
+	} {
+		if r.count > 0 {
+			estimate.Items = append(estimate.Items, Item{Resource: r.name, Quantity: r.count, MonthlyCost: r.price * HoursPerMonth * float64(r.count)})
+		}
+	}
+	estimate.Unknown = sets.List(sets.New(estimate.Unknown...))
+	return estimate, nil
+}
+
+func addItem(items map[string]*Item, name string, quantity int64, cost float64) {
+	item, ok := items[name]
+	if !ok {
+		item = &Item{Resource: name}
+		items[name] = item
+	}
+	item.Quantity += quantity
+	item.MonthlyCost += cost
+}
+
+func sortedItems(items map[string]*Item) []Item {
+	ret := make([]Item, 0, len(items))
+	for _, item := range items {
+		ret = append(ret, *item)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Resource < ret[j].Resource })
+	return ret
+}
+
+// providerMachine returns the instance type, zone and disks of a machine
+// provider spec.
+func providerMachine(platform string, spec machineapi.ProviderSpec) (machine, error) {
+	if spec.Value == nil {
+		return machine{}, errors.New("machine has no provider spec")
+	}
+	switch p := spec.Value.Object.(type) {
+	case *machineapi.AWSMachineProviderConfig:
+		m := machine{instanceType: p.InstanceType, zone: p.Placement.AvailabilityZone}
+		for _, bd := range p.BlockDevices {
+			if bd.EBS == nil || bd.EBS.VolumeSize == nil {
+				continue
+			}
+			volumeType := "gp3"
+			if bd.EBS.VolumeType != nil {
+				volumeType = *bd.EBS.VolumeType
+			}
+			m.disks = append(m.disks, disk{diskType: volumeType, sizeGB: *bd.EBS.VolumeSize})
+		}
+		return m, nil
+	case *machineapi.AzureMachineProviderSpec:
+		m := machine{instanceType: p.VMSize, zone: p.Zone}
+		osDiskType := p.OSDisk.ManagedDisk.StorageAccountType
+		if osDiskType == "" {
+			osDiskType = "Premium_LRS"
+		}
+		m.disks = append(m.disks, disk{diskType: osDiskType, sizeGB: int64(p.OSDisk.DiskSizeGB)})
+		for _, d := range p.DataDisks {
+			m.disks = append(m.disks, disk{diskType: string(d.ManagedDisk.StorageAccountType), sizeGB: int64(d.DiskSizeGB)})
+		}
+		return m, nil
+	case *machineapi.GCPMachineProviderSpec:
+		m := machine{instanceType: p.MachineType, zone: p.Zone}
+		for _, d := range p.Disks {
+			m.disks = append(m.disks, disk{diskType: d.Type, sizeGB: d.SizeGB})
+		}
+		return m, nil
+	default:
+		return machine{}, errors.Errorf("unsupported provider spec %T for platform %s", spec.Value.Object, platform)
+	}
+}
+
+// networkResources returns the billable network resources created by the
+// installer for the cluster.
+func networkResources(config *types.InstallConfig, machines []machine) network {
+	var n network
+	external := config.Publish == types.ExternalPublishingStrategy
+	switch config.Platform.Name() {
+	case aws.Name:
+		// An internal load balancer for the API, and an external one
+		// when the API is published.
+		n.loadBalancers = 1
+		if external {
+			n.loadBalancers++
+		}
+		// The installer creates a NAT gateway, with an elastic IP, per
+		// availability zone when it creates the VPC.
+		if len(config.Platform.AWS.Subnets) == 0 {
+			zones := sets.New[string]()
+			for _, m := range machines {
+				zones.Insert(m.zone)
+			}
+			n.natGateways = int64(zones.Len())
+			n.publicIPs = int64(zones.Len())
+		}
+	case azure.Name:
+		n.loadBalancers = 1
+		if external {
+			n.publicIPs++
+		}
+		if outbound := config.Platform.Azure.OutboundType; outbound == "" || outbound == azure.LoadbalancerOutboundType {
+			n.publicIPs++
+		}
+		if n.publicIPs > 0 {
+			n.loadBalancers++
+		}
+		if config.Platform.Azure.OutboundType == azure.NatGatewayOutboundType {
+			n.natGateways = 1
+		}
+	case gcp.Name:
+		n.loadBalancers = 1
+		if external {
+			n.loadBalancers++
+			n.publicIPs++
+		}
+	}
+	return n
+}
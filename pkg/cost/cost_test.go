@@ -0,0 +1,71 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestEstimateCostAWS(t *testing.T) {
+	config := &types.InstallConfig{
+		Publish: types.ExternalPublishingStrategy,
+		Platform: types.Platform{
+			AWS: &awstypes.Platform{Region: "us-east-1"},
+		},
+	}
+	providerSpec := func(instanceType, zone string) machineapi.ProviderSpec {
+		return machineapi.ProviderSpec{Value: &runtime.RawExtension{Object: &machineapi.AWSMachineProviderConfig{
+			InstanceType: instanceType,
+			Placement:    machineapi.Placement{AvailabilityZone: zone},
+			BlockDevices: []machineapi.BlockDeviceMappingSpec{{
+				EBS: &machineapi.EBSBlockDeviceSpec{VolumeSize: ptr.To[int64](100), VolumeType: ptr.To("gp3")},
+			}},
+		}}}
+	}
+	var masters []machineapi.Machine
+	for _, zone := range []string{"us-east-1a", "us-east-1b", "us-east-1c"} {
+		masters = append(masters, machineapi.Machine{Spec: machineapi.MachineSpec{ProviderSpec: providerSpec("m6i.xlarge", zone)}})
+	}
+	workers := []machineapi.MachineSet{{Spec: machineapi.MachineSetSpec{
+		Replicas: ptr.To[int32](3),
+		Template: machineapi.MachineTemplateSpec{Spec: machineapi.MachineSpec{ProviderSpec: providerSpec("m5.unknown", "us-east-1a")}},
+	}}}
+
+	estimate, err := EstimateCost(config, masters, workers)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "us-east-1", estimate.Region)
+	assert.Equal(t, []string{"compute instance m5.unknown"}, estimate.Unknown)
+
+	expected := []Item{
+		{Resource: "control plane instance m6i.xlarge", Quantity: 3, MonthlyCost: 0.192 * HoursPerMonth * 3},
+		{Resource: "compute disk gp3 100 GB", Quantity: 3, MonthlyCost: 0.08 * 100 * 3},
+		{Resource: "control plane disk gp3 100 GB", Quantity: 3, MonthlyCost: 0.08 * 100 * 3},
+		{Resource: "load balancer", Quantity: 2, MonthlyCost: 0.0225 * HoursPerMonth * 2},
+		{Resource: "NAT gateway", Quantity: 3, MonthlyCost: 0.045 * HoursPerMonth * 3},
+		{Resource: "public IP address", Quantity: 3, MonthlyCost: 0.005 * HoursPerMonth * 3},
+	}
+	if assert.Len(t, estimate.Items, len(expected)) {
+		for i := range expected {
+			assert.Equal(t, expected[i].Resource, estimate.Items[i].Resource)
+			assert.Equal(t, expected[i].Quantity, estimate.Items[i].Quantity)
+			assert.InDelta(t, expected[i].MonthlyCost, estimate.Items[i].MonthlyCost, 0.001)
+		}
+	}
+}
+
+func TestEstimateCostUnsupportedPlatform(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{None: &none.Platform{}},
+	}
+	_, err := EstimateCost(config, nil, nil)
+	assert.EqualError(t, err, `cost estimation is not supported on platform "none", it is only supported on aws, azure and gcp`)
+}
@@ -0,0 +1,3 @@
+// Package cost estimates the monthly cost of the cloud resources of a cluster
+// from bundled price sheets.
+package cost
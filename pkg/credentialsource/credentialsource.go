@@ -0,0 +1,203 @@
+// Package credentialsource fetches platform credentials at runtime from
+// HashiCorp Vault or from an external command, so that they do not have to be
+// stored in local credentials files.
+package credentialsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SourceEnvVar selects the credential source, vault or command.
+	SourceEnvVar = "OPENSHIFT_INSTALL_CREDENTIALS_SOURCE"
+	// VaultPathEnvVar is the Vault path under which the credentials of each
+	// platform are stored, e.g. secret/data/openshift for the aws
+	// credentials in secret/data/openshift/aws. The Vault server and token
+	// are read from VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE.
+	VaultPathEnvVar = "OPENSHIFT_INSTALL_CREDENTIALS_VAULT_PATH"
+	// CommandEnvVar is the command printing the credentials of the
+	// platform given as its first argument as a JSON object.
+	CommandEnvVar = "OPENSHIFT_INSTALL_CREDENTIALS_COMMAND"
+
+	vaultSource   = "vault"
+	commandSource = "command"
+)
+
+// Source provides the credentials of the platforms.
+type Source interface {
+	// Name describes the source.
+	Name() string
+	// Fetch returns the credentials of the platform, by name.
+	Fetch(ctx context.Context, platform string) (map[string]string, error)
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]map[string]string{}
+)
+
+// Fetch returns the credentials of the platform from the source configured
+// in the environment. It returns nil when no source is configured. The
+// credentials of a platform are fetched once and kept in memory only.
+func Fetch(ctx context.Context, platform string) (map[string]string, error) {
+	source, err := FromEnvironment()
+	if err != nil || source == nil {
+		return nil, err
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if values, ok := cache[platform]; ok {
+		return values, nil
+	}
+	values, err := source.Fetch(ctx, platform)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the %s credentials from %s", platform, source.Name())
+	}
+	logrus.Infof("Credentials loaded from %s", source.Name())
+	cache[platform] = values
+	return values, nil
+}
+
+// FromEnvironment returns the source configured in the environment, or nil
+// when no source is configured.
+func FromEnvironment() (Source, error) {
+	switch source := os.Getenv(SourceEnvVar); source {
+	case "":
+		return nil, nil
+	case vaultSource:
+		v := &Vault{
+			Address:   strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/"),
+			Token:     os.Getenv("VAULT_TOKEN"),
+			Namespace: os.Getenv("VAULT_NAMESPACE"),
+			Path:      strings.Trim(os.Getenv(VaultPathEnvVar), "/"),
+		}
+		if v.Address == "" || v.Token == "" || v.Path == "" {
+			return nil, errors.Errorf("the vault credential source requires VAULT_ADDR, VAULT_TOKEN and %s", VaultPathEnvVar)
+		}
+		return v, nil
+	case commandSource:
+		c := &Command{Command: os.Getenv(CommandEnvVar)}
+		if c.Command == "" {
+			return nil, errors.Errorf("the command credential source requires %s", CommandEnvVar)
+		}
+		return c, nil
+	default:
+		return nil, errors.Errorf("unsupported credential source %q in %s, it must be %s or %s", source, SourceEnvVar, vaultSource, commandSource)
+	}
+}
+
+// Vault reads the credentials from a key/value secrets engine of HashiCorp
+// Vault.
+type Vault struct {
+	Address   string
+	Token     string
+	Namespace string
+	// Path is the path under which the secret of each platform is stored.
+	Path string
+}
+
+// Name describes the source.
+func (v *Vault) Name() string {
+	return fmt.Sprintf("Vault secret %s", v.Path)
+}
+
+// Fetch returns the credentials of the platform from the secret named after
+// the platform. Both versions of the key/value secrets engine are supported.
+func (v *Vault) Fetch(ctx context.Context, platform string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/v1/%s/%s", v.Address, v.Path, platform)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s reading %s/%s", resp.Status, v.Path, platform)
+	}
+
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the secret")
+	}
+	// Version 2 of the engine nests the values, next to their metadata.
+	if data, ok := secret.Data["data"]; ok {
+		if _, ok := secret.Data["metadata"]; ok {
+			return parseValues(data)
+		}
+	}
+	values := make(map[string]string, len(secret.Data))
+	for k, raw := range secret.Data {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, errors.Wrapf(err, "the value of %s is not a string", k)
+		}
+		values[k] = value
+	}
+	return values, nil
+}
+
+// Command runs a command printing the credentials.
+type Command struct {
+	// Command is run by the shell, with the platform as its first
+	// argument, and must print the credentials as a JSON object of
+	// strings.
+	Command string
+}
+
+// Name describes the source.
+func (c *Command) Name() string {
+	return "the credentials command"
+}
+
+// Fetch runs the command and returns the credentials it prints.
+func (c *Command) Fetch(ctx context.Context, platform string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", c.Command, "sh", platform) //nolint:gosec // the command is provided by the user
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return parseValues(stdout.Bytes())
+}
+
+func parseValues(data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, errors.Wrap(err, "the credentials must be a JSON object of strings")
+	}
+	return values, nil
+}
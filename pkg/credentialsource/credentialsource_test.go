@@ -0,0 +1,86 @@
+package credentialsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultFetch(t *testing.T) {
+	cases := []struct {
+		name     string
+		response string
+		expected map[string]string
+	}{
+		{
+			name:     "kv version 1",
+			response: `{"data":{"aws_access_key_id":"id","aws_secret_access_key":"secret"}}`,
+			expected: map[string]string{"aws_access_key_id": "id", "aws_secret_access_key": "secret"},
+		},
+		{
+			name:     "kv version 2",
+			response: `{"data":{"data":{"aws_access_key_id":"id","aws_secret_access_key":"secret"},"metadata":{"version":3}}}`,
+			expected: map[string]string{"aws_access_key_id": "id", "aws_secret_access_key": "secret"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/secret/data/openshift/aws" || r.Header.Get("X-Vault-Token") != "token" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			v := &Vault{Address: server.URL, Token: "token", Path: "secret/data/openshift"}
+			values, err := v.Fetch(context.Background(), "aws")
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, values)
+			}
+		})
+	}
+}
+
+func TestVaultFetchForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	v := &Vault{Address: server.URL, Token: "token", Path: "secret"}
+	_, err := v.Fetch(context.Background(), "azure")
+	assert.EqualError(t, err, "unexpected status 403 Forbidden reading secret/azure")
+}
+
+func TestCommandFetch(t *testing.T) {
+	c := &Command{Command: `printf '{"platform":"%s"}' "$1"`}
+	values, err := c.Fetch(context.Background(), "vsphere")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"platform": "vsphere"}, values)
+	}
+
+	c = &Command{Command: "echo not json"}
+	_, err = c.Fetch(context.Background(), "vsphere")
+	assert.Error(t, err)
+}
+
+func TestFromEnvironment(t *testing.T) {
+	t.Setenv(SourceEnvVar, "")
+	source, err := FromEnvironment()
+	assert.NoError(t, err)
+	assert.Nil(t, source)
+
+	t.Setenv(SourceEnvVar, "vault")
+	t.Setenv("VAULT_ADDR", "")
+	_, err = FromEnvironment()
+	assert.EqualError(t, err, "the vault credential source requires VAULT_ADDR, VAULT_TOKEN and OPENSHIFT_INSTALL_CREDENTIALS_VAULT_PATH")
+
+	t.Setenv(SourceEnvVar, "file")
+	_, err = FromEnvironment()
+	assert.EqualError(t, err, `unsupported credential source "file" in OPENSHIFT_INSTALL_CREDENTIALS_SOURCE, it must be vault or command`)
+}
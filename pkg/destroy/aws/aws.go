@@ -73,6 +73,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 	for _, filter := range metadata.ClusterPlatformMetadata.AWS.Identifier {
 		filters = append(filters, filter)
 	}
+	filters = append(filters, operatorFilters(metadata.InfraID)...)
 	region := metadata.ClusterPlatformMetadata.AWS.Region
 	session, err := awssession.GetSessionWithOptions(
 		awssession.WithRegion(region),
@@ -189,6 +190,17 @@ func (o *ClusterUninstaller) RunWithContext(ctx context.Context) ([]string, erro
 		return resourcesToDelete.UnsortedList(), err
 	}
 
+	// Report the resources created by in-cluster operators, and delete the records external-dns created for the
+	// service load balancers while the load balancers can still be looked up.
+	operatorResources, err := findOperatorResources(ctx, tagClients, o.Filters)
+	if err != nil {
+		o.Logger.WithError(err).Info("error while finding resources created by in-cluster operators")
+	}
+	reportOperatorResources(o.Logger, operatorResources)
+	if err := o.deleteExternalDNSRecords(ctx, awsSession, operatorResources[serviceLoadBalancerKind]); err != nil {
+		return resourcesToDelete.UnsortedList(), errors.Wrap(err, "failed to delete the external-dns records")
+	}
+
 	// Delete the rest of the resources.
 	err = wait.PollImmediateUntil(
 		time.Second*10,
@@ -32,7 +32,7 @@ func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, a
 		subtype := segments[0]
 		id = segments[1]
 		switch subtype {
-		case "net":
+		case "app", "net":
 			return deleteElasticLoadBalancerV2(ctx, elbv2.New(session), arn, logger)
 		default:
 			return errors.Errorf("unrecognized elastic load balancing resource subtype %s", subtype)
@@ -41,6 +41,8 @@ func deleteElasticLoadBalancing(ctx context.Context, session *session.Session, a
 		return deleteElasticLoadBalancerTargetGroup(ctx, elbv2.New(session), arn, logger)
 	case "listener":
 		return deleteElasticLoadBalancerListener(ctx, elbv2.New(session), arn, logger)
+	case "listener-rule":
+		return deleteElasticLoadBalancerListenerRule(ctx, elbv2.New(session), arn, logger)
 	default:
 		return errors.Errorf("unrecognized elastic load balancing resource type %s", resourceType)
 	}
@@ -133,6 +135,24 @@ func deleteElasticLoadBalancerListener(ctx context.Context, client *elbv2.ELBV2,
 	return nil
 }
 
+// deleteElasticLoadBalancerListenerRule deletes a rule of an application load
+// balancer listener, as created by the AWS Load Balancer Controller.
+func deleteElasticLoadBalancerListenerRule(ctx context.Context, client *elbv2.ELBV2, arn arn.ARN, logger logrus.FieldLogger) error {
+	_, err := client.DeleteRuleWithContext(ctx, &elbv2.DeleteRuleInput{
+		RuleArn: aws.String(arn.String()),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == elbv2.ErrCodeRuleNotFoundException {
+			logger.Info("Not found or already deleted")
+			return nil
+		}
+		return err
+	}
+
+	logger.Info("Deleted")
+	return nil
+}
+
 func deleteElasticLoadBalancerV2(ctx context.Context, client *elbv2.ELBV2, arn arn.ARN, logger logrus.FieldLogger) error {
 	_, err := client.DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: aws.String(arn.String()),
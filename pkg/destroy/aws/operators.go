@@ -0,0 +1,293 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// The kinds of the resources created by in-cluster operators, as reported
+// before they are deleted.
+const (
+	serviceLoadBalancerKind = "service load balancers"
+	persistentVolumeKind    = "persistent volumes"
+	volumeSnapshotKind      = "volume snapshots"
+	imageRegistryBucketKind = "image registry buckets"
+)
+
+// operatorFilters returns the filters matching the resources created by
+// in-cluster operators without the cluster tags of the installer. The AWS
+// Load Balancer Operator runs its controller with the infrastructure ID as
+// cluster name, which it tags the load balancers, target groups and security
+// groups with.
+func operatorFilters(infraID string) []Filter {
+	return []Filter{
+		{"elbv2.k8s.aws/cluster": infraID},
+	}
+}
+
+// operatorResourceKind returns the kind of the resource if it was created by
+// an in-cluster operator, or "" if it was created by the installer.
+func operatorResourceKind(parsed arn.ARN, tags map[string]string) string {
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := tags[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	resourceType, _, _ := strings.Cut(parsed.Resource, "/")
+	switch parsed.Service {
+	case "elasticloadbalancing":
+		if resourceType == "loadbalancer" && has("kubernetes.io/service-name", "elbv2.k8s.aws/cluster") {
+			return serviceLoadBalancerKind
+		}
+	case "ec2":
+		switch resourceType {
+		case "volume":
+			if has("kubernetes.io/created-for/pvc/name", "ebs.csi.aws.com/cluster") {
+				return persistentVolumeKind
+			}
+		case "snapshot":
+			if has("CSIVolumeSnapshotName", "ebs.csi.aws.com/cluster") {
+				return volumeSnapshotKind
+			}
+		}
+	case "elasticfilesystem":
+		if resourceType == "access-point" && has("efs.csi.aws.com/cluster") {
+			return persistentVolumeKind
+		}
+	case "s3":
+		if strings.Contains(parsed.Resource, "image-registry") {
+			return imageRegistryBucketKind
+		}
+	}
+	return ""
+}
+
+// findOperatorResources returns the ARNs, by kind, of the resources matching
+// the filters that were created by in-cluster operators.
+func findOperatorResources(
+	ctx context.Context,
+	tagClients []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI,
+	filters []Filter,
+) (map[string][]string, error) {
+	found := sets.New[string]()
+	resources := map[string][]string{}
+	for _, tagClient := range tagClients {
+		for _, filter := range filters {
+			tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(filter))
+			for key, value := range filter {
+				tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
+					Key:    aws.String(key),
+					Values: []*string{aws.String(value)},
+				})
+			}
+			err := tagClient.GetResourcesPagesWithContext(
+				ctx,
+				&resourcegroupstaggingapi.GetResourcesInput{TagFilters: tagFilters},
+				func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+					for _, resource := range results.ResourceTagMappingList {
+						arnString := aws.StringValue(resource.ResourceARN)
+						parsed, err := arn.Parse(arnString)
+						if err != nil || found.Has(arnString) {
+							continue
+						}
+						tags := make(map[string]string, len(resource.Tags))
+						for _, tag := range resource.Tags {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+						if kind := operatorResourceKind(parsed, tags); kind != "" {
+							found.Insert(arnString)
+							resources[kind] = append(resources[kind], arnString)
+						}
+					}
+					return !lastPage
+				},
+			)
+			if err != nil {
+				return resources, errors.Wrap(err, "get tagged resources")
+			}
+		}
+	}
+	return resources, nil
+}
+
+// reportOperatorResources logs the resources created by in-cluster operators,
+// which are deleted along with the resources created by the installer.
+func reportOperatorResources(logger logrus.FieldLogger, resources map[string][]string) {
+	kinds := make([]string, 0, len(resources))
+	for kind := range resources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		logger.Infof("Found %d %s created by in-cluster operators", len(resources[kind]), kind)
+		for _, arnString := range resources[kind] {
+			logger.WithField("arn", arnString).Debug("Created by an in-cluster operator")
+		}
+	}
+}
+
+// deleteExternalDNSRecords deletes the records of the public zone of the
+// cluster domain pointing at the load balancers, with their ownership TXT
+// records. external-dns creates them for the services of the cluster, and
+// they are not tagged, nor mirrored in the private zone of the cluster. They
+// are deleted before the load balancers, whose DNS names cannot be looked up
+// afterwards.
+func (o *ClusterUninstaller) deleteExternalDNSRecords(ctx context.Context, awsSession *session.Session, loadBalancers []string) error {
+	if len(loadBalancers) == 0 || o.ClusterDomain == "" {
+		return nil
+	}
+	dnsNames, err := loadBalancerDNSNames(ctx, awsSession, loadBalancers)
+	if err != nil {
+		return err
+	}
+
+	client := route53.New(awsSession)
+	zoneID, err := findAncestorPublicRoute53(ctx, client, o.ClusterDomain+".", o.Logger)
+	if err != nil {
+		return errors.Wrap(err, "find the public zone")
+	}
+	if zoneID == "" {
+		o.Logger.Debug("public zone not found, no external-dns records to delete")
+		return nil
+	}
+
+	var recordSets []*route53.ResourceRecordSet
+	err = client.ListResourceRecordSetsPagesWithContext(
+		ctx,
+		&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)},
+		func(results *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+			recordSets = append(recordSets, results.ResourceRecordSets...)
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "list the records of public zone %s", zoneID)
+	}
+
+	records := externalDNSRecords(recordSets, dnsNames)
+	if len(records) > 0 {
+		o.Logger.Infof("Found %d external-dns records pointing at the service load balancers", len(records))
+	}
+	logger := o.Logger.WithField("public zone", zoneID)
+	for _, recordSet := range records {
+		if err := deleteRoute53RecordSet(ctx, client, zoneID, recordSet, logger); err != nil {
+			return errors.Wrapf(err, "deleting record set %s %s from public zone %s", aws.StringValue(recordSet.Type), aws.StringValue(recordSet.Name), zoneID)
+		}
+	}
+	return nil
+}
+
+// loadBalancerDNSNames returns the normalized DNS names of the classic and
+// v2 load balancers.
+func loadBalancerDNSNames(ctx context.Context, awsSession *session.Session, loadBalancers []string) (sets.Set[string], error) {
+	var classicNames, v2ARNs []*string
+	for _, arnString := range loadBalancers {
+		parsed, err := arn.Parse(arnString)
+		if err != nil {
+			continue
+		}
+		_, id, err := splitSlash("resource", parsed.Resource)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(id, "/") {
+			v2ARNs = append(v2ARNs, aws.String(arnString))
+		} else {
+			classicNames = append(classicNames, aws.String(id))
+		}
+	}
+
+	dnsNames := sets.New[string]()
+	if len(classicNames) > 0 {
+		response, err := elb.New(awsSession).DescribeLoadBalancersWithContext(ctx, &elb.DescribeLoadBalancersInput{LoadBalancerNames: classicNames})
+		if err != nil {
+			return nil, errors.Wrap(err, "describe the classic load balancers")
+		}
+		for _, lb := range response.LoadBalancerDescriptions {
+			dnsNames.Insert(normalizeDNSName(aws.StringValue(lb.DNSName)))
+		}
+	}
+	if len(v2ARNs) > 0 {
+		response, err := elbv2.New(awsSession).DescribeLoadBalancersWithContext(ctx, &elbv2.DescribeLoadBalancersInput{LoadBalancerArns: v2ARNs})
+		if err != nil {
+			return nil, errors.Wrap(err, "describe the load balancers")
+		}
+		for _, lb := range response.LoadBalancers {
+			dnsNames.Insert(normalizeDNSName(aws.StringValue(lb.DNSName)))
+		}
+	}
+	return dnsNames, nil
+}
+
+// normalizeDNSName lower cases the DNS name, and trims its trailing dot and
+// the dualstack prefix of alias targets.
+func normalizeDNSName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	return strings.TrimPrefix(name, "dualstack.")
+}
+
+// externalDNSRecords returns the A, AAAA and CNAME records pointing at one of
+// the DNS names, and the TXT records with which external-dns records their
+// ownership. The TXT records are named as the records, or prefixed with their
+// type by newer external-dns versions.
+func externalDNSRecords(recordSets []*route53.ResourceRecordSet, dnsNames sets.Set[string]) []*route53.ResourceRecordSet {
+	var records []*route53.ResourceRecordSet
+	names := sets.New[string]()
+	for _, recordSet := range recordSets {
+		recordType := aws.StringValue(recordSet.Type)
+		if recordType != "A" && recordType != "AAAA" && recordType != "CNAME" {
+			continue
+		}
+		var targets []string
+		if recordSet.AliasTarget != nil {
+			targets = append(targets, aws.StringValue(recordSet.AliasTarget.DNSName))
+		}
+		for _, record := range recordSet.ResourceRecords {
+			targets = append(targets, aws.StringValue(record.Value))
+		}
+		for _, target := range targets {
+			if dnsNames.Has(normalizeDNSName(target)) {
+				records = append(records, recordSet)
+				names.Insert(strings.ToLower(aws.StringValue(recordSet.Name)))
+				break
+			}
+		}
+	}
+
+	for _, recordSet := range recordSets {
+		if aws.StringValue(recordSet.Type) != "TXT" {
+			continue
+		}
+		name := strings.ToLower(aws.StringValue(recordSet.Name))
+		owned := names.Has(name)
+		for _, prefix := range []string{"a-", "aaaa-", "cname-"} {
+			owned = owned || (strings.HasPrefix(name, prefix) && names.Has(strings.TrimPrefix(name, prefix)))
+		}
+		if !owned {
+			continue
+		}
+		for _, record := range recordSet.ResourceRecords {
+			if strings.Contains(aws.StringValue(record.Value), "heritage=external-dns") {
+				records = append(records, recordSet)
+				break
+			}
+		}
+	}
+	return records
+}
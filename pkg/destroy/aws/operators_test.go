@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestOperatorResourceKind(t *testing.T) {
+	cases := []struct {
+		name     string
+		arn      string
+		tags     map[string]string
+		expected string
+	}{
+		{
+			name:     "service classic load balancer",
+			arn:      "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/a1b2c3",
+			tags:     map[string]string{"kubernetes.io/cluster/test-abcde": "owned", "kubernetes.io/service-name": "openshift-ingress/router-default"},
+			expected: serviceLoadBalancerKind,
+		},
+		{
+			name:     "application load balancer",
+			arn:      "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/k8s-echo/0123456789abcdef",
+			tags:     map[string]string{"elbv2.k8s.aws/cluster": "test-abcde"},
+			expected: serviceLoadBalancerKind,
+		},
+		{
+			name: "api load balancer",
+			arn:  "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-abcde-int/0123456789abcdef",
+			tags: map[string]string{"kubernetes.io/cluster/test-abcde": "owned"},
+		},
+		{
+			name:     "persistent volume",
+			arn:      "arn:aws:ec2:us-east-1:123456789012:volume/vol-0123456789abcdef",
+			tags:     map[string]string{"kubernetes.io/cluster/test-abcde": "owned", "kubernetes.io/created-for/pvc/name": "data"},
+			expected: persistentVolumeKind,
+		},
+		{
+			name: "root volume",
+			arn:  "arn:aws:ec2:us-east-1:123456789012:volume/vol-0fedcba9876543210",
+			tags: map[string]string{"kubernetes.io/cluster/test-abcde": "owned"},
+		},
+		{
+			name:     "volume snapshot",
+			arn:      "arn:aws:ec2:us-east-1::snapshot/snap-0123456789abcdef",
+			tags:     map[string]string{"CSIVolumeSnapshotName": "snapshot-1"},
+			expected: volumeSnapshotKind,
+		},
+		{
+			name:     "efs access point",
+			arn:      "arn:aws:elasticfilesystem:us-east-1:123456789012:access-point/fsap-0123456789abcdef",
+			tags:     map[string]string{"efs.csi.aws.com/cluster": "true"},
+			expected: persistentVolumeKind,
+		},
+		{
+			name:     "image registry bucket",
+			arn:      "arn:aws:s3:::test-abcde-image-registry-us-east-1-abcdefghijkl",
+			tags:     map[string]string{"kubernetes.io/cluster/test-abcde": "owned"},
+			expected: imageRegistryBucketKind,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := arn.Parse(tc.arn)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, operatorResourceKind(parsed, tc.tags))
+			}
+		})
+	}
+}
+
+func TestExternalDNSRecords(t *testing.T) {
+	recordSet := func(name, recordType, alias string, values ...string) *route53.ResourceRecordSet {
+		r := &route53.ResourceRecordSet{Name: aws.String(name), Type: aws.String(recordType)}
+		if alias != "" {
+			r.AliasTarget = &route53.AliasTarget{DNSName: aws.String(alias)}
+		}
+		for _, value := range values {
+			r.ResourceRecords = append(r.ResourceRecords, &route53.ResourceRecord{Value: aws.String(value)})
+		}
+		return r
+	}
+	recordSets := []*route53.ResourceRecordSet{
+		recordSet("example.com.", "SOA", "", "ns-1.awsdns-1.org. awsdns-hostmaster.amazon.com. 1 7200 900 1209600 86400"),
+		recordSet("echo.example.com.", "A", "dualstack.k8s-echo-0123456789.us-east-1.elb.amazonaws.com."),
+		recordSet("a-echo.example.com.", "TXT", "", `"heritage=external-dns,external-dns/owner=test-abcde"`),
+		recordSet("web.example.com.", "CNAME", "", "a1b2c3-123.us-east-1.elb.amazonaws.com"),
+		recordSet("web.example.com.", "TXT", "", `"heritage=external-dns,external-dns/owner=test-abcde"`),
+		recordSet("other.example.com.", "A", "dualstack.other-lb.us-east-1.elb.amazonaws.com."),
+		recordSet("a-other.example.com.", "TXT", "", `"heritage=external-dns,external-dns/owner=other"`),
+		recordSet("echo.example.com.", "TXT", "", `"v=spf1 -all"`),
+	}
+	dnsNames := sets.New("k8s-echo-0123456789.us-east-1.elb.amazonaws.com", "a1b2c3-123.us-east-1.elb.amazonaws.com")
+
+	var records []string
+	for _, r := range externalDNSRecords(recordSets, dnsNames) {
+		records = append(records, aws.StringValue(r.Type)+" "+aws.StringValue(r.Name))
+	}
+	assert.Equal(t, []string{
+		"A echo.example.com.",
+		"CNAME web.example.com.",
+		"TXT a-echo.example.com.",
+		"TXT web.example.com.",
+	}, records)
+}
@@ -47,6 +47,10 @@ type ClusterUninstaller struct {
 	ResourceGroupName           string
 	BaseDomainResourceGroupName string
 	NetworkResourceGroupName    string
+	// PreexistingResourceGroup is true when the cluster was installed in a
+	// resource group provided by the user. Only the resources created for
+	// the cluster are deleted from it, instead of the whole group.
+	PreexistingResourceGroup bool
 
 	Logger logrus.FieldLogger
 
@@ -58,6 +62,11 @@ type ClusterUninstaller struct {
 	msgraphClient           *msgraphsdk.GraphServiceClient
 	resourceGraphClient     *armresourcegraph.Client
 	tagsClient              *armresources.TagsClient
+	resourcesClient         *armresources.Client
+	providersClient         *armresources.ProvidersClient
+	// providers caches the resource providers, by lower case namespace, to
+	// look up the API versions of the resources to delete.
+	providers map[string]*armresources.Provider
 }
 
 func (o *ClusterUninstaller) configureClients() error {
@@ -113,6 +122,19 @@ func (o *ClusterUninstaller) configureClients() error {
 	}
 	o.tagsClient = tagsClient
 
+	resourcesClient, err := armresources.NewClient(o.Session.Credentials.SubscriptionID, o.Session.TokenCreds, clientOpts)
+	if err != nil {
+		return err
+	}
+	o.resourcesClient = resourcesClient
+
+	providersClient, err := armresources.NewProvidersClient(o.Session.Credentials.SubscriptionID, o.Session.TokenCreds, clientOpts)
+	if err != nil {
+		return err
+	}
+	o.providersClient = providersClient
+	o.providers = map[string]*armresources.Provider{}
+
 	return nil
 }
 
@@ -133,6 +155,7 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		ResourceGroupName:           metadata.Azure.ResourceGroupName,
 		Logger:                      logger,
 		BaseDomainResourceGroupName: metadata.Azure.BaseDomainResourceGroupName,
+		PreexistingResourceGroup:    metadata.Azure.ResourceGroupName != "",
 		CloudName:                   cloudName,
 	}, nil
 }
@@ -216,8 +239,13 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 		1*time.Second,
 		false,
 		func(ctx context.Context) (bool, error) {
-			o.Logger.Debugf("deleting resource group")
-			err = deleteResourceGroup(ctx, o.resourceGroupsClient, o.Logger, o.ResourceGroupName)
+			if o.PreexistingResourceGroup {
+				o.Logger.Debugf("deleting the cluster resources of resource group %s", o.ResourceGroupName)
+				err = o.deleteOwnedResources(ctx)
+			} else {
+				o.Logger.Debugf("deleting resource group")
+				err = deleteResourceGroup(ctx, o.resourceGroupsClient, o.Logger, o.ResourceGroupName)
+			}
 			if err != nil {
 				o.Logger.Debug(err)
 				if isAuthError(err) {
@@ -672,6 +700,11 @@ func isAuthError(err error) bool {
 		}
 	}
 
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) && (respErr.StatusCode == http.StatusUnauthorized || respErr.StatusCode == http.StatusForbidden) {
+		return true
+	}
+
 	// https://github.com/Azure/azure-sdk-for-go/issues/16736
 	// https://github.com/Azure/azure-sdk-for-go/blob/sdk/azidentity/v1.1.0/sdk/azidentity/errors.go#L36
 	var authErr *azidentity.AuthenticationFailedError
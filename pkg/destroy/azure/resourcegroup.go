@@ -0,0 +1,247 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	azcoreto "github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/installer/pkg/types/azure"
+)
+
+// deletionOrder lists the resource types, in lower case, that must be deleted
+// before the resources of the types following them, because those depend on
+// them. The resources of the other types are deleted last.
+var deletionOrder = []string{
+	"microsoft.compute/virtualmachines",
+	"microsoft.compute/virtualmachinescalesets",
+	"microsoft.network/privatednszones/virtualnetworklinks",
+	"microsoft.network/privateendpoints",
+	"microsoft.network/networkinterfaces",
+	"microsoft.network/loadbalancers",
+	"microsoft.network/publicipaddresses",
+	"microsoft.compute/disks",
+	"microsoft.compute/snapshots",
+	"microsoft.compute/galleries/images/versions",
+	"microsoft.compute/galleries/images",
+	"microsoft.compute/images",
+}
+
+// lastDeleted lists the resource types, in lower case, that the resources of
+// all the other types may depend on.
+var lastDeleted = []string{
+	"microsoft.compute/galleries",
+	"microsoft.network/privatednszones",
+	"microsoft.network/virtualnetworks",
+	"microsoft.network/networksecuritygroups",
+	"microsoft.managedidentity/userassignedidentities",
+}
+
+// deletionRank returns the rank of the resource type in the deletion order.
+func deletionRank(resourceType string) int {
+	resourceType = strings.ToLower(resourceType)
+	for i, t := range deletionOrder {
+		if t == resourceType {
+			return i
+		}
+	}
+	for i, t := range lastDeleted {
+		if t == resourceType {
+			return len(deletionOrder) + 1 + i
+		}
+	}
+	return len(deletionOrder)
+}
+
+// isOwnedResource returns true if the resource was created for the cluster:
+// either it is tagged as owned by the cluster, or its name starts with the
+// infrastructure ID.
+func isOwnedResource(resource *armresources.GenericResourceExpanded, infraID string) bool {
+	if value, ok := resource.Tags[fmt.Sprintf("kubernetes.io_cluster.%s", infraID)]; ok && value != nil && *value == "owned" {
+		return true
+	}
+	return strings.HasPrefix(to.String(resource.Name), infraID+"-")
+}
+
+// apiVersion returns the latest stable API version of the resource type of
+// the provider, or its latest preview version if it has no stable one.
+func apiVersion(provider *armresources.Provider, resourceType string) (string, error) {
+	for _, t := range provider.ResourceTypes {
+		if !strings.EqualFold(to.String(t.ResourceType), resourceType) || len(t.APIVersions) == 0 {
+			continue
+		}
+		versions := make([]string, 0, len(t.APIVersions))
+		for _, v := range t.APIVersions {
+			versions = append(versions, to.String(v))
+		}
+		// The versions are dates, so that sorting them sorts them by age.
+		sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+		for _, v := range versions {
+			if !strings.Contains(v, "preview") {
+				return v, nil
+			}
+		}
+		return versions[0], nil
+	}
+	return "", fmt.Errorf("no API version found for resource type %s/%s", to.String(provider.Namespace), resourceType)
+}
+
+// deleteOwnedResources deletes the resources of the pre-existing resource
+// group that were created for the cluster, keeping the group and the other
+// resources in it. The resources are deleted in the deletion order, and the
+// deletion stops at the first rank with a resource that could not be deleted
+// so that it is retried once the resources depending on it are gone.
+func (o *ClusterUninstaller) deleteOwnedResources(ctx context.Context) error {
+	var owned, skipped []*armresources.GenericResourceExpanded
+	pager := o.resourcesClient.NewListByResourceGroupPager(o.ResourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			if isResponseNotFoundError(err) {
+				o.Logger.WithField("resource group", o.ResourceGroupName).Debug("already deleted")
+				return nil
+			}
+			return fmt.Errorf("failed to list the resources of resource group %s: %w", o.ResourceGroupName, err)
+		}
+		for _, resource := range page.Value {
+			if isOwnedResource(resource, o.InfraID) {
+				owned = append(owned, resource)
+			} else {
+				skipped = append(skipped, resource)
+			}
+		}
+	}
+
+	if len(owned) > 0 {
+		sort.SliceStable(owned, func(i, j int) bool {
+			return deletionRank(to.String(owned[i].Type)) < deletionRank(to.String(owned[j].Type))
+		})
+		var errs []error
+		rank := deletionRank(to.String(owned[0].Type))
+		for _, resource := range owned {
+			if r := deletionRank(to.String(resource.Type)); r != rank {
+				if len(errs) > 0 {
+					break
+				}
+				rank = r
+			}
+			if err := o.deleteResource(ctx, resource); err != nil {
+				if isAuthError(err) {
+					return err
+				}
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return utilerrors.NewAggregate(errs)
+		}
+	}
+
+	o.reportSkippedResources(skipped)
+	return o.untagResourceGroup(ctx)
+}
+
+// deleteResource deletes the resource, with the API version of its type.
+func (o *ClusterUninstaller) deleteResource(ctx context.Context, resource *armresources.GenericResourceExpanded) error {
+	id, resourceType := to.String(resource.ID), to.String(resource.Type)
+	logger := o.Logger.WithFields(logrus.Fields{
+		"resource": to.String(resource.Name),
+		"type":     resourceType,
+	})
+
+	namespace, typeName, ok := strings.Cut(resourceType, "/")
+	if !ok {
+		return fmt.Errorf("invalid resource type %s", resourceType)
+	}
+	key := strings.ToLower(namespace)
+	provider, ok := o.providers[key]
+	if !ok {
+		resp, err := o.providersClient.Get(ctx, namespace, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get resource provider %s: %w", namespace, err)
+		}
+		provider = &resp.Provider
+		o.providers[key] = provider
+	}
+	version, err := apiVersion(provider, typeName)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+	poller, err := o.resourcesClient.BeginDeleteByID(ctx, id, version, nil)
+	if err == nil {
+		_, err = poller.PollUntilDone(ctx, nil)
+	}
+	if err != nil {
+		if isResponseNotFoundError(err) {
+			logger.Debug("already deleted")
+			return nil
+		}
+		return fmt.Errorf("failed to delete %s: %w", id, err)
+	}
+	logger.Info("deleted")
+	return nil
+}
+
+// reportSkippedResources logs the resources of the pre-existing resource
+// group that were not created for the cluster and were left in it.
+func (o *ClusterUninstaller) reportSkippedResources(skipped []*armresources.GenericResourceExpanded) {
+	if len(skipped) == 0 {
+		return
+	}
+	o.Logger.Infof("Kept %d resources of resource group %s that were not created for the cluster:", len(skipped), o.ResourceGroupName)
+	for _, resource := range skipped {
+		o.Logger.Infof("  %s %s", to.String(resource.Type), to.String(resource.Name))
+	}
+}
+
+// untagResourceGroup removes the tags added by the installer from the
+// pre-existing resource group.
+func (o *ClusterUninstaller) untagResourceGroup(ctx context.Context) error {
+	scope := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", o.Session.Credentials.SubscriptionID, o.ResourceGroupName)
+	tags := map[string]*string{fmt.Sprintf("kubernetes.io_cluster.%s", o.InfraID): to.StringPtr("owned")}
+	for _, key := range []string{azure.TagMetadataRegion, azure.TagMetadataBaseDomainRG, azure.TagMetadataNetworkRG} {
+		tags[key] = nil
+	}
+	resp, err := o.tagsClient.GetAtScope(ctx, scope, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the tags of resource group %s: %w", o.ResourceGroupName, err)
+	}
+	toDelete := map[string]*string{}
+	if resp.Properties != nil {
+		for key, value := range resp.Properties.Tags {
+			if expected, ok := tags[key]; ok && (expected == nil || to.String(value) == *expected) {
+				toDelete[key] = value
+			}
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+	_, err = o.tagsClient.UpdateAtScope(ctx, scope, armresources.TagsPatchResource{
+		Operation:  azcoreto.Ptr(armresources.TagsPatchOperationDelete),
+		Properties: &armresources.Tags{Tags: toDelete},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove the cluster tags from resource group %s: %w", o.ResourceGroupName, err)
+	}
+	o.Logger.WithField("resource group", o.ResourceGroupName).Info("removed cluster tags")
+	return nil
+}
+
+func isResponseNotFoundError(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
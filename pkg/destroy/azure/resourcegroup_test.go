@@ -0,0 +1,99 @@
+package azure
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOwnedResource(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource *armresources.GenericResourceExpanded
+		expected bool
+	}{
+		{
+			name: "tagged as owned",
+			resource: &armresources.GenericResourceExpanded{
+				Name: to.StringPtr("internal-lb"),
+				Tags: map[string]*string{"kubernetes.io_cluster.test-abcde": to.StringPtr("owned")},
+			},
+			expected: true,
+		},
+		{
+			name:     "named after the cluster",
+			resource: &armresources.GenericResourceExpanded{Name: to.StringPtr("test-abcde-master-0_OSDisk")},
+			expected: true,
+		},
+		{
+			name: "tagged as shared",
+			resource: &armresources.GenericResourceExpanded{
+				Name: to.StringPtr("vnet"),
+				Tags: map[string]*string{"kubernetes.io_cluster.test-abcde": to.StringPtr("shared")},
+			},
+			expected: false,
+		},
+		{
+			name:     "other cluster",
+			resource: &armresources.GenericResourceExpanded{Name: to.StringPtr("test-abcdef-master-0")},
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isOwnedResource(tc.resource, "test-abcde"))
+		})
+	}
+}
+
+func TestDeletionRank(t *testing.T) {
+	types := []string{
+		"Microsoft.Network/virtualNetworks",
+		"Microsoft.Storage/storageAccounts",
+		"Microsoft.Network/networkInterfaces",
+		"Microsoft.Compute/virtualMachines",
+		"Microsoft.Network/privateDnsZones",
+		"Microsoft.Network/privateDnsZones/virtualNetworkLinks",
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		return deletionRank(types[i]) < deletionRank(types[j])
+	})
+	assert.Equal(t, []string{
+		"Microsoft.Compute/virtualMachines",
+		"Microsoft.Network/privateDnsZones/virtualNetworkLinks",
+		"Microsoft.Network/networkInterfaces",
+		"Microsoft.Storage/storageAccounts",
+		"Microsoft.Network/privateDnsZones",
+		"Microsoft.Network/virtualNetworks",
+	}, types)
+}
+
+func TestAPIVersion(t *testing.T) {
+	provider := &armresources.Provider{
+		Namespace: to.StringPtr("Microsoft.Network"),
+		ResourceTypes: []*armresources.ProviderResourceType{
+			{
+				ResourceType: to.StringPtr("loadBalancers"),
+				APIVersions:  []*string{to.StringPtr("2023-06-01"), to.StringPtr("2024-01-01-preview"), to.StringPtr("2023-09-01")},
+			},
+			{
+				ResourceType: to.StringPtr("frontDoors"),
+				APIVersions:  []*string{to.StringPtr("2019-01-01-preview")},
+			},
+		},
+	}
+
+	version, err := apiVersion(provider, "loadbalancers")
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-09-01", version)
+
+	version, err = apiVersion(provider, "frontDoors")
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-01-01-preview", version)
+
+	_, err = apiVersion(provider, "publicIPAddresses")
+	assert.EqualError(t, err, "no API version found for resource type Microsoft.Network/publicIPAddresses")
+}
@@ -10,15 +10,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
-	"github.com/openshift/api/features"
 	"github.com/openshift/installer/pkg/asset/cluster/metadata"
 	osp "github.com/openshift/installer/pkg/destroy/openstack"
 	"github.com/openshift/installer/pkg/infrastructure/openstack/preprovision"
 	infra "github.com/openshift/installer/pkg/infrastructure/platform"
 	ibmcloudtfvars "github.com/openshift/installer/pkg/tfvars/ibmcloud"
-	"github.com/openshift/installer/pkg/types"
-	typesazure "github.com/openshift/installer/pkg/types/azure"
-	"github.com/openshift/installer/pkg/types/featuregates"
 	ibmcloudtypes "github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/openstack"
 )
@@ -46,11 +42,6 @@ func Destroy(ctx context.Context, dir string) (err error) {
 		}
 	}
 
-	// Azure Stack uses the Azure platform but has its own Terraform configuration.
-	if platform == typesazure.Name && metadata.Azure.CloudName == typesazure.StackCloud {
-		platform = typesazure.StackTerraformName
-	}
-
 	// IBM Cloud allows override of service endpoints, which would be required during bootstrap destroy.
 	// Create a JSON file with overrides, if these endpoints are present
 	if platform == ibmcloudtypes.Name && metadata.IBMCloud != nil && len(metadata.IBMCloud.ServiceEndpoints) > 0 {
@@ -70,16 +61,7 @@ func Destroy(ctx context.Context, dir string) (err error) {
 		}
 	}
 
-	// Get cluster profile for new FeatureGate access.  Blank is no longer an option, so default to
-	// SelfManaged.
-	clusterProfile := types.GetClusterProfileName()
-	featureSets, ok := features.AllFeatureSets()[clusterProfile]
-	if !ok {
-		return fmt.Errorf("no feature sets for cluster profile %q", clusterProfile)
-	}
-	fg := featuregates.FeatureGateFromFeatureSets(featureSets, metadata.FeatureSet, metadata.CustomFeatureSet)
-
-	provider, err := infra.ProviderForPlatform(platform, fg)
+	provider, err := infra.ProviderForMetadata(metadata)
 	if err != nil {
 		return fmt.Errorf("error getting infrastructure provider: %w", err)
 	}
@@ -17,7 +17,7 @@ type dnsZone struct {
 	project string
 }
 
-func (o *ClusterUninstaller) listDNSZones(ctx context.Context) (private *dnsZone, public []dnsZone, err error) {
+func (o *ClusterUninstaller) listDNSZones(ctx context.Context) (private []dnsZone, public []dnsZone, err error) {
 	o.Logger.Debugf("Listing DNS Zones")
 	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
@@ -34,7 +34,7 @@ func (o *ClusterUninstaller) listDNSZones(ctx context.Context) (private *dnsZone
 				switch zone.Visibility {
 				case "private":
 					if o.isClusterResource(zone.Name) || (o.PrivateZoneDomain != "" && o.PrivateZoneDomain == zone.DnsName) {
-						private = &dnsZone{name: zone.Name, domain: zone.DnsName, project: project}
+						private = append(private, dnsZone{name: zone.Name, domain: zone.DnsName, project: project})
 					}
 				default:
 					public = append(public, dnsZone{name: zone.Name, domain: zone.DnsName, project: project})
@@ -154,23 +154,35 @@ func (o *ClusterUninstaller) getMatchingRecordSets(parentRecords, childRecords [
 }
 
 // destroyDNS deletes DNS resources associated with the cluster. It first finds
-// the private DNS zone that belongs to the cluster by looking for a zone prefixed
-// with the cluster's infra ID. It then finds a public zone that is the parent of
-// the cluster's private zone by searching for zones with a matching domain (in
-// order from specific to general). If/when a parent DNS zone is found, the records
-// from the private zone are matched to records in the parent zone (by using type
-// and name for each record). Matching records are removed from the public zone.
-// Finally all records are removed from the private zone and the private zone is removed.
+// the private DNS zones that belong to the cluster by looking for zones prefixed
+// with the cluster's infra ID, such as the cluster zone and the googleapis.com
+// zone. For each of them, it then finds a public zone that is the parent of the
+// private zone by searching for zones with a matching domain (in order from
+// specific to general). If/when a parent DNS zone is found, the records from the
+// private zone are matched to records in the parent zone (by using type and name
+// for each record). Matching records are removed from the public zone. Finally
+// all records are removed from the private zone and the private zone is removed.
 func (o *ClusterUninstaller) destroyDNS(ctx context.Context) error {
-	privateZone, publicZones, err := o.listDNSZones(ctx)
+	privateZones, publicZones, err := o.listDNSZones(ctx)
 	if err != nil {
 		return err
 	}
-	if privateZone == nil {
+	if len(privateZones) == 0 {
 		o.Logger.Debugf("Private DNS zone not found")
 		return nil
 	}
 
+	for i := range privateZones {
+		if err := o.destroyPrivateDNSZone(ctx, &privateZones[i], publicZones); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destroyPrivateDNSZone deletes the private zone, along with its records in
+// its parent public zones.
+func (o *ClusterUninstaller) destroyPrivateDNSZone(ctx context.Context, privateZone *dnsZone, publicZones []dnsZone) error {
 	zoneRecordSets, err := o.listDNSZoneRecordSets(ctx, privateZone)
 	if err != nil {
 		return err
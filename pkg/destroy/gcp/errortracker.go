@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -12,11 +13,14 @@ const (
 
 // errorTracker holds a history of errors
 type errorTracker struct {
+	mu      sync.Mutex
 	history map[string]time.Time
 }
 
 // suppressWarning logs errors WARN once every duration and the rest to DEBUG
 func (o *errorTracker) suppressWarning(identifier string, err error, logger logrus.FieldLogger) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	if o.history == nil {
 		o.history = map[string]time.Time{}
 	}
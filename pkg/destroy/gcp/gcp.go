@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -160,51 +161,15 @@ func (o *ClusterUninstaller) Run() (*types.ClusterQuota, error) {
 }
 
 func (o *ClusterUninstaller) destroyCluster() (bool, error) {
-	stagedFuncs := [][]struct {
-		name    string
-		execute func(ctx context.Context) error
-	}{{
-		{name: "Stop instances", execute: o.stopInstances},
-	}, {
-		{name: "Cloud controller resources", execute: o.discoverCloudControllerResources},
-	}, {
-		{name: "Instances", execute: o.destroyInstances},
-		{name: "Disks", execute: o.destroyDisks},
-		{name: "Service accounts", execute: o.destroyServiceAccounts},
-		{name: "Images", execute: o.destroyImages},
-		{name: "DNS", execute: o.destroyDNS},
-		{name: "Buckets", execute: o.destroyBuckets},
-		{name: "Routes", execute: o.destroyRoutes},
-		{name: "Firewalls", execute: o.destroyFirewalls},
-		{name: "Addresses", execute: o.destroyAddresses},
-		{name: "Forwarding rules", execute: o.destroyForwardingRules},
-		{name: "Target Pools", execute: o.destroyTargetPools},
-		{name: "Instance groups", execute: o.destroyInstanceGroups},
-		{name: "Target TCP Proxies", execute: o.destroyTargetTCPProxies},
-		{name: "Backend services", execute: o.destroyBackendServices},
-		{name: "Health checks", execute: o.destroyHealthChecks},
-		{name: "HTTP Health checks", execute: o.destroyHTTPHealthChecks},
-		{name: "Routers", execute: o.destroyRouters},
-		{name: "Subnetworks", execute: o.destroySubnetworks},
-		{name: "Networks", execute: o.destroyNetworks},
-	}}
-
-	// create the main Context, so all stages can accept and make context children
+	steps := o.deletionSteps()
+	if err := validateDeletionSteps(steps); err != nil {
+		return false, err
+	}
+
+	// create the main Context, so all steps can accept and make context children
 	ctx := context.Background()
 
-	done := true
-	for _, stage := range stagedFuncs {
-		if done {
-			for _, f := range stage {
-				err := f.execute(ctx)
-				if err != nil {
-					o.Logger.Debugf("%s: %v", f.name, err)
-					done = false
-				}
-			}
-		}
-	}
-	return done, nil
+	return o.runDeletionSteps(ctx, steps), nil
 }
 
 // getZoneName extracts a zone name from a zone URL
@@ -283,6 +248,7 @@ func aggregateError(errs []error, pending ...int) error {
 // requestIDTracker keeps track of a set of request IDs mapped to a unique resource
 // identifier
 type requestIDTracker struct {
+	mu         sync.Mutex
 	requestIDs map[string]string
 }
 
@@ -294,7 +260,9 @@ func newRequestIDTracker() requestIDTracker {
 
 // requestID returns a UID for a given item identifier. Unless the ID is reset, the
 // same requestID will be returned every time for a given item.
-func (t requestIDTracker) requestID(identifier ...string) string {
+func (t *requestIDTracker) requestID(identifier ...string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	key := strings.Join(identifier, "/")
 	id, exists := t.requestIDs[key]
 	if !exists {
@@ -307,13 +275,16 @@ func (t requestIDTracker) requestID(identifier ...string) string {
 // resetRequestID resets the request ID used for a particular item. This
 // should be called whenever a request fails, and a brand new request should be
 // sent.
-func (t requestIDTracker) resetRequestID(identifier ...string) {
+func (t *requestIDTracker) resetRequestID(identifier ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	key := strings.Join(identifier, "/")
 	delete(t.requestIDs, key)
 }
 
 // pendingItemTracker tracks a set of pending item names for a given type of resource
 type pendingItemTracker struct {
+	mu           sync.Mutex
 	pendingItems map[string]cloudResources
 	removedQuota []gcptypes.QuotaUsage
 }
@@ -326,6 +297,8 @@ func newPendingItemTracker() pendingItemTracker {
 
 // GetAllPendintItems returns a slice of all of the pending items across all types.
 func (t *pendingItemTracker) GetAllPendingItems() []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	var items []cloudResource
 	for _, is := range t.pendingItems {
 		for _, i := range is {
@@ -337,6 +310,8 @@ func (t *pendingItemTracker) GetAllPendingItems() []cloudResource {
 
 // getPendingItems returns the list of resources to be deleted.
 func (t *pendingItemTracker) getPendingItems(itemType string) []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	lastFound, exists := t.pendingItems[itemType]
 	if !exists {
 		lastFound = cloudResources{}
@@ -346,6 +321,8 @@ func (t *pendingItemTracker) getPendingItems(itemType string) []cloudResource {
 
 // insertPendingItems adds to the list of resources to be deleted.
 func (t *pendingItemTracker) insertPendingItems(itemType string, items []cloudResource) []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	lastFound, exists := t.pendingItems[itemType]
 	if !exists {
 		lastFound = cloudResources{}
@@ -357,6 +334,8 @@ func (t *pendingItemTracker) insertPendingItems(itemType string, items []cloudRe
 
 // deletePendingItems removes from the list of resources to be deleted.
 func (t *pendingItemTracker) deletePendingItems(itemType string, items []cloudResource) []cloudResource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	lastFound, exists := t.pendingItems[itemType]
 	if !exists {
 		lastFound = cloudResources{}
@@ -0,0 +1,167 @@
+package gcp
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// deletionStep deletes the cluster resources of some types.
+type deletionStep struct {
+	name    string
+	execute func(ctx context.Context) error
+
+	// itemTypes are the types of the pending items deleted by the step. The
+	// step is complete once none of them is pending anymore, even if it
+	// returned no error while waiting on the deletion operations.
+	itemTypes []string
+
+	// dependsOn are the names of the steps deleting the resources that must
+	// be gone before the step runs, because the resources of the step are in
+	// use by them.
+	dependsOn []string
+}
+
+// deletionSteps returns the steps deleting the cluster resources. They form a
+// dependency graph, whose independent branches are deleted concurrently.
+func (o *ClusterUninstaller) deletionSteps() []deletionStep {
+	const (
+		stopInstances    = "Stop instances"
+		cloudController  = "Cloud controller resources"
+		instances        = "Instances"
+		forwardingRules  = "Forwarding rules"
+		addresses        = "Addresses"
+		targetPools      = "Target Pools"
+		targetTCPProxies = "Target TCP Proxies"
+		backendServices  = "Backend services"
+		instanceGroups   = "Instance groups"
+		routes           = "Routes"
+		firewalls        = "Firewalls"
+		routers          = "Routers"
+		subnetworks      = "Subnetworks"
+		networks         = "Networks"
+	)
+
+	return []deletionStep{
+		{name: stopInstances, execute: o.stopInstances, itemTypes: []string{"stopinstance"}},
+		// The cloud controller resources are discovered from the instances,
+		// before they are deleted.
+		{name: cloudController, execute: o.discoverCloudControllerResources, dependsOn: []string{stopInstances}},
+		{name: instances, execute: o.destroyInstances, itemTypes: []string{"instance"}, dependsOn: []string{cloudController}},
+		{name: "Disks", execute: o.destroyDisks, itemTypes: []string{"disk"}, dependsOn: []string{instances}},
+		{name: "Service accounts", execute: o.destroyServiceAccounts, itemTypes: []string{"serviceaccount"}, dependsOn: []string{cloudController}},
+		{name: "Images", execute: o.destroyImages, itemTypes: []string{"image"}, dependsOn: []string{cloudController}},
+		{name: "DNS", execute: o.destroyDNS, dependsOn: []string{cloudController}},
+		{name: "Buckets", execute: o.destroyBuckets, itemTypes: []string{"bucket"}, dependsOn: []string{cloudController}},
+		{name: routes, execute: o.destroyRoutes, itemTypes: []string{"route"}, dependsOn: []string{cloudController}},
+		{name: firewalls, execute: o.destroyFirewalls, itemTypes: []string{"firewall"}, dependsOn: []string{cloudController}},
+		{name: forwardingRules, execute: o.destroyForwardingRules, itemTypes: []string{"forwardingrule"}, dependsOn: []string{cloudController}},
+		{name: addresses, execute: o.destroyAddresses, itemTypes: []string{"address"}, dependsOn: []string{forwardingRules}},
+		{name: targetPools, execute: o.destroyTargetPools, itemTypes: []string{"targetpool"}, dependsOn: []string{forwardingRules}},
+		{name: targetTCPProxies, execute: o.destroyTargetTCPProxies, itemTypes: []string{"targettcpproxy"}, dependsOn: []string{forwardingRules}},
+		{name: backendServices, execute: o.destroyBackendServices, itemTypes: []string{"backendservice"}, dependsOn: []string{forwardingRules, targetTCPProxies}},
+		{name: instanceGroups, execute: o.destroyInstanceGroups, itemTypes: []string{"instancegroup"}, dependsOn: []string{instances, backendServices}},
+		{name: "Health checks", execute: o.destroyHealthChecks, itemTypes: []string{"healthcheck", "regionHealthCheck"}, dependsOn: []string{backendServices}},
+		{name: "HTTP Health checks", execute: o.destroyHTTPHealthChecks, itemTypes: []string{"httphealthcheck"}, dependsOn: []string{targetPools}},
+		{name: routers, execute: o.destroyRouters, itemTypes: []string{"router"}, dependsOn: []string{cloudController}},
+		{name: subnetworks, execute: o.destroySubnetworks, itemTypes: []string{"subnetwork"}, dependsOn: []string{instances, instanceGroups, addresses, routers}},
+		{name: networks, execute: o.destroyNetworks, itemTypes: []string{"network"}, dependsOn: []string{subnetworks, routes, firewalls}},
+	}
+}
+
+// validateDeletionSteps checks that the steps form a dependency graph: their
+// names are unique, and their dependencies exist and have no cycle.
+func validateDeletionSteps(steps []deletionStep) error {
+	byName := make(map[string]deletionStep, len(steps))
+	for _, step := range steps {
+		if _, ok := byName[step.name]; ok {
+			return errors.Errorf("duplicate deletion step %q", step.name)
+		}
+		byName[step.name] = step
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return errors.Errorf("deletion steps depend on each other: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range byName[name].dependsOn {
+			if _, ok := byName[dep]; !ok {
+				return errors.Errorf("deletion step %q depends on unknown step %q", name, dep)
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, step := range steps {
+		if err := visit(step.name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runDeletionSteps runs each step as soon as the steps it depends on are
+// complete, concurrently with the other steps that are ready. The steps
+// depending on a step that failed, or still has pending items, are skipped
+// until the next attempt. It returns true if all the steps are complete.
+func (o *ClusterUninstaller) runDeletionSteps(ctx context.Context, steps []deletionStep) bool {
+	type result struct {
+		done     chan struct{}
+		complete bool
+	}
+	results := make(map[string]*result, len(steps))
+	for _, step := range steps {
+		results[step.name] = &result{done: make(chan struct{})}
+	}
+
+	var wg sync.WaitGroup
+	for _, step := range steps {
+		step, res := step, results[step.name]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(res.done)
+			for _, dep := range step.dependsOn {
+				<-results[dep].done
+				if !results[dep].complete {
+					o.Logger.Debugf("%s: waiting on %s", step.name, strings.ToLower(dep))
+					return
+				}
+			}
+			if err := step.execute(ctx); err != nil {
+				o.Logger.Debugf("%s: %v", step.name, err)
+				return
+			}
+			for _, itemType := range step.itemTypes {
+				if pending := o.getPendingItems(itemType); len(pending) > 0 {
+					o.Logger.Debugf("%s: %d items pending", step.name, len(pending))
+					return
+				}
+			}
+			res.complete = true
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range results {
+		if !res.complete {
+			return false
+		}
+	}
+	return true
+}
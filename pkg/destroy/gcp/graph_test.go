@@ -0,0 +1,120 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDeletionSteps(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	cases := []struct {
+		name  string
+		steps []deletionStep
+		err   string
+	}{
+		{
+			name:  "destroyer steps",
+			steps: (&ClusterUninstaller{}).deletionSteps(),
+		},
+		{
+			name: "duplicate step",
+			steps: []deletionStep{
+				{name: "a", execute: noop},
+				{name: "a", execute: noop},
+			},
+			err: `duplicate deletion step "a"`,
+		},
+		{
+			name: "unknown dependency",
+			steps: []deletionStep{
+				{name: "a", execute: noop, dependsOn: []string{"b"}},
+			},
+			err: `deletion step "a" depends on unknown step "b"`,
+		},
+		{
+			name: "cycle",
+			steps: []deletionStep{
+				{name: "a", execute: noop, dependsOn: []string{"b"}},
+				{name: "b", execute: noop, dependsOn: []string{"c"}},
+				{name: "c", execute: noop, dependsOn: []string{"a"}},
+			},
+			err: "deletion steps depend on each other: a -> b -> c -> a",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDeletionSteps(tc.steps)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRunDeletionSteps(t *testing.T) {
+	o := &ClusterUninstaller{
+		Logger:             logrus.New(),
+		pendingItemTracker: newPendingItemTracker(),
+	}
+
+	var mu sync.Mutex
+	var ran []string
+	step := func(name string, err error, dependsOn ...string) deletionStep {
+		return deletionStep{
+			name: name,
+			execute: func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, name)
+				return err
+			},
+			itemTypes: []string{name},
+			dependsOn: dependsOn,
+		}
+	}
+
+	// The independent steps must run concurrently to meet.
+	meeting := make(chan struct{})
+	meet := func(name string) deletionStep {
+		s := step(name, nil)
+		execute := s.execute
+		s.execute = func(ctx context.Context) error {
+			select {
+			case meeting <- struct{}{}:
+			case <-meeting:
+			case <-time.After(10 * time.Second):
+				return errors.New("not run concurrently")
+			}
+			return execute(ctx)
+		}
+		return s
+	}
+
+	o.insertPendingItems("pending", []cloudResource{{key: "lb", name: "lb"}})
+	steps := []deletionStep{
+		meet("first"),
+		meet("second"),
+		step("after both", nil, "first", "second"),
+		step("failing", errors.New("in use"), "first"),
+		step("after failing", nil, "failing"),
+		step("pending", nil, "second"),
+		step("after pending", nil, "pending"),
+	}
+	assert.NoError(t, validateDeletionSteps(steps))
+	assert.False(t, o.runDeletionSteps(context.Background(), steps))
+	assert.ElementsMatch(t, []string{"first", "second", "after both", "failing", "pending"}, ran)
+
+	o.deletePendingItems("pending", o.getPendingItems("pending"))
+	ran = nil
+	steps[3] = step("failing", nil, "first")
+	assert.True(t, o.runDeletionSteps(context.Background(), steps))
+	assert.ElementsMatch(t, []string{"first", "second", "after both", "failing", "after failing", "pending", "after pending"}, ran)
+}
@@ -211,6 +211,17 @@ func filterTags(filters Filter) []string {
 	return tags
 }
 
+// clusterIDFromFilter returns the value of the openshiftClusterID tag of the
+// filter.
+func clusterIDFromFilter(filters Filter) string {
+	for k, v := range filters {
+		if strings.ToLower(k) == "openshiftclusterid" {
+			return v
+		}
+	}
+	return ""
+}
+
 func deleteServers(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
 	logger.Debug("Deleting openstack servers")
 	defer logger.Debugf("Exiting deleting openstack servers")
@@ -1159,85 +1170,95 @@ func deleteContainers(opts *clientconfig.ClientOpts, filter Filter, logger logru
 			logger.Error(err)
 			return false, nil
 		}
-		for key, val := range filter {
-			// Swift mangles the case so openshiftClusterID becomes
-			// Openshiftclusterid in the X-Container-Meta- HEAD output
-			titlekey := strings.Title(strings.ToLower(key))
-			if metadata[titlekey] == val {
-				queue := newSemaphore(3)
-				errCh := make(chan error)
-				err := objects.List(conn, container, nil).EachPage(func(page pagination.Page) (bool, error) {
-					objectsOnPage, err := objects.ExtractNames(page)
+		if !isClusterContainer(container, metadata, filter) {
+			continue
+		}
+		queue := newSemaphore(3)
+		errCh := make(chan error)
+		err = objects.List(conn, container, nil).EachPage(func(page pagination.Page) (bool, error) {
+			objectsOnPage, err := objects.ExtractNames(page)
+			if err != nil {
+				return false, err
+			}
+			queue.Add(func() {
+				for len(objectsOnPage) > 0 {
+					logger.Debugf("Initiating bulk deletion of %d objects in container %q", len(objectsOnPage), container)
+					resp, err := objects.BulkDelete(conn, container, objectsOnPage).Extract()
 					if err != nil {
-						return false, err
+						errCh <- err
+						return
 					}
-					queue.Add(func() {
-						for len(objectsOnPage) > 0 {
-							logger.Debugf("Initiating bulk deletion of %d objects in container %q", len(objectsOnPage), container)
-							resp, err := objects.BulkDelete(conn, container, objectsOnPage).Extract()
-							if err != nil {
-								errCh <- err
-								return
-							}
-							if len(resp.Errors) > 0 {
-								// Convert resp.Errors to golang errors.
-								// Each error is represented by a list of 2 strings, where the first one
-								// is the object name, and the second one contains an error message.
-								for _, objectError := range resp.Errors {
-									errCh <- fmt.Errorf("cannot delete object %q: %s", objectError[0], objectError[1])
-								}
-								logger.Debugf("Terminating object deletion routine with error. Deleted %d objects out of %d.", resp.NumberDeleted, len(objectsOnPage))
-							}
-
-							// Some object-storage instances may be set to have a limit to the LIST operation
-							// that is higher to the limit to the BULK DELETE operation. On those clouds, objects
-							// in the BULK DELETE call beyond the limit are silently ignored. In this loop, after
-							// checking that no errors were encountered, we reduce the BULK DELETE list by the
-							// number of processed objects, and send it back to the server if it's not empty.
-							objectsOnPage = objectsOnPage[resp.NumberDeleted+resp.NumberNotFound:]
+					if len(resp.Errors) > 0 {
+						// Convert resp.Errors to golang errors.
+						// Each error is represented by a list of 2 strings, where the first one
+						// is the object name, and the second one contains an error message.
+						for _, objectError := range resp.Errors {
+							errCh <- fmt.Errorf("cannot delete object %q: %s", objectError[0], objectError[1])
 						}
-						logger.Debugf("Terminating object deletion routine.")
-					})
-					return true, nil
-				})
-				if err != nil {
-					var gerr gophercloud.ErrDefault404
-					if !errors.As(err, &gerr) {
-						logger.Errorf("Bulk deletion of container %q objects failed: %v", container, err)
-						return false, nil
-					}
-				}
-				var errs []error
-				go func() {
-					for err := range errCh {
-						errs = append(errs, err)
+						logger.Debugf("Terminating object deletion routine with error. Deleted %d objects out of %d.", resp.NumberDeleted, len(objectsOnPage))
 					}
-				}()
 
-				queue.Wait()
-				close(errCh)
-				if len(errs) > 0 {
-					return false, fmt.Errorf("errors occurred during bulk deletion of the objects of container %q: %w", container, k8serrors.NewAggregate(errs))
-				}
-				logger.Debugf("Deleting container %q", container)
-				_, err = containers.Delete(conn, container).Extract()
-				if err != nil {
-					// Ignore the error if the container cannot be found and return with an appropriate message if it's another type of error
-					var gerr gophercloud.ErrDefault404
-					if !errors.As(err, &gerr) {
-						logger.Errorf("Deleting container %q failed: %v", container, err)
-						return false, nil
-					}
-					logger.Debugf("Cannot find container %q. It's probably already been deleted.", container)
+					// Some object-storage instances may be set to have a limit to the LIST operation
+					// that is higher to the limit to the BULK DELETE operation. On those clouds, objects
+					// in the BULK DELETE call beyond the limit are silently ignored. In this loop, after
+					// checking that no errors were encountered, we reduce the BULK DELETE list by the
+					// number of processed objects, and send it back to the server if it's not empty.
+					objectsOnPage = objectsOnPage[resp.NumberDeleted+resp.NumberNotFound:]
 				}
-				// If a metadata key matched, we're done so break from the loop
-				break
+				logger.Debugf("Terminating object deletion routine.")
+			})
+			return true, nil
+		})
+		if err != nil {
+			var gerr gophercloud.ErrDefault404
+			if !errors.As(err, &gerr) {
+				logger.Errorf("Bulk deletion of container %q objects failed: %v", container, err)
+				return false, nil
+			}
+		}
+		var errs []error
+		go func() {
+			for err := range errCh {
+				errs = append(errs, err)
 			}
+		}()
+
+		queue.Wait()
+		close(errCh)
+		if len(errs) > 0 {
+			return false, fmt.Errorf("errors occurred during bulk deletion of the objects of container %q: %w", container, k8serrors.NewAggregate(errs))
+		}
+		logger.Debugf("Deleting container %q", container)
+		_, err = containers.Delete(conn, container).Extract()
+		if err != nil {
+			// Ignore the error if the container cannot be found and return with an appropriate message if it's another type of error
+			var gerr gophercloud.ErrDefault404
+			if !errors.As(err, &gerr) {
+				logger.Errorf("Deleting container %q failed: %v", container, err)
+				return false, nil
+			}
+			logger.Debugf("Cannot find container %q. It's probably already been deleted.", container)
 		}
 	}
 	return true, nil
 }
 
+// isClusterContainer returns true if the container was created for the
+// cluster: either its metadata matches the filter, or it was created by the
+// image registry operator, which names it after the cluster ID.
+func isClusterContainer(name string, metadata map[string]string, filter Filter) bool {
+	for key, val := range filter {
+		// Swift mangles the case so openshiftClusterID becomes
+		// Openshiftclusterid in the X-Container-Meta- HEAD output
+		titlekey := strings.Title(strings.ToLower(key))
+		if metadata[titlekey] == val {
+			return true
+		}
+	}
+	clusterID := clusterIDFromFilter(filter)
+	return clusterID != "" && strings.HasPrefix(name, clusterID+"-image-registry")
+}
+
 func deleteTrunks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
 	logger.Debug("Deleting openstack trunks")
 	defer logger.Debugf("Exiting deleting openstack trunks")
@@ -1271,22 +1292,89 @@ func deleteTrunks(opts *clientconfig.ClientOpts, filter Filter, logger logrus.Fi
 	numberToDelete := len(allTrunks)
 	numberDeleted := 0
 	for _, trunk := range allTrunks {
-		logger.Debugf("Deleting Trunk %q", trunk.ID)
-		err = trunks.Delete(conn, trunk.ID).ExtractErr()
+		if !deleteTrunk(conn, trunk, logger) {
+			continue
+		}
+		numberDeleted++
+	}
+	return numberDeleted == numberToDelete, nil
+}
+
+// deleteTrunk detaches the sub-ports of the trunk and deletes it, then deletes
+// the sub-ports sharing a tag with the trunk: they were created for the
+// cluster along with it and would be left behind otherwise. It returns true
+// if the trunk is gone.
+func deleteTrunk(conn *gophercloud.ServiceClient, trunk trunks.Trunk, logger logrus.FieldLogger) bool {
+	if len(trunk.Subports) > 0 {
+		removeOpts := trunks.RemoveSubportsOpts{}
+		for _, subport := range trunk.Subports {
+			removeOpts.Subports = append(removeOpts.Subports, trunks.RemoveSubport{PortID: subport.PortID})
+		}
+		logger.Debugf("Removing %d sub-ports from Trunk %q", len(trunk.Subports), trunk.ID)
+		_, err := trunks.RemoveSubports(conn, trunk.ID, removeOpts).Extract()
+		if err != nil {
+			var gerr gophercloud.ErrDefault404
+			if errors.As(err, &gerr) {
+				logger.Debugf("Cannot find trunk %q. It's probably already been deleted.", trunk.ID)
+				return true
+			}
+			// Just log the error, the trunk deletion is retried
+			logger.Debugf("Removing the sub-ports from Trunk %q failed: %v", trunk.ID, err)
+			return false
+		}
+	}
+
+	logger.Debugf("Deleting Trunk %q", trunk.ID)
+	err := trunks.Delete(conn, trunk.ID).ExtractErr()
+	if err != nil {
+		// Ignore the error if the trunk cannot be found
+		var gerr gophercloud.ErrDefault404
+		if !errors.As(err, &gerr) {
+			// This can fail when the trunk is still in use so return/retry
+			// Just log the error and move on to the next trunk
+			logger.Debugf("Deleting Trunk %q failed: %v", trunk.ID, err)
+			return false
+		}
+		logger.Debugf("Cannot find trunk %q. It's probably already been deleted.", trunk.ID)
+	}
+
+	for _, subport := range trunk.Subports {
+		port, err := ports.Get(conn, subport.PortID).Extract()
 		if err != nil {
-			// Ignore the error if the trunk cannot be found
 			var gerr gophercloud.ErrDefault404
 			if !errors.As(err, &gerr) {
-				// This can fail when the trunk is still in use so return/retry
-				// Just log the error and move on to the next trunk
-				logger.Debugf("Deleting Trunk %q failed: %v", trunk.ID, err)
-				continue
+				logger.Debugf("Getting sub-port %q of Trunk %q failed: %v", subport.PortID, trunk.ID, err)
+			}
+			continue
+		}
+		if !sharesTag(port.Tags, trunk.Tags) {
+			logger.Debugf("Keeping sub-port %q of Trunk %q, which was not created for the cluster", port.ID, trunk.ID)
+			continue
+		}
+		logger.Debugf("Deleting sub-port %q of Trunk %q", port.ID, trunk.ID)
+		err = ports.Delete(conn, port.ID).ExtractErr()
+		if err != nil {
+			var gerr gophercloud.ErrDefault404
+			if !errors.As(err, &gerr) {
+				// The ports tagged for the cluster are deleted along with the
+				// other ports otherwise
+				logger.Debugf("Deleting sub-port %q of Trunk %q failed: %v", port.ID, trunk.ID, err)
 			}
-			logger.Debugf("Cannot find trunk %q. It's probably already been deleted.", trunk.ID)
 		}
-		numberDeleted++
 	}
-	return numberDeleted == numberToDelete, nil
+	return true
+}
+
+// sharesTag returns true if the tags have a tag in common.
+func sharesTag(tags, otherTags []string) bool {
+	for _, tag := range tags {
+		for _, otherTag := range otherTags {
+			if tag == otherTag {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func deleteAssociatedTrunk(conn *gophercloud.ServiceClient, logger logrus.FieldLogger, portID string) {
@@ -1313,19 +1401,7 @@ func deleteAssociatedTrunk(conn *gophercloud.ServiceClient, logger logrus.FieldL
 		return
 	}
 	for _, trunk := range allTrunks {
-		logger.Debugf("Deleting Trunk %q", trunk.ID)
-		err = trunks.Delete(conn, trunk.ID).ExtractErr()
-		if err != nil {
-			// Ignore the error if the trunk cannot be found
-			var gerr gophercloud.ErrDefault404
-			if !errors.As(err, &gerr) {
-				// This can fail when the trunk is still in use so return/retry
-				// Just log the error and move on to the next trunk
-				logger.Debugf("Deleting Trunk %q failed: %v", trunk.ID, err)
-				continue
-			}
-			logger.Debugf("Cannot find trunk %q. It's probably already been deleted.", trunk.ID)
-		}
+		deleteTrunk(conn, trunk, logger)
 	}
 	return
 }
@@ -1431,13 +1507,7 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	logger.Debug("Deleting OpenStack volumes")
 	defer logger.Debugf("Exiting deleting OpenStack volumes")
 
-	var clusterID string
-	for k, v := range filter {
-		if strings.ToLower(k) == "openshiftclusterid" {
-			clusterID = v
-			break
-		}
-	}
+	clusterID := clusterIDFromFilter(filter)
 
 	conn, err := openstackdefaults.NewServiceClient("volume", opts)
 	if err != nil {
@@ -1461,14 +1531,7 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 
 	volumeIDs := []string{}
 	for _, volume := range allVolumes {
-		// First, we need to delete all volumes that have names with the cluster ID as a prefix.
-		// They are created by the in-tree Cinder provisioner.
-		if strings.HasPrefix(volume.Name, clusterID) {
-			volumeIDs = append(volumeIDs, volume.ID)
-		}
-		// Second, we need to delete volumes created by the CSI driver. They contain their cluster ID
-		// in the metadata.
-		if val, ok := volume.Metadata[cinderCSIClusterIDKey]; ok && val == clusterID {
+		if isClusterVolume(volume, clusterID) {
 			volumeIDs = append(volumeIDs, volume.ID)
 		}
 	}
@@ -1498,17 +1561,27 @@ func deleteVolumes(opts *clientconfig.ClientOpts, filter Filter, logger logrus.F
 	return numberDeleted == numberToDelete, nil
 }
 
+// isClusterVolume returns true if the volume was created for the cluster.
+func isClusterVolume(volume volumes.Volume, clusterID string) bool {
+	if clusterID == "" {
+		return false
+	}
+	// Volumes created by the in-tree Cinder provisioner have names with the
+	// cluster ID as a prefix.
+	if strings.HasPrefix(volume.Name, clusterID) {
+		return true
+	}
+	// Volumes created by the CSI driver contain their cluster ID in the
+	// metadata.
+	val, ok := volume.Metadata[cinderCSIClusterIDKey]
+	return ok && val == clusterID
+}
+
 func deleteVolumeSnapshots(opts *clientconfig.ClientOpts, filter Filter, logger logrus.FieldLogger) (bool, error) {
 	logger.Debug("Deleting OpenStack volume snapshots")
 	defer logger.Debugf("Exiting deleting OpenStack volume snapshots")
 
-	var clusterID string
-	for k, v := range filter {
-		if strings.ToLower(k) == "openshiftclusterid" {
-			clusterID = v
-			break
-		}
-	}
+	clusterID := clusterIDFromFilter(filter)
 
 	conn, err := openstackdefaults.NewServiceClient("volume", opts)
 	if err != nil {
@@ -1516,6 +1589,25 @@ func deleteVolumeSnapshots(opts *clientconfig.ClientOpts, filter Filter, logger
 		return false, nil
 	}
 
+	allVolumePages, err := volumes.List(conn, volumes.ListOpts{}).AllPages()
+	if err != nil {
+		logger.Error(err)
+		return false, nil
+	}
+
+	allVolumes, err := volumes.ExtractVolumes(allVolumePages)
+	if err != nil {
+		logger.Error(err)
+		return false, nil
+	}
+
+	clusterVolumes := map[string]bool{}
+	for _, volume := range allVolumes {
+		if isClusterVolume(volume, clusterID) {
+			clusterVolumes[volume.ID] = true
+		}
+	}
+
 	listOpts := snapshots.ListOpts{}
 
 	allPages, err := snapshots.List(conn, listOpts).AllPages()
@@ -1530,23 +1622,30 @@ func deleteVolumeSnapshots(opts *clientconfig.ClientOpts, filter Filter, logger
 		return false, nil
 	}
 
-	numberToDelete := len(allSnapshots)
-	numberDeleted := 0
+	snapshotIDs := []string{}
 	for _, snapshot := range allSnapshots {
-		// Delete only those snapshots that contain cluster ID in the metadata
-		if val, ok := snapshot.Metadata[cinderCSIClusterIDKey]; ok && val == clusterID {
-			logger.Debugf("Deleting volume snapshot %q", snapshot.ID)
-			err = snapshots.Delete(conn, snapshot.ID).ExtractErr()
-			if err != nil {
-				// Ignore the error if the server cannot be found
-				var gerr gophercloud.ErrDefault404
-				if !errors.As(err, &gerr) {
-					// Just log the error and move on to the next volume snapshot
-					logger.Debugf("Deleting volume snapshot %q failed: %v", snapshot.ID, err)
-					continue
-				}
-				logger.Debugf("Cannot find volume snapshot %q. It's probably already been deleted.", snapshot.ID)
+		// Delete the snapshots created by the CSI driver, which contain the
+		// cluster ID in the metadata, and the other snapshots of the cluster
+		// volumes, which would prevent deleting them.
+		if val, ok := snapshot.Metadata[cinderCSIClusterIDKey]; (ok && val == clusterID) || clusterVolumes[snapshot.VolumeID] {
+			snapshotIDs = append(snapshotIDs, snapshot.ID)
+		}
+	}
+
+	numberToDelete := len(snapshotIDs)
+	numberDeleted := 0
+	for _, snapshotID := range snapshotIDs {
+		logger.Debugf("Deleting volume snapshot %q", snapshotID)
+		err = snapshots.Delete(conn, snapshotID).ExtractErr()
+		if err != nil {
+			// Ignore the error if the snapshot cannot be found
+			var gerr gophercloud.ErrDefault404
+			if !errors.As(err, &gerr) {
+				// Just log the error and move on to the next volume snapshot
+				logger.Debugf("Deleting volume snapshot %q failed: %v", snapshotID, err)
+				continue
 			}
+			logger.Debugf("Cannot find volume snapshot %q. It's probably already been deleted.", snapshotID)
 		}
 		numberDeleted++
 	}
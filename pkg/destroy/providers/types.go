@@ -12,5 +12,11 @@ type Destroyer interface {
 	Run() (*types.ClusterQuota, error)
 }
 
+// DryRunner is implemented by the destroyers able to list the resources they
+// would destroy, without destroying them.
+type DryRunner interface {
+	DryRun() error
+}
+
 // NewFunc is an interface for creating platform-specific destroyers.
 type NewFunc func(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error)
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/cns"
+	cnstypes "github.com/vmware/govmomi/cns/types"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/pbm"
 	pbmtypes "github.com/vmware/govmomi/pbm/types"
 	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/library"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25"
@@ -34,6 +37,11 @@ type API interface {
 	DeleteStoragePolicy(ctx context.Context, policyName string) error
 	DeleteTag(ctx context.Context, id string) error
 	DeleteTagCategory(ctx context.Context, id string) error
+	ListLibraryItems(ctx context.Context, tagID string) ([]string, error)
+	LibraryItemClusters(ctx context.Context, id string) ([]string, error)
+	DeleteLibraryItem(ctx context.Context, id string) error
+	ListCNSVolumes(ctx context.Context, clusterID string) ([]cnstypes.CnsVolume, error)
+	DeleteCNSVolume(ctx context.Context, volumeID string) error
 }
 
 // Client makes calls to the Azure API.
@@ -45,6 +53,9 @@ type Client struct {
 
 const defaultTimeout = time.Minute * 5
 
+// libraryItemType is the type of content library items when tagged.
+const libraryItemType = "com.vmware.content.library.Item"
+
 // NewClient initializes a client.
 // Logout() must be called when you are done with the client.
 func NewClient(vCenter, username, password string) (*Client, error) {
@@ -280,3 +291,122 @@ func (c *Client) DeleteTagCategory(ctx context.Context, categoryName string) err
 
 	return utilerrors.NewAggregate(errs)
 }
+
+// ListLibraryItems returns the IDs of the content library items attached to the tag.
+func (c *Client) ListLibraryItems(ctx context.Context, tagID string) ([]string, error) {
+	refs, err := c.getAttachedObjectsOnTag(ctx, tagID, libraryItemType)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.Value)
+	}
+	return ids, nil
+}
+
+// LibraryItemClusters returns the infrastructure IDs of the clusters whose
+// tag is attached to the content library item.
+func (c *Client) LibraryItemClusters(ctx context.Context, id string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	tagManager := tags.NewManager(c.restClient)
+	attached, err := tagManager.GetAttachedTags(ctx, types.ManagedObjectReference{Type: libraryItemType, Value: id})
+	if err != nil {
+		return nil, err
+	}
+
+	var infraIDs []string
+	for _, tag := range attached {
+		category, err := tagManager.GetCategory(ctx, tag.CategoryID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get category %q", tag.CategoryID)
+		}
+		// The installer creates one tag per cluster, named after the
+		// infrastructure ID, in the openshift-<infraID> category.
+		if category.Name == "openshift-"+tag.Name {
+			infraIDs = append(infraIDs, tag.Name)
+		}
+	}
+	return infraIDs, nil
+}
+
+// DeleteLibraryItem deletes the content library item `id`.
+func (c *Client) DeleteLibraryItem(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	libraryManager := library.NewManager(c.restClient)
+	item, err := libraryManager.GetLibraryItem(ctx, id)
+	if isNotFound(err) {
+		return nil
+	}
+	if err == nil {
+		err = libraryManager.DeleteLibraryItem(ctx, item)
+	}
+	return err
+}
+
+// ListCNSVolumes returns the CNS volumes, the first-class disks created by the
+// CSI driver, of the container cluster `clusterID`.
+func (c *Client) ListCNSVolumes(ctx context.Context, clusterID string) ([]cnstypes.CnsVolume, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	cnsClient, err := cns.NewClient(ctx, c.client)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := cnstypes.CnsQueryFilter{
+		ContainerClusterIds: []string{clusterID},
+	}
+	var volumes []cnstypes.CnsVolume
+	for {
+		result, err := cnsClient.QueryVolume(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, result.Volumes...)
+		if len(result.Volumes) == 0 || result.Cursor.Offset >= result.Cursor.TotalRecords {
+			return volumes, nil
+		}
+		cursor := result.Cursor
+		filter.Cursor = &cursor
+	}
+}
+
+// DeleteCNSVolume deletes the CNS volume `volumeID` along with its disk.
+func (c *Client) DeleteCNSVolume(ctx context.Context, volumeID string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	cnsClient, err := cns.NewClient(ctx, c.client)
+	if err != nil {
+		return err
+	}
+
+	task, err := cnsClient.DeleteVolume(ctx, []cnstypes.CnsVolumeId{{Id: volumeID}}, true)
+	if err != nil {
+		return err
+	}
+	taskInfo, err := cns.GetTaskInfo(ctx, task)
+	if err != nil {
+		return err
+	}
+	taskResult, err := cns.GetTaskResult(ctx, taskInfo)
+	if err != nil {
+		return err
+	}
+	if taskResult == nil {
+		return errors.Errorf("no result for the deletion of CNS volume %s", volumeID)
+	}
+	if fault := taskResult.GetCnsVolumeOperationResult().Fault; fault != nil {
+		if _, ok := fault.Fault.(*types.NotFound); ok {
+			return nil
+		}
+		return errors.Errorf("failed to delete CNS volume %s: %s", volumeID, fault.LocalizedMessage)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	types "github.com/vmware/govmomi/cns/types"
 	mo "github.com/vmware/govmomi/vim25/mo"
 )
 
@@ -35,6 +36,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// DeleteCNSVolume mocks base method.
+func (m *MockAPI) DeleteCNSVolume(ctx context.Context, volumeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCNSVolume", ctx, volumeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCNSVolume indicates an expected call of DeleteCNSVolume.
+func (mr *MockAPIMockRecorder) DeleteCNSVolume(ctx, volumeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCNSVolume", reflect.TypeOf((*MockAPI)(nil).DeleteCNSVolume), ctx, volumeID)
+}
+
 // DeleteFolder mocks base method.
 func (m *MockAPI) DeleteFolder(ctx context.Context, f mo.Folder) error {
 	m.ctrl.T.Helper()
@@ -49,6 +64,20 @@ func (mr *MockAPIMockRecorder) DeleteFolder(ctx, f interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockAPI)(nil).DeleteFolder), ctx, f)
 }
 
+// DeleteLibraryItem mocks base method.
+func (m *MockAPI) DeleteLibraryItem(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLibraryItem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLibraryItem indicates an expected call of DeleteLibraryItem.
+func (mr *MockAPIMockRecorder) DeleteLibraryItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLibraryItem", reflect.TypeOf((*MockAPI)(nil).DeleteLibraryItem), ctx, id)
+}
+
 // DeleteStoragePolicy mocks base method.
 func (m *MockAPI) DeleteStoragePolicy(ctx context.Context, policyName string) error {
 	m.ctrl.T.Helper()
@@ -105,6 +134,36 @@ func (mr *MockAPIMockRecorder) DeleteVirtualMachine(ctx, vmMO interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualMachine", reflect.TypeOf((*MockAPI)(nil).DeleteVirtualMachine), ctx, vmMO)
 }
 
+// LibraryItemClusters mocks base method.
+func (m *MockAPI) LibraryItemClusters(ctx context.Context, id string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LibraryItemClusters", ctx, id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LibraryItemClusters indicates an expected call of LibraryItemClusters.
+func (mr *MockAPIMockRecorder) LibraryItemClusters(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LibraryItemClusters", reflect.TypeOf((*MockAPI)(nil).LibraryItemClusters), ctx, id)
+}
+
+// ListCNSVolumes mocks base method.
+func (m *MockAPI) ListCNSVolumes(ctx context.Context, clusterID string) ([]types.CnsVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCNSVolumes", ctx, clusterID)
+	ret0, _ := ret[0].([]types.CnsVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCNSVolumes indicates an expected call of ListCNSVolumes.
+func (mr *MockAPIMockRecorder) ListCNSVolumes(ctx, clusterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCNSVolumes", reflect.TypeOf((*MockAPI)(nil).ListCNSVolumes), ctx, clusterID)
+}
+
 // ListFolders mocks base method.
 func (m *MockAPI) ListFolders(ctx context.Context, tagID string) ([]mo.Folder, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +179,21 @@ func (mr *MockAPIMockRecorder) ListFolders(ctx, tagID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockAPI)(nil).ListFolders), ctx, tagID)
 }
 
+// ListLibraryItems mocks base method.
+func (m *MockAPI) ListLibraryItems(ctx context.Context, tagID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLibraryItems", ctx, tagID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLibraryItems indicates an expected call of ListLibraryItems.
+func (mr *MockAPIMockRecorder) ListLibraryItems(ctx, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLibraryItems", reflect.TypeOf((*MockAPI)(nil).ListLibraryItems), ctx, tagID)
+}
+
 // ListVirtualMachines mocks base method.
 func (m *MockAPI) ListVirtualMachines(ctx context.Context, tagID string) ([]mo.VirtualMachine, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +203,145 @@ func (o *ClusterUninstaller) deleteVirtualMachines(ctx context.Context) error {
 	return utilerrors.NewAggregate(errs)
 }
 
+func (o *ClusterUninstaller) deleteLibraryItems(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	o.Logger.Debug("Delete Content Library Items")
+	found, err := o.client.ListLibraryItems(ctx, o.InfraID)
+	if err != nil {
+		o.Logger.Debug(err)
+		return err
+	}
+
+	var errs []error
+	for _, id := range found {
+		itemLogger := o.Logger.WithField("LibraryItem", id)
+		clusters, err := o.client.LibraryItemClusters(ctx, id)
+		if err != nil {
+			itemLogger.Debug(err)
+			errs = append(errs, err)
+			continue
+		}
+		var others []string
+		for _, c := range clusters {
+			if c != o.InfraID {
+				others = append(others, c)
+			}
+		}
+		// The item is shared by the clusters installed from the same OVA, so
+		// it is only removed once no other cluster references it.
+		if len(others) > 0 {
+			itemLogger.Infof("Not deleted, still used by clusters %s", strings.Join(others, ", "))
+			continue
+		}
+		if err := o.client.DeleteLibraryItem(ctx, id); err != nil {
+			itemLogger.Debug(err)
+			errs = append(errs, err)
+			continue
+		}
+		itemLogger.Info("Destroyed")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *ClusterUninstaller) deleteCNSVolumes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*30)
+	defer cancel()
+
+	o.Logger.Debug("Delete CNS Volumes")
+	found, err := o.client.ListCNSVolumes(ctx, o.InfraID)
+	if err != nil {
+		o.Logger.Debug(err)
+		return err
+	}
+
+	var errs []error
+	for _, volume := range found {
+		volumeLogger := o.Logger.WithField("CNSVolume", volume.Name)
+		if err := o.client.DeleteCNSVolume(ctx, volume.VolumeId.Id); err != nil {
+			volumeLogger.Debug(err)
+			errs = append(errs, err)
+			continue
+		}
+		volumeLogger.Info("Destroyed")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// listResources returns the resources of the cluster, as "<type> <name>".
+func (o *ClusterUninstaller) listResources(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var resources []string
+	vms, err := o.client.ListVirtualMachines(ctx, o.InfraID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vmMO := range vms {
+		resources = append(resources, "VirtualMachine "+vmMO.Name)
+	}
+
+	folders, err := o.client.ListFolders(ctx, o.InfraID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range folders {
+		resources = append(resources, "Folder "+f.Name)
+	}
+
+	items, err := o.client.ListLibraryItems(ctx, o.InfraID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range items {
+		clusters, err := o.client.LibraryItemClusters(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		shared := false
+		for _, c := range clusters {
+			shared = shared || c != o.InfraID
+		}
+		if !shared {
+			resources = append(resources, "LibraryItem "+id)
+		}
+	}
+
+	volumes, err := o.client.ListCNSVolumes(ctx, o.InfraID)
+	if err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		resources = append(resources, fmt.Sprintf("CNSVolume %s (%s)", volume.Name, volume.VolumeId.Id))
+	}
+
+	return append(resources,
+		fmt.Sprintf("StoragePolicy openshift-storage-policy-%s", o.InfraID),
+		"Tag "+o.InfraID,
+		"TagCategory openshift-"+o.InfraID,
+	), nil
+}
+
+// DryRun logs the resources that Run would destroy, without destroying them.
+// The storage policy, tag and tag category are listed by the names they would
+// have, whether they exist or not.
+func (o *ClusterUninstaller) DryRun() error {
+	defer o.client.Logout()
+
+	resources, err := o.listResources(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "failed to list cluster resources")
+	}
+	for _, resource := range resources {
+		o.Logger.Infof("Would destroy %s", resource)
+	}
+	return nil
+}
+
 func (o *ClusterUninstaller) destroyCluster(ctx context.Context) (bool, error) {
 	stagedFuncs := [][]struct {
 		name    string
@@ -213,6 +352,8 @@ func (o *ClusterUninstaller) destroyCluster(ctx context.Context) (bool, error) {
 		{name: "Virtual Machines", execute: o.deleteVirtualMachines},
 	}, {
 		{name: "Folder", execute: o.deleteFolder},
+		{name: "Content Library Items", execute: o.deleteLibraryItems},
+		{name: "CNS Volumes", execute: o.deleteCNSVolumes},
 	}, {
 		{name: "Storage Policy", execute: o.deleteStoragePolicy},
 		{name: "Tag", execute: o.deleteTag},
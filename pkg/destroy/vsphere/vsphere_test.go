@@ -10,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	cnstypes "github.com/vmware/govmomi/cns/types"
 	"github.com/vmware/govmomi/vim25/mo"
 	vspheretypes "github.com/vmware/govmomi/vim25/types"
 
@@ -584,3 +585,231 @@ func TestDeleteTagCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteLibraryItems(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vsphereClient := mock.NewMockAPI(mockCtrl)
+
+	const sharedID = "shared-infra-id"
+
+	listFails := func(m *types.ClusterMetadata) {
+		m.InfraID = listFailsID
+	}
+	deleteFails := func(m *types.ClusterMetadata) {
+		m.InfraID = deleteFailsID
+	}
+	shared := func(m *types.ClusterMetadata) {
+		m.InfraID = sharedID
+	}
+
+	cases := []testCase{
+		{
+			name:      "Delete Library Items succeeds",
+			editFuncs: editMetadataFuncs{},
+			errorMsg:  "",
+		},
+		{
+			name:      "Library Item still used by another cluster",
+			editFuncs: editMetadataFuncs{shared},
+			errorMsg:  "",
+		},
+		{
+			name:      "List Library Items fails",
+			editFuncs: editMetadataFuncs{listFails},
+			errorMsg:  "some vsphere error",
+		},
+		{
+			name:      "Delete Library Item fails",
+			editFuncs: editMetadataFuncs{deleteFails},
+			errorMsg:  "some vsphere error",
+		},
+	}
+
+	vsphereClient.
+		EXPECT().
+		ListLibraryItems(gomock.Any(), gomock.Eq(listFailsID)).
+		Return(nil, errors.New("some vsphere error listing Library Items")).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		ListLibraryItems(gomock.Any(), gomock.Eq(deleteFailsID)).
+		Return([]string{"failing-item"}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		ListLibraryItems(gomock.Any(), gomock.Eq(sharedID)).
+		Return([]string{"shared-item"}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		ListLibraryItems(gomock.Any(), gomock.Any()).
+		Return([]string{"item"}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		LibraryItemClusters(gomock.Any(), gomock.Eq("shared-item")).
+		Return([]string{sharedID, "other-infra-id"}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		LibraryItemClusters(gomock.Any(), gomock.Eq("failing-item")).
+		Return([]string{deleteFailsID}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		LibraryItemClusters(gomock.Any(), gomock.Any()).
+		Return([]string{infraID}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteLibraryItem(gomock.Any(), gomock.Eq("failing-item")).
+		Return(errors.New("some vsphere error deleting Library Item")).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteLibraryItem(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			editedMetadata := newDefaultMetadata()
+			for _, edit := range tc.editFuncs {
+				edit(&editedMetadata)
+			}
+			uninstaller := newWithClient(nullLogger, &editedMetadata, vsphereClient)
+			assert.NotNil(t, uninstaller)
+			err := uninstaller.deleteLibraryItems(context.TODO())
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteCNSVolumes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vsphereClient := mock.NewMockAPI(mockCtrl)
+
+	volume := func(name, id string) cnstypes.CnsVolume {
+		return cnstypes.CnsVolume{Name: name, VolumeId: cnstypes.CnsVolumeId{Id: id}}
+	}
+
+	listFails := func(m *types.ClusterMetadata) {
+		m.InfraID = listFailsID
+	}
+	deleteFails := func(m *types.ClusterMetadata) {
+		m.InfraID = deleteFailsID
+	}
+
+	cases := []testCase{
+		{
+			name:      "Delete CNS Volumes succeeds",
+			editFuncs: editMetadataFuncs{},
+			errorMsg:  "",
+		},
+		{
+			name:      "List CNS Volumes fails",
+			editFuncs: editMetadataFuncs{listFails},
+			errorMsg:  "some vsphere error",
+		},
+		{
+			name:      "Delete CNS Volume fails",
+			editFuncs: editMetadataFuncs{deleteFails},
+			errorMsg:  "some vsphere error",
+		},
+	}
+
+	vsphereClient.
+		EXPECT().
+		ListCNSVolumes(gomock.Any(), gomock.Eq(listFailsID)).
+		Return(nil, errors.New("some vsphere error listing CNS Volumes")).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		ListCNSVolumes(gomock.Any(), gomock.Eq(deleteFailsID)).
+		Return([]cnstypes.CnsVolume{volume("pvc-1", "volume-1"), volume("pvc-fail", "failing-volume")}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		ListCNSVolumes(gomock.Any(), gomock.Any()).
+		Return([]cnstypes.CnsVolume{volume("pvc-1", "volume-1"), volume("pvc-2", "volume-2")}, nil).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteCNSVolume(gomock.Any(), gomock.Eq("failing-volume")).
+		Return(errors.New("some vsphere error deleting CNS Volume")).
+		AnyTimes()
+	vsphereClient.
+		EXPECT().
+		DeleteCNSVolume(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			editedMetadata := newDefaultMetadata()
+			for _, edit := range tc.editFuncs {
+				edit(&editedMetadata)
+			}
+			uninstaller := newWithClient(nullLogger, &editedMetadata, vsphereClient)
+			assert.NotNil(t, uninstaller)
+			err := uninstaller.deleteCNSVolumes(context.TODO())
+			if tc.errorMsg != "" {
+				assert.Regexp(t, tc.errorMsg, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListResources(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	vsphereClient := mock.NewMockAPI(mockCtrl)
+
+	folder := mo.Folder{}
+	folder.Name = infraID
+
+	vsphereClient.
+		EXPECT().
+		ListVirtualMachines(gomock.Any(), gomock.Eq(infraID)).
+		Return([]mo.VirtualMachine{runningVM, stoppedVM}, nil)
+	vsphereClient.
+		EXPECT().
+		ListFolders(gomock.Any(), gomock.Eq(infraID)).
+		Return([]mo.Folder{folder}, nil)
+	vsphereClient.
+		EXPECT().
+		ListLibraryItems(gomock.Any(), gomock.Eq(infraID)).
+		Return([]string{"item", "shared-item"}, nil)
+	vsphereClient.
+		EXPECT().
+		LibraryItemClusters(gomock.Any(), gomock.Eq("item")).
+		Return([]string{infraID}, nil)
+	vsphereClient.
+		EXPECT().
+		LibraryItemClusters(gomock.Any(), gomock.Eq("shared-item")).
+		Return([]string{infraID, "other-infra-id"}, nil)
+	vsphereClient.
+		EXPECT().
+		ListCNSVolumes(gomock.Any(), gomock.Eq(infraID)).
+		Return([]cnstypes.CnsVolume{{Name: "pvc-1", VolumeId: cnstypes.CnsVolumeId{Id: "volume-1"}}}, nil)
+
+	metadata := newDefaultMetadata()
+	uninstaller := newWithClient(nullLogger, &metadata, vsphereClient)
+	resources, err := uninstaller.listResources(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"VirtualMachine runningVM",
+		"VirtualMachine stoppedVM",
+		"Folder infra-id",
+		"LibraryItem item",
+		"CNSVolume pvc-1 (volume-1)",
+		"StoragePolicy openshift-storage-policy-infra-id",
+		"Tag infra-id",
+		"TagCategory openshift-infra-id",
+	}, resources)
+}
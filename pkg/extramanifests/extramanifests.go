@@ -0,0 +1,224 @@
+// Package extramanifests applies the manifests of the extra-manifests
+// directory to the cluster once its control plane is up. Unlike the manifests
+// embedded in the bootstrap ignition, they may depend on the operators
+// installed with the cluster, like the namespaced custom resources of the
+// operators installed by OLM.
+package extramanifests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DirName is the directory of the extra manifests, in the assets
+	// directory.
+	DirName = "extra-manifests"
+
+	// fieldManager owns the fields set by the extra manifests, so that they
+	// can be applied again after they were changed in the cluster.
+	fieldManager = "openshift-install"
+
+	retryInterval = 10 * time.Second
+)
+
+// Manifest is an object of the extra manifests.
+type Manifest struct {
+	// File is the path of the manifest, relative to the extra manifests
+	// directory.
+	File   string
+	Object *unstructured.Unstructured
+}
+
+func (m *Manifest) String() string {
+	name := m.Object.GetName()
+	if ns := m.Object.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+	return fmt.Sprintf("%s %s (%s)", m.Object.GetKind(), name, m.File)
+}
+
+// Load reads the YAML and JSON manifests of the extra manifests directory in
+// the assets directory. The objects are ordered by file name, except for the
+// namespaces and the custom resource definitions that come first since the
+// other objects may depend on them. It returns no manifest if the directory
+// does not exist.
+func Load(assetsDir string) ([]*Manifest, error) {
+	dir := filepath.Join(assetsDir, DirName)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var manifests []*Manifest
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		fileManifests, err := decode(entry.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, fileManifests...)
+	}
+
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return rank(manifests[i].Object) < rank(manifests[j].Object)
+	})
+	return manifests, nil
+}
+
+func decode(file string, data []byte) ([]*Manifest, error) {
+	var manifests []*Manifest
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		raw, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if s := strings.TrimSpace(string(raw)); s == "null" || s == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("%s: %s has no name", file, obj.GetKind())
+		}
+		manifests = append(manifests, &Manifest{File: file, Object: obj})
+	}
+}
+
+func rank(obj *unstructured.Unstructured) int {
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Namespace":
+		return 0
+	case "CustomResourceDefinition.apiextensions.k8s.io":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Apply applies the manifests to the cluster with server-side apply. The
+// manifests that fail, because their kind is not served yet or their
+// namespace does not exist yet, are retried until the timeout expires.
+func Apply(ctx context.Context, config *rest.Config, manifests []*Manifest, timeout time.Duration) error {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create the discovery client: %w", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create the dynamic client: %w", err)
+	}
+
+	pending := manifests
+	var errs []error
+	err = wait.PollUntilContextTimeout(ctx, retryInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		// The API resources are discovered again on every attempt, to find
+		// the custom resources defined since the last one.
+		groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+		if err != nil {
+			logrus.Debugf("Failed to discover the API resources: %v", err)
+			return false, nil
+		}
+		pending, errs = applyPending(ctx, client, restmapper.NewDiscoveryRESTMapper(groupResources), pending)
+		for _, err := range errs {
+			logrus.Debug(err)
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to apply %d extra manifests: %w", len(pending), utilerrors.NewAggregate(errs))
+		}
+		return fmt.Errorf("failed to apply %d extra manifests: %w", len(pending), err)
+	}
+	return nil
+}
+
+// applyPending applies the manifests in order, and returns those that
+// failed with their errors.
+func applyPending(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, manifests []*Manifest) ([]*Manifest, []error) {
+	var failed []*Manifest
+	var errs []error
+	for _, m := range manifests {
+		if err := apply(ctx, client, mapper, m.Object); err != nil {
+			failed = append(failed, m)
+			errs = append(errs, fmt.Errorf("%s: %w", m, err))
+			continue
+		}
+		logrus.Infof("Applied %s", m)
+	}
+	return failed, errs
+}
+
+func apply(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	var resource dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := obj.GetNamespace()
+		if ns == "" {
+			ns = metav1.NamespaceDefault
+		}
+		resource = client.Resource(mapping.Resource).Namespace(ns)
+	}
+	force := true
+	_, err = resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+	})
+	return err
+}
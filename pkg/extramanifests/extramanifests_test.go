@@ -0,0 +1,92 @@
+package extramanifests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		expected []string
+		err      string
+	}{
+		{
+			name: "no directory",
+		},
+		{
+			name: "ordered manifests",
+			files: map[string]string{
+				"01-subscription.yaml": `apiVersion: operators.coreos.com/v1alpha1
+kind: Subscription
+metadata:
+  name: widgets
+  namespace: openshift-widgets
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: big
+  namespace: openshift-widgets
+---
+`,
+				"00-namespace.yml": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: openshift-widgets
+`,
+				"02-crd.json": `{"apiVersion": "apiextensions.k8s.io/v1", "kind": "CustomResourceDefinition", "metadata": {"name": "gadgets.example.com"}}`,
+				"README.md":   "not a manifest",
+			},
+			expected: []string{
+				"Namespace openshift-widgets (00-namespace.yml)",
+				"CustomResourceDefinition gadgets.example.com (02-crd.json)",
+				"Subscription openshift-widgets/widgets (01-subscription.yaml)",
+				"Widget openshift-widgets/big (01-subscription.yaml)",
+			},
+		},
+		{
+			name: "no name",
+			files: map[string]string{
+				"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: default\n",
+			},
+			err: "cm.yaml: ConfigMap has no name",
+		},
+		{
+			name: "no kind",
+			files: map[string]string{
+				"cm.yaml": "apiVersion: v1\nmetadata:\n  name: cm\n",
+			},
+			err: `failed to decode cm.yaml: Object 'Kind' is missing in '{"apiVersion":"v1","metadata":{"name":"cm"}}'`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tc.files != nil {
+				assert.NoError(t, os.Mkdir(filepath.Join(dir, DirName), 0o755))
+				for name, data := range tc.files {
+					assert.NoError(t, os.WriteFile(filepath.Join(dir, DirName, name), []byte(data), 0o600))
+				}
+			}
+			manifests, err := Load(dir)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			actual := make([]string, 0, len(manifests))
+			for _, m := range manifests {
+				actual = append(actual, m.String())
+			}
+			if tc.expected == nil {
+				tc.expected = []string{}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -186,8 +186,17 @@ func (g *Gather) downloadConsoleOutput(ctx context.Context, ec2Client *ec2.EC2,
 
 	input := &ec2.GetConsoleOutputInput{
 		InstanceId: instance.InstanceId,
+		Latest:     aws.Bool(true),
 	}
 	result, err := ec2Client.GetConsoleOutputWithContext(ctx, input)
+	if err != nil {
+		// Only the instances built on the Nitro System provide the latest
+		// output; the others provide the output buffered at their last
+		// state change.
+		logger.Debugf("Failed to get the latest console output, falling back to the buffered output: %v", err)
+		input.Latest = nil
+		result, err = ec2Client.GetConsoleOutputWithContext(ctx, input)
+	}
 	if err != nil {
 		// Cast err to awserr.Error to get the Message from an error.
 		if aerr, ok := err.(awserr.Error); ok {
@@ -0,0 +1,207 @@
+// Package redact strips the secrets, and optionally the IP addresses, from
+// the install logs and the gathered log bundles, so that they can be
+// attached to support cases.
+package redact
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Redacted replaces the redacted values.
+const Redacted = "REDACTED"
+
+var (
+	// secretPatterns match the secrets. The first group of the match is kept,
+	// and the rest of it is redacted.
+	secretPatterns = []*regexp.Regexp{
+		// Private keys, whole.
+		regexp.MustCompile(`()-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+		// The auths of the pull secrets, in JSON or escaped in a JSON string.
+		regexp.MustCompile(`(\\*"auth\\*"[ \t]*:[ \t]*\\*")([^"\\]+)`),
+		// Passwords, secrets, tokens and keys in key-value pairs.
+		regexp.MustCompile(`(?i)((?:password|passwd|secret|token|apikey|api_key|access_key|secret_key|client_secret)\\*"?[ \t]*[:=][ \t]*\\*["']?)([^\s"'\\,{}[\]&]+)`),
+		// Bearer tokens of the Authorization headers.
+		regexp.MustCompile(`(?i)(bearer[ \t]+)([A-Za-z0-9\-._~+/]+=*)`),
+		// OpenShift OAuth access tokens.
+		regexp.MustCompile(`()(sha256~[A-Za-z0-9_-]+)`),
+		// AWS session tokens.
+		regexp.MustCompile(`(?i)(X-Amz-Security-Token=)([^&\s"]+)`),
+	}
+
+	ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern = regexp.MustCompile(`\b(?:[0-9a-fA-F]{0,4}:){2,7}[0-9a-fA-F]{0,4}\b`)
+)
+
+// Redactor redacts the secrets of the content it is given.
+type Redactor struct {
+	// IPs makes the redactor redact the IP addresses too.
+	IPs bool
+}
+
+// Bytes returns the content with its secrets redacted.
+func (r *Redactor) Bytes(content []byte) []byte {
+	for _, pattern := range secretPatterns {
+		content = pattern.ReplaceAll(content, []byte("${1}"+Redacted))
+	}
+	if r.IPs {
+		for _, pattern := range []*regexp.Regexp{ipv4Pattern, ipv6Pattern} {
+			content = pattern.ReplaceAllFunc(content, func(match []byte) []byte {
+				// The patterns match more than addresses, e.g. times.
+				if net.ParseIP(string(match)) == nil {
+					return match
+				}
+				return []byte(Redacted)
+			})
+		}
+	}
+	return content
+}
+
+// String returns the content with its secrets redacted.
+func (r *Redactor) String(content string) string {
+	return string(r.Bytes([]byte(content)))
+}
+
+// Formatter is a logrus formatter redacting the secrets of the entries
+// formatted by the wrapped formatter.
+type Formatter struct {
+	logrus.Formatter
+	Redactor *Redactor
+}
+
+// Format formats the entry with the wrapped formatter, and redacts it.
+func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	line, err := f.Formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	return f.Redactor.Bytes(line), nil
+}
+
+// IsArchive returns true if the path is a gzipped tarball, like the log
+// bundles.
+func IsArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// File redacts the file at the source path into the destination path. The
+// files of the gzipped tarballs are redacted one by one.
+func (r *Redactor) File(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if IsArchive(src) {
+		err = r.archive(out, in)
+	} else {
+		var content []byte
+		if content, err = io.ReadAll(in); err == nil {
+			_, err = out.Write(r.content(src, content))
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to redact %s", src)
+	}
+	return out.Close()
+}
+
+// archive redacts the files of the gzipped tarball.
+func (r *Redactor) archive(dst io.Writer, src io.Reader) error {
+	gzipReader, err := gzip.NewReader(src)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	gzipWriter := gzip.NewWriter(dst)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return err
+			}
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", header.Name)
+		}
+		content = r.content(header.Name, content)
+		header.Size = int64(len(content))
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// content redacts the content of the named file. The gzipped files are
+// redacted uncompressed, and the binary files are kept as is.
+func (r *Redactor) content(name string, content []byte) []byte {
+	if IsArchive(name) {
+		var buf bytes.Buffer
+		if err := r.archive(&buf, bytes.NewReader(content)); err != nil {
+			return content
+		}
+		return buf.Bytes()
+	}
+	if strings.HasSuffix(name, ".gz") {
+		gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return content
+		}
+		defer gzipReader.Close()
+		uncompressed, err := io.ReadAll(gzipReader)
+		if err != nil {
+			return content
+		}
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		if _, err := gzipWriter.Write(r.content(strings.TrimSuffix(name, ".gz"), uncompressed)); err != nil {
+			return content
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return content
+		}
+		return buf.Bytes()
+	}
+	if bytes.IndexByte(content[:min(len(content), 8192)], 0) >= 0 {
+		return content
+	}
+	return r.Bytes(content)
+}
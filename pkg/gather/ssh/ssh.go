@@ -25,7 +25,56 @@ func NewClient(user, address string, keys []string) (*ssh.Client, error) {
 		return nil, errors.Wrap(err, "failed to initialize the SSH agent")
 	}
 
-	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+	client, err := ssh.Dial("tcp", address, clientConfig(user, ag))
+	if err != nil {
+		return nil, authError(err, agentType)
+	}
+	if err := agent.ForwardToAgent(client, ag); err != nil {
+		return nil, errors.Wrap(err, "failed to forward agent")
+	}
+	return client, nil
+}
+
+// NewClientThroughJumpHost creates a new SSH client which can be used to SSH
+// to address through the jump host at jumpAddress, e.g. a bastion host
+// reaching a private network, using user and the keys on both hosts.
+//
+// if keys list is empty, it tries to load the keys from the user's environment.
+func NewClientThroughJumpHost(user, jumpAddress, address string, keys []string) (*ssh.Client, error) {
+	ag, agentType, err := getAgent(keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize the SSH agent")
+	}
+
+	jump, err := ssh.Dial("tcp", jumpAddress, clientConfig(user, ag))
+	if err != nil {
+		return nil, errors.Wrapf(authError(err, agentType), "failed to connect to the jump host %s", jumpAddress)
+	}
+	conn, err := jump.Dial("tcp", address)
+	if err != nil {
+		jump.Close()
+		return nil, err
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, clientConfig(user, ag))
+	if err != nil {
+		conn.Close()
+		jump.Close()
+		return nil, authError(err, agentType)
+	}
+	client := ssh.NewClient(c, chans, reqs)
+	go func() {
+		client.Wait()
+		jump.Close()
+	}()
+	if err := agent.ForwardToAgent(client, ag); err != nil {
+		client.Close()
+		return nil, errors.Wrap(err, "failed to forward agent")
+	}
+	return client, nil
+}
+
+func clientConfig(user string, ag agent.Agent) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
 		User: user,
 		Auth: []ssh.AuthMethod{
 			// Use a callback rather than PublicKeys
@@ -34,20 +83,17 @@ func NewClient(user, address string, keys []string) (*ssh.Client, error) {
 			ssh.PublicKeysCallback(ag.Signers),
 		},
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	})
-	if err != nil {
-		if strings.Contains(err.Error(), "ssh: handshake failed: ssh: unable to authenticate") {
-			if agentType == "agent" {
-				return nil, errors.Wrap(err, "failed to use pre-existing agent, make sure the appropriate keys exist in the agent for authentication")
-			}
-			return nil, errors.Wrap(err, "failed to use the provided keys for authentication")
-		}
-		return nil, err
 	}
-	if err := agent.ForwardToAgent(client, ag); err != nil {
-		return nil, errors.Wrap(err, "failed to forward agent")
+}
+
+func authError(err error, agentType string) error {
+	if strings.Contains(err.Error(), "ssh: handshake failed: ssh: unable to authenticate") {
+		if agentType == "agent" {
+			return errors.Wrap(err, "failed to use pre-existing agent, make sure the appropriate keys exist in the agent for authentication")
+		}
+		return errors.Wrap(err, "failed to use the provided keys for authentication")
 	}
-	return client, nil
+	return err
 }
 
 // Run uses an SSH client to execute commands.
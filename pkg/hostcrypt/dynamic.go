@@ -10,8 +10,16 @@ func allowFIPSCluster() error {
 	if err != nil {
 		return err
 	}
-	if fipsEnabled {
-		return nil
+	if !fipsEnabled {
+		return fmt.Errorf("enable FIPS mode on the host")
 	}
-	return fmt.Errorf("enable FIPS mode on the host")
+
+	policyFIPS, policy, err := hostCryptoPolicyFIPS()
+	if err != nil {
+		return err
+	}
+	if !policyFIPS {
+		return fmt.Errorf("the host crypto policy is %s, set it to FIPS with update-crypto-policies --set FIPS", policy)
+	}
+	return nil
 }
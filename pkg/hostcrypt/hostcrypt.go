@@ -1,7 +1,9 @@
 package hostcrypt
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"runtime"
 	"strconv"
@@ -9,7 +11,8 @@ import (
 )
 
 const (
-	fipsFile = "/proc/sys/crypto/fips_enabled"
+	fipsFile         = "/proc/sys/crypto/fips_enabled"
+	cryptoPolicyFile = "/etc/crypto-policies/state/current"
 )
 
 // VerifyHostTargetState checks that the current binary matches the expected cryptographic state
@@ -42,3 +45,19 @@ func hostFIPSEnabled() (bool, error) {
 
 	return hostFIPS, nil
 }
+
+// hostCryptoPolicyFIPS returns whether the system-wide crypto policy of the
+// host is FIPS, or a subpolicy of it. Hosts without crypto policies are
+// considered to comply.
+func hostCryptoPolicyFIPS() (bool, string, error) {
+	data, err := os.ReadFile(cryptoPolicyFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, "", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read the host crypto policy %s: %w", cryptoPolicyFile, err)
+	}
+
+	policy := strings.TrimSpace(string(data))
+	return policy == "FIPS" || strings.HasPrefix(policy, "FIPS:"), policy, nil
+}
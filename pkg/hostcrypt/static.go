@@ -11,6 +11,8 @@ func allowFIPSCluster() error {
 	hostMsg := ""
 	if fipsEnabled, err := hostFIPSEnabled(); err != nil || !fipsEnabled {
 		hostMsg = " on a host with FIPS enabled"
+	} else if policyFIPS, _, err := hostCryptoPolicyFIPS(); err != nil || !policyFIPS {
+		hostMsg = " on a host with the FIPS crypto policy"
 	}
 	return fmt.Errorf("use the FIPS-capable installer binary for RHEL 9%s.\n%s",
 		hostMsg, binaryInstructions)
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/sirupsen/logrus"
 	"k8s.io/utils/ptr"
 	capa "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -15,15 +16,20 @@ import (
 	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
 	"github.com/openshift/installer/pkg/infrastructure/clusterapi"
+	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 var _ clusterapi.Provider = (*Provider)(nil)
 var _ clusterapi.PreProvider = (*Provider)(nil)
 var _ clusterapi.InfraReadyProvider = (*Provider)(nil)
+var _ clusterapi.ResourcesProvider = (*Provider)(nil)
 
 // Provider implements AWS CAPI installation.
-type Provider struct{}
+type Provider struct {
+	// resources are the cluster resources discovered or created in InfraReady.
+	resources *types.ClusterResources
+}
 
 // Name gives the name of the provider, AWS.
 func (*Provider) Name() string { return awstypes.Name }
@@ -34,7 +40,7 @@ func (*Provider) PreProvision(ctx context.Context, in clusterapi.PreProvisionInp
 		return fmt.Errorf("failed to create IAM roles: %w", err)
 	}
 
-	amiID, err := copyAMIToRegion(ctx, in.InstallConfig, in.InfraID, string(*in.RhcosImage))
+	amiID, err := copyAMIToRegion(ctx, in.InstallConfig, in.InfraID, in.RhcosImage.ControlPlane)
 	if err != nil {
 		return fmt.Errorf("failed to copy AMI: %w", err)
 	}
@@ -46,8 +52,9 @@ func (*Provider) PreProvision(ctx context.Context, in clusterapi.PreProvisionInp
 	return nil
 }
 
-// InfraReady creates private hosted zone and DNS records.
-func (*Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput) error {
+// InfraReady creates private hosted zone and DNS records, and restricts the
+// bootstrap ignition bucket to the VPC endpoint with the VPC endpoint delivery.
+func (p *Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput) error {
 	awsCluster := &capa.AWSCluster{}
 	key := k8sClient.ObjectKey{
 		Name:      in.InfraID,
@@ -113,9 +120,62 @@ func (*Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput)
 		return fmt.Errorf("failed to create route53 records: %w", err)
 	}
 
+	if lb := in.InstallConfig.Config.AWS.APILoadBalancer; lb != nil && len(lb.AdditionalTags) > 0 {
+		logrus.Infoln("Tagging the API load balancers")
+		if err := tagAPILoadBalancers(ctx, awsSession, awsCluster, lb.AdditionalTags); err != nil {
+			return fmt.Errorf("failed to tag the API load balancers: %w", err)
+		}
+	}
+
+	if in.InstallConfig.Config.BootstrapIgnitionDelivery() == types.BootstrapIgnitionDeliveryVPCEndpoint && awsCluster.Spec.S3Bucket != nil {
+		vpcEndpointID := in.InstallConfig.Config.BootstrapIgnition.VPCEndpointID
+		logrus.Infof("Restricting the bootstrap ignition bucket to VPC endpoint %s", vpcEndpointID)
+		if err := restrictIgnitionBucket(ctx, awsSession, awsCluster.Spec.Region, awsCluster.Spec.S3Bucket.Name, vpcEndpointID); err != nil {
+			return fmt.Errorf("failed to restrict the bootstrap ignition bucket: %w", err)
+		}
+	}
+
+	p.resources = &types.ClusterResources{
+		NetworkID:        vpcID,
+		SubnetIDs:        subnetIDs,
+		PrivateDNSZoneID: phzID,
+	}
 	return nil
 }
 
+// Resources returns the VPC, subnets and private hosted zone of the cluster.
+func (p *Provider) Resources(_ context.Context, _ clusterapi.ResourcesInput) (*types.ClusterResources, error) {
+	if p.resources == nil {
+		return nil, fmt.Errorf("the cluster resources are not known before the infrastructure is ready")
+	}
+	return p.resources, nil
+}
+
+// tagAPILoadBalancers adds the additional tags of the install config to the
+// API load balancers created by CAPA, which only tags them with the user tags.
+func tagAPILoadBalancers(ctx context.Context, awsSession *session.Session, awsCluster *capa.AWSCluster, tags map[string]string) error {
+	var arns []string
+	for _, lb := range []capa.LoadBalancer{awsCluster.Status.Network.APIServerELB, awsCluster.Status.Network.SecondaryAPIServerELB} {
+		if lb.ARN != "" {
+			arns = append(arns, lb.ARN)
+		}
+	}
+	if len(arns) == 0 {
+		return nil
+	}
+
+	elbTags := make([]*elbv2.Tag, 0, len(tags))
+	for k, v := range tags {
+		elbTags = append(elbTags, &elbv2.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	client := elbv2.New(awsSession, aws.NewConfig().WithRegion(awsCluster.Spec.Region))
+	_, err := client.AddTagsWithContext(ctx, &elbv2.AddTagsInput{
+		ResourceArns: aws.StringSlice(arns),
+		Tags:         elbTags,
+	})
+	return err
+}
+
 func getVPCFromSubnets(ctx context.Context, awsSession *session.Session, region string, subnetIDs []string) (string, error) {
 	var vpcID string
 	var lastError error
@@ -0,0 +1,71 @@
+package clusterapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// bucketPolicy is an S3 bucket policy. The statements are kept as they are,
+// so that the statements set by CAPA are not altered.
+type bucketPolicy struct {
+	Version   string            `json:"Version"`
+	Statement []json.RawMessage `json:"Statement"`
+}
+
+// restrictIgnitionBucket denies reading the bootstrap ignition bucket other
+// than through the VPC endpoint, so that the bootstrap machine fetches the
+// bootstrap ignition through the VPC endpoint and the signed URL cannot be
+// used from outside the VPC.
+func restrictIgnitionBucket(ctx context.Context, awsSession *session.Session, region, bucket, vpcEndpointID string) error {
+	client := s3.New(awsSession, aws.NewConfig().WithRegion(region))
+
+	policy := bucketPolicy{Version: "2012-10-17"}
+	out, err := client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		var awsErr awserr.Error
+		if !errors.As(err, &awsErr) || awsErr.Code() != "NoSuchBucketPolicy" {
+			return fmt.Errorf("failed to get the policy of bucket %s: %w", bucket, err)
+		}
+	} else if err := json.Unmarshal([]byte(aws.StringValue(out.Policy)), &policy); err != nil {
+		return fmt.Errorf("failed to parse the policy of bucket %s: %w", bucket, err)
+	}
+
+	partition := endpoints.AwsPartitionID
+	if p, found := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); found {
+		partition = p.ID()
+	}
+	statement, err := json.Marshal(map[string]interface{}{
+		"Sid":       "DenyOutsideVPCEndpoint",
+		"Effect":    "Deny",
+		"Principal": "*",
+		"Action":    "s3:GetObject",
+		"Resource":  fmt.Sprintf("arn:%s:s3:::%s/*", partition, bucket),
+		"Condition": map[string]interface{}{
+			"StringNotEquals": map[string]string{"aws:SourceVpce": vpcEndpointID},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	policy.Statement = append(policy.Statement, statement)
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	if _, err := client.PutBucketPolicyWithContext(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(string(data)),
+	}); err != nil {
+		return fmt.Errorf("failed to set the policy of bucket %s: %w", bucket, err)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -20,7 +21,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster/metadata"
 	tfvarsAsset "github.com/openshift/installer/pkg/asset/cluster/tfvars"
 	awssession "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/infrastructure"
@@ -98,8 +101,9 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 	if err != nil {
 		return nil, err
 	}
+	awsSession.Handlers.Complete.PushBackNamed(errorContextHandler)
 
-	availabilityZones := sets.New(clusterAWSConfig.MasterSecurityGroups...)
+	availabilityZones := sets.New(clusterAWSConfig.MasterAvailabilityZones...)
 	availabilityZones.Insert(clusterAWSConfig.WorkerAvailabilityZones...)
 
 	tags := mergeTags(clusterAWSConfig.ExtraTags, map[string]string{
@@ -107,6 +111,7 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 	})
 
 	usePublicEndpoints := clusterAWSConfig.PublishStrategy == "External"
+	ipv6Only := clusterAWSConfig.IPFamily == string(awstypes.IPv6IPFamily)
 
 	logger := logrus.StandardLogger()
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
@@ -127,13 +132,17 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 		infraID:          clusterConfig.ClusterID,
 		region:           clusterAWSConfig.Region,
 		vpcID:            clusterAWSConfig.VPC,
-		cidrV4Block:      clusterConfig.MachineV4CIDRs[0],
 		zones:            sets.List(availabilityZones),
 		tags:             tags,
 		privateSubnetIDs: clusterAWSConfig.PrivateSubnets,
 		edgeZones:        clusterAWSConfig.EdgeLocalZones,
 		edgeParentMap:    clusterAWSConfig.EdgeZonesGatewayIndex,
 	}
+	// IPv6 clusters have no IPv4 machine network and always use
+	// existing subnets.
+	if len(clusterConfig.MachineV4CIDRs) > 0 {
+		vpcInput.cidrV4Block = clusterConfig.MachineV4CIDRs[0]
+	}
 	if clusterAWSConfig.PublicSubnets != nil {
 		vpcInput.publicSubnetIDs = *clusterAWSConfig.PublicSubnets
 	}
@@ -145,18 +154,43 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 
 	logger.Infoln("Creating Load Balancer resources")
 	elbClient := elbv2.New(awsSession)
+	apiLBSubnetIDs := vpcOutput.publicSubnetIDs
+	if len(clusterAWSConfig.APILBSubnets) > 0 {
+		apiLBSubnetIDs = clusterAWSConfig.APILBSubnets
+	}
+	lbTags := mergeTags(clusterAWSConfig.APILBExtraTags, tags)
+	classicAPILB := usePublicEndpoints && clusterAWSConfig.APILBType == string(configv1.Classic)
 	lbInput := lbInputOptions{
 		infraID:          clusterConfig.ClusterID,
 		vpcID:            vpcOutput.vpcID,
 		privateSubnetIDs: vpcOutput.privateSubnetIDs,
-		publicSubnetIDs:  vpcOutput.publicSubnetIDs,
-		tags:             tags,
+		publicSubnetIDs:  apiLBSubnetIDs,
+		tags:             lbTags,
 		isPrivateCluster: !usePublicEndpoints,
+		classicExternal:  classicAPILB,
+		ipv6:             ipv6Only,
 	}
 	lbOutput, err := createLoadBalancers(ctx, logger, elbClient, &lbInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create load balancers: %w", err)
 	}
+	classicELBClient := elb.New(awsSession)
+	var classicLBName string
+	if classicAPILB {
+		classicLB, err := ensureClassicExternalLoadBalancer(ctx, logger, classicELBClient, ec2Client, &classicLBInputOptions{
+			infraID:     clusterConfig.ClusterID,
+			vpcID:       vpcOutput.vpcID,
+			subnetIDs:   apiLBSubnetIDs,
+			idleTimeout: clusterAWSConfig.APILBIdleTimeout,
+			tags:        lbTags,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create external classic load balancer: %w", err)
+		}
+		classicLBName = aws.StringValue(classicLB.LoadBalancerName)
+		lbOutput.external.zoneID = aws.StringValue(classicLB.CanonicalHostedZoneNameID)
+		lbOutput.external.dnsName = aws.StringValue(classicLB.DNSName)
+	}
 
 	logger.Infoln("Creating DNS resources")
 	r53Config := awssession.GetR53ClientCfg(awsSession, clusterAWSConfig.InternalZoneRole)
@@ -177,6 +211,7 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 		lbInternalZoneID:  lbOutput.internal.zoneID,
 		lbInternalZoneDNS: lbOutput.internal.dnsName,
 		isPrivateCluster:  !usePublicEndpoints,
+		ipv6:              ipv6Only,
 		internalZone:      clusterAWSConfig.InternalZone,
 	}
 	err = createDNSResources(ctx, logger, r53Client, assumedRoleClient, &dnsInput)
@@ -192,6 +227,9 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 		isPrivateCluster: !usePublicEndpoints,
 		tags:             tags,
 	}
+	if ipv6Only {
+		sgInput.cidrV6Blocks = clusterConfig.MachineV6CIDRs
+	}
 	sgOutput, err := createSecurityGroups(ctx, logger, ec2Client, &sgInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create security groups: %w", err)
@@ -219,11 +257,13 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 			volumeIOPS:         0,
 			isEncrypted:        true,
 			metadataAuth:       clusterAWSConfig.BootstrapMetadataAuthentication,
+			tenancy:            bootstrapTenancy(clusterAWSConfig.MasterTenancy),
 			kmsKeyID:           clusterAWSConfig.KMSKeyID,
 			securityGroupIds:   []string{sgOutput.bootstrap, sgOutput.controlPlane},
 			targetGroupARNs:    lbOutput.targetGroupArns,
 			subnetID:           bootstrapSubnet,
 			associatePublicIP:  usePublicEndpoints,
+			ipv6:               ipv6Only,
 			userData:           clusterAWSConfig.BootstrapIgnitionStub,
 			partitionDNSSuffix: partitionDNSSuffix,
 			tags:               tags,
@@ -251,9 +291,13 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 			isEncrypted:        clusterAWSConfig.Encrypted,
 			kmsKeyID:           clusterAWSConfig.KMSKeyID,
 			metadataAuth:       clusterAWSConfig.MasterMetadataAuthentication,
+			tenancy:            clusterAWSConfig.MasterTenancy,
+			hostResourceGroup:  clusterAWSConfig.MasterHostResourceGroupARN,
+			reservationID:      clusterAWSConfig.MasterCapacityReservationID,
 			securityGroupIds:   append(clusterAWSConfig.MasterSecurityGroups, sgOutput.controlPlane),
 			targetGroupARNs:    lbOutput.targetGroupArns,
 			associatePublicIP:  len(os.Getenv("OPENSHIFT_INSTALL_AWS_PUBLIC_ONLY")) > 0,
+			ipv6:               ipv6Only,
 			userData:           clusterConfig.IgnitionMaster,
 			partitionDNSSuffix: partitionDNSSuffix,
 			tags:               tags,
@@ -262,12 +306,21 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 		privateSubnetIDs:  vpcOutput.privateSubnetIDs,
 		zoneToSubnetMap:   vpcOutput.zoneToSubnetMap,
 		availabilityZones: clusterAWSConfig.MasterAvailabilityZones,
+		hostIDs:           clusterAWSConfig.MasterHostIDs,
 	}
 	controlPlaneOut, err := createControlPlaneResources(ctx, logger, ec2Client, iamClient, elbClient, &controlPlaneInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create control plane resources: %w", err)
 	}
 
+	if classicAPILB {
+		instanceIDs := append([]string{bootstrapOut.instanceID}, controlPlaneOut.controlPlaneInstanceIDs...)
+		if err := registerClassicLoadBalancerInstances(ctx, classicELBClient, classicLBName, instanceIDs); err != nil {
+			return nil, err
+		}
+		logger.Infoln("Registered instances with the external classic load balancer")
+	}
+
 	logger.Infoln("Creating compute resources")
 	computeInput := computeInputOptions{
 		infraID:            clusterConfig.ClusterID,
@@ -297,8 +350,17 @@ func (a InfraProvider) Provision(ctx context.Context, dir string, parents asset.
 	if err != nil {
 		return nil, fmt.Errorf("failed to write cluster output: %w", err)
 	}
+
+	metadataFile, err := metadata.WithResources(dir, &types.ClusterResources{
+		NetworkID: vpcOutput.vpcID,
+		SubnetIDs: append(append([]string{}, vpcOutput.privateSubnetIDs...), vpcOutput.publicSubnetIDs...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record the cluster resources: %w", err)
+	}
 	return []*asset.File{
 		{Filename: clusterOutputFileName, Data: data},
+		metadataFile,
 	}, nil
 }
 
@@ -340,6 +402,7 @@ func (a InfraProvider) DestroyBootstrap(dir string) error {
 		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
 		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
 	})
+	awsSession.Handlers.Complete.PushBackNamed(errorContextHandler)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
@@ -359,6 +422,17 @@ func (a InfraProvider) DestroyBootstrap(dir string) error {
 	return nil
 }
 
+// Destroy removes the cluster outputs and the variables files from the
+// install dir.
+func (a InfraProvider) Destroy(dir string) error {
+	for _, filename := range []string{clusterOutputFileName, tfVarsFileName, tfPlatformVarsFileName} {
+		if err := os.Remove(filepath.Join(dir, filename)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
 // ExtractHostAddresses extracts the IPs of the bootstrap and control plane machines.
 func (a InfraProvider) ExtractHostAddresses(dir string, ic *types.InstallConfig, ha *infrastructure.HostAddresses) error {
 	clusterOutput := &output{}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -26,8 +27,9 @@ type bootstrapInputOptions struct {
 }
 
 type bootstrapOutput struct {
-	privateIP string
-	publicIP  string
+	instanceID string
+	privateIP  string
+	publicIP   string
 }
 
 func createBootstrapResources(ctx context.Context, logger logrus.FieldLogger, ec2Client ec2iface.EC2API, iamClient iamiface.IAMAPI, s3Client s3iface.S3API, elbClient elbv2iface.ELBV2API, input *bootstrapInputOptions) (*bootstrapOutput, error) {
@@ -50,8 +52,9 @@ func createBootstrapResources(ctx context.Context, logger logrus.FieldLogger, ec
 	}
 
 	return &bootstrapOutput{
-		privateIP: aws.StringValue(instance.PrivateIpAddress),
-		publicIP:  aws.StringValue(instance.PublicIpAddress),
+		instanceID: aws.StringValue(instance.InstanceId),
+		privateIP:  aws.StringValue(instanceIP(instance, input.ipv6)),
+		publicIP:   aws.StringValue(instance.PublicIpAddress),
 	}, nil
 }
 
@@ -212,3 +215,13 @@ func createBootstrapInstanceProfile(ctx context.Context, logger logrus.FieldLogg
 	}
 	return createInstanceProfile(ctx, logger, client, profileInput)
 }
+
+// bootstrapTenancy returns the tenancy of the bootstrap instance. The capacity
+// of dedicated hosts is reserved for the control plane, so the bootstrap
+// instance runs on dedicated hardware instead.
+func bootstrapTenancy(masterTenancy string) string {
+	if masterTenancy == ec2.TenancyHost {
+		return ec2.TenancyDedicated
+	}
+	return masterTenancy
+}
@@ -0,0 +1,153 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultClassicIdleTimeout is the idle timeout, in seconds, of the Classic
+// external API load balancer, long enough not to cut the API watches.
+const defaultClassicIdleTimeout = 600
+
+type classicLBInputOptions struct {
+	infraID     string
+	vpcID       string
+	subnetIDs   []string
+	idleTimeout int64
+	tags        map[string]string
+}
+
+// ensureClassicExternalLoadBalancer creates the Classic external API load
+// balancer, which is used instead of the external Network Load Balancer when
+// the install config asks for it. Unlike the Network Load Balancers, it needs
+// a security group to accept the API traffic, and its targets are instances.
+func ensureClassicExternalLoadBalancer(ctx context.Context, logger logrus.FieldLogger, elbClient *elb.ELB, ec2Client ec2iface.EC2API, input *classicLBInputOptions) (*elb.LoadBalancerDescription, error) {
+	lbName := fmt.Sprintf("%s-ext", input.infraID)
+	l := logger.WithField("name", lbName)
+
+	sgName := fmt.Sprintf("%s-apilb-sg", input.infraID)
+	sg, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, sgName, false, input.tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security group: %w", err)
+	}
+	ingress := createSGRule(sg.GroupId, "tcp", []string{"0.0.0.0/0"}, nil, apiPort, apiPort, false, nil)
+	if err := authorizeIngressRules(ctx, ec2Client, sg, []*ec2.IpPermission{ingress}); err != nil {
+		return nil, fmt.Errorf("failed to attach ingress rules to security group: %w", err)
+	}
+
+	createdOrFoundMsg := "Found existing load balancer"
+	lb, err := existingClassicLoadBalancer(ctx, elbClient, lbName)
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			return nil, err
+		}
+		createdOrFoundMsg = "Created load balancer"
+		_, err = elbClient.CreateLoadBalancerWithContext(ctx, &elb.CreateLoadBalancerInput{
+			LoadBalancerName: aws.String(lbName),
+			Listeners: []*elb.Listener{{
+				Protocol:         aws.String("TCP"),
+				LoadBalancerPort: aws.Int64(apiPort),
+				InstanceProtocol: aws.String("TCP"),
+				InstancePort:     aws.Int64(apiPort),
+			}},
+			Scheme:         aws.String("internet-facing"),
+			SecurityGroups: []*string{sg.GroupId},
+			Subnets:        aws.StringSlice(input.subnetIDs),
+			Tags:           classicELBTags(input.tags),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create load balancer: %w", err)
+		}
+		lb, err = existingClassicLoadBalancer(ctx, elbClient, lbName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	l.Infoln(createdOrFoundMsg)
+
+	_, err = elbClient.ConfigureHealthCheckWithContext(ctx, &elb.ConfigureHealthCheckInput{
+		LoadBalancerName: lb.LoadBalancerName,
+		HealthCheck: &elb.HealthCheck{
+			Target:             aws.String(fmt.Sprintf("HTTPS:%d%s", apiPort, readyzPath)),
+			Interval:           aws.Int64(10),
+			Timeout:            aws.Int64(5),
+			HealthyThreshold:   aws.Int64(2),
+			UnhealthyThreshold: aws.Int64(2),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure health check: %w", err)
+	}
+
+	idleTimeout := input.idleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = defaultClassicIdleTimeout
+	}
+	_, err = elbClient.ModifyLoadBalancerAttributesWithContext(ctx, &elb.ModifyLoadBalancerAttributesInput{
+		LoadBalancerName: lb.LoadBalancerName,
+		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(idleTimeout)},
+			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(true)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set load balancer attributes: %w", err)
+	}
+	l.Infoln("Configured load balancer health check and attributes")
+
+	return lb, nil
+}
+
+func existingClassicLoadBalancer(ctx context.Context, client *elb.ELB, lbName string) (*elb.LoadBalancerDescription, error) {
+	res, err := client.DescribeLoadBalancersWithContext(ctx, &elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: aws.StringSlice([]string{lbName}),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == elb.ErrCodeAccessPointNotFoundException {
+			return nil, errNotFound
+		}
+		return nil, fmt.Errorf("failed to list load balancers: %w", err)
+	}
+	for _, lb := range res.LoadBalancerDescriptions {
+		return lb, nil
+	}
+	return nil, errNotFound
+}
+
+// registerClassicLoadBalancerInstances registers the instances with the
+// Classic load balancer.
+func registerClassicLoadBalancerInstances(ctx context.Context, client *elb.ELB, lbName string, instanceIDs []string) error {
+	instances := make([]*elb.Instance, 0, len(instanceIDs))
+	for _, id := range instanceIDs {
+		instances = append(instances, &elb.Instance{InstanceId: aws.String(id)})
+	}
+	_, err := client.RegisterInstancesWithLoadBalancerWithContext(ctx, &elb.RegisterInstancesWithLoadBalancerInput{
+		LoadBalancerName: aws.String(lbName),
+		Instances:        instances,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register instances with load balancer (%s): %w", lbName, err)
+	}
+	return nil
+}
+
+func classicELBTags(tags map[string]string) []*elb.Tag {
+	etags := make([]*elb.Tag, 0, len(tags))
+	for k, v := range tags {
+		k, v := k, v
+		etags = append(etags, &elb.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return etags
+}
@@ -3,8 +3,10 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -18,10 +20,12 @@ type controlPlaneInputOptions struct {
 	privateSubnetIDs  []string
 	zoneToSubnetMap   map[string]string
 	availabilityZones []string
+	hostIDs           []string
 }
 
 type controlPlaneOutput struct {
-	controlPlaneIPs []string
+	controlPlaneIPs         []string
+	controlPlaneInstanceIDs []string
 }
 
 func createControlPlaneResources(ctx context.Context, logger logrus.FieldLogger, ec2Client ec2iface.EC2API, iamClient iamiface.IAMAPI, elbClient elbv2iface.ELBV2API, input *controlPlaneInputOptions) (*controlPlaneOutput, error) {
@@ -31,24 +35,36 @@ func createControlPlaneResources(ctx context.Context, logger logrus.FieldLogger,
 		return nil, fmt.Errorf("failed to create control plane instance profile: %w", err)
 	}
 
+	zoneToHostIDs, err := dedicatedHostsByZone(ctx, ec2Client, input.hostIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	instanceIPs := make([]string, 0, input.nReplicas)
+	instanceIDs := make([]string, 0, input.nReplicas)
 	for i := 0; i < input.nReplicas; i++ {
 		options := input.instanceInputOptions
 		options.name = fmt.Sprintf("%s-master-%d", input.infraID, i)
 		// Choose appropriate subnet according to zone
 		zoneIdx := i % len(input.availabilityZones)
-		options.subnetID = input.zoneToSubnetMap[input.availabilityZones[zoneIdx]]
+		zone := input.availabilityZones[zoneIdx]
+		options.subnetID = input.zoneToSubnetMap[zone]
 		options.instanceProfileARN = aws.StringValue(instanceProfile.Arn)
+		// Spread the instances of a zone across the dedicated hosts in the zone
+		if hostIDs := zoneToHostIDs[zone]; len(hostIDs) > 0 {
+			options.hostID = hostIDs[(i/len(input.availabilityZones))%len(hostIDs)]
+		}
 
 		instance, err := ensureInstance(ctx, logger, ec2Client, elbClient, &options)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create control plane (%s): %w", options.name, err)
 		}
-		instanceIPs = append(instanceIPs, aws.StringValue(instance.PrivateIpAddress))
+		instanceIPs = append(instanceIPs, aws.StringValue(instanceIP(instance, options.ipv6)))
+		instanceIDs = append(instanceIDs, aws.StringValue(instance.InstanceId))
 	}
 	logger.Infoln("Created control plane instances")
 
-	return &controlPlaneOutput{controlPlaneIPs: instanceIPs}, nil
+	return &controlPlaneOutput{controlPlaneIPs: instanceIPs, controlPlaneInstanceIDs: instanceIDs}, nil
 }
 
 func createControlPlaneInstanceProfile(ctx context.Context, logger logrus.FieldLogger, client iamiface.IAMAPI, name string, roleName string, partitionDNSSuffix string, tags map[string]string) (*iam.InstanceProfile, error) {
@@ -125,3 +141,30 @@ func createControlPlaneInstanceProfile(ctx context.Context, logger logrus.FieldL
 	}
 	return createInstanceProfile(ctx, logger, client, profileInput)
 }
+
+// dedicatedHostsByZone returns the given dedicated hosts indexed by their availability zone.
+func dedicatedHostsByZone(ctx context.Context, client ec2iface.EC2API, hostIDs []string) (map[string][]string, error) {
+	zoneToHostIDs := map[string][]string{}
+	if len(hostIDs) == 0 {
+		return zoneToHostIDs, nil
+	}
+
+	err := client.DescribeHostsPagesWithContext(ctx,
+		&ec2.DescribeHostsInput{HostIds: aws.StringSlice(hostIDs)},
+		func(page *ec2.DescribeHostsOutput, lastPage bool) bool {
+			for _, host := range page.Hosts {
+				zone := aws.StringValue(host.AvailabilityZone)
+				zoneToHostIDs[zone] = append(zoneToHostIDs[zone], aws.StringValue(host.HostId))
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe dedicated hosts: %w", err)
+	}
+	for _, ids := range zoneToHostIDs {
+		sort.Strings(ids)
+	}
+
+	return zoneToHostIDs, nil
+}
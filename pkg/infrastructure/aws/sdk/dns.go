@@ -33,6 +33,7 @@ type dnsInputOptions struct {
 	lbInternalZoneDNS string
 	internalZone      string
 	isPrivateCluster  bool
+	ipv6              bool
 	tags              map[string]string
 }
 
@@ -40,6 +41,12 @@ func createDNSResources(ctx context.Context, logger logrus.FieldLogger, route53C
 	apiName := fmt.Sprintf("api.%s", input.clusterDomain)
 	apiIntName := fmt.Sprintf("api-int.%s", input.clusterDomain)
 	useCNAME := cnameRegions.Has(input.region)
+	// The machines of IPv6 clusters only resolve the API to the IPv6
+	// addresses of the load balancers.
+	recordType := route53.RRTypeA
+	if input.ipv6 {
+		recordType = route53.RRTypeAaaa
+	}
 
 	if !input.isPrivateCluster {
 		publicZone, err := existingHostedZone(ctx, route53Client, input.baseDomain, false)
@@ -53,7 +60,7 @@ func createDNSResources(ctx context.Context, logger logrus.FieldLogger, route53C
 		}).Infoln("Found existing public zone")
 
 		// Create API record in public zone
-		_, err = createRecord(ctx, route53Client, zoneID, apiName, input.lbExternalZoneDNS, input.lbExternalZoneID, useCNAME)
+		_, err = createRecord(ctx, route53Client, zoneID, apiName, input.lbExternalZoneDNS, input.lbExternalZoneID, recordType, useCNAME)
 		if err != nil {
 			return fmt.Errorf("failed to create api record (%s) in public zone: %w", apiName, err)
 		}
@@ -74,14 +81,14 @@ func createDNSResources(ctx context.Context, logger logrus.FieldLogger, route53C
 	// to a different account than the rest of the cluster resources.
 
 	// Create API record in private zone
-	_, err := createRecord(ctx, assumedRoleClient, privateZoneID, apiName, input.lbInternalZoneDNS, input.lbInternalZoneID, useCNAME)
+	_, err := createRecord(ctx, assumedRoleClient, privateZoneID, apiName, input.lbInternalZoneDNS, input.lbInternalZoneID, recordType, useCNAME)
 	if err != nil {
 		return fmt.Errorf("failed to create api record (%s) in private zone: %w", apiName, err)
 	}
 	logger.Infoln("Created api DNS record for private zone")
 
 	// Create API-int record in privat zone
-	_, err = createRecord(ctx, assumedRoleClient, privateZoneID, apiIntName, input.lbInternalZoneDNS, input.lbInternalZoneID, useCNAME)
+	_, err = createRecord(ctx, assumedRoleClient, privateZoneID, apiIntName, input.lbInternalZoneDNS, input.lbInternalZoneID, recordType, useCNAME)
 	if err != nil {
 		return fmt.Errorf("failed to create api-int record (%s) in private zone: %w", apiIntName, err)
 	}
@@ -213,7 +220,7 @@ func createHostedZone(ctx context.Context, client route53iface.Route53API, name,
 	return res.HostedZone, nil
 }
 
-func createRecord(ctx context.Context, client route53iface.Route53API, zoneID string, name string, dnsName string, aliasZoneID string, useCNAME bool) (*route53.ChangeInfo, error) {
+func createRecord(ctx context.Context, client route53iface.Route53API, zoneID string, name string, dnsName string, aliasZoneID string, recordType string, useCNAME bool) (*route53.ChangeInfo, error) {
 	recordSet := &route53.ResourceRecordSet{
 		Name: aws.String(cleanRecordName(name)),
 	}
@@ -224,7 +231,7 @@ func createRecord(ctx context.Context, client route53iface.Route53API, zoneID st
 			{Value: aws.String(dnsName)},
 		})
 	} else {
-		recordSet.SetType("A")
+		recordSet.SetType(recordType)
 		recordSet.SetAliasTarget(&route53.AliasTarget{
 			DNSName:              aws.String(dnsName),
 			HostedZoneId:         aws.String(aliasZoneID),
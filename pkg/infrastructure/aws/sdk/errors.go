@@ -0,0 +1,29 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// errorContextHandler adds the service and operation names to the errors
+// returned by AWS API calls, so failures during provisioning point at the
+// call that failed. The error code is preserved so that callers checking
+// for specific codes keep working.
+var errorContextHandler = request.NamedHandler{
+	Name: "openshiftInstaller.ErrorContextHandler",
+	Fn: func(r *request.Request) {
+		aerr, ok := r.Error.(awserr.Error) //nolint:errorlint
+		if !ok {
+			return
+		}
+		message := fmt.Sprintf("%s %s: %s", r.ClientInfo.ServiceName, r.Operation.Name, aerr.Message())
+		wrapped := awserr.New(aerr.Code(), message, aerr.OrigErr())
+		if reqErr, ok := aerr.(awserr.RequestFailure); ok { //nolint:errorlint
+			r.Error = awserr.NewRequestFailure(wrapped, reqErr.StatusCode(), reqErr.RequestID())
+			return
+		}
+		r.Error = wrapped
+	},
+}
@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorContextHandler(t *testing.T) {
+	newRequest := func(err error) *request.Request {
+		return &request.Request{
+			ClientInfo: metadata.ClientInfo{ServiceName: "ec2"},
+			Operation:  &request.Operation{Name: "CreateVpc"},
+			Error:      err,
+		}
+	}
+
+	r := newRequest(awserr.NewRequestFailure(awserr.New("VpcLimitExceeded", "The maximum number of VPCs has been reached.", nil), 400, "abc-123"))
+	errorContextHandler.Fn(r)
+	var reqErr awserr.RequestFailure
+	if assert.True(t, errors.As(r.Error, &reqErr)) {
+		assert.Equal(t, "VpcLimitExceeded", reqErr.Code())
+		assert.Equal(t, "ec2 CreateVpc: The maximum number of VPCs has been reached.", reqErr.Message())
+		assert.Equal(t, 400, reqErr.StatusCode())
+		assert.Equal(t, "abc-123", reqErr.RequestID())
+	}
+
+	r = newRequest(awserr.New(request.CanceledErrorCode, "request context canceled", nil))
+	errorContextHandler.Fn(r)
+	var aerr awserr.Error
+	if assert.True(t, errors.As(r.Error, &aerr)) {
+		assert.Equal(t, request.CanceledErrorCode, aerr.Code())
+		assert.Equal(t, "ec2 CreateVpc: request context canceled", aerr.Message())
+	}
+
+	r = newRequest(nil)
+	errorContextHandler.Fn(r)
+	assert.NoError(t, r.Error)
+}
@@ -37,10 +37,15 @@ type instanceInputOptions struct {
 	volumeType         string
 	metadataAuth       string
 	partitionDNSSuffix string
+	tenancy            string
+	hostID             string
+	hostResourceGroup  string
+	reservationID      string
 	volumeSize         int64
 	volumeIOPS         int64
 	isEncrypted        bool
 	associatePublicIP  bool
+	ipv6               bool
 	securityGroupIds   []string
 	targetGroupARNs    []string
 	tags               map[string]string
@@ -85,7 +90,7 @@ func ensureInstance(ctx context.Context, logger logrus.FieldLogger, ec2Client ec
 				return false, nil
 			}
 			instance = res.Reservations[0].Instances[0]
-			if instance.PrivateIpAddress == nil {
+			if instanceIP(instance, input.ipv6) == nil {
 				return false, nil
 			}
 			if input.associatePublicIP && instance.PublicIpAddress == nil {
@@ -105,7 +110,7 @@ func ensureInstance(ctx context.Context, logger logrus.FieldLogger, ec2Client ec
 		_, err = elbClient.RegisterTargetsWithContext(ctx, &elbv2.RegisterTargetsInput{
 			TargetGroupArn: aws.String(targetGroup),
 			Targets: []*elbv2.TargetDescription{
-				{Id: instance.PrivateIpAddress},
+				{Id: instanceIP(instance, input.ipv6)},
 			},
 		})
 		if err != nil {
@@ -117,6 +122,20 @@ func ensureInstance(ctx context.Context, logger logrus.FieldLogger, ec2Client ec
 	return instance, nil
 }
 
+// instanceIP returns the private address of the instance, which is its IPv6
+// address in IPv6 clusters.
+func instanceIP(instance *ec2.Instance, ipv6 bool) *string {
+	if !ipv6 {
+		return instance.PrivateIpAddress
+	}
+	for _, ni := range instance.NetworkInterfaces {
+		for _, addr := range ni.Ipv6Addresses {
+			return addr.Ipv6Address
+		}
+	}
+	return nil
+}
+
 func existingInstance(ctx context.Context, client ec2iface.EC2API, filters []*ec2.Filter) (*ec2.Instance, error) {
 	res, err := client.DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{Filters: filters})
 	if err != nil {
@@ -151,22 +170,46 @@ func createInstance(ctx context.Context, client ec2iface.EC2API, input *instance
 	if len(httpTokens) == 0 {
 		httpTokens = "optional"
 	}
-	res, err := client.RunInstancesWithContext(ctx, &ec2.RunInstancesInput{
-		ImageId:      aws.String(input.amiID),
-		InstanceType: aws.String(input.instanceType),
-		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
-			{
-				DeviceIndex:              aws.Int64(0),
-				SubnetId:                 aws.String(input.subnetID),
-				Groups:                   aws.StringSlice(input.securityGroupIds),
-				AssociatePublicIpAddress: aws.Bool(input.associatePublicIP),
+	networkInterface := &ec2.InstanceNetworkInterfaceSpecification{
+		DeviceIndex:              aws.Int64(0),
+		SubnetId:                 aws.String(input.subnetID),
+		Groups:                   aws.StringSlice(input.securityGroupIds),
+		AssociatePublicIpAddress: aws.Bool(input.associatePublicIP),
+	}
+	metadataOptions := &ec2.InstanceMetadataOptionsRequest{
+		HttpEndpoint: aws.String("enabled"),
+		HttpTokens:   aws.String(httpTokens),
+	}
+	if input.ipv6 {
+		networkInterface.Ipv6AddressCount = aws.Int64(1)
+		metadataOptions.HttpProtocolIpv6 = aws.String(ec2.InstanceMetadataProtocolStateEnabled)
+	}
+	var placement *ec2.Placement
+	if len(input.tenancy) > 0 {
+		placement = &ec2.Placement{Tenancy: aws.String(input.tenancy)}
+		if len(input.hostID) > 0 {
+			placement.HostId = aws.String(input.hostID)
+		}
+		if len(input.hostResourceGroup) > 0 {
+			placement.HostResourceGroupArn = aws.String(input.hostResourceGroup)
+		}
+	}
+	var capacityReservation *ec2.CapacityReservationSpecification
+	if len(input.reservationID) > 0 {
+		capacityReservation = &ec2.CapacityReservationSpecification{
+			CapacityReservationTarget: &ec2.CapacityReservationTarget{
+				CapacityReservationId: aws.String(input.reservationID),
 			},
-		},
-		MetadataOptions: &ec2.InstanceMetadataOptionsRequest{
-			HttpEndpoint: aws.String("enabled"),
-			HttpTokens:   aws.String(httpTokens),
-		},
-		UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(input.userData))),
+		}
+	}
+	res, err := client.RunInstancesWithContext(ctx, &ec2.RunInstancesInput{
+		ImageId:                          aws.String(input.amiID),
+		InstanceType:                     aws.String(input.instanceType),
+		NetworkInterfaces:                []*ec2.InstanceNetworkInterfaceSpecification{networkInterface},
+		MetadataOptions:                  metadataOptions,
+		Placement:                        placement,
+		CapacityReservationSpecification: capacityReservation,
+		UserData:                         aws.String(base64.StdEncoding.EncodeToString([]byte(input.userData))),
 		// InvalidParameterCombination: Network interfaces and an instance-level security groups may not be specified on the same request
 		// SecurityGroupIds:  aws.StringSlice(options.securityGroupIDs),
 		MinCount: aws.Int64(1),
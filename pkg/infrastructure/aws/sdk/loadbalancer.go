@@ -25,6 +25,10 @@ type lbInputOptions struct {
 	infraID          string
 	vpcID            string
 	isPrivateCluster bool
+	// classicExternal is true when the external API load balancer is a
+	// Classic load balancer, created separately.
+	classicExternal  bool
+	ipv6             bool
 	tags             map[string]string
 	privateSubnetIDs []string
 	publicSubnetIDs  []string
@@ -66,6 +70,11 @@ func createLoadBalancers(ctx context.Context, logger logrus.FieldLogger, elbClie
 		output.targetGroupArns = sets.List(state.targetGroupArns)
 		return output, nil
 	}
+	if input.classicExternal {
+		logger.Debugln("Skipping creation of public NLB because of Classic API load balancer")
+		output.targetGroupArns = sets.List(state.targetGroupArns)
+		return output, nil
+	}
 
 	externalLB, err := state.ensureExternalLoadBalancer(ctx, logger, elbClient, input.publicSubnetIDs, input.tags)
 	if err != nil {
@@ -81,14 +90,14 @@ func createLoadBalancers(ctx context.Context, logger logrus.FieldLogger, elbClie
 
 func (o *lbState) ensureInternalLoadBalancer(ctx context.Context, logger logrus.FieldLogger, client elbv2iface.ELBV2API, subnets []string, tags map[string]string) (*elbv2.LoadBalancer, error) {
 	lbName := fmt.Sprintf("%s-int", o.input.infraID)
-	lb, err := ensureLoadBalancer(ctx, logger, client, lbName, subnets, false, tags)
+	lb, err := ensureLoadBalancer(ctx, logger, client, lbName, subnets, false, o.input.ipv6, tags)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create internalA target group
 	aTGName := fmt.Sprintf("%s-aint", o.input.infraID)
-	aTG, err := ensureTargetGroup(ctx, logger, client, aTGName, o.input.vpcID, readyzPath, apiPort, tags)
+	aTG, err := ensureTargetGroup(ctx, logger, client, aTGName, o.input.vpcID, readyzPath, apiPort, o.input.ipv6, tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create internalA target group: %w", err)
 	}
@@ -104,7 +113,7 @@ func (o *lbState) ensureInternalLoadBalancer(ctx context.Context, logger logrus.
 
 	// Create internalS target group
 	sTGName := fmt.Sprintf("%s-sint", o.input.infraID)
-	sTG, err := ensureTargetGroup(ctx, logger, client, sTGName, o.input.vpcID, healthzPath, servicePort, tags)
+	sTG, err := ensureTargetGroup(ctx, logger, client, sTGName, o.input.vpcID, healthzPath, servicePort, o.input.ipv6, tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create internalS target group: %w", err)
 	}
@@ -123,14 +132,14 @@ func (o *lbState) ensureInternalLoadBalancer(ctx context.Context, logger logrus.
 
 func (o *lbState) ensureExternalLoadBalancer(ctx context.Context, logger logrus.FieldLogger, client elbv2iface.ELBV2API, subnets []string, tags map[string]string) (*elbv2.LoadBalancer, error) {
 	lbName := fmt.Sprintf("%s-ext", o.input.infraID)
-	lb, err := ensureLoadBalancer(ctx, logger, client, lbName, subnets, true, tags)
+	lb, err := ensureLoadBalancer(ctx, logger, client, lbName, subnets, true, o.input.ipv6, tags)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create target group
 	tgName := fmt.Sprintf("%s-aext", o.input.infraID)
-	tg, err := ensureTargetGroup(ctx, logger, client, tgName, o.input.vpcID, readyzPath, apiPort, tags)
+	tg, err := ensureTargetGroup(ctx, logger, client, tgName, o.input.vpcID, readyzPath, apiPort, o.input.ipv6, tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create external target group: %w", err)
 	}
@@ -146,7 +155,7 @@ func (o *lbState) ensureExternalLoadBalancer(ctx context.Context, logger logrus.
 	return lb, nil
 }
 
-func ensureLoadBalancer(ctx context.Context, logger logrus.FieldLogger, client elbv2iface.ELBV2API, lbName string, subnets []string, isPublic bool, tags map[string]string) (*elbv2.LoadBalancer, error) {
+func ensureLoadBalancer(ctx context.Context, logger logrus.FieldLogger, client elbv2iface.ELBV2API, lbName string, subnets []string, isPublic bool, ipv6 bool, tags map[string]string) (*elbv2.LoadBalancer, error) {
 	l := logger.WithField("name", lbName)
 	createdOrFoundMsg := "Found existing load balancer"
 	lb, err := existingLoadBalancer(ctx, client, lbName)
@@ -155,7 +164,7 @@ func ensureLoadBalancer(ctx context.Context, logger logrus.FieldLogger, client e
 			return nil, err
 		}
 		createdOrFoundMsg = "Created load balancer"
-		lb, err = createLoadBalancer(ctx, client, lbName, subnets, isPublic, tags)
+		lb, err = createLoadBalancer(ctx, client, lbName, subnets, isPublic, ipv6, tags)
 		if err != nil {
 			return nil, err
 		}
@@ -181,15 +190,21 @@ func ensureLoadBalancer(ctx context.Context, logger logrus.FieldLogger, client e
 	return lb, nil
 }
 
-func createLoadBalancer(ctx context.Context, client elbv2iface.ELBV2API, lbName string, subnets []string, isPublic bool, tags map[string]string) (*elbv2.LoadBalancer, error) {
+func createLoadBalancer(ctx context.Context, client elbv2iface.ELBV2API, lbName string, subnets []string, isPublic bool, ipv6 bool, tags map[string]string) (*elbv2.LoadBalancer, error) {
 	scheme := "internal"
 	if isPublic {
 		scheme = "internet-facing"
 	}
+	// Load balancers of IPv6 clusters answer both IPv4 and IPv6 clients
+	// and forward to the IPv6 addresses of the targets.
+	var ipAddressType *string
+	if ipv6 {
+		ipAddressType = aws.String(elbv2.IpAddressTypeDualstack)
+	}
 
 	res, err := client.CreateLoadBalancerWithContext(ctx, &elbv2.CreateLoadBalancerInput{
 		CustomerOwnedIpv4Pool: nil,
-		IpAddressType:         nil,
+		IpAddressType:         ipAddressType,
 		Name:                  aws.String(lbName),
 		Scheme:                aws.String(scheme),
 		SecurityGroups:        nil,
@@ -224,7 +239,7 @@ func existingLoadBalancer(ctx context.Context, client elbv2iface.ELBV2API, lbNam
 	return nil, errNotFound
 }
 
-func ensureTargetGroup(ctx context.Context, logger logrus.FieldLogger, client elbv2iface.ELBV2API, targetName string, vpcID string, healthCheckPath string, port int64, tags map[string]string) (*elbv2.TargetGroup, error) {
+func ensureTargetGroup(ctx context.Context, logger logrus.FieldLogger, client elbv2iface.ELBV2API, targetName string, vpcID string, healthCheckPath string, port int64, ipv6 bool, tags map[string]string) (*elbv2.TargetGroup, error) {
 	l := logger.WithField("name", targetName)
 	createdOrFoundMsg := "Found existing Target Group"
 	tg, err := existingTargetGroup(ctx, client, targetName)
@@ -233,7 +248,7 @@ func ensureTargetGroup(ctx context.Context, logger logrus.FieldLogger, client el
 			return nil, err
 		}
 		createdOrFoundMsg = "Created Target Group"
-		tg, err = createTargetGroup(ctx, client, targetName, vpcID, healthCheckPath, port, tags)
+		tg, err = createTargetGroup(ctx, client, targetName, vpcID, healthCheckPath, port, ipv6, tags)
 		if err != nil {
 			return nil, err
 		}
@@ -262,7 +277,7 @@ func existingTargetGroup(ctx context.Context, client elbv2iface.ELBV2API, target
 	return nil, errNotFound
 }
 
-func createTargetGroup(ctx context.Context, client elbv2iface.ELBV2API, targetName string, vpcID string, healthCheckPath string, port int64, tags map[string]string) (*elbv2.TargetGroup, error) {
+func createTargetGroup(ctx context.Context, client elbv2iface.ELBV2API, targetName string, vpcID string, healthCheckPath string, port int64, ipv6 bool, tags map[string]string) (*elbv2.TargetGroup, error) {
 	ttags := mergeTags(tags, map[string]string{
 		"Name": targetName,
 	})
@@ -281,6 +296,9 @@ func createTargetGroup(ctx context.Context, client elbv2iface.ELBV2API, targetNa
 		TargetType:                 aws.String("ip"),
 		VpcId:                      aws.String(vpcID),
 	}
+	if ipv6 {
+		input.IpAddressType = aws.String(elbv2.TargetGroupIpAddressTypeEnumIpv6)
+	}
 	res, err := client.CreateTargetGroupWithContext(ctx, input)
 	if err != nil {
 		return nil, err
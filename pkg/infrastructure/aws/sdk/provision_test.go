@@ -2256,7 +2256,7 @@ func TestEnsureSecurityGroup(t *testing.T) {
 	for _, test := range tests {
 		test := test // TODO: remove with golang 1.22
 		t.Run(test.name, func(t *testing.T) {
-			res, err := ensureSecurityGroup(context.TODO(), logger, &test.mockSvc, "infraID", "vpc-1", "node-sg", map[string]string{"custom-tag": "custom-value"})
+			res, err := ensureSecurityGroup(context.TODO(), logger, &test.mockSvc, "infraID", "vpc-1", "node-sg", false, map[string]string{"custom-tag": "custom-value"})
 			if test.expectedErr == "" {
 				assert.NoError(t, err)
 				assert.Equal(t, test.expectedOut, res)
@@ -2280,10 +2280,10 @@ func TestCreateSecurityGroups(t *testing.T) {
 		GroupId: aws.String("sg-3"),
 	}
 	cidrBlocks := []string{"10.0.0.0/16"}
-	defaultEgress := defaultEgressRules(aws.String("sg-1"))
-	bootstrapIngress := defaultBootstrapSGIngressRules(aws.String("sg-1"), cidrBlocks)
-	masterIngress := defaultMasterSGIngressRules(aws.String("sg-2"), aws.String("sg-3"), cidrBlocks)
-	workerIngress := defaultWorkerSGIngressRules(aws.String("sg-3"), aws.String("sg-2"), cidrBlocks)
+	defaultEgress := defaultEgressRules(aws.String("sg-1"), false)
+	bootstrapIngress := defaultBootstrapSGIngressRules(aws.String("sg-1"), cidrBlocks, nil)
+	masterIngress := defaultMasterSGIngressRules(aws.String("sg-2"), aws.String("sg-3"), cidrBlocks, nil)
+	workerIngress := defaultWorkerSGIngressRules(aws.String("sg-3"), aws.String("sg-2"), cidrBlocks, nil)
 
 	tests := []struct {
 		name        string
@@ -3293,7 +3293,7 @@ func TestEnsureTargetGroup(t *testing.T) {
 	for _, test := range tests {
 		test := test // TODO: remove with golang 1.22
 		t.Run(test.name, func(t *testing.T) {
-			res, err := ensureTargetGroup(context.TODO(), logger, &test.mockSvc, "tgName", "vpc-1", readyzPath, apiPort, map[string]string{})
+			res, err := ensureTargetGroup(context.TODO(), logger, &test.mockSvc, "tgName", "vpc-1", readyzPath, apiPort, false, map[string]string{})
 			if test.expectedErr == "" {
 				assert.NoError(t, err)
 				assert.Equal(t, test.expectedOut, res)
@@ -3403,7 +3403,7 @@ func TestEnsureLoadBalancer(t *testing.T) {
 	for _, test := range tests {
 		test := test // TODO: remove with golang 1.22
 		t.Run(test.name, func(t *testing.T) {
-			res, err := ensureLoadBalancer(context.TODO(), logger, &test.mockSvc, "lbName", []string{}, true, map[string]string{})
+			res, err := ensureLoadBalancer(context.TODO(), logger, &test.mockSvc, "lbName", []string{}, true, false, map[string]string{})
 			if test.expectedErr == "" {
 				assert.NoError(t, err)
 				assert.Equal(t, test.expectedOut, res)
@@ -14,12 +14,16 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
-const errDuplicatePermission = "InvalidPermission.Duplicate"
+const (
+	errDuplicatePermission = "InvalidPermission.Duplicate"
+	icmpv6Protocol         = "58"
+)
 
 type sgInputOptions struct {
 	infraID          string
 	vpcID            string
 	cidrV4Blocks     []string
+	cidrV6Blocks     []string
 	isPrivateCluster bool
 	tags             map[string]string
 }
@@ -32,39 +36,43 @@ type sgOutput struct {
 
 func createSecurityGroups(ctx context.Context, logger logrus.FieldLogger, ec2Client ec2iface.EC2API, input *sgInputOptions) (*sgOutput, error) {
 	bootstrapSGName := fmt.Sprintf("%s-bootstrap-sg", input.infraID)
-	bootstrapSG, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, bootstrapSGName, input.tags)
+	ipv6 := len(input.cidrV6Blocks) > 0
+	bootstrapSG, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, bootstrapSGName, ipv6, input.tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bootstrap security group: %w", err)
 	}
 
-	cidrs := input.cidrV4Blocks
+	cidrs, cidrsV6 := input.cidrV4Blocks, input.cidrV6Blocks
 	if !input.isPrivateCluster {
 		cidrs = []string{"0.0.0.0/0"}
+		if ipv6 {
+			cidrsV6 = []string{"::/0"}
+		}
 	}
-	bootstrapIngress := defaultBootstrapSGIngressRules(bootstrapSG.GroupId, cidrs)
+	bootstrapIngress := defaultBootstrapSGIngressRules(bootstrapSG.GroupId, cidrs, cidrsV6)
 	err = authorizeIngressRules(ctx, ec2Client, bootstrapSG, bootstrapIngress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to attach ingress rules to bootstrap security group: %w", err)
 	}
 
 	masterSGName := fmt.Sprintf("%s-master-sg", input.infraID)
-	masterSG, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, masterSGName, input.tags)
+	masterSG, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, masterSGName, ipv6, input.tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create control plane security group: %w", err)
 	}
 
 	workerSGName := fmt.Sprintf("%s-worker-sg", input.infraID)
-	workerSG, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, workerSGName, input.tags)
+	workerSG, err := ensureSecurityGroup(ctx, logger, ec2Client, input.infraID, input.vpcID, workerSGName, ipv6, input.tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compute security group: %w", err)
 	}
 
-	masterIngress := defaultMasterSGIngressRules(masterSG.GroupId, workerSG.GroupId, input.cidrV4Blocks)
+	masterIngress := defaultMasterSGIngressRules(masterSG.GroupId, workerSG.GroupId, input.cidrV4Blocks, input.cidrV6Blocks)
 	err = authorizeIngressRules(ctx, ec2Client, masterSG, masterIngress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to attach ingress rules to master security group: %w", err)
 	}
-	workerIngress := defaultWorkerSGIngressRules(workerSG.GroupId, masterSG.GroupId, input.cidrV4Blocks)
+	workerIngress := defaultWorkerSGIngressRules(workerSG.GroupId, masterSG.GroupId, input.cidrV4Blocks, input.cidrV6Blocks)
 	err = authorizeIngressRules(ctx, ec2Client, workerSG, workerIngress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to attach ingress rules to worker security group: %w", err)
@@ -77,7 +85,7 @@ func createSecurityGroups(ctx context.Context, logger logrus.FieldLogger, ec2Cli
 	}, nil
 }
 
-func ensureSecurityGroup(ctx context.Context, logger logrus.FieldLogger, client ec2iface.EC2API, infraID, vpcID string, name string, tags map[string]string) (*ec2.SecurityGroup, error) {
+func ensureSecurityGroup(ctx context.Context, logger logrus.FieldLogger, client ec2iface.EC2API, infraID, vpcID string, name string, ipv6 bool, tags map[string]string) (*ec2.SecurityGroup, error) {
 	filters := ec2Filters(infraID, name)
 	l := logger.WithField("name", name)
 	createdOrFoundMsg := "Found existing security group"
@@ -95,7 +103,7 @@ func ensureSecurityGroup(ctx context.Context, logger logrus.FieldLogger, client
 	}
 	l.WithField("id", aws.StringValue(sg.GroupId)).Infoln(createdOrFoundMsg)
 
-	egressPermissions := defaultEgressRules(sg.GroupId)
+	egressPermissions := defaultEgressRules(sg.GroupId, ipv6)
 	// Apply egress rules that haven't been applied yet
 	toAuthorize := make([]*ec2.IpPermission, 0, len(egressPermissions))
 	for _, permission := range egressPermissions {
@@ -187,31 +195,33 @@ func createSecurityGroup(ctx context.Context, client ec2iface.EC2API, name strin
 	return out.SecurityGroups[0], nil
 }
 
-func defaultEgressRules(securityGroupID *string) []*ec2.IpPermission {
-	return []*ec2.IpPermission{
+func defaultEgressRules(securityGroupID *string, ipv6 bool) []*ec2.IpPermission {
+	rules := []*ec2.IpPermission{
 		createSGRule(securityGroupID, "-1", []string{"0.0.0.0/0"}, nil, 0, 0, false, nil),
 	}
+	if ipv6 {
+		rules = append(rules, createSGRule(securityGroupID, "-1", nil, []string{"::/0"}, 0, 0, false, nil))
+	}
+	return rules
 }
 
-func defaultBootstrapSGIngressRules(securityGroupID *string, cidrBlocks []string) []*ec2.IpPermission {
+func defaultBootstrapSGIngressRules(securityGroupID *string, cidrBlocks, cidrV6Blocks []string) []*ec2.IpPermission {
 	return []*ec2.IpPermission{
 		// bootstrap ssh
-		createSGRule(securityGroupID, "tcp", cidrBlocks, nil, 22, 22, false, nil),
+		createSGRule(securityGroupID, "tcp", cidrBlocks, cidrV6Blocks, 22, 22, false, nil),
 		// bootstrap journald gateway
-		createSGRule(securityGroupID, "tcp", cidrBlocks, nil, 19531, 19531, false, nil),
+		createSGRule(securityGroupID, "tcp", cidrBlocks, cidrV6Blocks, 19531, 19531, false, nil),
 	}
 }
 
-func defaultMasterSGIngressRules(masterSGID *string, workerSGID *string, cidrBlocks []string) []*ec2.IpPermission {
-	return []*ec2.IpPermission{
+func defaultMasterSGIngressRules(masterSGID *string, workerSGID *string, cidrBlocks, cidrV6Blocks []string) []*ec2.IpPermission {
+	rules := []*ec2.IpPermission{
 		// master mcs
-		createSGRule(masterSGID, "tcp", cidrBlocks, nil, 22623, 22623, false, nil),
-		// master icmp
-		createSGRule(masterSGID, "icmp", cidrBlocks, nil, -1, -1, false, nil),
+		createSGRule(masterSGID, "tcp", cidrBlocks, cidrV6Blocks, 22623, 22623, false, nil),
 		// master ssh
-		createSGRule(masterSGID, "tcp", cidrBlocks, nil, 22, 22, false, nil),
+		createSGRule(masterSGID, "tcp", cidrBlocks, cidrV6Blocks, 22, 22, false, nil),
 		// master https
-		createSGRule(masterSGID, "tcp", cidrBlocks, nil, 6443, 6443, false, nil),
+		createSGRule(masterSGID, "tcp", cidrBlocks, cidrV6Blocks, 6443, 6443, false, nil),
 		// master vxlan
 		createSGRule(masterSGID, "udp", nil, nil, 4789, 4789, true, nil),
 		// master geneve
@@ -267,14 +277,21 @@ func defaultMasterSGIngressRules(masterSGID *string, workerSGID *string, cidrBlo
 		// master services udp from worker
 		createSGRule(masterSGID, "udp", nil, nil, 30000, 32767, false, workerSGID),
 	}
+	if len(cidrBlocks) > 0 {
+		// master icmp
+		rules = append(rules, createSGRule(masterSGID, "icmp", cidrBlocks, nil, -1, -1, false, nil))
+	}
+	if len(cidrV6Blocks) > 0 {
+		// master icmpv6
+		rules = append(rules, createSGRule(masterSGID, icmpv6Protocol, nil, cidrV6Blocks, -1, -1, false, nil))
+	}
+	return rules
 }
 
-func defaultWorkerSGIngressRules(workerSGID *string, masterSGID *string, cidrBlocks []string) []*ec2.IpPermission {
-	return []*ec2.IpPermission{
-		// worker icmp
-		createSGRule(workerSGID, "icmp", cidrBlocks, nil, -1, -1, false, nil),
+func defaultWorkerSGIngressRules(workerSGID *string, masterSGID *string, cidrBlocks, cidrV6Blocks []string) []*ec2.IpPermission {
+	rules := []*ec2.IpPermission{
 		// worker ssh
-		createSGRule(workerSGID, "tcp", cidrBlocks, nil, 22, 22, false, nil),
+		createSGRule(workerSGID, "tcp", cidrBlocks, cidrV6Blocks, 22, 22, false, nil),
 		// worker vxlan
 		createSGRule(workerSGID, "udp", nil, nil, 4789, 4789, true, nil),
 		// worker geneve
@@ -316,6 +333,15 @@ func defaultWorkerSGIngressRules(workerSGID *string, masterSGID *string, cidrBlo
 		// worker services udp from master
 		createSGRule(workerSGID, "udp", nil, nil, 30000, 32767, false, masterSGID),
 	}
+	if len(cidrBlocks) > 0 {
+		// worker icmp
+		rules = append(rules, createSGRule(workerSGID, "icmp", cidrBlocks, nil, -1, -1, false, nil))
+	}
+	if len(cidrV6Blocks) > 0 {
+		// worker icmpv6
+		rules = append(rules, createSGRule(workerSGID, icmpv6Protocol, nil, cidrV6Blocks, -1, -1, false, nil))
+	}
+	return rules
 }
 
 func createSGRule(sgID *string, protocol string, cidrV4Blocks, cidrV6Blocks []string, fromPort, toPort int64, self bool, sourceSGID *string) *ec2.IpPermission {
@@ -29,6 +29,10 @@ import (
 	aztypes "github.com/openshift/installer/pkg/types/azure"
 )
 
+// maxCustomDataSize is the maximum size of the custom data of a VM, before it
+// is base64-encoded to the 64 KiB accepted by Azure.
+const maxCustomDataSize = 48 * 1024
+
 // Provider implements Azure CAPI installation.
 type Provider struct {
 	ResourceGroupName    string
@@ -45,6 +49,7 @@ var _ clusterapi.PreProvider = (*Provider)(nil)
 var _ clusterapi.InfraReadyProvider = (*Provider)(nil)
 var _ clusterapi.PostProvider = (*Provider)(nil)
 var _ clusterapi.IgnitionProvider = (*Provider)(nil)
+var _ clusterapi.ResourcesProvider = (*Provider)(nil)
 
 // Name returns the name of the provider.
 func (p *Provider) Name() string {
@@ -353,7 +358,7 @@ func (p *Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput
 
 	var lbBap *armnetwork.BackendAddressPool
 	var extLBFQDN string
-	if in.InstallConfig.Config.Publish == types.ExternalPublishingStrategy {
+	if in.InstallConfig.Config.PublicAPI() {
 		publicIP, err := createPublicIP(ctx, lbInput)
 		if err != nil {
 			return fmt.Errorf("failed to create public ip: %w", err)
@@ -385,6 +390,29 @@ func (p *Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput
 	return nil
 }
 
+// Resources returns the resource group, virtual network and subnets of the
+// cluster.
+func (p *Provider) Resources(ctx context.Context, in clusterapi.ResourcesInput) (*types.ClusterResources, error) {
+	azureCluster := &capz.AzureCluster{}
+	key := client.ObjectKey{
+		Name:      in.InfraID,
+		Namespace: capiutils.Namespace,
+	}
+	if err := in.Client.Get(ctx, key, azureCluster); err != nil {
+		return nil, fmt.Errorf("failed to get Azure cluster: %w", err)
+	}
+
+	subnets := make([]string, 0, len(azureCluster.Spec.NetworkSpec.Subnets))
+	for _, subnet := range azureCluster.Spec.NetworkSpec.Subnets {
+		subnets = append(subnets, subnet.Name)
+	}
+	return &types.ClusterResources{
+		NetworkID:     azureCluster.Spec.NetworkSpec.Vnet.Name,
+		SubnetIDs:     subnets,
+		ResourceGroup: p.ResourceGroupName,
+	}, nil
+}
+
 // PostProvision provisions an external Load Balancer (when appropriate), and adds configuration
 // for the MCS to the CAPI-provisioned internal LB.
 func (p *Provider) PostProvision(ctx context.Context, in clusterapi.PostProvisionInput) error {
@@ -394,7 +422,7 @@ func (p *Provider) PostProvision(ctx context.Context, in clusterapi.PostProvisio
 	}
 	subscriptionID := ssn.Credentials.SubscriptionID
 
-	if in.InstallConfig.Config.Publish == types.ExternalPublishingStrategy {
+	if in.InstallConfig.Config.PublicAPI() {
 		vmClient, err := armcompute.NewVirtualMachinesClient(subscriptionID, ssn.TokenCreds, nil)
 		if err != nil {
 			return fmt.Errorf("error creating vm client: %w", err)
@@ -480,8 +508,17 @@ func randomString(length int) string {
 }
 
 // Ignition provisions the Azure container that holds the bootstrap ignition
-// file.
+// file. With the inline delivery, no container is created and the bootstrap
+// ignition is passed compressed in the custom data of the bootstrap VM.
 func (p Provider) Ignition(ctx context.Context, in clusterapi.IgnitionInput) ([]byte, error) {
+	if in.InstallConfig.Config.BootstrapIgnitionDelivery() == types.BootstrapIgnitionDeliveryInline {
+		ignShim, err := bootstrap.GenerateInlineIgnitionShim(in.BootstrapIgnData, in.InstallConfig.Config.AdditionalTrustBundle, in.InstallConfig.Config.Proxy, maxCustomDataSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inline the bootstrap ignition: %w", err)
+		}
+		return ignShim, nil
+	}
+
 	session, err := in.InstallConfig.Azure.Session()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
@@ -13,7 +13,6 @@ import (
 
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
 	"github.com/openshift/installer/pkg/infrastructure/clusterapi"
-	"github.com/openshift/installer/pkg/types"
 )
 
 type recordListType string
@@ -38,7 +37,7 @@ type recordPrivateList struct {
 
 // Create DNS entries for azure.
 func createDNSEntries(ctx context.Context, in clusterapi.InfraReadyInput, extLBFQDN string, resourceGroup string) error {
-	private := in.InstallConfig.Config.Publish == types.InternalPublishingStrategy
+	private := !in.InstallConfig.Config.PublicAPI()
 	baseDomainResourceGroup := in.InstallConfig.Config.Azure.BaseDomainResourceGroupName
 	zone := in.InstallConfig.Config.BaseDomain
 	privatezone := in.InstallConfig.Config.ClusterDomain()
@@ -3,6 +3,8 @@ package baremetal
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/infrastructure"
@@ -46,6 +48,16 @@ func (a Provider) DestroyBootstrap(dir string) error {
 	return nil
 }
 
+// Destroy removes the variables files from the install dir.
+func (a Provider) Destroy(dir string) error {
+	for _, filename := range []string{tfVarsFileName, tfPlatformVarsFileName} {
+		if err := os.Remove(filepath.Join(dir, filename)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
 // ExtractHostAddresses extracts the IPs of the bootstrap and control plane machines.
 func (a Provider) ExtractHostAddresses(dir string, ic *types.InstallConfig, ha *infrastructure.HostAddresses) error {
 	ha.Bootstrap = ic.Platform.BareMetal.BootstrapProvisioningIP
@@ -80,6 +80,7 @@ func (i *InfraProvider) Provision(ctx context.Context, dir string, parents asset
 	rhcosImage := new(rhcos.Image)
 	bootstrapIgnAsset := &bootstrap.Bootstrap{}
 	masterIgnAsset := &machine.Master{}
+	bastionIgnAsset := &machine.Bastion{}
 	tfvarsAsset := &tfvars.TerraformVariables{}
 	parents.Get(
 		manifestsAsset,
@@ -91,6 +92,7 @@ func (i *InfraProvider) Provision(ctx context.Context, dir string, parents asset
 		rhcosImage,
 		bootstrapIgnAsset,
 		masterIgnAsset,
+		bastionIgnAsset,
 		capiMachinesAsset,
 		tfvarsAsset,
 	)
@@ -250,6 +252,10 @@ func (i *InfraProvider) Provision(ctx context.Context, dir string, parents asset
 	bootstrapIgnSecret := IgnitionSecret(bootstrapIgnData, clusterID.InfraID, "bootstrap")
 	masterIgnSecret := IgnitionSecret(masterIgnAsset.Files()[0].Data, clusterID.InfraID, "master")
 	machineManifests = append(machineManifests, bootstrapIgnSecret, masterIgnSecret)
+	if installConfig.Config.Bastion != nil {
+		bastionIgnSecret := IgnitionSecret(bastionIgnAsset.Files()[0].Data, clusterID.InfraID, "bastion")
+		machineManifests = append(machineManifests, bastionIgnSecret)
+	}
 
 	timer.StartTimer(machineStage)
 	// Create the machine manifests.
@@ -266,6 +272,14 @@ func (i *InfraProvider) Provision(ctx context.Context, dir string, parents asset
 		if reps := installConfig.Config.ControlPlane.Replicas; reps != nil {
 			masterCount = *reps
 		}
+		machineNames := []string{}
+		for i := int64(0); i < masterCount; i++ {
+			machineNames = append(machineNames, fmt.Sprintf("%s-%s-%d", clusterID.InfraID, "master", i))
+		}
+		// The address of the bastion is recorded once it is provisioned.
+		if installConfig.Config.Bastion != nil {
+			machineNames = append(machineNames, capiutils.GenerateBastionMachineName(clusterID.InfraID))
+		}
 
 		untilTime := time.Now().Add(timeout)
 		timezone, _ := untilTime.Zone()
@@ -276,10 +290,10 @@ func (i *InfraProvider) Provision(ctx context.Context, dir string, parents asset
 			Steps:    32,
 			Cap:      timeout,
 		}, func(ctx context.Context) (bool, error) {
-			for i := int64(0); i < masterCount; i++ {
+			for _, name := range machineNames {
 				machine := &clusterv1.Machine{}
 				if err := cl.Get(ctx, client.ObjectKey{
-					Name:      fmt.Sprintf("%s-%s-%d", clusterID.InfraID, "master", i),
+					Name:      name,
 					Namespace: capiutils.Namespace,
 				}, machine); err != nil {
 					if apierrors.IsNotFound(err) {
@@ -349,6 +363,36 @@ func (i *InfraProvider) Provision(ctx context.Context, dir string, parents asset
 		})
 	}
 
+	var resources *types.ClusterResources
+	if p, ok := i.impl.(ResourcesProvider); ok {
+		resources, err = p.Resources(ctx, ResourcesInput{
+			Client:        cl,
+			InstallConfig: installConfig,
+			InfraID:       clusterID.InfraID,
+		})
+		if err != nil {
+			return fileList, fmt.Errorf("failed to collect the cluster resources: %w", err)
+		}
+	}
+	if installConfig.Config.Bastion != nil {
+		address := bastionAddress(machineManifests, clusterID.InfraID)
+		if address == "" {
+			return fileList, fmt.Errorf("the bastion machine has no address")
+		}
+		logrus.Infof("The bastion host is reachable at %s", address)
+		if resources == nil {
+			resources = &types.ClusterResources{}
+		}
+		resources.BastionAddress = address
+	}
+	if resources != nil {
+		metadataFile, err := metadata.WithResources(dir, resources)
+		if err != nil {
+			return fileList, fmt.Errorf("failed to record the cluster resources: %w", err)
+		}
+		fileList = append(fileList, metadataFile)
+	}
+
 	logrus.Infof("Cluster API resources have been created. Waiting for cluster to become ready...")
 
 	// If we're skipping bootstrap destroy, shutdown the local control plane.
@@ -410,6 +454,17 @@ func (i *InfraProvider) DestroyBootstrap(dir string) error {
 	return nil
 }
 
+// bastionAddress returns the address of the provisioned bastion machine.
+func bastionAddress(machineManifests []client.Object, infraID string) string {
+	name := capiutils.GenerateBastionMachineName(infraID)
+	for _, m := range machineManifests {
+		if machine, ok := m.(*clusterv1.Machine); ok && machine.Name == name {
+			return machineAddress(machine.Status.Addresses)
+		}
+	}
+	return ""
+}
+
 type machineManifest struct {
 	Status struct {
 		Addresses []clusterv1.MachineAddress `yaml:"addresses"`
@@ -428,9 +483,14 @@ func extractIPAddress(manifestPath string) (string, error) {
 	if err := yaml.Unmarshal(data, &manifest); err != nil {
 		return "", fmt.Errorf("failed to unmarshal manifest %s: %w", manifestPath, err)
 	}
+	return machineAddress(manifest.Status.Addresses), nil
+}
 
+// machineAddress returns the external IP address of a machine, or its
+// internal one if it has none.
+func machineAddress(addresses clusterv1.MachineAddresses) string {
 	var ipAddr string
-	for _, addr := range manifest.Status.Addresses {
+	for _, addr := range addresses {
 		switch addr.Type {
 		case clusterv1.MachineExternalIP:
 			ipAddr = addr.Address
@@ -444,7 +504,26 @@ func extractIPAddress(manifestPath string) (string, error) {
 		}
 	}
 
-	return ipAddr, nil
+	return ipAddr
+}
+
+// Destroy stops the local control plane if it is still running and runs the
+// Destroy hook of the platform provider.
+func (i *InfraProvider) Destroy(dir string) error {
+	if sys := clusterapi.System(); sys.State() == clusterapi.SystemStateRunning {
+		sys.Teardown()
+	}
+
+	if p, ok := i.impl.(DestroyProvider); ok {
+		metadata, err := metadata.Load(dir)
+		if err != nil {
+			return err
+		}
+		if err := p.Destroy(context.TODO(), DestroyInput{Dir: dir, Metadata: metadata}); err != nil {
+			return fmt.Errorf("failed during destroy hook: %w", err)
+		}
+	}
+	return nil
 }
 
 // ExtractHostAddresses extracts the IPs of the bootstrap and control plane machines.
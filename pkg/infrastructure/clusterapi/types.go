@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/manifests"
 	"github.com/openshift/installer/pkg/asset/rhcos"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // Provider is the base interface that cloud platforms
@@ -81,9 +82,37 @@ type PostProvider interface {
 	PostProvision(ctx context.Context, in PostProvisionInput) error
 }
 
+// DestroyProvider defines the Destroy hook, which is called once the
+// cluster resources have been destroyed.
+type DestroyProvider interface {
+	// Destroy should be used to clean up what the platform provider keeps
+	// in the install dir or outside of the cluster resources.
+	Destroy(ctx context.Context, in DestroyInput) error
+}
+
+// DestroyInput collects the args passed to the Destroy hook.
+type DestroyInput struct {
+	Dir      string
+	Metadata *types.ClusterMetadata
+}
+
 // PostProvisionInput collects the args passed to the PostProvision hook.
 type PostProvisionInput struct {
 	Client        client.Client
 	InstallConfig *installconfig.InstallConfig
 	InfraID       string
 }
+
+// ResourcesProvider defines the Resources hook, which is called once the
+// cluster has been provisioned to record the identifiers of the cluster
+// resources in metadata.json.
+type ResourcesProvider interface {
+	Resources(ctx context.Context, in ResourcesInput) (*types.ClusterResources, error)
+}
+
+// ResourcesInput collects the args passed to the Resources hook.
+type ResourcesInput struct {
+	Client        client.Client
+	InstallConfig *installconfig.InstallConfig
+	InfraID       string
+}
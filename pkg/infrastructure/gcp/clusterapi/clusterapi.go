@@ -14,6 +14,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset/cluster/metadata"
 	"github.com/openshift/installer/pkg/asset/cluster/tfvars"
+	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap/gcp"
 	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
 	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
@@ -22,6 +23,9 @@ import (
 	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 )
 
+// maxMetadataValueSize is the maximum size of a metadata value of an instance.
+const maxMetadataValueSize = 256 * 1024
+
 // Provider implements gcp infrastructure in conjunction with the
 // GCP CAPI provider.
 type Provider struct {
@@ -31,6 +35,7 @@ var _ clusterapi.PreProvider = (*Provider)(nil)
 var _ clusterapi.IgnitionProvider = (*Provider)(nil)
 var _ clusterapi.InfraReadyProvider = (*Provider)(nil)
 var _ clusterapi.PostProvider = (*Provider)(nil)
+var _ clusterapi.ResourcesProvider = (*Provider)(nil)
 
 // Name returns the name for the platform.
 func (p Provider) Name() string {
@@ -58,12 +63,15 @@ func (p Provider) PreProvision(ctx context.Context, in clusterapi.PreProvisionIn
 	}
 
 	// ServiceAccount for workers
-	workerSA, err := CreateServiceAccount(ctx, in.InfraID, projectID, "worker")
-	if err != nil {
-		return fmt.Errorf("failed to create worker serviceAccount: %w", err)
-	}
-	if err = AddServiceAccountRoles(ctx, projectID, workerSA, GetWorkerRoles()); err != nil {
-		return fmt.Errorf("failed to add worker roles: %w", err)
+	// Only create ServiceAccount for workers if a compute pool does not run as a pre-created one
+	if !icgcp.ComputeServiceAccountsProvided(in.InstallConfig.Config) {
+		workerSA, err := CreateServiceAccount(ctx, in.InfraID, projectID, "worker")
+		if err != nil {
+			return fmt.Errorf("failed to create worker serviceAccount: %w", err)
+		}
+		if err = AddServiceAccountRoles(ctx, projectID, workerSA, GetWorkerRoles()); err != nil {
+			return fmt.Errorf("failed to add worker roles: %w", err)
+		}
 	}
 
 	return nil
@@ -73,14 +81,21 @@ func (p Provider) PreProvision(ctx context.Context, in clusterapi.PreProvisionIn
 // populate the metadata field of the bootstrap instance as the data can be too large. Instead, the data is
 // added to a bucket. A signed url is generated to point to the bucket and the ignition data will be
 // updated to point to the url. This is also allows for bootstrap data to be edited after its initial creation.
+//
+// With the inline delivery, no bucket is created and the ignition data is passed compressed in the metadata
+// of the bootstrap instance instead.
 func (p Provider) Ignition(ctx context.Context, in clusterapi.IgnitionInput) ([]byte, error) {
+	if in.InstallConfig.Config.BootstrapIgnitionDelivery() == types.BootstrapIgnitionDeliveryInline {
+		return inlineIgnition(ctx, in)
+	}
+
 	// Create the bucket and presigned url. The url is generated using a known/expected name so that the
 	// url can be retrieved from the api by this name.
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*2)
 	defer cancel()
 
 	bucketName := gcp.GetBootstrapStorageName(in.InfraID)
-	bucketHandle, err := gcp.CreateBucketHandle(ctx, bucketName)
+	bucketHandle, err := gcp.CreateBucketHandle(ctx, bucketName, in.InstallConfig.Config.GCP.ServiceEndpoints)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bucket handle %s: %w", bucketName, err)
 	}
@@ -124,6 +139,25 @@ func (p Provider) Ignition(ctx context.Context, in clusterapi.IgnitionInput) ([]
 	return nil, fmt.Errorf("failed to complete ignition process")
 }
 
+// inlineIgnition returns the shim passing the bootstrap ignition data in the
+// metadata of the bootstrap instance.
+func inlineIgnition(ctx context.Context, in clusterapi.IgnitionInput) ([]byte, error) {
+	editedIgnitionBytes, err := EditIgnition(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit bootstrap ignition: %w", err)
+	}
+	ignitionBytes := in.BootstrapIgnData
+	if editedIgnitionBytes != nil {
+		ignitionBytes = editedIgnitionBytes
+	}
+	ic := in.InstallConfig.Config
+	shim, err := bootstrap.GenerateInlineIgnitionShim(ignitionBytes, ic.AdditionalTrustBundle, ic.Proxy, maxMetadataValueSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inline the bootstrap ignition: %w", err)
+	}
+	return shim, nil
+}
+
 // InfraReady is called once cluster.Status.InfrastructureReady
 // is true, typically after load balancers have been provisioned. It can be used
 // to create DNS records.
@@ -140,11 +174,11 @@ func (p Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput)
 	// public load balancer is created by CAPG. The health check for this load balancer is also created by
 	// the CAPG.
 	apiIPAddress := gcpCluster.Spec.ControlPlaneEndpoint.Host
-	if apiIPAddress == "" && in.InstallConfig.Config.Publish == types.ExternalPublishingStrategy {
-		logrus.Debugf("publish strategy is set to external but api address is empty")
+	if apiIPAddress == "" && in.InstallConfig.Config.PublicAPI() {
+		logrus.Debugf("the api is published externally but its address is empty")
 	}
 
-	client, err := icgcp.NewClient(context.TODO())
+	client, err := icgcp.NewClient(context.TODO(), in.InstallConfig.Config.GCP.ServiceEndpoints)
 	if err != nil {
 		return err
 	}
@@ -195,7 +229,7 @@ func (p Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput)
 		return fmt.Errorf("failed to add firewall rules: %w", err)
 	}
 
-	if in.InstallConfig.Config.GCP.UserProvisionedDNS != gcptypes.UserProvisionedDNSEnabled {
+	if !in.InstallConfig.Config.ClusterHostedDNS() {
 		// Get the network from the GCP Cluster. The network is used to create the private managed zone.
 		if gcpCluster.Status.Network.SelfLink == nil {
 			return fmt.Errorf("failed to get GCP network: %w", err)
@@ -212,6 +246,12 @@ func (p Provider) InfraReady(ctx context.Context, in clusterapi.InfraReadyInput)
 		}
 	}
 
+	if in.InstallConfig.Config.GCP.GoogleAPIAccess != nil {
+		if err := createGoogleAPIsManagedZone(ctx, in.InstallConfig, in.InfraID, networkSelfLink); err != nil {
+			return fmt.Errorf("failed to create the Google APIs managed zone: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -223,12 +263,40 @@ func (p Provider) DestroyBootstrap(dir string) error {
 		return err
 	}
 
-	if err := gcp.DestroyStorage(context.Background(), metadata.ClusterID); err != nil {
+	if err := gcp.DestroyStorage(context.Background(), metadata.ClusterID, metadata.GCP.ServiceEndpoints); err != nil {
 		return fmt.Errorf("failed to destroy storage")
 	}
 	return nil
 }
 
+// Resources returns the network and subnets of the cluster.
+func (p Provider) Resources(ctx context.Context, in clusterapi.ResourcesInput) (*types.ClusterResources, error) {
+	gcpCluster := &capg.GCPCluster{}
+	key := client.ObjectKey{
+		Name:      in.InfraID,
+		Namespace: capiutils.Namespace,
+	}
+	if err := in.Client.Get(ctx, key, gcpCluster); err != nil {
+		return nil, fmt.Errorf("failed to get GCP cluster: %w", err)
+	}
+	if gcpCluster.Status.Network.SelfLink == nil {
+		return nil, fmt.Errorf("failed to get GCP network")
+	}
+
+	masterSubnetName := gcptypes.DefaultSubnetName(in.InfraID, "master")
+	if in.InstallConfig.Config.GCP.ControlPlaneSubnet != "" {
+		masterSubnetName = in.InstallConfig.Config.GCP.ControlPlaneSubnet
+	}
+	workerSubnetName := gcptypes.DefaultSubnetName(in.InfraID, "worker")
+	if in.InstallConfig.Config.GCP.ComputeSubnet != "" {
+		workerSubnetName = in.InstallConfig.Config.GCP.ComputeSubnet
+	}
+	return &types.ClusterResources{
+		NetworkID: path.Base(*gcpCluster.Status.Network.SelfLink),
+		SubnetIDs: []string{masterSubnetName, workerSubnetName},
+	}, nil
+}
+
 // PostProvision should be called to add or update and GCP resources after provisioning has completed.
 func (p Provider) PostProvision(ctx context.Context, in clusterapi.PostProvisionInput) error {
 	return nil
@@ -4,16 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"google.golang.org/api/dns/v1"
 	"google.golang.org/api/option"
 
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	gcpic "github.com/openshift/installer/pkg/asset/installconfig/gcp"
-	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 )
 
+// googleAPIsDomain is the domain of the Google APIs.
+const googleAPIsDomain = "googleapis.com."
+
 var (
 	errNotFound = errors.New("not found")
 )
@@ -22,7 +27,7 @@ func getDNSZoneName(ctx context.Context, ic *installconfig.InstallConfig, isPubl
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*1)
 	defer cancel()
 
-	client, err := gcpic.NewClient(ctx)
+	client, err := gcpic.NewClient(ctx, ic.Config.GCP.ServiceEndpoints)
 	if err != nil {
 		return "", fmt.Errorf("failed to create new client: %w", err)
 	}
@@ -93,7 +98,7 @@ func createRecordSets(ctx context.Context, ic *installconfig.InstallConfig, clus
 		},
 	}
 
-	if ic.Config.Publish == types.ExternalPublishingStrategy {
+	if ic.Config.PublicAPI() {
 		existingPublicZoneName, err := getDNSZoneName(ctx, ic, true)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find a public zone: %w", err)
@@ -182,3 +187,101 @@ func createPrivateManagedZone(ctx context.Context, ic *installconfig.InstallConf
 
 	return nil
 }
+
+// googleAPIsRecordSets returns the records resolving the Google APIs to the
+// addresses of the configured access in the googleapis.com zone.
+func googleAPIsRecordSets(access *gcptypes.GoogleAPIAccess) []*dns.ResourceRecordSet {
+	var apex string
+	switch access.Type {
+	case gcptypes.PrivateGoogleAccess:
+		apex = "private.googleapis.com."
+	case gcptypes.RestrictedGoogleAccess:
+		apex = "restricted.googleapis.com."
+	default:
+		// The Private Service Connect endpoint serves all the Google APIs.
+		return []*dns.ResourceRecordSet{{
+			Name:    "*.googleapis.com.",
+			Type:    "A",
+			Ttl:     300,
+			Rrdatas: access.Addresses(),
+		}}
+	}
+	return []*dns.ResourceRecordSet{
+		{
+			Name:    apex,
+			Type:    "A",
+			Ttl:     300,
+			Rrdatas: access.Addresses(),
+		},
+		{
+			Name:    "*.googleapis.com.",
+			Type:    "CNAME",
+			Ttl:     300,
+			Rrdatas: []string{apex},
+		},
+	}
+}
+
+// createGoogleAPIsManagedZone creates a private managed zone for googleapis.com
+// in the network of the cluster, so that the cluster reaches the Google APIs
+// through their private addresses. The zone is not created when the network
+// already has one.
+func createGoogleAPIsManagedZone(ctx context.Context, ic *installconfig.InstallConfig, clusterID, network string) error {
+	ssn, err := gcpic.GetSession(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	dnsService, err := dns.NewService(ctx, option.WithCredentials(ssn.Credentials))
+	if err != nil {
+		return fmt.Errorf("failed to create the gcp dns service: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*2)
+	defer cancel()
+
+	projects := []string{ic.Config.GCP.ProjectID}
+	if ic.Config.GCP.NetworkProjectID != "" {
+		projects = append(projects, ic.Config.GCP.NetworkProjectID)
+	}
+	for _, project := range projects {
+		zones, err := dnsService.ManagedZones.List(project).DnsName(googleAPIsDomain).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("failed to list the managed zones of project %s: %w", project, err)
+		}
+		for _, zone := range zones.ManagedZones {
+			if zone.Visibility != "private" || zone.PrivateVisibilityConfig == nil {
+				continue
+			}
+			for _, n := range zone.PrivateVisibilityConfig.Networks {
+				if path.Base(n.NetworkUrl) == path.Base(network) {
+					logrus.Infof("Using the existing %s zone %s of the network to reach the Google APIs", googleAPIsDomain, zone.Name)
+					return nil
+				}
+			}
+		}
+	}
+
+	managedZone := &dns.ManagedZone{
+		Name:        fmt.Sprintf("%s-googleapis-zone", clusterID),
+		Description: resourceDescription,
+		DnsName:     googleAPIsDomain,
+		Visibility:  "private",
+		Labels:      mergeLabels(ic, clusterID),
+		PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+			Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{
+				{
+					NetworkUrl: network,
+				},
+			},
+		},
+	}
+	if _, err = dnsService.ManagedZones.Create(ic.Config.GCP.ProjectID, managedZone).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to create the %s managed zone: %w", googleAPIsDomain, err)
+	}
+
+	change := &dns.Change{Additions: googleAPIsRecordSets(ic.Config.GCP.GoogleAPIAccess)}
+	if _, err := dnsService.Changes.Create(ic.Config.GCP.ProjectID, managedZone.Name, change).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to create the %s record sets: %w", googleAPIsDomain, err)
+	}
+	return nil
+}
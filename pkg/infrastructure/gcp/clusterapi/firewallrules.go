@@ -7,6 +7,7 @@ import (
 
 	"google.golang.org/api/compute/v1"
 
+	"github.com/openshift/installer/pkg/asset/manifests/capiutils"
 	"github.com/openshift/installer/pkg/infrastructure/clusterapi"
 )
 
@@ -108,6 +109,17 @@ func getInternalNetworkPorts() []*compute.FirewallAllowed {
 	}
 }
 
+func getBastionPorts() []*compute.FirewallAllowed {
+	return []*compute.FirewallAllowed{
+		{
+			IPProtocol: "tcp",
+			Ports: []string{
+				"22", // SSH
+			},
+		},
+	}
+}
+
 // addFirewallRule creates the firewall rule and adds it the compute's firewalls.
 func addFirewallRule(ctx context.Context, name, network, projectID string, ports []*compute.FirewallAllowed, srcTags, targetTags, srcRanges []string) error {
 	service, err := NewComputeService()
@@ -185,7 +197,20 @@ func createFirewallRules(ctx context.Context, in clusterapi.InfraReadyInput, net
 	targetTags = []string{workerTag, masterTag}
 	machineCIDR := in.InstallConfig.Config.Networking.MachineNetwork[0].CIDR.String()
 	srcRanges = []string{machineCIDR}
-	err := addFirewallRule(ctx, firewallName, network, projectID, getInternalNetworkPorts(), srcTags, targetTags, srcRanges)
+	if err := addFirewallRule(ctx, firewallName, network, projectID, getInternalNetworkPorts(), srcTags, targetTags, srcRanges); err != nil {
+		return err
+	}
 
-	return err
+	// bastion rules are used to access ssh on the bastion host from anywhere
+	if in.InstallConfig.Config.Bastion != nil {
+		firewallName = fmt.Sprintf("%s-bastion", in.InfraID)
+		srcTags = []string{}
+		targetTags = []string{capiutils.BastionNetworkTag(in.InfraID)}
+		srcRanges = []string{"0.0.0.0/0"}
+		if err := addFirewallRule(ctx, firewallName, network, projectID, getBastionPorts(), srcTags, targetTags, srcRanges); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
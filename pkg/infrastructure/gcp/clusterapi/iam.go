@@ -38,10 +38,7 @@ func GetMasterRoles() []string {
 
 // GetWorkerRoles returns the pre-defined roles for a worker node.
 func GetWorkerRoles() []string {
-	return []string{
-		"roles/compute.viewer",
-		"roles/storage.admin",
-	}
+	return gcp.ComputeRoles
 }
 
 // CreateServiceAccount is used to create a service account for a compute instance.
@@ -35,7 +35,7 @@ func EditIgnition(ctx context.Context, in clusterapi.IgnitionInput) ([]byte, err
 	ctx, cancel := context.WithTimeout(ctx, time.Minute*2)
 	defer cancel()
 
-	if in.InstallConfig.Config.GCP.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled {
+	if in.InstallConfig.Config.ClusterHostedDNS() {
 		gcpCluster := &capg.GCPCluster{}
 		key := client.ObjectKey{
 			Name:      in.InfraID,
@@ -0,0 +1,37 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/openshift/api/features"
+	"github.com/openshift/installer/pkg/infrastructure"
+	"github.com/openshift/installer/pkg/types"
+	typesazure "github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/featuregates"
+)
+
+// ProviderForMetadata returns the infrastructure provider that provisioned
+// the cluster described by the metadata.
+func ProviderForMetadata(metadata *types.ClusterMetadata) (infrastructure.Provider, error) {
+	platform := metadata.Platform()
+	if platform == "" {
+		return nil, errors.New("no platform configured in metadata")
+	}
+
+	// Azure Stack uses the Azure platform but has its own Terraform configuration.
+	if platform == typesazure.Name && metadata.Azure.CloudName == typesazure.StackCloud {
+		platform = typesazure.StackTerraformName
+	}
+
+	// Get cluster profile for new FeatureGate access.  Blank is no longer an option, so default to
+	// SelfManaged.
+	clusterProfile := types.GetClusterProfileName()
+	featureSets, ok := features.AllFeatureSets()[clusterProfile]
+	if !ok {
+		return nil, fmt.Errorf("no feature sets for cluster profile %q", clusterProfile)
+	}
+	fg := featuregates.FeatureGateFromFeatureSets(featureSets, metadata.FeatureSet, metadata.CustomFeatureSet)
+
+	return ProviderForPlatform(platform, fg)
+}
@@ -6,7 +6,6 @@ package platform
 import (
 	"fmt"
 
-	"github.com/openshift/api/features"
 	"github.com/openshift/installer/pkg/infrastructure"
 	awscapi "github.com/openshift/installer/pkg/infrastructure/aws/clusterapi"
 	awsinfra "github.com/openshift/installer/pkg/infrastructure/aws/sdk"
@@ -20,7 +19,6 @@ import (
 	powervscapi "github.com/openshift/installer/pkg/infrastructure/powervs/clusterapi"
 	vspherecapi "github.com/openshift/installer/pkg/infrastructure/vsphere/clusterapi"
 	"github.com/openshift/installer/pkg/terraform"
-	"github.com/openshift/installer/pkg/terraform/stages/aws"
 	"github.com/openshift/installer/pkg/terraform/stages/azure"
 	"github.com/openshift/installer/pkg/terraform/stages/gcp"
 	"github.com/openshift/installer/pkg/terraform/stages/ibmcloud"
@@ -54,10 +52,7 @@ func ProviderForPlatform(platform string, fg featuregates.FeatureGate) (infrastr
 		if types.ClusterAPIFeatureGateEnabled(platform, fg) {
 			return clusterapi.InitializeProvider(&awscapi.Provider{}), nil
 		}
-		if fg.Enabled(features.FeatureGateInstallAlternateInfrastructureAWS) {
-			return awsinfra.InitializeProvider(), nil
-		}
-		return terraform.InitializeProvider(aws.PlatformStages), nil
+		return awsinfra.InitializeProvider(), nil
 	case azuretypes.Name:
 		if types.ClusterAPIFeatureGateEnabled(platform, fg) {
 			return clusterapi.InitializeProvider(&azureinfra.Provider{}), nil
@@ -110,3 +105,20 @@ func ProviderForPlatform(platform string, fg featuregates.FeatureGate) (infrastr
 	}
 	return nil, fmt.Errorf("unsupported platform %q", platform)
 }
+
+// TerraformStages returns the terraform stages of the platforms provisioned
+// with terraform, by platform.
+func TerraformStages() map[string][]terraform.Stage {
+	return map[string][]terraform.Stage{
+		azuretypes.Name:               azure.PlatformStages,
+		azuretypes.StackTerraformName: azure.StackPlatformStages,
+		gcptypes.Name:                 gcp.PlatformStages,
+		ibmcloudtypes.Name:            ibmcloud.PlatformStages,
+		libvirttypes.Name:             libvirt.PlatformStages,
+		nutanixtypes.Name:             nutanix.PlatformStages,
+		openstacktypes.Name:           openstack.PlatformStages,
+		ovirttypes.Name:               ovirt.PlatformStages,
+		powervstypes.Name:             powervs.PlatformStages,
+		vspheretypes.Name:             vsphere.PlatformStages,
+	}
+}
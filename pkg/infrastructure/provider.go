@@ -20,10 +20,26 @@ type Provider interface {
 	// DestroyBootstrap destroys the temporary bootstrap resources.
 	DestroyBootstrap(dir string) error
 
+	// Destroy cleans up what the provider keeps in the install dir to track
+	// the infrastructure, such as state files. It is called once the cluster
+	// resources have been destroyed.
+	Destroy(dir string) error
+
 	// ExtractHostAddresses extracts the IPs of the bootstrap and control plane machines.
 	ExtractHostAddresses(dir string, config *types.InstallConfig, ha *HostAddresses) error
 }
 
+// Planner is implemented by the providers that can preview the
+// infrastructure resources they would create, without creating them.
+type Planner interface {
+	// Plan previews the infrastructure resources of the stages.
+	// ctx: parent context
+	// dir: the path of the install dir
+	// parents: the parent assets, which can be used to obtain any cluser asset dependencies
+	// returns a slice of File assets holding the previews.
+	Plan(ctx context.Context, dir string, parents asset.Parents) ([]*asset.File, error)
+}
+
 // HostAddresses contains the node addresses & ports to be
 // used for gather bootsrap debug logs.
 type HostAddresses struct {
@@ -27,7 +27,7 @@ func (p Provider) Name() string {
 	return vsphere.Name
 }
 
-func initializeFoldersAndTemplates(ctx context.Context, cachedImage string, failureDomain vsphere.FailureDomain, session *session.Session, diskType vsphere.DiskType, clusterID, tagID string) error {
+func initializeFoldersAndTemplates(ctx context.Context, cachedImage string, libraryItem *rhcosLibraryItem, failureDomain vsphere.FailureDomain, session *session.Session, diskType vsphere.DiskType, clusterID, tagID string) error {
 	finder := session.Finder
 
 	dc, err := finder.Datacenter(ctx, failureDomain.Topology.Datacenter)
@@ -55,6 +55,12 @@ func initializeFoldersAndTemplates(ctx context.Context, cachedImage string, fail
 
 	// if the template is empty, the ova must be imported
 	if len(failureDomain.Topology.Template) == 0 {
+		if libraryItem != nil {
+			if err = deployRhcosTemplate(ctx, session, folderMo, libraryItem, clusterID, tagID, string(diskType), failureDomain); err != nil {
+				return fmt.Errorf("failed to deploy template from content library: %w", err)
+			}
+			return nil
+		}
 		if err = importRhcosOva(ctx, session, folderMo,
 			cachedImage, clusterID, tagID, string(diskType), failureDomain); err != nil {
 			return fmt.Errorf("failed to import ova: %w", err)
@@ -70,12 +76,13 @@ func (p Provider) PreProvision(ctx context.Context, in clusterapi.PreProvisionIn
 	 * one tag and tag category per vcenter
 	 * one folder per datacenter
 	 * one template per region/zone aka failuredomain
+	 * one content library item per vcenter, when a content library is used
 	 */
 	installConfig := in.InstallConfig
 	clusterID := &installconfig.ClusterID{InfraID: in.InfraID}
 	var tagID string
 
-	cachedImage, err := cache.DownloadImageFile(string(*in.RhcosImage), cache.InstallerApplicationName)
+	cachedImage, err := cache.DownloadImageFile(in.RhcosImage.ControlPlane, cache.InstallerApplicationName)
 	if err != nil {
 		return fmt.Errorf("failed to use cached vsphere image: %w", err)
 	}
@@ -101,12 +108,20 @@ func (p Provider) PreProvision(ctx context.Context, in clusterapi.PreProvisionIn
 			}
 		}
 
+		var libraryItem *rhcosLibraryItem
 		for _, failureDomain := range installConfig.Config.VSphere.FailureDomains {
 			if failureDomain.Server != server {
 				continue
 			}
 
-			if err = initializeFoldersAndTemplates(ctx, cachedImage, failureDomain, vctrSession, installConfig.Config.VSphere.DiskType, clusterID.InfraID, tagID); err != nil {
+			if libraryName := installConfig.Config.VSphere.ContentLibrary; libraryName != "" && libraryItem == nil && failureDomain.Topology.Template == "" {
+				libraryItem, err = importRhcosToContentLibrary(ctx, vctrSession, libraryName, cachedImage, tagID, failureDomain)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err = initializeFoldersAndTemplates(ctx, cachedImage, libraryItem, failureDomain, vctrSession, installConfig.Config.VSphere.DiskType, clusterID.InfraID, tagID); err != nil {
 				return fmt.Errorf("unable to initialize folders and templates: %w", err)
 			}
 		}
@@ -0,0 +1,330 @@
+package clusterapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/govc/importx"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+
+	"github.com/openshift/installer/pkg/types/vsphere"
+)
+
+// libraryItemType is the type of content library items when tagged.
+const libraryItemType = "com.vmware.content.library.Item"
+
+// rhcosLibraryItem is the RHCOS OVA imported into a content library.
+type rhcosLibraryItem struct {
+	ID string
+	// Network is the name of the network defined by the OVF.
+	Network string
+}
+
+// importRhcosToContentLibrary imports the RHCOS OVA as an OVF item of the
+// content library, creating the library if needed. The item is named after
+// the sha256 of the OVA so that it is shared by every install using the same
+// image; an existing item is only reused if its files match the checksums of
+// the OVA manifest. The cluster tag is attached to the item to record that the
+// cluster references it.
+func importRhcosToContentLibrary(ctx context.Context, session *session.Session, libraryName, cachedImage, tagID string, failureDomain vsphere.FailureDomain) (*rhcosLibraryItem, error) {
+	m := library.NewManager(session.TagManager.Client)
+
+	sum, err := fileSHA256(cachedImage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum ova %s: %w", cachedImage, err)
+	}
+	itemName := "rhcos-" + sum
+
+	archive := &importx.ArchiveFlag{Archive: &importx.TapeArchive{Path: cachedImage}}
+	ovfName, ovfEnvelope, err := readOvf(archive)
+	if err != nil {
+		return nil, err
+	}
+	if len(ovfEnvelope.Network.Networks) != 1 {
+		return nil, fmt.Errorf("expected the OVA to only have a single network adapter")
+	}
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{ovfName}
+	for _, ref := range ovfEnvelope.References {
+		files = append(files, ref.Href)
+	}
+	for _, f := range files {
+		if manifest[f] == nil {
+			return nil, fmt.Errorf("ova manifest has no checksum for %s", f)
+		}
+	}
+
+	libraryID, err := findOrCreateLibrary(ctx, session, m, libraryName, failureDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	itemIDs, err := m.FindLibraryItems(ctx, library.FindItem{LibraryID: libraryID, Name: itemName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content library item %s: %w", itemName, err)
+	}
+
+	var itemID string
+	if len(itemIDs) > 0 {
+		itemID = itemIDs[0]
+		logrus.Infof("Using OVA %s already imported into content library %s.", itemName, libraryName)
+		if err := verifyLibraryItem(ctx, m, itemID, manifest, files); err != nil {
+			return nil, fmt.Errorf("content library item %s does not match the ova, delete it to import the ova again: %w", itemName, err)
+		}
+	} else {
+		logrus.Infof("Importing OVA %s into content library %s.", itemName, libraryName)
+		itemID, err = uploadLibraryItem(ctx, m, libraryID, itemName, archive, manifest, files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import ova into content library %s: %w", libraryName, err)
+		}
+	}
+
+	ref := types.ManagedObjectReference{Type: libraryItemType, Value: itemID}
+	if err := session.TagManager.AttachTag(ctx, tagID, ref); err != nil {
+		return nil, fmt.Errorf("unable to attach tag to content library item: %w", err)
+	}
+
+	return &rhcosLibraryItem{ID: itemID, Network: ovfEnvelope.Network.Networks[0].Name}, nil
+}
+
+func findOrCreateLibrary(ctx context.Context, session *session.Session, m *library.Manager, name string, failureDomain vsphere.FailureDomain) (string, error) {
+	ids, err := m.FindLibrary(ctx, library.Find{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("failed to find content library %s: %w", name, err)
+	}
+	if len(ids) > 0 {
+		return ids[0], nil
+	}
+
+	datastore, err := session.Finder.Datastore(ctx, failureDomain.Topology.Datastore)
+	if err != nil {
+		return "", fmt.Errorf("failed to find datastore: %w", err)
+	}
+	logrus.Infof("Creating content library %s on datastore %s.", name, datastore.Name())
+	id, err := m.CreateLibrary(ctx, library.Library{
+		Name: name,
+		Type: "LOCAL",
+		Storage: []library.StorageBacking{{
+			DatastoreID: datastore.Reference().Value,
+			Type:        "DATASTORE",
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create content library %s: %w", name, err)
+	}
+	return id, nil
+}
+
+// verifyLibraryItem checks that the files of the item have the checksums
+// listed in the OVA manifest.
+func verifyLibraryItem(ctx context.Context, m *library.Manager, itemID string, manifest map[string]*library.Checksum, files []string) error {
+	itemFiles, err := m.ListLibraryItemFiles(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	checksums := map[string]*library.Checksum{}
+	for _, f := range itemFiles {
+		checksums[f.Name] = f.Checksum
+	}
+	for _, name := range files {
+		got, want := checksums[name], manifest[name]
+		if got == nil {
+			return fmt.Errorf("file %s is missing", name)
+		}
+		if got.Checksum != want.Checksum {
+			return fmt.Errorf("file %s has %s checksum %s, expected %s", name, want.Algorithm, got.Checksum, want.Checksum)
+		}
+	}
+	return nil
+}
+
+// uploadLibraryItem creates the item and pushes the OVF descriptor and disks
+// of the OVA into it. vCenter verifies each file against its manifest checksum.
+func uploadLibraryItem(ctx context.Context, m *library.Manager, libraryID, itemName string, archive *importx.ArchiveFlag, manifest map[string]*library.Checksum, files []string) (string, error) {
+	itemID, err := m.CreateLibraryItem(ctx, library.Item{
+		Name:      itemName,
+		Type:      library.ItemTypeOVF,
+		LibraryID: libraryID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create content library item: %w", err)
+	}
+
+	sessionID, err := m.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		if derr := m.DeleteLibraryItem(ctx, &library.Item{ID: itemID}); derr != nil {
+			logrus.Warnf("Failed to delete content library item %s: %v", itemName, derr)
+		}
+		return "", fmt.Errorf("failed to create update session: %w", err)
+	}
+
+	for _, name := range files {
+		if err = uploadLibraryFile(ctx, m, sessionID, archive, name, manifest[name]); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = m.CompleteLibraryItemUpdateSession(ctx, sessionID)
+	}
+	if err == nil {
+		err = m.WaitOnLibraryItemUpdateSession(ctx, sessionID, 3*time.Second, nil)
+	}
+	if err != nil {
+		// Do not leave a partial item behind, it would be reused by the next install.
+		if cerr := m.CancelLibraryItemUpdateSession(ctx, sessionID); cerr != nil {
+			logrus.Debugf("Failed to cancel update session %s: %v", sessionID, cerr)
+		}
+		if derr := m.DeleteLibraryItem(ctx, &library.Item{ID: itemID}); derr != nil {
+			logrus.Warnf("Failed to delete partially imported content library item %s: %v", itemName, derr)
+		}
+		return "", err
+	}
+	return itemID, nil
+}
+
+func uploadLibraryFile(ctx context.Context, m *library.Manager, sessionID string, archive *importx.ArchiveFlag, name string, checksum *library.Checksum) error {
+	f, size, err := archive.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := m.AddLibraryItemFile(ctx, sessionID, library.UpdateFile{
+		Name:       name,
+		SourceType: "PUSH",
+		Size:       size,
+		Checksum:   checksum,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add file %s: %w", name, err)
+	}
+
+	u, err := url.Parse(info.UploadEndpoint.URI)
+	if err != nil {
+		return err
+	}
+	p := soap.DefaultUpload
+	p.ContentLength = size
+	if err := m.Client.Upload(ctx, f, u, &p); err != nil {
+		return fmt.Errorf("failed to upload file %s: %w", name, err)
+	}
+	return nil
+}
+
+// deployRhcosTemplate deploys the content library item into the RHCOS
+// template of the failure domain.
+func deployRhcosTemplate(ctx context.Context, session *session.Session, folder *object.Folder, item *rhcosLibraryItem, clusterID, tagID, diskProvisioningType string, failureDomain vsphere.FailureDomain) error {
+	name := fmt.Sprintf("%s-rhcos-%s-%s", clusterID, failureDomain.Region, failureDomain.Zone)
+	logrus.Infof("Deploying template %v from content library into failure domain %v.", name, failureDomain.Name)
+
+	cluster, err := session.Finder.ClusterComputeResource(ctx, failureDomain.Topology.ComputeCluster)
+	if err != nil {
+		return fmt.Errorf("failed to find compute cluster: %w", err)
+	}
+	resourcePool, err := session.Finder.ResourcePool(ctx, failureDomain.Topology.ResourcePool)
+	if err != nil {
+		return fmt.Errorf("failed to find resource pool: %w", err)
+	}
+	networkRef, err := session.Finder.Network(ctx, path.Join(cluster.InventoryPath, failureDomain.Topology.Networks[0]))
+	if err != nil {
+		return fmt.Errorf("failed to find network: %w", err)
+	}
+	datastore, err := session.Finder.Datastore(ctx, failureDomain.Topology.Datastore)
+	if err != nil {
+		return fmt.Errorf("failed to find datastore: %w", err)
+	}
+
+	deploy := vcenter.Deploy{
+		DeploymentSpec: vcenter.DeploymentSpec{
+			Name:                name,
+			DefaultDatastoreID:  datastore.Reference().Value,
+			StorageProvisioning: diskProvisioningType,
+			AcceptAllEULA:       true,
+			NetworkMappings: []vcenter.NetworkMapping{{
+				Key:   item.Network,
+				Value: networkRef.Reference().Value,
+			}},
+		},
+		Target: vcenter.Target{
+			ResourcePoolID: resourcePool.Reference().Value,
+			FolderID:       folder.Reference().Value,
+		},
+	}
+	ref, err := vcenter.NewManager(session.TagManager.Client).DeployLibraryItem(ctx, item.ID, deploy)
+	if err != nil {
+		return fmt.Errorf("failed to deploy content library item: %w", err)
+	}
+
+	vm := object.NewVirtualMachine(session.Client.Client, *ref)
+	if err := vm.MarkAsTemplate(ctx); err != nil {
+		return fmt.Errorf("failed to mark vm as template: %w", err)
+	}
+	if err := attachTag(ctx, session, vm.Reference().Value, tagID); err != nil {
+		return fmt.Errorf("failed to attach tag: %w", err)
+	}
+	return nil
+}
+
+// readOvf returns the name and the envelope of the OVF descriptor of the OVA.
+func readOvf(archive *importx.ArchiveFlag) (string, *ovf.Envelope, error) {
+	r, _, err := archive.Open("*.ovf")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read the ovf descriptor: %w", err)
+	}
+	defer r.Close()
+	name := "rhcos.ovf"
+	if entry, ok := r.(*importx.TapeArchiveEntry); ok {
+		name = path.Base(entry.Name)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read the ovf descriptor: %w", err)
+	}
+	envelope, err := archive.ReadEnvelope(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse ovf: %w", err)
+	}
+	return name, envelope, nil
+}
+
+func readManifest(archive *importx.ArchiveFlag) (map[string]*library.Checksum, error) {
+	r, _, err := archive.Open("*.mf")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ova manifest: %w", err)
+	}
+	defer r.Close()
+	manifest, err := library.ReadManifest(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the ova manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func fileSHA256(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -55,6 +55,7 @@ func createClusterTagID(ctx context.Context, session *session.Session, clusterID
 				"urn:vim25:Folder",
 				"urn:vim25:Datastore",
 				"urn:vim25:StoragePod",
+				libraryItemType,
 			},
 		}
 		tagCategoryID, err = tagManager.CreateCategory(ctx, clusterTagCategory)
@@ -0,0 +1,230 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Emitter sends the reports of the installer commands to a metrics backend.
+type Emitter interface {
+	Emit(ctx context.Context, r *Report) error
+}
+
+// NewEmitter returns the emitter of the metrics URL:
+//   - file:///path appends the reports to a local file, one JSON object
+//     per line.
+//   - http(s)://host[/path] sends the reports to an OpenTelemetry collector
+//     with OTLP over HTTP, to the path or /v1/metrics if it has none.
+func NewEmitter(metricsURL string) (Emitter, error) {
+	u, err := url.Parse(metricsURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid metrics URL")
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.Errorf("invalid metrics URL %s: missing path", metricsURL)
+		}
+		return &fileEmitter{path: u.Path}, nil
+	case "http", "https":
+		if u.Path == "" || u.Path == "/" {
+			u.Path = "/v1/metrics"
+		}
+		return &otlpEmitter{url: u.String(), client: &http.Client{Timeout: 30 * time.Second}}, nil
+	default:
+		return nil, errors.Errorf("unsupported metrics URL scheme %q, expected file, http or https", u.Scheme)
+	}
+}
+
+type fileEmitter struct {
+	path string
+}
+
+// Emit appends the report to the file.
+func (e *fileEmitter) Emit(ctx context.Context, r *Report) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the metrics report")
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0750); err != nil {
+		return errors.Wrap(err, "failed to create the metrics directory")
+	}
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the metrics file")
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write the metrics file")
+	}
+	return nil
+}
+
+type otlpEmitter struct {
+	url    string
+	client *http.Client
+}
+
+// Emit sends the report as OTLP metrics, encoded in JSON.
+func (e *otlpEmitter) Emit(ctx context.Context, r *Report) error {
+	data, err := json.Marshal(otlpRequest(r))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the metrics report")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the metrics request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send the metrics")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("failed to send the metrics: %s", resp.Status)
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP metrics protocol, in its JSON
+// encoding, used to send the reports.
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpDataPoint struct {
+	Attributes        []otlpAttribute `json:"attributes"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	AsDouble          *float64        `json:"asDouble,omitempty"`
+	AsInt             string          `json:"asInt,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpDataPoint `json:"dataPoints"`
+	AggregationTemporality int             `json:"aggregationTemporality"`
+	IsMonotonic            bool            `json:"isMonotonic"`
+}
+
+type otlpMetric struct {
+	Name  string     `json:"name"`
+	Unit  string     `json:"unit,omitempty"`
+	Gauge *otlpGauge `json:"gauge,omitempty"`
+	Sum   *otlpSum   `json:"sum,omitempty"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpExportRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+// aggregationTemporalityDelta is the temporality of the counter of the
+// commands, which counts each command once.
+const aggregationTemporalityDelta = 1
+
+// attributes returns the attributes of the key-value pairs, skipping the
+// empty values.
+func attributes(keyValues ...string) []otlpAttribute {
+	attrs := make([]otlpAttribute, 0, len(keyValues)/2)
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		if keyValues[i+1] != "" {
+			attrs = append(attrs, otlpAttribute{Key: keyValues[i], Value: otlpAnyValue{StringValue: keyValues[i+1]}})
+		}
+	}
+	return attrs
+}
+
+// otlpRequest converts the report to a counter of the commands, by result
+// and failure classification, and gauges of the duration of the command and
+// of each of its stages.
+func otlpRequest(r *Report) *otlpExportRequest {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	command := strings.ReplaceAll(r.Command, " ", "_")
+
+	runs := otlpMetric{
+		Name: "openshift_install.runs",
+		Sum: &otlpSum{
+			DataPoints: []otlpDataPoint{{
+				Attributes:        attributes("command", command, "result", r.Result, "failed_phase", r.FailedPhase, "failed_stage", r.FailedStage),
+				StartTimeUnixNano: strconv.FormatInt(r.StartTime.UnixNano(), 10),
+				TimeUnixNano:      now,
+				AsInt:             "1",
+			}},
+			AggregationTemporality: aggregationTemporalityDelta,
+			IsMonotonic:            true,
+		},
+	}
+	duration := otlpMetric{
+		Name: "openshift_install.duration",
+		Unit: "s",
+		Gauge: &otlpGauge{DataPoints: []otlpDataPoint{{
+			Attributes:   attributes("command", command, "result", r.Result),
+			TimeUnixNano: now,
+			AsDouble:     &r.DurationSeconds,
+		}}},
+	}
+	stages := otlpMetric{Name: "openshift_install.stage.duration", Unit: "s", Gauge: &otlpGauge{}}
+	for i := range r.Stages {
+		s := &r.Stages[i]
+		stages.Gauge.DataPoints = append(stages.Gauge.DataPoints, otlpDataPoint{
+			Attributes:   attributes("command", command, "stage", s.Name, "phase", s.Phase, "completed", strconv.FormatBool(s.Completed)),
+			TimeUnixNano: now,
+			AsDouble:     &s.DurationSeconds,
+		})
+	}
+
+	metrics := []otlpMetric{runs, duration}
+	if len(stages.Gauge.DataPoints) > 0 {
+		metrics = append(metrics, stages)
+	}
+	return &otlpExportRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource: otlpResource{Attributes: attributes(
+			"service.name", "openshift-install",
+			"service.version", r.Version,
+			"openshift.platform", r.Platform,
+			"openshift.infra_id", r.InfraID,
+		)},
+		ScopeMetrics: []otlpScopeMetrics{{
+			Scope:   otlpScope{Name: "github.com/openshift/installer/pkg/metrics/report"},
+			Metrics: metrics,
+		}},
+	}}}
+}
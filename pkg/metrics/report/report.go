@@ -0,0 +1,105 @@
+// Package report records the duration of the phases of an installer command
+// and the classification of its failure, and emits them to a metrics backend
+// so that the reliability of the installs can be tracked over time.
+package report
+
+import (
+	"time"
+
+	"github.com/openshift/installer/pkg/metrics/timer"
+)
+
+// Phases of the installer commands the stages of the timer belong to.
+const (
+	PhaseAssetGeneration = "asset-generation"
+	PhaseInfrastructure  = "infrastructure"
+	PhaseBootstrap       = "bootstrap"
+	PhaseOperatorRollout = "operator-rollout"
+)
+
+// Results of the installer commands.
+const (
+	ResultSuccess     = "success"
+	ResultFailure     = "failure"
+	ResultInterrupted = "interrupted"
+)
+
+// stagePhases maps the stages of the timer that are not part of the
+// infrastructure provisioning to their phase. The other stages are the
+// terraform and cluster-api provisioning stages.
+var stagePhases = map[string]string{
+	"API":                         PhaseBootstrap,
+	"Bootstrap Complete":          PhaseBootstrap,
+	"Bootstrap Destroy":           PhaseBootstrap,
+	"Cluster Operators Available": PhaseOperatorRollout,
+	"Cluster Operators Stable":    PhaseOperatorRollout,
+	"Console":                     PhaseOperatorRollout,
+}
+
+// Stage is the time spent in a stage of the command.
+type Stage struct {
+	Name            string  `json:"name"`
+	Phase           string  `json:"phase"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Completed       bool    `json:"completed"`
+}
+
+// Report is the outcome of an installer command.
+type Report struct {
+	Command         string    `json:"command"`
+	Version         string    `json:"version"`
+	Platform        string    `json:"platform,omitempty"`
+	InfraID         string    `json:"infraID,omitempty"`
+	StartTime       time.Time `json:"startTime"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Result          string    `json:"result"`
+	// FailedPhase and FailedStage classify the failure of the command, by
+	// the phase and the stage it was in when it failed.
+	FailedPhase string  `json:"failedPhase,omitempty"`
+	FailedStage string  `json:"failedStage,omitempty"`
+	Stages      []Stage `json:"stages"`
+}
+
+// PhaseOf returns the phase the stage of the timer belongs to.
+func PhaseOf(stage string) string {
+	if phase, ok := stagePhases[stage]; ok {
+		return phase
+	}
+	return PhaseInfrastructure
+}
+
+// New returns the report of the command from the stages of its timer. A
+// failed command is classified by the innermost stage that was not
+// complete, or as failed during the asset generation when it was not in
+// any stage.
+func New(command string, version string, result string, stages []timer.Stage) *Report {
+	r := &Report{
+		Command: command,
+		Version: version,
+		Result:  result,
+		Stages:  []Stage{},
+	}
+	for _, s := range stages {
+		if s.Name == timer.TotalTimeElapsed {
+			r.DurationSeconds = s.Duration.Seconds()
+			continue
+		}
+		r.Stages = append(r.Stages, Stage{
+			Name:            s.Name,
+			Phase:           PhaseOf(s.Name),
+			DurationSeconds: s.Duration.Seconds(),
+			Completed:       s.Done,
+		})
+	}
+	r.StartTime = time.Now().Add(-time.Duration(r.DurationSeconds * float64(time.Second))).UTC()
+
+	if result != ResultSuccess {
+		r.FailedPhase = PhaseAssetGeneration
+		for _, s := range r.Stages {
+			if !s.Completed {
+				r.FailedPhase, r.FailedStage = s.Phase, s.Name
+			}
+		}
+	}
+	return r
+}
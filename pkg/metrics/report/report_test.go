@@ -0,0 +1,161 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/metrics/timer"
+)
+
+func TestNew(t *testing.T) {
+	stages := []timer.Stage{
+		{Name: timer.TotalTimeElapsed, Duration: 40 * time.Minute},
+		{Name: "cluster", Duration: 5 * time.Minute, Done: true},
+		{Name: "Bootstrap Complete", Duration: 20 * time.Minute, Done: true},
+		{Name: "Cluster Operators Available", Duration: 15 * time.Minute},
+	}
+	cases := []struct {
+		name   string
+		result string
+		stages []timer.Stage
+		phase  string
+		stage  string
+	}{
+		{
+			name:   "success",
+			result: ResultSuccess,
+			stages: stages,
+		},
+		{
+			name:   "failed operator rollout",
+			result: ResultFailure,
+			stages: stages,
+			phase:  PhaseOperatorRollout,
+			stage:  "Cluster Operators Available",
+		},
+		{
+			name:   "failed infrastructure",
+			result: ResultInterrupted,
+			stages: []timer.Stage{
+				{Name: timer.TotalTimeElapsed, Duration: time.Minute},
+				{Name: "network", Duration: time.Minute},
+			},
+			phase: PhaseInfrastructure,
+			stage: "network",
+		},
+		{
+			name:   "failed asset generation",
+			result: ResultFailure,
+			stages: []timer.Stage{{Name: timer.TotalTimeElapsed, Duration: time.Second}},
+			phase:  PhaseAssetGeneration,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New("create cluster", "4.16.0", tc.result, tc.stages)
+			assert.Equal(t, tc.result, r.Result)
+			assert.Equal(t, tc.phase, r.FailedPhase)
+			assert.Equal(t, tc.stage, r.FailedStage)
+			assert.Equal(t, tc.stages[0].Duration.Seconds(), r.DurationSeconds)
+			assert.Len(t, r.Stages, len(tc.stages)-1)
+		})
+	}
+
+	r := New("create cluster", "4.16.0", ResultSuccess, stages)
+	assert.Equal(t, []Stage{
+		{Name: "cluster", Phase: PhaseInfrastructure, DurationSeconds: 300, Completed: true},
+		{Name: "Bootstrap Complete", Phase: PhaseBootstrap, DurationSeconds: 1200, Completed: true},
+		{Name: "Cluster Operators Available", Phase: PhaseOperatorRollout, DurationSeconds: 900},
+	}, r.Stages)
+}
+
+func TestNewEmitter(t *testing.T) {
+	cases := []struct {
+		url string
+		err string
+	}{
+		{url: "file:///var/log/installs.json"},
+		{url: "http://collector:4318"},
+		{url: "https://collector/otlp/v1/metrics"},
+		{url: "file://", err: "invalid metrics URL file://: missing path"},
+		{url: "s3://bucket/metrics", err: `unsupported metrics URL scheme "s3", expected file, http or https`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.url, func(t *testing.T) {
+			_, err := NewEmitter(tc.url)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestFileEmitter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics", "installs.json")
+	emitter, err := NewEmitter("file://" + path)
+	assert.NoError(t, err)
+
+	for _, result := range []string{ResultFailure, ResultSuccess} {
+		assert.NoError(t, emitter.Emit(context.Background(), New("create cluster", "4.16.0", result, nil)))
+	}
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	var r Report
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &r))
+	assert.Equal(t, ResultFailure, r.Result)
+	assert.Equal(t, PhaseAssetGeneration, r.FailedPhase)
+}
+
+func TestOTLPEmitter(t *testing.T) {
+	var received otlpExportRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	emitter, err := NewEmitter(server.URL)
+	assert.NoError(t, err)
+	r := New("create cluster", "4.16.0", ResultFailure, []timer.Stage{
+		{Name: timer.TotalTimeElapsed, Duration: 30 * time.Minute},
+		{Name: "Bootstrap Complete", Duration: 30 * time.Minute},
+	})
+	r.Platform = "aws"
+	assert.NoError(t, emitter.Emit(context.Background(), r))
+
+	if assert.Len(t, received.ResourceMetrics, 1) {
+		rm := received.ResourceMetrics[0]
+		assert.Contains(t, rm.Resource.Attributes, otlpAttribute{Key: "openshift.platform", Value: otlpAnyValue{StringValue: "aws"}})
+		metrics := rm.ScopeMetrics[0].Metrics
+		assert.Len(t, metrics, 3)
+		assert.Equal(t, "openshift_install.runs", metrics[0].Name)
+		assert.Equal(t, []otlpAttribute{
+			{Key: "command", Value: otlpAnyValue{StringValue: "create_cluster"}},
+			{Key: "result", Value: otlpAnyValue{StringValue: ResultFailure}},
+			{Key: "failed_phase", Value: otlpAnyValue{StringValue: PhaseBootstrap}},
+			{Key: "failed_stage", Value: otlpAnyValue{StringValue: "Bootstrap Complete"}},
+		}, metrics[0].Sum.DataPoints[0].Attributes)
+		assert.Equal(t, "openshift_install.stage.duration", metrics[2].Name)
+		assert.Equal(t, 1800.0, *metrics[2].Gauge.DataPoints[0].AsDouble)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	assert.EqualError(t, emitter.Emit(context.Background(), r), "failed to send the metrics: 400 Bad Request")
+}
@@ -37,6 +37,20 @@ func LogSummary() {
 	timer.LogSummary(logrus.StandardLogger())
 }
 
+// Stage is the time spent in a stage of the timer.
+type Stage struct {
+	Name     string
+	Duration time.Duration
+	// Done is false if the stage was started but not stopped yet, in which
+	// case the duration is the time elapsed since it was started.
+	Done bool
+}
+
+// Stages returns the stages collected so far, in the order they were started.
+func Stages() []Stage {
+	return timer.Stages()
+}
+
 // NewTimer returns a new timer that can be used to track sections and
 func NewTimer() Timer {
 	return Timer{
@@ -98,3 +112,16 @@ func (t *Timer) LogSummary(logger *logrus.Logger) {
 	}
 	logger.Infof("Time elapsed: %s", t.stageTimes[TotalTimeElapsed])
 }
+
+// Stages returns the stages collected so far, in the order they were started.
+func (t *Timer) Stages() []Stage {
+	stages := make([]Stage, 0, len(t.listOfStages))
+	for _, item := range t.listOfStages {
+		if duration, done := t.stageTimes[item]; done {
+			stages = append(stages, Stage{Name: item, Duration: duration, Done: true})
+		} else {
+			stages = append(stages, Stage{Name: item, Duration: time.Since(t.startTimes[item]).Round(time.Second)})
+		}
+	}
+	return stages
+}
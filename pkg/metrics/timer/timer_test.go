@@ -129,3 +129,28 @@ func TestNewTimer(t *testing.T) {
 		t.Fatalf("Expected empty list of startTimes property in the new timer created, got %d", len(timer.stageTimes))
 	}
 }
+
+func TestStages(t *testing.T) {
+	timer := NewTimer()
+
+	timer.StartTimer(TotalTimeElapsed)
+	timer.StartTimer("testStage1")
+	timer.StartTimer("testStage2")
+	timer.stageTimes["testStage1"] = 3 * time.Second
+
+	stages := timer.Stages()
+	if len(stages) != 3 {
+		t.Fatalf("Expected 3 stages, got %d", len(stages))
+	}
+	for i, name := range []string{TotalTimeElapsed, "testStage1", "testStage2"} {
+		if stages[i].Name != name {
+			t.Fatalf("Expected stage %d to be %s, got %s", i, name, stages[i].Name)
+		}
+	}
+	if !stages[1].Done || stages[1].Duration != 3*time.Second {
+		t.Fatalf("Expected testStage1 to be done in 3s, got %+v", stages[1])
+	}
+	if stages[2].Done {
+		t.Fatalf("Expected testStage2 not to be done, got %+v", stages[2])
+	}
+}
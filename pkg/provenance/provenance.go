@@ -0,0 +1,179 @@
+// Package provenance signs the files generated by the installer and records
+// an in-toto provenance attestation of the installer and of the inputs they
+// were generated from.
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatementFilename is the name of the provenance attestation in the
+	// assets directory.
+	StatementFilename = "provenance.intoto.json"
+
+	statementType = "https://in-toto.io/Statement/v1"
+	predicateType = "https://slsa.dev/provenance/v1"
+	buildType     = "https://github.com/openshift/installer/provenance/v1"
+	builderID     = "https://github.com/openshift/installer"
+)
+
+// Statement is an in-toto attestation statement.
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Subject  `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is a file covered by the attestation.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance is a SLSA provenance predicate.
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+// BuildDefinition describes the inputs of the installer.
+type BuildDefinition struct {
+	BuildType          string             `json:"buildType"`
+	ExternalParameters ExternalParameters `json:"externalParameters"`
+}
+
+// ExternalParameters are the inputs of the installer.
+type ExternalParameters struct {
+	// Target is the command generating the subjects.
+	Target string `json:"target"`
+	// ReleaseImage is the release image pull spec of the cluster.
+	ReleaseImage string `json:"releaseImage,omitempty"`
+	// InstallConfig is the digest of the install-config. The
+	// install-config itself is not included because it holds secrets.
+	InstallConfig map[string]string `json:"installConfig,omitempty"`
+}
+
+// RunDetails describes the installer run.
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Builder identifies the installer.
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version"`
+}
+
+// Metadata holds the time of the installer run.
+type Metadata struct {
+	FinishedOn time.Time `json:"finishedOn"`
+}
+
+// Inputs are the inputs recorded in the attestation.
+type Inputs struct {
+	// Target is the command generating the files.
+	Target string
+	// InstallerVersion is the version of the installer.
+	InstallerVersion string
+	// InstallerCommit is the commit the installer was built from.
+	InstallerCommit string
+	// ReleaseImage is the release image pull spec of the cluster.
+	ReleaseImage string
+	// InstallConfig is the content of the install-config, if known.
+	InstallConfig []byte
+}
+
+// NewStatement returns the provenance attestation of the files, relative to
+// the directory, generated from the inputs.
+func NewStatement(directory string, files []string, inputs Inputs) (*Statement, error) {
+	files = append([]string(nil), files...)
+	sort.Strings(files)
+	subjects := make([]Subject, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(directory, f))
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, Subject{Name: filepath.ToSlash(f), Digest: digest(data)})
+	}
+
+	params := ExternalParameters{
+		Target:       inputs.Target,
+		ReleaseImage: inputs.ReleaseImage,
+	}
+	if inputs.InstallConfig != nil {
+		params.InstallConfig = digest(inputs.InstallConfig)
+	}
+	version := map[string]string{"openshift-install": inputs.InstallerVersion}
+	if inputs.InstallerCommit != "" {
+		version["commit"] = inputs.InstallerCommit
+	}
+
+	return &Statement{
+		Type:          statementType,
+		Subject:       subjects,
+		PredicateType: predicateType,
+		Predicate: Provenance{
+			BuildDefinition: BuildDefinition{
+				BuildType:          buildType,
+				ExternalParameters: params,
+			},
+			RunDetails: RunDetails{
+				Builder:  Builder{ID: builderID, Version: version},
+				Metadata: Metadata{FinishedOn: time.Now().UTC().Truncate(time.Second)},
+			},
+		},
+	}, nil
+}
+
+// SignAndAttest signs the files, relative to the directory, and writes the
+// signed provenance attestation of the files and inputs to the directory.
+// The signature of each file is written next to it.
+func SignAndAttest(directory string, files []string, inputs Inputs, signer Signer) error {
+	statement, err := NewStatement(directory, files, inputs)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the provenance attestation")
+	}
+	data, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the provenance attestation")
+	}
+	if err := os.WriteFile(filepath.Join(directory, StatementFilename), data, 0o640); err != nil { //nolint:gosec // no sensitive info
+		return errors.Wrap(err, "failed to write the provenance attestation")
+	}
+
+	for _, f := range append(files, StatementFilename) {
+		if err := signFile(filepath.Join(directory, f), signer); err != nil {
+			return errors.Wrapf(err, "failed to sign %s", f)
+		}
+	}
+	return nil
+}
+
+func signFile(path string, signer Signer) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sig, err := signer.Sign(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+signer.Extension(), sig, 0o640) //nolint:gosec // no sensitive info
+}
+
+func digest(data []byte) map[string]string {
+	sum := sha256.Sum256(data)
+	return map[string]string{"sha256": hex.EncodeToString(sum[:])}
+}
@@ -0,0 +1,132 @@
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySigner(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	data := []byte("bootstrap.ign")
+	cases := []struct {
+		name   string
+		key    []byte
+		verify func(t *testing.T, sig []byte)
+		err    string
+	}{
+		{
+			name: "ecdsa",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			verify: func(t *testing.T, sig []byte) {
+				t.Helper()
+				digest := sha256.Sum256(data)
+				assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], sig))
+			},
+		},
+		{
+			name: "ed25519",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER}),
+			verify: func(t *testing.T, sig []byte) {
+				t.Helper()
+				assert.True(t, ed25519.Verify(edKey.Public().(ed25519.PublicKey), data, sig))
+			},
+		},
+		{
+			name: "encrypted",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("key")}),
+			err:  "encrypted signing keys are not supported",
+		},
+		{
+			name: "not pem",
+			key:  []byte("key"),
+			err:  "the signing key is not PEM-encoded",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := NewKeySigner(tc.key)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			encoded, err := signer.Sign(data)
+			require.NoError(t, err)
+			sig, err := base64.StdEncoding.DecodeString(string(encoded))
+			require.NoError(t, err)
+			tc.verify(t, sig)
+		})
+	}
+}
+
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(data []byte) ([]byte, error) {
+	return append([]byte("signed "), data...), nil
+}
+
+func (fakeSigner) Extension() string {
+	return ".sig"
+}
+
+func TestSignAndAttest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "manifests"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests", "cvo-overrides.yaml"), []byte("overrides"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bootstrap.ign"), []byte("ignition"), 0o600))
+
+	files := []string{"manifests/cvo-overrides.yaml", "bootstrap.ign"}
+	err := SignAndAttest(dir, files, Inputs{
+		Target:           "ignition-configs",
+		InstallerVersion: "v4.16.0",
+		ReleaseImage:     "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64",
+		InstallConfig:    []byte("install-config"),
+	}, fakeSigner{})
+	require.NoError(t, err)
+
+	for _, f := range append(files, StatementFilename) {
+		data, err := os.ReadFile(filepath.Join(dir, f))
+		require.NoError(t, err)
+		sig, err := os.ReadFile(filepath.Join(dir, f+".sig"))
+		require.NoError(t, err)
+		assert.Equal(t, "signed "+string(data), string(sig))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, StatementFilename))
+	require.NoError(t, err)
+	var statement Statement
+	require.NoError(t, json.Unmarshal(data, &statement))
+	assert.Equal(t, statementType, statement.Type)
+	assert.Equal(t, predicateType, statement.PredicateType)
+	assert.Equal(t, []Subject{
+		{Name: "bootstrap.ign", Digest: digest([]byte("ignition"))},
+		{Name: "manifests/cvo-overrides.yaml", Digest: digest([]byte("overrides"))},
+	}, statement.Subject)
+	assert.Equal(t, ExternalParameters{
+		Target:        "ignition-configs",
+		ReleaseImage:  "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64",
+		InstallConfig: digest([]byte("install-config")),
+	}, statement.Predicate.BuildDefinition.ExternalParameters)
+	assert.Equal(t, map[string]string{"openshift-install": "v4.16.0"}, statement.Predicate.RunDetails.Builder.Version)
+}
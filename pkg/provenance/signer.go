@@ -0,0 +1,139 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// gpgPrefix is the prefix of the signing key specifications selecting a GPG
+// key rather than a private key file.
+const gpgPrefix = "gpg:"
+
+// Signer produces detached signatures.
+type Signer interface {
+	// Sign returns the detached signature of the data.
+	Sign(data []byte) ([]byte, error)
+	// Extension is the extension of the signature files.
+	Extension() string
+}
+
+// NewSigner returns the signer for the signing key specification, either
+// gpg:<key id> for a key of the GPG keyring or the path of a PEM-encoded,
+// unencrypted ECDSA, Ed25519 or RSA private key.
+func NewSigner(spec string) (Signer, error) {
+	if keyID, ok := strings.CutPrefix(spec, gpgPrefix); ok {
+		if keyID == "" {
+			return nil, errors.New("no GPG key ID given")
+		}
+		return &GPGSigner{KeyID: keyID}, nil
+	}
+
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the signing key")
+	}
+	return NewKeySigner(data)
+}
+
+// KeySigner signs the SHA-256 digest of the data with a private key. Its
+// base64-encoded signatures can be verified with the public key using
+// cosign verify-blob or openssl dgst.
+type KeySigner struct {
+	key crypto.Signer
+}
+
+// NewKeySigner returns a signer for a PEM-encoded private key.
+func NewKeySigner(data []byte) (*KeySigner, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("the signing key is not PEM-encoded")
+	}
+	if strings.Contains(block.Type, "ENCRYPTED") || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return nil, errors.New("encrypted signing keys are not supported")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the signing key")
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey, *rsa.PrivateKey:
+		return &KeySigner{key: k.(crypto.Signer)}, nil
+	default:
+		return nil, errors.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// Sign returns the base64-encoded signature of the data.
+func (s *KeySigner) Sign(data []byte) ([]byte, error) {
+	var sig []byte
+	var err error
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		sig, err = s.key.Sign(rand.Reader, data, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(data)
+		sig, err = s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// Extension is the extension of the signature files.
+func (s *KeySigner) Extension() string {
+	return ".sig"
+}
+
+// PublicKey returns the public key of the signer.
+func (s *KeySigner) PublicKey() crypto.PublicKey {
+	return s.key.Public()
+}
+
+// GPGSigner signs the data with a key of the GPG keyring. Its ASCII-armored
+// signatures can be verified with gpg --verify.
+type GPGSigner struct {
+	// KeyID identifies the key of the keyring.
+	KeyID string
+}
+
+// Sign returns the ASCII-armored signature of the data.
+func (s *GPGSigner) Sign(data []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("gpg", "--batch", "--yes", "--armor", "--detach-sign", "--local-user", s.KeyID, "--output", "-")
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to sign with GPG key %s: %s", s.KeyID, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// Extension is the extension of the signature files.
+func (s *GPGSigner) Extension() string {
+	return ".asc"
+}
@@ -0,0 +1,131 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	aznetwork "github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/network/mgmt/network"
+	azcompute "github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/openshift/installer/pkg/quota"
+)
+
+const (
+	// ComputeService is the service of the compute quotas.
+	ComputeService = "compute"
+	// NetworkService is the service of the network quotas.
+	NetworkService = "network"
+)
+
+// record stores the usage and limit of a resource.
+type record struct {
+	Service string
+	Name    string
+	InUse   int64
+	Limit   int64
+}
+
+// Load loads the quota information of the compute and network resources of
+// the subscription in a region. The quotas are named <service>/<resource>,
+// for instance compute/cores or network/PublicIPAddresses.
+func Load(ctx context.Context, ssn *azureconfig.Session, region string) ([]quota.Quota, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	computeRecords, err := loadComputeUsages(ctx, ssn, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load compute usages")
+	}
+	networkRecords, err := loadNetworkUsages(ctx, ssn, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load network usages")
+	}
+	return newQuotas(region, append(computeRecords, networkRecords...)), nil
+}
+
+func loadComputeUsages(ctx context.Context, ssn *azureconfig.Session, region string) ([]record, error) {
+	client := azcompute.NewUsageClientWithBaseURI(ssn.Environment.ResourceManagerEndpoint, ssn.Credentials.SubscriptionID)
+	client.Authorizer = ssn.Authorizer
+
+	var records []record
+	page, err := client.List(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	for ; page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		for _, usage := range page.Values() {
+			if usage.Name == nil || usage.Name.Value == nil || usage.CurrentValue == nil || usage.Limit == nil {
+				continue
+			}
+			records = append(records, record{
+				Service: ComputeService,
+				Name:    *usage.Name.Value,
+				InUse:   int64(*usage.CurrentValue),
+				Limit:   *usage.Limit,
+			})
+		}
+	}
+	return records, nil
+}
+
+func loadNetworkUsages(ctx context.Context, ssn *azureconfig.Session, region string) ([]record, error) {
+	client := aznetwork.NewUsagesClientWithBaseURI(ssn.Environment.ResourceManagerEndpoint, ssn.Credentials.SubscriptionID)
+	client.Authorizer = ssn.Authorizer
+
+	var records []record
+	page, err := client.List(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	for ; page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		for _, usage := range page.Values() {
+			if usage.Name == nil || usage.Name.Value == nil || usage.CurrentValue == nil || usage.Limit == nil {
+				continue
+			}
+			records = append(records, record{
+				Service: NetworkService,
+				Name:    *usage.Name.Value,
+				InUse:   *usage.CurrentValue,
+				Limit:   *usage.Limit,
+			})
+		}
+	}
+	return records, nil
+}
+
+func newQuotas(region string, records []record) []quota.Quota {
+	ret := make([]quota.Quota, 0, len(records))
+	for _, r := range records {
+		ret = append(ret, quota.Quota{
+			Service: r.Service,
+			Name:    fmt.Sprintf("%s/%s", r.Service, r.Name),
+			Region:  region,
+			InUse:   r.InUse,
+			Limit:   r.Limit,
+		})
+	}
+	return ret
+}
+
+// IsUnauthorized checks if the error is un authorized.
+func IsUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dErr autorest.DetailedError
+	if errors.As(err, &dErr) {
+		return dErr.StatusCode == http.StatusForbidden || dErr.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
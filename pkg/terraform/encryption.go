@@ -0,0 +1,173 @@
+package terraform
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"golang.org/x/crypto/scrypt"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+)
+
+const (
+	// StatePassphraseEnvVar is the environment variable holding the
+	// passphrase used to encrypt the Terraform state files.
+	StatePassphraseEnvVar = "OPENSHIFT_INSTALL_TFSTATE_PASSPHRASE"
+	// StateKMSKeyEnvVar is the environment variable holding the ID, ARN or
+	// alias of the AWS KMS key used to encrypt the Terraform state files.
+	StateKMSKeyEnvVar = "OPENSHIFT_INSTALL_TFSTATE_KMS_KEY"
+
+	keySourcePassphrase = "passphrase"
+	keySourceAWSKMS     = "aws-kms"
+
+	encryptedStateVersion = 1
+)
+
+// encryptedState is the format of an encrypted Terraform state file. The
+// state is sealed with AES-256-GCM, with a key derived from a passphrase
+// using scrypt, or a data key generated by AWS KMS.
+type encryptedState struct {
+	Version      int    `json:"openshiftInstallEncryptedState"`
+	KeySource    string `json:"keySource"`
+	Salt         []byte `json:"salt,omitempty"`
+	KMSKey       string `json:"kmsKey,omitempty"`
+	EncryptedKey []byte `json:"encryptedKey,omitempty"`
+	Nonce        []byte `json:"nonce"`
+	Ciphertext   []byte `json:"ciphertext"`
+}
+
+// encryptState encrypts the state with the key configured in the
+// environment. The state is returned unchanged when no key is configured.
+func encryptState(data []byte) ([]byte, error) {
+	passphrase, kmsKey := os.Getenv(StatePassphraseEnvVar), os.Getenv(StateKMSKeyEnvVar)
+
+	state := encryptedState{Version: encryptedStateVersion}
+	var key []byte
+	switch {
+	case kmsKey != "":
+		client, err := kmsClient(kmsKey)
+		if err != nil {
+			return nil, err
+		}
+		out, err := client.GenerateDataKey(&kms.GenerateDataKeyInput{
+			KeyId:   aws.String(kmsKey),
+			KeySpec: aws.String(kms.DataKeySpecAes256),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate a data key with %s: %w", kmsKey, err)
+		}
+		state.KeySource, state.KMSKey, state.EncryptedKey, key = keySourceAWSKMS, aws.StringValue(out.KeyId), out.CiphertextBlob, out.Plaintext
+	case passphrase != "":
+		state.KeySource, state.Salt = keySourcePassphrase, make([]byte, 16)
+		if _, err := io.ReadFull(rand.Reader, state.Salt); err != nil {
+			return nil, err
+		}
+		var err error
+		if key, err = passphraseKey(passphrase, state.Salt); err != nil {
+			return nil, err
+		}
+	default:
+		return data, nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	state.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, state.Nonce); err != nil {
+		return nil, err
+	}
+	state.Ciphertext = gcm.Seal(nil, state.Nonce, data, nil)
+	return json.Marshal(state)
+}
+
+// decryptState decrypts a state encrypted by encryptState. A state that is
+// not encrypted is returned unchanged.
+func decryptState(data []byte) ([]byte, error) {
+	var state encryptedState
+	if err := json.Unmarshal(data, &state); err != nil || state.Version == 0 {
+		return data, nil
+	}
+	if state.Version != encryptedStateVersion {
+		return nil, fmt.Errorf("unsupported encrypted Terraform state version %d", state.Version)
+	}
+
+	var key []byte
+	switch state.KeySource {
+	case keySourceAWSKMS:
+		// The ARN of the key is recorded in the state, so the key does not
+		// need to be configured to decrypt it.
+		kmsKey := state.KMSKey
+		if kmsKey == "" {
+			kmsKey = os.Getenv(StateKMSKeyEnvVar)
+		}
+		client, err := kmsClient(kmsKey)
+		if err != nil {
+			return nil, err
+		}
+		out, err := client.Decrypt(&kms.DecryptInput{
+			CiphertextBlob: state.EncryptedKey,
+			KeyId:          aws.String(kmsKey),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the Terraform state data key: %w", err)
+		}
+		key = out.Plaintext
+	case keySourcePassphrase:
+		passphrase := os.Getenv(StatePassphraseEnvVar)
+		if passphrase == "" {
+			return nil, fmt.Errorf("the Terraform state is encrypted with a passphrase, set %s to decrypt it", StatePassphraseEnvVar)
+		}
+		var err error
+		if key, err = passphraseKey(passphrase, state.Salt); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key source %q for the encrypted Terraform state", state.KeySource)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, state.Nonce, state.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the Terraform state, check the key: %w", err)
+	}
+	return plaintext, nil
+}
+
+func passphraseKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// kmsClient returns a KMS client for the region of the key when the key is
+// given as an ARN, or for the default region otherwise.
+func kmsClient(key string) (*kms.KMS, error) {
+	var opts []awsconfig.SessionOptions
+	if keyARN, err := arn.Parse(key); err == nil {
+		opts = append(opts, awsconfig.WithRegion(keyARN.Region))
+	}
+	ssn, err := awsconfig.GetSessionWithOptions(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an AWS session: %w", err)
+	}
+	return kms.New(ssn), nil
+}
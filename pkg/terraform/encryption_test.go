@@ -0,0 +1,40 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateEncryption(t *testing.T) {
+	state := []byte(`{"version": 4, "resources": []}`)
+
+	t.Run("no key", func(t *testing.T) {
+		encrypted, err := encryptState(state)
+		assert.NoError(t, err)
+		assert.Equal(t, state, encrypted)
+
+		decrypted, err := decryptState(encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, state, decrypted)
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		t.Setenv(StatePassphraseEnvVar, "correct horse battery staple")
+		encrypted, err := encryptState(state)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(encrypted), "resources")
+
+		decrypted, err := decryptState(encrypted)
+		assert.NoError(t, err)
+		assert.Equal(t, state, decrypted)
+
+		t.Setenv(StatePassphraseEnvVar, "wrong passphrase")
+		_, err = decryptState(encrypted)
+		assert.ErrorContains(t, err, "failed to decrypt the Terraform state")
+
+		t.Setenv(StatePassphraseEnvVar, "")
+		_, err = decryptState(encrypted)
+		assert.EqualError(t, err, "the Terraform state is encrypted with a passphrase, set OPENSHIFT_INSTALL_TFSTATE_PASSPHRASE to decrypt it")
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openshift/installer/data"
@@ -47,7 +48,12 @@ func unpack(dir string, platform string, target string) (err error) {
 // unpackAndInit unpacks the platform-specific Terraform modules into
 // the given directory and then runs 'terraform init'.
 func unpackAndInit(dir string, platform string, target string, terraformDir string, providers []prov.Provider) (err error) {
-	err = unpack(dir, platform, target)
+	mirror := os.Getenv(MirrorEnvVar)
+	if mirror != "" {
+		err = unpackFromMirror(dir, mirror, platform, target, terraformDir)
+	} else {
+		err = unpack(dir, platform, target)
+	}
 	if err != nil {
 		return errors.Wrap(err, "failed to unpack Terraform modules")
 	}
@@ -64,8 +70,13 @@ func unpackAndInit(dir string, platform string, target string, terraformDir stri
 	// Explicitly specify the CLI config file to use so that we control the providers that are used.
 	os.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(dir, "terraform.rc"))
 
+	opts := []tfexec.InitOption{tfexec.PluginDir(filepath.Join(terraformDir, "plugins"))}
+	if mirror != "" {
+		// The modules were downloaded when the mirror was exported.
+		opts = append(opts, tfexec.Get(false))
+	}
 	return errors.Wrap(
-		tf.Init(context.Background(), tfexec.PluginDir(filepath.Join(terraformDir, "plugins"))),
+		tf.Init(context.Background(), opts...),
 		"failed doing terraform init",
 	)
 }
@@ -110,7 +121,17 @@ func addFileToAllDirectories(name string, data []byte, dir string) error {
 }
 
 // UnpackTerraform unpacks the terraform binary and the specified provider binaries into the specified directory.
+// They are taken from the terraform mirror when one is set with OPENSHIFT_INSTALL_TERRAFORM_MIRROR.
 func UnpackTerraform(dir string, stages []Stage) error {
+	if mirror := os.Getenv(MirrorEnvVar); mirror != "" {
+		logrus.Infof("Using the terraform mirror %s", mirror)
+		return unpackTerraformFromMirror(dir, mirror, stages)
+	}
+	return unpackEmbedded(dir, stages)
+}
+
+// unpackEmbedded unpacks the terraform binary and the provider binaries embedded in the installer.
+func unpackEmbedded(dir string, stages []Stage) error {
 	// Unpack the terraform binary.
 	if err := prov.UnpackTerraformBinary(filepath.Join(dir, "bin")); err != nil {
 		return err
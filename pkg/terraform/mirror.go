@@ -0,0 +1,161 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// MirrorEnvVar is the environment variable pointing to a terraform mirror
+// exported by ExportMirror. When it is set, the terraform binary, the
+// providers and the modules are taken from the mirror, and terraform init
+// does not reach the terraform registry.
+const MirrorEnvVar = "OPENSHIFT_INSTALL_TERRAFORM_MIRROR"
+
+const mirrorConfigTemplate = `provider_installation {
+  filesystem_mirror {
+    path = %q
+  }
+}
+`
+
+// mirrorModulesDir returns the directory of the modules of a stage in the
+// mirror.
+func mirrorModulesDir(mirror, platform, stage string) string {
+	return filepath.Join(mirror, "modules", platform, stage)
+}
+
+// ExportMirror exports the terraform binary, the providers and the modules of
+// the stages into the filesystem mirror dir, downloading the modules the
+// stages get from the terraform registry, so that the stages can be applied
+// with the mirror on hosts without access to the registry.
+func ExportMirror(ctx context.Context, dir string, stages []Stage) error {
+	mirror, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := unpackEmbedded(mirror, stages); err != nil {
+		return errors.Wrap(err, "failed to export terraform and its providers")
+	}
+
+	for _, stage := range stages {
+		modulesDir := mirrorModulesDir(mirror, stage.Platform(), stage.Name())
+		logrus.Infof("Exporting the terraform modules of the %s %q stage", stage.Platform(), stage.Name())
+		if err := os.MkdirAll(filepath.Dir(modulesDir), 0777); err != nil {
+			return err
+		}
+		if err := unpack(modulesDir, stage.Platform(), stage.Name()); err != nil {
+			return errors.Wrapf(err, "failed to unpack the modules of the %q stage", stage.Name())
+		}
+		if err := addVersionsFiles(modulesDir, stage.Providers()); err != nil {
+			return errors.Wrap(err, "failed to write versions.tf files")
+		}
+		config := fmt.Sprintf(mirrorConfigTemplate, filepath.Join(mirror, "plugins"))
+		if err := os.WriteFile(filepath.Join(modulesDir, "terraform.rc"), []byte(config), 0666); err != nil {
+			return err
+		}
+
+		tf, err := newTFExec(modulesDir, mirror)
+		if err != nil {
+			return errors.Wrap(err, "failed to create a new tfexec")
+		}
+		// Keep the downloaded modules with the stage, relative to it, so
+		// that they can be copied with it.
+		os.Setenv("TF_DATA_DIR", ".terraform")
+		os.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(modulesDir, "terraform.rc"))
+		if err := tf.Get(ctx); err != nil {
+			return errors.Wrapf(err, "failed to download the modules of the %q stage", stage.Name())
+		}
+	}
+	return nil
+}
+
+// unpackFromMirror copies the modules of the stage from the mirror into the
+// given directory, and the modules it downloaded into the terraform data dir.
+func unpackFromMirror(dir string, mirror string, platform string, target string, terraformDir string) error {
+	modulesDir := mirrorModulesDir(mirror, platform, target)
+	if _, err := os.Stat(modulesDir); err != nil {
+		return errors.Wrapf(err, "the terraform mirror %s has no modules for the %s %q stage", mirror, platform, target)
+	}
+	if err := copyDir(modulesDir, dir); err != nil {
+		return err
+	}
+
+	manifest := filepath.Join(modulesDir, ".terraform", "modules", "modules.json")
+	if _, err := os.Stat(manifest); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	dataModulesDir := filepath.Join(terraformDir, ".terraform", "modules")
+	if err := os.MkdirAll(dataModulesDir, 0777); err != nil {
+		return err
+	}
+	return copyFile(manifest, filepath.Join(dataModulesDir, "modules.json"))
+}
+
+// unpackTerraformFromMirror copies the terraform binary and the providers of
+// the stages from the mirror into the given directory.
+func unpackTerraformFromMirror(dir string, mirror string, stages []Stage) error {
+	if err := copyDir(filepath.Join(mirror, "bin"), filepath.Join(dir, "bin")); err != nil {
+		return errors.Wrapf(err, "failed to copy terraform from the mirror %s", mirror)
+	}
+	providers := sets.New[string]()
+	for _, stage := range stages {
+		for _, provider := range stage.Providers() {
+			if providers.Has(provider.Name) {
+				continue
+			}
+			providerDir := filepath.Join(strings.Split(provider.Source, "/")...)
+			if err := copyDir(filepath.Join(mirror, "plugins", providerDir), filepath.Join(dir, "plugins", providerDir)); err != nil {
+				return errors.Wrapf(err, "failed to copy the %s provider from the mirror %s", provider.Name, mirror)
+			}
+			providers.Insert(provider.Name)
+		}
+	}
+	return nil
+}
+
+// copyDir copies the tree of the src directory into the dest directory,
+// keeping the file modes.
+func copyDir(src, dest string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0777)
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dest string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	return err
+}
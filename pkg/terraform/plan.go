@@ -0,0 +1,163 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster/tfvars"
+	"github.com/openshift/installer/pkg/infrastructure"
+)
+
+// PlanFilename is the name of the binary plan file written by 'terraform plan'.
+const PlanFilename = "terraform.tfplan"
+
+var _ infrastructure.Planner = (*Provider)(nil)
+
+// PlanFilenames returns the names of the human-readable and JSON plan files
+// of the stage.
+func PlanFilenames(stage Stage) (text string, json string) {
+	base := fmt.Sprintf("terraform.%s.plan", stage.Name())
+	return base + ".txt", base + ".json"
+}
+
+// Plan implements pkg/infrastructure/provider.Planner. Plan iterates through
+// each of the stages and runs 'terraform plan' for the stage, without applying
+// it, returning the plans in human-readable and JSON forms. The stages
+// completed by a previous run are skipped. A stage that takes the outputs of
+// an earlier stage as input cannot be planned until that stage is applied, so
+// the failures of the stages following the first planned one are only
+// reported.
+func (p *Provider) Plan(ctx context.Context, dir string, parents asset.Parents) ([]*asset.File, error) {
+	tfVars := &tfvars.TerraformVariables{}
+	parents.Get(tfVars)
+	vars := tfVars.Files()
+
+	checkpoints, err := asset.LoadCheckpoints(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoints: %w", err)
+	}
+
+	fileList := []*asset.File{}
+	terraformDir := filepath.Join(dir, "terraform")
+	if err := os.Mkdir(terraformDir, 0777); err != nil {
+		return nil, fmt.Errorf("could not create the terraform directory: %w", err)
+	}
+
+	terraformDirPath, err := filepath.Abs(terraformDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get absolute path of terraform directory: %w", err)
+	}
+
+	defer os.RemoveAll(terraformDir)
+	if err = UnpackTerraform(terraformDirPath, p.stages); err != nil {
+		return nil, fmt.Errorf("error unpacking terraform: %w", err)
+	}
+
+	planned := ""
+	for _, stage := range p.stages {
+		if checkpoints.Completed(stage.Name()) {
+			outputs, _, err := loadCompletedStage(dir, stage)
+			if err != nil {
+				return fileList, fmt.Errorf("failed to resume from the %q stage: %w", stage.Name(), err)
+			}
+			logrus.Infof("Skipping the %q stage, which was completed by a previous run", stage.Name())
+			vars = append(vars, outputs)
+			continue
+		}
+
+		priorState, err := loadPriorState(dir, stage)
+		if err != nil {
+			return fileList, err
+		}
+
+		files, err := planStage(ctx, stage.Platform(), stage, terraformDirPath, vars, priorState)
+		if err != nil {
+			if planned == "" {
+				return fileList, fmt.Errorf("failure planning terraform for %q stage: %w", stage.Name(), err)
+			}
+			logrus.Warnf("Could not plan the %q stage, which may need the outputs of the %q stage to be applied first: %v", stage.Name(), planned, err)
+			continue
+		}
+		fileList = append(fileList, files...)
+		if planned == "" {
+			planned = stage.Name()
+		}
+	}
+	return fileList, nil
+}
+
+func planStage(ctx context.Context, platform string, stage Stage, terraformDir string, tfvarsFiles []*asset.File, priorState *asset.File) ([]*asset.File, error) {
+	// Copy the terraform.tfvars to a temp directory which will contain the terraform plan.
+	tmpDir, err := os.MkdirTemp("", fmt.Sprintf("openshift-install-%s-", stage.Name()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temp dir for terraform execution")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	planPath := filepath.Join(tmpDir, PlanFilename)
+	opts := []tfexec.PlanOption{tfexec.Out(planPath)}
+	for _, file := range tfvarsFiles {
+		if err := os.WriteFile(filepath.Join(tmpDir, file.Filename), file.Data, 0o600); err != nil {
+			return nil, err
+		}
+		opts = append(opts, tfexec.VarFile(filepath.Join(tmpDir, file.Filename)))
+	}
+
+	// Plan against the state of an interrupted run, so that only the
+	// resources it did not create are planned.
+	if priorState != nil {
+		data, err := decryptState(priorState.Data)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, StateFilename), data, 0o600); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := unpackAndInit(tmpDir, platform, stage.Name(), terraformDir, stage.Providers()); err != nil {
+		return nil, err
+	}
+
+	tf, err := newTFExec(tmpDir, terraformDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a new tfexec")
+	}
+	changes, err := tf.Plan(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(diagnoseApplyError(err), "failed to plan Terraform")
+	}
+	if !changes {
+		logrus.Infof("The %q stage has no changes to apply", stage.Name())
+	}
+
+	text, err := tf.ShowPlanFileRaw(ctx, planPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to show the Terraform plan")
+	}
+	plan, err := tf.ShowPlanFile(ctx, planPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to show the Terraform plan as JSON")
+	}
+	// The variables hold the credentials and the bootstrap ignition, which
+	// are otherwise only written to disk in the encrypted state.
+	plan.Variables = nil
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the Terraform plan")
+	}
+
+	textFilename, jsonFilename := PlanFilenames(stage)
+	return []*asset.File{
+		{Filename: textFilename, Data: []byte(text)},
+		{Filename: jsonFilename, Data: data},
+	}, nil
+}
@@ -36,11 +36,19 @@ func InitializeProvider(stages []Stage) infrastructure.Provider {
 
 // Provision implements pkg/infrastructure/provider.Provision. Provision iterates
 // through each of the stages and applies the Terraform config for the stage.
+// Each completed stage is checkpointed in the state file, so that when a
+// previous run was interrupted the completed stages are skipped and the
+// interrupted stage is applied again on top of its partial state.
 func (p *Provider) Provision(_ context.Context, dir string, parents asset.Parents) ([]*asset.File, error) {
 	tfVars := &tfvars.TerraformVariables{}
 	parents.Get(tfVars)
 	vars := tfVars.Files()
 
+	checkpoints, err := asset.LoadCheckpoints(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoints: %w", err)
+	}
+
 	fileList := []*asset.File{}
 	terraformDir := filepath.Join(dir, "terraform")
 	if err := os.Mkdir(terraformDir, 0777); err != nil {
@@ -58,7 +66,26 @@ func (p *Provider) Provision(_ context.Context, dir string, parents asset.Parent
 	}
 
 	for _, stage := range p.stages {
-		outputs, stateFile, err := applyStage(stage.Platform(), stage, terraformDirPath, vars)
+		if checkpoints.Completed(stage.Name()) {
+			outputs, stateFile, err := loadCompletedStage(dir, stage)
+			if err != nil {
+				return fileList, fmt.Errorf("failed to resume from the %q stage: %w", stage.Name(), err)
+			}
+			logrus.Infof("Skipping the %q stage, which was completed by a previous run", stage.Name())
+			vars = append(vars, outputs)
+			fileList = append(fileList, outputs, stateFile)
+			continue
+		}
+
+		priorState, err := loadPriorState(dir, stage)
+		if err != nil {
+			return fileList, err
+		}
+		if priorState != nil {
+			logrus.Infof("Resuming the %q stage from the state of a previous run", stage.Name())
+		}
+
+		outputs, stateFile, err := applyStage(stage.Platform(), stage, terraformDirPath, vars, priorState)
 		if err != nil {
 			// Write the state file to the install directory even if the apply failed.
 			if stateFile != nil {
@@ -74,10 +101,54 @@ func (p *Provider) Provision(_ context.Context, dir string, parents asset.Parent
 		if extErr != nil {
 			return fileList, fmt.Errorf("failed to extract load balancer information: %w", extErr)
 		}
+
+		// The outputs and state are only in memory until the cluster asset is
+		// persisted, so write them before recording the checkpoint.
+		for _, f := range []*asset.File{outputs, stateFile} {
+			if err := os.WriteFile(filepath.Join(dir, f.Filename), f.Data, 0o640); err != nil { //nolint:gosec // state file doesn't need to be 0600
+				return fileList, fmt.Errorf("failed to write %s: %w", f.Filename, err)
+			}
+		}
+		if err := checkpoints.Complete(stage.Name()); err != nil {
+			return fileList, fmt.Errorf("failed to checkpoint the %q stage: %w", stage.Name(), err)
+		}
+	}
+
+	if err := checkpoints.Clear(); err != nil {
+		return fileList, fmt.Errorf("failed to clear checkpoints: %w", err)
 	}
 	return fileList, nil
 }
 
+// loadCompletedStage reads the outputs and state files written to the install
+// dir by a previous run that completed the stage.
+func loadCompletedStage(dir string, stage Stage) (outputs, state *asset.File, err error) {
+	outputsData, err := os.ReadFile(filepath.Join(dir, stage.OutputsFilename()))
+	if err != nil {
+		return nil, nil, err
+	}
+	stateData, err := os.ReadFile(filepath.Join(dir, stage.StateFilename()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &asset.File{Filename: stage.OutputsFilename(), Data: outputsData},
+		&asset.File{Filename: stage.StateFilename(), Data: stateData}, nil
+}
+
+// loadPriorState reads the state file left in the install dir by a previous
+// run that was interrupted while applying the stage. Returns nil if there is
+// no such state file.
+func loadPriorState(dir string, stage Stage) (*asset.File, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stage.StateFilename()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the state of the %q stage: %w", stage.Name(), err)
+	}
+	return &asset.File{Filename: stage.StateFilename(), Data: data}, nil
+}
+
 // DestroyBootstrap implements pkg/infrastructure/provider.DestroyBootstrap.
 // DestroyBootstrap iterates through each stage, and will run the destroy
 // command when defined on a stage.
@@ -117,7 +188,7 @@ func (p *Provider) DestroyBootstrap(dir string) error {
 
 		stateFilePathInInstallDir := filepath.Join(dir, stage.StateFilename())
 		stateFilePathInTempDir := filepath.Join(tempDir, StateFilename)
-		if err := copyFile(stateFilePathInInstallDir, stateFilePathInTempDir); err != nil {
+		if err := copyStateFile(stateFilePathInInstallDir, stateFilePathInTempDir, decryptState); err != nil {
 			return fmt.Errorf("failed to copy state file to the temporary directory: %w", err)
 		}
 
@@ -142,13 +213,32 @@ func (p *Provider) DestroyBootstrap(dir string) error {
 			return err
 		}
 
-		if err := copyFile(stateFilePathInTempDir, stateFilePathInInstallDir); err != nil {
+		if err := copyStateFile(stateFilePathInTempDir, stateFilePathInInstallDir, encryptState); err != nil {
 			return fmt.Errorf("failed to copy state file from the temporary directory: %w", err)
 		}
 	}
 	return nil
 }
 
+// Destroy implements pkg/infrastructure/provider.Destroy. Destroy removes the
+// Terraform state and variables files from the install dir.
+func (p *Provider) Destroy(dir string) error {
+	var files []string
+	for _, pattern := range []string{"*.tfstate", "*.tfvars.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return fmt.Errorf("failed to glob for terraform files: %w", err)
+		}
+		files = append(files, matches...)
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil {
+			return fmt.Errorf("failed to remove terraform file %q: %w", f, err)
+		}
+	}
+	return nil
+}
+
 // ExtractHostAddresses implements pkg/infrastructure/provider.ExtractHostAddresses. Extracts the addresses to be used
 // for gathering debug logs by inspecting the Terraform output files.
 func (p *Provider) ExtractHostAddresses(dir string, config *types.InstallConfig, ha *infrastructure.HostAddresses) error {
@@ -256,7 +346,7 @@ func Destroy(dir string, platform string, stage Stage, terraformDir string, extr
 	)
 }
 
-func applyStage(platform string, stage Stage, terraformDir string, tfvarsFiles []*asset.File) (*asset.File, *asset.File, error) {
+func applyStage(platform string, stage Stage, terraformDir string, tfvarsFiles []*asset.File, priorState *asset.File) (*asset.File, *asset.File, error) {
 	// Copy the terraform.tfvars to a temp directory which will contain the terraform plan.
 	tmpDir, err := os.MkdirTemp("", fmt.Sprintf("openshift-install-%s-", stage.Name()))
 	if err != nil {
@@ -272,6 +362,18 @@ func applyStage(platform string, stage Stage, terraformDir string, tfvarsFiles [
 		extraOpts = append(extraOpts, tfexec.VarFile(filepath.Join(tmpDir, file.Filename)))
 	}
 
+	// Start from the state of an interrupted run so that the resources it
+	// created are adopted rather than created again.
+	if priorState != nil {
+		data, err := decryptState(priorState.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, StateFilename), data, 0o600); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return applyTerraform(tmpDir, platform, stage, terraformDir, extraOpts...)
 }
 
@@ -282,13 +384,22 @@ func applyTerraform(tmpDir string, platform string, stage Stage, terraformDir st
 	applyErr := Apply(tmpDir, platform, stage, terraformDir, opts...)
 
 	if data, err := os.ReadFile(filepath.Join(tmpDir, StateFilename)); err == nil {
+		// The state is kept even when it cannot be encrypted, so that the
+		// resources it records can still be destroyed.
+		if encrypted, err := encryptState(data); err != nil {
+			logrus.Warnf("Failed to encrypt tfstate, writing it unencrypted: %v", err)
+		} else {
+			data = encrypted
+		}
 		stateFile = &asset.File{
 			Filename: stage.StateFilename(),
 			Data:     data,
 		}
 	} else if !os.IsNotExist(err) {
 		logrus.Errorf("Failed to read tfstate: %v", err)
-		return nil, nil, errors.Wrap(err, "failed to read tfstate")
+		if applyErr == nil {
+			return nil, nil, errors.Wrap(err, "failed to read tfstate")
+		}
 	}
 
 	if applyErr != nil {
@@ -315,3 +426,15 @@ func copyFile(from string, to string) error {
 
 	return os.WriteFile(to, data, 0o666) //nolint:gosec // state file doesn't need to be 0600
 }
+
+// copyStateFile copies a state file, encrypting or decrypting it on the way.
+func copyStateFile(from string, to string, transform func([]byte) ([]byte, error)) error {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if data, err = transform(data); err != nil {
+		return err
+	}
+	return os.WriteFile(to, data, 0o600)
+}
@@ -50,6 +50,16 @@ type Config struct {
 	PreserveBootstrapIgnition       bool              `json:"aws_preserve_bootstrap_ignition"`
 	MasterSecurityGroups            []string          `json:"aws_master_security_groups,omitempty"`
 	PublicIpv4Pool                  string            `json:"aws_public_ipv4_pool"`
+	IPFamily                        string            `json:"aws_ip_family,omitempty"`
+	IPv6Egress                      string            `json:"aws_ipv6_egress,omitempty"`
+	MasterTenancy                   string            `json:"aws_master_tenancy,omitempty"`
+	MasterHostIDs                   []string          `json:"aws_master_host_ids,omitempty"`
+	MasterHostResourceGroupARN      string            `json:"aws_master_host_resource_group_arn,omitempty"`
+	MasterCapacityReservationID     string            `json:"aws_master_capacity_reservation_id,omitempty"`
+	APILBType                       string            `json:"aws_api_lb_type,omitempty"`
+	APILBSubnets                    []string          `json:"aws_api_lb_subnets,omitempty"`
+	APILBIdleTimeout                int64             `json:"aws_api_lb_idle_timeout,omitempty"`
+	APILBExtraTags                  map[string]string `json:"aws_api_lb_extra_tags,omitempty"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -83,6 +93,15 @@ type TFVarsSources struct {
 	MasterSecurityGroups []string
 
 	PublicIpv4Pool string
+
+	IPFamily   typesaws.IPFamily
+	IPv6Egress typesaws.IPv6EgressType
+
+	MasterHostPlacement *typesaws.HostPlacement
+
+	MasterReservationID string
+
+	APILoadBalancer *typesaws.APILoadBalancer
 }
 
 // TFVars generates AWS-specific Terraform variables launching the cluster.
@@ -211,6 +230,21 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		PreserveBootstrapIgnition: sources.PreserveBootstrapIgnition,
 		MasterSecurityGroups:      sources.MasterSecurityGroups,
 		PublicIpv4Pool:            sources.PublicIpv4Pool,
+		IPFamily:                  string(sources.IPFamily),
+		IPv6Egress:                string(sources.IPv6Egress),
+		MasterTenancy:             string(masterConfig.Placement.Tenancy),
+	}
+
+	cfg.MasterCapacityReservationID = sources.MasterReservationID
+	if lb := sources.APILoadBalancer; lb != nil {
+		cfg.APILBType = string(lb.Type)
+		cfg.APILBSubnets = lb.Subnets
+		cfg.APILBIdleTimeout = lb.IdleTimeoutSeconds
+		cfg.APILBExtraTags = lb.AdditionalTags
+	}
+	if hp := sources.MasterHostPlacement; hp != nil {
+		cfg.MasterHostIDs = hp.HostIDs
+		cfg.MasterHostResourceGroupARN = hp.HostResourceGroupARN
 	}
 
 	stubIgn, err := bootstrap.GenerateIgnitionShimWithCertBundleAndProxy(sources.IgnitionPresignedURL, sources.AdditionalTrustBundle, sources.Proxy)
@@ -36,6 +36,7 @@ type config struct {
 	Auth                                    `json:",inline"`
 	Environment                             string            `json:"azure_environment"`
 	ARMEndpoint                             string            `json:"azure_arm_endpoint"`
+	CustomEndpoints                         map[string]string `json:"azure_custom_endpoints,omitempty"`
 	ExtraTags                               map[string]string `json:"azure_extra_tags,omitempty"`
 	MasterInstanceType                      string            `json:"azure_master_vm_type,omitempty"`
 	MasterAvailabilityZones                 []string          `json:"azure_master_availability_zones"`
@@ -81,6 +82,7 @@ type TFVarsSources struct {
 	Auth                            Auth
 	CloudName                       azure.CloudEnvironment
 	ARMEndpoint                     string
+	ServiceEndpoints                []azure.ServiceEndpoint
 	ResourceGroupName               string
 	BaseDomainResourceGroupName     string
 	MasterConfigs                   []*machineapi.AzureMachineProviderSpec
@@ -112,6 +114,11 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		masterAvailabilityZones[i] = c.Zone
 	}
 
+	endpoints := make(map[string]string)
+	for _, service := range sources.ServiceEndpoints {
+		endpoints[string(service.Name)] = service.URL
+	}
+
 	environment, err := environment(sources.CloudName)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not determine Azure environment to use for Terraform")
@@ -172,6 +179,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		Auth:                                    sources.Auth,
 		Environment:                             environment,
 		ARMEndpoint:                             sources.ARMEndpoint,
+		CustomEndpoints:                         endpoints,
 		Region:                                  region,
 		MasterInstanceType:                      masterConfig.VMSize,
 		MasterAvailabilityZones:                 masterAvailabilityZones,
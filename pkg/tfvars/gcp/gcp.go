@@ -7,6 +7,7 @@ import (
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	gcpconsts "github.com/openshift/installer/pkg/constants/gcp"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/gcp"
 )
 
 const (
@@ -29,6 +30,7 @@ type config struct {
 	MasterAvailabilityZones   []string          `json:"gcp_master_availability_zones"`
 	Image                     string            `json:"gcp_image,omitempty"`
 	InstanceServiceAccount    string            `json:"gcp_instance_service_account,omitempty"`
+	WorkerServiceAccount      string            `json:"gcp_worker_service_account,omitempty"`
 	VolumeType                string            `json:"gcp_master_root_volume_type"`
 	VolumeSize                int64             `json:"gcp_master_root_volume_size"`
 	VolumeKMSKeyLink          string            `json:"gcp_root_volume_kms_key_link"`
@@ -43,11 +45,16 @@ type config struct {
 	SecureBoot                string            `json:"gcp_master_secure_boot,omitempty"`
 	OnHostMaintenance         string            `json:"gcp_master_on_host_maintenance,omitempty"`
 	EnableConfidentialCompute string            `json:"gcp_master_confidential_compute,omitempty"`
+	VTPM                      string            `json:"gcp_master_vtpm,omitempty"`
+	IntegrityMonitoring       string            `json:"gcp_master_integrity_monitoring,omitempty"`
 	ExtraLabels               map[string]string `json:"gcp_extra_labels,omitempty"`
 	UserProvisionedDNS        bool              `json:"gcp_user_provisioned_dns,omitempty"`
 	ExtraTags                 map[string]string `json:"gcp_extra_tags,omitempty"`
 	IgnitionShim              string            `json:"gcp_ignition_shim,omitempty"`
 	PresignedURL              string            `json:"gcp_signed_url"`
+	CustomEndpoints           map[string]string `json:"gcp_custom_endpoints,omitempty"`
+	GoogleAPIAccessType       string            `json:"gcp_google_api_access_type,omitempty"`
+	GoogleAPIAccessAddresses  []string          `json:"gcp_google_api_access_addresses,omitempty"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -65,6 +72,11 @@ type TFVarsSources struct {
 	UserTags            map[string]string
 	IgnitionShim        string
 	PresignedURL        string
+	ServiceEndpoints    []gcp.ServiceEndpoint
+	GoogleAPIAccess     *gcp.GoogleAPIAccess
+	// WorkerServiceAccount is the pre-created service account of compute
+	// nodes. The installer creates one when it is empty.
+	WorkerServiceAccount string
 }
 
 // TFVars generates gcp-specific Terraform variables launching the cluster.
@@ -83,6 +95,11 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		labels[k] = v
 	}
 
+	endpoints := make(map[string]string)
+	for _, service := range sources.ServiceEndpoints {
+		endpoints[string(service.Name)] = service.URL
+	}
+
 	cfg := &config{
 		Auth:                      sources.Auth,
 		Region:                    masterConfig.Region,
@@ -104,11 +121,20 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		SecureBoot:                string(masterConfig.ShieldedInstanceConfig.SecureBoot),
 		EnableConfidentialCompute: string(masterConfig.ConfidentialCompute),
 		OnHostMaintenance:         string(masterConfig.OnHostMaintenance),
+		VTPM:                      string(masterConfig.ShieldedInstanceConfig.VirtualizedTrustedPlatformModule),
+		IntegrityMonitoring:       string(masterConfig.ShieldedInstanceConfig.IntegrityMonitoring),
 		ExtraLabels:               labels,
 		UserProvisionedDNS:        sources.UserProvisionedDNS,
 		ExtraTags:                 sources.UserTags,
 		IgnitionShim:              sources.IgnitionShim,
 		PresignedURL:              sources.PresignedURL,
+		WorkerServiceAccount:      sources.WorkerServiceAccount,
+		CustomEndpoints:           endpoints,
+	}
+
+	if access := sources.GoogleAPIAccess; access != nil {
+		cfg.GoogleAPIAccessType = string(access.Type)
+		cfg.GoogleAPIAccessAddresses = access.Addresses()
 	}
 
 	if masterConfig.Disks[0].EncryptionKey != nil {
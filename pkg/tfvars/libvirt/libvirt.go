@@ -29,6 +29,9 @@ type config struct {
 	BootstrapMemory int                 `json:"libvirt_bootstrap_memory,omitempty"`
 	MasterDiskSize  string              `json:"libvirt_master_size,omitempty"`
 	DnsmasqOptions  []map[string]string `json:"libvirt_dnsmasq_options,omitempty"`
+
+	MasterAdditionalNetworks []string   `json:"libvirt_master_additional_networks,omitempty"`
+	MasterHostDevices        [][]string `json:"libvirt_master_host_devices,omitempty"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -40,6 +43,11 @@ type TFVarsSources struct {
 	MasterCount    int
 	Architecture   types.Architecture
 	DnsmasqOptions []map[string]string
+	// AdditionalNetworks are the libvirt networks attached to every master.
+	AdditionalNetworks []string
+	// HostDevices are the PCI addresses of the host devices distributed
+	// evenly across the masters.
+	HostDevices []string
 }
 
 // TFVars generates libvirt-specific Terraform variables.
@@ -81,6 +89,18 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		MasterMemory:   strconv.Itoa(sources.MasterConfig.DomainMemory),
 		MasterVcpu:     strconv.Itoa(sources.MasterConfig.DomainVcpu),
 		DnsmasqOptions: sources.DnsmasqOptions,
+
+		MasterAdditionalNetworks: sources.AdditionalNetworks,
+	}
+
+	if len(sources.HostDevices) > 0 {
+		if sources.MasterCount == 0 || len(sources.HostDevices)%sources.MasterCount != 0 {
+			return nil, errors.Errorf("cannot distribute %d host devices across %d masters", len(sources.HostDevices), sources.MasterCount)
+		}
+		perMaster := len(sources.HostDevices) / sources.MasterCount
+		for i := 0; i < sources.MasterCount; i++ {
+			cfg.MasterHostDevices = append(cfg.MasterHostDevices, sources.HostDevices[i*perMaster:(i+1)*perMaster])
+		}
 	}
 
 	if sources.MasterConfig.Volume.VolumeSize != nil {
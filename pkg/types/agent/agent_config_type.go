@@ -1,6 +1,10 @@
 package agent
 
 import (
+	"fmt"
+	"strings"
+	"text/template"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	aiv1beta1 "github.com/openshift/assisted-service/api/v1beta1"
@@ -27,15 +31,396 @@ type Config struct {
 	RendezvousIP         string `json:"rendezvousIP,omitempty"`
 	BootArtifactsBaseURL string `json:"bootArtifactsBaseURL,omitempty"`
 	Hosts                []Host `json:"hosts,omitempty"`
+
+	// RendezvousHostname is the hostname of node0. When set, the installer
+	// locates node0 by resolving <rendezvousHostname>.local over mDNS, so that
+	// it can still be reached if the address leased by DHCP changed.
+	// +optional
+	RendezvousHostname string `json:"rendezvousHostname,omitempty"`
+
+	// DHCP declares that the hosts get their network configuration from DHCP.
+	// No host networkConfig nor NMStateConfig is then needed, and the
+	// rendezvousIP must be set explicitly, typically to the address reserved
+	// for node0 on the DHCP server.
+	// +optional
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// CatalogSources lists additional operator catalogs made available to
+	// the cluster from its first boot.
+	// +optional
+	CatalogSources []CatalogSource `json:"catalogSources,omitempty"`
+
+	// Operators lists the operators installed with the cluster. A namespace,
+	// an operator group and a subscription are generated for each operator.
+	// +optional
+	Operators []Operator `json:"operators,omitempty"`
+
+	// ExtraManifests lists additional manifests, such as the configuration
+	// of an installed operator, that are applied when the cluster is
+	// installed.
+	// +optional
+	ExtraManifests []ExtraManifest `json:"extraManifests,omitempty"`
+
+	// HostPolicies assign the role and hostname of the discovered hosts
+	// which are not listed in hosts. The first policy matching a host
+	// applies. They are applied through the agent REST API while
+	// `agent wait-for bootstrap-complete` runs, before the installation
+	// starts.
+	// +optional
+	HostPolicies []HostPolicy `json:"hostPolicies,omitempty"`
+
+	// MinimalHardwareRequirements overrides the minimal hardware the hosts
+	// must have for their role, e.g. to install a lab on smaller machines
+	// than the supported defaults. The overrides are embedded in the
+	// InfraEnv.
+	// +optional
+	MinimalHardwareRequirements *MinimalHardwareRequirements `json:"minimalHardwareRequirements,omitempty"`
+
+	// ImageCustomization adds site-specific files, systemd units and kernel
+	// arguments to the agent image, e.g. for monitoring or VPN agents.
+	// +optional
+	ImageCustomization *ImageCustomization `json:"imageCustomization,omitempty"`
+
+	// Appliance embeds the release payload, or a subset of it, into the
+	// agent ISO or into an accompanying data ISO, so that the cluster can
+	// be installed at sites without any registry.
+	// +optional
+	Appliance *Appliance `json:"appliance,omitempty"`
+}
+
+// DefaultApplianceRegistryImage is the image providing the registry binary
+// which serves the embedded release payload on the hosts.
+const DefaultApplianceRegistryImage = "docker.io/library/registry:2"
+
+// Appliance defines the release payload embedded into the agent image. On
+// boot, the hosts serve the embedded payload from a read-only local
+// registry, and pull the release images from it. The media holding the
+// payload must stay attached to the hosts, since the installed nodes keep
+// serving the payload from it.
+type Appliance struct {
+	// Images lists the component images of the release payload embedded,
+	// by their name in the payload, e.g. machine-config-operator. The
+	// release image itself is always embedded. The default is the whole
+	// payload.
+	// +optional
+	Images []string `json:"images,omitempty"`
+
+	// RegistryImage is the image providing the /bin/registry binary of the
+	// distribution registry. The default is DefaultApplianceRegistryImage.
+	// +optional
+	RegistryImage string `json:"registryImage,omitempty"`
+
+	// DataISO writes the payload into agent.<arch>.data.iso rather than
+	// into the agent ISO, so that the agent ISO keeps its usual size. The
+	// data ISO must be attached to the hosts along with the agent ISO.
+	// +optional
+	DataISO bool `json:"dataISO,omitempty"`
+}
+
+// ImageCustomization defines the files, systemd units and kernel arguments
+// added to the ignition of the agent ISO and PXE files.
+type ImageCustomization struct {
+	// Files lists the files written to the live system.
+	// +optional
+	Files []ImageFile `json:"files,omitempty"`
+
+	// SystemdUnits lists the systemd units added to the live system.
+	// +optional
+	SystemdUnits []SystemdUnit `json:"systemdUnits,omitempty"`
+
+	// KernelArguments lists the kernel arguments appended to the kernel
+	// command line, e.g. console=ttyS1,115200n8.
+	// +optional
+	KernelArguments []string `json:"kernelArguments,omitempty"`
+}
+
+// ImageFile defines a file written to the live system.
+type ImageFile struct {
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+
+	// Mode is the permission mode of the file, as a decimal number, e.g.
+	// 420 for 0644. The default is 420.
+	// +optional
+	Mode *int `json:"mode,omitempty"`
+
+	// Contents is the content of the file.
+	Contents string `json:"contents"`
+}
+
+// SystemdUnit defines a systemd unit added to the live system.
+type SystemdUnit struct {
+	// Name is the name of the unit, e.g. node-exporter.service.
+	Name string `json:"name"`
+
+	// Contents is the content of the unit file.
+	Contents string `json:"contents"`
+
+	// Enabled is whether the unit is enabled. The default is true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// MinimalHardwareRequirements defines the minimal hardware of the hosts per
+// role. A requirement which is not set keeps its default.
+type MinimalHardwareRequirements struct {
+	// Master is the minimal hardware of the control plane hosts of a
+	// cluster with several control plane hosts.
+	// +optional
+	Master *Hardware `json:"master,omitempty"`
+
+	// Worker is the minimal hardware of the compute hosts.
+	// +optional
+	Worker *Hardware `json:"worker,omitempty"`
+
+	// SNO is the minimal hardware of the host of a single node cluster.
+	// +optional
+	SNO *Hardware `json:"sno,omitempty"`
+}
+
+// Hardware defines the hardware of a host.
+type Hardware struct {
+	// CPUCores is the number of CPU cores.
+	// +optional
+	CPUCores int64 `json:"cpuCores,omitempty"`
+
+	// MemoryMiB is the size of the memory in MiB.
+	// +optional
+	MemoryMiB int64 `json:"memoryMiB,omitempty"`
+
+	// DiskSizeGB is the size of the installation disk in GB.
+	// +optional
+	DiskSizeGB int64 `json:"diskSizeGB,omitempty"`
+}
+
+// HostPolicy assigns the role and hostname of the discovered hosts it
+// matches.
+type HostPolicy struct {
+	// MACPrefixes restricts the policy to the hosts with an interface whose
+	// MAC address starts with one of the prefixes, e.g. 3c:fd:fe. The policy
+	// matches all hosts when empty.
+	// +optional
+	MACPrefixes []string `json:"macPrefixes,omitempty"`
+
+	// Role is the role assigned to the hosts, master or worker.
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// HostnameTemplate is a Go template of the hostname assigned to the
+	// hosts, e.g. worker-{{.Index}}. .Index is the index of the host among
+	// the hosts matched by the policy, from 0, and .Role its role.
+	// +optional
+	HostnameTemplate string `json:"hostnameTemplate,omitempty"`
+}
+
+// Matches returns whether the policy applies to a host with the given MAC
+// addresses.
+func (p *HostPolicy) Matches(macAddresses []string) bool {
+	if len(p.MACPrefixes) == 0 {
+		return true
+	}
+	for _, mac := range macAddresses {
+		for _, prefix := range p.MACPrefixes {
+			if strings.HasPrefix(strings.ToLower(mac), strings.ToLower(prefix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Hostname returns the hostname the policy assigns to the host with the
+// given index and role.
+func (p *HostPolicy) Hostname(index int, role string) (string, error) {
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(p.HostnameTemplate)
+	if err != nil {
+		return "", err
+	}
+	var hostname strings.Builder
+	if err := tmpl.Execute(&hostname, struct {
+		Index int
+		Role  string
+	}{Index: index, Role: role}); err != nil {
+		return "", err
+	}
+	return hostname.String(), nil
+}
+
+// CatalogSource defines an operator catalog.
+type CatalogSource struct {
+	// Name is the name of the catalog source in the
+	// openshift-marketplace namespace.
+	Name string `json:"name"`
+
+	// Image is the pull spec of the catalog index image.
+	Image string `json:"image"`
+
+	// DisplayName is the name of the catalog shown in the console.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Operator defines an operator subscription.
+type Operator struct {
+	// Name is the name of the operator package.
+	Name string `json:"name"`
+
+	// Namespace is the namespace the operator is installed in.
+	// The default is openshift-<name>.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Channel is the channel the operator is subscribed to.
+	// The default is the default channel of the package.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Source is the name of the catalog source providing the operator.
+	// The default is redhat-operators.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// ExtraManifest defines a manifest applied when the cluster is installed.
+type ExtraManifest struct {
+	// Name is the file name of the manifest, ending in .yaml or .yml.
+	Name string `json:"name"`
+
+	// Content holds one or more YAML documents.
+	Content string `json:"content"`
 }
 
 // Host defines per host configurations
 type Host struct {
-	Hostname        string                    `json:"hostname,omitempty"`
-	Role            string                    `json:"role,omitempty"`
-	RootDeviceHints baremetal.RootDeviceHints `json:"rootDeviceHints,omitempty"`
+	Hostname        string          `json:"hostname,omitempty"`
+	Role            string          `json:"role,omitempty"`
+	RootDeviceHints RootDeviceHints `json:"rootDeviceHints,omitempty"`
 	// list of interfaces and mac addresses
 	Interfaces    []*aiv1beta1.Interface `json:"interfaces,omitempty"`
 	NetworkConfig aiv1beta1.NetConfig    `json:"networkConfig,omitempty"`
-	BMC           baremetal.BMC
+	// Network is a simplified network configuration, expanded into NMState
+	// and merged with the networkConfig.
+	// +optional
+	Network *HostNetwork `json:"network,omitempty"`
+	// CPUArchitecture is the CPU architecture of the host, e.g. amd64 or
+	// arm64. When set, it is checked against the architecture of the
+	// release image and of the machine pool of the role of the host.
+	// +optional
+	CPUArchitecture string `json:"cpuArchitecture,omitempty"`
+	// Hardware declares the hardware of the host, which is checked
+	// against the minimal hardware requirements of its role.
+	// +optional
+	Hardware *Hardware `json:"hardware,omitempty"`
+	BMC      baremetal.BMC
+}
+
+// HostNetwork defines the bonds and VLANs of a host, from which the
+// installer generates its NMState network configuration.
+type HostNetwork struct {
+	// Bonds lists the bonds of the host.
+	// +optional
+	Bonds []Bond `json:"bonds,omitempty"`
+
+	// VLANs lists the VLAN interfaces of the host.
+	// +optional
+	VLANs []VLAN `json:"vlans,omitempty"`
+
+	// DNSServers lists the addresses of the DNS servers of the host.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+}
+
+// Bond defines a bond of host interfaces.
+type Bond struct {
+	// Name is the name of the bond, e.g. bond0.
+	Name string `json:"name"`
+
+	// Mode is the bonding mode: balance-rr, active-backup, balance-xor,
+	// broadcast, 802.3ad, balance-tlb or balance-alb.
+	Mode string `json:"mode"`
+
+	// Members lists the names of the host interfaces in the bond.
+	Members []string `json:"members"`
+
+	// MTU is the MTU of the bond.
+	// +optional
+	MTU int `json:"mtu,omitempty"`
+
+	// Addresses lists the static addresses of the bond, in CIDR notation.
+	// The bond has no address when empty, e.g. when it only carries VLANs.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Gateway is the next hop of the default route through the bond.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// VLAN defines a VLAN interface on top of a host interface or a bond.
+type VLAN struct {
+	// Name is the name of the VLAN interface. The default is
+	// <baseInterface>.<id>.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// BaseInterface is the name of the host interface or bond the VLAN is
+	// on.
+	BaseInterface string `json:"baseInterface"`
+
+	// ID is the VLAN ID, between 1 and 4094.
+	ID int `json:"id"`
+
+	// Addresses lists the static addresses of the VLAN interface, in CIDR
+	// notation.
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Gateway is the next hop of the default route through the VLAN
+	// interface.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// InterfaceName returns the name of the VLAN interface.
+func (v *VLAN) InterfaceName() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return fmt.Sprintf("%s.%d", v.BaseInterface, v.ID)
+}
+
+// RootDeviceHints extends the baremetal root device hints with the
+// settings required to select a root device reached over a SAN.
+type RootDeviceHints struct {
+	baremetal.RootDeviceHints `json:",inline"`
+
+	// Multipath restricts the selection to device-mapper multipath devices,
+	// so that the multipath device is used for the installation instead of
+	// one of its paths. When set, the device is identified by its WWN or by
+	// a /dev/mapper/ or /dev/disk/by-id/dm-uuid-mpath- device name.
+	// +optional
+	Multipath bool `json:"multipath,omitempty"`
+
+	// ISCSI is the iSCSI target holding the root device. The host logs in to
+	// the target before the disks are inventoried.
+	// +optional
+	ISCSI *ISCSITarget `json:"iscsi,omitempty"`
+}
+
+// ISCSITarget defines an iSCSI target and the LUN holding the root device.
+type ISCSITarget struct {
+	// Portal is the address of the target portal, as host:port.
+	Portal string `json:"portal"`
+
+	// IQN is the iSCSI qualified name of the target.
+	IQN string `json:"iqn"`
+
+	// LUN is the logical unit number of the root device on the target.
+	// +optional
+	LUN int `json:"lun,omitempty"`
+
+	// InitiatorName is the iSCSI qualified name used by the host. When
+	// omitted, the default initiator name of the host is used.
+	// +optional
+	InitiatorName string `json:"initiatorName,omitempty"`
 }
@@ -28,6 +28,9 @@ var (
 
 // SetPlatformDefaults sets the defaults for the platform.
 func SetPlatformDefaults(p *aws.Platform) {
+	if p.IsIPv6Only() && p.IPv6Egress == "" {
+		p.IPv6Egress = aws.NAT64IPv6Egress
+	}
 }
 
 // InstanceTypes returns a list of instance types, in decreasing priority order, which we should use for a given
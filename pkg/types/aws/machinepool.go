@@ -41,6 +41,80 @@ type MachinePool struct {
 	// +kubebuilder:validation:MaxItems=10
 	// +optional
 	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
+
+	// Tenancy indicates whether the instances of the machine pool run on shared or single-tenant hardware.
+	// "dedicated" runs the instances on hardware dedicated to the account, and "host" runs them on
+	// dedicated hosts, optionally selected by HostPlacement.
+	// Leave unset to run the instances on shared hardware.
+	//
+	// +kubebuilder:validation:Enum=default;dedicated;host
+	// +optional
+	Tenancy Tenancy `json:"tenancy,omitempty"`
+
+	// HostPlacement selects the dedicated hosts the instances of the machine pool are launched on.
+	// It is only valid when Tenancy is "host", and only for the control plane, whose zones must be set
+	// so that the hosts of each zone are checked to have capacity for its instances. It is not supported
+	// with the Cluster API install. When omitted, instances are launched on any available dedicated host
+	// of the account which has auto-placement enabled.
+	//
+	// +optional
+	HostPlacement *HostPlacement `json:"hostPlacement,omitempty"`
+
+	// CapacityReservationID is the ID of an on-demand capacity reservation (ODCR), in the format cr-xxxx,
+	// which the instances of the machine pool are launched into. The reservation must match the instance
+	// type and zones of the machine pool and have capacity for its replicas.
+	// Control plane instances target the reservation. Compute instances are launched by the machine API,
+	// which consumes the reservation automatically, so it must accept open instance matching.
+	//
+	// +optional
+	CapacityReservationID string `json:"capacityReservationID,omitempty"`
+
+	// SpotMarketOptions launches the instances of a compute machine pool as spot instances,
+	// which may be interrupted when AWS needs the capacity back.
+	// It is not supported for the control plane.
+	//
+	// +optional
+	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
+}
+
+// SpotMarketOptions defines the options of spot instances.
+type SpotMarketOptions struct {
+	// MaxPrice is the maximum hourly price in USD to pay for a spot instance, e.g. "0.05".
+	// Leave unset to pay up to the on-demand price.
+	//
+	// +optional
+	MaxPrice string `json:"maxPrice,omitempty"`
+}
+
+// Tenancy indicates whether instances run on shared or single-tenant hardware.
+type Tenancy string
+
+const (
+	// DefaultTenancy runs instances on shared hardware.
+	DefaultTenancy Tenancy = "default"
+
+	// DedicatedTenancy runs instances on single-tenant hardware dedicated to the account.
+	DedicatedTenancy Tenancy = "dedicated"
+
+	// HostTenancy runs instances on dedicated hosts allocated by the account.
+	HostTenancy Tenancy = "host"
+)
+
+// HostPlacement selects the dedicated hosts instances are launched on.
+// Exactly one of HostIDs or HostResourceGroupARN must be set.
+type HostPlacement struct {
+	// HostIDs are the IDs of the dedicated hosts, in the format h-xxxx. Instances are
+	// distributed across the hosts, which must have capacity for the instance type of
+	// the machine pool and be located in its zones.
+	//
+	// +optional
+	HostIDs []string `json:"hostIDs,omitempty"`
+
+	// HostResourceGroupARN is the ARN of a host resource group, which allocates and
+	// manages the dedicated hosts on behalf of the account.
+	//
+	// +optional
+	HostResourceGroupARN string `json:"hostResourceGroupARN,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -85,6 +159,22 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if len(required.AdditionalSecurityGroupIDs) > 0 {
 		a.AdditionalSecurityGroupIDs = required.AdditionalSecurityGroupIDs
 	}
+
+	if required.Tenancy != "" {
+		a.Tenancy = required.Tenancy
+	}
+
+	if required.HostPlacement != nil {
+		a.HostPlacement = required.HostPlacement
+	}
+
+	if required.CapacityReservationID != "" {
+		a.CapacityReservationID = required.CapacityReservationID
+	}
+
+	if required.SpotMarketOptions != nil {
+		a.SpotMarketOptions = required.SpotMarketOptions
+	}
 }
 
 // EC2RootVolume defines the storage for an ec2 instance.
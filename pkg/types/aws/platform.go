@@ -13,6 +13,34 @@ const (
 	VolumeTypeGp3 = "gp3"
 )
 
+// IPFamily is the IP family of the cluster machines.
+// +kubebuilder:validation:Enum="";IPv4;IPv6
+type IPFamily string
+
+const (
+	// IPv4IPFamily runs the cluster machines with IPv4 addresses.
+	IPv4IPFamily IPFamily = "IPv4"
+	// IPv6IPFamily runs the cluster machines with single-stack IPv6
+	// networking, in existing dual-stack subnets.
+	IPv6IPFamily IPFamily = "IPv6"
+)
+
+// IPv6EgressType is how the machines of an IPv6 cluster reach IPv4
+// destinations.
+// +kubebuilder:validation:Enum="";NAT64;None
+type IPv6EgressType string
+
+const (
+	// NAT64IPv6Egress reaches IPv4 destinations through the DNS64
+	// resolution of the subnets and a NAT gateway routing the 64:ff9b::/96
+	// prefix.
+	NAT64IPv6Egress IPv6EgressType = "NAT64"
+	// NoneIPv6Egress does not reach IPv4 destinations. All the endpoints
+	// used by the cluster, including the release image registry, must be
+	// reachable over IPv6.
+	NoneIPv6Egress IPv6EgressType = "None"
+)
+
 // Platform stores all the global configuration that all machinesets
 // use.
 type Platform struct {
@@ -96,12 +124,17 @@ type Platform struct {
 	// transport layer (TCP/SSL). See the following for additional details:
 	// https://docs.aws.amazon.com/AmazonECS/latest/developerguide/load-balancer-types.html#nlb
 	//
-	// If this field is not set explicitly, it defaults to "Classic".  This
-	// default is subject to change over time.
+	// If this field is not set explicitly, it defaults to the type of the
+	// external API load balancer when apiLoadBalancer.type is set, and to
+	// "Classic" otherwise.  This default is subject to change over time.
 	//
 	// +optional
 	LBType configv1.AWSLBType `json:"lbType,omitempty"`
 
+	// APILoadBalancer configures the load balancers of the Kubernetes API.
+	// +optional
+	APILoadBalancer *APILoadBalancer `json:"apiLoadBalancer,omitempty"`
+
 	// PreserveBootstrapIgnition is an optional field that can be used to make the S3 deletion optional
 	// during bootstrap destroy.
 	// +optional
@@ -111,6 +144,63 @@ type Platform struct {
 	// Public IPv4 address that you bring to your AWS account with BYOIP.
 	// +optional
 	PublicIpv4Pool string `json:"publicIpv4Pool,omitempty"`
+
+	// IPFamily is the IP family of the cluster machines. IPv6 installs the
+	// cluster with single-stack IPv6 networking in existing dual-stack
+	// subnets, which the network load balancers require. It is not supported
+	// with the Cluster API install. Defaults to IPv4.
+	// +optional
+	IPFamily IPFamily `json:"ipFamily,omitempty"`
+
+	// IPv6Egress is how the machines of an IPv6 cluster reach IPv4
+	// destinations. NAT64 requires DNS64 to be enabled on the private
+	// subnets and their route tables to route 64:ff9b::/96 to a NAT gateway.
+	// It may only be set when ipFamily is IPv6 and defaults to NAT64.
+	// +optional
+	IPv6Egress IPv6EgressType `json:"ipv6Egress,omitempty"`
+}
+
+// IsIPv6Only returns true if the cluster machines run with single-stack IPv6
+// networking.
+func (p *Platform) IsIPv6Only() bool {
+	return p.IPFamily == IPv6IPFamily
+}
+
+// APILoadBalancerType returns the type of the external API load balancer,
+// which defaults to NLB.
+func (p *Platform) APILoadBalancerType() configv1.AWSLBType {
+	if p.APILoadBalancer != nil && p.APILoadBalancer.Type != "" {
+		return p.APILoadBalancer.Type
+	}
+	return configv1.NLB
+}
+
+// APILoadBalancer configures the load balancers of the Kubernetes API.
+type APILoadBalancer struct {
+	// Type is the type of the external API load balancer, NLB or Classic.
+	// The internal API load balancer, which also serves the machine config
+	// server, is always a Network Load Balancer. Defaults to NLB.
+	//
+	// +kubebuilder:validation:Enum="";NLB;Classic
+	// +optional
+	Type configv1.AWSLBType `json:"type,omitempty"`
+
+	// Subnets are the IDs of the existing public subnets of the external API
+	// load balancer. They must be among the subnets of the platform, and
+	// default to all its public subnets.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
+	// IdleTimeoutSeconds is the time, in seconds, a connection to a Classic
+	// external API load balancer may stay idle before it is closed, from 1 to
+	// 4000. Defaults to 600.
+	// +optional
+	IdleTimeoutSeconds int64 `json:"idleTimeoutSeconds,omitempty"`
+
+	// AdditionalTags are the tags added to the API load balancers, on top of
+	// the user tags.
+	// +optional
+	AdditionalTags map[string]string `json:"additionalTags,omitempty"`
 }
 
 // ServiceEndpoint store the configuration for services to
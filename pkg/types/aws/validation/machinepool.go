@@ -2,6 +2,8 @@ package validation
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -27,6 +29,12 @@ var (
 	}()
 
 	validMetadataAuthValues = sets.NewString("Required", "Optional")
+
+	validTenancyValues = sets.NewString(string(aws.DefaultTenancy), string(aws.DedicatedTenancy), string(aws.HostTenancy))
+
+	hostIDRegex = regexp.MustCompile(`^h-[0-9a-f]{8}([0-9a-f]{9})?$`)
+
+	capacityReservationIDRegex = regexp.MustCompile(`^cr-[0-9a-f]{17}$`)
 )
 
 // AWS has a limit of 16 security groups. See:
@@ -53,6 +61,45 @@ func ValidateMachinePool(platform *aws.Platform, p *aws.MachinePool, fldPath *fi
 	}
 
 	allErrs = append(allErrs, validateSecurityGroups(platform, p, fldPath)...)
+	allErrs = append(allErrs, validateTenancy(p, fldPath)...)
+	allErrs = append(allErrs, validateCapacityReservationAndSpot(p, fldPath)...)
+
+	return allErrs
+}
+
+func validateTenancy(p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if p.Tenancy != "" && !validTenancyValues.Has(string(p.Tenancy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("tenancy"), p.Tenancy, validTenancyValues.List()))
+	}
+
+	if p.HostPlacement == nil {
+		return allErrs
+	}
+	hostPath := fldPath.Child("hostPlacement")
+	if p.Tenancy != aws.HostTenancy {
+		allErrs = append(allErrs, field.Forbidden(hostPath, fmt.Sprintf("hostPlacement is only valid when tenancy is %s", aws.HostTenancy)))
+	}
+	switch {
+	case len(p.HostPlacement.HostIDs) > 0 && p.HostPlacement.HostResourceGroupARN != "":
+		allErrs = append(allErrs, field.Forbidden(hostPath, "only one of hostIDs or hostResourceGroupARN may be set"))
+	case len(p.HostPlacement.HostIDs) == 0 && p.HostPlacement.HostResourceGroupARN == "":
+		allErrs = append(allErrs, field.Required(hostPath, "one of hostIDs or hostResourceGroupARN must be set"))
+	}
+	hostIDs := sets.NewString()
+	for i, id := range p.HostPlacement.HostIDs {
+		if !hostIDRegex.MatchString(id) {
+			allErrs = append(allErrs, field.Invalid(hostPath.Child("hostIDs").Index(i), id, "host ID must be in the format h-xxxx"))
+		}
+		if hostIDs.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(hostPath.Child("hostIDs").Index(i), id))
+		}
+		hostIDs.Insert(id)
+	}
+	if arn := p.HostPlacement.HostResourceGroupARN; arn != "" && !strings.HasPrefix(arn, "arn:") {
+		allErrs = append(allErrs, field.Invalid(hostPath.Child("hostResourceGroupARN"), arn, "must be a valid ARN"))
+	}
 
 	return allErrs
 }
@@ -72,6 +119,54 @@ func validateSecurityGroups(platform *aws.Platform, p *aws.MachinePool, fldPath
 	return allErrs
 }
 
+func validateCapacityReservationAndSpot(p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if id := p.CapacityReservationID; id != "" && !capacityReservationIDRegex.MatchString(id) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("capacityReservationID"), id, "capacity reservation ID must be in the format cr-xxxx"))
+	}
+
+	if p.SpotMarketOptions == nil {
+		return allErrs
+	}
+	if p.CapacityReservationID != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("spotMarketOptions"), "spot instances cannot be launched into a capacity reservation"))
+	}
+	if p.Tenancy == aws.HostTenancy {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("spotMarketOptions"), "spot instances cannot be launched on dedicated hosts"))
+	}
+	if price := p.SpotMarketOptions.MaxPrice; price != "" {
+		if v, err := strconv.ParseFloat(price, 64); err != nil || v <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("spotMarketOptions", "maxPrice"), price, "max price must be a positive number"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateControlPlaneSpotMarketOptions checks that the control plane does not run on spot instances.
+func ValidateControlPlaneSpotMarketOptions(platform *aws.Platform, p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	pool := &aws.MachinePool{}
+	pool.Set(platform.DefaultMachinePlatform)
+	pool.Set(p)
+
+	if pool.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("spotMarketOptions"), "spot instances are not supported for the control plane"))
+	}
+	return allErrs
+}
+
+// ValidateComputeHostPlacement checks that the specified compute machine pool does not select
+// dedicated hosts, which the machine API cannot launch instances on.
+func ValidateComputeHostPlacement(p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p != nil && p.HostPlacement != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostPlacement"), "hostPlacement is only supported for the control plane"))
+	}
+	return allErrs
+}
+
 func validateVolumeSize(p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	volumeSize := p.EC2RootVolume.Size
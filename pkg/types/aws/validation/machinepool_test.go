@@ -128,6 +128,102 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			expected: `^test-path\.authentication: Invalid value: \"foobarbaz\": must be either Required or Optional$`,
 		},
+		{
+			name: "valid dedicated tenancy",
+			pool: &aws.MachinePool{
+				Tenancy: aws.DedicatedTenancy,
+			},
+		},
+		{
+			name: "valid dedicated hosts",
+			pool: &aws.MachinePool{
+				Tenancy: aws.HostTenancy,
+				HostPlacement: &aws.HostPlacement{
+					HostIDs: []string{"h-0123456789abcdef0", "h-0123456789abcdef1"},
+				},
+			},
+		},
+		{
+			name: "valid host resource group",
+			pool: &aws.MachinePool{
+				Tenancy: aws.HostTenancy,
+				HostPlacement: &aws.HostPlacement{
+					HostResourceGroupARN: "arn:aws:resource-groups:us-east-1:123456789012:group/hosts",
+				},
+			},
+		},
+		{
+			name: "invalid tenancy",
+			pool: &aws.MachinePool{
+				Tenancy: "single",
+			},
+			expected: `^test-path\.tenancy: Unsupported value: "single": supported values: "dedicated", "default", "host"$`,
+		},
+		{
+			name: "host placement without host tenancy",
+			pool: &aws.MachinePool{
+				Tenancy: aws.DedicatedTenancy,
+				HostPlacement: &aws.HostPlacement{
+					HostIDs: []string{"h-0123456789abcdef0"},
+				},
+			},
+			expected: `^test-path\.hostPlacement: Forbidden: hostPlacement is only valid when tenancy is host$`,
+		},
+		{
+			name: "host placement with hosts and resource group",
+			pool: &aws.MachinePool{
+				Tenancy: aws.HostTenancy,
+				HostPlacement: &aws.HostPlacement{
+					HostIDs:              []string{"h-0123456789abcdef0"},
+					HostResourceGroupARN: "arn:aws:resource-groups:us-east-1:123456789012:group/hosts",
+				},
+			},
+			expected: `^test-path\.hostPlacement: Forbidden: only one of hostIDs or hostResourceGroupARN may be set$`,
+		},
+		{
+			name: "invalid and duplicate host IDs",
+			pool: &aws.MachinePool{
+				Tenancy: aws.HostTenancy,
+				HostPlacement: &aws.HostPlacement{
+					HostIDs: []string{"host-1", "h-0123456789abcdef0", "h-0123456789abcdef0"},
+				},
+			},
+			expected: `^\[test-path\.hostPlacement\.hostIDs\[0\]: Invalid value: "host-1": host ID must be in the format h-xxxx, test-path\.hostPlacement\.hostIDs\[2\]: Duplicate value: "h-0123456789abcdef0"\]$`,
+		},
+		{
+			name: "valid capacity reservation",
+			pool: &aws.MachinePool{
+				CapacityReservationID: "cr-0123456789abcdef0",
+			},
+		},
+		{
+			name: "invalid capacity reservation",
+			pool: &aws.MachinePool{
+				CapacityReservationID: "reservation",
+			},
+			expected: `^test-path\.capacityReservationID: Invalid value: "reservation": capacity reservation ID must be in the format cr-xxxx$`,
+		},
+		{
+			name: "valid spot market options",
+			pool: &aws.MachinePool{
+				SpotMarketOptions: &aws.SpotMarketOptions{MaxPrice: "0.05"},
+			},
+		},
+		{
+			name: "invalid spot max price",
+			pool: &aws.MachinePool{
+				SpotMarketOptions: &aws.SpotMarketOptions{MaxPrice: "-1"},
+			},
+			expected: `^test-path\.spotMarketOptions\.maxPrice: Invalid value: "-1": max price must be a positive number$`,
+		},
+		{
+			name: "spot in capacity reservation",
+			pool: &aws.MachinePool{
+				CapacityReservationID: "cr-0123456789abcdef0",
+				SpotMarketOptions:     &aws.SpotMarketOptions{},
+			},
+			expected: `^test-path\.spotMarketOptions: Forbidden: spot instances cannot be launched into a capacity reservation$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 )
@@ -51,13 +53,77 @@ func ValidatePlatform(p *aws.Platform, cm types.CredentialsMode, fldPath *field.
 		}
 	}
 
+	allErrs = append(allErrs, validateIPFamily(p, fldPath)...)
 	allErrs = append(allErrs, validateServiceEndpoints(p.ServiceEndpoints, fldPath.Child("serviceEndpoints"))...)
 	allErrs = append(allErrs, validateUserTags(p.UserTags, p.PropagateUserTag, fldPath.Child("userTags"))...)
+	if p.APILoadBalancer != nil {
+		allErrs = append(allErrs, validateAPILoadBalancer(p, fldPath.Child("apiLoadBalancer"))...)
+	}
 
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p, p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+		allErrs = append(allErrs, ValidateComputeHostPlacement(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+	}
+
+	return allErrs
+}
+
+func validateIPFamily(p *aws.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch p.IPFamily {
+	case "", aws.IPv4IPFamily:
+		if p.IPv6Egress != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ipv6Egress"), "may only be set when ipFamily is IPv6"))
+		}
+	case aws.IPv6IPFamily:
+		if len(p.Subnets) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("subnets"), "existing dual-stack subnets are required when ipFamily is IPv6"))
+		}
+		if p.PublicIpv4Pool != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIpv4Pool"), "may not be used when ipFamily is IPv6"))
+		}
+		switch p.IPv6Egress {
+		case "", aws.NAT64IPv6Egress, aws.NoneIPv6Egress:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("ipv6Egress"), p.IPv6Egress, []string{string(aws.NAT64IPv6Egress), string(aws.NoneIPv6Egress)}))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("ipFamily"), p.IPFamily, []string{string(aws.IPv4IPFamily), string(aws.IPv6IPFamily)}))
+	}
+	return allErrs
+}
+
+func validateAPILoadBalancer(p *aws.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	lb := p.APILoadBalancer
+	switch lb.Type {
+	case "", configv1.NLB:
+	case configv1.Classic:
+		if p.IsIPv6Only() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "a Classic load balancer may not be used when ipFamily is IPv6"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), lb.Type, []string{string(configv1.NLB), string(configv1.Classic)}))
+	}
+
+	if len(lb.Subnets) > 0 {
+		platformSubnets := sets.New(p.Subnets...)
+		for i, subnet := range lb.Subnets {
+			if !platformSubnets.Has(subnet) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("subnets").Index(i), subnet, "must be one of the subnets of the platform"))
+			}
+		}
+	}
+
+	if lb.IdleTimeoutSeconds != 0 {
+		if p.APILoadBalancerType() != configv1.Classic {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("idleTimeoutSeconds"), "may only be set for a Classic load balancer"))
+		} else if lb.IdleTimeoutSeconds < 1 || lb.IdleTimeoutSeconds > 4000 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutSeconds"), lb.IdleTimeoutSeconds, "must be between 1 and 4000"))
+		}
 	}
 
+	allErrs = append(allErrs, validateUserTags(lb.AdditionalTags, p.PropagateUserTag, fldPath.Child("additionalTags"))...)
 	return allErrs
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 )
@@ -49,6 +50,49 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			expected: `^test-path\.hostedZone: Invalid value: "test-hosted-zone": may not use an existing hosted zone when not using existing subnets$`,
 		},
+		{
+			name: "IPv6-only with subnets",
+			platform: &aws.Platform{
+				Region:     "us-east-1",
+				Subnets:    []string{"test-subnet"},
+				IPFamily:   aws.IPv6IPFamily,
+				IPv6Egress: aws.NoneIPv6Egress,
+			},
+		},
+		{
+			name: "IPv6-only without subnets",
+			platform: &aws.Platform{
+				Region:   "us-east-1",
+				IPFamily: aws.IPv6IPFamily,
+			},
+			expected: `^test-path\.subnets: Required value: existing dual-stack subnets are required when ipFamily is IPv6$`,
+		},
+		{
+			name: "invalid IPv6 egress",
+			platform: &aws.Platform{
+				Region:     "us-east-1",
+				Subnets:    []string{"test-subnet"},
+				IPFamily:   aws.IPv6IPFamily,
+				IPv6Egress: "EgressOnly",
+			},
+			expected: `^test-path\.ipv6Egress: Unsupported value: "EgressOnly": supported values: "NAT64", "None"$`,
+		},
+		{
+			name: "IPv6 egress with IPv4",
+			platform: &aws.Platform{
+				Region:     "us-east-1",
+				IPv6Egress: aws.NAT64IPv6Egress,
+			},
+			expected: `^test-path\.ipv6Egress: Forbidden: may only be set when ipFamily is IPv6$`,
+		},
+		{
+			name: "invalid IP family",
+			platform: &aws.Platform{
+				Region:   "us-east-1",
+				IPFamily: "DualStack",
+			},
+			expected: `^test-path\.ipFamily: Unsupported value: "DualStack": supported values: "IPv4", "IPv6"$`,
+		},
 		{
 			name: "invalid url for service endpoint",
 			platform: &aws.Platform{
@@ -205,6 +249,44 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			expected: `^test-path\.hostedZoneRole: Forbidden: when specifying a hostedZoneRole, either Passthrough or Manual credential mode must be specified$`,
 		},
+		{
+			name: "valid Classic API load balancer",
+			platform: &aws.Platform{
+				Region:  "us-east-1",
+				Subnets: []string{"public-subnet", "private-subnet"},
+				APILoadBalancer: &aws.APILoadBalancer{
+					Type:               configv1.Classic,
+					Subnets:            []string{"public-subnet"},
+					IdleTimeoutSeconds: 3600,
+					AdditionalTags:     map[string]string{"team": "api"},
+				},
+			},
+		},
+		{
+			name: "API load balancer subnet not in the platform subnets",
+			platform: &aws.Platform{
+				Region:          "us-east-1",
+				Subnets:         []string{"private-subnet"},
+				APILoadBalancer: &aws.APILoadBalancer{Subnets: []string{"public-subnet"}},
+			},
+			expected: `^test-path\.apiLoadBalancer\.subnets\[0\]: Invalid value: "public-subnet": must be one of the subnets of the platform$`,
+		},
+		{
+			name: "idle timeout for NLB API load balancer",
+			platform: &aws.Platform{
+				Region:          "us-east-1",
+				APILoadBalancer: &aws.APILoadBalancer{IdleTimeoutSeconds: 600},
+			},
+			expected: `^test-path\.apiLoadBalancer\.idleTimeoutSeconds: Forbidden: may only be set for a Classic load balancer$`,
+		},
+		{
+			name: "invalid API load balancer type",
+			platform: &aws.Platform{
+				Region:          "us-east-1",
+				APILoadBalancer: &aws.APILoadBalancer{Type: "ALB"},
+			},
+			expected: `^test-path\.apiLoadBalancer\.type: Unsupported value: "ALB": supported values: "NLB", "Classic"$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -79,3 +79,11 @@ type VMDiskSecurityProfile struct {
 
 // DefaultDiskType holds the default Azure disk type used by the VMs.
 const DefaultDiskType string = "Premium_LRS"
+
+// EtcdDiskLun is the logical unit number of the dedicated etcd disk attached
+// to the control plane VMs.
+const EtcdDiskLun int32 = 0
+
+// EtcdDiskDevice is the device of the dedicated etcd disk attached to the
+// control plane VMs, as linked by the Azure udev rules for its LUN.
+const EtcdDiskDevice string = "/dev/disk/azure/scsi1/lun0"
@@ -35,6 +35,12 @@ type Platform struct {
 	// ARMEndpoint is the endpoint for the Azure API when installing on Azure Stack.
 	ARMEndpoint string `json:"armEndpoint,omitempty"`
 
+	// ServiceEndpoints list contains custom endpoints which will override the
+	// default endpoints of the Azure services of the cloud environment.
+	// There must be only one ServiceEndpoint for a service.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
 	// ClusterOSImage is the url of a storage blob in the Azure Stack environment containing an RHCOS VHD. This field is required for Azure Stack and not applicable to Azure.
 	ClusterOSImage string `json:"clusterOSImage,omitempty"`
 
@@ -76,7 +82,9 @@ type Platform struct {
 	CloudName CloudEnvironment `json:"cloudName,omitempty"`
 
 	// OutboundType is a strategy for how egress from cluster is achieved. When not specified default is "Loadbalancer".
-	// "NatGateway" is only available in TechPreview.
+	// "NatGateway" attaches NAT gateways to the subnets of the cluster and is not allowed with a pre-existing network.
+	// "UserDefinedRouting" requires a pre-existing network whose subnets have a route table with a default route
+	// providing the egress, e.g. to a firewall appliance.
 	//
 	// +kubebuilder:default=Loadbalancer
 	// +optional
@@ -102,6 +110,49 @@ type Platform struct {
 	CustomerManagedKey *CustomerManagedKey `json:"customerManagedKey,omitempty"`
 }
 
+// ServiceEndpointName is the name of an Azure service whose endpoint can be
+// overridden.
+// +kubebuilder:validation:Enum=ResourceManager;ActiveDirectory;Graph;KeyVault
+type ServiceEndpointName string
+
+const (
+	// ResourceManagerServiceEndpoint is the Azure Resource Manager API.
+	ResourceManagerServiceEndpoint ServiceEndpointName = "ResourceManager"
+	// ActiveDirectoryServiceEndpoint is the Microsoft Entra ID (Active
+	// Directory) authority used to authenticate.
+	ActiveDirectoryServiceEndpoint ServiceEndpointName = "ActiveDirectory"
+	// GraphServiceEndpoint is the Microsoft Graph API.
+	GraphServiceEndpoint ServiceEndpointName = "Graph"
+	// KeyVaultServiceEndpoint is the Azure Key Vault API.
+	KeyVaultServiceEndpoint ServiceEndpointName = "KeyVault"
+)
+
+// ServiceEndpoint stores the configuration for services to
+// override existing defaults of Azure Services.
+type ServiceEndpoint struct {
+	// Name is the name of the Azure service.
+	// This must be provided and cannot be empty.
+	Name ServiceEndpointName `json:"name"`
+
+	// URL is fully qualified URI with scheme https, that overrides the default
+	// endpoint of the service.
+	// This must be provided and cannot be empty.
+	//
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+}
+
+// ServiceEndpointURL returns the URL overriding the endpoint of the service,
+// or an empty string when the endpoint is not overridden.
+func (p *Platform) ServiceEndpointURL(name ServiceEndpointName) string {
+	for _, e := range p.ServiceEndpoints {
+		if e.Name == name {
+			return e.URL
+		}
+	}
+	return ""
+}
+
 // KeyVault defines an Azure Key Vault.
 type KeyVault struct {
 	// ResourceGroup defines the Azure resource group used by the key
@@ -2,13 +2,13 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
-	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/azure"
 )
@@ -98,14 +98,9 @@ func ValidatePlatform(p *azure.Platform, publish types.PublishingStrategy, fldPa
 	if p.OutboundType == azure.UserDefinedRoutingOutboundType && p.VirtualNetwork == "" {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, fmt.Sprintf("%s is only allowed when installing to pre-existing network", azure.UserDefinedRoutingOutboundType)))
 	}
-	if p.OutboundType == azure.NatGatewayOutboundType {
-		if ic.FeatureSet != configv1.TechPreviewNoUpgrade {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, "not supported in this feature set"))
-		}
-		if p.VirtualNetwork != "" {
-			// For now, BYO network and NAT gateways are not compatible
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, fmt.Sprintf("%s is not allowed when installing to pre-existing network", azure.NatGatewayOutboundType)))
-		}
+	if p.OutboundType == azure.NatGatewayOutboundType && p.VirtualNetwork != "" {
+		// For now, BYO network and NAT gateways are not compatible
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, fmt.Sprintf("%s is not allowed when installing to pre-existing network", azure.NatGatewayOutboundType)))
 	}
 
 	if p.CustomerManagedKey != nil {
@@ -120,6 +115,8 @@ func ValidatePlatform(p *azure.Platform, publish types.PublishingStrategy, fldPa
 	// check if configured userTags are valid.
 	allErrs = append(allErrs, validateUserTags(p.UserTags, fldPath.Child("userTags"))...)
 
+	allErrs = append(allErrs, validateServiceEndpoints(p.ServiceEndpoints, fldPath.Child("serviceEndpoints"))...)
+
 	switch cloud := p.CloudName; cloud {
 	case azure.StackCloud:
 		allErrs = append(allErrs, validateAzureStack(p, fldPath)...)
@@ -262,6 +259,11 @@ func validateAzureStack(p *azure.Platform, fldPath *field.Path) field.ErrorList
 	if p.ARMEndpoint == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("armEndpoint"), "ARM endpoint must be set when installing on Azure Stack"))
 	}
+	for i, e := range p.ServiceEndpoints {
+		if e.Name == azure.ResourceManagerServiceEndpoint {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceEndpoints").Index(i).Child("name"), e.Name, "the ARM endpoint of Azure Stack must be set with armEndpoint"))
+		}
+	}
 	switch p.OutboundType {
 	case azure.UserDefinedRoutingOutboundType:
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("outboundType"), p.OutboundType, "Azure Stack does not support user-defined routing"))
@@ -270,3 +272,59 @@ func validateAzureStack(p *azure.Platform, fldPath *field.Path) field.ErrorList
 	}
 	return allErrs
 }
+
+var (
+	validServiceEndpointNames = map[azure.ServiceEndpointName]bool{
+		azure.ResourceManagerServiceEndpoint: true,
+		azure.ActiveDirectoryServiceEndpoint: true,
+		azure.GraphServiceEndpoint:           true,
+		azure.KeyVaultServiceEndpoint:        true,
+	}
+
+	validServiceEndpointNameValues = func() []string {
+		v := make([]string, 0, len(validServiceEndpointNames))
+		for n := range validServiceEndpointNames {
+			v = append(v, string(n))
+		}
+		sort.Strings(v)
+		return v
+	}()
+)
+
+func validateServiceEndpoints(endpoints []azure.ServiceEndpoint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	tracker := map[azure.ServiceEndpointName]int{}
+	for idx, e := range endpoints {
+		fldp := fldPath.Index(idx)
+		if !validServiceEndpointNames[e.Name] {
+			allErrs = append(allErrs, field.NotSupported(fldp.Child("name"), e.Name, validServiceEndpointNameValues))
+		}
+		if eidx, ok := tracker[e.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(fldp.Child("name"), e.Name, fmt.Sprintf("duplicate service endpoint not allowed for %s, service endpoint already defined at %s", e.Name, fldPath.Index(eidx))))
+		} else {
+			tracker[e.Name] = idx
+		}
+
+		if err := validateServiceURL(e.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldp.Child("url"), e.URL, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateServiceURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if s := u.Scheme; s != "https" {
+		return fmt.Errorf("invalid scheme %q, only https allowed", s)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("host cannot be empty, empty host provided")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("no request parameters must be provided")
+	}
+	return nil
+}
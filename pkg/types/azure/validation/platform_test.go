@@ -164,13 +164,21 @@ func TestValidatePlatform(t *testing.T) {
 			expected: `^test-path\.outboundType: Invalid value: "UserDefinedRouting": UserDefinedRouting is only allowed when installing to pre-existing network$`,
 		},
 		{
-			name: "invalid nat gateway",
+			name: "valid nat gateway",
 			platform: func() *azure.Platform {
 				p := validPlatform()
 				p.OutboundType = azure.NatGatewayOutboundType
 				return p
 			}(),
-			expected: `^test-path\.outboundType: Invalid value: "NatGateway": not supported in this feature set$`,
+		},
+		{
+			name: "invalid nat gateway with pre-existing network",
+			platform: func() *azure.Platform {
+				p := validNetworkPlatform()
+				p.OutboundType = azure.NatGatewayOutboundType
+				return p
+			}(),
+			expected: `^test-path\.outboundType: Invalid value: "NatGateway": NatGateway is not allowed when installing to pre-existing network$`,
 		},
 		{
 			name: "missing key vault name",
@@ -244,6 +252,58 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			expected: `^test-path\.customerManagedKey: Invalid value: "-": invalid user assigned identity key for encryption$`,
 		},
+		{
+			name: "valid service endpoints",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.ServiceEndpoints = []azure.ServiceEndpoint{
+					{Name: azure.ResourceManagerServiceEndpoint, URL: "https://management.example.com/"},
+					{Name: azure.ActiveDirectoryServiceEndpoint, URL: "https://login.example.com/"},
+				}
+				return p
+			}(),
+		},
+		{
+			name: "unsupported service endpoint",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.ServiceEndpoints = []azure.ServiceEndpoint{{Name: "Storage", URL: "https://storage.example.com/"}}
+				return p
+			}(),
+			expected: `^test-path\.serviceEndpoints\[0\]\.name: Unsupported value: "Storage": supported values: "ActiveDirectory", "Graph", "KeyVault", "ResourceManager"$`,
+		},
+		{
+			name: "duplicate service endpoints",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.ServiceEndpoints = []azure.ServiceEndpoint{
+					{Name: azure.GraphServiceEndpoint, URL: "https://graph.example.com/"},
+					{Name: azure.GraphServiceEndpoint, URL: "https://graph.example.org/"},
+				}
+				return p
+			}(),
+			expected: `^test-path\.serviceEndpoints\[1\]\.name: Invalid value: "Graph": duplicate service endpoint not allowed for Graph, service endpoint already defined at test-path\.serviceEndpoints\[0\]$`,
+		},
+		{
+			name: "http service endpoint",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.ServiceEndpoints = []azure.ServiceEndpoint{{Name: azure.KeyVaultServiceEndpoint, URL: "http://vault.example.com/"}}
+				return p
+			}(),
+			expected: `^test-path\.serviceEndpoints\[0\]\.url: Invalid value: "http://vault\.example\.com/": invalid scheme "http", only https allowed$`,
+		},
+		{
+			name: "resource manager service endpoint on Azure Stack",
+			platform: func() *azure.Platform {
+				p := validPlatform()
+				p.CloudName = azure.StackCloud
+				p.ARMEndpoint = "https://management.local.azurestack.external"
+				p.ServiceEndpoints = []azure.ServiceEndpoint{{Name: azure.ResourceManagerServiceEndpoint, URL: "https://management.example.com/"}}
+				return p
+			}(),
+			expected: `^test-path\.serviceEndpoints\[0\]\.name: Invalid value: "ResourceManager": the ARM endpoint of Azure Stack must be set with armEndpoint$`,
+		},
 	}
 	ic := types.InstallConfig{}
 	for _, tc := range cases {
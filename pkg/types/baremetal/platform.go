@@ -9,10 +9,16 @@ import (
 
 // BMC stores the information about a baremetal host's management controller.
 type BMC struct {
-	Username                       string `json:"username" validate:"required"`
-	Password                       string `json:"password" validate:"required"`
+	Username                       string `json:"username" validate:"required_without=CredentialsName"`
+	Password                       string `json:"password" validate:"required_without=CredentialsName"`
 	Address                        string `json:"address" validate:"required,uniqueField"`
 	DisableCertificateVerification bool   `json:"disableCertificateVerification"`
+
+	// CredentialsName is the name of a Secret holding the username and
+	// password of the BMC, provided in the bmc-credentials directory of the
+	// install directory. It is mutually exclusive with Username and Password.
+	// +optional
+	CredentialsName string `json:"credentialsName,omitempty"`
 }
 
 // BootMode puts the server in legacy (BIOS), UEFI secure boot or UEFI mode for
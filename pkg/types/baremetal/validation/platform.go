@@ -205,7 +205,7 @@ func validateHostsBase(hosts []*baremetal.Host, fldPath *field.Path, filter vali
 			for _, err := range err.(validator.ValidationErrors) {
 				childName := fldPath.Index(idx).Child(err.Namespace()[len(hostType)+1:])
 				switch err.Tag() {
-				case "required":
+				case "required", "required_without":
 					hostErrs = append(hostErrs, field.Required(childName, "missing "+err.Field()))
 				case "uniqueField":
 					hostErrs = append(hostErrs, field.Duplicate(childName, err.Value()))
@@ -234,6 +234,28 @@ func validateHostsBMCOnly(hosts []*baremetal.Host, fldPath *field.Path) field.Er
 	})
 }
 
+// validateHostsBMCCredentialsName checks that the BMC credentials of a host
+// are either referenced by name or inlined, but not both.
+func validateHostsBMCCredentialsName(hosts []*baremetal.Host, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for idx, host := range hosts {
+		name := host.BMC.CredentialsName
+		if name == "" {
+			continue
+		}
+		namePath := fldPath.Index(idx).Child("BMC", "credentialsName")
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(namePath, name, msg))
+		}
+		if host.BMC.Username != "" || host.BMC.Password != "" {
+			allErrs = append(allErrs, field.Forbidden(namePath, "credentialsName cannot be set together with username and password"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateOSImages(p *baremetal.Platform, fldPath *field.Path) field.ErrorList {
 	platformErrs := field.ErrorList{}
 
@@ -409,6 +431,26 @@ func validateProvisioningNetworkDisabledSupported(hosts []*baremetal.Host, fldPa
 	return
 }
 
+// validateProvisioningNetworkDisabled checks that a platform without a
+// provisioning network can be provisioned through virtual media only, so that
+// unsupported configurations are rejected when the install-config is loaded
+// rather than when the hosts are registered in Ironic.
+func validateProvisioningNetworkDisabled(p *baremetal.Platform, agentBasedInstallation bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if p.ProvisioningDHCPRange != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("provisioningDHCPRange"), "DHCP is not served when the provisioning network is disabled"))
+	}
+
+	// Agent-based installations boot the hosts from the agent ISO and do
+	// not use the BMCs to provision the control plane.
+	if !agentBasedInstallation {
+		allErrs = append(allErrs, validateProvisioningNetworkDisabledSupported(p.Hosts, fldPath.Child("Hosts"))...)
+	}
+
+	return allErrs
+}
+
 // ValidatePlatform checks that the specified platform is valid.
 func ValidatePlatform(p *baremetal.Platform, agentBasedInstallation bool, n *types.Networking, fldPath *field.Path, c *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -421,6 +463,10 @@ func ValidatePlatform(p *baremetal.Platform, agentBasedInstallation bool, n *typ
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provisioningNetwork"), p.ProvisioningNetwork, provisioningNetwork.List()))
 	}
 
+	if p.ProvisioningNetwork == baremetal.DisabledProvisioningNetwork {
+		allErrs = append(allErrs, validateProvisioningNetworkDisabled(p, agentBasedInstallation, fldPath)...)
+	}
+
 	if p.BootstrapProvisioningIP != "" {
 		if err := validate.IP(p.BootstrapProvisioningIP); err != nil {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("bootstrapProvisioningIP"), p.BootstrapProvisioningIP, err.Error()))
@@ -576,6 +622,7 @@ func ValidateProvisioningNetworking(p *baremetal.Platform, n *types.Networking,
 	}
 
 	allErrs = append(allErrs, validateHostsBMCOnly(p.Hosts, fldPath)...)
+	allErrs = append(allErrs, validateHostsBMCCredentialsName(p.Hosts, fldPath.Child("hosts"))...)
 
 	return allErrs
 }
@@ -290,6 +290,26 @@ func TestValidatePlatform(t *testing.T) {
 			name:     "provisioningNetwork_disabled_valid",
 			platform: platform().ProvisioningNetwork(baremetal.DisabledProvisioningNetwork).build(),
 		},
+		{
+			name: "provisioningNetwork_disabled_virtualmedia_valid",
+			platform: platform().
+				ProvisioningNetwork(baremetal.DisabledProvisioningNetwork).
+				Hosts(host1().BMCAddress("redfish-virtualmedia://192.168.111.1/redfish/v1/Systems/1")).build(),
+		},
+		{
+			name: "provisioningNetwork_disabled_ipmi",
+			platform: platform().
+				ProvisioningNetwork(baremetal.DisabledProvisioningNetwork).
+				Hosts(host1()).build(),
+			expected: "baremetal.Hosts\\[0\\].BMC: Invalid value: \"ipmi://192.168.111.1\": driver ipmi requires provisioning network",
+		},
+		{
+			name: "provisioningNetwork_disabled_dhcp_range",
+			platform: platform().
+				ProvisioningNetwork(baremetal.DisabledProvisioningNetwork).
+				ProvisioningDHCPRange("172.22.0.10,172.22.0.50").build(),
+			expected: "baremetal.provisioningDHCPRange: Forbidden: DHCP is not served when the provisioning network is disabled",
+		},
 		{
 			name:     "provisioningNetwork_unmanaged_valid",
 			platform: platform().ProvisioningNetwork(baremetal.UnmanagedProvisioningNetwork).build(),
@@ -468,6 +488,23 @@ func TestValidateProvisioning(t *testing.T) {
 				Hosts(host1().BMCPassword("")).build(),
 			expected: "baremetal.hosts\\[0\\].BMC.Password: Required value: missing Password",
 		},
+		{
+			name: "bmc_credentials_name",
+			platform: platform().
+				Hosts(host1().BMCUsername("").BMCPassword("").BMCCredentialsName("host1-bmc-credentials")).build(),
+		},
+		{
+			name: "bmc_credentials_name_with_username",
+			platform: platform().
+				Hosts(host1().BMCPassword("").BMCCredentialsName("host1-bmc-credentials")).build(),
+			expected: "baremetal.hosts\\[0\\].BMC.credentialsName: Forbidden: credentialsName cannot be set together with username and password",
+		},
+		{
+			name: "bmc_credentials_name_invalid",
+			platform: platform().
+				Hosts(host1().BMCUsername("").BMCPassword("").BMCCredentialsName("Host1_Credentials")).build(),
+			expected: "baremetal.hosts\\[0\\].BMC.credentialsName: Invalid value: \"Host1_Credentials\": a lowercase RFC 1123 subdomain",
+		},
 		{
 			name: "valid_with_os_image_overrides",
 			platform: platform().
@@ -864,6 +901,11 @@ func (hb *hostBuilder) BMCPassword(value string) *hostBuilder {
 	return hb
 }
 
+func (hb *hostBuilder) BMCCredentialsName(value string) *hostBuilder {
+	hb.Host.BMC.CredentialsName = value
+	return hb
+}
+
 func (hb *hostBuilder) Role(value string) *hostBuilder {
 	hb.Host.Role = value
 	return hb
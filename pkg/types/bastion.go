@@ -0,0 +1,13 @@
+package types
+
+// Bastion configures an SSH bastion host provisioned in the cluster network
+// of a private cluster. The bastion has a public address and lets the
+// installer reach the bootstrap and control plane hosts, e.g. to gather
+// their logs when the installation fails. It is kept until the cluster is
+// destroyed.
+type Bastion struct {
+	// InstanceType is the instance type of the bastion host. It defaults to
+	// a small instance type of the platform.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+}
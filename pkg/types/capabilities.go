@@ -0,0 +1,93 @@
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// CapabilityDependencies maps an optional capability to the capabilities it
+// requires in order to function. Enabling a capability implicitly enables its
+// dependencies.
+var CapabilityDependencies = map[configv1.ClusterVersionCapability][]configv1.ClusterVersionCapability{
+	configv1.ClusterVersionCapabilityBaremetal:   {configv1.ClusterVersionCapabilityMachineAPI},
+	configv1.ClusterVersionCapabilityMarketplace: {configv1.ClusterVersionCapabilityOperatorLifecycleManager},
+	configv1.ClusterVersionCapabilityConsole:     {configv1.ClusterVersionCapabilityIngress},
+}
+
+// KnownCapabilities returns every capability that is part of at least one
+// capability set.
+func KnownCapabilities() sets.Set[configv1.ClusterVersionCapability] {
+	known := sets.New[configv1.ClusterVersionCapability]()
+	for _, capabilities := range configv1.ClusterVersionCapabilitySets {
+		known.Insert(capabilities...)
+	}
+	return known
+}
+
+// RequiredCapabilities returns the capabilities that are transitively
+// required by the given capability, not including the capability itself.
+func RequiredCapabilities(capability configv1.ClusterVersionCapability) sets.Set[configv1.ClusterVersionCapability] {
+	required := sets.New[configv1.ClusterVersionCapability]()
+	pending := append([]configv1.ClusterVersionCapability{}, CapabilityDependencies[capability]...)
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+		if next == capability || required.Has(next) {
+			continue
+		}
+		required.Insert(next)
+		pending = append(pending, CapabilityDependencies[next]...)
+	}
+	return required
+}
+
+// EnabledCapabilities returns the set of capabilities enabled by the
+// install-config. The capabilities of the baseline set and the additional
+// enabled capabilities are extended with their dependencies, then the
+// disabled capabilities are removed.
+func (c *InstallConfig) EnabledCapabilities() sets.Set[configv1.ClusterVersionCapability] {
+	capSet := configv1.ClusterVersionCapabilitySetCurrent
+	if c.Capabilities != nil && c.Capabilities.BaselineCapabilitySet != "" {
+		capSet = c.Capabilities.BaselineCapabilitySet
+	}
+	enabled := sets.New[configv1.ClusterVersionCapability](configv1.ClusterVersionCapabilitySets[capSet]...)
+	if c.Capabilities == nil {
+		return enabled
+	}
+
+	enabled.Insert(c.Capabilities.AdditionalEnabledCapabilities...)
+	for _, capability := range enabled.UnsortedList() {
+		enabled = enabled.Union(RequiredCapabilities(capability))
+	}
+	return enabled.Delete(c.Capabilities.DisabledCapabilities...)
+}
+
+// ResolvedCapabilities returns the capabilities in the form accepted by the
+// cluster-version operator, which has no notion of disabled capabilities or
+// of dependencies between capabilities. When capabilities are disabled the
+// baseline capability set is replaced by None and every enabled capability
+// is listed explicitly.
+func (c *InstallConfig) ResolvedCapabilities() *Capabilities {
+	if c.Capabilities == nil {
+		return nil
+	}
+
+	enabled := c.EnabledCapabilities()
+	resolved := &Capabilities{
+		BaselineCapabilitySet: c.Capabilities.BaselineCapabilitySet,
+	}
+	if len(c.Capabilities.DisabledCapabilities) > 0 {
+		resolved.BaselineCapabilitySet = configv1.ClusterVersionCapabilitySetNone
+	} else {
+		capSet := resolved.BaselineCapabilitySet
+		if capSet == "" {
+			capSet = configv1.ClusterVersionCapabilitySetCurrent
+		}
+		enabled = enabled.Delete(configv1.ClusterVersionCapabilitySets[capSet]...)
+	}
+	if enabled.Len() > 0 {
+		resolved.AdditionalEnabledCapabilities = sets.List(enabled)
+	}
+	return resolved
+}
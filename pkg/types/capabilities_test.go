@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func TestResolvedCapabilities(t *testing.T) {
+	cases := []struct {
+		name         string
+		capabilities *Capabilities
+		expected     *Capabilities
+	}{
+		{
+			name: "no capabilities",
+		},
+		{
+			name:         "baseline only",
+			capabilities: &Capabilities{BaselineCapabilitySet: configv1.ClusterVersionCapabilitySetCurrent},
+			expected:     &Capabilities{BaselineCapabilitySet: configv1.ClusterVersionCapabilitySetCurrent},
+		},
+		{
+			name: "dependencies are enabled",
+			capabilities: &Capabilities{
+				BaselineCapabilitySet:         configv1.ClusterVersionCapabilitySetNone,
+				AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{configv1.ClusterVersionCapabilityConsole, configv1.ClusterVersionCapabilityBaremetal},
+			},
+			expected: &Capabilities{
+				BaselineCapabilitySet: configv1.ClusterVersionCapabilitySetNone,
+				AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{
+					configv1.ClusterVersionCapabilityConsole,
+					configv1.ClusterVersionCapabilityIngress,
+					configv1.ClusterVersionCapabilityMachineAPI,
+					configv1.ClusterVersionCapabilityBaremetal,
+				},
+			},
+		},
+		{
+			name: "disabled capabilities are listed explicitly",
+			capabilities: &Capabilities{
+				BaselineCapabilitySet: configv1.ClusterVersionCapabilitySet4_11,
+				DisabledCapabilities:  []configv1.ClusterVersionCapability{configv1.ClusterVersionCapabilityBaremetal, configv1.ClusterVersionCapabilityOpenShiftSamples},
+			},
+			expected: &Capabilities{
+				BaselineCapabilitySet: configv1.ClusterVersionCapabilitySetNone,
+				AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{
+					configv1.ClusterVersionCapabilityMachineAPI,
+					configv1.ClusterVersionCapabilityOperatorLifecycleManager,
+					configv1.ClusterVersionCapabilityMarketplace,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := &InstallConfig{Capabilities: tc.capabilities}
+			assert.Equal(t, tc.expected, ic.ResolvedCapabilities())
+		})
+	}
+}
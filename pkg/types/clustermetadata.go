@@ -15,9 +15,16 @@ import (
 	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
+// ClusterMetadataVersion is the version of the metadata.json schema written
+// by this installer. Metadata without a version was written before the
+// schema was versioned, and is read as version v1.
+const ClusterMetadataVersion = "v2"
+
 // ClusterMetadata contains information
 // regarding the cluster that was created by installer.
 type ClusterMetadata struct {
+	// Version is the version of the metadata schema.
+	Version string `json:"version,omitempty"`
 	// ClusterName is the name for the cluster.
 	ClusterName string `json:"clusterName"`
 	// ClusterID is a globally unique ID that is used to identify an Openshift cluster.
@@ -27,6 +34,35 @@ type ClusterMetadata struct {
 	ClusterPlatformMetadata `json:",inline"`
 	FeatureSet              configv1.FeatureSet          `json:"featureSet"`
 	CustomFeatureSet        *configv1.CustomFeatureGates `json:"customFeatureSet"`
+	// Resources identifies the resources created for the cluster. It is
+	// recorded once the infrastructure has been provisioned.
+	Resources *ClusterResources `json:"resources,omitempty"`
+	// SSHPrivateKeyPath is the path, relative to the asset directory, of the
+	// private SSH key the installer generated for the core user.
+	SSHPrivateKeyPath string `json:"sshPrivateKeyPath,omitempty"`
+	// Tags are the user tags, or GCP user labels, of the cluster resources.
+	// Destroying a cluster tagged as production requires a confirmation.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// ClusterResources identifies the platform resources created, or used, by
+// the installer for the cluster.
+type ClusterResources struct {
+	// NetworkID is the ID of the AWS VPC, or the name of the Azure virtual
+	// network or GCP network.
+	NetworkID string `json:"networkID,omitempty"`
+	// SubnetIDs are the IDs of the AWS subnets, or the names of the Azure
+	// or GCP subnets.
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+	// ResourceGroup is the name of the Azure resource group.
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// PrivateDNSZoneID is the ID of the private DNS zone of the cluster.
+	PrivateDNSZoneID string `json:"privateDNSZoneID,omitempty"`
+	// PublicDNSZoneID is the ID of the public DNS zone of the base domain.
+	PublicDNSZoneID string `json:"publicDNSZoneID,omitempty"`
+	// BastionAddress is the public address of the SSH bastion host of a
+	// private cluster, through which the installer reaches its hosts.
+	BastionAddress string `json:"bastionAddress,omitempty"`
 }
 
 // ClusterPlatformMetadata contains metadata for platfrom.
@@ -82,6 +82,17 @@ func SetInstallConfigDefaults(c *types.InstallConfig) {
 		SetMachinePoolDefaults(&c.Compute[i], c.Platform.Name())
 	}
 
+	for i := range c.BootImages {
+		if c.BootImages[i].Architecture == "" {
+			c.BootImages[i].Architecture = c.ControlPlane.Architecture
+		}
+	}
+	for i := range c.ReleaseImages {
+		if c.ReleaseImages[i].Architecture == "" {
+			c.ReleaseImages[i].Architecture = c.ControlPlane.Architecture
+		}
+	}
+
 	if c.CredentialsMode == "" {
 		if c.Platform.Azure != nil && c.Platform.Azure.CloudName == azure.StackCloud {
 			c.CredentialsMode = types.ManualCredentialsMode
@@ -233,6 +233,17 @@ func TestSetInstallConfigDefaults(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "boot images present",
+			config: &types.InstallConfig{
+				BootImages: []types.BootImage{{Image: "ami-0123456789abcdef0"}},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultInstallConfig()
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "ami-0123456789abcdef0"}}
+				return c
+			}(),
+		},
 		{
 			name: "Edge Compute present",
 			config: &types.InstallConfig{
@@ -2,6 +2,8 @@ package defaults
 
 import (
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/version"
 )
@@ -24,6 +26,32 @@ func SetMachinePoolDefaults(p *types.MachinePool, platform string) {
 	if p.Architecture == "" {
 		p.Architecture = version.DefaultArch()
 	}
+	if p.DiskEncryption != nil && p.DiskEncryption.Threshold == 0 {
+		p.DiskEncryption.Threshold = 1
+	}
+	if p.EtcdDisk != nil {
+		setEtcdDiskDefaults(p.EtcdDisk, platform)
+	}
+}
+
+// setEtcdDiskDefaults sets the defaults of the etcd disk on the platforms
+// where the installer attaches it to the control plane machines.
+func setEtcdDiskDefaults(d *types.EtcdDisk, platform string) {
+	var device string
+	switch platform {
+	case azure.Name:
+		device = azure.EtcdDiskDevice
+	case gcp.Name:
+		device = gcp.EtcdDiskDevice
+	default:
+		return
+	}
+	if d.Device == "" {
+		d.Device = device
+	}
+	if d.DiskSizeGB == 0 {
+		d.DiskSizeGB = types.DefaultEtcdDiskSizeGB
+	}
 }
 
 // hasEdgePoolConfig checks if the Edge compute pool has been defined on install-config.
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/types/gcp"
+)
+
+// ClusterDNS is the configuration of the in-cluster DNS resolvers.
+type ClusterDNS struct {
+	// DNSType is the DNS solution of the cluster. With PlatformDefault, the
+	// default, the installer creates the records of the cluster in the
+	// zones of the cloud. With ClusterHosted, the zones are left to the
+	// user, the cluster resolves its own API and ingress names with an
+	// in-cluster CoreDNS, for accounts without the rights to delegate DNS.
+	// It is only supported on GCP.
+	// +kubebuilder:validation:Enum="";PlatformDefault;ClusterHosted
+	// +optional
+	DNSType configv1.DNSType `json:"dnsType,omitempty"`
+
+	// Forwarders forward the queries for some domains to dedicated name
+	// servers, e.g. the internal zones of a split-horizon corporate DNS.
+	// +optional
+	Forwarders []DNSForwarder `json:"forwarders,omitempty"`
+
+	// UpstreamResolvers replace the name servers of the nodes as the
+	// resolvers of the queries no forwarder handles.
+	// +optional
+	UpstreamResolvers *DNSUpstreamResolvers `json:"upstreamResolvers,omitempty"`
+}
+
+// DNSForwarder forwards the queries for some domains to name servers.
+type DNSForwarder struct {
+	// Name is the name of the forwarder.
+	Name string `json:"name"`
+
+	// Zones are the domains the queries are forwarded for.
+	Zones []string `json:"zones"`
+
+	// Upstreams are the name servers, as an IP address with an optional
+	// port, e.g. "10.0.0.53" or "10.0.0.53:5353".
+	Upstreams []string `json:"upstreams"`
+
+	// Policy is the order in which the upstreams are queried. The default
+	// is Random.
+	// +optional
+	Policy operatorv1.ForwardingPolicy `json:"policy,omitempty"`
+}
+
+// DNSUpstreamResolvers are the resolvers of the queries no forwarder handles.
+type DNSUpstreamResolvers struct {
+	// Upstreams are the name servers, as an IP address with an optional
+	// port, e.g. "10.0.0.53" or "10.0.0.53:5353".
+	Upstreams []string `json:"upstreams"`
+
+	// Policy is the order in which the upstreams are queried. The default
+	// is Sequential.
+	// +optional
+	Policy operatorv1.ForwardingPolicy `json:"policy,omitempty"`
+}
+
+// ClusterHostedDNS returns whether the cluster resolves its own names instead
+// of relying on the DNS zones of the cloud.
+func (c *InstallConfig) ClusterHostedDNS() bool {
+	if c.ClusterDNS != nil && c.ClusterDNS.DNSType == configv1.ClusterHostedDNSType {
+		return true
+	}
+	return c.GCP != nil && c.GCP.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled
+}
+
+// SplitDNSUpstream splits an upstream name server into its IP address and
+// its port, which is 0 when the upstream sets none.
+func SplitDNSUpstream(upstream string) (string, uint32, error) {
+	if ip := net.ParseIP(upstream); ip != nil {
+		return upstream, 0, nil
+	}
+	host, portString, err := net.SplitHostPort(upstream)
+	if err != nil {
+		return "", 0, err
+	}
+	if net.ParseIP(host) == nil {
+		return "", 0, fmt.Errorf("%q is not an IP address", host)
+	}
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, fmt.Errorf("%q is not a valid port", portString)
+	}
+	return host, uint32(port), nil
+}
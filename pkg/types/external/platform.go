@@ -27,4 +27,13 @@ type Platform struct {
 	// +kubebuilder:validation:Enum="";External
 	// +optional
 	CloudControllerManager CloudControllerManager `json:"cloudControllerManager,omitempty"`
+
+	// CloudControllerManagerCredentials are the credentials the external
+	// cloud controller manager authenticates to the cloud with. They are
+	// stored, with the same keys, in the cloud-controller-manager-credentials
+	// secret of the openshift-cloud-controller-manager namespace, for the
+	// cloud controller manager deployed with the manifests of the provider.
+	// They require cloudControllerManager to be External.
+	// +optional
+	CloudControllerManagerCredentials map[string]string `json:"cloudControllerManagerCredentials,omitempty"`
 }
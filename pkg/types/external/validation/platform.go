@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/external"
+)
+
+// ValidatePlatform checks that the specified platform is valid.
+func ValidatePlatform(p *external.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(p.CloudControllerManagerCredentials) > 0 {
+		credsPath := fldPath.Child("cloudControllerManagerCredentials")
+		if p.CloudControllerManager != external.CloudControllerManagerTypeExternal {
+			allErrs = append(allErrs, field.Forbidden(credsPath, "credentials are only supported with an External cloud controller manager"))
+		}
+		for _, key := range sets.List(sets.KeySet(p.CloudControllerManagerCredentials)) {
+			if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(credsPath.Key(key), key, strings.Join(errs, ", ")))
+			}
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types/external"
+)
+
+func TestValidatePlatform(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform *external.Platform
+		valid    bool
+	}{
+		{
+			name:     "minimal",
+			platform: &external.Platform{},
+			valid:    true,
+		},
+		{
+			name: "credentials of an external cloud controller manager",
+			platform: &external.Platform{
+				PlatformName:                      "partner",
+				CloudControllerManager:            external.CloudControllerManagerTypeExternal,
+				CloudControllerManagerCredentials: map[string]string{"api-token": "token", "cloud.conf": "[Global]"},
+			},
+			valid: true,
+		},
+		{
+			name: "credentials without a cloud controller manager",
+			platform: &external.Platform{
+				CloudControllerManagerCredentials: map[string]string{"api-token": "token"},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid credentials key",
+			platform: &external.Platform{
+				CloudControllerManager:            external.CloudControllerManagerTypeExternal,
+				CloudControllerManagerCredentials: map[string]string{"api token": "token"},
+			},
+			valid: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePlatform(tc.platform, field.NewPath("test-path")).ToAggregate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
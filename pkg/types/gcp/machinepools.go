@@ -1,5 +1,31 @@
 package gcp
 
+// FeatureSwitch indicates whether a machine feature is enabled or disabled.
+type FeatureSwitch string
+
+const (
+	// EnabledFeature indicates that the feature is enabled.
+	EnabledFeature FeatureSwitch = "Enabled"
+
+	// DisabledFeature indicates that the feature is disabled.
+	DisabledFeature FeatureSwitch = "Disabled"
+)
+
+// OnHostMaintenanceType indicates the behavior of an instance during a host maintenance event.
+type OnHostMaintenanceType string
+
+const (
+	// OnHostMaintenanceMigrate live migrates the instance during a host maintenance event.
+	OnHostMaintenanceMigrate OnHostMaintenanceType = "Migrate"
+
+	// OnHostMaintenanceTerminate stops the instance during a host maintenance event.
+	OnHostMaintenanceTerminate OnHostMaintenanceType = "Terminate"
+)
+
+// ConfidentialComputeMachineFamilies are the machine families which support confidential compute.
+// https://cloud.google.com/confidential-computing/confidential-vm/docs/supported-configurations#machine-type-cpu-zone
+var ConfidentialComputeMachineFamilies = []string{"c2d", "c3d", "n2d"}
+
 // MachinePool stores the configuration for a machine pool installed on GCP.
 type MachinePool struct {
 	// Zones is list of availability zones that can be used.
@@ -49,10 +75,27 @@ type MachinePool struct {
 	// +optional
 	ConfidentialCompute string `json:"confidentialCompute,omitempty"`
 
-	// ServiceAccount is the email of a gcp service account to be used for shared
-	// vpc installations. The provided service account will be attached to control-plane nodes
-	// in order to provide the permissions required by the cloud provider in the host project.
-	// This field is only supported in the control-plane machinepool.
+	// VirtualizedTrustedPlatformModule Defines whether the instance should have a virtualized trusted platform module (vTPM)
+	// enabled. The vTPM enables Measured Boot, which validates the boot integrity of the instance.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is false.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	VirtualizedTrustedPlatformModule string `json:"virtualizedTrustedPlatformModule,omitempty"`
+
+	// IntegrityMonitoring Defines whether the instance should have integrity monitoring enabled.
+	// Integrity monitoring compares the boot measurements of the instance against a baseline and requires the vTPM.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is false.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	IntegrityMonitoring string `json:"integrityMonitoring,omitempty"`
+
+	// ServiceAccount is the email of a pre-created gcp service account to attach to the
+	// machines in the pool.
+	// For the control-plane machinepool it is only supported in shared vpc installations, where it
+	// provides the permissions required by the cloud provider in the host project.
+	// For compute machinepools the service account must hold the roles required by the nodes, and
+	// the installer will not create a service account for the pool. This is required in
+	// organizations where the installer is not permitted to create service accounts.
 	//
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
@@ -139,6 +182,14 @@ func (a *MachinePool) Set(required *MachinePool) {
 		a.ConfidentialCompute = required.ConfidentialCompute
 	}
 
+	if required.VirtualizedTrustedPlatformModule != "" {
+		a.VirtualizedTrustedPlatformModule = required.VirtualizedTrustedPlatformModule
+	}
+
+	if required.IntegrityMonitoring != "" {
+		a.IntegrityMonitoring = required.IntegrityMonitoring
+	}
+
 	if required.ServiceAccount != "" {
 		a.ServiceAccount = required.ServiceAccount
 	}
@@ -219,3 +270,7 @@ func (k *KMSKeyReference) Set(required *KMSKeyReference) {
 		k.Location = required.Location
 	}
 }
+
+// EtcdDiskDevice is the device of the dedicated etcd disk attached to the
+// control plane instances, as the first disk after the boot disk.
+const EtcdDiskDevice string = "/dev/disk/by-id/google-persistent-disk-1"
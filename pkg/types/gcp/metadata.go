@@ -6,4 +6,8 @@ type Metadata struct {
 	ProjectID         string `json:"projectID"`
 	NetworkProjectID  string `json:"networkProjectID,omitempty"`
 	PrivateZoneDomain string `json:"privateZoneDomain,omitempty"`
+
+	// ServiceEndpoints list contains custom endpoints which will override
+	// the default endpoints of the GCP services.
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
 }
@@ -71,6 +71,129 @@ type Platform struct {
 	// +default="Disabled"
 	// +kubebuilder:validation:Enum="Enabled";"Disabled"
 	UserProvisionedDNS UserProvisionedDNS `json:"userProvisionedDNS,omitempty"`
+
+	// ServiceEndpoints list contains custom endpoints which will override the
+	// default endpoints of the GCP services, such as the endpoints of Private
+	// Service Connect for Google APIs.
+	// There must be only one ServiceEndpoint for a service.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
+	// GoogleAPIAccess configures how the cluster reaches the Google APIs when
+	// its network has no egress to the Internet. The installer creates a
+	// private DNS zone for googleapis.com in the network, resolving the
+	// Google APIs to the private addresses of the chosen access.
+	// It requires an existing network.
+	// +optional
+	GoogleAPIAccess *GoogleAPIAccess `json:"googleAPIAccess,omitempty"`
+}
+
+// GoogleAPIAccessType is a way to reach the Google APIs from a network
+// without egress to the Internet.
+// +kubebuilder:validation:Enum=PrivateGoogleAccess;RestrictedGoogleAccess;PrivateServiceConnect
+type GoogleAPIAccessType string
+
+const (
+	// PrivateGoogleAccess reaches the Google APIs through the
+	// private.googleapis.com addresses.
+	// see https://cloud.google.com/vpc/docs/configure-private-google-access
+	PrivateGoogleAccess GoogleAPIAccessType = "PrivateGoogleAccess"
+
+	// RestrictedGoogleAccess reaches the Google APIs supported by VPC
+	// Service Controls through the restricted.googleapis.com addresses.
+	// see https://cloud.google.com/vpc-service-controls/docs/set-up-private-connectivity
+	RestrictedGoogleAccess GoogleAPIAccessType = "RestrictedGoogleAccess"
+
+	// PrivateServiceConnect reaches the Google APIs through a Private
+	// Service Connect endpoint of the network.
+	// see https://cloud.google.com/vpc/docs/configure-private-service-connect-apis
+	PrivateServiceConnect GoogleAPIAccessType = "PrivateServiceConnect"
+)
+
+// GoogleAPIAccess stores how the cluster reaches the Google APIs.
+type GoogleAPIAccess struct {
+	// Type is the way the cluster reaches the Google APIs.
+	Type GoogleAPIAccessType `json:"type"`
+
+	// PrivateServiceConnectEndpoint is the IPv4 address of the Private
+	// Service Connect endpoint for Google APIs in the network.
+	// It is required when the type is PrivateServiceConnect.
+	// +optional
+	PrivateServiceConnectEndpoint string `json:"privateServiceConnectEndpoint,omitempty"`
+}
+
+// Addresses returns the addresses that the Google APIs resolve to in the
+// network of the cluster.
+func (a *GoogleAPIAccess) Addresses() []string {
+	switch a.Type {
+	case PrivateGoogleAccess:
+		return []string{"199.36.153.8", "199.36.153.9", "199.36.153.10", "199.36.153.11"}
+	case RestrictedGoogleAccess:
+		return []string{"199.36.153.4", "199.36.153.5", "199.36.153.6", "199.36.153.7"}
+	case PrivateServiceConnect:
+		return []string{a.PrivateServiceConnectEndpoint}
+	}
+	return nil
+}
+
+// AddressRange returns the CIDR of the addresses of the Google APIs that
+// the network must route to the default Internet gateway, or an empty string
+// when they are addresses of the network itself.
+func (a *GoogleAPIAccess) AddressRange() string {
+	switch a.Type {
+	case PrivateGoogleAccess:
+		return "199.36.153.8/30"
+	case RestrictedGoogleAccess:
+		return "199.36.153.4/30"
+	}
+	return ""
+}
+
+// ServiceEndpointName is the name of a GCP service whose endpoint can be
+// overridden.
+// +kubebuilder:validation:Enum=compute;dns;iam;cloudresourcemanager;serviceusage;storage
+type ServiceEndpointName string
+
+const (
+	// ComputeServiceEndpoint is the Compute Engine API.
+	ComputeServiceEndpoint ServiceEndpointName = "compute"
+	// DNSServiceEndpoint is the Cloud DNS API.
+	DNSServiceEndpoint ServiceEndpointName = "dns"
+	// IAMServiceEndpoint is the Identity and Access Management API.
+	IAMServiceEndpoint ServiceEndpointName = "iam"
+	// CloudResourceManagerServiceEndpoint is the Cloud Resource Manager API.
+	CloudResourceManagerServiceEndpoint ServiceEndpointName = "cloudresourcemanager"
+	// ServiceUsageServiceEndpoint is the Service Usage API.
+	ServiceUsageServiceEndpoint ServiceEndpointName = "serviceusage"
+	// StorageServiceEndpoint is the Cloud Storage API.
+	StorageServiceEndpoint ServiceEndpointName = "storage"
+)
+
+// ServiceEndpoint stores the configuration for services to
+// override existing defaults of GCP Services.
+type ServiceEndpoint struct {
+	// Name is the name of the GCP service.
+	// This must be provided and cannot be empty.
+	Name ServiceEndpointName `json:"name"`
+
+	// URL is fully qualified URI with scheme https, that overrides the default
+	// endpoint of the service, including the path of its API version when the
+	// client library expects one (e.g. https://compute-example.p.googleapis.com/compute/v1/).
+	// This must be provided and cannot be empty.
+	//
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+}
+
+// ServiceEndpointURL returns the URL overriding the endpoint of the service,
+// or an empty string when the endpoint is not overridden.
+func (p *Platform) ServiceEndpointURL(name ServiceEndpointName) string {
+	for _, e := range p.ServiceEndpoints {
+		if e.Name == name {
+			return e.URL
+		}
+	}
+	return ""
 }
 
 // UserLabel is a label to apply to GCP resources created for the cluster.
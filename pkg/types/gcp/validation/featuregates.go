@@ -3,6 +3,7 @@ package validation
 import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	configv1 "github.com/openshift/api/config/v1"
 	features "github.com/openshift/api/features"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/featuregates"
@@ -29,5 +30,10 @@ func GatedFeatures(c *types.InstallConfig) []featuregates.GatedInstallConfigFeat
 			Condition:       g.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled,
 			Field:           field.NewPath("platform", "gcp", "userProvisionedDNS"),
 		},
+		{
+			FeatureGateName: features.FeatureGateGCPClusterHostedDNS,
+			Condition:       c.ClusterDNS != nil && c.ClusterDNS.DNSType == configv1.ClusterHostedDNSType,
+			Field:           field.NewPath("clusterDNS", "dnsType"),
+		},
 	}
 }
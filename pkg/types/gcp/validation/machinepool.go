@@ -47,18 +47,36 @@ func ValidateMachinePool(platform *gcp.Platform, p *gcp.MachinePool, fldPath *fi
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("tags").Index(i), tag, fmt.Sprintf("maximum number of characters is 63")))
 		}
 	}
+
+	featureSwitches := []string{string(gcp.EnabledFeature), string(gcp.DisabledFeature)}
+	for _, feature := range []struct {
+		name  string
+		value string
+	}{
+		{name: "secureBoot", value: p.SecureBoot},
+		{name: "confidentialCompute", value: p.ConfidentialCompute},
+		{name: "virtualizedTrustedPlatformModule", value: p.VirtualizedTrustedPlatformModule},
+		{name: "integrityMonitoring", value: p.IntegrityMonitoring},
+	} {
+		if feature.value != "" && !sets.NewString(featureSwitches...).Has(feature.value) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child(feature.name), feature.value, featureSwitches))
+		}
+	}
+	if p.OnHostMaintenance != "" {
+		maintenanceTypes := []string{string(gcp.OnHostMaintenanceMigrate), string(gcp.OnHostMaintenanceTerminate)}
+		if !sets.NewString(maintenanceTypes...).Has(p.OnHostMaintenance) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("onHostMaintenance"), p.OnHostMaintenance, maintenanceTypes))
+		}
+	}
 	return allErrs
 }
 
-// ValidateServiceAccount checks that the service account is only supplied for control plane nodes and during
-// a shared vpn installation.
+// ValidateServiceAccount checks that a service account is only supplied for control plane nodes during
+// a shared vpc installation. Compute pools may always run as a pre-created service account.
 func ValidateServiceAccount(platform *gcp.Platform, p *types.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if p.Platform.GCP.ServiceAccount != "" {
-		if p.Name != "master" {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccount"), p.Platform.GCP.ServiceAccount, fmt.Sprintf("service accounts only valid for master nodes, provided for %s nodes", p.Name)))
-		}
+	if p.Platform.GCP.ServiceAccount != "" && p.Name == "master" {
 		if platform.NetworkProjectID == "" {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccount"), p.Platform.GCP.ServiceAccount, "service accounts only valid for xpn installs"))
 		}
@@ -85,6 +85,30 @@ func TestValidateMachinePool(t *testing.T) {
 			},
 			expected: `^test-path\.diskSizeGB: Invalid value: 66000: exceeding maximum GCP disk size limit, must be below 65536$`,
 		},
+		{
+			name: "valid shielded and confidential vm",
+			pool: &gcp.MachinePool{
+				SecureBoot:                       "Enabled",
+				VirtualizedTrustedPlatformModule: "Enabled",
+				IntegrityMonitoring:              "Enabled",
+				ConfidentialCompute:              "Enabled",
+				OnHostMaintenance:                "Terminate",
+			},
+		},
+		{
+			name: "invalid vtpm",
+			pool: &gcp.MachinePool{
+				VirtualizedTrustedPlatformModule: "On",
+			},
+			expected: `^test-path\.virtualizedTrustedPlatformModule: Unsupported value: "On": supported values: "Enabled", "Disabled"$`,
+		},
+		{
+			name: "invalid on host maintenance",
+			pool: &gcp.MachinePool{
+				OnHostMaintenance: "Restart",
+			},
+			expected: `^test-path\.onHostMaintenance: Unsupported value: "Restart": supported values: "Migrate", "Terminate"$`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
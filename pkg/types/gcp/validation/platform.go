@@ -2,6 +2,8 @@ package validation
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"sort"
 
@@ -119,9 +121,116 @@ func ValidatePlatform(p *gcp.Platform, fldPath *field.Path, ic *types.InstallCon
 	// check if configured userLabels are valid.
 	allErrs = append(allErrs, validateUserLabels(p.UserLabels, fldPath.Child("userLabels"))...)
 
+	allErrs = append(allErrs, validateServiceEndpoints(p.ServiceEndpoints, fldPath.Child("serviceEndpoints"))...)
+
+	if p.GoogleAPIAccess != nil {
+		allErrs = append(allErrs, validateGoogleAPIAccess(p, fldPath)...)
+	}
+
+	return allErrs
+}
+
+var (
+	validGoogleAPIAccessTypes = map[gcp.GoogleAPIAccessType]bool{
+		gcp.PrivateGoogleAccess:    true,
+		gcp.RestrictedGoogleAccess: true,
+		gcp.PrivateServiceConnect:  true,
+	}
+
+	validGoogleAPIAccessTypeValues = func() []string {
+		v := make([]string, 0, len(validGoogleAPIAccessTypes))
+		for t := range validGoogleAPIAccessTypes {
+			v = append(v, string(t))
+		}
+		sort.Strings(v)
+		return v
+	}()
+)
+
+func validateGoogleAPIAccess(p *gcp.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.Network == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("network"), "must provide a VPC network when configuring the access to the Google APIs"))
+	}
+
+	access := p.GoogleAPIAccess
+	fldPath = fldPath.Child("googleAPIAccess")
+	if !validGoogleAPIAccessTypes[access.Type] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), access.Type, validGoogleAPIAccessTypeValues))
+	}
+
+	endpointPath := fldPath.Child("privateServiceConnectEndpoint")
+	switch {
+	case access.Type == gcp.PrivateServiceConnect && access.PrivateServiceConnectEndpoint == "":
+		allErrs = append(allErrs, field.Required(endpointPath, fmt.Sprintf("must provide the endpoint address when the type is %s", gcp.PrivateServiceConnect)))
+	case access.Type == gcp.PrivateServiceConnect:
+		if ip := net.ParseIP(access.PrivateServiceConnectEndpoint); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(endpointPath, access.PrivateServiceConnectEndpoint, "must be an IPv4 address"))
+		}
+	case access.PrivateServiceConnectEndpoint != "":
+		allErrs = append(allErrs, field.Forbidden(endpointPath, fmt.Sprintf("only allowed when the type is %s", gcp.PrivateServiceConnect)))
+	}
 	return allErrs
 }
 
+var (
+	validServiceEndpointNames = map[gcp.ServiceEndpointName]bool{
+		gcp.ComputeServiceEndpoint:              true,
+		gcp.DNSServiceEndpoint:                  true,
+		gcp.IAMServiceEndpoint:                  true,
+		gcp.CloudResourceManagerServiceEndpoint: true,
+		gcp.ServiceUsageServiceEndpoint:         true,
+		gcp.StorageServiceEndpoint:              true,
+	}
+
+	validServiceEndpointNameValues = func() []string {
+		v := make([]string, 0, len(validServiceEndpointNames))
+		for n := range validServiceEndpointNames {
+			v = append(v, string(n))
+		}
+		sort.Strings(v)
+		return v
+	}()
+)
+
+func validateServiceEndpoints(endpoints []gcp.ServiceEndpoint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	tracker := map[gcp.ServiceEndpointName]int{}
+	for idx, e := range endpoints {
+		fldp := fldPath.Index(idx)
+		if !validServiceEndpointNames[e.Name] {
+			allErrs = append(allErrs, field.NotSupported(fldp.Child("name"), e.Name, validServiceEndpointNameValues))
+		}
+		if eidx, ok := tracker[e.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(fldp.Child("name"), e.Name, fmt.Sprintf("duplicate service endpoint not allowed for %s, service endpoint already defined at %s", e.Name, fldPath.Index(eidx))))
+		} else {
+			tracker[e.Name] = idx
+		}
+
+		if err := validateServiceURL(e.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldp.Child("url"), e.URL, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateServiceURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if s := u.Scheme; s != "https" {
+		return fmt.Errorf("invalid scheme %q, only https allowed", s)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("host cannot be empty, empty host provided")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("no request parameters must be provided")
+	}
+	return nil
+}
+
 // validateUserLabels verifies if configured number of UserLabels is not more than
 // allowed limit and the label keys and values are valid.
 func validateUserLabels(labels []gcp.UserLabel, fldPath *field.Path) field.ErrorList {
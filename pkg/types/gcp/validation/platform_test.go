@@ -153,6 +153,125 @@ func TestValidatePlatform(t *testing.T) {
 			credentialsMode: types.MintCredentialsMode,
 			valid:           false,
 		},
+		{
+			name: "valid service endpoints",
+			platform: &gcp.Platform{
+				Region: "us-east1",
+				ServiceEndpoints: []gcp.ServiceEndpoint{{
+					Name: gcp.ComputeServiceEndpoint,
+					URL:  "https://compute-test.p.googleapis.com/compute/v1/",
+				}, {
+					Name: gcp.StorageServiceEndpoint,
+					URL:  "https://storage-test.p.googleapis.com",
+				}},
+			},
+			valid: true,
+		},
+		{
+			name: "unsupported service endpoint",
+			platform: &gcp.Platform{
+				Region: "us-east1",
+				ServiceEndpoints: []gcp.ServiceEndpoint{{
+					Name: "bigquery",
+					URL:  "https://bigquery-test.p.googleapis.com",
+				}},
+			},
+			valid: false,
+		},
+		{
+			name: "duplicate service endpoints",
+			platform: &gcp.Platform{
+				Region: "us-east1",
+				ServiceEndpoints: []gcp.ServiceEndpoint{{
+					Name: gcp.DNSServiceEndpoint,
+					URL:  "https://dns-test.p.googleapis.com",
+				}, {
+					Name: gcp.DNSServiceEndpoint,
+					URL:  "https://dns-other.p.googleapis.com",
+				}},
+			},
+			valid: false,
+		},
+		{
+			name: "http service endpoint",
+			platform: &gcp.Platform{
+				Region: "us-east1",
+				ServiceEndpoints: []gcp.ServiceEndpoint{{
+					Name: gcp.IAMServiceEndpoint,
+					URL:  "http://iam-test.p.googleapis.com",
+				}},
+			},
+			valid: false,
+		},
+		{
+			name: "valid restricted google access",
+			platform: &gcp.Platform{
+				Region:             "us-east1",
+				Network:            "valid-vpc",
+				ComputeSubnet:      "valid-compute-subnet",
+				ControlPlaneSubnet: "valid-cp-subnet",
+				GoogleAPIAccess:    &gcp.GoogleAPIAccess{Type: gcp.RestrictedGoogleAccess},
+			},
+			valid: true,
+		},
+		{
+			name: "valid private service connect",
+			platform: &gcp.Platform{
+				Region:             "us-east1",
+				Network:            "valid-vpc",
+				ComputeSubnet:      "valid-compute-subnet",
+				ControlPlaneSubnet: "valid-cp-subnet",
+				GoogleAPIAccess: &gcp.GoogleAPIAccess{
+					Type:                          gcp.PrivateServiceConnect,
+					PrivateServiceConnectEndpoint: "10.100.0.2",
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "google api access without network",
+			platform: &gcp.Platform{
+				Region:          "us-east1",
+				GoogleAPIAccess: &gcp.GoogleAPIAccess{Type: gcp.PrivateGoogleAccess},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid google api access type",
+			platform: &gcp.Platform{
+				Region:             "us-east1",
+				Network:            "valid-vpc",
+				ComputeSubnet:      "valid-compute-subnet",
+				ControlPlaneSubnet: "valid-cp-subnet",
+				GoogleAPIAccess:    &gcp.GoogleAPIAccess{Type: "Public"},
+			},
+			valid: false,
+		},
+		{
+			name: "private service connect without endpoint",
+			platform: &gcp.Platform{
+				Region:             "us-east1",
+				Network:            "valid-vpc",
+				ComputeSubnet:      "valid-compute-subnet",
+				ControlPlaneSubnet: "valid-cp-subnet",
+				GoogleAPIAccess:    &gcp.GoogleAPIAccess{Type: gcp.PrivateServiceConnect},
+			},
+			valid: false,
+		},
+		{
+			name: "private service connect endpoint with private google access",
+			platform: &gcp.Platform{
+				Region:             "us-east1",
+				Network:            "valid-vpc",
+				ComputeSubnet:      "valid-compute-subnet",
+				ControlPlaneSubnet: "valid-cp-subnet",
+				GoogleAPIAccess: &gcp.GoogleAPIAccess{
+					Type:                          gcp.PrivateGoogleAccess,
+					PrivateServiceConnectEndpoint: "10.100.0.2",
+				},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
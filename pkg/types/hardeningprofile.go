@@ -0,0 +1,17 @@
+package types
+
+// HardeningProfile is a curated set of security hardening settings applied
+// to the cluster at install time.
+// +kubebuilder:validation:Enum="";None;CIS
+type HardeningProfile string
+
+const (
+	// HardeningProfileNone applies no hardening beyond the OpenShift defaults.
+	HardeningProfileNone HardeningProfile = "None"
+
+	// HardeningProfileCIS applies the settings aligned with the CIS Red Hat
+	// OpenShift Container Platform benchmark: the WriteRequestBodies audit
+	// profile, kubelet protections, network policies for the openshift-*
+	// namespaces without workloads, and no self-provisioning of projects.
+	HardeningProfileCIS HardeningProfile = "CIS"
+)
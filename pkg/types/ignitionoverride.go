@@ -0,0 +1,77 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
+	"github.com/openshift/installer/pkg/types/openstack"
+	"github.com/openshift/installer/pkg/types/powervs"
+)
+
+// IgnitionOverrideFormat is the format of an ignition override.
+// +kubebuilder:validation:Enum="";Ignition;Butane
+type IgnitionOverrideFormat string
+
+const (
+	// IgnitionOverrideFormatIgnition is an ignition config.
+	IgnitionOverrideFormatIgnition IgnitionOverrideFormat = "Ignition"
+
+	// IgnitionOverrideFormatButane is a butane config, translated to an
+	// ignition config with the butane tool.
+	IgnitionOverrideFormatButane IgnitionOverrideFormat = "Butane"
+)
+
+// IgnitionOverride is an ignition snippet merged into the pointer ignition
+// config of the machines of a role. It is applied by ignition on the first
+// boot of the machines only, and is not managed by the machine config
+// operator afterwards; use MachineConfig manifests for the configuration
+// that must be kept.
+type IgnitionOverride struct {
+	// Role is the role of the machines the snippet is applied to, master or
+	// worker.
+	Role string `json:"role"`
+
+	// Format is the format of the snippet. Defaults to Ignition.
+	// +optional
+	Format IgnitionOverrideFormat `json:"format,omitempty"`
+
+	// Config is the snippet.
+	Config string `json:"config"`
+}
+
+// userDataLimits are the sizes of the user data of the machines accepted by
+// the platforms, in bytes, before they are base64-encoded when the platform
+// requires it.
+var userDataLimits = map[string]int{
+	aws.Name:       16 * 1024,
+	azure.Name:     48 * 1024,
+	gcp.Name:       256 * 1024,
+	ibmcloud.Name:  64 * 1024,
+	openstack.Name: 48 * 1024,
+	powervs.Name:   63 * 1024,
+}
+
+// MaxUserDataSize returns the size of the user data of the machines accepted
+// by the platform, or 0 when the platform has no known limit.
+func MaxUserDataSize(platform string) int {
+	return userDataLimits[platform]
+}
+
+// CompressedDataURL returns the data gzipped and base64-encoded in a data URL,
+// as the ignition overrides are merged into the pointer ignition configs.
+func CompressedDataURL(data []byte) (string, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	return "data:;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,108 @@
+package types
+
+// ImageRegistry configures the integrated image registry, rendered as the
+// cluster configuration of the image registry operator.
+type ImageRegistry struct {
+	// Storage is the storage backend of the registry. Exactly one backend
+	// must be set.
+	Storage ImageRegistryStorage `json:"storage"`
+}
+
+// ImageRegistryStorage is the storage backend of the image registry.
+type ImageRegistryStorage struct {
+	// S3 stores the images in an S3 bucket. It is only supported on the AWS
+	// platform.
+	// +optional
+	S3 *ImageRegistryStorageS3 `json:"s3,omitempty"`
+
+	// Azure stores the images in an Azure blob storage container. It is only
+	// supported on the Azure platform.
+	// +optional
+	Azure *ImageRegistryStorageAzure `json:"azure,omitempty"`
+
+	// GCS stores the images in a Google Cloud Storage bucket. It is only
+	// supported on the GCP platform.
+	// +optional
+	GCS *ImageRegistryStorageGCS `json:"gcs,omitempty"`
+
+	// PVC stores the images in a persistent volume.
+	// +optional
+	PVC *ImageRegistryStoragePVC `json:"pvc,omitempty"`
+
+	// EmptyDir stores the images on the node running the registry, where
+	// they are lost when the registry pod is restarted. It is only supported
+	// on single-node clusters.
+	// +optional
+	EmptyDir *ImageRegistryStorageEmptyDir `json:"emptyDir,omitempty"`
+}
+
+// ImageRegistryStorageS3 is an S3 bucket storing the images of the registry.
+type ImageRegistryStorageS3 struct {
+	// Bucket is the name of the bucket. It is created by the registry
+	// operator if it does not exist.
+	Bucket string `json:"bucket"`
+
+	// Region is the region of the bucket. It defaults to the region of the
+	// cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// KMSKeyID is the ID of the KMS key encrypting the bucket, instead of
+	// the AWS managed key.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
+// ImageRegistryStorageAzure is an Azure blob storage container storing the
+// images of the registry.
+type ImageRegistryStorageAzure struct {
+	// AccountName is the name of the storage account.
+	AccountName string `json:"accountName"`
+
+	// Container is the name of the blob storage container.
+	Container string `json:"container"`
+}
+
+// ImageRegistryStorageGCS is a Google Cloud Storage bucket storing the
+// images of the registry.
+type ImageRegistryStorageGCS struct {
+	// Bucket is the name of the bucket. It is created by the registry
+	// operator if it does not exist.
+	Bucket string `json:"bucket"`
+
+	// Region is the region of the bucket. It defaults to the region of the
+	// cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// ProjectID is the project of the bucket. It defaults to the project of
+	// the cluster.
+	// +optional
+	ProjectID string `json:"projectID,omitempty"`
+}
+
+// ImageRegistryStoragePVC is a persistent volume claim storing the images of
+// the registry.
+type ImageRegistryStoragePVC struct {
+	// StorageClassName is the storage class of the claim. It defaults to the
+	// default storage class of the cluster.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Size is the size of the claim, e.g. 100Gi.
+	// +kubebuilder:default="100Gi"
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// AccessMode is the access mode of the claim, ReadWriteMany or
+	// ReadWriteOnce. A ReadWriteOnce volume limits the registry to a single
+	// replica, which is recreated on updates.
+	// +kubebuilder:validation:Enum="";ReadWriteMany;ReadWriteOnce
+	// +kubebuilder:default=ReadWriteMany
+	// +optional
+	AccessMode string `json:"accessMode,omitempty"`
+}
+
+// ImageRegistryStorageEmptyDir is the ephemeral storage of the images of the
+// registry.
+type ImageRegistryStorageEmptyDir struct{}
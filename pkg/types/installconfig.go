@@ -109,10 +109,38 @@ type InstallConfig struct {
 	// "Always" : always adds AdditionalTrustBundle.
 	AdditionalTrustBundlePolicy PolicyType `json:"additionalTrustBundlePolicy,omitempty"`
 
+	// AdditionalTrustedRegistryCAs maps image registry hostnames, with an
+	// optional port, e.g. mirror.example.com:5000, to the PEM-encoded CA
+	// bundle trusted only when pulling from that registry. They are set as the
+	// additionalTrustedCA of the cluster image config, so that each mirror of
+	// a disconnected site keeps its own CA rather than one global bundle.
+	// +optional
+	AdditionalTrustedRegistryCAs map[string]string `json:"additionalTrustedRegistryCAs,omitempty"`
+
 	// SSHKey is the public Secure Shell (SSH) key to provide access to instances.
 	// +optional
 	SSHKey string `json:"sshKey,omitempty"`
 
+	// SSHKeys is a list of additional public Secure Shell (SSH) keys that
+	// are authorized for the core user, in addition to SSHKey. It is not
+	// supported on Power VS.
+	// +optional
+	SSHKeys []string `json:"sshKeys,omitempty"`
+
+	// GenerateSSHKey makes the installer generate an ed25519 key pair into
+	// the asset directory when neither SSHKey nor SSHKeys is set. The
+	// generated public key is authorized for the core user and the private
+	// key is used by `gather bootstrap`. It is not supported on Power VS and
+	// by the agent-based installer.
+	// +optional
+	GenerateSSHKey bool `json:"generateSSHKey,omitempty"`
+
+	// AdditionalNTPServers is a list of NTP servers (hostname or IP) that
+	// the cluster nodes synchronize their clocks with, in addition to the
+	// default sources of the operating system.
+	// +optional
+	AdditionalNTPServers []string `json:"additionalNTPServers,omitempty"`
+
 	// BaseDomain is the base domain to which the cluster should belong.
 	BaseDomain string `json:"baseDomain"`
 
@@ -219,6 +247,141 @@ type InstallConfig struct {
 	// E.g. "featureGates": ["FeatureGate1=true", "FeatureGate2=false"].
 	// +optional
 	FeatureGates []string `json:"featureGates,omitempty"`
+
+	// Endpoints overrides the default hostnames and port used to reach the
+	// cluster API and ingress.
+	// +optional
+	Endpoints *ClusterEndpoints `json:"endpoints,omitempty"`
+
+	// ServingCertificates supplies the certificates served by the cluster
+	// API and the default ingress controller, instead of the ones signed by
+	// the cluster.
+	// +optional
+	ServingCertificates *ServingCertificates `json:"servingCertificates,omitempty"`
+
+	// Audit configures the audit logging of the OpenShift API servers from
+	// the start, so that it is not changed, and the control plane
+	// restarted, after the installation.
+	// +optional
+	Audit *Audit `json:"audit,omitempty"`
+
+	// EtcdEncryption encrypts the sensitive resources, e.g. secrets, that the
+	// API servers store in etcd. It is rendered in the APIServer config, so
+	// that the resources are encrypted from the installation instead of once
+	// encryption is turned on afterwards.
+	// +optional
+	EtcdEncryption *EtcdEncryption `json:"etcdEncryption,omitempty"`
+
+	// OAuth configures the identity providers of the cluster OAuth server,
+	// so that users can log in as soon as the installation completes.
+	// +optional
+	OAuth *OAuth `json:"oauth,omitempty"`
+
+	// ClusterDNS configures the forwarding of the in-cluster DNS, so that
+	// split-horizon DNS resolves from the start of the installation.
+	// +optional
+	ClusterDNS *ClusterDNS `json:"clusterDNS,omitempty"`
+
+	// PerformanceProfile tunes the nodes for low latency workloads, e.g.
+	// single-node DUs, from the first boot instead of after an extra
+	// reboot. It is only supported on the baremetal and none platforms.
+	// +optional
+	PerformanceProfile *PerformanceProfile `json:"performanceProfile,omitempty"`
+
+	// HardeningProfile renders a curated set of hardening manifests, e.g.
+	// aligned with the CIS benchmark, so that the cluster is compliant from
+	// the start. The audit profile it sets is overridden by audit.profile.
+	// +optional
+	HardeningProfile HardeningProfile `json:"hardeningProfile,omitempty"`
+
+	// ImageRegistry configures the storage of the integrated image registry,
+	// which otherwise defaults to the storage of the platform, or is not
+	// deployed on the platforms without one.
+	// +optional
+	ImageRegistry *ImageRegistry `json:"imageRegistry,omitempty"`
+
+	// BootImages overrides, per architecture, the RHCOS boot image selected
+	// from the stream metadata embedded in the installer. This allows
+	// installing with custom or pre-copied boot images, e.g. in regions where
+	// no RHCOS image is published.
+	// The overrides take precedence over the platform-specific image fields.
+	// +optional
+	BootImages []BootImage `json:"bootImages,omitempty"`
+
+	// ReleaseImages overrides, per architecture, the release payload pinned
+	// in the installer, replacing OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE.
+	// The payload for the control plane architecture is installed. The
+	// overrides must be pinned by digest.
+	// +optional
+	ReleaseImages []ReleaseImage `json:"releaseImages,omitempty"`
+
+	// ReleaseImageVerification requires the signatures of the release image
+	// overrides to be verified before installing. By default the overrides
+	// are only pinned by digest.
+	// +optional
+	ReleaseImageVerification *ReleaseImageVerification `json:"releaseImageVerification,omitempty"`
+
+	// BootstrapIgnition configures how the bootstrap ignition is delivered
+	// to the bootstrap machine. By default it is staged in the object
+	// storage of the platform and fetched from a signed URL.
+	// +optional
+	BootstrapIgnition *BootstrapIgnition `json:"bootstrapIgnition,omitempty"`
+
+	// IgnitionOverrides are ignition snippets merged, gzipped, into the
+	// pointer ignition configs of the machines of their role. The pointer
+	// ignition configs must fit in the user data of the machines of the
+	// platform.
+	// +optional
+	IgnitionOverrides []IgnitionOverride `json:"ignitionOverrides,omitempty"`
+
+	// Bastion provisions an SSH bastion host with a public address in the
+	// network of a private cluster. It is supported on AWS, Azure and GCP.
+	// +optional
+	Bastion *Bastion `json:"bastion,omitempty"`
+}
+
+// BootImage returns the boot image override for the given architecture, or
+// an empty string when the stream metadata should be used.
+func (c *InstallConfig) BootImage(architecture Architecture) string {
+	for _, b := range c.BootImages {
+		if b.Architecture == architecture {
+			return b.Image
+		}
+	}
+	return ""
+}
+
+// BootstrapIgnitionDelivery returns how the bootstrap ignition is delivered
+// to the bootstrap machine.
+func (c *InstallConfig) BootstrapIgnitionDelivery() BootstrapIgnitionDelivery {
+	if c.BootstrapIgnition == nil || c.BootstrapIgnition.Delivery == "" {
+		return BootstrapIgnitionDeliveryObjectStorage
+	}
+	return c.BootstrapIgnition.Delivery
+}
+
+// ReleaseImage returns the release image override for the given
+// architecture, or an empty string when the pinned release image should be
+// used.
+func (c *InstallConfig) ReleaseImage(architecture Architecture) string {
+	for _, r := range c.ReleaseImages {
+		if r.Architecture == architecture {
+			return r.PullSpec
+		}
+	}
+	return ""
+}
+
+// AuthorizedSSHKeys returns the public SSH keys configured in the
+// install-config, starting with SSHKey and followed by SSHKeys.
+func (c *InstallConfig) AuthorizedSSHKeys() []string {
+	var keys []string
+	for _, key := range append([]string{c.SSHKey}, c.SSHKeys...) {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // ClusterDomain returns the DNS domain that all records for a cluster must belong to.
@@ -226,6 +389,66 @@ func (c *InstallConfig) ClusterDomain() string {
 	return fmt.Sprintf("%s.%s", c.ObjectMeta.Name, strings.TrimSuffix(c.BaseDomain, "."))
 }
 
+// APIHostname returns the hostname of the external cluster API.
+func (c *InstallConfig) APIHostname() string {
+	if c.Endpoints != nil && c.Endpoints.APIHostname != "" {
+		return c.Endpoints.APIHostname
+	}
+	return fmt.Sprintf("api.%s", c.ClusterDomain())
+}
+
+// APIInternalHostname returns the hostname of the internal cluster API.
+func (c *InstallConfig) APIInternalHostname() string {
+	if c.Endpoints != nil && c.Endpoints.APIInternalHostname != "" {
+		return c.Endpoints.APIInternalHostname
+	}
+	return fmt.Sprintf("api-int.%s", c.ClusterDomain())
+}
+
+// APIPort returns the port on which the cluster API is reached through its
+// hostnames.
+func (c *InstallConfig) APIPort() int32 {
+	if c.Endpoints != nil && c.Endpoints.APIPort != 0 {
+		return c.Endpoints.APIPort
+	}
+	return DefaultAPIPort
+}
+
+// IngressDomain returns the domain under which the default ingress
+// controller serves routes.
+func (c *InstallConfig) IngressDomain() string {
+	if c.Endpoints != nil && c.Endpoints.IngressDomain != "" {
+		return c.Endpoints.IngressDomain
+	}
+	return fmt.Sprintf("apps.%s", c.ClusterDomain())
+}
+
+// PublicAPI returns true if the cluster API is exposed to the Internet,
+// either because the cluster is published externally, or because the mixed
+// publishing strategy publishes the API server externally.
+func (c *InstallConfig) PublicAPI() bool {
+	switch c.Publish {
+	case InternalPublishingStrategy:
+		return false
+	case MixedPublishingStrategy:
+		return c.OperatorPublishingStrategy == nil || c.OperatorPublishingStrategy.APIServer != "Internal"
+	}
+	return true
+}
+
+// PublicIngress returns true if the default ingress controller is exposed to
+// the Internet, either because the cluster is published externally, or
+// because the mixed publishing strategy publishes the ingress externally.
+func (c *InstallConfig) PublicIngress() bool {
+	switch c.Publish {
+	case InternalPublishingStrategy:
+		return false
+	case MixedPublishingStrategy:
+		return c.OperatorPublishingStrategy == nil || c.OperatorPublishingStrategy.Ingress != "Internal"
+	}
+	return true
+}
+
 // IsFCOS returns true if Fedora CoreOS-only modifications are enabled
 func (c *InstallConfig) IsFCOS() bool {
 	return FCOS
@@ -408,6 +631,22 @@ type Networking struct {
 	// +optional
 	ClusterNetworkMTU uint32 `json:"clusterNetworkMTU,omitempty"`
 
+	// OVNKubernetesConfig customizes the OVN-Kubernetes network plugin.
+	// It is only valid with the OVNKubernetes network type.
+	// +optional
+	OVNKubernetesConfig *OVNKubernetesConfig `json:"ovnKubernetesConfig,omitempty"`
+
+	// AdditionalNetworks are the secondary networks of the pods, rendered
+	// as NetworkAttachmentDefinitions so that they are defined as soon as
+	// the cluster comes up.
+	// +optional
+	AdditionalNetworks []AdditionalNetwork `json:"additionalNetworks,omitempty"`
+
+	// EgressIPs are the initial egress IPs of the cluster. They are only
+	// valid with the OVNKubernetes network type.
+	// +optional
+	EgressIPs []EgressIP `json:"egressIPs,omitempty"`
+
 	// Deprecated types, scheduled to be removed
 
 	// Deprecated way to configure an IP address pool for machines.
@@ -526,6 +765,205 @@ type Capabilities struct {
 	// baselineCapabilitySet. The default is an empty set.
 	// +optional
 	AdditionalEnabledCapabilities []configv1.ClusterVersionCapability `json:"additionalEnabledCapabilities,omitempty"`
+
+	// disabledCapabilities removes capabilities from the set enabled by
+	// baselineCapabilitySet and additionalEnabledCapabilities. Capabilities
+	// required by an enabled capability are enabled automatically, so a
+	// capability may only be disabled when no enabled capability requires it.
+	// The default is an empty set.
+	// +optional
+	DisabledCapabilities []configv1.ClusterVersionCapability `json:"disabledCapabilities,omitempty"`
+}
+
+// DefaultAPIPort is the port on which the cluster API is served.
+const DefaultAPIPort int32 = 6443
+
+// ClusterEndpoints overrides the default names of the cluster endpoints.
+type ClusterEndpoints struct {
+	// APIHostname is the hostname of the external cluster API.
+	// The default is api.<cluster domain>.
+	// +optional
+	APIHostname string `json:"apiHostname,omitempty"`
+
+	// APIInternalHostname is the hostname of the internal cluster API.
+	// The default is api-int.<cluster domain>.
+	// +optional
+	APIInternalHostname string `json:"apiInternalHostname,omitempty"`
+
+	// APIPort is the port on which the load balancer in front of the
+	// control plane serves the cluster API. The default is 6443.
+	// On the on-prem platforms it may only be changed when the load
+	// balancer is user managed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	APIPort int32 `json:"apiPort,omitempty"`
+
+	// IngressDomain is the domain under which the default ingress
+	// controller serves routes. The default is apps.<cluster domain>.
+	// +optional
+	IngressDomain string `json:"ingressDomain,omitempty"`
+}
+
+// ServingCertificates are user-provided serving certificates for the cluster
+// endpoints.
+type ServingCertificates struct {
+	// APIServer is the certificate served by the API server for the external
+	// API hostname.
+	// +optional
+	APIServer *CertificateKeyPair `json:"apiServer,omitempty"`
+
+	// Ingress is the default certificate of the default ingress controller.
+	// It must be valid for the wildcard of the ingress domain.
+	// +optional
+	Ingress *CertificateKeyPair `json:"ingress,omitempty"`
+}
+
+// Audit is the audit configuration of the OpenShift API servers.
+type Audit struct {
+	// Profile is the audit profile applied to all the requests that no
+	// custom rule matches. One of Default, WriteRequestBodies,
+	// AllRequestBodies and None. The default is Default.
+	// +kubebuilder:validation:Enum="";Default;WriteRequestBodies;AllRequestBodies;None
+	// +optional
+	Profile configv1.AuditProfileType `json:"profile,omitempty"`
+
+	// CustomRules are audit profiles for the requests of users in the given
+	// groups. They take precedence over Profile, and the first matching
+	// rule applies.
+	// +optional
+	CustomRules []configv1.AuditCustomRule `json:"customRules,omitempty"`
+}
+
+// EtcdEncryptionType is the type of encryption of the resources in etcd.
+// +kubebuilder:validation:Enum=aescbc;aesgcm;KMS
+type EtcdEncryptionType string
+
+const (
+	// EtcdEncryptionTypeAESCBC encrypts with AES-CBC and keys generated and
+	// rotated by the API server operators.
+	EtcdEncryptionTypeAESCBC EtcdEncryptionType = "aescbc"
+
+	// EtcdEncryptionTypeAESGCM encrypts with AES-GCM and keys generated and
+	// rotated by the API server operators.
+	EtcdEncryptionTypeAESGCM EtcdEncryptionType = "aesgcm"
+
+	// EtcdEncryptionTypeKMS encrypts with keys wrapped by a key of the key
+	// management service of the cloud.
+	EtcdEncryptionTypeKMS EtcdEncryptionType = "KMS"
+)
+
+// EtcdEncryption is the encryption at rest of the resources stored in etcd.
+type EtcdEncryption struct {
+	// Type is the type of encryption, one of aescbc, aesgcm and KMS.
+	Type EtcdEncryptionType `json:"type"`
+
+	// KMS is the key management service key wrapping the encryption keys.
+	// It is required when the type is KMS.
+	// +optional
+	KMS *EtcdEncryptionKMS `json:"kms,omitempty"`
+}
+
+// EtcdEncryptionKMS is the key management service key wrapping the etcd
+// encryption keys.
+type EtcdEncryptionKMS struct {
+	// AWS is the AWS KMS key. It is only supported on the AWS platform.
+	// +optional
+	AWS *AWSKMSKey `json:"aws,omitempty"`
+}
+
+// AWSKMSKey is an AWS KMS key.
+type AWSKMSKey struct {
+	// KeyARN is the ARN of the symmetric encryption key, in the region of
+	// the cluster. The control plane instances must be allowed to use it.
+	KeyARN string `json:"keyARN"`
+}
+
+// BootImage is the boot image used for the machines of one architecture.
+type BootImage struct {
+	// Architecture is the architecture of the machines booted from the image.
+	// Defaults to the control plane architecture.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// Image is the boot image, in the format used by the stream metadata for
+	// the platform: an AMI ID on AWS, a projects/<project>/global/images/<name>
+	// path on GCP, a <bucket>/<object> path on Power VS, and an image URL
+	// (e.g. a qcow2, VHD or OVA) on the other platforms.
+	Image string `json:"image"`
+}
+
+// ReleaseImage is the release payload installed for one architecture.
+type ReleaseImage struct {
+	// Architecture is the architecture of the control plane the payload is
+	// installed for. Defaults to the control plane architecture.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// PullSpec is the pull spec of the payload, pinned by digest, e.g.
+	// quay.io/openshift-release-dev/ocp-release@sha256:<digest>.
+	PullSpec string `json:"pullSpec"`
+}
+
+// ReleaseImageVerification is the verification of the signatures of the
+// release image overrides.
+type ReleaseImageVerification struct {
+	// PublicKeys are the ASCII-armored public keys of the signers of the
+	// release payloads.
+	PublicKeys string `json:"publicKeys"`
+
+	// SignatureStore is the URL of the store of the release payload
+	// signatures, laid out as <store>/sha256=<hash>/signature-<n>. Defaults
+	// to the store of the OpenShift release signatures.
+	// +optional
+	SignatureStore string `json:"signatureStore,omitempty"`
+}
+
+// BootstrapIgnitionDelivery is how the bootstrap ignition is delivered to the
+// bootstrap machine.
+// +kubebuilder:validation:Enum="";ObjectStorage;Inline;VPCEndpoint
+type BootstrapIgnitionDelivery string
+
+const (
+	// BootstrapIgnitionDeliveryObjectStorage stages the bootstrap ignition in
+	// the object storage of the platform, fetched from a signed URL.
+	BootstrapIgnitionDeliveryObjectStorage BootstrapIgnitionDelivery = "ObjectStorage"
+
+	// BootstrapIgnitionDeliveryInline passes the bootstrap ignition,
+	// compressed, in the instance metadata of the bootstrap machine, so that
+	// no object storage is created. It is supported on Azure and GCP, when
+	// the compressed ignition fits the instance metadata.
+	BootstrapIgnitionDeliveryInline BootstrapIgnitionDelivery = "Inline"
+
+	// BootstrapIgnitionDeliveryVPCEndpoint stages the bootstrap ignition in
+	// the object storage, which is only reachable through a VPC endpoint, so
+	// that it is never fetched from the public endpoint. It is supported on
+	// AWS.
+	BootstrapIgnitionDeliveryVPCEndpoint BootstrapIgnitionDelivery = "VPCEndpoint"
+)
+
+// BootstrapIgnition configures the delivery of the bootstrap ignition.
+type BootstrapIgnition struct {
+	// Delivery is how the bootstrap ignition is delivered to the bootstrap
+	// machine.
+	// +optional
+	Delivery BootstrapIgnitionDelivery `json:"delivery,omitempty"`
+
+	// VPCEndpointID is the ID of the S3 VPC endpoint of the cluster VPC the
+	// bootstrap ignition bucket is restricted to, with the VPCEndpoint
+	// delivery.
+	// +optional
+	VPCEndpointID string `json:"vpcEndpointID,omitempty"`
+}
+
+// CertificateKeyPair is a PEM-encoded certificate and its private key.
+type CertificateKeyPair struct {
+	// Certificate is the PEM-encoded certificate, followed by the
+	// intermediate certificates of its chain.
+	Certificate string `json:"certificate"`
+
+	// Key is the PEM-encoded private key of the certificate.
+	Key string `json:"key"`
 }
 
 // WorkerMachinePool retrieves the worker MachinePool from InstallConfig.Compute
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPlatformNamesSorted(t *testing.T) {
@@ -13,3 +14,44 @@ func TestPlatformNamesSorted(t *testing.T) {
 	sort.Strings(sorted)
 	assert.Equal(t, sorted, PlatformNames)
 }
+
+func TestClusterEndpoints(t *testing.T) {
+	ic := &InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		BaseDomain: "example.com.",
+	}
+	assert.Equal(t, "api.test-cluster.example.com", ic.APIHostname())
+	assert.Equal(t, "api-int.test-cluster.example.com", ic.APIInternalHostname())
+	assert.Equal(t, int32(6443), ic.APIPort())
+	assert.Equal(t, "apps.test-cluster.example.com", ic.IngressDomain())
+
+	ic.Endpoints = &ClusterEndpoints{
+		APIHostname:         "k8s.example.com",
+		APIInternalHostname: "k8s-internal.example.com",
+		APIPort:             443,
+		IngressDomain:       "apps.example.com",
+	}
+	assert.Equal(t, "k8s.example.com", ic.APIHostname())
+	assert.Equal(t, "k8s-internal.example.com", ic.APIInternalHostname())
+	assert.Equal(t, int32(443), ic.APIPort())
+	assert.Equal(t, "apps.example.com", ic.IngressDomain())
+}
+
+func TestPublishedEndpoints(t *testing.T) {
+	ic := &InstallConfig{Publish: ExternalPublishingStrategy}
+	assert.True(t, ic.PublicAPI())
+	assert.True(t, ic.PublicIngress())
+
+	ic.Publish = InternalPublishingStrategy
+	assert.False(t, ic.PublicAPI())
+	assert.False(t, ic.PublicIngress())
+
+	ic.Publish = MixedPublishingStrategy
+	ic.OperatorPublishingStrategy = &OperatorPublishingStrategy{APIServer: "Internal", Ingress: "External"}
+	assert.False(t, ic.PublicAPI())
+	assert.True(t, ic.PublicIngress())
+
+	ic.OperatorPublishingStrategy = &OperatorPublishingStrategy{APIServer: "External", Ingress: "Internal"}
+	assert.True(t, ic.PublicAPI())
+	assert.False(t, ic.PublicIngress())
+}
@@ -3,6 +3,22 @@ package libvirt
 // MachinePool stores the configuration for a machine pool installed
 // on libvirt.
 type MachinePool struct {
+	// AdditionalNetworks are the names of existing libvirt networks attached
+	// as additional interfaces to the domains of the pool, after the cluster
+	// network. Only supported for the control plane pool.
+	//
+	// +optional
+	AdditionalNetworks []string `json:"additionalNetworks,omitempty"`
+
+	// HostDevices are the PCI addresses of host devices, such as SR-IOV
+	// virtual functions, passed through to the domains of the pool, in the
+	// domain:bus:slot.function form (e.g. 0000:3b:02.1). The devices are
+	// distributed evenly across the domains in order, so their number must be
+	// a multiple of the number of replicas. Only supported for the control
+	// plane pool.
+	//
+	// +optional
+	HostDevices []string `json:"hostDevices,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -10,4 +26,11 @@ func (l *MachinePool) Set(required *MachinePool) {
 	if required == nil || l == nil {
 		return
 	}
+
+	if len(required.AdditionalNetworks) > 0 {
+		l.AdditionalNetworks = required.AdditionalNetworks
+	}
+	if len(required.HostDevices) > 0 {
+		l.HostDevices = required.HostDevices
+	}
 }
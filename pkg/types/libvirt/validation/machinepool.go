@@ -1,12 +1,44 @@
 package validation
 
 import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/libvirt"
 )
 
+// pciAddressRegexp matches PCI addresses in the domain:bus:slot.function form.
+var pciAddressRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-1][0-9a-fA-F]\.[0-7]$`)
+
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *libvirt.MachinePool, fldPath *field.Path) field.ErrorList {
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+
+	networks := sets.New[string]()
+	for i, network := range p.AdditionalNetworks {
+		fp := fldPath.Child("additionalNetworks").Index(i)
+		switch {
+		case network == "":
+			allErrs = append(allErrs, field.Required(fp, "network name is required"))
+		case networks.Has(network):
+			allErrs = append(allErrs, field.Duplicate(fp, network))
+		}
+		networks.Insert(network)
+	}
+
+	devices := sets.New[string]()
+	for i, device := range p.HostDevices {
+		fp := fldPath.Child("hostDevices").Index(i)
+		switch {
+		case !pciAddressRegexp.MatchString(device):
+			allErrs = append(allErrs, field.Invalid(fp, device, "must be a PCI address in the domain:bus:slot.function form"))
+		case devices.Has(device):
+			allErrs = append(allErrs, field.Duplicate(fp, device))
+		}
+		devices.Insert(device)
+	}
+
+	return allErrs
 }
@@ -20,6 +20,48 @@ func TestValidateMachinePool(t *testing.T) {
 			pool:  &libvirt.MachinePool{},
 			valid: true,
 		},
+		{
+			name: "additional networks",
+			pool: &libvirt.MachinePool{
+				AdditionalNetworks: []string{"sriov", "storage"},
+			},
+			valid: true,
+		},
+		{
+			name: "empty additional network",
+			pool: &libvirt.MachinePool{
+				AdditionalNetworks: []string{""},
+			},
+			valid: false,
+		},
+		{
+			name: "duplicate additional network",
+			pool: &libvirt.MachinePool{
+				AdditionalNetworks: []string{"sriov", "sriov"},
+			},
+			valid: false,
+		},
+		{
+			name: "host devices",
+			pool: &libvirt.MachinePool{
+				HostDevices: []string{"0000:3b:02.0", "0000:3b:1f.7"},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid host device",
+			pool: &libvirt.MachinePool{
+				HostDevices: []string{"3b:02.0"},
+			},
+			valid: false,
+		},
+		{
+			name: "duplicate host device",
+			pool: &libvirt.MachinePool{
+				HostDevices: []string{"0000:3b:02.0", "0000:3b:02.0"},
+			},
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -14,7 +14,14 @@ func ValidatePlatform(p *libvirt.Platform, fldPath *field.Path) field.ErrorList
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("uri"), p.URI, err.Error()))
 	}
 	if p.DefaultMachinePlatform != nil {
-		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+		fp := fldPath.Child("defaultMachinePlatform")
+		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fp)...)
+		if len(p.DefaultMachinePlatform.AdditionalNetworks) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fp.Child("additionalNetworks"), "additional networks are only supported for the control plane pool"))
+		}
+		if len(p.DefaultMachinePlatform.HostDevices) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fp.Child("hostDevices"), "host devices are only supported for the control plane pool"))
+		}
 	}
 	if p.Network != nil {
 		if p.Network.IfName == "" {
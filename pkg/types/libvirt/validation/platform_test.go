@@ -65,6 +65,17 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "default machine pool with host devices",
+			platform: func() *libvirt.Platform {
+				p := validPlatform()
+				p.DefaultMachinePlatform = &libvirt.MachinePool{
+					HostDevices: []string{"0000:3b:02.0"},
+				}
+				return p
+			}(),
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"errors"
+	"regexp"
+
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
@@ -76,6 +79,129 @@ type MachinePool struct {
 	// +kubebuilder:default=amd64
 	// +optional
 	Architecture Architecture `json:"architecture,omitempty"`
+
+	// DiskEncryption configures LUKS encryption of the root filesystem
+	// of the machines in the pool.
+	// +optional
+	DiskEncryption *DiskEncryption `json:"diskEncryption,omitempty"`
+
+	// BootDiskMirror configures mirroring (RAID1) of the boot disk of
+	// the machines in the pool across several devices.
+	// +optional
+	BootDiskMirror *BootDiskMirror `json:"bootDiskMirror,omitempty"`
+
+	// EtcdDisk places the etcd data directory on a dedicated disk, isolating
+	// the etcd IO from the rest of the system. It is only supported for the
+	// control plane pool on Azure and GCP, where the installer attaches the
+	// disk to the control plane machines.
+	// +optional
+	EtcdDisk *EtcdDisk `json:"etcdDisk,omitempty"`
+
+	// KubeletConfig tunes the kubelet of the machines in the pool. It is
+	// rendered as a KubeletConfig for the machine config pool of the
+	// machines, so the settings apply from the first boot.
+	// +optional
+	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
+
+	// HostnamePattern is the pattern of the hostnames of the machines in the
+	// pool, e.g. prod-a-worker-{index:3}. The {index} placeholder is replaced
+	// at boot with the offset of the IPv4 address of the machine in the
+	// machine networks, zero-padded to the optional width, so that the
+	// hostnames are unique and predictable. It is only supported on AWS.
+	// +optional
+	HostnamePattern string `json:"hostnamePattern,omitempty"`
+}
+
+// hostnamePatternRegexp matches the hostname patterns: a DNS label with a
+// single {index} placeholder, optionally with the width it is zero-padded to.
+var hostnamePatternRegexp = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*)?\{index(:([1-9]))?\}([a-z0-9-]*[a-z0-9])?$`)
+
+// HostnamePatternFormat returns the printf format of the hostname pattern,
+// formatting the index, and the number of characters of the pattern besides
+// the index.
+func HostnamePatternFormat(pattern string) (format string, fixedLength int, err error) {
+	m := hostnamePatternRegexp.FindStringSubmatch(pattern)
+	if m == nil {
+		return "", 0, errors.New("must consist of lower case alphanumeric characters or '-', start and end with an alphanumeric character or the placeholder, and contain a single {index} or {index:<width>} placeholder")
+	}
+	verb := "%d"
+	if m[3] != "" {
+		verb = "%0" + m[3] + "d"
+	}
+	return m[1] + verb + m[4], len(m[1]) + len(m[4]), nil
+}
+
+// DiskEncryption defines how the root filesystem is encrypted and unlocked.
+// At least one of TPM2 or Tang must be set.
+type DiskEncryption struct {
+	// TPM2 binds the LUKS key to the TPM2 device of the machine.
+	// +optional
+	TPM2 bool `json:"tpm2,omitempty"`
+
+	// Tang is the list of Tang servers used to unlock the root filesystem.
+	// +optional
+	Tang []TangServer `json:"tang,omitempty"`
+
+	// Threshold is the minimum number of key sources (the TPM2 device and
+	// each of the Tang servers) that must be available to unlock the root
+	// filesystem. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// TangServer is a Tang server used for network-bound disk encryption.
+type TangServer struct {
+	// URL is the URL of the Tang server.
+	URL string `json:"url"`
+
+	// Thumbprint is the thumbprint of the Tang server signing key.
+	Thumbprint string `json:"thumbprint"`
+}
+
+// BootDiskMirror defines the devices the boot disk is mirrored across.
+type BootDiskMirror struct {
+	// Devices is the list of block devices (e.g. /dev/sda) the boot disk is
+	// mirrored across. At least two devices are required.
+	Devices []string `json:"devices"`
+}
+
+// KubeletConfig is the kubelet tuning of the machines in a pool.
+type KubeletConfig struct {
+	// MaxPods is the maximum number of pods that can run on a machine.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPods int32 `json:"maxPods,omitempty"`
+
+	// SystemReserved are the resources reserved for the system daemons,
+	// keyed by cpu, memory and ephemeral-storage, e.g. {"cpu": "500m"}.
+	// +optional
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+
+	// TopologyManagerPolicy is the policy of the kubelet topology manager.
+	// One of none, best-effort, restricted and single-numa-node.
+	// +kubebuilder:validation:Enum="";none;best-effort;restricted;single-numa-node
+	// +optional
+	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+}
+
+// DefaultEtcdDiskSizeGB is the default size of the etcd disk attached by the
+// installer.
+const DefaultEtcdDiskSizeGB int32 = 64
+
+// EtcdDisk defines the disk holding the etcd data directory.
+type EtcdDisk struct {
+	// Device is the block device of the etcd disk, preferably a stable path
+	// such as /dev/disk/by-id/<id>. It defaults to the path the disk is
+	// attached under by the installer.
+	// +optional
+	Device string `json:"device,omitempty"`
+
+	// DiskSizeGB is the size of the disk attached to the control plane
+	// machines. Defaults to 64.
+	// +kubebuilder:validation:Minimum=16
+	// +optional
+	DiskSizeGB int32 `json:"diskSizeGB,omitempty"`
 }
 
 // MachinePoolPlatform is the platform-specific configuration for a machine
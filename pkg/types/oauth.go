@@ -0,0 +1,110 @@
+package types
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// OAuth is the initial configuration of the cluster OAuth server.
+type OAuth struct {
+	// IdentityProviders are the identity providers users authenticate with.
+	// +optional
+	IdentityProviders []IdentityProvider `json:"identityProviders,omitempty"`
+
+	// DisableKubeadmin removes the temporary kubeadmin user, so the cluster
+	// is only reachable through the identity providers and the admin
+	// kubeconfig. It requires at least one identity provider.
+	// +optional
+	DisableKubeadmin bool `json:"disableKubeadmin,omitempty"`
+}
+
+// IdentityProvider is an identity provider of the cluster OAuth server.
+// Exactly one of HTPasswd, LDAP and OpenID must be set.
+type IdentityProvider struct {
+	// Name is the name of the identity provider, used as a prefix of the
+	// user names of its identities.
+	Name string `json:"name"`
+
+	// MappingMethod determines how identities of the provider are mapped
+	// to users. The default is claim.
+	// +optional
+	MappingMethod configv1.MappingMethodType `json:"mappingMethod,omitempty"`
+
+	// HTPasswd authenticates users against an htpasswd file.
+	// +optional
+	HTPasswd *HTPasswdIdentityProvider `json:"htpasswd,omitempty"`
+
+	// LDAP authenticates users against an LDAP server.
+	// +optional
+	LDAP *LDAPIdentityProvider `json:"ldap,omitempty"`
+
+	// OpenID authenticates users against an OpenID Connect provider.
+	// +optional
+	OpenID *OpenIDIdentityProvider `json:"openID,omitempty"`
+}
+
+// HTPasswdIdentityProvider is an htpasswd identity provider.
+type HTPasswdIdentityProvider struct {
+	// FileData is the content of the htpasswd file, with bcrypt hashed
+	// passwords.
+	FileData string `json:"fileData"`
+}
+
+// LDAPIdentityProvider is an LDAP identity provider.
+type LDAPIdentityProvider struct {
+	// URL is an RFC 2255 URL specifying the LDAP host and search parameters.
+	URL string `json:"url"`
+
+	// BindDN is an optional DN to bind with during the search phase.
+	// +optional
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPassword is the password to bind with during the search phase.
+	// +optional
+	BindPassword string `json:"bindPassword,omitempty"`
+
+	// Insecure makes the connection to the LDAP server use ldap:// instead
+	// of ldaps://, without TLS.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// CA is the PEM-encoded bundle used to verify the certificate of the
+	// LDAP server.
+	// +optional
+	CA string `json:"ca,omitempty"`
+
+	// Attributes maps the LDAP attributes to identities.
+	Attributes configv1.LDAPAttributeMapping `json:"attributes"`
+}
+
+// OpenIDIdentityProvider is an OpenID Connect identity provider.
+type OpenIDIdentityProvider struct {
+	// ClientID is the OAuth client ID.
+	ClientID string `json:"clientID"`
+
+	// ClientSecret is the OAuth client secret.
+	ClientSecret string `json:"clientSecret"`
+
+	// Issuer is the URL the OpenID provider asserts as its issuer
+	// identifier. It must use the https scheme.
+	Issuer string `json:"issuer"`
+
+	// CA is the PEM-encoded bundle used to verify the certificate of the
+	// issuer.
+	// +optional
+	CA string `json:"ca,omitempty"`
+
+	// ExtraScopes are scopes requested in addition to the openid scope.
+	// +optional
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+
+	// Claims maps the claims of the ID token to identities. The default maps
+	// the preferred_username, name and email claims.
+	// +optional
+	Claims *configv1.OpenIDClaims `json:"claims,omitempty"`
+}
+
+// KubeadminDisabled returns whether the install-config removes the kubeadmin
+// user.
+func (c *InstallConfig) KubeadminDisabled() bool {
+	return c.OAuth != nil && c.OAuth.DisableKubeadmin
+}
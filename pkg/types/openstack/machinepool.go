@@ -23,6 +23,13 @@ type MachinePool struct {
 	// +optional
 	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
 
+	// AdditionalPorts contains ports that are created for each machine on
+	// pre-existing networks, after the machine network port and the ports
+	// on AdditionalNetworkIDs. They can be used to attach SR-IOV or
+	// VLAN-aware (trunk) interfaces to the machines.
+	// +optional
+	AdditionalPorts []AdditionalPort `json:"additionalPorts,omitempty"`
+
 	// ServerGroupPolicy will be used to create the Server Group that will contain all the machines of this MachinePool.
 	// Defaults to "soft-anti-affinity".
 	ServerGroupPolicy ServerGroupPolicy `json:"serverGroupPolicy,omitempty"`
@@ -70,6 +77,10 @@ func (o *MachinePool) Set(required *MachinePool) {
 		o.AdditionalSecurityGroupIDs = append(required.AdditionalSecurityGroupIDs[:0:0], required.AdditionalSecurityGroupIDs...)
 	}
 
+	if required.AdditionalPorts != nil {
+		o.AdditionalPorts = append(required.AdditionalPorts[:0:0], required.AdditionalPorts...)
+	}
+
 	if required.ServerGroupPolicy != "" {
 		o.ServerGroupPolicy = required.ServerGroupPolicy
 	}
@@ -100,6 +111,40 @@ type RootVolume struct {
 	Zones []string `json:"zones,omitempty"`
 }
 
+// AdditionalPort defines a port created for each machine on a pre-existing
+// network.
+type AdditionalPort struct {
+	// NetworkID is the ID of the network the port is created on, presented
+	// in UUID v4 format.
+	NetworkID string `json:"networkID"`
+
+	// SubnetIDs contains IDs of subnets of the network where the port gets
+	// a fixed IP, where each ID is presented in UUID v4 format.
+	// When empty, Neutron allocates addresses as configured on the network.
+	// +optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+
+	// VNICType is the type of virtual NIC bound to the port. Use "direct"
+	// or "direct-physical" for SR-IOV interfaces.
+	// Defaults to "normal".
+	// +kubebuilder:validation:Enum=normal;direct;direct-physical;macvtap;virtio-forwarder;vdpa;
+	// +optional
+	VNICType string `json:"vnicType,omitempty"`
+
+	// PortSecurity enables or disables port security on the port. When
+	// disabled, neither security groups nor allowed address pairs are
+	// applied to the port.
+	// Defaults to the value configured on the network.
+	// +optional
+	PortSecurity *bool `json:"portSecurity,omitempty"`
+
+	// Trunk creates a Neutron trunk with the port as its parent port,
+	// making the interface VLAN-aware. It requires the Neutron trunk
+	// extension.
+	// +optional
+	Trunk bool `json:"trunk,omitempty"`
+}
+
 // PortTarget defines, directly or indirectly, one or more subnets where to attach a port.
 type PortTarget struct {
 	// Network is a query for an openstack network that the port will be discovered on.
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/openstack"
@@ -13,6 +14,15 @@ var validServerGroupPolicies = []string{
 	string(openstack.SGPolicySoftAntiAffinity),
 }
 
+var validVNICTypes = []string{
+	"normal",
+	"direct",
+	"direct-physical",
+	"macvtap",
+	"virtio-forwarder",
+	"vdpa",
+}
+
 // ValidateMachinePool validates Control plane and Compute MachinePools
 func ValidateMachinePool(_ *openstack.Platform, machinePool *openstack.MachinePool, role string, fldPath *field.Path) field.ErrorList {
 	if machinePool == nil {
@@ -60,5 +70,25 @@ func ValidateMachinePool(_ *openstack.Platform, machinePool *openstack.MachinePo
 		}
 	}
 
+	for i, port := range machinePool.AdditionalPorts {
+		errs = append(errs, validateAdditionalPort(port, fldPath.Child("additionalPorts").Index(i))...)
+	}
+
+	return errs
+}
+
+func validateAdditionalPort(port openstack.AdditionalPort, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if port.NetworkID == "" {
+		errs = append(errs, field.Required(fldPath.Child("networkID"), "the network of an additional port must be specified"))
+	}
+	if port.VNICType != "" && !sets.New(validVNICTypes...).Has(port.VNICType) {
+		errs = append(errs, field.NotSupported(fldPath.Child("vnicType"), port.VNICType, validVNICTypes))
+	}
+	// A direct-physical port passes the whole physical function through to
+	// the instance, so Neutron cannot attach subports to it.
+	if port.Trunk && port.VNICType == "direct-physical" {
+		errs = append(errs, field.Invalid(fldPath.Child("trunk"), port.Trunk, "trunk cannot be enabled on a port with vnicType direct-physical"))
+	}
 	return errs
 }
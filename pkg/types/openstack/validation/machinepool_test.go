@@ -21,6 +21,10 @@ func withAvailabilityZone(zones []string) func(*openstack.MachinePool) {
 	return func(mp *openstack.MachinePool) { mp.Zones = zones }
 }
 
+func withAdditionalPorts(ports ...openstack.AdditionalPort) func(*openstack.MachinePool) {
+	return func(mp *openstack.MachinePool) { mp.AdditionalPorts = ports }
+}
+
 func testMachinePool(options ...func(*openstack.MachinePool)) *openstack.MachinePool {
 	var mp openstack.MachinePool
 	for _, apply := range options {
@@ -145,6 +149,42 @@ func TestValidateMachinePool(t *testing.T) {
 				exactlyNErrors(1),
 			),
 		},
+		{
+			"with valid additional ports",
+			testMachinePool(withAdditionalPorts(
+				openstack.AdditionalPort{NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11", VNICType: "direct"},
+				openstack.AdditionalPort{NetworkID: "6a8c3b39-21de-4b5c-a6a8-8d3e6c7f9b20", Trunk: true},
+			)),
+			"default",
+			check(noError),
+		},
+		{
+			"with additional port missing network",
+			testMachinePool(withAdditionalPorts(openstack.AdditionalPort{VNICType: "direct"})),
+			"default",
+			check(
+				someErrorType(field.ErrorTypeRequired),
+				exactlyNErrors(1),
+			),
+		},
+		{
+			"with additional port invalid vnicType",
+			testMachinePool(withAdditionalPorts(openstack.AdditionalPort{NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11", VNICType: "sriov"})),
+			"default",
+			check(
+				someErrorType(field.ErrorTypeNotSupported),
+				exactlyNErrors(1),
+			),
+		},
+		{
+			"with trunk on direct-physical additional port",
+			testMachinePool(withAdditionalPorts(openstack.AdditionalPort{NetworkID: "0dd8a3e6-4d2b-4fa0-9d6a-0a6c3f5c7c11", VNICType: "direct-physical", Trunk: true})),
+			"default",
+			check(
+				someErrorType(field.ErrorTypeInvalid),
+				exactlyNErrors(1),
+			),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			errs := ValidateMachinePool(nil, tc.machinePool, tc.role, nil)
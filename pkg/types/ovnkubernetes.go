@@ -0,0 +1,51 @@
+package types
+
+import (
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/ipnet"
+)
+
+// OVNGatewayMode is the way the egress traffic of the pods leaves the nodes
+// with the OVN-Kubernetes network plugin.
+// +kubebuilder:validation:Enum="";Local;Shared
+type OVNGatewayMode string
+
+const (
+	// OVNGatewayModeShared sends the egress traffic directly from OVN to the
+	// node interface. It is the default of the network plugin.
+	OVNGatewayModeShared OVNGatewayMode = "Shared"
+
+	// OVNGatewayModeLocal routes the egress traffic through the host network
+	// stack, so that it follows the routing table and the firewall rules of
+	// the nodes.
+	OVNGatewayModeLocal OVNGatewayMode = "Local"
+)
+
+// OVNKubernetesConfig customizes the OVN-Kubernetes network plugin at install
+// time. The options are rendered in the configuration of the cluster network
+// operator.
+type OVNKubernetesConfig struct {
+	// MTU is the MTU of the overlay network, in bytes. It must be lower than
+	// the MTU of the machine network by the overhead of the encapsulation.
+	// If unset, the network operator computes it from the MTU of the nodes.
+	// +optional
+	MTU uint32 `json:"mtu,omitempty"`
+
+	// GatewayMode is the way the egress traffic of the pods leaves the
+	// nodes: Shared, the default, or Local.
+	// +optional
+	GatewayMode OVNGatewayMode `json:"gatewayMode,omitempty"`
+
+	// V4InternalSubnet is the IPv4 subnet used internally by OVN-Kubernetes.
+	// It must not overlap any other network of the cluster, nor any network
+	// the cluster needs to reach. The default is 100.64.0.0/16.
+	// +optional
+	V4InternalSubnet *ipnet.IPNet `json:"v4InternalSubnet,omitempty"`
+
+	// IPsecMode enables the IPsec encryption of the traffic: Disabled, the
+	// default, External for the traffic leaving the cluster only, or Full
+	// for both the traffic between the pods and the external traffic.
+	// +kubebuilder:validation:Enum="";Disabled;External;Full
+	// +optional
+	IPsecMode operatorv1.IPsecMode `json:"ipsecMode,omitempty"`
+}
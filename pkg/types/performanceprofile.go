@@ -0,0 +1,48 @@
+package types
+
+// PerformanceProfile is the low latency tuning of the nodes of a telco
+// (DU) installation, rendered as a PerformanceProfile of the Node Tuning
+// Operator.
+type PerformanceProfile struct {
+	// ReservedCPUs is the set of CPUs reserved for the housekeeping of the
+	// operating system and the cluster, in the cpuset format, e.g. "0-1".
+	ReservedCPUs string `json:"reservedCPUs"`
+
+	// IsolatedCPUs is the set of CPUs isolated for the latency sensitive
+	// workloads, in the cpuset format, e.g. "2-31". It must not overlap
+	// the reserved CPUs.
+	IsolatedCPUs string `json:"isolatedCPUs"`
+
+	// HugePages are the huge pages allocated on the nodes.
+	// +optional
+	HugePages *HugePages `json:"hugePages,omitempty"`
+
+	// RealTimeKernel installs the real-time kernel on the nodes.
+	// +optional
+	RealTimeKernel bool `json:"realTimeKernel,omitempty"`
+}
+
+// HugePages are the huge pages allocated on the nodes.
+type HugePages struct {
+	// DefaultSize is the default huge page size of the kernel, one of 2M
+	// and 1G.
+	// +optional
+	DefaultSize string `json:"defaultSize,omitempty"`
+
+	// Pages are the huge pages to allocate.
+	Pages []HugePage `json:"pages"`
+}
+
+// HugePage is a number of huge pages of a size.
+type HugePage struct {
+	// Size is the size of the huge pages, one of 2M and 1G.
+	Size string `json:"size"`
+
+	// Count is the number of huge pages to allocate.
+	Count int32 `json:"count"`
+
+	// Node is the NUMA node the huge pages are allocated on. When unset,
+	// they are spread over all the NUMA nodes.
+	// +optional
+	Node *int32 `json:"node,omitempty"`
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdditionalNetwork is a secondary network of the pods, defined as a
+// NetworkAttachmentDefinition for Multus.
+type AdditionalNetwork struct {
+	// Name is the name of the NetworkAttachmentDefinition, referenced by
+	// the k8s.v1.cni.cncf.io/networks annotation of the pods.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the NetworkAttachmentDefinition. It is
+	// created if it does not exist. The default is the default namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Config is the CNI configuration of the network, as a JSON document,
+	// e.g. a macvlan or SR-IOV configuration with its IPAM.
+	Config string `json:"config"`
+}
+
+// EgressIP is a set of IP addresses used as the source address of the egress
+// traffic of some pods, with the OVN-Kubernetes network plugin. The egress
+// IPs are assigned to the nodes labeled k8s.ovn.org/egress-assignable.
+type EgressIP struct {
+	// Name is the name of the EgressIP.
+	Name string `json:"name"`
+
+	// EgressIPs are the IP addresses. They must be in a machine network.
+	EgressIPs []string `json:"egressIPs"`
+
+	// NamespaceSelector selects the namespaces of the pods.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// PodSelector selects the pods in the namespaces. All the pods of the
+	// namespaces are selected by default.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
@@ -1,21 +1,29 @@
 package validation
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	dockerref "github.com/containers/image/docker/reference"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilsnet "k8s.io/utils/net"
 
@@ -32,6 +40,7 @@ import (
 	"github.com/openshift/installer/pkg/types/baremetal"
 	baremetalvalidation "github.com/openshift/installer/pkg/types/baremetal/validation"
 	"github.com/openshift/installer/pkg/types/external"
+	externalvalidation "github.com/openshift/installer/pkg/types/external/validation"
 	"github.com/openshift/installer/pkg/types/featuregates"
 	"github.com/openshift/installer/pkg/types/gcp"
 	gcpvalidation "github.com/openshift/installer/pkg/types/gcp/validation"
@@ -39,6 +48,7 @@ import (
 	ibmcloudvalidation "github.com/openshift/installer/pkg/types/ibmcloud/validation"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtvalidation "github.com/openshift/installer/pkg/types/libvirt/validation"
+	"github.com/openshift/installer/pkg/types/none"
 	"github.com/openshift/installer/pkg/types/nutanix"
 	nutanixvalidation "github.com/openshift/installer/pkg/types/nutanix/validation"
 	"github.com/openshift/installer/pkg/types/openstack"
@@ -55,9 +65,6 @@ import (
 // hostCryptBypassedAnnotation is set if the host crypt check was bypassed via environment variable.
 const hostCryptBypassedAnnotation = "install.openshift.io/hostcrypt-check-bypassed"
 
-// list of known plugins that require hostPrefix to be set
-var pluginsUsingHostPrefix = sets.NewString(string(operv1.NetworkTypeOVNKubernetes))
-
 // ValidateInstallConfig checks that the specified install config is valid.
 //
 //nolint:gocyclo
@@ -75,12 +82,90 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 
 	if c.FIPS {
 		allErrs = append(allErrs, validateFIPSconfig(c)...)
-	} else if c.SSHKey != "" {
-		if err := validate.SSHPublicKey(c.SSHKey); err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, err.Error()))
+	} else {
+		if c.SSHKey != "" {
+			if err := validate.SSHPublicKey(c.SSHKey); err != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, err.Error()))
+			}
+		}
+		for i, key := range c.SSHKeys {
+			if err := validate.SSHPublicKey(key); err != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("sshKeys").Index(i), key, err.Error()))
+			}
+		}
+	}
+	if c.Platform.PowerVS != nil {
+		// The single sshKey is imported into the workspace of the cluster.
+		if len(c.SSHKeys) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("sshKeys"), "additional SSH keys are not supported on platform powervs"))
+		}
+		if c.GenerateSSHKey {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("generateSSHKey"), "generated SSH keys are not supported on platform powervs"))
 		}
 	}
 
+	allErrs = append(allErrs, validateAdditionalNTPServers(c.AdditionalNTPServers, field.NewPath("additionalNTPServers"))...)
+	if c.Endpoints != nil {
+		allErrs = append(allErrs, validateEndpoints(c.Endpoints, &c.Platform, field.NewPath("endpoints"))...)
+	}
+	if c.ServingCertificates != nil {
+		fldPath := field.NewPath("servingCertificates")
+		if c.ServingCertificates.APIServer != nil {
+			allErrs = append(allErrs, validateServingCertificate(c.ServingCertificates.APIServer, c.APIHostname(), fldPath.Child("apiServer"))...)
+		}
+		if c.ServingCertificates.Ingress != nil {
+			// The certificate is served for every route under the ingress
+			// domain, so it must be valid for its wildcard.
+			allErrs = append(allErrs, validateServingCertificate(c.ServingCertificates.Ingress, "*."+c.IngressDomain(), fldPath.Child("ingress"))...)
+		}
+	}
+	if c.Audit != nil {
+		allErrs = append(allErrs, validateAudit(c.Audit, field.NewPath("audit"))...)
+	}
+	if c.EtcdEncryption != nil {
+		allErrs = append(allErrs, validateEtcdEncryption(c, field.NewPath("etcdEncryption"))...)
+	}
+	if c.OAuth != nil {
+		allErrs = append(allErrs, validateOAuth(c.OAuth, field.NewPath("oauth"))...)
+	}
+	if c.ClusterDNS != nil {
+		allErrs = append(allErrs, validateClusterDNS(c.ClusterDNS, &c.Platform, field.NewPath("clusterDNS"))...)
+	}
+	if c.PerformanceProfile != nil {
+		allErrs = append(allErrs, validatePerformanceProfile(c.PerformanceProfile, &c.Platform, field.NewPath("performanceProfile"))...)
+	}
+	switch c.HardeningProfile {
+	case "", types.HardeningProfileNone, types.HardeningProfileCIS:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("hardeningProfile"), c.HardeningProfile, []string{string(types.HardeningProfileNone), string(types.HardeningProfileCIS)}))
+	}
+	if c.ImageRegistry != nil {
+		allErrs = append(allErrs, validateImageRegistry(c, field.NewPath("imageRegistry"))...)
+	}
+	if c.Bastion != nil {
+		allErrs = append(allErrs, validateBastion(c, field.NewPath("bastion"))...)
+	}
+	if len(c.BootImages) > 0 {
+		allErrs = append(allErrs, validateBootImages(c.BootImages, &c.Platform, field.NewPath("bootImages"))...)
+	}
+	if len(c.ReleaseImages) > 0 {
+		allErrs = append(allErrs, validateReleaseImages(c.ReleaseImages, field.NewPath("releaseImages"))...)
+	}
+	if c.ReleaseImageVerification != nil {
+		allErrs = append(allErrs, validateReleaseImageVerification(c, field.NewPath("releaseImageVerification"))...)
+	}
+	if c.BootstrapIgnition != nil {
+		allErrs = append(allErrs, validateBootstrapIgnition(c, field.NewPath("bootstrapIgnition"))...)
+	}
+	if c.AWS != nil && c.AWS.IsIPv6Only() && types.ClusterAPIFeatureGateEnabled(aws.Name, c.EnabledFeatureGates()) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("platform", "aws", "ipFamily"), "IPv6 is not supported with the Cluster API install"))
+	}
+	if c.ControlPlane != nil && c.ControlPlane.Platform.AWS != nil && c.ControlPlane.Platform.AWS.HostPlacement != nil && types.ClusterAPIFeatureGateEnabled(aws.Name, c.EnabledFeatureGates()) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("controlPlane", "platform", "aws", "hostPlacement"), "hostPlacement is not supported with the Cluster API install"))
+	}
+	if len(c.IgnitionOverrides) > 0 {
+		allErrs = append(allErrs, validateIgnitionOverrides(c.IgnitionOverrides, c.Platform.Name(), field.NewPath("ignitionOverrides"))...)
+	}
 	if c.AdditionalTrustBundle != "" {
 		if err := validate.CABundle(c.AdditionalTrustBundle); err != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("additionalTrustBundle"), c.AdditionalTrustBundle, err.Error()))
@@ -91,6 +176,7 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 			allErrs = append(allErrs, field.Invalid(field.NewPath("additionalTrustBundlePolicy"), c.AdditionalTrustBundlePolicy, err.Error()))
 		}
 	}
+	allErrs = append(allErrs, validateAdditionalTrustedRegistryCAs(c.AdditionalTrustedRegistryCAs, field.NewPath("additionalTrustedRegistryCAs"))...)
 	nameErr := validate.ClusterName(c.ObjectMeta.Name)
 	if c.Platform.GCP != nil || c.Platform.Azure != nil {
 		nameErr = validate.ClusterName1035(c.ObjectMeta.Name)
@@ -112,11 +198,7 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 		}
 	}
 	if c.Networking != nil {
-		allErrs = append(allErrs, validateNetworking(c.Networking, c.IsSingleNodeOpenShift(), field.NewPath("networking"))...)
-		allErrs = append(allErrs, validateNetworkingIPVersion(c.Networking, &c.Platform)...)
-		allErrs = append(allErrs, validateNetworkingForPlatform(c.Networking, &c.Platform, field.NewPath("networking"))...)
-		allErrs = append(allErrs, validateNetworkingClusterNetworkMTU(c, field.NewPath("networking", "clusterNetworkMTU"))...)
-		allErrs = append(allErrs, validateVIPsForPlatform(c.Networking, &c.Platform, field.NewPath("platform"))...)
+		allErrs = append(allErrs, validateNetworkingConfig(c)...)
 	} else {
 		allErrs = append(allErrs, field.Required(field.NewPath("networking"), "networking is required"))
 	}
@@ -162,7 +244,7 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 
 	if c.Publish == types.MixedPublishingStrategy {
 		switch platformName := c.Platform.Name(); platformName {
-		case azure.Name:
+		case aws.Name, azure.Name, gcp.Name:
 		default:
 			allErrs = append(allErrs, field.Invalid(field.NewPath("publish"), c.Publish, fmt.Sprintf("mixed publish strategy is not supported on %q platform", platformName)))
 		}
@@ -182,10 +264,10 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 			c.OperatorPublishingStrategy.Ingress = "External"
 		}
 		if !acceptedValues.Has(c.OperatorPublishingStrategy.APIServer) {
-			allErrs = append(allErrs, field.NotSupported(field.NewPath("apiserver"), c.OperatorPublishingStrategy.APIServer, sets.List(acceptedValues)))
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("operatorPublishingStrategy", "apiserver"), c.OperatorPublishingStrategy.APIServer, sets.List(acceptedValues)))
 		}
 		if !acceptedValues.Has(c.OperatorPublishingStrategy.Ingress) {
-			allErrs = append(allErrs, field.NotSupported(field.NewPath("ingress"), c.OperatorPublishingStrategy.Ingress, sets.List(acceptedValues)))
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("operatorPublishingStrategy", "ingress"), c.OperatorPublishingStrategy.Ingress, sets.List(acceptedValues)))
 		}
 		if c.OperatorPublishingStrategy.APIServer == "Internal" && c.OperatorPublishingStrategy.Ingress == "Internal" {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("publish"), c.OperatorPublishingStrategy.APIServer, "cannot set both fields to internal in a mixed cluster, use publish internal instead"))
@@ -193,24 +275,13 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 	}
 
 	if c.Capabilities != nil {
-		capSet := c.Capabilities.BaselineCapabilitySet
-		if capSet == "" {
-			capSet = configv1.ClusterVersionCapabilitySetCurrent
-		}
-		enabledCaps := sets.New[configv1.ClusterVersionCapability](configv1.ClusterVersionCapabilitySets[capSet]...)
-		enabledCaps.Insert(c.Capabilities.AdditionalEnabledCapabilities...)
+		enabledCaps := c.EnabledCapabilities()
 
-		if c.Capabilities.BaselineCapabilitySet == configv1.ClusterVersionCapabilitySetNone {
-			enabledCaps := sets.New[configv1.ClusterVersionCapability](c.Capabilities.AdditionalEnabledCapabilities...)
-			if enabledCaps.Has(configv1.ClusterVersionCapabilityBaremetal) && !enabledCaps.Has(configv1.ClusterVersionCapabilityMachineAPI) {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("additionalEnabledCapabilities"), c.Capabilities.AdditionalEnabledCapabilities,
-					"the baremetal capability requires the MachineAPI capability"))
-			}
-			if enabledCaps.Has(configv1.ClusterVersionCapabilityMarketplace) && !enabledCaps.Has(configv1.ClusterVersionCapabilityOperatorLifecycleManager) {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("additionalEnabledCapabilities"), c.Capabilities.AdditionalEnabledCapabilities,
-					"the marketplace capability requires the OperatorLifecycleManager capability"))
-			}
-			if c.Platform.BareMetal != nil && !enabledCaps.Has(configv1.ClusterVersionCapabilityBaremetal) {
+		if c.Platform.BareMetal != nil && !enabledCaps.Has(configv1.ClusterVersionCapabilityBaremetal) {
+			if slices.Contains(c.Capabilities.DisabledCapabilities, configv1.ClusterVersionCapabilityBaremetal) {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("capabilities", "disabledCapabilities"), c.Capabilities.DisabledCapabilities,
+					"platform baremetal requires the baremetal capability"))
+			} else {
 				allErrs = append(allErrs, field.Invalid(field.NewPath("additionalEnabledCapabilities"), c.Capabilities.AdditionalEnabledCapabilities,
 					"platform baremetal requires the baremetal capability"))
 			}
@@ -241,6 +312,777 @@ func ValidateInstallConfig(c *types.InstallConfig, usingAgentMethod bool) field.
 	return allErrs
 }
 
+func validateAdditionalNTPServers(servers []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.New[string]()
+	for i, server := range servers {
+		if validate.DomainName(server, true) != nil && validate.IP(server) != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), server, "NTP server is not a valid domain name nor a valid IP"))
+		}
+		if seen.Has(server) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), server))
+		}
+		seen.Insert(server)
+	}
+	return allErrs
+}
+
+// customAPIEndpointPlatforms are the platforms on which neither the load
+// balancer in front of the control plane nor the DNS records of the API are
+// provisioned by the installer, so that the API may be served under other
+// names and on another port than the default ones.
+var customAPIEndpointPlatforms = sets.New(
+	none.Name,
+	external.Name,
+	baremetal.Name,
+	nutanix.Name,
+	openstack.Name,
+	ovirt.Name,
+	vsphere.Name,
+)
+
+// heterogeneousPlatforms are the platforms on which compute pools may use a
+// different architecture than the control plane. The boot images on these
+// platforms are either referenced per architecture or provided by the release
+// payload, rather than uploaded once by the installer.
+var heterogeneousPlatforms = sets.New(
+	aws.Name,
+	gcp.Name,
+	baremetal.Name,
+	external.Name,
+	none.Name,
+)
+
+var (
+	amiIDRegexp    = regexp.MustCompile(`^ami-[0-9a-f]{8}([0-9a-f]{9})?$`)
+	gcpImageRegexp = regexp.MustCompile(`^projects/[a-z][-a-z0-9]{4,28}[a-z0-9]/global/images/[a-z]([-a-z0-9]*[a-z0-9])?$`)
+	sha256Regexp   = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+	// integrityCheckedImagePlatforms are the platforms whose stream metadata
+	// artifacts are referenced by URL, with the uncompressed sha256 of the
+	// image as a query parameter.
+	integrityCheckedImagePlatforms = sets.New(
+		baremetal.Name,
+		ibmcloud.Name,
+		libvirt.Name,
+		nutanix.Name,
+		openstack.Name,
+		ovirt.Name,
+		vsphere.Name,
+	)
+)
+
+func validateBootImages(images []types.BootImage, platform *types.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[types.Architecture]bool{}
+	for i, b := range images {
+		idxPath := fldPath.Index(i)
+		if !validArchitectures[b.Architecture] {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("architecture"), b.Architecture, validArchitectureValues))
+		} else if seen[b.Architecture] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("architecture"), b.Architecture))
+		}
+		seen[b.Architecture] = true
+
+		if b.Image == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("image"), "image is required"))
+			continue
+		}
+		if err := validateBootImage(b.Image, platform.Name()); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("image"), b.Image, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateBootImage checks that the image is in the format used by the
+// stream metadata for the platform.
+func validateBootImage(image string, platform string) error {
+	switch platform {
+	case aws.Name:
+		if !amiIDRegexp.MatchString(image) {
+			return errors.New("must be an AMI ID")
+		}
+	case gcp.Name:
+		if !gcpImageRegexp.MatchString(image) {
+			return errors.New("must be of the form projects/<project>/global/images/<name>")
+		}
+	case powervs.Name:
+		if parts := strings.SplitN(image, "/", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.New("must be of the form <bucket>/<object>")
+		}
+	case azure.Name:
+		return validate.URIWithProtocol(image, "https")
+	default:
+		if !integrityCheckedImagePlatforms.Has(platform) {
+			return fmt.Errorf("boot images cannot be overridden on platform %s", platform)
+		}
+		u, err := url.ParseRequestURI(image)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.New("must be an http or https URL")
+		}
+		if !sha256Regexp.MatchString(u.Query().Get("sha256")) {
+			return errors.New("must include the sha256 of the uncompressed image as a query parameter")
+		}
+	}
+	return nil
+}
+
+func validateReleaseImages(images []types.ReleaseImage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[types.Architecture]bool{}
+	for i, r := range images {
+		idxPath := fldPath.Index(i)
+		if !validArchitectures[r.Architecture] {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("architecture"), r.Architecture, validArchitectureValues))
+		} else if seen[r.Architecture] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("architecture"), r.Architecture))
+		}
+		seen[r.Architecture] = true
+
+		if r.PullSpec == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("pullSpec"), "pullSpec is required"))
+			continue
+		}
+		ref, err := dockerref.ParseNormalizedNamed(r.PullSpec)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("pullSpec"), r.PullSpec, err.Error()))
+			continue
+		}
+		if _, ok := ref.(dockerref.Canonical); !ok {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("pullSpec"), r.PullSpec, "must be pinned by digest"))
+		}
+	}
+	return allErrs
+}
+
+func validateReleaseImageVerification(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	v := c.ReleaseImageVerification
+	if len(c.ReleaseImages) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("releaseImages"), "release images must be set to verify their signatures"))
+	}
+	if v.PublicKeys == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("publicKeys"), "publicKeys is required"))
+	} else if !strings.Contains(v.PublicKeys, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("publicKeys"), v.PublicKeys, "must contain ASCII-armored PGP public keys"))
+	}
+	if v.SignatureStore != "" {
+		if u, err := url.ParseRequestURI(v.SignatureStore); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("signatureStore"), v.SignatureStore, "must be an http or https URL"))
+		}
+	}
+	return allErrs
+}
+
+var vpcEndpointIDRegexp = regexp.MustCompile(`^vpce-[0-9a-f]+$`)
+
+func validateBootstrapIgnition(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	b := c.BootstrapIgnition
+	platform := c.Platform.Name()
+	switch b.Delivery {
+	case "", types.BootstrapIgnitionDeliveryObjectStorage:
+	case types.BootstrapIgnitionDeliveryInline:
+		if platform != azure.Name && platform != gcp.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("delivery"), b.Delivery, "inline delivery is only supported on Azure and GCP"))
+		} else if !types.ClusterAPIFeatureGateEnabled(platform, c.EnabledFeatureGates()) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("delivery"), b.Delivery, "inline delivery requires the Cluster API install"))
+		}
+	case types.BootstrapIgnitionDeliveryVPCEndpoint:
+		if platform != aws.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("delivery"), b.Delivery, "VPC endpoint delivery is only supported on AWS"))
+		} else if len(c.AWS.Subnets) == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("delivery"), b.Delivery, "VPC endpoint delivery requires installing into existing subnets"))
+		} else if !types.ClusterAPIFeatureGateEnabled(platform, c.EnabledFeatureGates()) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("delivery"), b.Delivery, "VPC endpoint delivery requires the Cluster API install"))
+		}
+		if b.VPCEndpointID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("vpcEndpointID"), "vpcEndpointID is required with VPC endpoint delivery"))
+		} else if !vpcEndpointIDRegexp.MatchString(b.VPCEndpointID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcEndpointID"), b.VPCEndpointID, "must be a VPC endpoint ID"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("delivery"), b.Delivery, []string{string(types.BootstrapIgnitionDeliveryObjectStorage), string(types.BootstrapIgnitionDeliveryInline), string(types.BootstrapIgnitionDeliveryVPCEndpoint)}))
+	}
+	if b.Delivery != types.BootstrapIgnitionDeliveryVPCEndpoint && b.VPCEndpointID != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("vpcEndpointID"), "vpcEndpointID is only used with VPC endpoint delivery"))
+	}
+	return allErrs
+}
+
+// pointerIgnitionSize is an upper bound of the size of a pointer ignition
+// config with its root CA, before the ignition overrides are merged into it.
+const pointerIgnitionSize = 3 * 1024
+
+func validateIgnitionOverrides(overrides []types.IgnitionOverride, platform string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	sizes := map[string]int{}
+	for i, o := range overrides {
+		idxPath := fldPath.Index(i)
+		if o.Role != types.MachinePoolControlPlaneRoleName && o.Role != types.MachinePoolComputeRoleName {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("role"), o.Role, []string{types.MachinePoolControlPlaneRoleName, types.MachinePoolComputeRoleName}))
+		}
+		if o.Config == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("config"), "config is required"))
+			continue
+		}
+		switch o.Format {
+		case "", types.IgnitionOverrideFormatIgnition:
+			if err := validateIgnitionSnippet(o.Config); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("config"), o.Config, err.Error()))
+				continue
+			}
+		case types.IgnitionOverrideFormatButane:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("format"), o.Format, []string{string(types.IgnitionOverrideFormatIgnition), string(types.IgnitionOverrideFormatButane)}))
+			continue
+		}
+		source, err := types.CompressedDataURL([]byte(o.Config))
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(idxPath.Child("config"), err))
+			continue
+		}
+		sizes[o.Role] += len(source)
+	}
+
+	limit := types.MaxUserDataSize(platform)
+	if limit == 0 {
+		return allErrs
+	}
+	for _, role := range []string{types.MachinePoolControlPlaneRoleName, types.MachinePoolComputeRoleName} {
+		if size := sizes[role] + pointerIgnitionSize; sizes[role] > 0 && size > limit {
+			allErrs = append(allErrs, field.Invalid(fldPath, role, fmt.Sprintf("the %s pointer ignition config would be about %d bytes with its overrides, more than the %d bytes of user data on %s", role, size, limit, platform)))
+		}
+	}
+	return allErrs
+}
+
+// validateIgnitionSnippet checks that the snippet is an ignition config of a
+// version the pointer ignition configs can merge.
+func validateIgnitionSnippet(snippet string) error {
+	var config struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+	}
+	if err := json.Unmarshal([]byte(snippet), &config); err != nil {
+		return fmt.Errorf("must be an ignition config: %w", err)
+	}
+	if !strings.HasPrefix(config.Ignition.Version, "3.") {
+		return fmt.Errorf("must be an ignition config of spec 3, not %q", config.Ignition.Version)
+	}
+	return nil
+}
+
+func validateEndpoints(endpoints *types.ClusterEndpoints, platform *types.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	customAPIEndpointSupported := customAPIEndpointPlatforms.Has(platform.Name())
+	hostnames := []struct {
+		name  string
+		value string
+		api   bool
+	}{
+		{name: "apiHostname", value: endpoints.APIHostname, api: true},
+		{name: "apiInternalHostname", value: endpoints.APIInternalHostname, api: true},
+		{name: "ingressDomain", value: endpoints.IngressDomain},
+	}
+	for _, h := range hostnames {
+		if h.value == "" {
+			continue
+		}
+		if err := validate.DomainName(h.value, false); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(h.name), h.value, err.Error()))
+		} else if h.api && !customAPIEndpointSupported {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(h.name), h.value, fmt.Sprintf("a custom API hostname is not supported on platform %s, where the installer provisions the API DNS records", platform.Name())))
+		}
+	}
+	if d := endpoints.IngressDomain; d != "" && (d == endpoints.APIHostname || d == endpoints.APIInternalHostname) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ingressDomain"), d, "must not be the same as the API hostnames"))
+	}
+
+	if port := endpoints.APIPort; port != 0 && port != types.DefaultAPIPort {
+		switch {
+		case port < 1 || port > 65535:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiPort"), port, "must be between 1 and 65535"))
+		case port == 22623 || port == 22624:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiPort"), port, "is reserved for the machine config server"))
+		case !customAPIEndpointSupported:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiPort"), port, fmt.Sprintf("a custom API port is not supported on platform %s, where the installer provisions the API load balancer", platform.Name())))
+		case platform.Name() != none.Name && platform.Name() != external.Name && loadBalancerType(platform) != configv1.LoadBalancerTypeUserManaged:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiPort"), port, fmt.Sprintf("a custom API port requires loadBalancer.type %s on platform %s, as the cluster managed load balancer serves the API on port %d", configv1.LoadBalancerTypeUserManaged, platform.Name(), types.DefaultAPIPort)))
+		}
+	}
+	return allErrs
+}
+
+// loadBalancerType returns the type of the load balancer in front of the
+// control plane on the on-prem platforms.
+func loadBalancerType(p *types.Platform) configv1.PlatformLoadBalancerType {
+	switch {
+	case p.BareMetal != nil && p.BareMetal.LoadBalancer != nil:
+		return p.BareMetal.LoadBalancer.Type
+	case p.Nutanix != nil && p.Nutanix.LoadBalancer != nil:
+		return p.Nutanix.LoadBalancer.Type
+	case p.OpenStack != nil && p.OpenStack.LoadBalancer != nil:
+		return p.OpenStack.LoadBalancer.Type
+	case p.Ovirt != nil && p.Ovirt.LoadBalancer != nil:
+		return p.Ovirt.LoadBalancer.Type
+	case p.VSphere != nil && p.VSphere.LoadBalancer != nil:
+		return p.VSphere.LoadBalancer.Type
+	default:
+		return ""
+	}
+}
+
+func validateServingCertificate(pair *types.CertificateKeyPair, hostname string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if pair.Certificate == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("certificate"), "certificate is required"))
+	}
+	if pair.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key is required"))
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	keyPair, err := tls.X509KeyPair([]byte(pair.Certificate), []byte(pair.Key))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, "<redacted>", fmt.Sprintf("invalid certificate and key: %v", err)))
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath.Child("certificate"), "<redacted>", err.Error()))
+	}
+	if time.Now().After(cert.NotAfter) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("certificate"), "<redacted>", fmt.Sprintf("certificate expired on %s", cert.NotAfter.UTC().Format(time.RFC3339))))
+	}
+	// VerifyHostname does not match a wildcard against a wildcard, so check
+	// the wildcard hostname with a name it covers.
+	if err := cert.VerifyHostname(strings.Replace(hostname, "*", "wildcard-check", 1)); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("certificate"), "<redacted>", fmt.Sprintf("certificate is not valid for %s", hostname)))
+	}
+	return allErrs
+}
+
+var validAuditProfiles = []string{
+	string(configv1.DefaultAuditProfileType),
+	string(configv1.WriteRequestBodiesAuditProfileType),
+	string(configv1.AllRequestBodiesAuditProfileType),
+	string(configv1.NoneAuditProfileType),
+}
+
+func validateAudit(audit *types.Audit, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if audit.Profile != "" && !sets.NewString(validAuditProfiles...).Has(string(audit.Profile)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("profile"), audit.Profile, validAuditProfiles))
+	}
+	groups := sets.NewString()
+	for i, rule := range audit.CustomRules {
+		rulePath := fldPath.Child("customRules").Index(i)
+		switch {
+		case rule.Group == "":
+			allErrs = append(allErrs, field.Required(rulePath.Child("group"), "group is required"))
+		case groups.Has(rule.Group):
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("group"), rule.Group))
+		}
+		groups.Insert(rule.Group)
+		if !sets.NewString(validAuditProfiles...).Has(string(rule.Profile)) {
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("profile"), rule.Profile, validAuditProfiles))
+		}
+	}
+	return allErrs
+}
+
+var validEtcdEncryptionTypes = []string{
+	string(types.EtcdEncryptionTypeAESCBC),
+	string(types.EtcdEncryptionTypeAESGCM),
+	string(types.EtcdEncryptionTypeKMS),
+}
+
+// validateEtcdEncryption checks the etcd encryption. The KMS encryption type
+// is only supported on AWS, and is gated by the TechPreviewNoUpgrade feature
+// set in the APIServer API.
+func validateEtcdEncryption(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	encryption := c.EtcdEncryption
+	switch encryption.Type {
+	case types.EtcdEncryptionTypeAESCBC, types.EtcdEncryptionTypeAESGCM:
+		if encryption.KMS != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("kms"), fmt.Sprintf("kms may only be set when type is %s", types.EtcdEncryptionTypeKMS)))
+		}
+	case types.EtcdEncryptionTypeKMS:
+		if c.FeatureSet != configv1.TechPreviewNoUpgrade && c.FeatureSet != configv1.CustomNoUpgrade {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "KMS encryption requires the TechPreviewNoUpgrade or CustomNoUpgrade feature set"))
+		}
+		kmsPath := fldPath.Child("kms", "aws")
+		if c.Platform.Name() != aws.Name {
+			return append(allErrs, field.Invalid(fldPath.Child("type"), encryption.Type, fmt.Sprintf("KMS encryption is not supported on %s", c.Platform.Name())))
+		}
+		if encryption.KMS == nil || encryption.KMS.AWS == nil || encryption.KMS.AWS.KeyARN == "" {
+			return append(allErrs, field.Required(kmsPath.Child("keyARN"), "the ARN of the KMS key is required when type is KMS"))
+		}
+		keyARN := encryption.KMS.AWS.KeyARN
+		parsed, err := arn.Parse(keyARN)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(kmsPath.Child("keyARN"), keyARN, err.Error()))
+		case parsed.Service != "kms" || !strings.HasPrefix(parsed.Resource, "key/"):
+			allErrs = append(allErrs, field.Invalid(kmsPath.Child("keyARN"), keyARN, "must be the ARN of a KMS key"))
+		case parsed.Region != c.AWS.Region:
+			allErrs = append(allErrs, field.Invalid(kmsPath.Child("keyARN"), keyARN, fmt.Sprintf("the KMS key must be in the region of the cluster, %s", c.AWS.Region)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), encryption.Type, validEtcdEncryptionTypes))
+	}
+	return allErrs
+}
+
+var validMappingMethods = []string{
+	string(configv1.MappingMethodClaim),
+	string(configv1.MappingMethodLookup),
+	string(configv1.MappingMethodAdd),
+}
+
+func validateOAuth(oauth *types.OAuth, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if oauth.DisableKubeadmin && len(oauth.IdentityProviders) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("disableKubeadmin"), oauth.DisableKubeadmin, "the kubeadmin user can only be disabled when an identity provider is configured"))
+	}
+	names := sets.NewString()
+	for i, idp := range oauth.IdentityProviders {
+		idpPath := fldPath.Child("identityProviders").Index(i)
+		switch {
+		case idp.Name == "":
+			allErrs = append(allErrs, field.Required(idpPath.Child("name"), "name is required"))
+		case names.Has(idp.Name):
+			allErrs = append(allErrs, field.Duplicate(idpPath.Child("name"), idp.Name))
+		}
+		names.Insert(idp.Name)
+		if idp.MappingMethod != "" && !sets.NewString(validMappingMethods...).Has(string(idp.MappingMethod)) {
+			allErrs = append(allErrs, field.NotSupported(idpPath.Child("mappingMethod"), idp.MappingMethod, validMappingMethods))
+		}
+
+		providers := 0
+		if idp.HTPasswd != nil {
+			providers++
+			if idp.HTPasswd.FileData == "" {
+				allErrs = append(allErrs, field.Required(idpPath.Child("htpasswd", "fileData"), "fileData is required"))
+			}
+		}
+		if idp.LDAP != nil {
+			providers++
+			allErrs = append(allErrs, validateLDAPIdentityProvider(idp.LDAP, idpPath.Child("ldap"))...)
+		}
+		if idp.OpenID != nil {
+			providers++
+			allErrs = append(allErrs, validateOpenIDIdentityProvider(idp.OpenID, idpPath.Child("openID"))...)
+		}
+		if providers != 1 {
+			allErrs = append(allErrs, field.Invalid(idpPath, idp.Name, "exactly one of htpasswd, ldap and openID must be set"))
+		}
+	}
+	return allErrs
+}
+
+var validForwardingPolicies = []string{
+	string(operv1.RandomForwardingPolicy),
+	string(operv1.RoundRobinForwardingPolicy),
+	string(operv1.SequentialForwardingPolicy),
+}
+
+var validDNSTypes = []string{
+	string(configv1.PlatformDefaultDNSType),
+	string(configv1.ClusterHostedDNSType),
+}
+
+func validateClusterDNS(dns *types.ClusterDNS, platform *types.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch dns.DNSType {
+	case "":
+	case configv1.PlatformDefaultDNSType:
+		if platform.GCP != nil && platform.GCP.UserProvisionedDNS == gcp.UserProvisionedDNSEnabled {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsType"), dns.DNSType, "the DNS is provisioned by the user with platform.gcp.userProvisionedDNS"))
+		}
+	case configv1.ClusterHostedDNSType:
+		// The infrastructure only reports the DNS type of GCP clusters,
+		// so the in-cluster DNS is not set up on other platforms.
+		if platform.Name() != gcp.Name {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsType"), fmt.Sprintf("%s DNS is not supported on platform %s", dns.DNSType, platform.Name())))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("dnsType"), dns.DNSType, validDNSTypes))
+	}
+	names := sets.NewString()
+	zones := sets.NewString()
+	for i, forwarder := range dns.Forwarders {
+		forwarderPath := fldPath.Child("forwarders").Index(i)
+		switch {
+		case forwarder.Name == "":
+			allErrs = append(allErrs, field.Required(forwarderPath.Child("name"), "name is required"))
+		case names.Has(forwarder.Name):
+			allErrs = append(allErrs, field.Duplicate(forwarderPath.Child("name"), forwarder.Name))
+		}
+		names.Insert(forwarder.Name)
+		if len(forwarder.Zones) == 0 {
+			allErrs = append(allErrs, field.Required(forwarderPath.Child("zones"), "at least one zone is required"))
+		}
+		for j, zone := range forwarder.Zones {
+			zonePath := forwarderPath.Child("zones").Index(j)
+			if err := validate.DomainName(zone, false); err != nil {
+				allErrs = append(allErrs, field.Invalid(zonePath, zone, err.Error()))
+				continue
+			}
+			// A domain can only be forwarded to a single set of upstreams.
+			if zones.Has(zone) {
+				allErrs = append(allErrs, field.Duplicate(zonePath, zone))
+			}
+			zones.Insert(zone)
+		}
+		allErrs = append(allErrs, validateDNSUpstreams(forwarder.Upstreams, forwarder.Policy, forwarderPath)...)
+	}
+	if dns.UpstreamResolvers != nil {
+		allErrs = append(allErrs, validateDNSUpstreams(dns.UpstreamResolvers.Upstreams, dns.UpstreamResolvers.Policy, fldPath.Child("upstreamResolvers"))...)
+	}
+	return allErrs
+}
+
+func validateDNSUpstreams(upstreams []string, policy operv1.ForwardingPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(upstreams) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("upstreams"), "at least one upstream is required"))
+	}
+	for i, upstream := range upstreams {
+		if _, _, err := types.SplitDNSUpstream(upstream); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("upstreams").Index(i), upstream, err.Error()))
+		}
+	}
+	if policy != "" && !sets.NewString(validForwardingPolicies...).Has(string(policy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("policy"), policy, validForwardingPolicies))
+	}
+	return allErrs
+}
+
+var validHugePageSizes = []string{"2M", "1G"}
+
+func validatePerformanceProfile(profile *types.PerformanceProfile, platform *types.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if name := platform.Name(); name != baremetal.Name && name != none.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("performance profiles are not supported on platform %s", name)))
+	}
+	reserved, err := parseCPUSet(profile.ReservedCPUs)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("reservedCPUs"), profile.ReservedCPUs, err.Error()))
+	}
+	isolated, err := parseCPUSet(profile.IsolatedCPUs)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("isolatedCPUs"), profile.IsolatedCPUs, err.Error()))
+	}
+	if overlap := reserved.Intersection(isolated); overlap.Len() > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("isolatedCPUs"), profile.IsolatedCPUs, fmt.Sprintf("overlaps the reserved CPUs %v", sets.List(overlap))))
+	}
+	if hugePages := profile.HugePages; hugePages != nil {
+		hugePagesPath := fldPath.Child("hugePages")
+		if hugePages.DefaultSize != "" && !slices.Contains(validHugePageSizes, hugePages.DefaultSize) {
+			allErrs = append(allErrs, field.NotSupported(hugePagesPath.Child("defaultSize"), hugePages.DefaultSize, validHugePageSizes))
+		}
+		for i, page := range hugePages.Pages {
+			pagePath := hugePagesPath.Child("pages").Index(i)
+			if !slices.Contains(validHugePageSizes, page.Size) {
+				allErrs = append(allErrs, field.NotSupported(pagePath.Child("size"), page.Size, validHugePageSizes))
+			}
+			if page.Count <= 0 {
+				allErrs = append(allErrs, field.Invalid(pagePath.Child("count"), page.Count, "count must be positive"))
+			}
+			if page.Node != nil && *page.Node < 0 {
+				allErrs = append(allErrs, field.Invalid(pagePath.Child("node"), *page.Node, "node must not be negative"))
+			}
+		}
+	}
+	return allErrs
+}
+
+var (
+	// s3BucketNameRegexp matches the S3 bucket names that are valid in all
+	// the regions and usable with virtual-hosted-style requests.
+	s3BucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	// gcsBucketNameRegexp matches the GCS bucket names without dots, which
+	// would require a verified domain.
+	gcsBucketNameRegexp        = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,61}[a-z0-9]$`)
+	azureStorageAccountRegexp  = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+	azureBlobContainerRegexp   = regexp.MustCompile(`^[a-z0-9]([a-z0-9]|-[a-z0-9]){2,62}$`)
+	validRegistryPVCAccessMode = []string{"ReadWriteMany", "ReadWriteOnce"}
+)
+
+func validateImageRegistry(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	storage := c.ImageRegistry.Storage
+	storagePath := fldPath.Child("storage")
+	platform := c.Platform.Name()
+
+	var backends []string
+	if s3 := storage.S3; s3 != nil {
+		backends = append(backends, "s3")
+		s3Path := storagePath.Child("s3")
+		if platform != aws.Name {
+			allErrs = append(allErrs, field.Forbidden(s3Path, fmt.Sprintf("S3 storage is not supported on platform %s", platform)))
+		}
+		if !s3BucketNameRegexp.MatchString(s3.Bucket) || strings.Contains(s3.Bucket, "..") || net.ParseIP(s3.Bucket) != nil {
+			allErrs = append(allErrs, field.Invalid(s3Path.Child("bucket"), s3.Bucket, "must be 3 to 63 lower case letters, digits, dots and hyphens, starting and ending with a letter or a digit, and not an IP address"))
+		}
+	}
+	if az := storage.Azure; az != nil {
+		backends = append(backends, "azure")
+		azurePath := storagePath.Child("azure")
+		if platform != azure.Name {
+			allErrs = append(allErrs, field.Forbidden(azurePath, fmt.Sprintf("Azure storage is not supported on platform %s", platform)))
+		}
+		if !azureStorageAccountRegexp.MatchString(az.AccountName) {
+			allErrs = append(allErrs, field.Invalid(azurePath.Child("accountName"), az.AccountName, "must be 3 to 24 lower case letters and digits"))
+		}
+		if !azureBlobContainerRegexp.MatchString(az.Container) {
+			allErrs = append(allErrs, field.Invalid(azurePath.Child("container"), az.Container, "must be 3 to 63 lower case letters, digits and single hyphens, starting and ending with a letter or a digit"))
+		}
+	}
+	if gcs := storage.GCS; gcs != nil {
+		backends = append(backends, "gcs")
+		gcsPath := storagePath.Child("gcs")
+		if platform != gcp.Name {
+			allErrs = append(allErrs, field.Forbidden(gcsPath, fmt.Sprintf("GCS storage is not supported on platform %s", platform)))
+		}
+		if !gcsBucketNameRegexp.MatchString(gcs.Bucket) || strings.HasPrefix(gcs.Bucket, "goog") {
+			allErrs = append(allErrs, field.Invalid(gcsPath.Child("bucket"), gcs.Bucket, "must be 3 to 63 lower case letters, digits, underscores and hyphens, starting and ending with a letter or a digit, and not start with goog"))
+		}
+	}
+	if pvc := storage.PVC; pvc != nil {
+		backends = append(backends, "pvc")
+		pvcPath := storagePath.Child("pvc")
+		if pvc.Size != "" {
+			if size, err := resource.ParseQuantity(pvc.Size); err != nil {
+				allErrs = append(allErrs, field.Invalid(pvcPath.Child("size"), pvc.Size, err.Error()))
+			} else if size.Sign() <= 0 {
+				allErrs = append(allErrs, field.Invalid(pvcPath.Child("size"), pvc.Size, "size must be positive"))
+			}
+		}
+		if pvc.AccessMode != "" && !slices.Contains(validRegistryPVCAccessMode, pvc.AccessMode) {
+			allErrs = append(allErrs, field.NotSupported(pvcPath.Child("accessMode"), pvc.AccessMode, validRegistryPVCAccessMode))
+		}
+		if pvc.StorageClassName != "" {
+			for _, msg := range utilvalidation.IsDNS1123Subdomain(pvc.StorageClassName) {
+				allErrs = append(allErrs, field.Invalid(pvcPath.Child("storageClassName"), pvc.StorageClassName, msg))
+			}
+		}
+	}
+	if storage.EmptyDir != nil {
+		backends = append(backends, "emptyDir")
+		if !isSingleNodeCluster(c) {
+			allErrs = append(allErrs, field.Forbidden(storagePath.Child("emptyDir"), "emptyDir storage is only supported on single-node clusters, where a single registry replica runs"))
+		}
+	}
+
+	switch len(backends) {
+	case 0:
+		allErrs = append(allErrs, field.Required(storagePath, "one of s3, azure, gcs, pvc and emptyDir must be set"))
+	case 1:
+	default:
+		allErrs = append(allErrs, field.Invalid(storagePath, strings.Join(backends, ", "), "only one storage backend may be set"))
+	}
+	return allErrs
+}
+
+func validateBastion(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch platform := c.Platform.Name(); platform {
+	case aws.Name, azure.Name, gcp.Name:
+	default:
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("a bastion host is not supported on platform %s", platform)))
+	}
+	if c.Publish == types.ExternalPublishingStrategy || c.Publish == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a bastion host is only supported for private clusters, whose publish strategy is Internal or Mixed"))
+	}
+	return allErrs
+}
+
+// isSingleNodeCluster returns true if the cluster has a single control plane
+// node and no compute node.
+func isSingleNodeCluster(c *types.InstallConfig) bool {
+	if c.ControlPlane == nil || c.ControlPlane.Replicas == nil || *c.ControlPlane.Replicas != 1 {
+		return false
+	}
+	for _, pool := range c.Compute {
+		if pool.Replicas != nil && *pool.Replicas != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCPUSet parses a set of CPUs in the cpuset format, a comma-separated
+// list of CPU IDs and ranges, e.g. "0-3,8".
+func parseCPUSet(s string) (sets.Set[int], error) {
+	cpus := sets.New[int]()
+	if strings.TrimSpace(s) == "" {
+		return cpus, errors.New("at least one CPU is required")
+	}
+	for _, part := range strings.Split(s, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 0 {
+			return cpus, errors.Errorf("invalid CPU %q", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil || last < first {
+				return cpus, errors.Errorf("invalid CPU range %q", part)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			cpus.Insert(cpu)
+		}
+	}
+	return cpus, nil
+}
+
+func validateLDAPIdentityProvider(ldap *types.LDAPIdentityProvider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if u, err := url.Parse(ldap.URL); err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), ldap.URL, "must be an ldap:// or ldaps:// URL"))
+	}
+	if ldap.BindPassword != "" && ldap.BindDN == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("bindDN"), "bindDN is required with bindPassword"))
+	}
+	if ldap.CA != "" {
+		if err := validate.CABundle(ldap.CA); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ca"), ldap.CA, err.Error()))
+		}
+	}
+	if len(ldap.Attributes.ID) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("attributes", "id"), "at least one id attribute is required"))
+	}
+	return allErrs
+}
+
+func validateOpenIDIdentityProvider(openID *types.OpenIDIdentityProvider, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if openID.ClientID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientID"), "clientID is required"))
+	}
+	if openID.ClientSecret == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientSecret"), "clientSecret is required"))
+	}
+	if err := validate.URIWithProtocol(openID.Issuer, "https"); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("issuer"), openID.Issuer, err.Error()))
+	}
+	if openID.CA != "" {
+		if err := validate.CABundle(openID.CA); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ca"), openID.CA, err.Error()))
+		}
+	}
+	return allErrs
+}
+
 // ipAddressType indicates the address types provided for a given field
 type ipAddressType struct {
 	IPv4    bool
@@ -364,6 +1206,7 @@ func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.E
 		case p.Nutanix != nil:
 		case p.None != nil:
 		case p.External != nil:
+		case p.AWS != nil && p.AWS.IsIPv6Only():
 		case p.Azure != nil && p.Azure.CloudName == azure.StackCloud:
 			allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "IPv6", "Azure Stack does not support IPv6"))
 		default:
@@ -374,225 +1217,12 @@ func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.E
 		if len(n.ServiceNetwork) > 1 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("networking", "serviceNetwork"), strings.Join(ipnetworksToStrings(n.ServiceNetwork), ", "), "only one service network can be specified"))
 		}
-
-	default:
-		// we should have a validation error for no specified machineNetwork, serviceNetwork, or clusterNetwork
-	}
-
-	return allErrs
-}
-
-func validateNetworking(n *types.Networking, singleNodeOpenShift bool, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-	if n.NetworkType == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("networkType"), "network provider type required"))
-	}
-
-	// NOTE(dulek): We're hardcoding "Kuryr" here as the plan is to remove it from the API very soon. We can remove
-	//              this check once some more general validation of the supported NetworkTypes is in place.
-	if n.NetworkType == "Kuryr" {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("networkType"), n.NetworkType, "networkType Kuryr is not supported on OpenShift later than 4.14"))
-	}
-
-	if n.NetworkType == string(operv1.NetworkTypeOpenShiftSDN) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("networkType"), n.NetworkType, "networkType OpenShiftSDN is not supported, please use OVNKubernetes"))
-	}
-
-	if len(n.MachineNetwork) > 0 {
-		for i, network := range n.MachineNetwork {
-			if err := validate.SubnetCIDR(&network.CIDR.IPNet); err != nil {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNetwork").Index(i), network.CIDR.String(), err.Error()))
-			}
-			for j, subNetwork := range n.MachineNetwork[0:i] {
-				if validate.DoCIDRsOverlap(&network.CIDR.IPNet, &subNetwork.CIDR.IPNet) {
-					allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNetwork").Index(i), network.CIDR.String(), fmt.Sprintf("machine network must not overlap with machine network %d", j)))
-				}
-			}
-		}
-	} else {
-		allErrs = append(allErrs, field.Required(fldPath.Child("machineNetwork"), "at least one machine network is required"))
-	}
-
-	for i, sn := range n.ServiceNetwork {
-		if err := validate.ServiceSubnetCIDR(&sn.IPNet); err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), err.Error()))
-		}
-		for _, network := range n.MachineNetwork {
-			if validate.DoCIDRsOverlap(&sn.IPNet, &network.CIDR.IPNet) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), "service network must not overlap with any of the machine networks"))
-			}
+		if p.AWS != nil && p.AWS.IsIPv6Only() {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("networking"), "IPv4", "IPv6 networks are required when the AWS ipFamily is IPv6"))
 		}
-		for j, snn := range n.ServiceNetwork[0:i] {
-			if validate.DoCIDRsOverlap(&sn.IPNet, &snn.IPNet) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), fmt.Sprintf("service network must not overlap with service network %d", j)))
-			}
-		}
-	}
-	if len(n.ServiceNetwork) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("serviceNetwork"), "a service network is required"))
-	}
 
-	for i, cn := range n.ClusterNetwork {
-		allErrs = append(allErrs, validateClusterNetwork(n, &cn, i, fldPath.Child("clusterNetwork").Index(i))...)
-	}
-	if len(n.ClusterNetwork) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("clusterNetwork"), "cluster network required"))
-	}
-	return allErrs
-}
-
-func validateNetworkingForPlatform(n *types.Networking, platform *types.Platform, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-	switch {
-	case platform.Libvirt != nil:
-		errMsg := "overlaps with default Docker Bridge subnet"
-		for idx, mn := range n.MachineNetwork {
-			if validate.DoCIDRsOverlap(&mn.CIDR.IPNet, validate.DockerBridgeCIDR) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNewtork").Index(idx), mn.CIDR.String(), errMsg))
-			}
-		}
-		for idx, sn := range n.ServiceNetwork {
-			if validate.DoCIDRsOverlap(&sn.IPNet, validate.DockerBridgeCIDR) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(idx), sn.String(), errMsg))
-			}
-		}
-		for idx, cn := range n.ClusterNetwork {
-			if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, validate.DockerBridgeCIDR) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterNetwork").Index(idx), cn.CIDR.String(), errMsg))
-			}
-		}
 	default:
-		warningMsgFmt := "%s: %s overlaps with default Docker Bridge subnet"
-		for idx, mn := range n.MachineNetwork {
-			if validate.DoCIDRsOverlap(&mn.CIDR.IPNet, validate.DockerBridgeCIDR) {
-				logrus.Warnf(warningMsgFmt, fldPath.Child("machineNetwork").Index(idx), mn.CIDR.String())
-			}
-		}
-		for idx, sn := range n.ServiceNetwork {
-			if validate.DoCIDRsOverlap(&sn.IPNet, validate.DockerBridgeCIDR) {
-				logrus.Warnf(warningMsgFmt, fldPath.Child("serviceNetwork").Index(idx), sn.String())
-			}
-		}
-		for idx, cn := range n.ClusterNetwork {
-			if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, validate.DockerBridgeCIDR) {
-				logrus.Warnf(warningMsgFmt, fldPath.Child("clusterNetwork").Index(idx), cn.CIDR.String())
-			}
-		}
-	}
-	return allErrs
-}
-
-func validateClusterNetwork(n *types.Networking, cn *types.ClusterNetworkEntry, idx int, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-	if err := validate.SubnetCIDR(&cn.CIDR.IPNet); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.IPNet.String(), err.Error()))
-	}
-	for _, network := range n.MachineNetwork {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &network.CIDR.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), "cluster network must not overlap with any of the machine networks"))
-		}
-	}
-	for i, sn := range n.ServiceNetwork {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &sn.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with service network %d", i)))
-		}
-	}
-	for i, acn := range n.ClusterNetwork[0:idx] {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &acn.CIDR.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with cluster network %d", i)))
-		}
-	}
-	if cn.HostPrefix < 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "hostPrefix must be positive"))
-	}
-	// ignore hostPrefix if the plugin does not use it and has it unset
-	if pluginsUsingHostPrefix.Has(n.NetworkType) || (cn.HostPrefix != 0) {
-		if ones, bits := cn.CIDR.Mask.Size(); cn.HostPrefix < int32(ones) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "cluster network host subnetwork prefix must not be larger size than CIDR "+cn.CIDR.String()))
-		} else if bits == 128 && cn.HostPrefix != 64 {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "cluster network host subnetwork prefix must be 64 for IPv6 networks"))
-		}
-	}
-	return allErrs
-}
-
-func validateNetworkingClusterNetworkMTU(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
-	// higherLimitMTUVPC is the MTU limit for AWS VPC.
-	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/network_mtu.html#jumbo_frame_instances
-	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/network_mtu.html
-	const higherLimitMTUVPC uint32 = uint32(9001)
-
-	// lowerLimitMTUVPC is the lower limit to prevent users setting too low values impacting in the
-	// cluster network performance. Tested values with 1100 decreases 70% in the network performance
-	// in AWS deployments:
-	const lowerLimitMTUVPC uint32 = uint32(1000)
-
-	// higherLimitMTUEdge defines the maximium generally supported MTU in AWS Local and Wavelength Zones.
-	// Mostly AWS Local or Wavelength zones have limited MTU between those and in the Region.
-	// It is required to raise a warning message when the user-defined MTU is higher than general supported.
-	// https://docs.aws.amazon.com/local-zones/latest/ug/how-local-zones-work.html#considerations
-	// https://docs.aws.amazon.com/wavelength/latest/developerguide/how-wavelengths-work.html
-	const higherLimitMTUEdge uint32 = uint32(1300)
-
-	// MTU overhead for the network plugin OVNKubernetes.
-	// https://docs.openshift.com/container-platform/4.14/networking/changing-cluster-network-mtu.html#mtu-value-selection_changing-cluster-network-mtu
-	const minOverheadOVN uint32 = uint32(100)
-
-	allErrs := field.ErrorList{}
-
-	if c.Networking == nil {
-		return nil
-	}
-
-	if c.Networking.ClusterNetworkMTU == 0 {
-		return nil
-	}
-
-	if c.Platform.Name() != aws.Name {
-		return append(allErrs, field.Invalid(fldPath, int(c.Networking.ClusterNetworkMTU), "cluster network MTU is allowed only in AWS deployments"))
-	}
-
-	network := c.NetworkType
-	mtu := c.Networking.ClusterNetworkMTU
-
-	// Calculating the MTU limits considering the base overhead for each network plugin.
-	limitEdgeOVNKubernetes := higherLimitMTUEdge - minOverheadOVN
-	limitOVNKubernetes := higherLimitMTUVPC - minOverheadOVN
-
-	if mtu > higherLimitMTUVPC {
-		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU exceeds the maximum value of %d", higherLimitMTUVPC)))
-	}
-
-	// Prevent too low MTU values.
-	// Tests in AWS Local Zones with MTU of 1100 decreased the network
-	// performance in 70%. The check protects the cluster stability from
-	// user defining too lower numbers.
-	// https://issues.redhat.com/browse/OCPBUGS-11098
-	if mtu < lowerLimitMTUVPC {
-		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU is lower than the minimum value of %d", lowerLimitMTUVPC)))
-	}
-
-	hasEdgePool := false
-	warnEdgePool := false
-	for _, compute := range c.Compute {
-		if compute.Name == types.MachinePoolEdgeRoleName {
-			hasEdgePool = true
-			break
-		}
-	}
-
-	if network != string(operv1.NetworkTypeOVNKubernetes) {
-		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU is not valid with network plugin %s", network)))
-	}
-
-	if mtu > limitOVNKubernetes {
-		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU exceeds the maximum value with the network plugin %s of %d", network, limitOVNKubernetes)))
-	}
-	if hasEdgePool && (mtu > limitEdgeOVNKubernetes) {
-		warnEdgePool = true
-	}
-	if warnEdgePool {
-		logrus.Warnf("networking.ClusterNetworkMTU exceeds the maximum value generally supported by AWS Local or Wavelength zones. Please ensure all AWS Zones defined in the edge compute pool accepts the MTU %d bytes between nodes (EC2) in the zone and in the Region.", mtu)
+		// we should have a validation error for no specified machineNetwork, serviceNetwork, or clusterNetwork
 	}
 
 	return allErrs
@@ -622,6 +1252,7 @@ func validateComputeEdge(platform *types.Platform, pName string, fldPath *field.
 func validateCompute(platform *types.Platform, control *types.MachinePool, pools []types.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	poolNames := map[string]bool{}
+	kubeletConfigPool, hostnamePatternPool := "", ""
 	for i, p := range pools {
 		poolFldPath := fldPath.Index(i)
 		switch p.Name {
@@ -636,10 +1267,53 @@ func validateCompute(platform *types.Platform, control *types.MachinePool, pools
 			allErrs = append(allErrs, field.Duplicate(poolFldPath.Child("name"), p.Name))
 		}
 		poolNames[p.Name] = true
-		if control != nil && control.Architecture != p.Architecture {
-			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("architecture"), p.Architecture, "heteregeneous multi-arch is not supported; compute pool architecture must match control plane"))
+		if i > 0 && pools[0].Architecture != p.Architecture {
+			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("architecture"), p.Architecture, "all compute pools must use the same architecture"))
+		} else if control != nil && control.Architecture != p.Architecture && !heterogeneousPlatforms.Has(platform.Name()) {
+			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("architecture"), p.Architecture, fmt.Sprintf("heterogeneous clusters are not supported on %s; compute pool architecture must match control plane", platform.Name())))
 		}
 		allErrs = append(allErrs, ValidateMachinePool(platform, &p, poolFldPath)...)
+		// All the compute pools share the worker machine config pool.
+		if p.KubeletConfig != nil {
+			if kubeletConfigPool != "" {
+				allErrs = append(allErrs, field.Forbidden(poolFldPath.Child("kubeletConfig"), fmt.Sprintf("the kubelet config is already set by compute pool %s, and compute pools share the worker machine config pool", kubeletConfigPool)))
+			}
+			kubeletConfigPool = p.Name
+		}
+		if p.HostnamePattern != "" {
+			if hostnamePatternPool != "" {
+				allErrs = append(allErrs, field.Forbidden(poolFldPath.Child("hostnamePattern"), fmt.Sprintf("the hostname pattern is already set by compute pool %s, and compute pools share the worker machine config pool", hostnamePatternPool)))
+			}
+			hostnamePatternPool = p.Name
+		}
+	}
+	return allErrs
+}
+
+// multiArchRelease is the architecture reported by release payloads that
+// are manifest lists covering several architectures.
+const multiArchRelease = "multi"
+
+// ValidateReleaseArchitecture checks that the machine pool architectures can
+// be installed from a release payload of the given architecture. Compute pools
+// may only differ from the control plane (a heterogeneous cluster) when the
+// payload is a multi-arch manifest list. An empty releaseArch means that the
+// payload could not be inspected.
+func ValidateReleaseArchitecture(c *types.InstallConfig, releaseArch string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if c.ControlPlane == nil || releaseArch == multiArchRelease {
+		return allErrs
+	}
+	for i, p := range c.Compute {
+		if p.Architecture == c.ControlPlane.Architecture {
+			continue
+		}
+		fldPath := field.NewPath("compute").Index(i).Child("architecture")
+		if releaseArch == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, p.Architecture, "heterogeneous clusters require a multi-arch release payload, but the release payload architecture could not be determined"))
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(fldPath, p.Architecture, fmt.Sprintf("heterogeneous clusters require a multi-arch release payload, but the release payload is %s", releaseArch)))
 	}
 	return allErrs
 }
@@ -952,6 +1626,9 @@ func validatePlatform(platform *types.Platform, usingAgentMethod bool, fldPath *
 			return nutanixvalidation.ValidatePlatform(platform.Nutanix, f, c)
 		})
 	}
+	if platform.External != nil {
+		validate(external.Name, platform.External, func(f *field.Path) field.ErrorList { return externalvalidation.ValidatePlatform(platform.External, f) })
+	}
 	return allErrs
 }
 
@@ -1029,6 +1706,26 @@ func validateImageDigestSources(groups []types.ImageDigestSource, fldPath *field
 	return allErrs
 }
 
+func validateAdditionalTrustedRegistryCAs(cas map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, registry := range sets.StringKeySet(cas).List() {
+		ca := cas[registry]
+		host, port, err := net.SplitHostPort(registry)
+		if err != nil {
+			host, port = registry, ""
+		}
+		if err := validate.Host(host); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(registry), registry, "must be the hostname of a registry, with an optional port"))
+		} else if p, err := strconv.Atoi(port); port != "" && (err != nil || p < 1 || p > 65535) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(registry), registry, "invalid port"))
+		}
+		if err := validate.CABundle(ca); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(registry), ca, err.Error()))
+		}
+	}
+	return allErrs
+}
+
 func validateNamedRepository(r string) error {
 	ref, err := dockerref.ParseNamed(r)
 	if err != nil {
@@ -1156,23 +1853,38 @@ func validateIPProxy(proxy string, n *types.Networking, fldPath *field.Path) fie
 	return allErrs
 }
 
+// fipsArchitectures are the architectures on which the cryptographic modules
+// of RHCOS are FIPS validated.
+var fipsArchitectures = []string{string(types.ArchitectureAMD64), string(types.ArchitecturePPC64LE), string(types.ArchitectureS390X)}
+
 // validateFIPSconfig checks if the current install-config is compatible with FIPS standards
 // and returns an error if it's not the case. As of this writing, only rsa or ecdsa algorithms are supported
-// for ssh keys on FIPS.
+// for ssh keys on FIPS, the release payload must ship FIPS-validated cryptographic modules and the machines
+// must run on an architecture on which those modules are validated.
 func validateFIPSconfig(c *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
-	if c.SSHKey != "" {
-		sshParsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.SSHKey))
-		if err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, fmt.Sprintf("Fatal error trying to parse configured public key: %s", err)))
-		} else {
-			sshKeyType := sshParsedKey.Type()
-			re := regexp.MustCompile(`^ecdsa-sha2-nistp\d{3}$|^ssh-rsa$`)
-			if !re.MatchString(sshKeyType) {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("sshKey"), c.SSHKey, fmt.Sprintf("SSH key type %s unavailable when FIPS is enabled. Please use rsa or ecdsa.", sshKeyType)))
-			}
+	if c.IsOKD() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("fips"), "FIPS mode is not supported with OKD, whose release payload does not ship FIPS-validated cryptographic modules"))
+	} else if ri := os.Getenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); isOKDReleaseImage(ri) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("fips"), fmt.Sprintf("FIPS mode is not supported with the OKD release image %s, which does not ship FIPS-validated cryptographic modules", ri)))
+	}
+	if c.ControlPlane != nil && c.ControlPlane.Architecture != "" && !sets.NewString(fipsArchitectures...).Has(string(c.ControlPlane.Architecture)) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("controlPlane", "architecture"), c.ControlPlane.Architecture, fipsArchitectures))
+	}
+	for i, p := range c.Compute {
+		if p.Architecture != "" && !sets.NewString(fipsArchitectures...).Has(string(p.Architecture)) {
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("compute").Index(i).Child("architecture"), p.Architecture, fipsArchitectures))
 		}
 	}
+	if c.SSHKey != "" {
+		allErrs = append(allErrs, validateFIPSSSHKey(c.SSHKey, field.NewPath("sshKey"))...)
+	}
+	for i, key := range c.SSHKeys {
+		allErrs = append(allErrs, validateFIPSSSHKey(key, field.NewPath("sshKeys").Index(i))...)
+	}
+	if c.GenerateSSHKey && len(c.AuthorizedSSHKeys()) == 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("generateSSHKey"), "generated ed25519 SSH keys are unavailable when FIPS is enabled"))
+	}
 
 	if err := hostcrypt.VerifyHostTargetState(c.FIPS); err != nil {
 		if skip, ok := os.LookupEnv("OPENSHIFT_INSTALL_SKIP_HOSTCRYPT_VALIDATION"); ok && skip != "" {
@@ -1188,6 +1900,34 @@ func validateFIPSconfig(c *types.InstallConfig) field.ErrorList {
 	return allErrs
 }
 
+// validateFIPSSSHKey checks that the SSH public key uses a key type that is
+// available when FIPS is enabled.
+func validateFIPSSSHKey(key string, fldPath *field.Path) field.ErrorList {
+	sshParsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, key, fmt.Sprintf("Fatal error trying to parse configured public key: %s", err))}
+	}
+	sshKeyType := sshParsedKey.Type()
+	re := regexp.MustCompile(`^ecdsa-sha2-nistp\d{3}$|^ssh-rsa$`)
+	if !re.MatchString(sshKeyType) {
+		return field.ErrorList{field.Invalid(fldPath, key, fmt.Sprintf("SSH key type %s unavailable when FIPS is enabled. Please use rsa or ecdsa.", sshKeyType))}
+	}
+	return nil
+}
+
+// isOKDReleaseImage returns whether the release image pull spec refers to an
+// OKD release repository.
+func isOKDReleaseImage(pullSpec string) bool {
+	repository, _, _ := strings.Cut(pullSpec, "@")
+	for _, component := range strings.Split(repository, "/")[1:] {
+		component, _, _ = strings.Cut(component, ":")
+		if component == "okd" || component == "origin" {
+			return true
+		}
+	}
+	return false
+}
+
 // validateCapabilities checks if additional, optional OpenShift components are specified in the
 // install-config to be included in the installation.
 func validateCapabilities(c *types.Capabilities, fldPath *field.Path) field.ErrorList {
@@ -1213,6 +1953,33 @@ func validateCapabilities(c *types.Capabilities, fldPath *field.Path) field.Erro
 			allErrs = append(allErrs, field.NotSupported(fldPath.Child("additionalEnabledCapabilities").Index(i), capability, allAvailableCapabilities.List()))
 		}
 	}
+
+	additional := sets.New[configv1.ClusterVersionCapability](c.AdditionalEnabledCapabilities...)
+	disabled := sets.New[configv1.ClusterVersionCapability](c.DisabledCapabilities...)
+	for i, capability := range c.DisabledCapabilities {
+		switch {
+		case !allAvailableCapabilities.Has(string(capability)):
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("disabledCapabilities").Index(i), capability, allAvailableCapabilities.List()))
+		case additional.Has(capability):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("disabledCapabilities").Index(i), capability,
+				"capability is also listed in additionalEnabledCapabilities"))
+		}
+	}
+
+	// A capability cannot be disabled while a capability that requires it
+	// is enabled, since the dependencies of enabled capabilities are enabled
+	// automatically.
+	requested := sets.New[configv1.ClusterVersionCapability](configv1.ClusterVersionCapabilitySets[c.BaselineCapabilitySet]...)
+	requested.Insert(c.AdditionalEnabledCapabilities...)
+	for _, capability := range sets.List(requested.Difference(disabled)) {
+		for _, required := range sets.List(types.RequiredCapabilities(capability)) {
+			if !disabled.Has(required) {
+				continue
+			}
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("disabledCapabilities"), c.DisabledCapabilities,
+				fmt.Sprintf("the %s capability requires the %s capability, which is disabled; either disable %s as well or stop disabling %s", capability, required, capability, required)))
+		}
+	}
 	return allErrs
 }
 
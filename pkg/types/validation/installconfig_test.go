@@ -1,9 +1,18 @@
 package validation
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -284,6 +293,36 @@ func validDualStackNetworkingConfig() *types.Networking {
 	}
 }
 
+// servingCertificate returns a self-signed serving certificate valid for the
+// DNS names until notAfter.
+func servingCertificate(notAfter time.Time, dnsNames ...string) *types.CertificateKeyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return &types.CertificateKeyPair{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Key:         string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
 func TestValidateInstallConfig(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -322,1129 +361,2345 @@ func TestValidateInstallConfig(t *testing.T) {
 			expectedError: `^sshKey: Invalid value: "bad-ssh-key": ssh: no key found$`,
 		},
 		{
-			name: "invalid base domain",
+			name: "valid ssh keys",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.BaseDomain = ".bad-domain."
+				c.SSHKey = validSSHKey()
+				c.SSHKeys = []string{validSSHKey()}
 				return c
 			}(),
-			expectedError: `^baseDomain: Invalid value: "\.bad-domain\.": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '\.', and must start and end with an alphanumeric character \(e\.g\. 'example\.com', regex used for validation is '\[a-z0-9]\(\[-a-z0-9]\*\[a-z0-9]\)\?\(\\\.\[a-z0-9]\(\[-a-z0-9]\*\[a-z0-9]\)\?\)\*'\)$`,
 		},
 		{
-			name: "overly long cluster domain",
+			name: "invalid ssh keys",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ObjectMeta.Name = fmt.Sprintf("test-cluster%042d", 0)
-				c.BaseDomain = fmt.Sprintf("test-domain%056d.a%060d.b%060d.c%060d", 0, 0, 0, 0)
+				c.SSHKeys = []string{validSSHKey(), "bad-ssh-key"}
 				return c
 			}(),
-			expectedError: `^baseDomain: Invalid value: "` + fmt.Sprintf("test-cluster%042d.test-domain%056d.a%060d.b%060d.c%060d", 0, 0, 0, 0, 0) + `": must be no more than 253 characters$`,
+			expectedError: `^sshKeys\[1\]: Invalid value: "bad-ssh-key": ssh: no key found$`,
 		},
 		{
-			name: "missing networking",
+			name: "ssh keys on powervs",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking = nil
+				c.SSHKey = validSSHKey()
+				c.SSHKeys = []string{validSSHKey()}
+				c.GenerateSSHKey = true
+				c.Platform = types.Platform{
+					PowerVS: validPowerVSPlatform(),
+				}
 				return c
 			}(),
-			expectedError: `^networking: Required value: networking is required$`,
+			expectedError: `^\[sshKeys: Forbidden: additional SSH keys are not supported on platform powervs, generateSSHKey: Forbidden: generated SSH keys are not supported on platform powervs\]$`,
 		},
 		{
-			name: "invalid network type",
+			name: "valid audit",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = ""
+				c.Audit = &types.Audit{
+					Profile:     configv1.WriteRequestBodiesAuditProfileType,
+					CustomRules: []configv1.AuditCustomRule{{Group: "system:authenticated:oauth", Profile: configv1.AllRequestBodiesAuditProfileType}},
+				}
 				return c
 			}(),
-			expectedError: `^networking.networkType: Required value: network provider type required$`,
 		},
 		{
-			name: "missing service network",
+			name: "invalid audit profile",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ServiceNetwork = nil
+				c.Audit = &types.Audit{Profile: "Everything"}
 				return c
 			}(),
-			expectedError: `^networking\.serviceNetwork: Required value: a service network is required$`,
+			expectedError: `^audit\.profile: Unsupported value: "Everything": supported values: "Default", "WriteRequestBodies", "AllRequestBodies", "None"$`,
 		},
 		{
-			name: "invalid service network",
+			name: "duplicate audit custom rule group",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("13.0.128.0/16")
+				c.Audit = &types.Audit{
+					CustomRules: []configv1.AuditCustomRule{
+						{Group: "system:authenticated:oauth", Profile: configv1.AllRequestBodiesAuditProfileType},
+						{Group: "system:authenticated:oauth", Profile: configv1.NoneAuditProfileType},
+					},
+				}
 				return c
 			}(),
-			expectedError: `^networking\.serviceNetwork\[0\]: Invalid value: "13\.0\.128\.0/16": invalid network address. got 13\.0\.128\.0/16, expecting 13\.0\.0\.0/16$`,
+			expectedError: `^audit\.customRules\[1\]\.group: Duplicate value: "system:authenticated:oauth"$`,
 		},
 		{
-			name: "overlapping service network and machine cidr",
+			name: "valid etcd encryption",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("10.0.2.0/24")
+				c.EtcdEncryption = &types.EtcdEncryption{Type: types.EtcdEncryptionTypeAESGCM}
 				return c
 			}(),
-			expectedError: `^networking\.serviceNetwork\[0\]: Invalid value: "10\.0\.2\.0/24": service network must not overlap with any of the machine networks$`,
 		},
 		{
-			name: "overlapping machine network and machine network",
+			name: "invalid etcd encryption type",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.MachineNetwork = []types.MachineNetworkEntry{
-					{CIDR: *ipnet.MustParseCIDR("13.0.0.0/16")},
-					{CIDR: *ipnet.MustParseCIDR("13.0.2.0/24")},
-				}
-
+				c.EtcdEncryption = &types.EtcdEncryption{Type: "identity"}
 				return c
 			}(),
-			// also triggers the only-one-machine-network validation
-			expectedError: `^networking\.machineNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": machine network must not overlap with machine network 0$`,
+			expectedError: `^etcdEncryption\.type: Unsupported value: "identity": supported values: "aescbc", "aesgcm", "KMS"$`,
 		},
 		{
-			name: "overlapping service network and service network",
+			name: "valid etcd encryption with KMS",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ServiceNetwork = []ipnet.IPNet{
-					*ipnet.MustParseCIDR("13.0.0.0/16"),
-					*ipnet.MustParseCIDR("13.0.2.0/24"),
+				c.FeatureSet = configv1.TechPreviewNoUpgrade
+				c.EtcdEncryption = &types.EtcdEncryption{
+					Type: types.EtcdEncryptionTypeKMS,
+					KMS:  &types.EtcdEncryptionKMS{AWS: &types.AWSKMSKey{KeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}},
 				}
-
 				return c
 			}(),
-			// also triggers the only-one-service-network validation
-			expectedError: `^\[networking\.serviceNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": service network must not overlap with service network 0, networking\.serviceNetwork: Invalid value: "13\.0\.0\.0/16, 13\.0\.2\.0/24": only one service network can be specified]$`,
 		},
 		{
-			name: "missing machine networks",
+			name: "etcd encryption with KMS without the tech preview feature set",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.MachineNetwork = nil
+				c.EtcdEncryption = &types.EtcdEncryption{
+					Type: types.EtcdEncryptionTypeKMS,
+					KMS:  &types.EtcdEncryptionKMS{AWS: &types.AWSKMSKey{KeyARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}},
+				}
 				return c
 			}(),
-			expectedError: `^networking\.machineNetwork: Required value: at least one machine network is required$`,
+			expectedError: `^etcdEncryption\.type: Forbidden: KMS encryption requires the TechPreviewNoUpgrade or CustomNoUpgrade feature set$`,
 		},
 		{
-			name: "invalid machine cidr",
+			name: "etcd encryption with KMS key in another region",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.MachineNetwork = []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("11.0.128.0/16")}}
+				c.FeatureSet = configv1.TechPreviewNoUpgrade
+				c.EtcdEncryption = &types.EtcdEncryption{
+					Type: types.EtcdEncryptionTypeKMS,
+					KMS:  &types.EtcdEncryptionKMS{AWS: &types.AWSKMSKey{KeyARN: "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}},
+				}
 				return c
 			}(),
-			expectedError: `^networking\.machineNetwork\[0\]: Invalid value: "11\.0\.128\.0/16": invalid network address. got 11\.0\.128\.0/16, expecting 11\.0\.0\.0/16$`,
+			expectedError: `^etcdEncryption\.kms\.aws\.keyARN: Invalid value: "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab": the KMS key must be in the region of the cluster, us-east-1$`,
 		},
 		{
-			name: "invalid cluster network",
+			name: "etcd encryption with KMS without key",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{{CIDR: *ipnet.MustParseCIDR("12.0.128.0/16"), HostPrefix: 23}}
+				c.FeatureSet = configv1.TechPreviewNoUpgrade
+				c.EtcdEncryption = &types.EtcdEncryption{Type: types.EtcdEncryptionTypeKMS}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "12\.0\.128\.0/16": invalid network address. got 12\.0\.128\.0/16, expecting 12\.0\.0\.0/16$`,
+			expectedError: `^etcdEncryption\.kms\.aws\.keyARN: Required value: the ARN of the KMS key is required when type is KMS$`,
 		},
 		{
-			name: "overlapping cluster network and machine cidr",
+			name: "valid identity providers",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("10.0.3.0/24")
+				c.OAuth = &types.OAuth{
+					IdentityProviders: []types.IdentityProvider{{
+						Name:     "htpasswd",
+						HTPasswd: &types.HTPasswdIdentityProvider{FileData: "admin:$2y$05$hash"},
+					}, {
+						Name: "ldap",
+						LDAP: &types.LDAPIdentityProvider{
+							URL:        "ldaps://ldap.test-domain/ou=users,dc=test?uid",
+							Attributes: configv1.LDAPAttributeMapping{ID: []string{"dn"}},
+						},
+					}, {
+						Name: "sso",
+						OpenID: &types.OpenIDIdentityProvider{
+							ClientID:     "openshift",
+							ClientSecret: "secret",
+							Issuer:       "https://sso.test-domain",
+						},
+					}},
+					DisableKubeadmin: true,
+				}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "10\.0\.3\.0/24": cluster network must not overlap with any of the machine networks$`,
 		},
 		{
-			name: "overlapping cluster network and service network",
+			name: "kubeadmin disabled without identity providers",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("172.30.2.0/24")
+				c.OAuth = &types.OAuth{DisableKubeadmin: true}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "172\.30\.2\.0/24": cluster network must not overlap with service network 0$`,
+			expectedError: `^oauth\.disableKubeadmin: Invalid value: true: the kubeadmin user can only be disabled when an identity provider is configured$`,
 		},
 		{
-			name: "overlapping cluster network and cluster network",
+			name: "identity provider with several types",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{
-					{CIDR: *ipnet.MustParseCIDR("12.0.0.0/16"), HostPrefix: 23},
-					{CIDR: *ipnet.MustParseCIDR("12.0.3.0/24"), HostPrefix: 25},
+				c.OAuth = &types.OAuth{
+					IdentityProviders: []types.IdentityProvider{{
+						Name:     "both",
+						HTPasswd: &types.HTPasswdIdentityProvider{FileData: "admin:$2y$05$hash"},
+						OpenID: &types.OpenIDIdentityProvider{
+							ClientID:     "openshift",
+							ClientSecret: "secret",
+							Issuer:       "https://sso.test-domain",
+						},
+					}},
 				}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[1]\.cidr: Invalid value: "12\.0\.3\.0/24": cluster network must not overlap with cluster network 0$`,
+			expectedError: `^oauth\.identityProviders\[0\]: Invalid value: "both": exactly one of htpasswd, ldap and openID must be set$`,
 		},
 		{
-			name: "cluster network host prefix too large",
+			name: "openid identity provider with http issuer",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("192.168.1.0/24")
-				c.Networking.ClusterNetwork[0].HostPrefix = 23
+				c.OAuth = &types.OAuth{
+					IdentityProviders: []types.IdentityProvider{{
+						Name: "sso",
+						OpenID: &types.OpenIDIdentityProvider{
+							ClientID:     "openshift",
+							ClientSecret: "secret",
+							Issuer:       "http://sso.test-domain",
+						},
+					}},
+				}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.hostPrefix: Invalid value: 23: cluster network host subnetwork prefix must not be larger size than CIDR 192.168.1.0/24$`,
+			expectedError: `^oauth\.identityProviders\[0\]\.openID\.issuer: Invalid value: "http://sso\.test-domain": must use https protocol$`,
 		},
 		{
-			name: "cluster network host prefix unset",
+			name: "invalid additional trusted registry hostname",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = "OVNKubernetes"
-				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("192.168.1.0/24")
-				c.Networking.ClusterNetwork[0].HostPrefix = 0
+				c.AdditionalTrustedRegistryCAs = map[string]string{"mirror_example.com": "test-ca"}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetwork\[0]\.hostPrefix: Invalid value: 0: cluster network host subnetwork prefix must not be larger size than CIDR 192.168.1.0/24$`,
+			expectedError: `additionalTrustedRegistryCAs\[mirror_example\.com\]: Invalid value: "mirror_example\.com": must be the hostname of a registry, with an optional port`,
 		},
 		{
-			name: "cluster network host prefix unset ignored",
+			name: "invalid additional trusted registry port",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = "HostPrefixNotRequiredPlugin"
-				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("192.168.1.0/24")
+				c.AdditionalTrustedRegistryCAs = map[string]string{"mirror.example.com:70000": "test-ca"}
 				return c
 			}(),
-			expectedError: ``,
+			expectedError: `additionalTrustedRegistryCAs\[mirror\.example\.com:70000\]: Invalid value: "mirror\.example\.com:70000": invalid port`,
 		},
 		{
-			name: "networking clusterNetworkMTU - valid high limit ovn",
+			name: "invalid additional trusted registry CA",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
-				c.Networking.ClusterNetworkMTU = 8901
-				fmt.Println(c.Platform.Name())
+				c.AdditionalTrustedRegistryCAs = map[string]string{"mirror.example.com:5000": "test-ca"}
 				return c
 			}(),
+			expectedError: `^additionalTrustedRegistryCAs\[mirror\.example\.com:5000\]: Invalid value: "test-ca": invalid block$`,
 		},
 		{
-			name: "networking clusterNetworkMTU - valid low limit",
+			name: "valid additional NTP servers",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
-				c.Networking.ClusterNetworkMTU = 1000
+				c.AdditionalNTPServers = []string{"ntp.example.com", "192.168.1.10"}
 				return c
 			}(),
 		},
 		{
-			name: "networking clusterNetworkMTU - invalid value lower",
+			name: "invalid additional NTP server",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetworkMTU = 999
+				c.AdditionalNTPServers = []string{"ntp.example.com", "invalid_ntp.example.com"}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 999: cluster network MTU is lower than the minimum value of 1000$`,
+			expectedError: `^additionalNTPServers\[1\]: Invalid value: "invalid_ntp.example.com": NTP server is not a valid domain name nor a valid IP$`,
 		},
 		{
-			name: "networking clusterNetworkMTU - invalid value ovn",
+			name: "duplicate additional NTP server",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
-				c.Networking.ClusterNetworkMTU = 8951
+				c.AdditionalNTPServers = []string{"ntp.example.com", "ntp.example.com"}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 8951: cluster network MTU exceeds the maximum value with the network plugin OVNKubernetes of 8901$`,
+			expectedError: `^additionalNTPServers\[1\]: Duplicate value: "ntp.example.com"$`,
 		},
 		{
-			name: "networking clusterNetworkMTU - invalid jumbo value",
+			name: "valid cluster DNS",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.ClusterNetworkMTU = 9002
+				c.ClusterDNS = &types.ClusterDNS{
+					Forwarders: []types.DNSForwarder{{
+						Name:      "corp",
+						Zones:     []string{"corp.example.com"},
+						Upstreams: []string{"10.0.0.53", "[fd00::53]:5353"},
+						Policy:    operv1.SequentialForwardingPolicy,
+					}},
+					UpstreamResolvers: &types.DNSUpstreamResolvers{Upstreams: []string{"10.0.0.54"}},
+				}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 9002: cluster network MTU exceeds the maximum value of 9001$`,
 		},
 		{
-			name: "networking clusterNetworkMTU - invalid for non-aws",
+			name: "cluster hosted DNS on unsupported platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
-				c.Networking.ClusterNetworkMTU = 8901
-				c.Platform = types.Platform{
-					None: &none.Platform{},
-				}
+				c.ClusterDNS = &types.ClusterDNS{DNSType: configv1.ClusterHostedDNSType}
 				return c
 			}(),
-			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 8901: cluster network MTU is allowed only in AWS deployments`,
+			expectedError: `^clusterDNS\.dnsType: Forbidden: ClusterHosted DNS is not supported on platform aws$`,
 		},
 		{
-			name: "networking clusterNetworkMTU - unsupported network type",
+			name: "unsupported cluster DNS type",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Networking.NetworkType = string(operv1.NetworkTypeOpenShiftSDN)
-				c.Networking.ClusterNetworkMTU = 8000
+				c.ClusterDNS = &types.ClusterDNS{DNSType: "Cloud"}
 				return c
 			}(),
-			expectedError: `networking.networkType: Invalid value: "OpenShiftSDN": networkType OpenShiftSDN is not supported, please use OVNKubernetes, networking.clusterNetworkMTU: Invalid value: 8000: cluster network MTU is not valid with network plugin OpenShiftSDN`,
+			expectedError: `^clusterDNS\.dnsType: Unsupported value: "Cloud": supported values: "PlatformDefault", "ClusterHosted"$`,
 		},
 		{
-			name: "missing control plane",
+			name: "cluster DNS zone forwarded twice",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ControlPlane = nil
+				c.ClusterDNS = &types.ClusterDNS{
+					Forwarders: []types.DNSForwarder{
+						{Name: "corp", Zones: []string{"corp.example.com"}, Upstreams: []string{"10.0.0.53"}},
+						{Name: "corp-backup", Zones: []string{"corp.example.com"}, Upstreams: []string{"10.0.1.53"}},
+					},
+				}
 				return c
 			}(),
-			expectedError: `^controlPlane: Required value: controlPlane is required$`,
+			expectedError: `^clusterDNS\.forwarders\[1\]\.zones\[0\]: Duplicate value: "corp\.example\.com"$`,
 		},
 		{
-			name: "control plane with 0 replicas",
+			name: "cluster DNS upstream with a host name",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ControlPlane.Replicas = pointer.Int64Ptr(0)
+				c.ClusterDNS = &types.ClusterDNS{
+					UpstreamResolvers: &types.DNSUpstreamResolvers{Upstreams: []string{"ns.example.com:53"}},
+				}
 				return c
 			}(),
-			expectedError: `^controlPlane.replicas: Invalid value: 0: number of control plane replicas must be positive$`,
+			expectedError: `^clusterDNS\.upstreamResolvers\.upstreams\[0\]: Invalid value: "ns\.example\.com:53": "ns\.example\.com" is not an IP address$`,
 		},
 		{
-			name: "invalid control plane",
+			name: "cluster DNS forwarder without upstreams",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ControlPlane.Replicas = nil
+				c.ClusterDNS = &types.ClusterDNS{
+					Forwarders: []types.DNSForwarder{{Name: "corp", Zones: []string{"corp.example.com"}}},
+				}
 				return c
 			}(),
-			expectedError: `^controlPlane.replicas: Required value: replicas is required$`,
+			expectedError: `^clusterDNS\.forwarders\[0\]\.upstreams: Required value: at least one upstream is required$`,
 		},
 		{
-			name: "missing compute",
+			name: "valid performance profile",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute = nil
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.PerformanceProfile = &types.PerformanceProfile{
+					ReservedCPUs: "0-1,32-33",
+					IsolatedCPUs: "2-31,34-63",
+					HugePages: &types.HugePages{
+						DefaultSize: "1G",
+						Pages:       []types.HugePage{{Size: "1G", Count: 16, Node: pointer.Int32(0)}},
+					},
+					RealTimeKernel: true,
+				}
 				return c
 			}(),
 		},
 		{
-			name: "empty compute",
+			name: "performance profile on unsupported platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute = []types.MachinePool{}
+				c.PerformanceProfile = &types.PerformanceProfile{ReservedCPUs: "0-1", IsolatedCPUs: "2-7"}
 				return c
 			}(),
+			expectedError: `^performanceProfile: Forbidden: performance profiles are not supported on platform aws$`,
 		},
 		{
-			name: "duplicate compute",
+			name: "performance profile with overlapping CPUs",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute = []types.MachinePool{
-					*validMachinePool("worker"),
-					*validMachinePool("worker"),
-				}
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.PerformanceProfile = &types.PerformanceProfile{ReservedCPUs: "0-3", IsolatedCPUs: "2-7"}
 				return c
 			}(),
-			expectedError: `^compute\[1\]\.name: Duplicate value: "worker"$`,
+			expectedError: `^performanceProfile\.isolatedCPUs: Invalid value: "2-7": overlaps the reserved CPUs \[2 3\]$`,
 		},
 		{
-			name: "no compute replicas",
+			name: "performance profile with invalid CPU range",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute = []types.MachinePool{
-					func() types.MachinePool {
-						p := *validMachinePool("worker")
-						p.Replicas = pointer.Int64Ptr(0)
-						return p
-					}(),
-				}
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.PerformanceProfile = &types.PerformanceProfile{ReservedCPUs: "3-0", IsolatedCPUs: "4-7"}
 				return c
 			}(),
+			expectedError: `^performanceProfile\.reservedCPUs: Invalid value: "3-0": invalid CPU range "3-0"$`,
 		},
 		{
-			name: "missing platform",
+			name: "performance profile with unsupported huge page size",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{}
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.PerformanceProfile = &types.PerformanceProfile{
+					ReservedCPUs: "0-1",
+					IsolatedCPUs: "2-7",
+					HugePages:    &types.HugePages{Pages: []types.HugePage{{Size: "4K", Count: 1}}},
+				}
 				return c
 			}(),
-			expectedError: `^platform: Invalid value: "": must specify one of the platforms \(aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, powervs, vsphere\)$`,
+			expectedError: `^performanceProfile\.hugePages\.pages\[0\]\.size: Unsupported value: "4K": supported values: "2M", "1G"$`,
 		},
 		{
-			name: "multiple platforms",
+			name: "CIS hardening profile",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform.Libvirt = validLibvirtPlatform()
+				c.HardeningProfile = types.HardeningProfileCIS
 				return c
 			}(),
-			expectedError: `^platform: Invalid value: "aws": must only specify a single type of platform; cannot use both "aws" and "libvirt"$`,
 		},
 		{
-			name: "invalid aws platform",
+			name: "unsupported hardening profile",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					AWS: &aws.Platform{},
-				}
+				c.HardeningProfile = "STIG"
 				return c
 			}(),
-			expectedError: `^platform\.aws\.region: Required value: region must be specified$`,
+			expectedError: `^hardeningProfile: Unsupported value: "STIG": supported values: "None", "CIS"$`,
 		},
 		{
-			name: "valid libvirt platform",
+			name: "S3 image registry storage",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					Libvirt: validLibvirtPlatform(),
-				}
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					S3: &types.ImageRegistryStorageS3{Bucket: "test-cluster-registry"},
+				}}
 				return c
 			}(),
-			expectedError: `^platform: Invalid value: "libvirt": must specify one of the platforms \(aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, powervs, vsphere\)$`,
 		},
 		{
-			name: "invalid libvirt platform",
+			name: "invalid S3 image registry bucket",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					Libvirt: validLibvirtPlatform(),
-				}
-				c.Platform.Libvirt.URI = ""
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					S3: &types.ImageRegistryStorageS3{Bucket: "Registry"},
+				}}
 				return c
 			}(),
-			expectedError: `^\[platform: Invalid value: "libvirt": must specify one of the platforms \(aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, powervs, vsphere\), platform\.libvirt\.uri: Invalid value: "": invalid URI "" \(no scheme\)]$`,
+			expectedError: `^imageRegistry\.storage\.s3\.bucket: Invalid value: "Registry": must be 3 to 63 lower case letters, digits, dots and hyphens, starting and ending with a letter or a digit, and not an IP address$`,
 		},
 		{
-			name: "valid none platform",
+			name: "GCS image registry storage on AWS",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					None: &none.Platform{},
-				}
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					GCS: &types.ImageRegistryStorageGCS{Bucket: "registry"},
+				}}
 				return c
 			}(),
+			expectedError: `^imageRegistry\.storage\.gcs: Forbidden: GCS storage is not supported on platform aws$`,
 		},
 		{
-			name: "valid openstack platform",
+			name: "PVC image registry storage",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					OpenStack: validOpenStackPlatform(),
-				}
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					PVC: &types.ImageRegistryStoragePVC{StorageClassName: "gp3-csi", Size: "200Gi", AccessMode: "ReadWriteOnce"},
+				}}
 				return c
 			}(),
 		},
 		{
-			name: "valid baremetal platform",
+			name: "invalid PVC image registry storage",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					BareMetal: validBareMetalPlatform(),
-				}
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					PVC: &types.ImageRegistryStoragePVC{Size: "-1Gi", AccessMode: "ReadOnlyMany"},
+				}}
 				return c
 			}(),
+			expectedError: `^\[imageRegistry\.storage\.pvc\.size: Invalid value: "-1Gi": size must be positive, imageRegistry\.storage\.pvc\.accessMode: Unsupported value: "ReadOnlyMany": supported values: "ReadWriteMany", "ReadWriteOnce"\]$`,
 		},
 		{
-			name: "valid vsphere platform",
+			name: "emptyDir image registry storage on multiple nodes",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					VSphere: validVSpherePlatform(),
-				}
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					EmptyDir: &types.ImageRegistryStorageEmptyDir{},
+				}}
 				return c
 			}(),
+			expectedError: `^imageRegistry\.storage\.emptyDir: Forbidden: emptyDir storage is only supported on single-node clusters, where a single registry replica runs$`,
 		},
 		{
-			name: "invalid vsphere platform",
+			name: "several image registry storage backends",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					VSphere: validVSpherePlatform(),
-				}
-				c.Platform.VSphere.VCenters[0].Server = ""
+				c.ImageRegistry = &types.ImageRegistry{Storage: types.ImageRegistryStorage{
+					S3:  &types.ImageRegistryStorageS3{Bucket: "registry"},
+					PVC: &types.ImageRegistryStoragePVC{},
+				}}
 				return c
 			}(),
-			expectedError: `platform\.vsphere\.vcenters\.server: Required value: must be the domain name or IP address of the vCenter(.*)`,
+			expectedError: `^imageRegistry\.storage: Invalid value: "s3, pvc": only one storage backend may be set$`,
 		},
 		{
-			name: "invalid vsphere folder",
+			name: "no image registry storage backend",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					VSphere: validVSpherePlatform(),
-				}
-				c.Platform.VSphere.FailureDomains[0].Topology.Folder = "my-folder"
+				c.ImageRegistry = &types.ImageRegistry{}
 				return c
 			}(),
-			expectedError: `^platform\.vsphere\.failureDomains\.topology.folder: Invalid value: "my-folder": full path of folder must be provided in format /<datacenter>/vm/<folder>$`,
+			expectedError: `^imageRegistry\.storage: Required value: one of s3, azure, gcs, pvc and emptyDir must be set$`,
 		},
 		{
-			name: "invalid vsphere resource pool",
+			name: "valid bastion",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					VSphere: validVSpherePlatform(),
-				}
-				c.Platform.VSphere.FailureDomains[0].Topology.ResourcePool = "my-resource-pool"
+				c.Publish = types.InternalPublishingStrategy
+				c.Bastion = &types.Bastion{InstanceType: "t3.small"}
 				return c
 			}(),
-			expectedError: `^platform\.vsphere\.failureDomains\.topology\.resourcePool: Invalid value: "my-resource-pool": full path of resource pool must be provided in format /<datacenter>/host/<cluster>/\.\.\.$`,
 		},
 		{
-			name: "empty proxy settings",
+			name: "bastion for external cluster",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = ""
-				c.Proxy.HTTPSProxy = ""
-				c.Proxy.NoProxy = ""
+				c.Bastion = &types.Bastion{}
 				return c
 			}(),
-			expectedError: `^proxy: Required value: must include httpProxy or httpsProxy$`,
+			expectedError: `^bastion: Forbidden: a bastion host is only supported for private clusters, whose publish strategy is Internal or Mixed$`,
 		},
 		{
-			name: "invalid HTTPProxy",
+			name: "bastion on unsupported platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://bad%20uri"
+				c.Platform = types.Platform{
+					BareMetal: validBareMetalPlatform(),
+				}
+				c.Publish = types.InternalPublishingStrategy
+				c.Bastion = &types.Bastion{}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Invalid value: "http://bad%20uri": parse "http://bad%20uri": invalid URL escape "%20"$`,
+			expectedError: `bastion: Forbidden: a bastion host is not supported on platform baremetal`,
 		},
 		{
-			name: "invalid HTTPProxy Schema missing",
+			name: "valid mixed publish",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http//baduri"
+				c.Publish = types.MixedPublishingStrategy
+				c.OperatorPublishingStrategy = &types.OperatorPublishingStrategy{APIServer: "Internal"}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Invalid value: "http//baduri": parse "http//baduri": invalid URI for request$`,
 		},
 		{
-			name: "HTTPProxy with port overlapping with Cluster Networks",
+			name: "mixed publish with invalid API server strategy",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://192.168.1.25:3030"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Publish = types.MixedPublishingStrategy
+				c.OperatorPublishingStrategy = &types.OperatorPublishingStrategy{APIServer: "Private"}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Invalid value: "http://192.168.1.25:3030": proxy value is part of the cluster networks$`,
+			expectedError: `^operatorPublishingStrategy\.apiserver: Unsupported value: "Private": supported values: "External", "Internal"$`,
 		},
 		{
-			name: "overlapping HTTPProxy and Cluster Networks",
+			name: "mixed publish on unsupported platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://192.168.1.25"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Platform = types.Platform{
+					BareMetal: validBareMetalPlatform(),
+				}
+				c.Publish = types.MixedPublishingStrategy
+				c.OperatorPublishingStrategy = &types.OperatorPublishingStrategy{Ingress: "Internal"}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks$`,
+			expectedError: `publish: Invalid value: "Mixed": mixed publish strategy is not supported on "baremetal" platform`,
 		},
 		{
-			name: "non-overlapping HTTPProxy and Cluster Networks",
+			name: "valid custom endpoints",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://192.169.1.25"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Endpoints = &types.ClusterEndpoints{
+					APIHostname:         "k8s.example.com",
+					APIInternalHostname: "k8s-internal.example.com",
+					IngressDomain:       "apps.example.com",
+				}
 				return c
 			}(),
 		},
 		{
-			name: "overlapping HTTPProxy and more than one Cluster Networks",
+			name: "invalid custom API hostname",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://192.168.1.25"
-				c.Networking = validIPv4NetworkingConfig()
-				c.ClusterNetwork = append(c.ClusterNetwork, []types.ClusterNetworkEntry{
-					{
-						CIDR:       *ipnet.MustParseCIDR("192.168.0.0/16"),
-						HostPrefix: 28,
-					},
-				}...,
-				)
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Endpoints = &types.ClusterEndpoints{APIHostname: "k8s_api.example.com"}
 				return c
 			}(),
-			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0, proxy.httpProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
+			expectedError: `^endpoints\.apiHostname: Invalid value: "k8s_api\.example\.com": .*$`,
 		},
 		{
-			name: "non-overlapping HTTPProxy and Service Networks",
+			name: "ingress domain same as API hostname",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://172.31.0.25"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Endpoints = &types.ClusterEndpoints{APIHostname: "k8s.example.com", IngressDomain: "k8s.example.com"}
 				return c
 			}(),
+			expectedError: `^endpoints\.ingressDomain: Invalid value: "k8s\.example\.com": must not be the same as the API hostnames$`,
 		},
 		{
-			name: "HTTPProxy with port overlapping with Service Networks",
+			name: "custom API hostname on platform with installer provisioned DNS",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://172.30.0.25:3030"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Endpoints = &types.ClusterEndpoints{APIHostname: "k8s.example.com", IngressDomain: "apps.example.com"}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Invalid value: "http://172.30.0.25:3030": proxy value is part of the service networks$`,
+			expectedError: `^endpoints\.apiHostname: Invalid value: "k8s\.example\.com": a custom API hostname is not supported on platform aws, where the installer provisions the API DNS records$`,
 		},
 		{
-			name: "overlapping HTTPProxy and Service Networks",
+			name: "custom API port on platform none",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://172.30.0.25"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Endpoints = &types.ClusterEndpoints{APIPort: 443}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks$`,
 		},
 		{
-			name: "overlapping HTTPProxy and more than one Service Networks",
+			name: "custom API port on platform with an installer provisioned load balancer",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "http://172.30.0.25"
-				c.Networking = validIPv4NetworkingConfig()
-				c.ServiceNetwork = append(c.ServiceNetwork, []ipnet.IPNet{
-					*ipnet.MustParseCIDR("172.30.1.0/24"),
-				}...,
-				)
+				c.Endpoints = &types.ClusterEndpoints{APIPort: 443}
 				return c
 			}(),
-			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
+			expectedError: `^endpoints\.apiPort: Invalid value: 443: a custom API port is not supported on platform aws, where the installer provisions the API load balancer$`,
 		},
 		{
-			name: "non-overlapping HTTPSProxy and Cluster Networks",
+			name: "custom API port on platform with a user managed load balancer",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://192.168.2.25"
-				c.Networking = validIPv4NetworkingConfig()
+				c.FeatureSet = configv1.TechPreviewNoUpgrade
+				c.Platform = types.Platform{BareMetal: validBareMetalPlatform()}
+				c.Platform.BareMetal.LoadBalancer = &configv1.BareMetalPlatformLoadBalancer{Type: configv1.LoadBalancerTypeUserManaged}
+				c.Endpoints = &types.ClusterEndpoints{APIPort: 443}
 				return c
 			}(),
 		},
 		{
-			name: "HTTPSProxy with port overlapping with Cluster Networks",
+			name: "custom API port on platform with a cluster managed load balancer",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://192.168.1.25:3030"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Platform = types.Platform{BareMetal: validBareMetalPlatform()}
+				c.Endpoints = &types.ClusterEndpoints{APIPort: 443}
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Invalid value: "http://192.168.1.25:3030": proxy value is part of the cluster networks$`,
+			expectedError: `^endpoints\.apiPort: Invalid value: 443: a custom API port requires loadBalancer\.type UserManaged on platform baremetal, as the cluster managed load balancer serves the API on port 6443$`,
 		},
 		{
-			name: "overlapping HTTPSProxy and Cluster Networks",
+			name: "custom API port reserved for the machine config server",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://192.168.1.25"
-				c.Networking = validIPv4NetworkingConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Endpoints = &types.ClusterEndpoints{APIPort: 22623}
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks$`,
+			expectedError: `^endpoints\.apiPort: Invalid value: 22623: is reserved for the machine config server$`,
 		},
 		{
-			name: "overlapping HTTPSProxy and more than one Cluster Networks",
+			name: "valid serving certificates",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://192.168.1.25"
-				c.Networking = validIPv4NetworkingConfig()
-				c.ClusterNetwork = append(c.ClusterNetwork, []types.ClusterNetworkEntry{
-					{
-						CIDR:       *ipnet.MustParseCIDR("192.168.0.0/16"),
-						HostPrefix: 28,
-					},
-				}...,
-				)
+				c.ServingCertificates = &types.ServingCertificates{
+					APIServer: servingCertificate(time.Now().Add(time.Hour), "api.test-cluster.test-domain"),
+					Ingress:   servingCertificate(time.Now().Add(time.Hour), "*.apps.test-cluster.test-domain"),
+				}
 				return c
 			}(),
-			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0, proxy.httpsProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
 		},
 		{
-			name: "overlapping HTTPSProxy and Service Networks",
+			name: "serving certificate without key",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://172.30.0.25"
-				c.Networking = validIPv4NetworkingConfig()
+				cert := servingCertificate(time.Now().Add(time.Hour), "api.test-cluster.test-domain")
+				cert.Key = ""
+				c.ServingCertificates = &types.ServingCertificates{APIServer: cert}
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks$`,
+			expectedError: `^servingCertificates\.apiServer\.key: Required value: key is required$`,
 		},
 		{
-			name: "HTTPSProxy with port overlapping with Service Networks",
+			name: "serving certificate with mismatched key",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://172.30.0.25:3030"
-				c.Networking = validIPv4NetworkingConfig()
+				cert := servingCertificate(time.Now().Add(time.Hour), "api.test-cluster.test-domain")
+				cert.Key = servingCertificate(time.Now().Add(time.Hour), "api.test-cluster.test-domain").Key
+				c.ServingCertificates = &types.ServingCertificates{APIServer: cert}
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Invalid value: "http://172.30.0.25:3030": proxy value is part of the service networks$`,
+			expectedError: `^servingCertificates\.apiServer: Invalid value: "<redacted>": invalid certificate and key: tls: private key does not match public key$`,
 		},
 		{
-			name: "overlapping HTTPSProxy and more than one Service Networks",
+			name: "expired serving certificate",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http://172.30.0.25"
-				c.Networking = validIPv4NetworkingConfig()
-				c.ServiceNetwork = append(c.ServiceNetwork, []ipnet.IPNet{
-					*ipnet.MustParseCIDR("172.30.1.0/24"),
-				}...,
-				)
+				c.ServingCertificates = &types.ServingCertificates{
+					APIServer: servingCertificate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "api.test-cluster.test-domain"),
+				}
 				return c
 			}(),
-			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpsProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
+			expectedError: `^servingCertificates\.apiServer\.certificate: Invalid value: "<redacted>": certificate expired on 2020-01-01T00:00:00Z$`,
 		},
 		{
-			name: "invalid HTTPProxy Schema different schema",
+			name: "ingress serving certificate not valid for the wildcard",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPProxy = "ftp://baduri"
+				c.ServingCertificates = &types.ServingCertificates{
+					Ingress: servingCertificate(time.Now().Add(time.Hour), "console.apps.test-cluster.test-domain"),
+				}
 				return c
 			}(),
-			expectedError: `^proxy.httpProxy: Unsupported value: "ftp": supported values: "http"$`,
+			expectedError: `^servingCertificates\.ingress\.certificate: Invalid value: "<redacted>": certificate is not valid for \*\.apps\.test-cluster\.test-domain$`,
 		},
 		{
-			name: "invalid HTTPSProxy",
+			name: "invalid base domain",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "https://bad%20uri"
+				c.BaseDomain = ".bad-domain."
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Invalid value: "https://bad%20uri": parse "https://bad%20uri": invalid URL escape "%20"$`,
+			expectedError: `^baseDomain: Invalid value: "\.bad-domain\.": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '\.', and must start and end with an alphanumeric character \(e\.g\. 'example\.com', regex used for validation is '\[a-z0-9]\(\[-a-z0-9]\*\[a-z0-9]\)\?\(\\\.\[a-z0-9]\(\[-a-z0-9]\*\[a-z0-9]\)\?\)\*'\)$`,
 		},
 		{
-			name: "invalid HTTPSProxy Schema missing",
+			name: "overly long cluster domain",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "http//baduri"
+				c.ObjectMeta.Name = fmt.Sprintf("test-cluster%042d", 0)
+				c.BaseDomain = fmt.Sprintf("test-domain%056d.a%060d.b%060d.c%060d", 0, 0, 0, 0)
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Invalid value: "http//baduri": parse "http//baduri": invalid URI for request$`,
+			expectedError: `^baseDomain: Invalid value: "` + fmt.Sprintf("test-cluster%042d.test-domain%056d.a%060d.b%060d.c%060d", 0, 0, 0, 0, 0) + `": must be no more than 253 characters$`,
 		},
 		{
-			name: "invalid HTTPSProxy Schema different schema",
+			name: "missing networking",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.HTTPSProxy = "ftp://baduri"
+				c.Networking = nil
 				return c
 			}(),
-			expectedError: `^proxy.httpsProxy: Unsupported value: "ftp": supported values: "http", "https"$`,
+			expectedError: `^networking: Required value: networking is required$`,
 		},
 		{
-			name: "invalid NoProxy domain",
+			name: "invalid network type",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.NoProxy = "good-no-proxy.com,*.bad-proxy"
+				c.Networking.NetworkType = ""
 				return c
 			}(),
-			expectedError: `^\Qproxy.noProxy: Invalid value: "good-no-proxy.com,*.bad-proxy": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 1 "*.bad-proxy"\E$`,
+			expectedError: `^networking.networkType: Required value: network provider type required$`,
 		},
 		{
-			name: "invalid NoProxy spaces",
+			name: "missing service network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.NoProxy = "good-no-proxy.com, *.bad-proxy"
+				c.Networking.ServiceNetwork = nil
 				return c
 			}(),
-			expectedError: `^\Q[proxy.noProxy: Invalid value: "good-no-proxy.com, *.bad-proxy": noProxy must not have spaces, proxy.noProxy: Invalid value: "good-no-proxy.com, *.bad-proxy": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 1 "*.bad-proxy"]\E$`,
+			expectedError: `^networking\.serviceNetwork: Required value: a service network is required$`,
 		},
 		{
-			name: "invalid NoProxy CIDR",
+			name: "invalid service network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.NoProxy = "good-no-proxy.com,172.bad.CIDR.0/16"
+				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("13.0.128.0/16")
 				return c
 			}(),
-			expectedError: `^\Qproxy.noProxy: Invalid value: "good-no-proxy.com,172.bad.CIDR.0/16": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 1 "172.bad.CIDR.0/16"\E$`,
+			expectedError: `^networking\.serviceNetwork\[0\]: Invalid value: "13\.0\.128\.0/16": invalid network address. got 13\.0\.128\.0/16, expecting 13\.0\.0\.0/16$`,
 		},
 		{
-			name: "invalid NoProxy domain & CIDR",
+			name: "overlapping service network and machine cidr",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.NoProxy = "good-no-proxy.com,a-good-one,*.bad-proxy.,another,172.bad.CIDR.0/16,good-end"
+				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("10.0.2.0/24")
 				return c
 			}(),
-			expectedError: `^\Q[proxy.noProxy: Invalid value: "good-no-proxy.com,a-good-one,*.bad-proxy.,another,172.bad.CIDR.0/16,good-end": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 2 "*.bad-proxy.", proxy.noProxy: Invalid value: "good-no-proxy.com,a-good-one,*.bad-proxy.,another,172.bad.CIDR.0/16,good-end": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 4 "172.bad.CIDR.0/16"]\E$`,
+			expectedError: `^networking\.serviceNetwork\[0\]: Invalid value: "10\.0\.2\.0/24": service network must not overlap with any of the machine networks$`,
 		},
 		{
-			name: "valid * NoProxy",
+			name: "overlapping machine network and machine network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Proxy.NoProxy = "*"
+				c.Networking.MachineNetwork = []types.MachineNetworkEntry{
+					{CIDR: *ipnet.MustParseCIDR("13.0.0.0/16")},
+					{CIDR: *ipnet.MustParseCIDR("13.0.2.0/24")},
+				}
+
 				return c
 			}(),
+			// also triggers the only-one-machine-network validation
+			expectedError: `^networking\.machineNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": machine network must not overlap with machine network 0$`,
 		},
 		{
-			name: "valid GCP platform",
+			name: "overlapping service network and service network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					GCP: validGCPPlatform(),
+				c.Networking.ServiceNetwork = []ipnet.IPNet{
+					*ipnet.MustParseCIDR("13.0.0.0/16"),
+					*ipnet.MustParseCIDR("13.0.2.0/24"),
 				}
+
 				return c
 			}(),
+			// also triggers the only-one-service-network validation
+			expectedError: `^\[networking\.serviceNetwork\[1\]: Invalid value: "13\.0\.2\.0/24": service network must not overlap with service network 0, networking\.serviceNetwork: Invalid value: "13\.0\.0\.0/16, 13\.0\.2\.0/24": only one service network can be specified]$`,
 		},
 		{
-			name: "invalid GCP cluster name",
+			name: "missing machine networks",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					GCP: validGCPPlatform(),
-				}
-				c.ObjectMeta.Name = "1-invalid-cluster"
+				c.Networking.MachineNetwork = nil
 				return c
 			}(),
-			expectedError: `^metadata\.name: Invalid value: "1-invalid-cluster": cluster name must begin with a lower-case letter$`,
+			expectedError: `^networking\.machineNetwork: Required value: at least one machine network is required$`,
 		},
 		{
-			name: "valid ibmcloud platform",
+			name: "invalid machine cidr",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					IBMCloud: validIBMCloudPlatform(),
-				}
+				c.Networking.MachineNetwork = []types.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("11.0.128.0/16")}}
 				return c
 			}(),
+			expectedError: `^networking\.machineNetwork\[0\]: Invalid value: "11\.0\.128\.0/16": invalid network address. got 11\.0\.128\.0/16, expecting 11\.0\.0\.0/16$`,
 		},
 		{
-			name: "invalid ibmcloud platform",
+			name: "invalid cluster network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					IBMCloud: &ibmcloud.Platform{},
-				}
+				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{{CIDR: *ipnet.MustParseCIDR("12.0.128.0/16"), HostPrefix: 23}}
 				return c
 			}(),
-			expectedError: `^\Qplatform.ibmcloud.region: Required value: region must be specified\E$`,
+			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "12\.0\.128\.0/16": invalid network address. got 12\.0\.128\.0/16, expecting 12\.0\.0\.0/16$`,
 		},
 		{
-			name: "valid powervs platform",
+			name: "overlapping cluster network and machine cidr",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.SSHKey = validSSHKey()
-				c.Platform = types.Platform{
-					PowerVS: validPowerVSPlatform(),
-				}
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("10.0.3.0/24")
 				return c
 			}(),
+			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "10\.0\.3\.0/24": cluster network must not overlap with any of the machine networks$`,
 		},
 		{
-			name: "valid powervs platform manual credential mod",
+			name: "overlapping cluster network and service network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.SSHKey = validSSHKey()
-				c.Platform = types.Platform{
-					PowerVS: validPowerVSPlatform(),
-				}
-				c.CredentialsMode = types.ManualCredentialsMode
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("172.30.2.0/24")
 				return c
 			}(),
+			expectedError: `^networking\.clusterNetwork\[0]\.cidr: Invalid value: "172\.30\.2\.0/24": cluster network must not overlap with service network 0$`,
 		},
 		{
-			name: "invalid powervs platform mint credential mod",
+			name: "overlapping cluster network and cluster network",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.SSHKey = validSSHKey()
-				c.Platform = types.Platform{
-					PowerVS: validPowerVSPlatform(),
+				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{
+					{CIDR: *ipnet.MustParseCIDR("12.0.0.0/16"), HostPrefix: 23},
+					{CIDR: *ipnet.MustParseCIDR("12.0.3.0/24"), HostPrefix: 25},
 				}
-				c.CredentialsMode = types.MintCredentialsMode
 				return c
 			}(),
-			expectedError: `^credentialsMode: Unsupported value: "Mint": supported values: "Manual"$`,
+			expectedError: `^networking\.clusterNetwork\[1]\.cidr: Invalid value: "12\.0\.3\.0/24": cluster network must not overlap with cluster network 0$`,
 		},
 		{
-			name: "invalid powervs platform",
+			name: "cluster network host prefix too large",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.SSHKey = validSSHKey()
-				c.Platform = types.Platform{
-					PowerVS: &powervs.Platform{},
-				}
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("192.168.1.0/24")
+				c.Networking.ClusterNetwork[0].HostPrefix = 23
 				return c
 			}(),
-			expectedError: `^\Qplatform.powervs.zone: Required value: zone must be specified\E$`,
+			expectedError: `^networking\.clusterNetwork\[0]\.hostPrefix: Invalid value: 23: cluster network host subnetwork prefix must not be larger size than CIDR 192.168.1.0/24$`,
 		},
 		{
-			name: "valid azurestack platform",
+			name: "cluster network host prefix unset",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					Azure: validAzureStackPlatform(),
-				}
+				c.Networking.NetworkType = "OVNKubernetes"
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("192.168.1.0/24")
+				c.Networking.ClusterNetwork[0].HostPrefix = 0
 				return c
 			}(),
+			expectedError: `^networking\.clusterNetwork\[0]\.hostPrefix: Invalid value: 0: cluster network host subnetwork prefix must not be larger size than CIDR 192.168.1.0/24$`,
 		},
 		{
-			name: "invalid azurestack platform mint credentials mod",
+			name: "cluster network host prefix unset ignored",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{
-					Azure: validAzureStackPlatform(),
-				}
-				c.CredentialsMode = types.MintCredentialsMode
+				c.Networking.NetworkType = "HostPrefixNotRequiredPlugin"
+				c.Networking.ClusterNetwork[0].CIDR = *ipnet.MustParseCIDR("192.168.1.0/24")
 				return c
 			}(),
-			expectedError: `^credentialsMode: Unsupported value: "Mint": supported values: "Manual"$`,
+			expectedError: ``,
 		},
 		{
-			name: "release image source is not valid",
+			name: "cluster network host subnets for all nodes",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source: "ocp/release-x.y",
-				}}
+				c.ControlPlane.Replicas = pointer.Int64Ptr(3)
+				c.Compute[0].Replicas = pointer.Int64Ptr(13)
+				return c
+			}(),
+		},
+		{
+			name: "cluster network host subnets fewer than nodes",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = pointer.Int64Ptr(3)
+				c.Compute[0].Replicas = pointer.Int64Ptr(14)
+				return c
+			}(),
+			expectedError: `^networking\.clusterNetwork: Invalid value: "192\.168\.1\.0/24/hostPrefix 28": the IPv4 cluster networks provide 16 host subnets, which is fewer than the 17 nodes of the cluster; use a larger cluster network or a larger hostPrefix$`,
+		},
+		{
+			name: "cluster network host subnets and other networking errors",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = pointer.Int64Ptr(3)
+				c.Compute[0].Replicas = pointer.Int64Ptr(14)
+				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("10.0.2.0/24")
+				return c
+			}(),
+			expectedError: `^\[networking\.serviceNetwork\[0]: Invalid value: "10\.0\.2\.0/24": service network must not overlap with any of the machine networks, networking\.clusterNetwork: Invalid value: "192\.168\.1\.0/24/hostPrefix 28": the IPv4 cluster networks provide 16 host subnets, which is fewer than the 17 nodes of the cluster; use a larger cluster network or a larger hostPrefix]$`,
+		},
+		{
+			name: "networking clusterNetworkMTU - valid high limit ovn",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
+				c.Networking.ClusterNetworkMTU = 8901
+				fmt.Println(c.Platform.Name())
+				return c
+			}(),
+		},
+		{
+			name: "networking clusterNetworkMTU - valid low limit",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
+				c.Networking.ClusterNetworkMTU = 1000
+				return c
+			}(),
+		},
+		{
+			name: "networking clusterNetworkMTU - invalid value lower",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetworkMTU = 999
+				return c
+			}(),
+			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 999: cluster network MTU is lower than the minimum value of 1000$`,
+		},
+		{
+			name: "networking clusterNetworkMTU - invalid value ovn",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
+				c.Networking.ClusterNetworkMTU = 8951
+				return c
+			}(),
+			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 8951: cluster network MTU exceeds the maximum value with the network plugin OVNKubernetes of 8901$`,
+		},
+		{
+			name: "networking clusterNetworkMTU - invalid jumbo value",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetworkMTU = 9002
+				return c
+			}(),
+			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 9002: cluster network MTU exceeds the maximum value of 9001$`,
+		},
+		{
+			name: "networking clusterNetworkMTU - invalid for non-aws",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = string(operv1.NetworkTypeOVNKubernetes)
+				c.Networking.ClusterNetworkMTU = 8901
+				c.Platform = types.Platform{
+					None: &none.Platform{},
+				}
+				return c
+			}(),
+			expectedError: `^networking\.clusterNetworkMTU: Invalid value: 8901: cluster network MTU is allowed only in AWS deployments`,
+		},
+		{
+			name: "networking clusterNetworkMTU - unsupported network type",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = string(operv1.NetworkTypeOpenShiftSDN)
+				c.Networking.ClusterNetworkMTU = 8000
+				return c
+			}(),
+			expectedError: `networking.networkType: Invalid value: "OpenShiftSDN": networkType OpenShiftSDN is not supported, please use OVNKubernetes, networking.clusterNetworkMTU: Invalid value: 8000: cluster network MTU is not valid with network plugin OpenShiftSDN`,
+		},
+		{
+			name: "valid ovnKubernetesConfig",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{
+					MTU:              8855,
+					GatewayMode:      types.OVNGatewayModeLocal,
+					V4InternalSubnet: ipnet.MustParseCIDR("100.65.0.0/16"),
+					IPsecMode:        operv1.IPsecModeFull,
+				}
+				return c
+			}(),
+		},
+		{
+			name: "ovnKubernetesConfig MTU exceeds the platform limit with IPsec",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{
+					MTU:       8901,
+					IPsecMode: operv1.IPsecModeExternal,
+				}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 8901: MTU exceeds the maximum value of 8855 on platform aws$`,
+		},
+		{
+			name: "ovnKubernetesConfig MTU on platform none",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 8901}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 8901: MTU exceeds the maximum value of 8900 on platform none$`,
+		},
+		{
+			name: "ovnKubernetesConfig MTU lower than the minimum",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 576}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 576: MTU is lower than the minimum value of 1000$`,
+		},
+		{
+			name: "ovnKubernetesConfig MTU different from clusterNetworkMTU",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetworkMTU = 8000
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{MTU: 8500}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.mtu: Invalid value: 8500: MTU must match clusterNetworkMTU 8000$`,
+		},
+		{
+			name: "ovnKubernetesConfig with another network type",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = "Calico"
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{GatewayMode: types.OVNGatewayModeLocal}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig: Invalid value: "Calico": ovnKubernetesConfig is only valid with the network type OVNKubernetes$`,
+		},
+		{
+			name: "ovnKubernetesConfig unsupported gateway mode",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{GatewayMode: "Remote"}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.gatewayMode: Unsupported value: "Remote": supported values: "Local", "Shared"$`,
+		},
+		{
+			name: "ovnKubernetesConfig shared gateway mode on powervs",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.SSHKey = validSSHKey()
+				c.Platform = types.Platform{
+					PowerVS: validPowerVSPlatform(),
+				}
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{GatewayMode: types.OVNGatewayModeShared}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.gatewayMode: Invalid value: "Shared": gateway mode Shared is not supported on platform powervs$`,
+		},
+		{
+			name: "ovnKubernetesConfig internal subnet overlapping the service network",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{V4InternalSubnet: ipnet.MustParseCIDR("172.30.0.0/20")}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.v4InternalSubnet: Invalid value: "172\.30\.0\.0/20": internal subnet must not overlap with any of the service networks$`,
+		},
+		{
+			name: "ovnKubernetesConfig IPv6 internal subnet",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.OVNKubernetesConfig = &types.OVNKubernetesConfig{V4InternalSubnet: ipnet.MustParseCIDR("fd98::/64")}
+				return c
+			}(),
+			expectedError: `^networking\.ovnKubernetesConfig\.v4InternalSubnet: Invalid value: "fd98::/64": must be an IPv4 network$`,
+		},
+		{
+			name: "valid additional networks and egress IPs",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.AdditionalNetworks = []types.AdditionalNetwork{
+					{Name: "fronthaul", Namespace: "ran", Config: `{"cniVersion":"0.3.1","type":"macvlan","master":"ens4"}`},
+					{Name: "fronthaul", Config: `{"cniVersion":"0.3.1","name":"fronthaul","plugins":[{"type":"bridge"}]}`},
+				}
+				c.Networking.EgressIPs = []types.EgressIP{{
+					Name:              "ran",
+					EgressIPs:         []string{"10.0.0.200", "10.0.0.201"},
+					NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "ran"}},
+				}}
+				return c
+			}(),
+		},
+		{
+			name: "additional network duplicated in its namespace",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.AdditionalNetworks = []types.AdditionalNetwork{
+					{Name: "fronthaul", Config: `{"cniVersion":"0.3.1","type":"macvlan"}`},
+					{Name: "fronthaul", Namespace: "default", Config: `{"cniVersion":"0.3.1","type":"macvlan"}`},
+				}
+				return c
+			}(),
+			expectedError: `^networking\.additionalNetworks\[1]\.name: Duplicate value: "fronthaul"$`,
+		},
+		{
+			name: "additional network with an invalid CNI configuration",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.AdditionalNetworks = []types.AdditionalNetwork{
+					{Name: "fronthaul", Config: `{"cniVersion":"0.3.1","master":"ens4"}`},
+				}
+				return c
+			}(),
+			expectedError: `^networking\.additionalNetworks\[0]\.config: Invalid value: .*: CNI configuration must set a plugin type or a list of plugins$`,
+		},
+		{
+			name: "egress IP outside of the machine networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.EgressIPs = []types.EgressIP{{
+					Name:              "ran",
+					EgressIPs:         []string{"192.168.100.10"},
+					NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "ran"}},
+				}}
+				return c
+			}(),
+			expectedError: `^networking\.egressIPs\[0]\.egressIPs\[0]: Invalid value: "192\.168\.100\.10": egress IP must be in one of the machine networks$`,
+		},
+		{
+			name: "egress IP duplicated and without namespace selector",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.EgressIPs = []types.EgressIP{
+					{
+						Name:              "ran",
+						EgressIPs:         []string{"10.0.0.200"},
+						NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "ran"}},
+					},
+					{
+						Name:      "core",
+						EgressIPs: []string{"10.0.0.200"},
+					},
+				}
+				return c
+			}(),
+			expectedError: `^\[networking\.egressIPs\[1]\.egressIPs\[0]: Duplicate value: "10\.0\.0\.200", networking\.egressIPs\[1]\.namespaceSelector: Required value: a namespace selector is required]$`,
+		},
+		{
+			name: "egress IPs with another network type",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.NetworkType = "Calico"
+				c.Networking.EgressIPs = []types.EgressIP{{Name: "ran", EgressIPs: []string{"10.0.0.200"}}}
+				return c
+			}(),
+			expectedError: `^networking\.egressIPs: Invalid value: "Calico": egress IPs are only valid with the network type OVNKubernetes$`,
+		},
+		{
+			name: "missing control plane",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane = nil
+				return c
+			}(),
+			expectedError: `^controlPlane: Required value: controlPlane is required$`,
+		},
+		{
+			name: "control plane with 0 replicas",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = pointer.Int64Ptr(0)
+				return c
+			}(),
+			expectedError: `^controlPlane.replicas: Invalid value: 0: number of control plane replicas must be positive$`,
+		},
+		{
+			name: "invalid control plane",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = nil
+				return c
+			}(),
+			expectedError: `^controlPlane.replicas: Required value: replicas is required$`,
+		},
+		{
+			name: "missing compute",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute = nil
+				return c
+			}(),
+		},
+		{
+			name: "empty compute",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute = []types.MachinePool{}
+				return c
+			}(),
+		},
+		{
+			name: "duplicate compute",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute = []types.MachinePool{
+					*validMachinePool("worker"),
+					*validMachinePool("worker"),
+				}
+				return c
+			}(),
+			expectedError: `^compute\[1\]\.name: Duplicate value: "worker"$`,
+		},
+		{
+			name: "no compute replicas",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute = []types.MachinePool{
+					func() types.MachinePool {
+						p := *validMachinePool("worker")
+						p.Replicas = pointer.Int64Ptr(0)
+						return p
+					}(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "missing platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{}
+				return c
+			}(),
+			expectedError: `^platform: Invalid value: "": must specify one of the platforms \(aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, powervs, vsphere\)$`,
+		},
+		{
+			name: "multiple platforms",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform.Libvirt = validLibvirtPlatform()
+				return c
+			}(),
+			expectedError: `^platform: Invalid value: "aws": must only specify a single type of platform; cannot use both "aws" and "libvirt"$`,
+		},
+		{
+			name: "invalid aws platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					AWS: &aws.Platform{},
+				}
+				return c
+			}(),
+			expectedError: `^platform\.aws\.region: Required value: region must be specified$`,
+		},
+		{
+			name: "valid libvirt platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					Libvirt: validLibvirtPlatform(),
+				}
+				return c
+			}(),
+			expectedError: `^platform: Invalid value: "libvirt": must specify one of the platforms \(aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, powervs, vsphere\)$`,
+		},
+		{
+			name: "invalid libvirt platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					Libvirt: validLibvirtPlatform(),
+				}
+				c.Platform.Libvirt.URI = ""
+				return c
+			}(),
+			expectedError: `^\[platform: Invalid value: "libvirt": must specify one of the platforms \(aws, azure, baremetal, external, gcp, ibmcloud, none, nutanix, openstack, powervs, vsphere\), platform\.libvirt\.uri: Invalid value: "": invalid URI "" \(no scheme\)]$`,
+		},
+		{
+			name: "valid none platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					None: &none.Platform{},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "valid openstack platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					OpenStack: validOpenStackPlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "valid baremetal platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					BareMetal: validBareMetalPlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "valid vsphere platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					VSphere: validVSpherePlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "invalid vsphere platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					VSphere: validVSpherePlatform(),
+				}
+				c.Platform.VSphere.VCenters[0].Server = ""
+				return c
+			}(),
+			expectedError: `platform\.vsphere\.vcenters\.server: Required value: must be the domain name or IP address of the vCenter(.*)`,
+		},
+		{
+			name: "invalid vsphere folder",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					VSphere: validVSpherePlatform(),
+				}
+				c.Platform.VSphere.FailureDomains[0].Topology.Folder = "my-folder"
+				return c
+			}(),
+			expectedError: `^platform\.vsphere\.failureDomains\.topology.folder: Invalid value: "my-folder": full path of folder must be provided in format /<datacenter>/vm/<folder>$`,
+		},
+		{
+			name: "invalid vsphere resource pool",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					VSphere: validVSpherePlatform(),
+				}
+				c.Platform.VSphere.FailureDomains[0].Topology.ResourcePool = "my-resource-pool"
+				return c
+			}(),
+			expectedError: `^platform\.vsphere\.failureDomains\.topology\.resourcePool: Invalid value: "my-resource-pool": full path of resource pool must be provided in format /<datacenter>/host/<cluster>/\.\.\.$`,
+		},
+		{
+			name: "empty proxy settings",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = ""
+				c.Proxy.HTTPSProxy = ""
+				c.Proxy.NoProxy = ""
+				return c
+			}(),
+			expectedError: `^proxy: Required value: must include httpProxy or httpsProxy$`,
+		},
+		{
+			name: "invalid HTTPProxy",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://bad%20uri"
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Invalid value: "http://bad%20uri": parse "http://bad%20uri": invalid URL escape "%20"$`,
+		},
+		{
+			name: "invalid HTTPProxy Schema missing",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http//baduri"
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Invalid value: "http//baduri": parse "http//baduri": invalid URI for request$`,
+		},
+		{
+			name: "HTTPProxy with port overlapping with Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://192.168.1.25:3030"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Invalid value: "http://192.168.1.25:3030": proxy value is part of the cluster networks$`,
+		},
+		{
+			name: "overlapping HTTPProxy and Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://192.168.1.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks$`,
+		},
+		{
+			name: "non-overlapping HTTPProxy and Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://192.169.1.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+		},
+		{
+			name: "overlapping HTTPProxy and more than one Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://192.168.1.25"
+				c.Networking = validIPv4NetworkingConfig()
+				c.ClusterNetwork = append(c.ClusterNetwork, []types.ClusterNetworkEntry{
+					{
+						CIDR:       *ipnet.MustParseCIDR("192.168.0.0/16"),
+						HostPrefix: 28,
+					},
+				}...,
+				)
+				return c
+			}(),
+			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0, proxy.httpProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
+		},
+		{
+			name: "non-overlapping HTTPProxy and Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://172.31.0.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+		},
+		{
+			name: "HTTPProxy with port overlapping with Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://172.30.0.25:3030"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Invalid value: "http://172.30.0.25:3030": proxy value is part of the service networks$`,
+		},
+		{
+			name: "overlapping HTTPProxy and Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://172.30.0.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks$`,
+		},
+		{
+			name: "overlapping HTTPProxy and more than one Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "http://172.30.0.25"
+				c.Networking = validIPv4NetworkingConfig()
+				c.ServiceNetwork = append(c.ServiceNetwork, []ipnet.IPNet{
+					*ipnet.MustParseCIDR("172.30.1.0/24"),
+				}...,
+				)
+				return c
+			}(),
+			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
+		},
+		{
+			name: "non-overlapping HTTPSProxy and Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://192.168.2.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+		},
+		{
+			name: "HTTPSProxy with port overlapping with Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://192.168.1.25:3030"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Invalid value: "http://192.168.1.25:3030": proxy value is part of the cluster networks$`,
+		},
+		{
+			name: "overlapping HTTPSProxy and Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://192.168.1.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks$`,
+		},
+		{
+			name: "overlapping HTTPSProxy and more than one Cluster Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://192.168.1.25"
+				c.Networking = validIPv4NetworkingConfig()
+				c.ClusterNetwork = append(c.ClusterNetwork, []types.ClusterNetworkEntry{
+					{
+						CIDR:       *ipnet.MustParseCIDR("192.168.0.0/16"),
+						HostPrefix: 28,
+					},
+				}...,
+				)
+				return c
+			}(),
+			expectedError: `^\Q[networking.clusterNetwork[1].cidr: Invalid value: "192.168.0.0/16": cluster network must not overlap with cluster network 0, proxy.httpsProxy: Invalid value: "http://192.168.1.25": proxy value is part of the cluster networks]\E$`,
+		},
+		{
+			name: "overlapping HTTPSProxy and Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://172.30.0.25"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks$`,
+		},
+		{
+			name: "HTTPSProxy with port overlapping with Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://172.30.0.25:3030"
+				c.Networking = validIPv4NetworkingConfig()
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Invalid value: "http://172.30.0.25:3030": proxy value is part of the service networks$`,
+		},
+		{
+			name: "overlapping HTTPSProxy and more than one Service Networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http://172.30.0.25"
+				c.Networking = validIPv4NetworkingConfig()
+				c.ServiceNetwork = append(c.ServiceNetwork, []ipnet.IPNet{
+					*ipnet.MustParseCIDR("172.30.1.0/24"),
+				}...,
+				)
+				return c
+			}(),
+			expectedError: `^\Q[networking.serviceNetwork[1]: Invalid value: "172.30.1.0/24": service network must not overlap with service network 0, networking.serviceNetwork: Invalid value: "172.30.0.0/16, 172.30.1.0/24": only one service network can be specified, proxy.httpsProxy: Invalid value: "http://172.30.0.25": proxy value is part of the service networks]\E$`,
+		},
+		{
+			name: "invalid HTTPProxy Schema different schema",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPProxy = "ftp://baduri"
+				return c
+			}(),
+			expectedError: `^proxy.httpProxy: Unsupported value: "ftp": supported values: "http"$`,
+		},
+		{
+			name: "invalid HTTPSProxy",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "https://bad%20uri"
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Invalid value: "https://bad%20uri": parse "https://bad%20uri": invalid URL escape "%20"$`,
+		},
+		{
+			name: "invalid HTTPSProxy Schema missing",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "http//baduri"
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Invalid value: "http//baduri": parse "http//baduri": invalid URI for request$`,
+		},
+		{
+			name: "invalid HTTPSProxy Schema different schema",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.HTTPSProxy = "ftp://baduri"
+				return c
+			}(),
+			expectedError: `^proxy.httpsProxy: Unsupported value: "ftp": supported values: "http", "https"$`,
+		},
+		{
+			name: "invalid NoProxy domain",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.NoProxy = "good-no-proxy.com,*.bad-proxy"
+				return c
+			}(),
+			expectedError: `^\Qproxy.noProxy: Invalid value: "good-no-proxy.com,*.bad-proxy": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 1 "*.bad-proxy"\E$`,
+		},
+		{
+			name: "invalid NoProxy spaces",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.NoProxy = "good-no-proxy.com, *.bad-proxy"
+				return c
+			}(),
+			expectedError: `^\Q[proxy.noProxy: Invalid value: "good-no-proxy.com, *.bad-proxy": noProxy must not have spaces, proxy.noProxy: Invalid value: "good-no-proxy.com, *.bad-proxy": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 1 "*.bad-proxy"]\E$`,
+		},
+		{
+			name: "invalid NoProxy CIDR",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.NoProxy = "good-no-proxy.com,172.bad.CIDR.0/16"
+				return c
+			}(),
+			expectedError: `^\Qproxy.noProxy: Invalid value: "good-no-proxy.com,172.bad.CIDR.0/16": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 1 "172.bad.CIDR.0/16"\E$`,
+		},
+		{
+			name: "invalid NoProxy domain & CIDR",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.NoProxy = "good-no-proxy.com,a-good-one,*.bad-proxy.,another,172.bad.CIDR.0/16,good-end"
+				return c
+			}(),
+			expectedError: `^\Q[proxy.noProxy: Invalid value: "good-no-proxy.com,a-good-one,*.bad-proxy.,another,172.bad.CIDR.0/16,good-end": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 2 "*.bad-proxy.", proxy.noProxy: Invalid value: "good-no-proxy.com,a-good-one,*.bad-proxy.,another,172.bad.CIDR.0/16,good-end": each element of noProxy must be a IP, CIDR or domain without wildcard characters, which is violated by element 4 "172.bad.CIDR.0/16"]\E$`,
+		},
+		{
+			name: "valid * NoProxy",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Proxy.NoProxy = "*"
+				return c
+			}(),
+		},
+		{
+			name: "valid GCP platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					GCP: validGCPPlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "invalid GCP cluster name",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					GCP: validGCPPlatform(),
+				}
+				c.ObjectMeta.Name = "1-invalid-cluster"
+				return c
+			}(),
+			expectedError: `^metadata\.name: Invalid value: "1-invalid-cluster": cluster name must begin with a lower-case letter$`,
+		},
+		{
+			name: "valid ibmcloud platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					IBMCloud: validIBMCloudPlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "invalid ibmcloud platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					IBMCloud: &ibmcloud.Platform{},
+				}
+				return c
+			}(),
+			expectedError: `^\Qplatform.ibmcloud.region: Required value: region must be specified\E$`,
+		},
+		{
+			name: "valid powervs platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.SSHKey = validSSHKey()
+				c.Platform = types.Platform{
+					PowerVS: validPowerVSPlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "valid powervs platform manual credential mod",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.SSHKey = validSSHKey()
+				c.Platform = types.Platform{
+					PowerVS: validPowerVSPlatform(),
+				}
+				c.CredentialsMode = types.ManualCredentialsMode
+				return c
+			}(),
+		},
+		{
+			name: "invalid powervs platform mint credential mod",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.SSHKey = validSSHKey()
+				c.Platform = types.Platform{
+					PowerVS: validPowerVSPlatform(),
+				}
+				c.CredentialsMode = types.MintCredentialsMode
+				return c
+			}(),
+			expectedError: `^credentialsMode: Unsupported value: "Mint": supported values: "Manual"$`,
+		},
+		{
+			name: "invalid powervs platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.SSHKey = validSSHKey()
+				c.Platform = types.Platform{
+					PowerVS: &powervs.Platform{},
+				}
+				return c
+			}(),
+			expectedError: `^\Qplatform.powervs.zone: Required value: zone must be specified\E$`,
+		},
+		{
+			name: "valid azurestack platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					Azure: validAzureStackPlatform(),
+				}
+				return c
+			}(),
+		},
+		{
+			name: "invalid azurestack platform mint credentials mod",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					Azure: validAzureStackPlatform(),
+				}
+				c.CredentialsMode = types.MintCredentialsMode
+				return c
+			}(),
+			expectedError: `^credentialsMode: Unsupported value: "Mint": supported values: "Manual"$`,
+		},
+		{
+			name: "release image source is not valid",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source: "ocp/release-x.y",
+				}}
+				return c
+			}(),
+			expectedError: `^imageContentSources\[0\]\.source: Invalid value: "ocp/release-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+		},
+		{
+			name: "release image source's mirror is not valid",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source:  "q.io/ocp/release-x.y",
+					Mirrors: []string{"ocp/openshift-x.y"},
+				}}
+				return c
+			}(),
+			expectedError: `^imageContentSources\[0\]\.mirrors\[0\]: Invalid value: "ocp/openshift-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+		},
+		{
+			name: "release image source's mirror is valid",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source:  "q.io/ocp/release-x.y",
+					Mirrors: []string{"mirror.example.com:5000"},
+				}}
+				return c
+			}(),
+		},
+		{
+			name: "release image source is not repository but reference by digest",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source: "quay.io/ocp/release-x.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c",
+				}}
+				return c
+			}(),
+			expectedError: `^imageContentSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c": must be repository--not reference$`,
+		},
+		{
+			name: "release image source is not repository but reference by tag",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source: "quay.io/ocp/release-x.y:latest",
+				}}
+				return c
+			}(),
+			expectedError: `^imageContentSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y:latest": must be repository--not reference$`,
+		},
+		{
+			name: "valid release image source",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source: "quay.io/ocp/release-x.y",
+				}}
+				return c
+			}(),
+		},
+		{
+			name: "release image source is not valid ImageDigestSource",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source: "ocp/release-x.y",
+				}}
+				return c
+			}(),
+			expectedError: `^imageDigestSources\[0\]\.source: Invalid value: "ocp/release-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+		},
+		{
+			name: "release image source's mirror is not valid ImageDigestSource",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source:  "q.io/ocp/release-x.y",
+					Mirrors: []string{"ocp/openshift-x.y"},
+				}}
+				return c
+			}(),
+			expectedError: `^imageDigestSources\[0\]\.mirrors\[0\]: Invalid value: "ocp/openshift-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+		},
+		{
+			name: "release image source's mirror is valid ImageDigestSource",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source:  "q.io/ocp/release-x.y",
+					Mirrors: []string{"mirror.example.com:5000"},
+				}}
+				return c
+			}(),
+		},
+		{
+			name: "release image source is not repository but reference by digest ImageDigestSource",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source: "quay.io/ocp/release-x.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c",
+				}}
+				return c
+			}(),
+			expectedError: `^imageDigestSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c": must be repository--not reference$`,
+		},
+		{
+			name: "release image source is not repository but reference by tag ImageDigestSource",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source: "quay.io/ocp/release-x.y:latest",
+				}}
+				return c
+			}(),
+			expectedError: `^imageDigestSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y:latest": must be repository--not reference$`,
+		},
+		{
+			name: "valid release image source ImageDigstSourrce",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source: "quay.io/ocp/release-x.y",
+				}}
+				return c
+			}(),
+		},
+		{
+			name: "error out ImageContentSources and ImageDigestSources and are set at the same time",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.DeprecatedImageContentSources = []types.ImageContentSource{{
+					Source:  "q.io/ocp/source",
+					Mirrors: []string{"ocp/openshift/mirror"},
+				}}
+				c.ImageDigestSources = []types.ImageDigestSource{{
+					Source:  "q.io/ocp/source",
+					Mirrors: []string{"ocp-digest/openshift/mirror"}}}
+				return c
+			}(),
+			expectedError: `cannot set imageContentSources and imageDigestSources at the same time`,
+		},
+		{
+			name: "invalid publishing strategy",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Publish = types.PublishingStrategy("ExternalInternalDoNotCare")
+				return c
+			}(),
+			expectedError: `^publish: Unsupported value: \"ExternalInternalDoNotCare\": supported values: \"External\", \"Internal\"`,
+		},
+
+		{
+			name: "valid dual-stack configuration",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validDualStackNetworkingConfig()
+				return c
+			}(),
+		},
+		{
+			name: "valid single-stack IPv6 configuration",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validIPv6NetworkingConfig()
+				return c
+			}(),
+		},
+		{
+			name: "invalid dual-stack configuration, bad platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{GCP: validGCPPlatform()}
+				c.Networking = validDualStackNetworkingConfig()
+				return c
+			}(),
+			expectedError: `Invalid value: "DualStack": dual-stack IPv4/IPv6 is not supported for this platform, specify only one type of address`,
+		},
+		{
+			name: "invalid single-stack IPv6 configuration, bad platform",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{GCP: validGCPPlatform()}
+				c.Networking = validIPv6NetworkingConfig()
+				return c
+			}(),
+			expectedError: `Invalid value: "IPv6": single-stack IPv6 is not supported for this platform`,
+		},
+		{
+			name: "valid single-stack IPv6 configuration on AWS",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{AWS: validAWSPlatform()}
+				c.Platform.AWS.Subnets = []string{"subnet-ipv6"}
+				c.Platform.AWS.IPFamily = aws.IPv6IPFamily
+				c.Networking = validIPv6NetworkingConfig()
+				return c
+			}(),
+		},
+		{
+			name: "invalid IPv4 configuration with AWS IPv6 ipFamily",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{AWS: validAWSPlatform()}
+				c.Platform.AWS.Subnets = []string{"subnet-ipv6"}
+				c.Platform.AWS.IPFamily = aws.IPv6IPFamily
+				return c
+			}(),
+			expectedError: `Invalid value: "IPv4": IPv6 networks are required when the AWS ipFamily is IPv6`,
+		},
+		{
+			name: "invalid AWS IPv6 ipFamily with the Cluster API install",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{AWS: validAWSPlatform()}
+				c.Platform.AWS.Subnets = []string{"subnet-ipv6"}
+				c.Platform.AWS.IPFamily = aws.IPv6IPFamily
+				c.Networking = validIPv6NetworkingConfig()
+				c.FeatureSet = configv1.CustomNoUpgrade
+				c.FeatureGates = []string{"ClusterAPIInstallAWS=True"}
+				return c
+			}(),
+			expectedError: `^platform\.aws\.ipFamily: Forbidden: IPv6 is not supported with the Cluster API install$`,
+		},
+		{
+			name: "invalid AWS hostPlacement with the Cluster API install",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Platform.AWS = &aws.MachinePool{
+					Tenancy:       aws.HostTenancy,
+					HostPlacement: &aws.HostPlacement{HostIDs: []string{"h-0123456789abcdef0"}},
+				}
+				c.FeatureSet = configv1.CustomNoUpgrade
+				c.FeatureGates = []string{"ClusterAPIInstallAWS=True"}
+				return c
+			}(),
+			expectedError: `^controlPlane\.platform\.aws\.hostPlacement: Forbidden: hostPlacement is not supported with the Cluster API install$`,
+		},
+		{
+			name: "invalid AWS hostPlacement on a compute pool",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute[0].Platform.AWS = &aws.MachinePool{
+					Tenancy:       aws.HostTenancy,
+					HostPlacement: &aws.HostPlacement{HostIDs: []string{"h-0123456789abcdef0"}},
+				}
+				return c
+			}(),
+			expectedError: `^compute\[0\]\.platform\.aws\.hostPlacement: Forbidden: hostPlacement is only supported for the control plane$`,
+		},
+		{
+			name: "invalid dual-stack configuration, machine has no IPv6",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validDualStackNetworkingConfig()
+				c.Networking.MachineNetwork = c.Networking.MachineNetwork[:1]
+				return c
+			}(),
+			expectedError: `Invalid value: "10.0.0.0/16": dual-stack IPv4/IPv6 requires an IPv6 network in this list`,
+		},
+		{
+			name: "invalid dual-stack configuration, IPv6-primary",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validDualStackNetworkingConfig()
+				c.Networking.ServiceNetwork = []ipnet.IPNet{
+					c.Networking.ServiceNetwork[1],
+					c.Networking.ServiceNetwork[0],
+				}
+				return c
+			}(),
+			expectedError: `Invalid value: "ffd1::/112, 172.30.0.0/16": IPv4 addresses must be listed before IPv6 addresses`,
+		},
+		{
+			name: "valid dual-stack configuration with mixed-order clusterNetworks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validDualStackNetworkingConfig()
+				c.Networking.ClusterNetwork = append(c.Networking.ClusterNetwork,
+					types.ClusterNetworkEntry{
+						CIDR:       *ipnet.MustParseCIDR("192.168.2.0/24"),
+						HostPrefix: 28,
+					},
+				)
+				// ClusterNetwork is now "IPv4, IPv6, IPv4", which is allowed
+				return c
+			}(),
+		},
+		{
+			name: "invalid IPv6 hostprefix",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validIPv6NetworkingConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 72
+				return c
+			}(),
+			expectedError: `Invalid value: 72: cluster network host subnetwork prefix must be 64 for IPv6 networks`,
+		},
+		{
+			name: "invalid IPv6 service network size",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validIPv6NetworkingConfig()
+				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("ffd1::/48")
+				return c
+			}(),
+			expectedError: `Invalid value: "ffd1::/48": subnet size for IPv6 service network should be /112`,
+		},
+		{
+			name: "architecture is not supported",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Compute[0].Architecture = types.ArchitectureS390X
+				c.ControlPlane.Architecture = types.ArchitectureS390X
 				return c
 			}(),
-			expectedError: `^imageContentSources\[0\]\.source: Invalid value: "ocp/release-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+			expectedError: `[controlPlane.architecture: Unsupported value: "s390x": supported values: "amd64", "arm64", compute\[0\].architecture: Unsupported value: "s390x": supported values: "amd64", "arm64"]`,
 		},
 		{
-			name: "release image source's mirror is not valid",
+			name: "architecture is not supported",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source:  "q.io/ocp/release-x.y",
-					Mirrors: []string{"ocp/openshift-x.y"},
-				}}
+				c.Compute[0].Architecture = types.ArchitecturePPC64LE
+				c.ControlPlane.Architecture = types.ArchitecturePPC64LE
 				return c
 			}(),
-			expectedError: `^imageContentSources\[0\]\.mirrors\[0\]: Invalid value: "ocp/openshift-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+			expectedError: `[controlPlane.architecture: Unsupported value: "ppc64le": supported values: "amd64", "arm64", compute\[0\].architecture: Unsupported value: "ppc64le": supported values: "amd64", "arm64"]`,
 		},
 		{
-			name: "release image source's mirror is valid",
+			name: "heterogeneous cluster",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source:  "q.io/ocp/release-x.y",
-					Mirrors: []string{"mirror.example.com:5000"},
-				}}
+				c.Compute[0].Architecture = types.ArchitectureARM64
 				return c
 			}(),
 		},
 		{
-			name: "release image source is not repository but reference by digest",
+			name: "heterogeneous cluster on unsupported platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source: "quay.io/ocp/release-x.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c",
-				}}
+				c.Platform = types.Platform{
+					OpenStack: validOpenStackPlatform(),
+				}
+				c.Compute[0].Architecture = types.ArchitectureARM64
 				return c
 			}(),
-			expectedError: `^imageContentSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c": must be repository--not reference$`,
+			expectedError: `^compute\[0\].architecture: Invalid value: "arm64": heterogeneous clusters are not supported on openstack; compute pool architecture must match control plane$`,
 		},
 		{
-			name: "release image source is not repository but reference by tag",
+			name: "compute pools with different architectures",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source: "quay.io/ocp/release-x.y:latest",
-				}}
+				edge := validMachinePool(types.MachinePoolEdgeRoleName)
+				edge.Architecture = types.ArchitectureARM64
+				c.Compute = append(c.Compute, *edge)
 				return c
 			}(),
-			expectedError: `^imageContentSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y:latest": must be repository--not reference$`,
+			expectedError: `^compute\[1\].architecture: Invalid value: "arm64": all compute pools must use the same architecture$`,
 		},
 		{
-			name: "valid release image source",
+			name: "valid boot images",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source: "quay.io/ocp/release-x.y",
-				}}
+				c.BootImages = []types.BootImage{
+					{Architecture: types.ArchitectureAMD64, Image: "ami-0123456789abcdef0"},
+					{Architecture: types.ArchitectureARM64, Image: "ami-01234567"},
+				}
 				return c
 			}(),
 		},
 		{
-			name: "release image source is not valid ImageDigestSource",
+			name: "duplicate boot image architecture",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source: "ocp/release-x.y",
-				}}
+				c.BootImages = []types.BootImage{
+					{Architecture: types.ArchitectureAMD64, Image: "ami-0123456789abcdef0"},
+					{Architecture: types.ArchitectureAMD64, Image: "ami-01234567"},
+				}
 				return c
 			}(),
-			expectedError: `^imageDigestSources\[0\]\.source: Invalid value: "ocp/release-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+			expectedError: `^bootImages\[1\]\.architecture: Duplicate value: "amd64"$`,
 		},
 		{
-			name: "release image source's mirror is not valid ImageDigestSource",
+			name: "invalid AWS boot image",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source:  "q.io/ocp/release-x.y",
-					Mirrors: []string{"ocp/openshift-x.y"},
-				}}
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "rhcos-4.16"}}
 				return c
 			}(),
-			expectedError: `^imageDigestSources\[0\]\.mirrors\[0\]: Invalid value: "ocp/openshift-x\.y": the repository provided is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, \'\-\' or \'\.\', and must start and end with an alphanumeric character \(e.g. \'example\.com\', regex used for validation is \'\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\(\\\.\[a\-z0\-9\]\(\[\-a\-z0\-9\]\*\[a\-z0\-9\]\)\?\)\*\'\)`,
+			expectedError: `^bootImages\[0\]\.image: Invalid value: "rhcos-4.16": must be an AMI ID$`,
 		},
 		{
-			name: "release image source's mirror is valid ImageDigestSource",
+			name: "missing boot image",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source:  "q.io/ocp/release-x.y",
-					Mirrors: []string{"mirror.example.com:5000"},
-				}}
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64}}
 				return c
 			}(),
+			expectedError: `^bootImages\[0\]\.image: Required value: image is required$`,
 		},
 		{
-			name: "release image source is not repository but reference by digest ImageDigestSource",
+			name: "valid GCP boot image",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source: "quay.io/ocp/release-x.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c",
-				}}
+				c.Platform = types.Platform{GCP: validGCPPlatform()}
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "projects/rhcos-cloud/global/images/rhcos-416-94-x86-64"}}
 				return c
 			}(),
-			expectedError: `^imageDigestSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y@sha256:397c867cc10bcc90cf05ae9b71dd3de6000535e27cb6c704d9f503879202582c": must be repository--not reference$`,
 		},
 		{
-			name: "release image source is not repository but reference by tag ImageDigestSource",
+			name: "valid OpenStack boot image",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source: "quay.io/ocp/release-x.y:latest",
-				}}
+				c.Platform = types.Platform{OpenStack: validOpenStackPlatform()}
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "https://mirror.example.com/rhcos-openstack.x86_64.qcow2.gz?sha256=" + strings.Repeat("a", 64)}}
 				return c
 			}(),
-			expectedError: `^imageDigestSources\[0\]\.source: Invalid value: "quay\.io/ocp/release-x\.y:latest": must be repository--not reference$`,
 		},
 		{
-			name: "valid release image source ImageDigstSourrce",
+			name: "OpenStack boot image without sha256",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source: "quay.io/ocp/release-x.y",
-				}}
+				c.Platform = types.Platform{OpenStack: validOpenStackPlatform()}
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "https://mirror.example.com/rhcos-openstack.x86_64.qcow2.gz"}}
 				return c
 			}(),
+			expectedError: `^bootImages\[0\]\.image: Invalid value: "https://mirror.example.com/rhcos-openstack.x86_64.qcow2.gz": must include the sha256 of the uncompressed image as a query parameter$`,
 		},
 		{
-			name: "error out ImageContentSources and ImageDigestSources and are set at the same time",
+			name: "boot image on none platform",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.DeprecatedImageContentSources = []types.ImageContentSource{{
-					Source:  "q.io/ocp/source",
-					Mirrors: []string{"ocp/openshift/mirror"},
-				}}
-				c.ImageDigestSources = []types.ImageDigestSource{{
-					Source:  "q.io/ocp/source",
-					Mirrors: []string{"ocp-digest/openshift/mirror"}}}
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.BootImages = []types.BootImage{{Architecture: types.ArchitectureAMD64, Image: "https://mirror.example.com/rhcos.iso"}}
 				return c
 			}(),
-			expectedError: `cannot set imageContentSources and imageDigestSources at the same time`,
+			expectedError: `^bootImages\[0\]\.image: Invalid value: "https://mirror.example.com/rhcos.iso": boot images cannot be overridden on platform none$`,
 		},
 		{
-			name: "invalid publishing strategy",
+			name: "valid release images",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Publish = types.PublishingStrategy("ExternalInternalDoNotCare")
+				c.ReleaseImages = []types.ReleaseImage{
+					{Architecture: types.ArchitectureAMD64, PullSpec: "quay.io/openshift-release-dev/ocp-release@sha256:" + strings.Repeat("a", 64)},
+					{Architecture: types.ArchitectureARM64, PullSpec: "quay.io/openshift-release-dev/ocp-release@sha256:" + strings.Repeat("b", 64)},
+				}
+				c.ReleaseImageVerification = &types.ReleaseImageVerification{
+					PublicKeys:     "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQINBErgSTsBEACh2A4b0O9t+vzC9VrVtL1AKvUWi9OPCjkvR7Xd8DtJxeeMZ5eF\n-----END PGP PUBLIC KEY BLOCK-----\n",
+					SignatureStore: "https://mirror.example.com/signatures",
+				}
 				return c
 			}(),
-			expectedError: `^publish: Unsupported value: \"ExternalInternalDoNotCare\": supported values: \"External\", \"Internal\"`,
 		},
-
 		{
-			name: "valid dual-stack configuration",
+			name: "duplicate release image architecture",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validDualStackNetworkingConfig()
+				c.ReleaseImages = []types.ReleaseImage{
+					{Architecture: types.ArchitectureAMD64, PullSpec: "quay.io/openshift-release-dev/ocp-release@sha256:" + strings.Repeat("a", 64)},
+					{Architecture: types.ArchitectureAMD64, PullSpec: "quay.io/openshift-release-dev/ocp-release@sha256:" + strings.Repeat("b", 64)},
+				}
 				return c
 			}(),
+			expectedError: `^releaseImages\[1\]\.architecture: Duplicate value: "amd64"$`,
 		},
 		{
-			name: "valid single-stack IPv6 configuration",
+			name: "release image not pinned by digest",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validIPv6NetworkingConfig()
+				c.ReleaseImages = []types.ReleaseImage{{Architecture: types.ArchitectureAMD64, PullSpec: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64"}}
 				return c
 			}(),
+			expectedError: `^releaseImages\[0\]\.pullSpec: Invalid value: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64": must be pinned by digest$`,
 		},
 		{
-			name: "invalid dual-stack configuration, bad platform",
+			name: "missing release image pull spec",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{GCP: validGCPPlatform()}
-				c.Networking = validDualStackNetworkingConfig()
+				c.ReleaseImages = []types.ReleaseImage{{Architecture: types.ArchitectureAMD64}}
 				return c
 			}(),
-			expectedError: `Invalid value: "DualStack": dual-stack IPv4/IPv6 is not supported for this platform, specify only one type of address`,
+			expectedError: `^releaseImages\[0\]\.pullSpec: Required value: pullSpec is required$`,
 		},
 		{
-			name: "invalid single-stack IPv6 configuration, bad platform",
+			name: "release image verification without release images",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{GCP: validGCPPlatform()}
-				c.Networking = validIPv6NetworkingConfig()
+				c.ReleaseImageVerification = &types.ReleaseImageVerification{
+					PublicKeys: "-----BEGIN PGP PUBLIC KEY BLOCK-----\n-----END PGP PUBLIC KEY BLOCK-----\n",
+				}
 				return c
 			}(),
-			expectedError: `Invalid value: "IPv6": single-stack IPv6 is not supported for this platform`,
+			expectedError: `^releaseImages: Required value: release images must be set to verify their signatures$`,
 		},
 		{
-			name: "invalid dual-stack configuration, machine has no IPv6",
+			name: "invalid release image verification",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validDualStackNetworkingConfig()
-				c.Networking.MachineNetwork = c.Networking.MachineNetwork[:1]
+				c.ReleaseImages = []types.ReleaseImage{{Architecture: types.ArchitectureAMD64, PullSpec: "quay.io/openshift-release-dev/ocp-release@sha256:" + strings.Repeat("a", 64)}}
+				c.ReleaseImageVerification = &types.ReleaseImageVerification{
+					PublicKeys:     "ssh-rsa AAAA",
+					SignatureStore: "file:///signatures",
+				}
 				return c
 			}(),
-			expectedError: `Invalid value: "10.0.0.0/16": dual-stack IPv4/IPv6 requires an IPv6 network in this list`,
+			expectedError: `^\[releaseImageVerification\.publicKeys: Invalid value: "ssh-rsa AAAA": must contain ASCII-armored PGP public keys, releaseImageVerification\.signatureStore: Invalid value: "file:///signatures": must be an http or https URL\]$`,
 		},
 		{
-			name: "invalid dual-stack configuration, IPv6-primary",
+			name: "valid bootstrap ignition delivery",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validDualStackNetworkingConfig()
-				c.Networking.ServiceNetwork = []ipnet.IPNet{
-					c.Networking.ServiceNetwork[1],
-					c.Networking.ServiceNetwork[0],
-				}
+				c.BootstrapIgnition = &types.BootstrapIgnition{Delivery: types.BootstrapIgnitionDeliveryObjectStorage}
 				return c
 			}(),
-			expectedError: `Invalid value: "ffd1::/112, 172.30.0.0/16": IPv4 addresses must be listed before IPv6 addresses`,
 		},
 		{
-			name: "valid dual-stack configuration with mixed-order clusterNetworks",
+			name: "inline bootstrap ignition delivery on AWS",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validDualStackNetworkingConfig()
-				c.Networking.ClusterNetwork = append(c.Networking.ClusterNetwork,
-					types.ClusterNetworkEntry{
-						CIDR:       *ipnet.MustParseCIDR("192.168.2.0/24"),
-						HostPrefix: 28,
-					},
-				)
-				// ClusterNetwork is now "IPv4, IPv6, IPv4", which is allowed
+				c.BootstrapIgnition = &types.BootstrapIgnition{Delivery: types.BootstrapIgnitionDeliveryInline}
 				return c
 			}(),
+			expectedError: `^bootstrapIgnition\.delivery: Invalid value: "Inline": inline delivery is only supported on Azure and GCP$`,
 		},
 		{
-			name: "invalid IPv6 hostprefix",
+			name: "VPC endpoint bootstrap ignition delivery without subnets",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validIPv6NetworkingConfig()
-				c.Networking.ClusterNetwork[0].HostPrefix = 72
+				c.BootstrapIgnition = &types.BootstrapIgnition{Delivery: types.BootstrapIgnitionDeliveryVPCEndpoint}
 				return c
 			}(),
-			expectedError: `Invalid value: 72: cluster network host subnetwork prefix must be 64 for IPv6 networks`,
+			expectedError: `^\[bootstrapIgnition\.delivery: Invalid value: "VPCEndpoint": VPC endpoint delivery requires installing into existing subnets, bootstrapIgnition\.vpcEndpointID: Required value: vpcEndpointID is required with VPC endpoint delivery\]$`,
 		},
 		{
-			name: "invalid IPv6 service network size",
+			name: "VPC endpoint ID without VPC endpoint delivery",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Platform = types.Platform{None: &none.Platform{}}
-				c.Networking = validIPv6NetworkingConfig()
-				c.Networking.ServiceNetwork[0] = *ipnet.MustParseCIDR("ffd1::/48")
+				c.BootstrapIgnition = &types.BootstrapIgnition{VPCEndpointID: "vpce-0123456789abcdef0"}
 				return c
 			}(),
-			expectedError: `Invalid value: "ffd1::/48": subnet size for IPv6 service network should be /112`,
+			expectedError: `^bootstrapIgnition\.vpcEndpointID: Forbidden: vpcEndpointID is only used with VPC endpoint delivery$`,
 		},
 		{
-			name: "architecture is not supported",
+			name: "valid ignition overrides",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute[0].Architecture = types.ArchitectureS390X
-				c.ControlPlane.Architecture = types.ArchitectureS390X
+				c.IgnitionOverrides = []types.IgnitionOverride{
+					{Role: "worker", Config: `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/motd","contents":{"source":"data:,hello"}}]}}`},
+					{Role: "master", Format: types.IgnitionOverrideFormatButane, Config: "variant: rhcos\nversion: 1.0.0\n"},
+				}
 				return c
 			}(),
-			expectedError: `[controlPlane.architecture: Unsupported value: "s390x": supported values: "amd64", "arm64", compute\[0\].architecture: Unsupported value: "s390x": supported values: "amd64", "arm64"]`,
 		},
 		{
-			name: "architecture is not supported",
+			name: "invalid ignition overrides",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute[0].Architecture = types.ArchitecturePPC64LE
-				c.ControlPlane.Architecture = types.ArchitecturePPC64LE
+				c.IgnitionOverrides = []types.IgnitionOverride{
+					{Role: "infra", Config: `{"ignition":{"version":"2.2.0"}}`},
+				}
 				return c
 			}(),
-			expectedError: `[controlPlane.architecture: Unsupported value: "ppc64le": supported values: "amd64", "arm64", compute\[0\].architecture: Unsupported value: "ppc64le": supported values: "amd64", "arm64"]`,
+			expectedError: `^\[ignitionOverrides\[0\]\.role: Unsupported value: "infra": supported values: "master", "worker", ignitionOverrides\[0\]\.config: Invalid value: "{\\"ignition\\":{\\"version\\":\\"2\.2\.0\\"}}": must be an ignition config of spec 3, not "2\.2\.0"\]$`,
 		},
 		{
-			name: "cluster is not heteregenous",
+			name: "ignition overrides larger than the user data",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
-				c.Compute[0].Architecture = types.ArchitectureARM64
+				data := make([]byte, 24*1024)
+				if _, err := rand.Read(data); err != nil {
+					panic(err)
+				}
+				c.IgnitionOverrides = []types.IgnitionOverride{
+					{Role: "worker", Format: types.IgnitionOverrideFormatButane, Config: fmt.Sprintf("variant: rhcos\nversion: 1.0.0\n# %x\n", data)},
+				}
 				return c
 			}(),
-			expectedError: `^compute\[0\].architecture: Invalid value: "arm64": heteregeneous multi-arch is not supported; compute pool architecture must match control plane$`,
+			expectedError: `^ignitionOverrides: Invalid value: "worker": the worker pointer ignition config would be about \d+ bytes with its overrides, more than the 16384 bytes of user data on aws$`,
 		},
 		{
 			name: "valid cloud credentials mode",
@@ -1582,24 +2837,88 @@ func TestValidateInstallConfig(t *testing.T) {
 			expectedError: `capabilities.baselineCapabilitySet: Unsupported value: "vNotValid": supported values: .*`,
 		},
 		{
-			name: "invalid capability marketplace specified without OperatorLifecycleManager",
+			name: "invalid capability marketplace specified with OperatorLifecycleManager disabled",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "None",
+					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{"marketplace"},
+					DisabledCapabilities:          []configv1.ClusterVersionCapability{"OperatorLifecycleManager"}}
+				return c
+			}(),
+			expectedError: `capabilities.disabledCapabilities: Invalid value: \[\]v1.ClusterVersionCapability{"OperatorLifecycleManager"}: the marketplace capability requires the OperatorLifecycleManager capability, which is disabled; either disable marketplace as well or stop disabling OperatorLifecycleManager`,
+		},
+		{
+			name: "invalid capability baremetal specified with MachineAPI disabled",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
 				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "None",
-					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{"marketplace"}}
+					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{"baremetal"},
+					DisabledCapabilities:          []configv1.ClusterVersionCapability{"MachineAPI"}}
 				return c
 			}(),
-			expectedError: `additionalEnabledCapabilities: Invalid value: \[\]v1.ClusterVersionCapability{"marketplace"}: the marketplace capability requires the OperatorLifecycleManager capability`,
+			expectedError: `capabilities.disabledCapabilities: Invalid value: \[\]v1.ClusterVersionCapability{"MachineAPI"}: the baremetal capability requires the MachineAPI capability, which is disabled`,
 		},
 		{
-			name: "invalid capability baremetal specified without MachineAPI",
+			name: "capability dependencies are enabled automatically",
 			installConfig: func() *types.InstallConfig {
 				c := validInstallConfig()
 				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "None",
-					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{"baremetal"}}
+					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{"baremetal", "marketplace", "Console", "CloudCredential", "CloudControllerManager"}}
+				return c
+			}(),
+		},
+		{
+			name: "valid disabled capability from the baseline set",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "vCurrent",
+					DisabledCapabilities: []configv1.ClusterVersionCapability{"Console", "openshift-samples"}}
+				return c
+			}(),
+		},
+		{
+			name: "invalid disabled capability required by the baseline set",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "vCurrent",
+					DisabledCapabilities: []configv1.ClusterVersionCapability{"Ingress"}}
+				return c
+			}(),
+			expectedError: `capabilities.disabledCapabilities: Invalid value: \[\]v1.ClusterVersionCapability{"Ingress"}: the Console capability requires the Ingress capability, which is disabled; either disable Console as well or stop disabling Ingress`,
+		},
+		{
+			name: "invalid disabled capability specified",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "vCurrent",
+					DisabledCapabilities: []configv1.ClusterVersionCapability{"not-valid"}}
+				return c
+			}(),
+			expectedError: `capabilities.disabledCapabilities\[0\]: Unsupported value: "not-valid": supported values: .*`,
+		},
+		{
+			name: "invalid capability both enabled and disabled",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "vCurrent",
+					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{"Console"},
+					DisabledCapabilities:          []configv1.ClusterVersionCapability{"Console"}}
+				return c
+			}(),
+			expectedError: `capabilities.disabledCapabilities\[0\]: Invalid value: "Console": capability is also listed in additionalEnabledCapabilities`,
+		},
+		{
+			name: "baremetal platform with the baremetal capability disabled",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{
+					BareMetal: validBareMetalPlatform(),
+				}
+				c.Capabilities = &types.Capabilities{BaselineCapabilitySet: "vCurrent",
+					DisabledCapabilities: []configv1.ClusterVersionCapability{"baremetal"}}
 				return c
 			}(),
-			expectedError: `additionalEnabledCapabilities: Invalid value: \[\]v1.ClusterVersionCapability{"baremetal"}: the baremetal capability requires the MachineAPI capability`,
+			expectedError: `capabilities.disabledCapabilities: Invalid value: \[\]v1.ClusterVersionCapability{"baremetal"}: platform baremetal requires the baremetal capability`,
 		},
 		{
 			name: "valid additional enabled capability specified",
@@ -2250,6 +3569,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Capabilities = &types.Capabilities{
 					BaselineCapabilitySet:         configv1.ClusterVersionCapabilitySetNone,
 					AdditionalEnabledCapabilities: []configv1.ClusterVersionCapability{configv1.ClusterVersionCapabilityBaremetal},
+					DisabledCapabilities:          []configv1.ClusterVersionCapability{configv1.ClusterVersionCapabilityMachineAPI},
 				}
 				return c
 			}(),
@@ -2440,6 +3760,141 @@ func TestValidateInstallConfig(t *testing.T) {
 	}
 }
 
+func TestValidateFIPSConfig(t *testing.T) {
+	t.Setenv("OPENSHIFT_INSTALL_SKIP_HOSTCRYPT_VALIDATION", "true")
+	cases := []struct {
+		name         string
+		config       func(c *types.InstallConfig)
+		releaseImage string
+		expected     string
+	}{
+		{
+			name:   "valid",
+			config: func(c *types.InstallConfig) {},
+		},
+		{
+			name: "ed25519 ssh key",
+			config: func(c *types.InstallConfig) {
+				c.SSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMljfY9BVNB1VJtnTrBih7ggGWlwzCHzI463F4owPMSJ"
+			},
+			expected: `^sshKey: Invalid value: "ssh-ed25519 .*": SSH key type ssh-ed25519 unavailable when FIPS is enabled. Please use rsa or ecdsa.$`,
+		},
+		{
+			name: "ed25519 additional ssh key",
+			config: func(c *types.InstallConfig) {
+				c.SSHKeys = []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMljfY9BVNB1VJtnTrBih7ggGWlwzCHzI463F4owPMSJ"}
+			},
+			expected: `^sshKeys\[0\]: Invalid value: "ssh-ed25519 .*": SSH key type ssh-ed25519 unavailable when FIPS is enabled. Please use rsa or ecdsa.$`,
+		},
+		{
+			name: "generated ssh key",
+			config: func(c *types.InstallConfig) {
+				c.GenerateSSHKey = true
+			},
+			expected: `^generateSSHKey: Forbidden: generated ed25519 SSH keys are unavailable when FIPS is enabled$`,
+		},
+		{
+			name: "generated ssh key with provided ssh key",
+			config: func(c *types.InstallConfig) {
+				c.SSHKey = validSSHKey()
+				c.GenerateSSHKey = true
+			},
+		},
+		{
+			name: "arm64 control plane",
+			config: func(c *types.InstallConfig) {
+				c.ControlPlane.Architecture = types.ArchitectureARM64
+			},
+			expected: `^controlPlane.architecture: Unsupported value: "arm64": supported values: "amd64", "ppc64le", "s390x"$`,
+		},
+		{
+			name: "arm64 compute",
+			config: func(c *types.InstallConfig) {
+				c.Compute[0].Architecture = types.ArchitectureARM64
+			},
+			expected: `^compute\[0\].architecture: Unsupported value: "arm64": supported values: "amd64", "ppc64le", "s390x"$`,
+		},
+		{
+			name:         "OKD release image",
+			config:       func(c *types.InstallConfig) {},
+			releaseImage: "quay.io/openshift/okd:4.15.0-0.okd-2024-03-10-010116",
+			expected:     `^fips: Forbidden: FIPS mode is not supported with the OKD release image quay.io/openshift/okd:4.15.0-0.okd-2024-03-10-010116, which does not ship FIPS-validated cryptographic modules$`,
+		},
+		{
+			name:         "OCP release image",
+			config:       func(c *types.InstallConfig) {},
+			releaseImage: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE", tc.releaseImage)
+			c := validInstallConfig()
+			c.FIPS = true
+			tc.config(c)
+			err := validateFIPSconfig(c).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateReleaseArchitecture(t *testing.T) {
+	cases := []struct {
+		name        string
+		config      func(c *types.InstallConfig)
+		releaseArch string
+		expected    string
+	}{
+		{
+			name:        "homogeneous cluster",
+			config:      func(c *types.InstallConfig) {},
+			releaseArch: types.ArchitectureAMD64,
+		},
+		{
+			name:   "homogeneous cluster with unknown payload",
+			config: func(c *types.InstallConfig) {},
+		},
+		{
+			name: "heterogeneous cluster with multi payload",
+			config: func(c *types.InstallConfig) {
+				c.Compute[0].Architecture = types.ArchitectureARM64
+			},
+			releaseArch: "multi",
+		},
+		{
+			name: "heterogeneous cluster with single arch payload",
+			config: func(c *types.InstallConfig) {
+				c.Compute[0].Architecture = types.ArchitectureARM64
+			},
+			releaseArch: types.ArchitectureAMD64,
+			expected:    `^compute\[0\].architecture: Invalid value: "arm64": heterogeneous clusters require a multi-arch release payload, but the release payload is amd64$`,
+		},
+		{
+			name: "heterogeneous cluster with unknown payload",
+			config: func(c *types.InstallConfig) {
+				c.Compute[0].Architecture = types.ArchitectureARM64
+			},
+			expected: `^compute\[0\].architecture: Invalid value: "arm64": heterogeneous clusters require a multi-arch release payload, but the release payload architecture could not be determined$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := validInstallConfig()
+			tc.config(c)
+			err := ValidateReleaseArchitecture(c, tc.releaseArch).ToAggregate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Regexp(t, tc.expected, err)
+			}
+		})
+	}
+}
+
 func Test_ensureIPv4IsFirstInDualStackSlice(t *testing.T) {
 	tests := []struct {
 		name    string
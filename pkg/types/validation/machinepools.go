@@ -2,7 +2,10 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -26,6 +29,7 @@ import (
 	powervsvalidation "github.com/openshift/installer/pkg/types/powervs/validation"
 	"github.com/openshift/installer/pkg/types/vsphere"
 	vspherevalidation "github.com/openshift/installer/pkg/types/vsphere/validation"
+	"github.com/openshift/installer/pkg/validate"
 )
 
 var (
@@ -77,10 +81,150 @@ func ValidateMachinePool(platform *types.Platform, p *types.MachinePool, fldPath
 	if platform.AWS != nil {
 		allErrs = append(allErrs, awsvalidation.ValidateMachinePoolArchitecture(p, fldPath.Child("architecture"))...)
 	}
+	if p.DiskEncryption != nil {
+		allErrs = append(allErrs, validateDiskEncryption(p.DiskEncryption, fldPath.Child("diskEncryption"))...)
+	}
+	if p.BootDiskMirror != nil {
+		allErrs = append(allErrs, validateBootDiskMirror(p.BootDiskMirror, p.Architecture, fldPath.Child("bootDiskMirror"))...)
+	}
+	if p.EtcdDisk != nil {
+		allErrs = append(allErrs, validateEtcdDisk(platform, p, fldPath.Child("etcdDisk"))...)
+	}
+	if p.KubeletConfig != nil {
+		allErrs = append(allErrs, validateKubeletConfig(p.KubeletConfig, fldPath.Child("kubeletConfig"))...)
+	}
+	if p.HostnamePattern != "" {
+		allErrs = append(allErrs, validateHostnamePattern(platform, p.HostnamePattern, fldPath.Child("hostnamePattern"))...)
+	}
 	allErrs = append(allErrs, validateMachinePoolPlatform(platform, &p.Platform, p, fldPath.Child("platform"))...)
 	return allErrs
 }
 
+func validateDiskEncryption(e *types.DiskEncryption, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	sources := len(e.Tang)
+	if e.TPM2 {
+		sources++
+	}
+	if sources == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of tpm2 or tang must be configured"))
+	}
+	for i, tang := range e.Tang {
+		tangPath := fldPath.Child("tang").Index(i)
+		if tang.URL == "" {
+			allErrs = append(allErrs, field.Required(tangPath.Child("url"), "the URL of the Tang server is required"))
+		} else if err := validate.URI(tang.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(tangPath.Child("url"), tang.URL, err.Error()))
+		} else if !strings.HasPrefix(tang.URL, "http://") && !strings.HasPrefix(tang.URL, "https://") {
+			allErrs = append(allErrs, field.Invalid(tangPath.Child("url"), tang.URL, "must use http or https protocol"))
+		}
+		if tang.Thumbprint == "" {
+			allErrs = append(allErrs, field.Required(tangPath.Child("thumbprint"), "the thumbprint of the Tang server is required"))
+		}
+	}
+	if e.Threshold < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("threshold"), e.Threshold, "threshold must not be negative"))
+	} else if sources > 0 && e.Threshold > sources {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("threshold"), e.Threshold,
+			fmt.Sprintf("threshold cannot be greater than the number of configured key sources (%d)", sources)))
+	}
+	return allErrs
+}
+
+func validateBootDiskMirror(m *types.BootDiskMirror, arch types.Architecture, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if arch == types.ArchitectureS390X {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "boot disk mirroring is not supported on s390x"))
+	}
+	if len(m.Devices) < 2 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("devices"), m.Devices, "at least two devices are required to mirror the boot disk"))
+	}
+	seen := sets.New[string]()
+	for i, device := range m.Devices {
+		if !strings.HasPrefix(device, "/dev/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("devices").Index(i), device, "device must be an absolute path under /dev"))
+		}
+		if seen.Has(device) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("devices").Index(i), device))
+		}
+		seen.Insert(device)
+	}
+	return allErrs
+}
+
+func validateEtcdDisk(platform *types.Platform, p *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	d := p.EtcdDisk
+	switch platform.Name() {
+	case azure.Name, gcp.Name:
+	default:
+		return append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("a dedicated etcd disk is only supported on Azure and GCP, where the installer attaches it, not on platform %s", platform.Name())))
+	}
+	if p.Name != types.MachinePoolControlPlaneRoleName {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "a dedicated etcd disk is only supported for the control plane pool"))
+	}
+	if d.Device == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("device"), "the device of the etcd disk is required"))
+	} else if !strings.HasPrefix(d.Device, "/dev/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("device"), d.Device, "device must be an absolute path under /dev"))
+	} else if p.BootDiskMirror != nil && sets.New(p.BootDiskMirror.Devices...).Has(d.Device) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("device"), d.Device, "the etcd disk must not be one of the boot disk mirror devices"))
+	}
+	if d.DiskSizeGB != 0 && d.DiskSizeGB < 16 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), d.DiskSizeGB, "the etcd disk must be at least 16 GB"))
+	}
+	return allErrs
+}
+
+var (
+	validSystemReservedResources = []string{"cpu", "memory", "ephemeral-storage"}
+
+	validTopologyManagerPolicies = []string{"none", "best-effort", "restricted", "single-numa-node"}
+)
+
+func validateKubeletConfig(k *types.KubeletConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if k.MaxPods < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPods"), k.MaxPods, "maxPods must not be negative"))
+	}
+	for _, name := range sets.List(sets.KeySet(k.SystemReserved)) {
+		if !sets.New(validSystemReservedResources...).Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("systemReserved").Key(name), name, validSystemReservedResources))
+			continue
+		}
+		if _, err := resource.ParseQuantity(k.SystemReserved[name]); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("systemReserved").Key(name), k.SystemReserved[name], err.Error()))
+		}
+	}
+	if k.TopologyManagerPolicy != "" && !sets.New(validTopologyManagerPolicies...).Has(k.TopologyManagerPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("topologyManagerPolicy"), k.TopologyManagerPolicy, validTopologyManagerPolicies))
+	}
+	return allErrs
+}
+
+// maxIndexDigits is the number of digits of the largest index of a hostname
+// pattern, the offset of an IPv4 address.
+const maxIndexDigits = 10
+
+// validateHostnamePattern checks that the pattern renders valid hostnames. It
+// is only supported on AWS, where the node names do not follow the hostnames,
+// so that renaming the hosts does not break the lookup of the instances.
+func validateHostnamePattern(platform *types.Platform, pattern string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if platform.Name() != aws.Name {
+		return append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("hostname patterns are not supported on %s", platform.Name())))
+	}
+	_, fixedLength, err := types.HostnamePatternFormat(pattern)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, pattern, err.Error()))
+	}
+	// The width of the placeholder is at most 9, below the maximum digits.
+	if fixedLength+maxIndexDigits > 63 {
+		allErrs = append(allErrs, field.Invalid(fldPath, pattern, fmt.Sprintf("the rendered hostnames must be no more than 63 characters, leaving at most %d characters besides the index", 63-maxIndexDigits)))
+	}
+	return allErrs
+}
+
 func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolPlatform, pool *types.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	platformName := platform.Name()
@@ -94,6 +238,11 @@ func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolP
 	}
 	if platform.AWS != nil {
 		allErrs = append(allErrs, awsvalidation.ValidateAMIID(platform.AWS, p.AWS, fldPath.Child("aws"))...)
+		if pool.Name == types.MachinePoolControlPlaneRoleName {
+			allErrs = append(allErrs, awsvalidation.ValidateControlPlaneSpotMarketOptions(platform.AWS, p.AWS, fldPath.Child("aws"))...)
+		} else {
+			allErrs = append(allErrs, awsvalidation.ValidateComputeHostPlacement(p.AWS, fldPath.Child("aws"))...)
+		}
 	}
 	if p.AWS != nil {
 		validate(aws.Name, p.AWS, func(f *field.Path) field.ErrorList { return awsvalidation.ValidateMachinePool(platform.AWS, p.AWS, f) })
@@ -112,7 +261,7 @@ func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolP
 		})
 	}
 	if p.Libvirt != nil {
-		validate(libvirt.Name, p.Libvirt, func(f *field.Path) field.ErrorList { return libvirtvalidation.ValidateMachinePool(p.Libvirt, f) })
+		validate(libvirt.Name, p.Libvirt, func(f *field.Path) field.ErrorList { return validateLibvirtMachinePool(p.Libvirt, pool, f) })
 	}
 	if p.BareMetal != nil {
 		validate(baremetal.Name, p.BareMetal, func(f *field.Path) field.ErrorList { return baremetalvalidation.ValidateMachinePool(p.BareMetal, f) })
@@ -145,3 +294,24 @@ func validateGCPMachinePool(platform *types.Platform, p *types.MachinePoolPlatfo
 
 	return allErrs
 }
+
+func validateLibvirtMachinePool(p *libvirt.MachinePool, pool *types.MachinePool, f *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, libvirtvalidation.ValidateMachinePool(p, f)...)
+	// Compute domains are created by the libvirt machine API provider, which
+	// cannot attach additional networks nor host devices.
+	if pool.Name != types.MachinePoolControlPlaneRoleName {
+		if len(p.AdditionalNetworks) > 0 {
+			allErrs = append(allErrs, field.Forbidden(f.Child("additionalNetworks"), "additional networks are only supported for the control plane pool"))
+		}
+		if len(p.HostDevices) > 0 {
+			allErrs = append(allErrs, field.Forbidden(f.Child("hostDevices"), "host devices are only supported for the control plane pool"))
+		}
+	} else if replicas := pool.Replicas; replicas != nil && *replicas > 0 && int64(len(p.HostDevices))%*replicas != 0 {
+		allErrs = append(allErrs, field.Invalid(f.Child("hostDevices"), p.HostDevices,
+			fmt.Sprintf("the number of host devices must be a multiple of the number of replicas (%d)", *replicas)))
+	}
+
+	return allErrs
+}
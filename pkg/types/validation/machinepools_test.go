@@ -87,6 +87,32 @@ func TestValidateMachinePool(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name:     "valid aws spot compute pool",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.Platform = types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{
+						SpotMarketOptions: &aws.SpotMarketOptions{MaxPrice: "0.1"},
+					},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "invalid aws spot control plane",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1", DefaultMachinePlatform: &aws.MachinePool{SpotMarketOptions: &aws.SpotMarketOptions{}}}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.Platform = types.MachinePoolPlatform{
+					AWS: &aws.MachinePool{},
+				}
+				return p
+			}(),
+			valid: false,
+		},
 		{
 			name:     "valid azure",
 			platform: &types.Platform{Azure: &azure.Platform{Region: "eastus"}},
@@ -111,6 +137,51 @@ func TestValidateMachinePool(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name:     "libvirt control plane host devices",
+			platform: &types.Platform{Libvirt: &libvirt.Platform{}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.Replicas = pointer.Int64Ptr(2)
+				p.Platform = types.MachinePoolPlatform{
+					Libvirt: &libvirt.MachinePool{
+						AdditionalNetworks: []string{"sriov"},
+						HostDevices:        []string{"0000:3b:02.0", "0000:3b:02.1"},
+					},
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "libvirt control plane host devices not multiple of replicas",
+			platform: &types.Platform{Libvirt: &libvirt.Platform{}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.Replicas = pointer.Int64Ptr(3)
+				p.Platform = types.MachinePoolPlatform{
+					Libvirt: &libvirt.MachinePool{
+						HostDevices: []string{"0000:3b:02.0", "0000:3b:02.1"},
+					},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "libvirt compute additional networks",
+			platform: &types.Platform{Libvirt: &libvirt.Platform{}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.Platform = types.MachinePoolPlatform{
+					Libvirt: &libvirt.MachinePool{
+						AdditionalNetworks: []string{"sriov"},
+					},
+				}
+				return p
+			}(),
+			valid: false,
+		},
 		{
 			name:     "valid openstack",
 			platform: &types.Platform{OpenStack: &openstack.Platform{}},
@@ -205,7 +276,7 @@ func TestValidateMachinePool(t *testing.T) {
 			valid: true,
 		},
 		{
-			name:     "invalid GCP service account on machine pool type",
+			name:     "valid GCP service account on compute pool non xpn install",
 			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east-1"}},
 			pool: func() *types.MachinePool {
 				p := validMachinePool("worker")
@@ -216,7 +287,7 @@ func TestValidateMachinePool(t *testing.T) {
 				}
 				return p
 			}(),
-			valid: false,
+			valid: true,
 		},
 		{
 			name:     "invalid GCP service account non xpn install",
@@ -232,6 +303,260 @@ func TestValidateMachinePool(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name:     "valid disk encryption",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.DiskEncryption = &types.DiskEncryption{
+					TPM2: true,
+					Tang: []types.TangServer{{
+						URL:        "http://tang.example.com:7500",
+						Thumbprint: "PLjNyRdGw03zlRoGjQYMahSZGu9",
+					}},
+					Threshold: 2,
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "disk encryption without key sources",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.DiskEncryption = &types.DiskEncryption{}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "disk encryption threshold exceeds key sources",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.DiskEncryption = &types.DiskEncryption{TPM2: true, Threshold: 2}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "disk encryption tang server without thumbprint",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.DiskEncryption = &types.DiskEncryption{
+					Tang: []types.TangServer{{URL: "http://tang.example.com:7500"}},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "valid boot disk mirror",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.BootDiskMirror = &types.BootDiskMirror{Devices: []string{"/dev/sda", "/dev/sdb"}}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "boot disk mirror with a single device",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.BootDiskMirror = &types.BootDiskMirror{Devices: []string{"/dev/sda"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "boot disk mirror with duplicate devices",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.BootDiskMirror = &types.BootDiskMirror{Devices: []string{"/dev/sda", "/dev/sda"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "valid etcd disk",
+			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.EtcdDisk = &types.EtcdDisk{Device: "/dev/disk/by-id/nvme-etcd"}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "etcd disk on compute pool",
+			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.EtcdDisk = &types.EtcdDisk{Device: "/dev/sdb"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "etcd disk without device",
+			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.EtcdDisk = &types.EtcdDisk{}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "etcd disk on platform not attaching it",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.EtcdDisk = &types.EtcdDisk{Device: "/dev/sdb"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "etcd disk too small",
+			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.EtcdDisk = &types.EtcdDisk{Device: "/dev/sdb", DiskSizeGB: 8}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "etcd disk on a boot disk mirror device",
+			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("master")
+				p.BootDiskMirror = &types.BootDiskMirror{Devices: []string{"/dev/sda", "/dev/sdb"}}
+				p.EtcdDisk = &types.EtcdDisk{Device: "/dev/sdb"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "valid kubelet config",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.KubeletConfig = &types.KubeletConfig{
+					MaxPods:               500,
+					SystemReserved:        map[string]string{"cpu": "500m", "memory": "1Gi"},
+					TopologyManagerPolicy: "single-numa-node",
+				}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "negative kubelet max pods",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.KubeletConfig = &types.KubeletConfig{MaxPods: -1}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "unsupported kubelet system reserved resource",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.KubeletConfig = &types.KubeletConfig{SystemReserved: map[string]string{"pods": "10"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "invalid kubelet system reserved quantity",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.KubeletConfig = &types.KubeletConfig{SystemReserved: map[string]string{"memory": "a lot"}}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "unsupported kubelet topology manager policy",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.KubeletConfig = &types.KubeletConfig{TopologyManagerPolicy: "numa"}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "valid hostname pattern",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.HostnamePattern = "prod-a-worker-{index:3}"
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "hostname pattern without placeholder",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.HostnamePattern = "prod-a-worker"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "hostname pattern with upper case characters",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.HostnamePattern = "Prod-{index}"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "hostname pattern ending with a dash",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.HostnamePattern = "{index}-"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "hostname pattern too long",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.HostnamePattern = "a-very-long-hostname-prefix-that-leaves-no-room-for-index-{index}"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "hostname pattern on unsupported platform",
+			platform: &types.Platform{GCP: &gcp.Platform{Region: "us-east1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("worker")
+				p.HostnamePattern = "prod-a-worker-{index}"
+				return p
+			}(),
+			valid: false,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
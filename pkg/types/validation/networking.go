@@ -0,0 +1,548 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/types/powervs"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+// list of known plugins that require hostPrefix to be set
+var pluginsUsingHostPrefix = sets.NewString(string(operv1.NetworkTypeOVNKubernetes))
+
+// maxHostSubnetShift caps the number of host subnets computed for a cluster
+// network, so that large IPv6 networks do not overflow.
+const maxHostSubnetShift = 62
+
+// validateNetworkingConfig checks the networking section of the install
+// config, and its consistency with the platform and the machine pools. All
+// the checks are run, so that every error is reported at once.
+func validateNetworkingConfig(c *types.InstallConfig) field.ErrorList {
+	fldPath := field.NewPath("networking")
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateNetworking(c.Networking, c.IsSingleNodeOpenShift(), fldPath)...)
+	allErrs = append(allErrs, validateNetworkingIPVersion(c.Networking, &c.Platform)...)
+	allErrs = append(allErrs, validateNetworkingForPlatform(c.Networking, &c.Platform, fldPath)...)
+	allErrs = append(allErrs, validateNetworkingClusterNetworkMTU(c, fldPath.Child("clusterNetworkMTU"))...)
+	allErrs = append(allErrs, validateOVNKubernetesConfig(c, fldPath.Child("ovnKubernetesConfig"))...)
+	allErrs = append(allErrs, validateAdditionalNetworks(c.Networking, fldPath.Child("additionalNetworks"))...)
+	allErrs = append(allErrs, validateEgressIPs(c.Networking, fldPath.Child("egressIPs"))...)
+	allErrs = append(allErrs, validateClusterNetworkCapacity(c, fldPath.Child("clusterNetwork"))...)
+	allErrs = append(allErrs, validateVIPsForPlatform(c.Networking, &c.Platform, field.NewPath("platform"))...)
+	return allErrs
+}
+
+func validateNetworking(n *types.Networking, singleNodeOpenShift bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if n.NetworkType == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("networkType"), "network provider type required"))
+	}
+
+	// NOTE(dulek): We're hardcoding "Kuryr" here as the plan is to remove it from the API very soon. We can remove
+	//              this check once some more general validation of the supported NetworkTypes is in place.
+	if n.NetworkType == "Kuryr" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("networkType"), n.NetworkType, "networkType Kuryr is not supported on OpenShift later than 4.14"))
+	}
+
+	if n.NetworkType == string(operv1.NetworkTypeOpenShiftSDN) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("networkType"), n.NetworkType, "networkType OpenShiftSDN is not supported, please use OVNKubernetes"))
+	}
+
+	if len(n.MachineNetwork) > 0 {
+		for i, network := range n.MachineNetwork {
+			if err := validate.SubnetCIDR(&network.CIDR.IPNet); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNetwork").Index(i), network.CIDR.String(), err.Error()))
+			}
+			for j, subNetwork := range n.MachineNetwork[0:i] {
+				if validate.DoCIDRsOverlap(&network.CIDR.IPNet, &subNetwork.CIDR.IPNet) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNetwork").Index(i), network.CIDR.String(), fmt.Sprintf("machine network must not overlap with machine network %d", j)))
+				}
+			}
+		}
+	} else {
+		allErrs = append(allErrs, field.Required(fldPath.Child("machineNetwork"), "at least one machine network is required"))
+	}
+
+	for i, sn := range n.ServiceNetwork {
+		if err := validate.ServiceSubnetCIDR(&sn.IPNet); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), err.Error()))
+		}
+		for _, network := range n.MachineNetwork {
+			if validate.DoCIDRsOverlap(&sn.IPNet, &network.CIDR.IPNet) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), "service network must not overlap with any of the machine networks"))
+			}
+		}
+		for j, snn := range n.ServiceNetwork[0:i] {
+			if validate.DoCIDRsOverlap(&sn.IPNet, &snn.IPNet) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), fmt.Sprintf("service network must not overlap with service network %d", j)))
+			}
+		}
+	}
+	if len(n.ServiceNetwork) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceNetwork"), "a service network is required"))
+	}
+
+	for i, cn := range n.ClusterNetwork {
+		allErrs = append(allErrs, validateClusterNetwork(n, &cn, i, fldPath.Child("clusterNetwork").Index(i))...)
+	}
+	if len(n.ClusterNetwork) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusterNetwork"), "cluster network required"))
+	}
+	return allErrs
+}
+
+func validateNetworkingForPlatform(n *types.Networking, platform *types.Platform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case platform.Libvirt != nil:
+		errMsg := "overlaps with default Docker Bridge subnet"
+		for idx, mn := range n.MachineNetwork {
+			if validate.DoCIDRsOverlap(&mn.CIDR.IPNet, validate.DockerBridgeCIDR) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("machineNewtork").Index(idx), mn.CIDR.String(), errMsg))
+			}
+		}
+		for idx, sn := range n.ServiceNetwork {
+			if validate.DoCIDRsOverlap(&sn.IPNet, validate.DockerBridgeCIDR) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(idx), sn.String(), errMsg))
+			}
+		}
+		for idx, cn := range n.ClusterNetwork {
+			if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, validate.DockerBridgeCIDR) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterNetwork").Index(idx), cn.CIDR.String(), errMsg))
+			}
+		}
+	default:
+		warningMsgFmt := "%s: %s overlaps with default Docker Bridge subnet"
+		for idx, mn := range n.MachineNetwork {
+			if validate.DoCIDRsOverlap(&mn.CIDR.IPNet, validate.DockerBridgeCIDR) {
+				logrus.Warnf(warningMsgFmt, fldPath.Child("machineNetwork").Index(idx), mn.CIDR.String())
+			}
+		}
+		for idx, sn := range n.ServiceNetwork {
+			if validate.DoCIDRsOverlap(&sn.IPNet, validate.DockerBridgeCIDR) {
+				logrus.Warnf(warningMsgFmt, fldPath.Child("serviceNetwork").Index(idx), sn.String())
+			}
+		}
+		for idx, cn := range n.ClusterNetwork {
+			if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, validate.DockerBridgeCIDR) {
+				logrus.Warnf(warningMsgFmt, fldPath.Child("clusterNetwork").Index(idx), cn.CIDR.String())
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateClusterNetwork(n *types.Networking, cn *types.ClusterNetworkEntry, idx int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := validate.SubnetCIDR(&cn.CIDR.IPNet); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.IPNet.String(), err.Error()))
+	}
+	for _, network := range n.MachineNetwork {
+		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &network.CIDR.IPNet) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), "cluster network must not overlap with any of the machine networks"))
+		}
+	}
+	for i, sn := range n.ServiceNetwork {
+		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &sn.IPNet) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with service network %d", i)))
+		}
+	}
+	for i, acn := range n.ClusterNetwork[0:idx] {
+		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &acn.CIDR.IPNet) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with cluster network %d", i)))
+		}
+	}
+	if cn.HostPrefix < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "hostPrefix must be positive"))
+	}
+	// ignore hostPrefix if the plugin does not use it and has it unset
+	if pluginsUsingHostPrefix.Has(n.NetworkType) || (cn.HostPrefix != 0) {
+		if ones, bits := cn.CIDR.Mask.Size(); cn.HostPrefix < int32(ones) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "cluster network host subnetwork prefix must not be larger size than CIDR "+cn.CIDR.String()))
+		} else if bits == 128 && cn.HostPrefix != 64 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "cluster network host subnetwork prefix must be 64 for IPv6 networks"))
+		}
+	}
+	return allErrs
+}
+
+func validateNetworkingClusterNetworkMTU(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	// higherLimitMTUVPC is the MTU limit for AWS VPC.
+	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/network_mtu.html#jumbo_frame_instances
+	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/network_mtu.html
+	const higherLimitMTUVPC uint32 = uint32(9001)
+
+	// lowerLimitMTUVPC is the lower limit to prevent users setting too low values impacting in the
+	// cluster network performance. Tested values with 1100 decreases 70% in the network performance
+	// in AWS deployments:
+	const lowerLimitMTUVPC uint32 = uint32(1000)
+
+	// higherLimitMTUEdge defines the maximium generally supported MTU in AWS Local and Wavelength Zones.
+	// Mostly AWS Local or Wavelength zones have limited MTU between those and in the Region.
+	// It is required to raise a warning message when the user-defined MTU is higher than general supported.
+	// https://docs.aws.amazon.com/local-zones/latest/ug/how-local-zones-work.html#considerations
+	// https://docs.aws.amazon.com/wavelength/latest/developerguide/how-wavelengths-work.html
+	const higherLimitMTUEdge uint32 = uint32(1300)
+
+	// MTU overhead for the network plugin OVNKubernetes.
+	// https://docs.openshift.com/container-platform/4.14/networking/changing-cluster-network-mtu.html#mtu-value-selection_changing-cluster-network-mtu
+	const minOverheadOVN uint32 = uint32(100)
+
+	allErrs := field.ErrorList{}
+
+	if c.Networking == nil {
+		return nil
+	}
+
+	if c.Networking.ClusterNetworkMTU == 0 {
+		return nil
+	}
+
+	if c.Platform.Name() != aws.Name {
+		return append(allErrs, field.Invalid(fldPath, int(c.Networking.ClusterNetworkMTU), "cluster network MTU is allowed only in AWS deployments"))
+	}
+
+	network := c.NetworkType
+	mtu := c.Networking.ClusterNetworkMTU
+
+	// Calculating the MTU limits considering the base overhead for each network plugin.
+	limitEdgeOVNKubernetes := higherLimitMTUEdge - minOverheadOVN
+	limitOVNKubernetes := higherLimitMTUVPC - minOverheadOVN
+
+	if mtu > higherLimitMTUVPC {
+		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU exceeds the maximum value of %d", higherLimitMTUVPC)))
+	}
+
+	// Prevent too low MTU values.
+	// Tests in AWS Local Zones with MTU of 1100 decreased the network
+	// performance in 70%. The check protects the cluster stability from
+	// user defining too lower numbers.
+	// https://issues.redhat.com/browse/OCPBUGS-11098
+	if mtu < lowerLimitMTUVPC {
+		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU is lower than the minimum value of %d", lowerLimitMTUVPC)))
+	}
+
+	hasEdgePool := false
+	warnEdgePool := false
+	for _, compute := range c.Compute {
+		if compute.Name == types.MachinePoolEdgeRoleName {
+			hasEdgePool = true
+			break
+		}
+	}
+
+	if network != string(operv1.NetworkTypeOVNKubernetes) {
+		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU is not valid with network plugin %s", network)))
+	}
+
+	if mtu > limitOVNKubernetes {
+		return append(allErrs, field.Invalid(fldPath, int(mtu), fmt.Sprintf("cluster network MTU exceeds the maximum value with the network plugin %s of %d", network, limitOVNKubernetes)))
+	}
+	if hasEdgePool && (mtu > limitEdgeOVNKubernetes) {
+		warnEdgePool = true
+	}
+	if warnEdgePool {
+		logrus.Warnf("networking.ClusterNetworkMTU exceeds the maximum value generally supported by AWS Local or Wavelength zones. Please ensure all AWS Zones defined in the edge compute pool accepts the MTU %d bytes between nodes (EC2) in the zone and in the Region.", mtu)
+	}
+
+	return allErrs
+}
+
+// ovnKubernetesMaxHostMTU are the largest MTUs of the machine networks on the
+// cloud platforms. The other platforms are limited to jumbo frames.
+// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/network_mtu.html
+// https://cloud.google.com/vpc/docs/mtu
+var ovnKubernetesMaxHostMTU = map[string]uint32{
+	aws.Name: 9001,
+	gcp.Name: 8896,
+}
+
+const (
+	// defaultMaxHostMTU is the largest MTU of the machine networks on the
+	// platforms without a known limit.
+	defaultMaxHostMTU uint32 = 9000
+
+	// ovnKubernetesOverhead is the overhead of the Geneve encapsulation of
+	// OVN-Kubernetes, and ipsecOverhead the additional overhead of the
+	// ESP encryption when IPsec is enabled.
+	// https://docs.openshift.com/container-platform/4.14/networking/changing-cluster-network-mtu.html#mtu-value-selection_changing-cluster-network-mtu
+	ovnKubernetesOverhead uint32 = 100
+	ipsecOverhead         uint32 = 46
+
+	// minOVNKubernetesMTU is the lowest MTU accepted for the overlay network,
+	// and minOVNKubernetesMTUIPv6 the lowest one with IPv6, the minimum MTU
+	// of the IPv6 links.
+	minOVNKubernetesMTU     uint32 = 1000
+	minOVNKubernetesMTUIPv6 uint32 = 1280
+)
+
+// validateOVNKubernetesConfig checks the customization of the OVN-Kubernetes
+// network plugin against the network type, the other networks of the cluster
+// and the limits of the platform.
+func validateOVNKubernetesConfig(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	if c.Networking == nil || c.Networking.OVNKubernetesConfig == nil {
+		return nil
+	}
+	n := c.Networking
+	cfg := n.OVNKubernetesConfig
+	allErrs := field.ErrorList{}
+
+	if n.NetworkType != string(operv1.NetworkTypeOVNKubernetes) {
+		return append(allErrs, field.Invalid(fldPath, n.NetworkType, fmt.Sprintf("ovnKubernetesConfig is only valid with the network type %s", operv1.NetworkTypeOVNKubernetes)))
+	}
+
+	switch cfg.IPsecMode {
+	case "", operv1.IPsecModeDisabled, operv1.IPsecModeExternal, operv1.IPsecModeFull:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("ipsecMode"), cfg.IPsecMode, []string{string(operv1.IPsecModeDisabled), string(operv1.IPsecModeExternal), string(operv1.IPsecModeFull)}))
+	}
+
+	if cfg.MTU != 0 {
+		mtuPath := fldPath.Child("mtu")
+		maxHostMTU, ok := ovnKubernetesMaxHostMTU[c.Platform.Name()]
+		if !ok {
+			maxHostMTU = defaultMaxHostMTU
+		}
+		maxMTU := maxHostMTU - ovnKubernetesOverhead
+		if cfg.IPsecMode == operv1.IPsecModeExternal || cfg.IPsecMode == operv1.IPsecModeFull {
+			maxMTU -= ipsecOverhead
+		}
+		minMTU := minOVNKubernetesMTU
+		for _, cn := range n.ClusterNetwork {
+			if cn.CIDR.IP.To4() == nil {
+				minMTU = minOVNKubernetesMTUIPv6
+			}
+		}
+		switch {
+		case cfg.MTU > maxMTU:
+			allErrs = append(allErrs, field.Invalid(mtuPath, int(cfg.MTU), fmt.Sprintf("MTU exceeds the maximum value of %d on platform %s", maxMTU, c.Platform.Name())))
+		case cfg.MTU < minMTU:
+			allErrs = append(allErrs, field.Invalid(mtuPath, int(cfg.MTU), fmt.Sprintf("MTU is lower than the minimum value of %d", minMTU)))
+		}
+		if n.ClusterNetworkMTU != 0 && n.ClusterNetworkMTU != cfg.MTU {
+			allErrs = append(allErrs, field.Invalid(mtuPath, int(cfg.MTU), fmt.Sprintf("MTU must match clusterNetworkMTU %d", n.ClusterNetworkMTU)))
+		}
+	}
+
+	switch cfg.GatewayMode {
+	case "", types.OVNGatewayModeLocal:
+	case types.OVNGatewayModeShared:
+		// The egress traffic on Power VS must be routed through the host.
+		if c.Platform.Name() == powervs.Name {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gatewayMode"), cfg.GatewayMode, fmt.Sprintf("gateway mode %s is not supported on platform %s", cfg.GatewayMode, powervs.Name)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("gatewayMode"), cfg.GatewayMode, []string{string(types.OVNGatewayModeLocal), string(types.OVNGatewayModeShared)}))
+	}
+
+	if sn := cfg.V4InternalSubnet; sn != nil {
+		snPath := fldPath.Child("v4InternalSubnet")
+		if sn.IP.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(snPath, sn.String(), "must be an IPv4 network"))
+		} else if err := validate.SubnetCIDR(&sn.IPNet); err != nil {
+			allErrs = append(allErrs, field.Invalid(snPath, sn.String(), err.Error()))
+		}
+		for _, network := range n.MachineNetwork {
+			if validate.DoCIDRsOverlap(&sn.IPNet, &network.CIDR.IPNet) {
+				allErrs = append(allErrs, field.Invalid(snPath, sn.String(), "internal subnet must not overlap with any of the machine networks"))
+			}
+		}
+		for _, network := range n.ClusterNetwork {
+			if validate.DoCIDRsOverlap(&sn.IPNet, &network.CIDR.IPNet) {
+				allErrs = append(allErrs, field.Invalid(snPath, sn.String(), "internal subnet must not overlap with any of the cluster networks"))
+			}
+		}
+		for _, network := range n.ServiceNetwork {
+			if validate.DoCIDRsOverlap(&sn.IPNet, &network.IPNet) {
+				allErrs = append(allErrs, field.Invalid(snPath, sn.String(), "internal subnet must not overlap with any of the service networks"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateAdditionalNetworks checks the NetworkAttachmentDefinitions of the
+// secondary networks: their names and their CNI configurations.
+func validateAdditionalNetworks(n *types.Networking, fldPath *field.Path) field.ErrorList {
+	if n == nil {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, network := range n.AdditionalNetworks {
+		idxPath := fldPath.Index(i)
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(network.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), network.Name, msg))
+		}
+		namespace := network.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		for _, msg := range utilvalidation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("namespace"), network.Namespace, msg))
+		}
+		key := namespace + "/" + network.Name
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), network.Name))
+		}
+		seen.Insert(key)
+
+		// A CNI configuration has a plugin type, or the list of plugins of
+		// a configuration list.
+		config := struct {
+			CNIVersion string            `json:"cniVersion"`
+			Type       string            `json:"type"`
+			Plugins    []json.RawMessage `json:"plugins"`
+		}{}
+		if err := json.Unmarshal([]byte(network.Config), &config); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("config"), network.Config, fmt.Sprintf("invalid CNI configuration: %v", err)))
+			continue
+		}
+		if config.CNIVersion == "" {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("config"), network.Config, "CNI configuration must set cniVersion"))
+		}
+		if config.Type == "" && len(config.Plugins) == 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("config"), network.Config, "CNI configuration must set a plugin type or a list of plugins"))
+		}
+	}
+	return allErrs
+}
+
+// validateEgressIPs checks the egress IPs: they are assigned by the
+// OVN-Kubernetes network plugin to the nodes, so they must be unique and in
+// the machine networks.
+func validateEgressIPs(n *types.Networking, fldPath *field.Path) field.ErrorList {
+	if n == nil || len(n.EgressIPs) == 0 {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	if n.NetworkType != string(operv1.NetworkTypeOVNKubernetes) {
+		return append(allErrs, field.Invalid(fldPath, n.NetworkType, fmt.Sprintf("egress IPs are only valid with the network type %s", operv1.NetworkTypeOVNKubernetes)))
+	}
+
+	names := sets.NewString()
+	ips := sets.NewString()
+	for i, egressIP := range n.EgressIPs {
+		idxPath := fldPath.Index(i)
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(egressIP.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), egressIP.Name, msg))
+		}
+		if names.Has(egressIP.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), egressIP.Name))
+		}
+		names.Insert(egressIP.Name)
+
+		if len(egressIP.EgressIPs) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("egressIPs"), "at least one egress IP is required"))
+		}
+		for j, address := range egressIP.EgressIPs {
+			ipPath := idxPath.Child("egressIPs").Index(j)
+			ip := net.ParseIP(address)
+			if ip == nil {
+				allErrs = append(allErrs, field.Invalid(ipPath, address, "must be a valid IP address"))
+				continue
+			}
+			if ips.Has(ip.String()) {
+				allErrs = append(allErrs, field.Duplicate(ipPath, address))
+			}
+			ips.Insert(ip.String())
+			inMachineNetwork := false
+			for _, network := range n.MachineNetwork {
+				if network.CIDR.Contains(ip) {
+					inMachineNetwork = true
+					break
+				}
+			}
+			if !inMachineNetwork {
+				allErrs = append(allErrs, field.Invalid(ipPath, address, "egress IP must be in one of the machine networks"))
+			}
+		}
+
+		if len(egressIP.NamespaceSelector.MatchLabels) == 0 && len(egressIP.NamespaceSelector.MatchExpressions) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("namespaceSelector"), "a namespace selector is required"))
+		}
+		opts := metav1validation.LabelSelectorValidationOptions{}
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&egressIP.NamespaceSelector, opts, idxPath.Child("namespaceSelector"))...)
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(egressIP.PodSelector, opts, idxPath.Child("podSelector"))...)
+	}
+	return allErrs
+}
+
+// validateClusterNetworkCapacity checks that, for each IP family, the cluster
+// networks can be split in enough host subnets for all the nodes requested by
+// the machine pools. Every node is assigned a host subnet of hostPrefix size.
+func validateClusterNetworkCapacity(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	n := c.Networking
+	if !pluginsUsingHostPrefix.Has(n.NetworkType) {
+		return nil
+	}
+	nodes := expectedNodeCount(c)
+	if nodes == 0 {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	for _, family := range []struct {
+		name string
+		bits int
+	}{
+		{name: "IPv4", bits: 32},
+		{name: "IPv6", bits: 128},
+	} {
+		var (
+			hostSubnets int64
+			networks    []string
+		)
+		for _, cn := range n.ClusterNetwork {
+			ones, bits := cn.CIDR.Mask.Size()
+			if bits != family.bits {
+				continue
+			}
+			networks = append(networks, fmt.Sprintf("%s/hostPrefix %d", cn.CIDR.String(), cn.HostPrefix))
+			// Invalid host prefixes are reported by validateClusterNetwork.
+			if int(cn.HostPrefix) < ones || int(cn.HostPrefix) > bits {
+				continue
+			}
+			shift := int(cn.HostPrefix) - ones
+			if shift > maxHostSubnetShift {
+				shift = maxHostSubnetShift
+			}
+			if hostSubnets < nodes {
+				hostSubnets += int64(1) << shift
+			}
+		}
+		if len(networks) > 0 && hostSubnets < nodes {
+			allErrs = append(allErrs, field.Invalid(fldPath, strings.Join(networks, ", "), fmt.Sprintf("the %s cluster networks provide %d host subnets, which is fewer than the %d nodes of the cluster; use a larger cluster network or a larger hostPrefix", family.name, hostSubnets, nodes)))
+		}
+	}
+	return allErrs
+}
+
+// expectedNodeCount returns the number of nodes requested by the control
+// plane and compute machine pools.
+func expectedNodeCount(c *types.InstallConfig) int64 {
+	var count int64
+	if c.ControlPlane != nil && c.ControlPlane.Replicas != nil {
+		count += *c.ControlPlane.Replicas
+	}
+	for _, pool := range c.Compute {
+		if pool.Replicas != nil {
+			count += *pool.Replicas
+		}
+	}
+	return count
+}
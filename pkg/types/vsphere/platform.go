@@ -73,6 +73,17 @@ type Platform struct {
 	// ClusterOSImage overrides the url provided in rhcos.json to download the RHCOS OVA
 	ClusterOSImage string `json:"clusterOSImage,omitempty"`
 
+	// ContentLibrary is the name of a local content library into which the
+	// RHCOS OVA is imported, instead of importing it once per cluster. The
+	// library is created on the datastore of the first failure domain when it
+	// does not exist. An OVA already imported by a previous install is reused,
+	// and it is only removed on destroy when no other cluster references it.
+	// The content library is only used when the infrastructure is provisioned
+	// with Cluster API.
+	// +kubebuilder:validation:MaxLength=80
+	// +optional
+	ContentLibrary string `json:"contentLibrary,omitempty"`
+
 	// DeprecatedAPIVIP is the virtual IP address for the api endpoint
 	// Deprecated: Use APIVIPs
 	//
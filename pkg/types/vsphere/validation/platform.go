@@ -36,6 +36,9 @@ func ValidatePlatform(p *vsphere.Platform, agentBasedInstallation bool, fldPath
 	if len(p.DiskType) != 0 {
 		allErrs = append(allErrs, validateDiskType(p, fldPath)...)
 	}
+	if len(p.ContentLibrary) > 80 {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("contentLibrary"), p.ContentLibrary, 80))
+	}
 
 	if !agentBasedInstallation {
 		if len(p.VCenters) == 0 {
@@ -3,6 +3,7 @@ package validation
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -194,6 +195,23 @@ func TestValidatePlatform(t *testing.T) {
 			}(),
 			expectedError: `^test-path\.diskType: Invalid value: "invalidDiskType": diskType must be one of \[eagerZeroedThick thick thin\]$`,
 		},
+		{
+			name: "Valid content library",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.ContentLibrary = "openshift-rhcos"
+				return p
+			}(),
+		},
+		{
+			name: "Content library name too long",
+			platform: func() *vsphere.Platform {
+				p := validPlatform()
+				p.ContentLibrary = strings.Repeat("a", 81)
+				return p
+			}(),
+			expectedError: `^test-path\.contentLibrary: Too long: must have at most 80 bytes$`,
+		},
 		{
 			name: "Additional tag IDs provided",
 			platform: func() *vsphere.Platform {